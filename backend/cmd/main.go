@@ -73,6 +73,9 @@ func main() {
 		MaxAge:           12 * time.Hour,
 	}))
 
+	// Compress large responses such as query results
+	ginApp.Use(middleware.CompressionMiddleware())
+
 	// Setup routes
 	routes.SetupDefaultRoutes(ginApp)
 
@@ -31,6 +31,11 @@ type Environment struct {
 	AdminUser                        string
 	AdminPassword                    string
 	DefaultLLMModel                  string
+	LLMDefaultFallbackChain          []string // Model IDs tried in order when the selected model is rate limited or unavailable
+	LLMProxyURL                      string   // Replaces the OpenAI, Gemini and Claude base URLs, e.g. an enterprise proxy auditing LLM traffic
+	LLMProxySigningKey               string   // HMAC key for signing LLM requests, empty sends them unsigned
+	LLMProxySigningAlgorithm         string   // hmac-sha256 or hmac-sha512
+	ContextSummarizationEnabled      bool     // Summarize the oldest half of long LLM contexts instead of only pruning them
 
 	// Database configs
 	MongoURI          string
@@ -71,6 +76,8 @@ type Environment struct {
 	SpreadsheetPostgresPassword  string
 	SpreadsheetPostgresSSLMode   string
 	SpreadsheetDataEncryptionKey string
+	SpreadsheetAsyncImportMB     int // Uploads larger than this are imported in the background
+	ImportMetadataTTLHours       int // Hours spreadsheet import metadata is kept in Redis
 
 	// Google OAuth configs
 	GoogleClientID     string
@@ -86,6 +93,39 @@ type Environment struct {
 	// Embedding configs
 	EmbeddingProvider string // "openai" or "gemini" — auto-detected if empty
 	EmbeddingModel    string // e.g. "text-embedding-3-small" or "text-embedding-004"
+
+	// Cohere configs (Command LLMs, embeddings + rerank for top-K table selection)
+	CohereAPIKey     string
+	EmbeddingEnabled bool // Inject only the top-K most relevant tables into the LLM context
+	EmbeddingTopK    int  // Number of tables to inject when EmbeddingEnabled is true
+
+	// Schema auto refresh configs
+	SchemaAutoRefreshEnabled bool // Poll connected databases for schema changes
+	SchemaPollInterval       int  // Seconds between schema polls
+	MongoChangeStreamEnabled bool // Watch MongoDB inserts for new fields, needs a replica set or sharded cluster
+
+	// Query execution configs
+	MaxQueryResultRows    int // Row cap injected into SELECT queries without a smaller LIMIT, admins can override it per user
+	QueryMaxRetries       int // Retries of a read-only query failing with a transient connection error (reset, timeout, EOF)
+	QueryBaseRetryDelayMs int // Backoff before the first retry, doubled after every attempt
+
+	// Prompt injection configs
+	PromptInjectionCheckEnabled bool // Reject user messages that try to override the system prompt
+
+	// Database connection pool configs (database/sql based drivers)
+	DBPoolMin         int // MaxOpenConns a pool starts with
+	DBPoolMax         int // MaxOpenConns a pool may grow to as chats share it
+	DBPoolIdleTimeout int // Seconds before an idle pooled connection is closed
+
+	// HashiCorp Vault configs, connections with a vault secret path read their credentials from Vault
+	VaultAddr              string
+	VaultToken             string
+	VaultRoleID            string // AppRole auth, used when VaultToken is empty
+	VaultSecretID          string
+	VaultAllowedPathPrefix string // Secret paths users may read, {user_id} gives every user their own subtree
+
+	// SSL certificate configs, for connections whose certificates are given as https://, s3:// or gs:// URLs
+	SSLCertCacheTTLSeconds int // Seconds a downloaded certificate is reused before it is downloaded again
 }
 
 var Env Environment
@@ -135,6 +175,11 @@ func LoadEnv() error {
 
 	// LLM configs
 	Env.DefaultLLMModel = getEnvWithDefault("DEFAULT_LLM_MODEL", "")
+	Env.LLMDefaultFallbackChain = constants.ParseLLMFallbackChain(getEnvWithDefault("LLM_DEFAULT_FALLBACK_CHAIN", ""))
+	Env.LLMProxyURL = getEnvWithDefault("LLM_PROXY_URL", "")
+	Env.LLMProxySigningKey = getEnvWithDefault("LLM_PROXY_SIGNING_KEY", "")
+	Env.LLMProxySigningAlgorithm = getEnvWithDefault("LLM_PROXY_SIGNING_ALGORITHM", constants.DefaultLLMProxySigningAlgorithm)
+	Env.ContextSummarizationEnabled = getEnvWithDefault("CONTEXT_SUMMARIZATION_ENABLED", "false") == "true"
 
 	// OpenAI configs - API key only, models defined in constants/supported_models.go
 	Env.OpenAIAPIKey = getRequiredEnv("OPENAI_API_KEY", "")
@@ -166,6 +211,8 @@ func LoadEnv() error {
 	Env.SpreadsheetPostgresPassword = getRequiredEnv("SPREADSHEET_POSTGRES_PASSWORD", "")
 	Env.SpreadsheetPostgresSSLMode = getEnvWithDefault("SPREADSHEET_POSTGRES_SSL_MODE", "disable")
 	Env.SpreadsheetDataEncryptionKey = getRequiredEnv("SPREADSHEET_DATA_ENCRYPTION_KEY", "spreadsheet_data_key_32bytes")
+	Env.SpreadsheetAsyncImportMB = getIntEnvWithDefault("SPREADSHEET_ASYNC_IMPORT_THRESHOLD_MB", constants.DefaultSpreadsheetAsyncImportMB)
+	Env.ImportMetadataTTLHours = getIntEnvWithDefault("IMPORT_METADATA_TTL_HOURS", constants.DefaultImportMetadataTTLHours)
 
 	// Google OAuth configs (used for both authentication and Google Sheets integration)
 	Env.GoogleClientID = getEnvWithDefault("GOOGLE_CLIENT_ID", "")
@@ -182,6 +229,39 @@ func LoadEnv() error {
 	Env.EmbeddingProvider = getEnvWithDefault("EMBEDDING_PROVIDER", "")
 	Env.EmbeddingModel = getEnvWithDefault("EMBEDDING_MODEL", "")
 
+	// Cohere configs — enables Command models, top-K table selection is opt-in via EMBEDDING_ENABLED
+	Env.CohereAPIKey = getEnvWithDefault("COHERE_API_KEY", "")
+	Env.EmbeddingEnabled = getEnvWithDefault("EMBEDDING_ENABLED", "false") == "true"
+	Env.EmbeddingTopK = getIntEnvWithDefault("EMBEDDING_TOP_K", constants.DefaultEmbeddingTopK)
+
+	// Schema auto refresh configs
+	Env.SchemaAutoRefreshEnabled = getEnvWithDefault("SCHEMA_AUTO_REFRESH_ENABLED", "true") == "true"
+	Env.SchemaPollInterval = getIntEnvWithDefault("SCHEMA_POLL_INTERVAL", 300)
+	Env.MongoChangeStreamEnabled = getEnvWithDefault("MONGO_CHANGE_STREAM_ENABLED", "false") == "true"
+
+	// Query execution configs
+	Env.MaxQueryResultRows = getIntEnvWithDefault("MAX_QUERY_RESULT_ROWS", constants.DefaultMaxQueryResultRows)
+	Env.QueryMaxRetries = getIntEnvWithDefault("QUERY_MAX_RETRIES", constants.DefaultQueryMaxRetries)
+	Env.QueryBaseRetryDelayMs = getIntEnvWithDefault("QUERY_BASE_RETRY_DELAY_MS", constants.DefaultQueryBaseRetryDelayMs)
+
+	// Prompt injection configs
+	Env.PromptInjectionCheckEnabled = getEnvWithDefault("PROMPT_INJECTION_CHECK_ENABLED", "true") == "true"
+
+	// Database connection pool configs
+	Env.DBPoolMin = getIntEnvWithDefault("DB_POOL_MIN", constants.DefaultDBPoolMin)
+	Env.DBPoolMax = getIntEnvWithDefault("DB_POOL_MAX", constants.DefaultDBPoolMax)
+	Env.DBPoolIdleTimeout = getIntEnvWithDefault("DB_POOL_IDLE_TIMEOUT", constants.DefaultDBPoolIdleTimeoutSeconds)
+
+	// HashiCorp Vault configs
+	Env.VaultAddr = getEnvWithDefault("VAULT_ADDR", "")
+	Env.VaultToken = getEnvWithDefault("VAULT_TOKEN", "")
+	Env.VaultRoleID = getEnvWithDefault("VAULT_ROLE_ID", "")
+	Env.VaultSecretID = getEnvWithDefault("VAULT_SECRET_ID", "")
+	Env.VaultAllowedPathPrefix = getEnvWithDefault("VAULT_ALLOWED_PATH_PREFIX", "")
+
+	// SSL certificate configs
+	Env.SSLCertCacheTTLSeconds = getIntEnvWithDefault("SSL_CERT_CACHE_TTL_SECONDS", constants.DefaultSSLCertCacheTTLSeconds)
+
 	return validateConfig()
 }
 
@@ -248,6 +328,27 @@ func validateConfig() error {
 		return fmt.Errorf("SPREADSHEET_DATA_ENCRYPTION_KEY must be exactly 32 bytes for AES-GCM encryption, got: %d bytes", len(Env.SpreadsheetDataEncryptionKey))
 	}
 
+	// Validate database connection pool configs
+	if Env.DBPoolMin <= 0 {
+		return fmt.Errorf("DB_POOL_MIN must be positive, got: %d", Env.DBPoolMin)
+	}
+	if Env.DBPoolMax < Env.DBPoolMin {
+		return fmt.Errorf("DB_POOL_MAX (%d) must be greater than or equal to DB_POOL_MIN (%d)", Env.DBPoolMax, Env.DBPoolMin)
+	}
+
+	// Validate Vault auth
+	if Env.VaultAddr != "" && Env.VaultToken == "" && (Env.VaultRoleID == "" || Env.VaultSecretID == "") {
+		return fmt.Errorf("VAULT_ADDR is set but neither VAULT_TOKEN nor VAULT_ROLE_ID and VAULT_SECRET_ID are configured")
+	}
+	if Env.VaultAddr != "" && Env.VaultAllowedPathPrefix == "" {
+		return fmt.Errorf("VAULT_ADDR is set but VAULT_ALLOWED_PATH_PREFIX is not, set it to the Vault path users may read secrets from")
+	}
+
+	// Validate SSL certificate cache
+	if Env.SSLCertCacheTTLSeconds < 0 {
+		return fmt.Errorf("SSL_CERT_CACHE_TTL_SECONDS must not be negative, got: %d", Env.SSLCertCacheTTLSeconds)
+	}
+
 	// Validate SSL mode
 	validSSLModes := map[string]bool{"disable": true, "require": true, "verify-ca": true, "verify-full": true}
 	if !validSSLModes[Env.SpreadsheetPostgresSSLMode] {
@@ -265,11 +366,31 @@ func validateConfig() error {
 		}
 	}
 
+	// Validate LLM proxy configs
+	if Env.LLMProxyURL != "" && !isValidURI(Env.LLMProxyURL) {
+		return fmt.Errorf("invalid LLM_PROXY_URL format: %s", Env.LLMProxyURL)
+	}
+	if !constants.IsValidLLMProxySigningAlgorithm(Env.LLMProxySigningAlgorithm) {
+		return fmt.Errorf("invalid LLM_PROXY_SIGNING_ALGORITHM: %s, must be one of: %s, %s", Env.LLMProxySigningAlgorithm,
+			constants.LLMProxySigningAlgorithmHMACSHA256, constants.LLMProxySigningAlgorithmHMACSHA512)
+	}
+
+	// Validate LLM fallback chain, models of providers without API keys are skipped at runtime
+	if len(Env.LLMDefaultFallbackChain) > constants.MaxLLMFallbackChainLength {
+		return fmt.Errorf("LLM_DEFAULT_FALLBACK_CHAIN can list at most %d models, got: %d", constants.MaxLLMFallbackChainLength, len(Env.LLMDefaultFallbackChain))
+	}
+	for _, modelID := range Env.LLMDefaultFallbackChain {
+		if constants.GetLLMModel(modelID) == nil {
+			return fmt.Errorf("invalid LLM_DEFAULT_FALLBACK_CHAIN: %s, model not found in supported models", modelID)
+		}
+	}
+
 	// Disable models for providers without API keys configured
-	constants.DisableUnavailableProviders(Env.OpenAIAPIKey, Env.GeminiAPIKey, Env.ClaudeAPIKey, Env.OllamaBaseURL)
+	constants.DisableUnavailableProviders(Env.OpenAIAPIKey, Env.GeminiAPIKey, Env.ClaudeAPIKey, Env.OllamaBaseURL, Env.CohereAPIKey)
+	constants.SetDefaultLLMFallbackChain(Env.LLMDefaultFallbackChain)
 
 	// Log LLM model initialization status
-	constants.LogModelInitialization(Env.OpenAIAPIKey, Env.GeminiAPIKey, Env.ClaudeAPIKey, Env.OllamaBaseURL)
+	constants.LogModelInitialization(Env.OpenAIAPIKey, Env.GeminiAPIKey, Env.ClaudeAPIKey, Env.OllamaBaseURL, Env.CohereAPIKey)
 
 	// Validate & resolve Embedding Provider + Model against supported constants
 	resolvedProvider, resolvedModel, embErr := constants.ValidateEmbeddingConfig(
@@ -285,6 +406,27 @@ func validateConfig() error {
 	Env.EmbeddingProvider = resolvedProvider
 	Env.EmbeddingModel = resolvedModel
 
+	// Validate query result cap
+	if Env.MaxQueryResultRows <= 0 {
+		return fmt.Errorf("MAX_QUERY_RESULT_ROWS must be positive, got: %d", Env.MaxQueryResultRows)
+	}
+
+	// Validate transient query error retries
+	if Env.QueryMaxRetries < 0 {
+		return fmt.Errorf("QUERY_MAX_RETRIES must not be negative, got: %d", Env.QueryMaxRetries)
+	}
+	if Env.QueryBaseRetryDelayMs <= 0 {
+		return fmt.Errorf("QUERY_BASE_RETRY_DELAY_MS must be positive, got: %d", Env.QueryBaseRetryDelayMs)
+	}
+
+	// Validate top-K table selection
+	if Env.EmbeddingTopK <= 0 {
+		return fmt.Errorf("EMBEDDING_TOP_K must be positive, got: %d", Env.EmbeddingTopK)
+	}
+	if Env.EmbeddingEnabled && Env.CohereAPIKey == "" {
+		return fmt.Errorf("EMBEDDING_ENABLED is true but COHERE_API_KEY is not configured")
+	}
+
 	// Log embedding initialization status
 	constants.LogEmbeddingInitialization(Env.EmbeddingProvider, Env.EmbeddingModel, Env.OpenAIAPIKey, Env.GeminiAPIKey)
 
@@ -0,0 +1,75 @@
+package dtos
+
+import "time"
+
+// AnalyticsRequest holds the query parameters of the analytics endpoints.
+// From and To accept RFC3339 timestamps or YYYY-MM-DD dates.
+type AnalyticsRequest struct {
+	From    string `form:"from"`
+	To      string `form:"to"`
+	GroupBy string `form:"groupBy" binding:"omitempty,oneof=day week"`
+}
+
+// AnalyticsResponse holds usage analytics for all chats or a single chat.
+// Time-series arrays are sorted by period and can be charted directly.
+type AnalyticsResponse struct {
+	From       time.Time                `json:"from"`
+	To         time.Time                `json:"to"`
+	GroupBy    string                   `json:"group_by"`
+	ChatID     *string                  `json:"chat_id,omitempty"`
+	Summary    AnalyticsSummary         `json:"summary"`
+	Messages   []AnalyticsMessagePoint  `json:"messages"`
+	Latency    []AnalyticsLatencyPoint  `json:"latency"`
+	Errors     []AnalyticsErrorPoint    `json:"errors"`
+	QueryTypes []AnalyticsCount         `json:"query_types"`
+	TopTables  []AnalyticsCount         `json:"top_tables"`
+	LLMModels  []AnalyticsLLMModelCount `json:"llm_models"`
+}
+
+// AnalyticsSummary holds the totals for the whole requested range
+type AnalyticsSummary struct {
+	TotalMessages    int64   `json:"total_messages"`
+	UserMessages     int64   `json:"user_messages"`
+	ExecutedQueries  int64   `json:"executed_queries"`
+	FailedQueries    int64   `json:"failed_queries"`
+	ErrorRate        float64 `json:"error_rate"`         // failed / executed, 0-1
+	AvgLLMLatencyMs  float64 `json:"avg_llm_latency_ms"` // time between a user message and its AI response
+	ActiveChats      int64   `json:"active_chats"`
+	GeneratedQueries int64   `json:"generated_queries"`
+}
+
+// AnalyticsMessagePoint is the number of messages sent in a period
+type AnalyticsMessagePoint struct {
+	Period    string `json:"period"` // YYYY-MM-DD for day, YYYY-Www (ISO week) for week
+	Total     int64  `json:"total"`
+	User      int64  `json:"user"`
+	Assistant int64  `json:"assistant"`
+}
+
+// AnalyticsLatencyPoint is the average AI response time in a period
+type AnalyticsLatencyPoint struct {
+	Period string  `json:"period"`
+	AvgMs  float64 `json:"avg_ms"`
+	Count  int64   `json:"count"`
+}
+
+// AnalyticsErrorPoint is the query error rate in a period
+type AnalyticsErrorPoint struct {
+	Period    string  `json:"period"`
+	Executed  int64   `json:"executed"`
+	Failed    int64   `json:"failed"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+// AnalyticsCount is a named count, e.g. a query type or a table
+type AnalyticsCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// AnalyticsLLMModelCount is the number of AI responses generated by a model
+type AnalyticsLLMModelCount struct {
+	Model       string `json:"model"`
+	DisplayName string `json:"display_name,omitempty"`
+	Count       int64  `json:"count"`
+}
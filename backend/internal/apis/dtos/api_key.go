@@ -0,0 +1,21 @@
+package dtos
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"key_name" binding:"required"`
+}
+
+// APIKeyResponse describes an issued API key, without the key itself
+type APIKeyResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"key_name"`
+	CreatedAt  string  `json:"created_at"`
+	LastUsedAt *string `json:"last_used_at"`
+	ExpiresAt  string  `json:"expires_at"`
+	RevokedAt  *string `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is returned once when a key is issued, it is the only time the key is shown
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
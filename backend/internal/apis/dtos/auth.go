@@ -18,6 +18,18 @@ type UserSignupSecretRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+// UpdateUserQueryLimitsRequest sets a user's query result row cap, null restores the server default
+type UpdateUserQueryLimitsRequest struct {
+	MaxQueryResultRows *int `json:"max_query_result_rows" binding:"omitempty,min=1"`
+}
+
+type UserQueryLimitsResponse struct {
+	Username           string `json:"username"`
+	MaxQueryResultRows int    `json:"max_query_result_rows"` // The cap that applies to the user
+	IsOverride         bool   `json:"is_override"`           // False when the server default applies
+}
+
 type AuthResponse struct {
 	AccessToken  string      `json:"access_token"`
 	RefreshToken string      `json:"refresh_token"`
@@ -48,11 +60,11 @@ type ForgotPasswordResponse struct {
 
 // Google OAuth DTOs
 type GoogleOAuthRequest struct {
-	Code             string  `json:"code" binding:"required"`         // Authorization code from Google
-	RedirectURI      string  `json:"redirect_uri" binding:"required"` // Must match the one registered
-	UserSignupSecret *string `json:"user_signup_secret,omitempty"`    // Required for signup in production
-	Purpose          string  `json:"purpose" binding:"required"`      // "auth" or "spreadsheet"
-	Action           string  `json:"action,omitempty"`                // "login" or "signup" (for auth purpose)
+	Code             string  `json:"code" binding:"required"`                                 // Authorization code from Google
+	RedirectURI      string  `json:"redirect_uri" binding:"required"`                         // Must match the one registered
+	UserSignupSecret *string `json:"user_signup_secret,omitempty"`                            // Required for signup in production
+	Purpose          string  `json:"purpose" binding:"required,oneof=auth spreadsheet"`       // "auth" or "spreadsheet"
+	Action           string  `json:"action,omitempty" binding:"omitempty,oneof=login signup"` // "login" or "signup" (for auth purpose)
 }
 
 type GoogleOAuthCallbackRequest struct {
@@ -0,0 +1,42 @@
+package dtos
+
+import "time"
+
+// StartCDCWatchRequest starts checking tables for changes and calling a webhook when they change
+type StartCDCWatchRequest struct {
+	Tables               []string `json:"tables" binding:"required,min=1,max=10"`
+	CheckIntervalSeconds int      `json:"checkIntervalSeconds" binding:"required,min=10,max=3600"`
+	WebhookURL           string   `json:"webhookUrl" binding:"required,url"`
+	TimestampColumn      string   `json:"timestampColumn"` // Defaults to updated_at
+}
+
+// CDCWatchResponse describes a running CDC watcher
+type CDCWatchResponse struct {
+	WatchID              string    `json:"watch_id"`
+	ChatID               string    `json:"chat_id"`
+	Tables               []string  `json:"tables"`
+	TimestampColumn      string    `json:"timestamp_column"`
+	CheckIntervalSeconds int       `json:"check_interval_seconds"`
+	WebhookURL           string    `json:"webhook_url"`
+	StartedAt            time.Time `json:"started_at"`
+}
+
+// CDCWatchState is what Redis keeps of a CDC watcher, with the last maximum and row count of every table
+type CDCWatchState struct {
+	Watch        CDCWatchResponse  `json:"watch"`
+	PreviousMax  map[string]string `json:"previous_max"`
+	PreviousRows map[string]int64  `json:"previous_rows"`
+	CheckedAt    time.Time         `json:"checked_at"`
+}
+
+// CDCWebhookPayload is posted to the webhook when the maximum timestamp of a table increased.
+// EstimatedNewRows is the growth of the row count since the previous check, updates don't add rows.
+type CDCWebhookPayload struct {
+	WatchID          string    `json:"watchId"`
+	ChatID           string    `json:"chatId"`
+	Table            string    `json:"table"`
+	PreviousMax      string    `json:"previousMax"`
+	CurrentMax       string    `json:"currentMax"`
+	EstimatedNewRows int64     `json:"estimatedNewRows"`
+	DetectedAt       time.Time `json:"detectedAt"`
+}
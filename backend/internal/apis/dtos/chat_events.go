@@ -0,0 +1,9 @@
+package dtos
+
+// ChatEvent is broadcast to every subscriber of a chat's shared event stream
+type ChatEvent struct {
+	Type      string      `json:"type"` // new_message
+	ChatID    string      `json:"chat_id"`
+	Data      interface{} `json:"data,omitempty"`
+	Timestamp string      `json:"timestamp"`
+}
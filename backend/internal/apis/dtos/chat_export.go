@@ -0,0 +1,68 @@
+package dtos
+
+import (
+	"time"
+
+	"neobase-ai/internal/models"
+)
+
+// ChatExport is the portable JSON format used to move a chat between NeoBase instances
+type ChatExport struct {
+	ExportVersion        string                    `json:"exportVersion"`
+	ExportedAt           time.Time                 `json:"exportedAt"`
+	IncludesCredentials  bool                      `json:"includesCredentials"`
+	Chat                 ChatExportMetadata        `json:"chat"`
+	Connection           ChatExportConnection      `json:"connection"`
+	SecondaryConnections []ChatExportConnection    `json:"secondaryConnections,omitempty"`
+	Messages             []models.Message          `json:"messages"`
+	LLMMessages          []models.LLMMessage       `json:"llmMessages,omitempty"` // Informational only, rebuilt from messages on import
+	SchemaSnapshot       *ChatExportSchemaSnapshot `json:"schemaSnapshot,omitempty"`
+}
+
+// ChatExportMetadata holds the chat level fields of an exported chat
+type ChatExportMetadata struct {
+	ID                  string              `json:"id"` // ID on the source instance, a new ID is assigned on import
+	SelectedCollections string              `json:"selectedCollections"`
+	Settings            models.ChatSettings `json:"settings"`
+	PreferredLLMModel   *string             `json:"preferredLLMModel,omitempty"`
+	CreatedAt           time.Time           `json:"createdAt"`
+	UpdatedAt           time.Time           `json:"updatedAt"`
+}
+
+// ChatExportConnection is a decrypted connection config.
+// Secrets are hidden from the embedded connection's JSON, so they travel in Credentials.
+type ChatExportConnection struct {
+	models.Connection
+	Credentials *ChatExportCredentials `json:"credentials,omitempty"` // Only set when the export includes credentials
+}
+
+// ChatExportCredentials holds the decrypted secrets of a connection
+type ChatExportCredentials struct {
+	Password                 *string `json:"password,omitempty"`
+	SSHPrivateKey            *string `json:"ssh_private_key,omitempty"`
+	SSHPassphrase            *string `json:"ssh_passphrase,omitempty"`
+	SSHPassword              *string `json:"ssh_password,omitempty"`
+	GoogleAuthToken          *string `json:"google_auth_token,omitempty"`
+	GoogleRefreshToken       *string `json:"google_refresh_token,omitempty"`
+	SupabaseAnonKey          *string `json:"supabase_anon_key,omitempty"`
+	SupabaseServiceRoleKey   *string `json:"supabase_service_role_key,omitempty"`
+	AirtableAPIKey           *string `json:"airtable_api_key,omitempty"`
+	PlanetscaleServiceToken  *string `json:"planetscale_service_token,omitempty"`
+	InfluxToken              *string `json:"influx_token,omitempty"`
+	FirestoreCredentialsJSON *string `json:"firestore_credentials_json,omitempty"`
+	CloudflareAPIToken       *string `json:"cloudflare_api_token,omitempty"`
+}
+
+// ChatExportSchemaSnapshot is the cached LLM schema of the chat at export time
+type ChatExportSchemaSnapshot struct {
+	Schema    string     `json:"schema"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ChatImportResponse is returned after a chat export has been imported
+type ChatImportResponse struct {
+	Chat             *ChatResponse `json:"chat"`
+	MessagesImported int           `json:"messagesImported"`
+	MessagesSkipped  int           `json:"messagesSkipped"`
+	Warnings         []string      `json:"warnings,omitempty"`
+}
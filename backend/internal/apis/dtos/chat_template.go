@@ -0,0 +1,14 @@
+package dtos
+
+// CreateChatFromTemplateRequest creates a chat from a template. Connection is required when the
+// template is not backed by the demo database, and must be of the template's database type.
+type CreateChatFromTemplateRequest struct {
+	Connection *CreateConnectionRequest `json:"connection,omitempty"`
+	Settings   CreateChatSettings       `json:"settings,omitempty"`
+}
+
+// ChatFromTemplateResponse is the created chat and the example messages it was populated with
+type ChatFromTemplateResponse struct {
+	Chat     *ChatResponse      `json:"chat"`
+	Messages []*MessageResponse `json:"messages"`
+}
@@ -17,3 +17,16 @@ type ConnectDBRequest struct {
 type DisconnectDBRequest struct {
 	StreamID string `json:"stream_id" binding:"required"`
 }
+
+// ConnectionDiagnostic explains why a connection test failed. The checks run in order and stop at the first
+// failure, so every check after FailedCheck is false as well.
+type ConnectionDiagnostic struct {
+	TCPReachable      bool    `json:"tcpReachable"`
+	TLSValid          bool    `json:"tlsValid"` // true when the connection doesn't use SSL
+	AuthSucceeded     bool    `json:"authSucceeded"`
+	DatabaseExists    bool    `json:"databaseExists"`
+	UserHasPermission bool    `json:"userHasPermission"`
+	FailedCheck       string  `json:"failedCheck,omitempty"` // tcp, tls, auth, database, permission or unknown
+	Error             *string `json:"error,omitempty"`
+	Suggestion        string  `json:"suggestion"`
+}
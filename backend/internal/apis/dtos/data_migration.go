@@ -0,0 +1,14 @@
+package dtos
+
+// DataMigrationRequest represents a request to generate migration scripts for a schema or data change
+type DataMigrationRequest struct {
+	Description string `json:"description" binding:"required"`
+}
+
+// DataMigrationResponse holds the chat messages created for a generated migration.
+// The assistant message carries the forward, rollback and verification scripts as DDL_MIGRATION queries.
+type DataMigrationResponse struct {
+	UserMessage *MessageResponse `json:"user_message"`
+	Message     *MessageResponse `json:"message"`
+	Warnings    []string         `json:"warnings,omitempty"`
+}
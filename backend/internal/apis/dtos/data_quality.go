@@ -0,0 +1,38 @@
+package dtos
+
+import "time"
+
+// DataQualityRequest represents a request to generate a data quality report
+type DataQualityRequest struct {
+	Tables []string `json:"tables" binding:"required,min=1"`
+}
+
+// DataQualityReport is the aggregated data quality report across the requested tables
+type DataQualityReport struct {
+	ChatID       string               `json:"chat_id"`
+	DatabaseType string               `json:"database_type"`
+	OverallScore float64              `json:"overall_score"` // Average of the per-table health scores (0-100)
+	Tables       []TableQualityReport `json:"tables"`
+	GeneratedAt  time.Time            `json:"generated_at"`
+}
+
+// TableQualityReport holds the data quality results for a single table
+type TableQualityReport struct {
+	TableName      string                   `json:"table_name"`
+	HealthScore    float64                  `json:"health_score"` // 0-100, higher is healthier
+	TotalRows      int64                    `json:"total_rows"`
+	DuplicateRows  int64                    `json:"duplicate_rows"`
+	NullPercentage map[string]float64       `json:"null_percentage,omitempty"` // column -> % of NULL values
+	Issues         []string                 `json:"issues"`
+	Checks         []DataQualityCheckResult `json:"checks"`
+}
+
+// DataQualityCheckResult is the outcome of a single generated check query
+type DataQualityCheckResult struct {
+	CheckType       string                   `json:"check_type"` // null_percentage, duplicates, numeric_stats, distinct_counts
+	Column          string                   `json:"column,omitempty"`
+	Query           string                   `json:"query"`
+	Result          []map[string]interface{} `json:"result,omitempty"`
+	Error           *string                  `json:"error,omitempty"`
+	ExecutionTimeMs int64                    `json:"execution_time_ms"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+// DependencyGraphNode is a table or collection a query touches
+type DependencyGraphNode struct {
+	Table    string `json:"table"`
+	RowCount int64  `json:"rowCount"` // Estimated from the cached schema, 0 when unknown
+}
+
+// DependencyGraphEdge is a join between two tables of a query
+type DependencyGraphEdge struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	JoinCondition string `json:"joinCondition"`
+	// JoinType is INNER, LEFT, RIGHT, FULL or CROSS as written in the query, or FOREIGN_KEY for a relationship
+	// between two tables of the query that it does not join on
+	JoinType string `json:"joinType"`
+}
+
+// DependencyGraph shows the tables a query reads or writes and how they are joined
+type DependencyGraph struct {
+	Nodes []DependencyGraphNode `json:"nodes"`
+	Edges []DependencyGraphEdge `json:"edges"`
+	// Adjacency lists the tables each table is joined to, every node has an entry
+	Adjacency map[string][]string `json:"adjacency"`
+}
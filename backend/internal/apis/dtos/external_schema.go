@@ -0,0 +1,19 @@
+package dtos
+
+import (
+	"neobase-ai/internal/models"
+	"time"
+)
+
+// ExternalSchemaSourceResponse describes an uploaded schema file and what was read from it
+type ExternalSchemaSourceResponse struct {
+	ID            string                       `json:"id"`
+	ChatID        string                       `json:"chat_id"`
+	SourceType    string                       `json:"source_type"`
+	FileName      string                       `json:"file_name"`
+	Tables        []models.ExternalSchemaTable `json:"tables"`
+	RelationCount int                          `json:"relation_count"`
+	// UnmatchedTables are tables of the file that the database schema doesn't have, often a stale file
+	UnmatchedTables []string  `json:"unmatched_tables,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package dtos
+
+// FederatedExecuteRequest represents a request to run one query per database and join the results in the backend
+type FederatedExecuteRequest struct {
+	PrimaryQuery     string `json:"primary_query" binding:"required"`
+	SecondaryQuery   string `json:"secondary_query" binding:"required"`
+	PrimaryJoinKey   string `json:"primary_join_key" binding:"required"`   // Column in the primary result used for the join
+	SecondaryJoinKey string `json:"secondary_join_key" binding:"required"` // Column in the secondary result used for the join
+	JoinType         string `json:"join_type,omitempty"`                   // "inner" (default) or "left"
+}
+
+// FederatedExecuteResponse is the merged result of a federated query
+type FederatedExecuteResponse struct {
+	Columns           []string                 `json:"columns"`
+	Rows              []map[string]interface{} `json:"rows"`
+	RowCount          int                      `json:"row_count"`
+	PrimaryRowCount   int                      `json:"primary_row_count"`
+	SecondaryRowCount int                      `json:"secondary_row_count"`
+	JoinType          string                   `json:"join_type"`
+	Truncated         bool                     `json:"truncated"` // True if the joined result exceeded the row limit
+	ExecutionTimeMs   int64                    `json:"execution_time_ms"`
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"neobase-ai/internal/models"
+)
+
+// UserDataDeletionReport lists what was erased for a user's right to erasure request
+type UserDataDeletionReport struct {
+	UserID                string    `json:"userId"`
+	DeletedChats          int64     `json:"deletedChats"`
+	DeletedMessages       int64     `json:"deletedMessages"`
+	DeletedFeedback       int64     `json:"deletedFeedback"`
+	DeletedReactions      int64     `json:"deletedReactions"`
+	DeletedVisualizations int64     `json:"deletedVisualizations"`
+	DeletedDashboards     int64     `json:"deletedDashboards"`
+	DeletedWidgets        int64     `json:"deletedWidgets"`
+	DeletedKnowledgeBases int64     `json:"deletedKnowledgeBases"`
+	DeletedQueryTemplates int64     `json:"deletedQueryTemplates"`
+	DeletedSecureNotes    int64     `json:"deletedSecureNotes"`
+	DeletedSchemaFiles    int64     `json:"deletedSchemaFiles"`
+	DeletedReports        int64     `json:"deletedReports"`
+	DeletedRollbacks      int64     `json:"deletedRollbacks"`
+	DeletedAPIKeys        int64     `json:"deletedApiKeys"`
+	DeletedAccount        bool      `json:"deletedAccount"` // The account is kept when any collection failed, so the request can be retried
+	Errors                []string  `json:"errors,omitempty"`
+	DeletedAt             time.Time `json:"deletedAt"`
+}
+
+// UserDataExport is the portable JSON archive of everything NeoBase holds about a user (GDPR Article 20).
+// Connection passwords, keys and tokens are left out, secure notes stay encrypted.
+type UserDataExport struct {
+	ExportVersion  string                        `json:"exportVersion"`
+	ExportedAt     time.Time                     `json:"exportedAt"`
+	User           *models.User                  `json:"user"`
+	Chats          []models.Chat                 `json:"chats"`
+	Messages       []models.Message              `json:"messages"`
+	Feedback       []models.MessageFeedback      `json:"feedback"`
+	Reactions      []models.MessageReaction      `json:"reactions"`
+	Visualizations []models.MessageVisualization `json:"visualizations"`
+	Dashboards     []models.Dashboard            `json:"dashboards"`
+	Widgets        []models.Widget               `json:"widgets"`
+	KnowledgeBases []models.KnowledgeBase        `json:"knowledgeBases"`
+	QueryTemplates []models.QueryTemplate        `json:"queryTemplates"`
+	SecureNotes    []models.SecureNote           `json:"secureNotes"`
+	SchemaFiles    []models.ExternalSchemaSource `json:"schemaFiles"`
+	Reports        []models.Report               `json:"reports"`
+	Rollbacks      []models.RollbackRecord       `json:"rollbacks"`
+	APIKeys        []models.APIKey               `json:"apiKeys"`
+}
@@ -0,0 +1,27 @@
+package dtos
+
+// GlobalSearchRequest holds the query parameters of the search across all chats of a user
+type GlobalSearchRequest struct {
+	Q        string `form:"q" binding:"required,max=200"`
+	Type     string `form:"type" binding:"omitempty,oneof=message query"` // default is message
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
+}
+
+// GlobalSearchResult is a message, or a query of a message, matching the keyword
+type GlobalSearchResult struct {
+	ChatID    string `json:"chatID"`
+	ChatTitle string `json:"chatTitle"`
+	MessageID string `json:"messageID"`
+	QueryID   string `json:"queryID,omitempty"` // Only set for query searches
+	Snippet   string `json:"snippet"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// GlobalSearchResponse is a page of global search results, best text matches or newest queries first
+type GlobalSearchResponse struct {
+	Results  []GlobalSearchResult `json:"results"`
+	Total    int64                `json:"total"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"pageSize"`
+}
@@ -1,5 +1,7 @@
 package dtos
 
+import "time"
+
 // ImportMetadata contains metadata about imported data
 type ImportMetadata struct {
 	TableName   string                 `json:"table_name"`
@@ -9,6 +11,7 @@ type ImportMetadata struct {
 	Issues      []string               `json:"issues,omitempty"`
 	Suggestions []string               `json:"suggestions,omitempty"`
 	Columns     []ImportColumnMetadata `json:"columns"`
+	StoredAt    time.Time              `json:"stored_at"`
 }
 
 // ImportColumnMetadata contains metadata about an imported column
@@ -20,4 +23,31 @@ type ImportColumnMetadata struct {
 	UniqueCount  int    `json:"unique_count"`
 	IsEmpty      bool   `json:"is_empty"`
 	IsPrimaryKey bool   `json:"is_primary_key"`
-}
\ No newline at end of file
+}
+
+// ImportProgress is streamed with "import_progress" events while a spreadsheet import is running
+type ImportProgress struct {
+	JobID     string   `json:"job_id,omitempty"`
+	TableName string   `json:"table_name"`
+	Processed int      `json:"processed"`
+	Total     int      `json:"total"`
+	Errors    []string `json:"errors"`
+}
+
+// ImportStatus is the persisted state of the latest spreadsheet import for a chat
+type ImportStatus struct {
+	ImportProgress
+	Status      string                     `json:"status"` // running, completed, failed
+	Error       string                     `json:"error,omitempty"`
+	Result      *SpreadsheetUploadResponse `json:"result,omitempty"`
+	StartedAt   time.Time                  `json:"started_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+	CompletedAt *time.Time                 `json:"completed_at,omitempty"`
+}
+
+// ImportJobResponse is returned when a large upload is accepted for background import
+type ImportJobResponse struct {
+	JobID     string `json:"job_id"`
+	Status    string `json:"status"`
+	StatusURL string `json:"status_url"`
+}
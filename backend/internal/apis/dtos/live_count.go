@@ -0,0 +1,14 @@
+package dtos
+
+// LiveCountRequest asks how many rows of a table match a WHERE clause, sent while the user types it
+type LiveCountRequest struct {
+	Table string `json:"table" binding:"required"`
+	// WhereClause is the condition alone, without the WHERE keyword
+	WhereClause string `json:"whereClause" binding:"required,max=2000"`
+}
+
+// LiveCountResponse holds the number of matching rows and how long counting them took
+type LiveCountResponse struct {
+	Count       int64 `json:"count"`
+	EstimatedMs int64 `json:"estimatedMs"`
+}
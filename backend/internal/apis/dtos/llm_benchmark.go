@@ -0,0 +1,40 @@
+package dtos
+
+// BenchmarkRequest runs every test case against every model, with the schema of the chat's connection
+type BenchmarkRequest struct {
+	Models    []string            `json:"models" binding:"required,min=1"`
+	ChatID    string              `json:"chatId" binding:"required"`
+	TestCases []BenchmarkTestCase `json:"testCases" binding:"required,min=1,dive"`
+}
+
+// BenchmarkTestCase is a question and a Go regular expression one of the generated queries must match
+type BenchmarkTestCase struct {
+	Question             string `json:"question" binding:"required"`
+	ExpectedQueryPattern string `json:"expectedQueryPattern" binding:"required"`
+}
+
+// BenchmarkResult holds the outcome of every model and test case pair, and a summary per model
+type BenchmarkResult struct {
+	Results []BenchmarkCaseResult   `json:"results"`
+	Summary []BenchmarkModelSummary `json:"summary"`
+}
+
+// BenchmarkCaseResult is the outcome of one test case on one model.
+// Token counts are estimated from the prompt and response length, as providers don't all report usage.
+type BenchmarkCaseResult struct {
+	Model          string  `json:"model"`
+	TestCase       string  `json:"testCase"`
+	Passed         bool    `json:"passed"`
+	LatencyMs      int64   `json:"latencyMs"`
+	InputTokens    int     `json:"inputTokens"`
+	OutputTokens   int     `json:"outputTokens"`
+	GeneratedQuery string  `json:"generatedQuery,omitempty"` // The query matching the pattern, or the first generated query
+	Error          *string `json:"error,omitempty"`
+}
+
+// BenchmarkModelSummary is the pass rate and average latency of a model over all test cases
+type BenchmarkModelSummary struct {
+	Model        string  `json:"model"`
+	PassRate     float64 `json:"passRate"` // Share of passed test cases, from 0 to 1
+	AvgLatencyMs int64   `json:"avgLatencyMs"`
+}
@@ -0,0 +1,32 @@
+package dtos
+
+// MagicQueryRequest asks a one-off question about a database given by its connection URI, without a chat
+type MagicQueryRequest struct {
+	DBType        string `json:"dbType" binding:"required"`
+	ConnectionURI string `json:"connectionURI" binding:"required"`
+	Question      string `json:"question" binding:"required"`
+	Model         string `json:"model"`   // LLM model ID, empty uses the default model
+	Execute       bool   `json:"execute"` // Run the generated read-only queries and return their results
+}
+
+// MagicQueryResponse is the LLM's answer with the generated queries and, when executed, their results
+type MagicQueryResponse struct {
+	AssistantMessage string             `json:"assistant_message"`
+	Queries          []MagicQueryResult `json:"queries"`
+	Model            string             `json:"model,omitempty"`
+}
+
+// MagicQueryResult is one generated query. Only read-only queries are executed, writes are returned for review.
+type MagicQueryResult struct {
+	Query           string      `json:"query"`
+	QueryType       string      `json:"query_type"`
+	Explanation     string      `json:"explanation"`
+	IsCritical      bool        `json:"is_critical"`
+	Executed        bool        `json:"executed"`
+	SkippedReason   string      `json:"skipped_reason,omitempty"`
+	Result          interface{} `json:"result,omitempty"`
+	ExecutionTimeMs int         `json:"execution_time_ms,omitempty"`
+	Error           *QueryError `json:"error,omitempty"`
+	Truncated       bool        `json:"truncated,omitempty"`    // the result was cut off at the user's row cap
+	TruncatedAt     int         `json:"truncated_at,omitempty"` // the row cap the result was cut off at
+}
@@ -14,22 +14,39 @@ type CreateMessageRequest struct {
 	LLMModel string `json:"llm_model,omitempty"` // Selected LLM model ID (e.g., "gpt-4o", "gemini-2.0-flash")
 }
 
+// RegenerateMessageRequest asks for a new AI response to a user message, with the message content unchanged
+type RegenerateMessageRequest struct {
+	StreamID string `json:"stream_id" binding:"required"`
+}
+
 type MessageResponse struct {
-	ID            string          `json:"id"`
-	ChatID        string          `json:"chat_id"`
-	UserMessageID *string         `json:"user_message_id,omitempty"` // Only for AI response, this is the user message id of the message that triggered the AI response
-	Type          string          `json:"type"`
-	Content       string          `json:"content"`
-	Queries       *[]Query        `json:"queries,omitempty"`
-	ActionButtons *[]ActionButton `json:"action_buttons,omitempty"` // UI action buttons suggested by the LLM
-	LLMModel      *string         `json:"llm_model,omitempty"`      // LLM model ID used to generate this message (nullable for backward compatibility)
-	LLMModelName  *string         `json:"llm_model_name,omitempty"` // Display name for the LLM model (e.g., "GPT-4 Omni", "Gemini 2.0 Flash")
-	IsEdited      bool            `json:"is_edited"`
-	NonTechMode   bool            `json:"non_tech_mode"`       // Whether this message was generated in non-tech mode
-	IsPinned      bool            `json:"is_pinned"`           // Whether this message is pinned
-	PinnedAt      *string         `json:"pinned_at,omitempty"` // When the message was pinned
-	CreatedAt     string          `json:"created_at"`
-	UpdatedAt     string          `json:"updated_at"`
+	ID              string          `json:"id"`
+	ChatID          string          `json:"chat_id"`
+	UserMessageID   *string         `json:"user_message_id,omitempty"` // Only for AI response, this is the user message id of the message that triggered the AI response
+	Type            string          `json:"type"`
+	Content         string          `json:"content"`
+	Queries         *[]Query        `json:"queries,omitempty"`
+	ActionButtons   *[]ActionButton `json:"action_buttons,omitempty"` // UI action buttons suggested by the LLM
+	LLMModel        *string         `json:"llm_model,omitempty"`      // LLM model ID used to generate this message (nullable for backward compatibility)
+	LLMModelName    *string         `json:"llm_model_name,omitempty"` // Display name for the LLM model (e.g., "GPT-4 Omni", "Gemini 2.0 Flash")
+	LLMModelUsed    *string         `json:"llm_model_used,omitempty"` // Model that actually answered, differs from LLMModel when a fallback model was used
+	IsEdited        bool            `json:"is_edited"`
+	NonTechMode     bool            `json:"non_tech_mode"`               // Whether this message was generated in non-tech mode
+	IsPinned        bool            `json:"is_pinned"`                   // Whether this message is pinned
+	PinnedAt        *string         `json:"pinned_at,omitempty"`         // When the message was pinned
+	ParentMessageID *string         `json:"parent_message_id,omitempty"` // AI message a threaded follow-up was asked about
+	ThreadID        *string         `json:"thread_id,omitempty"`         // Root AI message of the thread, omitted for main thread messages
+	FeedbackCount   int             `json:"feedback_count"`              // Number of user feedbacks on this AI response
+	AvgRating       float64         `json:"avg_rating"`                  // Average feedback rating (1-5), 0 without feedback
+	Reactions       map[string]int  `json:"reactions,omitempty"`         // Number of reactions per emoji, e.g. {"👍": 3}
+	CreatedAt       string          `json:"created_at"`
+	UpdatedAt       string          `json:"updated_at"`
+
+	// RegenerationCount is how many times the AI response to this user message was regenerated
+	RegenerationCount int `json:"regeneration_count,omitempty"`
+
+	// RollbackCount is how many rollbacks of this message's queries were attempted
+	RollbackCount int `json:"rollback_count"`
 }
 
 // ActionButton represents a UI action button that can be suggested by the LLM
@@ -60,7 +77,18 @@ type Query struct {
 	Pagination             *Pagination            `json:"pagination,omitempty"`
 	Visualization          *VisualizationData     `json:"visualization,omitempty"` // Visualization state for this query
 	IsEdited               bool                   `json:"is_edited"`
-	ActionAt               *string                `json:"action_at,omitempty"` // The timestamp when the action was taken
+	ActionAt               *string                `json:"action_at,omitempty"`        // The timestamp when the action was taken
+	ComplexityScore        string                 `json:"complexity_score,omitempty"` // low, medium, high or extreme, extreme queries are never auto-executed
+	MigrationRisk          *MigrationRisk         `json:"migrationRisk,omitempty"`    // Risk of running a DDL query, medium and high risk queries need confirmation
+	// Table names to type before a TRUNCATE runs, sent back as confirmationText when executing the query
+	RequiresConfirmationText string `json:"requiresConfirmationText,omitempty"`
+}
+
+// MigrationRisk is the assessed locking and data loss risk of a DDL query
+type MigrationRisk struct {
+	RiskLevel             string `json:"riskLevel"` // low, medium or high
+	Reason                string `json:"reason"`
+	EstimatedLockDuration string `json:"estimatedLockDuration"`
 }
 
 // VisualizationData contains the visualization state for a query
@@ -82,6 +110,8 @@ type Pagination struct {
 	CursorField       *string `json:"cursor_field,omitempty"`       // Field used for cursor pagination
 	CursorDirection   *string `json:"cursor_direction,omitempty"`   // ASC or DESC
 	PageSize          *int    `json:"page_size,omitempty"`          // Records per page
+	KeysetQuery       string  `json:"keyset_query,omitempty"`       // Keyset page query, pages after the first can request it by sending last_key
+	KeysetField       string  `json:"keyset_field,omitempty"`       // Field whose value is returned as last_key
 	// We do not return the paginatedQuery and countQuery in the response
 }
 
@@ -173,6 +203,10 @@ func ToQueryDtoWithDecryption(queries *[]models.Query, decryptFunc func(string)
 				CursorDirection:   query.Pagination.CursorDirection,
 				PageSize:          query.Pagination.PageSize,
 			}
+			if query.KeysetPagination != nil {
+				pagination.KeysetQuery = query.KeysetPagination.KeysetQuery
+				pagination.KeysetField = query.KeysetPagination.KeyField
+			}
 		}
 		log.Printf("ToQueryDto -> final exampleResult: %v", exampleResult)
 
@@ -231,26 +265,29 @@ func ToQueryDtoWithDecryption(queries *[]models.Query, decryptFunc func(string)
 			log.Printf("ToQueryDto -> Skipping visualization fetch for query %s (vizRepo=%v, ctx=%v, vizID=%v)", query.ID.Hex(), vizRepo != nil, ctx != nil, query.VisualizationID != nil)
 		}
 		queriesDto[i] = Query{
-			ID:                     query.ID.Hex(),
-			Query:                  query.Query,
-			Description:            query.Description,
-			ExecutionTime:          query.ExecutionTime,
-			ExampleExecutionTime:   query.ExampleExecutionTime,
-			CanRollback:            query.CanRollback,
-			IsCritical:             query.IsCritical,
-			IsExecuted:             query.IsExecuted,
-			IsRolledBack:           query.IsRolledBack,
-			Error:                  (*QueryError)(query.Error),
-			ExampleResult:          exampleResult,
-			ExecutionResult:        executionResult,
-			QueryType:              query.QueryType,
-			Tables:                 query.Tables,
-			RollbackQuery:          query.RollbackQuery,
-			RollbackDependentQuery: query.RollbackDependentQuery,
-			Pagination:             pagination,
-			Visualization:          visualizationData,
-			IsEdited:               query.IsEdited,
-			ActionAt:               query.ActionAt,
+			ID:                       query.ID.Hex(),
+			Query:                    query.Query,
+			Description:              query.Description,
+			ExecutionTime:            query.ExecutionTime,
+			ExampleExecutionTime:     query.ExampleExecutionTime,
+			CanRollback:              query.CanRollback,
+			IsCritical:               query.IsCritical,
+			IsExecuted:               query.IsExecuted,
+			IsRolledBack:             query.IsRolledBack,
+			Error:                    (*QueryError)(query.Error),
+			ExampleResult:            exampleResult,
+			ExecutionResult:          executionResult,
+			QueryType:                query.QueryType,
+			Tables:                   query.Tables,
+			RollbackQuery:            query.RollbackQuery,
+			RollbackDependentQuery:   query.RollbackDependentQuery,
+			Pagination:               pagination,
+			Visualization:            visualizationData,
+			IsEdited:                 query.IsEdited,
+			ActionAt:                 query.ActionAt,
+			ComplexityScore:          query.ComplexityScore,
+			MigrationRisk:            (*MigrationRisk)(query.MigrationRisk),
+			RequiresConfirmationText: query.RequiresConfirmationText,
 		}
 	}
 	return &queriesDto
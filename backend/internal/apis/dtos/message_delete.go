@@ -0,0 +1,22 @@
+package dtos
+
+// DeleteMessagesRequest filters the messages deleted by DELETE /chats/:id/messages. Without filters every message is deleted.
+type DeleteMessagesRequest struct {
+	Before    string `form:"before"`
+	Type      string `form:"type" binding:"omitempty,oneof=user assistant"`
+	HasErrors *bool  `form:"hasErrors"`
+}
+
+// IsEmpty reports whether no filter was given
+func (r *DeleteMessagesRequest) IsEmpty() bool {
+	return r.Before == "" && r.Type == "" && r.HasErrors == nil
+}
+
+// DeleteMessagesResponse reports a filtered deletion. Large deletions run in the background,
+// then only the job ID and the number of matched messages are known.
+type DeleteMessagesResponse struct {
+	DeletedCount int64  `json:"deletedCount"`
+	MatchedCount int64  `json:"matchedCount"`
+	JobID        string `json:"jobId,omitempty"`
+	Status       string `json:"status"`
+}
@@ -0,0 +1,50 @@
+package dtos
+
+type MessageFeedbackRequest struct {
+	Rating   int     `json:"rating" binding:"required,min=1,max=5"`
+	Issue    string  `json:"issue" binding:"required,oneof=wrong_table wrong_query wrong_explanation too_slow correct"`
+	FreeText *string `json:"freeText,omitempty"`
+}
+
+// MessageFeedbackResponse is the saved feedback and the updated feedback stats of the message
+type MessageFeedbackResponse struct {
+	MessageID     string  `json:"message_id"`
+	Rating        int     `json:"rating"`
+	Issue         string  `json:"issue"`
+	FreeText      *string `json:"freeText,omitempty"`
+	FeedbackCount int     `json:"feedback_count"`
+	AvgRating     float64 `json:"avg_rating"`
+	IsLowRated    bool    `json:"is_low_rated"`
+}
+
+// FeedbackItem is one feedback in the admin feedback review
+type FeedbackItem struct {
+	ID        string  `json:"id"`
+	UserID    string  `json:"user_id"`
+	ChatID    string  `json:"chat_id"`
+	MessageID string  `json:"message_id"`
+	Rating    int     `json:"rating"`
+	Issue     string  `json:"issue"`
+	FreeText  *string `json:"freeText,omitempty"`
+	DBType    string  `json:"db_type"`
+	LLMModel  string  `json:"llm_model"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// FeedbackRatingStats is the number of feedbacks and average rating of an LLM model or database type
+type FeedbackRatingStats struct {
+	Name      string  `json:"name"`
+	Count     int64   `json:"count"`
+	AvgRating float64 `json:"avg_rating"`
+	LowRated  int64   `json:"low_rated"`
+}
+
+// AdminFeedbackResponse is a page of feedback with the average ratings by LLM model and database type
+type AdminFeedbackResponse struct {
+	Feedback []FeedbackItem        `json:"feedback"`
+	Total    int64                 `json:"total"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"page_size"`
+	ByModel  []FeedbackRatingStats `json:"by_model"`
+	ByDBType []FeedbackRatingStats `json:"by_db_type"`
+}
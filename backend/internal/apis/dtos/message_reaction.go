@@ -0,0 +1,12 @@
+package dtos
+
+type MessageReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+// MessageReactionResponse is the reaction that was added or removed and the updated reaction counts of the message
+type MessageReactionResponse struct {
+	MessageID string         `json:"message_id"`
+	Emoji     string         `json:"emoji"`
+	Reactions map[string]int `json:"reactions"`
+}
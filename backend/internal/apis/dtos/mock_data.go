@@ -0,0 +1,16 @@
+package dtos
+
+// MockDataRequest represents a request to fill tables with generated test data
+type MockDataRequest struct {
+	Tables       []string `json:"tables" binding:"required,min=1"`
+	RowsPerTable int      `json:"rowsPerTable" binding:"min=0,max=100"` // Defaults to constants.MockDataDefaultRowsPerTable
+}
+
+// MockDataResponse holds the rows inserted per table and the chat messages created for them.
+// The assistant message carries one executed INSERT query per table, each rolling back with its DELETE.
+type MockDataResponse struct {
+	InsertedRows map[string]int   `json:"insertedRows"`
+	UserMessage  *MessageResponse `json:"userMessage"`
+	Message      *MessageResponse `json:"message"`
+	Warnings     []string         `json:"warnings,omitempty"`
+}
@@ -1,9 +1,21 @@
 package dtos
 
+import "time"
+
 type ExecuteQueryRequest struct {
 	MessageID string `json:"message_id" binding:"required"`
 	QueryID   string `json:"query_id" binding:"required"`
 	StreamID  string `json:"stream_id" binding:"required"`
+	DryRun    bool   `json:"dry_run"` // count the rows an UPDATE, DELETE or INSERT would change without running it
+	// ConfirmationText must equal the query's requiresConfirmationText, the truncated table names, to run a TRUNCATE
+	ConfirmationText string `json:"confirmationText"`
+}
+
+// DryRunResult is the number of rows a write query would change
+type DryRunResult struct {
+	AffectedRows int64  `json:"affected_rows"`
+	QueryType    string `json:"query_type"`
+	CountQuery   string `json:"count_query,omitempty"` // the read-only query that was run to count the rows
 }
 
 type RollbackQueryRequest struct {
@@ -31,14 +43,22 @@ type QueryExecutionResponse struct {
 	ActionButtons     *[]ActionButton `json:"action_buttons,omitempty"`
 	ActionAt          *string         `json:"action_at,omitempty"`
 	UpdatedContent    *string         `json:"updated_content,omitempty"` // set when explainErrorWithLLM updates message content
+	Truncated         bool            `json:"truncated,omitempty"`       // the result was cut off at the user's row cap
+	TruncatedAt       int             `json:"truncated_at,omitempty"`    // the row cap the result was cut off at
+	DryRunResult      *DryRunResult   `json:"dry_run_result,omitempty"`  // set instead of the execution result for dry runs
+	// EstimatedCost is the PostgreSQL EXPLAIN total cost, set when the chat has a query cost budget
+	EstimatedCost      float64 `json:"estimated_cost,omitempty"`
+	CostBudgetExceeded bool    `json:"cost_budget_exceeded,omitempty"` // the query was blocked for costing more than the budget
 }
 
 type QueryResultsRequest struct {
 	MessageID string  `json:"message_id" binding:"required"`
 	QueryID   string  `json:"query_id" binding:"required"`
 	StreamID  string  `json:"stream_id" binding:"required"`
-	Offset    int     `json:"offset"` // Deprecated: Use Cursor instead
-	Cursor    *string `json:"cursor"` // Cursor value for cursor-based pagination
+	Offset    int     `json:"offset" binding:"min=0"` // Deprecated: Use Cursor instead
+	Cursor    *string `json:"cursor"`                 // Cursor value for cursor-based pagination
+	// LastKey is the last row's key from the previous page; when set, keyset pagination is used instead of offset
+	LastKey interface{} `json:"last_key,omitempty"`
 }
 
 type QueryResultsResponse struct {
@@ -50,6 +70,7 @@ type QueryResultsResponse struct {
 	TotalRecordsCount *int            `json:"total_records_count"`
 	NextCursor        *string         `json:"next_cursor,omitempty"` // Cursor for next page (cursor-based pagination)
 	HasMore           bool            `json:"has_more"`              // Whether more results exist
+	LastKey           interface{}     `json:"last_key,omitempty"`    // Key of the last row, send back as last_key to fetch the next keyset page
 	ActionButtons     *[]ActionButton `json:"action_buttons,omitempty"`
 	ActionAt          *string         `json:"action_at,omitempty"`
 }
@@ -67,3 +88,35 @@ type EditQueryResponse struct {
 	Query     string `json:"query"`
 	IsEdited  bool   `json:"is_edited"`
 }
+
+// QueryHistoryRequest holds the query parameters of the query history endpoint.
+// From and To accept RFC3339 timestamps or YYYY-MM-DD dates.
+type QueryHistoryRequest struct {
+	From      string `form:"from"`
+	To        string `form:"to"`
+	QueryType string `form:"queryType"`
+	Executed  *bool  `form:"executed"`
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	PageSize  int    `form:"pageSize" binding:"omitempty,min=1,max=200"`
+}
+
+// QueryHistoryItem is a single generated query without the content of its message
+type QueryHistoryItem struct {
+	QueryID       string    `json:"query_id"`
+	MessageID     string    `json:"message_id"`
+	Query         string    `json:"query"`
+	QueryType     *string   `json:"query_type"`
+	IsExecuted    bool      `json:"is_executed"`
+	IsRolledBack  bool      `json:"is_rolled_back"`
+	ExecutionTime *int      `json:"execution_time"`
+	Tables        *string   `json:"tables"`
+	Error         *string   `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type QueryHistoryResponse struct {
+	Queries  []QueryHistoryItem `json:"queries"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+// ExplainQueryRequest asks for a plain English explanation of a query, the query is not executed.
+// Either query or templateId must be set, a template is explained with its :name placeholders.
+type ExplainQueryRequest struct {
+	Query      string `json:"query"`
+	QueryType  string `json:"queryType"`
+	TemplateID string `json:"templateId"`
+}
+
+// QueryExplanationStep is one step of a query, in the order the database evaluates it
+type QueryExplanationStep struct {
+	Step        int    `json:"step"`
+	Description string `json:"description"`
+}
+
+// QueryExplanationResponse explains what a query does in plain English
+type QueryExplanationResponse struct {
+	Explanation string                 `json:"explanation"`
+	Steps       []QueryExplanationStep `json:"steps"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+// ComparePlansRequest holds two versions of the same query to compare
+type ComparePlansRequest struct {
+	QueryA string `json:"queryA" binding:"required"`
+	QueryB string `json:"queryB" binding:"required"`
+}
+
+// QueryPlanSummary is the parsed EXPLAIN output of one query
+type QueryPlanSummary struct {
+	Query         string      `json:"query"`
+	TotalCost     float64     `json:"total_cost"`
+	EstimatedRows float64     `json:"estimated_rows"`
+	PlanNodes     []string    `json:"plan_nodes"`   // Node types in plan order, e.g. Seq Scan, Hash Join
+	IndexesUsed   []string    `json:"indexes_used"` // Indexes the plan reads
+	JoinTypes     []string    `json:"join_types"`   // Join algorithms and kinds, e.g. Hash Join (Inner)
+	FullScans     []string    `json:"full_scans"`   // Tables read without an index
+	Plan          interface{} `json:"plan"`         // Raw JSON plan as returned by the database
+}
+
+// ComparePlansResponse compares the plans of two queries, the winner has the lower total cost
+type ComparePlansResponse struct {
+	DatabaseType string           `json:"database_type"`
+	PlanA        QueryPlanSummary `json:"planA"`
+	PlanB        QueryPlanSummary `json:"planB"`
+	Winner       string           `json:"winner"` // A, B or tie
+	Differences  []string         `json:"differences"`
+}
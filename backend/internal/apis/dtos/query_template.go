@@ -0,0 +1,39 @@
+package dtos
+
+import (
+	"neobase-ai/internal/models"
+	"time"
+)
+
+// CreateQueryTemplateRequest saves a read-only query with :name placeholders, e.g.
+// SELECT * FROM orders WHERE status = :status AND created_at > :from_date
+type CreateQueryTemplateRequest struct {
+	Name       string                 `json:"name" binding:"required,max=200"`
+	Template   string                 `json:"template" binding:"required"`
+	Parameters []models.TemplateParam `json:"parameters"` // Every placeholder of the template must be declared
+}
+
+// ExecuteQueryTemplateRequest fills in a template's parameters, parameters with a default value can be left out
+type ExecuteQueryTemplateRequest struct {
+	Params map[string]interface{} `json:"params"`
+}
+
+// QueryTemplateResponse is a saved query template
+type QueryTemplateResponse struct {
+	ID         string                 `json:"id"`
+	ChatID     string                 `json:"chat_id"`
+	Name       string                 `json:"name"`
+	Template   string                 `json:"template"`
+	Parameters []models.TemplateParam `json:"parameters"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+// QueryTemplateExecutionResponse is the result of running a template with bound parameters
+type QueryTemplateExecutionResponse struct {
+	TemplateID      string      `json:"template_id"`
+	ExecutionTime   int         `json:"execution_time"`
+	ExecutionResult interface{} `json:"execution_result"`
+	Truncated       bool        `json:"truncated,omitempty"`    // the result was cut off at the row cap
+	TruncatedAt     int         `json:"truncated_at,omitempty"` // the row cap the result was cut off at
+}
@@ -0,0 +1,33 @@
+package dtos
+
+import "time"
+
+// StartQueryWatchRequest starts re-running a query of a message on an interval
+type StartQueryWatchRequest struct {
+	Query           string `json:"query"` // Defaults to the stored query of the message
+	IntervalSeconds int    `json:"intervalSeconds" binding:"required,min=5,max=60"`
+	MessageID       string `json:"messageId" binding:"required"`
+	QueryID         string `json:"queryId" binding:"required"`
+	StreamID        string `json:"streamId" binding:"required"`
+}
+
+// QueryWatchResponse describes a running watch
+type QueryWatchResponse struct {
+	WatchID         string    `json:"watch_id"`
+	ChatID          string    `json:"chat_id"`
+	MessageID       string    `json:"message_id"`
+	QueryID         string    `json:"query_id"`
+	Query           string    `json:"query"`
+	IntervalSeconds int       `json:"interval_seconds"`
+	StartedAt       time.Time `json:"started_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+// QueryWatchDiff is the difference between two runs of a watched query.
+// Rows are compared as whole values, so a changed row shows up as removed and added.
+type QueryWatchDiff struct {
+	Added         []map[string]interface{} `json:"added"`
+	Removed       []map[string]interface{} `json:"removed"`
+	RowCountDelta int                      `json:"row_count_delta"`
+	Changed       bool                     `json:"changed"`
+}
@@ -0,0 +1,53 @@
+package dtos
+
+import (
+	"neobase-ai/internal/models"
+	"time"
+)
+
+// GenerateReportRequest asks for a report answering each section's question from the chat's database
+type GenerateReportRequest struct {
+	Title    string                 `json:"title" binding:"required,max=200"`
+	Sections []ReportSectionRequest `json:"sections" binding:"required,min=1,dive"`
+	StreamID string                 `json:"stream_id" binding:"required"` // Receives the report_section and report_completed events
+}
+
+type ReportSectionRequest struct {
+	Heading  string `json:"heading" binding:"required,max=200"`
+	Question string `json:"question" binding:"required"`
+}
+
+// ReportResponse is a generated report. Markdown is empty until the report is completed, and in lists.
+type ReportResponse struct {
+	ID        string                 `json:"id"`
+	ChatID    string                 `json:"chat_id"`
+	Title     string                 `json:"title"`
+	Status    string                 `json:"status"`
+	Sections  []models.ReportSection `json:"sections"`
+	Report    string                 `json:"report,omitempty"`
+	Error     *string                `json:"error,omitempty"`
+	Model     string                 `json:"model,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// ReportSectionEvent is streamed as each section's query completes
+type ReportSectionEvent struct {
+	ReportID string               `json:"report_id"`
+	Index    int                  `json:"index"`
+	Total    int                  `json:"total"`
+	Section  models.ReportSection `json:"section"`
+}
+
+// ReportCompletedEvent is streamed once the report is written, or failed
+type ReportCompletedEvent struct {
+	ReportID string  `json:"report_id"`
+	Report   string  `json:"report,omitempty"`
+	Error    *string `json:"error,omitempty"`
+}
+
+// ReportPDF is a completed report rendered as a PDF file
+type ReportPDF struct {
+	FileName string
+	Content  []byte
+}
@@ -5,3 +5,15 @@ type Response struct {
 	Data    interface{} `json:"data,omitempty"`
 	Error   *string     `json:"error,omitempty"`
 }
+
+// FieldError describes a request body field that failed validation
+type FieldError struct {
+	Field   string      `json:"field"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+// ValidationErrorsResponse is the data of a 400 response for a request body that failed validation
+type ValidationErrorsResponse struct {
+	Errors []FieldError `json:"errors"`
+}
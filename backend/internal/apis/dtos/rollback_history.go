@@ -0,0 +1,28 @@
+package dtos
+
+// RollbackHistoryRequest holds the query parameters of the rollback history endpoint
+type RollbackHistoryRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"pageSize" binding:"omitempty,min=1,max=100"`
+}
+
+// RollbackRecordResponse is a rollback attempt, or the dependent query run that preceded it
+type RollbackRecordResponse struct {
+	ID               string  `json:"id"`
+	MessageID        string  `json:"message_id"`
+	QueryID          string  `json:"query_id"`
+	Step             string  `json:"step"` // dependent_query or rollback
+	RollbackQuery    string  `json:"rollback_query"`
+	Success          bool    `json:"success"`
+	Error            *string `json:"error,omitempty"`
+	DependentQueryID *string `json:"dependent_query_id,omitempty"` // ID of the dependent_query record linked to this rollback
+	ExecutedAt       string  `json:"executed_at"`
+}
+
+// RollbackHistoryResponse is a page of a chat's rollback attempts, most recent first
+type RollbackHistoryResponse struct {
+	Records  []RollbackRecordResponse `json:"records"`
+	Total    int64                    `json:"total"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"page_size"`
+}
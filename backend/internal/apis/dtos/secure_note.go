@@ -0,0 +1,19 @@
+package dtos
+
+import "time"
+
+// CreateSecureNoteRequest adds context about the connection that every LLM call of the chat receives
+type CreateSecureNoteRequest struct {
+	Content  string `json:"content" binding:"required,max=4000"`
+	IsActive *bool  `json:"is_active"` // Defaults to true
+}
+
+// SecureNoteResponse is a decrypted secure note
+type SecureNoteResponse struct {
+	ID        string    `json:"id"`
+	ChatID    string    `json:"chat_id"`
+	Content   string    `json:"content"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
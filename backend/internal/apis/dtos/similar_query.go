@@ -0,0 +1,24 @@
+package dtos
+
+// SimilarQueryRequest asks for past queries of a chat whose questions resemble a new one
+type SimilarQueryRequest struct {
+	Question string `json:"question" binding:"required"`
+	TopK     int    `json:"topK" binding:"omitempty,min=1,max=50"`
+}
+
+// SimilarQuery is a query generated for a past question similar to the new one
+type SimilarQuery struct {
+	MessageID  string  `json:"messageId"`
+	QueryID    string  `json:"queryId"`
+	Question   string  `json:"question"`
+	Query      string  `json:"query"`
+	Similarity float64 `json:"similarity"`
+	ExecutedAt *string `json:"executedAt,omitempty"`
+}
+
+// SimilarQueryResponse lists the most similar past queries, best match first.
+// SearchMethod is "semantic" when embeddings were compared and "fulltext" otherwise.
+type SimilarQueryResponse struct {
+	Results      []SimilarQuery `json:"results"`
+	SearchMethod string         `json:"searchMethod"`
+}
@@ -33,4 +33,46 @@ type SpreadsheetDownloadResponse struct {
 	TableName string                   `json:"table_name"`
 	Columns   []string                 `json:"columns"`
 	Rows      []map[string]interface{} `json:"rows"`
-}
\ No newline at end of file
+}
+
+// UpdateColumnTypeRequest represents a request to change the type of a spreadsheet column
+type UpdateColumnTypeRequest struct {
+	NewType string `json:"newType" binding:"required"` // INTEGER, DECIMAL(p,s), TIMESTAMP, DATE or BOOLEAN
+}
+
+// ColumnTypeUpdateResponse represents the outcome of a column type change
+type ColumnTypeUpdateResponse struct {
+	TableName    string                   `json:"table_name"`
+	ColumnName   string                   `json:"column_name"`
+	PreviousType string                   `json:"previous_type"`
+	NewType      string                   `json:"new_type"`
+	FailedRows   []map[string]interface{} `json:"failed_rows,omitempty"` // Rows whose values could not be cast
+}
+
+// GoogleSheetsSyncRequest represents a request to pull the latest data of a Google Sheets connection
+type GoogleSheetsSyncRequest struct {
+	StreamID string `json:"stream_id"` // Optional SSE stream to receive google_sheets_sync_progress events on
+}
+
+// GoogleSheetsSyncProgress is streamed after each table of the sheet is synced
+type GoogleSheetsSyncProgress struct {
+	TableName    string `json:"table_name"`
+	TablesSynced int    `json:"tables_synced"`
+	TablesTotal  int    `json:"tables_total"`
+	Error        string `json:"error,omitempty"`
+}
+
+// GoogleSheetsSyncTableResult is the outcome of syncing a single table
+type GoogleSheetsSyncTableResult struct {
+	TableName     string `json:"table_name"`
+	PreviousRows  int    `json:"previous_rows"`
+	RowCount      int    `json:"row_count"`
+	SheetRowCount int    `json:"sheet_row_count"`
+	Error         string `json:"error,omitempty"`
+}
+
+// GoogleSheetsSyncResponse represents the outcome of a Google Sheets sync
+type GoogleSheetsSyncResponse struct {
+	Tables       []GoogleSheetsSyncTableResult `json:"tables"`
+	LastSyncedAt time.Time                     `json:"last_synced_at"`
+}
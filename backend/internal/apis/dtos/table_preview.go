@@ -0,0 +1,42 @@
+package dtos
+
+// TablePreviewResponse holds sample rows of a table, fetched without the LLM
+type TablePreviewResponse struct {
+	TableName       string                   `json:"table_name"`
+	Columns         []string                 `json:"columns"`
+	Rows            []map[string]interface{} `json:"rows"`
+	Limit           int                      `json:"limit"`
+	ExecutionTimeMs int64                    `json:"execution_time_ms"`
+}
+
+// ColumnStatistics describes the distribution of a column's values, computed by template queries without the LLM.
+// Min and max are set for numeric and timestamp columns, avg, stddev and percentiles for numeric columns,
+// top values for text columns and monthly counts for timestamp columns.
+type ColumnStatistics struct {
+	TableName     string      `json:"tableName"`
+	ColumnName    string      `json:"columnName"`
+	Type          string      `json:"type"`     // numeric, text or timestamp
+	DataType      string      `json:"dataType"` // The column's type as the database reports it
+	TotalCount    int64       `json:"totalCount"`
+	NullCount     int64       `json:"nullCount"`
+	DistinctCount int64       `json:"distinctCount"`
+	Min           interface{} `json:"min,omitempty"`
+	Max           interface{} `json:"max,omitempty"`
+	Avg           *float64    `json:"avg,omitempty"`
+	StdDev        *float64    `json:"stddev,omitempty"`
+	// Percentiles holds p25, p50, p75 and p95, for databases with a percentile function
+	Percentiles     map[string]interface{} `json:"percentiles,omitempty"`
+	TopValues       []ColumnValueCount     `json:"topValues,omitempty"`
+	MonthlyCounts   []ColumnMonthCount     `json:"monthlyCounts,omitempty"` // Oldest month first
+	ExecutionTimeMs int64                  `json:"executionTimeMs"`
+}
+
+type ColumnValueCount struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
+
+type ColumnMonthCount struct {
+	Month string `json:"month"` // YYYY-MM
+	Count int64  `json:"count"`
+}
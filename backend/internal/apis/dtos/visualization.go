@@ -49,7 +49,9 @@ type ChartRenderConfig struct {
 	XAxis    AxisConfig     `json:"x_axis"`
 	YAxis    *AxisConfig    `json:"y_axis,omitempty"` // Optional for pie charts
 	Series   []SeriesConfig `json:"series,omitempty"`
-	Pie      *PieConfig     `json:"pie,omitempty"` // For pie charts
+	Pie      *PieConfig     `json:"pie,omitempty"`     // For pie charts
+	Heatmap  *HeatmapConfig `json:"heatmap,omitempty"` // For heatmaps
+	Funnel   *FunnelConfig  `json:"funnel,omitempty"`  // For funnel charts
 	Colors   []string       `json:"colors"`
 	Features ChartFeatures  `json:"features"`
 }
@@ -79,6 +81,21 @@ type PieConfig struct {
 	InnerRadius int    `json:"inner_radius,omitempty"` // For donut chart
 }
 
+// HeatmapConfig defines heatmap cells, one per x/y pair
+type HeatmapConfig struct {
+	XKey     string   `json:"x_key"`
+	YKey     string   `json:"y_key"`
+	ValueKey string   `json:"value_key"`
+	Colors   []string `json:"colors,omitempty"`
+}
+
+// FunnelConfig defines funnel stages, one per row in order
+type FunnelConfig struct {
+	StageKey string   `json:"stage_key"`
+	ValueKey string   `json:"value_key"`
+	Colors   []string `json:"colors,omitempty"`
+}
+
 // ChartFeatures toggles chart interactive features
 type ChartFeatures struct {
 	Tooltip     bool `json:"tooltip"`
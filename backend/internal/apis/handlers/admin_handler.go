@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler handles admin-only operational endpoints
+type AdminHandler struct {
+	adminService services.AdminService
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminService services.AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// GetPoolStats returns database connection pool stats (admin only)
+// GET /api/admin/pool-stats
+func (h *AdminHandler) GetPoolStats(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	resp, statusCode, err := h.adminService.GetPoolStats(userID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// GetFeedback returns AI response feedback with average ratings by model and database type (admin only)
+// GET /api/admin/feedback?page=1&page_size=20&low_rated=true
+func (h *AdminHandler) GetFeedback(c *gin.Context) {
+	userID := c.GetString("userID")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	lowRatedOnly := c.Query("low_rated") == "true"
+
+	resp, statusCode, err := h.adminService.GetFeedback(c.Request.Context(), userID, lowRatedOnly, page, pageSize)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// GetLLMCircuitBreakers returns the circuit breaker state of every LLM provider (admin only)
+// GET /api/admin/llm-circuit-breakers
+func (h *AdminHandler) GetLLMCircuitBreakers(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	resp, statusCode, err := h.adminService.GetLLMCircuitBreakers(userID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// ResetLLMCircuitBreaker closes the circuit of an LLM provider (admin only)
+// POST /api/admin/llm-circuit-breakers/:provider/reset
+func (h *AdminHandler) ResetLLMCircuitBreaker(c *gin.Context) {
+	userID := c.GetString("userID")
+	provider := c.Param("provider")
+
+	resp, statusCode, err := h.adminService.ResetLLMCircuitBreaker(userID, provider)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
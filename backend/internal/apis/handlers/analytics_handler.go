@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler handles chat usage analytics endpoints
+type AnalyticsHandler struct {
+	analyticsService services.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsService services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetGlobalAnalytics returns usage analytics across all chats (admin only)
+// GET /api/analytics/chats?from=&to=&groupBy=day|week
+func (h *AnalyticsHandler) GetGlobalAnalytics(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req dtos.AnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorMsg := err.Error()
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	resp, statusCode, err := h.analyticsService.GetGlobalAnalytics(c, userID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// GetChatAnalytics returns usage analytics for a single chat
+// GET /api/chats/:id/analytics?from=&to=&groupBy=day|week
+func (h *AnalyticsHandler) GetChatAnalytics(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.AnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorMsg := err.Error()
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	resp, statusCode, err := h.analyticsService.GetChatAnalytics(c, userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/middleware"
+	"neobase-ai/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles the API keys users query NeoBase with from scripts
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// @Summary Create an API key
+// @Description Issue a long-lived API key for programmatic access. The key is only returned once. API keys cannot create API keys.
+// @Accept json
+// @Produce json
+// @Param body body dtos.CreateAPIKeyRequest true "API key name"
+// @Success 201 {object} dtos.Response{data=dtos.CreateAPIKeyResponse}
+// @Router /api/users/me/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID := c.GetString("userID")
+	tokenType := c.GetString("tokenType")
+
+	var req dtos.CreateAPIKeyRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), userID, tokenType, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary List API keys
+// @Description List the API keys issued to the user, without the keys themselves
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]dtos.APIKeyResponse}
+// @Router /api/users/me/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	response, statusCode, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), userID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Revoke an API key
+// @Description Revoke an API key, it is rejected by every endpoint from then on
+// @Produce json
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} dtos.Response
+// @Router /api/users/me/api-keys/{keyId} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID := c.GetString("userID")
+	keyID := c.Param("keyId")
+
+	statusCode, err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), userID, keyID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    "API key revoked",
+	})
+}
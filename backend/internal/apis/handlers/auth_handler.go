@@ -3,6 +3,7 @@ package handlers
 import (
 	"log"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/middleware"
 	"neobase-ai/internal/services"
 	"net/http"
 	"strings"
@@ -32,12 +33,7 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 
 func (h *AuthHandler) Signup(c *gin.Context) {
 	var req dtos.SignupRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -68,12 +64,7 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 // @Success 200 {object} dtos.Response
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dtos.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -102,12 +93,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 func (h *AuthHandler) GenerateUserSignupSecret(c *gin.Context) {
 	var req dtos.UserSignupSecretRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -172,12 +158,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 func (h *AuthHandler) Logout(c *gin.Context) {
 	var req dtos.LogoutRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -241,12 +222,7 @@ func (h *AuthHandler) GetUser(c *gin.Context) {
 // @Success 200 {object} dtos.Response
 func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	var req dtos.ForgotPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -274,12 +250,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 // @Success 200 {object} dtos.Response
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req dtos.ResetPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -306,12 +277,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 // @Success 200 {object} dtos.ValidateSignupSecretResponse
 func (h *AuthHandler) ValidateSignupSecret(c *gin.Context) {
 	var req dtos.ValidateSignupSecretRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -337,12 +303,7 @@ func (h *AuthHandler) ValidateSignupSecret(c *gin.Context) {
 // @Success 200 {object} dtos.AuthResponse
 func (h *AuthHandler) GoogleOAuthCallback(c *gin.Context) {
 	var req dtos.GoogleOAuthRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -369,3 +330,35 @@ func (h *AuthHandler) GoogleOAuthCallback(c *gin.Context) {
 
 	c.JSON(http.StatusOK, authResponse)
 }
+
+// @Summary Update User Query Limits
+// @Description Override a user's query result row cap (admin only)
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param updateUserQueryLimitsRequest body dtos.UpdateUserQueryLimitsRequest true "Query limits"
+// @Success 200 {object} dtos.Response
+func (h *AuthHandler) UpdateUserQueryLimits(c *gin.Context) {
+	userID := c.GetString("userID")
+	username := c.Param("username")
+
+	var req dtos.UpdateUserQueryLimitsRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.authService.UpdateUserQueryLimits(userID, username, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
@@ -3,12 +3,18 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/middleware"
 	"neobase-ai/internal/services"
 	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,12 +44,7 @@ func NewChatHandler(chatService services.ChatService) *ChatHandler {
 
 func (h *ChatHandler) Create(c *gin.Context) {
 	var req dtos.CreateChatRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -181,12 +182,7 @@ func (h *ChatHandler) GetByID(c *gin.Context) {
 
 func (h *ChatHandler) Update(c *gin.Context) {
 	var req dtos.UpdateChatRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -242,6 +238,7 @@ func (h *ChatHandler) Delete(c *gin.Context) {
 // @Param id path string true "Chat ID"
 // @Param duplicate_messages query bool false "Duplicate messages" default(false)
 // @Param duplicate_dashboards query bool false "Duplicate dashboards" default(false)
+// @Param sourceChatId query string false "Chat of the user whose connection the duplicate is pointed at"
 
 func (h *ChatHandler) Duplicate(c *gin.Context) {
 	userID := c.GetString("userID")
@@ -249,7 +246,166 @@ func (h *ChatHandler) Duplicate(c *gin.Context) {
 	duplicateMessages := c.Query("duplicate_messages") == "true"
 	duplicateDashboards := c.Query("duplicate_dashboards") == "true"
 
-	response, statusCode, err := h.chatService.Duplicate(userID, chatID, duplicateMessages, duplicateDashboards)
+	// Optionally re-point the duplicate at the connection of another chat (environment promotion)
+	var newConnectionConfig *dbmanager.ConnectionConfig
+	if sourceChatID := c.Query("sourceChatId"); sourceChatID != "" {
+		config, statusCode, err := h.chatService.GetSourceChatConnectionConfig(userID, sourceChatID)
+		if err != nil {
+			errorMsg := err.Error()
+			c.JSON(int(statusCode), dtos.Response{
+				Success: false,
+				Error:   &errorMsg,
+			})
+			return
+		}
+		newConnectionConfig = config
+	}
+
+	response, statusCode, err := h.chatService.Duplicate(userID, chatID, duplicateMessages, duplicateDashboards, newConnectionConfig)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Generate a chat title
+// @Description Regenerate the chat title with the LLM from the chat's first exchange, replacing the current title
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=dtos.ChatResponse}
+// @Router /api/chats/{id}/generate-title [post]
+func (h *ChatHandler) GenerateTitle(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	response, statusCode, err := h.chatService.GenerateTitle(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Export a chat
+// @Description Export a chat with its connection config, messages and schema snapshot to import it into another NeoBase instance
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param includeCredentials query bool false "Include decrypted connection credentials" default(false)
+// @Success 200 {object} dtos.Response{data=dtos.ChatExport}
+// @Router /api/chats/{id}/export [get]
+func (h *ChatHandler) ExportChat(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	includeCredentials := c.Query("includeCredentials") == "true"
+
+	response, statusCode, err := h.chatService.ExportChat(c.Request.Context(), userID, chatID, includeCredentials)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Import a chat
+// @Description Recreate a chat exported from another NeoBase instance, re-encrypting its credentials with this instance's key
+// @Accept json
+// @Produce json
+// @Param body body dtos.ChatExport true "Chat export"
+// @Success 201 {object} dtos.Response{data=dtos.ChatImportResponse}
+// @Router /api/chats/import [post]
+func (h *ChatHandler) ImportChat(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxChatImportSizeBytes)
+
+	var export dtos.ChatExport
+	if !middleware.BindAndValidate(c, &export) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.ImportChat(c.Request.Context(), userID, &export)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary List chat templates
+// @Description List the pre-configured chat templates with their example messages
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]models.ChatTemplate}
+// @Router /api/chat-templates [get]
+func (h *ChatHandler) ListChatTemplates(c *gin.Context) {
+	response, statusCode, err := h.chatService.ListChatTemplates()
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Create a chat from a template
+// @Description Create a chat from a template, using the demo database when it backs the template, and populate it with the template's example messages
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Chat template ID"
+// @Param body body dtos.CreateChatFromTemplateRequest false "Connection and settings"
+// @Success 201 {object} dtos.Response{data=dtos.ChatFromTemplateResponse}
+// @Router /api/chats/from-template/{templateId} [post]
+func (h *ChatHandler) CreateChatFromTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	templateID := c.Param("templateId")
+
+	var req dtos.CreateChatFromTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if !middleware.BindAndValidate(c, &req) {
+			return
+		}
+	}
+
+	response, statusCode, err := h.chatService.CreateChatFromTemplate(userID, templateID, &req)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -270,14 +426,16 @@ func (h *ChatHandler) Duplicate(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Chat ID"
+// @Param threadId query string false "Only list the messages of this thread"
 
 func (h *ChatHandler) ListMessages(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+	threadID := c.Query("threadId")
 
-	response, statusCode, err := h.chatService.ListMessages(userID, chatID, page, pageSize)
+	response, statusCode, err := h.chatService.ListMessages(userID, chatID, page, pageSize, threadID)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -301,19 +459,47 @@ func (h *ChatHandler) ListMessages(c *gin.Context) {
 
 func (h *ChatHandler) CreateMessage(c *gin.Context) {
 	var req dtos.CreateMessageRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	response, statusCode, err := h.chatService.CreateMessage(c.Request.Context(), userID, chatID, req.StreamID, req.Content, req.LLMModel)
+	if err != nil {
 		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
+		c.JSON(int(statusCode), dtos.Response{
 			Success: false,
 			Error:   &errorMsg,
 		})
 		return
 	}
 
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Create a threaded reply
+// @Description Ask a follow-up question about a prior AI message, answered in the thread of that message
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param messageId path string true "AI message ID"
+
+func (h *ChatHandler) CreateThreadMessage(c *gin.Context) {
+	var req dtos.CreateMessageRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
+	messageID := c.Param("messageId")
 
-	response, statusCode, err := h.chatService.CreateMessage(c.Request.Context(), userID, chatID, req.StreamID, req.Content, req.LLMModel)
+	response, statusCode, err := h.chatService.CreateThreadMessage(c.Request.Context(), userID, chatID, messageID, req.StreamID, req.Content, req.LLMModel)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -337,20 +523,50 @@ func (h *ChatHandler) CreateMessage(c *gin.Context) {
 
 func (h *ChatHandler) UpdateMessage(c *gin.Context) {
 	var req dtos.CreateMessageRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	messageID := c.Param("messageId")
+
+	response, statusCode, err := h.chatService.UpdateMessage(c.Request.Context(), userID, chatID, messageID, req.StreamID, &req)
+	if err != nil {
 		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
+		c.JSON(int(statusCode), dtos.Response{
 			Success: false,
 			Error:   &errorMsg,
 		})
 		return
 	}
 
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Regenerate an AI response
+// @Description Ask the LLM again for a user message, replacing the AI response to it with a new one
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param messageId path string true "User message ID"
+// @Param body body dtos.RegenerateMessageRequest true "Stream to send the new response on"
+// @Success 200 {object} dtos.Response{data=dtos.MessageResponse}
+// @Router /api/chats/{id}/messages/{messageId}/regenerate [post]
+func (h *ChatHandler) RegenerateMessage(c *gin.Context) {
+	var req dtos.RegenerateMessageRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 	messageID := c.Param("messageId")
 
-	response, statusCode, err := h.chatService.UpdateMessage(c.Request.Context(), userID, chatID, messageID, req.StreamID, &req)
+	response, statusCode, err := h.chatService.RegenerateMessage(c.Request.Context(), userID, chatID, messageID, req.StreamID)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -367,16 +583,49 @@ func (h *ChatHandler) UpdateMessage(c *gin.Context) {
 }
 
 // @Summary Delete messages
-// @Description Delete messages
+// @Description Delete all messages of a chat, or only those matching the filters. Filtered deletions of more than 100 messages run in the background and return 202 with a job ID.
 // @Accept json
 // @Produce json
 // @Param id path string true "Chat ID"
-
+// @Param before query string false "Only messages created before this time, RFC3339 or YYYY-MM-DD"
+// @Param type query string false "Message type, user or assistant"
+// @Param hasErrors query bool false "Only messages with (true) or without (false) failed queries"
+// @Success 200 {object} dtos.Response{data=dtos.DeleteMessagesResponse}
+// @Success 202 {object} dtos.Response{data=dtos.DeleteMessagesResponse}
+// @Router /api/chats/{id}/messages [delete]
 func (h *ChatHandler) DeleteMessages(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 
-	statusCode, err := h.chatService.DeleteMessages(userID, chatID)
+	var req dtos.DeleteMessagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorMsg := err.Error()
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	if !req.IsEmpty() {
+		response, statusCode, err := h.chatService.DeleteMessagesByFilter(userID, chatID, &req)
+		if err != nil {
+			errorMsg := err.Error()
+			c.JSON(int(statusCode), dtos.Response{
+				Success: false,
+				Error:   &errorMsg,
+			})
+			return
+		}
+
+		c.JSON(int(statusCode), dtos.Response{
+			Success: true,
+			Data:    response,
+		})
+		return
+	}
+
+	statusCode, err := h.chatService.DeleteAllMessages(userID, chatID)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -474,73 +723,250 @@ func (h *ChatHandler) ListPinnedMessages(c *gin.Context) {
 	})
 }
 
-// @Summary Handle stream event
-// @Description Handle stream event
+// @Summary List query history
+// @Description List the queries generated in a chat without message content, filtered by date, query type and execution status
 // @Accept json
 // @Produce json
-// @Param userID path string true "User ID"
-// @Param chatID path string true "Chat ID"
-
-// HandleStreamEvent implements the StreamHandler interface
-func (h *ChatHandler) HandleStreamEvent(userID, chatID, streamID string, response dtos.StreamResponse) {
-	streamKey := fmt.Sprintf("%s:%s:%s", userID, chatID, streamID)
-
-	h.streamMutex.RLock()
-	streamChan, exists := h.streams[streamKey]
-	h.streamMutex.RUnlock()
+// @Param id path string true "Chat ID"
+// @Param from query string false "Start of the range, RFC3339 or YYYY-MM-DD"
+// @Param to query string false "End of the range, RFC3339 or YYYY-MM-DD"
+// @Param queryType query string false "Query type, e.g. SELECT"
+// @Param executed query bool false "Execution status"
+// @Param page query int false "Page number"
+// @Param pageSize query int false "Page size"
+// @Success 200 {object} dtos.Response{data=dtos.QueryHistoryResponse}
+// @Router /api/chats/{id}/query-history [get]
+func (h *ChatHandler) GetQueryHistory(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
 
-	if !exists {
-		log.Printf("No stream found for key: %s", streamKey)
+	var req dtos.QueryHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorMsg := err.Error()
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
 		return
 	}
 
-	// Try to send with timeout
-	select {
-	case streamChan <- response:
-		log.Printf("Successfully sent event to stream: %s, event: %s", streamKey, response.Event)
-	case <-time.After(100 * time.Millisecond):
-		log.Printf("Timeout sending event to stream: %s", streamKey)
+	response, statusCode, err := h.chatService.GetQueryHistory(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
 	}
-}
 
-// HasStream checks if an SSE stream exists for the given user, chat, and stream ID
-func (h *ChatHandler) HasStream(userID, chatID, streamID string) bool {
-	streamKey := fmt.Sprintf("%s:%s:%s", userID, chatID, streamID)
-	h.streamMutex.RLock()
-	defer h.streamMutex.RUnlock()
-	_, exists := h.streams[streamKey]
-	return exists
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
 }
 
-// @Summary Stream chat
-// @Description Stream chat
-// @Accept json
+// @Summary Get rollback history
+// @Description List the chat's rollback attempts with their outcome, most recent first. Two-step rollbacks include the dependent query run, linked to the rollback
 // @Produce json
 // @Param id path string true "Chat ID"
-
-// StreamChat handles SSE endpoint
-func (h *ChatHandler) StreamChat(c *gin.Context) {
+// @Param page query int false "Page number"
+// @Param pageSize query int false "Page size, 20 by default"
+// @Success 200 {object} dtos.Response{data=dtos.RollbackHistoryResponse}
+// @Router /api/chats/{id}/rollback-history [get]
+func (h *ChatHandler) GetRollbackHistory(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
-	streamID := c.Query("stream_id")
 
-	if streamID == "" {
+	var req dtos.RollbackHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorMsg := err.Error()
 		c.JSON(http.StatusBadRequest, dtos.Response{
 			Success: false,
-			Error:   utils.ToStringPtr("stream_id is required"),
+			Error:   &errorMsg,
 		})
 		return
 	}
 
-	streamKey := fmt.Sprintf("%s:%s:%s", userID, chatID, streamID)
-	log.Printf("Starting stream for key: %s", streamKey)
-
-	// Create buffered channel
-	h.streamMutex.Lock()
-	streamChan := make(chan dtos.StreamResponse, 100)
-	h.streams[streamKey] = streamChan
-	h.streamMutex.Unlock()
-
+	response, statusCode, err := h.chatService.GetRollbackHistory(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Find similar past queries
+// @Description Find the queries generated for past questions of the chat that are similar to a new question. Uses embeddings when configured, otherwise a full-text search
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.SimilarQueryRequest true "Question to compare"
+// @Success 200 {object} dtos.Response{data=dtos.SimilarQueryResponse}
+// @Router /api/chats/{id}/similar-queries [post]
+func (h *ChatHandler) FindSimilarQueries(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.SimilarQueryRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.FindSimilarQueries(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Explain a query in plain English
+// @Description Describe what a query does in plain prose, step by step, for readers who cannot read SQL. The query is not executed
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.ExplainQueryRequest true "Query, or ID of a query template, to explain"
+// @Success 200 {object} dtos.Response{data=dtos.QueryExplanationResponse}
+// @Router /api/chats/{id}/explain-query [post]
+func (h *ChatHandler) ExplainQuery(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.ExplainQueryRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.ExplainQueryInPlainEnglish(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Get a query's dependency graph
+// @Description List the tables a message's query touches with their row counts, and the joins between them with their conditions. The query is parsed, not executed, and foreign keys of the cached schema fill in joins the query does not spell out
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param messageId path string true "Message ID"
+// @Param queryId path string true "Query ID"
+// @Success 200 {object} dtos.Response{data=dtos.DependencyGraph}
+// @Router /api/chats/{id}/messages/{messageId}/queries/{queryId}/dependency-graph [post]
+func (h *ChatHandler) GetQueryDependencyGraph(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	messageID := c.Param("messageId")
+	queryID := c.Param("queryId")
+
+	graph, statusCode, err := h.chatService.GetQueryDependencyGraph(c.Request.Context(), userID, chatID, messageID, queryID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    graph,
+	})
+}
+
+// @Summary Handle stream event
+// @Description Handle stream event
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID"
+// @Param chatID path string true "Chat ID"
+
+// HandleStreamEvent implements the StreamHandler interface
+func (h *ChatHandler) HandleStreamEvent(userID, chatID, streamID string, response dtos.StreamResponse) {
+	streamKey := fmt.Sprintf("%s:%s:%s", userID, chatID, streamID)
+
+	h.streamMutex.RLock()
+	streamChan, exists := h.streams[streamKey]
+	h.streamMutex.RUnlock()
+
+	if !exists {
+		log.Printf("No stream found for key: %s", streamKey)
+		return
+	}
+
+	// Try to send with timeout
+	select {
+	case streamChan <- response:
+		log.Printf("Successfully sent event to stream: %s, event: %s", streamKey, response.Event)
+	case <-time.After(100 * time.Millisecond):
+		log.Printf("Timeout sending event to stream: %s", streamKey)
+	}
+}
+
+// HasStream checks if an SSE stream exists for the given user, chat, and stream ID
+func (h *ChatHandler) HasStream(userID, chatID, streamID string) bool {
+	streamKey := fmt.Sprintf("%s:%s:%s", userID, chatID, streamID)
+	h.streamMutex.RLock()
+	defer h.streamMutex.RUnlock()
+	_, exists := h.streams[streamKey]
+	return exists
+}
+
+// @Summary Stream chat
+// @Description Stream chat
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+
+// StreamChat handles SSE endpoint
+func (h *ChatHandler) StreamChat(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	streamID := c.Query("stream_id")
+
+	if streamID == "" {
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   utils.ToStringPtr("stream_id is required"),
+		})
+		return
+	}
+
+	streamKey := fmt.Sprintf("%s:%s:%s", userID, chatID, streamID)
+	log.Printf("Starting stream for key: %s", streamKey)
+
+	// Create buffered channel
+	h.streamMutex.Lock()
+	streamChan := make(chan dtos.StreamResponse, 100)
+	h.streams[streamKey] = streamChan
+	h.streamMutex.Unlock()
+
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
@@ -602,6 +1028,72 @@ func (h *ChatHandler) StreamChat(c *gin.Context) {
 	}
 }
 
+// @Summary Stream chat events
+// @Description Stream events shared by everyone watching the chat, such as new messages
+// @Produce text/event-stream
+// @Param id path string true "Chat ID"
+// @Router /api/chats/{id}/events [get]
+
+// ChatEvents handles the shared chat room SSE endpoint
+func (h *ChatHandler) ChatEvents(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	// Subscription ends when the client disconnects
+	ctx := c.Request.Context()
+	sub, statusCode, err := h.chatService.SubscribeChatEvents(ctx, userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("Transfer-Encoding", "chunked")
+
+	heartbeatTicker := time.NewTicker(30 * time.Second)
+	defer heartbeatTicker.Stop()
+
+	data, _ := json.Marshal(dtos.StreamResponse{
+		Event: "connected",
+		Data:  "Chat events stream established",
+	})
+	c.Writer.Write([]byte(fmt.Sprintf("data: %s\n\n", data)))
+	c.Writer.Flush()
+
+	messages := sub.Messages()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Client disconnected from chat events -> chatID: %s, userID: %s", chatID, userID)
+			return
+
+		case <-heartbeatTicker.C:
+			data, _ := json.Marshal(dtos.StreamResponse{
+				Event: "heartbeat",
+				Data:  "ping",
+			})
+			c.Writer.Write([]byte(fmt.Sprintf("data: %s\n\n", data)))
+			c.Writer.Flush()
+
+		case payload, ok := <-messages:
+			if !ok {
+				log.Printf("Chat events subscription closed for chatID: %s", chatID)
+				return
+			}
+			// Payloads are already JSON-encoded dtos.ChatEvent values
+			c.Writer.Write([]byte(fmt.Sprintf("data: %s\n\n", payload)))
+			c.Writer.Flush()
+		}
+	}
+}
+
 // @Summary Cancel stream
 // @Description Cancel currently streaming response
 // @Accept json
@@ -655,11 +1147,7 @@ func (h *ChatHandler) ConnectDB(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   utils.ToStringPtr(fmt.Sprintf("Invalid request: %v", err)),
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -689,11 +1177,7 @@ func (h *ChatHandler) DisconnectDB(c *gin.Context) {
 	var req dtos.DisconnectDBRequest
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   utils.ToStringPtr(fmt.Sprintf("Invalid request: %v", err)),
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -738,6 +1222,63 @@ func (h *ChatHandler) GetDBConnectionStatus(c *gin.Context) {
 	})
 }
 
+// @Summary Check DB Health
+// @Description Run a test query on the database and check the user can read its tables
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+
+// CheckDBHealth returns the health of the chat's connection
+func (h *ChatHandler) CheckDBHealth(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	result, statusCode, err := h.chatService.CheckDBHealth(userID, chatID)
+	if err != nil {
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   utils.ToStringPtr(err.Error()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// @Summary Diagnose a connection
+// @Description Test a connection step by step (TCP, TLS, authentication, database, permission) and explain how to fix the step that fails
+// @Accept json
+// @Produce json
+// @Param body body dtos.CreateConnectionRequest true "Connection to diagnose"
+// @Success 200 {object} dtos.Response{data=dtos.ConnectionDiagnostic}
+// @Router /api/connections/diagnose [post]
+func (h *ChatHandler) DiagnoseConnection(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req dtos.CreateConnectionRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	diagnostic, statusCode, err := h.chatService.DiagnoseConnection(userID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    diagnostic,
+	})
+}
+
 // @Summary Refresh Schema
 // @Description Refresh the schema of a database
 // @Accept json
@@ -775,19 +1316,21 @@ func (h *ChatHandler) ExecuteQuery(c *gin.Context) {
 	chatID := c.Param("id")
 
 	var req dtos.ExecuteQueryRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   utils.ToStringPtr(err.Error()),
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
 	// Execute query
 	response, status, err := h.chatService.ExecuteQuery(c.Request.Context(), userID, chatID, &req)
 	if err != nil {
+		// A query blocked by the cost budget still comes with its estimated cost
+		var data interface{}
+		if response != nil {
+			data = response
+		}
 		c.JSON(int(status), dtos.Response{
 			Success: false,
+			Data:    data,
 			Error:   utils.ToStringPtr(err.Error()),
 		})
 		return
@@ -810,11 +1353,7 @@ func (h *ChatHandler) RollbackQuery(c *gin.Context) {
 	chatID := c.Param("id")
 
 	var req dtos.RollbackQueryRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   utils.ToStringPtr(err.Error()),
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -844,8 +1383,7 @@ func (h *ChatHandler) CancelQueryExecution(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 	var req dtos.CancelQueryExecutionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -958,17 +1496,13 @@ func (h *ChatHandler) GetQueryResults(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 	var req dtos.QueryResultsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   utils.ToStringPtr(err.Error()),
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
 	// Support both cursor and offset for backward compatibility
 	// Cursor takes precedence if both are provided
-	response, status, err := h.chatService.GetQueryResults(c.Request.Context(), userID, chatID, req.MessageID, req.QueryID, req.StreamID, req.Offset, req.Cursor)
+	response, status, err := h.chatService.GetQueryResults(c.Request.Context(), userID, chatID, req.MessageID, req.QueryID, req.StreamID, req.Offset, req.Cursor, req.LastKey)
 	if err != nil {
 		c.JSON(int(status), dtos.Response{
 			Success: false,
@@ -993,11 +1527,7 @@ func (h *ChatHandler) EditQuery(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 	var req dtos.EditQueryRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   utils.ToStringPtr(err.Error()),
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -1042,30 +1572,86 @@ func (h *ChatHandler) GetTables(c *gin.Context) {
 	})
 }
 
-// @Summary Get query recommendations
-// @Description Get 3 AI-generated query recommendations based on database schema and context
+// @Summary Compare query plans
+// @Description EXPLAIN two versions of a read-only query and compare cost, plan nodes, index usage and joins (PostgreSQL and MySQL)
+// @Accept json
 // @Produce json
 // @Param id path string true "Chat ID"
-// @Query stream_id query string true "Stream ID"
-// @Success 200 {object} dtos.Response{data=dtos.QueryRecommendationsResponse}
-// @Router /api/chats/{id}/recommendations [get]
-func (h *ChatHandler) GetQueryRecommendations(c *gin.Context) {
+// @Param body body dtos.ComparePlansRequest true "Queries to compare"
+// @Success 200 {object} dtos.Response{data=dtos.ComparePlansResponse}
+// @Router /api/chats/{id}/compare-plans [post]
+func (h *ChatHandler) ComparePlans(c *gin.Context) {
+	userID := c.GetString("userID")
 	chatID := c.Param("id")
+
+	var req dtos.ComparePlansRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.ComparePlans(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Create a secure note
+// @Description Store encrypted context about the connection that is added to every LLM call of the chat
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.CreateSecureNoteRequest true "Note content"
+// @Success 201 {object} dtos.Response{data=dtos.SecureNoteResponse}
+// @Router /api/chats/{id}/secure-notes [post]
+func (h *ChatHandler) CreateSecureNote(c *gin.Context) {
 	userID := c.GetString("userID")
-	streamID := c.Query("stream_id")
+	chatID := c.Param("id")
 
-	if streamID == "" {
-		c.JSON(http.StatusBadRequest, dtos.Response{
+	var req dtos.CreateSecureNoteRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.CreateSecureNote(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
 			Success: false,
-			Error:   utils.ToStringPtr("stream_id is required"),
+			Error:   &errorMsg,
 		})
 		return
 	}
 
-	recommendations, status, err := h.chatService.GetQueryRecommendations(c.Request.Context(), userID, chatID, streamID)
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary List secure notes
+// @Description Get the decrypted secure notes of a chat, only available to the chat owner
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=[]dtos.SecureNoteResponse}
+// @Router /api/chats/{id}/secure-notes [get]
+func (h *ChatHandler) ListSecureNotes(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	response, statusCode, err := h.chatService.ListSecureNotes(c.Request.Context(), userID, chatID)
 	if err != nil {
 		errorMsg := err.Error()
-		c.JSON(int(status), dtos.Response{
+		c.JSON(int(statusCode), dtos.Response{
 			Success: false,
 			Error:   &errorMsg,
 		})
@@ -1074,22 +1660,54 @@ func (h *ChatHandler) GetQueryRecommendations(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dtos.Response{
 		Success: true,
-		Data:    recommendations,
+		Data:    response,
 	})
 }
 
-// GetImportMetadata gets import metadata for a chat
-// @Summary Get import metadata
-// @Description Get import metadata for a Google Sheets or spreadsheet connection
+// @Summary Create a query template
+// @Description Save a read-only query with :name placeholders, e.g. WHERE status = :status, whose values are filled in when it is run
 // @Accept json
 // @Produce json
 // @Param id path string true "Chat ID"
-// @Success 200 {object} dtos.Response
-func (h *ChatHandler) GetImportMetadata(c *gin.Context) {
+// @Param body body dtos.CreateQueryTemplateRequest true "Template and its parameters"
+// @Success 201 {object} dtos.Response{data=dtos.QueryTemplateResponse}
+// @Router /api/chats/{id}/query-templates [post]
+func (h *ChatHandler) CreateQueryTemplate(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 
-	metadata, statusCode, err := h.chatService.GetImportMetadata(c.Request.Context(), userID, chatID)
+	var req dtos.CreateQueryTemplateRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.CreateQueryTemplate(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary List query templates
+// @Description Get the query templates of a chat
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=[]dtos.QueryTemplateResponse}
+// @Router /api/chats/{id}/query-templates [get]
+func (h *ChatHandler) ListQueryTemplates(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	response, statusCode, err := h.chatService.ListQueryTemplates(c.Request.Context(), userID, chatID)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -1101,26 +1719,92 @@ func (h *ChatHandler) GetImportMetadata(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dtos.Response{
 		Success: true,
-		Data:    metadata,
+		Data:    response,
 	})
 }
 
-// GetChatService returns the chat service instance
-func (h *ChatHandler) GetChatService() services.ChatService {
-	return h.chatService
+// @Summary Run a query template
+// @Description Fill in a template's parameters and run it. Values are checked against the parameter types and bound by the database driver, never concatenated into the query
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param templateId path string true "Template ID"
+// @Param body body dtos.ExecuteQueryTemplateRequest true "Parameter values"
+// @Success 200 {object} dtos.Response{data=dtos.QueryTemplateExecutionResponse}
+// @Router /api/chats/{id}/query-templates/{templateId}/execute [post]
+func (h *ChatHandler) ExecuteQueryTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	templateID := c.Param("templateId")
+
+	var req dtos.ExecuteQueryTemplateRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.ExecuteQueryTemplate(c.Request.Context(), userID, chatID, templateID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
 }
 
-// @Summary Get knowledge base for a chat
-// @Description Retrieve table/field descriptions for the knowledge base
+// @Summary Upload a Prisma schema
+// @Description Add a schema.prisma as a schema source. Its relation names and documentation are merged into the schema the LLM receives, a new upload replaces the previous one
+// @Accept multipart/form-data
 // @Produce json
 // @Param id path string true "Chat ID"
-// @Success 200 {object} dtos.Response{data=dtos.KnowledgeBaseResponse}
-// @Router /api/chats/{id}/knowledge-base [get]
-func (h *ChatHandler) GetKnowledgeBase(c *gin.Context) {
+// @Param file formData file true "schema.prisma"
+// @Success 200 {object} dtos.Response{data=dtos.ExternalSchemaSourceResponse}
+// @Router /api/chats/{id}/schema/upload-prisma [post]
+func (h *ChatHandler) UploadPrismaSchema(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 
-	kb, statusCode, err := h.chatService.GetKnowledgeBase(c.Request.Context(), userID, chatID)
+	// The limit leaves room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.PrismaSchemaMaxBytes+64<<10)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		errorMsg := "A schema.prisma file is required in the \"file\" field"
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+	defer file.Close()
+
+	if ext := strings.ToLower(filepath.Ext(header.Filename)); ext != ".prisma" {
+		errorMsg := "Invalid file type. Only .prisma files are allowed"
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	// One byte past the limit is read so an oversized file is reported instead of truncated
+	content, err := io.ReadAll(io.LimitReader(file, constants.PrismaSchemaMaxBytes+1))
+	if err != nil || len(content) > constants.PrismaSchemaMaxBytes {
+		errorMsg := fmt.Sprintf("The Prisma schema must be at most %d bytes", constants.PrismaSchemaMaxBytes)
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	response, statusCode, err := h.chatService.UploadPrismaSchema(c.Request.Context(), userID, chatID, filepath.Base(header.Filename), content)
 	if err != nil {
 		errorMsg := err.Error()
 		c.JSON(int(statusCode), dtos.Response{
@@ -1132,37 +1816,1094 @@ func (h *ChatHandler) GetKnowledgeBase(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dtos.Response{
 		Success: true,
-		Data: dtos.KnowledgeBaseResponse{
-			ChatID:            kb.ChatID.Hex(),
-			TableDescriptions: kb.TableDescriptions,
-			CreatedAt:         kb.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:         kb.UpdatedAt.Format(time.RFC3339),
-		},
+		Data:    response,
 	})
 }
 
-// @Summary Update knowledge base for a chat
-// @Description Save or update table/field descriptions and trigger vectorization
+// @Summary Enrich the schema from an OpenAPI spec
+// @Description Add an OpenAPI 3 or Swagger 2.0 spec (swagger.json, openapi.yaml) as a schema source. Its component schemas are matched to tables by name, and their descriptions, examples and enum values are merged into the schema the LLM receives. A new upload replaces the previous one
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param file formData file true "OpenAPI spec in JSON or YAML"
+// @Success 200 {object} dtos.Response{data=dtos.ExternalSchemaSourceResponse}
+// @Router /api/chats/{id}/schema/enrich-from-openapi [post]
+func (h *ChatHandler) UploadOpenAPISpec(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	// The limit leaves room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.OpenAPISpecMaxBytes+64<<10)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		errorMsg := "An OpenAPI spec file is required in the \"file\" field"
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".json", ".yaml", ".yml":
+	default:
+		errorMsg := "Invalid file type. Only .json, .yaml and .yml files are allowed"
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	// One byte past the limit is read so an oversized file is reported instead of truncated
+	content, err := io.ReadAll(io.LimitReader(file, constants.OpenAPISpecMaxBytes+1))
+	if err != nil || len(content) > constants.OpenAPISpecMaxBytes {
+		errorMsg := fmt.Sprintf("The OpenAPI spec must be at most %d bytes", constants.OpenAPISpecMaxBytes)
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	response, statusCode, err := h.chatService.UploadOpenAPISpec(c.Request.Context(), userID, chatID, filepath.Base(header.Filename), content)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Watch a query
+// @Description Re-run a read-only query every intervalSeconds and send watch_result stream events with the result and a diff from the previous run
 // @Accept json
 // @Produce json
 // @Param id path string true "Chat ID"
-// @Param body body dtos.UpdateKnowledgeBaseRequest true "Knowledge base data"
-// @Success 200 {object} dtos.Response{data=dtos.KnowledgeBaseResponse}
-// @Router /api/chats/{id}/knowledge-base [put]
-func (h *ChatHandler) UpdateKnowledgeBase(c *gin.Context) {
+// @Param body body dtos.StartQueryWatchRequest true "Query to watch"
+// @Success 200 {object} dtos.Response{data=dtos.QueryWatchResponse}
+// @Router /api/chats/{id}/watch [post]
+func (h *ChatHandler) StartQueryWatch(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("id")
 
-	var req dtos.UpdateKnowledgeBaseRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var req dtos.StartQueryWatchRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.StartQueryWatch(userID, chatID, &req)
+	if err != nil {
 		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Stop watching a query
+// @Description Stop a running query watch
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param watchId path string true "Watch ID"
+// @Success 200 {object} dtos.Response
+// @Router /api/chats/{id}/watch/{watchId} [delete]
+func (h *ChatHandler) StopQueryWatch(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	watchID := c.Param("watchId")
+
+	statusCode, err := h.chatService.StopQueryWatch(userID, chatID, watchID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
 			Success: false,
 			Error:   &errorMsg,
 		})
 		return
 	}
 
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    "Watch stopped",
+	})
+}
+
+// @Summary Start a CDC watcher
+// @Description Check the maximum of a timestamp column of tables on an interval and post to a webhook when it increases. At most 5 watchers per user.
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.StartCDCWatchRequest true "Tables, check interval and webhook URL"
+// @Success 200 {object} dtos.Response{data=dtos.CDCWatchResponse}
+// @Router /api/chats/{id}/cdc/watch [post]
+func (h *ChatHandler) StartCDCWatch(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.StartCDCWatchRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.StartCDCWatch(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Stop a CDC watcher
+// @Description Stop a running CDC watcher
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param watchId path string true "Watch ID"
+// @Success 200 {object} dtos.Response
+// @Router /api/chats/{id}/cdc/watch/{watchId} [delete]
+func (h *ChatHandler) StopCDCWatch(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	watchID := c.Param("watchId")
+
+	statusCode, err := h.chatService.StopCDCWatch(c.Request.Context(), userID, chatID, watchID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    "CDC watcher stopped",
+	})
+}
+
+// @Summary Preview table data
+// @Description Get sample rows of a table directly from the database, without an LLM round-trip
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param tableName path string true "Table or collection name"
+// @Param limit query int false "Number of rows (default 20, max 100)"
+// @Success 200 {object} dtos.Response{data=dtos.TablePreviewResponse}
+// @Router /api/chats/{id}/tables/{tableName}/preview [get]
+func (h *ChatHandler) GetTablePreview(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	tableName := c.Param("tableName")
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, dtos.Response{
+				Success: false,
+				Error:   utils.ToStringPtr("limit must be a positive number"),
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	response, statusCode, err := h.chatService.GetTablePreview(c.Request.Context(), userID, chatID, tableName, limit)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Count matching rows
+// @Description Count the rows of a table matching a WHERE clause, for live feedback while it is typed. The clause may only use the table's columns, literals, comparison operators, AND, OR, NOT, IN, BETWEEN, LIKE and IS NULL
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.LiveCountRequest true "Table and WHERE clause"
+// @Success 200 {object} dtos.Response{data=dtos.LiveCountResponse}
+// @Router /api/chats/{id}/live-count [post]
+func (h *ChatHandler) LiveCount(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.LiveCountRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.LiveCount(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Get column statistics
+// @Description Get the distribution of a column's values, computed by template queries without the LLM: min, max, avg, stddev and percentiles for numeric columns, most frequent values for text columns and counts per month for timestamp columns
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param tableName path string true "Table or collection name"
+// @Param columnName path string true "Column or field name"
+// @Success 200 {object} dtos.Response{data=dtos.ColumnStatistics}
+// @Router /api/chats/{id}/tables/{tableName}/columns/{columnName}/stats [get]
+func (h *ChatHandler) GetColumnStatistics(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	tableName := c.Param("tableName")
+	columnName := c.Param("columnName")
+
+	response, statusCode, err := h.chatService.GetColumnStatistics(c.Request.Context(), userID, chatID, tableName, columnName)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Get query recommendations
+// @Description Get 3 AI-generated query recommendations based on database schema and context
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Query stream_id query string true "Stream ID"
+// @Query timezone query string false "IANA time zone of the user, e.g. Europe/Berlin, defaults to UTC"
+// @Success 200 {object} dtos.Response{data=dtos.QueryRecommendationsResponse}
+// @Router /api/chats/{id}/recommendations [get]
+func (h *ChatHandler) GetQueryRecommendations(c *gin.Context) {
+	chatID := c.Param("id")
+	userID := c.GetString("userID")
+	streamID := c.Query("stream_id")
+
+	if streamID == "" {
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   utils.ToStringPtr("stream_id is required"),
+		})
+		return
+	}
+
+	// The time of day used for recommendations is taken in the user's time zone, not the server's
+	location := time.UTC
+	if timezone := c.Query("timezone"); timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dtos.Response{
+				Success: false,
+				Error:   utils.ToStringPtr(fmt.Sprintf("invalid timezone: %s", timezone)),
+			})
+			return
+		}
+		location = loaded
+	}
+
+	recommendations, status, err := h.chatService.GetQueryRecommendations(c.Request.Context(), userID, chatID, streamID, location)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(status), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    recommendations,
+	})
+}
+
+// GetImportMetadata gets import metadata for a chat
+// @Summary Get import metadata
+// @Description Get import metadata for a Google Sheets or spreadsheet connection
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response
+func (h *ChatHandler) GetImportMetadata(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	metadata, statusCode, err := h.chatService.GetImportMetadata(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    metadata,
+	})
+}
+
+// GetImportStatus gets the progress of the latest spreadsheet import for a chat
+// @Summary Get import status
+// @Description Get the progress of the latest spreadsheet import, including background imports started for large uploads
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=dtos.ImportStatus}
+// @Router /api/chats/{id}/import/status [get]
+func (h *ChatHandler) GetImportStatus(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	status, statusCode, err := h.chatService.GetImportStatus(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    status,
+	})
+}
+
+// @Summary Update spreadsheet column type
+// @Description Change the type of a spreadsheet column, re-casting existing data in place
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param tableName path string true "Table name"
+// @Param columnName path string true "Column name"
+// @Param body body dtos.UpdateColumnTypeRequest true "New column type"
+// @Success 200 {object} dtos.Response{data=dtos.ColumnTypeUpdateResponse}
+// @Router /api/chats/{id}/spreadsheet/tables/{tableName}/columns/{columnName}/type [put]
+func (h *ChatHandler) UpdateSpreadsheetColumnType(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	tableName := c.Param("tableName")
+	columnName := c.Param("columnName")
+
+	var req dtos.UpdateColumnTypeRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.UpdateSpreadsheetColumnType(c.Request.Context(), userID, chatID, tableName, columnName, req.NewType)
+	if err != nil {
+		errorMsg := err.Error()
+		// Include the partial response so callers can see which rows failed to cast
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Data:    response,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Sync Google Sheet
+// @Description Pull the latest rows of a Google Sheets connection and merge them into the stored tables without re-importing
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.GoogleSheetsSyncRequest false "Optional stream to receive sync progress on"
+// @Success 200 {object} dtos.Response{data=dtos.GoogleSheetsSyncResponse}
+// @Router /api/chats/{id}/google-sheets/sync [post]
+func (h *ChatHandler) SyncGoogleSheet(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.GoogleSheetsSyncRequest
+	if c.Request.ContentLength > 0 {
+		if !middleware.BindAndValidate(c, &req) {
+			return
+		}
+	}
+
+	response, statusCode, err := h.chatService.SyncGoogleSheet(c.Request.Context(), userID, chatID, req.StreamID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Update hidden tables
+// @Description Replace the tables left out of the schema the AI sees, e.g. tables with PII. Hidden tables can still be queried directly.
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.UpdateHiddenTablesRequest true "Tables to hide, an empty list shows every table"
+// @Success 200 {object} dtos.Response{data=dtos.ChatResponse}
+// @Router /api/chats/{id}/settings/hidden-tables [put]
+func (h *ChatHandler) UpdateHiddenTables(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.UpdateHiddenTablesRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.UpdateHiddenTables(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Update system prompt rules
+// @Description Replace the rules appended to the chat's system prompt, at most 2000 characters. Injection phrases are removed and the rules are stored encrypted.
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.UpdateSystemPromptRequest true "Rules to append, an empty value removes them"
+// @Success 200 {object} dtos.Response{data=dtos.ChatResponse}
+// @Router /api/chats/{id}/settings/system-prompt [put]
+func (h *ChatHandler) UpdateSystemPrompt(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.UpdateSystemPromptRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.UpdateSystemPrompt(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Update schema aliases
+// @Description Replace the business names the AI sees instead of the real table and column names. Queries the AI writes with them run against the real names.
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.SchemaAliases true "Aliases by real table name and by real table.column, empty maps remove every alias"
+// @Success 200 {object} dtos.Response{data=dtos.ChatResponse}
+// @Router /api/chats/{id}/schema/aliases [post]
+func (h *ChatHandler) UpdateSchemaAliases(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.SchemaAliases
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.UpdateSchemaAliases(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Re-resolve Vault credentials
+// @Description Read the username and password of the chat's connection from its Vault secret path again, e.g. after a credential rotation
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=dtos.ChatResponse}
+// @Router /api/chats/{id}/connection/re-resolve-vault [put]
+func (h *ChatHandler) ReResolveVaultCredentials(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	response, statusCode, err := h.chatService.ReResolveVaultCredentials(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary List PlanetScale branches
+// @Description List the branches of the PlanetScale database of a chat, using the service token stored on the connection
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=dtos.PlanetscaleBranchesResponse}
+// @Router /api/chats/{id}/planetscale/branches [get]
+func (h *ChatHandler) ListPlanetscaleBranches(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	response, statusCode, err := h.chatService.ListPlanetscaleBranches(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Generate data quality report
+// @Description Run read-only checks for NULLs, duplicates, outliers and distinct values on the given tables
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.DataQualityRequest true "Tables to analyze"
+// @Success 200 {object} dtos.Response{data=dtos.DataQualityReport}
+// @Router /api/chats/{id}/data-quality [post]
+func (h *ChatHandler) GenerateDataQualityReport(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.DataQualityRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	report, statusCode, err := h.chatService.GenerateDataQualityReport(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// @Summary Generate a report
+// @Description Answer each section's question with a query, then write a Markdown report from the results. The report is generated in the background, streaming report_section events as sections complete and report_completed at the end.
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.GenerateReportRequest true "Report title and sections"
+// @Success 202 {object} dtos.Response{data=dtos.ReportResponse}
+// @Router /api/chats/{id}/generate-report [post]
+func (h *ChatHandler) GenerateReport(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.GenerateReportRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	report, statusCode, err := h.chatService.GenerateReport(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// @Summary List reports
+// @Description List the chat's generated reports, newest first, without their Markdown
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=[]dtos.ReportResponse}
+// @Router /api/chats/{id}/reports [get]
+func (h *ChatHandler) ListReports(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	reports, statusCode, err := h.chatService.ListReports(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    reports,
+	})
+}
+
+// @Summary Get a report
+// @Description Get a generated report with its Markdown
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param reportId path string true "Report ID"
+// @Success 200 {object} dtos.Response{data=dtos.ReportResponse}
+// @Router /api/chats/{id}/reports/{reportId} [get]
+func (h *ChatHandler) GetReport(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	reportID := c.Param("reportId")
+
+	report, statusCode, err := h.chatService.GetReport(c.Request.Context(), userID, chatID, reportID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// @Summary Download a report as PDF
+// @Description Render a completed report as a PDF file
+// @Produce application/pdf
+// @Param id path string true "Chat ID"
+// @Param reportId path string true "Report ID"
+// @Success 200 {file} file
+// @Router /api/chats/{id}/reports/{reportId}/pdf [get]
+func (h *ChatHandler) GetReportPDF(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	reportID := c.Param("reportId")
+
+	pdf, statusCode, err := h.chatService.GetReportPDF(c.Request.Context(), userID, chatID, reportID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", pdf.FileName))
+	c.Data(http.StatusOK, "application/pdf", pdf.Content)
+}
+
+// @Summary Submit feedback on an AI response
+// @Description Rate an AI response from 1 to 5 with the kind of issue, responses rated 2 or less are flagged for prompt analysis
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param messageId path string true "Message ID"
+// @Param body body dtos.MessageFeedbackRequest true "Feedback"
+// @Success 200 {object} dtos.Response{data=dtos.MessageFeedbackResponse}
+// @Router /api/chats/{id}/messages/{messageId}/feedback [post]
+func (h *ChatHandler) SubmitMessageFeedback(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	messageID := c.Param("messageId")
+
+	var req dtos.MessageFeedbackRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.SubmitMessageFeedback(c.Request.Context(), userID, chatID, messageID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary React to a message
+// @Description Add the user's emoji reaction (👍, 👎 or 🔥) to a message, reacting twice with the same emoji is a no-op
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param messageId path string true "Message ID"
+// @Param body body dtos.MessageReactionRequest true "Reaction"
+// @Success 200 {object} dtos.Response{data=dtos.MessageReactionResponse}
+// @Router /api/chats/{id}/messages/{messageId}/reactions [post]
+func (h *ChatHandler) AddMessageReaction(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	messageID := c.Param("messageId")
+
+	var req dtos.MessageReactionRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.AddMessageReaction(c.Request.Context(), userID, chatID, messageID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Remove a reaction from a message
+// @Description Remove the user's emoji reaction from a message
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param messageId path string true "Message ID"
+// @Param emoji path string true "Emoji (URL encoded)"
+// @Success 200 {object} dtos.Response{data=dtos.MessageReactionResponse}
+// @Router /api/chats/{id}/messages/{messageId}/reactions/{emoji} [delete]
+func (h *ChatHandler) RemoveMessageReaction(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	messageID := c.Param("messageId")
+	emoji := c.Param("emoji")
+
+	response, statusCode, err := h.chatService.RemoveMessageReaction(c.Request.Context(), userID, chatID, messageID, emoji)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Generate migration scripts
+// @Description Generate forward, rollback and verification scripts for a schema or data change, saved to the chat as DDL_MIGRATION queries
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.DataMigrationRequest true "Migration description"
+// @Success 200 {object} dtos.Response{data=dtos.DataMigrationResponse}
+// @Router /api/chats/{id}/migration [post]
+func (h *ChatHandler) GenerateDataMigration(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.DataMigrationRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.GenerateDataMigration(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Generate mock data
+// @Description Fill tables with realistic fake data generated from the schema, inserted in one transaction where supported and saved to the chat as critical INSERT queries with their rollbacks. Only example databases are allowed unless force is true.
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param force query bool false "Insert into a database that isn't an example database"
+// @Param body body dtos.MockDataRequest true "Tables to fill and rows per table"
+// @Success 200 {object} dtos.Response{data=dtos.MockDataResponse}
+// @Router /api/chats/{id}/mock-data [post]
+func (h *ChatHandler) GenerateMockData(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.MockDataRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	force := c.Query("force") == "true"
+	response, statusCode, err := h.chatService.GenerateMockData(c.Request.Context(), userID, chatID, &req, force)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Run a magic query
+// @Description Answer a one-off question about a database given by its connection URI, without creating a chat. Credentials are never stored.
+// @Accept json
+// @Produce json
+// @Param body body dtos.MagicQueryRequest true "Database type, connection URI, question, model and whether to execute the queries"
+// @Success 200 {object} dtos.Response{data=dtos.MagicQueryResponse}
+// @Router /api/magic-query [post]
+func (h *ChatHandler) MagicQuery(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req dtos.MagicQueryRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.MagicQuery(c.Request.Context(), userID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Run an LLM model benchmark
+// @Description Ask every model every test case question with the chat's schema and check the generated queries against the expected patterns (admin only)
+// @Accept json
+// @Produce json
+// @Param body body dtos.BenchmarkRequest true "Models, chat ID and test cases"
+// @Success 200 {object} dtos.Response{data=dtos.BenchmarkResult}
+// @Router /api/admin/benchmark [post]
+func (h *ChatHandler) RunLLMBenchmark(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req dtos.BenchmarkRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.RunLLMBenchmark(c.Request.Context(), userID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// @Summary Execute federated query
+// @Description Run one read-only query on the primary and one on the secondary database, then hash join the results
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.FederatedExecuteRequest true "Queries and join keys"
+// @Success 200 {object} dtos.Response{data=dtos.FederatedExecuteResponse}
+// @Router /api/chats/{id}/federated-execute [post]
+func (h *ChatHandler) ExecuteFederatedQuery(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.FederatedExecuteRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
+	response, statusCode, err := h.chatService.ExecuteFederatedQuery(c.Request.Context(), userID, chatID, &req)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetChatService returns the chat service instance
+func (h *ChatHandler) GetChatService() services.ChatService {
+	return h.chatService
+}
+
+// @Summary Get knowledge base for a chat
+// @Description Retrieve table/field descriptions for the knowledge base
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Success 200 {object} dtos.Response{data=dtos.KnowledgeBaseResponse}
+// @Router /api/chats/{id}/knowledge-base [get]
+func (h *ChatHandler) GetKnowledgeBase(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	kb, statusCode, err := h.chatService.GetKnowledgeBase(c.Request.Context(), userID, chatID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.Response{
+		Success: true,
+		Data: dtos.KnowledgeBaseResponse{
+			ChatID:            kb.ChatID.Hex(),
+			TableDescriptions: kb.TableDescriptions,
+			CreatedAt:         kb.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:         kb.UpdatedAt.Format(time.RFC3339),
+		},
+	})
+}
+
+// @Summary Update knowledge base for a chat
+// @Description Save or update table/field descriptions and trigger vectorization
+// @Accept json
+// @Produce json
+// @Param id path string true "Chat ID"
+// @Param body body dtos.UpdateKnowledgeBaseRequest true "Knowledge base data"
+// @Success 200 {object} dtos.Response{data=dtos.KnowledgeBaseResponse}
+// @Router /api/chats/{id}/knowledge-base [put]
+func (h *ChatHandler) UpdateKnowledgeBase(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+
+	var req dtos.UpdateKnowledgeBaseRequest
+	if !middleware.BindAndValidate(c, &req) {
+		return
+	}
+
 	kb, statusCode, err := h.chatService.UpdateKnowledgeBase(c.Request.Context(), userID, chatID, req.TableDescriptions)
 	if err != nil {
 		errorMsg := err.Error()
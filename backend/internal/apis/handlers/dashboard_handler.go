@@ -5,6 +5,7 @@ import (
 	"log"
 	"neobase-ai/internal/apis/dtos"
 	"neobase-ai/internal/constants"
+	"neobase-ai/internal/middleware"
 	"neobase-ai/internal/services"
 	"net/http"
 
@@ -32,12 +33,7 @@ func (h *DashboardHandler) CreateDashboard(c *gin.Context) {
 	chatID := c.Param("id")
 
 	var req dtos.CreateDashboardRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -112,12 +108,7 @@ func (h *DashboardHandler) UpdateDashboard(c *gin.Context) {
 	dashboardID := c.Param("dashboardId")
 
 	var req dtos.UpdateDashboardRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -168,12 +159,7 @@ func (h *DashboardHandler) AddWidget(c *gin.Context) {
 	dashboardID := c.Param("dashboardId")
 
 	var req dtos.AddWidgetRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -202,12 +188,7 @@ func (h *DashboardHandler) EditWidget(c *gin.Context) {
 	widgetID := c.Param("widgetId")
 
 	var req dtos.EditWidgetRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -298,12 +279,7 @@ func (h *DashboardHandler) CreateFromBlueprints(c *gin.Context) {
 	streamID := c.Query("stream_id")
 
 	var req dtos.CreateFromBlueprintsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -341,12 +317,7 @@ func (h *DashboardHandler) RegenerateDashboard(c *gin.Context) {
 	streamID := c.Query("stream_id")
 
 	var req dtos.RegenerateDashboardRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -481,12 +452,7 @@ func (h *DashboardHandler) ValidateImport(c *gin.Context) {
 	chatID := c.Param("id")
 
 	var req dtos.ValidateImportRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -552,12 +518,7 @@ func (h *DashboardHandler) ImportDashboard(c *gin.Context) {
 	chatID := c.Param("id")
 
 	var req dtos.ImportDashboardRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GDPRHandler handles personal data erasure and export requests
+type GDPRHandler struct {
+	gdprService services.GDPRService
+}
+
+// NewGDPRHandler creates a new GDPR handler
+func NewGDPRHandler(gdprService services.GDPRService) *GDPRHandler {
+	return &GDPRHandler{
+		gdprService: gdprService,
+	}
+}
+
+// DeleteUserData erases a user's data and returns a report of what was deleted (admin only)
+// DELETE /api/admin/users/:userId/data
+func (h *GDPRHandler) DeleteUserData(c *gin.Context) {
+	adminUserID := c.GetString("userID")
+	userID := c.Param("userId")
+
+	report, statusCode, err := h.gdprService.DeleteUserData(c.Request.Context(), adminUserID, userID)
+	if err != nil {
+		errorMsg := err.Error()
+		// A partial erasure still reports what was deleted
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Data:    report,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// ExportUserData downloads all of the user's data as a JSON archive
+// POST /api/users/me/export-data
+func (h *GDPRHandler) ExportUserData(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	export, statusCode, err := h.gdprService.ExportUserData(c.Request.Context(), userID)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=neobase-data-%s.json", export.ExportedAt.Format("2006-01-02")))
+	c.JSON(int(statusCode), export)
+}
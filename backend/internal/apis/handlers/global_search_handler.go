@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GlobalSearchHandler handles the search across all chats of a user
+type GlobalSearchHandler struct {
+	globalSearchService services.GlobalSearchService
+}
+
+// NewGlobalSearchHandler creates a new global search handler
+func NewGlobalSearchHandler(globalSearchService services.GlobalSearchService) *GlobalSearchHandler {
+	return &GlobalSearchHandler{
+		globalSearchService: globalSearchService,
+	}
+}
+
+// Search returns the user's messages, or queries, matching a keyword across all their chats
+// GET /api/search?q=keyword&type=message|query&page=1&pageSize=20
+func (h *GlobalSearchHandler) Search(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req dtos.GlobalSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorMsg := err.Error()
+		c.JSON(http.StatusBadRequest, dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	resp, statusCode, err := h.globalSearchService.Search(c, userID, req.Q, req.Type, req.Page, req.PageSize)
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    resp,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"neobase-ai/config"
+	"neobase-ai/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/oauth2"
@@ -95,8 +96,7 @@ func (h *GoogleOAuthHandler) ValidateGoogleSheetAccess(c *gin.Context) {
 		SheetID      string `json:"sheet_id" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -165,8 +165,7 @@ func (h *GoogleOAuthHandler) RefreshGoogleToken(c *gin.Context) {
 		RefreshToken string `json:"refresh_token" binding:"required"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
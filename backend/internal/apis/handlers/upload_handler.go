@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"bufio"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -9,8 +11,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"neobase-ai/config"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
 	"neobase-ai/internal/services"
+	"neobase-ai/pkg/dbmanager"
 
 	"github.com/gin-gonic/gin"
 
@@ -27,7 +32,7 @@ func NewUploadHandler(chatService services.ChatService) *UploadHandler {
 	}
 }
 
-// UploadFile handles CSV/Excel file uploads
+// UploadFile handles CSV, Excel, JSON and JSON Lines file uploads
 func (h *UploadHandler) UploadFile(c *gin.Context) {
 	userID := c.GetString("userID")
 	chatID := c.Param("chatID")
@@ -37,8 +42,8 @@ func (h *UploadHandler) UploadFile(c *gin.Context) {
 		return
 	}
 
-	// Parse multipart form
-	err := c.Request.ParseMultipartForm(100 << 20) // 100 MB limit
+	// Parse multipart form; parts beyond the memory limit are buffered to disk in chunks
+	err := c.Request.ParseMultipartForm(constants.SpreadsheetUploadMaxMemory)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form"})
 		return
@@ -51,10 +56,12 @@ func (h *UploadHandler) UploadFile(c *gin.Context) {
 	}
 	defer file.Close()
 
-	// Validate file extension
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if ext != ".csv" && ext != ".xlsx" && ext != ".xls" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only CSV and Excel files are allowed"})
+	// Detect the file format from the extension, and for .json files from the first bytes
+	reader := bufio.NewReader(file)
+	head, _ := reader.Peek(512)
+	format, err := dbmanager.DetectSpreadsheetFileFormat(header.Filename, head)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -82,31 +89,63 @@ func (h *UploadHandler) UploadFile(c *gin.Context) {
 		UpdateExisting:   c.DefaultPostForm("updateExisting", "true") == "true",
 		InsertNew:        c.DefaultPostForm("insertNew", "true") == "true",
 		DeleteMissing:    c.DefaultPostForm("deleteMissing", "false") == "true",
+		FixedHeaders:     format == constants.SpreadsheetFormatJSON || format == constants.SpreadsheetFormatJSONLines, // JSON records name their columns
+	}
+
+	// Optional column type overrides as a JSON object, e.g. {"price": "DECIMAL(10,2)", "created_at": "TIMESTAMP"}
+	var columnTypes map[string]string
+	if rawColumnTypes := c.PostForm("columnTypes"); rawColumnTypes != "" {
+		if err := json.Unmarshal([]byte(rawColumnTypes), &columnTypes); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "columnTypes must be a JSON object of column names to types"})
+			return
+		}
 	}
 
+	// Optional SSE stream to receive import_progress events on
+	streamID := c.PostForm("stream_id")
+
 	log.Printf("UploadHandler -> Processing file: %s as table: %s", header.Filename, tableName)
 
 	// Process the file based on type and get raw data
 	var interfaceData [][]interface{}
 
-	if ext == ".csv" {
-		interfaceData, err = h.processCSVRaw(file)
+	switch format {
+	case constants.SpreadsheetFormatCSV:
+		interfaceData, err = h.processCSVRaw(reader)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read CSV: %v", err)})
 			return
 		}
-	} else {
-		interfaceData, err = h.processExcelRaw(file, header.Filename)
+	case constants.SpreadsheetFormatJSON, constants.SpreadsheetFormatJSONLines:
+		interfaceData, err = dbmanager.ParseJSONRecords(reader, format)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read JSON: %v", err)})
+			return
+		}
+	default:
+		interfaceData, err = h.processExcelRaw(reader, header.Filename)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to read Excel: %v", err)})
 			return
 		}
 	}
 	
+	// Large files are imported in the background; the client polls the import status with the returned job ID
+	if header.Size > int64(config.Env.SpreadsheetAsyncImportMB)<<20 {
+		job, statusCode, err := h.chatService.StartSpreadsheetImportJob(
+			userID, chatID, streamID, tableName, interfaceData, mergeStrategy, mergeOptions, columnTypes)
+		if err != nil {
+			c.JSON(int(statusCode), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
 	// Use unified processor (exactly like Google Sheets)
 	// This will handle all analysis, region detection, and storage
 	result, statusCode, err := h.chatService.ProcessAndStoreSpreadsheetUnified(
-		userID, chatID, tableName, interfaceData, mergeStrategy, mergeOptions)
+		userID, chatID, streamID, tableName, interfaceData, mergeStrategy, mergeOptions, columnTypes)
 	if err != nil {
 		c.JSON(int(statusCode), gin.H{"error": err.Error()})
 		return
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/middleware"
 	"neobase-ai/internal/services"
 	"net/http"
 
@@ -31,12 +32,7 @@ func (h *VisualizationHandler) GenerateVisualization(c *gin.Context) {
 
 	// Parse request
 	var req dtos.GenerateVisualizationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -90,12 +86,7 @@ func (h *VisualizationHandler) ExecuteChartQuery(c *gin.Context) {
 		Limit              int                      `json:"limit"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -141,12 +132,7 @@ func (h *VisualizationHandler) GetVisualizationData(c *gin.Context) {
 		Offset    int    `json:"offset"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -178,3 +164,50 @@ func (h *VisualizationHandler) GetVisualizationData(c *gin.Context) {
 		Data:    data,
 	})
 }
+
+// ListVisualizationTemplates lists the chart templates, optionally only those usable with a database type
+// GET /api/visualization-templates?dbType=postgresql
+func (h *VisualizationHandler) ListVisualizationTemplates(c *gin.Context) {
+	templates, statusCode, err := h.chatService.ListVisualizationTemplates(c.Query("dbType"))
+	if err != nil {
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    templates,
+	})
+}
+
+// ApplyVisualizationTemplate builds a query's chart from a template, without calling the LLM
+// POST /api/chats/:id/messages/:messageId/queries/:queryId/apply-template/:templateId
+func (h *VisualizationHandler) ApplyVisualizationTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	chatID := c.Param("id")
+	messageID := c.Param("messageId")
+	queryID := c.Param("queryId")
+	templateID := c.Param("templateId")
+
+	log.Printf("ApplyVisualizationTemplate -> userID: %s, chatID: %s, messageID: %s, queryID: %s, templateID: %s", userID, chatID, messageID, queryID, templateID)
+
+	visualization, statusCode, err := h.chatService.ApplyVisualizationTemplate(c, userID, chatID, messageID, queryID, templateID)
+	if err != nil {
+		log.Printf("ApplyVisualizationTemplate -> Error: %v", err)
+		errorMsg := err.Error()
+		c.JSON(int(statusCode), dtos.Response{
+			Success: false,
+			Error:   &errorMsg,
+		})
+		return
+	}
+
+	c.JSON(int(statusCode), dtos.Response{
+		Success: true,
+		Data:    visualization,
+	})
+}
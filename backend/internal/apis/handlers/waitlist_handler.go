@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/middleware"
 	"neobase-ai/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -26,12 +27,7 @@ type WaitlistRequest struct {
 
 func (h *WaitlistHandler) AddToWaitlist(c *gin.Context) {
 	var req WaitlistRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errorMsg := err.Error()
-		c.JSON(http.StatusBadRequest, dtos.Response{
-			Success: false,
-			Error:   &errorMsg,
-		})
+	if !middleware.BindAndValidate(c, &req) {
 		return
 	}
 
@@ -1,19 +1,24 @@
 package middlewares
 
 import (
+	"context"
 	"log"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
 	"neobase-ai/internal/di"
 	"neobase-ai/internal/repositories"
 	"neobase-ai/internal/utils"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var jwtService *utils.JWTService
 var tokenRepo repositories.TokenRepository
+var apiKeyRepo repositories.APIKeyRepository
 
 func AuthMiddleware() gin.HandlerFunc {
 	if jwtService == nil {
@@ -30,6 +35,13 @@ func AuthMiddleware() gin.HandlerFunc {
 			log.Fatalf("Failed to provide Token repository: %v", err)
 		}
 	}
+	if apiKeyRepo == nil {
+		if err := di.DiContainer.Invoke(func(repo repositories.APIKeyRepository) {
+			apiKeyRepo = repo
+		}); err != nil {
+			log.Fatalf("Failed to provide API key repository: %v", err)
+		}
+	}
 
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -67,7 +79,7 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := (*jwtService).ValidateToken(token)
+		claims, err := (*jwtService).ParseToken(token)
 		if err != nil {
 			errorMsg := "Invalid or expired token"
 			c.JSON(http.StatusUnauthorized, dtos.Response{
@@ -77,9 +89,38 @@ func AuthMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		log.Printf("User ID from Auth Middleware: %s", *claims)
 
-		c.Set("userID", *claims)
+		// API keys are revoked by ID rather than by blacklisting the token
+		if claims.Type == constants.TokenTypeAPIKey {
+			if claims.ID == "" || tokenRepo.IsAPIKeyRevoked(claims.ID) {
+				errorMsg := "API key has been revoked"
+				c.JSON(http.StatusUnauthorized, dtos.Response{
+					Success: false,
+					Error:   &errorMsg,
+				})
+				c.Abort()
+				return
+			}
+			go recordAPIKeyUsage(claims.ID)
+			c.Set("apiKeyID", claims.ID)
+		}
+		log.Printf("User ID from Auth Middleware: %s", claims.UserID)
+
+		c.Set("userID", claims.UserID)
+		c.Set("tokenType", claims.Type)
 		c.Next()
 	}
 }
+
+// recordAPIKeyUsage updates the last_used_at of an API key shown in the key listing
+func recordAPIKeyUsage(keyID string) {
+	keyObjID, err := primitive.ObjectIDFromHex(keyID)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := apiKeyRepo.UpdateLastUsed(ctx, keyObjID, time.Now(), constants.APIKeyLastUsedInterval); err != nil {
+		log.Printf("AuthMiddleware -> Failed to record API key usage: %v", err)
+	}
+}
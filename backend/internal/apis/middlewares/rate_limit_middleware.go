@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"fmt"
+	"neobase-ai/internal/apis/dtos"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// keyedRateLimiter is the token bucket of one user or IP address, with the time it was last used
+type keyedRateLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimitMiddleware limits each authenticated user to perMinute requests per minute, allowing short bursts.
+// Every call creates its own set of limiters, so routes that use separate middlewares are throttled separately.
+// It must run after AuthMiddleware, which sets the userID.
+func RateLimitMiddleware(perMinute, burst int, idleTimeout time.Duration) gin.HandlerFunc {
+	return keyedRateLimitMiddleware(perMinute, burst, idleTimeout, func(c *gin.Context) string {
+		return c.GetString("userID")
+	})
+}
+
+// IPRateLimitMiddleware limits each client IP address to perMinute requests per minute, allowing short bursts.
+// It can run before AuthMiddleware, so unauthenticated requests are throttled too.
+func IPRateLimitMiddleware(perMinute, burst int, idleTimeout time.Duration) gin.HandlerFunc {
+	return keyedRateLimitMiddleware(perMinute, burst, idleTimeout, func(c *gin.Context) string {
+		return c.ClientIP()
+	})
+}
+
+// keyedRateLimitMiddleware keeps one token bucket per key returned by keyOf
+func keyedRateLimitMiddleware(perMinute, burst int, idleTimeout time.Duration, keyOf func(c *gin.Context) string) gin.HandlerFunc {
+	var mu sync.Mutex
+	limiters := make(map[string]*keyedRateLimiter)
+	lastCleanup := time.Now()
+	every := rate.Every(time.Minute / time.Duration(perMinute))
+
+	return func(c *gin.Context) {
+		key := keyOf(c)
+		now := time.Now()
+
+		mu.Lock()
+		// Drop limiters that have been idle long enough to be back at a full bucket
+		if now.Sub(lastCleanup) > idleTimeout {
+			for id, entry := range limiters {
+				if now.Sub(entry.lastSeen) > idleTimeout {
+					delete(limiters, id)
+				}
+			}
+			lastCleanup = now
+		}
+		entry, ok := limiters[key]
+		if !ok {
+			entry = &keyedRateLimiter{limiter: rate.NewLimiter(every, burst)}
+			limiters[key] = entry
+		}
+		entry.lastSeen = now
+		allowed := entry.limiter.AllowN(now, 1)
+		mu.Unlock()
+
+		if !allowed {
+			errorMsg := fmt.Sprintf("Too many requests, at most %d per minute are allowed", perMinute)
+			c.JSON(http.StatusTooManyRequests, dtos.Response{
+				Success: false,
+				Error:   &errorMsg,
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
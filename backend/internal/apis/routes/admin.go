@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"log"
+	"neobase-ai/internal/apis/middlewares"
+	"neobase-ai/internal/di"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminRoutes(router *gin.Engine) {
+	adminHandler, err := di.GetAdminHandler()
+	if err != nil {
+		log.Fatalf("Failed to get admin handler: %v", err)
+	}
+
+	gdprHandler, err := di.GetGDPRHandler()
+	if err != nil {
+		log.Fatalf("Failed to get GDPR handler: %v", err)
+	}
+
+	chatHandler, err := di.GetChatHandler()
+	if err != nil {
+		log.Fatalf("Failed to get chat handler: %v", err)
+	}
+
+	// Admin-only operational endpoints, the admin check happens in the service
+	admin := router.Group("/api/admin")
+	admin.Use(middlewares.AuthMiddleware())
+	{
+		admin.GET("/pool-stats", adminHandler.GetPoolStats)
+		admin.GET("/feedback", adminHandler.GetFeedback)
+		admin.GET("/llm-circuit-breakers", adminHandler.GetLLMCircuitBreakers)
+		admin.POST("/llm-circuit-breakers/:provider/reset", adminHandler.ResetLLMCircuitBreaker)
+		admin.POST("/benchmark", chatHandler.RunLLMBenchmark)
+		admin.DELETE("/users/:userId/data", gdprHandler.DeleteUserData)
+	}
+}
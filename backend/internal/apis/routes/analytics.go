@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"log"
+	"neobase-ai/internal/apis/middlewares"
+	"neobase-ai/internal/di"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAnalyticsRoutes(router *gin.Engine) {
+	analyticsHandler, err := di.GetAnalyticsHandler()
+	if err != nil {
+		log.Fatalf("Failed to get analytics handler: %v", err)
+	}
+
+	// Admin analytics across all chats
+	analytics := router.Group("/api/analytics")
+	analytics.Use(middlewares.AuthMiddleware())
+	{
+		analytics.GET("/chats", analyticsHandler.GetGlobalAnalytics)
+	}
+
+	// Analytics scoped to a single chat
+	protected := router.Group("/api/chats")
+	protected.Use(middlewares.AuthMiddleware())
+	{
+		protected.GET("/:id/analytics", analyticsHandler.GetChatAnalytics)
+	}
+}
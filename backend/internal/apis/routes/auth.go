@@ -32,5 +32,6 @@ func SetupAuthRoutes(router *gin.Engine) {
 		protected.GET("/", authHandler.GetUser)
 		protected.POST("/logout", authHandler.Logout)
 		protected.GET("/refresh-token", authHandler.RefreshToken)
+		protected.PUT("/users/:username/query-limits", authHandler.UpdateUserQueryLimits)
 	}
 }
@@ -3,7 +3,9 @@ package routes
 import (
 	"log"
 	"neobase-ai/internal/apis/middlewares"
+	"neobase-ai/internal/constants"
 	"neobase-ai/internal/di"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,28 +26,59 @@ func SetupChatRoutes(router *gin.Engine) {
 		protected.PATCH("/:id", chatHandler.Update)
 		protected.DELETE("/:id", chatHandler.Delete)
 		protected.POST("/:id/duplicate", chatHandler.Duplicate) // Has query param "duplicate_messages"
+		protected.POST("/:id/generate-title", chatHandler.GenerateTitle)
+
+		// Move chats between NeoBase instances
+		protected.GET("/:id/export", chatHandler.ExportChat) // Has query param "includeCredentials"
+		protected.POST("/import", chatHandler.ImportChat)
+
+		// Chats pre-populated from a template
+		protected.POST("/from-template/:templateId", chatHandler.CreateChatFromTemplate)
 
 		// Messages within a chat
 		protected.GET("/:id/messages", chatHandler.ListMessages)
 		protected.POST("/:id/messages", chatHandler.CreateMessage)
 		protected.PATCH("/:id/messages/:messageId", chatHandler.UpdateMessage)
+		protected.POST("/:id/messages/:messageId/regenerate", chatHandler.RegenerateMessage)
 		protected.DELETE("/:id/messages", chatHandler.DeleteMessages)
+		protected.POST("/:id/messages/:messageId/thread", chatHandler.CreateThreadMessage)
 
 		// Message pinning
 		protected.POST("/:id/messages/:messageId/pin", chatHandler.PinMessage)
 		protected.DELETE("/:id/messages/:messageId/pin", chatHandler.UnpinMessage)
 		protected.GET("/:id/messages/pinned", chatHandler.ListPinnedMessages)
 
+		// Feedback on AI responses
+		protected.POST("/:id/messages/:messageId/feedback", chatHandler.SubmitMessageFeedback)
+
+		// Emoji reactions on messages
+		protected.POST("/:id/messages/:messageId/reactions", chatHandler.AddMessageReaction)
+		protected.DELETE("/:id/messages/:messageId/reactions/:emoji", chatHandler.RemoveMessageReaction)
+
 		// Database connection routes
 		protected.POST("/:id/connect", chatHandler.ConnectDB)
 		protected.POST("/:id/disconnect", chatHandler.DisconnectDB)
 		protected.GET("/:id/connection-status", chatHandler.GetDBConnectionStatus)
+		protected.GET("/:id/health", chatHandler.CheckDBHealth)
+		protected.PUT("/:id/connection/re-resolve-vault", chatHandler.ReResolveVaultCredentials)
 		protected.POST("/:id/refresh-schema", chatHandler.RefreshSchema)
+		protected.POST("/:id/schema/upload-prisma", chatHandler.UploadPrismaSchema)
+		protected.POST("/:id/schema/enrich-from-openapi", chatHandler.UploadOpenAPISpec)
+		protected.POST("/:id/schema/aliases", chatHandler.UpdateSchemaAliases)
 		protected.GET("/:id/tables", chatHandler.GetTables)
+		protected.PUT("/:id/settings/hidden-tables", chatHandler.UpdateHiddenTables)
+		protected.PUT("/:id/settings/system-prompt", chatHandler.UpdateSystemPrompt)
+		// Sample rows without an LLM round-trip, throttled on its own since it is cheap and called often
+		protected.GET("/:id/tables/:tableName/preview", middlewares.RateLimitMiddleware(constants.TablePreviewRateLimitPerMinute, constants.TablePreviewRateLimitBurst, constants.TablePreviewRateLimitIdleMinutes*time.Minute), chatHandler.GetTablePreview)
+		// Distribution of a column's values from template queries
+		protected.GET("/:id/tables/:tableName/columns/:columnName/stats", chatHandler.GetColumnStatistics)
+		// Rows matching a WHERE clause as the user types it, throttled on its own since it is called on keystrokes
+		protected.POST("/:id/live-count", middlewares.RateLimitMiddleware(constants.LiveCountRateLimitPerMinute, constants.LiveCountRateLimitBurst, constants.LiveCountRateLimitIdleMinutes*time.Minute), chatHandler.LiveCount)
 
 		// SSE endpoints for streaming
 		protected.GET("/:id/stream", chatHandler.StreamChat)
 		protected.POST("/:id/stream/cancel", chatHandler.CancelStream)
+		protected.GET("/:id/events", chatHandler.ChatEvents)
 
 		// Query execution routes
 		protected.POST("/:id/queries/execute", chatHandler.ExecuteQuery)
@@ -53,15 +86,80 @@ func SetupChatRoutes(router *gin.Engine) {
 		protected.POST("/:id/queries/cancel", chatHandler.CancelQueryExecution)
 		protected.POST("/:id/queries/results", chatHandler.GetQueryResults)
 		protected.PATCH("/:id/queries/edit", chatHandler.EditQuery)
+		protected.POST("/:id/federated-execute", chatHandler.ExecuteFederatedQuery)
+		protected.POST("/:id/compare-plans", chatHandler.ComparePlans)
+		protected.GET("/:id/query-history", chatHandler.GetQueryHistory)
+		protected.GET("/:id/rollback-history", chatHandler.GetRollbackHistory)
+		protected.POST("/:id/similar-queries", chatHandler.FindSimilarQueries)
+		protected.POST("/:id/explain-query", chatHandler.ExplainQuery)
+		// Tables a query touches and the joins between them, parsed from the query without running it
+		protected.POST("/:id/messages/:messageId/queries/:queryId/dependency-graph", chatHandler.GetQueryDependencyGraph)
+
+		// Re-run a query on an interval and stream the results with a diff
+		protected.POST("/:id/watch", chatHandler.StartQueryWatch)
+		protected.DELETE("/:id/watch/:watchId", chatHandler.StopQueryWatch)
+		protected.POST("/:id/cdc/watch", chatHandler.StartCDCWatch)
+		protected.DELETE("/:id/cdc/watch/:watchId", chatHandler.StopCDCWatch)
 
 		// Query recommendations
 		protected.GET("/:id/recommendations", chatHandler.GetQueryRecommendations)
 
+		// Data quality report
+		protected.POST("/:id/data-quality", chatHandler.GenerateDataQualityReport)
+		protected.POST("/:id/migration", chatHandler.GenerateDataMigration)
+
+		// Generated test data, only for example databases unless forced
+		protected.POST("/:id/mock-data", chatHandler.GenerateMockData)
+
+		// Reports answering a list of questions, downloadable as Markdown or PDF
+		protected.POST("/:id/generate-report", chatHandler.GenerateReport)
+		protected.GET("/:id/reports", chatHandler.ListReports)
+		protected.GET("/:id/reports/:reportId", chatHandler.GetReport)
+		protected.GET("/:id/reports/:reportId/pdf", chatHandler.GetReportPDF)
+
 		// Import metadata for spreadsheets and Google Sheets
 		protected.GET("/:id/import-metadata", chatHandler.GetImportMetadata)
+		protected.GET("/:id/import/status", chatHandler.GetImportStatus)
+
+		// Spreadsheet column type overrides
+		protected.PUT("/:id/spreadsheet/tables/:tableName/columns/:columnName/type", chatHandler.UpdateSpreadsheetColumnType)
+
+		// Google Sheets sync without re-importing
+		protected.POST("/:id/google-sheets/sync", chatHandler.SyncGoogleSheet)
+
+		// PlanetScale branches of the connected database
+		protected.GET("/:id/planetscale/branches", chatHandler.ListPlanetscaleBranches)
+
+		// Secure notes, encrypted context sent with every LLM call
+		protected.POST("/:id/secure-notes", chatHandler.CreateSecureNote)
+		protected.GET("/:id/secure-notes", chatHandler.ListSecureNotes)
+
+		// Query templates, parameterized queries filled in when they are run
+		protected.POST("/:id/query-templates", chatHandler.CreateQueryTemplate)
+		protected.GET("/:id/query-templates", chatHandler.ListQueryTemplates)
+		protected.POST("/:id/query-templates/:templateId/execute", chatHandler.ExecuteQueryTemplate)
 
 		// Knowledge Base
 		protected.GET("/:id/knowledge-base", chatHandler.GetKnowledgeBase)
 		protected.PUT("/:id/knowledge-base", chatHandler.UpdateKnowledgeBase)
 	}
+
+	templates := router.Group("/api/chat-templates")
+	templates.Use(middlewares.AuthMiddleware())
+	{
+		templates.GET("", chatHandler.ListChatTemplates)
+	}
+
+	connections := router.Group("/api/connections")
+	connections.Use(middlewares.AuthMiddleware())
+	{
+		// Explains why a connection test fails, before a chat is created
+		connections.POST("/diagnose", chatHandler.DiagnoseConnection)
+	}
+
+	// One-off questions without a chat, throttled per IP before authentication since every call connects to a database and calls the LLM
+	router.POST("/api/magic-query",
+		middlewares.IPRateLimitMiddleware(constants.MagicQueryRateLimitPerMinute, constants.MagicQueryRateLimitBurst, constants.MagicQueryRateLimitIdleMinutes*time.Minute),
+		middlewares.AuthMiddleware(),
+		chatHandler.MagicQuery)
 }
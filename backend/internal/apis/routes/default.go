@@ -34,6 +34,10 @@ func SetupDefaultRoutes(router *gin.Engine) {
 	SetupChatRoutes(router)
 	SetupVisualizationRoutes(router)
 	SetupDashboardRoutes(router)
+	SetupAnalyticsRoutes(router)
+	SetupSearchRoutes(router)
+	SetupAdminRoutes(router)
+	SetupUserRoutes(router)
 	SetupWaitlistRoutes(router)
 	SetupUploadRoutes(router)
 	SetupGoogleOAuthRoutes(router)
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"log"
+	"neobase-ai/internal/apis/middlewares"
+	"neobase-ai/internal/di"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSearchRoutes(router *gin.Engine) {
+	globalSearchHandler, err := di.GetGlobalSearchHandler()
+	if err != nil {
+		log.Fatalf("Failed to get global search handler: %v", err)
+	}
+
+	// Search across all chats of the user
+	search := router.Group("/api/search")
+	search.Use(middlewares.AuthMiddleware())
+	{
+		search.GET("", globalSearchHandler.Search)
+	}
+}
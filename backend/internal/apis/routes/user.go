@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"log"
+	"neobase-ai/internal/apis/middlewares"
+	"neobase-ai/internal/di"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupUserRoutes(router *gin.Engine) {
+	apiKeyHandler, err := di.GetAPIKeyHandler()
+	if err != nil {
+		log.Fatalf("Failed to get API key handler: %v", err)
+	}
+
+	gdprHandler, err := di.GetGDPRHandler()
+	if err != nil {
+		log.Fatalf("Failed to get GDPR handler: %v", err)
+	}
+
+	protected := router.Group("/api/users/me")
+	protected.Use(middlewares.AuthMiddleware())
+	{
+		// API keys for programmatic access
+		protected.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+		protected.GET("/api-keys", apiKeyHandler.ListAPIKeys)
+		protected.DELETE("/api-keys/:keyId", apiKeyHandler.RevokeAPIKey)
+
+		// Personal data export (GDPR Article 20)
+		protected.POST("/export-data", gdprHandler.ExportUserData)
+	}
+}
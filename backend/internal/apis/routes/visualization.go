@@ -21,5 +21,12 @@ func SetupVisualizationRoutes(router *gin.Engine) {
 		protected.POST("/:id/messages/:messageId/visualizations", vizHandler.GenerateVisualization)
 		protected.POST("/:id/execute-chart", vizHandler.ExecuteChartQuery)
 		protected.POST("/:id/visualization-data", vizHandler.GetVisualizationData)
+		protected.POST("/:id/messages/:messageId/queries/:queryId/apply-template/:templateId", vizHandler.ApplyVisualizationTemplate)
+	}
+
+	templates := router.Group("/api/visualization-templates")
+	templates.Use(middlewares.AuthMiddleware())
+	{
+		templates.GET("", vizHandler.ListVisualizationTemplates)
 	}
 }
@@ -0,0 +1,11 @@
+package constants
+
+// Chat analytics grouping and range limits
+const (
+	AnalyticsGroupByDay  = "day"
+	AnalyticsGroupByWeek = "week"
+
+	DefaultAnalyticsRangeDays = 30
+	MaxAnalyticsRangeDays     = 366
+	AnalyticsTopItemsLimit    = 10
+)
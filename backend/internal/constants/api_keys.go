@@ -0,0 +1,16 @@
+package constants
+
+import "time"
+
+const (
+	// TokenTypeAPIKey is the "type" claim of API keys, session tokens have no type claim
+	TokenTypeAPIKey = "api_key"
+	// APIKeyExpiration is how long an API key stays valid after it is issued
+	APIKeyExpiration = 365 * 24 * time.Hour
+	// APIKeyMaxNameLength is the maximum length of an API key name
+	APIKeyMaxNameLength = 100
+	// APIKeyMaxPerUser is the maximum number of active API keys a user can have
+	APIKeyMaxPerUser = 20
+	// APIKeyLastUsedInterval limits how often last_used_at is written for a key
+	APIKeyLastUsedInterval = time.Minute
+)
@@ -0,0 +1,32 @@
+package constants
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	CDCMinIntervalSeconds     = 10               // Shortest interval between two checks of a CDC watcher
+	CDCMaxIntervalSeconds     = 3600             // Longest interval between two checks of a CDC watcher
+	CDCMaxWatchersPerUser     = 5                // CDC watchers a user can have running at the same time
+	CDCMaxTables              = 10               // Tables a single CDC watcher checks
+	CDCDefaultTimestampColumn = "updated_at"     // Column whose maximum tells that a table changed
+	CDCWebhookTimeout         = 10 * time.Second // Bounds a single webhook delivery
+	CDCWebhookUserAgent       = "NeoBase-CDC/1.0"
+)
+
+// GetCDCWatchKey returns the Redis key holding the state of a CDC watcher.
+// The key expires a few intervals after the last check, so watchers lost in a restart don't count towards the limit.
+func GetCDCWatchKey(userID, watchID string) string {
+	return fmt.Sprintf("cdc_watch:%s:%s", userID, watchID)
+}
+
+// GetCDCWatchKeyPattern matches the keys of every CDC watcher of a user
+func GetCDCWatchKeyPattern(userID string) string {
+	return fmt.Sprintf("cdc_watch:%s:*", userID)
+}
+
+// GetCDCWatchTTL returns how long the state of a CDC watcher is kept after a check
+func GetCDCWatchTTL(intervalSeconds int) time.Duration {
+	return time.Duration(intervalSeconds)*3*time.Second + time.Minute
+}
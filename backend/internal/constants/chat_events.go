@@ -0,0 +1,12 @@
+package constants
+
+import "fmt"
+
+const (
+	ChatEventNewMessage = "new_message" // A message was added to the chat
+)
+
+// ChatEventsChannel returns the pub/sub channel shared by everyone watching a chat
+func ChatEventsChannel(chatID string) string {
+	return fmt.Sprintf("chat_events:%s", chatID)
+}
@@ -0,0 +1,51 @@
+package constants
+
+import "fmt"
+
+// ChatTitleMaxLength is the longest title a chat can have, generated or set by the user
+const ChatTitleMaxLength = 100
+
+// ChatTitleMessageMaxLength caps how much of each message is sent to the LLM to generate a title
+const ChatTitleMessageMaxLength = 1000
+
+// ChatTitleModelPreference lists the models used to generate chat titles, cheapest first.
+// A title is a trivial task, so the chat's own model is only used when none of these is available.
+var ChatTitleModelPreference = []string{
+	"gemini-2.0-flash-lite",
+	"gemini-2.5-flash-lite",
+	"gemini-2.0-flash",
+	"gemini-2.5-flash",
+	"gpt-4o-mini",
+	"gpt-5-nano",
+	"gpt-4.1-mini",
+	"claude-3-5-haiku-20241022",
+	"claude-haiku-4-5",
+}
+
+// GetChatTitleModel returns the cheapest enabled model for chat titles, or nil if none is enabled
+func GetChatTitleModel() *LLMModel {
+	for _, modelID := range ChatTitleModelPreference {
+		if model := GetLLMModel(modelID); model != nil && model.IsEnabled {
+			return model
+		}
+	}
+	return nil
+}
+
+// GeminiChatTitlePrompt is the system prompt used to name a chat from its first exchange.
+// It is sent through GenerateRawJSON so the LLM returns the title JSON directly.
+const GeminiChatTitlePrompt = `You are NeoBase AI Chat Titler. Generate a 5-word title for this conversation between a user and a database assistant.
+
+Rules:
+- Use at most 5 words that describe what the user wants to know or do, e.g. "Monthly Revenue by Region", "Find Inactive Customer Accounts".
+- Use Title Case. No quotes, emojis or trailing punctuation.
+- Don't include the words "Chat", "Conversation", "Query" or "NeoBase" unless they are part of the subject.
+- If the user only greeted the assistant, describe the database instead, e.g. "Exploring the Orders Database".
+
+Respond ONLY with valid JSON, no markdown:
+{"title": "the title"}`
+
+// GetChatTitleUserMessage builds the user message sent with GeminiChatTitlePrompt
+func GetChatTitleUserMessage(dbType, userMessage, assistantMessage string) string {
+	return fmt.Sprintf("Database type: %s\n\nUser message:\n%s\n\nAssistant response:\n%s", dbType, userMessage, assistantMessage)
+}
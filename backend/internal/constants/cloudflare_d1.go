@@ -0,0 +1,77 @@
+package constants
+
+import "time"
+
+// Cloudflare D1 HTTP API settings
+const (
+	// CloudflareAPIHost is stored as the host of D1 connections, queries always go to Cloudflare's API
+	CloudflareAPIHost       = "api.cloudflare.com"
+	CloudflareD1DefaultPort = "443"
+	// CloudflareAPIBaseURL is the base of the Cloudflare v4 REST API
+	CloudflareAPIBaseURL = "https://api.cloudflare.com/client/v4"
+	// CloudflareD1RequestTimeout bounds a single query request, D1 stops queries after 30 seconds itself
+	CloudflareD1RequestTimeout = 60 * time.Second
+)
+
+// GeminiCloudflareD1Prompt is appended to the PostgreSQL prompt for Cloudflare D1 connections.
+// D1 is SQLite served over Cloudflare's HTTP API.
+const GeminiCloudflareD1Prompt = `
+
+---
+### Cloudflare D1-Specific Rules (append to the SQL rules above)
+
+You are assisting a **Cloudflare D1** database — a serverless database built on **SQLite**, queried through Cloudflare's HTTP API.
+The standard SQL rules above apply, but D1 is NOT PostgreSQL. Where they differ, these rules win:
+
+1. **SQLite Dialect**
+   - Write SQLite SQL. There are no schemas: refer to tables by name only (users, not public.users).
+   - Quote identifiers with double quotes ("order"), string literals with single quotes.
+   - Types are dynamic: INTEGER, REAL, TEXT, BLOB and NULL. There is no BOOLEAN (use 0/1), no native DATE/TIMESTAMP (dates are TEXT in ISO 8601 or INTEGER Unix epochs), and no arrays.
+   - There is no ILIKE: LIKE is already case-insensitive for ASCII. Use lower(col) = lower('value') for exact case-insensitive matches.
+   - Date and time: date('now'), datetime('now', '-7 days'), strftime('%Y-%m', created_at), julianday(). There is no NOW(), INTERVAL, DATE_TRUNC or EXTRACT.
+   - String concatenation uses ||. Use COALESCE/IFNULL for null handling, CAST(x AS INTEGER) for conversions.
+   - JSON columns are TEXT: use json_extract(col, '$.key') and the ->> operator, json_each() to expand arrays.
+   - Not supported: stored procedures, sequences (use INTEGER PRIMARY KEY AUTOINCREMENT), DISTINCT ON, LATERAL joins and generate_series (use a recursive CTE instead).
+   - D1 does not load every SQLite extension: JSON, FTS5 full-text search and the math functions are available, loadable extensions are not.
+   - The rowid column exists on every table unless it is declared WITHOUT ROWID.
+
+2. **D1 Limits**
+   - ATTACH DATABASE and DETACH are NOT allowed. Each connection is ONE database; there are no cross-database queries.
+   - PRAGMA statements are limited to read-only ones such as PRAGMA table_info("users"), PRAGMA table_list and PRAGMA foreign_key_list("users").
+   - BEGIN, COMMIT, ROLLBACK and SAVEPOINT are NOT allowed: every query runs on its own and commits immediately. Never wrap statements in a transaction.
+   - A single SQL statement is limited to 100 KB and a query can run at most 30 seconds. Keep queries focused and always add a LIMIT to reads.
+   - Bound parameters are limited to 100 per query; prefer literal values in queries.
+   - Discovery: SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT GLOB 'sqlite_*' AND name NOT GLOB '_cf_*' lists the user's tables. Tables starting with _cf_ are internal to Cloudflare, never query or change them.
+
+3. **Pagination**
+   - Paginate with LIMIT n OFFSET m and ALWAYS an ORDER BY on a unique column (e.g. the primary key or rowid) so pages are stable:
+     SELECT id, name FROM users ORDER BY id LIMIT 50 OFFSET offset_size
+   - For countQuery, use SELECT COUNT(*) FROM table with the same WHERE clause.
+
+4. **Writes Are Critical**
+   - INSERT, UPDATE, DELETE, CREATE, ALTER and DROP change data: ALWAYS set isCritical: true.
+   - ALTER TABLE supports only RENAME TABLE, RENAME COLUMN, ADD COLUMN and DROP COLUMN. Other changes need a new table, a copy of the data and a rename; explain this to the user.
+   - There is no TRUNCATE: use DELETE FROM table.
+   - Rollbacks work like in the rules above (an inverse UPDATE, DELETE or INSERT) because writes cannot be undone with a transaction. D1 Time Travel can restore the whole database to a point in time; mention it for destructive writes.
+`
+
+// CloudflareD1VisualizationExtensions is appended to the PostgreSQL visualization prompt.
+const CloudflareD1VisualizationExtensions = `
+
+Cloudflare D1-specific visualization guidance:
+- D1 is SQLite: dates are TEXT or Unix epoch INTEGER columns, group them with strftime('%Y-%m-%d', col) or date(col, 'unixepoch').
+- Boolean-like columns hold 0 and 1, label them with CASE WHEN col = 1 THEN 'Yes' ELSE 'No' END.
+- Aggregate in SQL with COUNT, SUM, AVG, MIN and MAX instead of plotting raw rows, D1 returns at most what the query asks for.
+`
+
+func getCloudflareD1NonTechInstructions() string {
+	return `
+
+**CLOUDFLARE D1 SPECIFIC REQUIREMENTS**:
+
+1. Write SQLite SQL: use date('now', '-7 days') and strftime('%Y-%m', col) for dates, never NOW() or INTERVAL.
+2. Show 0/1 columns as Yes/No and format epoch or ISO 8601 dates as readable dates with strftime or date().
+3. Alias columns with friendly names in double quotes, e.g. COUNT(*) AS "Total Orders".
+4. Never show or query tables that start with _cf_, they are internal to Cloudflare.
+`
+}
@@ -0,0 +1,46 @@
+package constants
+
+var CohereLLMModels = []LLMModel{
+	// Command A Series (Latest Generation)
+	{
+		ID:                  "command-a-03-2025",
+		Provider:            Cohere,
+		DisplayName:         "Command A (Most Capable)",
+		IsEnabled:           true,
+		Default:             ptrBool(true),
+		MaxCompletionTokens: 8000,
+		Temperature:         0.3,
+		InputTokenLimit:     256000,
+		Description:         "Cohere's most performant model, strong at tool use, agents and retrieval augmented generation",
+	},
+	// Command R Series (RAG & Tool Use)
+	{
+		ID:                  "command-r-plus",
+		Provider:            Cohere,
+		DisplayName:         "Command R+ (RAG & Tool Use)",
+		IsEnabled:           true,
+		MaxCompletionTokens: 4000,
+		Temperature:         0.3,
+		InputTokenLimit:     128000,
+		Description:         "Enterprise model optimized for retrieval augmented generation and multi-step tool use",
+	},
+	{
+		ID:                  "command-r",
+		Provider:            Cohere,
+		DisplayName:         "Command R (Fast)",
+		IsEnabled:           true,
+		MaxCompletionTokens: 4000,
+		Temperature:         0.3,
+		InputTokenLimit:     128000,
+		Description:         "Lighter Command model for fast, cost efficient conversational and retrieval tasks",
+	},
+}
+
+// Cohere only accepts a json_object response format, so the response schemas below are
+// injected into the system prompt instead of being enforced by the API (same shape as Claude's)
+
+// Initial LLM Response Schema for Cohere
+const CohereLLMResponseSchemaJSON = ClaudeLLMResponseSchemaJSON
+
+// Recommendations Response Schema for Cohere
+const CohereRecommendationsSchemaJSON = ClaudeRecommendationsSchemaJSON
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	ColumnStatsKindNumeric    = "numeric"
+	ColumnStatsKindText       = "text"
+	ColumnStatsKindTimestamp  = "timestamp"
+	ColumnStatsTopValuesLimit = 10  // Most frequent values returned for text columns
+	ColumnStatsMaxMonths      = 240 // Most recent months of a timestamp column's distribution
+	ColumnStatsTimeoutSeconds = 60  // Timeout of each statistics query, they scan the whole table
+)
@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+// Steps of a connection diagnosis, in the order they are checked
+const (
+	ConnectionCheckTCP        = "tcp"
+	ConnectionCheckTLS        = "tls"
+	ConnectionCheckAuth       = "auth"
+	ConnectionCheckDatabase   = "database"
+	ConnectionCheckPermission = "permission"
+	// ConnectionCheckUnknown is reported when the database failed with an error no step could be blamed for
+	ConnectionCheckUnknown = "unknown"
+)
+
+const (
+	// ConnectionDiagnosticDialTimeout bounds the TCP connection to the database host
+	ConnectionDiagnosticDialTimeout = 5 * time.Second
+	// ConnectionDiagnosticTLSTimeout bounds the TLS handshake with the database server
+	ConnectionDiagnosticTLSTimeout = 10 * time.Second
+)
@@ -0,0 +1,14 @@
+package constants
+
+const (
+	// DefaultDBPoolMin is the MaxOpenConns a new database/sql pool starts with when DB_POOL_MIN is not set
+	DefaultDBPoolMin = 5
+	// DefaultDBPoolMax is the MaxOpenConns a pool may grow to when DB_POOL_MAX is not set
+	DefaultDBPoolMax = 50
+	// DefaultDBPoolIdleTimeoutSeconds closes pooled connections idle for longer than this when DB_POOL_IDLE_TIMEOUT is not set
+	DefaultDBPoolIdleTimeoutSeconds = 300
+	// DBPoolConnectionsPerChat is the share of a pool reserved for each chat using it
+	DBPoolConnectionsPerChat = 2
+	// DBPoolHighUtilizationPercent is the share of MaxOpenConns in use that triggers a warning and pool growth
+	DBPoolHighUtilizationPercent = 80
+)
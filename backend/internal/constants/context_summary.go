@@ -0,0 +1,46 @@
+package constants
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ContextSummarizeThreshold is the share of the model's input token limit the context may use before
+	// its oldest half is summarized, below the pruning threshold so pruning stays the last resort
+	ContextSummarizeThreshold = 0.7
+	// ContextSummaryMessageMaxLength caps how much of each message is sent to the LLM to be summarized
+	ContextSummaryMessageMaxLength = 2000
+	// ContextSummaryTTL keeps a summary for reuse while the same messages are the oldest half of the context
+	ContextSummaryTTL = 24 * time.Hour
+)
+
+// GetContextSummaryKey is the Redis key of a chat's summary of a set of messages, identified by their hash
+func GetContextSummaryKey(chatID, messagesHash string) string {
+	return fmt.Sprintf("context_summary:%s:%s", chatID, messagesHash)
+}
+
+// GetContextSummaryModel returns the cheapest enabled model to summarize conversations with, the same
+// models as chat titles, or nil if none is enabled
+func GetContextSummaryModel() *LLMModel {
+	return GetChatTitleModel()
+}
+
+// GeminiSummarizationPrompt is the system prompt used to condense the oldest turns of a conversation.
+// It is sent through GenerateRawJSON so the LLM returns the summary JSON directly.
+const GeminiSummarizationPrompt = `You are NeoBase AI Conversation Summarizer. Summarize the earlier part of a conversation between a user and a database assistant, so the assistant can continue the conversation without the original messages.
+
+Rules:
+- Keep every fact the assistant may need later: tables, collections and columns discussed, filters and time ranges, queries that were run and what they returned, and decisions or preferences the user stated.
+- Keep exact identifiers (table, column, collection names and values) as written.
+- Mention queries that failed and why, so they aren't repeated.
+- Leave out greetings, formatting and anything repeated.
+- Write in the third person ("The user asked...", "The assistant ran..."), at most 300 words.
+
+Respond ONLY with valid JSON, no markdown:
+{"summary": "the summary"}`
+
+// GetSummarizationUserMessage builds the user message sent with GeminiSummarizationPrompt
+func GetSummarizationUserMessage(transcript string) string {
+	return fmt.Sprintf("Conversation to summarize:\n\n%s", transcript)
+}
@@ -528,6 +528,31 @@ DATABASE-SPECIFIC INSTRUCTIONS (PostgreSQL/YugabyteDB):
 - Use TO_CHAR(col, 'YYYY-MM-DD') for date formatting.
 - JOINs are preferred over subqueries for readability and performance.
 - All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypeSupabase:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Supabase):
+- Write standard PostgreSQL SQL queries. Supabase is hosted PostgreSQL.
+- Use double-quoted identifiers for case-sensitive names: "TableName"."ColumnName"
+- Use LIMIT/OFFSET for pagination. Default LIMIT 50 for table widgets.
+- Use NOW() and INTERVAL for time-based filtering: WHERE created_at >= NOW() - INTERVAL '7 days'
+- Use DATE_TRUNC('day', col) for grouping by date periods.
+- Tables with Row Level Security may return only the rows visible to the current role; do not label such widgets as totals.
+- Storage usage lives in storage.objects: SUM((metadata ->> 'size')::bigint) grouped by bucket_id.
+- Never reference "storage_bucket:<id>" virtual tables in SQL; query storage.objects WHERE bucket_id = '<id>' instead.
+- All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypeNeon:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Neon):
+- Write standard PostgreSQL SQL queries. Neon is serverless PostgreSQL.
+- Use double-quoted identifiers for case-sensitive names: "TableName"."ColumnName"
+- Use LIMIT/OFFSET for pagination. Default LIMIT 50 for table widgets.
+- Use NOW() and INTERVAL for time-based filtering: WHERE created_at >= NOW() - INTERVAL '7 days'
+- Use DATE_TRUNC('day', col) for grouping by date periods.
+- Widgets only reflect the data of the branch this connection points to.
+- Keep widget queries light: the compute may be suspended and scales with load, so prefer selective filters and aggregations.
+- All queries MUST be SELECT-only (read-only).
 `
 	case DatabaseTypeTimescaleDB:
 		return `
@@ -557,6 +582,19 @@ DATABASE-SPECIFIC INSTRUCTIONS (MySQL):
 - Use DATE(col) or DATE_FORMAT(col, '%Y-%m-%d') for grouping by date.
 - JOINs are preferred over subqueries.
 - All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypePlanetscale:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (PlanetScale):
+- Write standard SQL queries using MySQL syntax. PlanetScale is MySQL-compatible (Vitess).
+- Use backtick-quoted identifiers for reserved words: ` + "`table`.`column`" + `
+- Use single quotes for string literals: 'value'
+- Use LIMIT for pagination. Default LIMIT 50 for table widgets.
+- Use NOW() and INTERVAL for time-based filtering: WHERE created_at >= NOW() - INTERVAL 7 DAY
+- Use DATE_FORMAT(col, '%Y-%m-%d') for grouping by date.
+- Foreign keys are usually not enforced; use LEFT JOIN so rows with missing parents are not dropped.
+- Filter on the sharding (vindex) column where possible to avoid scatter queries across shards.
+- All queries MUST be SELECT-only (read-only).
 `
 	case DatabaseTypeStarRocks:
 		return `
@@ -574,6 +612,57 @@ DATABASE-SPECIFIC INSTRUCTIONS (StarRocks):
 - JOINs are preferred over subqueries.
 - Specify columns explicitly — avoid SELECT * on wide columnar tables.
 - All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypeTrino:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Trino):
+- Write ANSI SQL queries using Trino syntax.
+- Use double-quoted identifiers for names: "table"."column". Never use backticks.
+- Use single quotes for string literals: 'value'
+- Trino requires OFFSET before LIMIT. Default LIMIT 50 for table widgets.
+- Use current_timestamp and INTERVAL for time filtering: WHERE created_at >= current_timestamp - INTERVAL '7' DAY
+- Use date_trunc('day', col) for date grouping and format_datetime(col, 'yyyy-MM-dd') for formatting.
+- Use count(*), sum(), avg(), min(), max() and approx_distinct() for aggregations.
+- Use coalesce(col, default) for null handling and TRY_CAST when data may be dirty.
+- ALWAYS filter on partition columns (e.g. dt, date) so widgets do not scan the whole data lake.
+- Use CROSS JOIN UNNEST to flatten ARRAY and MAP columns.
+- All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypeOracle:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Oracle):
+- Write Oracle SQL queries. There is no LIMIT: use FETCH FIRST 50 ROWS ONLY for table widgets.
+- Unquoted identifiers are UPPERCASE; quote result aliases in lowercase ("total") so widget keys stay stable.
+- Never use AS before a table alias: FROM orders o.
+- Use SYSDATE and day arithmetic for time filtering: WHERE created_at >= SYSDATE - 7
+- Use TRUNC(col, 'DD' | 'IW' | 'MM') for date grouping and TO_CHAR(col, 'YYYY-MM-DD') for formatting.
+- Use COUNT(*), SUM(), AVG(), MIN(), MAX() and APPROX_COUNT_DISTINCT() for aggregations.
+- Use COALESCE(col, default) or NVL(col, default) for null handling.
+- Every SELECT needs a FROM clause; use FROM DUAL for expressions.
+- All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypeInfluxDB:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (InfluxDB):
+- Write SQL queries for InfluxDB 3. Measurements are tables, every measurement has a time column.
+- Use double-quoted identifiers for names: "cpu"."usage_idle". Use single quotes for string literals: 'value'
+- ALWAYS filter on time: WHERE time > now() - INTERVAL '24 hours'
+- Use date_bin(INTERVAL '1 hour', time) for time buckets and ORDER BY the bucket.
+- Group by tags (e.g. host, region) and aggregate fields with avg(), sum(), count(), min(), max().
+- Use LIMIT for table widgets. Default LIMIT 50.
+- All queries MUST be SELECT-only (read-only).
+`
+	case DatabaseTypeCloudflareD1:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Cloudflare D1):
+- Write SQLite queries. There are no schemas, refer to tables by name only.
+- Use double-quoted identifiers for names: "orders"."total". Use single quotes for string literals: 'value'
+- Use date('now', '-7 days') and datetime() for time filtering: WHERE created_at >= date('now', '-7 days')
+- Use strftime('%Y-%m-%d', col) for date grouping and formatting. There is no DATE_TRUNC, NOW() or INTERVAL.
+- Use COUNT(*), SUM(), AVG(), MIN(), MAX() for aggregations and COALESCE(col, default) for null handling.
+- Never query tables starting with _cf_ or sqlite_, they are internal.
+- Use LIMIT for table widgets. Default LIMIT 50.
+- All queries MUST be SELECT-only (read-only).
 `
 	case DatabaseTypeMongoDB:
 		return `
@@ -590,6 +679,75 @@ DATABASE-SPECIFIC INSTRUCTIONS (MongoDB):
 - Use $project to reshape output fields.
 - For stat widgets, pipeline should return a single document with the value.
 - All operations MUST be read-only (find/aggregate only, no update/delete/insert).
+`
+	case DatabaseTypeFerretDB:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (FerretDB):
+- FerretDB is MongoDB-compatible. Write MongoDB aggregation pipelines or find queries.
+- Format: db.collection.aggregate([...stages...]) or db.collection.find({...})
+- Each pipeline stage MUST be a separate object: [{"$match": {...}}, {"$group": {...}}]
+- Use $$NOW (double dollar) for system date variables, NOT $NOW.
+- Always quote field names in all objects: {"$match": {"status": "active"}}
+- Stick to $match, $group, $sort, $limit, $project, $unwind and $count. Do NOT use $where, $facet, $graphLookup or $lookup with a sub-pipeline.
+- Use $limit for pagination: {"$limit": 50}
+- For stat widgets, pipeline should return a single document with the value.
+- All operations MUST be read-only (find/aggregate only, no update/delete/insert).
+`
+	case DatabaseTypeAirtable:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Airtable):
+- Airtable is NOT SQL. Write Airtable read operations only: base("Table Name").select({...})
+- Options are strict JSON: {"filterByFormula": "...", "fields": [...], "sort": [{"field": "Name", "direction": "desc"}], "maxRecords": 50}
+- Filter with Airtable formulas using curly-brace field references: AND({Status} = 'Active', IS_AFTER({Created}, DATEADD(TODAY(), -7, 'days')))
+- Airtable cannot aggregate on the server. Select only the fields a widget needs and keep maxRecords small (default 50, at most 1000).
+- Always list "fields" explicitly, linked record fields only contain record ids.
+- All operations MUST be read-only (select/find only, no create/update/destroy).
+`
+	case DatabaseTypeTemporal:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Temporal):
+- Temporal is NOT SQL. Write Temporal CLI reads only: temporal workflow list --query "..." --limit 50 or temporal workflow count --query "..."
+- The --query value is a visibility query over search attributes: WorkflowType='OrderWorkflow' AND StartTime > '2024-01-01T00:00:00Z'
+- Use count for KPI widgets (e.g. failed executions today) and list for tables, keep --limit small (default 50).
+- Temporal cannot aggregate beyond counts. For breakdowns by status or workflow type, use one count per category.
+- All commands MUST be read-only (list/count/describe only, no signal/cancel/terminate).
+`
+	case DatabaseTypePocketBase:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (PocketBase):
+- PocketBase is NOT SQL. Write PocketBase API reads only: GET /api/collections/{collection}/records {"filter": "...", "fields": "...", "perPage": 50}
+- Filters use PocketBase filter expressions: status = 'paid' && created >= @monthStart
+- Use {"count": true} for KPI widgets and keep perPage small (default 50, at most 500) for tables and charts.
+- PocketBase cannot aggregate beyond counts. For breakdowns by category, use one count per category.
+- All requests MUST be read-only (GET only, no POST/PATCH/DELETE).
+`
+	case DatabaseTypeNATS:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (NATS JetStream):
+- NATS JetStream is NOT SQL. Write JetStream reads only: js.FetchMsg("STREAM", 50, {"subject": "...", "startTime": "..."}) or js.CountMsg("STREAM", {"subject": "..."})
+- Messages can only be filtered by subject (wildcards * and >), start sequence and start time, payloads are returned as data text.
+- Use js.CountMsg for KPI widgets and js.FetchMsg with "last": true for recent activity, keep batches small (default 50, at most 1000).
+- JetStream cannot aggregate beyond counts. For breakdowns by subject, use one count per subject.
+- All calls MUST be read-only (no js.Publish).
+`
+	case DatabaseTypeFirestore:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (Firestore):
+- Firestore is NOT SQL. Write Firestore reads only: db.Collection("orders").Where("status", "==", "paid").OrderBy("createdAt", "desc").Limit(50) or db.Collection("orders").Where("status", "==", "paid").Count()
+- Every query reads ONE collection, there are no JOINs. Timestamps are compared as {"$date": "2024-06-01T00:00:00Z"}.
+- Use Count() for KPI widgets and keep Limit small (default 50, at most 1000) for tables and charts.
+- Inequality filters on more than one field need a composite index, filter ranges on one field only.
+- Firestore cannot aggregate beyond counts. For breakdowns by category, use one count per category.
+- All queries MUST be read-only (no Add, Set, Update or Delete).
+`
+	case DatabaseTypeYugabyteDBCQL:
+		return `
+DATABASE-SPECIFIC INSTRUCTIONS (YugabyteDB YCQL):
+- YCQL is Cassandra Query Language, NOT PostgreSQL. Every SELECT reads ONE table: no JOINs, subqueries or CTEs.
+- Restrict every partition key column with = or IN. Queries on other columns need ALLOW FILTERING and scan the whole table, only use it on small tables.
+- GROUP BY only works on the partition key and clustering columns. Aggregates: COUNT, SUM, AVG, MIN, MAX.
+- Put LIMIT before ALLOW FILTERING and keep it small (default 50).
+- All queries MUST be read-only (SELECT only).
 `
 	case DatabaseTypeClickhouse:
 		return `
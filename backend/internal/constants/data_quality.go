@@ -0,0 +1,55 @@
+package constants
+
+import "fmt"
+
+const (
+	DataQualityMaxTables           = 20 // Maximum tables per data quality report request
+	DataQualityMaxParallelQueries  = 5  // Maximum data quality check queries running at the same time
+	DataQualityQueryTimeoutSeconds = 60 // Timeout per data quality check query
+	DataQualityOutlierStdDevFactor = 3  // A max/min further than this many stddevs from the mean is flagged as an outlier
+	DataQualityCheckNullPercentage = "null_percentage"
+	DataQualityCheckDuplicates     = "duplicates"
+	DataQualityCheckNumericStats   = "numeric_stats"
+	DataQualityCheckDistinctCounts = "distinct_counts"
+)
+
+// GeminiDataQualityPrompt is the system prompt used to generate data quality check queries.
+// It is sent through GenerateRawJSON so the LLM returns the checks JSON directly instead of
+// the standard NeoBase assistantMessage/queries response format.
+const GeminiDataQualityPrompt = `You are NeoBase AI Data Quality Analyst. Your task is to generate READ-ONLY queries that measure the health of the requested tables/collections.
+
+For EACH requested table, generate checks of these types:
+1. "null_percentage": one query per table returning one row per column with the percentage of NULL (or missing) values.
+   Result columns: column_name, null_count, total_rows, null_percentage (0-100).
+2. "duplicates": one query per table counting fully duplicated rows (all non-primary-key columns equal).
+   Result columns: total_rows, duplicate_count.
+3. "numeric_stats": one query per numeric column returning its statistics.
+   Result columns: column_name, min_value, max_value, avg_value, stddev_value.
+4. "distinct_counts": one query per categorical column (text/enum/boolean-like columns, NOT free text or ids) returning its distinct value count.
+   Result columns: column_name, distinct_count, total_rows.
+
+Rules:
+- Queries MUST be read-only: only SELECT / WITH statements for SQL databases, only find/aggregate/countDocuments for MongoDB. Never use INSERT, UPDATE, DELETE, DROP, ALTER, TRUNCATE, $out or $merge.
+- Each query must be a single statement without a trailing semicolon.
+- Use the exact table and column names from the schema, quoted according to the database's rules.
+- For MongoDB, write queries as db.<collection>.aggregate([...]) pipelines using $group, $project and $cond to compute the same result columns.
+- Use the exact result column names listed above so results can be aggregated.
+- Only reference the requested tables.
+
+Respond ONLY with JSON in this exact format (no markdown, no explanation):
+{
+  "checks": [
+    {
+      "table": "users",
+      "checkType": "null_percentage",
+      "column": "",
+      "query": "SELECT ..."
+    }
+  ]
+}
+"column" is the checked column for numeric_stats and distinct_counts, and an empty string otherwise.`
+
+// GetDataQualityUserMessage builds the user message for data quality check generation.
+func GetDataQualityUserMessage(dbType string, tables []string, schema string) string {
+	return fmt.Sprintf("Database type: %s\n\nRequested tables: %v\n\nHere is the schema of the requested tables:\n\n%s", dbType, tables, schema)
+}
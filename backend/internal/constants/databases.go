@@ -1,16 +1,53 @@
 package constants
 
 const (
-	DatabaseTypePostgreSQL   = "postgresql"
-	DatabaseTypeYugabyteDB   = "yugabytedb"
-	DatabaseTypeMySQL        = "mysql"
-	DatabaseTypeMongoDB      = "mongodb"
-	DatabaseTypeRedis        = "redis"
-	DatabaseTypeNeo4j        = "neo4j"
-	DatabaseTypeClickhouse   = "clickhouse"
-	DatabaseTypeCassandra    = "cassandra"
-	DatabaseTypeSpreadsheet  = "spreadsheet"
-	DatabaseTypeGoogleSheets = "google_sheets"
-	DatabaseTypeTimescaleDB  = "timescaledb"
-	DatabaseTypeStarRocks    = "starrocks"
+	DatabaseTypePostgreSQL = "postgresql"
+	DatabaseTypeYugabyteDB = "yugabytedb"
+	// DatabaseTypeYugabyteDBCQL is a yugabytedb connection using the Cassandra-compatible YCQL API instead of YSQL
+	DatabaseTypeYugabyteDBCQL = "yugabytedb_ycql"
+	DatabaseTypeMySQL         = "mysql"
+	DatabaseTypeMongoDB       = "mongodb"
+	DatabaseTypeRedis         = "redis"
+	DatabaseTypeNeo4j         = "neo4j"
+	DatabaseTypeClickhouse    = "clickhouse"
+	DatabaseTypeCassandra     = "cassandra"
+	DatabaseTypeSpreadsheet   = "spreadsheet"
+	DatabaseTypeGoogleSheets  = "google_sheets"
+	DatabaseTypeTimescaleDB   = "timescaledb"
+	DatabaseTypeStarRocks     = "starrocks"
+	DatabaseTypeSupabase      = "supabase"
+	DatabaseTypeTrino         = "trino"
+	DatabaseTypeAirtable      = "airtable"
+	DatabaseTypePlanetscale   = "planetscale"
+	DatabaseTypeFerretDB      = "ferretdb"
+	DatabaseTypeOracle        = "oracle"
+	DatabaseTypeInfluxDB      = "influxdb"
+	DatabaseTypeTemporal      = "temporal"
+	DatabaseTypePocketBase    = "pocketbase"
+	DatabaseTypeNATS          = "nats"
+	DatabaseTypeFirestore     = "firestore"
+	DatabaseTypeNeon          = "neon"
+	DatabaseTypeCloudflareD1  = "cloudflare_d1"
 )
+
+// ConnectionURISchemes lists the URI schemes accepted in a connection URI for each database type
+var ConnectionURISchemes = map[string][]string{
+	DatabaseTypePostgreSQL:    {"postgresql", "postgres"},
+	DatabaseTypeYugabyteDB:    {"postgresql", "postgres", "yugabytedb"},
+	DatabaseTypeTimescaleDB:   {"postgresql", "postgres"},
+	DatabaseTypeSupabase:      {"postgresql", "postgres"},
+	DatabaseTypeNeon:          {"postgresql", "postgres"},
+	DatabaseTypeMySQL:         {"mysql"},
+	DatabaseTypeStarRocks:     {"mysql", "starrocks"},
+	DatabaseTypePlanetscale:   {"mysql"},
+	DatabaseTypeClickhouse:    {"clickhouse", "tcp"},
+	DatabaseTypeMongoDB:       {"mongodb", "mongodb+srv"},
+	DatabaseTypeFerretDB:      {"mongodb"},
+	DatabaseTypeRedis:         {"redis", "rediss"},
+	DatabaseTypeNeo4j:         {"neo4j", "neo4j+s", "neo4j+ssc", "bolt", "bolt+s", "bolt+ssc"},
+	DatabaseTypeCassandra:     {"cassandra"},
+	DatabaseTypeYugabyteDBCQL: {"cassandra", "ycql"},
+	DatabaseTypeTrino:         {"trino", "http", "https"},
+	DatabaseTypeOracle:        {"oracle"},
+	DatabaseTypeInfluxDB:      {"influxdb", "http", "https"},
+}
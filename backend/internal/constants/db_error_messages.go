@@ -0,0 +1,82 @@
+package constants
+
+import "regexp"
+
+// Database error categories detected from query execution errors
+const (
+	DBErrorTableNotFound    = "table_not_found"
+	DBErrorColumnNotFound   = "column_not_found"
+	DBErrorPermissionDenied = "permission_denied"
+	DBErrorSyntax           = "syntax_error"
+	DBErrorTimeout          = "timeout"
+	DBErrorConnection       = "connection"
+)
+
+// dbErrorCategory maps an error code or a pattern of the database error message to a category
+type dbErrorCategory struct {
+	Category string
+	Codes    []string
+	Pattern  *regexp.Regexp
+}
+
+// dbErrorCategories are checked in order, so the more specific categories come first
+var dbErrorCategories = []dbErrorCategory{
+	{
+		Category: DBErrorTimeout,
+		Codes:    []string{"QUERY_EXECUTION_TIMED_OUT"},
+		Pattern:  regexp.MustCompile(`(?i)statement timeout|context deadline exceeded|timed out|timeout exceeded|max_execution_time|exceeded time limit|exceededtimelimit`),
+	},
+	{
+		Category: DBErrorColumnNotFound,
+		Pattern:  regexp.MustCompile(`(?i)column \S+( of relation \S+)? does not exist|unknown column|no such column|missing columns|unknown identifier`),
+	},
+	{
+		Category: DBErrorTableNotFound,
+		Codes:    []string{"TABLE_NOT_FOUND", "COLLECTION_NOT_FOUND"},
+		Pattern:  regexp.MustCompile(`(?i)doesn't exist|does not exist|no such table|unknown table|ns not found|collection \S+ not found`),
+	},
+	{
+		Category: DBErrorPermissionDenied,
+		Pattern:  regexp.MustCompile(`(?i)permission denied|access denied|not authorized|unauthorized|insufficient privilege|command denied`),
+	},
+	{
+		Category: DBErrorSyntax,
+		Codes:    []string{"INVALID_QUERY"},
+		Pattern:  regexp.MustCompile(`(?i)syntax error|error in your sql syntax|syntax_error|unexpected token|parse error|failed to parse`),
+	},
+	{
+		Category: DBErrorConnection,
+		Codes:    []string{"CONNECTION_ERROR", "NO_CONNECTION_FOUND"},
+		Pattern:  regexp.MustCompile(`(?i)connection refused|connection reset|broken pipe|no such host|server closed the connection|bad connection`),
+	},
+}
+
+// FriendlyDBErrorMessages are the messages shown to business users (non-technical mode) for each error category
+var FriendlyDBErrorMessages = map[string]string{
+	DBErrorTableNotFound:    "The table we tried to query doesn't exist in your database. Try refreshing the schema.",
+	DBErrorColumnNotFound:   "One of the fields we tried to use doesn't exist in that table. Try refreshing the schema or rephrasing your question.",
+	DBErrorPermissionDenied: "Your database account isn't allowed to access this data. Ask your database administrator for access.",
+	DBErrorSyntax:           "We couldn't understand the query we generated for your question. Try rephrasing it or asking for something more specific.",
+	DBErrorTimeout:          "Your database took too long to answer. Try asking for a smaller range of data, such as a shorter time period.",
+	DBErrorConnection:       "We couldn't reach your database right now. Check that it is running and try again.",
+}
+
+// DetectDBErrorCategory returns the category of a query execution error, or "" if it is not recognised
+func DetectDBErrorCategory(code, message string) string {
+	for _, category := range dbErrorCategories {
+		for _, c := range category.Codes {
+			if code == c {
+				return category.Category
+			}
+		}
+		if category.Pattern.MatchString(message) {
+			return category.Category
+		}
+	}
+	return ""
+}
+
+// GetFriendlyDBErrorMessage returns the business friendly message for a query execution error, or "" if it is not recognised
+func GetFriendlyDBErrorMessage(code, message string) string {
+	return FriendlyDBErrorMessages[DetectDBErrorCategory(code, message)]
+}
@@ -74,18 +74,58 @@ func GetRagNoMatchingTablesFound(dbType string) string {
 	// Build the DB-specific discovery step
 	var discoveryStep string
 	switch dbType {
-	case DatabaseTypeMongoDB:
+	case DatabaseTypeMongoDB, DatabaseTypeFerretDB:
 		discoveryStep = "1. Start by using execute_read_query with the query `db.getCollectionNames()` to list all available collections in the MongoDB database.\n" +
 			"2. Once you identify potentially relevant collections, call get_table_info with those specific collection names to see their fields and structure.\n" +
 			"3. Use execute_read_query to run further exploratory queries as needed (e.g. `db.collectionName.find({}).limit(5)` to see sample documents).\n"
+	case DatabaseTypeAirtable:
+		discoveryStep = "1. Start by using execute_read_query with the query `base.tables()` to list all available tables in the Airtable base.\n" +
+			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their fields and structure.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed (e.g. `base(\"Table Name\").select({\"maxRecords\": 5})` to see sample records).\n"
+	case DatabaseTypeTemporal:
+		discoveryStep = "1. Start by using execute_read_query with the query `temporal workflow list --limit 20` to see recent workflow executions and their workflow types in the connection's namespace.\n" +
+			"2. Once you identify potentially relevant workflow types, call get_table_info with those specific workflow type names to see the search attributes they can be filtered on.\n" +
+			"3. Use execute_read_query to run further exploratory commands as needed (e.g. `temporal workflow count --query \"WorkflowType='OrderWorkflow' AND ExecutionStatus='Failed'\"`).\n"
+	case DatabaseTypePocketBase:
+		discoveryStep = "1. Start by using execute_read_query with the query `GET /api/collections` to list all available collections of the PocketBase instance.\n" +
+			"2. Once you identify potentially relevant collections, call get_table_info with those specific collection names to see their fields and relations.\n" +
+			"3. Use execute_read_query to run further exploratory requests as needed (e.g. `GET /api/collections/orders/records {\"perPage\": 5}` to see sample records).\n"
+	case DatabaseTypeNATS:
+		discoveryStep = "1. Start by using execute_read_query with the query `js.Streams()` to list all JetStream streams with their subjects and message counts.\n" +
+			"2. Once you identify potentially relevant streams, call get_table_info with those specific stream names to see their subjects and sequence range.\n" +
+			"3. Use execute_read_query to run further exploratory calls as needed (e.g. `js.FetchMsg(\"ORDERS\", 5, {\"last\": true})` to see the latest messages).\n"
+	case DatabaseTypeFirestore:
+		discoveryStep = "1. Start by using execute_read_query with the query `db.Collections()` to list all top-level collections of the Firestore database.\n" +
+			"2. Once you identify potentially relevant collections, call get_table_info with those specific collection names to see the fields inferred from their documents.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed (e.g. `db.Collection(\"orders\").Limit(5)` to see sample documents).\n"
+	case DatabaseTypeYugabyteDBCQL:
+		discoveryStep = "1. Start by using execute_read_query with the query `SELECT keyspace_name, table_name FROM system_schema.tables` to list all available tables, the connection's keyspace holds the user's tables.\n" +
+			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their columns, partition key and clustering columns.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed, restricting the partition key or adding a LIMIT (e.g. `SELECT * FROM orders LIMIT 5`).\n"
 	case DatabaseTypeClickhouse:
 		discoveryStep = "1. Start by using execute_read_query with the query `SHOW TABLES` to list all available tables in the ClickHouse database.\n" +
 			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their columns and structure.\n" +
 			"3. Use execute_read_query to run further exploratory queries as needed to understand the data.\n"
-	case DatabaseTypeMySQL, DatabaseTypeStarRocks:
+	case DatabaseTypeMySQL, DatabaseTypeStarRocks, DatabaseTypePlanetscale:
 		discoveryStep = "1. Start by using execute_read_query with the query `SHOW TABLES` to list all available tables in the MySQL database.\n" +
 			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their columns and structure.\n" +
 			"3. Use execute_read_query to run further exploratory queries as needed to understand the data.\n"
+	case DatabaseTypeTrino:
+		discoveryStep = "1. Start by using execute_read_query with the query `SHOW TABLES` to list all available tables in the connection's default Trino catalog and schema.\n" +
+			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their columns and structure.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed, always with a LIMIT and a partition filter where possible.\n"
+	case DatabaseTypeOracle:
+		discoveryStep = "1. Start by using execute_read_query with the query `SELECT table_name FROM user_tables ORDER BY table_name` to list all available tables in the connecting user's Oracle schema.\n" +
+			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their columns and structure.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed, using FETCH FIRST n ROWS ONLY instead of LIMIT.\n"
+	case DatabaseTypeInfluxDB:
+		discoveryStep = "1. Start by using execute_read_query with the query `SHOW MEASUREMENTS` to list all available measurements (tables) in the InfluxDB database.\n" +
+			"2. Once you identify potentially relevant measurements, call get_table_info with those specific measurement names to see their tags, fields and structure.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed, always with a time range (e.g. `WHERE time > now() - INTERVAL '1 hour'`) and a LIMIT.\n"
+	case DatabaseTypeCloudflareD1:
+		discoveryStep = "1. Start by using execute_read_query with the query `SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT GLOB 'sqlite_*' AND name NOT GLOB '_cf_*'` to list all available tables in the D1 database.\n" +
+			"2. Once you identify potentially relevant tables, call get_table_info with those specific table names to see their columns and structure.\n" +
+			"3. Use execute_read_query to run further exploratory queries as needed, always with a LIMIT (e.g. `SELECT * FROM orders LIMIT 5`).\n"
 	case DatabaseTypeSpreadsheet:
 		// Spreadsheet connections use a chat-specific PostgreSQL schema (conn_<chatID>),
 		// not the 'public' schema. Use current_schema() which resolves to the correct one.
@@ -119,7 +159,7 @@ func GetRagQdrantUnavailable(dbType string, tableListing string) string {
 	// Build the DB-specific discovery step
 	var discoveryStep string
 	switch dbType {
-	case DatabaseTypeMongoDB:
+	case DatabaseTypeMongoDB, DatabaseTypeFerretDB:
 		discoveryStep = "Use get_table_info with selected collection names to see their fields and structure, or execute_read_query for further exploration."
 	case DatabaseTypeClickhouse:
 		discoveryStep = "Use get_table_info with selected table names to see their columns and structure, or execute_read_query for further exploration."
@@ -145,6 +185,7 @@ func GetRagQdrantUnavailable(dbType string, tableListing string) string {
 const (
 	EmbeddingProviderOpenAI = "openai"
 	EmbeddingProviderGemini = "gemini"
+	EmbeddingProviderCohere = "cohere" // Used for top-K table selection only, not the Qdrant RAG pipeline
 )
 
 // SupportedEmbeddingProviders lists all valid embedding provider identifiers.
@@ -158,7 +199,7 @@ var SupportedEmbeddingProviders = []string{
 // EmbeddingModel represents a supported embedding model configuration.
 type EmbeddingModel struct {
 	ID          string `json:"id"`          // Model identifier used in API calls
-	Provider    string `json:"provider"`    // Provider name ("openai" or "gemini")
+	Provider    string `json:"provider"`    // Provider name ("openai", "gemini" or "cohere")
 	DisplayName string `json:"displayName"` // Human-readable name
 	Dimension   int    `json:"dimension"`   // Output embedding vector dimension
 	MaxInput    int    `json:"maxInput"`    // Maximum input tokens
@@ -228,12 +269,38 @@ var GeminiEmbeddingModels = []EmbeddingModel{
 	},
 }
 
+// Cohere Embedding Models
+var CohereEmbeddingModels = []EmbeddingModel{
+	{
+		ID:          "embed-english-v3.0",
+		Provider:    EmbeddingProviderCohere,
+		DisplayName: "Embed English v3",
+		Dimension:   1024,
+		MaxInput:    512,
+		IsDefault:   true,
+		Description: "Cohere English embedding model, pairs with Cohere Rerank for schema relevance search",
+	},
+	{
+		ID:          "embed-multilingual-v3.0",
+		Provider:    EmbeddingProviderCohere,
+		DisplayName: "Embed Multilingual v3",
+		Dimension:   1024,
+		MaxInput:    512,
+		IsDefault:   false,
+		Description: "Cohere multilingual embedding model for schemas and questions in 100+ languages",
+	},
+}
+
 // SupportedEmbeddingModels is the combined list of all supported embedding models.
 var SupportedEmbeddingModels = append(
-	OpenAIEmbeddingModels,
-	GeminiEmbeddingModels...,
+	append(OpenAIEmbeddingModels, GeminiEmbeddingModels...),
+	CohereEmbeddingModels...,
 )
 
+// DefaultEmbeddingTopK is the default number of tables injected into the LLM context
+// when embedding-based table selection (EMBEDDING_ENABLED) is turned on.
+const DefaultEmbeddingTopK = 15
+
 // --- Lookup & Validation Functions ---
 
 // IsValidEmbeddingProvider checks if the given provider string is supported.
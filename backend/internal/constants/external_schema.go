@@ -0,0 +1,19 @@
+package constants
+
+const (
+	// ExternalSchemaSourcePrisma is the source type of uploaded schema.prisma files
+	ExternalSchemaSourcePrisma = "prisma"
+	// PrismaSchemaLabel names the source in the schema sent to the LLM
+	PrismaSchemaLabel = "Prisma schema"
+	// PrismaSchemaMaxBytes is the largest schema.prisma accepted, large monorepo schemas stay well below it
+	PrismaSchemaMaxBytes = 2 << 20
+
+	// ExternalSchemaSourceOpenAPI is the source type of uploaded OpenAPI (or Swagger 2.0) specs
+	ExternalSchemaSourceOpenAPI = "openapi"
+	// OpenAPISpecLabel names the source in the schema sent to the LLM
+	OpenAPISpecLabel = "OpenAPI spec"
+	// OpenAPISpecMaxBytes is the largest spec accepted, specs of large APIs with many paths reach a few megabytes
+	OpenAPISpecMaxBytes = 10 << 20
+	// OpenAPIEnumMaxValues caps the enum values added to a column description, longer enums are cut with "..."
+	OpenAPIEnumMaxValues = 20
+)
@@ -0,0 +1,35 @@
+package constants
+
+import "fmt"
+
+const (
+	MaxSecondaryConnections = 1     // Federation is currently limited to a single secondary database per chat
+	FederatedMaxResultRows  = 10000 // Maximum rows returned by a federated hash join
+	FederatedJoinTypeInner  = "inner"
+	FederatedJoinTypeLeft   = "left"
+)
+
+// FederationPromptTemplate is appended to the system context when a chat has a secondary connection.
+// It gives the LLM the secondary schema and explains how to split cross-database questions.
+// Parameters: primaryDBType, secondaryDBType, secondarySchema
+const FederationPromptTemplate = `
+--- Federated Query Context ---
+This chat is connected to TWO databases:
+- PRIMARY database (%[1]s): described by the main schema above.
+- SECONDARY database (%[2]s): described by the schema below.
+
+When the user's question references tables from BOTH databases:
+1. Generate exactly two read-only queries: the first for the PRIMARY database (in %[1]s syntax), the second for the SECONDARY database (in %[2]s syntax).
+2. Start each query's description with "[primary]" or "[secondary]" so it is clear which database it runs against.
+3. Select the join key column in both queries (e.g., user_id in both) and do NOT try to join across databases in a single query.
+4. In assistantMessage, state that the results come from two databases and must be joined client-side, and name the join key columns for each side.
+
+When the question only references one database, generate queries for that database only and still prefix the description with "[primary]" or "[secondary]".
+
+SECONDARY database schema:
+%[3]s`
+
+// GetFederationPrompt returns the federation context for a chat with a secondary connection.
+func GetFederationPrompt(primaryDBType, secondaryDBType, secondarySchema string) string {
+	return fmt.Sprintf(FederationPromptTemplate, primaryDBType, secondaryDBType, secondarySchema)
+}
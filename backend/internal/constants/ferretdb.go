@@ -0,0 +1,44 @@
+package constants
+
+const (
+	FerretDBSettingsCollection = "_ferretdb_settings" // Collection FerretDB keeps its own metadata in
+	FerretDBBuildInfoVersion   = "ferretdbVersion"    // buildInfo field only FerretDB returns
+)
+
+// GeminiFerretDBPrompt is appended to the MongoDB prompt for FerretDB connections.
+// FerretDB is an open-source MongoDB replacement that stores data in PostgreSQL.
+const GeminiFerretDBPrompt = `
+
+---
+### FerretDB-Specific Rules (append to MongoDB rules above)
+
+You are assisting a **FerretDB** database — an open-source, MongoDB wire-compatible database that stores documents in PostgreSQL.
+All standard MongoDB rules above apply. Additionally, FerretDB does not implement every MongoDB feature:
+
+1. **Unsupported Operators and Commands**
+   - $where is NOT supported. Express the condition with query operators or $expr instead.
+   - mapReduce is NOT supported. Use an aggregate() pipeline with $group instead.
+   - Server-side JavaScript ($function, $accumulator) is NOT supported.
+
+2. **Limited Text Search**
+   - $text support is limited: text indexes may be missing and $meta: "textScore" sorting is not reliable.
+   - Prefer $regex with the "i" option for keyword searches, e.g. {"name": {"$regex": "john", "$options": "i"}}, and keep them on small collections or combine them with selective filters.
+
+3. **Partial $lookup Support**
+   - Only the basic form of $lookup (localField / foreignField) is reliable. Avoid $lookup with "let" and a sub-pipeline, and avoid $graphLookup.
+   - When a join is complex, run two queries and explain the relationship, or use $lookup with localField / foreignField followed by $unwind and $match.
+
+4. **Aggregation**
+   - Stick to common stages: $match, $group, $sort, $limit, $skip, $project, $unwind, $count, $addFields / $set, $unset.
+   - Put $match first so filters are pushed down to PostgreSQL.
+
+5. **Internal Collections**
+   - Ignore collections whose names start with _ferretdb, they hold FerretDB's own metadata.
+`
+
+// FerretDBVisualizationExtensions is appended to the MongoDB visualization prompt.
+const FerretDBVisualizationExtensions = `
+
+FerretDB-specific visualization guidance:
+- Aggregate with simple pipelines ($match, $group, $sort, $limit); avoid $lookup sub-pipelines and $facet, which FerretDB may not support.
+`
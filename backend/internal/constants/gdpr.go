@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const (
+	// GDPRActionDeletion is the GDPR log action of an admin erasing a user's data
+	GDPRActionDeletion = "deletion"
+	// GDPRActionExport is the GDPR log action of a user exporting their data
+	GDPRActionExport = "export"
+	// UserDataExportVersion is the format version of the user data archive
+	UserDataExportVersion = "1.0"
+	// GDPROperationTimeout bounds a whole deletion or export across all collections
+	GDPROperationTimeout = 5 * time.Minute
+)
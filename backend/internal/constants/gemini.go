@@ -144,6 +144,14 @@ var GeminiLLMResponseSchema = &genai.Schema{
 								Type:        genai.TypeString,
 								Description: "Field/column used as the pagination cursor (e.g. 'id', 'created_at', 'createdAt'). Must be present in SELECT/projection result. Leave EMPTY STRING for offset-based pagination.",
 							},
+							"keysetQuery": &genai.Schema{
+								Type:        genai.TypeString,
+								Description: "ONLY when paginatedQuery is OFFSET-BASED (uses offset_size) and the result is ordered by a unique, ever-increasing key (primary key or created_at): the same query filtered on that key using the '{{last_key}}' placeholder, ordered by the key, LIMIT 50. SQL example: SELECT id, name FROM users WHERE id > {{last_key}} ORDER BY id ASC LIMIT 50. Used instead of OFFSET for deep pages. Leave EMPTY STRING for cursor-based pagination or when there is no such key.",
+							},
+							"keyset_field": &genai.Schema{
+								Type:        genai.TypeString,
+								Description: "Column used by keysetQuery (e.g. 'id', 'created_at'). Must be present in the SELECT list. Leave EMPTY STRING when keysetQuery is empty.",
+							},
 							"page_size": &genai.Schema{
 								Type:        genai.TypeNumber,
 								Description: "Number of records per page. Use 50.",
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	GlobalSearchTypeMessage     = "message" // Text search on the content of messages
+	GlobalSearchTypeQuery       = "query"   // Substring search on the queries of messages
+	GlobalSearchDefaultPageSize = 20
+	GlobalSearchMaxPageSize     = 100
+	GlobalSearchMaxKeywordLen   = 200 // Longest keyword accepted by the global search
+	GlobalSearchSnippetRadius   = 80  // Characters kept on each side of the match in a result snippet
+)
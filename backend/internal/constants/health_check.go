@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	// HealthCheckInterval is how often the health monitor checks every connected chat
+	HealthCheckInterval = 30 * time.Second
+	// HealthCheckTimeout bounds one health check, round trip and permission check together
+	HealthCheckTimeout = 10 * time.Second
+	// HealthCheckPermissionTables is how many known tables are tried before concluding nothing can be read
+	HealthCheckPermissionTables = 3
+)
@@ -0,0 +1,8 @@
+package constants
+
+// HiddenTableUnavailableMessage is the answer the LLM gives when the user asks about a table hidden from it
+const HiddenTableUnavailableMessage = "This table is not available in my knowledge base."
+
+// HiddenTableContext is added to the system LLM message when the user's message names a hidden table.
+// It does not name the table, the LLM only knows it from the user's own words.
+const HiddenTableContext = "\n\nThe user's latest message names a table that is hidden from your knowledge base. Do not generate queries for it, do not guess its columns and do not mention other hidden tables. Set assistantMessage to: \"" + HiddenTableUnavailableMessage + "\" and leave queries empty, unless the message also asks about tables in the schema."
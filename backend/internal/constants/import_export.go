@@ -11,6 +11,16 @@ const (
 
 var SupportedSchemaVersions = []string{"1.0.0"}
 
+// Chat Import/Export Constants
+const (
+	ChatExportVersion      = "1.0"
+	ChatExportPageSize     = 1000 // Messages fetched per page while exporting a chat
+	MaxChatImportSizeMB    = 50
+	MaxChatImportSizeBytes = MaxChatImportSizeMB * 1024 * 1024
+)
+
+var SupportedChatExportVersions = []string{"1.0"}
+
 // ExportFormat represents the complete exported dashboard structure
 type ExportFormat struct {
 	SchemaVersion string                 `json:"schemaVersion"`
@@ -0,0 +1,96 @@
+package constants
+
+import "time"
+
+// InfluxDB 3 HTTP API settings
+const (
+	InfluxDBDefaultPort = "8181"
+	// InfluxDBRequestTimeout bounds a single query or write request
+	InfluxDBRequestTimeout = 60 * time.Second
+	// InfluxDBExampleRecordsWindow limits example records to recent data, so sampling does not scan every Parquet file
+	InfluxDBExampleRecordsWindow = "7 days"
+)
+
+// GeminiInfluxDBPrompt is appended to the PostgreSQL prompt for InfluxDB connections.
+// InfluxDB 3 stores time series in measurements and is queried with SQL or InfluxQL over HTTP.
+const GeminiInfluxDBPrompt = `
+
+---
+### InfluxDB-Specific Rules (append to the SQL rules above)
+
+You are assisting an **InfluxDB 3** database — a columnar time-series database that is queried with SQL (Apache DataFusion dialect) or InfluxQL.
+The standard SQL rules above apply, but InfluxDB is NOT PostgreSQL. Where they differ, these rules win:
+
+1. **Data Model**
+   - Measurements are tables. The schema lists every measurement with its columns.
+   - Every measurement has a **time** column (timestamp, nanosecond precision). It is always present and is the primary way to filter and order data.
+   - **Tags** are indexed string columns that identify a series (e.g. host, region, sensor_id). Filter and group by tags; they are cheap to filter on.
+   - **Fields** are the measured values (float, integer, unsigned, string or boolean, e.g. usage_idle, temperature). Fields are not indexed; aggregate them, do not use them as grouping keys.
+   - The schema marks each column as tag, field or time. A series is one measurement + one set of tag values.
+   - There are no primary keys, foreign keys, joins across databases, or constraints. JOINs between measurements work in SQL but are rarely needed; prefer one measurement per query.
+
+2. **Always Bound Time**
+   - ALWAYS filter on time. A query without a time range reads every file of the measurement.
+   - Relative ranges:
+     - SQL: WHERE time > now() - INTERVAL '1 hour', WHERE time >= now() - INTERVAL '7 days'
+     - InfluxQL: WHERE time > now() - 1h, WHERE time >= now() - 7d
+   - Absolute ranges: WHERE time >= '2025-01-01T00:00:00Z' AND time < '2025-01-02T00:00:00Z'
+   - "Last 24 hours" means time > now() - 24h, not the calendar day.
+   - Quote identifiers with double quotes ("cpu", "usage_idle"), string literals with single quotes.
+
+3. **Downsampling and Gap Filling**
+   - InfluxQL (preferred for downsampling with gap filling):
+     SELECT MEAN("usage_idle") FROM "cpu" WHERE time > now() - 1d GROUP BY time(1h), "host" FILL(previous)
+     - GROUP BY time(interval) buckets rows by time, e.g. time(1m), time(5m), time(1h), time(1d). It requires a WHERE time condition.
+     - FILL(previous) repeats the last value in empty buckets. Other options: FILL(null) (default), FILL(none) (drop empty buckets), FILL(0) or any constant, FILL(linear).
+     - Every selected field must be aggregated (MEAN, MEDIAN, SUM, COUNT, MIN, MAX, FIRST, LAST, PERCENTILE(field, 95)) when GROUP BY time() is used.
+     - Tags in GROUP BY split the result per series: GROUP BY time(1h), "host".
+   - SQL equivalent:
+     SELECT date_bin(INTERVAL '1 hour', time) AS time, host, avg(usage_idle) AS usage_idle FROM cpu WHERE time > now() - INTERVAL '1 day' GROUP BY 1, host ORDER BY 1
+     - For gap filling in SQL, use date_bin_gapfill(INTERVAL '1 hour', time) with locf(avg(usage_idle)) (last observation carried forward, the same as FILL(previous)) or interpolate(avg(usage_idle)). date_bin_gapfill requires a bounded time range in WHERE.
+   - The system runs statements that start with SHOW or use GROUP BY time(...) or FILL(...) as InfluxQL, and everything else as SQL. Do not mix the two syntaxes in one query.
+
+4. **Functions and Syntax**
+   - SQL: now(), date_bin(), date_trunc('day', time), to_timestamp(), extract(), avg(), sum(), count(), min(), max(), median(), approx_percentile_cont(col, 0.95), selector_first(value, time), selector_last(value, time).
+   - InfluxQL: MEAN(), MEDIAN(), SUM(), COUNT(), MIN(), MAX(), FIRST(), LAST(), PERCENTILE(), DERIVATIVE(), NON_NEGATIVE_DERIVATIVE(), MOVING_AVERAGE(), DIFFERENCE().
+   - The latest value of each series: SQL SELECT DISTINCT ON is NOT supported, use selector_last() grouped by tags, or InfluxQL SELECT LAST("field") FROM "m" WHERE time > now() - 1h GROUP BY "tag".
+   - Discovery (InfluxQL): SHOW MEASUREMENTS, SHOW FIELD KEYS FROM "m", SHOW TAG KEYS FROM "m", SHOW TAG VALUES FROM "m" WITH KEY = "host".
+
+5. **Pagination**
+   - Paginate with LIMIT n OFFSET m and ALWAYS ORDER BY time (DESC for "latest" questions) so pages are stable:
+     SELECT time, host, usage_idle FROM cpu WHERE time > now() - INTERVAL '1 day' ORDER BY time DESC LIMIT 50 OFFSET offset_size
+   - Keep the same time filter on every page.
+   - For countQuery, use SELECT COUNT(*) FROM measurement with the same WHERE clause.
+
+6. **Writes Are Critical**
+   - InfluxDB 3 has no UPDATE, no row-level DELETE and no transactions. SQL INSERT, UPDATE, DELETE, ALTER and CREATE are not supported.
+   - Write points with line protocol, one point per line, prefixed with INSERT:
+     INSERT cpu,host=server01,region=us-west usage_idle=92.5,usage_user=3.1 1735689600000000000
+     - Format: measurement,tag1=value1,tag2=value2 field1=value1,field2=value2 [timestamp in nanoseconds]. Without a timestamp the server time is used.
+     - String field values are double-quoted (status="ok"), integers end with i (count=5i), booleans are true/false. Tag values are never quoted.
+     - Writing a point with the same measurement, tag set and timestamp OVERWRITES the existing field values. This is the only way to "update" data.
+   - Remove a whole measurement and all its data with: DROP MEASUREMENT "name"
+   - ALWAYS set isCritical: true for every INSERT and DROP MEASUREMENT.
+   - Writes cannot be rolled back: set canRollback: false and leave rollbackQuery and rollbackDependentQuery as empty strings. Explain in assistantMessage which points will be written or what will be deleted.
+`
+
+// InfluxDBVisualizationExtensions is appended to the PostgreSQL visualization prompt.
+const InfluxDBVisualizationExtensions = `
+
+InfluxDB-specific visualization guidance:
+- Results are time series: the "time" column is the natural X axis, tags identify the series and fields are the values.
+- Prefer line charts for fields over time, with one line per tag value (e.g. per host).
+- Always keep a time range and downsample with date_bin() or GROUP BY time() so charts do not plot raw points over long ranges.
+`
+
+func getInfluxDBNonTechInstructions() string {
+	return `
+
+**INFLUXDB SPECIFIC REQUIREMENTS**:
+
+1. ALWAYS filter on time (e.g. WHERE time > now() - INTERVAL '7 days'). When the user gives no range, use the last 24 hours and say so in plain words.
+2. Downsample long ranges (date_bin(INTERVAL '1 hour', time) or GROUP BY time(1h)) so results show a readable trend instead of thousands of raw points.
+3. Show tag values (like host or region names) as labels and alias aggregated fields with friendly names, e.g. avg(usage_idle) AS "Average Idle CPU".
+4. Order by time, newest first for "latest" or "current" questions.
+`
+}
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	LiveCountQueryTimeoutSeconds  = 2  // Counts are run on every keystroke, slower ones are given up on
+	LiveCountRateLimitPerMinute   = 30 // Live counts allowed per user per minute
+	LiveCountRateLimitBurst       = 5  // Live counts a user can run back to back before the per-minute rate applies
+	LiveCountRateLimitIdleMinutes = 10 // Per-user limiters unused for this long are dropped
+)
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	LLMBenchmarkMaxModels          = 10   // Maximum models compared in one benchmark
+	LLMBenchmarkMaxTestCases       = 20   // Maximum test cases run against each model
+	LLMBenchmarkMaxQuestionLength  = 1000 // Maximum length of a test case's question
+	LLMBenchmarkCallTimeoutSeconds = 120  // Timeout of a single LLM call
+	LLMBenchmarkTimeoutMinutes     = 15   // Timeout of the whole benchmark
+)
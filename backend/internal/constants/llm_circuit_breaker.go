@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	// LLMCircuitBreakerFailureThreshold is how many rate limit, unavailable or network errors open a provider's circuit
+	LLMCircuitBreakerFailureThreshold = 5
+	// LLMCircuitBreakerFailureWindow is how far back failures are counted towards the threshold
+	LLMCircuitBreakerFailureWindow = 60 * time.Second
+	// LLMCircuitBreakerCooldown is how long an open circuit rejects calls before one probe call is let through
+	LLMCircuitBreakerCooldown = 30 * time.Second
+)
@@ -0,0 +1,52 @@
+package constants
+
+import "strings"
+
+// MaxLLMFallbackChainLength caps the models tried for one message, each failed attempt adds latency
+const MaxLLMFallbackChainLength = 5
+
+// LLMFallbackErrorMarkers are substrings of LLM errors worth retrying with another model:
+// rate limits (429), an unavailable or overloaded service (503) and network errors
+var LLMFallbackErrorMarkers = []string{
+	"429",
+	"too many requests",
+	"rate limit",
+	"resource_exhausted",
+	"503",
+	"service unavailable",
+	"overloaded",
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+}
+
+// defaultLLMFallbackChain is set from LLM_DEFAULT_FALLBACK_CHAIN and used by chats without their own fallback chain
+var defaultLLMFallbackChain []string
+
+// SetDefaultLLMFallbackChain sets the model IDs tried in order when the selected model fails
+func SetDefaultLLMFallbackChain(modelIDs []string) {
+	defaultLLMFallbackChain = modelIDs
+}
+
+// GetDefaultLLMFallbackChain returns the model IDs of LLM_DEFAULT_FALLBACK_CHAIN
+func GetDefaultLLMFallbackChain() []string {
+	return defaultLLMFallbackChain
+}
+
+// ParseLLMFallbackChain splits a comma-separated list of model IDs, dropping blanks and duplicates
+func ParseLLMFallbackChain(value string) []string {
+	var modelIDs []string
+	seen := make(map[string]bool)
+	for _, modelID := range strings.Split(value, ",") {
+		modelID = strings.TrimSpace(modelID)
+		if modelID == "" || seen[modelID] {
+			continue
+		}
+		seen[modelID] = true
+		modelIDs = append(modelIDs, modelID)
+	}
+	return modelIDs
+}
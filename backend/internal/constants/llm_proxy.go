@@ -0,0 +1,21 @@
+package constants
+
+// Request signing for enterprise proxies in front of the LLM APIs (LLM_PROXY_URL)
+const (
+	LLMProxySigningAlgorithmHMACSHA256 = "hmac-sha256"
+	LLMProxySigningAlgorithmHMACSHA512 = "hmac-sha512"
+	DefaultLLMProxySigningAlgorithm    = LLMProxySigningAlgorithmHMACSHA256
+
+	LLMProxyTimestampHeader = "X-NeoBase-Timestamp" // Unix seconds, signed together with the body so the proxy can reject replays
+	LLMProxySignatureHeader = "X-NeoBase-Signature" // Hex encoded HMAC of timestamp + body
+)
+
+// IsValidLLMProxySigningAlgorithm reports whether LLM_PROXY_SIGNING_ALGORITHM is supported
+func IsValidLLMProxySigningAlgorithm(algorithm string) bool {
+	return algorithm == LLMProxySigningAlgorithmHMACSHA256 || algorithm == LLMProxySigningAlgorithmHMACSHA512
+}
+
+// Default base URLs of the LLM APIs, replaced by LLM_PROXY_URL when set
+const (
+	ClaudeAPIBaseURL = "https://api.anthropic.com"
+)
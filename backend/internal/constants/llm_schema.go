@@ -31,6 +31,12 @@ type QueryInfo struct {
 	RollbackDependentQuery string                    `json:"rollbackDependentQuery,omitempty"`
 }
 
+// KeysetLastKeyPlaceholder is replaced with the previous page's last key value in keyset pagination queries
+const KeysetLastKeyPlaceholder = "{{last_key}}"
+
+// OffsetSizePlaceholder is replaced with the row offset in offset-based pagination queries
+const OffsetSizePlaceholder = "offset_size"
+
 type Pagination struct {
 	TotalRecordsCount *int    `json:"total_records_count"` // Total number of records that the original query returns, found by running the countQuery
 	PaginatedQuery    *string `json:"paginated_query"`     // Paginated version of the query. For cursor-based: use {{cursor_value}} placeholder. For offset-based (fallback): use offset_size placeholder.
@@ -39,4 +45,7 @@ type Pagination struct {
 	CursorField     *string `json:"cursor_field,omitempty"`     // Field used as the pagination cursor (e.g. "_id", "id", "created_at"). Empty for offset-based.
 	CursorDirection *string `json:"cursor_direction,omitempty"` // "ASC" or "DESC"
 	PageSize        *int    `json:"page_size,omitempty"`        // Number of records per page (default 50)
+	// Keyset pagination fields (alternative to offset_size for deep pages)
+	KeysetQuery *string `json:"keysetQuery,omitempty"`  // Query filtered on keyset_field > {{last_key}}. Empty when not applicable.
+	KeysetField *string `json:"keyset_field,omitempty"` // Field whose last value is substituted for {{last_key}}
 }
@@ -10,6 +10,7 @@ const (
 	Gemini = "gemini"
 	Claude = "claude"
 	Ollama = "ollama"
+	Cohere = "cohere"
 )
 
 // GetLLMResponseSchema returns the appropriate response schema based on the LLM provider
@@ -24,6 +25,8 @@ func GetLLMResponseSchema(provider string, dbType string) interface{} {
 		return ClaudeLLMResponseSchemaJSON
 	case Ollama:
 		return OllamaLLMResponseSchemaJSON
+	case Cohere:
+		return CohereLLMResponseSchemaJSON
 	default:
 		return OpenAILLMResponseSchema
 	}
@@ -64,20 +67,83 @@ func getDatabasePrompt(dbType string) string {
 		return ClickhousePrompt
 	case DatabaseTypeMongoDB:
 		return MongoDBPrompt
+	case DatabaseTypeFerretDB:
+		// Replace the opening identity line so the LLM knows it is a FerretDB assistant,
+		// not a generic MongoDB assistant, while keeping all MongoDB rules intact.
+		return "You are NeoBase AI, a FerretDB database assistant. FerretDB is an open-source MongoDB-compatible database that stores documents in PostgreSQL. Your task is to generate & manage safe, efficient, and schema-aware MongoDB queries and aggregations based on user requests. Follow these rules meticulously:" +
+			MongoDBPrompt[strings.Index(MongoDBPrompt, "\n"):] +
+			GeminiFerretDBPrompt
+	case DatabaseTypeAirtable:
+		return GeminiAirtablePrompt
+	case DatabaseTypeTemporal:
+		return GeminiTemporalPrompt
+	case DatabaseTypePocketBase:
+		return GeminiPocketBasePrompt
+	case DatabaseTypeNATS:
+		return GeminiNATSPrompt
+	case DatabaseTypeFirestore:
+		return GeminiFirestorePrompt
+	case DatabaseTypeYugabyteDBCQL:
+		return GeminiYugabyteDBCQLPrompt
 	case DatabaseTypeTimescaleDB:
 		// Replace the opening identity line so the LLM knows it is a TimescaleDB assistant,
 		// not a generic PostgreSQL assistant, while keeping all PostgreSQL rules intact.
 		return "You are NeoBase AI, a TimescaleDB database assistant. TimescaleDB is a PostgreSQL extension optimised for time-series data. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
 			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
 			TimescaleDBExtensions
+	case DatabaseTypeSupabase:
+		// Replace the opening identity line so the LLM knows it is a Supabase assistant,
+		// not a generic PostgreSQL assistant, while keeping all PostgreSQL rules intact.
+		return "You are NeoBase AI, a Supabase database assistant. Supabase is a hosted PostgreSQL platform with Row Level Security, built-in auth and storage. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
+			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
+			GeminiSupabasePrompt
+	case DatabaseTypeNeon:
+		// Replace the opening identity line so the LLM knows it is a Neon assistant,
+		// not a generic PostgreSQL assistant, while keeping all PostgreSQL rules intact.
+		return "You are NeoBase AI, a Neon database assistant. Neon is serverless PostgreSQL with database branching and computes that scale to zero. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
+			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
+			GeminiNeonPrompt
+	case DatabaseTypeTrino:
+		// Trino speaks ANSI SQL, so reuse the PostgreSQL rules with a Trino identity line
+		// and the Trino-specific rules appended.
+		return "You are NeoBase AI, a Trino database assistant. Trino is a distributed SQL query engine for data lakes (Hive, Iceberg, Delta Lake) and federated sources. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
+			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
+			GeminiTrinoPrompt
+	case DatabaseTypeOracle:
+		// Replace the opening identity line so the LLM knows it is an Oracle assistant,
+		// and append the Oracle dialect rules, which override the PostgreSQL ones.
+		return "You are NeoBase AI, an Oracle Database assistant. Oracle Database is an enterprise relational database with its own SQL dialect and PL/SQL. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
+			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
+			GeminiOraclePrompt
+	case DatabaseTypeInfluxDB:
+		// InfluxDB 3 is queried with SQL, so reuse the PostgreSQL rules with an InfluxDB identity line
+		// and append the time-series rules, which override the PostgreSQL ones.
+		return "You are NeoBase AI, an InfluxDB database assistant. InfluxDB 3 is a time-series database queried with SQL and InfluxQL. Your task is to generate & manage safe, efficient, and schema-aware SQL and InfluxQL queries, results based on user requests." +
+			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
+			GeminiInfluxDBPrompt
+	case DatabaseTypeCloudflareD1:
+		// D1 is SQLite, so reuse the PostgreSQL rules with a D1 identity line
+		// and append the SQLite dialect and D1 limits, which override the PostgreSQL ones.
+		return "You are NeoBase AI, a Cloudflare D1 database assistant. Cloudflare D1 is a serverless SQLite database queried over Cloudflare's HTTP API. Your task is to generate & manage safe, efficient, and schema-aware SQLite queries, results based on user requests." +
+			PostgreSQLPrompt[strings.Index(PostgreSQLPrompt, "\n"):] +
+			GeminiCloudflareD1Prompt
 	case DatabaseTypeStarRocks:
 		// Replace the opening identity line so the LLM knows it is a StarRocks assistant,
 		// not a generic MySQL assistant, while keeping all MySQL rules intact.
 		return "You are NeoBase AI, a StarRocks database assistant. StarRocks is a MySQL-wire-compatible MPP OLAP database optimised for large-scale real-time analytics. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
 			MySQLPrompt[strings.Index(MySQLPrompt, "\n"):] +
 			StarRocksExtensions
+	case DatabaseTypePlanetscale:
+		// Replace the opening identity line so the LLM knows it is a PlanetScale assistant,
+		// not a generic MySQL assistant, while keeping all MySQL rules intact.
+		return "You are NeoBase AI, a PlanetScale database assistant. PlanetScale is a MySQL-compatible serverless database built on Vitess with git-like branches. Your task is to generate & manage safe, efficient, and schema-aware SQL queries, results based on user requests." +
+			MySQLPrompt[strings.Index(MySQLPrompt, "\n"):] +
+			GeminiPlanetscalePrompt
 	case DatabaseTypeSpreadsheet:
-		return PostgreSQLPrompt // Use PostgreSQL schema since spreadsheet uses PostgreSQL internally
+		// Spreadsheets use PostgreSQL internally, the PostgreSQL rules are extended with the spreadsheet ones
+		return SpreadsheetPrompt
+	case DatabaseTypeGoogleSheets:
+		return GeminiGoogleSheetsPrompt
 	default:
 		return PostgreSQLPrompt // Default to PostgreSQL
 	}
@@ -130,11 +196,27 @@ Examples of CORRECT assistantMessage:
 
 	// Add database-specific instructions
 	switch dbType {
-	case DatabaseTypeMongoDB:
+	case DatabaseTypeMongoDB, DatabaseTypeFerretDB:
 		return baseInstructions + getMongoDBNonTechInstructions()
-	case DatabaseTypePostgreSQL, DatabaseTypeYugabyteDB, DatabaseTypeTimescaleDB:
+	case DatabaseTypeAirtable:
+		return baseInstructions + getAirtableNonTechInstructions()
+	case DatabaseTypeTemporal:
+		return baseInstructions + getTemporalNonTechInstructions()
+	case DatabaseTypePocketBase:
+		return baseInstructions + getPocketBaseNonTechInstructions()
+	case DatabaseTypeNATS:
+		return baseInstructions + getNATSNonTechInstructions()
+	case DatabaseTypeFirestore:
+		return baseInstructions + getFirestoreNonTechInstructions()
+	case DatabaseTypeYugabyteDBCQL:
+		return baseInstructions + getYugabyteDBCQLNonTechInstructions()
+	case DatabaseTypeInfluxDB:
+		return baseInstructions + getInfluxDBNonTechInstructions()
+	case DatabaseTypeCloudflareD1:
+		return baseInstructions + getCloudflareD1NonTechInstructions()
+	case DatabaseTypePostgreSQL, DatabaseTypeYugabyteDB, DatabaseTypeTimescaleDB, DatabaseTypeSupabase, DatabaseTypeNeon, DatabaseTypeTrino, DatabaseTypeOracle:
 		return baseInstructions + getPostgreSQLNonTechInstructions()
-	case DatabaseTypeMySQL, DatabaseTypeStarRocks:
+	case DatabaseTypeMySQL, DatabaseTypeStarRocks, DatabaseTypePlanetscale:
 		return baseInstructions + getMySQLNonTechInstructions()
 	case DatabaseTypeClickhouse:
 		return baseInstructions + getClickhouseNonTechInstructions()
@@ -154,6 +236,8 @@ func GetRecommendationsSchema(provider string) interface{} {
 		return ClaudeRecommendationsSchemaJSON
 	case Ollama:
 		return OllamaRecommendationsSchemaJSON
+	case Cohere:
+		return CohereRecommendationsSchemaJSON
 	default:
 		return OpenAIRecommendationsResponseSchema // Default to OpenAI
 	}
@@ -172,14 +256,42 @@ func GetVisualizationPrompt(dbType string) string {
 		return MySQLVisualizationPrompt
 	case DatabaseTypeYugabyteDB:
 		return YugabyteVisualizationPrompt
+	case DatabaseTypeYugabyteDBCQL:
+		return YugabyteVisualizationPrompt + YugabyteDBCQLVisualizationExtensions
 	case DatabaseTypeClickhouse:
 		return ClickhouseVisualizationPrompt
 	case DatabaseTypeMongoDB:
 		return MongoDBVisualizationPrompt
+	case DatabaseTypeFerretDB:
+		return MongoDBVisualizationPrompt + FerretDBVisualizationExtensions
+	case DatabaseTypeAirtable:
+		return MongoDBVisualizationPrompt + AirtableVisualizationExtensions
+	case DatabaseTypeTemporal:
+		return MongoDBVisualizationPrompt + TemporalVisualizationExtensions
+	case DatabaseTypePocketBase:
+		return MongoDBVisualizationPrompt + PocketBaseVisualizationExtensions
+	case DatabaseTypeNATS:
+		return MongoDBVisualizationPrompt + NATSVisualizationExtensions
+	case DatabaseTypeFirestore:
+		return MongoDBVisualizationPrompt + FirestoreVisualizationExtensions
 	case DatabaseTypeTimescaleDB:
 		return PostgreSQLVisualizationPrompt + TimescaleDBVisualizationExtensions
+	case DatabaseTypeSupabase:
+		return PostgreSQLVisualizationPrompt + SupabaseVisualizationExtensions
+	case DatabaseTypeNeon:
+		return PostgreSQLVisualizationPrompt
+	case DatabaseTypeTrino:
+		return PostgreSQLVisualizationPrompt + TrinoVisualizationExtensions
+	case DatabaseTypeOracle:
+		return PostgreSQLVisualizationPrompt + OracleVisualizationExtensions
+	case DatabaseTypeInfluxDB:
+		return PostgreSQLVisualizationPrompt + InfluxDBVisualizationExtensions
+	case DatabaseTypeCloudflareD1:
+		return PostgreSQLVisualizationPrompt + CloudflareD1VisualizationExtensions
 	case DatabaseTypeStarRocks:
 		return MySQLVisualizationPrompt + StarRocksVisualizationExtensions
+	case DatabaseTypePlanetscale:
+		return MySQLVisualizationPrompt + PlanetscaleVisualizationExtensions
 	case DatabaseTypeSpreadsheet:
 		return PostgreSQLVisualizationPrompt // Use PostgreSQL prompt for spreadsheets
 	default:
@@ -202,6 +314,12 @@ The recommendations should be:
 - User-Friendly & Meaningful that user should understand
 Consider the database type, the schema and any recent conversation context when generating recommendations.
 
+The system message may include a "Recommendation Context" JSON object with usage signals:
+- frequent_tables: tables the user queried most in recent messages, with how often. Make at least a third of the recommendations about these tables and reference their REAL column names from the schema (e.g. "Show orders grouped by status" only if orders.status exists). Never invent columns.
+- time_of_day and suggested_focus: in the morning favour reports and summaries, in the afternoon favour debugging questions (anomalies, duplicates, missing or inconsistent values), in the evening favour open exploration.
+- has_empty_results and empty_result_tables: recent queries returned 0 rows, which often means a filter was too strict. Include recommendations that check value distributions, date ranges or distinct values of the filtered columns on those tables.
+- database_type: keep the wording natural for that database (collections and documents for MongoDB, tables and rows otherwise).
+
 Response format should be JSON with this structure:
 {
   "recommendations": [
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	MagicQueryMaxQuestionLength    = 2000 // Maximum length of a magic query question
+	MagicQueryTimeoutSeconds       = 120  // Timeout of the whole round trip: connecting, fetching the schema, the LLM call and running the queries
+	MagicQueryRateLimitPerMinute   = 5    // Magic queries allowed per IP address per minute
+	MagicQueryRateLimitBurst       = 5    // Magic queries an IP address can run back to back before the per-minute rate applies
+	MagicQueryRateLimitIdleMinutes = 10   // Per-IP limiters unused for this long are dropped
+)
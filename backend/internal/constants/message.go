@@ -7,3 +7,13 @@ const (
 	MessageTypeAssistant MessageType = "assistant"
 	MessageTypeSystem    MessageType = "system"
 )
+
+const (
+	// BulkMessageDeleteAsyncThreshold is the number of matched messages above which a filtered deletion runs in the background
+	BulkMessageDeleteAsyncThreshold = 100
+	// BulkMessageDeleteBatchSize is the number of messages deleted per batch by a background deletion
+	BulkMessageDeleteBatchSize = 500
+
+	BulkMessageDeleteStatusCompleted = "completed"
+	BulkMessageDeleteStatusRunning   = "running"
+)
@@ -0,0 +1,16 @@
+package constants
+
+const (
+	FeedbackIssueWrongTable       = "wrong_table"
+	FeedbackIssueWrongQuery       = "wrong_query"
+	FeedbackIssueWrongExplanation = "wrong_explanation"
+	FeedbackIssueTooSlow          = "too_slow"
+	FeedbackIssueCorrect          = "correct"
+
+	FeedbackMinRating = 1
+	FeedbackMaxRating = 5
+	// FeedbackLowRatingThreshold flags AI responses rated at or below it for prompt fine-tuning analysis
+	FeedbackLowRatingThreshold = 2
+	// FeedbackMaxFreeTextLength is the maximum length of the free text comment of a feedback
+	FeedbackMaxFreeTextLength = 2000
+)
@@ -0,0 +1,20 @@
+package constants
+
+const (
+	MessageReactionThumbsUp   = "👍"
+	MessageReactionThumbsDown = "👎"
+	MessageReactionFire       = "🔥"
+)
+
+// AllowedMessageReactions are the emojis users can react to a message with
+var AllowedMessageReactions = []string{MessageReactionThumbsUp, MessageReactionThumbsDown, MessageReactionFire}
+
+// IsAllowedMessageReaction reports whether an emoji can be used as a message reaction
+func IsAllowedMessageReaction(emoji string) bool {
+	for _, allowed := range AllowedMessageReactions {
+		if emoji == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package constants
+
+// Migration risk levels, assigned to AI generated DDL queries before they are executed
+const (
+	MigrationRiskLow    = "low"
+	MigrationRiskMedium = "medium"
+	MigrationRiskHigh   = "high"
+)
+
+// Estimated lock durations reported with a migration risk
+const (
+	MigrationLockNone         = "none"
+	MigrationLockMetadataOnly = "milliseconds (metadata-only change)"
+	MigrationLockTableSize    = "proportional to table size"
+	MigrationLockUntilDone    = "until the statement completes"
+)
+
+// TruncateConfirmationMismatchMessage is returned when the text typed to confirm a TRUNCATE is not the truncated table names
+const TruncateConfirmationMismatchMessage = "Confirmation text does not match. Type the table name exactly to confirm."
+
+// MigrationRiskRank orders risk levels so the riskiest statement of a query wins
+var MigrationRiskRank = map[string]int{
+	MigrationRiskLow:    1,
+	MigrationRiskMedium: 2,
+	MigrationRiskHigh:   3,
+}
@@ -1,5 +1,7 @@
 package constants
 
+import "regexp"
+
 // MongoDB specific prompt for the intial AI response
 const MongoDBPrompt = `You are NeoBase AI, a MongoDB database assistant, you're an AI database administrator. Your task is to generate & manage safe, efficient, and schema-aware MongoDB queries and aggregations based on user requests. Follow these rules meticulously:
 
@@ -128,6 +130,10 @@ Also, if the rollback is hard to achieve as the AI requires actual value of the
 - Limit to Max 2 buttons per response to avoid overwhelming the user.
 - **NEVER generate action buttons for pagination** (e.g., "Show next N records", "Load more", "Next page"). Pagination is handled automatically by the system UI.
 
+**Replica Set Reads**:
+- The connection may be configured with a read preference (secondary, secondaryPreferred, nearest) that serves reads from replica set secondaries. Writes always go to the primary.
+- Reads from secondaries may lag behind writes: a find or aggregate run right after an insert, update or delete can miss that change. Mention this in assistantMessage when the user reads data they just modified.
+
 For MongoDB queries, use the standard MongoDB query syntax. For example:
 - db.collection.find({field: value})
 - db.collection.insertOne({field: value})
@@ -472,3 +478,23 @@ CRITICAL - The 'assistantMessage' MUST be simple and non-technical:
 - ❌ WRONG: "I'm fetching the feedback with user details, limiting to 1 result"
 `
 }
+
+// MongoDB read preferences, matching the modes of the driver's readpref package
+const (
+	MongoDBReadPreferencePrimary            = "primary"
+	MongoDBReadPreferencePrimaryPreferred   = "primaryPreferred"
+	MongoDBReadPreferenceSecondary          = "secondary"
+	MongoDBReadPreferenceSecondaryPreferred = "secondaryPreferred"
+	MongoDBReadPreferenceNearest            = "nearest"
+)
+
+// ActionUseSecondaryReads is the action button that opens the connection settings to pick a secondary read preference
+const ActionUseSecondaryReads = "use_secondary_reads"
+
+// mongoDBFindPattern matches db.collection.find(...) and findOne(...)
+var mongoDBFindPattern = regexp.MustCompile(`^\s*db\.[^.(\s]+\.find(One)?\s*\(`)
+
+// IsMongoDBFindQuery reports whether a MongoDB query is a plain find, which is safe to serve from a secondary.
+func IsMongoDBFindQuery(query string) bool {
+	return mongoDBFindPattern.MatchString(query)
+}
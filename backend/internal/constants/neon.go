@@ -0,0 +1,78 @@
+package constants
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	NeonHostSuffix             = ".neon.tech"    // Hostname suffix of every Neon compute endpoint
+	NeonPoolerHostSuffix       = "-pooler"       // Suffix of the endpoint ID in hostnames of Neon's PgBouncer pooler
+	NeonMaxPoolConnections     = 10              // Neon computes are small, keep the pgxpool below their connection limit
+	NeonConnectMaxAttempts     = 3               // A suspended compute can take a few seconds to wake up
+	NeonConnectRetryBaseDelay  = 1 * time.Second // Doubled after every failed attempt
+	NeonConnectAttemptTimeout  = 15 * time.Second
+	NeonPoolMaxConnIdleTime    = 5 * time.Minute // Release idle connections so the compute can scale to zero
+	NeonPoolHealthCheckPeriod  = 1 * time.Minute
+	NeonEndpointOptionTemplate = "endpoint=%s" // Sent in the options parameter for clients without SNI support
+)
+
+// IsNeonHost reports whether a hostname belongs to a Neon compute endpoint
+func IsNeonHost(host string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimSpace(host)), NeonHostSuffix)
+}
+
+// GetNeonEndpointID returns the endpoint ID of a Neon hostname, e.g. "ep-cool-darkness-123456" for
+// "ep-cool-darkness-123456-pooler.us-east-2.aws.neon.tech", or "" if the host is not a Neon host
+func GetNeonEndpointID(host string) string {
+	if !IsNeonHost(host) {
+		return ""
+	}
+	endpointID := strings.ToLower(strings.TrimSpace(host))
+	if idx := strings.Index(endpointID, "."); idx >= 0 {
+		endpointID = endpointID[:idx]
+	}
+	return strings.TrimSuffix(endpointID, NeonPoolerHostSuffix)
+}
+
+// GeminiNeonPrompt is appended to the PostgreSQL prompt for Neon connections.
+// Neon is serverless PostgreSQL with copy-on-write branches and computes that scale to zero.
+const GeminiNeonPrompt = `
+
+---
+### Neon-Specific Rules (append to PostgreSQL rules above)
+
+You are assisting a **Neon** database — serverless PostgreSQL with database branching and autoscaling computes.
+All standard PostgreSQL rules above apply. Additionally:
+
+1. **Branching**
+   - Neon databases can be branched like git: a branch is an instant copy-on-write clone of its parent's data and schema.
+   - Every branch has its own compute endpoint and therefore its OWN connection string. Queries only see the data of the branch this chat is connected to.
+   - When the user wants to try a risky migration or bulk change, suggest running it on a separate branch first; branches are created in the Neon console, CLI or API, not with SQL.
+   - Never claim that a change made here is visible on other branches.
+
+2. **Suspended Computes**
+   - Idle computes are suspended and resume on the next connection. The first query after a pause can take a few seconds, and a connection attempt may fail while the compute is waking up.
+   - Connection attempts are retried automatically; if the user reports a timeout or "compute is suspended" error, explain that retrying usually succeeds once the compute is active.
+   - Session state (temporary tables, SET parameters, prepared statements) is lost when the compute is suspended; do not rely on it across queries.
+
+3. **Connection Pooling**
+   - Pooled connections (hostnames containing "-pooler") go through PgBouncer in transaction mode: avoid session-level features such as SET without LOCAL, LISTEN/NOTIFY, advisory session locks and WITH HOLD cursors.
+
+4. **Extensions and Limits**
+   - Check installed extensions with: SELECT extname, extversion FROM pg_extension
+   - Neon supports pgvector, PostGIS, pg_stat_statements and most popular extensions; CREATE EXTENSION may be used when the user asks.
+   - Prefer queries with selective filters and LIMIT: compute size is limited and autoscaling takes time to react to sudden load.
+`
+
+// GetNeonConnectionContext returns the branch context of a Neon connection
+func GetNeonConnectionContext(host string) string {
+	neonContext := "\n--- Neon Connection Context ---\n"
+	if endpointID := GetNeonEndpointID(host); endpointID != "" {
+		neonContext += "This chat is connected to the Neon compute endpoint " + endpointID + ". Each branch has its own endpoint, so results only reflect the branch served by this endpoint.\n"
+	}
+	if strings.Contains(strings.ToLower(host), NeonPoolerHostSuffix+".") {
+		neonContext += "The connection goes through Neon's PgBouncer pooler in transaction mode; avoid session-level state.\n"
+	}
+	return neonContext
+}
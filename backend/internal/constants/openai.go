@@ -236,6 +236,14 @@ const OpenAILLMResponseSchema = `{
                                "type": "string",
                                "description": "Field/column used as the pagination cursor (e.g. 'id', 'created_at', 'createdAt'). Must be present in the SELECT/projection result. Leave EMPTY STRING for offset-based pagination."
                            },
+                           "keysetQuery": {
+                               "type": "string",
+                               "description": "ONLY when paginatedQuery is OFFSET-BASED (uses offset_size) and the result is ordered by a unique, ever-increasing key (primary key or created_at): the same query filtered on that key using the '{{last_key}}' placeholder, ordered by the key, LIMIT 50. SQL example: SELECT id, name FROM users WHERE id > {{last_key}} ORDER BY id ASC LIMIT 50. Used instead of OFFSET for deep pages. Leave EMPTY STRING for cursor-based pagination or when there is no such key."
+                           },
+                           "keyset_field": {
+                               "type": "string",
+                               "description": "Column used by keysetQuery (e.g. 'id', 'created_at'). Must be present in the SELECT list. Leave EMPTY STRING when keysetQuery is empty."
+                           },
                            "page_size": {
                                "type": "number",
                                "description": "Number of records per page. Use 50."
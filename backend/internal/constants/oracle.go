@@ -0,0 +1,85 @@
+package constants
+
+// Oracle connection defaults
+const (
+	OracleDefaultPort   = "1521"
+	OracleSavepointName = "neobase_query" // Savepoint set before a query runs so it can be rolled back with ROLLBACK TO
+)
+
+// GeminiOraclePrompt is appended to the PostgreSQL prompt for Oracle connections.
+// Oracle Database 19c+ speaks SQL with its own dialect (PL/SQL), types and pagination syntax.
+const GeminiOraclePrompt = `
+
+---
+### Oracle-Specific Rules (append to the SQL rules above)
+
+You are assisting an **Oracle Database** (19c or later). The standard SQL rules above apply, but Oracle is NOT PostgreSQL. Where they differ, these rules win:
+
+1. **Identifiers and Schemas**
+   - Unquoted identifiers are stored in UPPERCASE. Write table and column names unquoted, or quote them in uppercase ("ORDERS"."CUSTOMER_ID"). Only quote lowercase names when the schema shows them in lowercase.
+   - Tables belong to the connecting user's schema. Prefix tables of other schemas with the owner: HR.EMPLOYEES.
+   - Table aliases must NOT use AS: FROM orders o (correct), FROM orders AS o (syntax error). Column aliases may use AS.
+   - There is no LIMIT, no ILIKE, no boolean column type in SQL (before 23ai), and no SELECT without FROM.
+
+2. **The DUAL Table**
+   - Every SELECT needs a FROM clause. Use the one-row DUAL table for expressions: SELECT SYSDATE FROM DUAL, SELECT my_seq.NEXTVAL FROM DUAL.
+
+3. **Row Limiting: ROWNUM vs FETCH FIRST**
+   - Use FETCH FIRST n ROWS ONLY (12c+) to limit results: SELECT * FROM orders ORDER BY created_at DESC FETCH FIRST 10 ROWS ONLY.
+   - ROWNUM is assigned BEFORE ORDER BY, so WHERE ROWNUM <= 10 with ORDER BY returns 10 arbitrary rows sorted, not the top 10. Only use ROWNUM in an outer query around an ordered subquery, and prefer FETCH FIRST.
+   - Use FETCH FIRST n ROWS WITH TIES to include ties on the last row.
+
+4. **Pagination**
+   - Paginate with OFFSET n ROWS FETCH NEXT 50 ROWS ONLY after the ORDER BY:
+     SELECT * FROM orders ORDER BY id OFFSET offset_size ROWS FETCH NEXT 50 ROWS ONLY
+   - The paginatedQuery must use this syntax with offset_size as the placeholder, never LIMIT ... OFFSET ....
+   - The countQuery is SELECT COUNT(*) AS total_count FROM (original query without FETCH/OFFSET). Do not alias the subquery with AS.
+
+5. **NULL Handling: NVL vs COALESCE**
+   - NVL(a, b) takes exactly two arguments and always evaluates both. COALESCE(a, b, c, ...) takes any number of arguments, stops at the first non-NULL one and requires matching types. Prefer COALESCE; NVL is fine for simple two-argument defaults.
+   - NVL2(expr, if_not_null, if_null) and DECODE(expr, search, result, ..., default) are common in existing Oracle code.
+   - Oracle treats the empty string '' as NULL: WHERE col = '' never matches; use WHERE col IS NULL.
+
+6. **Data Types**
+   - VARCHAR2(n) is the standard string type (never VARCHAR). Use NVARCHAR2 for Unicode data and CLOB for large text.
+   - NUMBER(p, s) holds all numerics: NUMBER(10) for integers, NUMBER(12, 2) for money, NUMBER without precision for any number.
+   - DATE stores date AND time down to the second. TIMESTAMP adds fractional seconds; TIMESTAMP WITH TIME ZONE keeps the zone.
+   - Booleans are usually NUMBER(1) or CHAR(1) ('Y'/'N') columns.
+
+7. **Dates and Times**
+   - SYSDATE returns the current DATE (date and time), SYSTIMESTAMP the current TIMESTAMP WITH TIME ZONE.
+   - TRUNC(SYSDATE) is midnight today. TRUNC(date_col, 'MM') truncates to the month, 'IW' to the ISO week, 'YYYY' to the year.
+   - Date arithmetic is in days: SYSDATE - 7 is a week ago; use ADD_MONTHS(SYSDATE, -1) for months, or INTERVAL '1' HOUR.
+   - Convert with TO_DATE('2024-01-15', 'YYYY-MM-DD'), TO_CHAR(date_col, 'YYYY-MM-DD'), TO_TIMESTAMP(); use DATE '2024-01-15' literals.
+
+8. **Hierarchical Queries (CONNECT BY)**
+   - Walk parent/child trees with CONNECT BY:
+     SELECT employee_id, manager_id, LEVEL, SYS_CONNECT_BY_PATH(last_name, '/') AS path
+     FROM employees
+     START WITH manager_id IS NULL
+     CONNECT BY PRIOR employee_id = manager_id
+     ORDER SIBLINGS BY last_name
+   - LEVEL is the depth, CONNECT_BY_ISLEAF flags leaves, and NOCYCLE protects against loops. Recursive WITH clauses also work.
+
+9. **Writes, Transactions and Rollback**
+   - DML (INSERT, UPDATE, DELETE, MERGE) runs in a transaction. NeoBase sets a SAVEPOINT before running a query, so a failed or cancelled query is undone with ROLLBACK TO SAVEPOINT.
+   - For rollbackQuery, write the inverse DML (e.g. an UPDATE restoring the previous values). When a query has several statements, they may be wrapped as: SAVEPOINT before_change; <statements>; and undone with ROLLBACK TO SAVEPOINT before_change.
+   - DDL (CREATE, ALTER, DROP, TRUNCATE) commits implicitly and CANNOT be rolled back with ROLLBACK or savepoints. Mark DDL as isCritical: true, and provide an inverse DDL statement as rollbackQuery only when one exists.
+   - Use MERGE INTO ... USING ... ON (...) WHEN MATCHED THEN UPDATE WHEN NOT MATCHED THEN INSERT for upserts; there is no ON CONFLICT.
+   - Generate ids with sequences (my_seq.NEXTVAL) or identity columns (GENERATED BY DEFAULT AS IDENTITY).
+   - Anonymous PL/SQL blocks (BEGIN ... END;) must be sent as a single statement ending with END;.
+
+10. **Functions**
+   - Strings: || for concatenation (CONCAT takes only two arguments), SUBSTR, INSTR, LENGTH, UPPER, LOWER, TRIM, LPAD, REGEXP_LIKE, REGEXP_SUBSTR, REGEXP_REPLACE. Case-insensitive search: UPPER(col) LIKE UPPER('%value%').
+   - Aggregates: LISTAGG(col, ', ') WITHIN GROUP (ORDER BY col) instead of STRING_AGG; analytic functions (ROW_NUMBER() OVER (...), RANK, LAG, LEAD) work as usual.
+   - Data dictionary: ALL_TABLES, ALL_TAB_COLUMNS, ALL_CONSTRAINTS, ALL_INDEXES, USER_TABLES.
+`
+
+// OracleVisualizationExtensions is appended to the PostgreSQL visualization prompt.
+const OracleVisualizationExtensions = `
+
+Oracle-specific visualization guidance:
+- Use Oracle syntax: FETCH FIRST n ROWS ONLY instead of LIMIT, no AS before table aliases, and FROM DUAL for expressions.
+- Bucket dates with TRUNC(date_col, 'DD' | 'IW' | 'MM' | 'YYYY') and label them with TO_CHAR(date_col, 'YYYY-MM-DD').
+- Unquoted identifiers are returned in UPPERCASE, so alias result columns in double quotes ("month", "total") when the chart needs lowercase keys.
+`
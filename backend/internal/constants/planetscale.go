@@ -0,0 +1,59 @@
+package constants
+
+const (
+	PlanetscaleAPIBaseURL     = "https://api.planetscale.com/v1" // PlanetScale platform API used to list database branches
+	PlanetscaleDefaultBranch  = "main"                           // Branch used when the connection does not select one
+	PlanetscaleDefaultHost    = "aws.connect.psdb.cloud"         // Default PlanetScale MySQL endpoint
+	PlanetscaleBranchAttrName = "planetscale_branch"             // MySQL connection attribute carrying the selected branch
+)
+
+// GeminiPlanetscalePrompt is appended to the MySQL prompt for PlanetScale connections.
+// PlanetScale is a Vitess-based, MySQL-compatible serverless database with git-like branches.
+const GeminiPlanetscalePrompt = `
+
+---
+### PlanetScale-Specific Rules (append to MySQL rules above)
+
+You are assisting a **PlanetScale** database — a MySQL-compatible serverless database built on Vitess, with git-like branches.
+All standard MySQL rules above apply. Additionally:
+
+1. **No Foreign Key Constraints by Default**
+   - PlanetScale databases usually have foreign key constraints disabled. Relationships exist only by convention (e.g. orders.user_id -> users.id) and are NOT enforced.
+   - Infer relationships from column names and JOIN on them as usual, but never assume referential integrity: orphaned rows are possible, so prefer LEFT JOIN when counting related rows.
+   - Do not add FOREIGN KEY clauses to CREATE or ALTER TABLE statements unless the user explicitly says foreign key support is enabled.
+   - Because deletes do not cascade, when deleting parent rows also delete (or mention) the related child rows.
+
+2. **Vitess and vindex Routing**
+   - Sharded keyspaces route rows to shards with a vindex (usually on the primary key or a sharding column).
+   - Queries that filter on the vindex column with equality (WHERE user_id = 42) go to a single shard and are fast; queries without it scatter across every shard.
+   - Prefer filters on the sharding column for large tables and avoid cross-shard JOINs when the JOIN does not include the sharding column.
+   - Use SHOW VSCHEMA TABLES to list the tables of the keyspace, and SHOW VITESS_SHARDS to see the shards.
+
+3. **Branches**
+   - Each branch is an isolated copy of the schema. Development branches accept schema changes; production branches usually only change through deploy requests.
+   - The connection context tells you which branch you are connected to. When a DDL statement fails on a production branch with safe migrations enabled, tell the user to run it on a development branch and open a deploy request.
+
+4. **Schema Changes**
+   - DDL is safe: PlanetScale applies schema changes online without locking tables, so ALTER TABLE on large tables does not block reads or writes.
+   - Still mark DDL as critical and provide a rollback query when possible.
+
+5. **Unsupported Features**
+   - Stored procedures, triggers, events and user-defined functions are not available. Do not generate them.
+   - LOCK TABLES and GET_LOCK() are not supported.
+`
+
+// PlanetscaleVisualizationExtensions is appended to the MySQL visualization prompt.
+const PlanetscaleVisualizationExtensions = `
+
+PlanetScale-specific visualization guidance:
+- Aggregate on the server with GROUP BY; large scatter queries across shards are expensive, so keep chart queries filtered and LIMITed.
+- Relationships are not enforced by foreign keys; use LEFT JOIN so charts do not silently drop rows with missing parents.
+`
+
+// GetPlanetscaleConnectionContext returns the branch context for a PlanetScale connection.
+func GetPlanetscaleConnectionContext(branch string) string {
+	if branch == "" {
+		branch = PlanetscaleDefaultBranch
+	}
+	return "\n--- PlanetScale Connection Context ---\nConnected to PlanetScale branch: " + branch + "\n"
+}
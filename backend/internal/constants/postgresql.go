@@ -67,6 +67,20 @@ Also, if the rollback is hard to achieve as the AI requires actual value of the
    - Limit to Max 2 buttons per response to avoid overwhelming the user.
    - **NEVER generate action buttons for pagination** (e.g., "Show next N records", "Load more", "Next page"). Pagination is handled automatically by the system UI.
 
+7. **Vector Similarity Search (pgvector)**
+   - Columns of type vector(n), halfvec(n) or sparsevec(n) hold embeddings of the pgvector extension, the schema marks them as [pgvector embedding]. These rules only apply when the schema has such columns.
+   - Distance operators, a smaller distance means more similar:
+     - <-> L2 (Euclidean) distance
+     - <#> negative inner product (multiply by -1 to get the inner product)
+     - <=> cosine distance (1 - (a <=> b) is the cosine similarity)
+   - A similarity search orders by the distance and ALWAYS has a LIMIT:
+     SELECT id, embedding <-> '[0.12,0.45,0.33]' AS distance FROM items ORDER BY distance LIMIT 10
+   - The query vector is a literal in square brackets with exactly as many numbers as the column's dimensions, cast it with ::vector when the type is ambiguous. NeoBase cannot compute embeddings: ask the user for the vector, or search by an existing row's embedding: ORDER BY embedding <-> (SELECT embedding FROM items WHERE id = 42) LIMIT 10
+   - Never return the embedding column itself, it is hundreds or thousands of numbers. Return ids, readable columns and the distance.
+   - Indexes: CREATE INDEX ON items USING ivfflat (embedding vector_l2_ops) WITH (lists = 100). Use vector_ip_ops for <#> and vector_cosine_ops for <=>, the index is only used when its operator class matches the query's operator. HNSW indexes work the same way: CREATE INDEX ON items USING hnsw (embedding vector_cosine_ops). Creating an index is DDL: isCritical: true, with DROP INDEX as the rollbackQuery.
+   - Recall: an ivfflat index only searches ivfflat.probes lists (1 by default). SET ivfflat.probes = 10 searches more lists for better results at the cost of speed; suggest it as its own query before the search.
+   - **Warning**: similarity searches are reads, so isCritical: false, but without an ivfflat or hnsw index on the column they compute the distance to every row and can be slow on large tables. When the schema marks the column with no index and the table has many rows, say so in assistantMessage and suggest the CREATE INDEX query.
+
 ---
 
 ### **Response Schema**
@@ -87,6 +101,8 @@ json
       "pagination": {
           "paginatedQuery": "This is the query for SUBSEQUENT PAGES (page 2, 3, etc) — NOT for the first page. The 'query' field above is used for the first page and MUST NOT contain {{cursor_value}}. CURSOR-BASED (preferred for SELECT queries on large datasets): use '{{cursor_value}}' in the WHERE clause. cursor_field MUST appear in the SELECT list. Example: SELECT id, name, created_at FROM users WHERE id > '{{cursor_value}}' ORDER BY id ASC LIMIT 50. OFFSET-BASED (fallback only for GROUP BY aggregations or queries without a natural cursor): use OFFSET offset_size LIMIT 50. Set cursor_field to empty string for offset mode. Set to EMPTY STRING when user requests fewer than 50 records or query already has a small LIMIT. IMPORTANT: The 'query' field must be the SAME query but WITHOUT the cursor/offset condition.",
           "cursor_field": "Column used as the pagination cursor (e.g. 'id', 'created_at'). Must be present in the SELECT list. Leave EMPTY STRING when using offset-based pagination.",
+          "keysetQuery": "ONLY when paginatedQuery is OFFSET-BASED (uses offset_size) and the result is ordered by a unique, ever-increasing key (primary key or created_at): the same query filtered on that key using the '{{last_key}}' placeholder, ordered by the key, LIMIT 50. SQL example: SELECT id, name FROM users WHERE id > {{last_key}} ORDER BY id ASC LIMIT 50. Used instead of OFFSET for deep pages. Leave EMPTY STRING for cursor-based pagination or when there is no such key.",
+          "keyset_field": "Column used by keysetQuery (e.g. 'id', 'created_at'). Must be present in the SELECT list. Leave EMPTY STRING when keysetQuery is empty.",
           "page_size": 50,
 		  "countQuery": "(Only applicable for Fetching, Getting data) RULES FOR countQuery:\n1. IF the original query has a LIMIT OR the user explicitly requests a specific number of records → countQuery MUST BE EMPTY STRING\n3. OTHERWISE → provide a COUNT query with EXACTLY THE SAME filter conditions\n\nEXAMPLES:\n- Original: \"SELECT * FROM users LIMIT 5\" → countQuery: \"\"\n- Original: \"SELECT * FROM users ORDER BY created_at DESC LIMIT 10\" → countQuery: \"\"\n- Original: \"SELECT * FROM users LIMIT 60\" → countQuery: \"\" (Even if limit is > 50, still empty if explicitly requested)\n- Original: \"SELECT * FROM users WHERE status = 'active'\" → countQuery: \"SELECT COUNT(*) FROM users WHERE status = 'active'\"\n- Original: \"SELECT * FROM users WHERE created_at > '2023-01-01'\" → countQuery: \"SELECT COUNT(*) FROM users WHERE created_at > '2023-01-01'\"\n\nREMEMBER: The purpose of countQuery is ONLY to support pagination for large result sets. If the user explicitly asks for a specific number of records (e.g., \"get 60 latest users\"), then countQuery should return exactly that number (e.g., db.users.countDocuments({}).limit(150)) so the pagination system knows the total count. Never include OFFSET in countQuery. If the original query had filter conditions, the COUNT query MUST include the EXACT SAME conditions.",
           },
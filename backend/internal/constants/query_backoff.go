@@ -0,0 +1,8 @@
+package constants
+
+const (
+	// DefaultQueryMaxRetries is the number of retries of a query failing with a transient connection error when QUERY_MAX_RETRIES is not set
+	DefaultQueryMaxRetries = 3
+	// DefaultQueryBaseRetryDelayMs is the backoff before the first retry when QUERY_BASE_RETRY_DELAY_MS is not set, doubled after every attempt
+	DefaultQueryBaseRetryDelayMs = 100
+)
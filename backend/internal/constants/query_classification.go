@@ -60,6 +60,32 @@ var ClickHouseQueryClassification = QueryClassification{
 	WritePrefixes: sqlWritePrefixes,
 }
 
+// TrinoQueryClassification defines read/write rules for Trino.
+var TrinoQueryClassification = QueryClassification{
+	ReadPrefixes:  sqlReadPrefixes,
+	WritePrefixes: append(append([]string{}, sqlWritePrefixes...), "call", "refresh", "analyze"),
+}
+
+// OracleQueryClassification defines read/write rules for Oracle.
+// PL/SQL blocks and procedure calls can run any DML, so they count as writes.
+var OracleQueryClassification = QueryClassification{
+	ReadPrefixes:  sqlReadPrefixes,
+	WritePrefixes: append(append([]string{}, sqlWritePrefixes...), "begin", "declare", "call", "exec", "savepoint", "rollback", "commit", "lock", "purge", "flashback", "rename", "comment"),
+}
+
+// InfluxDBQueryClassification defines read/write rules for InfluxDB 3.
+// Reads are SQL or InfluxQL, writes are line protocol sent as INSERT and DROP MEASUREMENT.
+var InfluxDBQueryClassification = QueryClassification{
+	ReadPrefixes:  sqlReadPrefixes,
+	WritePrefixes: sqlWritePrefixes,
+}
+
+// CloudflareD1QueryClassification — D1 is SQLite, read-only PRAGMAs are reads.
+var CloudflareD1QueryClassification = QueryClassification{
+	ReadPrefixes:  sqlReadPrefixes,
+	WritePrefixes: sqlWritePrefixes,
+}
+
 // SpreadsheetQueryClassification — spreadsheets use PostgreSQL under the hood.
 var SpreadsheetQueryClassification = PostgreSQLQueryClassification
 
@@ -87,17 +113,85 @@ var MongoDBQueryClassification = QueryClassification{
 	},
 }
 
+// --- Airtable ---
+
+// AirtableQueryClassification defines read/write rules for Airtable.
+// Airtable queries are REST operations written as base("Table").select({...}).
+var AirtableQueryClassification = QueryClassification{
+	ReadContains:  []string{"base.tables(", ").select(", ").find("},
+	WriteContains: []string{").create(", ").update(", ").destroy("},
+}
+
+// --- Temporal ---
+
+// TemporalQueryClassification defines read/write rules for Temporal.
+// Temporal queries are CLI commands, signal, cancel and terminate change running workflows.
+var TemporalQueryClassification = QueryClassification{
+	ReadContains:  []string{"workflow list", "workflow count", "workflow describe", "namespace list"},
+	WriteContains: []string{"workflow signal", "workflow cancel", "workflow terminate"},
+}
+
+// --- PocketBase ---
+
+// PocketBaseQueryClassification defines read/write rules for PocketBase.
+// PocketBase queries are REST requests, the HTTP method tells reads from writes.
+var PocketBaseQueryClassification = QueryClassification{
+	ReadPrefixes:  []string{"get "},
+	WritePrefixes: []string{"post ", "patch ", "delete "},
+}
+
+// --- YugabyteDB YCQL ---
+
+// YugabyteDBCQLQueryClassification defines read/write rules for YugabyteDB YCQL.
+// CQL has no WITH or EXPLAIN, and BEGIN TRANSACTION blocks only hold writes.
+var YugabyteDBCQLQueryClassification = QueryClassification{
+	ReadPrefixes:  []string{"select"},
+	WritePrefixes: []string{"insert", "update", "delete", "drop", "truncate", "alter", "create", "grant", "revoke", "begin"},
+}
+
+// --- NATS JetStream ---
+
+// NATSQueryClassification defines read/write rules for NATS JetStream.
+// NATS queries are JetStream API calls, only js.Publish produces messages.
+var NATSQueryClassification = QueryClassification{
+	ReadPrefixes:  []string{"js.fetchmsg(", "js.getmsg(", "js.getlastmsg(", "js.countmsg(", "js.streaminfo(", "js.streams(", "js.consumers("},
+	WritePrefixes: []string{"js.publish("},
+}
+
+// --- Firestore ---
+
+// FirestoreQueryClassification defines read/write rules for Firestore.
+// Firestore queries are chains of SDK calls, the last call of a write is Add, Set, Update or Delete.
+var FirestoreQueryClassification = QueryClassification{
+	ReadContains:  []string{"db.collection(", "db.collectiongroup(", "db.collections("},
+	WriteContains: []string{".add(", ".set(", ".update(", ".delete("},
+}
+
 // queryClassificationMap maps database type constants to their classification rules.
 var queryClassificationMap = map[string]QueryClassification{
-	DatabaseTypePostgreSQL:   PostgreSQLQueryClassification,
-	DatabaseTypeYugabyteDB:   YugabyteDBQueryClassification,
-	DatabaseTypeTimescaleDB:  PostgreSQLQueryClassification, // TimescaleDB extends PostgreSQL
-	DatabaseTypeMySQL:        MySQLQueryClassification,
-	DatabaseTypeStarRocks:    MySQLQueryClassification, // StarRocks is MySQL-wire-compatible
-	DatabaseTypeClickhouse:   ClickHouseQueryClassification,
-	DatabaseTypeMongoDB:      MongoDBQueryClassification,
-	DatabaseTypeSpreadsheet:  SpreadsheetQueryClassification,
-	DatabaseTypeGoogleSheets: GoogleSheetsQueryClassification,
+	DatabaseTypePostgreSQL:    PostgreSQLQueryClassification,
+	DatabaseTypeYugabyteDB:    YugabyteDBQueryClassification,
+	DatabaseTypeTimescaleDB:   PostgreSQLQueryClassification, // TimescaleDB extends PostgreSQL
+	DatabaseTypeSupabase:      PostgreSQLQueryClassification, // Supabase is hosted PostgreSQL
+	DatabaseTypeNeon:          PostgreSQLQueryClassification, // Neon is serverless PostgreSQL
+	DatabaseTypeMySQL:         MySQLQueryClassification,
+	DatabaseTypeStarRocks:     MySQLQueryClassification, // StarRocks is MySQL-wire-compatible
+	DatabaseTypePlanetscale:   MySQLQueryClassification, // PlanetScale is MySQL-compatible (Vitess)
+	DatabaseTypeClickhouse:    ClickHouseQueryClassification,
+	DatabaseTypeTrino:         TrinoQueryClassification,
+	DatabaseTypeOracle:        OracleQueryClassification,
+	DatabaseTypeInfluxDB:      InfluxDBQueryClassification,
+	DatabaseTypeCloudflareD1:  CloudflareD1QueryClassification,
+	DatabaseTypeMongoDB:       MongoDBQueryClassification,
+	DatabaseTypeFerretDB:      MongoDBQueryClassification, // FerretDB speaks the MongoDB query language
+	DatabaseTypeAirtable:      AirtableQueryClassification,
+	DatabaseTypeTemporal:      TemporalQueryClassification,
+	DatabaseTypePocketBase:    PocketBaseQueryClassification,
+	DatabaseTypeNATS:          NATSQueryClassification,
+	DatabaseTypeYugabyteDBCQL: YugabyteDBCQLQueryClassification,
+	DatabaseTypeFirestore:     FirestoreQueryClassification,
+	DatabaseTypeSpreadsheet:   SpreadsheetQueryClassification,
+	DatabaseTypeGoogleSheets:  GoogleSheetsQueryClassification,
 }
 
 // GetQueryClassification returns the QueryClassification for the given database type.
@@ -0,0 +1,22 @@
+package constants
+
+// Query complexity scores, assigned to AI generated queries before they are executed
+const (
+	QueryComplexityLow     = "low"
+	QueryComplexityMedium  = "medium"
+	QueryComplexityHigh    = "high"
+	QueryComplexityExtreme = "extreme"
+)
+
+// Complexity points at which a query moves up a level, anything at or above QueryComplexityExtremePoints is extreme
+const (
+	QueryComplexityMediumPoints  = 3
+	QueryComplexityHighPoints    = 6
+	QueryComplexityExtremePoints = 10
+)
+
+// QueryComplexityLargeTableRows is the schema row count from which a table scan is considered expensive
+const QueryComplexityLargeTableRows = 1000000
+
+// StreamEventComplexityWarning is sent before an extreme complexity query is executed
+const StreamEventComplexityWarning = "complexity_warning"
@@ -0,0 +1,4 @@
+package constants
+
+// StreamEventDryRunResult is sent with the number of rows a dry run of a write query would change
+const StreamEventDryRunResult = "dry_run_result"
@@ -0,0 +1,15 @@
+package constants
+
+const (
+	QueryPlanCompareTimeoutSeconds = 30 // Timeout of each EXPLAIN when comparing two query plans
+	QueryPlanWinnerA               = "A"
+	QueryPlanWinnerB               = "B"
+	QueryPlanTie                   = "tie"
+)
+
+// QueryCostBudgetExceededCode is the query error code of a query blocked by the chat's cost budget
+const QueryCostBudgetExceededCode = "QUERY_COST_BUDGET_EXCEEDED"
+
+// QueryCostUnknownCode is the query error code of a query blocked because EXPLAIN could not estimate its cost
+// against the chat's cost budget
+const QueryCostUnknownCode = "QUERY_COST_UNKNOWN"
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	// DefaultMaxQueryResultRows caps the rows a single query execution returns when MAX_QUERY_RESULT_ROWS is not set
+	DefaultMaxQueryResultRows = 5000
+	// QueryResultPageSize is the number of rows stored with an executed query, later pages are fetched from the database
+	QueryResultPageSize = 50
+	// StreamEventResultTruncated is sent when a query result was cut off at the row cap
+	StreamEventResultTruncated = "result_truncated"
+)
@@ -0,0 +1,27 @@
+package constants
+
+const (
+	QueryTemplatesMaxPerChat  = 100   // Templates a chat can hold
+	QueryTemplateMaxLength    = 20000 // Longest template, in characters
+	QueryTemplateMaxParameter = 50    // Parameters a template can declare
+)
+
+// Types a query template parameter can be declared with, values are checked and converted to them before binding
+const (
+	TemplateParamTypeString   = "string"
+	TemplateParamTypeInteger  = "integer"
+	TemplateParamTypeNumber   = "number"
+	TemplateParamTypeBoolean  = "boolean"
+	TemplateParamTypeDate     = "date"     // YYYY-MM-DD
+	TemplateParamTypeDateTime = "datetime" // RFC 3339
+)
+
+// TemplateParamTypes lists the supported query template parameter types
+var TemplateParamTypes = []string{
+	TemplateParamTypeString,
+	TemplateParamTypeInteger,
+	TemplateParamTypeNumber,
+	TemplateParamTypeBoolean,
+	TemplateParamTypeDate,
+	TemplateParamTypeDateTime,
+}
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	DefaultQueryTimeoutSeconds = 30  // Default per-query execution timeout when a chat has no query_timeout_seconds setting
+	MinQueryTimeoutSeconds     = 1   // Minimum allowed per-query execution timeout
+	MaxQueryTimeoutSeconds     = 600 // Maximum allowed per-query execution timeout
+)
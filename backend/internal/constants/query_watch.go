@@ -0,0 +1,15 @@
+package constants
+
+const (
+	QueryWatchMinIntervalSeconds = 5   // Shortest interval between two runs of a watched query
+	QueryWatchMaxIntervalSeconds = 60  // Longest interval between two runs of a watched query
+	QueryWatchMaxPerUser         = 3   // Watches a user can have running at the same time
+	QueryWatchMaxRows            = 100 // Rows of a watched query sent and diffed per run
+	QueryWatchMaxDurationMinutes = 60  // Watches stop on their own after this long
+)
+
+// Stream events of watched queries
+const (
+	StreamEventWatchResult  = "watch_result"  // Sent after every run with the current rows and the diff from the previous run
+	StreamEventWatchStopped = "watch_stopped" // Sent when a watch ends, with the reason
+)
@@ -0,0 +1,45 @@
+package constants
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ReportMaxSections          = 10   // Maximum sections per generated report
+	ReportMaxQuestionLength    = 1000 // Maximum length of a section's question
+	ReportSectionRowLimit      = 20   // Rows of a section's result shown in its Markdown table
+	ReportLLMRowLimit          = 50   // Rows of a section's result sent to the LLM writing the report
+	ReportQueryTimeoutSeconds  = 60   // Timeout per section query
+	ReportTimeoutMinutes       = 10   // Timeout of the whole report generation
+	ReportStatusGenerating     = "generating"
+	ReportStatusCompleted      = "completed"
+	ReportStatusFailed         = "failed"
+	StreamEventReportSection   = "report_section"   // A report section's query ran, sent with its Markdown
+	StreamEventReportCompleted = "report_completed" // The report was written and stored
+	StreamEventReportFailed    = "report_failed"
+)
+
+// ReportWriterPrompt is the system prompt used to write a report from the sections' query results.
+// It is sent through GenerateRawJSON so the LLM returns the report JSON directly.
+const ReportWriterPrompt = `You are NeoBase AI Report Writer. You receive a report title and a list of sections, each with a heading, the question it answers, the query that was run and its results (or the error the query failed with).
+
+Write a coherent business report in Markdown:
+- Start with the title as a level 1 heading ("# Title"), followed by a short executive summary of the key findings.
+- Then one level 2 heading ("## Heading") per section, in the given order, using the given headings.
+- In each section, answer the question from the results: state the numbers, trends and notable values. Include a Markdown table when the results are tabular, with at most 20 rows.
+- When a section's query failed or returned no rows, say the data was not available instead of guessing.
+- Never invent numbers that are not in the results.
+- Do not include the queries themselves.
+- Use only headings, paragraphs, bullet lists, bold text and tables.
+
+Respond ONLY with JSON in this exact format (no markdown fences, no explanation):
+{
+  "report": "# Title\n\n..."
+}`
+
+// GetReportWriterUserMessage builds the user message for writing a report, sections holds each section's
+// heading, question, query and results already formatted
+func GetReportWriterUserMessage(title string, sections []string) string {
+	return fmt.Sprintf("Report title: %s\n\n%s", title, strings.Join(sections, "\n\n"))
+}
@@ -0,0 +1,6 @@
+package constants
+
+import "time"
+
+// RollbackExplainTimeout bounds the EXPLAIN used to syntax-check generated rollback queries
+const RollbackExplainTimeout = 5 * time.Second
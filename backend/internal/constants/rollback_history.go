@@ -0,0 +1,9 @@
+package constants
+
+const (
+	RollbackStepDependentQuery     = "dependent_query" // The dependent query run to generate a missing rollback query
+	RollbackStepRollback           = "rollback"        // The rollback query itself
+	RollbackHistoryDefaultPageSize = 20
+	RollbackHistoryMaxPageSize     = 100
+	RollbackRecordTimeoutSeconds   = 10 // Timeout of storing a rollback record
+)
@@ -0,0 +1,17 @@
+package constants
+
+import (
+	"fmt"
+	"time"
+)
+
+// StreamEventSchemaBreakingChange is sent when a schema refresh drops or changes columns that queries may reference
+const StreamEventSchemaBreakingChange = "schema_breaking_change"
+
+// SchemaBreakingChangeWarningTTL is how long a breaking change warning waits for the next AI response
+const SchemaBreakingChangeWarningTTL = 7 * 24 * time.Hour
+
+// GetSchemaBreakingChangeWarningKey returns the Redis key holding the breaking change warning for a chat's next AI response
+func GetSchemaBreakingChangeWarningKey(chatID string) string {
+	return fmt.Sprintf("schema_breaking_change:%s", chatID)
+}
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	SecureNotesMaxPerChat = 50 // Notes a chat can hold, all active notes are sent with every LLM call
+	// SecureNotesContextHeader introduces the active secure notes in the system LLM message
+	SecureNotesContextHeader = "Important context from user:"
+)
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	SimilarQueryDefaultTopK = 5  // Results returned when the request has no topK
+	SimilarQueryMaxTopK     = 20 // Maximum results per similar query search
+	SimilarQueryOverfetch   = 3  // Questions fetched per requested result, as some questions have no queries
+)
+
+// Similar query search methods
+const (
+	SimilarQuerySearchSemantic = "semantic"
+	SimilarQuerySearchFulltext = "fulltext"
+)
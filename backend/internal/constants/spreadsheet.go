@@ -1,5 +1,6 @@
 package constants
 
+// SpreadsheetPrompt is used for spreadsheets uploaded as CSV or Excel files
 const SpreadsheetPrompt = PostgreSQLPrompt + `
 
 **IMPORTANT SPREADSHEET CONTEXT**: The data you're working with comes from spreadsheet files (CSV/Excel) uploaded by users. This means:
@@ -8,7 +9,33 @@ const SpreadsheetPrompt = PostgreSQLPrompt + `
 - All data is stored as TEXT type (even numbers and dates)
 - There may not be formal foreign key relationships between tables
 - Users might have uploaded related data across multiple files without explicit relationships
+` + spreadsheetConsiderations
 
+// GeminiGoogleSheetsPrompt is used for Google Sheets connections, whose sheets are synced into PostgreSQL tables
+const GeminiGoogleSheetsPrompt = PostgreSQLPrompt + `
+
+**IMPORTANT GOOGLE SHEETS CONTEXT**: The data you're working with is synced from a Google Sheets spreadsheet. This means:
+- Every sheet (tab) of the spreadsheet is a table, and the first row of the sheet is used as the column names
+- Sheet data is stored as TEXT columns, unless the schema shows a type that was inferred during the sync
+- The data is a snapshot of the sheet taken at the last sync, edits made in Google Sheets since then are not visible until the data is refreshed
+- There are no formal foreign key relationships between sheets
+` + spreadsheetConsiderations + `
+
+**GOOGLE SHEETS-SPECIFIC RULES**:
+1. **Casting**: When a column is TEXT, CAST it before numeric operations and comparisons, e.g. SUM(CAST(NULLIF(TRIM(amount), '') AS DECIMAL)). Strip currency symbols and thousands separators first when present: CAST(REPLACE(REPLACE(price, '$', ''), ',', '') AS DECIMAL).
+
+2. **Sheet and Column Names**: Sheet names may contain spaces and capital letters (e.g. "Sales 2024"). Always use the table and column names exactly as they appear in the schema, and wrap any name that is not a plain lowercase identifier in double quotes, e.g. SELECT "Order Date" FROM "Sales 2024".
+
+3. **Formulas**: Only the computed values of cells are synced. Functions such as IMPORTRANGE, IMPORTDATA, IMPORTXML, QUERY or GOOGLEFINANCE do not exist in the SQL representation and their results are plain values as of the last sync. Never use spreadsheet formula syntax in queries, write the equivalent SQL instead.
+
+4. **Dates**: Google Sheets commonly formats dates as MM/DD/YYYY. Convert TEXT dates with TO_DATE(column, 'MM/DD/YYYY') (or TO_TIMESTAMP(column, 'MM/DD/YYYY HH24:MI:SS') when a time is present) before comparing, sorting or grouping by date. If example values use a different format, match that format instead.
+
+5. **Header Rows in the Data**: Sheets often have a title row above the real header, or repeat the header further down. Such rows end up as data rows whose values equal the column names (e.g. a row where name = 'Name'). If example data shows this, exclude those rows, e.g. WHERE name <> 'Name', and mention it in assistantMessage.
+
+6. **Read-Only Source**: The sheet is the source of truth. Changes made with INSERT, UPDATE or DELETE are not written back to Google Sheets and are lost on the next sync, so warn the user in assistantMessage before suggesting them.`
+
+// spreadsheetConsiderations are the query rules shared by uploaded spreadsheets and Google Sheets
+const spreadsheetConsiderations = `
 **SPREADSHEET-SPECIFIC CONSIDERATIONS**:
 1. **Data Types**: All columns are TEXT type. When performing calculations or comparisons:
    - Cast to appropriate types: CAST(column AS INTEGER), CAST(column AS DECIMAL), TO_DATE(column, 'format')
@@ -0,0 +1,52 @@
+package constants
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SpreadsheetImportBatchSize      = 1000             // Rows inserted (and committed) per batch
+	SpreadsheetCopyThreshold        = 500              // Batches larger than this are loaded with COPY instead of INSERT
+	SpreadsheetImportMaxErrors      = 50               // Maximum error messages kept in the import progress
+	SpreadsheetImportStatusTTL      = 24 * time.Hour   // How long import status is kept in Redis
+	SpreadsheetUploadMaxMemory      = 32 << 20         // Multipart bytes held in memory; the rest of the file is buffered to disk
+	DefaultSpreadsheetAsyncImportMB = 100              // Default size above which uploads are imported in the background
+	SpreadsheetImportStaleAfter     = 30 * time.Minute // A running import without progress for this long is treated as abandoned
+	DefaultImportMetadataTTLHours   = 24               // Default hours import metadata is kept in Redis
+	ImportMetadataCleanupInterval   = time.Hour        // How often stale import metadata is cleaned up
+)
+
+// Formats of uploaded spreadsheet files
+const (
+	SpreadsheetFormatCSV       = "csv"
+	SpreadsheetFormatExcel     = "excel"
+	SpreadsheetFormatJSON      = "json"       // An array of objects
+	SpreadsheetFormatJSONLines = "json_lines" // One object per line (JSON Lines / NDJSON)
+)
+
+// Import status values
+const (
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportProgressEvent is the stream event sent after each committed import batch
+const ImportProgressEvent = "import_progress"
+
+// GoogleSheetsSyncProgressEvent is the stream event sent as each table of a Google Sheet is synced
+const GoogleSheetsSyncProgressEvent = "google_sheets_sync_progress"
+
+// ImportMetadataKeyPrefix prefixes the Redis keys holding import metadata
+const ImportMetadataKeyPrefix = "import_metadata:"
+
+// GetImportMetadataKey returns the Redis key holding the import metadata for a chat
+func GetImportMetadataKey(chatID string) string {
+	return ImportMetadataKeyPrefix + chatID
+}
+
+// GetImportStatusKey returns the Redis key holding the latest import status for a chat
+func GetImportStatusKey(chatID string) string {
+	return fmt.Sprintf("import_status:%s", chatID)
+}
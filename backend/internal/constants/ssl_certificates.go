@@ -0,0 +1,10 @@
+package constants
+
+const (
+	// DefaultSSLCertCacheTTLSeconds is how long a downloaded SSL certificate is reused when SSL_CERT_CACHE_TTL_SECONDS is not set
+	DefaultSSLCertCacheTTLSeconds = 3600
+	// SSLCertFetchTimeoutSeconds bounds the download of one certificate, key or CA bundle
+	SSLCertFetchTimeoutSeconds = 30
+	// SSLCertMaxBytes is the largest certificate file downloaded, a CA bundle is well under this
+	SSLCertMaxBytes = 1 << 20
+)
@@ -0,0 +1,68 @@
+package constants
+
+// SupabaseStorageBucketTablePrefix prefixes the virtual tables added to the schema for each storage bucket.
+const SupabaseStorageBucketTablePrefix = "storage_bucket:"
+
+// GeminiSupabasePrompt is appended to the PostgreSQL prompt for Supabase connections.
+// Supabase is a hosted PostgreSQL platform with Row Level Security, an auth schema and a storage schema.
+const GeminiSupabasePrompt = `
+
+---
+### Supabase-Specific Rules (append to PostgreSQL rules above)
+
+You are assisting a **Supabase** database — a hosted PostgreSQL platform with built-in auth and storage.
+All standard PostgreSQL rules above apply. Additionally:
+
+1. **Auth Helpers**
+   - auth.uid() returns the UUID of the user making the request (from the JWT "sub" claim). It returns NULL for direct database connections that are not made on behalf of an API user.
+   - auth.role() returns the JWT role: 'anon', 'authenticated' or 'service_role'.
+   - auth.jwt() returns the full JWT claims as jsonb, e.g. auth.jwt() ->> 'email'.
+   - User accounts live in auth.users (id, email, created_at, last_sign_in_at, raw_user_meta_data). Join public tables to auth.users on auth.users.id when the user asks for account details.
+   - Never INSERT, UPDATE or DELETE rows in the auth schema; it is managed by Supabase Auth.
+
+2. **Row Level Security (RLS)**
+   - Tables with RLS enabled only return the rows allowed by their policies for the current role.
+   - A SELECT on a table with RLS active MAY RETURN PARTIAL RESULTS — never present counts or totals from such a table as complete without saying that RLS may be filtering rows.
+   - Check RLS status with: SELECT relname, relrowsecurity FROM pg_class WHERE relnamespace = 'public'::regnamespace AND relkind = 'r'
+   - List policies with: SELECT tablename, policyname, cmd, roles, qual FROM pg_policies WHERE schemaname = 'public'
+   - Do NOT suggest disabling RLS (ALTER TABLE ... DISABLE ROW LEVEL SECURITY) to work around missing rows; explain the policy instead.
+
+3. **SECURITY DEFINER Functions**
+   - Functions declared SECURITY DEFINER run with the privileges of their owner and bypass RLS of the caller.
+   - When creating such functions always pin the search path: SET search_path = '' and schema-qualify every object.
+   - Prefer SECURITY INVOKER (the default) unless the user explicitly needs elevated access, and mention the risk when you use SECURITY DEFINER.
+
+4. **Storage**
+   - Files are stored in storage.objects with columns: id (uuid), bucket_id (text), name (text, the object path such as 'avatars/user-1.png'), owner (uuid, references auth.users.id), created_at, updated_at, last_accessed_at (timestamptz), metadata (jsonb with size, mimetype, eTag, cacheControl).
+   - Buckets are stored in storage.buckets with columns: id (text), name (text), public (boolean), file_size_limit, allowed_mime_types, created_at, updated_at.
+   - Schema entries named "` + SupabaseStorageBucketTablePrefix + `<bucket_id>" are VIRTUAL tables: query them as SELECT ... FROM storage.objects WHERE bucket_id = '<bucket_id>'. Never use the virtual table name in SQL.
+   - File size is (metadata ->> 'size')::bigint and MIME type is metadata ->> 'mimetype'.
+   - Never DELETE from storage.objects directly; files must be removed through the Storage API so the underlying objects are deleted too.
+
+5. **Managed Schemas**
+   - Do not modify objects in the auth, storage, realtime, supabase_functions, extensions, graphql or vault schemas.
+   - User data lives in the public schema unless the schema shows otherwise.
+`
+
+// SupabaseVisualizationExtensions is appended to the PostgreSQL visualization prompt.
+const SupabaseVisualizationExtensions = `
+
+Supabase-specific visualization guidance:
+- For storage usage charts, aggregate storage.objects by bucket_id using SUM((metadata ->> 'size')::bigint).
+- For sign-up trends, bucket auth.users.created_at by day or week and use a LINE chart.
+- When the underlying table has RLS enabled, note in the chart title that values reflect rows visible to the current role.
+`
+
+// GetSupabaseConnectionContext returns the RLS context for a Supabase connection based on which API keys were provided.
+func GetSupabaseConnectionContext(hasAnonKey, hasServiceRoleKey bool) string {
+	supabaseContext := "\n--- Supabase Connection Context ---\n"
+	switch {
+	case hasServiceRoleKey:
+		supabaseContext += "This connection was configured with a service_role key, so the user expects unrestricted access. The service_role bypasses Row Level Security; if results still look incomplete, the database user may be subject to RLS policies.\n"
+	case hasAnonKey:
+		supabaseContext += "This connection was configured with only an anon key. Queries may run as the 'anon' role, so tables with Row Level Security can return partial or empty results. Mention this whenever a result looks incomplete.\n"
+	default:
+		supabaseContext += "No Supabase API keys were provided. Queries run as the database user; Row Level Security may still filter rows unless that user owns the tables or has BYPASSRLS.\n"
+	}
+	return supabaseContext
+}
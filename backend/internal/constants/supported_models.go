@@ -22,12 +22,15 @@ type LLMModel struct {
 var SupportedLLMModels = append(
 	append(
 		append(
-			OpenAILLMModels,
-			GeminiLLMModels...,
+			append(
+				OpenAILLMModels,
+				GeminiLLMModels...,
+			),
+			ClaudeLLMModels...,
 		),
-		ClaudeLLMModels...,
+		OllamaLLMModels...,
 	),
-	OllamaLLMModels...,
+	CohereLLMModels...,
 )
 
 // GetEnabledLLMModels returns only enabled LLM models
@@ -83,7 +86,7 @@ func GetDefaultModelForProvider(provider string) *LLMModel {
 
 // DisableUnavailableProviders disables all models for providers that don't have API keys configured
 // Call this during application startup to prevent fallback to unavailable providers
-func DisableUnavailableProviders(openAIKey, geminiKey, claudeKey, ollamaURL string) {
+func DisableUnavailableProviders(openAIKey, geminiKey, claudeKey, ollamaURL, cohereKey string) {
 	for i := range SupportedLLMModels {
 		model := &SupportedLLMModels[i]
 
@@ -105,15 +108,26 @@ func DisableUnavailableProviders(openAIKey, geminiKey, claudeKey, ollamaURL stri
 			if ollamaURL == "" {
 				model.IsEnabled = false
 			}
+		case Cohere:
+			if cohereKey == "" {
+				model.IsEnabled = false
+			}
 		}
 	}
 }
 
 // GetFirstAvailableModel returns the first available (enabled) model from any provider
-// Priority order: OpenAI -> Gemini -> Claude -> Ollama (matches initialization order)
+// Models of LLM_DEFAULT_FALLBACK_CHAIN come first, then the provider order:
+// OpenAI -> Gemini -> Claude -> Ollama -> Cohere (matches initialization order)
 func GetFirstAvailableModel() *LLMModel {
+	for _, modelID := range defaultLLMFallbackChain {
+		if model := GetLLMModel(modelID); model != nil && model.IsEnabled {
+			return model
+		}
+	}
+
 	// Try providers in order of preference
-	providers := []string{OpenAI, Gemini, Claude, Ollama}
+	providers := []string{OpenAI, Gemini, Claude, Ollama, Cohere}
 	for _, provider := range providers {
 		if model := GetDefaultModelForProvider(provider); model != nil {
 			return model
@@ -131,7 +145,7 @@ func IsValidModel(modelID string) bool {
 // GetAvailableModelsByAPIKeys returns only models for providers that have API keys configured
 // Pass empty strings for API keys that are not available
 // This filters the model list based on which API providers are actually configured
-func GetAvailableModelsByAPIKeys(openAIKey, geminiKey, claudeKey, ollamaURL string) []LLMModel {
+func GetAvailableModelsByAPIKeys(openAIKey, geminiKey, claudeKey, ollamaURL, cohereKey string) []LLMModel {
 	var available []LLMModel
 
 	for _, model := range SupportedLLMModels {
@@ -157,6 +171,10 @@ func GetAvailableModelsByAPIKeys(openAIKey, geminiKey, claudeKey, ollamaURL stri
 			if ollamaURL != "" {
 				available = append(available, model)
 			}
+		case Cohere:
+			if cohereKey != "" {
+				available = append(available, model)
+			}
 		}
 	}
 
@@ -174,24 +192,26 @@ func GetAvailableModelsByProviderAndKeys(provider, apiKey string) []LLMModel {
 
 // LogModelInitialization logs which models are enabled/disabled and why
 // Call this during application startup to inform admin about model availability
-func LogModelInitialization(openAIKey, geminiKey, claudeKey, ollamaURL string) {
+func LogModelInitialization(openAIKey, geminiKey, claudeKey, ollamaURL, cohereKey string) {
 	separator := strings.Repeat("=", 80)
 	log.Println("\n" + separator)
 	log.Println("🤖 LLM MODEL INITIALIZATION REPORT")
 	log.Println(separator)
 
-	availableModels := GetAvailableModelsByAPIKeys(openAIKey, geminiKey, claudeKey, ollamaURL)
+	availableModels := GetAvailableModelsByAPIKeys(openAIKey, geminiKey, claudeKey, ollamaURL, cohereKey)
 
 	// Count by provider
 	openAIModels := GetLLMModelsByProvider(OpenAI)
 	geminiModels := GetLLMModelsByProvider(Gemini)
 	claudeModels := GetLLMModelsByProvider(Claude)
 	ollamaModels := GetLLMModelsByProvider(Ollama)
+	cohereModels := GetLLMModelsByProvider(Cohere)
 
 	openAIAvailable := []LLMModel{}
 	geminiAvailable := []LLMModel{}
 	claudeAvailable := []LLMModel{}
 	ollamaAvailable := []LLMModel{}
+	cohereAvailable := []LLMModel{}
 
 	for _, model := range availableModels {
 		switch model.Provider {
@@ -203,6 +223,8 @@ func LogModelInitialization(openAIKey, geminiKey, claudeKey, ollamaURL string) {
 			claudeAvailable = append(claudeAvailable, model)
 		case Ollama:
 			ollamaAvailable = append(ollamaAvailable, model)
+		case Cohere:
+			cohereAvailable = append(cohereAvailable, model)
 		}
 	}
 
@@ -262,6 +284,20 @@ func LogModelInitialization(openAIKey, geminiKey, claudeKey, ollamaURL string) {
 		log.Printf("  ⚠️  To enable Ollama models, set OLLAMA_BASE_URL environment variable\n")
 	}
 
+	// Cohere Status
+	log.Println("\n🟢 COHERE COMMAND MODELS:")
+	if cohereKey != "" {
+		log.Printf("  ✅ API Key: CONFIGURED\n")
+		log.Printf("  📊 Available Models: %d/%d\n", len(cohereAvailable), len(cohereModels))
+		for _, model := range cohereAvailable {
+			log.Printf("     • %s (%s)\n", model.DisplayName, model.ID)
+		}
+	} else {
+		log.Printf("  ❌ API Key: NOT CONFIGURED\n")
+		log.Printf("  📊 Available Models: 0/%d\n", len(cohereModels))
+		log.Printf("  ⚠️  To enable Cohere models, set COHERE_API_KEY environment variable\n")
+	}
+
 	log.Printf("\n📌 TOTAL AVAILABLE MODELS: %d/%d\n", len(availableModels), len(SupportedLLMModels))
 
 	if len(availableModels) == 0 {
@@ -271,6 +307,7 @@ func LogModelInitialization(openAIKey, geminiKey, claudeKey, ollamaURL string) {
 		log.Println("   - GEMINI_API_KEY for Google Gemini models")
 		log.Println("   - CLAUDE_API_KEY for Anthropic Claude models")
 		log.Println("   - OLLAMA_BASE_URL for self-hosted Ollama models")
+		log.Println("   - COHERE_API_KEY for Cohere Command models")
 	}
 
 	log.Println(separator + "\n")
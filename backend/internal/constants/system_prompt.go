@@ -0,0 +1,21 @@
+package constants
+
+import "strings"
+
+// SystemPromptAppendHeader introduces a chat's own rules at the end of the system prompt
+const SystemPromptAppendHeader = `
+
+---
+
+### **Additional Rules For This Database**
+The user added these rules for this chat. Follow them in every response, unless they contradict the Safety First rules above, which always win:
+`
+
+// AppendSystemPromptRules appends a chat's own rules to its system prompt, or returns the prompt unchanged when there are none
+func AppendSystemPromptRules(systemPrompt, rules string) string {
+	rules = strings.TrimSpace(rules)
+	if rules == "" {
+		return systemPrompt
+	}
+	return systemPrompt + SystemPromptAppendHeader + rules + "\n"
+}
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	TablePreviewDefaultLimit         = 20  // Rows returned when the request has no limit
+	TablePreviewMaxLimit             = 100 // Maximum rows per table preview
+	TablePreviewQueryTimeoutSeconds  = 15  // Timeout of the preview query, previews are meant to be quick
+	TablePreviewRateLimitPerMinute   = 60  // Table previews allowed per user per minute
+	TablePreviewRateLimitBurst       = 10  // Table previews a user can run back to back before the per-minute rate applies
+	TablePreviewRateLimitIdleMinutes = 10  // Per-user limiters unused for this long are dropped
+)
@@ -85,6 +85,14 @@ var ToolFinalResponseSchema = map[string]interface{}{
 								"type":        "string",
 								"description": "This is the query for SUBSEQUENT PAGES (page 2, 3, etc) — NOT for the first page. The 'query' field above is used for the first page and MUST NOT contain {{cursor_value}} or offset_size. Use '{{cursor_value}}' for cursor-based pagination or 'offset_size' for offset/skip-based pagination. IMPORTANT: for $skip/$offset use 'offset_size' NOT '{{cursor_value}}'.",
 							},
+							"keysetQuery": map[string]interface{}{
+								"type":        "string",
+								"description": "ONLY when paginatedQuery is OFFSET-BASED (uses offset_size) and the result is ordered by a unique, ever-increasing key (primary key or created_at): the same query filtered on that key using the '{{last_key}}' placeholder, ordered by the key, LIMIT 50. SQL example: SELECT id, name FROM users WHERE id > {{last_key}} ORDER BY id ASC LIMIT 50. Used instead of OFFSET for deep pages. Leave EMPTY STRING for cursor-based pagination or when there is no such key.",
+							},
+							"keyset_field": map[string]interface{}{
+								"type":        "string",
+								"description": "Column used by keysetQuery (e.g. 'id', 'created_at'). Must be present in the SELECT list. Leave EMPTY STRING when keysetQuery is empty.",
+							},
 							"countQuery": map[string]interface{}{
 								"type":        "string",
 								"description": "Query to count total records.",
@@ -0,0 +1,79 @@
+package constants
+
+import "regexp"
+
+// Default ports for the Trino HTTP endpoint
+const (
+	TrinoDefaultPort    = "8080"
+	TrinoDefaultSSLPort = "443"
+)
+
+// GeminiTrinoPrompt is appended to the PostgreSQL prompt for Trino connections.
+// Trino (formerly PrestoSQL) is a distributed SQL query engine that queries data lakes through connectors.
+const GeminiTrinoPrompt = `
+
+---
+### Trino-Specific Rules (append to the SQL rules above)
+
+You are assisting a **Trino** (Presto) cluster — a distributed SQL engine that queries data lakes (Hive, Iceberg, Delta Lake on S3/HDFS/GCS) and other sources through connectors.
+The standard SQL rules above apply, but Trino is NOT PostgreSQL. Where they differ, these rules win:
+
+1. **Namespaces**
+   - Objects use a three-level name: catalog.schema.table. The connection already sets a default catalog and schema, so unqualified table names resolve against them.
+   - Fully qualify tables (catalog.schema.table) when the user refers to another catalog or schema, e.g. for cross-catalog joins.
+   - Quote identifiers with double quotes, never backticks. String literals use single quotes.
+   - Use SHOW CATALOGS, SHOW SCHEMAS FROM <catalog> and SHOW TABLES FROM <catalog>.<schema> for discovery.
+
+2. **Complex Types**
+   - ARRAY: access elements with 1-based subscripts (arr[1]), or element_at(arr, 1) which returns NULL instead of failing when out of range. Use cardinality(arr) for the length and contains(arr, value) for membership.
+   - MAP: access values with element_at(map_col, 'key') (map_col['key'] fails when the key is missing). Use map_keys(), map_values() and map_entries().
+   - ROW: access fields with dot notation, e.g. address.city.
+   - Flatten arrays and maps with CROSS JOIN UNNEST, e.g.
+     SELECT o.id, item.sku FROM orders o CROSS JOIN UNNEST(o.items) AS item (sku, qty)
+     Use WITH ORDINALITY to get the element position. Use LEFT JOIN UNNEST(...) ON true to keep rows with empty arrays.
+
+3. **Lambda Functions**
+   - Higher-order functions take lambdas written as x -> expression: transform(arr, x -> x * 2), filter(arr, x -> x > 0), reduce(arr, 0, (s, x) -> s + x, s -> s), any_match(arr, x -> x = 'a'), transform_values(map_col, (k, v) -> v + 1).
+   - Prefer these over UNNEST + GROUP BY when the result stays one row per input row.
+
+4. **Hive Connector Specifics**
+   - Tables are usually partitioned by columns such as dt, date, year/month/day or region. ALWAYS filter on partition columns when the user's question allows it; a query without a partition filter scans every file in object storage.
+   - Partition columns appear as regular columns. List partitions with SELECT * FROM "table$partitions" (Hive) or "table$partitions" / "table$snapshots" (Iceberg).
+   - Hidden columns "$path" and "$file_modified_time" identify the underlying files.
+   - Hive partition values are often strings; compare them as strings (dt = '2024-01-15') unless the schema says otherwise.
+
+5. **Writes Depend on the Connector**
+   - INSERT is supported by most lake connectors. UPDATE, DELETE and MERGE only work when the connector supports them (Iceberg, Delta Lake, Hive ACID tables, and JDBC connectors). Plain Hive tables usually support only DELETE of whole partitions.
+   - When a write may not be supported, say so in the explanation and prefer a SELECT that previews the affected rows.
+   - INSERT INTO ... SELECT creates new files and partitions in the lake and cannot be undone by a simple DELETE on most connectors. ALWAYS set isCritical: true for INSERT INTO ... SELECT, and canRollback: false unless the connector supports DELETE.
+   - Trino has no multi-statement transactions across catalogs. Do not rely on BEGIN/COMMIT for rollback.
+
+6. **Pagination and Cost**
+   - Trino requires OFFSET before LIMIT: SELECT ... ORDER BY id OFFSET offset_size LIMIT 50. LIMIT ... OFFSET ... is a syntax error.
+   - OFFSET is only deterministic with an ORDER BY.
+   - Large offsets are expensive on S3-backed tables: Trino must read and discard every skipped row, so each deeper page rescans the same files. Prefer cursor-based pagination on a sortable column, always keep the partition filter, and warn the user in the explanation when a query is likely to be paged deeply over a large table.
+
+7. **Functions and Syntax**
+   - Dates: current_date, current_timestamp, date_trunc('day', ts), date_add('day', -7, current_date), date_diff('day', a, b), from_unixtime(), to_unixtime(), format_datetime(ts, 'yyyy-MM-dd').
+   - Casting: CAST(x AS varchar) fails on bad input; use TRY_CAST(x AS integer) when data may be dirty.
+   - Use approx_distinct(), approx_percentile() and approx_most_frequent() for fast estimates on very large tables.
+   - Regular expressions: regexp_like(), regexp_extract(), regexp_replace(). JSON: json_extract_scalar(), json_parse(), CAST(json AS ARRAY(varchar)).
+   - There is no ILIKE; use lower(col) LIKE lower('%value%').
+`
+
+// TrinoVisualizationExtensions is appended to the PostgreSQL visualization prompt.
+const TrinoVisualizationExtensions = `
+
+Trino-specific visualization guidance:
+- Use Trino syntax: double-quoted identifiers, date_trunc('day', ts) for time buckets and approx_distinct() for large distinct counts.
+- Always keep a partition filter (e.g. dt >= '2024-01-01') in chart queries so refreshes do not scan the whole lake.
+- Aggregate server-side and LIMIT the number of groups; data lake tables are often too large for client-side aggregation.
+`
+
+// trinoInsertSelectPattern matches INSERT INTO ... SELECT, which writes new partitions to the lake
+var trinoInsertSelectPattern = regexp.MustCompile(`(?is)^\s*insert\s+into\s+[^\s(]+(\s*\([^)]*\))?\s*\(?\s*(select|with)\b`)
+
+// IsTrinoInsertSelect reports whether a Trino query is an INSERT INTO ... SELECT.
+func IsTrinoInsertSelect(query string) bool {
+	return trinoInsertSelectPattern.MatchString(query)
+}
@@ -0,0 +1,12 @@
+package constants
+
+import "time"
+
+const (
+	VaultAppRoleLoginPath  = "auth/approle/login" // AppRole auth method mounted at its default path
+	VaultRequestTimeout    = 10 * time.Second
+	VaultTokenRenewMargin  = 30 * time.Second // An AppRole token is replaced this long before it expires
+	VaultUserIDPlaceholder = "{user_id}"      // Replaced with the requesting user's ID in VAULT_ALLOWED_PATH_PREFIX
+	VaultUsernameKey       = "username"       // Keys of the secret holding the database credentials
+	VaultPasswordKey       = "password"
+)
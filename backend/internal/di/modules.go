@@ -13,7 +13,9 @@ import (
 	"neobase-ai/pkg/embedding"
 	"neobase-ai/pkg/llm"
 	"neobase-ai/pkg/mongodb"
+	"neobase-ai/pkg/pubsub"
 	"neobase-ai/pkg/redis"
+	"neobase-ai/pkg/vault"
 	"neobase-ai/pkg/vectordb"
 	"time"
 
@@ -58,6 +60,9 @@ func Initialize() {
 	// Initialize dashboard repository with Redis support
 	dashboardRepo := repositories.NewDashboardRepository(mongodbClient, redisRepo)
 
+	// Remove spreadsheet import metadata older than IMPORT_METADATA_TTL_HOURS every hour
+	go dbmanager.NewImportMetadataStore(redisRepo).StartCleanup(context.Background(), constants.ImportMetadataCleanupInterval)
+
 	// Provide all dependencies to the container
 	if err := DiContainer.Provide(func() *mongodb.MongoDBClient { return mongodbClient }); err != nil {
 		log.Fatalf("Failed to provide MongoDB client: %v", err)
@@ -67,6 +72,11 @@ func Initialize() {
 		log.Fatalf("Failed to provide Redis repositories: %v", err)
 	}
 
+	// Chat room events are broadcast over the same Redis connection
+	if err := DiContainer.Provide(func() pubsub.PubSub { return pubsub.NewRedisPubSub(redisClient) }); err != nil {
+		log.Fatalf("Failed to provide pub/sub: %v", err)
+	}
+
 	if err := DiContainer.Provide(func() utils.JWTService { return jwtService }); err != nil {
 		log.Fatalf("Failed to provide JWT service: %v", err)
 	}
@@ -94,10 +104,24 @@ func Initialize() {
 		manager.RegisterDriver(constants.DatabaseTypePostgreSQL, dbmanager.NewPostgresDriver())
 		manager.RegisterDriver(constants.DatabaseTypeYugabyteDB, dbmanager.NewPostgresDriver())  // Use same driver for both
 		manager.RegisterDriver(constants.DatabaseTypeTimescaleDB, dbmanager.NewPostgresDriver()) // TimescaleDB is a PostgreSQL extension
+		manager.RegisterDriver(constants.DatabaseTypeSupabase, dbmanager.NewSupabaseDriver())    // Supabase is hosted PostgreSQL with storage buckets
+		manager.RegisterDriver(constants.DatabaseTypeNeon, dbmanager.NewNeonDriver())            // Neon is serverless PostgreSQL behind a pgxpool
 		manager.RegisterDriver(constants.DatabaseTypeMySQL, dbmanager.NewMySQLDriver())
-		manager.RegisterDriver(constants.DatabaseTypeStarRocks, dbmanager.NewMySQLDriver()) // StarRocks uses MySQL wire protocol
+		manager.RegisterDriver(constants.DatabaseTypeStarRocks, dbmanager.NewMySQLDriver())   // StarRocks uses MySQL wire protocol
+		manager.RegisterDriver(constants.DatabaseTypePlanetscale, dbmanager.NewMySQLDriver()) // PlanetScale is MySQL-compatible (Vitess)
 		manager.RegisterDriver(constants.DatabaseTypeClickhouse, dbmanager.NewClickHouseDriver())
+		manager.RegisterDriver(constants.DatabaseTypeTrino, dbmanager.NewTrinoDriver())
+		manager.RegisterDriver(constants.DatabaseTypeOracle, dbmanager.NewOracleDriver())
+		manager.RegisterDriver(constants.DatabaseTypeAirtable, dbmanager.NewAirtableDriver())           // Airtable is queried over its REST API
+		manager.RegisterDriver(constants.DatabaseTypeInfluxDB, dbmanager.NewInfluxDBDriver())           // InfluxDB 3 is queried over its HTTP API
+		manager.RegisterDriver(constants.DatabaseTypeTemporal, dbmanager.NewTemporalDriver())           // Temporal is queried over its gRPC frontend service
+		manager.RegisterDriver(constants.DatabaseTypePocketBase, dbmanager.NewPocketBaseDriver())       // PocketBase is queried over its REST API
+		manager.RegisterDriver(constants.DatabaseTypeNATS, dbmanager.NewNATSDriver())                   // NATS is queried with the JetStream API
+		manager.RegisterDriver(constants.DatabaseTypeYugabyteDBCQL, dbmanager.NewYugabyteDBCQLDriver()) // YugabyteDB's Cassandra-compatible YCQL API
+		manager.RegisterDriver(constants.DatabaseTypeFirestore, dbmanager.NewFirestoreDriver())         // Firestore is queried with the Firestore SDK
+		manager.RegisterDriver(constants.DatabaseTypeCloudflareD1, dbmanager.NewCloudflareD1Driver())   // Cloudflare D1 is SQLite queried over the Cloudflare API
 		manager.RegisterDriver(constants.DatabaseTypeMongoDB, dbmanager.NewMongoDBDriver())
+		manager.RegisterDriver(constants.DatabaseTypeFerretDB, dbmanager.NewMongoDBDriver()) // FerretDB speaks the MongoDB wire protocol
 		manager.RegisterDriver(constants.DatabaseTypeSpreadsheet, dbmanager.NewSpreadsheetDriver())
 
 		// Register schema fetchers
@@ -110,22 +134,75 @@ func Initialize() {
 		manager.RegisterFetcher(constants.DatabaseTypeTimescaleDB, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
 			return &dbmanager.PostgresDriver{} // TimescaleDB is a PostgreSQL extension
 		})
+		manager.RegisterFetcher(constants.DatabaseTypeSupabase, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.SupabaseDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeNeon, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.PostgresDriver{} // Neon is serverless PostgreSQL
+		})
 		manager.RegisterFetcher(constants.DatabaseTypeMySQL, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
 			return dbmanager.NewMySQLSchemaFetcher(db)
 		})
 		manager.RegisterFetcher(constants.DatabaseTypeStarRocks, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
 			return dbmanager.NewMySQLSchemaFetcher(db) // StarRocks is MySQL-wire-compatible
 		})
+		manager.RegisterFetcher(constants.DatabaseTypePlanetscale, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return dbmanager.NewMySQLSchemaFetcher(db) // PlanetScale is MySQL-compatible (Vitess)
+		})
 		manager.RegisterFetcher(constants.DatabaseTypeClickhouse, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
 			return &dbmanager.ClickHouseDriver{}
 		})
+		manager.RegisterFetcher(constants.DatabaseTypeTrino, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.TrinoDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeOracle, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.OracleDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeAirtable, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.AirtableDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeInfluxDB, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.InfluxDBDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeTemporal, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.TemporalDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypePocketBase, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.PocketBaseDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeNATS, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.NATSDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeYugabyteDBCQL, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.YugabyteDBCQLDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeFirestore, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.FirestoreDriver{}
+		})
+		manager.RegisterFetcher(constants.DatabaseTypeCloudflareD1, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.CloudflareD1Driver{}
+		})
 		manager.RegisterFetcher(constants.DatabaseTypeMongoDB, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
 			return &dbmanager.MongoDBDriver{}
 		})
+		manager.RegisterFetcher(constants.DatabaseTypeFerretDB, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
+			return &dbmanager.MongoDBDriver{} // FerretDB is MongoDB-compatible
+		})
 		manager.RegisterFetcher(constants.DatabaseTypeSpreadsheet, func(db dbmanager.DBExecutor) dbmanager.SchemaFetcher {
 			return &dbmanager.PostgresDriver{}
 		})
 
+		// Enable top-K table selection with Cohere embeddings + rerank if configured
+		if config.Env.EmbeddingEnabled {
+			cohereProvider, err := embedding.NewCohereProvider(config.Env.CohereAPIKey, "")
+			if err != nil {
+				log.Printf("Warning: Failed to initialize Cohere table embeddings: %v", err)
+			} else {
+				manager.GetSchemaManager().SetTableEmbeddings(cohereProvider, cohereProvider, embedding.NewTableEmbeddingStore(redisRepo))
+				log.Printf("Cohere table embeddings enabled (top-K: %d)", config.Env.EmbeddingTopK)
+			}
+		}
+
 		return manager, nil
 	}); err != nil {
 		log.Fatalf("Failed to provide DB manager: %v", err)
@@ -187,6 +264,21 @@ func Initialize() {
 	if err := DiContainer.Provide(func() *llm.Manager {
 		manager := llm.NewManager()
 
+		// Sign LLM requests for an enterprise proxy (nil if LLM_PROXY_SIGNING_KEY is not set)
+		var llmSigner llm.LLMRequestSigner
+		if config.Env.LLMProxySigningKey != "" {
+			signer, err := llm.NewHMACRequestSigner(config.Env.LLMProxySigningKey, config.Env.LLMProxySigningAlgorithm)
+			if err != nil {
+				log.Printf("Warning: Failed to initialize LLM request signer: %v", err)
+			} else {
+				llmSigner = signer
+				log.Printf("LLM request signing enabled (%s)", config.Env.LLMProxySigningAlgorithm)
+			}
+		}
+		if config.Env.LLMProxyURL != "" {
+			log.Printf("LLM requests routed through proxy: %s", config.Env.LLMProxyURL)
+		}
+
 		// Register OpenAI client if API key is available
 		if config.Env.OpenAIAPIKey != "" {
 			// Get default OpenAI model from supported models
@@ -207,6 +299,8 @@ func Initialize() {
 				APIKey:              config.Env.OpenAIAPIKey,
 				MaxCompletionTokens: defaultOpenAIModel.MaxCompletionTokens,
 				Temperature:         defaultOpenAIModel.Temperature,
+				ProxyURL:            config.Env.LLMProxyURL,
+				Signer:              llmSigner,
 				DBConfigs: []llm.LLMDBConfig{
 					{
 						DBType:       constants.DatabaseTypePostgreSQL,
@@ -223,6 +317,16 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeTimescaleDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeTimescaleDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeSupabase,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeSupabase),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeSupabase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNeon,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeNeon),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeNeon, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMySQL,
 						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeMySQL),
@@ -233,16 +337,76 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeStarRocks),
 						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeStarRocks, false),
 					},
+					{
+						DBType:       constants.DatabaseTypePlanetscale,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypePlanetscale),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypePlanetscale, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeClickhouse,
 						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeClickhouse),
 						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeClickhouse, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeTrino,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeTrino),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeTrino, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeOracle,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeOracle),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeOracle, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeAirtable,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeAirtable),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeAirtable, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeInfluxDB,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeInfluxDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeInfluxDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTemporal,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeTemporal),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeTemporal, false),
+					},
+					{
+						DBType:       constants.DatabaseTypePocketBase,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypePocketBase),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypePocketBase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNATS,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeNATS),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeNATS, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeYugabyteDBCQL,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeYugabyteDBCQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeYugabyteDBCQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeFirestore,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeFirestore),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeFirestore, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeCloudflareD1,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeCloudflareD1),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeCloudflareD1, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMongoDB,
 						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeMongoDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeMongoDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeFerretDB,
+						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeFerretDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.OpenAI, constants.DatabaseTypeFerretDB, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeSpreadsheet,
 						Schema:       constants.GetLLMResponseSchema(constants.OpenAI, constants.DatabaseTypeSpreadsheet),
@@ -275,6 +439,8 @@ func Initialize() {
 				APIKey:              config.Env.GeminiAPIKey,
 				MaxCompletionTokens: defaultGeminiModel.MaxCompletionTokens,
 				Temperature:         defaultGeminiModel.Temperature,
+				ProxyURL:            config.Env.LLMProxyURL,
+				Signer:              llmSigner,
 				DBConfigs: []llm.LLMDBConfig{
 					{
 						DBType:       constants.DatabaseTypePostgreSQL,
@@ -291,6 +457,16 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeTimescaleDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeTimescaleDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeSupabase,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeSupabase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeSupabase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNeon,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeNeon),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeNeon, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMySQL,
 						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeMySQL),
@@ -301,16 +477,76 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeStarRocks),
 						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeStarRocks, false),
 					},
+					{
+						DBType:       constants.DatabaseTypePlanetscale,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypePlanetscale),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypePlanetscale, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeClickhouse,
 						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeClickhouse),
 						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeClickhouse, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeTrino,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeTrino),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeTrino, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeOracle,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeOracle),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeOracle, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeAirtable,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeAirtable),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeAirtable, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeInfluxDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeInfluxDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeInfluxDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTemporal,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeTemporal),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeTemporal, false),
+					},
+					{
+						DBType:       constants.DatabaseTypePocketBase,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypePocketBase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypePocketBase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNATS,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeNATS),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeNATS, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeYugabyteDBCQL,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeYugabyteDBCQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeYugabyteDBCQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeFirestore,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeFirestore),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeFirestore, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeCloudflareD1,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeCloudflareD1),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeCloudflareD1, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMongoDB,
 						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeMongoDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeMongoDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeFerretDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeFerretDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Gemini, constants.DatabaseTypeFerretDB, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeSpreadsheet,
 						Schema:       constants.GetLLMResponseSchema(constants.Gemini, constants.DatabaseTypeSpreadsheet),
@@ -343,6 +579,8 @@ func Initialize() {
 				APIKey:              config.Env.ClaudeAPIKey,
 				MaxCompletionTokens: defaultClaudeModel.MaxCompletionTokens,
 				Temperature:         defaultClaudeModel.Temperature,
+				ProxyURL:            config.Env.LLMProxyURL,
+				Signer:              llmSigner,
 				DBConfigs: []llm.LLMDBConfig{
 					{
 						DBType:       constants.DatabaseTypePostgreSQL,
@@ -359,6 +597,16 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeTimescaleDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeTimescaleDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeSupabase,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeSupabase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeSupabase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNeon,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeNeon),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeNeon, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMySQL,
 						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeMySQL),
@@ -369,16 +617,76 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeStarRocks),
 						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeStarRocks, false),
 					},
+					{
+						DBType:       constants.DatabaseTypePlanetscale,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypePlanetscale),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypePlanetscale, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeClickhouse,
 						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeClickhouse),
 						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeClickhouse, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeTrino,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeTrino),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeTrino, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeOracle,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeOracle),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeOracle, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeAirtable,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeAirtable),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeAirtable, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeInfluxDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeInfluxDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeInfluxDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTemporal,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeTemporal),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeTemporal, false),
+					},
+					{
+						DBType:       constants.DatabaseTypePocketBase,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypePocketBase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypePocketBase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNATS,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeNATS),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeNATS, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeYugabyteDBCQL,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeYugabyteDBCQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeYugabyteDBCQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeFirestore,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeFirestore),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeFirestore, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeCloudflareD1,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeCloudflareD1),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeCloudflareD1, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMongoDB,
 						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeMongoDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeMongoDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeFerretDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeFerretDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Claude, constants.DatabaseTypeFerretDB, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeSpreadsheet,
 						Schema:       constants.GetLLMResponseSchema(constants.Claude, constants.DatabaseTypeSpreadsheet),
@@ -427,6 +735,16 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeTimescaleDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeTimescaleDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeSupabase,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeSupabase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeSupabase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNeon,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeNeon),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeNeon, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMySQL,
 						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeMySQL),
@@ -437,16 +755,76 @@ func Initialize() {
 						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeStarRocks),
 						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeStarRocks, false),
 					},
+					{
+						DBType:       constants.DatabaseTypePlanetscale,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypePlanetscale),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypePlanetscale, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeClickhouse,
 						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeClickhouse),
 						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeClickhouse, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeTrino,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeTrino),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeTrino, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeOracle,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeOracle),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeOracle, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeAirtable,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeAirtable),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeAirtable, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeInfluxDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeInfluxDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeInfluxDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTemporal,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeTemporal),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeTemporal, false),
+					},
+					{
+						DBType:       constants.DatabaseTypePocketBase,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypePocketBase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypePocketBase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNATS,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeNATS),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeNATS, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeYugabyteDBCQL,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeYugabyteDBCQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeYugabyteDBCQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeFirestore,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeFirestore),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeFirestore, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeCloudflareD1,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeCloudflareD1),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeCloudflareD1, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeMongoDB,
 						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeMongoDB),
 						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeMongoDB, false),
 					},
+					{
+						DBType:       constants.DatabaseTypeFerretDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeFerretDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Ollama, constants.DatabaseTypeFerretDB, false),
+					},
 					{
 						DBType:       constants.DatabaseTypeSpreadsheet,
 						Schema:       constants.GetLLMResponseSchema(constants.Ollama, constants.DatabaseTypeSpreadsheet),
@@ -459,6 +837,144 @@ func Initialize() {
 			}
 		}
 
+		// Register Cohere client if API key is available
+		if config.Env.CohereAPIKey != "" {
+			// Get default Cohere model from supported models
+			defaultCohereModel := constants.GetDefaultModelForProvider(constants.Cohere)
+			if defaultCohereModel == nil {
+				log.Printf("Warning: No default Cohere model found")
+				defaultCohereModel = &constants.LLMModel{
+					ID:                  "command-a-03-2025",
+					Provider:            constants.Cohere,
+					MaxCompletionTokens: 8000,
+					Temperature:         0.3,
+				}
+			}
+
+			err := manager.RegisterClient(constants.Cohere, llm.Config{
+				Provider:            constants.Cohere,
+				Model:               defaultCohereModel.ID,
+				APIKey:              config.Env.CohereAPIKey,
+				MaxCompletionTokens: defaultCohereModel.MaxCompletionTokens,
+				Temperature:         defaultCohereModel.Temperature,
+				DBConfigs: []llm.LLMDBConfig{
+					{
+						DBType:       constants.DatabaseTypePostgreSQL,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypePostgreSQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypePostgreSQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeYugabyteDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeYugabyteDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeYugabyteDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTimescaleDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeTimescaleDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeTimescaleDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeSupabase,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeSupabase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeSupabase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNeon,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeNeon),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeNeon, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeMySQL,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeMySQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeMySQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeStarRocks,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeStarRocks),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeStarRocks, false),
+					},
+					{
+						DBType:       constants.DatabaseTypePlanetscale,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypePlanetscale),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypePlanetscale, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeClickhouse,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeClickhouse),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeClickhouse, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTrino,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeTrino),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeTrino, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeOracle,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeOracle),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeOracle, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeAirtable,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeAirtable),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeAirtable, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeInfluxDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeInfluxDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeInfluxDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeTemporal,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeTemporal),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeTemporal, false),
+					},
+					{
+						DBType:       constants.DatabaseTypePocketBase,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypePocketBase),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypePocketBase, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeNATS,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeNATS),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeNATS, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeYugabyteDBCQL,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeYugabyteDBCQL),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeYugabyteDBCQL, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeFirestore,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeFirestore),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeFirestore, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeCloudflareD1,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeCloudflareD1),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeCloudflareD1, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeMongoDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeMongoDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeMongoDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeFerretDB,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeFerretDB),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeFerretDB, false),
+					},
+					{
+						DBType:       constants.DatabaseTypeSpreadsheet,
+						Schema:       constants.GetLLMResponseSchema(constants.Cohere, constants.DatabaseTypeSpreadsheet),
+						SystemPrompt: constants.GetSystemPrompt(constants.Cohere, constants.DatabaseTypeSpreadsheet, false),
+					},
+				},
+			})
+			if err != nil {
+				log.Printf("Warning: Failed to register Cohere client: %v", err)
+			}
+		}
+
 		return manager
 	}); err != nil {
 		log.Fatalf("Failed to provide LLM manager: %v", err)
@@ -474,6 +990,55 @@ func Initialize() {
 		log.Fatalf("Failed to provide knowledge base repository: %v", err)
 	}
 
+	// Feedback Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.FeedbackRepository {
+		return repositories.NewFeedbackRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide feedback repository: %v", err)
+	}
+
+	// Reaction Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.ReactionRepository {
+		return repositories.NewReactionRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide reaction repository: %v", err)
+	}
+
+	// Secure Note Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.SecureNoteRepository {
+		return repositories.NewSecureNoteRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide secure note repository: %v", err)
+	}
+
+	// Query Template Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.QueryTemplateRepository {
+		return repositories.NewQueryTemplateRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide query template repository: %v", err)
+	}
+
+	// Schema Source Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.SchemaSourceRepository {
+		return repositories.NewSchemaSourceRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide schema source repository: %v", err)
+	}
+
+	// Report Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.ReportRepository {
+		return repositories.NewReportRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide report repository: %v", err)
+	}
+
+	// Rollback Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.RollbackRepository {
+		return repositories.NewRollbackRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide rollback repository: %v", err)
+	}
+
 	// Update Chat Service provider to include DB manager setup
 	if err := DiContainer.Provide(func(
 		chatRepo repositories.ChatRepository,
@@ -484,6 +1049,15 @@ func Initialize() {
 		mongoClient *mongodb.MongoDBClient,
 		kbRepo repositories.KnowledgeBaseRepository,
 		dashboardRepo repositories.DashboardRepository,
+		chatPubSub pubsub.PubSub,
+		userRepo repositories.UserRepository,
+		feedbackRepo repositories.FeedbackRepository,
+		secureNoteRepo repositories.SecureNoteRepository,
+		reactionRepo repositories.ReactionRepository,
+		queryTemplateRepo repositories.QueryTemplateRepository,
+		schemaSourceRepo repositories.SchemaSourceRepository,
+		reportRepo repositories.ReportRepository,
+		rollbackRepo repositories.RollbackRepository,
 	) services.ChatService {
 		// Get a default LLM client - try in order of preference
 		var llmClient llm.Client
@@ -568,11 +1142,32 @@ func Initialize() {
 			}()
 		}
 
-		chatService := services.NewChatService(chatRepo, dbManager, llmClient, llmManager, redisRepo, visualizationRepo, vectorizationSvc, kbRepo, dashboardRepo)
+		// Initialize Vault secret resolver (nil if VAULT_ADDR is not set)
+		var vaultResolver *vault.VaultSecretResolver
+		if config.Env.VaultAddr != "" {
+			resolver, vErr := vault.NewVaultSecretResolver(vault.Config{
+				Address:           config.Env.VaultAddr,
+				Token:             config.Env.VaultToken,
+				RoleID:            config.Env.VaultRoleID,
+				SecretID:          config.Env.VaultSecretID,
+				AllowedPathPrefix: config.Env.VaultAllowedPathPrefix,
+			})
+			if vErr != nil {
+				log.Printf("Warning: Failed to initialize Vault client: %v", vErr)
+			} else {
+				vaultResolver = resolver
+				log.Printf("Vault secret resolver initialized: %s", config.Env.VaultAddr)
+			}
+		}
+
+		chatService := services.NewChatService(chatRepo, dbManager, llmClient, llmManager, redisRepo, visualizationRepo, vectorizationSvc, kbRepo, dashboardRepo, chatPubSub, userRepo, feedbackRepo, secureNoteRepo, reactionRepo, queryTemplateRepo, schemaSourceRepo, reportRepo, rollbackRepo, vaultResolver)
 
 		// Set chat service as stream handler for DB manager
 		dbManager.SetStreamHandler(chatService)
 
+		// Uploaded schema files are merged into the schema formatted for the LLM
+		dbManager.SetExternalSchemaProvider(chatService)
+
 		// Set chat service in auth service
 		err = DiContainer.Invoke(func(authService services.AuthService) {
 			authService.SetChatService(chatService)
@@ -616,6 +1211,79 @@ func Initialize() {
 		log.Fatalf("Failed to provide dashboard import/export service: %v", err)
 	}
 
+	// Analytics Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.AnalyticsRepository {
+		return repositories.NewAnalyticsRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide analytics repository: %v", err)
+	}
+
+	// Analytics Service
+	if err := DiContainer.Provide(func(
+		analyticsRepo repositories.AnalyticsRepository,
+		chatRepo repositories.ChatRepository,
+		userRepo repositories.UserRepository,
+	) services.AnalyticsService {
+		return services.NewAnalyticsService(analyticsRepo, chatRepo, userRepo)
+	}); err != nil {
+		log.Fatalf("Failed to provide analytics service: %v", err)
+	}
+
+	// Global Search Service
+	if err := DiContainer.Provide(func(chatRepo repositories.ChatRepository) services.GlobalSearchService {
+		return services.NewGlobalSearchService(chatRepo)
+	}); err != nil {
+		log.Fatalf("Failed to provide global search service: %v", err)
+	}
+
+	// Admin Service
+	if err := DiContainer.Provide(func(
+		userRepo repositories.UserRepository,
+		dbManager *dbmanager.Manager,
+		feedbackRepo repositories.FeedbackRepository,
+		llmManager *llm.Manager,
+	) services.AdminService {
+		return services.NewAdminService(userRepo, dbManager, feedbackRepo, llmManager)
+	}); err != nil {
+		log.Fatalf("Failed to provide admin service: %v", err)
+	}
+
+	// GDPR Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.GDPRRepository {
+		return repositories.NewGDPRRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide GDPR repository: %v", err)
+	}
+
+	// GDPR Service
+	if err := DiContainer.Provide(func(
+		userRepo repositories.UserRepository,
+		gdprRepo repositories.GDPRRepository,
+		chatService services.ChatService,
+	) services.GDPRService {
+		return services.NewGDPRService(userRepo, gdprRepo, chatService)
+	}); err != nil {
+		log.Fatalf("Failed to provide GDPR service: %v", err)
+	}
+
+	// API Key Repository
+	if err := DiContainer.Provide(func(mongoClient *mongodb.MongoDBClient) repositories.APIKeyRepository {
+		return repositories.NewAPIKeyRepository(mongoClient)
+	}); err != nil {
+		log.Fatalf("Failed to provide API key repository: %v", err)
+	}
+
+	// API Key Service
+	if err := DiContainer.Provide(func(
+		apiKeyRepo repositories.APIKeyRepository,
+		tokenRepo repositories.TokenRepository,
+		jwt utils.JWTService,
+	) services.APIKeyService {
+		return services.NewAPIKeyService(apiKeyRepo, tokenRepo, jwt)
+	}); err != nil {
+		log.Fatalf("Failed to provide API key service: %v", err)
+	}
+
 	// Provide handlers
 	if err := DiContainer.Provide(func(authService services.AuthService) *handlers.AuthHandler {
 		return handlers.NewAuthHandler(authService)
@@ -663,6 +1331,41 @@ func Initialize() {
 	}); err != nil {
 		log.Fatalf("Failed to provide dashboard handler: %v", err)
 	}
+
+	// Analytics Handler
+	if err := DiContainer.Provide(func(analyticsService services.AnalyticsService) *handlers.AnalyticsHandler {
+		return handlers.NewAnalyticsHandler(analyticsService)
+	}); err != nil {
+		log.Fatalf("Failed to provide analytics handler: %v", err)
+	}
+
+	// Global Search Handler
+	if err := DiContainer.Provide(func(globalSearchService services.GlobalSearchService) *handlers.GlobalSearchHandler {
+		return handlers.NewGlobalSearchHandler(globalSearchService)
+	}); err != nil {
+		log.Fatalf("Failed to provide global search handler: %v", err)
+	}
+
+	// Admin Handler
+	if err := DiContainer.Provide(func(adminService services.AdminService) *handlers.AdminHandler {
+		return handlers.NewAdminHandler(adminService)
+	}); err != nil {
+		log.Fatalf("Failed to provide admin handler: %v", err)
+	}
+
+	// GDPR Handler
+	if err := DiContainer.Provide(func(gdprService services.GDPRService) *handlers.GDPRHandler {
+		return handlers.NewGDPRHandler(gdprService)
+	}); err != nil {
+		log.Fatalf("Failed to provide GDPR handler: %v", err)
+	}
+
+	// API Key Handler
+	if err := DiContainer.Provide(func(apiKeyService services.APIKeyService) *handlers.APIKeyHandler {
+		return handlers.NewAPIKeyHandler(apiKeyService)
+	}); err != nil {
+		log.Fatalf("Failed to provide API key handler: %v", err)
+	}
 }
 
 // GetAuthHandler retrieves the AuthHandler from the DI container
@@ -737,6 +1440,66 @@ func GetDashboardHandler() (*handlers.DashboardHandler, error) {
 	return handler, nil
 }
 
+// GetAnalyticsHandler retrieves the AnalyticsHandler from the DI container
+func GetAnalyticsHandler() (*handlers.AnalyticsHandler, error) {
+	var handler *handlers.AnalyticsHandler
+	err := DiContainer.Invoke(func(h *handlers.AnalyticsHandler) {
+		handler = h
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+// GetGlobalSearchHandler retrieves the GlobalSearchHandler from the DI container
+func GetGlobalSearchHandler() (*handlers.GlobalSearchHandler, error) {
+	var handler *handlers.GlobalSearchHandler
+	err := DiContainer.Invoke(func(h *handlers.GlobalSearchHandler) {
+		handler = h
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+// GetAdminHandler retrieves the AdminHandler from the DI container
+func GetAdminHandler() (*handlers.AdminHandler, error) {
+	var handler *handlers.AdminHandler
+	err := DiContainer.Invoke(func(h *handlers.AdminHandler) {
+		handler = h
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+// GetGDPRHandler retrieves the GDPRHandler from the DI container
+func GetGDPRHandler() (*handlers.GDPRHandler, error) {
+	var handler *handlers.GDPRHandler
+	err := DiContainer.Invoke(func(h *handlers.GDPRHandler) {
+		handler = h
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+// GetAPIKeyHandler retrieves the APIKeyHandler from the DI container
+func GetAPIKeyHandler() (*handlers.APIKeyHandler, error) {
+	var handler *handlers.APIKeyHandler
+	err := DiContainer.Invoke(func(h *handlers.APIKeyHandler) {
+		handler = h
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
 // GetChatService retrieves the ChatService from the DI container
 func GetChatService() (services.ChatService, error) {
 	var service services.ChatService
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compressionMinLength is the smallest response body compressed, below it gzip's overhead outweighs the savings
+const compressionMinLength = 1024
+
+// uncompressedPaths are left alone: /metrics is compressed by Prometheus itself, the SSE streams must be
+// flushed as events are written, and the export downloads are streamed as files.
+var uncompressedPaths = []*regexp.Regexp{
+	regexp.MustCompile(`^/metrics$`),
+	regexp.MustCompile(`^/api/chats/[^/]+/(stream|events)$`),
+	regexp.MustCompile(`^/api/chats/[^/]+/export$`),
+	regexp.MustCompile(`^/api/chats/[^/]+/dashboards/[^/]+/export$`),
+}
+
+// CompressionMiddleware gzips responses of at least compressionMinLength bytes for clients sending
+// Accept-Encoding: gzip, setting Content-Encoding and Vary. Smaller bodies are written as they are.
+func CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !shouldCompress(c) {
+			c.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer writer.finish()
+
+		c.Next()
+	}
+}
+
+func shouldCompress(c *gin.Context) bool {
+	if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") ||
+		strings.Contains(c.GetHeader("Connection"), "Upgrade") {
+		return false
+	}
+	for _, pattern := range uncompressedPaths {
+		if pattern.MatchString(c.Request.URL.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+// gzipResponseWriter buffers the body until it reaches compressionMinLength, then switches to gzip
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() < compressionMinLength {
+		return len(data), nil
+	}
+	if err := w.commit(true); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// commit writes out the buffered bytes, gzipped when compress is set and the handler did not encode
+// the body itself. Everything written afterwards follows the same path.
+func (w *gzipResponseWriter) commit(compress bool) error {
+	if !compress || w.Header().Get("Content-Encoding") != "" {
+		w.passthrough = true
+		_, err := w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+		return err
+	}
+
+	header := w.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends the buffered bytes before flushing, a handler flushing a body still under
+// compressionMinLength wants it sent now, so it goes out uncompressed
+func (w *gzipResponseWriter) Flush() {
+	if w.gz == nil && !w.passthrough && w.buf.Len() > 0 {
+		_ = w.commit(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// finish writes out a body that stayed under the threshold, or closes the gzip stream
+func (w *gzipResponseWriter) finish() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+		return
+	}
+	if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
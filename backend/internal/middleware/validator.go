@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"neobase-ai/internal/apis/dtos"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// validate checks request bodies against the binding tags of the dtos, the same tags gin checks for query
+// parameters. Fields are reported by their JSON names.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// BindAndValidate decodes the JSON body of the request into req and validates it. On failure it writes a
+// 400 response listing the invalid fields and returns false, the handler then returns without calling the service.
+func BindAndValidate(c *gin.Context, req interface{}) bool {
+	fieldErrors := bindAndValidate(c, req)
+	if len(fieldErrors) == 0 {
+		return true
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		if fieldError.Field == "" {
+			messages = append(messages, fieldError.Message)
+		} else {
+			messages = append(messages, fieldError.Field+": "+fieldError.Message)
+		}
+	}
+	errorMsg := "Invalid request body: " + strings.Join(messages, "; ")
+	c.JSON(http.StatusBadRequest, dtos.Response{
+		Success: false,
+		Error:   &errorMsg,
+		Data:    dtos.ValidationErrorsResponse{Errors: fieldErrors},
+	})
+	return false
+}
+
+// bindAndValidate returns the errors of the request body, nil when it is valid
+func bindAndValidate(c *gin.Context, req interface{}) []dtos.FieldError {
+	if c.Request.Body == nil {
+		return []dtos.FieldError{{Message: "request body is required"}}
+	}
+
+	if err := json.NewDecoder(c.Request.Body).Decode(req); err != nil {
+		return []dtos.FieldError{decodeError(err)}
+	}
+
+	err := validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []dtos.FieldError{{Message: err.Error()}}
+	}
+
+	fieldErrors := make([]dtos.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors = append(fieldErrors, dtos.FieldError{
+			Field:   fieldPath(fieldError),
+			Message: validationMessage(fieldError),
+			Value:   fieldValue(fieldError),
+		})
+	}
+	return fieldErrors
+}
+
+// decodeError translates a json decoding error into the field it happened at
+func decodeError(err error) dtos.FieldError {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return dtos.FieldError{Message: "request body is required"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return dtos.FieldError{Message: "request body is not valid JSON: unexpected end of input"}
+	case errors.As(err, &syntaxError):
+		return dtos.FieldError{Message: fmt.Sprintf("request body is not valid JSON at offset %d: %v", syntaxError.Offset, syntaxError)}
+	case errors.As(err, &typeError):
+		return dtos.FieldError{
+			Field:   typeError.Field,
+			Message: "must be " + jsonTypeName(typeError.Type.Kind()),
+			Value:   typeError.Value,
+		}
+	default:
+		return dtos.FieldError{Message: err.Error()}
+	}
+}
+
+// fieldPath returns the JSON path of the field without the request struct name, e.g. connection.type
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// fieldValue returns the rejected value, left out for empty values and secrets
+func fieldValue(fieldError validator.FieldError) interface{} {
+	name := strings.ToLower(fieldError.Field())
+	for _, secret := range []string{"password", "secret", "token", "key"} {
+		if strings.Contains(name, secret) {
+			return nil
+		}
+	}
+
+	value := reflect.ValueOf(fieldError.Value())
+	if !value.IsValid() || value.IsZero() {
+		return nil
+	}
+	return fieldError.Value()
+}
+
+// validationMessage translates a failed validation tag into a readable message
+func validationMessage(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	sized := fieldError.Kind() == reflect.String || fieldError.Kind() == reflect.Slice || fieldError.Kind() == reflect.Map
+	unit := "items"
+	if fieldError.Kind() == reflect.String {
+		unit = "characters"
+	}
+
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "required_if", "required_with", "required_without":
+		return "is required with the other fields sent"
+	case "min", "gte":
+		if sized {
+			return fmt.Sprintf("must have at least %s %s", param, unit)
+		}
+		return "must be at least " + param
+	case "max", "lte":
+		if sized {
+			return fmt.Sprintf("must have at most %s %s", param, unit)
+		}
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "len":
+		if sized {
+			return fmt.Sprintf("must have exactly %s %s", param, unit)
+		}
+		return "must be " + param
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "dive":
+		return "has an invalid item"
+	default:
+		return fmt.Sprintf("failed the %s check", fieldError.Tag())
+	}
+}
+
+// jsonTypeName returns the JSON type a Go kind is decoded from
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return "a " + kind.String()
+	}
+}
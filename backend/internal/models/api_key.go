@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKey is a long-lived token issued to a user for programmatic access. The token itself is never stored,
+// the ID of the document is the token's jti and is used to revoke it.
+type APIKey struct {
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name       string             `bson:"key_name" json:"key_name"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	Base       `bson:",inline"`
+}
+
+func NewAPIKey(userID primitive.ObjectID, name string, expiresAt time.Time) *APIKey {
+	return &APIKey{
+		UserID:    userID,
+		Name:      name,
+		ExpiresAt: expiresAt,
+		Base:      NewBase(),
+	}
+}
@@ -1,15 +1,45 @@
 package models
 
 import (
+	"time"
+
+	"neobase-ai/internal/constants"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ChatSettings struct {
-	AutoExecuteQuery          bool   `bson:"auto_execute_query" json:"auto_execute_query,omitempty"`                   // default is true, Execute query automatically when LLM response is received
-	ShareDataWithAI           bool   `bson:"share_data_with_ai" json:"share_data_with_ai,omitempty"`                   // default is false, Don't share data with AI
-	NonTechMode               bool   `bson:"non_tech_mode" json:"non_tech_mode,omitempty"`                             // default is false, Enable non-technical mode for simplified responses
-	SelectedLLMModel          string `bson:"selected_llm_model" json:"selected_llm_model,omitempty"`                   // LLM model selected for this chat (e.g., "gpt-4o", "gemini-2.0-flash")
-	AutoGenerateVisualization bool   `bson:"auto_generate_visualization" json:"auto_generate_visualization,omitempty"` // default is false, Auto-generate chart visualizations for compatible queries
+	AutoExecuteQuery          bool              `bson:"auto_execute_query" json:"auto_execute_query,omitempty"`                   // default is true, Execute query automatically when LLM response is received
+	ShareDataWithAI           bool              `bson:"share_data_with_ai" json:"share_data_with_ai,omitempty"`                   // default is false, Don't share data with AI
+	NonTechMode               bool              `bson:"non_tech_mode" json:"non_tech_mode,omitempty"`                             // default is false, Enable non-technical mode for simplified responses
+	SelectedLLMModel          string            `bson:"selected_llm_model" json:"selected_llm_model,omitempty"`                   // LLM model selected for this chat (e.g., "gpt-4o", "gemini-2.0-flash")
+	AutoGenerateVisualization bool              `bson:"auto_generate_visualization" json:"auto_generate_visualization,omitempty"` // default is false, Auto-generate chart visualizations for compatible queries
+	QueryTimeoutSeconds       *int              `bson:"query_timeout_seconds,omitempty" json:"query_timeout_seconds,omitempty"`   // default is 30, Per-query execution timeout in seconds
+	EncryptedColumns          []string          `bson:"encrypted_columns,omitempty" json:"encrypted_columns,omitempty"`           // default is empty, Columns whose values are encrypted individually in stored results
+	FallbackChain             []string          `bson:"fallback_chain,omitempty" json:"fallback_chain,omitempty"`                 // default is empty (LLM_DEFAULT_FALLBACK_CHAIN), Model IDs tried in order when the selected model is rate limited or unavailable
+	MaxQueryCostUnits         *float64          `bson:"max_query_cost_units,omitempty" json:"max_query_cost_units,omitempty"`     // default is nil (no budget), PostgreSQL queries whose EXPLAIN total cost is higher are blocked
+	DisableParallelWorkers    bool              `bson:"disable_parallel_workers" json:"disable_parallel_workers,omitempty"`       // default is false, Run PostgreSQL queries with max_parallel_workers_per_gather = 0 for predictable costs
+	HiddenTables              []string          `bson:"hidden_tables,omitempty" json:"hidden_tables,omitempty"`                   // default is empty, Tables left out of the schema the LLM sees, they can still be queried directly
+	SystemPromptAppend        *string           `bson:"system_prompt_append,omitempty" json:"-"`                                  // default is nil, AES encrypted rules appended to the system prompt of this chat
+	SchemaAliases             *SchemaAliases    `bson:"schema_aliases,omitempty" json:"schema_aliases,omitempty"`                 // default is nil, Business names the LLM sees instead of the real table and column names
+	QueryStyle                *QueryStyleConfig `bson:"query_style,omitempty" json:"query_style,omitempty"`                       // default is nil, Team code style generated SQL queries are rewritten in
+}
+
+// QueryStyleConfig is how a team writes SQL, generated and edited queries are rewritten to match it.
+// Empty values leave that part of a query as the LLM wrote it.
+type QueryStyleConfig struct {
+	JoinStyle         string `bson:"join_style,omitempty" json:"joinStyle,omitempty"`                  // "INNER JOIN" or "JOIN"
+	Indentation       int    `bson:"indentation,omitempty" json:"indentation,omitempty"`               // 2 or 4 spaces
+	KeywordCase       string `bson:"keyword_case,omitempty" json:"keywordCase,omitempty"`              // "upper" or "lower"
+	NotEqualsOperator string `bson:"not_equals_operator,omitempty" json:"notEqualsOperator,omitempty"` // "!=" or "<>"
+	TableAliasSuffix  string `bson:"table_alias_suffix,omitempty" json:"tableAliasSuffix,omitempty"`   // e.g. "_t" aliases orders as orders_t
+}
+
+// SchemaAliases rename tables and columns in the schema the LLM sees, e.g. user_accounts for usr_acct.
+// Queries the LLM writes with them are mapped back to the real names before they run.
+type SchemaAliases struct {
+	TableAliases  map[string]string `bson:"table_aliases,omitempty" json:"tableAliases,omitempty"`   // Real table name -> alias
+	ColumnAliases map[string]string `bson:"column_aliases,omitempty" json:"columnAliases,omitempty"` // Real "table.column" -> alias
 }
 
 type Connection struct {
@@ -41,10 +71,61 @@ type Connection struct {
 	SSHPassword      *string `bson:"ssh_password,omitempty" json:"-"`                                    // Hide in JSON
 
 	// Google Sheets specific fields
-	GoogleSheetID      *string `bson:"google_sheet_id,omitempty" json:"google_sheet_id,omitempty"`
-	GoogleSheetURL     *string `bson:"google_sheet_url,omitempty" json:"google_sheet_url,omitempty"` // Encrypted, show in JSON for user reference
-	GoogleAuthToken    *string `bson:"google_auth_token,omitempty" json:"-"`                         // Hide in JSON
-	GoogleRefreshToken *string `bson:"google_refresh_token,omitempty" json:"-"`                      // Hide in JSON
+	GoogleSheetID      *string    `bson:"google_sheet_id,omitempty" json:"google_sheet_id,omitempty"`
+	GoogleSheetURL     *string    `bson:"google_sheet_url,omitempty" json:"google_sheet_url,omitempty"` // Encrypted, show in JSON for user reference
+	GoogleAuthToken    *string    `bson:"google_auth_token,omitempty" json:"-"`                         // Hide in JSON
+	GoogleRefreshToken *string    `bson:"google_refresh_token,omitempty" json:"-"`                      // Hide in JSON
+	LastSyncedAt       *time.Time `bson:"last_synced_at,omitempty" json:"last_synced_at,omitempty"`     // When the sheet data was last synced without re-importing
+
+	// Supabase API keys, used to tell the LLM whether Row Level Security applies
+	SupabaseAnonKey        *string `bson:"supabase_anon_key,omitempty" json:"-"`         // Hide in JSON
+	SupabaseServiceRoleKey *string `bson:"supabase_service_role_key,omitempty" json:"-"` // Hide in JSON
+
+	// Trino default catalog and schema, the first two levels of catalog.schema.table
+	Catalog *string `bson:"catalog,omitempty" json:"catalog,omitempty"`
+	Schema  *string `bson:"schema,omitempty" json:"schema,omitempty"`
+
+	// Oracle service name of the Easy Connect string (host:port/service_name)
+	ServiceName *string `bson:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Airtable personal access token and base ID
+	AirtableAPIKey *string `bson:"airtable_api_key,omitempty" json:"-"` // Hide in JSON
+	AirtableBaseID *string `bson:"airtable_base_id,omitempty" json:"airtable_base_id,omitempty"`
+
+	// PlanetScale branch, and the organization and service token used to list branches
+	PlanetscaleBranch         *string `bson:"planetscale_branch,omitempty" json:"planetscale_branch,omitempty"`
+	PlanetscaleOrganization   *string `bson:"planetscale_organization,omitempty" json:"planetscale_organization,omitempty"`
+	PlanetscaleServiceTokenID *string `bson:"planetscale_service_token_id,omitempty" json:"planetscale_service_token_id,omitempty"`
+	PlanetscaleServiceToken   *string `bson:"planetscale_service_token,omitempty" json:"-"` // Hide in JSON
+
+	// InfluxDB API token, and the organization used for InfluxDB Cloud writes
+	InfluxOrg   *string `bson:"influx_org,omitempty" json:"influx_org,omitempty"`
+	InfluxToken *string `bson:"influx_token,omitempty" json:"-"` // Hide in JSON
+
+	// MongoDB read preference, empty reads from the primary
+	ReadPreference *string `bson:"read_preference,omitempty" json:"read_preference,omitempty"`
+
+	// Temporal frontend address (host:port) and the namespace workflows are listed in
+	TemporalNamespace *string `bson:"temporal_namespace,omitempty" json:"temporal_namespace,omitempty"`
+	TemporalAddress   *string `bson:"temporal_address,omitempty" json:"temporal_address,omitempty"`
+
+	// NATS .creds file path on the server, used instead of a username and password or token
+	NATSCredentialsFile *string `bson:"nats_credentials_file,omitempty" json:"nats_credentials_file,omitempty"`
+
+	// Firestore Google Cloud project and service account JSON
+	GoogleProjectID          *string `bson:"google_project_id,omitempty" json:"google_project_id,omitempty"`
+	FirestoreCredentialsJSON *string `bson:"firestore_credentials_json,omitempty" json:"-"` // Hide in JSON
+
+	// Cloudflare D1 account, database and API token
+	CloudflareAccountID    *string `bson:"cloudflare_account_id,omitempty" json:"cloudflare_account_id,omitempty"`
+	CloudflareD1DatabaseID *string `bson:"cloudflare_d1_database_id,omitempty" json:"cloudflare_d1_database_id,omitempty"`
+	CloudflareAPIToken     *string `bson:"cloudflare_api_token,omitempty" json:"-"` // Hide in JSON
+
+	// YugabyteDB API the connection uses: "ysql" (PostgreSQL-compatible) or "ycql" (Cassandra-compatible)
+	APIType *string `bson:"api_type,omitempty" json:"api_type,omitempty"`
+
+	// HashiCorp Vault secret path, the username and password are resolved from it at save time and on re-resolve
+	VaultSecretPath *string `bson:"vault_secret_path,omitempty" json:"vault_secret_path,omitempty"`
 
 	// Schema Cache - stores formatted schema for LLM context
 	CurrentSchema   *string             `bson:"current_schema,omitempty" json:"current_schema,omitempty"`       // Formatted schema string ready for LLM
@@ -59,7 +140,13 @@ type Chat struct {
 	SelectedCollections string             `bson:"selected_collections" json:"selected_collections"` // "ALL" or comma-separated table names
 	Settings            ChatSettings       `bson:"settings" json:"settings"`
 	PreferredLLMModel   *string            `bson:"preferred_llm_model" json:"preferred_llm_model"` // User's preferred LLM model for this chat
-	Base                `bson:",inline"`
+	// SecondaryConnections are additional databases queried alongside Connection for federated queries (encrypted like Connection)
+	SecondaryConnections []Connection `bson:"secondary_connections,omitempty" json:"secondary_connections,omitempty"`
+	// Title is generated by the LLM after the first AI response, unless the user has set one
+	Title *string `bson:"title,omitempty" json:"title,omitempty"`
+	// SummarizationCount is how many times the oldest half of the chat's LLM context has been summarized
+	SummarizationCount int `bson:"summarization_count" json:"summarization_count"`
+	Base               `bson:",inline"`
 }
 
 func NewChat(userID primitive.ObjectID, connection Connection, settings ChatSettings) *Chat {
@@ -78,5 +165,14 @@ func DefaultChatSettings() ChatSettings {
 		ShareDataWithAI:           false, // default is false, Don't share data with AI
 		NonTechMode:               false, // default is false, Technical mode enabled by default
 		AutoGenerateVisualization: false, // default is false, Don't auto-generate visualizations
+		QueryTimeoutSeconds:       nil,   // default is nil, falls back to constants.DefaultQueryTimeoutSeconds
+	}
+}
+
+// GetQueryTimeoutSeconds returns the per-query execution timeout, falling back to the default for older chats
+func (s ChatSettings) GetQueryTimeoutSeconds() int {
+	if s.QueryTimeoutSeconds == nil || *s.QueryTimeoutSeconds <= 0 {
+		return constants.DefaultQueryTimeoutSeconds
 	}
+	return *s.QueryTimeoutSeconds
 }
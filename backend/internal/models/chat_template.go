@@ -0,0 +1,13 @@
+package models
+
+// ChatTemplate is a pre-configured chat that new users can start from, with example questions
+// and the schema the questions are written against. Templates are seeded in code, not stored in MongoDB.
+type ChatTemplate struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	DatabaseType    string   `json:"database_type"`
+	ExampleMessages []string `json:"example_messages"`
+	SchemaExample   string   `json:"schema_example"`
+	IsExampleDB     bool     `json:"is_example_db"` // true when the demo database configured from environment variables can back this template
+}
@@ -0,0 +1,59 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ExternalSchemaSource is a schema file uploaded for a chat, such as a schema.prisma. It adds what database
+// introspection misses, relation names and descriptions, to the schema sent to the LLM. A chat has at most
+// one source per type, a new upload replaces the previous one.
+type ExternalSchemaSource struct {
+	ChatID     primitive.ObjectID    `bson:"chat_id" json:"chat_id"`
+	UserID     primitive.ObjectID    `bson:"user_id" json:"user_id"`
+	SourceType string                `bson:"source_type" json:"source_type"` // "prisma" or "openapi"
+	FileName   string                `bson:"file_name" json:"file_name"`
+	Content    string                `bson:"content" json:"content"` // The file as uploaded
+	Tables     []ExternalSchemaTable `bson:"tables" json:"tables"`
+	Base       `bson:",inline"`
+}
+
+// ExternalSchemaTable is a table as the source describes it, with database names rather than model names
+type ExternalSchemaTable struct {
+	Name        string                   `bson:"name" json:"name"`
+	Model       string                   `bson:"model,omitempty" json:"model,omitempty"`
+	Description string                   `bson:"description,omitempty" json:"description,omitempty"`
+	Columns     []ExternalSchemaColumn   `bson:"columns" json:"columns"`
+	Relations   []ExternalSchemaRelation `bson:"relations,omitempty" json:"relations,omitempty"`
+}
+
+type ExternalSchemaColumn struct {
+	Name        string `bson:"name" json:"name"`
+	Type        string `bson:"type" json:"type"`
+	NativeType  string `bson:"native_type,omitempty" json:"native_type,omitempty"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+	// Example and Enum come from OpenAPI specs, the example value and the values the column is limited to
+	Example string   `bson:"example,omitempty" json:"example,omitempty"`
+	Enum    []string `bson:"enum,omitempty" json:"enum,omitempty"`
+}
+
+// ExternalSchemaRelation is the foreign key side of a relation, Columns of the table reference RefColumns of RefTable
+type ExternalSchemaRelation struct {
+	Name        string   `bson:"name,omitempty" json:"name,omitempty"`
+	Field       string   `bson:"field" json:"field"` // The relation field of the model, e.g. "author"
+	Columns     []string `bson:"columns" json:"columns"`
+	RefTable    string   `bson:"ref_table" json:"ref_table"`
+	RefColumns  []string `bson:"ref_columns" json:"ref_columns"`
+	OnDelete    string   `bson:"on_delete,omitempty" json:"on_delete,omitempty"`
+	OnUpdate    string   `bson:"on_update,omitempty" json:"on_update,omitempty"`
+	Description string   `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+func NewExternalSchemaSource(chatID, userID primitive.ObjectID, sourceType, fileName, content string, tables []ExternalSchemaTable) *ExternalSchemaSource {
+	return &ExternalSchemaSource{
+		ChatID:     chatID,
+		UserID:     userID,
+		SourceType: sourceType,
+		FileName:   fileName,
+		Content:    content,
+		Tables:     tables,
+		Base:       NewBase(),
+	}
+}
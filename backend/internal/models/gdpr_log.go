@@ -0,0 +1,25 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// GDPRLog records a personal data request that was carried out, an erasure by an admin or an export by the user.
+// The log is append-only: it is never updated, and it outlives the data it describes, so it holds counts and IDs only.
+type GDPRLog struct {
+	Action        string             `bson:"action" json:"action"`                   // "deletion" or "export"
+	SubjectUserID primitive.ObjectID `bson:"subject_user_id" json:"subject_user_id"` // The user whose data it is
+	PerformedBy   primitive.ObjectID `bson:"performed_by" json:"performed_by"`       // The admin for a deletion, the user for an export
+	Counts        map[string]int64   `bson:"counts" json:"counts"`                   // Documents deleted or exported per collection
+	Errors        []string           `bson:"errors,omitempty" json:"errors,omitempty"`
+	Base          `bson:",inline"`
+}
+
+func NewGDPRLog(action string, subjectUserID, performedBy primitive.ObjectID, counts map[string]int64, errors []string) *GDPRLog {
+	return &GDPRLog{
+		Action:        action,
+		SubjectUserID: subjectUserID,
+		PerformedBy:   performedBy,
+		Counts:        counts,
+		Errors:        errors,
+		Base:          NewBase(),
+	}
+}
@@ -18,6 +18,7 @@ type LLMMessage struct {
 	Content     map[string]interface{} `json:"content"`       // user_message, assistant_response, or schema_update
 	IsEdited    bool                   `json:"is_edited"`     // Whether message content has been edited
 	NonTechMode bool                   `json:"non_tech_mode"` // Whether generated in non-tech mode
+	IsLowRated  bool                   `json:"is_low_rated"`  // Whether users rated this response low, for prompt fine-tuning analysis
 	CreatedAt   time.Time              `json:"created_at"`    // Timestamp for ordering
 	UpdatedAt   time.Time              `json:"updated_at"`    // Last update timestamp
 }
@@ -8,20 +8,33 @@ import (
 )
 
 type Message struct {
-	UserID        primitive.ObjectID  `bson:"user_id" json:"user_id"`
-	ChatID        primitive.ObjectID  `bson:"chat_id" json:"chat_id"`
-	UserMessageId *primitive.ObjectID `bson:"user_message_id,omitempty" json:"user_message_id,omitempty"` // Holds id of user message that was sent before this message, only applicable for Type assistant
-	Type          string              `bson:"type" json:"type"`                                           // 'user' or 'assistant'
-	Content       string              `bson:"content" json:"content"`
-	IsEdited      bool                `bson:"is_edited" json:"is_edited"` // if the message content has been edited, only for user messages
-	Queries       *[]Query            `bson:"queries,omitempty" json:"queries,omitempty"`
-	ActionButtons *[]ActionButton     `bson:"action_buttons,omitempty" json:"action_buttons,omitempty"` // UI action buttons suggested by the LLM
-	NonTechMode   bool                `bson:"non_tech_mode" json:"non_tech_mode"`                       // Whether this message was generated in non-tech mode
-	IsPinned      bool                `bson:"is_pinned" json:"is_pinned"`                               // Whether this message is pinned
-	PinnedAt      *time.Time          `bson:"pinned_at,omitempty" json:"pinned_at,omitempty"`           // When the message was pinned
-	LLMModel      *string             `bson:"llm_model,omitempty" json:"llm_model,omitempty"`           // LLM model used to generate this message (e.g., "gpt-4o", "gemini-2.0-flash") - nullable for backward compatibility
-	LLMModelName  *string             `bson:"llm_model_name,omitempty" json:"llm_model_name,omitempty"` // Human-readable display name for the LLM model (e.g., "GPT-4 Omni", "Gemini 2.0 Flash")
-	Base          `bson:",inline"`
+	UserID          primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	ChatID          primitive.ObjectID  `bson:"chat_id" json:"chat_id"`
+	UserMessageId   *primitive.ObjectID `bson:"user_message_id,omitempty" json:"user_message_id,omitempty"` // Holds id of user message that was sent before this message, only applicable for Type assistant
+	Type            string              `bson:"type" json:"type"`                                           // 'user' or 'assistant'
+	Content         string              `bson:"content" json:"content"`
+	IsEdited        bool                `bson:"is_edited" json:"is_edited"` // if the message content has been edited, only for user messages
+	Queries         *[]Query            `bson:"queries,omitempty" json:"queries,omitempty"`
+	ActionButtons   *[]ActionButton     `bson:"action_buttons,omitempty" json:"action_buttons,omitempty"`       // UI action buttons suggested by the LLM
+	NonTechMode     bool                `bson:"non_tech_mode" json:"non_tech_mode"`                             // Whether this message was generated in non-tech mode
+	IsPinned        bool                `bson:"is_pinned" json:"is_pinned"`                                     // Whether this message is pinned
+	PinnedAt        *time.Time          `bson:"pinned_at,omitempty" json:"pinned_at,omitempty"`                 // When the message was pinned
+	LLMModel        *string             `bson:"llm_model,omitempty" json:"llm_model,omitempty"`                 // LLM model used to generate this message (e.g., "gpt-4o", "gemini-2.0-flash") - nullable for backward compatibility
+	LLMModelName    *string             `bson:"llm_model_name,omitempty" json:"llm_model_name,omitempty"`       // Human-readable display name for the LLM model (e.g., "GPT-4 Omni", "Gemini 2.0 Flash")
+	LLMModelUsed    *string             `bson:"llm_model_used,omitempty" json:"llm_model_used,omitempty"`       // Model that actually answered, differs from LLMModel when a fallback model was used
+	ParentMessageID *primitive.ObjectID `bson:"parent_message_id,omitempty" json:"parent_message_id,omitempty"` // AI message a threaded follow-up was asked about, only set on the user message that opens a thread reply
+	ThreadID        *primitive.ObjectID `bson:"thread_id,omitempty" json:"thread_id,omitempty"`                 // Root AI message of the thread this message belongs to, nil for main thread messages
+	FeedbackCount   int                 `bson:"feedback_count,omitempty" json:"feedback_count,omitempty"`       // Number of user feedbacks on this AI response
+	AvgRating       float64             `bson:"avg_rating,omitempty" json:"avg_rating,omitempty"`               // Average feedback rating (1-5) of this AI response
+	IsLowRated      bool                `bson:"is_low_rated,omitempty" json:"is_low_rated,omitempty"`           // Average rating is at or below FeedbackLowRatingThreshold, flagged for prompt fine-tuning analysis
+	Reactions       map[string]int      `bson:"reactions,omitempty" json:"reactions,omitempty"`                 // Number of reactions per emoji, e.g. {"👍": 3}
+	Base            `bson:",inline"`
+
+	// RegenerationCount is how many times the AI response to this user message was regenerated
+	RegenerationCount int `bson:"regeneration_count,omitempty" json:"regeneration_count,omitempty"`
+
+	// RollbackCount is how many rollbacks of this message's queries were attempted, see RollbackRecord
+	RollbackCount int `bson:"rollback_count,omitempty" json:"rollback_count,omitempty"`
 }
 
 // ActionButton represents a UI action button that can be suggested by the LLM
@@ -37,6 +50,7 @@ type Query struct {
 	Query                  string              `bson:"query" json:"query"`
 	QueryType              *string             `bson:"query_type" json:"query_type"` // SELECT, INSERT, UPDATE, DELETE...
 	Pagination             *Pagination         `bson:"pagination,omitempty" json:"pagination,omitempty"`
+	KeysetPagination       *KeysetPagination   `bson:"keyset_pagination,omitempty" json:"keyset_pagination,omitempty"`
 	Tables                 *string             `bson:"tables" json:"tables"` // comma separated table names involved in the query
 	Description            string              `bson:"description" json:"description"`
 	RollbackDependentQuery *string             `bson:"rollback_dependent_query,omitempty" json:"rollback_dependent_query,omitempty"` // ID of the query that this query depends on
@@ -55,6 +69,17 @@ type Query struct {
 	ActionAt               *string             `bson:"action_at,omitempty" json:"action_at,omitempty"`               // The timestamp when the action was taken
 	LLMModel               string              `bson:"llm_model" json:"llm_model"`                                   // LLM model used to generate this query
 	VisualizationID        *primitive.ObjectID `bson:"visualization_id,omitempty" json:"visualization_id,omitempty"` // Reference to MessageVisualization, enables per-query visualization
+	ComplexityScore        string              `bson:"complexity_score,omitempty" json:"complexity_score,omitempty"` // low, medium, high or extreme, scored before execution
+	MigrationRisk          *MigrationRisk      `bson:"migration_risk,omitempty" json:"migration_risk,omitempty"`     // Risk of running a DDL query, assessed before execution
+	// Names of the tables a TRUNCATE empties, the user must type them exactly before the query runs
+	RequiresConfirmationText string `bson:"requires_confirmation_text,omitempty" json:"requires_confirmation_text,omitempty"`
+}
+
+// MigrationRisk is the assessed locking and data loss risk of a DDL query
+type MigrationRisk struct {
+	RiskLevel             string `bson:"risk_level" json:"risk_level"` // low, medium or high
+	Reason                string `bson:"reason" json:"reason"`
+	EstimatedLockDuration string `bson:"estimated_lock_duration" json:"estimated_lock_duration"`
 }
 
 type QueryError struct {
@@ -67,6 +92,9 @@ type Pagination struct {
 	TotalRecordsCount *int    `bson:"total_records_count" json:"total_records_count"` // Total number of records available for the query
 	PaginatedQuery    *string `bson:"paginated_query" json:"paginated_query"`         // The modified query string that includes pagination (e.g., LIMIT, OFFSET) to fetch a subset of results
 	CountQuery        *string `bson:"count_query" json:"count_query"`                 // The query string to get the total count of records (e.g., SELECT COUNT(*) FROM ...)
+
+	// Substring of PaginatedQuery that is replaced with the row offset when fetching later pages (e.g., "offset_size")
+	PaginatedQueryOffsetPlaceholder *string `bson:"paginated_query_offset_placeholder,omitempty" json:"paginated_query_offset_placeholder,omitempty"`
 	
 	// Cursor-based pagination fields (more efficient for large datasets)
 	CursorField     *string `bson:"cursor_field,omitempty" json:"cursor_field,omitempty"`         // Field used for cursor (e.g., "id", "created_at")
@@ -74,6 +102,13 @@ type Pagination struct {
 	PageSize        *int    `bson:"page_size,omitempty" json:"page_size,omitempty"`               // Number of records per page
 }
 
+// KeysetPagination pages through results with a "key > last key" condition instead of OFFSET,
+// so deep pages on large tables cost the same as the first page
+type KeysetPagination struct {
+	KeysetQuery string `bson:"keyset_query" json:"keyset_query"` // Query with the {{last_key}} placeholder (e.g., WHERE id > {{last_key}} ORDER BY id LIMIT 50)
+	KeyField    string `bson:"key_field" json:"key_field"`       // Column whose value in the last row becomes the next {{last_key}}
+}
+
 func NewMessage(userID, chatID primitive.ObjectID, msgType, content string, queries *[]Query, userMessageId *primitive.ObjectID) *Message {
 	log.Printf("NewMessage -> queries: %v", queries)
 	return &Message{
@@ -0,0 +1,18 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MessageFeedback is a user's rating of an AI response. A user has at most one feedback per message,
+// submitting again replaces it. DBType and LLMModel are copied from the chat and message so feedback
+// can be grouped without joins.
+type MessageFeedback struct {
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	ChatID    primitive.ObjectID `bson:"chat_id" json:"chat_id"`
+	MessageID primitive.ObjectID `bson:"message_id" json:"message_id"`
+	Rating    int                `bson:"rating" json:"rating"` // 1-5 stars
+	Issue     string             `bson:"issue" json:"issue"`   // wrong_table, wrong_query, wrong_explanation, too_slow or correct
+	FreeText  *string            `bson:"free_text,omitempty" json:"free_text,omitempty"`
+	DBType    string             `bson:"db_type" json:"db_type"`
+	LLMModel  string             `bson:"llm_model" json:"llm_model"`
+	Base      `bson:",inline"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MessageReaction is a user's emoji reaction to a message. A user reacts at most once with each emoji,
+// the per-emoji counts are kept on the message itself in Message.Reactions.
+type MessageReaction struct {
+	MessageID primitive.ObjectID `bson:"message_id" json:"message_id"`
+	ChatID    primitive.ObjectID `bson:"chat_id" json:"chat_id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Emoji     string             `bson:"emoji" json:"emoji"`
+	Base      `bson:",inline"`
+}
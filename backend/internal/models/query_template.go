@@ -0,0 +1,33 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// TemplateParam is a :name placeholder of a query template. DefaultValue is used when no value is given,
+// a parameter without a default must always be filled in.
+type TemplateParam struct {
+	Name         string  `bson:"name" json:"name"`
+	Type         string  `bson:"type" json:"type"` // one of constants.TemplateParamTypes
+	DefaultValue *string `bson:"default_value,omitempty" json:"default_value,omitempty"`
+}
+
+// QueryTemplate is a reusable read-only query with :name placeholders that are filled in when it is run.
+// Values are bound by the database driver, they are never written into the query text.
+type QueryTemplate struct {
+	ChatID     primitive.ObjectID `bson:"chat_id" json:"chat_id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name       string             `bson:"name" json:"name"`
+	Template   string             `bson:"template" json:"template"`
+	Parameters []TemplateParam    `bson:"parameters" json:"parameters"`
+	Base       `bson:",inline"`
+}
+
+func NewQueryTemplate(chatID, userID primitive.ObjectID, name, template string, parameters []TemplateParam) *QueryTemplate {
+	return &QueryTemplate{
+		ChatID:     chatID,
+		UserID:     userID,
+		Name:       name,
+		Template:   template,
+		Parameters: parameters,
+		Base:       NewBase(),
+	}
+}
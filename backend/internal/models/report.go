@@ -0,0 +1,44 @@
+package models
+
+import (
+	"neobase-ai/internal/constants"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Report is a Markdown report generated for a chat from a list of questions. Each section's question is answered
+// by a query run against the chat's database, then the LLM writes the report from all the results.
+type Report struct {
+	ChatID   primitive.ObjectID `bson:"chat_id" json:"chat_id"`
+	UserID   primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Title    string             `bson:"title" json:"title"`
+	Sections []ReportSection    `bson:"sections" json:"sections"`
+	Markdown string             `bson:"markdown" json:"markdown"`
+	Status   string             `bson:"status" json:"status"` // generating, completed or failed
+	Error    *string            `bson:"error,omitempty" json:"error,omitempty"`
+	Model    string             `bson:"model,omitempty" json:"model,omitempty"`
+	Base     `bson:",inline"`
+}
+
+// ReportSection is a question of a report with the query answering it
+type ReportSection struct {
+	Heading   string  `bson:"heading" json:"heading"`
+	Question  string  `bson:"question" json:"question"`
+	Query     string  `bson:"query,omitempty" json:"query,omitempty"`
+	QueryType string  `bson:"query_type,omitempty" json:"query_type,omitempty"`
+	RowCount  int     `bson:"row_count" json:"row_count"`
+	Error     *string `bson:"error,omitempty" json:"error,omitempty"`
+	Markdown  string  `bson:"markdown" json:"markdown"` // The section with its result table, before the LLM wrote the report
+}
+
+func NewReport(chatID, userID primitive.ObjectID, title string, sections []ReportSection, model string) *Report {
+	return &Report{
+		ChatID:   chatID,
+		UserID:   userID,
+		Title:    title,
+		Sections: sections,
+		Status:   constants.ReportStatusGenerating,
+		Model:    model,
+		Base:     NewBase(),
+	}
+}
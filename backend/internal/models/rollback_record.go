@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RollbackRecord is the audit entry of a rollback attempt and its outcome. A query without a stored rollback query
+// is rolled back in two steps, its dependent query is run to generate the rollback query, then the rollback runs.
+// Both steps are recorded, the rollback pointing to the dependent query's record with DependentQueryID.
+type RollbackRecord struct {
+	ChatID           primitive.ObjectID  `bson:"chat_id" json:"chat_id"`
+	UserID           primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	MessageID        primitive.ObjectID  `bson:"message_id" json:"message_id"`
+	QueryID          primitive.ObjectID  `bson:"query_id" json:"query_id"`
+	Step             string              `bson:"step" json:"step"`                     // dependent_query or rollback
+	RollbackQuery    string              `bson:"rollback_query" json:"rollback_query"` // The query run by the step, empty when none could be generated
+	Success          bool                `bson:"success" json:"success"`
+	Error            *string             `bson:"error,omitempty" json:"error,omitempty"`
+	ExecutedAt       time.Time           `bson:"executed_at" json:"executed_at"`
+	DependentQueryID *primitive.ObjectID `bson:"dependent_query_id,omitempty" json:"dependent_query_id,omitempty"` // Record of the dependent query run before this rollback
+	Base             `bson:",inline"`
+}
+
+func NewRollbackRecord(chatID, userID, messageID, queryID primitive.ObjectID, step, rollbackQuery string, errorMsg *string, dependentQueryID *primitive.ObjectID) *RollbackRecord {
+	base := NewBase()
+	return &RollbackRecord{
+		ChatID:           chatID,
+		UserID:           userID,
+		MessageID:        messageID,
+		QueryID:          queryID,
+		Step:             step,
+		RollbackQuery:    rollbackQuery,
+		Success:          errorMsg == nil,
+		Error:            errorMsg,
+		ExecutedAt:       base.CreatedAt,
+		DependentQueryID: dependentQueryID,
+		Base:             base,
+	}
+}
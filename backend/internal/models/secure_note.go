@@ -0,0 +1,23 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// SecureNote is context about a connection that the schema does not show, e.g. soft-delete flags or
+// archive schemas. Active notes are added to every LLM call of the chat. Content is encrypted at rest.
+type SecureNote struct {
+	ChatID   primitive.ObjectID `bson:"chat_id" json:"chat_id"`
+	UserID   primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Content  string             `bson:"content" json:"content"` // AES-GCM encrypted with the "ENC:" prefix
+	IsActive bool               `bson:"is_active" json:"is_active"`
+	Base     `bson:",inline"`
+}
+
+func NewSecureNote(chatID, userID primitive.ObjectID, content string, isActive bool) *SecureNote {
+	return &SecureNote{
+		ChatID:   chatID,
+		UserID:   userID,
+		Content:  content,
+		IsActive: isActive,
+		Base:     NewBase(),
+	}
+}
@@ -6,11 +6,12 @@ type User struct {
 	Username           string             `bson:"username" json:"username"`
 	Email              string             `bson:"email" json:"email"`
 	Password           string             `bson:"password" json:"-"`
-	AuthType           constants.AuthType `bson:"auth_type" json:"auth_type"`                                         // AuthTypeEmailPassword or AuthTypeGoogle, default is AuthTypeEmailPassword
-	GoogleID           *string            `bson:"google_id,omitempty" json:"google_id,omitempty"`                     // Google user ID
-	GoogleAccessToken  *string            `bson:"google_access_token,omitempty" json:"-"`                             // Google OAuth access token (not exposed in JSON)
-	GoogleRefreshToken *string            `bson:"google_refresh_token,omitempty" json:"-"`                            // Google OAuth refresh token (not exposed in JSON)
-	GoogleTokenExpiry  *int64             `bson:"google_token_expiry,omitempty" json:"google_token_expiry,omitempty"` // Token expiry timestamp
+	AuthType           constants.AuthType `bson:"auth_type" json:"auth_type"`                                             // AuthTypeEmailPassword or AuthTypeGoogle, default is AuthTypeEmailPassword
+	GoogleID           *string            `bson:"google_id,omitempty" json:"google_id,omitempty"`                         // Google user ID
+	GoogleAccessToken  *string            `bson:"google_access_token,omitempty" json:"-"`                                 // Google OAuth access token (not exposed in JSON)
+	GoogleRefreshToken *string            `bson:"google_refresh_token,omitempty" json:"-"`                                // Google OAuth refresh token (not exposed in JSON)
+	GoogleTokenExpiry  *int64             `bson:"google_token_expiry,omitempty" json:"google_token_expiry,omitempty"`     // Token expiry timestamp
+	MaxQueryResultRows *int               `bson:"max_query_result_rows,omitempty" json:"max_query_result_rows,omitempty"` // Admin override of MAX_QUERY_RESULT_ROWS
 	Base               `bson:",inline"`
 }
 
@@ -24,6 +25,14 @@ func NewUser(username, email, password string) *User {
 	}
 }
 
+// GetMaxQueryResultRows returns the user's row cap for query results, 0 when the server default applies
+func (u *User) GetMaxQueryResultRows() int {
+	if u.MaxQueryResultRows == nil || *u.MaxQueryResultRows <= 0 {
+		return 0
+	}
+	return *u.MaxQueryResultRows
+}
+
 // GetAuthType returns the auth type, defaulting to AuthTypeEmailPassword for backward compatibility
 func (u *User) GetAuthType() constants.AuthType {
 	if u.AuthType == "" {
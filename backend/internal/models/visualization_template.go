@@ -0,0 +1,15 @@
+package models
+
+// VisualizationTemplate is a chart pattern applied to a query result without calling the LLM.
+// ChartConfigJSON is a chart configuration whose column names are {{placeholder}} tokens, one per
+// RequiredColumns entry, replaced by the result's columns when the template is applied.
+// A {{placeholder:label}} token is replaced by the column's display label.
+// Templates are seeded in code, not stored in MongoDB.
+type VisualizationTemplate struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	DBType          string   `json:"db_type,omitempty"` // Empty when the template fits the results of any database
+	RequiredColumns []string `json:"required_columns"`  // date, number, category or country, with a digit suffix for a second column of the same kind
+	ChartConfigJSON string   `json:"chart_config_json"`
+}
@@ -0,0 +1,269 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnalyticsFilter scopes the analytics aggregations. A nil ChatID aggregates across all chats.
+type AnalyticsFilter struct {
+	From    time.Time
+	To      time.Time
+	GroupBy string
+	ChatID  *primitive.ObjectID
+}
+
+// MessageCountBucket is the number of messages in a period
+type MessageCountBucket struct {
+	Period    string `bson:"_id"`
+	Total     int64  `bson:"total"`
+	User      int64  `bson:"user"`
+	Assistant int64  `bson:"assistant"`
+}
+
+// LatencyBucket is the average AI response time in a period
+type LatencyBucket struct {
+	Period string  `bson:"_id"`
+	AvgMs  float64 `bson:"avg_ms"`
+	Count  int64   `bson:"count"`
+}
+
+// QueryErrorBucket is the number of executed and failed queries in a period
+type QueryErrorBucket struct {
+	Period   string `bson:"_id"`
+	Executed int64  `bson:"executed"`
+	Failed   int64  `bson:"failed"`
+}
+
+// NamedCount is a count grouped by a name, e.g. a query type, table or LLM model
+type NamedCount struct {
+	Name        string `bson:"_id"`
+	DisplayName string `bson:"display_name,omitempty"`
+	Count       int64  `bson:"count"`
+}
+
+// AnalyticsRepository computes usage analytics on demand from the messages collection
+type AnalyticsRepository interface {
+	GetMessageCounts(ctx context.Context, filter AnalyticsFilter) ([]MessageCountBucket, error)
+	GetResponseLatency(ctx context.Context, filter AnalyticsFilter) ([]LatencyBucket, error)
+	GetQueryErrorCounts(ctx context.Context, filter AnalyticsFilter) ([]QueryErrorBucket, error)
+	GetQueryTypeCounts(ctx context.Context, filter AnalyticsFilter) ([]NamedCount, error)
+	GetTopTables(ctx context.Context, filter AnalyticsFilter, limit int) ([]NamedCount, error)
+	GetLLMModelCounts(ctx context.Context, filter AnalyticsFilter) ([]NamedCount, error)
+	CountActiveChats(ctx context.Context, filter AnalyticsFilter) (int64, error)
+}
+
+type analyticsRepository struct {
+	messageCollection *mongo.Collection
+}
+
+func NewAnalyticsRepository(mongoClient *mongodb.MongoDBClient) AnalyticsRepository {
+	return &analyticsRepository{
+		messageCollection: mongoClient.GetCollectionByName("messages"),
+	}
+}
+
+// matchStage filters messages by creation time and, optionally, chat
+func (r *analyticsRepository) matchStage(filter AnalyticsFilter, extra bson.M) bson.D {
+	match := bson.M{
+		"created_at": bson.M{"$gte": filter.From, "$lte": filter.To},
+	}
+	if filter.ChatID != nil {
+		match["chat_id"] = *filter.ChatID
+	}
+	for key, value := range extra {
+		match[key] = value
+	}
+	return bson.D{{Key: "$match", Value: match}}
+}
+
+// periodExpression groups a date field by day (YYYY-MM-DD) or ISO week (YYYY-Www)
+func (r *analyticsRepository) periodExpression(groupBy, dateField string) bson.M {
+	format := "%Y-%m-%d"
+	if groupBy == constants.AnalyticsGroupByWeek {
+		format = "%G-W%V"
+	}
+	return bson.M{"$dateToString": bson.M{"format": format, "date": dateField}}
+}
+
+func (r *analyticsRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline, results interface{}) error {
+	cursor, err := r.messageCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, results)
+}
+
+func (r *analyticsRepository) GetMessageCounts(ctx context.Context, filter AnalyticsFilter) ([]MessageCountBucket, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, nil),
+		{{Key: "$group", Value: bson.M{
+			"_id":       r.periodExpression(filter.GroupBy, "$created_at"),
+			"total":     bson.M{"$sum": 1},
+			"user":      bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "user"}}, 1, 0}}},
+			"assistant": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", "assistant"}}, 1, 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var buckets []MessageCountBucket
+	if err := r.aggregate(ctx, pipeline, &buckets); err != nil {
+		log.Printf("AnalyticsRepository -> GetMessageCounts -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate message counts: %v", err)
+	}
+	return buckets, nil
+}
+
+// GetResponseLatency measures the time between each user message and the AI response that answered it
+func (r *analyticsRepository) GetResponseLatency(ctx context.Context, filter AnalyticsFilter) ([]LatencyBucket, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, bson.M{
+			"type":            "assistant",
+			"user_message_id": bson.M{"$ne": nil},
+		}),
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "messages",
+			"localField":   "user_message_id",
+			"foreignField": "_id",
+			"as":           "user_message",
+		}}},
+		{{Key: "$unwind", Value: "$user_message"}},
+		{{Key: "$project", Value: bson.M{
+			"created_at": 1,
+			"latency_ms": bson.M{"$subtract": bson.A{"$created_at", "$user_message.created_at"}},
+		}}},
+		{{Key: "$match", Value: bson.M{"latency_ms": bson.M{"$gte": 0}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    r.periodExpression(filter.GroupBy, "$created_at"),
+			"avg_ms": bson.M{"$avg": "$latency_ms"},
+			"count":  bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var buckets []LatencyBucket
+	if err := r.aggregate(ctx, pipeline, &buckets); err != nil {
+		log.Printf("AnalyticsRepository -> GetResponseLatency -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate response latency: %v", err)
+	}
+	return buckets, nil
+}
+
+func (r *analyticsRepository) GetQueryErrorCounts(ctx context.Context, filter AnalyticsFilter) ([]QueryErrorBucket, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, bson.M{"queries.is_executed": true}),
+		{{Key: "$unwind", Value: "$queries"}},
+		{{Key: "$match", Value: bson.M{"queries.is_executed": true}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      r.periodExpression(filter.GroupBy, "$created_at"),
+			"executed": bson.M{"$sum": 1},
+			"failed": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$queries.error", nil}}, nil}}, 0, 1,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var buckets []QueryErrorBucket
+	if err := r.aggregate(ctx, pipeline, &buckets); err != nil {
+		log.Printf("AnalyticsRepository -> GetQueryErrorCounts -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate query errors: %v", err)
+	}
+	return buckets, nil
+}
+
+func (r *analyticsRepository) GetQueryTypeCounts(ctx context.Context, filter AnalyticsFilter) ([]NamedCount, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, bson.M{"queries.0": bson.M{"$exists": true}}),
+		{{Key: "$unwind", Value: "$queries"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$toUpper": bson.M{"$ifNull": bson.A{"$queries.query_type", "UNKNOWN"}}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	var counts []NamedCount
+	if err := r.aggregate(ctx, pipeline, &counts); err != nil {
+		log.Printf("AnalyticsRepository -> GetQueryTypeCounts -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate query types: %v", err)
+	}
+	return counts, nil
+}
+
+// GetTopTables counts how often each table appears in generated queries (queries.tables is comma separated)
+func (r *analyticsRepository) GetTopTables(ctx context.Context, filter AnalyticsFilter, limit int) ([]NamedCount, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, bson.M{"queries.0": bson.M{"$exists": true}}),
+		{{Key: "$unwind", Value: "$queries"}},
+		{{Key: "$match", Value: bson.M{"queries.tables": bson.M{"$type": "string", "$ne": ""}}}},
+		{{Key: "$project", Value: bson.M{"table": bson.M{"$split": bson.A{"$queries.tables", ","}}}}},
+		{{Key: "$unwind", Value: "$table"}},
+		{{Key: "$project", Value: bson.M{"table": bson.M{"$trim": bson.M{"input": "$table"}}}}},
+		{{Key: "$match", Value: bson.M{"table": bson.M{"$ne": ""}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$table",
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	var counts []NamedCount
+	if err := r.aggregate(ctx, pipeline, &counts); err != nil {
+		log.Printf("AnalyticsRepository -> GetTopTables -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate top tables: %v", err)
+	}
+	return counts, nil
+}
+
+func (r *analyticsRepository) GetLLMModelCounts(ctx context.Context, filter AnalyticsFilter) ([]NamedCount, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, bson.M{
+			"type":      "assistant",
+			"llm_model": bson.M{"$type": "string", "$ne": ""},
+		}),
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$llm_model",
+			"display_name": bson.M{"$last": "$llm_model_name"},
+			"count":        bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	var counts []NamedCount
+	if err := r.aggregate(ctx, pipeline, &counts); err != nil {
+		log.Printf("AnalyticsRepository -> GetLLMModelCounts -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate LLM models: %v", err)
+	}
+	return counts, nil
+}
+
+func (r *analyticsRepository) CountActiveChats(ctx context.Context, filter AnalyticsFilter) (int64, error) {
+	pipeline := mongo.Pipeline{
+		r.matchStage(filter, nil),
+		{{Key: "$group", Value: bson.M{"_id": "$chat_id"}}},
+		{{Key: "$count", Value: "count"}},
+	}
+
+	var result []struct {
+		Count int64 `bson:"count"`
+	}
+	if err := r.aggregate(ctx, pipeline, &result); err != nil {
+		log.Printf("AnalyticsRepository -> CountActiveChats -> Error: %v", err)
+		return 0, fmt.Errorf("failed to count active chats: %v", err)
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Count, nil
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// APIKeyRepository defines operations for API key metadata persistence.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	FindByID(ctx context.Context, keyID primitive.ObjectID) (*models.APIKey, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]*models.APIKey, error)
+	CountActiveByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	Revoke(ctx context.Context, keyID primitive.ObjectID) error
+	UpdateLastUsed(ctx context.Context, keyID primitive.ObjectID, usedAt time.Time, minInterval time.Duration) error
+}
+
+type apiKeyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAPIKeyRepository creates a new repository backed by the `api_keys` MongoDB collection.
+func NewAPIKeyRepository(mongoClient *mongodb.MongoDBClient) APIKeyRepository {
+	repo := &apiKeyRepository{
+		collection: mongoClient.GetCollectionByName("api_keys"),
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		})
+		if err != nil {
+			log.Printf("APIKey -> Warning: failed to create user_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	if _, err := r.collection.InsertOne(ctx, key); err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, keyID primitive.ObjectID) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.collection.FindOne(ctx, bson.M{"_id": keyID}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find API key %s: %w", keyID.Hex(), err)
+	}
+	return &key, nil
+}
+
+// FindByUserID lists the API keys of a user, newest first
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]*models.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*models.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// CountActiveByUserID counts the keys of a user that are neither revoked nor expired
+func (r *apiKeyRepository) CountActiveByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"user_id":    userID,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": time.Now()},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	return count, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, keyID primitive.ObjectID) error {
+	now := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": keyID}, bson.M{
+		"$set": bson.M{"revoked_at": now, "updated_at": now},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key %s: %w", keyID.Hex(), err)
+	}
+	return nil
+}
+
+// UpdateLastUsed records when a key was last used. The write is skipped when the stored value is
+// more recent than minInterval, so busy keys do not write on every request.
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, keyID primitive.ObjectID, usedAt time.Time, minInterval time.Duration) error {
+	filter := bson.M{
+		"_id": keyID,
+		"$or": []bson.M{
+			{"last_used_at": bson.M{"$exists": false}},
+			{"last_used_at": bson.M{"$lt": usedAt.Add(-minInterval)}},
+		},
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"last_used_at": usedAt}})
+	if err != nil {
+		return fmt.Errorf("failed to update last used time of API key %s: %w", keyID.Hex(), err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"neobase-ai/internal/models"
 	"neobase-ai/pkg/mongodb"
 	"neobase-ai/pkg/redis"
+	"regexp"
 	"sync"
 	"time"
 
@@ -22,20 +23,66 @@ type ChatRepository interface {
 	Create(chat *models.Chat) error
 	Update(id primitive.ObjectID, chat *models.Chat) error
 	UpdateConnectionSchema(ctx context.Context, id primitive.ObjectID, schema string) error
+	UpdateSecondaryConnectionSchema(ctx context.Context, id primitive.ObjectID, schema string) error
+	UpdateConnectionLastSyncedAt(ctx context.Context, id primitive.ObjectID, syncedAt time.Time) error
+	UpdateTitle(ctx context.Context, id primitive.ObjectID, title string, onlyIfUnset bool) (bool, error)
 	UpdateChatTimestamp(chatID primitive.ObjectID) error
+	IncrementSummarizationCount(ctx context.Context, id primitive.ObjectID) error
 	Delete(id primitive.ObjectID) error
 	FindByID(id primitive.ObjectID) (*models.Chat, error)
 	FindByUserID(userID primitive.ObjectID, page, pageSize int) ([]*models.Chat, int64, error)
 	CreateMessage(message *models.Message) error
 	UpdateMessage(id primitive.ObjectID, message *models.Message) error
 	DeleteMessages(chatID primitive.ObjectID) error
+	FindMessageIDsByFilter(chatID primitive.ObjectID, filter MessageDeleteFilter) ([]primitive.ObjectID, error)
+	DeleteMessagesByIDs(chatID primitive.ObjectID, ids []primitive.ObjectID) (int64, error)
 	FindMessagesByChat(chatID primitive.ObjectID, page, pageSize int) ([]*models.Message, int64, error)
 	FindLatestMessageByChat(chatID primitive.ObjectID, page, pageSize int) ([]*models.Message, int64, error)
+	FindOldestMessagesByChat(chatID primitive.ObjectID, limit int) ([]*models.Message, error)
+	FindMessagesByThread(chatID, threadID primitive.ObjectID, page, pageSize int) ([]*models.Message, int64, error)
 	FindMessageByID(id primitive.ObjectID) (*models.Message, error)
 	FindNextMessageByID(id primitive.ObjectID) (*models.Message, error)
 	FindPinnedMessagesByChat(chatID primitive.ObjectID) ([]models.Message, error)
 	FindMessagesByChatAfterTime(chatID primitive.ObjectID, after time.Time, page, pageSize int) ([]models.Message, int64, error)
 	UpdateQueryVisualizationID(messageID, queryID, visualizationID primitive.ObjectID) error
+	UpdateMessageFeedbackStats(message *models.Message) error
+	UpdateMessageRollbackCount(message *models.Message) error
+	IncrementMessageReaction(messageID primitive.ObjectID, emoji string, delta int) (*models.Message, error)
+	FindQueriesByCriteria(chatID primitive.ObjectID, filter QueryHistoryFilter) ([]*QueryHistoryRecord, int64, error)
+	SearchMessagesByText(chatID primitive.ObjectID, text, messageType string, limit int) ([]*MessageTextMatch, error)
+	SearchUserMessagesByText(userID primitive.ObjectID, text string, page, pageSize int) ([]*MessageTextMatch, int64, error)
+	SearchUserQueriesByKeyword(userID primitive.ObjectID, keyword string, page, pageSize int) ([]*models.Message, int64, error)
+	FindAssistantRepliesByUserMessageIDs(chatID primitive.ObjectID, userMessageIDs []primitive.ObjectID) ([]*models.Message, error)
+}
+
+// QueryHistoryFilter narrows the queries returned by FindQueriesByCriteria. Zero values are not filtered on.
+type QueryHistoryFilter struct {
+	From       *time.Time
+	To         *time.Time
+	QueryType  string
+	IsExecuted *bool
+	Page       int
+	PageSize   int
+}
+
+// MessageDeleteFilter selects the messages of a chat to delete. Zero values are not filtered on.
+type MessageDeleteFilter struct {
+	Before    *time.Time
+	Type      string
+	HasErrors *bool
+}
+
+// MessageTextMatch is a message found by SearchMessagesByText together with its MongoDB text score
+type MessageTextMatch struct {
+	models.Message `bson:",inline"`
+	Score          float64 `bson:"score"`
+}
+
+// QueryHistoryRecord is a query together with the message it belongs to
+type QueryHistoryRecord struct {
+	MessageID primitive.ObjectID `bson:"message_id"`
+	CreatedAt time.Time          `bson:"created_at"`
+	Query     models.Query       `bson:"query"`
 }
 
 // concrete implementation of ChatRepository, using interface composition
@@ -48,12 +95,35 @@ type chatRepository struct {
 }
 
 func NewChatRepository(mongoClient *mongodb.MongoDBClient, redisRepo redis.IRedisRepositories) ChatRepository {
-	return &chatRepository{
+	repo := &chatRepository{
 		chatCollection:    mongoClient.GetCollectionByName("chats"),
 		messageCollection: mongoClient.GetCollectionByName("messages"),
 		redisRepo:         redisRepo,
 		cacheLocks:        make(map[string]*sync.RWMutex),
 	}
+
+	// Text index for SearchMessagesByText, the full-text fallback of the similar query search
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.messageCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "content", Value: "text"}},
+		})
+		if err != nil {
+			log.Printf("ChatRepository -> Warning: failed to create content text index: %v", err)
+		}
+
+		// Query search of SearchUserQueriesByKeyword, newest first
+		_, err = repo.messageCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+		})
+		if err != nil {
+			log.Printf("ChatRepository -> Warning: failed to create user_id index on messages: %v", err)
+		}
+	}()
+
+	return repo
 }
 
 // getCacheLock returns or creates a mutex for a specific cache key
@@ -456,6 +526,92 @@ func (r *chatRepository) UpdateConnectionSchema(ctx context.Context, id primitiv
 	return nil
 }
 
+// UpdateSecondaryConnectionSchema updates only the schema fields of the chat's secondary connection
+func (r *chatRepository) UpdateSecondaryConnectionSchema(ctx context.Context, id primitive.ObjectID, schema string) error {
+	now := primitive.NewDateTimeFromTime(time.Now())
+	filter := bson.M{"_id": id, "secondary_connections.0": bson.M{"$exists": true}}
+	update := bson.M{
+		"$set": bson.M{
+			"secondary_connections.0.current_schema":    schema,
+			"secondary_connections.0.schema_updated_at": now,
+		},
+	}
+
+	_, err := r.chatCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update secondary connection schema: %w", err)
+	}
+
+	// Update cache with fresh data
+	go r.updateChatCache(id)
+
+	return nil
+}
+
+// UpdateConnectionLastSyncedAt records when the connection's external data was last synced
+func (r *chatRepository) UpdateConnectionLastSyncedAt(ctx context.Context, id primitive.ObjectID, syncedAt time.Time) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{
+		"$set": bson.M{
+			"connection.last_synced_at": syncedAt,
+			"updated_at":                time.Now(),
+		},
+	}
+
+	_, err := r.chatCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update connection last synced at: %w", err)
+	}
+
+	// Update cache with fresh data
+	go r.updateChatCache(id)
+
+	return nil
+}
+
+// UpdateTitle sets the chat title and reports whether it was saved. With onlyIfUnset, a title
+// the chat already has, such as one the user typed while the title was generating, is kept.
+func (r *chatRepository) UpdateTitle(ctx context.Context, id primitive.ObjectID, title string, onlyIfUnset bool) (bool, error) {
+	filter := bson.M{"_id": id}
+	if onlyIfUnset {
+		filter["title"] = bson.M{"$in": bson.A{nil, ""}}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"title": title,
+		},
+	}
+
+	result, err := r.chatCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to update chat title: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+
+	// Update cache with fresh data
+	go r.updateChatCache(id)
+
+	return true, nil
+}
+
+// IncrementSummarizationCount records that the chat's LLM context was summarized once more
+func (r *chatRepository) IncrementSummarizationCount(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$inc": bson.M{"summarization_count": 1},
+	}
+
+	if _, err := r.chatCollection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to increment summarization count: %w", err)
+	}
+
+	// Update cache with fresh data
+	go r.updateChatCache(id)
+
+	return nil
+}
+
 func (r *chatRepository) Delete(id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
 	_, err := r.chatCollection.DeleteOne(context.Background(), filter)
@@ -591,6 +747,198 @@ func (r *chatRepository) DeleteMessages(chatID primitive.ObjectID) error {
 	return err
 }
 
+// FindMessageIDsByFilter returns the IDs of the chat's messages matching every condition of the filter
+func (r *chatRepository) FindMessageIDsByFilter(chatID primitive.ObjectID, filter MessageDeleteFilter) ([]primitive.ObjectID, error) {
+	conditions := bson.A{bson.M{"chat_id": chatID}}
+	if filter.Before != nil {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$lt": *filter.Before}})
+	}
+	if filter.Type != "" {
+		conditions = append(conditions, bson.M{"type": filter.Type})
+	}
+	if filter.HasErrors != nil {
+		failedQuery := bson.M{"queries": bson.M{"$elemMatch": bson.M{"error": bson.M{"$ne": nil}}}}
+		if *filter.HasErrors {
+			conditions = append(conditions, failedQuery)
+		} else {
+			conditions = append(conditions, bson.M{"$nor": bson.A{failedQuery}})
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$and": conditions}}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+	}
+
+	ctx := context.Background()
+	cursor, err := r.messageCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("FindMessageIDsByFilter -> Error: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	return ids, nil
+}
+
+// DeleteMessagesByIDs deletes the given messages of a chat and returns how many were deleted
+func (r *chatRepository) DeleteMessagesByIDs(chatID primitive.ObjectID, ids []primitive.ObjectID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	filter := bson.M{"chat_id": chatID, "_id": bson.M{"$in": ids}}
+	result, err := r.messageCollection.DeleteMany(context.Background(), filter)
+	if err != nil {
+		return 0, err
+	}
+
+	go func() {
+		r.invalidateMessageCache(chatID)
+		r.refreshPinnedCache(chatID)
+	}()
+
+	return result.DeletedCount, nil
+}
+
+// SearchMessagesByText runs a MongoDB text search on the content of the chat's messages of the given type,
+// best matches first
+func (r *chatRepository) SearchMessagesByText(chatID primitive.ObjectID, text, messageType string, limit int) ([]*MessageTextMatch, error) {
+	filter := bson.M{
+		"chat_id": chatID,
+		"type":    messageType,
+		"$text":   bson.M{"$search": text},
+	}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}}).
+		SetLimit(int64(limit))
+
+	ctx := context.Background()
+	cursor, err := r.messageCollection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("SearchMessagesByText -> Error: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []*MessageTextMatch
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// SearchUserMessagesByText runs a MongoDB text search on the content of every message of the user,
+// best matches first
+func (r *chatRepository) SearchUserMessagesByText(userID primitive.ObjectID, text string, page, pageSize int) ([]*MessageTextMatch, int64, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"$text":   bson.M{"$search": text},
+	}
+
+	ctx := context.Background()
+	total, err := r.messageCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		log.Printf("SearchUserMessagesByText -> Error counting: %v", err)
+		return nil, 0, err
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "created_at", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+
+	cursor, err := r.messageCollection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("SearchUserMessagesByText -> Error: %v", err)
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []*MessageTextMatch
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, 0, err
+	}
+	return matches, total, nil
+}
+
+// SearchUserQueriesByKeyword returns the messages of the user with a query containing the keyword, newest first.
+// The keyword is matched literally and case-insensitively.
+func (r *chatRepository) SearchUserQueriesByKeyword(userID primitive.ObjectID, keyword string, page, pageSize int) ([]*models.Message, int64, error) {
+	filter := bson.M{
+		"user_id": userID,
+		"queries": bson.M{"$elemMatch": bson.M{
+			"query": bson.M{"$regex": regexp.QuoteMeta(keyword), "$options": "i"},
+		}},
+	}
+
+	ctx := context.Background()
+	total, err := r.messageCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		log.Printf("SearchUserQueriesByKeyword -> Error counting: %v", err)
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+
+	cursor, err := r.messageCollection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("SearchUserQueriesByKeyword -> Error: %v", err)
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*models.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+// FindAssistantRepliesByUserMessageIDs returns the assistant messages answering the given user messages
+func (r *chatRepository) FindAssistantRepliesByUserMessageIDs(chatID primitive.ObjectID, userMessageIDs []primitive.ObjectID) ([]*models.Message, error) {
+	if len(userMessageIDs) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{
+		"chat_id":         chatID,
+		"type":            "assistant",
+		"user_message_id": bson.M{"$in": userMessageIDs},
+	}
+
+	ctx := context.Background()
+	cursor, err := r.messageCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*models.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
 func (r *chatRepository) FindMessagesByChat(chatID primitive.ObjectID, page, pageSize int) ([]*models.Message, int64, error) {
 	var messages []*models.Message
 	filter := bson.M{"chat_id": chatID}
@@ -680,7 +1028,8 @@ func (r *chatRepository) FindMessagesByChat(chatID primitive.ObjectID, page, pag
 
 func (r *chatRepository) FindLatestMessageByChat(chatID primitive.ObjectID, page, pageSize int) ([]*models.Message, int64, error) {
 	var messages []*models.Message
-	filter := bson.M{"chat_id": chatID}
+	// Thread replies are listed through FindMessagesByThread, not in the main thread
+	filter := bson.M{"chat_id": chatID, "thread_id": nil}
 
 	// Get total count
 	total, err := r.messageCollection.CountDocuments(context.Background(), filter)
@@ -705,6 +1054,34 @@ func (r *chatRepository) FindLatestMessageByChat(chatID primitive.ObjectID, page
 	return messages, total, err
 }
 
+// FindMessagesByThread finds the messages of a thread, newest first
+func (r *chatRepository) FindMessagesByThread(chatID, threadID primitive.ObjectID, page, pageSize int) ([]*models.Message, int64, error) {
+	var messages []*models.Message
+	filter := bson.M{"chat_id": chatID, "thread_id": threadID}
+
+	// Get total count
+	total, err := r.messageCollection.CountDocuments(context.Background(), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Setup pagination
+	skip := int64((page - 1) * pageSize)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}}) // Descending order, same as the main thread
+
+	cursor, err := r.messageCollection.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(context.Background())
+
+	err = cursor.All(context.Background(), &messages)
+	return messages, total, err
+}
+
 func (r *chatRepository) FindMessageByID(id primitive.ObjectID) (*models.Message, error) {
 	var message models.Message
 	err := r.messageCollection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&message)
@@ -777,6 +1154,27 @@ func (r *chatRepository) FindNextMessageByID(id primitive.ObjectID) (*models.Mes
 }
 
 // FindPinnedMessagesByChat finds all pinned messages for a chat, sorted by pinnedAt descending
+// FindOldestMessagesByChat returns the first messages of a chat, oldest first. The recent message cache
+// only holds the newest messages, so this always reads from the database.
+func (r *chatRepository) FindOldestMessagesByChat(chatID primitive.ObjectID, limit int) ([]*models.Message, error) {
+	ctx := context.Background()
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.messageCollection.Find(ctx, bson.M{"chat_id": chatID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oldest messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*models.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode oldest messages: %w", err)
+	}
+	return messages, nil
+}
+
 func (r *chatRepository) FindPinnedMessagesByChat(chatID primitive.ObjectID) ([]models.Message, error) {
 	ctx := context.Background()
 	cacheKey := fmt.Sprintf("chat:%s:pinned", chatID.Hex())
@@ -884,3 +1282,141 @@ func (r *chatRepository) UpdateQueryVisualizationID(messageID, queryID, visualiz
 
 	return nil
 }
+
+// UpdateMessageFeedbackStats stores the feedback count, average rating and low rated flag of a message.
+// The fields are set explicitly since UpdateMessage skips zero values of omitempty fields.
+func (r *chatRepository) UpdateMessageFeedbackStats(message *models.Message) error {
+	filter := bson.M{"_id": message.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"feedback_count": message.FeedbackCount,
+			"avg_rating":     message.AvgRating,
+			"is_low_rated":   message.IsLowRated,
+		},
+	}
+	if _, err := r.messageCollection.UpdateOne(context.Background(), filter, update); err != nil {
+		return err
+	}
+
+	go r.updateMessageInCache(message)
+	return nil
+}
+
+// UpdateMessageRollbackCount stores the number of rollback attempts of a message.
+// The field is set explicitly since UpdateMessage skips zero values of omitempty fields.
+func (r *chatRepository) UpdateMessageRollbackCount(message *models.Message) error {
+	filter := bson.M{"_id": message.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"rollback_count": message.RollbackCount,
+		},
+	}
+	if _, err := r.messageCollection.UpdateOne(context.Background(), filter, update); err != nil {
+		return err
+	}
+
+	go r.updateMessageInCache(message)
+	return nil
+}
+
+// IncrementMessageReaction adds delta to the count of an emoji on a message and returns the updated message.
+// Emojis whose count drops to zero are removed from the reactions map.
+func (r *chatRepository) IncrementMessageReaction(messageID primitive.ObjectID, emoji string, delta int) (*models.Message, error) {
+	field := "reactions." + emoji
+	var message models.Message
+	err := r.messageCollection.FindOneAndUpdate(context.Background(),
+		bson.M{"_id": messageID},
+		bson.M{"$inc": bson.M{field: delta}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&message)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Reactions[emoji] <= 0 {
+		filter := bson.M{"_id": messageID, field: bson.M{"$lte": 0}}
+		if _, err := r.messageCollection.UpdateOne(context.Background(), filter, bson.M{"$unset": bson.M{field: ""}}); err != nil {
+			return nil, err
+		}
+		delete(message.Reactions, emoji)
+	}
+
+	go r.updateMessageInCache(&message)
+	return &message, nil
+}
+
+// FindQueriesByCriteria returns the queries generated in a chat, newest first, without the message content.
+// Messages are matched with $elemMatch on the queries array and the array is unwound so each query is filtered and paged on its own.
+func (r *chatRepository) FindQueriesByCriteria(chatID primitive.ObjectID, filter QueryHistoryFilter) ([]*QueryHistoryRecord, int64, error) {
+	ctx := context.Background()
+
+	queryMatch := bson.M{}
+	if filter.QueryType != "" {
+		queryMatch["query_type"] = filter.QueryType
+	}
+	if filter.IsExecuted != nil {
+		queryMatch["is_executed"] = *filter.IsExecuted
+	}
+
+	messageMatch := bson.M{"chat_id": chatID}
+	if len(queryMatch) > 0 {
+		messageMatch["queries"] = bson.M{"$elemMatch": queryMatch}
+	} else {
+		messageMatch["queries.0"] = bson.M{"$exists": true}
+	}
+	createdAt := bson.M{}
+	if filter.From != nil {
+		createdAt["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		createdAt["$lte"] = *filter.To
+	}
+	if len(createdAt) > 0 {
+		messageMatch["created_at"] = createdAt
+	}
+
+	// The same conditions again on the unwound queries, $elemMatch only selects the messages
+	unwoundMatch := bson.M{}
+	for key, value := range queryMatch {
+		unwoundMatch["queries."+key] = value
+	}
+
+	skip := (filter.Page - 1) * filter.PageSize
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: messageMatch}},
+		{{Key: "$project", Value: bson.M{"_id": 1, "created_at": 1, "queries": 1}}},
+		{{Key: "$unwind", Value: "$queries"}},
+		{{Key: "$match", Value: unwoundMatch}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"items": bson.A{
+				bson.M{"$skip": skip},
+				bson.M{"$limit": filter.PageSize},
+				bson.M{"$project": bson.M{"_id": 0, "message_id": "$_id", "created_at": 1, "query": "$queries"}},
+			},
+		}}},
+	}
+
+	cursor, err := r.messageCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("FindQueriesByCriteria -> Error: %v", err)
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Items []*QueryHistoryRecord `bson:"items"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, 0, err
+	}
+	if len(results) == 0 || len(results[0].Total) == 0 {
+		return []*QueryHistoryRecord{}, 0, nil
+	}
+
+	return results[0].Items, results[0].Total[0].Count, nil
+}
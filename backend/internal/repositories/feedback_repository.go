@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FeedbackRatingStats is the number of feedbacks and average rating of a group, e.g. an LLM model or database type
+type FeedbackRatingStats struct {
+	Name      string  `bson:"_id" json:"name"`
+	Count     int64   `bson:"count" json:"count"`
+	AvgRating float64 `bson:"avg_rating" json:"avg_rating"`
+	LowRated  int64   `bson:"low_rated" json:"low_rated"`
+}
+
+// FeedbackRepository defines operations for AI response feedback persistence.
+type FeedbackRepository interface {
+	Upsert(ctx context.Context, feedback *models.MessageFeedback) error
+	GetMessageStats(ctx context.Context, messageID primitive.ObjectID) (int64, float64, error)
+	List(ctx context.Context, maxRating int, page, pageSize int) ([]*models.MessageFeedback, int64, error)
+	GetStatsBy(ctx context.Context, field string, lowRatingThreshold int) ([]FeedbackRatingStats, error)
+}
+
+type feedbackRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFeedbackRepository creates a new repository backed by the `message_feedback` MongoDB collection.
+func NewFeedbackRepository(mongoClient *mongodb.MongoDBClient) FeedbackRepository {
+	repo := &feedbackRepository{
+		collection: mongoClient.GetCollectionByName("message_feedback"),
+	}
+
+	// One feedback per user per message
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "message_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+		if err != nil {
+			log.Printf("MessageFeedback -> Warning: failed to create message_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+// Upsert stores a feedback, replacing the user's previous feedback on the same message
+func (r *feedbackRepository) Upsert(ctx context.Context, feedback *models.MessageFeedback) error {
+	filter := bson.M{"message_id": feedback.MessageID, "user_id": feedback.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"chat_id":    feedback.ChatID,
+			"rating":     feedback.Rating,
+			"issue":      feedback.Issue,
+			"free_text":  feedback.FreeText,
+			"db_type":    feedback.DBType,
+			"llm_model":  feedback.LLMModel,
+			"updated_at": feedback.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        feedback.ID,
+			"created_at": feedback.CreatedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save feedback for message %s: %w", feedback.MessageID.Hex(), err)
+	}
+	return nil
+}
+
+// GetMessageStats returns the number of feedbacks and the average rating of a message
+func (r *feedbackRepository) GetMessageStats(ctx context.Context, messageID primitive.ObjectID) (int64, float64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"message_id": messageID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        nil,
+			"count":      bson.M{"$sum": 1},
+			"avg_rating": bson.M{"$avg": "$rating"},
+		}}},
+	}
+
+	var stats []FeedbackRatingStats
+	if err := r.aggregate(ctx, pipeline, &stats); err != nil {
+		return 0, 0, fmt.Errorf("failed to aggregate feedback for message %s: %w", messageID.Hex(), err)
+	}
+	if len(stats) == 0 {
+		return 0, 0, nil
+	}
+	return stats[0].Count, stats[0].AvgRating, nil
+}
+
+// List returns feedback newest first. A maxRating above 0 only returns feedback rated at or below it.
+func (r *feedbackRepository) List(ctx context.Context, maxRating int, page, pageSize int) ([]*models.MessageFeedback, int64, error) {
+	filter := bson.M{}
+	if maxRating > 0 {
+		filter["rating"] = bson.M{"$lte": maxRating}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count feedback: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list feedback: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var feedback []*models.MessageFeedback
+	if err := cursor.All(ctx, &feedback); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode feedback: %w", err)
+	}
+	return feedback, total, nil
+}
+
+// GetStatsBy groups feedback by a field (llm_model or db_type), with the average rating and low rated count of each group
+func (r *feedbackRepository) GetStatsBy(ctx context.Context, field string, lowRatingThreshold int) ([]FeedbackRatingStats, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$" + field,
+			"count":      bson.M{"$sum": 1},
+			"avg_rating": bson.M{"$avg": "$rating"},
+			"low_rated":  bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$lte": bson.A{"$rating", lowRatingThreshold}}, 1, 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"avg_rating": 1}}},
+	}
+
+	var stats []FeedbackRatingStats
+	if err := r.aggregate(ctx, pipeline, &stats); err != nil {
+		log.Printf("FeedbackRepository -> GetStatsBy -> Error: %v", err)
+		return nil, fmt.Errorf("failed to aggregate feedback by %s: %w", field, err)
+	}
+	return stats, nil
+}
+
+func (r *feedbackRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline, results interface{}) error {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, results)
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UserDataCollections are the collections holding documents owned by a user, all keyed by user_id.
+// LLM messages are built from messages on the fly and are not stored.
+var UserDataCollections = []string{
+	"chats",
+	"messages",
+	"message_feedback",
+	"message_reactions",
+	"message_visualizations",
+	"dashboards",
+	"widgets",
+	"knowledge_bases",
+	"query_templates",
+	"secure_notes",
+	"schema_sources",
+	"reports",
+	"rollback_records",
+	"api_keys",
+}
+
+// GDPRRepository reads and deletes a user's documents across collections, and appends to the GDPR log.
+type GDPRRepository interface {
+	FindUserDocuments(ctx context.Context, collection string, userID primitive.ObjectID, results interface{}) error
+	DeleteUserDocuments(ctx context.Context, collection string, userID primitive.ObjectID) (int64, error)
+	CreateLog(ctx context.Context, entry *models.GDPRLog) error
+}
+
+type gdprRepository struct {
+	mongoClient   *mongodb.MongoDBClient
+	logCollection *mongo.Collection
+}
+
+// NewGDPRRepository creates a new repository, the log is backed by the `gdpr_logs` MongoDB collection.
+func NewGDPRRepository(mongoClient *mongodb.MongoDBClient) GDPRRepository {
+	return &gdprRepository{
+		mongoClient:   mongoClient,
+		logCollection: mongoClient.GetCollectionByName("gdpr_logs"),
+	}
+}
+
+// FindUserDocuments decodes all of a user's documents in a collection into results, a pointer to a slice
+func (r *gdprRepository) FindUserDocuments(ctx context.Context, collection string, userID primitive.ObjectID, results interface{}) error {
+	if !isUserDataCollection(collection) {
+		return fmt.Errorf("%s does not hold user data", collection)
+	}
+
+	coll := r.mongoClient.GetCollectionByName(collection)
+	cursor, err := coll.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %v", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, results); err != nil {
+		return fmt.Errorf("failed to decode %s: %v", collection, err)
+	}
+	return nil
+}
+
+// DeleteUserDocuments deletes all of a user's documents in a collection and returns how many were deleted
+func (r *gdprRepository) DeleteUserDocuments(ctx context.Context, collection string, userID primitive.ObjectID) (int64, error) {
+	if !isUserDataCollection(collection) {
+		return 0, fmt.Errorf("%s does not hold user data", collection)
+	}
+
+	result, err := r.mongoClient.GetCollectionByName(collection).DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete %s: %v", collection, err)
+	}
+	return result.DeletedCount, nil
+}
+
+// CreateLog appends an entry to the GDPR log, entries are never updated or deleted
+func (r *gdprRepository) CreateLog(ctx context.Context, entry *models.GDPRLog) error {
+	_, err := r.logCollection.InsertOne(ctx, entry)
+	return err
+}
+
+func isUserDataCollection(collection string) bool {
+	for _, name := range UserDataCollections {
+		if name == collection {
+			return true
+		}
+	}
+	return false
+}
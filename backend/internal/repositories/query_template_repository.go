@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// QueryTemplateRepository defines operations for query template persistence
+type QueryTemplateRepository interface {
+	Create(ctx context.Context, template *models.QueryTemplate) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.QueryTemplate, error)
+	FindByChatID(ctx context.Context, chatID primitive.ObjectID) ([]*models.QueryTemplate, error)
+	DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error
+}
+
+type queryTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewQueryTemplateRepository creates a new repository backed by the `query_templates` MongoDB collection.
+func NewQueryTemplateRepository(mongoClient *mongodb.MongoDBClient) QueryTemplateRepository {
+	repo := &queryTemplateRepository{
+		collection: mongoClient.GetCollectionByName("query_templates"),
+	}
+
+	// Templates are always listed per chat
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "chat_id", Value: 1}, {Key: "created_at", Value: 1}},
+		})
+		if err != nil {
+			log.Printf("QueryTemplate -> Warning: failed to create chat_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+// Create stores a new template
+func (r *queryTemplateRepository) Create(ctx context.Context, template *models.QueryTemplate) error {
+	if _, err := r.collection.InsertOne(ctx, template); err != nil {
+		return fmt.Errorf("failed to create query template for chat %s: %w", template.ChatID.Hex(), err)
+	}
+	return nil
+}
+
+// FindByID returns a template, or nil when it does not exist
+func (r *queryTemplateRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.QueryTemplate, error) {
+	var template models.QueryTemplate
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&template)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find query template %s: %w", id.Hex(), err)
+	}
+	return &template, nil
+}
+
+// FindByChatID returns the templates of a chat, oldest first
+func (r *queryTemplateRepository) FindByChatID(ctx context.Context, chatID primitive.ObjectID) ([]*models.QueryTemplate, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"chat_id": chatID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find query templates for chat %s: %w", chatID.Hex(), err)
+	}
+	defer cursor.Close(ctx)
+
+	templates := []*models.QueryTemplate{}
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, fmt.Errorf("failed to decode query templates for chat %s: %w", chatID.Hex(), err)
+	}
+	return templates, nil
+}
+
+// DeleteByChatID removes all templates of a chat
+func (r *queryTemplateRepository) DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"chat_id": chatID}); err != nil {
+		return fmt.Errorf("failed to delete query templates for chat %s: %w", chatID.Hex(), err)
+	}
+	return nil
+}
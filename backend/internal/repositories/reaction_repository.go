@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReactionRepository defines operations for message reaction persistence.
+// It records who reacted with what, the counts shown on messages are kept by ChatRepository.
+type ReactionRepository interface {
+	Add(ctx context.Context, reaction *models.MessageReaction) (bool, error)
+	Remove(ctx context.Context, messageID, userID primitive.ObjectID, emoji string) (bool, error)
+}
+
+type reactionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewReactionRepository creates a new repository backed by the `message_reactions` MongoDB collection.
+func NewReactionRepository(mongoClient *mongodb.MongoDBClient) ReactionRepository {
+	repo := &reactionRepository{
+		collection: mongoClient.GetCollectionByName("message_reactions"),
+	}
+
+	// One reaction per user per emoji per message
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "message_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "emoji", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+		if err != nil {
+			log.Printf("MessageReaction -> Warning: failed to create message_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+// Add stores a reaction and reports whether it is new, reacting twice with the same emoji is a no-op
+func (r *reactionRepository) Add(ctx context.Context, reaction *models.MessageReaction) (bool, error) {
+	filter := bson.M{"message_id": reaction.MessageID, "user_id": reaction.UserID, "emoji": reaction.Emoji}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"_id":        reaction.ID,
+			"chat_id":    reaction.ChatID,
+			"created_at": reaction.CreatedAt,
+			"updated_at": reaction.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, fmt.Errorf("failed to save reaction for message %s: %w", reaction.MessageID.Hex(), err)
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// Remove deletes a user's reaction and reports whether there was one
+func (r *reactionRepository) Remove(ctx context.Context, messageID, userID primitive.ObjectID, emoji string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"message_id": messageID, "user_id": userID, "emoji": emoji})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove reaction for message %s: %w", messageID.Hex(), err)
+	}
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReportRepository defines operations for generated report persistence
+type ReportRepository interface {
+	Create(ctx context.Context, report *models.Report) error
+	Update(ctx context.Context, report *models.Report) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Report, error)
+	FindByChatID(ctx context.Context, chatID primitive.ObjectID) ([]*models.Report, error)
+	DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error
+}
+
+type reportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewReportRepository creates a new repository backed by the `reports` MongoDB collection.
+func NewReportRepository(mongoClient *mongodb.MongoDBClient) ReportRepository {
+	repo := &reportRepository{
+		collection: mongoClient.GetCollectionByName("reports"),
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "chat_id", Value: 1}, {Key: "created_at", Value: -1}},
+		})
+		if err != nil {
+			log.Printf("Report -> Warning: failed to create chat_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+func (r *reportRepository) Create(ctx context.Context, report *models.Report) error {
+	if _, err := r.collection.InsertOne(ctx, report); err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	return nil
+}
+
+// Update replaces the report's sections, Markdown and status
+func (r *reportRepository) Update(ctx context.Context, report *models.Report) error {
+	report.UpdatedAt = time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"sections":   report.Sections,
+			"markdown":   report.Markdown,
+			"status":     report.Status,
+			"error":      report.Error,
+			"updated_at": report.UpdatedAt,
+		},
+	}
+	if _, err := r.collection.UpdateByID(ctx, report.ID, update); err != nil {
+		return fmt.Errorf("failed to update report %s: %w", report.ID.Hex(), err)
+	}
+	return nil
+}
+
+// FindByID returns the report, or nil when there is none
+func (r *reportRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Report, error) {
+	var report models.Report
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&report)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find report %s: %w", id.Hex(), err)
+	}
+	return &report, nil
+}
+
+// FindByChatID returns the reports of a chat, newest first. The Markdown is left out, lists don't show it.
+func (r *reportRepository) FindByChatID(ctx context.Context, chatID primitive.ObjectID) ([]*models.Report, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetProjection(bson.M{"markdown": 0, "sections.markdown": 0})
+	cursor, err := r.collection.Find(ctx, bson.M{"chat_id": chatID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reports for chat %s: %w", chatID.Hex(), err)
+	}
+	defer cursor.Close(ctx)
+
+	reports := []*models.Report{}
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, fmt.Errorf("failed to decode reports for chat %s: %w", chatID.Hex(), err)
+	}
+	return reports, nil
+}
+
+// DeleteByChatID removes all reports of a chat
+func (r *reportRepository) DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"chat_id": chatID}); err != nil {
+		return fmt.Errorf("failed to delete reports for chat %s: %w", chatID.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RollbackRepository defines operations for the rollback audit trail. Records are kept when their chat is
+// deleted so reversals stay auditable, they are only removed with the user's data.
+type RollbackRepository interface {
+	Create(ctx context.Context, record *models.RollbackRecord) error
+	FindByChatID(ctx context.Context, chatID primitive.ObjectID, page, pageSize int) ([]*models.RollbackRecord, int64, error)
+	CountRollbacksByMessageID(ctx context.Context, messageID primitive.ObjectID) (int64, error)
+}
+
+type rollbackRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRollbackRepository creates a new repository backed by the `rollback_records` MongoDB collection.
+func NewRollbackRepository(mongoClient *mongodb.MongoDBClient) RollbackRepository {
+	repo := &rollbackRepository{
+		collection: mongoClient.GetCollectionByName("rollback_records"),
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "chat_id", Value: 1}, {Key: "executed_at", Value: -1}}},
+			{Keys: bson.D{{Key: "message_id", Value: 1}, {Key: "step", Value: 1}}},
+		})
+		if err != nil {
+			log.Printf("Rollback -> Warning: failed to create indexes: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+func (r *rollbackRepository) Create(ctx context.Context, record *models.RollbackRecord) error {
+	if _, err := r.collection.InsertOne(ctx, record); err != nil {
+		return fmt.Errorf("failed to create rollback record: %w", err)
+	}
+	return nil
+}
+
+// FindByChatID returns a page of the chat's rollback records, most recent first, with the total count
+func (r *rollbackRepository) FindByChatID(ctx context.Context, chatID primitive.ObjectID, page, pageSize int) ([]*models.RollbackRecord, int64, error) {
+	filter := bson.M{"chat_id": chatID}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count rollback records for chat %s: %w", chatID.Hex(), err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "executed_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find rollback records for chat %s: %w", chatID.Hex(), err)
+	}
+	defer cursor.Close(ctx)
+
+	records := []*models.RollbackRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode rollback records for chat %s: %w", chatID.Hex(), err)
+	}
+	return records, total, nil
+}
+
+// CountRollbacksByMessageID counts the rollback attempts of a message's queries, dependent query runs excluded
+func (r *rollbackRepository) CountRollbacksByMessageID(ctx context.Context, messageID primitive.ObjectID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"message_id": messageID, "step": constants.RollbackStepRollback})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rollbacks for message %s: %w", messageID.Hex(), err)
+	}
+	return count, nil
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SchemaSourceRepository defines operations for uploaded schema file persistence
+type SchemaSourceRepository interface {
+	Upsert(ctx context.Context, source *models.ExternalSchemaSource) error
+	FindByChatID(ctx context.Context, chatID primitive.ObjectID) ([]*models.ExternalSchemaSource, error)
+	DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error
+}
+
+type schemaSourceRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSchemaSourceRepository creates a new repository backed by the `schema_sources` MongoDB collection.
+func NewSchemaSourceRepository(mongoClient *mongodb.MongoDBClient) SchemaSourceRepository {
+	repo := &schemaSourceRepository{
+		collection: mongoClient.GetCollectionByName("schema_sources"),
+	}
+
+	// One source per type per chat
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "chat_id", Value: 1}, {Key: "source_type", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+		if err != nil {
+			log.Printf("SchemaSource -> Warning: failed to create chat_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+// Upsert stores a source, replacing the chat's previous source of the same type.
+// The source is updated with the stored ID and creation time when it replaced one.
+func (r *schemaSourceRepository) Upsert(ctx context.Context, source *models.ExternalSchemaSource) error {
+	filter := bson.M{"chat_id": source.ChatID, "source_type": source.SourceType}
+	update := bson.M{
+		"$set": bson.M{
+			"user_id":    source.UserID,
+			"file_name":  source.FileName,
+			"content":    source.Content,
+			"tables":     source.Tables,
+			"updated_at": source.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        source.ID,
+			"created_at": source.CreatedAt,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(source); err != nil {
+		return fmt.Errorf("failed to save %s schema for chat %s: %w", source.SourceType, source.ChatID.Hex(), err)
+	}
+	return nil
+}
+
+// FindByChatID returns the sources of a chat, oldest first
+func (r *schemaSourceRepository) FindByChatID(ctx context.Context, chatID primitive.ObjectID) ([]*models.ExternalSchemaSource, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"chat_id": chatID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find external schemas for chat %s: %w", chatID.Hex(), err)
+	}
+	defer cursor.Close(ctx)
+
+	sources := []*models.ExternalSchemaSource{}
+	if err := cursor.All(ctx, &sources); err != nil {
+		return nil, fmt.Errorf("failed to decode external schemas for chat %s: %w", chatID.Hex(), err)
+	}
+	return sources, nil
+}
+
+// DeleteByChatID removes all sources of a chat
+func (r *schemaSourceRepository) DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"chat_id": chatID}); err != nil {
+		return fmt.Errorf("failed to delete external schemas for chat %s: %w", chatID.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/mongodb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SecureNoteRepository defines operations for secure note persistence.
+// Content is stored as given, encryption is done by the service.
+type SecureNoteRepository interface {
+	Create(ctx context.Context, note *models.SecureNote) error
+	FindByChatID(ctx context.Context, chatID primitive.ObjectID, activeOnly bool) ([]*models.SecureNote, error)
+	DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error
+}
+
+type secureNoteRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSecureNoteRepository creates a new repository backed by the `secure_notes` MongoDB collection.
+func NewSecureNoteRepository(mongoClient *mongodb.MongoDBClient) SecureNoteRepository {
+	repo := &secureNoteRepository{
+		collection: mongoClient.GetCollectionByName("secure_notes"),
+	}
+
+	// Notes are always read per chat
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "chat_id", Value: 1}, {Key: "created_at", Value: 1}},
+		})
+		if err != nil {
+			log.Printf("SecureNote -> Warning: failed to create chat_id index: %v", err)
+		}
+	}()
+
+	return repo
+}
+
+// Create stores a new note
+func (r *secureNoteRepository) Create(ctx context.Context, note *models.SecureNote) error {
+	if _, err := r.collection.InsertOne(ctx, note); err != nil {
+		return fmt.Errorf("failed to create secure note for chat %s: %w", note.ChatID.Hex(), err)
+	}
+	return nil
+}
+
+// FindByChatID returns the notes of a chat, oldest first
+func (r *secureNoteRepository) FindByChatID(ctx context.Context, chatID primitive.ObjectID, activeOnly bool) ([]*models.SecureNote, error) {
+	filter := bson.M{"chat_id": chatID}
+	if activeOnly {
+		filter["is_active"] = true
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secure notes for chat %s: %w", chatID.Hex(), err)
+	}
+	defer cursor.Close(ctx)
+
+	notes := []*models.SecureNote{}
+	if err := cursor.All(ctx, &notes); err != nil {
+		return nil, fmt.Errorf("failed to decode secure notes for chat %s: %w", chatID.Hex(), err)
+	}
+	return notes, nil
+}
+
+// DeleteByChatID removes all notes of a chat
+func (r *secureNoteRepository) DeleteByChatID(ctx context.Context, chatID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"chat_id": chatID}); err != nil {
+		return fmt.Errorf("failed to delete secure notes for chat %s: %w", chatID.Hex(), err)
+	}
+	return nil
+}
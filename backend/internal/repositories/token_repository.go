@@ -16,6 +16,8 @@ type TokenRepository interface {
 	DeleteRefreshToken(userID string, refreshToken string) error
 	BlacklistToken(token string, expiresAt time.Duration) error
 	IsTokenBlacklisted(token string) bool
+	RevokeAPIKey(keyID string, expiresIn time.Duration) error
+	IsAPIKeyRevoked(keyID string) bool
 }
 
 type tokenRepository struct {
@@ -102,3 +104,27 @@ func (r *tokenRepository) IsTokenBlacklisted(token string) bool {
 	}
 	return value == "blacklisted"
 }
+
+// RevokeAPIKey stores the ID (jti) of a revoked API key until the key would have expired
+func (r *tokenRepository) RevokeAPIKey(keyID string, expiresIn time.Duration) error {
+	log.Printf("Revoking API key %s with expiration: %v", keyID, expiresIn)
+	key := fmt.Sprintf("revoked_api_key:%s", keyID)
+
+	err := r.redis.Set(key, []byte("revoked"), expiresIn, context.Background())
+	if err != nil {
+		log.Printf("Error revoking API key: %v", err)
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	log.Printf("Successfully revoked API key")
+	return nil
+}
+
+func (r *tokenRepository) IsAPIKeyRevoked(keyID string) bool {
+	key := fmt.Sprintf("revoked_api_key:%s", keyID)
+	value, err := r.redis.Get(key, context.Background())
+	if err != nil {
+		return false
+	}
+	return value == "revoked"
+}
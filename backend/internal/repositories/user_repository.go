@@ -28,9 +28,11 @@ type UserRepository interface {
 	DeleteUserSignupSecret(secret string) error
 	FindByID(userID string) (*models.User, error)
 	UpdatePassword(userID, newPassword string) error
+	UpdateMaxQueryResultRows(userID string, maxRows *int) error
 	StorePasswordResetOTP(email, otp string) error
 	ValidatePasswordResetOTP(email, otp string) bool
 	DeletePasswordResetOTP(email string) error
+	Delete(user *models.User) error
 }
 
 type userRepository struct {
@@ -318,6 +320,41 @@ func (r *userRepository) UpdatePassword(userID, newPassword string) error {
 	return err
 }
 
+// UpdateMaxQueryResultRows sets the user's query result row cap, nil removes the override
+func (r *userRepository) UpdateMaxQueryResultRows(userID string, maxRows *int) error {
+	userIDPrimitive, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	var update bson.M
+	if maxRows == nil {
+		update = bson.M{
+			"$unset": bson.M{"max_query_result_rows": ""},
+			"$set":   bson.M{"updated_at": primitive.NewDateTimeFromTime(time.Now())},
+		}
+	} else {
+		update = bson.M{
+			"$set": bson.M{
+				"max_query_result_rows": *maxRows,
+				"updated_at":            primitive.NewDateTimeFromTime(time.Now()),
+			},
+		}
+	}
+
+	_, err = r.userCollection.UpdateOne(
+		context.Background(),
+		bson.M{"_id": userIDPrimitive},
+		update,
+	)
+
+	if err == nil {
+		go r.updateUserCache(userID)
+	}
+
+	return err
+}
+
 func (r *userRepository) StorePasswordResetOTP(email, otp string) error {
 	// Store OTP in Redis with 10 minutes expiration
 	key := fmt.Sprintf("password_reset_otp:%s", email)
@@ -383,3 +420,27 @@ func (r *userRepository) Update(userID string, user *models.User) error {
 
 	return err
 }
+
+// Delete removes a user's account and its cached copies
+func (r *userRepository) Delete(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.userCollection.DeleteOne(ctx, bson.M{"_id": user.ID}); err != nil {
+		return err
+	}
+
+	keys := []string{fmt.Sprintf("user:id:%s", user.ID.Hex())}
+	if user.Email != "" {
+		keys = append(keys, fmt.Sprintf("user:email:%s", user.Email))
+	}
+	if user.Username != "" {
+		keys = append(keys, fmt.Sprintf("user:username:%s", user.Username))
+	}
+	for _, key := range keys {
+		if err := r.redisRepo.Del(key, ctx); err != nil {
+			log.Printf("[CACHE ERROR] Failed to delete cached user - Key: %s, Error: %v", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"neobase-ai/config"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/repositories"
+	"neobase-ai/pkg/dbmanager"
+	"neobase-ai/pkg/llm"
+)
+
+// AdminService exposes operational data about the NeoBase instance. Only the admin user may call it.
+type AdminService interface {
+	GetPoolStats(userID string) (*dbmanager.PoolStatsResponse, uint32, error)
+	GetFeedback(ctx context.Context, userID string, lowRatedOnly bool, page, pageSize int) (*dtos.AdminFeedbackResponse, uint32, error)
+	GetLLMCircuitBreakers(userID string) ([]llm.CircuitBreakerStats, uint32, error)
+	ResetLLMCircuitBreaker(userID, provider string) (*llm.CircuitBreakerStats, uint32, error)
+}
+
+type adminService struct {
+	userRepo     repositories.UserRepository
+	dbManager    *dbmanager.Manager
+	feedbackRepo repositories.FeedbackRepository
+	llmManager   *llm.Manager
+}
+
+// NewAdminService creates a new admin service instance
+func NewAdminService(userRepo repositories.UserRepository, dbManager *dbmanager.Manager, feedbackRepo repositories.FeedbackRepository, llmManager *llm.Manager) AdminService {
+	return &adminService{
+		userRepo:     userRepo,
+		dbManager:    dbManager,
+		feedbackRepo: feedbackRepo,
+		llmManager:   llmManager,
+	}
+}
+
+// GetPoolStats returns the size and usage of the database connection pools, grouped by database type
+func (s *adminService) GetPoolStats(userID string) (*dbmanager.PoolStatsResponse, uint32, error) {
+	if status, err := s.requireAdmin(userID); err != nil {
+		return nil, status, err
+	}
+	return s.dbManager.GetPoolStats(), http.StatusOK, nil
+}
+
+// GetFeedback returns a page of AI response feedback, newest first, with the average ratings by LLM model and database type
+func (s *adminService) GetFeedback(ctx context.Context, userID string, lowRatedOnly bool, page, pageSize int) (*dtos.AdminFeedbackResponse, uint32, error) {
+	if status, err := s.requireAdmin(userID); err != nil {
+		return nil, status, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	maxRating := 0
+	if lowRatedOnly {
+		maxRating = constants.FeedbackLowRatingThreshold
+	}
+
+	feedback, total, err := s.feedbackRepo.List(ctx, maxRating, page, pageSize)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	byModel, err := s.feedbackRepo.GetStatsBy(ctx, "llm_model", constants.FeedbackLowRatingThreshold)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	byDBType, err := s.feedbackRepo.GetStatsBy(ctx, "db_type", constants.FeedbackLowRatingThreshold)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	items := make([]dtos.FeedbackItem, 0, len(feedback))
+	for _, f := range feedback {
+		items = append(items, dtos.FeedbackItem{
+			ID:        f.ID.Hex(),
+			UserID:    f.UserID.Hex(),
+			ChatID:    f.ChatID.Hex(),
+			MessageID: f.MessageID.Hex(),
+			Rating:    f.Rating,
+			Issue:     f.Issue,
+			FreeText:  f.FreeText,
+			DBType:    f.DBType,
+			LLMModel:  f.LLMModel,
+			CreatedAt: f.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &dtos.AdminFeedbackResponse{
+		Feedback: items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		ByModel:  toFeedbackRatingStats(byModel),
+		ByDBType: toFeedbackRatingStats(byDBType),
+	}, http.StatusOK, nil
+}
+
+// GetLLMCircuitBreakers returns the circuit breaker state of every LLM provider
+func (s *adminService) GetLLMCircuitBreakers(userID string) ([]llm.CircuitBreakerStats, uint32, error) {
+	if status, err := s.requireAdmin(userID); err != nil {
+		return nil, status, err
+	}
+	return s.llmManager.GetCircuitBreakers(), http.StatusOK, nil
+}
+
+// ResetLLMCircuitBreaker closes the circuit of an LLM provider, e.g. once its outage is known to be over
+func (s *adminService) ResetLLMCircuitBreaker(userID, provider string) (*llm.CircuitBreakerStats, uint32, error) {
+	if status, err := s.requireAdmin(userID); err != nil {
+		return nil, status, err
+	}
+
+	stats, err := s.llmManager.ResetCircuitBreaker(provider)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+	log.Printf("AdminService -> ResetLLMCircuitBreaker -> Circuit breaker of %s reset by user %s", provider, userID)
+	return stats, http.StatusOK, nil
+}
+
+func toFeedbackRatingStats(stats []repositories.FeedbackRatingStats) []dtos.FeedbackRatingStats {
+	result := make([]dtos.FeedbackRatingStats, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, dtos.FeedbackRatingStats{
+			Name:      stat.Name,
+			Count:     stat.Count,
+			AvgRating: stat.AvgRating,
+			LowRated:  stat.LowRated,
+		})
+	}
+	return result
+}
+
+// requireAdmin checks that the user is the configured admin user
+func (s *adminService) requireAdmin(userID string) (uint32, error) {
+	return requireAdminUser(s.userRepo, userID)
+}
+
+// requireAdminUser checks that the user is the configured admin user, for services with admin-only operations
+func requireAdminUser(userRepo repositories.UserRepository, userID string) (uint32, error) {
+	user, err := userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return http.StatusUnauthorized, fmt.Errorf("user not found")
+	}
+	if config.Env.AdminUser == "" || user.Username != config.Env.AdminUser {
+		return http.StatusForbidden, fmt.Errorf("admin access required")
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnalyticsService reports how chats are used. Everything is aggregated on demand from existing collections.
+type AnalyticsService interface {
+	GetGlobalAnalytics(ctx context.Context, userID string, req *dtos.AnalyticsRequest) (*dtos.AnalyticsResponse, uint32, error)
+	GetChatAnalytics(ctx context.Context, userID, chatID string, req *dtos.AnalyticsRequest) (*dtos.AnalyticsResponse, uint32, error)
+}
+
+type analyticsService struct {
+	analyticsRepo repositories.AnalyticsRepository
+	chatRepo      repositories.ChatRepository
+	userRepo      repositories.UserRepository
+}
+
+// NewAnalyticsService creates a new analytics service instance
+func NewAnalyticsService(
+	analyticsRepo repositories.AnalyticsRepository,
+	chatRepo repositories.ChatRepository,
+	userRepo repositories.UserRepository,
+) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo: analyticsRepo,
+		chatRepo:      chatRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// GetGlobalAnalytics aggregates analytics across all users. Only the admin user may call it.
+func (s *analyticsService) GetGlobalAnalytics(ctx context.Context, userID string, req *dtos.AnalyticsRequest) (*dtos.AnalyticsResponse, uint32, error) {
+	if status, err := requireAdminUser(s.userRepo, userID); err != nil {
+		return nil, status, err
+	}
+
+	filter, err := s.buildFilter(req)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return s.buildAnalytics(ctx, filter)
+}
+
+// GetChatAnalytics returns the same analytics scoped to a single chat owned by the user
+func (s *analyticsService) GetChatAnalytics(ctx context.Context, userID, chatID string, req *dtos.AnalyticsRequest) (*dtos.AnalyticsResponse, uint32, error) {
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+	if chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
+	}
+
+	filter, err := s.buildFilter(req)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	filter.ChatID = &chatObjID
+
+	resp, statusCode, err := s.buildAnalytics(ctx, filter)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	resp.ChatID = &chatID
+	return resp, statusCode, nil
+}
+
+// buildFilter validates the requested range, defaulting to the last DefaultAnalyticsRangeDays days grouped by day
+func (s *analyticsService) buildFilter(req *dtos.AnalyticsRequest) (repositories.AnalyticsFilter, error) {
+	filter := repositories.AnalyticsFilter{
+		To:      time.Now().UTC(),
+		GroupBy: constants.AnalyticsGroupByDay,
+	}
+	if req.GroupBy != "" {
+		filter.GroupBy = req.GroupBy
+	}
+
+	if req.To != "" {
+		to, dateOnly, err := parseAnalyticsTime(req.To)
+		if err != nil {
+			return filter, fmt.Errorf("invalid 'to' value: %v", err)
+		}
+		if dateOnly {
+			// Include the whole day
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+		filter.To = to
+	}
+
+	filter.From = filter.To.AddDate(0, 0, -constants.DefaultAnalyticsRangeDays)
+	if req.From != "" {
+		from, _, err := parseAnalyticsTime(req.From)
+		if err != nil {
+			return filter, fmt.Errorf("invalid 'from' value: %v", err)
+		}
+		filter.From = from
+	}
+
+	if !filter.From.Before(filter.To) {
+		return filter, fmt.Errorf("'from' must be before 'to'")
+	}
+	if filter.To.Sub(filter.From) > constants.MaxAnalyticsRangeDays*24*time.Hour {
+		return filter, fmt.Errorf("the analytics range cannot exceed %d days", constants.MaxAnalyticsRangeDays)
+	}
+
+	return filter, nil
+}
+
+// parseAnalyticsTime accepts RFC3339 timestamps or YYYY-MM-DD dates, reporting whether the value was a date
+func parseAnalyticsTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), false, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expected RFC3339 or YYYY-MM-DD")
+	}
+	return t, true, nil
+}
+
+func (s *analyticsService) buildAnalytics(ctx context.Context, filter repositories.AnalyticsFilter) (*dtos.AnalyticsResponse, uint32, error) {
+	log.Printf("AnalyticsService -> buildAnalytics -> from: %s, to: %s, groupBy: %s, chatID: %v",
+		filter.From.Format(time.RFC3339), filter.To.Format(time.RFC3339), filter.GroupBy, filter.ChatID)
+
+	resp := &dtos.AnalyticsResponse{
+		From:       filter.From,
+		To:         filter.To,
+		GroupBy:    filter.GroupBy,
+		Messages:   []dtos.AnalyticsMessagePoint{},
+		Latency:    []dtos.AnalyticsLatencyPoint{},
+		Errors:     []dtos.AnalyticsErrorPoint{},
+		QueryTypes: []dtos.AnalyticsCount{},
+		TopTables:  []dtos.AnalyticsCount{},
+		LLMModels:  []dtos.AnalyticsLLMModelCount{},
+	}
+
+	messageCounts, err := s.analyticsRepo.GetMessageCounts(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, bucket := range messageCounts {
+		resp.Messages = append(resp.Messages, dtos.AnalyticsMessagePoint{
+			Period:    bucket.Period,
+			Total:     bucket.Total,
+			User:      bucket.User,
+			Assistant: bucket.Assistant,
+		})
+		resp.Summary.TotalMessages += bucket.Total
+		resp.Summary.UserMessages += bucket.User
+	}
+
+	latency, err := s.analyticsRepo.GetResponseLatency(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	var latencyTotal float64
+	var latencyCount int64
+	for _, bucket := range latency {
+		resp.Latency = append(resp.Latency, dtos.AnalyticsLatencyPoint{
+			Period: bucket.Period,
+			AvgMs:  bucket.AvgMs,
+			Count:  bucket.Count,
+		})
+		latencyTotal += bucket.AvgMs * float64(bucket.Count)
+		latencyCount += bucket.Count
+	}
+	if latencyCount > 0 {
+		resp.Summary.AvgLLMLatencyMs = latencyTotal / float64(latencyCount)
+	}
+
+	queryErrors, err := s.analyticsRepo.GetQueryErrorCounts(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, bucket := range queryErrors {
+		point := dtos.AnalyticsErrorPoint{
+			Period:   bucket.Period,
+			Executed: bucket.Executed,
+			Failed:   bucket.Failed,
+		}
+		if bucket.Executed > 0 {
+			point.ErrorRate = float64(bucket.Failed) / float64(bucket.Executed)
+		}
+		resp.Errors = append(resp.Errors, point)
+		resp.Summary.ExecutedQueries += bucket.Executed
+		resp.Summary.FailedQueries += bucket.Failed
+	}
+	if resp.Summary.ExecutedQueries > 0 {
+		resp.Summary.ErrorRate = float64(resp.Summary.FailedQueries) / float64(resp.Summary.ExecutedQueries)
+	}
+
+	queryTypes, err := s.analyticsRepo.GetQueryTypeCounts(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, count := range queryTypes {
+		resp.QueryTypes = append(resp.QueryTypes, dtos.AnalyticsCount{Name: count.Name, Count: count.Count})
+		resp.Summary.GeneratedQueries += count.Count
+	}
+
+	topTables, err := s.analyticsRepo.GetTopTables(ctx, filter, constants.AnalyticsTopItemsLimit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, count := range topTables {
+		resp.TopTables = append(resp.TopTables, dtos.AnalyticsCount{Name: count.Name, Count: count.Count})
+	}
+
+	llmModels, err := s.analyticsRepo.GetLLMModelCounts(ctx, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	for _, count := range llmModels {
+		resp.LLMModels = append(resp.LLMModels, dtos.AnalyticsLLMModelCount{
+			Model:       count.Name,
+			DisplayName: count.DisplayName,
+			Count:       count.Count,
+		})
+	}
+
+	if filter.ChatID != nil {
+		if resp.Summary.TotalMessages > 0 {
+			resp.Summary.ActiveChats = 1
+		}
+	} else {
+		activeChats, err := s.analyticsRepo.CountActiveChats(ctx, filter)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		resp.Summary.ActiveChats = activeChats
+	}
+
+	return resp, http.StatusOK, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/repositories"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKeyService issues, lists and revokes the long-lived API keys users query NeoBase with from scripts
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, userID, tokenType string, req *dtos.CreateAPIKeyRequest) (*dtos.CreateAPIKeyResponse, uint32, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]dtos.APIKeyResponse, uint32, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID string) (uint32, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	tokenRepo  repositories.TokenRepository
+	jwtService utils.JWTService
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, tokenRepo repositories.TokenRepository, jwtService utils.JWTService) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		tokenRepo:  tokenRepo,
+		jwtService: jwtService,
+	}
+}
+
+// CreateAPIKey issues a new API key. tokenType is the type of the token the request was authenticated with,
+// API keys cannot issue other API keys.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, userID, tokenType string, req *dtos.CreateAPIKeyRequest) (*dtos.CreateAPIKeyResponse, uint32, error) {
+	if tokenType == constants.TokenTypeAPIKey {
+		return nil, http.StatusForbidden, fmt.Errorf("API keys cannot be used to create API keys, please sign in")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("key_name is required")
+	}
+	if len(name) > constants.APIKeyMaxNameLength {
+		return nil, http.StatusBadRequest, fmt.Errorf("key_name must be at most %d characters", constants.APIKeyMaxNameLength)
+	}
+
+	activeKeys, err := s.apiKeyRepo.CountActiveByUserID(ctx, userObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if activeKeys >= constants.APIKeyMaxPerUser {
+		return nil, http.StatusBadRequest, fmt.Errorf("you cannot have more than %d active API keys, please revoke one first", constants.APIKeyMaxPerUser)
+	}
+
+	apiKey := models.NewAPIKey(userObjID, name, time.Now().Add(constants.APIKeyExpiration))
+	token, err := s.jwtService.GenerateAPIKey(userID, apiKey.ID.Hex(), constants.APIKeyExpiration)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate API key: %v", err)
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	log.Printf("APIKeyService -> CreateAPIKey -> Issued API key %s for user %s", apiKey.ID.Hex(), userID)
+	return &dtos.CreateAPIKeyResponse{
+		APIKeyResponse: buildAPIKeyResponse(apiKey),
+		Key:            *token,
+	}, http.StatusCreated, nil
+}
+
+// ListAPIKeys lists the API keys of a user, without the keys themselves
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, userID string) ([]dtos.APIKeyResponse, uint32, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	response := make([]dtos.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		response = append(response, buildAPIKeyResponse(key))
+	}
+	return response, http.StatusOK, nil
+}
+
+// RevokeAPIKey adds the key to the revocation list checked by the auth middleware, until the key expires
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, userID, keyID string) (uint32, error) {
+	keyObjID, err := primitive.ObjectIDFromHex(keyID)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid API key ID format")
+	}
+
+	apiKey, err := s.apiKeyRepo.FindByID(ctx, keyObjID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if apiKey == nil || apiKey.UserID.Hex() != userID {
+		return http.StatusNotFound, fmt.Errorf("API key not found")
+	}
+	if apiKey.RevokedAt != nil {
+		return http.StatusOK, nil
+	}
+
+	if remaining := time.Until(apiKey.ExpiresAt); remaining > 0 {
+		if err := s.tokenRepo.RevokeAPIKey(keyID, remaining); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
+	if err := s.apiKeyRepo.Revoke(ctx, keyObjID); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	log.Printf("APIKeyService -> RevokeAPIKey -> Revoked API key %s of user %s", keyID, userID)
+	return http.StatusOK, nil
+}
+
+func buildAPIKeyResponse(key *models.APIKey) dtos.APIKeyResponse {
+	response := dtos.APIKeyResponse{
+		ID:        key.ID.Hex(),
+		Name:      key.Name,
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: key.ExpiresAt.Format(time.RFC3339),
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := key.LastUsedAt.Format(time.RFC3339)
+		response.LastUsedAt = &lastUsedAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := key.RevokedAt.Format(time.RFC3339)
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
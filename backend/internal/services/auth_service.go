@@ -27,6 +27,7 @@ type AuthService interface {
 	SetChatService(chatService ChatService)
 	ForgotPassword(req *dtos.ForgotPasswordRequest) (*dtos.ForgotPasswordResponse, uint, error)
 	ResetPassword(req *dtos.ResetPasswordRequest) (uint, error)
+	UpdateUserQueryLimits(adminUserID, username string, req *dtos.UpdateUserQueryLimitsRequest) (*dtos.UserQueryLimitsResponse, uint, error)
 }
 
 type authService struct {
@@ -674,3 +675,32 @@ func (s *authService) GoogleOAuthSignup(req *dtos.GoogleOAuthRequest) (*dtos.Aut
 		User:         *authUser,
 	}, http.StatusOK, nil
 }
+
+// UpdateUserQueryLimits overrides the query result row cap of a user. Only the admin user may call it.
+// The new cap applies from the user's next database connection.
+func (s *authService) UpdateUserQueryLimits(adminUserID, username string, req *dtos.UpdateUserQueryLimitsRequest) (*dtos.UserQueryLimitsResponse, uint, error) {
+	if status, err := requireAdminUser(s.userRepo, adminUserID); err != nil {
+		return nil, uint(status), err
+	}
+
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil || user == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("user not found")
+	}
+
+	if err := s.userRepo.UpdateMaxQueryResultRows(user.ID.Hex(), req.MaxQueryResultRows); err != nil {
+		log.Printf("AuthService -> UpdateUserQueryLimits -> Failed to update user %s: %v", username, err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update query limits: %v", err)
+	}
+
+	response := &dtos.UserQueryLimitsResponse{
+		Username:           user.Username,
+		MaxQueryResultRows: config.Env.MaxQueryResultRows,
+	}
+	if req.MaxQueryResultRows != nil {
+		response.MaxQueryResultRows = *req.MaxQueryResultRows
+		response.IsOverride = true
+	}
+	log.Printf("AuthService -> UpdateUserQueryLimits -> User %s query result cap set to %d (override: %v)", username, response.MaxQueryResultRows, response.IsOverride)
+	return response, http.StatusOK, nil
+}
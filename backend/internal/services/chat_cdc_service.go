@@ -0,0 +1,351 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// cdcWebhookClient delivers CDC webhooks, only to public addresses and without following redirects
+var cdcWebhookClient = utils.NewPublicHTTPClient(constants.CDCWebhookTimeout)
+
+// StartCDCWatch checks the maximum of a timestamp column of each table every CheckIntervalSeconds, and posts to
+// the webhook when it increased since the previous check. The first check only records where the tables are.
+// The state of the watcher lives in Redis, so the limit of watchers per user holds across instances.
+func (s *chatService) StartCDCWatch(ctx context.Context, userID, chatID string, req *dtos.StartCDCWatchRequest) (*dtos.CDCWatchResponse, uint32, error) {
+	log.Printf("ChatService -> StartCDCWatch -> userID: %s, chatID: %s, tables: %v, interval: %ds", userID, chatID, req.Tables, req.CheckIntervalSeconds)
+
+	if req.CheckIntervalSeconds < constants.CDCMinIntervalSeconds || req.CheckIntervalSeconds > constants.CDCMaxIntervalSeconds {
+		return nil, http.StatusBadRequest, fmt.Errorf("checkIntervalSeconds must be between %d and %d", constants.CDCMinIntervalSeconds, constants.CDCMaxIntervalSeconds)
+	}
+	tables := utils.NormalizeColumnNames(req.Tables)
+	if len(tables) == 0 || len(tables) > constants.CDCMaxTables {
+		return nil, http.StatusBadRequest, fmt.Errorf("between 1 and %d tables can be watched", constants.CDCMaxTables)
+	}
+	webhookURL, err := url.Parse(strings.TrimSpace(req.WebhookURL))
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("webhookUrl must be an http or https URL")
+	}
+	// The webhook client re-checks every address it dials, this only reports a bad URL up front
+	if err := utils.ValidatePublicHost(ctx, webhookURL.Hostname()); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("webhookUrl must point to a public host: %v", err)
+	}
+	column := strings.TrimSpace(req.TimestampColumn)
+	if column == "" {
+		column = constants.CDCDefaultTimestampColumn
+	}
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	for _, table := range tables {
+		if dbmanager.IsHiddenTable(chat.Settings.HiddenTables, table) {
+			return nil, http.StatusBadRequest, fmt.Errorf("table %s is hidden in this chat, show it again to watch it", table)
+		}
+	}
+	if _, err := utils.BuildCDCCheckQuery(chat.Connection.Type, tables[0], column); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	// Every table must have the timestamp column, or the watcher would fail on every check
+	if schema, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID); err == nil && schema != nil {
+		for _, table := range tables {
+			tableSchema, ok := schema.Tables[table]
+			if !ok {
+				return nil, http.StatusNotFound, fmt.Errorf("table %s not found in the database schema", table)
+			}
+			if _, ok := tableSchema.Columns[column]; !ok {
+				return nil, http.StatusBadRequest, fmt.Errorf("table %s has no %s column", table, column)
+			}
+		}
+	} else {
+		log.Printf("ChatService -> StartCDCWatch -> No stored schema for chat %s, tables are checked on the first run: %v", chatID, err)
+	}
+
+	if s.redisRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("CDC watchers are not available")
+	}
+	running, err := s.redisRepo.ScanKeys(constants.GetCDCWatchKeyPattern(userID), ctx)
+	if err != nil {
+		log.Printf("ChatService -> StartCDCWatch -> Error counting watchers: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to start CDC watcher: %v", err)
+	}
+	if len(running) >= constants.CDCMaxWatchersPerUser {
+		return nil, http.StatusTooManyRequests, fmt.Errorf("at most %d CDC watchers can run at the same time, stop a watcher first", constants.CDCMaxWatchersPerUser)
+	}
+
+	state := &dtos.CDCWatchState{
+		Watch: dtos.CDCWatchResponse{
+			WatchID:              primitive.NewObjectID().Hex(),
+			ChatID:               chatID,
+			Tables:               tables,
+			TimestampColumn:      column,
+			CheckIntervalSeconds: req.CheckIntervalSeconds,
+			WebhookURL:           webhookURL.String(),
+			StartedAt:            time.Now(),
+		},
+		PreviousMax:  make(map[string]string, len(tables)),
+		PreviousRows: make(map[string]int64, len(tables)),
+	}
+	if err := s.saveCDCWatchState(ctx, userID, state); err != nil {
+		log.Printf("ChatService -> StartCDCWatch -> Error saving watcher: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to start CDC watcher: %v", err)
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	s.watchersMu.Lock()
+	s.cdcWatchers[state.Watch.WatchID] = cancel
+	s.watchersMu.Unlock()
+
+	go s.runCDCWatch(watchCtx, userID, state)
+
+	log.Printf("ChatService -> StartCDCWatch -> Started CDC watcher %s", state.Watch.WatchID)
+	return &state.Watch, http.StatusOK, nil
+}
+
+// StopCDCWatch removes a CDC watcher of the user. A watcher running on another instance stops at its next check,
+// when it finds its state gone.
+func (s *chatService) StopCDCWatch(ctx context.Context, userID, chatID, watchID string) (uint32, error) {
+	if s.redisRepo == nil {
+		return http.StatusServiceUnavailable, fmt.Errorf("CDC watchers are not available")
+	}
+
+	state, err := s.loadCDCWatchState(ctx, userID, watchID)
+	if err != nil || state.Watch.ChatID != chatID {
+		return http.StatusNotFound, fmt.Errorf("CDC watcher not found")
+	}
+	if err := s.redisRepo.Del(constants.GetCDCWatchKey(userID, watchID), ctx); err != nil {
+		log.Printf("ChatService -> StopCDCWatch -> Error deleting watcher: %v", err)
+		return http.StatusInternalServerError, fmt.Errorf("failed to stop CDC watcher: %v", err)
+	}
+
+	s.watchersMu.Lock()
+	if cancel, ok := s.cdcWatchers[watchID]; ok {
+		cancel()
+	}
+	s.watchersMu.Unlock()
+
+	log.Printf("ChatService -> StopCDCWatch -> Stopped CDC watcher %s", watchID)
+	return http.StatusOK, nil
+}
+
+// runCDCWatch checks the tables of a watcher until it is stopped
+func (s *chatService) runCDCWatch(ctx context.Context, userID string, state *dtos.CDCWatchState) {
+	watchID := state.Watch.WatchID
+	defer func() {
+		s.watchersMu.Lock()
+		if cancel, ok := s.cdcWatchers[watchID]; ok {
+			cancel()
+			delete(s.cdcWatchers, watchID)
+		}
+		s.watchersMu.Unlock()
+		log.Printf("ChatService -> runCDCWatch -> CDC watcher %s ended", watchID)
+	}()
+
+	interval := time.Duration(state.Watch.CheckIntervalSeconds) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// The state is read again before every check, it is gone once the watcher was stopped anywhere
+		current, err := s.loadCDCWatchState(ctx, userID, watchID)
+		if err != nil {
+			return
+		}
+		state = current
+
+		for _, table := range state.Watch.Tables {
+			if ctx.Err() != nil {
+				return
+			}
+			s.checkCDCTable(ctx, userID, state, table, interval)
+		}
+		state.CheckedAt = time.Now()
+		if err := s.saveCDCWatchState(ctx, userID, state); err != nil {
+			log.Printf("ChatService -> runCDCWatch -> Error saving state of watcher %s: %v", watchID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkCDCTable reads the maximum timestamp and row count of a table, and calls the webhook when the maximum
+// increased since the previous check
+func (s *chatService) checkCDCTable(ctx context.Context, userID string, state *dtos.CDCWatchState, table string, timeout time.Duration) {
+	watch := state.Watch
+	if !s.dbManager.IsConnected(watch.ChatID) {
+		if _, err := s.ConnectDB(ctx, userID, watch.ChatID, fmt.Sprintf("cdc-%s", watch.WatchID)); err != nil {
+			log.Printf("ChatService -> checkCDCTable -> Error connecting for watcher %s: %v", watch.WatchID, err)
+			return
+		}
+	}
+	connInfo, exists := s.dbManager.GetConnectionInfo(watch.ChatID)
+	if !exists {
+		return
+	}
+	query, err := utils.BuildCDCCheckQuery(connInfo.Config.Type, table, watch.TimestampColumn)
+	if err != nil {
+		log.Printf("ChatService -> checkCDCTable -> %v", err)
+		return
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// A stream ID of its own keeps the check from clobbering the execution tracking of the user's stream
+	streamID := fmt.Sprintf("cdc-%s-%d", watch.WatchID, time.Now().UnixNano())
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, watch.ChatID, "", "", streamID, query, "SELECT", false, false)
+	if queryErr != nil {
+		log.Printf("ChatService -> checkCDCTable -> Error checking %s for watcher %s: %+v", table, watch.WatchID, queryErr)
+		return
+	}
+	if result == nil {
+		return
+	}
+	rows := extractResultRows(result.Result)
+	if len(rows) == 0 {
+		return
+	}
+
+	currentMax := cdcValueString(columnStatsValue(rows[0], "current_max"))
+	currentRows := columnStatsInt(columnStatsValue(rows[0], "row_count"))
+	previousMax, seen := state.PreviousMax[table]
+	previousRows := state.PreviousRows[table]
+	state.PreviousMax[table] = currentMax
+	state.PreviousRows[table] = currentRows
+
+	if !seen || !cdcValueIncreased(previousMax, currentMax) {
+		return
+	}
+
+	estimatedNewRows := currentRows - previousRows
+	if estimatedNewRows < 0 {
+		estimatedNewRows = 0
+	}
+	s.fireCDCWebhook(ctx, &watch, dtos.CDCWebhookPayload{
+		WatchID:          watch.WatchID,
+		ChatID:           watch.ChatID,
+		Table:            table,
+		PreviousMax:      previousMax,
+		CurrentMax:       currentMax,
+		EstimatedNewRows: estimatedNewRows,
+		DetectedAt:       time.Now(),
+	})
+}
+
+// fireCDCWebhook posts a change to the webhook of the watcher. Failed deliveries are logged, the next change
+// is delivered as usual.
+func (s *chatService) fireCDCWebhook(ctx context.Context, watch *dtos.CDCWatchResponse, payload dtos.CDCWebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("ChatService -> fireCDCWebhook -> Error encoding payload: %v", err)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, watch.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("ChatService -> fireCDCWebhook -> Error creating request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", constants.CDCWebhookUserAgent)
+
+	resp, err := cdcWebhookClient.Do(req)
+	if err != nil {
+		log.Printf("ChatService -> fireCDCWebhook -> Error delivering change of %s for watcher %s: %v", payload.Table, watch.WatchID, err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("ChatService -> fireCDCWebhook -> Webhook of watcher %s answered %d", watch.WatchID, resp.StatusCode)
+		return
+	}
+	log.Printf("ChatService -> fireCDCWebhook -> Delivered change of %s for watcher %s", payload.Table, watch.WatchID)
+}
+
+// saveCDCWatchState stores the state of a watcher, extending its expiry
+func (s *chatService) saveCDCWatchState(ctx context.Context, userID string, state *dtos.CDCWatchState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.redisRepo.Set(constants.GetCDCWatchKey(userID, state.Watch.WatchID), data, constants.GetCDCWatchTTL(state.Watch.CheckIntervalSeconds), ctx)
+}
+
+// loadCDCWatchState reads the state of a watcher, an error means it was stopped or expired
+func (s *chatService) loadCDCWatchState(ctx context.Context, userID, watchID string) (*dtos.CDCWatchState, error) {
+	data, err := s.redisRepo.Get(constants.GetCDCWatchKey(userID, watchID), ctx)
+	if err != nil || data == "" {
+		return nil, fmt.Errorf("CDC watcher not found")
+	}
+	var state dtos.CDCWatchState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, fmt.Errorf("failed to read CDC watcher: %v", err)
+	}
+	if state.PreviousMax == nil {
+		state.PreviousMax = make(map[string]string)
+	}
+	if state.PreviousRows == nil {
+		state.PreviousRows = make(map[string]int64)
+	}
+	return &state, nil
+}
+
+// cdcValueString formats a maximum read from the database, times as RFC 3339 so they compare in order
+func cdcValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// cdcTimeLayouts are the formats timestamps come back in from the drivers
+var cdcTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05.999999999", "2006-01-02"}
+
+// cdcValueIncreased reports whether the current maximum is later than the previous one. Values are compared as
+// times, then as numbers (Unix epochs), then as strings.
+func cdcValueIncreased(previous, current string) bool {
+	if current == "" || current == previous {
+		return false
+	}
+	if previous == "" {
+		return true
+	}
+	for _, layout := range cdcTimeLayouts {
+		previousTime, errPrevious := time.Parse(layout, previous)
+		currentTime, errCurrent := time.Parse(layout, current)
+		if errPrevious == nil && errCurrent == nil {
+			return currentTime.After(previousTime)
+		}
+	}
+	previousNumber, errPrevious := strconv.ParseFloat(previous, 64)
+	currentNumber, errCurrent := strconv.ParseFloat(current, 64)
+	if errPrevious == nil && errCurrent == nil {
+		return currentNumber > previousNumber
+	}
+	return current > previous
+}
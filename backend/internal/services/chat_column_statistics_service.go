@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GetColumnStatistics computes the distribution of a column's values with queries built from templates,
+// without an LLM round-trip. Like table previews, the table and column must exist in the chat's schema.
+func (s *chatService) GetColumnStatistics(ctx context.Context, userID, chatID, tableName, columnName string) (*dtos.ColumnStatistics, uint32, error) {
+	log.Printf("ChatService -> GetColumnStatistics -> userID: %s, chatID: %s, table: %s, column: %s", userID, chatID, tableName, columnName)
+
+	if _, status, err := s.findOwnedChat(userID, chatID); err != nil {
+		return nil, status, err
+	}
+
+	tableName = strings.TrimSpace(tableName)
+	columnName = strings.TrimSpace(columnName)
+	if tableName == "" || columnName == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("table and column names are required")
+	}
+
+	// Make sure we have a live connection
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		log.Printf("ChatService -> GetColumnStatistics -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("column-stats-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+		connInfo, exists = s.dbManager.GetConnectionInfo(chatID)
+		if !exists {
+			return nil, http.StatusInternalServerError, fmt.Errorf("connection created but not found in manager")
+		}
+	}
+	dbType := connInfo.Config.Type
+
+	dbConn, err := s.dbManager.GetConnection(chatID)
+	if err != nil {
+		log.Printf("ChatService -> GetColumnStatistics -> Error getting connection: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get database connection: %v", err)
+	}
+
+	schema, err := s.dbManager.GetSchemaManager().GetSchema(ctx, chatID, dbConn, dbType, []string{})
+	if err != nil {
+		log.Printf("ChatService -> GetColumnStatistics -> Error getting schema: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get schema: %v", err)
+	}
+
+	table, ok := schema.Tables[tableName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("table %s not found in the database schema", tableName)
+	}
+	column, ok := table.Columns[columnName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("column %s not found in table %s", columnName, tableName)
+	}
+
+	kind := utils.ColumnStatsKind(column.Type)
+	queries, err := utils.BuildColumnStatsQueries(dbType, tableName, columnName, kind)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	startTime := time.Now()
+	stats := &dtos.ColumnStatistics{
+		TableName:  tableName,
+		ColumnName: columnName,
+		Type:       kind,
+		DataType:   column.Type,
+	}
+
+	summary, err := s.runColumnStatsQuery(ctx, chatID, "summary", queries.Summary)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if len(summary) > 0 {
+		row := summary[0]
+		stats.TotalCount = columnStatsInt(columnStatsValue(row, "total_count"))
+		stats.NullCount = columnStatsInt(columnStatsValue(row, "null_count"))
+		stats.DistinctCount = columnStatsInt(columnStatsValue(row, "distinct_count"))
+		stats.Min = columnStatsValue(row, "min_value")
+		stats.Max = columnStatsValue(row, "max_value")
+		stats.Avg = columnStatsFloat(columnStatsValue(row, "avg_value"))
+		stats.StdDev = columnStatsFloat(columnStatsValue(row, "stddev_value"))
+		for _, name := range []string{"p25", "p50", "p75", "p95"} {
+			if value := columnStatsValue(row, name); value != nil {
+				if stats.Percentiles == nil {
+					stats.Percentiles = make(map[string]interface{})
+				}
+				stats.Percentiles[name] = value
+			}
+		}
+	}
+
+	if queries.DistinctCount != "" {
+		rows, err := s.runColumnStatsQuery(ctx, chatID, "distinct count", queries.DistinctCount)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if len(rows) > 0 {
+			stats.DistinctCount = columnStatsInt(columnStatsValue(rows[0], "distinct_count"))
+		}
+	}
+
+	if queries.TopValues != "" {
+		rows, err := s.runColumnStatsQuery(ctx, chatID, "top values", queries.TopValues)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		stats.TopValues = make([]dtos.ColumnValueCount, 0, len(rows))
+		for _, row := range rows {
+			stats.TopValues = append(stats.TopValues, dtos.ColumnValueCount{
+				Value: columnStatsValue(row, "stat_value"),
+				Count: columnStatsInt(columnStatsValue(row, "stat_count")),
+			})
+		}
+	}
+
+	if queries.Distribution != "" {
+		rows, err := s.runColumnStatsQuery(ctx, chatID, "monthly distribution", queries.Distribution)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		// The query returns the most recent months first
+		stats.MonthlyCounts = make([]dtos.ColumnMonthCount, len(rows))
+		for i, row := range rows {
+			stats.MonthlyCounts[len(rows)-1-i] = dtos.ColumnMonthCount{
+				Month: columnStatsMonth(columnStatsValue(row, "stat_month")),
+				Count: columnStatsInt(columnStatsValue(row, "stat_count")),
+			}
+		}
+	}
+
+	stats.ExecutionTimeMs = time.Since(startTime).Milliseconds()
+	return stats, http.StatusOK, nil
+}
+
+func (s *chatService) runColumnStatsQuery(ctx context.Context, chatID, name, query string) ([]map[string]interface{}, error) {
+	log.Printf("ChatService -> GetColumnStatistics -> %s query: %s", name, query)
+
+	queryCtx, cancel := context.WithTimeout(ctx, constants.ColumnStatsTimeoutSeconds*time.Second)
+	defer cancel()
+
+	streamID := fmt.Sprintf("column-stats-%s-%d", chatID, time.Now().UnixNano())
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, chatID, "", "", streamID, query, "SELECT", false, false)
+	if queryErr != nil {
+		log.Printf("ChatService -> GetColumnStatistics -> Error executing %s query: %+v", name, queryErr)
+		errorMsg := queryErr.Message
+		if queryErr.Details != "" {
+			errorMsg = fmt.Sprintf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return nil, fmt.Errorf("failed to compute the column's %s: %s", name, errorMsg)
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return extractResultRows(result.Result), nil
+}
+
+// columnStatsValue reads a result column, ignoring case since Oracle returns upper case names
+func columnStatsValue(row map[string]interface{}, name string) interface{} {
+	if value, ok := row[name]; ok {
+		return value
+	}
+	for key, value := range row {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return nil
+}
+
+func columnStatsInt(value interface{}) int64 {
+	number, ok := dataQualityFloat(value)
+	if !ok {
+		return 0
+	}
+	return int64(number)
+}
+
+// columnStatsFloat returns nil for NULL and for values that aren't finite numbers
+func columnStatsFloat(value interface{}) *float64 {
+	number, ok := dataQualityFloat(value)
+	if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+		return nil
+	}
+	return &number
+}
+
+// columnStatsMonth formats a month bucket, returned as a date, timestamp or YYYY-MM string, as YYYY-MM
+func columnStatsMonth(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format("2006-01")
+	}
+	month := strings.TrimSpace(fmt.Sprintf("%v", value))
+	if len(month) >= 7 && month[4] == '-' {
+		return month[:7]
+	}
+	return month
+}
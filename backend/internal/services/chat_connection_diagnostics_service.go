@@ -0,0 +1,77 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+)
+
+// DiagnoseConnection tests the connection of a request like Create does and explains, step by step, why it fails.
+// Nothing is stored and no connection is kept open.
+func (s *chatService) DiagnoseConnection(userID string, req *dtos.CreateConnectionRequest) (*dtos.ConnectionDiagnostic, uint32, error) {
+	log.Printf("ChatService -> DiagnoseConnection -> userID: %s, type: %s", userID, req.Type)
+
+	if !isValidDBType(req.Type) {
+		return nil, http.StatusBadRequest, fmt.Errorf("Unsupported data source type: %s", req.Type)
+	}
+	if req.Type == constants.DatabaseTypeSpreadsheet || req.Type == constants.DatabaseTypeGoogleSheets {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s connections have no server to diagnose", req.Type)
+	}
+
+	if err := applyConnectionURI(req); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	applyAirtableDefaults(req)
+	applyTemporalDefaults(req)
+	applyFirestoreDefaults(req)
+	applyCloudflareD1Defaults(req)
+	applyNeonDetection(req)
+	applyYugabyteDBAPIType(req)
+	if status, err := s.resolveVaultCredentials(userID, req); err != nil {
+		return nil, status, err
+	}
+
+	port := req.Port
+	if port == nil || *port == "" {
+		defaultPort := defaultPortForDBType(req.Type)
+		port = &defaultPort
+	}
+
+	diagnostic := s.dbManager.DiagnoseConnection(&dbmanager.ConnectionConfig{
+		Type:                     req.Type,
+		Host:                     req.Host,
+		Port:                     port,
+		Username:                 &req.Username,
+		Password:                 req.Password,
+		Database:                 req.Database,
+		AuthDatabase:             req.AuthDatabase,
+		SSLMode:                  req.SSLMode,
+		UseSSL:                   req.UseSSL,
+		SSLCertURL:               req.SSLCertURL,
+		SSLKeyURL:                req.SSLKeyURL,
+		SSLRootCertURL:           req.SSLRootCertURL,
+		Catalog:                  req.Catalog,
+		Schema:                   req.Schema,
+		ServiceName:              req.ServiceName,
+		AirtableAPIKey:           req.AirtableAPIKey,
+		AirtableBaseID:           req.AirtableBaseID,
+		PlanetscaleBranch:        req.PlanetscaleBranch,
+		InfluxOrg:                req.InfluxOrg,
+		InfluxToken:              req.InfluxToken,
+		ReadPreference:           req.ReadPreference,
+		TemporalNamespace:        req.TemporalNamespace,
+		TemporalAddress:          req.TemporalAddress,
+		NATSCredentialsFile:      req.NATSCredentialsFile,
+		GoogleProjectID:          req.GoogleProjectID,
+		FirestoreCredentialsJSON: req.FirestoreCredentialsJSON,
+		CloudflareAccountID:      req.CloudflareAccountID,
+		CloudflareD1DatabaseID:   req.CloudflareD1DatabaseID,
+		CloudflareAPIToken:       req.CloudflareAPIToken,
+	})
+
+	log.Printf("ChatService -> DiagnoseConnection -> %s connection to %s, failed check: %q", req.Type, req.Host, diagnostic.FailedCheck)
+	return diagnostic, http.StatusOK, nil
+}
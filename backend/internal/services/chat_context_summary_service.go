@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/llm"
+)
+
+// summarizeLLMContext replaces the oldest half of the chat's LLM context with a summary once the context passes
+// ContextSummarizeThreshold of the model's token limit. The summary is cached per set of messages, so the
+// following requests reuse it until the summarized messages change, and every fresh summary is counted on the
+// chat. Any failure returns the messages unchanged, the pruner still keeps them within the limit.
+func (s *chatService) summarizeLLMContext(ctx context.Context, chat *models.Chat, messages []*models.LLMMessage, contextModelID string, llmClient llm.Client) []*models.LLMMessage {
+	summaryClient := llmClient
+	summaryModelID := contextModelID
+	if summaryModel := constants.GetContextSummaryModel(); summaryModel != nil && s.llmManager != nil {
+		if providerClient, err := s.llmManager.GetClient(summaryModel.Provider); err == nil {
+			summaryClient = providerClient
+			summaryModelID = summaryModel.ID
+		}
+	}
+
+	summarizer := llm.NewConversationSummarizer(contextModelID, summaryClient, summaryModelID)
+	summarized := summarizer.SelectMessages(messages)
+	if len(summarized) == 0 {
+		return messages
+	}
+
+	cacheKey := constants.GetContextSummaryKey(chat.ID.Hex(), hashLLMMessages(summarized))
+	if cached, err := s.redisRepo.Get(cacheKey, ctx); err == nil && cached != "" {
+		var summary models.LLMMessage
+		if err := json.Unmarshal([]byte(cached), &summary); err == nil {
+			log.Printf("ChatService -> summarizeLLMContext -> Using cached summary of %d messages for chat %s", len(summarized), chat.ID.Hex())
+			return llm.ReplaceWithSummary(messages, summarized, &summary)
+		}
+	}
+
+	summary, err := summarizer.Summarize(ctx, summarized)
+	if err != nil {
+		log.Printf("ChatService -> summarizeLLMContext -> Failed to summarize chat %s: %v", chat.ID.Hex(), err)
+		return messages
+	}
+	summary.ChatID = chat.ID
+	summary.UserID = chat.UserID
+
+	if data, err := json.Marshal(summary); err == nil {
+		if err := s.redisRepo.Set(cacheKey, data, constants.ContextSummaryTTL, ctx); err != nil {
+			log.Printf("ChatService -> summarizeLLMContext -> Failed to cache summary: %v", err)
+		}
+	}
+	if err := s.chatRepo.IncrementSummarizationCount(ctx, chat.ID); err != nil {
+		log.Printf("ChatService -> summarizeLLMContext -> Failed to count summarization: %v", err)
+	}
+
+	log.Printf("ChatService -> summarizeLLMContext -> Summarized %d messages for chat %s", len(summarized), chat.ID.Hex())
+	return llm.ReplaceWithSummary(messages, summarized, summary)
+}
+
+// hashLLMMessages identifies a set of messages by their IDs and last update, so an edit invalidates its summary
+func hashLLMMessages(messages []*models.LLMMessage) string {
+	hash := sha256.New()
+	for _, msg := range messages {
+		hash.Write([]byte(msg.MessageID.Hex()))
+		hash.Write([]byte(msg.UpdatedAt.UTC().String()))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
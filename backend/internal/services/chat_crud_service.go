@@ -12,7 +12,10 @@ import (
 	"neobase-ai/internal/utils"
 	"neobase-ai/pkg/dbmanager"
 	"neobase-ai/pkg/llm"
+	"neobase-ai/pkg/pubsub"
 	"neobase-ai/pkg/redis"
+	"neobase-ai/pkg/vault"
+	"net"
 	"net/http"
 	"sort"
 	"strconv"
@@ -38,22 +41,38 @@ type ChatService interface {
 	CreateWithoutConnectionPing(userID string, req *dtos.CreateChatRequest) (*dtos.ChatResponse, uint32, error)
 	Update(userID, chatID string, req *dtos.UpdateChatRequest) (*dtos.ChatResponse, uint32, error)
 	Delete(userID, chatID string) (uint32, error)
+	ReleaseChatResources(chat *models.Chat)
 	GetByID(userID, chatID string) (*dtos.ChatResponse, uint32, error)
 	List(userID string, page, pageSize int) (*dtos.ChatListResponse, uint32, error)
 	CreateMessage(ctx context.Context, userID, chatID string, streamID string, content string, llmModel string) (*dtos.MessageResponse, uint16, error)
+	CreateThreadMessage(ctx context.Context, userID, chatID, messageID string, streamID string, content string, llmModel string) (*dtos.MessageResponse, uint16, error)
 	UpdateMessage(ctx context.Context, userID, chatID, messageID string, streamID string, req *dtos.CreateMessageRequest) (*dtos.MessageResponse, uint32, error)
-	DeleteMessages(userID, chatID string) (uint32, error)
-	Duplicate(userID, chatID string, duplicateMessages bool, duplicateDashboards bool) (*dtos.ChatResponse, uint32, error)
-	ListMessages(userID, chatID string, page, pageSize int) (*dtos.MessageListResponse, uint32, error)
+	RegenerateMessage(ctx context.Context, userID, chatID, messageID string, streamID string) (*dtos.MessageResponse, uint32, error)
+	DeleteAllMessages(userID, chatID string) (uint32, error)
+	DeleteMessagesByFilter(userID, chatID string, req *dtos.DeleteMessagesRequest) (*dtos.DeleteMessagesResponse, uint32, error)
+	GenerateTitle(ctx context.Context, userID, chatID string) (*dtos.ChatResponse, uint32, error)
+	Duplicate(userID, chatID string, duplicateMessages bool, duplicateDashboards bool, newConnectionConfig *dbmanager.ConnectionConfig) (*dtos.ChatResponse, uint32, error)
+	GetSourceChatConnectionConfig(userID, sourceChatID string) (*dbmanager.ConnectionConfig, uint32, error)
+	ExportChat(ctx context.Context, userID, chatID string, includeCredentials bool) (*dtos.ChatExport, uint32, error)
+	ImportChat(ctx context.Context, userID string, export *dtos.ChatExport) (*dtos.ChatImportResponse, uint32, error)
+	ListChatTemplates() ([]models.ChatTemplate, uint32, error)
+	CreateChatFromTemplate(userID, templateID string, req *dtos.CreateChatFromTemplateRequest) (*dtos.ChatFromTemplateResponse, uint32, error)
+	ListMessages(userID, chatID string, page, pageSize int, threadID string) (*dtos.MessageListResponse, uint32, error)
 	PinMessage(userID, chatID, messageID string) (interface{}, uint32, error)
 	UnpinMessage(userID, chatID, messageID string) (interface{}, uint32, error)
 	ListPinnedMessages(userID, chatID string) (*dtos.MessageListResponse, uint32, error)
+	GetQueryHistory(ctx context.Context, userID, chatID string, req *dtos.QueryHistoryRequest) (*dtos.QueryHistoryResponse, uint32, error)
+	FindSimilarQueries(ctx context.Context, userID, chatID string, req *dtos.SimilarQueryRequest) (*dtos.SimilarQueryResponse, uint32, error)
+	ExplainQueryInPlainEnglish(ctx context.Context, userID, chatID string, req *dtos.ExplainQueryRequest) (*dtos.QueryExplanationResponse, uint32, error)
 	EditQuery(ctx context.Context, userID, chatID, messageID, queryID string, query string) (*dtos.EditQueryResponse, uint32, error)
 	GetDBConnectionStatus(ctx context.Context, userID, chatID string) (*dtos.ConnectionStatusResponse, uint32, error)
+	CheckDBHealth(userID, chatID string) (*dbmanager.HealthCheckResult, uint32, error)
+	DiagnoseConnection(userID string, req *dtos.CreateConnectionRequest) (*dtos.ConnectionDiagnostic, uint32, error)
 	HandleSchemaChange(userID, chatID, streamID string, diff interface{})
 	HandleDBEvent(userID, chatID, streamID string, response dtos.StreamResponse)
 	GetAllTables(ctx context.Context, userID, chatID string) (*dtos.TablesResponse, uint32, error)
 	GetSelectedCollections(chatID string) (string, error)
+	GetExternalSchema(ctx context.Context, chatID string) (*dbmanager.ExternalSchema, error)
 
 	// Execution operations
 	CancelProcessing(userID, chatID, streamID string)
@@ -66,18 +85,58 @@ type ChatService interface {
 	processLLMResponseAndRunQuery(ctx context.Context, userID, chatID string, messageID, streamID string) error
 
 	// Spreadsheet operations
-	StoreSpreadsheetData(userID, chatID, tableName string, columns []string, data [][]string, mergeStrategy string, mergeOptions MergeOptions) (*dtos.SpreadsheetUploadResponse, uint32, error)
-	ProcessAndStoreSpreadsheetUnified(userID, chatID, tableName string, data [][]interface{}, mergeStrategy string, mergeOptions MergeOptions) (*dtos.SpreadsheetUploadResponse, uint32, error)
+	StoreSpreadsheetData(userID, chatID, streamID, tableName string, columns []string, data [][]string, mergeStrategy string, mergeOptions MergeOptions, columnTypes map[string]string) (*dtos.SpreadsheetUploadResponse, uint32, error)
+	ProcessAndStoreSpreadsheetUnified(userID, chatID, streamID, tableName string, data [][]interface{}, mergeStrategy string, mergeOptions MergeOptions, columnTypes map[string]string) (*dtos.SpreadsheetUploadResponse, uint32, error)
+	StartSpreadsheetImportJob(userID, chatID, streamID, tableName string, data [][]interface{}, mergeStrategy string, mergeOptions MergeOptions, columnTypes map[string]string) (*dtos.ImportJobResponse, uint32, error)
+	GetImportStatus(ctx context.Context, userID, chatID string) (*dtos.ImportStatus, uint32, error)
 	GetSpreadsheetTableData(userID, chatID, tableName string, page, pageSize int) (*dtos.SpreadsheetTableDataResponse, uint32, error)
 	DeleteSpreadsheetTable(userID, chatID, tableName string) (uint32, error)
 	DeleteSpreadsheetRow(userID, chatID, tableName string, rowID string) (uint32, error)
 	DownloadSpreadsheetTableData(userID, chatID, tableName string) (*dtos.SpreadsheetDownloadResponse, uint32, error)
 	DownloadSpreadsheetTableDataWithFilter(userID, chatID, tableName string, rowIDs []string) (*dtos.SpreadsheetDownloadResponse, uint32, error)
+	UpdateSpreadsheetColumnType(ctx context.Context, userID, chatID, tableName, columnName, newType string) (*dtos.ColumnTypeUpdateResponse, uint32, error)
+	SyncGoogleSheet(ctx context.Context, userID, chatID, streamID string) (*dtos.GoogleSheetsSyncResponse, uint32, error)
+	ListPlanetscaleBranches(ctx context.Context, userID, chatID string) (*dtos.PlanetscaleBranchesResponse, uint32, error)
+	ReResolveVaultCredentials(ctx context.Context, userID, chatID string) (*dtos.ChatResponse, uint32, error)
+	UpdateHiddenTables(ctx context.Context, userID, chatID string, req *dtos.UpdateHiddenTablesRequest) (*dtos.ChatResponse, uint32, error)
+	UpdateSystemPrompt(ctx context.Context, userID, chatID string, req *dtos.UpdateSystemPromptRequest) (*dtos.ChatResponse, uint32, error)
+	UpdateSchemaAliases(ctx context.Context, userID, chatID string, req *dtos.SchemaAliases) (*dtos.ChatResponse, uint32, error)
 
 	RefreshSchema(ctx context.Context, userID, chatID string, sync bool) (uint32, error)
-	GetQueryResults(ctx context.Context, userID, chatID, messageID, queryID, streamID string, offset int, cursor *string) (*dtos.QueryResultsResponse, uint32, error)
-	GetQueryRecommendations(ctx context.Context, userID, chatID string, streamID string) (*dtos.QueryRecommendationsResponse, uint32, error)
+	GetQueryResults(ctx context.Context, userID, chatID, messageID, queryID, streamID string, offset int, cursor *string, lastKey interface{}) (*dtos.QueryResultsResponse, uint32, error)
+	GetQueryRecommendations(ctx context.Context, userID, chatID string, streamID string, location *time.Location) (*dtos.QueryRecommendationsResponse, uint32, error)
 	GetImportMetadata(ctx context.Context, userID, chatID string) (*dtos.ImportMetadata, uint32, error)
+	ComparePlans(ctx context.Context, userID, chatID string, req *dtos.ComparePlansRequest) (*dtos.ComparePlansResponse, uint32, error)
+	CreateSecureNote(ctx context.Context, userID, chatID string, req *dtos.CreateSecureNoteRequest) (*dtos.SecureNoteResponse, uint32, error)
+	ListSecureNotes(ctx context.Context, userID, chatID string) ([]dtos.SecureNoteResponse, uint32, error)
+	CreateQueryTemplate(ctx context.Context, userID, chatID string, req *dtos.CreateQueryTemplateRequest) (*dtos.QueryTemplateResponse, uint32, error)
+	ListQueryTemplates(ctx context.Context, userID, chatID string) ([]dtos.QueryTemplateResponse, uint32, error)
+	UploadPrismaSchema(ctx context.Context, userID, chatID, fileName string, content []byte) (*dtos.ExternalSchemaSourceResponse, uint32, error)
+	UploadOpenAPISpec(ctx context.Context, userID, chatID, fileName string, content []byte) (*dtos.ExternalSchemaSourceResponse, uint32, error)
+	ExecuteQueryTemplate(ctx context.Context, userID, chatID, templateID string, req *dtos.ExecuteQueryTemplateRequest) (*dtos.QueryTemplateExecutionResponse, uint32, error)
+	StartQueryWatch(userID, chatID string, req *dtos.StartQueryWatchRequest) (*dtos.QueryWatchResponse, uint32, error)
+	StopQueryWatch(userID, chatID, watchID string) (uint32, error)
+	StartCDCWatch(ctx context.Context, userID, chatID string, req *dtos.StartCDCWatchRequest) (*dtos.CDCWatchResponse, uint32, error)
+	StopCDCWatch(ctx context.Context, userID, chatID, watchID string) (uint32, error)
+	GetTablePreview(ctx context.Context, userID, chatID, tableName string, limit int) (*dtos.TablePreviewResponse, uint32, error)
+	GetColumnStatistics(ctx context.Context, userID, chatID, tableName, columnName string) (*dtos.ColumnStatistics, uint32, error)
+	LiveCount(ctx context.Context, userID, chatID string, req *dtos.LiveCountRequest) (*dtos.LiveCountResponse, uint32, error)
+	GetQueryDependencyGraph(ctx context.Context, userID, chatID, messageID, queryID string) (*dtos.DependencyGraph, uint32, error)
+	GenerateDataQualityReport(ctx context.Context, userID, chatID string, req *dtos.DataQualityRequest) (*dtos.DataQualityReport, uint32, error)
+	GenerateDataMigration(ctx context.Context, userID, chatID string, req *dtos.DataMigrationRequest) (*dtos.DataMigrationResponse, uint32, error)
+	GenerateMockData(ctx context.Context, userID, chatID string, req *dtos.MockDataRequest, force bool) (*dtos.MockDataResponse, uint32, error)
+	SubmitMessageFeedback(ctx context.Context, userID, chatID, messageID string, req *dtos.MessageFeedbackRequest) (*dtos.MessageFeedbackResponse, uint32, error)
+	AddMessageReaction(ctx context.Context, userID, chatID, messageID string, req *dtos.MessageReactionRequest) (*dtos.MessageReactionResponse, uint32, error)
+	RemoveMessageReaction(ctx context.Context, userID, chatID, messageID, emoji string) (*dtos.MessageReactionResponse, uint32, error)
+	ExecuteFederatedQuery(ctx context.Context, userID, chatID string, req *dtos.FederatedExecuteRequest) (*dtos.FederatedExecuteResponse, uint32, error)
+	MagicQuery(ctx context.Context, userID string, req *dtos.MagicQueryRequest) (*dtos.MagicQueryResponse, uint32, error)
+	RunLLMBenchmark(ctx context.Context, userID string, req *dtos.BenchmarkRequest) (*dtos.BenchmarkResult, uint32, error)
+	GenerateReport(ctx context.Context, userID, chatID string, req *dtos.GenerateReportRequest) (*dtos.ReportResponse, uint32, error)
+	ListReports(ctx context.Context, userID, chatID string) ([]dtos.ReportResponse, uint32, error)
+	GetReport(ctx context.Context, userID, chatID, reportID string) (*dtos.ReportResponse, uint32, error)
+	GetReportPDF(ctx context.Context, userID, chatID, reportID string) (*dtos.ReportPDF, uint32, error)
+	GetRollbackHistory(ctx context.Context, userID, chatID string, req *dtos.RollbackHistoryRequest) (*dtos.RollbackHistoryResponse, uint32, error)
+	SubscribeChatEvents(ctx context.Context, userID, chatID string) (pubsub.Subscription, uint32, error)
 
 	// Visualization operations
 	GenerateVisualizationForQueryResults(ctx context.Context, userID, chatID string, chat *models.Chat, selectedLLMModel, userQuestion string, executedQueries []interface{}, queryResults []map[string]interface{}, isExplicitRequest bool) (*dtos.VisualizationResponse, error)
@@ -86,6 +145,8 @@ type ChatService interface {
 	GetVisualizationForQuery(ctx context.Context, queryID string) (*dtos.VisualizationResponse, error)                           // Get visualization by query ID (per-query visualization)
 	GetVisualizationData(ctx context.Context, userID, chatID, messageID, queryID string, limit, offset int) (interface{}, error) // Lazy-load visualization data on demand
 	ExecuteChartQuery(ctx context.Context, userID, chatID string, chartConfig *dtos.ChartConfiguration, limit int) ([]map[string]interface{}, error)
+	ListVisualizationTemplates(dbType string) ([]models.VisualizationTemplate, uint32, error)
+	ApplyVisualizationTemplate(ctx context.Context, userID, chatID, messageID, queryID, templateID string) (*dtos.VisualizationResponse, uint32, error)
 
 	// Knowledge Base operations
 	GetKnowledgeBase(ctx context.Context, userID, chatID string) (*models.KnowledgeBase, uint32, error)
@@ -107,6 +168,42 @@ type chatService struct {
 	vectorizationSvc  VectorizationService                 // RAG pipeline — can be nil if unavailable
 	kbRepo            repositories.KnowledgeBaseRepository // Knowledge base persistence
 	dashboardRepo     repositories.DashboardRepository     // Dashboard persistence for duplication
+	chatPubSub        pubsub.PubSub                        // Shared chat room events — can be nil if unavailable
+	userRepo          repositories.UserRepository          // Per-user limits set by the admin
+	feedbackRepo      repositories.FeedbackRepository      // Ratings of AI responses
+	reactionRepo      repositories.ReactionRepository      // Emoji reactions to messages
+	secureNoteRepo    repositories.SecureNoteRepository    // Encrypted user context sent with every LLM call
+	queryTemplateRepo repositories.QueryTemplateRepository // Parameterized queries saved per chat
+	schemaSourceRepo  repositories.SchemaSourceRepository  // Uploaded schema files, e.g. schema.prisma
+	reportRepo        repositories.ReportRepository        // Reports generated from a list of questions
+	rollbackRepo      repositories.RollbackRepository      // Audit trail of rollback attempts
+	vaultResolver     *vault.VaultSecretResolver           // Connection credentials stored in Vault — nil if Vault is not configured
+	activeWatchers    map[string]context.CancelFunc        // key: watchID
+	watchOwners       map[string]queryWatch                // key: watchID, owner of each active watch
+	cdcWatchers       map[string]context.CancelFunc        // key: CDC watchID, only watchers running on this instance
+	watchersMu        sync.Mutex
+}
+
+// validateQueryTimeoutSeconds checks that a per-query timeout setting is within the allowed range
+func validateQueryTimeoutSeconds(seconds int) error {
+	if seconds < constants.MinQueryTimeoutSeconds || seconds > constants.MaxQueryTimeoutSeconds {
+		return fmt.Errorf("query_timeout_seconds must be between %d and %d", constants.MinQueryTimeoutSeconds, constants.MaxQueryTimeoutSeconds)
+	}
+	return nil
+}
+
+// normalizeFallbackChain validates the model IDs of a chat's LLM fallback chain, dropping blanks and duplicates
+func normalizeFallbackChain(modelIDs []string) ([]string, error) {
+	chain := constants.ParseLLMFallbackChain(strings.Join(modelIDs, ","))
+	if len(chain) > constants.MaxLLMFallbackChainLength {
+		return nil, fmt.Errorf("fallback_chain can list at most %d models", constants.MaxLLMFallbackChainLength)
+	}
+	for _, modelID := range chain {
+		if constants.GetLLMModel(modelID) == nil {
+			return nil, fmt.Errorf("fallback_chain: unsupported LLM model %s", modelID)
+		}
+	}
+	return chain, nil
 }
 
 func isValidDBType(dbType string) bool {
@@ -121,7 +218,21 @@ func isValidDBType(dbType string) bool {
 		constants.DatabaseTypeSpreadsheet,
 		constants.DatabaseTypeGoogleSheets,
 		constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase,
+		constants.DatabaseTypeNeon,
 		constants.DatabaseTypeStarRocks,
+		constants.DatabaseTypeTrino,
+		constants.DatabaseTypeOracle,
+		constants.DatabaseTypeAirtable,
+		constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeFerretDB,
+		constants.DatabaseTypeInfluxDB,
+		constants.DatabaseTypeTemporal,
+		constants.DatabaseTypePocketBase,
+		constants.DatabaseTypeNATS,
+		constants.DatabaseTypeYugabyteDBCQL,
+		constants.DatabaseTypeFirestore,
+		constants.DatabaseTypeCloudflareD1,
 	}
 
 	for _, validType := range validTypes {
@@ -133,6 +244,103 @@ func isValidDBType(dbType string) bool {
 	return false
 }
 
+// applyAirtableDefaults addresses Airtable connections by API host and base ID,
+// so the connection pool and the connection form have a host and database to work with
+func applyAirtableDefaults(req *dtos.CreateConnectionRequest) {
+	if req == nil || req.Type != constants.DatabaseTypeAirtable {
+		return
+	}
+	if req.Host == "" {
+		req.Host = "api.airtable.com"
+	}
+	if req.Database == "" && req.AirtableBaseID != nil {
+		req.Database = *req.AirtableBaseID
+	}
+}
+
+// applyFirestoreDefaults addresses Firestore connections by API host and database ID,
+// so the connection pool and the connection form have a host and database to work with
+func applyFirestoreDefaults(req *dtos.CreateConnectionRequest) {
+	if req == nil || req.Type != constants.DatabaseTypeFirestore {
+		return
+	}
+	if req.Host == "" {
+		req.Host = constants.FirestoreHost
+	}
+	if req.Database == "" {
+		req.Database = constants.FirestoreDefaultDatabase
+	}
+}
+
+// applyCloudflareD1Defaults addresses Cloudflare D1 connections by API host and database ID,
+// so the connection pool and the connection form have a host and database to work with
+func applyCloudflareD1Defaults(req *dtos.CreateConnectionRequest) {
+	if req == nil || req.Type != constants.DatabaseTypeCloudflareD1 {
+		return
+	}
+	if req.Host == "" {
+		req.Host = constants.CloudflareAPIHost
+	}
+	if req.Database == "" && req.CloudflareD1DatabaseID != nil {
+		req.Database = *req.CloudflareD1DatabaseID
+	}
+}
+
+// applyTemporalDefaults fills the host, port and database of Temporal connections from the frontend address
+// and the namespace, so the connection pool and the connection form have them to work with
+func applyTemporalDefaults(req *dtos.CreateConnectionRequest) {
+	if req == nil || req.Type != constants.DatabaseTypeTemporal {
+		return
+	}
+	if req.Host == "" && req.TemporalAddress != nil {
+		if host, port, err := net.SplitHostPort(*req.TemporalAddress); err == nil {
+			req.Host = host
+			req.Port = &port
+		} else {
+			req.Host = *req.TemporalAddress
+		}
+	}
+	if req.Database == "" {
+		req.Database = constants.TemporalDefaultNamespace
+		if req.TemporalNamespace != nil && *req.TemporalNamespace != "" {
+			req.Database = *req.TemporalNamespace
+		}
+	}
+}
+
+// applyNeonDetection switches PostgreSQL connections to a Neon endpoint (*.neon.tech) to the Neon type,
+// so they get serverless connection pooling and connect retries
+func applyNeonDetection(req *dtos.CreateConnectionRequest) {
+	if req == nil || req.Type != constants.DatabaseTypePostgreSQL || !constants.IsNeonHost(req.Host) {
+		return
+	}
+	log.Printf("ChatService -> applyNeonDetection -> Detected Neon host %s, using the Neon data source type", req.Host)
+	req.Type = constants.DatabaseTypeNeon
+}
+
+// applyYugabyteDBAPIType switches YugabyteDB connections using the YCQL API to the YugabyteDB YCQL type,
+// which has its own driver, and records the API type of both so the connection form shows the one in use
+func applyYugabyteDBAPIType(req *dtos.CreateConnectionRequest) {
+	if req == nil {
+		return
+	}
+	if req.Type == constants.DatabaseTypeYugabyteDB && req.APIType != nil && *req.APIType == constants.YugabyteDBAPITypeYCQL {
+		req.Type = constants.DatabaseTypeYugabyteDBCQL
+	}
+	req.APIType = yugabyteDBAPIType(req.Type)
+}
+
+// yugabyteDBAPIType returns the YugabyteDB API of a data source type, nil for other databases
+func yugabyteDBAPIType(dbType string) *string {
+	switch dbType {
+	case constants.DatabaseTypeYugabyteDB:
+		return utils.StringPtr(constants.YugabyteDBAPITypeYSQL)
+	case constants.DatabaseTypeYugabyteDBCQL:
+		return utils.StringPtr(constants.YugabyteDBAPITypeYCQL)
+	}
+	return nil
+}
+
 func (s *chatService) SetStreamHandler(handler StreamHandler) {
 	s.streamHandler = handler
 }
@@ -166,6 +374,16 @@ func NewChatService(
 	vectorizationSvc VectorizationService,
 	kbRepo repositories.KnowledgeBaseRepository,
 	dashboardRepo repositories.DashboardRepository,
+	chatPubSub pubsub.PubSub,
+	userRepo repositories.UserRepository,
+	feedbackRepo repositories.FeedbackRepository,
+	secureNoteRepo repositories.SecureNoteRepository,
+	reactionRepo repositories.ReactionRepository,
+	queryTemplateRepo repositories.QueryTemplateRepository,
+	schemaSourceRepo repositories.SchemaSourceRepository,
+	reportRepo repositories.ReportRepository,
+	rollbackRepo repositories.RollbackRepository,
+	vaultResolver *vault.VaultSecretResolver,
 ) ChatService {
 	// Initialize crypto instance
 	crypto, err := utils.NewFromConfig()
@@ -187,15 +405,47 @@ func NewChatService(
 		vectorizationSvc:  vectorizationSvc,
 		kbRepo:            kbRepo,
 		dashboardRepo:     dashboardRepo,
+		chatPubSub:        chatPubSub,
+		userRepo:          userRepo,
+		feedbackRepo:      feedbackRepo,
+		secureNoteRepo:    secureNoteRepo,
+		reactionRepo:      reactionRepo,
+		queryTemplateRepo: queryTemplateRepo,
+		schemaSourceRepo:  schemaSourceRepo,
+		reportRepo:        reportRepo,
+		rollbackRepo:      rollbackRepo,
+		vaultResolver:     vaultResolver,
+		activeWatchers:    make(map[string]context.CancelFunc),
+		watchOwners:       make(map[string]queryWatch),
+		cdcWatchers:       make(map[string]context.CancelFunc),
+	}
+}
+
+// chatEncryptedColumns returns the columns whose values are encrypted on their own in stored results
+func chatEncryptedColumns(chat *models.Chat) []string {
+	if chat == nil {
+		return nil
 	}
+	return chat.Settings.EncryptedColumns
 }
 
-// encryptQueryResult encrypts a query result for storage
-func (s *chatService) encryptQueryResult(result string) string {
+// encryptQueryResult encrypts a query result for storage.
+// Values of the chat's encrypted columns are encrypted on their own first, so they stay
+// protected even where the whole result is decrypted for display.
+func (s *chatService) encryptQueryResult(result string, encryptedColumns []string) string {
 	if s.crypto == nil || result == "" {
 		return result
 	}
 
+	if len(encryptedColumns) > 0 {
+		columnEncrypted, err := s.crypto.EncryptColumns(result, encryptedColumns)
+		if err != nil {
+			log.Printf("ChatService -> encryptQueryResult -> Failed to encrypt columns %v: %v", encryptedColumns, err)
+		} else {
+			result = columnEncrypted
+		}
+	}
+
 	encrypted, err := s.crypto.EncryptField(result)
 	if err != nil {
 		log.Printf("ChatService -> encryptQueryResult -> Failed to encrypt: %v", err)
@@ -217,6 +467,13 @@ func (s *chatService) decryptQueryResult(result string) string {
 		return result // Return as-is for backward compatibility
 	}
 
+	columnDecrypted, err := s.crypto.DecryptColumns(decrypted)
+	if err != nil {
+		log.Printf("ChatService -> decryptQueryResult -> Failed to decrypt columns: %v", err)
+		return decrypted
+	}
+	decrypted = columnDecrypted
+
 	return decrypted
 }
 
@@ -245,22 +502,53 @@ func (s *chatService) Create(userID string, req *dtos.CreateChatRequest) (*dtos.
 		return nil, http.StatusBadRequest, fmt.Errorf("Unsupported data source type: %s", req.Connection.Type)
 	}
 
+	// Populate the connection fields from the connection URI, if one was provided
+	if err := applyConnectionURI(&req.Connection); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	applyAirtableDefaults(&req.Connection)
+	applyTemporalDefaults(&req.Connection)
+	applyFirestoreDefaults(&req.Connection)
+	applyCloudflareD1Defaults(&req.Connection)
+	applyNeonDetection(&req.Connection)
+	applyYugabyteDBAPIType(&req.Connection)
+	if status, err := s.resolveVaultCredentials(userID, &req.Connection); err != nil {
+		return nil, status, err
+	}
+
 	// Skip connection test for spreadsheet and Google Sheets types as they don't have traditional database connection
 	if req.Connection.Type != constants.DatabaseTypeSpreadsheet && req.Connection.Type != constants.DatabaseTypeGoogleSheets {
 		// Test connection without creating a persistent connection
 		err := s.dbManager.TestConnection(&dbmanager.ConnectionConfig{
-			Type:           req.Connection.Type,
-			Host:           req.Connection.Host,
-			Port:           req.Connection.Port,
-			Username:       &req.Connection.Username,
-			Password:       req.Connection.Password,
-			Database:       req.Connection.Database,
-			AuthDatabase:   req.Connection.AuthDatabase,
-			SSLMode:        req.Connection.SSLMode,
-			UseSSL:         req.Connection.UseSSL,
-			SSLCertURL:     req.Connection.SSLCertURL,
-			SSLKeyURL:      req.Connection.SSLKeyURL,
-			SSLRootCertURL: req.Connection.SSLRootCertURL,
+			Type:                     req.Connection.Type,
+			Host:                     req.Connection.Host,
+			Port:                     req.Connection.Port,
+			Username:                 &req.Connection.Username,
+			Password:                 req.Connection.Password,
+			Database:                 req.Connection.Database,
+			AuthDatabase:             req.Connection.AuthDatabase,
+			SSLMode:                  req.Connection.SSLMode,
+			UseSSL:                   req.Connection.UseSSL,
+			SSLCertURL:               req.Connection.SSLCertURL,
+			SSLKeyURL:                req.Connection.SSLKeyURL,
+			SSLRootCertURL:           req.Connection.SSLRootCertURL,
+			Catalog:                  req.Connection.Catalog,
+			Schema:                   req.Connection.Schema,
+			ServiceName:              req.Connection.ServiceName,
+			AirtableAPIKey:           req.Connection.AirtableAPIKey,
+			AirtableBaseID:           req.Connection.AirtableBaseID,
+			PlanetscaleBranch:        req.Connection.PlanetscaleBranch,
+			InfluxOrg:                req.Connection.InfluxOrg,
+			InfluxToken:              req.Connection.InfluxToken,
+			ReadPreference:           req.Connection.ReadPreference,
+			TemporalNamespace:        req.Connection.TemporalNamespace,
+			TemporalAddress:          req.Connection.TemporalAddress,
+			NATSCredentialsFile:      req.Connection.NATSCredentialsFile,
+			GoogleProjectID:          req.Connection.GoogleProjectID,
+			FirestoreCredentialsJSON: req.Connection.FirestoreCredentialsJSON,
+			CloudflareAccountID:      req.Connection.CloudflareAccountID,
+			CloudflareD1DatabaseID:   req.Connection.CloudflareD1DatabaseID,
+			CloudflareAPIToken:       req.Connection.CloudflareAPIToken,
 		})
 		if err != nil {
 			return nil, http.StatusBadRequest, fmt.Errorf("%v", err)
@@ -319,6 +607,30 @@ func (s *chatService) Create(userID string, req *dtos.CreateChatRequest) (*dtos.
 		connection.SSLCertURL = req.Connection.SSLCertURL
 		connection.SSLKeyURL = req.Connection.SSLKeyURL
 		connection.SSLRootCertURL = req.Connection.SSLRootCertURL
+		connection.Catalog = req.Connection.Catalog
+		connection.Schema = req.Connection.Schema
+		connection.ServiceName = req.Connection.ServiceName
+		connection.SupabaseAnonKey = req.Connection.SupabaseAnonKey
+		connection.SupabaseServiceRoleKey = req.Connection.SupabaseServiceRoleKey
+		connection.AirtableAPIKey = req.Connection.AirtableAPIKey
+		connection.AirtableBaseID = req.Connection.AirtableBaseID
+		connection.PlanetscaleBranch = req.Connection.PlanetscaleBranch
+		connection.PlanetscaleOrganization = req.Connection.PlanetscaleOrganization
+		connection.PlanetscaleServiceTokenID = req.Connection.PlanetscaleServiceTokenID
+		connection.PlanetscaleServiceToken = req.Connection.PlanetscaleServiceToken
+		connection.InfluxOrg = req.Connection.InfluxOrg
+		connection.InfluxToken = req.Connection.InfluxToken
+		connection.ReadPreference = req.Connection.ReadPreference
+		connection.TemporalNamespace = req.Connection.TemporalNamespace
+		connection.TemporalAddress = req.Connection.TemporalAddress
+		connection.NATSCredentialsFile = req.Connection.NATSCredentialsFile
+		connection.GoogleProjectID = req.Connection.GoogleProjectID
+		connection.FirestoreCredentialsJSON = req.Connection.FirestoreCredentialsJSON
+		connection.CloudflareAccountID = req.Connection.CloudflareAccountID
+		connection.CloudflareD1DatabaseID = req.Connection.CloudflareD1DatabaseID
+		connection.CloudflareAPIToken = req.Connection.CloudflareAPIToken
+		connection.APIType = req.Connection.APIType
+		connection.VaultSecretPath = req.Connection.VaultSecretPath
 	}
 
 	// Encrypt connection details
@@ -340,6 +652,40 @@ func (s *chatService) Create(userID string, req *dtos.CreateChatRequest) (*dtos.
 	if req.Settings.AutoGenerateVisualization != nil {
 		settings.AutoGenerateVisualization = *req.Settings.AutoGenerateVisualization
 	}
+	if req.Settings.QueryTimeoutSeconds != nil {
+		if err := validateQueryTimeoutSeconds(*req.Settings.QueryTimeoutSeconds); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		settings.QueryTimeoutSeconds = req.Settings.QueryTimeoutSeconds
+	}
+	if req.Settings.EncryptedColumns != nil {
+		settings.EncryptedColumns = utils.NormalizeColumnNames(*req.Settings.EncryptedColumns)
+	}
+	if req.Settings.FallbackChain != nil {
+		fallbackChain, err := normalizeFallbackChain(*req.Settings.FallbackChain)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		settings.FallbackChain = fallbackChain
+	}
+	if req.Settings.MaxQueryCostUnits != nil {
+		if err := validateMaxQueryCostUnits(*req.Settings.MaxQueryCostUnits); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if *req.Settings.MaxQueryCostUnits > 0 {
+			settings.MaxQueryCostUnits = req.Settings.MaxQueryCostUnits
+		}
+	}
+	if req.Settings.DisableParallelWorkers != nil {
+		settings.DisableParallelWorkers = *req.Settings.DisableParallelWorkers
+	}
+	if req.Settings.QueryStyle != nil {
+		queryStyle, err := queryStyleConfig(req.Settings.QueryStyle)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		settings.QueryStyle = queryStyle
+	}
 	log.Printf("ChatService -> Create -> Creating chat with settings: AutoExecuteQuery=%v, ShareDataWithAI=%v, NonTechMode=%v, AutoGenerateVisualization=%v",
 		settings.AutoExecuteQuery, settings.ShareDataWithAI, settings.NonTechMode, settings.AutoGenerateVisualization)
 	// Create chat with connection
@@ -375,6 +721,20 @@ func (s *chatService) CreateWithoutConnectionPing(userID string, req *dtos.Creat
 		return nil, http.StatusBadRequest, fmt.Errorf("Unsupported data source type: %s", req.Connection.Type)
 	}
 
+	// Populate the connection fields from the connection URI, if one was provided
+	if err := applyConnectionURI(&req.Connection); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	applyAirtableDefaults(&req.Connection)
+	applyTemporalDefaults(&req.Connection)
+	applyFirestoreDefaults(&req.Connection)
+	applyCloudflareD1Defaults(&req.Connection)
+	applyNeonDetection(&req.Connection)
+	applyYugabyteDBAPIType(&req.Connection)
+	if status, err := s.resolveVaultCredentials(userID, &req.Connection); err != nil {
+		return nil, status, err
+	}
+
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
@@ -428,6 +788,30 @@ func (s *chatService) CreateWithoutConnectionPing(userID string, req *dtos.Creat
 		connection.SSLCertURL = req.Connection.SSLCertURL
 		connection.SSLKeyURL = req.Connection.SSLKeyURL
 		connection.SSLRootCertURL = req.Connection.SSLRootCertURL
+		connection.Catalog = req.Connection.Catalog
+		connection.Schema = req.Connection.Schema
+		connection.ServiceName = req.Connection.ServiceName
+		connection.SupabaseAnonKey = req.Connection.SupabaseAnonKey
+		connection.SupabaseServiceRoleKey = req.Connection.SupabaseServiceRoleKey
+		connection.AirtableAPIKey = req.Connection.AirtableAPIKey
+		connection.AirtableBaseID = req.Connection.AirtableBaseID
+		connection.PlanetscaleBranch = req.Connection.PlanetscaleBranch
+		connection.PlanetscaleOrganization = req.Connection.PlanetscaleOrganization
+		connection.PlanetscaleServiceTokenID = req.Connection.PlanetscaleServiceTokenID
+		connection.PlanetscaleServiceToken = req.Connection.PlanetscaleServiceToken
+		connection.InfluxOrg = req.Connection.InfluxOrg
+		connection.InfluxToken = req.Connection.InfluxToken
+		connection.ReadPreference = req.Connection.ReadPreference
+		connection.TemporalNamespace = req.Connection.TemporalNamespace
+		connection.TemporalAddress = req.Connection.TemporalAddress
+		connection.NATSCredentialsFile = req.Connection.NATSCredentialsFile
+		connection.GoogleProjectID = req.Connection.GoogleProjectID
+		connection.FirestoreCredentialsJSON = req.Connection.FirestoreCredentialsJSON
+		connection.CloudflareAccountID = req.Connection.CloudflareAccountID
+		connection.CloudflareD1DatabaseID = req.Connection.CloudflareD1DatabaseID
+		connection.CloudflareAPIToken = req.Connection.CloudflareAPIToken
+		connection.APIType = req.Connection.APIType
+		connection.VaultSecretPath = req.Connection.VaultSecretPath
 	}
 
 	// Encrypt connection details
@@ -485,11 +869,48 @@ func (s *chatService) Update(userID, chatID string, req *dtos.UpdateChatRequest)
 		if !isValidDBType(req.Connection.Type) {
 			return nil, http.StatusBadRequest, fmt.Errorf("unsupported data source type: %s", req.Connection.Type)
 		}
+		if err := applyConnectionURI(req.Connection); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		applyAirtableDefaults(req.Connection)
+		applyTemporalDefaults(req.Connection)
+		applyFirestoreDefaults(req.Connection)
+		applyCloudflareD1Defaults(req.Connection)
+		applyNeonDetection(req.Connection)
+		applyYugabyteDBAPIType(req.Connection)
+		if status, err := s.resolveVaultCredentials(userID, req.Connection); err != nil {
+			return nil, status, err
+		}
 
 		// Create a copy of the existing connection and decrypt it for comparison
 		existingConn := chat.Connection
 		utils.DecryptConnection(&existingConn)
 
+		// The Airtable API key is never sent to the client, keep the stored key when the form omits it
+		if req.Connection.Type == constants.DatabaseTypeAirtable && req.Connection.AirtableAPIKey == nil {
+			req.Connection.AirtableAPIKey = existingConn.AirtableAPIKey
+		}
+
+		// Same for the PlanetScale service token
+		if req.Connection.Type == constants.DatabaseTypePlanetscale && req.Connection.PlanetscaleServiceToken == nil {
+			req.Connection.PlanetscaleServiceToken = existingConn.PlanetscaleServiceToken
+		}
+
+		// And the InfluxDB token
+		if req.Connection.Type == constants.DatabaseTypeInfluxDB && req.Connection.InfluxToken == nil {
+			req.Connection.InfluxToken = existingConn.InfluxToken
+		}
+
+		// And the Firestore service account JSON
+		if req.Connection.Type == constants.DatabaseTypeFirestore && req.Connection.FirestoreCredentialsJSON == nil {
+			req.Connection.FirestoreCredentialsJSON = existingConn.FirestoreCredentialsJSON
+		}
+
+		// And the Cloudflare API token
+		if req.Connection.Type == constants.DatabaseTypeCloudflareD1 && req.Connection.CloudflareAPIToken == nil {
+			req.Connection.CloudflareAPIToken = existingConn.CloudflareAPIToken
+		}
+
 		// Check if critical connection details have changed
 		// For spreadsheet and Google Sheets connections, we never consider credentials as changed since they use internal credentials
 		if req.Connection.Type == constants.DatabaseTypeSpreadsheet || req.Connection.Type == constants.DatabaseTypeGoogleSheets {
@@ -499,25 +920,60 @@ func (s *chatService) Update(userID, chatID string, req *dtos.UpdateChatRequest)
 				existingConn.Host != req.Connection.Host ||
 				existingConn.Port != req.Connection.Port ||
 				*existingConn.Username != req.Connection.Username ||
-				(req.Connection.Password != nil && existingConn.Password != nil && *existingConn.Password != *req.Connection.Password)
+				(req.Connection.Password != nil && existingConn.Password != nil && *existingConn.Password != *req.Connection.Password) ||
+				(req.Connection.AirtableAPIKey != nil && existingConn.AirtableAPIKey != nil && *existingConn.AirtableAPIKey != *req.Connection.AirtableAPIKey) ||
+				(req.Connection.InfluxToken != nil && existingConn.InfluxToken != nil && *existingConn.InfluxToken != *req.Connection.InfluxToken) ||
+				// The MongoDB client is created with its read preference, so a change needs a new client
+				(req.Connection.ReadPreference != nil && (existingConn.ReadPreference == nil || *existingConn.ReadPreference != *req.Connection.ReadPreference)) ||
+				// Switching PlanetScale branch reconnects with the credentials of the new branch
+				(req.Connection.PlanetscaleBranch != nil && (existingConn.PlanetscaleBranch == nil || *existingConn.PlanetscaleBranch != *req.Connection.PlanetscaleBranch)) ||
+				// A Temporal client is bound to its namespace
+				(req.Connection.TemporalNamespace != nil && (existingConn.TemporalNamespace == nil || *existingConn.TemporalNamespace != *req.Connection.TemporalNamespace)) ||
+				(req.Connection.TemporalAddress != nil && (existingConn.TemporalAddress == nil || *existingConn.TemporalAddress != *req.Connection.TemporalAddress)) ||
+				// NATS authenticates with the credentials file when the connection is opened
+				(req.Connection.NATSCredentialsFile != nil && (existingConn.NATSCredentialsFile == nil || *existingConn.NATSCredentialsFile != *req.Connection.NATSCredentialsFile)) ||
+				// A Firestore client is bound to its project and service account
+				(req.Connection.GoogleProjectID != nil && (existingConn.GoogleProjectID == nil || *existingConn.GoogleProjectID != *req.Connection.GoogleProjectID)) ||
+				(req.Connection.FirestoreCredentialsJSON != nil && existingConn.FirestoreCredentialsJSON != nil && *existingConn.FirestoreCredentialsJSON != *req.Connection.FirestoreCredentialsJSON) ||
+				// A D1 client is bound to its account, database and API token
+				(req.Connection.CloudflareAccountID != nil && (existingConn.CloudflareAccountID == nil || *existingConn.CloudflareAccountID != *req.Connection.CloudflareAccountID)) ||
+				(req.Connection.CloudflareD1DatabaseID != nil && (existingConn.CloudflareD1DatabaseID == nil || *existingConn.CloudflareD1DatabaseID != *req.Connection.CloudflareD1DatabaseID)) ||
+				(req.Connection.CloudflareAPIToken != nil && existingConn.CloudflareAPIToken != nil && *existingConn.CloudflareAPIToken != *req.Connection.CloudflareAPIToken)
 		}
 
 		// Skip connection test for spreadsheet and Google Sheets types as they don't have traditional database connection
 		if req.Connection.Type != constants.DatabaseTypeSpreadsheet && req.Connection.Type != constants.DatabaseTypeGoogleSheets {
 			// Test connection without creating a persistent connection
 			err = s.dbManager.TestConnection(&dbmanager.ConnectionConfig{
-				Type:           req.Connection.Type,
-				Host:           req.Connection.Host,
-				Port:           req.Connection.Port,
-				Username:       &req.Connection.Username,
-				Password:       req.Connection.Password,
-				Database:       req.Connection.Database,
-				AuthDatabase:   req.Connection.AuthDatabase,
-				UseSSL:         req.Connection.UseSSL,
-				SSLMode:        req.Connection.SSLMode,
-				SSLCertURL:     req.Connection.SSLCertURL,
-				SSLKeyURL:      req.Connection.SSLKeyURL,
-				SSLRootCertURL: req.Connection.SSLRootCertURL,
+				Type:                     req.Connection.Type,
+				Host:                     req.Connection.Host,
+				Port:                     req.Connection.Port,
+				Username:                 &req.Connection.Username,
+				Password:                 req.Connection.Password,
+				Database:                 req.Connection.Database,
+				AuthDatabase:             req.Connection.AuthDatabase,
+				UseSSL:                   req.Connection.UseSSL,
+				SSLMode:                  req.Connection.SSLMode,
+				SSLCertURL:               req.Connection.SSLCertURL,
+				SSLKeyURL:                req.Connection.SSLKeyURL,
+				SSLRootCertURL:           req.Connection.SSLRootCertURL,
+				Catalog:                  req.Connection.Catalog,
+				Schema:                   req.Connection.Schema,
+				ServiceName:              req.Connection.ServiceName,
+				AirtableAPIKey:           req.Connection.AirtableAPIKey,
+				AirtableBaseID:           req.Connection.AirtableBaseID,
+				PlanetscaleBranch:        req.Connection.PlanetscaleBranch,
+				InfluxOrg:                req.Connection.InfluxOrg,
+				InfluxToken:              req.Connection.InfluxToken,
+				ReadPreference:           req.Connection.ReadPreference,
+				TemporalNamespace:        req.Connection.TemporalNamespace,
+				TemporalAddress:          req.Connection.TemporalAddress,
+				NATSCredentialsFile:      req.Connection.NATSCredentialsFile,
+				GoogleProjectID:          req.Connection.GoogleProjectID,
+				FirestoreCredentialsJSON: req.Connection.FirestoreCredentialsJSON,
+				CloudflareAccountID:      req.Connection.CloudflareAccountID,
+				CloudflareD1DatabaseID:   req.Connection.CloudflareD1DatabaseID,
+				CloudflareAPIToken:       req.Connection.CloudflareAPIToken,
 			})
 			if err != nil {
 				return nil, http.StatusBadRequest, fmt.Errorf("%v", err)
@@ -538,8 +994,32 @@ func (s *chatService) Update(userID, chatID string, req *dtos.UpdateChatRequest)
 			SSLCertURL:     req.Connection.SSLCertURL,
 			SSLKeyURL:      req.Connection.SSLKeyURL,
 			SSLRootCertURL: req.Connection.SSLRootCertURL,
+			Catalog:        req.Connection.Catalog,
+			Schema:         req.Connection.Schema,
+			ServiceName:    req.Connection.ServiceName,
 			Base:           models.NewBase(),
 		}
+		connection.SupabaseAnonKey = req.Connection.SupabaseAnonKey
+		connection.SupabaseServiceRoleKey = req.Connection.SupabaseServiceRoleKey
+		connection.AirtableAPIKey = req.Connection.AirtableAPIKey
+		connection.AirtableBaseID = req.Connection.AirtableBaseID
+		connection.PlanetscaleBranch = req.Connection.PlanetscaleBranch
+		connection.PlanetscaleOrganization = req.Connection.PlanetscaleOrganization
+		connection.PlanetscaleServiceTokenID = req.Connection.PlanetscaleServiceTokenID
+		connection.PlanetscaleServiceToken = req.Connection.PlanetscaleServiceToken
+		connection.InfluxOrg = req.Connection.InfluxOrg
+		connection.InfluxToken = req.Connection.InfluxToken
+		connection.ReadPreference = req.Connection.ReadPreference
+		connection.TemporalNamespace = req.Connection.TemporalNamespace
+		connection.TemporalAddress = req.Connection.TemporalAddress
+		connection.NATSCredentialsFile = req.Connection.NATSCredentialsFile
+		connection.GoogleProjectID = req.Connection.GoogleProjectID
+		connection.FirestoreCredentialsJSON = req.Connection.FirestoreCredentialsJSON
+		connection.CloudflareAccountID = req.Connection.CloudflareAccountID
+		connection.CloudflareD1DatabaseID = req.Connection.CloudflareD1DatabaseID
+		connection.CloudflareAPIToken = req.Connection.CloudflareAPIToken
+		connection.APIType = req.Connection.APIType
+		connection.VaultSecretPath = req.Connection.VaultSecretPath
 
 		// Encrypt connection details
 		if err := utils.EncryptConnection(&connection); err != nil {
@@ -597,6 +1077,63 @@ func (s *chatService) Update(userID, chatID string, req *dtos.UpdateChatRequest)
 			log.Printf("ChatService -> Update -> AutoGenerateVisualization: %v", *req.Settings.AutoGenerateVisualization)
 			chat.Settings.AutoGenerateVisualization = *req.Settings.AutoGenerateVisualization
 		}
+		if req.Settings.QueryTimeoutSeconds != nil {
+			if err := validateQueryTimeoutSeconds(*req.Settings.QueryTimeoutSeconds); err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+			log.Printf("ChatService -> Update -> QueryTimeoutSeconds: %d", *req.Settings.QueryTimeoutSeconds)
+			chat.Settings.QueryTimeoutSeconds = req.Settings.QueryTimeoutSeconds
+		}
+		if req.Settings.EncryptedColumns != nil {
+			log.Printf("ChatService -> Update -> EncryptedColumns: %v", *req.Settings.EncryptedColumns)
+			chat.Settings.EncryptedColumns = utils.NormalizeColumnNames(*req.Settings.EncryptedColumns)
+		}
+		if req.Settings.FallbackChain != nil {
+			fallbackChain, err := normalizeFallbackChain(*req.Settings.FallbackChain)
+			if err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+			log.Printf("ChatService -> Update -> FallbackChain: %v", fallbackChain)
+			chat.Settings.FallbackChain = fallbackChain
+		}
+		if req.Settings.MaxQueryCostUnits != nil {
+			if err := validateMaxQueryCostUnits(*req.Settings.MaxQueryCostUnits); err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+			log.Printf("ChatService -> Update -> MaxQueryCostUnits: %v", *req.Settings.MaxQueryCostUnits)
+			if *req.Settings.MaxQueryCostUnits > 0 {
+				chat.Settings.MaxQueryCostUnits = req.Settings.MaxQueryCostUnits
+			} else {
+				chat.Settings.MaxQueryCostUnits = nil
+			}
+		}
+		if req.Settings.DisableParallelWorkers != nil {
+			log.Printf("ChatService -> Update -> DisableParallelWorkers: %v", *req.Settings.DisableParallelWorkers)
+			chat.Settings.DisableParallelWorkers = *req.Settings.DisableParallelWorkers
+			s.dbManager.SetParallelWorkersDisabled(chatID, chat.Settings.DisableParallelWorkers)
+		}
+		if req.Settings.QueryStyle != nil {
+			queryStyle, err := queryStyleConfig(req.Settings.QueryStyle)
+			if err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+			log.Printf("ChatService -> Update -> QueryStyle: %+v", queryStyle)
+			chat.Settings.QueryStyle = queryStyle
+		}
+	}
+
+	// Update the title if provided, an empty title clears it so it is generated again
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if len(title) > constants.ChatTitleMaxLength {
+			return nil, http.StatusBadRequest, fmt.Errorf("title must be at most %d characters", constants.ChatTitleMaxLength)
+		}
+		log.Printf("ChatService -> Update -> Title: %s", title)
+		if title == "" {
+			chat.Title = nil
+		} else {
+			chat.Title = &title
+		}
 	}
 
 	// Update preferred LLM model if provided
@@ -605,6 +1142,18 @@ func (s *chatService) Update(userID, chatID string, req *dtos.UpdateChatRequest)
 		chat.PreferredLLMModel = req.PreferredLLMModel
 	}
 
+	// Update secondary connections for federated queries if provided
+	if req.SecondaryConnections != nil {
+		secondaryConnections, status, err := s.buildSecondaryConnections(userID, *req.SecondaryConnections)
+		if err != nil {
+			return nil, status, err
+		}
+		// Drop the live secondary connection so the next federated query reconnects with the new details
+		s.disconnectSecondaryDB(chat)
+		chat.SecondaryConnections = secondaryConnections
+		log.Printf("ChatService -> Update -> SecondaryConnections: %d", len(secondaryConnections))
+	}
+
 	// Update the chat
 	if err := s.chatRepo.Update(chatObjID, chat); err != nil {
 		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update chat: %v", err)
@@ -707,17 +1256,7 @@ func (s *chatService) Delete(userID, chatID string) (uint32, error) {
 	}
 
 	go func() {
-		// Delete DB connection with connection type for safety validation
-		if err := s.dbManager.DisconnectWithType(chatID, userID, chat.Connection.Type, true); err != nil {
-			log.Printf("failed to delete DB connection: %v", err)
-		}
-
-		// Delete vectors from Qdrant
-		if s.vectorizationSvc != nil && s.vectorizationSvc.IsAvailable(context.Background()) {
-			if err := s.vectorizationSvc.DeleteChatVectors(context.Background(), chatID); err != nil {
-				log.Printf("failed to delete chat vectors: %v", err)
-			}
-		}
+		s.ReleaseChatResources(chat)
 
 		// Delete knowledge base from MongoDB
 		if s.kbRepo != nil {
@@ -725,11 +1264,58 @@ func (s *chatService) Delete(userID, chatID string) (uint32, error) {
 				log.Printf("failed to delete knowledge base: %v", err)
 			}
 		}
+
+		// Delete secure notes from MongoDB
+		if s.secureNoteRepo != nil {
+			if err := s.secureNoteRepo.DeleteByChatID(context.Background(), chatObjID); err != nil {
+				log.Printf("failed to delete secure notes: %v", err)
+			}
+		}
+
+		// Delete query templates from MongoDB
+		if s.queryTemplateRepo != nil {
+			if err := s.queryTemplateRepo.DeleteByChatID(context.Background(), chatObjID); err != nil {
+				log.Printf("failed to delete query templates: %v", err)
+			}
+		}
+
+		// Delete uploaded schema files from MongoDB
+		if s.schemaSourceRepo != nil {
+			if err := s.schemaSourceRepo.DeleteByChatID(context.Background(), chatObjID); err != nil {
+				log.Printf("failed to delete schema sources: %v", err)
+			}
+		}
+
+		// Delete generated reports from MongoDB
+		if s.reportRepo != nil {
+			if err := s.reportRepo.DeleteByChatID(context.Background(), chatObjID); err != nil {
+				log.Printf("failed to delete reports: %v", err)
+			}
+		}
 	}()
 
 	return http.StatusOK, nil
 }
 
+// ReleaseChatResources disconnects a deleted chat's databases and removes its vectors from Qdrant,
+// the data the chat has outside of MongoDB
+func (s *chatService) ReleaseChatResources(chat *models.Chat) {
+	chatID := chat.ID.Hex()
+
+	// Delete DB connection with connection type for safety validation
+	if err := s.dbManager.DisconnectWithType(chatID, chat.UserID.Hex(), chat.Connection.Type, true); err != nil {
+		log.Printf("failed to delete DB connection: %v", err)
+	}
+	s.disconnectSecondaryDB(chat)
+
+	// Delete vectors from Qdrant
+	if s.vectorizationSvc != nil && s.vectorizationSvc.IsAvailable(context.Background()) {
+		if err := s.vectorizationSvc.DeleteChatVectors(context.Background(), chatID); err != nil {
+			log.Printf("failed to delete chat vectors: %v", err)
+		}
+	}
+}
+
 // Get a chat by ID
 func (s *chatService) GetByID(userID, chatID string) (*dtos.ChatResponse, uint32, error) {
 	userObjID, err := primitive.ObjectIDFromHex(userID)
@@ -784,6 +1370,63 @@ func (s *chatService) List(userID string, page, pageSize int) (*dtos.ChatListRes
 
 // Create a new message
 func (s *chatService) CreateMessage(ctx context.Context, userID, chatID string, streamID string, content string, llmModel string) (*dtos.MessageResponse, uint16, error) {
+	return s.createMessage(ctx, userID, chatID, streamID, content, llmModel, nil)
+}
+
+// CreateThreadMessage creates a follow-up question about a prior AI message. The question and its
+// answer live in the thread of that message and stay out of the main conversation.
+func (s *chatService) CreateThreadMessage(ctx context.Context, userID, chatID, messageID string, streamID string, content string, llmModel string) (*dtos.MessageResponse, uint16, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	messageObjID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid message ID format")
+	}
+
+	parent, err := s.chatRepo.FindMessageByID(messageObjID)
+	if err != nil || parent == nil || parent.ChatID != chatObjID {
+		return nil, http.StatusNotFound, fmt.Errorf("message not found")
+	}
+	if parent.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to message")
+	}
+	if parent.Type != string(constants.MessageTypeAssistant) {
+		return nil, http.StatusBadRequest, fmt.Errorf("threads can only be started from an AI message")
+	}
+
+	return s.createMessage(ctx, userID, chatID, streamID, content, llmModel, parent)
+}
+
+// promptInjectionDetector screens user messages before they reach the LLM
+var promptInjectionDetector = utils.NewPromptInjectionDetector()
+
+// checkPromptInjection rejects a message that tries to override the system prompt, unless PROMPT_INJECTION_CHECK_ENABLED is false
+func checkPromptInjection(userID, content string) error {
+	if !config.Env.PromptInjectionCheckEnabled {
+		return nil
+	}
+	if detected, pattern := promptInjectionDetector.Detect(content); detected {
+		log.Printf("ChatService -> checkPromptInjection -> Rejected message of user %s, matched pattern: %s", userID, pattern)
+		return fmt.Errorf("%s", utils.ErrPromptInjectionMessage)
+	}
+	return nil
+}
+
+// createMessage saves a user message and starts the AI response. When parent is set, the message
+// is a threaded reply to that AI message.
+func (s *chatService) createMessage(ctx context.Context, userID, chatID string, streamID string, content string, llmModel string, parent *models.Message) (*dtos.MessageResponse, uint16, error) {
+	if err := checkPromptInjection(userID, content); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
 	// Validate chat exists and user has access
 	chatObjID, err := primitive.ObjectIDFromHex(chatID)
 	if err != nil {
@@ -819,11 +1462,32 @@ func (s *chatService) CreateMessage(ctx context.Context, userID, chatID string,
 	if llmModel != "" {
 		msg.LLMModel = &llmModel // Store the selected LLM model with the user message
 	}
+	if parent != nil {
+		// Replies to a message that is itself in a thread continue that thread
+		threadID := parent.ID
+		if parent.ThreadID != nil {
+			threadID = *parent.ThreadID
+		}
+		msg.ParentMessageID = &parent.ID
+		msg.ThreadID = &threadID
+		msg.UserMessageId = &parent.ID
+	}
 
 	if err := s.chatRepo.CreateMessage(msg); err != nil {
 		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save message: %v", err)
 	}
 
+	// Broadcast the new message to everyone watching this chat
+	s.publishChatEvent(chatID, constants.ChatEventNewMessage, &dtos.MessageResponse{
+		ID:              msg.ID.Hex(),
+		ChatID:          chatID,
+		Content:         content,
+		Type:            string(constants.MessageTypeUser),
+		ParentMessageID: objectIDHexPtr(msg.ParentMessageID),
+		ThreadID:        objectIDHexPtr(msg.ThreadID),
+		CreatedAt:       msg.CreatedAt.Format(time.RFC3339),
+	})
+
 	// Vectorize the user message in the background for conversational RAG retrieval
 	go func() {
 		bgCtx := context.Background()
@@ -850,6 +1514,7 @@ func (s *chatService) CreateMessage(ctx context.Context, userID, chatID string,
 			UserID:        userObjID,
 			ChatID:        chatObjID,
 			UserMessageId: &msg.ID,
+			ThreadID:      msg.ThreadID,
 			Content:       "Your Knowledge Base requires to be refreshed for latest knowledge, please refresh it to get accurate insights & analytics and then send a new message.",
 			Type:          string(constants.MessageTypeAssistant),
 			ActionButtons: &[]models.ActionButton{
@@ -883,6 +1548,7 @@ func (s *chatService) CreateMessage(ctx context.Context, userID, chatID string,
 					"content":        systemMsg.Content,
 					"type":           systemMsg.Type,
 					"action_buttons": dtos.ToActionButtonDto(systemMsg.ActionButtons),
+					"thread_id":      objectIDHexPtr(systemMsg.ThreadID),
 					"created_at":     systemMsg.CreatedAt.Format(time.RFC3339),
 				},
 			})
@@ -890,11 +1556,13 @@ func (s *chatService) CreateMessage(ctx context.Context, userID, chatID string,
 
 		// Return user message response (schema not ready yet)
 		return &dtos.MessageResponse{
-			ID:        msg.ID.Hex(),
-			ChatID:    chatID,
-			Content:   content,
-			Type:      string(constants.MessageTypeUser),
-			CreatedAt: msg.CreatedAt.Format(time.RFC3339),
+			ID:              msg.ID.Hex(),
+			ChatID:          chatID,
+			Content:         content,
+			Type:            string(constants.MessageTypeUser),
+			ParentMessageID: objectIDHexPtr(msg.ParentMessageID),
+			ThreadID:        objectIDHexPtr(msg.ThreadID),
+			CreatedAt:       msg.CreatedAt.Format(time.RFC3339),
 		}, http.StatusOK, nil
 	}
 
@@ -912,11 +1580,13 @@ func (s *chatService) CreateMessage(ctx context.Context, userID, chatID string,
 
 	// Return the actual message ID
 	return &dtos.MessageResponse{
-		ID:        msg.ID.Hex(), // Use actual message ID
-		ChatID:    chatID,
-		Content:   content,
-		Type:      string(constants.MessageTypeUser),
-		CreatedAt: msg.CreatedAt.Format(time.RFC3339),
+		ID:              msg.ID.Hex(), // Use actual message ID
+		ChatID:          chatID,
+		Content:         content,
+		Type:            string(constants.MessageTypeUser),
+		ParentMessageID: objectIDHexPtr(msg.ParentMessageID),
+		ThreadID:        objectIDHexPtr(msg.ThreadID),
+		CreatedAt:       msg.CreatedAt.Format(time.RFC3339),
 	}, http.StatusOK, nil
 }
 
@@ -956,6 +1626,9 @@ func (s *chatService) UpdateMessage(ctx context.Context, userID, chatID, message
 	}
 
 	log.Printf("UpdateMessage -> content: %+v", req.Content)
+	if err := checkPromptInjection(userID, req.Content); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
 	// Update message content, This is a user message
 	message.Content = req.Content
 	message.IsEdited = true
@@ -1023,8 +1696,61 @@ func (s *chatService) UpdateMessage(ctx context.Context, userID, chatID, message
 	return s.buildMessageResponse(message), http.StatusOK, nil
 }
 
-// Delete messages
-func (s *chatService) DeleteMessages(userID, chatID string) (uint32, error) {
+// RegenerateMessage asks the LLM again for a user message whose response was poor, without the user
+// rephrasing it. The previous AI response is deleted and the new one is stored as a new message.
+func (s *chatService) RegenerateMessage(ctx context.Context, userID, chatID, messageID string, streamID string) (*dtos.MessageResponse, uint32, error) {
+	log.Printf("ChatService -> RegenerateMessage -> userID: %s, chatID: %s, messageID: %s", userID, chatID, messageID)
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	messageObjID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid message ID format")
+	}
+
+	message, err := s.chatRepo.FindMessageByID(messageObjID)
+	if err != nil || message == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("message not found")
+	}
+	if message.ChatID != chat.ID {
+		return nil, http.StatusBadRequest, fmt.Errorf("message does not belong to chat")
+	}
+	if message.Type != string(constants.MessageTypeUser) {
+		return nil, http.StatusBadRequest, fmt.Errorf("only user messages can be regenerated, pass the message the AI responded to")
+	}
+
+	// Remove the previous AI response, processing then stores a new one instead of editing it
+	nextMessage, err := s.chatRepo.FindNextMessageByID(messageObjID)
+	if err == nil && nextMessage != nil && nextMessage.Type == string(constants.MessageTypeAssistant) {
+		log.Printf("RegenerateMessage -> Deleting previous AI message: %v", nextMessage.ID)
+		if _, err := s.deleteMessagesInBatches(chat.ID, []primitive.ObjectID{nextMessage.ID}); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to delete previous response: %v", err)
+		}
+	}
+
+	message.RegenerationCount++
+	if err := s.chatRepo.UpdateMessage(message.ID, message); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update message: %v", err)
+	}
+
+	// Same processing as a new or edited message
+	if chat.Settings.AutoExecuteQuery {
+		if err := s.processLLMResponseAndRunQuery(ctx, userID, chatID, messageID, streamID); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to process message: %v", err)
+		}
+	} else {
+		if err := s.processMessage(ctx, userID, chatID, messageID, streamID); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to process message: %v", err)
+		}
+	}
+	return s.buildMessageResponse(message), http.StatusOK, nil
+}
+
+// Delete all messages of a chat
+func (s *chatService) DeleteAllMessages(userID, chatID string) (uint32, error) {
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("invalid user ID format")
@@ -1056,7 +1782,7 @@ func (s *chatService) DeleteMessages(userID, chatID string) (uint32, error) {
 		if s.vectorizationSvc != nil {
 			bgCtx := context.Background()
 			if err := s.vectorizationSvc.DeleteChatMessageVectors(bgCtx, chatID); err != nil {
-				log.Printf("DeleteMessages -> Failed to delete message vectors: %v", err)
+				log.Printf("DeleteAllMessages -> Failed to delete message vectors: %v", err)
 			}
 		}
 	}()
@@ -1064,8 +1790,9 @@ func (s *chatService) DeleteMessages(userID, chatID string) (uint32, error) {
 	return http.StatusOK, nil
 }
 
-// Duplicate a chat
-func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, duplicateDashboards bool) (*dtos.ChatResponse, uint32, error) {
+// Duplicate a chat. When newConnectionConfig is set, the duplicate is pointed at that connection
+// instead of the original one (e.g. to promote a chat from staging to production).
+func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, duplicateDashboards bool, newConnectionConfig *dbmanager.ConnectionConfig) (*dtos.ChatResponse, uint32, error) {
 	// Validate user ID
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
@@ -1100,10 +1827,61 @@ func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, d
 			return nil, http.StatusBadRequest, fmt.Errorf("You cannot have more than 2 chats in trial mode")
 		}
 	}
+
+	// Swap the connection if a new one was provided, testing it before anything is created
+	connection := chat.Connection
+	connectionSwapped := newConnectionConfig != nil
+	if connectionSwapped {
+		if err := s.dbManager.TestConnection(newConnectionConfig); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("new connection test failed: %v", err)
+		}
+
+		connection = models.Connection{
+			Type:                      newConnectionConfig.Type,
+			Host:                      newConnectionConfig.Host,
+			Port:                      newConnectionConfig.Port,
+			Username:                  newConnectionConfig.Username,
+			Password:                  newConnectionConfig.Password,
+			Database:                  newConnectionConfig.Database,
+			AuthDatabase:              newConnectionConfig.AuthDatabase,
+			UseSSL:                    newConnectionConfig.UseSSL,
+			SSLMode:                   newConnectionConfig.SSLMode,
+			SSLCertURL:                newConnectionConfig.SSLCertURL,
+			SSLKeyURL:                 newConnectionConfig.SSLKeyURL,
+			SSLRootCertURL:            newConnectionConfig.SSLRootCertURL,
+			Catalog:                   newConnectionConfig.Catalog,
+			Schema:                    newConnectionConfig.Schema,
+			ServiceName:               newConnectionConfig.ServiceName,
+			AirtableAPIKey:            newConnectionConfig.AirtableAPIKey,
+			AirtableBaseID:            newConnectionConfig.AirtableBaseID,
+			PlanetscaleBranch:         newConnectionConfig.PlanetscaleBranch,
+			PlanetscaleOrganization:   newConnectionConfig.PlanetscaleOrganization,
+			PlanetscaleServiceTokenID: newConnectionConfig.PlanetscaleServiceTokenID,
+			PlanetscaleServiceToken:   newConnectionConfig.PlanetscaleServiceToken,
+			InfluxOrg:                 newConnectionConfig.InfluxOrg,
+			InfluxToken:               newConnectionConfig.InfluxToken,
+			ReadPreference:            newConnectionConfig.ReadPreference,
+			TemporalNamespace:         newConnectionConfig.TemporalNamespace,
+			TemporalAddress:           newConnectionConfig.TemporalAddress,
+			NATSCredentialsFile:       newConnectionConfig.NATSCredentialsFile,
+			GoogleProjectID:           newConnectionConfig.GoogleProjectID,
+			FirestoreCredentialsJSON:  newConnectionConfig.FirestoreCredentialsJSON,
+			CloudflareAccountID:       newConnectionConfig.CloudflareAccountID,
+			CloudflareD1DatabaseID:    newConnectionConfig.CloudflareD1DatabaseID,
+			CloudflareAPIToken:        newConnectionConfig.CloudflareAPIToken,
+			APIType:                   yugabyteDBAPIType(newConnectionConfig.Type),
+			Base:                      models.NewBase(),
+		}
+		if err := utils.EncryptConnection(&connection); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to secure connection details: %v", err)
+		}
+		log.Printf("ChatService -> Duplicate -> Swapping connection for duplicate of chat %s to %s/%s", chatID, newConnectionConfig.Type, newConnectionConfig.Database)
+	}
+
 	// Duplicate the chat
 	newChat := &models.Chat{
 		UserID:              userObjID,
-		Connection:          chat.Connection,
+		Connection:          connection,
 		SelectedCollections: chat.SelectedCollections,
 		Settings:            chat.Settings,
 		Base:                models.NewBase(), // Create a new Base with new ID and timestamps
@@ -1166,25 +1944,28 @@ func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, d
 					for i, q := range *originalMsg.Queries {
 						// Create a copy of the query with a new ID
 						queries[i] = models.Query{
-							ID:                     primitive.NewObjectID(),
-							Query:                  q.Query,
-							QueryType:              q.QueryType,
-							Tables:                 q.Tables,
-							Description:            q.Description,
-							RollbackDependentQuery: q.RollbackDependentQuery, // Will update in second pass
-							RollbackQuery:          q.RollbackQuery,
-							ExecutionTime:          q.ExecutionTime,
-							ExampleExecutionTime:   q.ExampleExecutionTime,
-							CanRollback:            q.CanRollback,
-							IsCritical:             q.IsCritical,
-							IsExecuted:             false, // Reset execution state in the duplicate
-							IsRolledBack:           false, // Reset rollback state
-							Error:                  q.Error,
-							ExampleResult:          q.ExampleResult,
-							ExecutionResult:        nil, // Clear execution results
-							IsEdited:               q.IsEdited,
-							Metadata:               q.Metadata,
-							ActionAt:               q.ActionAt,
+							ID:                       primitive.NewObjectID(),
+							Query:                    q.Query,
+							QueryType:                q.QueryType,
+							Tables:                   q.Tables,
+							Description:              q.Description,
+							RollbackDependentQuery:   q.RollbackDependentQuery, // Will update in second pass
+							RollbackQuery:            q.RollbackQuery,
+							ExecutionTime:            q.ExecutionTime,
+							ExampleExecutionTime:     q.ExampleExecutionTime,
+							CanRollback:              q.CanRollback,
+							IsCritical:               q.IsCritical,
+							IsExecuted:               false, // Reset execution state in the duplicate
+							IsRolledBack:             false, // Reset rollback state
+							Error:                    q.Error,
+							ExampleResult:            q.ExampleResult,
+							ExecutionResult:          nil, // Clear execution results
+							IsEdited:                 q.IsEdited,
+							Metadata:                 q.Metadata,
+							ActionAt:                 q.ActionAt,
+							ComplexityScore:          q.ComplexityScore,
+							MigrationRisk:            q.MigrationRisk,
+							RequiresConfirmationText: q.RequiresConfirmationText,
 						}
 
 						// Copy pagination if it exists
@@ -1195,6 +1976,17 @@ func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, d
 								CountQuery:        q.Pagination.CountQuery,
 							}
 						}
+						queries[i].KeysetPagination = q.KeysetPagination
+
+						// Results and errors from the original environment don't apply to the new connection
+						if connectionSwapped {
+							queries[i].Error = nil
+							queries[i].ExecutionTime = nil
+							queries[i].ExampleResult = nil
+							if queries[i].Pagination != nil {
+								queries[i].Pagination.TotalRecordsCount = nil
+							}
+						}
 					}
 					newMsg.Queries = &queries
 				}
@@ -1254,8 +2046,9 @@ func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, d
 
 		log.Printf("Chat duplication completed successfully with messages. New chat ID: %s", newChat.ID.Hex())
 
-		// Copy vectors (schema + messages) in background
-		if s.vectorizationSvc != nil {
+		// Copy vectors (schema + messages) in background. Skipped when the connection was swapped,
+		// since the schema vectors describe the original database; they're rebuilt on first connect.
+		if s.vectorizationSvc != nil && !connectionSwapped {
 			go func() {
 				copyCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 				defer cancel()
@@ -1280,7 +2073,7 @@ func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, d
 		}
 	} else {
 		// No messages duplicated — still copy schema vectors (same DB connection)
-		if s.vectorizationSvc != nil {
+		if s.vectorizationSvc != nil && !connectionSwapped {
 			go func() {
 				copyCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 				defer cancel()
@@ -1436,8 +2229,72 @@ func (s *chatService) Duplicate(userID, chatID string, duplicateMessages bool, d
 	return s.buildChatResponse(newChat), http.StatusOK, nil
 }
 
+// GetSourceChatConnectionConfig returns the decrypted connection config of another chat owned by the user,
+// so a duplicated chat can be pointed at it. Connections are stored per chat, there is no separate template entity.
+func (s *chatService) GetSourceChatConnectionConfig(userID, sourceChatID string) (*dbmanager.ConnectionConfig, uint32, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	sourceChatObjID, err := primitive.ObjectIDFromHex(sourceChatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid source chat ID format")
+	}
+
+	sourceChat, err := s.chatRepo.FindByID(sourceChatObjID)
+	if err != nil || sourceChat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("source chat not found")
+	}
+	if sourceChat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to source chat")
+	}
+
+	conn := sourceChat.Connection
+	if conn.Type == constants.DatabaseTypeSpreadsheet || conn.Type == constants.DatabaseTypeGoogleSheets {
+		return nil, http.StatusBadRequest, fmt.Errorf("the connection of a %s chat cannot be reused", conn.Type)
+	}
+	utils.DecryptConnection(&conn)
+
+	return &dbmanager.ConnectionConfig{
+		Type:                      conn.Type,
+		Host:                      conn.Host,
+		Port:                      conn.Port,
+		Username:                  conn.Username,
+		Password:                  conn.Password,
+		Database:                  conn.Database,
+		AuthDatabase:              conn.AuthDatabase,
+		UseSSL:                    conn.UseSSL,
+		SSLMode:                   conn.SSLMode,
+		SSLCertURL:                conn.SSLCertURL,
+		SSLKeyURL:                 conn.SSLKeyURL,
+		SSLRootCertURL:            conn.SSLRootCertURL,
+		Catalog:                   conn.Catalog,
+		Schema:                    conn.Schema,
+		ServiceName:               conn.ServiceName,
+		AirtableAPIKey:            conn.AirtableAPIKey,
+		AirtableBaseID:            conn.AirtableBaseID,
+		PlanetscaleBranch:         conn.PlanetscaleBranch,
+		PlanetscaleOrganization:   conn.PlanetscaleOrganization,
+		PlanetscaleServiceTokenID: conn.PlanetscaleServiceTokenID,
+		PlanetscaleServiceToken:   conn.PlanetscaleServiceToken,
+		InfluxOrg:                 conn.InfluxOrg,
+		InfluxToken:               conn.InfluxToken,
+		ReadPreference:            conn.ReadPreference,
+		TemporalNamespace:         conn.TemporalNamespace,
+		TemporalAddress:           conn.TemporalAddress,
+		NATSCredentialsFile:       conn.NATSCredentialsFile,
+		GoogleProjectID:           conn.GoogleProjectID,
+		FirestoreCredentialsJSON:  conn.FirestoreCredentialsJSON,
+		CloudflareAccountID:       conn.CloudflareAccountID,
+		CloudflareD1DatabaseID:    conn.CloudflareD1DatabaseID,
+		CloudflareAPIToken:        conn.CloudflareAPIToken,
+	}, http.StatusOK, nil
+}
+
 // List messages for a chat
-func (s *chatService) ListMessages(userID, chatID string, page, pageSize int) (*dtos.MessageListResponse, uint32, error) {
+// ListMessages lists the main thread of a chat, or only the messages of one thread when threadID is set
+func (s *chatService) ListMessages(userID, chatID string, page, pageSize int, threadID string) (*dtos.MessageListResponse, uint32, error) {
 	userObjID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
 		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
@@ -1460,9 +2317,22 @@ func (s *chatService) ListMessages(userID, chatID string, page, pageSize int) (*
 		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
 	}
 
-	messages, total, err := s.chatRepo.FindLatestMessageByChat(chatObjID, page, pageSize)
-	if err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch messages: %v", err)
+	var messages []*models.Message
+	var total int64
+	if threadID != "" {
+		threadObjID, err := primitive.ObjectIDFromHex(threadID)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid thread ID format")
+		}
+		messages, total, err = s.chatRepo.FindMessagesByThread(chatObjID, threadObjID, page, pageSize)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch thread messages: %v", err)
+		}
+	} else {
+		messages, total, err = s.chatRepo.FindLatestMessageByChat(chatObjID, page, pageSize)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch messages: %v", err)
+		}
 	}
 
 	response := &dtos.MessageListResponse{
@@ -1526,14 +2396,24 @@ func (s *chatService) PinMessage(userID, chatID, messageID string) (interface{},
 	// Handle cluster pinning logic
 	if message.Type == string(constants.MessageTypeUser) {
 		// If pinning a user message, also pin the AI response below it
-		messages, _, err := s.chatRepo.FindMessagesByChatAfterTime(chatObjID, message.CreatedAt, 1, 2)
-		if err == nil && len(messages) > 1 {
-			for _, msg := range messages {
-				if msg.ID != message.ID && msg.Type == string(constants.MessageTypeAssistant) {
-					msg.IsPinned = true
-					msg.PinnedAt = &now
-					s.chatRepo.UpdateMessage(msg.ID, &msg)
-					break
+		if message.ThreadID != nil {
+			// Thread replies are matched through their link, the next message in time may belong to the main thread
+			reply, err := s.chatRepo.FindNextMessageByID(message.ID)
+			if err == nil && reply != nil {
+				reply.IsPinned = true
+				reply.PinnedAt = &now
+				s.chatRepo.UpdateMessage(reply.ID, reply)
+			}
+		} else {
+			messages, _, err := s.chatRepo.FindMessagesByChatAfterTime(chatObjID, message.CreatedAt, 1, 2)
+			if err == nil && len(messages) > 1 {
+				for _, msg := range messages {
+					if msg.ID != message.ID && msg.Type == string(constants.MessageTypeAssistant) && msg.ThreadID == nil {
+						msg.IsPinned = true
+						msg.PinnedAt = &now
+						s.chatRepo.UpdateMessage(msg.ID, &msg)
+						break
+					}
 				}
 			}
 		}
@@ -1602,14 +2482,24 @@ func (s *chatService) UnpinMessage(userID, chatID, messageID string) (interface{
 	// Handle cluster unpinning logic
 	if message.Type == string(constants.MessageTypeUser) {
 		// If unpinning a user message, also unpin the AI response below it
-		messages, _, err := s.chatRepo.FindMessagesByChatAfterTime(chatObjID, message.CreatedAt, 1, 2)
-		if err == nil && len(messages) > 1 {
-			for _, msg := range messages {
-				if msg.ID != message.ID && msg.Type == string(constants.MessageTypeAssistant) {
-					msg.IsPinned = false
-					msg.PinnedAt = nil
-					s.chatRepo.UpdateMessage(msg.ID, &msg)
-					break
+		if message.ThreadID != nil {
+			// Thread replies are matched through their link, the next message in time may belong to the main thread
+			reply, err := s.chatRepo.FindNextMessageByID(message.ID)
+			if err == nil && reply != nil {
+				reply.IsPinned = false
+				reply.PinnedAt = nil
+				s.chatRepo.UpdateMessage(reply.ID, reply)
+			}
+		} else {
+			messages, _, err := s.chatRepo.FindMessagesByChatAfterTime(chatObjID, message.CreatedAt, 1, 2)
+			if err == nil && len(messages) > 1 {
+				for _, msg := range messages {
+					if msg.ID != message.ID && msg.Type == string(constants.MessageTypeAssistant) && msg.ThreadID == nil {
+						msg.IsPinned = false
+						msg.PinnedAt = nil
+						s.chatRepo.UpdateMessage(msg.ID, &msg)
+						break
+					}
 				}
 			}
 		}
@@ -1676,7 +2566,7 @@ func (s *chatService) ListPinnedMessages(userID, chatID string) (*dtos.MessageLi
 func (s *chatService) EditQuery(ctx context.Context, userID, chatID, messageID, queryID string, query string) (*dtos.EditQueryResponse, uint32, error) {
 	log.Printf("ChatService -> EditQuery -> userID: %s, chatID: %s, messageID: %s, queryID: %s, query: %s", userID, chatID, messageID, queryID, query)
 
-	_, message, queryData, err := s.verifyQueryOwnership(userID, chatID, messageID, queryID)
+	chat, message, queryData, err := s.verifyQueryOwnership(userID, chatID, messageID, queryID)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
 	}
@@ -1686,11 +2576,16 @@ func (s *chatService) EditQuery(ctx context.Context, userID, chatID, messageID,
 	}
 
 	originalQuery := queryData.Query
+	query = utils.NaturalizeQuery(query, chat.Connection.Type, chat.Settings.QueryStyle)
 	// Fix the query update logic
 	for i := range *message.Queries {
 		if (*message.Queries)[i].ID == queryData.ID {
 			(*message.Queries)[i].Query = query
 			(*message.Queries)[i].IsEdited = true
+			// The edited query may be cheaper or more expensive than the generated one
+			scoreQueryComplexity(s.newQueryComplexityScorer(ctx, chatID), chat.Connection.Type, &(*message.Queries)[i])
+			assessMigrationRisk(utils.NewMigrationSafetyChecker(), chat.Connection.Type, &(*message.Queries)[i])
+			applyTruncateSafeguard(&(*message.Queries)[i])
 			if (*message.Queries)[i].Pagination != nil && (*message.Queries)[i].Pagination.PaginatedQuery != nil {
 				(*message.Queries)[i].Pagination.PaginatedQuery = utils.StringPtr(strings.Replace(*(*message.Queries)[i].Pagination.PaginatedQuery, originalQuery, query, 1))
 			}
@@ -1744,6 +2639,19 @@ func (s *chatService) GetDBConnectionStatus(ctx context.Context, userID, chatID
 	}, http.StatusOK, nil
 }
 
+// CheckDBHealth runs a health check on the chat's connection, which must be connected
+func (s *chatService) CheckDBHealth(userID, chatID string) (*dbmanager.HealthCheckResult, uint32, error) {
+	if _, statusCode, err := s.findOwnedChat(userID, chatID); err != nil {
+		return nil, statusCode, err
+	}
+
+	if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+		return nil, http.StatusNotFound, fmt.Errorf("no connection found")
+	}
+
+	return s.dbManager.HealthCheck(chatID), http.StatusOK, nil
+}
+
 // HandleSchemaChange handles schema changes
 func (s *chatService) HandleSchemaChange(userID, chatID, streamID string, diff interface{}) {
 	log.Printf("ChatService -> HandleSchemaChange -> Starting for chatID: %s", chatID)
@@ -1815,7 +2723,7 @@ func (s *chatService) HandleSchemaChange(userID, chatID, streamID string, diff i
 			if err != nil {
 				log.Printf("ChatService -> HandleSchemaChange -> Error formatting schema with examples: %v", err)
 				// Fall back to the old method if there's an error
-				schemaMsg = s.dbManager.GetSchemaManager().FormatSchemaForLLM(schemaDiff.FullSchema)
+				schemaMsg = s.dbManager.GetSchemaManager().FormatSchemaForLLM(dbmanager.WithoutHiddenTables(schemaDiff.FullSchema, chat.Settings.HiddenTables))
 			}
 		} else {
 			// For subsequent changes, get current schema with examples and show changes
@@ -1828,7 +2736,7 @@ func (s *chatService) HandleSchemaChange(userID, chatID, streamID string, diff i
 					log.Printf("ChatService -> HandleSchemaChange -> Error getting schema: %v", schemaErr)
 					return
 				}
-				schemaMsg = s.dbManager.GetSchemaManager().FormatSchemaForLLM(schema)
+				schemaMsg = s.dbManager.GetSchemaManager().FormatSchemaForLLM(dbmanager.WithoutHiddenTables(schema, chat.Settings.HiddenTables))
 			}
 		}
 
@@ -1840,6 +2748,10 @@ func (s *chatService) HandleSchemaChange(userID, chatID, streamID string, diff i
 
 		log.Printf("ChatService -> HandleSchemaChange -> Schema update saved to chat.Connection")
 
+		if len(schemaDiff.BreakingChanges) > 0 {
+			s.notifySchemaBreakingChanges(ctx, userID, chatID, streamID, schemaDiff.BreakingChanges)
+		}
+
 		// Sync knowledge base and vectorize schema in background
 		// KB sync runs FIRST so enriched schema chunks include KB descriptions.
 		go func() {
@@ -1878,24 +2790,82 @@ func (s *chatService) buildChatResponse(chat *models.Chat) *dtos.ChatResponse {
 		username = *connectionCopy.Username
 	}
 
+	// Secondary connections are decrypted the same way, without exposing credentials
+	var secondaryConnections []dtos.ConnectionResponse
+	for _, secondary := range chat.SecondaryConnections {
+		utils.DecryptConnection(&secondary)
+		var secondaryUsername string
+		if secondary.Username != nil {
+			secondaryUsername = *secondary.Username
+		}
+		secondaryConnections = append(secondaryConnections, dtos.ConnectionResponse{
+			ID:                        secondary.ID.Hex(),
+			Type:                      secondary.Type,
+			Host:                      secondary.Host,
+			Port:                      secondary.Port,
+			Username:                  secondaryUsername,
+			Database:                  secondary.Database,
+			UseSSL:                    secondary.UseSSL,
+			SSLMode:                   secondary.SSLMode,
+			SSLCertURL:                secondary.SSLCertURL,
+			SSLKeyURL:                 secondary.SSLKeyURL,
+			SSLRootCertURL:            secondary.SSLRootCertURL,
+			Catalog:                   secondary.Catalog,
+			Schema:                    secondary.Schema,
+			ServiceName:               secondary.ServiceName,
+			AirtableBaseID:            secondary.AirtableBaseID,
+			PlanetscaleBranch:         secondary.PlanetscaleBranch,
+			PlanetscaleOrganization:   secondary.PlanetscaleOrganization,
+			PlanetscaleServiceTokenID: secondary.PlanetscaleServiceTokenID,
+			InfluxOrg:                 secondary.InfluxOrg,
+			ReadPreference:            secondary.ReadPreference,
+			TemporalNamespace:         secondary.TemporalNamespace,
+			TemporalAddress:           secondary.TemporalAddress,
+			NATSCredentialsFile:       secondary.NATSCredentialsFile,
+			GoogleProjectID:           secondary.GoogleProjectID,
+			CloudflareAccountID:       secondary.CloudflareAccountID,
+			CloudflareD1DatabaseID:    secondary.CloudflareD1DatabaseID,
+			APIType:                   secondary.APIType,
+			VaultSecretPath:           secondary.VaultSecretPath,
+		})
+	}
+
 	return &dtos.ChatResponse{
 		ID:     chat.ID.Hex(),
 		UserID: chat.UserID.Hex(),
 		Connection: dtos.ConnectionResponse{
-			ID:             chat.ID.Hex(),
-			Type:           connectionCopy.Type,
-			Host:           connectionCopy.Host,
-			Port:           connectionCopy.Port,
-			Username:       username,
-			Database:       connectionCopy.Database,
-			IsExampleDB:    connectionCopy.IsExampleDB,
-			UseSSL:         connectionCopy.UseSSL,
-			SSLMode:        connectionCopy.SSLMode,
-			SSLCertURL:     connectionCopy.SSLCertURL,
-			SSLKeyURL:      connectionCopy.SSLKeyURL,
-			SSLRootCertURL: connectionCopy.SSLRootCertURL,
-			GoogleSheetID:  connectionCopy.GoogleSheetID,
-			GoogleSheetURL: connectionCopy.GoogleSheetURL,
+			ID:                        chat.ID.Hex(),
+			Type:                      connectionCopy.Type,
+			Host:                      connectionCopy.Host,
+			Port:                      connectionCopy.Port,
+			Username:                  username,
+			Database:                  connectionCopy.Database,
+			IsExampleDB:               connectionCopy.IsExampleDB,
+			UseSSL:                    connectionCopy.UseSSL,
+			SSLMode:                   connectionCopy.SSLMode,
+			SSLCertURL:                connectionCopy.SSLCertURL,
+			SSLKeyURL:                 connectionCopy.SSLKeyURL,
+			SSLRootCertURL:            connectionCopy.SSLRootCertURL,
+			Catalog:                   connectionCopy.Catalog,
+			Schema:                    connectionCopy.Schema,
+			ServiceName:               connectionCopy.ServiceName,
+			GoogleSheetID:             connectionCopy.GoogleSheetID,
+			GoogleSheetURL:            connectionCopy.GoogleSheetURL,
+			LastSyncedAt:              connectionCopy.LastSyncedAt,
+			AirtableBaseID:            connectionCopy.AirtableBaseID,
+			PlanetscaleBranch:         connectionCopy.PlanetscaleBranch,
+			PlanetscaleOrganization:   connectionCopy.PlanetscaleOrganization,
+			PlanetscaleServiceTokenID: connectionCopy.PlanetscaleServiceTokenID,
+			InfluxOrg:                 connectionCopy.InfluxOrg,
+			ReadPreference:            connectionCopy.ReadPreference,
+			TemporalNamespace:         connectionCopy.TemporalNamespace,
+			TemporalAddress:           connectionCopy.TemporalAddress,
+			NATSCredentialsFile:       connectionCopy.NATSCredentialsFile,
+			GoogleProjectID:           connectionCopy.GoogleProjectID,
+			CloudflareAccountID:       connectionCopy.CloudflareAccountID,
+			CloudflareD1DatabaseID:    connectionCopy.CloudflareD1DatabaseID,
+			APIType:                   connectionCopy.APIType,
+			VaultSecretPath:           connectionCopy.VaultSecretPath,
 		},
 		SelectedCollections: chat.SelectedCollections,
 		CreatedAt:           chat.CreatedAt.Format(time.RFC3339),
@@ -1905,8 +2875,19 @@ func (s *chatService) buildChatResponse(chat *models.Chat) *dtos.ChatResponse {
 			ShareDataWithAI:           chat.Settings.ShareDataWithAI,
 			NonTechMode:               chat.Settings.NonTechMode,
 			AutoGenerateVisualization: chat.Settings.AutoGenerateVisualization,
+			QueryTimeoutSeconds:       chat.Settings.GetQueryTimeoutSeconds(),
+			EncryptedColumns:          chat.Settings.EncryptedColumns,
+			FallbackChain:             chat.Settings.FallbackChain,
+			MaxQueryCostUnits:         chat.Settings.MaxQueryCostUnits,
+			DisableParallelWorkers:    chat.Settings.DisableParallelWorkers,
+			HiddenTables:              chat.Settings.HiddenTables,
+			SystemPromptAppend:        s.decryptSystemPromptAppend(chat),
+			SchemaAliases:             schemaAliasesResponse(chat.Settings.SchemaAliases),
+			QueryStyle:                queryStyleResponse(chat.Settings.QueryStyle),
 		},
-		PreferredLLMModel: chat.PreferredLLMModel,
+		PreferredLLMModel:    chat.PreferredLLMModel,
+		SecondaryConnections: secondaryConnections,
+		Title:                chat.Title,
 	}
 }
 
@@ -1934,22 +2915,40 @@ func (s *chatService) buildMessageResponse(msg *models.Message) *dtos.MessageRes
 	}
 
 	return &dtos.MessageResponse{
-		ID:            msg.ID.Hex(),
-		ChatID:        msg.ChatID.Hex(),
-		UserMessageID: userMessageID,
-		Type:          msg.Type,
-		Content:       msg.Content,
-		Queries:       queriesDto,
-		ActionButtons: actionButtonsDto,
-		IsEdited:      msg.IsEdited,
-		NonTechMode:   msg.NonTechMode,
-		IsPinned:      msg.IsPinned,
-		PinnedAt:      pinnedAt,
-		LLMModel:      msg.LLMModel,
-		LLMModelName:  llmModelName,
-		CreatedAt:     msg.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:     msg.UpdatedAt.Format(time.RFC3339),
+		ID:              msg.ID.Hex(),
+		ChatID:          msg.ChatID.Hex(),
+		UserMessageID:   userMessageID,
+		Type:            msg.Type,
+		Content:         msg.Content,
+		Queries:         queriesDto,
+		ActionButtons:   actionButtonsDto,
+		IsEdited:        msg.IsEdited,
+		NonTechMode:     msg.NonTechMode,
+		IsPinned:        msg.IsPinned,
+		PinnedAt:        pinnedAt,
+		LLMModel:        msg.LLMModel,
+		LLMModelName:    llmModelName,
+		LLMModelUsed:    msg.LLMModelUsed,
+		ParentMessageID: objectIDHexPtr(msg.ParentMessageID),
+		ThreadID:        objectIDHexPtr(msg.ThreadID),
+		FeedbackCount:   msg.FeedbackCount,
+		AvgRating:       msg.AvgRating,
+		Reactions:       msg.Reactions,
+		CreatedAt:       msg.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       msg.UpdatedAt.Format(time.RFC3339),
+
+		RegenerationCount: msg.RegenerationCount,
+		RollbackCount:     msg.RollbackCount,
+	}
+}
+
+// objectIDHexPtr returns the hex form of an optional object ID
+func objectIDHexPtr(id *primitive.ObjectID) *string {
+	if id == nil {
+		return nil
 	}
+	hex := id.Hex()
+	return &hex
 }
 
 // Verify query ownership checks if the query belongs to the message and the message belongs to the chat
@@ -2112,7 +3111,25 @@ func (s *chatService) GetAllTables(ctx context.Context, userID, chatID string) (
 				Password:     chat.Connection.Password,
 				Database:     chat.Connection.Database,
 				AuthDatabase: chat.Connection.AuthDatabase,
+				Catalog:      chat.Connection.Catalog,
+				Schema:       chat.Connection.Schema,
+				ServiceName:  chat.Connection.ServiceName,
 				SchemaName:   schemaName,
+				// Airtable, InfluxDB and Cloudflare D1 connections authenticate with API tokens instead of a password
+				AirtableAPIKey:           chat.Connection.AirtableAPIKey,
+				AirtableBaseID:           chat.Connection.AirtableBaseID,
+				PlanetscaleBranch:        chat.Connection.PlanetscaleBranch,
+				InfluxOrg:                chat.Connection.InfluxOrg,
+				InfluxToken:              chat.Connection.InfluxToken,
+				ReadPreference:           chat.Connection.ReadPreference,
+				TemporalNamespace:        chat.Connection.TemporalNamespace,
+				TemporalAddress:          chat.Connection.TemporalAddress,
+				NATSCredentialsFile:      chat.Connection.NATSCredentialsFile,
+				GoogleProjectID:          chat.Connection.GoogleProjectID,
+				FirestoreCredentialsJSON: chat.Connection.FirestoreCredentialsJSON,
+				CloudflareAccountID:      chat.Connection.CloudflareAccountID,
+				CloudflareD1DatabaseID:   chat.Connection.CloudflareD1DatabaseID,
+				CloudflareAPIToken:       chat.Connection.CloudflareAPIToken,
 			})
 			if connectErr != nil {
 				log.Printf("ChatService -> GetAllTables -> Failed to connect: %v", connectErr)
@@ -2166,8 +3183,11 @@ func (s *chatService) GetAllTables(ctx context.Context, userID, chatID string) (
 				Name:       tableName,
 				Columns:    make([]dtos.ColumnInfo, 0, len(tableSchema.Columns)),
 				IsSelected: isAllSelected || selectedTablesMap[tableName],
+				IsHidden:   dbmanager.IsHiddenTable(chat.Settings.HiddenTables, tableName),
 				RowCount:   tableSchema.RowCount,
 				SizeBytes:  tableSchema.SizeBytes,
+				Engine:     tableSchema.Metadata[dbmanager.ClickHouseMetadataEngine],
+				Metadata:   tableSchema.Metadata,
 			}
 
 			for columnName, columnInfo := range tableSchema.Columns {
@@ -2237,8 +3257,8 @@ func (s *chatService) GetImportMetadata(ctx context.Context, userID, chatID stri
 	}
 
 	if metadata == nil {
-		// No metadata found, return empty response
-		return nil, http.StatusNotFound, fmt.Errorf("no import metadata found")
+		// Metadata is removed once an import completes and expires after IMPORT_METADATA_TTL_HOURS
+		return nil, http.StatusNotFound, fmt.Errorf("Import completed or expired")
 	}
 
 	return metadata, http.StatusOK, nil
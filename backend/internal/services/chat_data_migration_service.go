@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dataMigrationScript is one of the scripts generated by the LLM
+type dataMigrationScript struct {
+	Query       string `json:"query"`
+	Description string `json:"description"`
+}
+
+// dataMigrationPlan is the migration generated by the LLM
+type dataMigrationPlan struct {
+	Summary           string              `json:"summary"`
+	Tables            string              `json:"tables"`
+	ForwardMigration  dataMigrationScript `json:"forwardMigration"`
+	RollbackMigration dataMigrationScript `json:"rollbackMigration"`
+	VerificationQuery dataMigrationScript `json:"verificationQuery"`
+	Warnings          []string            `json:"warnings"`
+}
+
+// GenerateDataMigration asks the LLM for forward, rollback and verification scripts for a described
+// schema or data change, and stores them in the chat as critical DDL_MIGRATION queries the user runs step by step.
+func (s *chatService) GenerateDataMigration(ctx context.Context, userID, chatID string, req *dtos.DataMigrationRequest) (*dtos.DataMigrationResponse, uint32, error) {
+	log.Printf("ChatService -> GenerateDataMigration -> userID: %s, chatID: %s", userID, chatID)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("a migration description is required")
+	}
+	if len(description) > constants.DataMigrationMaxDescriptionLength {
+		return nil, http.StatusBadRequest, fmt.Errorf("the migration description must be at most %d characters", constants.DataMigrationMaxDescriptionLength)
+	}
+
+	dbType := chat.Connection.Type
+	switch dbType {
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB, constants.DatabaseTypeAirtable,
+		constants.DatabaseTypeInfluxDB, constants.DatabaseTypeTemporal, constants.DatabaseTypePocketBase, constants.DatabaseTypeNATS, constants.DatabaseTypeYugabyteDBCQL, constants.DatabaseTypeFirestore, constants.DatabaseTypeSpreadsheet, constants.DatabaseTypeGoogleSheets:
+		return nil, http.StatusBadRequest, fmt.Errorf("migration scripts are only supported for SQL databases")
+	}
+
+	if chat.Connection.CurrentSchema == nil || *chat.Connection.CurrentSchema == "" {
+		return nil, http.StatusConflict, fmt.Errorf("schema is not ready yet, please refresh the schema and try again")
+	}
+
+	plan, modelID, err := s.generateDataMigrationPlan(ctx, chat, dbType, description, *chat.Connection.CurrentSchema)
+	if err != nil {
+		log.Printf("ChatService -> GenerateDataMigration -> Error generating migration: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate migration scripts: %v", err)
+	}
+
+	if plan.ForwardMigration.Query == "" || plan.RollbackMigration.Query == "" || plan.VerificationQuery.Query == "" {
+		return nil, http.StatusInternalServerError, fmt.Errorf("the generated migration is missing a forward, rollback or verification script")
+	}
+	if !isReadOnlyQuery(dbType, plan.VerificationQuery.Query) {
+		return nil, http.StatusInternalServerError, fmt.Errorf("the generated verification query is not read-only")
+	}
+
+	userMsg := models.NewMessage(userObjID, chatObjID, string(constants.MessageTypeUser), fmt.Sprintf("Generate a migration: %s", description), nil, nil)
+	if err := s.chatRepo.CreateMessage(userMsg); err != nil {
+		log.Printf("ChatService -> GenerateDataMigration -> Error saving user message: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save migration request: %v", err)
+	}
+
+	queries := buildDataMigrationQueries(plan, modelID)
+	content := plan.Summary
+	if content == "" {
+		content = "Here is the migration, run the forward script first, then the verification query. Use the rollback script to revert it."
+	}
+	assistantMsg := models.NewMessage(userObjID, chatObjID, string(constants.MessageTypeAssistant), content, &queries, &userMsg.ID)
+	// Keep the assistant message after the user message in sorted results
+	assistantMsg.CreatedAt = userMsg.CreatedAt.Add(time.Second)
+	assistantMsg.UpdatedAt = assistantMsg.CreatedAt
+	if modelID != "" {
+		assistantMsg.LLMModel = &modelID
+	}
+	if err := s.chatRepo.CreateMessage(assistantMsg); err != nil {
+		log.Printf("ChatService -> GenerateDataMigration -> Error saving migration message: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save migration scripts: %v", err)
+	}
+
+	log.Printf("ChatService -> GenerateDataMigration -> Saved migration message %s with %d warnings", assistantMsg.ID.Hex(), len(plan.Warnings))
+	return &dtos.DataMigrationResponse{
+		UserMessage: s.buildMessageResponse(userMsg),
+		Message:     s.buildMessageResponse(assistantMsg),
+		Warnings:    plan.Warnings,
+	}, http.StatusOK, nil
+}
+
+// generateDataMigrationPlan calls the LLM with GeminiDataMigrationPrompt and parses the generated migration.
+// It returns the model ID used, empty when the default model was used.
+func (s *chatService) generateDataMigrationPlan(ctx context.Context, chat *models.Chat, dbType, description, schemaContext string) (*dataMigrationPlan, string, error) {
+	llmClient := s.llmClient
+	modelID := ""
+	if chat.PreferredLLMModel != nil && *chat.PreferredLLMModel != "" {
+		modelID = *chat.PreferredLLMModel
+		if s.llmManager != nil {
+			if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+				if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+					llmClient = providerClient
+				}
+			}
+		}
+	}
+
+	if llmClient == nil {
+		return nil, "", fmt.Errorf("no LLM client available")
+	}
+
+	userMessage := constants.GetDataMigrationUserMessage(dbType, description, schemaContext)
+	response, err := llmClient.GenerateRawJSON(ctx, constants.GeminiDataMigrationPrompt, userMessage, modelID)
+	if err != nil {
+		return nil, "", fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	var plan dataMigrationPlan
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &plan); err != nil {
+		return nil, "", fmt.Errorf("failed to parse migration JSON: %v", err)
+	}
+
+	plan.ForwardMigration.Query = strings.TrimSpace(plan.ForwardMigration.Query)
+	plan.RollbackMigration.Query = strings.TrimSpace(plan.RollbackMigration.Query)
+	plan.VerificationQuery.Query = strings.TrimSuffix(strings.TrimSpace(plan.VerificationQuery.Query), ";")
+
+	return &plan, modelID, nil
+}
+
+// buildDataMigrationQueries turns a migration into forward, rollback and verification queries.
+// Every step is critical so it always asks for confirmation, and the forward step rolls back with the rollback script.
+func buildDataMigrationQueries(plan *dataMigrationPlan, modelID string) []models.Query {
+	queryType := constants.QueryTypeDDLMigration
+	var tables *string
+	if plan.Tables != "" {
+		tables = &plan.Tables
+	}
+
+	newQuery := func(script dataMigrationScript, label string) models.Query {
+		return models.Query{
+			ID:          primitive.NewObjectID(),
+			Query:       script.Query,
+			QueryType:   &queryType,
+			Tables:      tables,
+			Description: fmt.Sprintf("%s: %s", label, script.Description),
+			IsCritical:  true,
+			LLMModel:    modelID,
+		}
+	}
+
+	forward := newQuery(plan.ForwardMigration, "Forward migration")
+	forward.RollbackQuery = &plan.RollbackMigration.Query
+	forward.CanRollback = true
+
+	return []models.Query{
+		forward,
+		newQuery(plan.RollbackMigration, "Rollback migration"),
+		newQuery(plan.VerificationQuery, "Verification query"),
+	}
+}
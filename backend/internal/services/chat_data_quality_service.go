@@ -0,0 +1,417 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dataQualityCheck is a single check query generated by the LLM
+type dataQualityCheck struct {
+	Table     string `json:"table"`
+	CheckType string `json:"checkType"`
+	Column    string `json:"column"`
+	Query     string `json:"query"`
+}
+
+var (
+	readOnlyWritePattern = regexp.MustCompile(`(?i)\b(insert|update|delete|drop|alter|truncate|create|grant|revoke|into|setval|nextval)\b|\$out\b|\$merge\b`)
+	// readOnlyLockingPattern matches row locks taken by SELECT ... FOR UPDATE/SHARE and MySQL's LOCK IN SHARE MODE
+	readOnlyLockingPattern = regexp.MustCompile(`(?i)\bfor\s+(update|share|no\s+key\s+update|key\s+share)\b|\block\s+in\s+share\s+mode\b`)
+	readOnlySQLPattern     = regexp.MustCompile(`(?i)^(select|with)\b`)
+	readOnlyMongoPattern   = regexp.MustCompile(`^db\.[^.\s]+\.(aggregate|find|countDocuments)\(`)
+)
+
+// GenerateDataQualityReport asks the LLM for read-only data quality checks on the requested tables,
+// runs them in parallel and aggregates the results into per-table health scores.
+func (s *chatService) GenerateDataQualityReport(ctx context.Context, userID, chatID string, req *dtos.DataQualityRequest) (*dtos.DataQualityReport, uint32, error) {
+	log.Printf("ChatService -> GenerateDataQualityReport -> userID: %s, chatID: %s, tables: %v", userID, chatID, req.Tables)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	// Deduplicate and trim the requested tables
+	tables := make([]string, 0, len(req.Tables))
+	seen := make(map[string]bool, len(req.Tables))
+	for _, table := range req.Tables {
+		table = strings.TrimSpace(table)
+		if table == "" || seen[table] {
+			continue
+		}
+		seen[table] = true
+		tables = append(tables, table)
+	}
+	if len(tables) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("at least one table is required")
+	}
+	if len(tables) > constants.DataQualityMaxTables {
+		return nil, http.StatusBadRequest, fmt.Errorf("a data quality report supports at most %d tables", constants.DataQualityMaxTables)
+	}
+
+	// Make sure we have a live connection
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		log.Printf("ChatService -> GenerateDataQualityReport -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("data-quality-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+		connInfo, exists = s.dbManager.GetConnectionInfo(chatID)
+		if !exists {
+			return nil, http.StatusInternalServerError, fmt.Errorf("connection created but not found in manager")
+		}
+	}
+	dbType := connInfo.Config.Type
+
+	// Prefer the schema limited to the requested tables; fall back to the full current schema
+	schemaContext, err := s.dbManager.GetSchemaManager().FormatSchemaForTables(ctx, chatID, tables)
+	if err != nil || strings.TrimSpace(schemaContext) == "" {
+		log.Printf("ChatService -> GenerateDataQualityReport -> Filtered schema unavailable, using current schema: %v", err)
+		if chat.Connection.CurrentSchema == nil || *chat.Connection.CurrentSchema == "" {
+			return nil, http.StatusConflict, fmt.Errorf("schema is not ready yet, please refresh the schema and try again")
+		}
+		schemaContext = *chat.Connection.CurrentSchema
+	}
+
+	checks, err := s.generateDataQualityChecks(ctx, chat, dbType, tables, schemaContext)
+	if err != nil {
+		log.Printf("ChatService -> GenerateDataQualityReport -> Error generating checks: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate data quality checks: %v", err)
+	}
+
+	// Drop checks that are not read-only or target tables outside the request
+	validChecks := make([]dataQualityCheck, 0, len(checks))
+	for _, check := range checks {
+		if !seen[check.Table] {
+			log.Printf("ChatService -> GenerateDataQualityReport -> Skipping check for unrequested table: %s", check.Table)
+			continue
+		}
+		if !isReadOnlyQuery(dbType, check.Query) {
+			log.Printf("ChatService -> GenerateDataQualityReport -> Skipping non read-only query: %s", check.Query)
+			continue
+		}
+		validChecks = append(validChecks, check)
+	}
+	if len(validChecks) == 0 {
+		return nil, http.StatusInternalServerError, fmt.Errorf("no valid data quality checks were generated")
+	}
+
+	results := s.runDataQualityChecks(ctx, chatID, validChecks)
+
+	// Group the results by table, keeping the requested table order
+	resultsByTable := make(map[string][]dtos.DataQualityCheckResult, len(tables))
+	for i, check := range validChecks {
+		resultsByTable[check.Table] = append(resultsByTable[check.Table], results[i])
+	}
+
+	report := &dtos.DataQualityReport{
+		ChatID:       chatID,
+		DatabaseType: dbType,
+		Tables:       make([]dtos.TableQualityReport, 0, len(tables)),
+		GeneratedAt:  time.Now(),
+	}
+	var scoreSum float64
+	for _, table := range tables {
+		tableReport := buildTableQualityReport(table, resultsByTable[table])
+		scoreSum += tableReport.HealthScore
+		report.Tables = append(report.Tables, tableReport)
+	}
+	report.OverallScore = math.Round(scoreSum/float64(len(tables))*10) / 10
+
+	log.Printf("ChatService -> GenerateDataQualityReport -> Completed %d checks across %d tables, overall score: %.1f", len(validChecks), len(tables), report.OverallScore)
+	return report, http.StatusOK, nil
+}
+
+// generateDataQualityChecks calls the LLM with GeminiDataQualityPrompt and parses the generated checks.
+func (s *chatService) generateDataQualityChecks(ctx context.Context, chat *models.Chat, dbType string, tables []string, schemaContext string) ([]dataQualityCheck, error) {
+	llmClient := s.llmClient
+	modelID := ""
+	if chat.PreferredLLMModel != nil && *chat.PreferredLLMModel != "" {
+		modelID = *chat.PreferredLLMModel
+		if s.llmManager != nil {
+			if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+				if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+					llmClient = providerClient
+				}
+			}
+		}
+	}
+
+	if llmClient == nil {
+		return nil, fmt.Errorf("no LLM client available")
+	}
+
+	userMessage := constants.GetDataQualityUserMessage(dbType, tables, schemaContext)
+	response, err := llmClient.GenerateRawJSON(ctx, constants.GeminiDataQualityPrompt, userMessage, modelID)
+	if err != nil {
+		return nil, fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	var parsed struct {
+		Checks []dataQualityCheck `json:"checks"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse data quality checks JSON: %v", err)
+	}
+
+	for i := range parsed.Checks {
+		parsed.Checks[i].Table = strings.TrimSpace(parsed.Checks[i].Table)
+		parsed.Checks[i].Query = strings.TrimSuffix(strings.TrimSpace(parsed.Checks[i].Query), ";")
+	}
+
+	log.Printf("ChatService -> generateDataQualityChecks -> LLM generated %d checks", len(parsed.Checks))
+	return parsed.Checks, nil
+}
+
+// runDataQualityChecks executes the checks in parallel, bounded by DataQualityMaxParallelQueries.
+// The returned results are in the same order as checks.
+func (s *chatService) runDataQualityChecks(ctx context.Context, chatID string, checks []dataQualityCheck) []dtos.DataQualityCheckResult {
+	results := make([]dtos.DataQualityCheckResult, len(checks))
+	semaphore := make(chan struct{}, constants.DataQualityMaxParallelQueries)
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check dataQualityCheck) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			queryCtx, cancel := context.WithTimeout(ctx, time.Duration(constants.DataQualityQueryTimeoutSeconds)*time.Second)
+			defer cancel()
+
+			startTime := time.Now()
+			// A unique stream ID per check keeps parallel executions from clobbering each other's tracking entry
+			streamID := fmt.Sprintf("data-quality-%s-%d", chatID, i)
+			result, queryErr := s.dbManager.ExecuteQuery(queryCtx, chatID, "", "", streamID, check.Query, "SELECT", false, false)
+
+			checkResult := dtos.DataQualityCheckResult{
+				CheckType:       check.CheckType,
+				Column:          check.Column,
+				Query:           check.Query,
+				ExecutionTimeMs: time.Since(startTime).Milliseconds(),
+			}
+			if queryErr != nil {
+				errorMsg := queryErr.Message
+				if queryErr.Details != "" {
+					errorMsg = fmt.Sprintf("%s: %s", queryErr.Message, queryErr.Details)
+				}
+				checkResult.Error = &errorMsg
+			} else if result != nil {
+				checkResult.Result = extractResultRows(result.Result)
+			}
+			results[i] = checkResult
+		}(i, check)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// buildTableQualityReport aggregates the check results for a table into a health score and list of issues.
+// The score starts at 100 and is reduced by the average NULL percentage, the duplicate row ratio,
+// columns with outliers and columns holding a single constant value.
+func buildTableQualityReport(table string, results []dtos.DataQualityCheckResult) dtos.TableQualityReport {
+	report := dtos.TableQualityReport{
+		TableName:      table,
+		NullPercentage: make(map[string]float64),
+		Issues:         []string{},
+		Checks:         results,
+	}
+	if report.Checks == nil {
+		report.Checks = []dtos.DataQualityCheckResult{}
+	}
+
+	succeeded, failed := 0, 0
+	outlierColumns, constantColumns := 0, 0
+	var duplicateRatio float64
+
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			continue
+		}
+		succeeded++
+
+		switch result.CheckType {
+		case constants.DataQualityCheckNullPercentage:
+			for _, row := range result.Result {
+				column := fmt.Sprintf("%v", row["column_name"])
+				if pct, ok := dataQualityFloat(row["null_percentage"]); ok {
+					report.NullPercentage[column] = math.Round(pct*100) / 100
+				}
+				if total, ok := dataQualityFloat(row["total_rows"]); ok && int64(total) > report.TotalRows {
+					report.TotalRows = int64(total)
+				}
+			}
+		case constants.DataQualityCheckDuplicates:
+			if len(result.Result) == 0 {
+				continue
+			}
+			row := result.Result[0]
+			if total, ok := dataQualityFloat(row["total_rows"]); ok && int64(total) > report.TotalRows {
+				report.TotalRows = int64(total)
+			}
+			if duplicates, ok := dataQualityFloat(row["duplicate_count"]); ok {
+				report.DuplicateRows = int64(duplicates)
+			}
+		case constants.DataQualityCheckNumericStats:
+			for _, row := range result.Result {
+				minValue, minOK := dataQualityFloat(row["min_value"])
+				maxValue, maxOK := dataQualityFloat(row["max_value"])
+				avgValue, avgOK := dataQualityFloat(row["avg_value"])
+				stddev, stddevOK := dataQualityFloat(row["stddev_value"])
+				if !minOK || !maxOK || !avgOK || !stddevOK || stddev == 0 {
+					continue
+				}
+				threshold := constants.DataQualityOutlierStdDevFactor * stddev
+				if maxValue-avgValue > threshold || avgValue-minValue > threshold {
+					outlierColumns++
+					report.Issues = append(report.Issues, fmt.Sprintf("Column %s has potential outliers (min %v, max %v, avg %.2f, stddev %.2f)", dataQualityColumn(result, row), minValue, maxValue, avgValue, stddev))
+				}
+			}
+		case constants.DataQualityCheckDistinctCounts:
+			for _, row := range result.Result {
+				distinct, distinctOK := dataQualityFloat(row["distinct_count"])
+				total, totalOK := dataQualityFloat(row["total_rows"])
+				if distinctOK && totalOK && distinct == 1 && total > 1 {
+					constantColumns++
+					report.Issues = append(report.Issues, fmt.Sprintf("Column %s holds a single value across all rows", dataQualityColumn(result, row)))
+				}
+			}
+		}
+	}
+
+	if succeeded == 0 {
+		report.Issues = append(report.Issues, "No data quality checks succeeded for this table")
+		return report
+	}
+	if failed > 0 {
+		report.Issues = append(report.Issues, fmt.Sprintf("%d of %d checks failed to run", failed, len(results)))
+	}
+
+	var avgNullPct float64
+	if len(report.NullPercentage) > 0 {
+		columns := make([]string, 0, len(report.NullPercentage))
+		for column := range report.NullPercentage {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		for _, column := range columns {
+			pct := report.NullPercentage[column]
+			avgNullPct += pct
+			if pct >= 50 {
+				report.Issues = append(report.Issues, fmt.Sprintf("Column %s is %.1f%% NULL", column, pct))
+			}
+		}
+		avgNullPct /= float64(len(report.NullPercentage))
+	}
+
+	if report.TotalRows > 0 && report.DuplicateRows > 0 {
+		duplicateRatio = math.Min(float64(report.DuplicateRows)/float64(report.TotalRows), 1)
+		report.Issues = append(report.Issues, fmt.Sprintf("%d duplicate rows (%.1f%%)", report.DuplicateRows, duplicateRatio*100))
+	}
+
+	score := 100 - avgNullPct*0.5 - duplicateRatio*100*0.3 - float64(outlierColumns)*5 - float64(constantColumns)*2
+	report.HealthScore = math.Round(math.Max(score, 0)*10) / 10
+	return report
+}
+
+// isReadOnlyQuery reports whether a generated query only reads data and is safe to run unattended.
+// SELECT ... INTO, row locks and sequence calls are rejected, as they write or block writers despite starting with SELECT.
+func isReadOnlyQuery(dbType, query string) bool {
+	query = strings.TrimSpace(query)
+	if query == "" || readOnlyWritePattern.MatchString(query) || readOnlyLockingPattern.MatchString(query) {
+		return false
+	}
+	if dbType == constants.DatabaseTypeMongoDB || dbType == constants.DatabaseTypeFerretDB {
+		return readOnlyMongoPattern.MatchString(query)
+	}
+	// Reject multiple statements
+	if strings.Contains(query, ";") {
+		return false
+	}
+	return readOnlySQLPattern.MatchString(query)
+}
+
+// extractResultRows normalizes a query execution result into rows.
+// SQL drivers and MongoDB wrap rows as {"results": [...]}; anything else is treated as a single row.
+func extractResultRows(result interface{}) []map[string]interface{} {
+	var rows []map[string]interface{}
+	switch v := result.(type) {
+	case nil:
+		return nil
+	case []map[string]interface{}:
+		rows = v
+	case map[string]interface{}:
+		if results, ok := v["results"]; ok {
+			if b, err := json.Marshal(results); err == nil {
+				json.Unmarshal(b, &rows)
+			}
+		} else {
+			rows = []map[string]interface{}{v}
+		}
+	default:
+		if b, err := json.Marshal(v); err == nil {
+			json.Unmarshal(b, &rows)
+		}
+	}
+	return rows
+}
+
+// dataQualityFloat converts a numeric result value (which may arrive as a number or string) to float64.
+func dataQualityFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, false
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		f, err := strconv.ParseFloat(strings.TrimSpace(fmt.Sprintf("%v", v)), 64)
+		return f, err == nil
+	}
+}
+
+// dataQualityColumn returns the column a result row refers to, falling back to the check's column.
+func dataQualityColumn(result dtos.DataQualityCheckResult, row map[string]interface{}) string {
+	if column, ok := row["column_name"]; ok && column != nil {
+		return fmt.Sprintf("%v", column)
+	}
+	return result.Column
+}
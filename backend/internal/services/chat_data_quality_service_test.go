@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+
+	"neobase-ai/internal/constants"
+)
+
+func TestIsReadOnlyQuery(t *testing.T) {
+	tests := []struct {
+		dbType string
+		query  string
+		want   bool
+	}{
+		{constants.DatabaseTypePostgreSQL, "SELECT count(*) FROM users WHERE email IS NULL", true},
+		{constants.DatabaseTypePostgreSQL, "WITH dupes AS (SELECT email FROM users GROUP BY email HAVING count(*) > 1) SELECT count(*) FROM dupes", true},
+		{constants.DatabaseTypePostgreSQL, "SELECT * INTO users_copy FROM users", false},
+		{constants.DatabaseTypePostgreSQL, "SELECT * FROM users FOR UPDATE", false},
+		{constants.DatabaseTypePostgreSQL, "SELECT * FROM users FOR NO KEY UPDATE SKIP LOCKED", false},
+		{constants.DatabaseTypePostgreSQL, "SELECT id FROM users FOR SHARE", false},
+		{constants.DatabaseTypeMySQL, "SELECT id FROM users LOCK IN SHARE MODE", false},
+		{constants.DatabaseTypeMySQL, "SELECT id FROM users INTO OUTFILE '/tmp/users.csv'", false},
+		{constants.DatabaseTypePostgreSQL, "SELECT setval('users_id_seq', 1)", false},
+		{constants.DatabaseTypePostgreSQL, "SELECT nextval('users_id_seq')", false},
+		{constants.DatabaseTypePostgreSQL, "SELECT 1; DELETE FROM users", false},
+		{constants.DatabaseTypeMongoDB, `db.users.countDocuments({"email": null})`, true},
+		{constants.DatabaseTypeMongoDB, `db.users.aggregate([{"$out": "users_copy"}])`, false},
+	}
+
+	for _, tt := range tests {
+		if got := isReadOnlyQuery(tt.dbType, tt.query); got != tt.want {
+			t.Errorf("isReadOnlyQuery(%s, %q) = %v, want %v", tt.dbType, tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetQueryDependencyGraph builds the graph of the tables a message's query touches and the joins between them.
+// The query is parsed, not executed. Row counts and foreign keys come from the cached schema, so the graph
+// needs no database connection and simply goes without them before the schema was first fetched.
+func (s *chatService) GetQueryDependencyGraph(ctx context.Context, userID, chatID, messageID, queryID string) (*dtos.DependencyGraph, uint32, error) {
+	log.Printf("ChatService -> GetQueryDependencyGraph -> userID: %s, chatID: %s, messageID: %s, queryID: %s", userID, chatID, messageID, queryID)
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	messageObjID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid message ID")
+	}
+	message, err := s.chatRepo.FindMessageByID(messageObjID)
+	if err != nil || message == nil || message.ChatID != chat.ID {
+		return nil, http.StatusNotFound, fmt.Errorf("message not found")
+	}
+
+	queryText := ""
+	found := false
+	if message.Queries != nil {
+		for _, query := range *message.Queries {
+			if query.ID.Hex() == queryID {
+				queryText = query.Query
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, http.StatusNotFound, fmt.Errorf("query not found in message")
+	}
+
+	parsed, err := utils.ParseQueryTables(queryText, chat.Connection.Type)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var schema *dbmanager.SchemaInfo
+	if stored, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID); err != nil {
+		log.Printf("ChatService -> GetQueryDependencyGraph -> No stored schema for chat %s, building the graph without row counts and foreign keys: %v", chatID, err)
+	} else {
+		schema = stored
+	}
+
+	return buildDependencyGraph(parsed, schema), http.StatusOK, nil
+}
+
+// buildDependencyGraph turns the parsed tables and joins into a graph. Joins without a condition, such as the
+// tables of a comma separated FROM list, take it from a foreign key between the two tables, and foreign keys
+// between tables of the query that it does not join on become FOREIGN_KEY edges.
+func buildDependencyGraph(parsed *utils.QueryTables, schema *dbmanager.SchemaInfo) *dtos.DependencyGraph {
+	// Schema table names are matched case-insensitively, the graph uses the schema's spelling
+	schemaTables := make(map[string]dbmanager.TableSchema)
+	if schema != nil {
+		for name, table := range schema.Tables {
+			schemaTables[strings.ToLower(name)] = table
+		}
+	}
+	tableName := func(name string) string {
+		if table, ok := schemaTables[strings.ToLower(name)]; ok && table.Name != "" {
+			return table.Name
+		}
+		return name
+	}
+
+	graph := &dtos.DependencyGraph{
+		Nodes:     make([]dtos.DependencyGraphNode, 0, len(parsed.Tables)),
+		Edges:     make([]dtos.DependencyGraphEdge, 0, len(parsed.Joins)),
+		Adjacency: make(map[string][]string, len(parsed.Tables)),
+	}
+	for _, name := range parsed.Tables {
+		name = tableName(name)
+		graph.Nodes = append(graph.Nodes, dtos.DependencyGraphNode{
+			Table:    name,
+			RowCount: schemaTables[strings.ToLower(name)].RowCount,
+		})
+		graph.Adjacency[name] = []string{}
+	}
+
+	joined := make(map[string]bool)
+	addEdge := func(edge dtos.DependencyGraphEdge) {
+		graph.Edges = append(graph.Edges, edge)
+		graph.Adjacency[edge.From] = append(graph.Adjacency[edge.From], edge.To)
+		joined[strings.ToLower(edge.From)+"\x00"+strings.ToLower(edge.To)] = true
+		joined[strings.ToLower(edge.To)+"\x00"+strings.ToLower(edge.From)] = true
+	}
+
+	for _, join := range parsed.Joins {
+		edge := dtos.DependencyGraphEdge{
+			From:          tableName(join.From),
+			To:            tableName(join.To),
+			JoinCondition: join.Condition,
+			JoinType:      join.Type,
+		}
+		if edge.JoinCondition == "" {
+			if fk, from, to, ok := foreignKeyBetween(schemaTables, edge.From, edge.To); ok {
+				edge.JoinCondition = fmt.Sprintf("%s.%s = %s.%s", from, fk.ColumnName, to, fk.RefColumn)
+			}
+		}
+		addEdge(edge)
+	}
+
+	for i, node := range graph.Nodes {
+		for _, other := range graph.Nodes[i+1:] {
+			if joined[strings.ToLower(node.Table)+"\x00"+strings.ToLower(other.Table)] {
+				continue
+			}
+			if fk, from, to, ok := foreignKeyBetween(schemaTables, node.Table, other.Table); ok {
+				addEdge(dtos.DependencyGraphEdge{
+					From:          from,
+					To:            to,
+					JoinCondition: fmt.Sprintf("%s.%s = %s.%s", from, fk.ColumnName, to, fk.RefColumn),
+					JoinType:      "FOREIGN_KEY",
+				})
+			}
+		}
+	}
+
+	return graph
+}
+
+// foreignKeyBetween finds a foreign key from either table to the other, and returns the referencing table first
+func foreignKeyBetween(schemaTables map[string]dbmanager.TableSchema, a, b string) (dbmanager.ForeignKey, string, string, bool) {
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		table, ok := schemaTables[strings.ToLower(pair[0])]
+		if !ok {
+			continue
+		}
+		// Sorted so a table with several keys to the other gives the same edge every time
+		names := make([]string, 0, len(table.ForeignKeys))
+		for name := range table.ForeignKeys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fk := table.ForeignKeys[name]
+			if strings.EqualFold(fk.RefTable, pair[1]) || strings.EqualFold(fk.RefTable[strings.LastIndex(fk.RefTable, ".")+1:], pair[1]) {
+				return fk, pair[0], pair[1], true
+			}
+		}
+	}
+	return dbmanager.ForeignKey{}, "", "", false
+}
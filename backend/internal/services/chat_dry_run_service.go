@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/dbmanager"
+)
+
+// executeDryRun counts the rows an UPDATE, DELETE or INSERT would change without running it.
+// The write is rewritten into a read-only count query, so the message and query are left untouched.
+func (s *chatService) executeDryRun(ctx context.Context, userID, chatID string, req *dtos.ExecuteQueryRequest, chat *models.Chat, query *models.Query, queryTimeout time.Duration) (*dtos.QueryExecutionResponse, uint32, error) {
+	queryType := ""
+	if query.QueryType != nil {
+		queryType = strings.ToUpper(strings.TrimSpace(*query.QueryType))
+	}
+	if queryType != "UPDATE" && queryType != "DELETE" && queryType != "INSERT" {
+		return nil, http.StatusBadRequest, fmt.Errorf("dry run is only available for UPDATE, DELETE and INSERT queries")
+	}
+
+	plan, err := dbmanager.BuildDryRunPlan(chat.Connection.Type, query.Query, queryType)
+	if err != nil {
+		log.Printf("ChatService -> executeDryRun -> Failed to build dry run: %v", err)
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to prepare dry run: %v", err)
+	}
+
+	result := &dtos.DryRunResult{
+		QueryType:  queryType,
+		CountQuery: plan.CountQuery,
+	}
+	if plan.StaticCount != nil {
+		result.AffectedRows = *plan.StaticCount
+	} else {
+		countCtx, countCancel := context.WithTimeout(ctx, queryTimeout)
+		countResult, queryErr := s.dbManager.ExecuteQuery(countCtx, chatID, req.MessageID, req.QueryID, req.StreamID, plan.CountQuery, "SELECT", false, true)
+		countCancel()
+		if queryErr != nil {
+			log.Printf("ChatService -> executeDryRun -> Error executing count query: %v", queryErr)
+			return nil, http.StatusBadRequest, fmt.Errorf("dry run failed: %s", queryErr.Message)
+		}
+		if countResult == nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("dry run returned no result")
+		}
+
+		count, err := dbmanager.ExtractDryRunCount(countResult.Result)
+		if err != nil {
+			log.Printf("ChatService -> executeDryRun -> Failed to read count: %v", err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("dry run failed: %v", err)
+		}
+		if plan.MaxCount > 0 && count > plan.MaxCount {
+			count = plan.MaxCount
+		}
+		result.AffectedRows = count
+	}
+
+	log.Printf("ChatService -> executeDryRun -> %s would affect %d rows", queryType, result.AffectedRows)
+
+	s.sendStreamEvent(userID, chatID, req.StreamID, dtos.StreamResponse{
+		Event: constants.StreamEventDryRunResult,
+		Data: map[string]interface{}{
+			"message_id":     req.MessageID,
+			"query_id":       req.QueryID,
+			"dry_run_result": result,
+		},
+	})
+
+	return &dtos.QueryExecutionResponse{
+		ChatID:       chatID,
+		MessageID:    req.MessageID,
+		QueryID:      req.QueryID,
+		IsExecuted:   query.IsExecuted,
+		IsRolledBack: query.IsRolledBack,
+		DryRunResult: result,
+	}, http.StatusOK, nil
+}
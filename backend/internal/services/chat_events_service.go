@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/pubsub"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// publishChatEvent broadcasts an event to everyone subscribed to the chat's shared event stream.
+// Publishing is best-effort and never fails the calling operation.
+func (s *chatService) publishChatEvent(chatID, eventType string, data interface{}) {
+	if s.chatPubSub == nil {
+		return
+	}
+
+	payload, err := json.Marshal(dtos.ChatEvent{
+		Type:      eventType,
+		ChatID:    chatID,
+		Data:      data,
+		Timestamp: time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.Printf("ChatService -> publishChatEvent -> Failed to marshal event: %v", err)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.chatPubSub.Publish(ctx, constants.ChatEventsChannel(chatID), payload); err != nil {
+			log.Printf("ChatService -> publishChatEvent -> Failed to publish %s event for chatID %s: %v", eventType, chatID, err)
+		}
+	}()
+}
+
+// SubscribeChatEvents subscribes to the shared event stream of a chat the user has access to.
+// The caller must Close the returned subscription.
+func (s *chatService) SubscribeChatEvents(ctx context.Context, userID, chatID string) (pubsub.Subscription, uint32, error) {
+	if s.chatPubSub == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("chat events are not available")
+	}
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+	if chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
+	}
+
+	sub, err := s.chatPubSub.Subscribe(ctx, constants.ChatEventsChannel(chatID))
+	if err != nil {
+		log.Printf("ChatService -> SubscribeChatEvents -> Failed to subscribe for chatID %s: %v", chatID, err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to subscribe to chat events")
+	}
+
+	return sub, http.StatusOK, nil
+}
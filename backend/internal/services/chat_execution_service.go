@@ -8,8 +8,10 @@ import (
 	"log"
 	"math/big"
 	mathrand "math/rand"
+	"neobase-ai/config"
 	"neobase-ai/internal/apis/dtos"
 	"neobase-ai/internal/constants"
+	"neobase-ai/internal/logger"
 	"neobase-ai/internal/models"
 	"neobase-ai/internal/utils"
 	"neobase-ai/pkg/dbmanager"
@@ -134,7 +136,7 @@ func (s *chatService) convertMessagesToLLMFormat(ctx context.Context, chat *mode
 					return nil, fmt.Errorf("failed to get schema: %v", schemaErr)
 				}
 
-				formattedSchema = s.dbManager.GetSchemaManager().FormatSchemaForLLM(schema)
+				formattedSchema = s.dbManager.GetSchemaManager().FormatSchemaForLLM(dbmanager.WithoutHiddenTables(schema, chat.Settings.HiddenTables))
 			}
 
 			schemaStr = formattedSchema
@@ -143,6 +145,40 @@ func (s *chatService) convertMessagesToLLMFormat(ctx context.Context, chat *mode
 		}
 	}
 
+	// Federated chats also describe the secondary database so the LLM can split cross-database questions
+	if federationContext := s.buildFederationContext(ctx, chat); federationContext != "" {
+		ragContext += federationContext
+	}
+
+	// Supabase chats tell the LLM whether Row Level Security may be filtering results
+	if chat.Connection.Type == constants.DatabaseTypeSupabase {
+		hasAnonKey := chat.Connection.SupabaseAnonKey != nil && *chat.Connection.SupabaseAnonKey != ""
+		hasServiceRoleKey := chat.Connection.SupabaseServiceRoleKey != nil && *chat.Connection.SupabaseServiceRoleKey != ""
+		ragContext += constants.GetSupabaseConnectionContext(hasAnonKey, hasServiceRoleKey)
+	}
+
+	// Neon chats tell the LLM which branch endpoint the queries run against
+	if chat.Connection.Type == constants.DatabaseTypeNeon {
+		ragContext += constants.GetNeonConnectionContext(chat.Connection.Host)
+	}
+
+	// PlanetScale chats tell the LLM which branch the queries run against
+	if chat.Connection.Type == constants.DatabaseTypePlanetscale {
+		branch := ""
+		if chat.Connection.PlanetscaleBranch != nil {
+			branch = *chat.Connection.PlanetscaleBranch
+		}
+		ragContext += constants.GetPlanetscaleConnectionContext(branch)
+	}
+
+	// Active secure notes carry context the schema does not show, e.g. soft-delete flags
+	if secureNotesContext := s.buildSecureNotesContext(ctx, chat); secureNotesContext != "" {
+		ragContext += secureNotesContext
+	}
+
+	// Questions about a hidden table are declined, the schema doesn't describe it
+	ragContext += buildHiddenTablesContext(chat, messages)
+
 	// Step 2: Create system message with schema + optional RAG context
 	now := time.Now()
 
@@ -153,6 +189,10 @@ func (s *chatService) convertMessagesToLLMFormat(ctx context.Context, chat *mode
 	if ragContext != "" {
 		systemContent["rag_context"] = ragContext
 	}
+	if systemPromptAppend := s.systemPromptRulesForLLM(chat); systemPromptAppend != "" {
+		logger.Debugf("ChatService -> convertMessagesToLLMFormat -> Appending %d characters of custom rules to the system prompt of chat %s", len(systemPromptAppend), chat.ID.Hex())
+		systemContent[llm.SystemPromptAppendKey] = systemPromptAppend
+	}
 
 	systemMessage := &models.LLMMessage{
 		ChatID:      chat.ID,
@@ -173,9 +213,9 @@ func (s *chatService) convertMessagesToLLMFormat(ctx context.Context, chat *mode
 		var contentMap map[string]interface{}
 
 		if string(msg.Type) == string(constants.MessageTypeUser) {
-			// User message
+			// User message, with angle brackets encoded so it cannot open or close tags of the prompt
 			contentMap = map[string]interface{}{
-				"user_message": msg.Content,
+				"user_message": utils.SanitizeLLMMessage(msg.Content),
 			}
 		} else {
 			// Assistant message - parse the content
@@ -251,6 +291,7 @@ func (s *chatService) convertMessagesToLLMFormat(ctx context.Context, chat *mode
 			Content:     contentMap,
 			IsEdited:    msg.IsEdited,
 			NonTechMode: chat.Settings.NonTechMode,
+			IsLowRated:  msg.IsLowRated,
 			CreatedAt:   msg.CreatedAt,
 			UpdatedAt:   msg.UpdatedAt,
 		}
@@ -379,6 +420,29 @@ func isExplorationQuery(upperQuery string) bool {
 	return false
 }
 
+// fetchThreadContextMessages returns the messages a threaded follow-up is answered from, newest first:
+// the latest messages of the thread, then the AI message the thread was started from and the user message it answered
+func (s *chatService) fetchThreadContextMessages(chatObjID, threadID primitive.ObjectID) ([]*models.Message, error) {
+	// Leave room for the parent pair inside the sliding window
+	threadMessages, _, err := s.chatRepo.FindMessagesByThread(chatObjID, threadID, 1, constants.SlidingWindowSize-2)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := s.chatRepo.FindMessageByID(threadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch thread parent message: %v", err)
+	}
+
+	messages := append(threadMessages, parent)
+	if parent.UserMessageId != nil {
+		if question, err := s.chatRepo.FindMessageByID(*parent.UserMessageId); err == nil && question != nil {
+			messages = append(messages, question)
+		}
+	}
+	return messages, nil
+}
+
 // private function, processLLMResponse processes the LLM response updates SSE stream only if synchronous is false, allowSSEUpdates is used to send SSE updates to the client except the final ai-response event
 func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, userMessageID, streamID string, synchronous bool, allowSSEUpdates bool) (*dtos.MessageResponse, error) {
 	log.Printf("processLLMResponse -> userID: %s, chatID: %s, streamID: %s", userID, chatID, streamID)
@@ -480,7 +544,14 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 	}
 
 	// Fetch all regular messages from the chat
-	regularMessages, _, err := s.chatRepo.FindMessagesByChat(chatObjID, 1, 50) // Get up to 50 messages
+	var regularMessages []*models.Message
+	isThreadMessage := userMessage != nil && userMessage.ThreadID != nil
+	if isThreadMessage {
+		// Threaded follow-ups only see the message pair they were asked about, not the full chat history
+		regularMessages, err = s.fetchThreadContextMessages(chatObjID, *userMessage.ThreadID)
+	} else {
+		regularMessages, _, err = s.chatRepo.FindMessagesByChat(chatObjID, 1, 50) // Get up to 50 messages
+	}
 	if err != nil {
 		s.handleError(ctx, chatID, err)
 		return nil, fmt.Errorf("failed to fetch messages: %v", err)
@@ -494,6 +565,10 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 	// Filter messages up to the current user message (inclusive)
 	filteredRegularMessages := make([]*models.Message, 0)
 	for _, msg := range regularMessages {
+		// Thread replies stay out of the main conversation's context
+		if !isThreadMessage && msg.ThreadID != nil {
+			continue
+		}
 		filteredRegularMessages = append(filteredRegularMessages, msg)
 		if msg.ID == userMessageObjID {
 			break
@@ -555,8 +630,8 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 			// If last assistant model is not available, try provider defaults
 			if selectedLLMModel == "" {
 				// Priority 3: Get default LLM model for the provider (in provider priority order)
-				// Try providers in order: OpenAI -> Gemini -> Claude -> Ollama
-				providers := []string{constants.OpenAI, constants.Gemini, constants.Claude, constants.Ollama}
+				// Try providers in order: OpenAI -> Gemini -> Claude -> Ollama -> Cohere
+				providers := []string{constants.OpenAI, constants.Gemini, constants.Claude, constants.Ollama, constants.Cohere}
 				for _, provider := range providers {
 					if defaultModel := constants.GetDefaultModelForProvider(provider); defaultModel != nil && defaultModel.IsEnabled {
 						selectedLLMModel = defaultModel.ID
@@ -580,8 +655,8 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 	// Handle fallback if still no model selected
 	if selectedLLMModel == "" {
 		// Use default LLM model for the provider (in provider priority order)
-		// Try providers in order: OpenAI -> Gemini -> Claude -> Ollama
-		providers := []string{constants.OpenAI, constants.Gemini, constants.Claude, constants.Ollama}
+		// Try providers in order: OpenAI -> Gemini -> Claude -> Ollama -> Cohere
+		providers := []string{constants.OpenAI, constants.Gemini, constants.Claude, constants.Ollama, constants.Cohere}
 		for _, provider := range providers {
 			if defaultModel := constants.GetDefaultModelForProvider(provider); defaultModel != nil && defaultModel.IsEnabled {
 				// Validate that the model is properly configured
@@ -721,6 +796,9 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 			if kbErr == nil && kb != nil && len(kb.TableDescriptions) > 0 {
 				var sb strings.Builder
 				for _, td := range kb.TableDescriptions {
+					if dbmanager.IsHiddenTable(chat.Settings.HiddenTables, td.TableName) {
+						continue
+					}
 					sb.WriteString(fmt.Sprintf("- %s", td.TableName))
 					if td.Description != "" {
 						sb.WriteString(fmt.Sprintf(": %s", td.Description))
@@ -737,6 +815,35 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		}
 	}
 
+	// --- Top-K table selection: inject only the most relevant tables' schemas ---
+	// Applies when the Qdrant pipeline did not already narrow the context down.
+	if !useRAGOnly && config.Env.EmbeddingEnabled && s.dbManager.GetSchemaManager().IsTableEmbeddingEnabled() {
+		userQuery := ""
+		for i := len(filteredRegularMessages) - 1; i >= 0; i-- {
+			if string(filteredRegularMessages[i].Type) == string(constants.MessageTypeUser) {
+				userQuery = filteredRegularMessages[i].Content
+				break
+			}
+		}
+
+		if userQuery != "" {
+			schemaManager := s.dbManager.GetSchemaManager()
+			topTables, topKErr := schemaManager.GetTopKRelevantTables(ctx, chatID, userQuery, config.Env.EmbeddingTopK)
+			if topKErr != nil {
+				log.Printf("processLLMResponse -> Top-K table selection failed (non-fatal), using full schema: %v", topKErr)
+			} else if len(topTables) > 0 {
+				topKSchema, formatErr := schemaManager.FormatSchemaForTables(ctx, chatID, topTables)
+				if formatErr != nil {
+					log.Printf("processLLMResponse -> Failed to format top-K schema (non-fatal), using full schema: %v", formatErr)
+				} else {
+					ragContext = "\n\n--- Relevant Schema Context (most relevant tables for this question) ---\n" + topKSchema
+					useRAGOnly = true
+					log.Printf("processLLMResponse -> Top-K table selection: injecting %d tables instead of the full schema", len(topTables))
+				}
+			}
+		}
+	}
+
 	// Convert the recent window messages to LLM format.
 	// When useRAGOnly=true, the full schema is omitted and only RAG chunks are sent as context.
 	filteredMessages, err := s.convertMessagesToLLMFormat(ctx, chat, recentMessages, connInfo.Config.Type, ragContext, useRAGOnly)
@@ -798,7 +905,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 					var contentMap map[string]interface{}
 					if role == string(constants.MessageTypeUser) {
 						contentMap = map[string]interface{}{
-							"user_message": content,
+							"user_message": utils.SanitizeLLMMessage(content),
 						}
 					} else {
 						contentMap = map[string]interface{}{
@@ -850,6 +957,20 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		}
 	}
 
+	// Drop the oldest turns from the LLM context if it would exceed the model's input window.
+	// Pruned messages stay in MongoDB, they are only left out of this call.
+	pruneModelID := selectedLLMModel
+	if pruneModelID == "" {
+		pruneModelID = llmClient.GetModelInfo().Name
+	}
+	if config.Env.ContextSummarizationEnabled {
+		// Summarizing first keeps what the oldest turns were about, pruning only drops what still doesn't fit
+		filteredMessages = s.summarizeLLMContext(ctx, chat, filteredMessages, pruneModelID, llmClient)
+	}
+	if pruner := llm.NewContextPruner(pruneModelID); pruner != nil {
+		filteredMessages = pruner.Prune(filteredMessages)
+	}
+
 	// Log messages being sent to LLM (for debugging)
 	log.Printf("========== LLM CONTEXT DEBUG START ==========")
 	log.Printf("processLLMResponse -> Sending %d messages to LLM", len(filteredMessages))
@@ -930,7 +1051,15 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		},
 	}
 
-	toolResult, err := llmClient.GenerateWithTools(ctx, filteredMessages, tools, toolExecutor, toolCallConfig)
+	// Rate limited or unavailable models are retried with the next model of the fallback chain
+	toolResult, llmModelUsed, err := s.generateWithFallback(ctx, llmClient, selectedLLMModel, chat.Settings, filteredMessages, tools, toolExecutor, toolCallConfig, func(modelID string) {
+		if !synchronous || allowSSEUpdates {
+			s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
+				Event: "ai-response-step",
+				Data:  fmt.Sprintf("%s is unavailable, retrying with %s..", s.getModelDisplayName(selectedLLMModel), s.getModelDisplayName(modelID)),
+			})
+		}
+	})
 	if err != nil {
 		if !synchronous || allowSSEUpdates {
 			// Get model display name for error response
@@ -995,6 +1124,8 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 
 	queries := []models.Query{}
 	if jsonResponse["queries"] != nil {
+		complexityScorer := s.newQueryComplexityScorer(ctx, chatID)
+		migrationChecker := utils.NewMigrationSafetyChecker()
 		for _, query := range jsonResponse["queries"].([]interface{}) {
 			queryMap := query.(map[string]interface{})
 			var exampleResult *string
@@ -1009,7 +1140,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 				resultStr := buf.String()
 				utils.PutJSONBuffer(buf)
 				// Encrypt the example result before storage
-				encryptedResult := s.encryptQueryResult(resultStr)
+				encryptedResult := s.encryptQueryResult(resultStr, chat.Settings.EncryptedColumns)
 				exampleResult = utils.StringPtr(encryptedResult)
 				log.Printf("processLLMResponse -> saving exampleResult (encrypted): %v", *exampleResult)
 			} else {
@@ -1046,11 +1177,17 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 
 			log.Printf("processLLMResponse -> queryMap[\"pagination\"]: %v", queryMap["pagination"])
 			pagination := &models.Pagination{}
+			var keysetPagination *models.KeysetPagination
 			if queryMap["pagination"] != nil {
 				if pagMap, ok := queryMap["pagination"].(map[string]interface{}); ok {
 					if pq, ok := pagMap["paginatedQuery"].(string); ok {
+						pq = utils.NaturalizeQuery(pq, connInfo.Config.Type, chat.Settings.QueryStyle)
 						pagination.PaginatedQuery = utils.StringPtr(pq)
 						log.Printf("processLLMResponse -> pagination.PaginatedQuery: %v", pq)
+						// Record the offset placeholder so later pages are fetched from the database with the real offset
+						if strings.Contains(pq, constants.OffsetSizePlaceholder) {
+							pagination.PaginatedQueryOffsetPlaceholder = utils.StringPtr(constants.OffsetSizePlaceholder)
+						}
 					}
 					if cq, ok := pagMap["countQuery"].(string); ok {
 						pagination.CountQuery = utils.StringPtr(cq)
@@ -1065,6 +1202,13 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 						pagination.CursorDirection = utils.StringPtr(cd)
 						log.Printf("processLLMResponse -> pagination.CursorDirection: %v", cd)
 					}
+					// Keyset pagination is only usable when the query carries the {{last_key}} placeholder and a key field
+					kq, _ := pagMap["keysetQuery"].(string)
+					kf, _ := pagMap["keyset_field"].(string)
+					if kq != "" && kf != "" && strings.Contains(kq, constants.KeysetLastKeyPlaceholder) {
+						keysetPagination = &models.KeysetPagination{KeysetQuery: kq, KeyField: kf}
+						log.Printf("processLLMResponse -> keysetPagination: field=%s, query=%s", kf, kq)
+					}
 					switch ps := pagMap["page_size"].(type) {
 					case float64:
 						if ps > 0 {
@@ -1132,6 +1276,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 
 			// Safely extract required string fields with defaults
 			queryStr, _ := queryMap["query"].(string)
+			queryStr = utils.NaturalizeQuery(queryStr, connInfo.Config.Type, chat.Settings.QueryStyle)
 			explanationStr, _ := queryMap["explanation"].(string)
 			canRollback, _ := queryMap["canRollback"].(bool)
 			isCritical, _ := queryMap["isCritical"].(bool)
@@ -1155,7 +1300,8 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 				RollbackQuery:          rollbackQuery,
 				RollbackDependentQuery: rollbackDependentQuery,
 				Pagination:             pagination,
-				LLMModel:               selectedLLMModel,
+				KeysetPagination:       keysetPagination,
+				LLMModel:               llmModelUsed,
 			}
 
 			// Handle ClickHouse-specific metadata
@@ -1187,6 +1333,49 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 				}
 			}
 
+			// Trino INSERT INTO ... SELECT writes new partitions to the data lake, so it always needs confirmation
+			if connInfo.Config.Type == constants.DatabaseTypeTrino && constants.IsTrinoInsertSelect(query.Query) {
+				query.IsCritical = true
+			}
+
+			// Airtable, InfluxDB and PocketBase have no transactions, so writes always need confirmation and can never be rolled back
+			if (connInfo.Config.Type == constants.DatabaseTypeAirtable || connInfo.Config.Type == constants.DatabaseTypeInfluxDB ||
+				connInfo.Config.Type == constants.DatabaseTypePocketBase) &&
+				!constants.IsReadOnlyQuery(query.Query, connInfo.Config.Type) {
+				query.IsCritical = true
+				query.CanRollback = false
+				query.RollbackQuery = nil
+				query.RollbackDependentQuery = nil
+			}
+
+			// YCQL, Firestore and D1 apply writes as soon as they are accepted, there is no transaction to confirm them in.
+			// Unlike the APIs above, a CQL, Firestore or D1 write can still be undone by its rollback query.
+			if (connInfo.Config.Type == constants.DatabaseTypeYugabyteDBCQL || connInfo.Config.Type == constants.DatabaseTypeFirestore ||
+				connInfo.Config.Type == constants.DatabaseTypeCloudflareD1) &&
+				!constants.IsReadOnlyQuery(query.Query, connInfo.Config.Type) {
+				query.IsCritical = true
+			}
+
+			// Temporal and NATS reads never need confirmation, while Temporal signal, cancel and terminate change running
+			// workflows for good and a published NATS message is delivered to every subscriber
+			if connInfo.Config.Type == constants.DatabaseTypeTemporal || connInfo.Config.Type == constants.DatabaseTypeNATS {
+				query.IsCritical = !constants.IsReadOnlyQuery(query.Query, connInfo.Config.Type)
+				query.CanRollback = false
+				query.RollbackQuery = nil
+				query.RollbackDependentQuery = nil
+			}
+
+			scoreQueryComplexity(complexityScorer, connInfo.Config.Type, &query)
+			assessMigrationRisk(migrationChecker, connInfo.Config.Type, &query)
+			applyTruncateSafeguard(&query)
+
+			// Discard rollback queries the LLM left incomplete so they are never offered to the user.
+			// Rollbacks with a dependent query are generated after that query runs, so they are not checked here.
+			if (query.CanRollback || (query.RollbackQuery != nil && *query.RollbackQuery != "")) &&
+				(query.RollbackDependentQuery == nil || *query.RollbackDependentQuery == "") {
+				s.validateRollbackQuery(chatID, connInfo.Config.Type, &query)
+			}
+
 			queries = append(queries, query)
 		}
 	}
@@ -1228,10 +1417,24 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		actionButtons = []models.ActionButton{}
 	}
 
+	// Plain reads on a MongoDB replica set can be served by secondaries, offer it while the connection reads from the primary
+	if len(actionButtons) < 2 && shouldSuggestSecondaryReads(connInfo.Config, queries) {
+		actionButtons = append(actionButtons, models.ActionButton{
+			ID:        primitive.NewObjectID(),
+			Label:     "Read from secondaries",
+			Action:    constants.ActionUseSecondaryReads,
+			IsPrimary: false,
+		})
+	}
+
 	assistantMessage := ""
 	if am, ok := jsonResponse["assistantMessage"].(string); ok {
 		assistantMessage = am
 	}
+	assistantMessage += describeMigrationRisks(queries)
+	if warning := s.takeSchemaBreakingChangeWarning(ctx, chatID); warning != "" {
+		assistantMessage = warning + "\n\n" + assistantMessage
+	}
 
 	// Find existing AI response message
 	existingMessage, err := s.chatRepo.FindNextMessageByID(userMessageObjID)
@@ -1267,6 +1470,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		if selectedLLMModel != "" {
 			existingMessage.LLMModel = &selectedLLMModel // Update with the LLM model used
 		}
+		existingMessage.LLMModelUsed = &llmModelUsed
 
 		// Update the message in the database
 		if err := s.chatRepo.UpdateMessage(existingMessage.ID, existingMessage); err != nil {
@@ -1293,6 +1497,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 					Type:          existingMessage.Type,
 					LLMModel:      existingMessage.LLMModel,
 					LLMModelName:  llmModelName,
+					LLMModelUsed:  existingMessage.LLMModelUsed,
 					CreatedAt:     existingMessage.CreatedAt.Format(time.RFC3339),
 					UpdatedAt:     existingMessage.UpdatedAt.Format(time.RFC3339),
 					IsEdited:      existingMessage.IsEdited,
@@ -1317,6 +1522,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 			Type:          existingMessage.Type,
 			LLMModel:      existingMessage.LLMModel,
 			LLMModelName:  llmModelNameForResponse,
+			LLMModelUsed:  existingMessage.LLMModelUsed,
 			NonTechMode:   existingMessage.NonTechMode,
 			CreatedAt:     existingMessage.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:     existingMessage.UpdatedAt.Format(time.RFC3339),
@@ -1340,15 +1546,24 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		UserMessageId: &userMessageObjID,         // Set the user message ID that this AI message is responding to
 		NonTechMode:   chat.Settings.NonTechMode, // Store the non-tech mode setting with the message
 	}
+	if userMessage != nil {
+		chatResponseMsg.ThreadID = userMessage.ThreadID // Answers to threaded follow-ups stay in the thread
+	}
 	if selectedLLMModel != "" {
 		chatResponseMsg.LLMModel = &selectedLLMModel // Store which LLM model was used to generate this message
 	}
+	chatResponseMsg.LLMModelUsed = &llmModelUsed
 
 	if err := s.chatRepo.CreateMessage(chatResponseMsg); err != nil {
 		log.Printf("processLLMResponse -> Error saving chat response message: %v", err)
 		return nil, err
 	}
 
+	// Name the chat after its first AI response, a chat whose title was cleared is named again
+	if chat.Title == nil && userMessage != nil {
+		go s.generateChatTitleInBackground(chat, userMessage.Content, assistantMessage)
+	}
+
 	// Vectorize assistant message in the background for conversational RAG
 	go func() {
 		bgCtx := context.Background()
@@ -1379,6 +1594,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 				Type:          chatResponseMsg.Type,
 				LLMModel:      chatResponseMsg.LLMModel,
 				LLMModelName:  llmModelName,
+				LLMModelUsed:  chatResponseMsg.LLMModelUsed,
 				NonTechMode:   chatResponseMsg.NonTechMode,
 				CreatedAt:     chatResponseMsg.CreatedAt.Format(time.RFC3339),
 				UpdatedAt:     chatResponseMsg.UpdatedAt.Format(time.RFC3339),
@@ -1400,6 +1616,7 @@ func (s *chatService) processLLMResponse(ctx context.Context, userID, chatID, us
 		Type:          chatResponseMsg.Type,
 		LLMModel:      chatResponseMsg.LLMModel,
 		LLMModelName:  llmModelName,
+		LLMModelUsed:  chatResponseMsg.LLMModelUsed,
 		NonTechMode:   chatResponseMsg.NonTechMode,
 		CreatedAt:     chatResponseMsg.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     chatResponseMsg.UpdatedAt.Format(time.RFC3339),
@@ -1493,23 +1710,7 @@ func (s *chatService) ConnectDB(ctx context.Context, userID, chatID string, stre
 
 	// Ensure port has a default value if empty
 	if chat.Connection.Port == nil || *chat.Connection.Port == "" {
-		var defaultPort string
-		switch chat.Connection.Type {
-		case constants.DatabaseTypePostgreSQL:
-			defaultPort = "5432"
-		case constants.DatabaseTypeTimescaleDB:
-			defaultPort = "5432" // TimescaleDB runs on standard PostgreSQL port
-		case constants.DatabaseTypeYugabyteDB:
-			defaultPort = "5433"
-		case constants.DatabaseTypeMySQL:
-			defaultPort = "3306"
-		case constants.DatabaseTypeStarRocks:
-			defaultPort = "9030" // StarRocks FE query port (MySQL protocol)
-		case constants.DatabaseTypeClickhouse:
-			defaultPort = "9000"
-		case constants.DatabaseTypeMongoDB:
-			defaultPort = "27017"
-		}
+		defaultPort := defaultPortForDBType(chat.Connection.Type)
 		chat.Connection.Port = &defaultPort
 	}
 
@@ -1521,35 +1722,133 @@ func (s *chatService) ConnectDB(ctx context.Context, userID, chatID string, stre
 
 	// Connect to database
 	err = s.dbManager.Connect(chatID, userID, streamID, dbmanager.ConnectionConfig{
-		Type:               chat.Connection.Type,
-		Host:               chat.Connection.Host,
-		Port:               chat.Connection.Port,
-		Username:           chat.Connection.Username,
-		Password:           chat.Connection.Password,
-		Database:           chat.Connection.Database,
-		AuthDatabase:       chat.Connection.AuthDatabase, // Added AuthDatabase
-		UseSSL:             chat.Connection.UseSSL,
-		SSLMode:            chat.Connection.SSLMode,
-		SSLCertURL:         chat.Connection.SSLCertURL,
-		SSLKeyURL:          chat.Connection.SSLKeyURL,
-		SSLRootCertURL:     chat.Connection.SSLRootCertURL,
-		GoogleSheetID:      chat.Connection.GoogleSheetID,
-		GoogleAuthToken:    chat.Connection.GoogleAuthToken,
-		GoogleRefreshToken: chat.Connection.GoogleRefreshToken,
-		SchemaName:         schemaName,
+		Type:                     chat.Connection.Type,
+		Host:                     chat.Connection.Host,
+		Port:                     chat.Connection.Port,
+		Username:                 chat.Connection.Username,
+		Password:                 chat.Connection.Password,
+		Database:                 chat.Connection.Database,
+		AuthDatabase:             chat.Connection.AuthDatabase, // Added AuthDatabase
+		UseSSL:                   chat.Connection.UseSSL,
+		SSLMode:                  chat.Connection.SSLMode,
+		SSLCertURL:               chat.Connection.SSLCertURL,
+		SSLKeyURL:                chat.Connection.SSLKeyURL,
+		SSLRootCertURL:           chat.Connection.SSLRootCertURL,
+		Catalog:                  chat.Connection.Catalog,
+		Schema:                   chat.Connection.Schema,
+		ServiceName:              chat.Connection.ServiceName,
+		GoogleSheetID:            chat.Connection.GoogleSheetID,
+		GoogleAuthToken:          chat.Connection.GoogleAuthToken,
+		GoogleRefreshToken:       chat.Connection.GoogleRefreshToken,
+		SupabaseAnonKey:          chat.Connection.SupabaseAnonKey,
+		SupabaseServiceRoleKey:   chat.Connection.SupabaseServiceRoleKey,
+		AirtableAPIKey:           chat.Connection.AirtableAPIKey,
+		AirtableBaseID:           chat.Connection.AirtableBaseID,
+		PlanetscaleBranch:        chat.Connection.PlanetscaleBranch,
+		InfluxOrg:                chat.Connection.InfluxOrg,
+		InfluxToken:              chat.Connection.InfluxToken,
+		ReadPreference:           chat.Connection.ReadPreference,
+		TemporalNamespace:        chat.Connection.TemporalNamespace,
+		TemporalAddress:          chat.Connection.TemporalAddress,
+		NATSCredentialsFile:      chat.Connection.NATSCredentialsFile,
+		GoogleProjectID:          chat.Connection.GoogleProjectID,
+		FirestoreCredentialsJSON: chat.Connection.FirestoreCredentialsJSON,
+		CloudflareAccountID:      chat.Connection.CloudflareAccountID,
+		CloudflareD1DatabaseID:   chat.Connection.CloudflareD1DatabaseID,
+		CloudflareAPIToken:       chat.Connection.CloudflareAPIToken,
+		SchemaName:               schemaName,
+		MaxResultRows:            s.getMaxQueryResultRows(userID),
+		DisableParallelWorkers:   chat.Settings.DisableParallelWorkers,
+		HiddenTables:             chat.Settings.HiddenTables,
+		SchemaAliases:            dbSchemaAliases(chat.Settings.SchemaAliases),
 	})
 
+	alreadyConnected := false
 	if err != nil {
 		if strings.Contains(err.Error(), "already exists") {
 			log.Printf("ChatService -> ConnectDB -> Database already connected, skipping connection")
+			alreadyConnected = true
 		} else {
 			return http.StatusBadRequest, fmt.Errorf("failed to connect: %v", err)
 		}
 	}
 
+	// An open connection isn't enough, the database must answer queries the user is allowed to run
+	if health := s.dbManager.HealthCheck(chatID); !health.IsHealthy {
+		message := "database health check failed"
+		if health.ErrorMessage != nil {
+			message = fmt.Sprintf("%s: %s", message, *health.ErrorMessage)
+		}
+		log.Printf("ChatService -> ConnectDB -> %s", message)
+		if !alreadyConnected {
+			if err := s.dbManager.Disconnect(chatID, userID, false); err != nil {
+				log.Printf("ChatService -> ConnectDB -> Error disconnecting unhealthy connection: %v", err)
+			}
+		}
+		return http.StatusServiceUnavailable, fmt.Errorf("%s", message)
+	}
+
 	return http.StatusOK, nil
 }
 
+// getMaxQueryResultRows returns the admin override of the user's query result row cap, 0 uses the server default
+func (s *chatService) getMaxQueryResultRows(userID string) int {
+	if s.userRepo == nil {
+		return 0
+	}
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		log.Printf("ChatService -> getMaxQueryResultRows -> Could not load user %s, using the default row cap: %v", userID, err)
+		return 0
+	}
+	return user.GetMaxQueryResultRows()
+}
+
+// defaultPortForDBType returns the default port for a database type, or "" if it has none.
+func defaultPortForDBType(dbType string) string {
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL:
+		return "5432"
+	case constants.DatabaseTypeTimescaleDB:
+		return "5432" // TimescaleDB runs on standard PostgreSQL port
+	case constants.DatabaseTypeSupabase:
+		return "5432" // Supabase direct connections use the standard PostgreSQL port
+	case constants.DatabaseTypeNeon:
+		return "5432"
+	case constants.DatabaseTypeYugabyteDB:
+		return "5433"
+	case constants.DatabaseTypeMySQL:
+		return "3306"
+	case constants.DatabaseTypeStarRocks:
+		return "9030" // StarRocks FE query port (MySQL protocol)
+	case constants.DatabaseTypePlanetscale:
+		return "3306"
+	case constants.DatabaseTypeClickhouse:
+		return "9000"
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		return "27017"
+	case constants.DatabaseTypeTrino:
+		return constants.TrinoDefaultPort
+	case constants.DatabaseTypeOracle:
+		return constants.OracleDefaultPort
+	case constants.DatabaseTypeInfluxDB:
+		return constants.InfluxDBDefaultPort
+	case constants.DatabaseTypeTemporal:
+		return constants.TemporalDefaultPort
+	case constants.DatabaseTypePocketBase:
+		return constants.PocketBaseDefaultPort
+	case constants.DatabaseTypeNATS:
+		return constants.NATSDefaultPort
+	case constants.DatabaseTypeYugabyteDBCQL:
+		return constants.YugabyteDBCQLDefaultPort
+	case constants.DatabaseTypeFirestore:
+		return constants.FirestoreDefaultPort
+	case constants.DatabaseTypeCloudflareD1:
+		return constants.CloudflareD1DefaultPort
+	}
+	return ""
+}
+
 // DisconnectDB disconnects from a database for the chat
 func (s *chatService) DisconnectDB(ctx context.Context, userID, chatID string, streamID string) (uint32, error) {
 	log.Printf("ChatService -> DisconnectDB -> Starting for chatID: %s", chatID)
@@ -1567,6 +1866,13 @@ func (s *chatService) DisconnectDB(ctx context.Context, userID, chatID string, s
 	return http.StatusOK, nil
 }
 
+// executeQueryWithTimeout runs a single query against the connected database, cancelling it once the timeout expires
+func (s *chatService) executeQueryWithTimeout(ctx context.Context, timeout time.Duration, chatID, messageID, queryID, streamID, query, queryType string) (*dbmanager.QueryExecutionResult, *dtos.QueryError) {
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return s.dbManager.ExecuteQuery(queryCtx, chatID, messageID, queryID, streamID, query, queryType, false, false)
+}
+
 // ExecuteQuery executes a query, runs realtime query to connected database, stores the result in execution_result etc...
 func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, req *dtos.ExecuteQueryRequest) (*dtos.QueryExecutionResponse, uint32, error) {
 	// Verify message and query ownership
@@ -1575,14 +1881,26 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 		return nil, http.StatusForbidden, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	// A TRUNCATE only runs once the user typed the truncated table names, a dry run doesn't change anything
+	if !req.DryRun && query.RequiresConfirmationText != "" && req.ConfirmationText != query.RequiresConfirmationText {
+		return nil, http.StatusBadRequest, fmt.Errorf(constants.TruncateConfirmationMismatchMessage)
+	}
+
+	// Each DB call is bounded by the chat's query timeout; the outer context leaves room for connecting and LLM retries
+	queryTimeoutSeconds := constants.DefaultQueryTimeoutSeconds
+	if chat != nil {
+		queryTimeoutSeconds = chat.Settings.GetQueryTimeoutSeconds()
+	}
+	queryTimeout := time.Duration(queryTimeoutSeconds) * time.Second
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout+1*time.Minute)
 	defer cancel()
 
 	select {
 	case <-ctx.Done():
 		return nil, http.StatusRequestTimeout, fmt.Errorf("query execution cancelled or timed out")
 	default:
-		log.Printf("ChatService -> ExecuteQuery -> msg: %+v", msg)
+		log.Printf("ChatService -> ExecuteQuery -> msg: %+v, queryTimeout: %v", msg, queryTimeout)
 	}
 
 	// Check connection status and connect if needed
@@ -1596,6 +1914,30 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 		time.Sleep(1 * time.Second)
 	}
 
+	// Dry runs only count the rows the write would change, the query itself is never executed
+	if req.DryRun {
+		if chat == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+		}
+		return s.executeDryRun(ctx, userID, chatID, req, chat, query, queryTimeout)
+	}
+
+	// Warn the client before running a query that was scored as extremely expensive
+	if chat != nil && query.ComplexityScore == constants.QueryComplexityExtreme {
+		s.sendComplexityWarning(ctx, userID, chatID, req.StreamID, req.MessageID, chat.Connection.Type, req.QueryID, query.Query)
+	}
+
+	// Block queries the planner estimates to cost more than the chat's budget, before anything runs
+	var estimatedCost float64
+	if chat != nil {
+		cost, err := s.checkQueryCostBudget(ctx, chat, chatID, query.Query)
+		if err != nil {
+			log.Printf("ChatService -> ExecuteQuery -> %v", err)
+			return costBudgetExceededResponse(chatID, req, query, cost, err), http.StatusBadRequest, err
+		}
+		estimatedCost = cost
+	}
+
 	var totalRecordsCount *int
 
 	// Safe dereference of QueryType — default to "SELECT" if nil.
@@ -1608,7 +1950,9 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 	// To find total records count, we need to execute the pagination.countQuery with findCount = true
 	if query.Pagination != nil && query.Pagination.CountQuery != nil && *query.Pagination.CountQuery != "" {
 		log.Printf("ChatService -> ExecuteQuery -> query.Pagination.CountQuery is present, will use it to get the total records count")
-		countResult, queryErr := s.dbManager.ExecuteQuery(ctx, chatID, req.MessageID, req.QueryID, req.StreamID, *query.Pagination.CountQuery, queryType, false, true)
+		countCtx, countCancel := context.WithTimeout(ctx, queryTimeout)
+		countResult, queryErr := s.dbManager.ExecuteQuery(countCtx, chatID, req.MessageID, req.QueryID, req.StreamID, *query.Pagination.CountQuery, queryType, false, true)
+		countCancel()
 		if queryErr != nil {
 			log.Printf("ChatService -> ExecuteQuery -> Error executing count query: %v", queryErr)
 		}
@@ -1791,18 +2135,32 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 
 	log.Printf("ChatService -> ExecuteQuery -> queryToExecute: %+v", queryToExecute)
 	// Execute query, we will be executing the pagination.paginatedQuery if it exists, else the query.Query
-	result, queryErr := s.dbManager.ExecuteQuery(ctx, chatID, req.MessageID, req.QueryID, req.StreamID, queryToExecute, queryType, false, false)
-	if queryErr != nil {
+	result, queryErr := s.executeQueryWithTimeout(ctx, queryTimeout, chatID, req.MessageID, req.QueryID, req.StreamID, queryToExecute, queryType)
+	if queryErr != nil && queryErr.Code != "QUERY_EXECUTION_TIMED_OUT" {
 		// Checking if executed query was paginatedQuery, if so, let's try to execute it again with the original query
 		if query.Pagination != nil && query.Pagination.PaginatedQuery != nil && *query.Pagination.PaginatedQuery != "" && queryToExecute == strings.Replace(*query.Pagination.PaginatedQuery, "offset_size", strconv.Itoa(0), 1) {
 			log.Printf("ChatService -> ExecuteQuery -> query.Pagination.PaginatedQuery was executed but faced an error, will try to execute the original query")
 			queryToExecute = query.Query
-			result, queryErr = s.dbManager.ExecuteQuery(ctx, chatID, req.MessageID, req.QueryID, req.StreamID, queryToExecute, queryType, false, false)
+			result, queryErr = s.executeQueryWithTimeout(ctx, queryTimeout, chatID, req.MessageID, req.QueryID, req.StreamID, queryToExecute, queryType)
 		}
 	}
 	var updatedContent *string // tracks content updated by explainErrorWithLLM (for SSE)
 	if queryErr != nil {
 		log.Printf("ChatService -> ExecuteQuery -> queryErr: %+v", queryErr)
+		if queryErr.Code == "QUERY_EXECUTION_TIMED_OUT" {
+			timeoutMsg := fmt.Sprintf("Query timed out after %ds", queryTimeoutSeconds)
+			s.sendStreamEvent(userID, chatID, req.StreamID, dtos.StreamResponse{
+				Event: "query_timeout",
+				Data: map[string]interface{}{
+					"chat_id":         chatID,
+					"message_id":      req.MessageID,
+					"query_id":        req.QueryID,
+					"timeout_seconds": queryTimeoutSeconds,
+					"message":         timeoutMsg,
+				},
+			})
+			return nil, http.StatusRequestTimeout, fmt.Errorf("%s", timeoutMsg)
+		}
 		if queryErr.Code == "FAILED_TO_START_TRANSACTION" || strings.Contains(queryErr.Message, "context deadline exceeded") || strings.Contains(queryErr.Message, "context canceled") {
 			return nil, http.StatusRequestTimeout, fmt.Errorf("query execution timed out")
 		}
@@ -1848,7 +2206,7 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 				})
 
 				// Execute the fixed query
-				retryResult, retryQueryErr := s.dbManager.ExecuteQuery(ctx, chatID, req.MessageID, req.QueryID, req.StreamID, fixedQuery, queryType, false, false)
+				retryResult, retryQueryErr := s.executeQueryWithTimeout(ctx, queryTimeout, chatID, req.MessageID, req.QueryID, req.StreamID, fixedQuery, queryType)
 				if retryQueryErr == nil && retryResult != nil {
 					log.Printf("ChatService -> ExecuteQuery -> Retry succeeded with fixed query")
 
@@ -1876,6 +2234,17 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 			}
 		}
 	}
+	// Business users get a plain language explanation in the assistant message, the technical error is kept for debugging
+	if queryErr != nil && chat != nil && chat.Settings.NonTechMode {
+		if friendly := constants.GetFriendlyDBErrorMessage(queryErr.Code, queryErr.Message); friendly != "" {
+			log.Printf("ChatService -> ExecuteQuery -> Translated query error for non-technical mode: code=%s msg=%s", queryErr.Code, queryErr.Message)
+			if !strings.Contains(msg.Content, friendly) {
+				content := strings.TrimSpace(msg.Content + "\n\n" + friendly)
+				msg.Content = content
+				updatedContent = &content
+			}
+		}
+	}
 	if queryErr != nil {
 		processCompleted := make(chan bool)
 		go func() {
@@ -1943,6 +2312,7 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 			ActionButtons:     dtos.ToActionButtonDto(msg.ActionButtons),
 			ActionAt:          query.ActionAt,
 			UpdatedContent:    updatedContent,
+			EstimatedCost:     estimatedCost,
 		}, http.StatusOK, nil
 	}
 	// Convert Result to JSON string first
@@ -1979,30 +2349,30 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 	if len(resultListFormatting) > 0 {
 		log.Printf("ChatService -> ExecuteQuery -> resultListFormatting: %+v", resultListFormatting)
 		formattedResultJSON = resultListFormatting
-		if len(resultListFormatting) > 50 {
-			log.Printf("ChatService -> ExecuteQuery -> resultListFormatting length > 50")
-			formattedResultJSON = resultListFormatting[:50] // Cap the result to 50 records
+		if len(resultListFormatting) > constants.QueryResultPageSize {
+			log.Printf("ChatService -> ExecuteQuery -> resultListFormatting length > %d", constants.QueryResultPageSize)
+			formattedResultJSON = resultListFormatting[:constants.QueryResultPageSize] // Cap the result to the first page
 
-			// Cap the result to 50 records
+			// Only the first page is stored, later pages are fetched from the database
 			cappedBuf := utils.GetJSONBuffer()
 			encoder := json.NewEncoder(cappedBuf)
 			encoder.SetEscapeHTML(false)
-			if err := encoder.Encode(resultListFormatting[:50]); err != nil {
+			if err := encoder.Encode(resultListFormatting[:constants.QueryResultPageSize]); err != nil {
 				log.Printf("ChatService -> ExecuteQuery -> Error marshaling capped results: %v", err)
 			} else {
 				resultJSONStr = cappedBuf.String()
-				result.Result = resultListFormatting[:50]
+				result.Result = resultListFormatting[:constants.QueryResultPageSize]
 			}
 			utils.PutJSONBuffer(cappedBuf)
 		}
 	} else if resultMapFormatting != nil && resultMapFormatting["results"] != nil && len(resultMapFormatting["results"].([]interface{})) > 0 {
 		log.Printf("ChatService -> ExecuteQuery -> resultMapFormatting: %+v", resultMapFormatting)
-		if len(resultMapFormatting["results"].([]interface{})) > 50 {
+		if len(resultMapFormatting["results"].([]interface{})) > constants.QueryResultPageSize {
 			formattedResultJSON = map[string]interface{}{
-				"results": resultMapFormatting["results"].([]interface{})[:50],
+				"results": resultMapFormatting["results"].([]interface{})[:constants.QueryResultPageSize],
 			}
 			cappedResults := map[string]interface{}{
-				"results": resultMapFormatting["results"].([]interface{})[:50],
+				"results": resultMapFormatting["results"].([]interface{})[:constants.QueryResultPageSize],
 			}
 			cappedResultsJSON, err := json.Marshal(cappedResults)
 			if err != nil {
@@ -2027,7 +2397,7 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 	query.IsRolledBack = false
 	query.ExecutionTime = &result.ExecutionTime
 	// Encrypt the execution result before storage
-	encryptedResult := s.encryptQueryResult(resultJSONStr)
+	encryptedResult := s.encryptQueryResult(resultJSONStr, chatEncryptedColumns(chat))
 	query.ExecutionResult = &encryptedResult
 	query.ActionAt = utils.StringPtr(time.Now().Format(time.RFC3339))
 	if totalRecordsCount != nil {
@@ -2065,7 +2435,7 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 					log.Printf("ChatService -> ExecuteQuery -> resultJSONStr: %v", resultJSONStr)
 					log.Printf("ChatService -> ExecuteQuery -> ExecutionResult before update: %v", (*msg.Queries)[i].ExecutionResult)
 					// Encrypt the execution result before storage
-					encryptedResult := s.encryptQueryResult(resultJSONStr)
+					encryptedResult := s.encryptQueryResult(resultJSONStr, chatEncryptedColumns(chat))
 					(*msg.Queries)[i].ExecutionResult = &encryptedResult
 					log.Printf("ChatService -> ExecuteQuery -> ExecutionResult after update: %v", (*msg.Queries)[i].ExecutionResult)
 					if result.Error != nil {
@@ -2122,16 +2492,25 @@ func (s *chatService) ExecuteQuery(ctx context.Context, userID, chatID string, r
 		TotalRecordsCount: totalRecordsCount,
 		ActionButtons:     dtos.ToActionButtonDto(msg.ActionButtons),
 		ActionAt:          query.ActionAt,
+		Truncated:         result.Truncated,
+		TruncatedAt:       result.TruncatedAt,
+		EstimatedCost:     estimatedCost,
 	}, http.StatusOK, nil
 }
 
-func (s *chatService) RollbackQuery(ctx context.Context, userID, chatID string, req *dtos.RollbackQueryRequest) (*dtos.QueryExecutionResponse, uint32, error) {
+func (s *chatService) RollbackQuery(ctx context.Context, userID, chatID string, req *dtos.RollbackQueryRequest) (response *dtos.QueryExecutionResponse, status uint32, err error) {
 	// Verify message and query ownership
 	chat, msg, query, err := s.verifyQueryOwnership(userID, chatID, req.MessageID, req.QueryID)
 	if err != nil {
 		return nil, http.StatusForbidden, err
 	}
 
+	// Every attempt is recorded with its outcome for auditing, linked to the dependent query run if there was one
+	var dependentQueryRecordID *primitive.ObjectID
+	defer func() {
+		s.recordRollbackOutcome(chat, msg, query, dependentQueryRecordID, response, err)
+	}()
+
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
@@ -2175,6 +2554,7 @@ func (s *chatService) RollbackQuery(ctx context.Context, userID, chatID string,
 
 		// Execute dependent query
 		dependentResult, queryErr := s.dbManager.ExecuteQuery(ctx, chatID, req.MessageID, req.QueryID, req.StreamID, *query.RollbackDependentQuery, *query.QueryType, false, false)
+		dependentQueryRecordID = s.recordRollbackStep(chat, msg, query, constants.RollbackStepDependentQuery, *query.RollbackDependentQuery, queryErrorMessage(queryErr), nil)
 		if queryErr != nil {
 			log.Printf("ChatService -> RollbackQuery -> queryErr: %+v", queryErr)
 			if queryErr.Code == "FAILED_TO_START_TRANSACTION" || strings.Contains(queryErr.Message, "context deadline exceeded") || strings.Contains(queryErr.Message, "context canceled") {
@@ -2481,7 +2861,7 @@ func (s *chatService) RollbackQuery(ctx context.Context, userID, chatID string,
 				resultJSONStr := buf.String()
 				utils.PutJSONBuffer(buf)
 				// Encrypt the execution result before storage
-				encryptedResult := s.encryptQueryResult(resultJSONStr)
+				encryptedResult := s.encryptQueryResult(resultJSONStr, chatEncryptedColumns(chat))
 				(*msg.Queries)[i].ExecutionResult = &encryptedResult
 				(*msg.Queries)[i].ActionAt = utils.StringPtr(time.Now().Format(time.RFC3339))
 				if result.Error != nil {
@@ -2637,15 +3017,28 @@ func (s *chatService) processLLMResponseAndRunQuery(ctx context.Context, userID,
 				})
 				tempQueries := make([]dtos.Query, len(*msgResp.Queries))
 				for i, query := range *msgResp.Queries {
-					// Gate auto-execution: skip critical queries, empty queries,
+					// Extreme complexity queries always need manual confirmation, even with auto-execute on
+					if query.ComplexityScore == constants.QueryComplexityExtreme {
+						s.sendComplexityWarning(ctx, userID, chatID, streamID, msgResp.ID, chat.Connection.Type, query.ID, query.Query)
+					}
+
+					// Gate auto-execution: skip critical queries, extreme complexity queries, empty queries,
 					// and exploration-only queries (e.g., SHOW TABLES, db.getCollectionNames())
 					// that only discover schema metadata and aren't useful as auto-executed results.
-					if query.Query != "" && !query.IsCritical && !isExplorationQuery(strings.ToUpper(strings.TrimSpace(query.Query))) {
+					if query.Query != "" && !query.IsCritical && query.ComplexityScore != constants.QueryComplexityExtreme &&
+						!isExplorationQuery(strings.ToUpper(strings.TrimSpace(query.Query))) {
 						executionResult, _, queryErr := s.ExecuteQuery(ctx, userID, chatID, &dtos.ExecuteQueryRequest{
 							MessageID: msgResp.ID,
 							QueryID:   query.ID,
 							StreamID:  streamID,
 						})
+						if queryErr != nil && executionResult != nil && executionResult.CostBudgetExceeded {
+							// Over the cost budget, the query is left for the user to narrow down and the others still run
+							log.Printf("ProcessLLMResponseAndRunQuery -> Query %s not auto-executed: %v", query.ID, queryErr)
+							query.Error = executionResult.Error
+							tempQueries[i] = query
+							continue
+						}
 						if queryErr != nil {
 							log.Printf("Error executing query: %v", queryErr)
 							// Send existing msgResp so far
@@ -3013,8 +3406,8 @@ func (s *chatService) RefreshSchema(ctx context.Context, userID, chatID string,
 // Fetches paginated results for a query using cursor-based pagination for efficiency.
 // Supports both cursor (preferred) and offset (backward compatibility) pagination.
 // Cursor-based pagination is more efficient for large datasets as it doesn't require scanning all previous rows.
-func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messageID, queryID, streamID string, offset int, cursor *string) (*dtos.QueryResultsResponse, uint32, error) {
-	log.Printf("ChatService -> GetQueryResults -> userID: %s, chatID: %s, messageID: %s, queryID: %s, streamID: %s, offset: %d, cursor: %v", userID, chatID, messageID, queryID, streamID, offset, cursor)
+func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messageID, queryID, streamID string, offset int, cursor *string, lastKey interface{}) (*dtos.QueryResultsResponse, uint32, error) {
+	log.Printf("ChatService -> GetQueryResults -> userID: %s, chatID: %s, messageID: %s, queryID: %s, streamID: %s, offset: %d, cursor: %v, lastKey: %v", userID, chatID, messageID, queryID, streamID, offset, cursor, lastKey)
 	chat, msg, query, err := s.verifyQueryOwnership(userID, chatID, messageID, queryID)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
@@ -3043,7 +3436,21 @@ func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messa
 	isCursorBased := (query.Pagination.CursorField != nil && *query.Pagination.CursorField != "") ||
 		(query.Pagination.PaginatedQuery != nil && strings.Contains(*query.Pagination.PaginatedQuery, "{{cursor_value}}"))
 
-	if isCursorBased {
+	// Keyset pagination is preferred over offset when the client sends the previous page's last key
+	isKeysetBased := !isCursorBased && lastKey != nil && query.KeysetPagination != nil && query.KeysetPagination.KeysetQuery != ""
+
+	if isKeysetBased {
+		dbType := ""
+		if chat != nil {
+			dbType = chat.Connection.Type
+		}
+		paginatedQuery = dbmanager.BuildKeysetQuery(dbType, query.KeysetPagination.KeysetQuery, lastKey)
+		log.Printf("ChatService -> GetQueryResults -> Using keyset pagination on %s", query.KeysetPagination.KeyField)
+		pageSize = 50
+		if query.Pagination.PageSize != nil {
+			pageSize = *query.Pagination.PageSize
+		}
+	} else if isCursorBased {
 		// Cursor-based pagination — all DB-specific logic delegated to dbmanager.BuildCursorQuery.
 		// Page 1 uses query.Query directly (cursor is empty). Pages 2+ inject cursor dynamically.
 		cursorVal := ""
@@ -3088,12 +3495,21 @@ func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messa
 			pageSize = 50 // default page size if not specified
 		}
 	} else {
-		// Offset-based pagination (backward compatibility)
+		// Offset-based pagination, the page at the offset is read from the database rather than from the stored first page.
+		// Messages saved before the placeholder was recorded fall back to offset_size.
 		log.Printf("ChatService -> GetQueryResults -> Using offset-based pagination with offset: %d", offset)
-		paginatedQuery = strings.Replace(*query.Pagination.PaginatedQuery, "offset_size", strconv.Itoa(offset), 1)
-		// Also handle AI using {{cursor_value}} as an offset placeholder (e.g. $skip: {{cursor_value}})
-		paginatedQuery = strings.ReplaceAll(paginatedQuery, "{{cursor_value}}", strconv.Itoa(offset))
-		pageSize = 50 // legacy default page size for offset pagination if not specified in the query
+		placeholder := constants.OffsetSizePlaceholder
+		if query.Pagination.PaginatedQueryOffsetPlaceholder != nil && *query.Pagination.PaginatedQueryOffsetPlaceholder != "" {
+			placeholder = *query.Pagination.PaginatedQueryOffsetPlaceholder
+		}
+		if offset > 0 && !strings.Contains(*query.Pagination.PaginatedQuery, placeholder) {
+			return nil, http.StatusBadRequest, fmt.Errorf("query does not support offset pagination")
+		}
+		paginatedQuery = strings.ReplaceAll(*query.Pagination.PaginatedQuery, placeholder, strconv.Itoa(offset))
+		pageSize = constants.QueryResultPageSize
+		if query.Pagination.PageSize != nil {
+			pageSize = *query.Pagination.PageSize
+		}
 	}
 
 	log.Printf("ChatService -> GetQueryResults -> paginatedQuery: %+v", paginatedQuery)
@@ -3191,7 +3607,7 @@ func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messa
 		}
 
 		if queryErr != nil {
-			return nil, http.StatusBadRequest, fmt.Errorf(queryErr.Message)
+			return nil, http.StatusBadRequest, fmt.Errorf("%s", queryErr.Message)
 		}
 	}
 
@@ -3267,6 +3683,24 @@ func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messa
 		}
 	}
 
+	// A full offset page means the next offset may have more rows
+	if !isCursorBased && !isKeysetBased {
+		hasMore = len(resultListFormatting) == pageSize
+	}
+
+	// Extract the last row's key so the client can request the next keyset page
+	var nextLastKey interface{}
+	if query.KeysetPagination != nil && query.KeysetPagination.KeyField != "" && len(resultListFormatting) > 0 {
+		if recordMap, ok := resultListFormatting[len(resultListFormatting)-1].(map[string]interface{}); ok {
+			if keyValue, exists := recordMap[query.KeysetPagination.KeyField]; exists {
+				nextLastKey = keyValue
+			}
+		}
+		if isKeysetBased {
+			hasMore = nextLastKey != nil && len(resultListFormatting) == pageSize
+		}
+	}
+
 	// Send SSE event with pagination info
 	s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
 		Event: "query-paginated-results",
@@ -3279,6 +3713,7 @@ func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messa
 			"total_records_count": query.Pagination.TotalRecordsCount,
 			"next_cursor":         nextCursor,
 			"has_more":            hasMore,
+			"last_key":            nextLastKey,
 		},
 	})
 
@@ -3291,6 +3726,7 @@ func (s *chatService) GetQueryResults(ctx context.Context, userID, chatID, messa
 		TotalRecordsCount: query.Pagination.TotalRecordsCount,
 		NextCursor:        nextCursor,
 		HasMore:           hasMore,
+		LastKey:           nextLastKey,
 	}, http.StatusOK, nil
 }
 
@@ -3434,8 +3870,162 @@ func (s *chatService) removeFixErrorButton(msg *models.Message) {
 	}
 }
 
+// validateRollbackQuery clears the rollback query and disables rollback when the query contains placeholders
+// or, for SQL databases with an active connection, fails to parse
+func (s *chatService) validateRollbackQuery(chatID, dbType string, query *models.Query) {
+	rollbackQuery := ""
+	if query.RollbackQuery != nil {
+		rollbackQuery = *query.RollbackQuery
+	}
+
+	valid, reason := utils.ValidateRollbackQuery(rollbackQuery, dbType)
+	if valid {
+		valid, reason = s.explainRollbackQuery(chatID, dbType, rollbackQuery)
+	}
+	if valid {
+		return
+	}
+
+	log.Printf("ChatService -> validateRollbackQuery -> Discarding rollback for query %s: %s", query.ID.Hex(), reason)
+	query.CanRollback = false
+	query.RollbackQuery = nil
+}
+
+// newQueryComplexityScorer builds a complexity scorer from the row counts in the chat's stored schema
+func (s *chatService) newQueryComplexityScorer(ctx context.Context, chatID string) *utils.QueryComplexityScorer {
+	tableRowCounts := make(map[string]int64)
+	schemaInfo, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID)
+	if err != nil {
+		log.Printf("ChatService -> newQueryComplexityScorer -> No stored schema for chat %s, scoring without row counts: %v", chatID, err)
+	} else {
+		for name, table := range schemaInfo.Tables {
+			tableRowCounts[name] = table.RowCount
+		}
+	}
+	return utils.NewQueryComplexityScorer(tableRowCounts)
+}
+
+// scoreQueryComplexity sets the complexity score of an AI generated query
+func scoreQueryComplexity(scorer *utils.QueryComplexityScorer, dbType string, query *models.Query) {
+	complexity := scorer.Score(query.Query, dbType)
+	if complexity == nil {
+		query.ComplexityScore = ""
+		return
+	}
+	query.ComplexityScore = complexity.Score
+	if complexity.Score != constants.QueryComplexityLow {
+		log.Printf("ChatService -> scoreQueryComplexity -> Query %s scored %s (%d points): %s",
+			query.ID.Hex(), complexity.Score, complexity.Points, strings.Join(complexity.Reasons, "; "))
+	}
+}
+
+// assessMigrationRisk sets the migration risk of an AI generated DDL query.
+// Medium and high risk migrations always need confirmation before they run.
+func assessMigrationRisk(checker *utils.MigrationSafetyChecker, dbType string, query *models.Query) {
+	risk := checker.Check(query.Query, dbType)
+	if risk == nil {
+		query.MigrationRisk = nil
+		return
+	}
+	query.MigrationRisk = &models.MigrationRisk{
+		RiskLevel:             risk.RiskLevel,
+		Reason:                risk.Reason,
+		EstimatedLockDuration: risk.EstimatedLockDuration,
+	}
+	if risk.RiskLevel != constants.MigrationRiskLow {
+		query.IsCritical = true
+		log.Printf("ChatService -> assessMigrationRisk -> Query %s has %s migration risk: %s", query.ID.Hex(), risk.RiskLevel, risk.Reason)
+	}
+}
+
+// applyTruncateSafeguard makes a query that truncates tables critical and requires their names to be typed before it runs
+func applyTruncateSafeguard(query *models.Query) {
+	query.RequiresConfirmationText = utils.NewTruncateTableSafeguard().ConfirmationText(query.Query)
+	if query.RequiresConfirmationText != "" {
+		query.IsCritical = true
+		log.Printf("ChatService -> applyTruncateSafeguard -> Query %s truncates %s, confirmation text required", query.ID.Hex(), query.RequiresConfirmationText)
+	}
+}
+
+// describeMigrationRisks summarises the migration risk of the DDL queries for the assistant message
+func describeMigrationRisks(queries []models.Query) string {
+	var summary strings.Builder
+	for i, query := range queries {
+		if query.MigrationRisk == nil {
+			continue
+		}
+		label := "Migration risk"
+		if len(queries) > 1 {
+			label = fmt.Sprintf("Migration risk of query %d", i+1)
+		}
+		fmt.Fprintf(&summary, "\n\n**%s: %s.** %s Estimated lock duration: %s.",
+			label, query.MigrationRisk.RiskLevel, query.MigrationRisk.Reason, query.MigrationRisk.EstimatedLockDuration)
+	}
+	return summary.String()
+}
+
+// sendComplexityWarning streams a complexity_warning event with the findings behind an extreme score
+func (s *chatService) sendComplexityWarning(ctx context.Context, userID, chatID, streamID, messageID, dbType, queryID, query string) {
+	var reasons []string
+	if complexity := s.newQueryComplexityScorer(ctx, chatID).Score(query, dbType); complexity != nil {
+		reasons = complexity.Reasons
+	}
+	s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
+		Event: constants.StreamEventComplexityWarning,
+		Data: map[string]interface{}{
+			"message_id":       messageID,
+			"query_id":         queryID,
+			"complexity_score": constants.QueryComplexityExtreme,
+			"reasons":          reasons,
+		},
+	})
+}
+
+// explainRollbackQuery runs EXPLAIN on single-statement DML rollbacks to catch syntax errors.
+// Only syntax errors invalidate the rollback: the objects it touches may not exist until the original query runs.
+func (s *chatService) explainRollbackQuery(chatID, dbType, rollbackQuery string) (bool, string) {
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeMySQL, constants.DatabaseTypePlanetscale:
+	default:
+		return true, ""
+	}
+
+	statement := strings.TrimSuffix(strings.TrimSpace(rollbackQuery), ";")
+	if strings.Contains(statement, ";") {
+		return true, ""
+	}
+	upper := strings.ToUpper(statement)
+	if !strings.HasPrefix(upper, "INSERT") && !strings.HasPrefix(upper, "UPDATE") && !strings.HasPrefix(upper, "DELETE") {
+		return true, ""
+	}
+
+	// Only validate against a connection that is already open
+	if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+		return true, ""
+	}
+	conn, err := s.dbManager.GetConnection(chatID)
+	if err != nil || conn.GetDB() == nil {
+		return true, ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RollbackExplainTimeout)
+	defer cancel()
+	rows, err := conn.GetDB().QueryContext(ctx, "EXPLAIN "+statement)
+	if err == nil {
+		rows.Close()
+		return true, ""
+	}
+
+	errMsg := strings.ToLower(err.Error())
+	if strings.Contains(errMsg, "syntax error") || strings.Contains(errMsg, "error in your sql syntax") {
+		return false, fmt.Sprintf("syntax error: %v", err)
+	}
+	return true, ""
+}
+
 // GetQueryRecommendations generates 4 random query recommendations with Redis caching
-func (s *chatService) GetQueryRecommendations(ctx context.Context, userID, chatID string, streamID string) (*dtos.QueryRecommendationsResponse, uint32, error) {
+func (s *chatService) GetQueryRecommendations(ctx context.Context, userID, chatID string, streamID string, location *time.Location) (*dtos.QueryRecommendationsResponse, uint32, error) {
 	log.Printf("ChatService -> GetQueryRecommendations -> userID: %s, chatID: %s, streamID: %s", userID, chatID, streamID)
 
 	// Get connection info
@@ -3585,6 +4175,18 @@ func (s *chatService) GetQueryRecommendations(ctx context.Context, userID, chatI
 		log.Printf("ChatService -> GetQueryRecommendations -> Injecting KB-derived schema as RAG context for recommendations")
 	}
 
+	// Add usage signals (frequent tables, time of day, empty results, database type) so recommendations
+	// follow what the user is working on
+	recoContext := s.buildRecommendationContext(recentMessages, connInfo.Config.Type, recommendationTime(time.Now(), location))
+	log.Printf("ChatService -> GetQueryRecommendations -> Recommendation context: %s", recommendationContextSummary(recoContext))
+	if recoContextBlock := formatRecommendationContext(recoContext); recoContextBlock != "" {
+		if recoRAGContext == "" {
+			recoRAGContext = recoContextBlock
+		} else {
+			recoRAGContext = recoRAGContext + "\n\n" + recoContextBlock
+		}
+	}
+
 	llmMessages, err = s.convertMessagesToLLMFormat(ctx, chat, recentMessages, connInfo.Config.Type, recoRAGContext, useRAGOnlyForReco)
 	if err != nil {
 		log.Printf("ChatService -> GetQueryRecommendations -> Error converting messages: %v", err)
@@ -3816,12 +4418,14 @@ func (s *chatService) vectorizeSchemaForChat(ctx context.Context, chatID string)
 
 	log.Printf("ChatService -> vectorizeSchemaForChat -> Starting enriched schema vectorization for chatID: %s", chatID)
 
-	// 1. Get the stored schema (FullSchema for structure)
+	// 1. Get the stored schema (FullSchema for structure), without the tables hidden from the LLM
 	schemaInfo, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID)
 	if err != nil {
 		log.Printf("ChatService -> vectorizeSchemaForChat -> Error getting stored schema: %v", err)
 		return
 	}
+	hiddenTables := s.dbManager.HiddenTables(chatID)
+	schemaInfo = dbmanager.WithoutHiddenTables(schemaInfo, hiddenTables)
 
 	// 2. Get connection info for db_type
 	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
@@ -3876,6 +4480,11 @@ func (s *chatService) vectorizeSchemaForChat(ctx context.Context, chatID string)
 	for t := range examplesByTable {
 		allTableNames[t] = true
 	}
+	for t := range allTableNames {
+		if dbmanager.IsHiddenTable(hiddenTables, t) {
+			delete(allTableNames, t)
+		}
+	}
 
 	for tName := range allTableNames {
 		enrichment := &TableEnrichment{
@@ -4102,3 +4711,20 @@ func (s *chatService) explainErrorWithLLM(ctx context.Context,
 
 	return "", fmt.Errorf("no assistantMessage found in error explanation response")
 }
+
+// shouldSuggestSecondaryReads reports whether a MongoDB connection still reads from the primary
+// while every generated query is a find, so routing reads to secondaryPreferred is worth suggesting
+func shouldSuggestSecondaryReads(config dbmanager.ConnectionConfig, queries []models.Query) bool {
+	if config.Type != constants.DatabaseTypeMongoDB || len(queries) == 0 {
+		return false
+	}
+	if config.ReadPreference != nil && *config.ReadPreference != constants.MongoDBReadPreferencePrimary {
+		return false
+	}
+	for _, query := range queries {
+		if !constants.IsMongoDBFindQuery(query.Query) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"strings"
+)
+
+// ExplainQueryInPlainEnglish asks the LLM to describe a query in plain prose, step by step, for readers
+// who cannot read SQL. The query is never executed, so no database connection is needed.
+// A query template is explained with its :name placeholders.
+func (s *chatService) ExplainQueryInPlainEnglish(ctx context.Context, userID, chatID string, req *dtos.ExplainQueryRequest) (*dtos.QueryExplanationResponse, uint32, error) {
+	log.Printf("ChatService -> ExplainQueryInPlainEnglish -> userID: %s, chatID: %s, queryType: %s", userID, chatID, req.QueryType)
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	query := strings.TrimSpace(req.Query)
+	queryType := strings.ToUpper(strings.TrimSpace(req.QueryType))
+	if req.TemplateID != "" {
+		template, statusCode, err := s.findChatQueryTemplate(ctx, chat, req.TemplateID)
+		if err != nil {
+			return nil, statusCode, err
+		}
+		query = template.Template
+		if queryType == "" {
+			queryType = "SELECT" // templates are read-only
+		}
+	}
+	if query == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("query or templateId is required")
+	}
+	if len(query) > constants.ExplainQueryMaxLength {
+		return nil, http.StatusBadRequest, fmt.Errorf("query is too long to explain, the limit is %d characters", constants.ExplainQueryMaxLength)
+	}
+
+	llmClient := s.llmClient
+	modelID := ""
+	if chat.PreferredLLMModel != nil && *chat.PreferredLLMModel != "" {
+		modelID = *chat.PreferredLLMModel
+		if s.llmManager != nil {
+			if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+				if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+					llmClient = providerClient
+				}
+			}
+		}
+	}
+	if llmClient == nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("no LLM client available")
+	}
+
+	// The schema lets the LLM name tables and columns by their meaning, the explanation still works without it
+	schemaContext := ""
+	if chat.Connection.CurrentSchema != nil {
+		schemaContext = *chat.Connection.CurrentSchema
+	}
+
+	userMessage := constants.GetExplainQueryUserMessage(chat.Connection.Type, queryType, query, schemaContext)
+	response, err := llmClient.GenerateRawJSON(ctx, constants.GeminiExplainQueryPrompt, userMessage, modelID)
+	if err != nil {
+		log.Printf("ChatService -> ExplainQueryInPlainEnglish -> LLM call failed: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to explain query: %v", err)
+	}
+
+	var explanation dtos.QueryExplanationResponse
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &explanation); err != nil {
+		log.Printf("ChatService -> ExplainQueryInPlainEnglish -> Error parsing LLM response: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to parse query explanation: %v", err)
+	}
+	if strings.TrimSpace(explanation.Explanation) == "" {
+		return nil, http.StatusInternalServerError, fmt.Errorf("the LLM returned an empty explanation")
+	}
+
+	// Number the steps in order, whatever numbering the LLM used
+	steps := make([]dtos.QueryExplanationStep, 0, len(explanation.Steps))
+	for _, step := range explanation.Steps {
+		if description := strings.TrimSpace(step.Description); description != "" {
+			steps = append(steps, dtos.QueryExplanationStep{Step: len(steps) + 1, Description: description})
+		}
+	}
+	explanation.Steps = steps
+
+	log.Printf("ChatService -> ExplainQueryInPlainEnglish -> Explained query in %d steps", len(steps))
+	return &explanation, http.StatusOK, nil
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"neobase-ai/config"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportChat exports a chat with its decrypted connection config, messages, LLM messages and schema snapshot
+// so it can be imported into another NeoBase instance. Connection secrets are only included when includeCredentials is set.
+func (s *chatService) ExportChat(ctx context.Context, userID, chatID string, includeCredentials bool) (*dtos.ChatExport, uint32, error) {
+	log.Printf("ChatService -> ExportChat -> chatID: %s, includeCredentials: %v", chatID, includeCredentials)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	messages, err := s.findAllChatMessages(chatObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch messages: %v", err)
+	}
+
+	export := &dtos.ChatExport{
+		ExportVersion:       constants.ChatExportVersion,
+		ExportedAt:          time.Now(),
+		IncludesCredentials: includeCredentials,
+		Chat: dtos.ChatExportMetadata{
+			ID:                  chat.ID.Hex(),
+			SelectedCollections: chat.SelectedCollections,
+			Settings:            chat.Settings,
+			PreferredLLMModel:   chat.PreferredLLMModel,
+			CreatedAt:           chat.CreatedAt,
+			UpdatedAt:           chat.UpdatedAt,
+		},
+		Messages:    make([]models.Message, 0, len(messages)),
+		LLMMessages: []models.LLMMessage{},
+	}
+
+	// LLM messages are not stored, they are built from the messages the same way they are sent to the LLM.
+	// This runs before the connection is decrypted below, as decrypting shares the connection's string pointers.
+	if len(messages) > 0 {
+		llmMessages, err := s.convertMessagesToLLMFormat(ctx, chat, messages, chat.Connection.Type, "", false)
+		if err != nil {
+			log.Printf("ChatService -> ExportChat -> Exporting without LLM messages: %v", err)
+		} else {
+			for _, llmMsg := range llmMessages {
+				export.LLMMessages = append(export.LLMMessages, *llmMsg)
+			}
+		}
+	}
+
+	for _, msg := range messages {
+		export.Messages = append(export.Messages, *msg)
+	}
+
+	if chat.Connection.CurrentSchema != nil && *chat.Connection.CurrentSchema != "" {
+		export.SchemaSnapshot = &dtos.ChatExportSchemaSnapshot{Schema: *chat.Connection.CurrentSchema}
+		if chat.Connection.SchemaUpdatedAt != nil {
+			updatedAt := chat.Connection.SchemaUpdatedAt.Time()
+			export.SchemaSnapshot.UpdatedAt = &updatedAt
+		}
+	}
+
+	export.Connection = exportConnection(chat.Connection, includeCredentials)
+	for _, secondary := range chat.SecondaryConnections {
+		export.SecondaryConnections = append(export.SecondaryConnections, exportConnection(secondary, includeCredentials))
+	}
+
+	log.Printf("ChatService -> ExportChat -> Exported chat %s with %d messages", chatID, len(export.Messages))
+	return export, http.StatusOK, nil
+}
+
+// ImportChat recreates an exported chat for the user. Credentials are re-encrypted with this instance's key,
+// and message, query and cross-reference IDs are remapped to new IDs. LLM messages are rebuilt from the messages.
+func (s *chatService) ImportChat(ctx context.Context, userID string, export *dtos.ChatExport) (*dtos.ChatImportResponse, uint32, error) {
+	log.Printf("ChatService -> ImportChat -> userID: %s, exportVersion: %s", userID, export.ExportVersion)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	if !isSupportedChatExportVersion(export.ExportVersion) {
+		return nil, http.StatusBadRequest, fmt.Errorf("unsupported export version: %s. Supported versions: %v", export.ExportVersion, constants.SupportedChatExportVersions)
+	}
+
+	if !isValidDBType(export.Connection.Type) {
+		return nil, http.StatusBadRequest, fmt.Errorf("Unsupported data source type: %s", export.Connection.Type)
+	}
+	for _, secondary := range export.SecondaryConnections {
+		if !isValidDBType(secondary.Type) {
+			return nil, http.StatusBadRequest, fmt.Errorf("Unsupported data source type: %s", secondary.Type)
+		}
+	}
+
+	// If trial mode, check if user already has 2 chats, return error
+	if config.Env.MaxChatsPerUser == 0 { // 0 == Trial Mode
+		chats, _, err := s.chatRepo.FindByUserID(userObjID, 1, 3) // Trying to fetch 3 chats
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch chat: %v", err)
+		}
+		if len(chats) >= 2 {
+			return nil, http.StatusBadRequest, fmt.Errorf("You cannot have more than 2 chats in trial mode")
+		}
+	}
+
+	response := &dtos.ChatImportResponse{Warnings: []string{}}
+
+	connection, err := importConnection(export.Connection, export.SchemaSnapshot)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to secure connection details: %v", err)
+	}
+
+	var secondaryConnections []models.Connection
+	for _, secondary := range export.SecondaryConnections {
+		secondaryConnection, err := importConnection(secondary, nil)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to secure connection details: %v", err)
+		}
+		secondaryConnections = append(secondaryConnections, secondaryConnection)
+	}
+
+	if !export.IncludesCredentials {
+		response.Warnings = append(response.Warnings, "The export does not include credentials, update the connection before connecting to the database")
+	}
+
+	selectedCollections := export.Chat.SelectedCollections
+	if selectedCollections == "" {
+		selectedCollections = "ALL"
+	}
+
+	newChat := &models.Chat{
+		UserID:               userObjID,
+		Connection:           connection,
+		SelectedCollections:  selectedCollections,
+		Settings:             export.Chat.Settings,
+		PreferredLLMModel:    export.Chat.PreferredLLMModel,
+		SecondaryConnections: secondaryConnections,
+		Base:                 models.NewBase(),
+	}
+
+	if err := s.chatRepo.Create(newChat); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create imported chat: %v", err)
+	}
+
+	// Messages are created oldest first so they list in the same order as on the source instance
+	messages := export.Messages
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+
+	// New IDs are assigned up front, so references to messages or queries later in the chat can be remapped too
+	messageIDMap := make(map[primitive.ObjectID]primitive.ObjectID, len(messages))
+	queryIDMap := make(map[string]string)
+	for _, msg := range messages {
+		messageIDMap[msg.ID] = primitive.NewObjectID()
+		if msg.Queries != nil {
+			for _, q := range *msg.Queries {
+				queryIDMap[q.ID.Hex()] = primitive.NewObjectID().Hex()
+			}
+		}
+	}
+
+	for _, msg := range messages {
+		newMsg := msg
+		newMsg.ID = messageIDMap[msg.ID]
+		newMsg.UserID = userObjID
+		newMsg.ChatID = newChat.ID
+		newMsg.UserMessageId = remapMessageID(messageIDMap, msg.UserMessageId)
+		newMsg.ParentMessageID = remapMessageID(messageIDMap, msg.ParentMessageID)
+		newMsg.ThreadID = remapMessageID(messageIDMap, msg.ThreadID)
+
+		if msg.Queries != nil {
+			queries := make([]models.Query, len(*msg.Queries))
+			for i, q := range *msg.Queries {
+				queries[i] = q
+				queries[i].ID, _ = primitive.ObjectIDFromHex(queryIDMap[q.ID.Hex()])
+				queries[i].VisualizationID = nil // Visualizations are not exported
+				if q.RollbackDependentQuery != nil {
+					if newQueryID, exists := queryIDMap[*q.RollbackDependentQuery]; exists {
+						queries[i].RollbackDependentQuery = &newQueryID
+					} else {
+						queries[i].RollbackDependentQuery = nil
+					}
+				}
+			}
+			newMsg.Queries = &queries
+		}
+
+		if msg.ActionButtons != nil {
+			actionButtons := make([]models.ActionButton, len(*msg.ActionButtons))
+			for i, btn := range *msg.ActionButtons {
+				actionButtons[i] = btn
+				actionButtons[i].ID = primitive.NewObjectID()
+			}
+			newMsg.ActionButtons = &actionButtons
+		}
+
+		if err := s.chatRepo.CreateMessage(&newMsg); err != nil {
+			log.Printf("ChatService -> ImportChat -> Error importing message %s: %v", msg.ID.Hex(), err)
+			response.MessagesSkipped++
+			continue
+		}
+		response.MessagesImported++
+	}
+
+	if response.MessagesSkipped > 0 {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("%d messages could not be imported", response.MessagesSkipped))
+	}
+
+	log.Printf("ChatService -> ImportChat -> Imported chat %s as %s with %d messages", export.Chat.ID, newChat.ID.Hex(), response.MessagesImported)
+	response.Chat = s.buildChatResponse(newChat)
+	return response, http.StatusCreated, nil
+}
+
+// findAllChatMessages returns every message of a chat, oldest first
+func (s *chatService) findAllChatMessages(chatID primitive.ObjectID) ([]*models.Message, error) {
+	var allMessages []*models.Message
+	for page := 1; ; page++ {
+		messages, total, err := s.chatRepo.FindMessagesByChat(chatID, page, constants.ChatExportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		allMessages = append(allMessages, messages...)
+		if len(messages) < constants.ChatExportPageSize || int64(len(allMessages)) >= total {
+			break
+		}
+	}
+
+	sort.SliceStable(allMessages, func(i, j int) bool {
+		return allMessages[i].CreatedAt.Before(allMessages[j].CreatedAt)
+	})
+	return allMessages, nil
+}
+
+// exportConnection decrypts a connection for export, moving its secrets into Credentials when they are included
+func exportConnection(connection models.Connection, includeCredentials bool) dtos.ChatExportConnection {
+	utils.DecryptConnection(&connection)
+
+	// The schema is exported once as the chat's schema snapshot
+	connection.CurrentSchema = nil
+	connection.SchemaUpdatedAt = nil
+
+	exported := dtos.ChatExportConnection{Connection: connection}
+	if includeCredentials {
+		exported.Credentials = &dtos.ChatExportCredentials{
+			Password:                 connection.Password,
+			SSHPrivateKey:            connection.SSHPrivateKey,
+			SSHPassphrase:            connection.SSHPassphrase,
+			SSHPassword:              connection.SSHPassword,
+			GoogleAuthToken:          connection.GoogleAuthToken,
+			GoogleRefreshToken:       connection.GoogleRefreshToken,
+			SupabaseAnonKey:          connection.SupabaseAnonKey,
+			SupabaseServiceRoleKey:   connection.SupabaseServiceRoleKey,
+			AirtableAPIKey:           connection.AirtableAPIKey,
+			PlanetscaleServiceToken:  connection.PlanetscaleServiceToken,
+			InfluxToken:              connection.InfluxToken,
+			FirestoreCredentialsJSON: connection.FirestoreCredentialsJSON,
+			CloudflareAPIToken:       connection.CloudflareAPIToken,
+		}
+	}
+	return exported
+}
+
+// importConnection rebuilds an exported connection and encrypts it with this instance's key
+func importConnection(exported dtos.ChatExportConnection, schemaSnapshot *dtos.ChatExportSchemaSnapshot) (models.Connection, error) {
+	connection := exported.Connection
+	connection.Base = models.NewBase()
+	connection.CurrentSchema = nil
+	connection.SchemaUpdatedAt = nil
+
+	if exported.Credentials != nil {
+		connection.Password = exported.Credentials.Password
+		connection.SSHPrivateKey = exported.Credentials.SSHPrivateKey
+		connection.SSHPassphrase = exported.Credentials.SSHPassphrase
+		connection.SSHPassword = exported.Credentials.SSHPassword
+		connection.GoogleAuthToken = exported.Credentials.GoogleAuthToken
+		connection.GoogleRefreshToken = exported.Credentials.GoogleRefreshToken
+		connection.SupabaseAnonKey = exported.Credentials.SupabaseAnonKey
+		connection.SupabaseServiceRoleKey = exported.Credentials.SupabaseServiceRoleKey
+		connection.AirtableAPIKey = exported.Credentials.AirtableAPIKey
+		connection.PlanetscaleServiceToken = exported.Credentials.PlanetscaleServiceToken
+		connection.InfluxToken = exported.Credentials.InfluxToken
+		connection.FirestoreCredentialsJSON = exported.Credentials.FirestoreCredentialsJSON
+		connection.CloudflareAPIToken = exported.Credentials.CloudflareAPIToken
+	}
+
+	// Restore the schema snapshot as the schema cache so the first message does not need to refetch it
+	if schemaSnapshot != nil && schemaSnapshot.Schema != "" {
+		schema := schemaSnapshot.Schema
+		connection.CurrentSchema = &schema
+		if schemaSnapshot.UpdatedAt != nil {
+			updatedAt := primitive.NewDateTimeFromTime(*schemaSnapshot.UpdatedAt)
+			connection.SchemaUpdatedAt = &updatedAt
+		}
+	}
+
+	if err := utils.EncryptConnection(&connection); err != nil {
+		return models.Connection{}, err
+	}
+	return connection, nil
+}
+
+// remapMessageID translates a message reference to the imported message's ID, nil if it is not part of the export
+func remapMessageID(messageIDMap map[primitive.ObjectID]primitive.ObjectID, id *primitive.ObjectID) *primitive.ObjectID {
+	if id == nil {
+		return nil
+	}
+	newID, exists := messageIDMap[*id]
+	if !exists {
+		return nil
+	}
+	return &newID
+}
+
+// isSupportedChatExportVersion reports whether a chat export version can be imported
+func isSupportedChatExportVersion(version string) bool {
+	for _, supported := range constants.SupportedChatExportVersions {
+		if version == supported {
+			return true
+		}
+	}
+	return false
+}
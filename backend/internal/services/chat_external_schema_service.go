@@ -0,0 +1,484 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/dbmanager"
+	"neobase-ai/pkg/parsers"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UploadPrismaSchema stores a schema.prisma for the chat. Its relation names and documentation are merged into
+// the schema the LLM sees, replacing any previously uploaded Prisma schema.
+func (s *chatService) UploadPrismaSchema(ctx context.Context, userID, chatID, fileName string, content []byte) (*dtos.ExternalSchemaSourceResponse, uint32, error) {
+	log.Printf("ChatService -> UploadPrismaSchema -> userID: %s, chatID: %s, file: %s (%d bytes)", userID, chatID, fileName, len(content))
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.schemaSourceRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("schema uploads are not available")
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("the Prisma schema is empty")
+	}
+
+	schema, err := parsers.ParsePrismaSchema(string(content))
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid Prisma schema: %v", err)
+	}
+	tables := buildPrismaSchemaTables(schema)
+	if len(tables) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("the Prisma schema has no models")
+	}
+
+	source := models.NewExternalSchemaSource(chat.ID, chat.UserID, constants.ExternalSchemaSourcePrisma, fileName, string(content), tables)
+	if err := s.schemaSourceRepo.Upsert(ctx, source); err != nil {
+		log.Printf("ChatService -> UploadPrismaSchema -> Error saving schema: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save Prisma schema")
+	}
+
+	response := s.externalSchemaSourceResponse(ctx, chat, source)
+	log.Printf("ChatService -> UploadPrismaSchema -> Stored %d tables and %d relations for chat %s",
+		len(tables), response.RelationCount, chatID)
+	return response, http.StatusOK, nil
+}
+
+// UploadOpenAPISpec stores an OpenAPI spec (or Swagger 2.0 document) for the chat. Its component schemas are
+// matched to the chat's tables by name, and their descriptions, examples and enum values are merged into the
+// schema the LLM sees, replacing any previously uploaded spec.
+func (s *chatService) UploadOpenAPISpec(ctx context.Context, userID, chatID, fileName string, content []byte) (*dtos.ExternalSchemaSourceResponse, uint32, error) {
+	log.Printf("ChatService -> UploadOpenAPISpec -> userID: %s, chatID: %s, file: %s (%d bytes)", userID, chatID, fileName, len(content))
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.schemaSourceRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("schema uploads are not available")
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("the OpenAPI spec is empty")
+	}
+
+	spec, err := parsers.ParseOpenAPISpec(content)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if len(spec.Schemas) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("the OpenAPI spec has no object schemas in its components")
+	}
+
+	// Without a fetched schema the spec's schema names are kept, they are matched again when the schema is formatted
+	var storedSchema *dbmanager.SchemaInfo
+	if stored, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID); err == nil {
+		storedSchema = stored
+	}
+	tables := buildOpenAPISchemaTables(spec, storedSchema)
+
+	source := models.NewExternalSchemaSource(chat.ID, chat.UserID, constants.ExternalSchemaSourceOpenAPI, fileName, string(content), tables)
+	if err := s.schemaSourceRepo.Upsert(ctx, source); err != nil {
+		log.Printf("ChatService -> UploadOpenAPISpec -> Error saving spec: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save OpenAPI spec")
+	}
+
+	response := s.externalSchemaSourceResponse(ctx, chat, source)
+	log.Printf("ChatService -> UploadOpenAPISpec -> Stored %d schemas of %q (%d unmatched) and %d relations for chat %s",
+		len(tables), spec.Title, len(response.UnmatchedTables), response.RelationCount, chatID)
+	return response, http.StatusOK, nil
+}
+
+// externalSchemaSourceResponse describes a stored upload, and formats the chat's schema again in the background
+// as the schema cached on the chat was formatted without it
+func (s *chatService) externalSchemaSourceResponse(ctx context.Context, chat *models.Chat, source *models.ExternalSchemaSource) *dtos.ExternalSchemaSourceResponse {
+	chatID := chat.ID.Hex()
+	response := &dtos.ExternalSchemaSourceResponse{
+		ID:              source.ID.Hex(),
+		ChatID:          chatID,
+		SourceType:      source.SourceType,
+		FileName:        source.FileName,
+		Tables:          source.Tables,
+		UnmatchedTables: s.findUnmatchedExternalTables(ctx, chatID, source.Tables),
+		UpdatedAt:       source.UpdatedAt,
+	}
+	for _, table := range source.Tables {
+		response.RelationCount += len(table.Relations)
+	}
+
+	if s.dbManager.IsConnected(chatID) {
+		go s.refreshFormattedSchema(chat)
+	}
+	return response
+}
+
+// GetExternalSchema implements dbmanager.ExternalSchemaProvider with the schema files uploaded for the chat
+func (s *chatService) GetExternalSchema(ctx context.Context, chatID string) (*dbmanager.ExternalSchema, error) {
+	if s.schemaSourceRepo == nil {
+		return nil, nil
+	}
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		// Federated and transient connections are not keyed by chat ID
+		return nil, nil
+	}
+
+	sources, err := s.schemaSourceRepo.FindByChatID(ctx, chatObjID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	external := &dbmanager.ExternalSchema{Tables: make(map[string]dbmanager.ExternalTable)}
+	labels := make([]string, 0, len(sources))
+	for _, source := range sources {
+		labels = append(labels, externalSchemaLabel(source.SourceType))
+		for _, table := range source.Tables {
+			externalTable := external.Tables[table.Name]
+			if externalTable.ColumnDescriptions == nil {
+				externalTable.ColumnDescriptions = make(map[string]string)
+			}
+			if externalTable.Description == "" {
+				externalTable.Description = table.Description
+			}
+			for _, column := range table.Columns {
+				description := externalColumnDescription(column)
+				if description != "" && externalTable.ColumnDescriptions[column.Name] == "" {
+					externalTable.ColumnDescriptions[column.Name] = description
+				}
+			}
+			for _, relation := range table.Relations {
+				externalTable.Relations = append(externalTable.Relations, dbmanager.ExternalRelation{
+					Name:        relation.Name,
+					Description: relation.Description,
+					Columns:     relation.Columns,
+					RefTable:    relation.RefTable,
+					RefColumns:  relation.RefColumns,
+					OnDelete:    relation.OnDelete,
+					OnUpdate:    relation.OnUpdate,
+				})
+			}
+			external.Tables[table.Name] = externalTable
+		}
+	}
+	external.Source = strings.Join(labels, ", ")
+	return external, nil
+}
+
+// buildPrismaSchemaTables turns Prisma models into tables with database names. Relation fields become
+// relations on the model holding the foreign key, the other side of a relation adds nothing.
+func buildPrismaSchemaTables(schema *parsers.PrismaSchema) []models.ExternalSchemaTable {
+	tables := make([]models.ExternalSchemaTable, 0, len(schema.Models))
+	for _, model := range schema.Models {
+		table := models.ExternalSchemaTable{
+			Name:        model.TableName(),
+			Model:       model.Name,
+			Description: model.Documentation,
+			Columns:     []models.ExternalSchemaColumn{},
+		}
+
+		for i := range model.Fields {
+			field := &model.Fields[i]
+			if !schema.IsRelationField(field) {
+				fieldType := field.Type
+				if field.IsList {
+					fieldType += "[]"
+				} else if field.IsOptional {
+					fieldType += "?"
+				}
+				table.Columns = append(table.Columns, models.ExternalSchemaColumn{
+					Name:        field.ColumnName(),
+					Type:        fieldType,
+					NativeType:  field.NativeType,
+					Description: field.Documentation,
+				})
+				continue
+			}
+			if field.Relation == nil || len(field.Relation.Fields) == 0 {
+				continue
+			}
+
+			relation := models.ExternalSchemaRelation{
+				Name:        field.Relation.Name,
+				Field:       field.Name,
+				RefTable:    field.Type,
+				OnDelete:    field.Relation.OnDelete,
+				OnUpdate:    field.Relation.OnUpdate,
+				Description: field.Documentation,
+			}
+			for _, name := range field.Relation.Fields {
+				if column := model.Field(name); column != nil {
+					name = column.ColumnName()
+				}
+				relation.Columns = append(relation.Columns, name)
+			}
+			target := schema.Model(field.Type)
+			if target != nil {
+				relation.RefTable = target.TableName()
+			}
+			for _, name := range field.Relation.References {
+				if target != nil {
+					if column := target.Field(name); column != nil {
+						name = column.ColumnName()
+					}
+				}
+				relation.RefColumns = append(relation.RefColumns, name)
+			}
+			table.Relations = append(table.Relations, relation)
+		}
+
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// externalColumnDescription is the description of a column followed by its allowed values and example,
+// e.g. "Order status; one of: pending, shipped, delivered"
+func externalColumnDescription(column models.ExternalSchemaColumn) string {
+	parts := []string{}
+	if column.Description != "" {
+		parts = append(parts, column.Description)
+	}
+	if len(column.Enum) > 0 {
+		values := column.Enum
+		if len(values) > constants.OpenAPIEnumMaxValues {
+			values = append(values[:constants.OpenAPIEnumMaxValues:constants.OpenAPIEnumMaxValues], "...")
+		}
+		parts = append(parts, "one of: "+strings.Join(values, ", "))
+	}
+	if column.Example != "" {
+		parts = append(parts, "e.g. "+column.Example)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// buildOpenAPISchemaTables turns the object schemas of a spec into tables. A schema is matched to a table of the
+// stored database schema by name, ignoring case, underscores and plurals, so Order and OrderItem match orders and
+// order_items. Properties are matched to columns the same way, createdAt matches created_at. Schemas matching the
+// same table are merged, the closest name first. A property referring to another schema becomes a relation when
+// the table has an id column for it, such as customer_id for customer.
+func buildOpenAPISchemaTables(spec *parsers.OpenAPISpec, storedSchema *dbmanager.SchemaInfo) []models.ExternalSchemaTable {
+	schemaTables := make(map[string]string, len(spec.Schemas))
+	for _, schema := range spec.Schemas {
+		schemaTables[schema.Name] = matchOpenAPITable(schema.Name, storedSchema)
+	}
+
+	// Exact names first, so Order describes orders before OrderResponse adds what it is missing
+	schemas := make([]parsers.OpenAPISchema, len(spec.Schemas))
+	copy(schemas, spec.Schemas)
+	sort.SliceStable(schemas, func(i, j int) bool {
+		return len(schemas[i].Name) < len(schemas[j].Name)
+	})
+
+	tables := []models.ExternalSchemaTable{}
+	index := make(map[string]int)
+	for _, schema := range schemas {
+		tableName := schemaTables[schema.Name]
+		i, ok := index[tableName]
+		if !ok {
+			tables = append(tables, models.ExternalSchemaTable{
+				Name:    tableName,
+				Model:   schema.Name,
+				Columns: []models.ExternalSchemaColumn{},
+			})
+			i = len(tables) - 1
+			index[tableName] = i
+		}
+		table := &tables[i]
+		if table.Description == "" {
+			table.Description = schema.Description
+		}
+
+		var tableColumns map[string]dbmanager.ColumnInfo
+		if storedSchema != nil {
+			tableColumns = storedSchema.Tables[tableName].Columns
+		}
+		for _, property := range schema.Properties {
+			columnName := matchOpenAPIColumn(property.Name, tableColumns)
+			if hasExternalColumn(table.Columns, columnName) {
+				continue
+			}
+
+			columnType := property.Type
+			if property.Format != "" {
+				columnType += "(" + property.Format + ")"
+			}
+			if property.Ref != "" && columnType == "" {
+				columnType = property.Ref
+			}
+			table.Columns = append(table.Columns, models.ExternalSchemaColumn{
+				Name:        columnName,
+				Type:        columnType,
+				Description: property.Description,
+				Example:     property.Example,
+				Enum:        property.Enum,
+			})
+
+			if property.Ref == "" || property.Type == "array" || tableColumns == nil {
+				continue
+			}
+			refTable, ok := schemaTables[property.Ref]
+			if !ok {
+				continue
+			}
+			idColumn := matchOpenAPIColumn(property.Name+"_id", tableColumns)
+			if _, exists := tableColumns[idColumn]; !exists {
+				continue
+			}
+			table.Relations = append(table.Relations, models.ExternalSchemaRelation{
+				Field:       property.Name,
+				Columns:     []string{idColumn},
+				RefTable:    refTable,
+				RefColumns:  []string{"id"},
+				Description: property.Description,
+			})
+		}
+	}
+	return tables
+}
+
+// matchOpenAPITable returns the table of the stored schema an OpenAPI schema name describes, or the schema name
+// when no table matches
+func matchOpenAPITable(schemaName string, storedSchema *dbmanager.SchemaInfo) string {
+	if storedSchema == nil {
+		return schemaName
+	}
+	name := singularSchemaName(normalizeSchemaName(schemaName))
+	trimmed := name
+	for _, suffix := range []string{"response", "request", "resource", "entity", "model", "schema", "dto"} {
+		if strings.HasSuffix(trimmed, suffix) && len(trimmed) > len(suffix) {
+			trimmed = singularSchemaName(strings.TrimSuffix(trimmed, suffix))
+			break
+		}
+	}
+
+	tableNames := make([]string, 0, len(storedSchema.Tables))
+	for tableName := range storedSchema.Tables {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+
+	for _, candidate := range []string{name, trimmed} {
+		for _, tableName := range tableNames {
+			table := singularSchemaName(normalizeSchemaName(tableName[strings.LastIndex(tableName, ".")+1:]))
+			if table == candidate {
+				return tableName
+			}
+		}
+	}
+	return schemaName
+}
+
+// matchOpenAPIColumn returns the column a property describes, or the property name when no column matches
+func matchOpenAPIColumn(propertyName string, columns map[string]dbmanager.ColumnInfo) string {
+	if _, ok := columns[propertyName]; ok {
+		return propertyName
+	}
+	normalized := normalizeSchemaName(propertyName)
+	for columnName := range columns {
+		if normalizeSchemaName(columnName) == normalized {
+			return columnName
+		}
+	}
+	return propertyName
+}
+
+func hasExternalColumn(columns []models.ExternalSchemaColumn, name string) bool {
+	for _, column := range columns {
+		if column.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSchemaName lowercases a name and drops everything but letters and digits, order_items becomes orderitems
+func normalizeSchemaName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// singularSchemaName strips an English plural ending, categories becomes category and addresses becomes address
+func singularSchemaName(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	default:
+		return name
+	}
+}
+
+// findUnmatchedExternalTables lists the uploaded tables the chat's stored database schema doesn't have.
+// Nothing is reported before the schema was first fetched.
+func (s *chatService) findUnmatchedExternalTables(ctx context.Context, chatID string, tables []models.ExternalSchemaTable) []string {
+	schema, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID)
+	if err != nil || schema == nil {
+		return nil
+	}
+
+	var unmatched []string
+	for _, table := range tables {
+		found := false
+		for name := range schema.Tables {
+			if strings.EqualFold(name, table.Name) || strings.EqualFold(name[strings.LastIndex(name, ".")+1:], table.Name) {
+				found = true
+				break
+			}
+		}
+		if _, isView := schema.Views[table.Name]; !found && !isView {
+			unmatched = append(unmatched, table.Name)
+		}
+	}
+	return unmatched
+}
+
+// refreshFormattedSchema formats the chat's schema again and saves it as the schema the LLM receives
+func (s *chatService) refreshFormattedSchema(chat *models.Chat) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	selectedCollections := []string{"ALL"}
+	if chat.SelectedCollections != "" && chat.SelectedCollections != "ALL" {
+		selectedCollections = strings.Split(chat.SelectedCollections, ",")
+	}
+
+	formattedSchema, err := s.dbManager.FormatSchemaWithExamples(ctx, chat.ID.Hex(), selectedCollections)
+	if err != nil {
+		log.Printf("ChatService -> refreshFormattedSchema -> Error formatting schema for chat %s: %v", chat.ID.Hex(), err)
+		return
+	}
+	if err := s.chatRepo.UpdateConnectionSchema(ctx, chat.ID, formattedSchema); err != nil {
+		log.Printf("ChatService -> refreshFormattedSchema -> Error saving schema for chat %s: %v", chat.ID.Hex(), err)
+	}
+}
+
+func externalSchemaLabel(sourceType string) string {
+	switch sourceType {
+	case constants.ExternalSchemaSourcePrisma:
+		return constants.PrismaSchemaLabel
+	case constants.ExternalSchemaSourceOpenAPI:
+		return constants.OpenAPISpecLabel
+	default:
+		return sourceType + " schema"
+	}
+}
@@ -0,0 +1,435 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// secondaryConnectionKey returns the key the secondary connection of a chat is registered under in the DB manager.
+// Keeping it separate from the chat ID gives the secondary database its own pool entry and stored schema.
+func secondaryConnectionKey(chatID string) string {
+	return chatID + "_secondary"
+}
+
+// buildSecondaryConnections validates, tests and encrypts the secondary connections from an update request.
+func (s *chatService) buildSecondaryConnections(userID string, reqs []dtos.CreateConnectionRequest) ([]models.Connection, uint32, error) {
+	if len(reqs) > constants.MaxSecondaryConnections {
+		return nil, http.StatusBadRequest, fmt.Errorf("at most %d secondary connection is supported", constants.MaxSecondaryConnections)
+	}
+
+	connections := make([]models.Connection, 0, len(reqs))
+	for _, req := range reqs {
+		if !isValidDBType(req.Type) {
+			return nil, http.StatusBadRequest, fmt.Errorf("unsupported data source type: %s", req.Type)
+		}
+		// Spreadsheets live in the internal database and are scoped to their own chat
+		if req.Type == constants.DatabaseTypeSpreadsheet || req.Type == constants.DatabaseTypeGoogleSheets {
+			return nil, http.StatusBadRequest, fmt.Errorf("%s cannot be used as a secondary connection", req.Type)
+		}
+		if err := applyConnectionURI(&req); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("secondary connection: %v", err)
+		}
+		applyAirtableDefaults(&req)
+		applyTemporalDefaults(&req)
+		applyFirestoreDefaults(&req)
+		applyCloudflareD1Defaults(&req)
+		applyNeonDetection(&req)
+		applyYugabyteDBAPIType(&req)
+		if status, err := s.resolveVaultCredentials(userID, &req); err != nil {
+			return nil, status, fmt.Errorf("secondary connection: %v", err)
+		}
+
+		username := req.Username
+		if err := s.dbManager.TestConnection(&dbmanager.ConnectionConfig{
+			Type:                     req.Type,
+			Host:                     req.Host,
+			Port:                     req.Port,
+			Username:                 &username,
+			Password:                 req.Password,
+			Database:                 req.Database,
+			AuthDatabase:             req.AuthDatabase,
+			UseSSL:                   req.UseSSL,
+			SSLMode:                  req.SSLMode,
+			SSLCertURL:               req.SSLCertURL,
+			SSLKeyURL:                req.SSLKeyURL,
+			SSLRootCertURL:           req.SSLRootCertURL,
+			Catalog:                  req.Catalog,
+			Schema:                   req.Schema,
+			ServiceName:              req.ServiceName,
+			AirtableAPIKey:           req.AirtableAPIKey,
+			AirtableBaseID:           req.AirtableBaseID,
+			PlanetscaleBranch:        req.PlanetscaleBranch,
+			InfluxOrg:                req.InfluxOrg,
+			InfluxToken:              req.InfluxToken,
+			ReadPreference:           req.ReadPreference,
+			TemporalNamespace:        req.TemporalNamespace,
+			TemporalAddress:          req.TemporalAddress,
+			NATSCredentialsFile:      req.NATSCredentialsFile,
+			GoogleProjectID:          req.GoogleProjectID,
+			FirestoreCredentialsJSON: req.FirestoreCredentialsJSON,
+			CloudflareAccountID:      req.CloudflareAccountID,
+			CloudflareD1DatabaseID:   req.CloudflareD1DatabaseID,
+			CloudflareAPIToken:       req.CloudflareAPIToken,
+		}); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("secondary connection test failed: %v", err)
+		}
+
+		connection := models.Connection{
+			Type:                      req.Type,
+			Host:                      req.Host,
+			Port:                      req.Port,
+			Username:                  &username,
+			Password:                  req.Password,
+			Database:                  req.Database,
+			AuthDatabase:              req.AuthDatabase,
+			UseSSL:                    req.UseSSL,
+			SSLMode:                   req.SSLMode,
+			SSLCertURL:                req.SSLCertURL,
+			SSLKeyURL:                 req.SSLKeyURL,
+			SSLRootCertURL:            req.SSLRootCertURL,
+			Catalog:                   req.Catalog,
+			Schema:                    req.Schema,
+			ServiceName:               req.ServiceName,
+			AirtableAPIKey:            req.AirtableAPIKey,
+			AirtableBaseID:            req.AirtableBaseID,
+			PlanetscaleBranch:         req.PlanetscaleBranch,
+			PlanetscaleOrganization:   req.PlanetscaleOrganization,
+			PlanetscaleServiceTokenID: req.PlanetscaleServiceTokenID,
+			PlanetscaleServiceToken:   req.PlanetscaleServiceToken,
+			InfluxOrg:                 req.InfluxOrg,
+			InfluxToken:               req.InfluxToken,
+			ReadPreference:            req.ReadPreference,
+			TemporalNamespace:         req.TemporalNamespace,
+			TemporalAddress:           req.TemporalAddress,
+			NATSCredentialsFile:       req.NATSCredentialsFile,
+			GoogleProjectID:           req.GoogleProjectID,
+			FirestoreCredentialsJSON:  req.FirestoreCredentialsJSON,
+			CloudflareAccountID:       req.CloudflareAccountID,
+			CloudflareD1DatabaseID:    req.CloudflareD1DatabaseID,
+			CloudflareAPIToken:        req.CloudflareAPIToken,
+			APIType:                   req.APIType,
+			VaultSecretPath:           req.VaultSecretPath,
+			Base:                      models.NewBase(),
+		}
+		if err := utils.EncryptConnection(&connection); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to secure secondary connection details: %v", err)
+		}
+		connections = append(connections, connection)
+	}
+
+	return connections, http.StatusOK, nil
+}
+
+// disconnectSecondaryDB drops the secondary connection of a chat, if any, along with its stored schema.
+func (s *chatService) disconnectSecondaryDB(chat *models.Chat) {
+	if len(chat.SecondaryConnections) == 0 {
+		return
+	}
+	chatID := chat.ID.Hex()
+	if err := s.dbManager.DisconnectWithType(secondaryConnectionKey(chatID), chat.UserID.Hex(), chat.SecondaryConnections[0].Type, true); err != nil {
+		log.Printf("ChatService -> disconnectSecondaryDB -> Failed to disconnect secondary connection for chat %s: %v", chatID, err)
+	}
+}
+
+// connectSecondaryDB makes sure the chat's secondary connection is live and returns its manager key.
+func (s *chatService) connectSecondaryDB(chat *models.Chat) (string, error) {
+	if len(chat.SecondaryConnections) == 0 {
+		return "", fmt.Errorf("chat has no secondary connection")
+	}
+
+	key := secondaryConnectionKey(chat.ID.Hex())
+	if _, exists := s.dbManager.GetConnectionInfo(key); exists {
+		return key, nil
+	}
+
+	conn := chat.SecondaryConnections[0]
+	utils.DecryptConnection(&conn)
+	if conn.Port == nil || *conn.Port == "" {
+		defaultPort := defaultPortForDBType(conn.Type)
+		conn.Port = &defaultPort
+	}
+
+	err := s.dbManager.Connect(key, chat.UserID.Hex(), "", dbmanager.ConnectionConfig{
+		Type:                     conn.Type,
+		Host:                     conn.Host,
+		Port:                     conn.Port,
+		Username:                 conn.Username,
+		Password:                 conn.Password,
+		Database:                 conn.Database,
+		AuthDatabase:             conn.AuthDatabase,
+		UseSSL:                   conn.UseSSL,
+		SSLMode:                  conn.SSLMode,
+		SSLCertURL:               conn.SSLCertURL,
+		SSLKeyURL:                conn.SSLKeyURL,
+		SSLRootCertURL:           conn.SSLRootCertURL,
+		Catalog:                  conn.Catalog,
+		Schema:                   conn.Schema,
+		ServiceName:              conn.ServiceName,
+		AirtableAPIKey:           conn.AirtableAPIKey,
+		AirtableBaseID:           conn.AirtableBaseID,
+		PlanetscaleBranch:        conn.PlanetscaleBranch,
+		InfluxOrg:                conn.InfluxOrg,
+		InfluxToken:              conn.InfluxToken,
+		ReadPreference:           conn.ReadPreference,
+		TemporalNamespace:        conn.TemporalNamespace,
+		TemporalAddress:          conn.TemporalAddress,
+		NATSCredentialsFile:      conn.NATSCredentialsFile,
+		GoogleProjectID:          conn.GoogleProjectID,
+		FirestoreCredentialsJSON: conn.FirestoreCredentialsJSON,
+		CloudflareAccountID:      conn.CloudflareAccountID,
+		CloudflareD1DatabaseID:   conn.CloudflareD1DatabaseID,
+		CloudflareAPIToken:       conn.CloudflareAPIToken,
+	})
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		return "", fmt.Errorf("failed to connect to secondary database: %v", err)
+	}
+
+	log.Printf("ChatService -> connectSecondaryDB -> Connected secondary %s database for chat %s", conn.Type, chat.ID.Hex())
+	return key, nil
+}
+
+// buildFederationContext returns the secondary schema and federation instructions for the LLM,
+// or "" when the chat has no secondary connection or its schema cannot be loaded.
+// The formatted schema is cached on the secondary connection like the primary one, so the secondary
+// database is only contacted until the first schema is stored, or again after the connection is updated.
+func (s *chatService) buildFederationContext(ctx context.Context, chat *models.Chat) string {
+	if len(chat.SecondaryConnections) == 0 {
+		return ""
+	}
+
+	secondary := chat.SecondaryConnections[0]
+	if secondary.CurrentSchema != nil && *secondary.CurrentSchema != "" {
+		return constants.GetFederationPrompt(chat.Connection.Type, secondary.Type, *secondary.CurrentSchema)
+	}
+
+	key, err := s.connectSecondaryDB(chat)
+	if err != nil {
+		log.Printf("ChatService -> buildFederationContext -> %v", err)
+		return ""
+	}
+
+	secondarySchema, err := s.dbManager.FormatSchemaWithExamples(ctx, key, []string{"ALL"})
+	if err != nil || secondarySchema == "" {
+		log.Printf("ChatService -> buildFederationContext -> Failed to get secondary schema: %v", err)
+		return ""
+	}
+
+	chat.SecondaryConnections[0].CurrentSchema = &secondarySchema
+	go func() {
+		updateCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.chatRepo.UpdateSecondaryConnectionSchema(updateCtx, chat.ID, secondarySchema); err != nil {
+			log.Printf("ChatService -> buildFederationContext -> Failed to cache secondary schema: %v", err)
+		}
+	}()
+
+	return constants.GetFederationPrompt(chat.Connection.Type, secondary.Type, secondarySchema)
+}
+
+// ExecuteFederatedQuery runs one read-only query against the primary and one against the secondary
+// database in parallel, then merges the two result sets with a hash join on the given keys.
+func (s *chatService) ExecuteFederatedQuery(ctx context.Context, userID, chatID string, req *dtos.FederatedExecuteRequest) (*dtos.FederatedExecuteResponse, uint32, error) {
+	log.Printf("ChatService -> ExecuteFederatedQuery -> userID: %s, chatID: %s", userID, chatID)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	if len(chat.SecondaryConnections) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("chat has no secondary connection")
+	}
+
+	joinType := strings.ToLower(strings.TrimSpace(req.JoinType))
+	if joinType == "" {
+		joinType = constants.FederatedJoinTypeInner
+	}
+	if joinType != constants.FederatedJoinTypeInner && joinType != constants.FederatedJoinTypeLeft {
+		return nil, http.StatusBadRequest, fmt.Errorf("unsupported join type: %s", req.JoinType)
+	}
+
+	primaryQuery := strings.TrimSuffix(strings.TrimSpace(req.PrimaryQuery), ";")
+	secondaryQuery := strings.TrimSuffix(strings.TrimSpace(req.SecondaryQuery), ";")
+	if !isReadOnlyQuery(chat.Connection.Type, primaryQuery) || !isReadOnlyQuery(chat.SecondaryConnections[0].Type, secondaryQuery) {
+		return nil, http.StatusBadRequest, fmt.Errorf("federated queries must be read-only")
+	}
+
+	// Make sure both connections are live
+	if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("federated-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to primary database: %v", err)
+		}
+	}
+	secondaryKey, err := s.connectSecondaryDB(chat)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	startTime := time.Now()
+
+	// Run both queries in parallel
+	var (
+		wg                             sync.WaitGroup
+		primaryRows, secondaryRows     []map[string]interface{}
+		primaryErr, secondaryErr       *dtos.QueryError
+		primaryResult, secondaryResult *dbmanager.QueryExecutionResult
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		primaryResult, primaryErr = s.dbManager.ExecuteQuery(ctx, chatID, "", "", fmt.Sprintf("federated-primary-%s", chatID), primaryQuery, "SELECT", false, false)
+	}()
+	go func() {
+		defer wg.Done()
+		secondaryResult, secondaryErr = s.dbManager.ExecuteQuery(ctx, secondaryKey, "", "", fmt.Sprintf("federated-secondary-%s", chatID), secondaryQuery, "SELECT", false, false)
+	}()
+	wg.Wait()
+
+	if primaryErr != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("primary query failed: %s", primaryErr.Message)
+	}
+	if secondaryErr != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("secondary query failed: %s", secondaryErr.Message)
+	}
+	if primaryResult != nil {
+		primaryRows = extractResultRows(primaryResult.Result)
+	}
+	if secondaryResult != nil {
+		secondaryRows = extractResultRows(secondaryResult.Result)
+	}
+
+	columns, rows, truncated := hashJoinResults(primaryRows, secondaryRows, req.PrimaryJoinKey, req.SecondaryJoinKey, joinType, constants.FederatedMaxResultRows)
+
+	log.Printf("ChatService -> ExecuteFederatedQuery -> Joined %d primary and %d secondary rows into %d rows (%s join)", len(primaryRows), len(secondaryRows), len(rows), joinType)
+
+	return &dtos.FederatedExecuteResponse{
+		Columns:           columns,
+		Rows:              rows,
+		RowCount:          len(rows),
+		PrimaryRowCount:   len(primaryRows),
+		SecondaryRowCount: len(secondaryRows),
+		JoinType:          joinType,
+		Truncated:         truncated,
+		ExecutionTimeMs:   time.Since(startTime).Milliseconds(),
+	}, http.StatusOK, nil
+}
+
+// hashJoinResults joins two result sets on primaryKey = secondaryKey. The secondary rows are hashed,
+// then each primary row is probed against the hash table. Secondary columns that clash with a primary
+// column are suffixed with "_secondary". For left joins, unmatched primary rows are kept with NULL
+// secondary columns. Returns the merged column list, the rows and whether the result was truncated.
+func hashJoinResults(primary, secondary []map[string]interface{}, primaryKey, secondaryKey, joinType string, limit int) ([]string, []map[string]interface{}, bool) {
+	primaryColumns := resultColumns(primary)
+	secondaryColumns := resultColumns(secondary)
+
+	primaryColumnSet := make(map[string]bool, len(primaryColumns))
+	for _, column := range primaryColumns {
+		primaryColumnSet[column] = true
+	}
+
+	// Map each secondary column to its name in the merged row
+	secondaryNames := make(map[string]string, len(secondaryColumns))
+	columns := append([]string{}, primaryColumns...)
+	for _, column := range secondaryColumns {
+		name := column
+		if primaryColumnSet[column] {
+			name = column + "_secondary"
+		}
+		secondaryNames[column] = name
+		columns = append(columns, name)
+	}
+
+	// Build phase: hash the secondary rows by join key (NULL keys never match)
+	hashTable := make(map[string][]map[string]interface{}, len(secondary))
+	for _, row := range secondary {
+		value, ok := row[secondaryKey]
+		if !ok || value == nil {
+			continue
+		}
+		key := fmt.Sprintf("%v", value)
+		hashTable[key] = append(hashTable[key], row)
+	}
+
+	// Probe phase
+	rows := make([]map[string]interface{}, 0)
+	for _, primaryRow := range primary {
+		var matches []map[string]interface{}
+		if value, ok := primaryRow[primaryKey]; ok && value != nil {
+			matches = hashTable[fmt.Sprintf("%v", value)]
+		}
+
+		if len(matches) == 0 {
+			if joinType != constants.FederatedJoinTypeLeft {
+				continue
+			}
+			merged := make(map[string]interface{}, len(columns))
+			for column, value := range primaryRow {
+				merged[column] = value
+			}
+			for _, name := range secondaryNames {
+				merged[name] = nil
+			}
+			if len(rows) >= limit {
+				return columns, rows, true
+			}
+			rows = append(rows, merged)
+			continue
+		}
+
+		for _, secondaryRow := range matches {
+			merged := make(map[string]interface{}, len(columns))
+			for column, value := range primaryRow {
+				merged[column] = value
+			}
+			for column, value := range secondaryRow {
+				merged[secondaryNames[column]] = value
+			}
+			if len(rows) >= limit {
+				return columns, rows, true
+			}
+			rows = append(rows, merged)
+		}
+	}
+
+	return columns, rows, false
+}
+
+// resultColumns returns the sorted union of column names across result rows.
+func resultColumns(rows []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		for column := range row {
+			seen[column] = true
+		}
+	}
+	columns := make([]string, 0, len(seen))
+	for column := range seen {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SubmitMessageFeedback stores the user's rating of an AI response and updates the feedback stats of the message.
+// Responses averaging FeedbackLowRatingThreshold stars or less are flagged as low rated for prompt fine-tuning analysis.
+func (s *chatService) SubmitMessageFeedback(ctx context.Context, userID, chatID, messageID string, req *dtos.MessageFeedbackRequest) (*dtos.MessageFeedbackResponse, uint32, error) {
+	log.Printf("ChatService -> SubmitMessageFeedback -> userID: %s, chatID: %s, messageID: %s", userID, chatID, messageID)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	messageObjID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid message ID format")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch chat: %v", err)
+	}
+	if chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+	if chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
+	}
+
+	message, err := s.chatRepo.FindMessageByID(messageObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch message: %v", err)
+	}
+	if message == nil || message.ChatID != chatObjID {
+		return nil, http.StatusNotFound, fmt.Errorf("message not found")
+	}
+	if message.Type != string(constants.MessageTypeAssistant) {
+		return nil, http.StatusBadRequest, fmt.Errorf("feedback can only be given on AI responses")
+	}
+
+	if req.Rating < constants.FeedbackMinRating || req.Rating > constants.FeedbackMaxRating {
+		return nil, http.StatusBadRequest, fmt.Errorf("rating must be between %d and %d", constants.FeedbackMinRating, constants.FeedbackMaxRating)
+	}
+	var freeText *string
+	if req.FreeText != nil {
+		if trimmed := strings.TrimSpace(*req.FreeText); trimmed != "" {
+			if len(trimmed) > constants.FeedbackMaxFreeTextLength {
+				return nil, http.StatusBadRequest, fmt.Errorf("freeText must be at most %d characters", constants.FeedbackMaxFreeTextLength)
+			}
+			freeText = &trimmed
+		}
+	}
+
+	llmModel := ""
+	if message.LLMModel != nil {
+		llmModel = *message.LLMModel
+	}
+	feedback := &models.MessageFeedback{
+		UserID:    userObjID,
+		ChatID:    chatObjID,
+		MessageID: messageObjID,
+		Rating:    req.Rating,
+		Issue:     req.Issue,
+		FreeText:  freeText,
+		DBType:    chat.Connection.Type,
+		LLMModel:  llmModel,
+		Base:      models.NewBase(),
+	}
+	if err := s.feedbackRepo.Upsert(ctx, feedback); err != nil {
+		log.Printf("ChatService -> SubmitMessageFeedback -> Error saving feedback: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save feedback: %v", err)
+	}
+
+	count, avgRating, err := s.feedbackRepo.GetMessageStats(ctx, messageObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update feedback stats: %v", err)
+	}
+	message.FeedbackCount = int(count)
+	message.AvgRating = avgRating
+	message.IsLowRated = count > 0 && avgRating <= constants.FeedbackLowRatingThreshold
+	if err := s.chatRepo.UpdateMessageFeedbackStats(message); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update feedback stats: %v", err)
+	}
+
+	if message.IsLowRated {
+		log.Printf("ChatService -> SubmitMessageFeedback -> Message %s flagged as low rated (%.1f stars, issue: %s, model: %s, db: %s)",
+			messageID, avgRating, req.Issue, llmModel, chat.Connection.Type)
+	}
+
+	return &dtos.MessageFeedbackResponse{
+		MessageID:     messageID,
+		Rating:        feedback.Rating,
+		Issue:         feedback.Issue,
+		FreeText:      feedback.FreeText,
+		FeedbackCount: message.FeedbackCount,
+		AvgRating:     message.AvgRating,
+		IsLowRated:    message.IsLowRated,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SyncGoogleSheet pulls the current rows of a Google Sheets connection and merges them into the stored tables.
+// Unchanged rows are kept, changed rows are updated and rows removed from the sheet are deleted.
+// Progress is streamed as google_sheets_sync_progress events when streamID is set.
+func (s *chatService) SyncGoogleSheet(ctx context.Context, userID, chatID, streamID string) (*dtos.GoogleSheetsSyncResponse, uint32, error) {
+	log.Printf("ChatService -> SyncGoogleSheet -> chatID: %s", chatID)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	if chat.Connection.Type != constants.DatabaseTypeGoogleSheets {
+		return nil, http.StatusBadRequest, fmt.Errorf("connection is not a Google Sheets type")
+	}
+
+	if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+		return nil, http.StatusBadRequest, fmt.Errorf("connection is not active, please connect before syncing")
+	}
+
+	// Decrypt a copy so the stored connection stays encrypted
+	connection := chat.Connection
+	utils.DecryptConnection(&connection)
+
+	var sheetID, refreshToken string
+	if connection.GoogleSheetID != nil {
+		sheetID = *connection.GoogleSheetID
+	}
+	if connection.GoogleRefreshToken != nil {
+		refreshToken = *connection.GoogleRefreshToken
+	}
+
+	tables, err := dbmanager.FetchGoogleSheetTables(ctx, sheetID, refreshToken)
+	if err != nil {
+		log.Printf("ChatService -> SyncGoogleSheet -> Failed to fetch sheet data: %v", err)
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to fetch Google Sheet data: %v", err)
+	}
+
+	// Rows are matched on their values, every row missing from the sheet is deleted
+	mergeOptions := MergeOptions{
+		Strategy:       "merge",
+		TrimWhitespace: true,
+		HandleNulls:    "empty",
+		AddNewCols:     true,
+		UpdateExisting: true,
+		InsertNew:      true,
+		DeleteMissing:  true,
+	}
+
+	response := &dtos.GoogleSheetsSyncResponse{
+		Tables: make([]dtos.GoogleSheetsSyncTableResult, 0, len(tables)),
+	}
+	for i, table := range tables {
+		result := dtos.GoogleSheetsSyncTableResult{
+			TableName:     table.TableName,
+			PreviousRows:  s.countSpreadsheetTableRows(chatID, table.TableName),
+			SheetRowCount: len(table.Rows),
+		}
+
+		if len(table.Rows) == 0 {
+			result.RowCount = result.PreviousRows
+		} else {
+			stored, _, err := s.StoreSpreadsheetData(userID, chatID, streamID, table.TableName, table.Headers, table.Rows, "merge", mergeOptions, nil)
+			if err != nil {
+				log.Printf("ChatService -> SyncGoogleSheet -> Failed to sync table %s: %v", table.TableName, err)
+				result.Error = err.Error()
+			} else {
+				result.RowCount = stored.RowCount
+			}
+		}
+		response.Tables = append(response.Tables, result)
+
+		if streamID != "" {
+			s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
+				Event: constants.GoogleSheetsSyncProgressEvent,
+				Data: dtos.GoogleSheetsSyncProgress{
+					TableName:    table.TableName,
+					TablesSynced: i + 1,
+					TablesTotal:  len(tables),
+					Error:        result.Error,
+				},
+			})
+		}
+	}
+
+	response.LastSyncedAt = time.Now()
+	if err := s.chatRepo.UpdateConnectionLastSyncedAt(ctx, chatObjID, response.LastSyncedAt); err != nil {
+		log.Printf("ChatService -> SyncGoogleSheet -> Failed to update last synced at: %v", err)
+	}
+
+	return response, http.StatusOK, nil
+}
+
+// countSpreadsheetTableRows returns the number of rows stored in a spreadsheet table, 0 if it does not exist yet
+func (s *chatService) countSpreadsheetTableRows(chatID, tableName string) int {
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		return 0
+	}
+	conn, err := s.dbManager.GetConnection(chatID)
+	if err != nil {
+		return 0
+	}
+
+	schemaName := connInfo.Config.SchemaName
+	if schemaName == "" {
+		schemaName = fmt.Sprintf("conn_%s", chatID)
+	}
+
+	var rows []map[string]interface{}
+	if err := conn.QueryRows(fmt.Sprintf("SELECT COUNT(*) as count FROM %s.%s", schemaName, tableName), &rows); err != nil || len(rows) == 0 {
+		return 0
+	}
+	if count, ok := rows[0]["count"].(int64); ok {
+		return int(count)
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// UpdateHiddenTables replaces the tables of a chat that are kept out of the LLM context. The stored schema is
+// formatted again without them and re-vectorized in the background. Hidden tables can still be queried directly.
+func (s *chatService) UpdateHiddenTables(ctx context.Context, userID, chatID string, req *dtos.UpdateHiddenTablesRequest) (*dtos.ChatResponse, uint32, error) {
+	log.Printf("ChatService -> UpdateHiddenTables -> userID: %s, chatID: %s, tables: %v", userID, chatID, req.HiddenTables)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	chat.Settings.HiddenTables = utils.NormalizeColumnNames(req.HiddenTables)
+	chat.UpdatedAt = time.Now()
+	if err := s.chatRepo.Update(chat.ID, chat); err != nil {
+		log.Printf("ChatService -> UpdateHiddenTables -> Error updating chat: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update hidden tables: %v", err)
+	}
+
+	s.dbManager.SetHiddenTables(chatID, chat.Settings.HiddenTables)
+	if _, connected := s.dbManager.GetConnectionInfo(chatID); connected {
+		// The cached schema and the schema vectors still describe the hidden tables
+		go func() {
+			s.refreshFormattedSchema(chat)
+			if s.vectorizationSvc != nil {
+				vecCtx, vecCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				defer vecCancel()
+				s.vectorizeSchemaForChat(vecCtx, chatID)
+			}
+		}()
+	}
+
+	return s.buildChatResponse(chat), http.StatusOK, nil
+}
+
+// buildHiddenTablesContext tells the LLM to decline when the latest user message names one of the chat's
+// hidden tables, or returns "" when it names none
+func buildHiddenTablesContext(chat *models.Chat, messages []*models.Message) string {
+	if len(chat.Settings.HiddenTables) == 0 {
+		return ""
+	}
+
+	for i := len(messages) - 1; i >= 0; i-- {
+		if string(messages[i].Type) != string(constants.MessageTypeUser) {
+			continue
+		}
+		for _, table := range chat.Settings.HiddenTables {
+			pattern := regexp.MustCompile(`(?i)(^|[^A-Za-z0-9_])` + regexp.QuoteMeta(table) + `($|[^A-Za-z0-9_])`)
+			if pattern.MatchString(messages[i].Content) {
+				return constants.HiddenTableContext
+			}
+		}
+		return ""
+	}
+	return ""
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/dbmanager"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// spreadsheetImportTracker streams spreadsheet import progress over the chat's SSE stream
+// and persists it to Redis so it can be polled. A nil tracker records nothing.
+type spreadsheetImportTracker struct {
+	s        *chatService
+	userID   string
+	chatID   string
+	streamID string
+	store    *dbmanager.ImportMetadataStore
+
+	mu     sync.Mutex
+	status dtos.ImportStatus
+}
+
+func (s *chatService) newSpreadsheetImportTracker(userID, chatID, streamID, jobID, tableName string) *spreadsheetImportTracker {
+	var store *dbmanager.ImportMetadataStore
+	if redisRepo := s.dbManager.GetRedisRepo(); redisRepo != nil {
+		store = dbmanager.NewImportMetadataStore(redisRepo)
+	}
+
+	now := time.Now()
+	t := &spreadsheetImportTracker{
+		s:        s,
+		userID:   userID,
+		chatID:   chatID,
+		streamID: streamID,
+		store:    store,
+		status: dtos.ImportStatus{
+			ImportProgress: dtos.ImportProgress{
+				JobID:     jobID,
+				TableName: tableName,
+				Errors:    []string{},
+			},
+			Status:    constants.ImportStatusRunning,
+			StartedAt: now,
+			UpdatedAt: now,
+		},
+	}
+	t.persist(t.status)
+	return t
+}
+
+// setTotal records the number of rows that will be imported
+func (t *spreadsheetImportTracker) setTotal(total int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.status.Total = total
+	t.status.UpdatedAt = time.Now()
+	snapshot := t.snapshot()
+	t.mu.Unlock()
+
+	t.persist(snapshot)
+}
+
+// addBatch records a committed (or failed) batch and notifies the client
+func (t *spreadsheetImportTracker) addBatch(processed int, errs ...string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.status.Processed += processed
+	for _, errMsg := range errs {
+		if len(t.status.Errors) >= constants.SpreadsheetImportMaxErrors {
+			break
+		}
+		t.status.Errors = append(t.status.Errors, errMsg)
+	}
+	t.status.UpdatedAt = time.Now()
+	snapshot := t.snapshot()
+	t.mu.Unlock()
+
+	t.send(snapshot.ImportProgress)
+	t.persist(snapshot)
+}
+
+// complete marks the import as finished with the given result
+func (t *spreadsheetImportTracker) complete(result *dtos.SpreadsheetUploadResponse) {
+	if t == nil {
+		return
+	}
+	t.finish(constants.ImportStatusCompleted, "", result)
+}
+
+// fail marks the import as failed
+func (t *spreadsheetImportTracker) fail(err error) {
+	if t == nil {
+		return
+	}
+	t.finish(constants.ImportStatusFailed, err.Error(), nil)
+}
+
+func (t *spreadsheetImportTracker) finish(status, errMsg string, result *dtos.SpreadsheetUploadResponse) {
+	t.mu.Lock()
+	now := time.Now()
+	t.status.Status = status
+	t.status.Error = errMsg
+	t.status.Result = result
+	t.status.UpdatedAt = now
+	t.status.CompletedAt = &now
+	snapshot := t.snapshot()
+	t.mu.Unlock()
+
+	t.send(snapshot.ImportProgress)
+	t.persist(snapshot)
+}
+
+// snapshot copies the status so it can be sent without holding the lock. Callers must hold t.mu.
+func (t *spreadsheetImportTracker) snapshot() dtos.ImportStatus {
+	snapshot := t.status
+	snapshot.Errors = append([]string{}, t.status.Errors...)
+	return snapshot
+}
+
+func (t *spreadsheetImportTracker) send(progress dtos.ImportProgress) {
+	if t.streamID == "" {
+		return
+	}
+	t.s.sendStreamEvent(t.userID, t.chatID, t.streamID, dtos.StreamResponse{
+		Event: constants.ImportProgressEvent,
+		Data:  progress,
+	})
+}
+
+func (t *spreadsheetImportTracker) persist(status dtos.ImportStatus) {
+	if t.store == nil {
+		return
+	}
+	if err := t.store.StoreImportStatus(t.chatID, &status); err != nil {
+		log.Printf("ChatService -> spreadsheetImportTracker -> Failed to persist import status for chat %s: %v", t.chatID, err)
+	}
+}
+
+// deleteImportMetadata removes the import metadata of a chat once its import has completed
+func (s *chatService) deleteImportMetadata(chatID string) {
+	redisRepo := s.dbManager.GetRedisRepo()
+	if redisRepo == nil {
+		return
+	}
+	if err := dbmanager.NewImportMetadataStore(redisRepo).DeleteMetadata(chatID); err != nil {
+		log.Printf("ChatService -> deleteImportMetadata -> Failed to delete import metadata for chat %s: %v", chatID, err)
+	}
+}
+
+// runSpreadsheetImport runs the unified spreadsheet import and records its outcome on the tracker
+func (s *chatService) runSpreadsheetImport(tracker *spreadsheetImportTracker, userID, chatID, baseTableName string, data [][]interface{}, mergeStrategy string, mergeOptions MergeOptions, columnTypes map[string]string) (*dtos.SpreadsheetUploadResponse, uint32, error) {
+	result, statusCode, err := s.processAndStoreSpreadsheetUnified(userID, chatID, baseTableName, data, mergeStrategy, mergeOptions, columnTypes, tracker)
+	if err != nil {
+		tracker.fail(err)
+		return nil, statusCode, err
+	}
+	tracker.complete(result)
+	return result, statusCode, nil
+}
+
+// StartSpreadsheetImportJob imports spreadsheet data in a background goroutine and returns a job ID immediately.
+// Progress is streamed on streamID and can be polled with GetImportStatus.
+func (s *chatService) StartSpreadsheetImportJob(userID, chatID, streamID, baseTableName string, data [][]interface{}, mergeStrategy string, mergeOptions MergeOptions, columnTypes map[string]string) (*dtos.ImportJobResponse, uint32, error) {
+	log.Printf("ChatService -> StartSpreadsheetImportJob -> chatID: %s, table: %s, rows: %d", chatID, baseTableName, len(data))
+
+	resolvedTypes, err := resolveSpreadsheetColumnTypes(columnTypes)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		return nil, http.StatusNotFound, fmt.Errorf("no connection found for chat: %s", chatID)
+	}
+	if connInfo.Config.Type != constants.DatabaseTypeSpreadsheet {
+		return nil, http.StatusBadRequest, fmt.Errorf("connection is not a spreadsheet type")
+	}
+
+	redisRepo := s.dbManager.GetRedisRepo()
+	if redisRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("background imports are not available")
+	}
+
+	// Only one import per chat at a time; imports that stopped reporting progress are considered abandoned
+	existing, err := dbmanager.NewImportMetadataStore(redisRepo).GetImportStatus(chatID)
+	if err != nil {
+		log.Printf("ChatService -> StartSpreadsheetImportJob -> Failed to read import status: %v", err)
+	} else if existing != nil && existing.Status == constants.ImportStatusRunning &&
+		time.Since(existing.UpdatedAt) < constants.SpreadsheetImportStaleAfter {
+		return nil, http.StatusConflict, fmt.Errorf("an import is already running for this chat")
+	}
+
+	jobID := primitive.NewObjectID().Hex()
+	tracker := s.newSpreadsheetImportTracker(userID, chatID, streamID, jobID, baseTableName)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("ChatService -> StartSpreadsheetImportJob -> Import job %s panicked: %v", jobID, r)
+				tracker.fail(fmt.Errorf("import failed unexpectedly"))
+			}
+		}()
+
+		if _, _, err := s.runSpreadsheetImport(tracker, userID, chatID, baseTableName, data, mergeStrategy, mergeOptions, resolvedTypes); err != nil {
+			log.Printf("ChatService -> StartSpreadsheetImportJob -> Import job %s failed: %v", jobID, err)
+			return
+		}
+		log.Printf("ChatService -> StartSpreadsheetImportJob -> Import job %s completed", jobID)
+	}()
+
+	return &dtos.ImportJobResponse{
+		JobID:     jobID,
+		Status:    constants.ImportStatusRunning,
+		StatusURL: fmt.Sprintf("/api/chats/%s/import/status", chatID),
+	}, http.StatusAccepted, nil
+}
+
+// GetImportStatus returns the progress of the latest spreadsheet import for a chat
+func (s *chatService) GetImportStatus(ctx context.Context, userID, chatID string) (*dtos.ImportStatus, uint32, error) {
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+	if chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
+	}
+
+	redisRepo := s.dbManager.GetRedisRepo()
+	if redisRepo == nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("redis not available")
+	}
+
+	status, err := dbmanager.NewImportMetadataStore(redisRepo).GetImportStatus(chatID)
+	if err != nil {
+		log.Printf("ChatService -> GetImportStatus -> Error retrieving import status: %v", err)
+		return nil, http.StatusInternalServerError, err
+	}
+	if status == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("no import found for this chat")
+	}
+
+	return status, http.StatusOK, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LiveCount counts the rows of a table matching a WHERE clause, for live feedback while the user types it.
+// Only the clause comes from the user, checked against a whitelist of comparisons and the table's columns,
+// and it is always run inside SELECT COUNT(*) with a short timeout.
+func (s *chatService) LiveCount(ctx context.Context, userID, chatID string, req *dtos.LiveCountRequest) (*dtos.LiveCountResponse, uint32, error) {
+	log.Printf("ChatService -> LiveCount -> userID: %s, chatID: %s, table: %s", userID, chatID, req.Table)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	tableName := strings.TrimSpace(req.Table)
+	if tableName == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("table name is required")
+	}
+	if dbmanager.IsHiddenTable(chat.Settings.HiddenTables, tableName) {
+		return nil, http.StatusBadRequest, fmt.Errorf("table %s is hidden in this chat, show it again to count its rows", tableName)
+	}
+
+	// Make sure we have a live connection
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		log.Printf("ChatService -> LiveCount -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("live-count-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+		connInfo, exists = s.dbManager.GetConnectionInfo(chatID)
+		if !exists {
+			return nil, http.StatusInternalServerError, fmt.Errorf("connection created but not found in manager")
+		}
+	}
+	dbType := connInfo.Config.Type
+
+	dbConn, err := s.dbManager.GetConnection(chatID)
+	if err != nil {
+		log.Printf("ChatService -> LiveCount -> Error getting connection: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get database connection: %v", err)
+	}
+
+	schema, err := s.dbManager.GetSchemaManager().GetSchema(ctx, chatID, dbConn, dbType, []string{})
+	if err != nil {
+		log.Printf("ChatService -> LiveCount -> Error getting schema: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get schema: %v", err)
+	}
+
+	table, ok := schema.Tables[tableName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("table %s not found in the database schema", tableName)
+	}
+	columns := make([]string, 0, len(table.Columns))
+	for name := range table.Columns {
+		columns = append(columns, name)
+	}
+
+	query, err := utils.BuildLiveCountQuery(dbType, tableName, columns, req.WhereClause)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, constants.LiveCountQueryTimeoutSeconds*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	streamID := fmt.Sprintf("live-count-%s-%d", chatID, startTime.UnixNano())
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, chatID, "", "", streamID, query, "SELECT", false, false)
+	if queryErr != nil {
+		if queryCtx.Err() == context.DeadlineExceeded {
+			return nil, http.StatusRequestTimeout, fmt.Errorf("counting took longer than %d seconds, narrow the WHERE clause", constants.LiveCountQueryTimeoutSeconds)
+		}
+		log.Printf("ChatService -> LiveCount -> Error executing count query: %+v", queryErr)
+		// The clause is usually half typed, so a failing count is the user's to fix
+		if queryErr.Details != "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return nil, http.StatusBadRequest, fmt.Errorf("%s", queryErr.Message)
+	}
+
+	var count int64
+	if result != nil {
+		if rows := extractResultRows(result.Result); len(rows) > 0 {
+			count = columnStatsInt(columnStatsValue(rows[0], "match_count"))
+		}
+	}
+
+	return &dtos.LiveCountResponse{
+		Count:       count,
+		EstimatedMs: time.Since(startTime).Milliseconds(),
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/llm"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// benchmarkModel is a model under benchmark with the client of its provider
+type benchmarkModel struct {
+	id       string
+	provider string
+	client   llm.Client
+}
+
+// benchmarkTestCase is a test case with its compiled pattern
+type benchmarkTestCase struct {
+	question string
+	pattern  *regexp.Regexp
+}
+
+// RunLLMBenchmark asks every model every test case question with the schema of the chat's connection, and checks
+// whether one of the generated queries matches the test case's pattern. The queries are never executed.
+// Models run in parallel, the test cases of a model run one after another. Only the admin user may run a benchmark.
+func (s *chatService) RunLLMBenchmark(ctx context.Context, userID string, req *dtos.BenchmarkRequest) (*dtos.BenchmarkResult, uint32, error) {
+	log.Printf("ChatService -> RunLLMBenchmark -> userID: %s, chatID: %s, models: %v, testCases: %d", userID, req.ChatID, req.Models, len(req.TestCases))
+
+	if status, err := requireAdminUser(s.userRepo, userID); err != nil {
+		return nil, status, err
+	}
+
+	chat, status, err := s.findOwnedChat(userID, req.ChatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if chat.Connection.CurrentSchema == nil || *chat.Connection.CurrentSchema == "" {
+		return nil, http.StatusConflict, fmt.Errorf("schema is not ready yet, please refresh the schema and try again")
+	}
+
+	if len(req.Models) > constants.LLMBenchmarkMaxModels {
+		return nil, http.StatusBadRequest, fmt.Errorf("a benchmark supports at most %d models", constants.LLMBenchmarkMaxModels)
+	}
+	if len(req.TestCases) > constants.LLMBenchmarkMaxTestCases {
+		return nil, http.StatusBadRequest, fmt.Errorf("a benchmark supports at most %d test cases", constants.LLMBenchmarkMaxTestCases)
+	}
+
+	models := make([]benchmarkModel, 0, len(req.Models))
+	seen := make(map[string]bool, len(req.Models))
+	for _, modelID := range req.Models {
+		modelID = strings.TrimSpace(modelID)
+		if modelID == "" || seen[modelID] {
+			continue
+		}
+		seen[modelID] = true
+		if !constants.IsValidModel(modelID) {
+			return nil, http.StatusBadRequest, fmt.Errorf("unsupported model: %s", modelID)
+		}
+		provider := constants.GetLLMModel(modelID).Provider
+		if s.llmManager == nil {
+			return nil, http.StatusServiceUnavailable, fmt.Errorf("no LLM client available")
+		}
+		client, err := s.llmManager.GetClient(provider)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("model %s is not available, its provider %s is not configured", modelID, provider)
+		}
+		models = append(models, benchmarkModel{id: modelID, provider: provider, client: client})
+	}
+	if len(models) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("at least one model is required")
+	}
+
+	testCases := make([]benchmarkTestCase, 0, len(req.TestCases))
+	for i, testCase := range req.TestCases {
+		question := strings.TrimSpace(testCase.Question)
+		if question == "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("test case %d needs a question", i+1)
+		}
+		if len(question) > constants.LLMBenchmarkMaxQuestionLength {
+			return nil, http.StatusBadRequest, fmt.Errorf("the question of test case %d must be at most %d characters", i+1, constants.LLMBenchmarkMaxQuestionLength)
+		}
+		pattern, err := regexp.Compile(testCase.ExpectedQueryPattern)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid expectedQueryPattern of test case %d: %v", i+1, err)
+		}
+		testCases = append(testCases, benchmarkTestCase{question: question, pattern: pattern})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.LLMBenchmarkTimeoutMinutes*time.Minute)
+	defer cancel()
+
+	dbType := chat.Connection.Type
+	schema := *chat.Connection.CurrentSchema
+
+	// One goroutine per model, each filling its own slot so the results keep the request order
+	resultsByModel := make([][]dtos.BenchmarkCaseResult, len(models))
+	var wg sync.WaitGroup
+	for i, model := range models {
+		wg.Add(1)
+		go func(i int, model benchmarkModel) {
+			defer wg.Done()
+			results := make([]dtos.BenchmarkCaseResult, 0, len(testCases))
+			for _, testCase := range testCases {
+				results = append(results, s.runBenchmarkCase(ctx, model, dbType, schema, testCase))
+			}
+			resultsByModel[i] = results
+		}(i, model)
+	}
+	wg.Wait()
+
+	response := &dtos.BenchmarkResult{
+		Results: make([]dtos.BenchmarkCaseResult, 0, len(models)*len(testCases)),
+		Summary: make([]dtos.BenchmarkModelSummary, 0, len(models)),
+	}
+	for i, model := range models {
+		passed := 0
+		var totalLatency int64
+		for _, result := range resultsByModel[i] {
+			if result.Passed {
+				passed++
+			}
+			totalLatency += result.LatencyMs
+		}
+		response.Results = append(response.Results, resultsByModel[i]...)
+		response.Summary = append(response.Summary, dtos.BenchmarkModelSummary{
+			Model:        model.id,
+			PassRate:     float64(passed) / float64(len(testCases)),
+			AvgLatencyMs: totalLatency / int64(len(testCases)),
+		})
+	}
+
+	log.Printf("ChatService -> RunLLMBenchmark -> Ran %d test cases on %d models for chat %s", len(testCases), len(models), req.ChatID)
+	return response, http.StatusOK, nil
+}
+
+// runBenchmarkCase asks the model a test case question and checks the generated queries against its pattern.
+// A failed LLM call or an unparsable response fails the test case.
+func (s *chatService) runBenchmarkCase(ctx context.Context, model benchmarkModel, dbType, schema string, testCase benchmarkTestCase) dtos.BenchmarkCaseResult {
+	result := dtos.BenchmarkCaseResult{
+		Model:    model.id,
+		TestCase: testCase.question,
+	}
+
+	messages := magicQueryMessages(schema, testCase.question)
+	result.InputTokens = llm.EstimateTokens(messages) + llm.EstimateTextTokens(constants.GetSystemPrompt(model.provider, dbType, false))
+
+	callCtx, cancel := context.WithTimeout(ctx, constants.LLMBenchmarkCallTimeoutSeconds*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	response, err := model.client.GenerateResponse(callCtx, messages, dbType, false, model.id)
+	result.LatencyMs = time.Since(startTime).Milliseconds()
+	if err != nil {
+		log.Printf("ChatService -> RunLLMBenchmark -> %s failed on %q: %v", model.id, testCase.question, err)
+		errorMsg := fmt.Sprintf("LLM call failed: %v", err)
+		result.Error = &errorMsg
+		return result
+	}
+	result.OutputTokens = llm.EstimateTextTokens(response)
+
+	var llmResponse constants.LLMResponse
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &llmResponse); err != nil {
+		errorMsg := fmt.Sprintf("failed to parse LLM response: %v", err)
+		result.Error = &errorMsg
+		return result
+	}
+
+	for _, query := range llmResponse.Queries {
+		queryText := strings.TrimSpace(query.Query)
+		if queryText == "" {
+			continue
+		}
+		if result.GeneratedQuery == "" {
+			result.GeneratedQuery = queryText
+		}
+		if testCase.pattern.MatchString(queryText) {
+			result.Passed = true
+			result.GeneratedQuery = queryText
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/llm"
+)
+
+// llmFallbackChain returns the models to retry with when the selected model fails: the chat's fallback chain,
+// or LLM_DEFAULT_FALLBACK_CHAIN when the chat has none, without the selected model itself
+func llmFallbackChain(selectedLLMModel string, settings models.ChatSettings) []string {
+	chain := settings.FallbackChain
+	if len(chain) == 0 {
+		chain = constants.GetDefaultLLMFallbackChain()
+	}
+
+	fallbacks := make([]string, 0, len(chain))
+	for _, modelID := range chain {
+		if modelID != selectedLLMModel {
+			fallbacks = append(fallbacks, modelID)
+		}
+	}
+	return fallbacks
+}
+
+// generateWithFallback runs the tool-calling LLM session with the selected model. If it is rate limited,
+// unavailable or unreachable, the session is retried with each model of the fallback chain in turn.
+// It returns the ID of the model that answered.
+func (s *chatService) generateWithFallback(
+	ctx context.Context,
+	llmClient llm.Client,
+	selectedLLMModel string,
+	settings models.ChatSettings,
+	messages []*models.LLMMessage,
+	tools []llm.ToolDefinition,
+	executor llm.ToolExecutorFunc,
+	config llm.ToolCallConfig,
+	onFallback func(modelID string),
+) (*llm.ToolCallResult, string, error) {
+	modelUsed := selectedLLMModel
+	if modelUsed == "" {
+		modelUsed = llmClient.GetModelInfo().Name
+	}
+
+	result, err := llmClient.GenerateWithTools(ctx, messages, tools, executor, config)
+	if err == nil || !llm.IsFallbackError(err) || s.llmManager == nil {
+		return result, modelUsed, err
+	}
+
+	for _, modelID := range llmFallbackChain(modelUsed, settings) {
+		if ctx.Err() != nil {
+			break
+		}
+
+		model := constants.GetLLMModel(modelID)
+		if model == nil || !model.IsEnabled {
+			continue
+		}
+		fallbackClient, clientErr := s.llmManager.GetClient(model.Provider)
+		if clientErr != nil {
+			log.Printf("ChatService -> generateWithFallback -> Skipping fallback model %s: %v", modelID, clientErr)
+			continue
+		}
+
+		log.Printf("ChatService -> generateWithFallback -> Model %s failed (%v), falling back to %s", modelUsed, err, modelID)
+		if onFallback != nil {
+			onFallback(modelID)
+		}
+
+		fallbackMessages := messages
+		if pruner := llm.NewContextPruner(modelID); pruner != nil {
+			fallbackMessages = pruner.Prune(messages)
+		}
+		fallbackConfig := config
+		fallbackConfig.ModelID = modelID
+
+		result, err = fallbackClient.GenerateWithTools(ctx, fallbackMessages, tools, executor, fallbackConfig)
+		modelUsed = modelID
+		if err == nil {
+			log.Printf("ChatService -> generateWithFallback -> Fallback model %s answered", modelID)
+			return result, modelUsed, nil
+		}
+		if !llm.IsFallbackError(err) {
+			break
+		}
+	}
+
+	return result, modelUsed, err
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MagicQuery answers a one-off question about a database given by its connection URI, without a chat.
+// It connects, fetches the schema, asks the LLM for queries and, when req.Execute is set, runs the read-only ones
+// with results capped at the user's row cap.
+// The connection is closed before returning, and no chat, message, schema or credential is stored.
+func (s *chatService) MagicQuery(ctx context.Context, userID string, req *dtos.MagicQueryRequest) (*dtos.MagicQueryResponse, uint32, error) {
+	log.Printf("ChatService -> MagicQuery -> userID: %s, dbType: %s, execute: %t", userID, req.DBType, req.Execute)
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("a question is required")
+	}
+	if len(question) > constants.MagicQueryMaxQuestionLength {
+		return nil, http.StatusBadRequest, fmt.Errorf("the question must be at most %d characters", constants.MagicQueryMaxQuestionLength)
+	}
+	if err := checkPromptInjection(userID, question); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if !isValidDBType(req.DBType) {
+		return nil, http.StatusBadRequest, fmt.Errorf("Unsupported data source type: %s", req.DBType)
+	}
+
+	modelID := strings.TrimSpace(req.Model)
+	if modelID != "" && !constants.IsValidModel(modelID) {
+		return nil, http.StatusBadRequest, fmt.Errorf("unsupported model: %s", modelID)
+	}
+
+	connectionURI := req.ConnectionURI
+	connection := dtos.CreateConnectionRequest{
+		Type:          req.DBType,
+		ConnectionURI: &connectionURI,
+	}
+	if err := applyConnectionURI(&connection); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	applyNeonDetection(&connection)
+
+	ctx, cancel := context.WithTimeout(ctx, constants.MagicQueryTimeoutSeconds*time.Second)
+	defer cancel()
+
+	transient, err := s.dbManager.OpenTransientConnection(dbmanager.ConnectionConfig{
+		Type:           connection.Type,
+		Host:           connection.Host,
+		Port:           connection.Port,
+		Username:       &connection.Username,
+		Password:       connection.Password,
+		Database:       connection.Database,
+		AuthDatabase:   connection.AuthDatabase,
+		SSLMode:        connection.SSLMode,
+		UseSSL:         connection.UseSSL,
+		Catalog:        connection.Catalog,
+		Schema:         connection.Schema,
+		ServiceName:    connection.ServiceName,
+		ReadPreference: connection.ReadPreference,
+		MaxResultRows:  s.getMaxQueryResultRows(userID),
+	})
+	if err != nil {
+		log.Printf("ChatService -> MagicQuery -> Connection failed: %v", err)
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+	}
+	defer transient.Close()
+
+	schema, err := transient.FormatSchema(ctx)
+	if err != nil {
+		log.Printf("ChatService -> MagicQuery -> Error fetching schema: %v", err)
+		return nil, http.StatusBadRequest, err
+	}
+
+	llmResponse, err := s.generateMagicQuery(ctx, connection.Type, schema, question, modelID)
+	if err != nil {
+		log.Printf("ChatService -> MagicQuery -> Error generating queries: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate queries: %v", err)
+	}
+
+	response := &dtos.MagicQueryResponse{
+		AssistantMessage: llmResponse.AssistantMessage,
+		Queries:          make([]dtos.MagicQueryResult, 0, len(llmResponse.Queries)),
+		Model:            modelID,
+	}
+	for _, query := range llmResponse.Queries {
+		result := dtos.MagicQueryResult{
+			Query:       strings.TrimSpace(query.Query),
+			QueryType:   query.QueryType,
+			Explanation: query.Explanation,
+			IsCritical:  query.IsCritical,
+		}
+
+		switch {
+		case !req.Execute:
+			// The queries are only generated
+		case result.Query == "":
+			result.SkippedReason = "the query is empty"
+		case query.IsCritical || !constants.IsReadOnlyQuery(result.Query, connection.Type):
+			// There is no chat to confirm a write in, so writes are only returned for review
+			result.SkippedReason = "only read-only queries are executed, run writes from a chat where they can be confirmed and rolled back"
+		default:
+			execution := transient.ExecuteQuery(ctx, result.Query, query.QueryType)
+			result.Executed = true
+			result.ExecutionTimeMs = execution.ExecutionTime
+			if execution.Error != nil {
+				result.Error = execution.Error
+			} else {
+				result.Result = execution.Result
+				result.Truncated = execution.Truncated
+				result.TruncatedAt = execution.TruncatedAt
+			}
+		}
+		response.Queries = append(response.Queries, result)
+	}
+
+	log.Printf("ChatService -> MagicQuery -> Generated %d queries for %s", len(response.Queries), connection.Type)
+	return response, http.StatusOK, nil
+}
+
+// generateMagicQuery asks the LLM for queries answering the question, with the database's system prompt
+// and response schema like a chat message. The schema is sent as the system message.
+func (s *chatService) generateMagicQuery(ctx context.Context, dbType, schema, question, modelID string) (*constants.LLMResponse, error) {
+	llmClient := s.llmClient
+	if modelID != "" && s.llmManager != nil {
+		if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+			if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+				llmClient = providerClient
+			}
+		}
+	}
+
+	if llmClient == nil {
+		return nil, fmt.Errorf("no LLM client available")
+	}
+
+	response, err := llmClient.GenerateResponse(ctx, magicQueryMessages(schema, question), dbType, false, modelID)
+	if err != nil {
+		return nil, fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	var llmResponse constants.LLMResponse
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &llmResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM response: %v", err)
+	}
+	return &llmResponse, nil
+}
+
+// magicQueryMessages builds the LLM context of a one-off question: the schema as the system message, then the question
+func magicQueryMessages(schema, question string) []*models.LLMMessage {
+	return []*models.LLMMessage{
+		{
+			Role: string(constants.MessageTypeSystem),
+			Content: map[string]interface{}{
+				"schema_update": schema,
+			},
+		},
+		{
+			Role: string(constants.MessageTypeUser),
+			Content: map[string]interface{}{
+				"user_message": utils.SanitizeLLMMessage(question),
+			},
+		},
+	}
+}
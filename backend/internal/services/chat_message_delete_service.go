@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/repositories"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteMessagesByFilter deletes the messages of a chat that match every given filter, together with
+// their vectors so the LLM context no longer retrieves them. Deletions of more than
+// BulkMessageDeleteAsyncThreshold messages run in the background and return a job ID.
+func (s *chatService) DeleteMessagesByFilter(userID, chatID string, req *dtos.DeleteMessagesRequest) (*dtos.DeleteMessagesResponse, uint32, error) {
+	log.Printf("ChatService -> DeleteMessagesByFilter -> chatID: %s, before: %s, type: %s, hasErrors: %v", chatID, req.Before, req.Type, req.HasErrors)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	// Verify chat ownership
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch chat: %v", err)
+	}
+	if chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+	if chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
+	}
+
+	filter := repositories.MessageDeleteFilter{
+		Type:      req.Type,
+		HasErrors: req.HasErrors,
+	}
+	if req.Before != "" {
+		before, _, err := parseAnalyticsTime(req.Before)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid 'before' value: %v", err)
+		}
+		filter.Before = &before
+	}
+
+	ids, err := s.chatRepo.FindMessageIDsByFilter(chatObjID, filter)
+	if err != nil {
+		log.Printf("ChatService -> DeleteMessagesByFilter -> Error finding messages: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to find messages: %v", err)
+	}
+
+	if len(ids) > constants.BulkMessageDeleteAsyncThreshold {
+		jobID := primitive.NewObjectID().Hex()
+		go func() {
+			deleted, err := s.deleteMessagesInBatches(chatObjID, ids)
+			if err != nil {
+				log.Printf("ChatService -> DeleteMessagesByFilter -> Job %s failed after deleting %d of %d messages: %v", jobID, deleted, len(ids), err)
+				return
+			}
+			log.Printf("ChatService -> DeleteMessagesByFilter -> Job %s deleted %d messages", jobID, deleted)
+		}()
+
+		return &dtos.DeleteMessagesResponse{
+			MatchedCount: int64(len(ids)),
+			JobID:        jobID,
+			Status:       constants.BulkMessageDeleteStatusRunning,
+		}, http.StatusAccepted, nil
+	}
+
+	deleted, err := s.deleteMessagesInBatches(chatObjID, ids)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to delete messages: %v", err)
+	}
+
+	return &dtos.DeleteMessagesResponse{
+		DeletedCount: deleted,
+		MatchedCount: int64(len(ids)),
+		Status:       constants.BulkMessageDeleteStatusCompleted,
+	}, http.StatusOK, nil
+}
+
+// deleteMessagesInBatches deletes the messages and their vectors batch by batch, returning how many messages were deleted
+func (s *chatService) deleteMessagesInBatches(chatObjID primitive.ObjectID, ids []primitive.ObjectID) (int64, error) {
+	var deleted int64
+	for start := 0; start < len(ids); start += constants.BulkMessageDeleteBatchSize {
+		end := start + constants.BulkMessageDeleteBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		count, err := s.chatRepo.DeleteMessagesByIDs(chatObjID, batch)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+
+		if s.vectorizationSvc != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			for _, id := range batch {
+				if err := s.vectorizationSvc.DeleteMessageVector(ctx, chatObjID.Hex(), id.Hex()); err != nil {
+					log.Printf("ChatService -> deleteMessagesInBatches -> Failed to delete vector of message %s: %v", id.Hex(), err)
+				}
+			}
+			cancel()
+		}
+	}
+	return deleted, nil
+}
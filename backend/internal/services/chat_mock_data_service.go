@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// mockDataTable is the generated data of one table
+type mockDataTable struct {
+	Table         string `json:"table"`
+	RowCount      int    `json:"rowCount"`
+	InsertQuery   string `json:"insertQuery"`
+	RollbackQuery string `json:"rollbackQuery"`
+}
+
+// mockDataPlan is the mock data generated by the LLM, tables in insert order
+type mockDataPlan struct {
+	Summary  string          `json:"summary"`
+	Tables   []mockDataTable `json:"tables"`
+	Warnings []string        `json:"warnings"`
+}
+
+// mockDataStringLiteralPattern matches single quoted SQL string literals, including doubled quotes inside them
+var mockDataStringLiteralPattern = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// GenerateMockData asks the LLM for INSERT statements filling the requested tables with realistic fake data and
+// runs them as one script, in a single transaction on databases that support it. The inserts are stored in the chat
+// as executed critical queries, each rolling back with a DELETE of exactly its rows. As this writes to the
+// database, it only runs on example databases unless force is set.
+func (s *chatService) GenerateMockData(ctx context.Context, userID, chatID string, req *dtos.MockDataRequest, force bool) (*dtos.MockDataResponse, uint32, error) {
+	log.Printf("ChatService -> GenerateMockData -> userID: %s, chatID: %s, tables: %v, rowsPerTable: %d, force: %v", userID, chatID, req.Tables, req.RowsPerTable, force)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if !chat.Connection.IsExampleDB && !force {
+		return nil, http.StatusForbidden, fmt.Errorf("mock data can only be generated for example databases, pass force=true to insert it into this database anyway")
+	}
+
+	dbType := chat.Connection.Type
+	switch dbType {
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB, constants.DatabaseTypeRedis, constants.DatabaseTypeCassandra,
+		constants.DatabaseTypeAirtable, constants.DatabaseTypeInfluxDB, constants.DatabaseTypeTemporal, constants.DatabaseTypePocketBase,
+		constants.DatabaseTypeNATS, constants.DatabaseTypeYugabyteDBCQL, constants.DatabaseTypeFirestore, constants.DatabaseTypeSpreadsheet,
+		constants.DatabaseTypeGoogleSheets:
+		return nil, http.StatusBadRequest, fmt.Errorf("mock data generation is only supported for SQL databases")
+	}
+
+	// Deduplicate and trim the requested tables
+	tables := make([]string, 0, len(req.Tables))
+	seen := make(map[string]bool, len(req.Tables))
+	for _, table := range req.Tables {
+		table = strings.TrimSpace(table)
+		if table == "" || seen[table] {
+			continue
+		}
+		seen[table] = true
+		tables = append(tables, table)
+	}
+	if len(tables) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("at least one table is required")
+	}
+	if len(tables) > constants.MockDataMaxTables {
+		return nil, http.StatusBadRequest, fmt.Errorf("mock data can be generated for at most %d tables at once", constants.MockDataMaxTables)
+	}
+
+	rowsPerTable := req.RowsPerTable
+	if rowsPerTable == 0 {
+		rowsPerTable = constants.MockDataDefaultRowsPerTable
+	}
+	if rowsPerTable < 1 || rowsPerTable > constants.MockDataMaxRowsPerTable {
+		return nil, http.StatusBadRequest, fmt.Errorf("rowsPerTable must be between 1 and %d", constants.MockDataMaxRowsPerTable)
+	}
+
+	// Make sure we have a live connection
+	if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+		log.Printf("ChatService -> GenerateMockData -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("mock-data-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+		if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+			return nil, http.StatusInternalServerError, fmt.Errorf("connection created but not found in manager")
+		}
+	}
+
+	// Prefer the schema limited to the requested tables; fall back to the full current schema
+	schemaContext, err := s.dbManager.GetSchemaManager().FormatSchemaForTables(ctx, chatID, tables)
+	if err != nil || strings.TrimSpace(schemaContext) == "" {
+		log.Printf("ChatService -> GenerateMockData -> Filtered schema unavailable, using current schema: %v", err)
+		if chat.Connection.CurrentSchema == nil || *chat.Connection.CurrentSchema == "" {
+			return nil, http.StatusConflict, fmt.Errorf("schema is not ready yet, please refresh the schema and try again")
+		}
+		schemaContext = *chat.Connection.CurrentSchema
+	}
+
+	plan, modelID, err := s.generateMockDataPlan(ctx, chat, dbType, tables, rowsPerTable, schemaContext)
+	if err != nil {
+		log.Printf("ChatService -> GenerateMockData -> Error generating mock data: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate mock data: %v", err)
+	}
+
+	// Every statement must be an insert into a requested table with a rollback deleting its rows
+	if len(plan.Tables) == 0 {
+		return nil, http.StatusInternalServerError, fmt.Errorf("no mock data was generated")
+	}
+	generated := make(map[string]bool, len(plan.Tables))
+	for _, table := range plan.Tables {
+		if !seen[table.Table] || generated[table.Table] {
+			return nil, http.StatusInternalServerError, fmt.Errorf("the generated mock data targets an unexpected table: %s", table.Table)
+		}
+		generated[table.Table] = true
+		if !isMockDataScript(table.InsertQuery, "INSERT") {
+			return nil, http.StatusInternalServerError, fmt.Errorf("the generated mock data for %s is not made of INSERT statements only", table.Table)
+		}
+		if !isMockDataScript(table.RollbackQuery, "DELETE") {
+			return nil, http.StatusInternalServerError, fmt.Errorf("the generated rollback for %s is not made of DELETE statements with a WHERE clause", table.Table)
+		}
+	}
+
+	script := make([]string, 0, len(plan.Tables))
+	for _, table := range plan.Tables {
+		script = append(script, strings.TrimSuffix(table.InsertQuery, ";")+";")
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, constants.MockDataTimeoutSeconds*time.Second)
+	defer cancel()
+
+	streamID := fmt.Sprintf("mock-data-%s-%d", chatID, time.Now().UnixNano())
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, chatID, "", "", streamID, strings.Join(script, "\n"), "INSERT", false, false)
+	if queryErr != nil {
+		log.Printf("ChatService -> GenerateMockData -> Error inserting mock data: %+v", queryErr)
+		errorMsg := queryErr.Message
+		if queryErr.Details != "" {
+			errorMsg = fmt.Sprintf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return nil, http.StatusUnprocessableEntity, fmt.Errorf("failed to insert the mock data: %s", errorMsg)
+	}
+	executionTime := 0
+	if result != nil {
+		executionTime = result.ExecutionTime
+	}
+
+	warnings := plan.Warnings
+	switch dbType {
+	case constants.DatabaseTypeClickhouse, constants.DatabaseTypeStarRocks, constants.DatabaseTypeTrino:
+		warnings = append(warnings, fmt.Sprintf("%s does not support transactions, the inserts were not run atomically", dbType))
+	}
+
+	userMsg := models.NewMessage(chat.UserID, chat.ID, string(constants.MessageTypeUser),
+		fmt.Sprintf("Generate %d rows of mock data for %s", rowsPerTable, strings.Join(tables, ", ")), nil, nil)
+	if err := s.chatRepo.CreateMessage(userMsg); err != nil {
+		log.Printf("ChatService -> GenerateMockData -> Error saving user message: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("mock data was inserted but the request could not be saved: %v", err)
+	}
+
+	queries := buildMockDataQueries(plan, modelID, executionTime)
+	content := plan.Summary
+	if content == "" {
+		content = "The mock data was inserted, roll back a query to delete the rows it inserted."
+	}
+	assistantMsg := models.NewMessage(chat.UserID, chat.ID, string(constants.MessageTypeAssistant), content, &queries, &userMsg.ID)
+	// Keep the assistant message after the user message in sorted results
+	assistantMsg.CreatedAt = userMsg.CreatedAt.Add(time.Second)
+	assistantMsg.UpdatedAt = assistantMsg.CreatedAt
+	if modelID != "" {
+		assistantMsg.LLMModel = &modelID
+	}
+	if err := s.chatRepo.CreateMessage(assistantMsg); err != nil {
+		log.Printf("ChatService -> GenerateMockData -> Error saving mock data message: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("mock data was inserted but its rollback queries could not be saved: %v", err)
+	}
+
+	insertedRows := make(map[string]int, len(plan.Tables))
+	for _, table := range plan.Tables {
+		insertedRows[table.Table] = table.RowCount
+	}
+
+	log.Printf("ChatService -> GenerateMockData -> Inserted mock data into %d tables, saved message %s", len(plan.Tables), assistantMsg.ID.Hex())
+	return &dtos.MockDataResponse{
+		InsertedRows: insertedRows,
+		UserMessage:  s.buildMessageResponse(userMsg),
+		Message:      s.buildMessageResponse(assistantMsg),
+		Warnings:     warnings,
+	}, http.StatusOK, nil
+}
+
+// generateMockDataPlan calls the LLM with GeminiMockDataPrompt and parses the generated statements.
+// It returns the model ID used, empty when the default model was used.
+func (s *chatService) generateMockDataPlan(ctx context.Context, chat *models.Chat, dbType string, tables []string, rowsPerTable int, schemaContext string) (*mockDataPlan, string, error) {
+	llmClient := s.llmClient
+	modelID := ""
+	if chat.PreferredLLMModel != nil && *chat.PreferredLLMModel != "" {
+		modelID = *chat.PreferredLLMModel
+		if s.llmManager != nil {
+			if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+				if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+					llmClient = providerClient
+				}
+			}
+		}
+	}
+
+	if llmClient == nil {
+		return nil, "", fmt.Errorf("no LLM client available")
+	}
+
+	userMessage := constants.GetMockDataUserMessage(dbType, tables, rowsPerTable, schemaContext)
+	response, err := llmClient.GenerateRawJSON(ctx, constants.GeminiMockDataPrompt, userMessage, modelID)
+	if err != nil {
+		return nil, "", fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	var plan mockDataPlan
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &plan); err != nil {
+		return nil, "", fmt.Errorf("failed to parse mock data JSON: %v", err)
+	}
+
+	for i := range plan.Tables {
+		plan.Tables[i].Table = strings.TrimSpace(plan.Tables[i].Table)
+		plan.Tables[i].InsertQuery = strings.TrimSpace(plan.Tables[i].InsertQuery)
+		plan.Tables[i].RollbackQuery = strings.TrimSpace(plan.Tables[i].RollbackQuery)
+	}
+
+	return &plan, modelID, nil
+}
+
+// isMockDataScript reports whether every statement of a script starts with keyword, DELETE statements also needing
+// a WHERE clause. String literals are blanked first, so the fake data can't hide or fake a statement.
+func isMockDataScript(script, keyword string) bool {
+	stripped := mockDataStringLiteralPattern.ReplaceAllString(script, "''")
+	statements := 0
+	for _, statement := range strings.Split(stripped, ";") {
+		statement = strings.ToUpper(strings.TrimSpace(statement))
+		if statement == "" {
+			continue
+		}
+		if !strings.HasPrefix(statement, keyword) {
+			return false
+		}
+		if keyword == "DELETE" && !strings.Contains(statement, "WHERE") {
+			return false
+		}
+		statements++
+	}
+	return statements > 0
+}
+
+// buildMockDataQueries turns the generated data into one executed INSERT query per table, in insert order.
+// They are critical and roll back with the DELETE of their rows, as they all ran in one script they share its execution time.
+func buildMockDataQueries(plan *mockDataPlan, modelID string, executionTime int) []models.Query {
+	queryType := "INSERT"
+	actionAt := utils.StringPtr(time.Now().Format(time.RFC3339))
+
+	queries := make([]models.Query, 0, len(plan.Tables))
+	for _, table := range plan.Tables {
+		tableName := table.Table
+		rollbackQuery := table.RollbackQuery
+		queries = append(queries, models.Query{
+			ID:            primitive.NewObjectID(),
+			Query:         table.InsertQuery,
+			QueryType:     &queryType,
+			Tables:        &tableName,
+			Description:   fmt.Sprintf("Insert %d rows of mock data into %s", table.RowCount, table.Table),
+			RollbackQuery: &rollbackQuery,
+			CanRollback:   true,
+			IsCritical:    true,
+			IsExecuted:    true,
+			ExecutionTime: &executionTime,
+			ActionAt:      actionAt,
+			LLMModel:      modelID,
+		})
+	}
+	return queries
+}
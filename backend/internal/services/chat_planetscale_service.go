@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListPlanetscaleBranches lists the branches of the PlanetScale database of a chat, together with the branch it is connected to.
+// Branches are listed with the service token stored on the connection.
+func (s *chatService) ListPlanetscaleBranches(ctx context.Context, userID, chatID string) (*dtos.PlanetscaleBranchesResponse, uint32, error) {
+	log.Printf("ChatService -> ListPlanetscaleBranches -> chatID: %s", chatID)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	if chat.Connection.Type != constants.DatabaseTypePlanetscale {
+		return nil, http.StatusBadRequest, fmt.Errorf("connection is not a PlanetScale type")
+	}
+
+	// Decrypt a copy so the stored connection stays encrypted
+	connection := chat.Connection
+	utils.DecryptConnection(&connection)
+
+	var organization, serviceTokenID, serviceToken string
+	if connection.PlanetscaleOrganization != nil {
+		organization = *connection.PlanetscaleOrganization
+	}
+	if connection.PlanetscaleServiceTokenID != nil {
+		serviceTokenID = *connection.PlanetscaleServiceTokenID
+	}
+	if connection.PlanetscaleServiceToken != nil {
+		serviceToken = *connection.PlanetscaleServiceToken
+	}
+	if organization == "" || serviceTokenID == "" || serviceToken == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("add the PlanetScale organization and a service token to the connection to list branches")
+	}
+
+	branches, err := dbmanager.ListPlanetscaleBranches(ctx, organization, connection.Database, serviceTokenID, serviceToken)
+	if err != nil {
+		log.Printf("ChatService -> ListPlanetscaleBranches -> Failed to list branches: %v", err)
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to list PlanetScale branches: %v", err)
+	}
+
+	currentBranch := constants.PlanetscaleDefaultBranch
+	if connection.PlanetscaleBranch != nil && *connection.PlanetscaleBranch != "" {
+		currentBranch = *connection.PlanetscaleBranch
+	}
+
+	response := &dtos.PlanetscaleBranchesResponse{
+		CurrentBranch: currentBranch,
+		Branches:      make([]dtos.PlanetscaleBranchResponse, 0, len(branches)),
+	}
+	for _, branch := range branches {
+		response.Branches = append(response.Branches, dtos.PlanetscaleBranchResponse{
+			Name:         branch.Name,
+			ParentBranch: branch.ParentBranch,
+			Production:   branch.Production,
+			Ready:        branch.Ready,
+			CreatedAt:    branch.CreatedAt,
+			UpdatedAt:    branch.UpdatedAt,
+		})
+	}
+
+	return response, http.StatusOK, nil
+}
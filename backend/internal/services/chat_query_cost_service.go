@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/dbmanager"
+)
+
+// explainableStatementPattern matches the statements PostgreSQL can EXPLAIN, DDL and utility statements have no plan
+var explainableStatementPattern = regexp.MustCompile(`(?is)^\s*(SELECT|WITH|INSERT|UPDATE|DELETE|MERGE|VALUES|TABLE)\b`)
+
+// errQueryCostUnknown marks a query blocked because its cost could not be checked against the budget
+var errQueryCostUnknown = errors.New("query cost could not be estimated")
+
+// checkQueryCostBudget estimates a PostgreSQL query's cost with EXPLAIN when the chat has a cost budget.
+// The chat's schema aliases are resolved first, as Manager.ExecuteQuery does before running the query.
+// It returns the estimated cost, 0 when there is no budget or the statement has no plan (DDL and utility statements),
+// and an error when the cost is over the budget. A query whose cost can't be estimated, because EXPLAIN fails or it
+// holds several statements, is blocked with errQueryCostUnknown. EXPLAIN without ANALYZE only plans the query, so
+// writes are estimated without running.
+func (s *chatService) checkQueryCostBudget(ctx context.Context, chat *models.Chat, chatID, query string) (float64, error) {
+	budget := chat.Settings.MaxQueryCostUnits
+	if budget == nil || *budget <= 0 || !isPostgresPlanType(chat.Connection.Type) {
+		return 0, nil
+	}
+
+	// Explain the query that will run, with the chat's schema aliases mapped back to the real names
+	if connInfo, exists := s.dbManager.GetConnectionInfo(chatID); exists {
+		query = dbmanager.ResolveSchemaAliases(query, connInfo.Config.Type, connInfo.Config.SchemaAliases)
+	}
+
+	statement := strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if !explainableStatementPattern.MatchString(statement) {
+		log.Printf("ChatService -> checkQueryCostBudget -> Statement has no query plan, skipping the cost check")
+		return 0, nil
+	}
+	if strings.Contains(statement, ";") {
+		return 0, fmt.Errorf("%w: run the statements one at a time to check each against the cost budget", errQueryCostUnknown)
+	}
+
+	conn, err := s.dbManager.GetConnection(chatID)
+	if err != nil || conn.GetDB() == nil {
+		return 0, fmt.Errorf("failed to get database connection")
+	}
+
+	plan, err := explainQueryPlan(ctx, conn.GetDB(), chat.Connection.Type, statement)
+	if err != nil {
+		log.Printf("ChatService -> checkQueryCostBudget -> EXPLAIN failed, blocking the query: %v", err)
+		return 0, fmt.Errorf("%w: %v", errQueryCostUnknown, err)
+	}
+
+	log.Printf("ChatService -> checkQueryCostBudget -> Estimated cost: %.2f, budget: %.2f", plan.TotalCost, *budget)
+	if plan.TotalCost > *budget {
+		return plan.TotalCost, fmt.Errorf("Query cost (%s) exceeds budget (%s). Please add a more specific WHERE clause.",
+			formatQueryCost(plan.TotalCost), formatQueryCost(*budget))
+	}
+	return plan.TotalCost, nil
+}
+
+// formatQueryCost prints a cost without trailing zeros, e.g. 1250 or 35.5
+func formatQueryCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', -1, 64)
+}
+
+// validateMaxQueryCostUnits checks a query cost budget setting, 0 removes the budget
+func validateMaxQueryCostUnits(units float64) error {
+	if units < 0 {
+		return fmt.Errorf("max_query_cost_units can't be negative")
+	}
+	return nil
+}
+
+// costBudgetExceededResponse is the execution response of a query blocked by the chat's cost budget,
+// either over the budget or with a cost that could not be estimated
+func costBudgetExceededResponse(chatID string, req *dtos.ExecuteQueryRequest, query *models.Query, cost float64, err error) *dtos.QueryExecutionResponse {
+	response := &dtos.QueryExecutionResponse{
+		ChatID:             chatID,
+		MessageID:          req.MessageID,
+		QueryID:            req.QueryID,
+		IsExecuted:         query.IsExecuted,
+		IsRolledBack:       query.IsRolledBack,
+		EstimatedCost:      cost,
+		CostBudgetExceeded: true,
+		Error: &dtos.QueryError{
+			Code:    constants.QueryCostBudgetExceededCode,
+			Message: err.Error(),
+			Details: fmt.Sprintf("Estimated cost from EXPLAIN: %s", formatQueryCost(cost)),
+		},
+	}
+	if errors.Is(err, errQueryCostUnknown) {
+		response.CostBudgetExceeded = false
+		response.Error.Code = constants.QueryCostUnknownCode
+		response.Error.Details = "The chat has a cost budget, so queries are only run once EXPLAIN can estimate their cost"
+	}
+	return response
+}
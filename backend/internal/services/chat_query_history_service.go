@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/repositories"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultQueryHistoryPageSize = 50
+	maxQueryHistoryPageSize     = 200
+)
+
+// GetQueryHistory returns a compact, paginated list of the queries generated in a chat, for auditing what was run.
+func (s *chatService) GetQueryHistory(ctx context.Context, userID, chatID string, req *dtos.QueryHistoryRequest) (*dtos.QueryHistoryResponse, uint32, error) {
+	log.Printf("ChatService -> GetQueryHistory -> chatID: %s, queryType: %s, executed: %v", chatID, req.QueryType, req.Executed)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID format")
+	}
+
+	// Verify chat ownership
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch chat: %v", err)
+	}
+	if chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+	if chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized access to chat")
+	}
+
+	filter := repositories.QueryHistoryFilter{
+		QueryType:  strings.ToUpper(strings.TrimSpace(req.QueryType)),
+		IsExecuted: req.Executed,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultQueryHistoryPageSize
+	} else if filter.PageSize > maxQueryHistoryPageSize {
+		filter.PageSize = maxQueryHistoryPageSize
+	}
+
+	if req.From != "" {
+		from, _, err := parseAnalyticsTime(req.From)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid 'from' value: %v", err)
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, dateOnly, err := parseAnalyticsTime(req.To)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid 'to' value: %v", err)
+		}
+		if dateOnly {
+			// Include the whole day
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, http.StatusBadRequest, fmt.Errorf("'from' must be before 'to'")
+	}
+
+	records, total, err := s.chatRepo.FindQueriesByCriteria(chatObjID, filter)
+	if err != nil {
+		log.Printf("ChatService -> GetQueryHistory -> Error fetching queries: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch query history: %v", err)
+	}
+
+	response := &dtos.QueryHistoryResponse{
+		Queries:  make([]dtos.QueryHistoryItem, 0, len(records)),
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}
+	for _, record := range records {
+		item := dtos.QueryHistoryItem{
+			QueryID:       record.Query.ID.Hex(),
+			MessageID:     record.MessageID.Hex(),
+			Query:         record.Query.Query,
+			QueryType:     record.Query.QueryType,
+			IsExecuted:    record.Query.IsExecuted,
+			IsRolledBack:  record.Query.IsRolledBack,
+			ExecutionTime: record.Query.ExecutionTime,
+			Tables:        record.Query.Tables,
+			CreatedAt:     record.CreatedAt,
+		}
+		if record.Query.Error != nil {
+			item.Error = &record.Query.Error.Message
+		}
+		response.Queries = append(response.Queries, item)
+	}
+
+	return response, http.StatusOK, nil
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ComparePlans runs EXPLAIN on two versions of a query in parallel and compares their plans.
+// Both queries must be read-only, and each EXPLAIN runs in a read-only transaction that is rolled back.
+func (s *chatService) ComparePlans(ctx context.Context, userID, chatID string, req *dtos.ComparePlansRequest) (*dtos.ComparePlansResponse, uint32, error) {
+	log.Printf("ChatService -> ComparePlans -> userID: %s, chatID: %s", userID, chatID)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	dbType := chat.Connection.Type
+	if !isPostgresPlanType(dbType) && !isMySQLPlanType(dbType) {
+		return nil, http.StatusBadRequest, fmt.Errorf("plan comparison is not supported for %s databases", dbType)
+	}
+
+	queryA := strings.TrimSuffix(strings.TrimSpace(req.QueryA), ";")
+	queryB := strings.TrimSuffix(strings.TrimSpace(req.QueryB), ";")
+	if !isReadOnlyQuery(dbType, queryA) || !isReadOnlyQuery(dbType, queryB) {
+		return nil, http.StatusBadRequest, fmt.Errorf("only single read-only SELECT queries can be compared")
+	}
+
+	if _, exists := s.dbManager.GetConnectionInfo(chatID); !exists {
+		log.Printf("ChatService -> ComparePlans -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("compare-plans-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+	}
+	conn, err := s.dbManager.GetConnection(chatID)
+	if err != nil || conn.GetDB() == nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get database connection")
+	}
+	db := conn.GetDB()
+
+	var planA, planB *dtos.QueryPlanSummary
+	var errA, errB error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		planA, errA = explainQueryPlan(ctx, db, dbType, queryA)
+	}()
+	go func() {
+		defer wg.Done()
+		planB, errB = explainQueryPlan(ctx, db, dbType, queryB)
+	}()
+	wg.Wait()
+
+	if errA != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to explain queryA: %v", errA)
+	}
+	if errB != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to explain queryB: %v", errB)
+	}
+
+	response := &dtos.ComparePlansResponse{
+		DatabaseType: dbType,
+		PlanA:        *planA,
+		PlanB:        *planB,
+		Winner:       queryPlanWinner(planA.TotalCost, planB.TotalCost),
+		Differences:  diffQueryPlans(planA, planB),
+	}
+	log.Printf("ChatService -> ComparePlans -> Cost A: %.2f, cost B: %.2f, winner: %s", planA.TotalCost, planB.TotalCost, response.Winner)
+	return response, http.StatusOK, nil
+}
+
+func isPostgresPlanType(dbType string) bool {
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB, constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
+		return true
+	}
+	return false
+}
+
+func isMySQLPlanType(dbType string) bool {
+	return dbType == constants.DatabaseTypeMySQL || dbType == constants.DatabaseTypePlanetscale
+}
+
+// explainQueryPlan runs EXPLAIN with JSON output in a read-only transaction and summarizes the plan
+func explainQueryPlan(ctx context.Context, db *sql.DB, dbType, query string) (*dtos.QueryPlanSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(constants.QueryPlanCompareTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start read-only transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	explain := "EXPLAIN (FORMAT JSON) " + query
+	if isMySQLPlanType(dbType) {
+		explain = "EXPLAIN FORMAT=JSON " + query
+	}
+
+	var raw string
+	if err := tx.QueryRowContext(ctx, explain).Scan(&raw); err != nil {
+		return nil, err
+	}
+
+	var plan interface{}
+	if err := json.Unmarshal([]byte(raw), &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %v", err)
+	}
+
+	summary := &dtos.QueryPlanSummary{
+		Query:       query,
+		PlanNodes:   []string{},
+		IndexesUsed: []string{},
+		JoinTypes:   []string{},
+		FullScans:   []string{},
+		Plan:        plan,
+	}
+	if isMySQLPlanType(dbType) {
+		summarizeMySQLPlan(plan, summary)
+	} else {
+		summarizePostgresPlan(plan, summary)
+	}
+	return summary, nil
+}
+
+// summarizePostgresPlan reads [{"Plan": {...}}], the root node carries the total cost and row estimate
+func summarizePostgresPlan(plan interface{}, summary *dtos.QueryPlanSummary) {
+	plans, ok := plan.([]interface{})
+	if !ok || len(plans) == 0 {
+		return
+	}
+	wrapper, ok := plans[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	root, ok := wrapper["Plan"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	summary.TotalCost = planFloat(root["Total Cost"])
+	summary.EstimatedRows = planFloat(root["Plan Rows"])
+	walkPostgresPlanNode(root, summary)
+}
+
+func walkPostgresPlanNode(node map[string]interface{}, summary *dtos.QueryPlanSummary) {
+	nodeType, _ := node["Node Type"].(string)
+	if nodeType != "" {
+		summary.PlanNodes = append(summary.PlanNodes, nodeType)
+	}
+	if index, ok := node["Index Name"].(string); ok && index != "" {
+		summary.IndexesUsed = appendUnique(summary.IndexesUsed, index)
+	}
+	if nodeType == "Seq Scan" {
+		if relation, ok := node["Relation Name"].(string); ok {
+			summary.FullScans = appendUnique(summary.FullScans, relation)
+		}
+	}
+	if joinType, ok := node["Join Type"].(string); ok && joinType != "" {
+		summary.JoinTypes = append(summary.JoinTypes, fmt.Sprintf("%s (%s)", nodeType, joinType))
+	}
+
+	if children, ok := node["Plans"].([]interface{}); ok {
+		for _, child := range children {
+			if childNode, ok := child.(map[string]interface{}); ok {
+				walkPostgresPlanNode(childNode, summary)
+			}
+		}
+	}
+}
+
+// summarizeMySQLPlan reads {"query_block": {"cost_info": {"query_cost": "..."}, ...}} and walks every
+// table access, nested loop and operation block below it
+func summarizeMySQLPlan(plan interface{}, summary *dtos.QueryPlanSummary) {
+	root, ok := plan.(map[string]interface{})
+	if !ok {
+		return
+	}
+	queryBlock, ok := root["query_block"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if costInfo, ok := queryBlock["cost_info"].(map[string]interface{}); ok {
+		summary.TotalCost = planFloat(costInfo["query_cost"])
+	}
+	walkMySQLPlanNode(queryBlock, summary)
+}
+
+func walkMySQLPlanNode(node interface{}, summary *dtos.QueryPlanSummary) {
+	switch v := node.(type) {
+	case []interface{}:
+		for _, child := range v {
+			walkMySQLPlanNode(child, summary)
+		}
+	case map[string]interface{}:
+		if _, ok := v["nested_loop"]; ok {
+			summary.PlanNodes = append(summary.PlanNodes, "nested_loop")
+			summary.JoinTypes = append(summary.JoinTypes, "Nested Loop")
+		}
+		for _, operation := range []string{"ordering_operation", "grouping_operation", "duplicates_removal", "materialized_from_subquery"} {
+			if _, ok := v[operation]; ok {
+				summary.PlanNodes = append(summary.PlanNodes, operation)
+			}
+		}
+		if table, ok := v["table"].(map[string]interface{}); ok {
+			summarizeMySQLTableAccess(table, summary)
+		}
+		for key, child := range v {
+			if key == "table" {
+				// Tables can nest subqueries and materialized blocks
+				if table, ok := child.(map[string]interface{}); ok {
+					for tableKey, tableChild := range table {
+						if tableKey != "cost_info" {
+							walkMySQLPlanNode(tableChild, summary)
+						}
+					}
+				}
+				continue
+			}
+			if key != "cost_info" {
+				walkMySQLPlanNode(child, summary)
+			}
+		}
+	}
+}
+
+func summarizeMySQLTableAccess(table map[string]interface{}, summary *dtos.QueryPlanSummary) {
+	name, _ := table["table_name"].(string)
+	accessType, _ := table["access_type"].(string)
+	summary.PlanNodes = append(summary.PlanNodes, fmt.Sprintf("%s access on %s", accessType, name))
+	if key, ok := table["key"].(string); ok && key != "" {
+		summary.IndexesUsed = appendUnique(summary.IndexesUsed, key)
+	}
+	if accessType == "ALL" {
+		summary.FullScans = appendUnique(summary.FullScans, name)
+	}
+	if joinBuffer, ok := table["using_join_buffer"].(string); ok && joinBuffer != "" {
+		summary.JoinTypes = append(summary.JoinTypes, joinBuffer)
+	}
+	rows := planFloat(table["rows_produced_per_join"])
+	if rows == 0 {
+		rows = planFloat(table["rows_examined_per_scan"])
+	}
+	if rows > summary.EstimatedRows {
+		summary.EstimatedRows = rows
+	}
+}
+
+// queryPlanWinner picks the plan with the lower total cost, costs equal to two decimals are a tie
+func queryPlanWinner(costA, costB float64) string {
+	roundedA := math.Round(costA*100) / 100
+	roundedB := math.Round(costB*100) / 100
+	switch {
+	case roundedA < roundedB:
+		return constants.QueryPlanWinnerA
+	case roundedB < roundedA:
+		return constants.QueryPlanWinnerB
+	default:
+		return constants.QueryPlanTie
+	}
+}
+
+// diffQueryPlans describes how the plans differ in cost, nodes, index usage, full scans and joins
+func diffQueryPlans(planA, planB *dtos.QueryPlanSummary) []string {
+	differences := []string{}
+
+	if planA.TotalCost != planB.TotalCost {
+		cheaper, cheaperCost, otherCost := "A", planA.TotalCost, planB.TotalCost
+		if planB.TotalCost < planA.TotalCost {
+			cheaper, cheaperCost, otherCost = "B", planB.TotalCost, planA.TotalCost
+		}
+		saving := 0.0
+		if otherCost > 0 {
+			saving = (otherCost - cheaperCost) / otherCost * 100
+		}
+		differences = append(differences, fmt.Sprintf("Total cost: A %.2f vs B %.2f, %s is %.1f%% cheaper", planA.TotalCost, planB.TotalCost, cheaper, saving))
+	}
+	if planA.EstimatedRows != planB.EstimatedRows {
+		differences = append(differences, fmt.Sprintf("Estimated rows: A %.0f vs B %.0f", planA.EstimatedRows, planB.EstimatedRows))
+	}
+
+	differences = appendListDifference(differences, "Plan nodes", planA.PlanNodes, planB.PlanNodes)
+	differences = appendListDifference(differences, "Indexes used", planA.IndexesUsed, planB.IndexesUsed)
+	differences = appendListDifference(differences, "Full table scans", planA.FullScans, planB.FullScans)
+	differences = appendListDifference(differences, "Join types", planA.JoinTypes, planB.JoinTypes)
+
+	return differences
+}
+
+// appendListDifference reports the entries only one of the plans has
+func appendListDifference(differences []string, label string, listA, listB []string) []string {
+	onlyA := listSubtract(listA, listB)
+	onlyB := listSubtract(listB, listA)
+	if len(onlyA) > 0 {
+		differences = append(differences, fmt.Sprintf("%s only in A: %s", label, strings.Join(onlyA, ", ")))
+	}
+	if len(onlyB) > 0 {
+		differences = append(differences, fmt.Sprintf("%s only in B: %s", label, strings.Join(onlyB, ", ")))
+	}
+	return differences
+}
+
+// listSubtract returns the entries of a missing from b, counting duplicates
+func listSubtract(a, b []string) []string {
+	remaining := make(map[string]int, len(b))
+	for _, entry := range b {
+		remaining[entry]++
+	}
+	result := []string{}
+	for _, entry := range a {
+		if remaining[entry] > 0 {
+			remaining[entry]--
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// planFloat reads a plan number, MySQL reports costs as strings
+func planFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
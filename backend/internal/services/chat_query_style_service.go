@@ -0,0 +1,40 @@
+package services
+
+import (
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"strings"
+)
+
+// queryStyleConfig validates a requested query style for the chat settings, nil when every value is empty
+func queryStyleConfig(style *dtos.QueryStyle) (*models.QueryStyleConfig, error) {
+	config := &models.QueryStyleConfig{
+		JoinStyle:         strings.ToUpper(strings.TrimSpace(style.JoinStyle)),
+		Indentation:       style.Indentation,
+		KeywordCase:       strings.ToLower(strings.TrimSpace(style.KeywordCase)),
+		NotEqualsOperator: strings.TrimSpace(style.NotEqualsOperator),
+		TableAliasSuffix:  strings.TrimSpace(style.TableAliasSuffix),
+	}
+	if err := utils.ValidateQueryStyle(config); err != nil {
+		return nil, err
+	}
+	if *config == (models.QueryStyleConfig{}) {
+		return nil, nil
+	}
+	return config, nil
+}
+
+// queryStyleResponse returns a chat's query style for the API, nil when it has none
+func queryStyleResponse(style *models.QueryStyleConfig) *dtos.QueryStyle {
+	if style == nil {
+		return nil
+	}
+	return &dtos.QueryStyle{
+		JoinStyle:         style.JoinStyle,
+		Indentation:       style.Indentation,
+		KeywordCase:       style.KeywordCase,
+		NotEqualsOperator: style.NotEqualsOperator,
+		TableAliasSuffix:  style.TableAliasSuffix,
+	}
+}
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateQueryTemplate saves a read-only query with :name placeholders for the chat's connection.
+// Every placeholder must be declared as a parameter with a type its values are checked against.
+func (s *chatService) CreateQueryTemplate(ctx context.Context, userID, chatID string, req *dtos.CreateQueryTemplateRequest) (*dtos.QueryTemplateResponse, uint32, error) {
+	log.Printf("ChatService -> CreateQueryTemplate -> userID: %s, chatID: %s", userID, chatID)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.queryTemplateRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("query templates are not available")
+	}
+	if !dbmanager.SupportsParameterBinding(chat.Connection.Type) {
+		return nil, http.StatusBadRequest, fmt.Errorf("query templates are not supported for %s connections", chat.Connection.Type)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("name is required")
+	}
+	template := strings.TrimSpace(req.Template)
+	if template == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("template is required")
+	}
+	if len(template) > constants.QueryTemplateMaxLength {
+		return nil, http.StatusBadRequest, fmt.Errorf("template is too long, the limit is %d characters", constants.QueryTemplateMaxLength)
+	}
+	if !constants.IsReadOnlyQuery(template, chat.Connection.Type) {
+		return nil, http.StatusBadRequest, fmt.Errorf("only read-only queries can be saved as templates")
+	}
+
+	parameters, err := validateTemplateParams(template, req.Parameters)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	existing, err := s.queryTemplateRepo.FindByChatID(ctx, chat.ID)
+	if err != nil {
+		log.Printf("ChatService -> CreateQueryTemplate -> Error counting templates: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create query template")
+	}
+	if len(existing) >= constants.QueryTemplatesMaxPerChat {
+		return nil, http.StatusBadRequest, fmt.Errorf("a chat can have at most %d query templates", constants.QueryTemplatesMaxPerChat)
+	}
+
+	queryTemplate := models.NewQueryTemplate(chat.ID, chat.UserID, name, template, parameters)
+	if err := s.queryTemplateRepo.Create(ctx, queryTemplate); err != nil {
+		log.Printf("ChatService -> CreateQueryTemplate -> Error creating template: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create query template")
+	}
+
+	return buildQueryTemplateResponse(queryTemplate), http.StatusCreated, nil
+}
+
+// ListQueryTemplates returns the query templates of a chat, oldest first
+func (s *chatService) ListQueryTemplates(ctx context.Context, userID, chatID string) ([]dtos.QueryTemplateResponse, uint32, error) {
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.queryTemplateRepo == nil {
+		return []dtos.QueryTemplateResponse{}, http.StatusOK, nil
+	}
+
+	templates, err := s.queryTemplateRepo.FindByChatID(ctx, chat.ID)
+	if err != nil {
+		log.Printf("ChatService -> ListQueryTemplates -> Error fetching templates: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch query templates")
+	}
+
+	response := make([]dtos.QueryTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		response = append(response, *buildQueryTemplateResponse(template))
+	}
+	return response, http.StatusOK, nil
+}
+
+// ExecuteQueryTemplate checks the given values against the template's parameter types and runs the template
+// with the values bound by the database driver, so a value can never change the query itself
+func (s *chatService) ExecuteQueryTemplate(ctx context.Context, userID, chatID, templateID string, req *dtos.ExecuteQueryTemplateRequest) (*dtos.QueryTemplateExecutionResponse, uint32, error) {
+	log.Printf("ChatService -> ExecuteQueryTemplate -> userID: %s, chatID: %s, templateID: %s", userID, chatID, templateID)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	template, status, err := s.findChatQueryTemplate(ctx, chat, templateID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	params, err := resolveTemplateParamValues(template.Parameters, req.Params)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	streamID := fmt.Sprintf("template-%s", templateID)
+	if !s.dbManager.IsConnected(chatID) {
+		if status, err := s.ConnectDB(ctx, userID, chatID, streamID); err != nil {
+			return nil, status, err
+		}
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Duration(chat.Settings.GetQueryTimeoutSeconds())*time.Second)
+	defer cancel()
+
+	result, queryErr := s.dbManager.ExecuteParameterizedQuery(queryCtx, chatID, template.Template, params)
+	if queryErr != nil {
+		log.Printf("ChatService -> ExecuteQueryTemplate -> Error executing template: %s %s", queryErr.Message, queryErr.Details)
+		status := http.StatusInternalServerError
+		switch queryErr.Code {
+		case "INVALID_PARAMETERS", "SAFETY_VIOLATION", "NOT_SUPPORTED":
+			status = http.StatusBadRequest
+		case "QUERY_EXECUTION_TIMED_OUT":
+			status = http.StatusRequestTimeout
+		}
+		if queryErr.Details != "" {
+			return nil, uint32(status), fmt.Errorf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return nil, uint32(status), fmt.Errorf("%s", queryErr.Message)
+	}
+
+	return &dtos.QueryTemplateExecutionResponse{
+		TemplateID:      templateID,
+		ExecutionTime:   result.ExecutionTime,
+		ExecutionResult: result.Result,
+		Truncated:       result.Truncated,
+		TruncatedAt:     result.TruncatedAt,
+	}, http.StatusOK, nil
+}
+
+// findChatQueryTemplate loads a template and checks that it belongs to the chat
+func (s *chatService) findChatQueryTemplate(ctx context.Context, chat *models.Chat, templateID string) (*models.QueryTemplate, uint32, error) {
+	if s.queryTemplateRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("query templates are not available")
+	}
+
+	templateObjID, err := primitive.ObjectIDFromHex(templateID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid template ID")
+	}
+
+	template, err := s.queryTemplateRepo.FindByID(ctx, templateObjID)
+	if err != nil {
+		log.Printf("ChatService -> findChatQueryTemplate -> Error fetching template: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch query template")
+	}
+	if template == nil || template.ChatID != chat.ID {
+		return nil, http.StatusNotFound, fmt.Errorf("query template not found")
+	}
+	return template, http.StatusOK, nil
+}
+
+// validateTemplateParams checks that the declared parameters match the template's placeholders one to one,
+// that their types are supported and that their default values are of their type
+func validateTemplateParams(template string, declared []models.TemplateParam) ([]models.TemplateParam, error) {
+	placeholders := dbmanager.NamedParameters(template)
+	if len(placeholders) > constants.QueryTemplateMaxParameter {
+		return nil, fmt.Errorf("a template can have at most %d parameters", constants.QueryTemplateMaxParameter)
+	}
+
+	used := make(map[string]bool, len(placeholders))
+	for _, name := range placeholders {
+		used[name] = true
+	}
+
+	parameters := make([]models.TemplateParam, 0, len(declared))
+	seen := make(map[string]bool, len(declared))
+	for _, param := range declared {
+		param.Name = strings.TrimPrefix(strings.TrimSpace(param.Name), ":")
+		if param.Name == "" {
+			return nil, fmt.Errorf("parameter name is required")
+		}
+		if seen[param.Name] {
+			return nil, fmt.Errorf("parameter %s is declared more than once", param.Name)
+		}
+		if !used[param.Name] {
+			return nil, fmt.Errorf("parameter %s is not used in the template", param.Name)
+		}
+		seen[param.Name] = true
+
+		param.Type = strings.ToLower(strings.TrimSpace(param.Type))
+		if param.Type == "" {
+			param.Type = constants.TemplateParamTypeString
+		}
+		if !isValidTemplateParamType(param.Type) {
+			return nil, fmt.Errorf("parameter %s has unsupported type %s, use one of: %s", param.Name, param.Type, strings.Join(constants.TemplateParamTypes, ", "))
+		}
+		if param.DefaultValue != nil {
+			if _, err := convertTemplateParamValue(param, *param.DefaultValue); err != nil {
+				return nil, fmt.Errorf("default value of parameter %s: %v", param.Name, err)
+			}
+		}
+		parameters = append(parameters, param)
+	}
+
+	for _, name := range placeholders {
+		if !seen[name] {
+			return nil, fmt.Errorf("placeholder :%s is not declared as a parameter", name)
+		}
+	}
+	return parameters, nil
+}
+
+func isValidTemplateParamType(paramType string) bool {
+	for _, supported := range constants.TemplateParamTypes {
+		if paramType == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveTemplateParamValues converts the given values to their parameter types, filling in defaults.
+// Values for parameters the template does not declare are rejected.
+func resolveTemplateParamValues(parameters []models.TemplateParam, given map[string]interface{}) (map[string]interface{}, error) {
+	declared := make(map[string]bool, len(parameters))
+	for _, param := range parameters {
+		declared[param.Name] = true
+	}
+	for name := range given {
+		if !declared[name] {
+			return nil, fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+
+	values := make(map[string]interface{}, len(parameters))
+	for _, param := range parameters {
+		raw, ok := given[param.Name]
+		if !ok || raw == nil {
+			if param.DefaultValue == nil {
+				return nil, fmt.Errorf("parameter %s is required", param.Name)
+			}
+			raw = *param.DefaultValue
+		}
+
+		value, err := convertTemplateParamValue(param, raw)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %v", param.Name, err)
+		}
+		values[param.Name] = value
+	}
+	return values, nil
+}
+
+// convertTemplateParamValue converts a JSON value, or a string such as a default value, to the parameter's type
+func convertTemplateParamValue(param models.TemplateParam, raw interface{}) (interface{}, error) {
+	text, isString := raw.(string)
+
+	switch param.Type {
+	case constants.TemplateParamTypeString:
+		if !isString {
+			return nil, fmt.Errorf("expected a string")
+		}
+		return text, nil
+
+	case constants.TemplateParamTypeInteger:
+		if isString {
+			value, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected an integer")
+			}
+			return value, nil
+		}
+		number, ok := raw.(float64)
+		if !ok || number != math.Trunc(number) {
+			return nil, fmt.Errorf("expected an integer")
+		}
+		return int64(number), nil
+
+	case constants.TemplateParamTypeNumber:
+		if isString {
+			value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected a number")
+			}
+			return value, nil
+		}
+		number, ok := raw.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected a number")
+		}
+		return number, nil
+
+	case constants.TemplateParamTypeBoolean:
+		if isString {
+			value, err := strconv.ParseBool(strings.TrimSpace(text))
+			if err != nil {
+				return nil, fmt.Errorf("expected true or false")
+			}
+			return value, nil
+		}
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		return value, nil
+
+	case constants.TemplateParamTypeDate:
+		if !isString {
+			return nil, fmt.Errorf("expected a date as YYYY-MM-DD")
+		}
+		value, err := time.Parse("2006-01-02", strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("expected a date as YYYY-MM-DD")
+		}
+		return value, nil
+
+	case constants.TemplateParamTypeDateTime:
+		if !isString {
+			return nil, fmt.Errorf("expected an RFC 3339 datetime")
+		}
+		value, err := time.Parse(time.RFC3339, strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("expected an RFC 3339 datetime, e.g. 2024-01-01T00:00:00Z")
+		}
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", param.Type)
+}
+
+func buildQueryTemplateResponse(template *models.QueryTemplate) *dtos.QueryTemplateResponse {
+	parameters := template.Parameters
+	if parameters == nil {
+		parameters = []models.TemplateParam{}
+	}
+	return &dtos.QueryTemplateResponse{
+		ID:         template.ID.Hex(),
+		ChatID:     template.ChatID.Hex(),
+		Name:       template.Name,
+		Template:   template.Template,
+		Parameters: parameters,
+		CreatedAt:  template.CreatedAt,
+		UpdatedAt:  template.UpdatedAt,
+	}
+}
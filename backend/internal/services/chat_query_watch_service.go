@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// queryWatch is a running watch, kept next to its cancel func in activeWatchers
+type queryWatch struct {
+	userID string
+	chatID string
+}
+
+// StartQueryWatch re-runs a read-only query every IntervalSeconds and streams each result with a diff
+// from the previous run. The watch ends when it is stopped, its stream disconnects or it expires.
+func (s *chatService) StartQueryWatch(userID, chatID string, req *dtos.StartQueryWatchRequest) (*dtos.QueryWatchResponse, uint32, error) {
+	log.Printf("ChatService -> StartQueryWatch -> userID: %s, chatID: %s, queryID: %s, interval: %ds", userID, chatID, req.QueryID, req.IntervalSeconds)
+
+	if req.IntervalSeconds < constants.QueryWatchMinIntervalSeconds || req.IntervalSeconds > constants.QueryWatchMaxIntervalSeconds {
+		return nil, http.StatusBadRequest, fmt.Errorf("intervalSeconds must be between %d and %d", constants.QueryWatchMinIntervalSeconds, constants.QueryWatchMaxIntervalSeconds)
+	}
+
+	chat, _, query, err := s.verifyQueryOwnership(userID, chatID, req.MessageID, req.QueryID)
+	if err != nil {
+		return nil, http.StatusForbidden, err
+	}
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat == nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	if s.streamHandler == nil || !s.streamHandler.HasStream(userID, chatID, req.StreamID) {
+		return nil, http.StatusBadRequest, fmt.Errorf("stream %s is not connected", req.StreamID)
+	}
+
+	watchedQuery := strings.TrimSpace(req.Query)
+	if watchedQuery == "" {
+		watchedQuery = query.Query
+	}
+	// Watched queries run unattended, so only reads are allowed
+	if !isReadOnlyQuery(chat.Connection.Type, watchedQuery) {
+		return nil, http.StatusBadRequest, fmt.Errorf("only read-only queries can be watched")
+	}
+
+	startedAt := time.Now()
+	watch := &dtos.QueryWatchResponse{
+		WatchID:         primitive.NewObjectID().Hex(),
+		ChatID:          chatID,
+		MessageID:       req.MessageID,
+		QueryID:         req.QueryID,
+		Query:           watchedQuery,
+		IntervalSeconds: req.IntervalSeconds,
+		StartedAt:       startedAt,
+		ExpiresAt:       startedAt.Add(constants.QueryWatchMaxDurationMinutes * time.Minute),
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), watch.ExpiresAt)
+
+	s.watchersMu.Lock()
+	running := 0
+	for _, owner := range s.watchOwners {
+		if owner.userID == userID {
+			running++
+		}
+	}
+	if running >= constants.QueryWatchMaxPerUser {
+		s.watchersMu.Unlock()
+		cancel()
+		return nil, http.StatusTooManyRequests, fmt.Errorf("at most %d queries can be watched at the same time, stop a watch first", constants.QueryWatchMaxPerUser)
+	}
+	s.activeWatchers[watch.WatchID] = cancel
+	s.watchOwners[watch.WatchID] = queryWatch{userID: userID, chatID: chatID}
+	s.watchersMu.Unlock()
+
+	go s.runQueryWatch(ctx, userID, req.StreamID, watch)
+
+	log.Printf("ChatService -> StartQueryWatch -> Started watch %s for query %s", watch.WatchID, req.QueryID)
+	return watch, http.StatusOK, nil
+}
+
+// StopQueryWatch cancels a running watch of the user
+func (s *chatService) StopQueryWatch(userID, chatID, watchID string) (uint32, error) {
+	s.watchersMu.Lock()
+	defer s.watchersMu.Unlock()
+
+	owner, ok := s.watchOwners[watchID]
+	if !ok || owner.chatID != chatID {
+		return http.StatusNotFound, fmt.Errorf("watch not found")
+	}
+	if owner.userID != userID {
+		return http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	if cancel, ok := s.activeWatchers[watchID]; ok {
+		cancel()
+	}
+	log.Printf("ChatService -> StopQueryWatch -> Stopped watch %s", watchID)
+	return http.StatusOK, nil
+}
+
+// runQueryWatch runs the watched query until the context ends or the stream goes away
+func (s *chatService) runQueryWatch(ctx context.Context, userID, streamID string, watch *dtos.QueryWatchResponse) {
+	reason := "stopped"
+	defer func() {
+		s.watchersMu.Lock()
+		if cancel, ok := s.activeWatchers[watch.WatchID]; ok {
+			cancel()
+			delete(s.activeWatchers, watch.WatchID)
+		}
+		delete(s.watchOwners, watch.WatchID)
+		s.watchersMu.Unlock()
+
+		s.sendStreamEvent(userID, watch.ChatID, streamID, dtos.StreamResponse{
+			Event: constants.StreamEventWatchStopped,
+			Data: map[string]interface{}{
+				"watch_id": watch.WatchID,
+				"query_id": watch.QueryID,
+				"reason":   reason,
+			},
+		})
+		log.Printf("ChatService -> runQueryWatch -> Watch %s ended: %s", watch.WatchID, reason)
+	}()
+
+	interval := time.Duration(watch.IntervalSeconds) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous []map[string]interface{}
+	for run := 1; ; run++ {
+		if s.streamHandler == nil || !s.streamHandler.HasStream(userID, watch.ChatID, streamID) {
+			reason = "stream disconnected"
+			return
+		}
+
+		rows, runErr := s.runWatchedQuery(ctx, userID, watch, interval)
+		if ctx.Err() != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				reason = "expired"
+			}
+			return
+		}
+
+		data := map[string]interface{}{
+			"watch_id":    watch.WatchID,
+			"message_id":  watch.MessageID,
+			"query_id":    watch.QueryID,
+			"run":         run,
+			"executed_at": time.Now().Format(time.RFC3339),
+		}
+		if runErr != nil {
+			data["error"] = runErr.Error()
+		} else {
+			data["result"] = rows
+			data["diff"] = diffWatchResults(previous, rows, run == 1)
+			previous = rows
+		}
+		s.sendStreamEvent(userID, watch.ChatID, streamID, dtos.StreamResponse{
+			Event: constants.StreamEventWatchResult,
+			Data:  data,
+		})
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				reason = "expired"
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWatchedQuery runs one iteration of a watch, bounded by the watch interval
+func (s *chatService) runWatchedQuery(ctx context.Context, userID string, watch *dtos.QueryWatchResponse, timeout time.Duration) ([]map[string]interface{}, error) {
+	if !s.dbManager.IsConnected(watch.ChatID) {
+		if _, err := s.ConnectDB(ctx, userID, watch.ChatID, fmt.Sprintf("watch-%s", watch.WatchID)); err != nil {
+			return nil, fmt.Errorf("failed to connect to database: %v", err)
+		}
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// A stream ID of its own keeps the watch from clobbering the execution tracking of the user's stream
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, watch.ChatID, watch.MessageID, watch.QueryID, fmt.Sprintf("watch-%s", watch.WatchID), watch.Query, "SELECT", false, false)
+	if queryErr != nil {
+		if queryErr.Details != "" {
+			return nil, fmt.Errorf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return nil, fmt.Errorf("%s", queryErr.Message)
+	}
+
+	rows := []map[string]interface{}{}
+	if result != nil {
+		if resultRows := extractResultRows(result.Result); resultRows != nil {
+			rows = resultRows
+		}
+	}
+	if len(rows) > constants.QueryWatchMaxRows {
+		rows = rows[:constants.QueryWatchMaxRows]
+	}
+	return rows, nil
+}
+
+// diffWatchResults compares two runs row by row. Rows are matched on their JSON encoding,
+// so duplicates are counted and a row whose values changed is reported as removed and added.
+func diffWatchResults(previous, current []map[string]interface{}, firstRun bool) dtos.QueryWatchDiff {
+	diff := dtos.QueryWatchDiff{
+		Added:         []map[string]interface{}{},
+		Removed:       []map[string]interface{}{},
+		RowCountDelta: len(current) - len(previous),
+	}
+	if firstRun {
+		return diff
+	}
+
+	remaining := make(map[string]int, len(previous))
+	for _, row := range previous {
+		remaining[watchRowKey(row)]++
+	}
+	for _, row := range current {
+		key := watchRowKey(row)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		diff.Added = append(diff.Added, row)
+	}
+	for _, row := range previous {
+		key := watchRowKey(row)
+		if remaining[key] > 0 {
+			remaining[key]--
+			diff.Removed = append(diff.Removed, row)
+		}
+	}
+
+	diff.Changed = len(diff.Added) > 0 || len(diff.Removed) > 0
+	return diff
+}
+
+// watchRowKey encodes a row for comparison, map keys are sorted by encoding/json
+func watchRowKey(row map[string]interface{}) string {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Sprintf("%v", row)
+	}
+	return string(data)
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddMessageReaction adds the user's emoji reaction to a message. Reacting twice with the same emoji
+// leaves the counts unchanged.
+func (s *chatService) AddMessageReaction(ctx context.Context, userID, chatID, messageID string, req *dtos.MessageReactionRequest) (*dtos.MessageReactionResponse, uint32, error) {
+	log.Printf("ChatService -> AddMessageReaction -> userID: %s, chatID: %s, messageID: %s", userID, chatID, messageID)
+
+	emoji := strings.TrimSpace(req.Emoji)
+	userObjID, message, statusCode, err := s.findReactionMessage(userID, chatID, messageID, emoji)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	reaction := &models.MessageReaction{
+		MessageID: message.ID,
+		ChatID:    message.ChatID,
+		UserID:    userObjID,
+		Emoji:     emoji,
+		Base:      models.NewBase(),
+	}
+	added, err := s.reactionRepo.Add(ctx, reaction)
+	if err != nil {
+		log.Printf("ChatService -> AddMessageReaction -> Error saving reaction: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save reaction: %v", err)
+	}
+
+	reactions := message.Reactions
+	if added {
+		updated, err := s.chatRepo.IncrementMessageReaction(message.ID, emoji, 1)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to update reactions: %v", err)
+		}
+		reactions = updated.Reactions
+	}
+
+	return messageReactionResponse(messageID, emoji, reactions), http.StatusOK, nil
+}
+
+// RemoveMessageReaction removes the user's emoji reaction from a message
+func (s *chatService) RemoveMessageReaction(ctx context.Context, userID, chatID, messageID, emoji string) (*dtos.MessageReactionResponse, uint32, error) {
+	log.Printf("ChatService -> RemoveMessageReaction -> userID: %s, chatID: %s, messageID: %s", userID, chatID, messageID)
+
+	emoji = strings.TrimSpace(emoji)
+	userObjID, message, statusCode, err := s.findReactionMessage(userID, chatID, messageID, emoji)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	removed, err := s.reactionRepo.Remove(ctx, message.ID, userObjID, emoji)
+	if err != nil {
+		log.Printf("ChatService -> RemoveMessageReaction -> Error removing reaction: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to remove reaction: %v", err)
+	}
+	if !removed {
+		return nil, http.StatusNotFound, fmt.Errorf("reaction not found")
+	}
+
+	updated, err := s.chatRepo.IncrementMessageReaction(message.ID, emoji, -1)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update reactions: %v", err)
+	}
+
+	return messageReactionResponse(messageID, emoji, updated.Reactions), http.StatusOK, nil
+}
+
+// findReactionMessage validates a reaction request and returns the user ID and the message reacted to
+func (s *chatService) findReactionMessage(userID, chatID, messageID, emoji string) (primitive.ObjectID, *models.Message, uint32, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return primitive.NilObjectID, nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	messageObjID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return primitive.NilObjectID, nil, http.StatusBadRequest, fmt.Errorf("invalid message ID format")
+	}
+
+	if !constants.IsAllowedMessageReaction(emoji) {
+		return primitive.NilObjectID, nil, http.StatusBadRequest, fmt.Errorf("emoji must be one of: %s", strings.Join(constants.AllowedMessageReactions, " "))
+	}
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return primitive.NilObjectID, nil, statusCode, err
+	}
+
+	message, err := s.chatRepo.FindMessageByID(messageObjID)
+	if err != nil || message == nil || message.ChatID != chat.ID {
+		return primitive.NilObjectID, nil, http.StatusNotFound, fmt.Errorf("message not found")
+	}
+
+	return userObjID, message, http.StatusOK, nil
+}
+
+func messageReactionResponse(messageID, emoji string, reactions map[string]int) *dtos.MessageReactionResponse {
+	if reactions == nil {
+		reactions = map[string]int{}
+	}
+	return &dtos.MessageReactionResponse{
+		MessageID: messageID,
+		Emoji:     emoji,
+		Reactions: reactions,
+	}
+}
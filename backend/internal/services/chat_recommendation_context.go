@@ -0,0 +1,171 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"neobase-ai/internal/models"
+)
+
+// recommendationTopTablesLimit caps how many frequently used tables are passed to the LLM
+const recommendationTopTablesLimit = 5
+
+// recommendationTableUsage is a table referenced by recent queries and how often it was used
+type recommendationTableUsage struct {
+	Table string `json:"table"`
+	Count int    `json:"count"`
+}
+
+// recommendationContext holds the usage signals sent to the LLM alongside the schema
+// so recommendations follow what the user is actually working on
+type recommendationContext struct {
+	DatabaseType      string                     `json:"database_type"`
+	TimeOfDay         string                     `json:"time_of_day"`
+	SuggestedFocus    string                     `json:"suggested_focus"`
+	FrequentTables    []recommendationTableUsage `json:"frequent_tables"`
+	HasEmptyResults   bool                       `json:"has_empty_results"`
+	EmptyResultTables []string                   `json:"empty_result_tables,omitempty"`
+}
+
+// buildRecommendationContext derives the recommendation signals from the recent messages of a chat
+func (s *chatService) buildRecommendationContext(messages []*models.Message, dbType string, now time.Time) *recommendationContext {
+	timeOfDay, focus := recommendationTimeOfDay(now)
+	recoContext := &recommendationContext{
+		DatabaseType:      dbType,
+		TimeOfDay:         timeOfDay,
+		SuggestedFocus:    focus,
+		FrequentTables:    []recommendationTableUsage{},
+		EmptyResultTables: []string{},
+	}
+
+	tableCounts := make(map[string]int)
+	emptyTables := make(map[string]bool)
+	for _, msg := range messages {
+		if msg == nil || msg.Queries == nil {
+			continue
+		}
+		for _, query := range *msg.Queries {
+			tables := splitQueryTables(query.Tables)
+			for _, table := range tables {
+				tableCounts[table]++
+			}
+
+			if s.queryReturnedNoRows(&query) {
+				recoContext.HasEmptyResults = true
+				for _, table := range tables {
+					emptyTables[table] = true
+				}
+			}
+		}
+	}
+
+	for table, count := range tableCounts {
+		recoContext.FrequentTables = append(recoContext.FrequentTables, recommendationTableUsage{Table: table, Count: count})
+	}
+	sort.Slice(recoContext.FrequentTables, func(i, j int) bool {
+		if recoContext.FrequentTables[i].Count != recoContext.FrequentTables[j].Count {
+			return recoContext.FrequentTables[i].Count > recoContext.FrequentTables[j].Count
+		}
+		return recoContext.FrequentTables[i].Table < recoContext.FrequentTables[j].Table
+	})
+	if len(recoContext.FrequentTables) > recommendationTopTablesLimit {
+		recoContext.FrequentTables = recoContext.FrequentTables[:recommendationTopTablesLimit]
+	}
+
+	for table := range emptyTables {
+		recoContext.EmptyResultTables = append(recoContext.EmptyResultTables, table)
+	}
+	sort.Strings(recoContext.EmptyResultTables)
+
+	return recoContext
+}
+
+// formatRecommendationContext renders the recommendation context as a block for the LLM system message
+func formatRecommendationContext(recoContext *recommendationContext) string {
+	contextJSON, err := json.MarshalIndent(map[string]interface{}{"context": recoContext}, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- Recommendation Context ---\n")
+	sb.WriteString(string(contextJSON))
+	sb.WriteString("\nUse this context as described in the recommendation rules. When frequent_tables is not empty, reference real column names of those tables from the schema.")
+	return sb.String()
+}
+
+// recommendationTime converts now to the user's location, or UTC when none is known,
+// so the time of day does not depend on the server's time zone
+func recommendationTime(now time.Time, location *time.Location) time.Time {
+	if location == nil {
+		location = time.UTC
+	}
+	return now.In(location)
+}
+
+// recommendationTimeOfDay maps the hour in now's location to a time-of-day bucket and the kind of questions usually asked then
+func recommendationTimeOfDay(now time.Time) (string, string) {
+	hour := now.Hour()
+	switch {
+	case hour >= 5 && hour < 12:
+		return "morning", "reports"
+	case hour >= 12 && hour < 18:
+		return "afternoon", "debugging"
+	default:
+		return "evening", "exploration"
+	}
+}
+
+// queryReturnedNoRows reports whether an executed query came back empty
+func (s *chatService) queryReturnedNoRows(query *models.Query) bool {
+	if !query.IsExecuted || query.Error != nil {
+		return false
+	}
+	if query.Pagination != nil && query.Pagination.TotalRecordsCount != nil {
+		return *query.Pagination.TotalRecordsCount == 0
+	}
+	if query.ExecutionResult == nil || *query.ExecutionResult == "" {
+		return false
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(s.decryptQueryResult(*query.ExecutionResult)), &result); err != nil {
+		return false
+	}
+	switch v := result.(type) {
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		if results, ok := v["results"]; ok {
+			if rows, ok := results.([]interface{}); ok {
+				return len(rows) == 0
+			}
+			return results == nil
+		}
+	}
+	return false
+}
+
+// splitQueryTables splits the comma separated table names stored on a query
+func splitQueryTables(tables *string) []string {
+	if tables == nil || *tables == "" {
+		return nil
+	}
+
+	var result []string
+	for _, table := range strings.Split(*tables, ",") {
+		table = strings.TrimSpace(table)
+		if table != "" {
+			result = append(result, table)
+		}
+	}
+	return result
+}
+
+// recommendationContextSummary is a short description of the context for logging
+func recommendationContextSummary(recoContext *recommendationContext) string {
+	return fmt.Sprintf("time_of_day=%s, frequent_tables=%d, has_empty_results=%t", recoContext.TimeOfDay, len(recoContext.FrequentTables), recoContext.HasEmptyResults)
+}
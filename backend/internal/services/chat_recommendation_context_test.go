@@ -0,0 +1,177 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"neobase-ai/internal/models"
+)
+
+func recommendationTestQuery(tables string, executed bool, result string) models.Query {
+	query := models.Query{Tables: &tables, IsExecuted: executed}
+	if result != "" {
+		query.ExecutionResult = &result
+	}
+	return query
+}
+
+func recommendationTestMessage(queries ...models.Query) *models.Message {
+	return &models.Message{Queries: &queries}
+}
+
+func TestBuildRecommendationContextFrequentTables(t *testing.T) {
+	s := &chatService{}
+	messages := []*models.Message{
+		recommendationTestMessage(
+			recommendationTestQuery("orders, customers", true, `{"results":[{"id":1}]}`),
+			recommendationTestQuery("orders", false, ""),
+		),
+		nil,
+		{Content: "a message without queries"},
+		recommendationTestMessage(
+			recommendationTestQuery("orders,products", true, `[{"id":1}]`),
+			recommendationTestQuery("customers", true, `[{"id":2}]`),
+		),
+	}
+
+	recoContext := s.buildRecommendationContext(messages, "postgresql", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+
+	want := []recommendationTableUsage{
+		{Table: "orders", Count: 3},
+		{Table: "customers", Count: 2},
+		{Table: "products", Count: 1},
+	}
+	if !reflect.DeepEqual(recoContext.FrequentTables, want) {
+		t.Errorf("FrequentTables = %+v, want %+v", recoContext.FrequentTables, want)
+	}
+	if recoContext.DatabaseType != "postgresql" {
+		t.Errorf("DatabaseType = %q, want postgresql", recoContext.DatabaseType)
+	}
+	if recoContext.HasEmptyResults {
+		t.Errorf("HasEmptyResults = true, want false")
+	}
+}
+
+func TestBuildRecommendationContextLimitsFrequentTables(t *testing.T) {
+	s := &chatService{}
+	messages := []*models.Message{
+		recommendationTestMessage(recommendationTestQuery("a,b,c,d,e,f,g", true, "")),
+		recommendationTestMessage(recommendationTestQuery("g", true, "")),
+	}
+
+	recoContext := s.buildRecommendationContext(messages, "mysql", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+
+	if len(recoContext.FrequentTables) != recommendationTopTablesLimit {
+		t.Fatalf("len(FrequentTables) = %d, want %d", len(recoContext.FrequentTables), recommendationTopTablesLimit)
+	}
+	// The most used table comes first, ties are ordered by name
+	if got := recoContext.FrequentTables[0]; got.Table != "g" || got.Count != 2 {
+		t.Errorf("FrequentTables[0] = %+v, want g used twice", got)
+	}
+	if got := recoContext.FrequentTables[1].Table; got != "a" {
+		t.Errorf("FrequentTables[1].Table = %q, want a", got)
+	}
+}
+
+func TestBuildRecommendationContextEmptyResults(t *testing.T) {
+	s := &chatService{}
+	zero := 0
+	paginated := recommendationTestQuery("events", true, `[{"id":1}]`)
+	paginated.Pagination = &models.Pagination{TotalRecordsCount: &zero}
+	failed := recommendationTestQuery("logs", true, "")
+	failed.Error = &models.QueryError{Message: "relation does not exist"}
+
+	messages := []*models.Message{
+		recommendationTestMessage(
+			recommendationTestQuery("users", true, `{"results":[]}`),
+			recommendationTestQuery("orders", true, `[]`),
+			recommendationTestQuery("products", true, `{"results":[{"id":1}]}`),
+			recommendationTestQuery("drafts", false, `[]`),
+			paginated,
+			failed,
+		),
+	}
+
+	recoContext := s.buildRecommendationContext(messages, "postgresql", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+
+	if !recoContext.HasEmptyResults {
+		t.Fatalf("HasEmptyResults = false, want true")
+	}
+	want := []string{"events", "orders", "users"}
+	if !reflect.DeepEqual(recoContext.EmptyResultTables, want) {
+		t.Errorf("EmptyResultTables = %v, want %v", recoContext.EmptyResultTables, want)
+	}
+}
+
+func TestBuildRecommendationContextWithoutMessages(t *testing.T) {
+	s := &chatService{}
+
+	recoContext := s.buildRecommendationContext(nil, "mongodb", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+
+	if recoContext.FrequentTables == nil || len(recoContext.FrequentTables) != 0 {
+		t.Errorf("FrequentTables = %#v, want an empty slice", recoContext.FrequentTables)
+	}
+	if recoContext.EmptyResultTables == nil || len(recoContext.EmptyResultTables) != 0 {
+		t.Errorf("EmptyResultTables = %#v, want an empty slice", recoContext.EmptyResultTables)
+	}
+}
+
+func TestRecommendationTimeOfDay(t *testing.T) {
+	tests := []struct {
+		hour      int
+		timeOfDay string
+		focus     string
+	}{
+		{0, "evening", "exploration"},
+		{4, "evening", "exploration"},
+		{5, "morning", "reports"},
+		{11, "morning", "reports"},
+		{12, "afternoon", "debugging"},
+		{17, "afternoon", "debugging"},
+		{18, "evening", "exploration"},
+		{23, "evening", "exploration"},
+	}
+
+	for _, tt := range tests {
+		timeOfDay, focus := recommendationTimeOfDay(time.Date(2026, 3, 2, tt.hour, 30, 0, 0, time.UTC))
+		if timeOfDay != tt.timeOfDay || focus != tt.focus {
+			t.Errorf("recommendationTimeOfDay(%02d:30) = (%q, %q), want (%q, %q)", tt.hour, timeOfDay, focus, tt.timeOfDay, tt.focus)
+		}
+	}
+}
+
+func TestRecommendationTimeUsesLocation(t *testing.T) {
+	// 08:00 UTC is morning in UTC but afternoon in UTC+9, whatever the server's own time zone is
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("UTC+9", 9*60*60)
+
+	if timeOfDay, _ := recommendationTimeOfDay(recommendationTime(now, nil)); timeOfDay != "morning" {
+		t.Errorf("time of day without a location = %q, want morning (UTC)", timeOfDay)
+	}
+	if timeOfDay, _ := recommendationTimeOfDay(recommendationTime(now, tokyo)); timeOfDay != "afternoon" {
+		t.Errorf("time of day in UTC+9 = %q, want afternoon", timeOfDay)
+	}
+
+	local := now.In(time.FixedZone("UTC-7", -7*60*60))
+	if got := recommendationTime(local, nil); got.Hour() != 8 {
+		t.Errorf("recommendationTime(local, nil).Hour() = %d, want 8", got.Hour())
+	}
+}
+
+func TestFormatRecommendationContext(t *testing.T) {
+	block := formatRecommendationContext(&recommendationContext{
+		DatabaseType:      "postgresql",
+		TimeOfDay:         "morning",
+		SuggestedFocus:    "reports",
+		FrequentTables:    []recommendationTableUsage{{Table: "orders", Count: 2}},
+		EmptyResultTables: []string{},
+	})
+
+	for _, want := range []string{"--- Recommendation Context ---", `"context"`, `"database_type": "postgresql"`, `"table": "orders"`} {
+		if !strings.Contains(block, want) {
+			t.Errorf("formatRecommendationContext() is missing %q:\n%s", want, block)
+		}
+	}
+}
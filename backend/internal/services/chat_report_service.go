@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/report"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var reportFileNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GenerateReport stores a report for the sections and generates it in the background: each section's question
+// is answered by a read-only query, streamed as a report_section event once it ran, then the LLM writes the
+// report from all the results and report_completed is streamed. The report is returned while still generating.
+func (s *chatService) GenerateReport(ctx context.Context, userID, chatID string, req *dtos.GenerateReportRequest) (*dtos.ReportResponse, uint32, error) {
+	log.Printf("ChatService -> GenerateReport -> userID: %s, chatID: %s, sections: %d", userID, chatID, len(req.Sections))
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.reportRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("reports are not available")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("a title is required")
+	}
+	if len(req.Sections) > constants.ReportMaxSections {
+		return nil, http.StatusBadRequest, fmt.Errorf("a report supports at most %d sections", constants.ReportMaxSections)
+	}
+	sections := make([]models.ReportSection, 0, len(req.Sections))
+	for i, section := range req.Sections {
+		heading := strings.TrimSpace(section.Heading)
+		question := strings.TrimSpace(section.Question)
+		if heading == "" || question == "" {
+			return nil, http.StatusBadRequest, fmt.Errorf("section %d needs a heading and a question", i+1)
+		}
+		if len(question) > constants.ReportMaxQuestionLength {
+			return nil, http.StatusBadRequest, fmt.Errorf("the question of section %d must be at most %d characters", i+1, constants.ReportMaxQuestionLength)
+		}
+		sections = append(sections, models.ReportSection{Heading: heading, Question: question})
+	}
+
+	// Make sure we have a live connection
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		log.Printf("ChatService -> GenerateReport -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, req.StreamID); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+		connInfo, exists = s.dbManager.GetConnectionInfo(chatID)
+		if !exists {
+			return nil, http.StatusInternalServerError, fmt.Errorf("connection created but not found in manager")
+		}
+	}
+	if chat.Connection.CurrentSchema == nil || *chat.Connection.CurrentSchema == "" {
+		return nil, http.StatusConflict, fmt.Errorf("schema is not ready yet, please refresh the schema and try again")
+	}
+
+	modelID := ""
+	if chat.PreferredLLMModel != nil {
+		modelID = *chat.PreferredLLMModel
+	}
+
+	rpt := models.NewReport(chat.ID, chat.UserID, title, sections, modelID)
+	if err := s.reportRepo.Create(ctx, rpt); err != nil {
+		log.Printf("ChatService -> GenerateReport -> Error saving report: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to save report")
+	}
+
+	response := buildReportResponse(rpt)
+	go s.runReport(userID, req.StreamID, chat, rpt, connInfo.Config.Type, *chat.Connection.CurrentSchema)
+	return &response, http.StatusAccepted, nil
+}
+
+// ListReports returns the chat's reports without their Markdown, newest first
+func (s *chatService) ListReports(ctx context.Context, userID, chatID string) ([]dtos.ReportResponse, uint32, error) {
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.reportRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("reports are not available")
+	}
+
+	reports, err := s.reportRepo.FindByChatID(ctx, chat.ID)
+	if err != nil {
+		log.Printf("ChatService -> ListReports -> Error: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to list reports")
+	}
+
+	responses := make([]dtos.ReportResponse, 0, len(reports))
+	for _, rpt := range reports {
+		responses = append(responses, buildReportResponse(rpt))
+	}
+	return responses, http.StatusOK, nil
+}
+
+// GetReport returns a report with its Markdown
+func (s *chatService) GetReport(ctx context.Context, userID, chatID, reportID string) (*dtos.ReportResponse, uint32, error) {
+	rpt, status, err := s.findChatReport(ctx, userID, chatID, reportID)
+	if err != nil {
+		return nil, status, err
+	}
+	response := buildReportResponse(rpt)
+	return &response, http.StatusOK, nil
+}
+
+// GetReportPDF renders a completed report as a PDF
+func (s *chatService) GetReportPDF(ctx context.Context, userID, chatID, reportID string) (*dtos.ReportPDF, uint32, error) {
+	rpt, status, err := s.findChatReport(ctx, userID, chatID, reportID)
+	if err != nil {
+		return nil, status, err
+	}
+	if rpt.Status != constants.ReportStatusCompleted {
+		return nil, http.StatusConflict, fmt.Errorf("the report is %s, only completed reports can be downloaded", rpt.Status)
+	}
+
+	content, err := report.RenderMarkdownPDF(rpt.Title, rpt.Markdown)
+	if err != nil {
+		log.Printf("ChatService -> GetReportPDF -> Error rendering report %s: %v", reportID, err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to render the report as PDF")
+	}
+
+	fileName := strings.Trim(reportFileNamePattern.ReplaceAllString(strings.ToLower(rpt.Title), "-"), "-")
+	if fileName == "" {
+		fileName = "report"
+	}
+	return &dtos.ReportPDF{FileName: fileName + ".pdf", Content: content}, http.StatusOK, nil
+}
+
+func (s *chatService) findChatReport(ctx context.Context, userID, chatID, reportID string) (*models.Report, uint32, error) {
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.reportRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("reports are not available")
+	}
+
+	reportObjID, err := primitive.ObjectIDFromHex(reportID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid report ID")
+	}
+	rpt, err := s.reportRepo.FindByID(ctx, reportObjID)
+	if err != nil {
+		log.Printf("ChatService -> findChatReport -> Error: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch report")
+	}
+	if rpt == nil || rpt.ChatID != chat.ID {
+		return nil, http.StatusNotFound, fmt.Errorf("report not found")
+	}
+	return rpt, http.StatusOK, nil
+}
+
+// runReport answers the sections one after the other, to stay clear of LLM rate limits, then writes the report.
+// When the LLM can't write it, the report is made of the sections' own Markdown.
+func (s *chatService) runReport(userID, streamID string, chat *models.Chat, rpt *models.Report, dbType, schema string) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.ReportTimeoutMinutes*time.Minute)
+	defer cancel()
+
+	chatID := chat.ID.Hex()
+	reportID := rpt.ID.Hex()
+	llmSections := make([]string, 0, len(rpt.Sections))
+
+	for i := range rpt.Sections {
+		section := &rpt.Sections[i]
+		rows := s.runReportSection(ctx, chatID, fmt.Sprintf("report-%s-%d", reportID, i), dbType, schema, rpt.Model, section)
+		llmSections = append(llmSections, formatReportSectionForLLM(i, section, rows))
+
+		if err := s.reportRepo.Update(ctx, rpt); err != nil {
+			log.Printf("ChatService -> runReport -> Error saving section %d of report %s: %v", i+1, reportID, err)
+		}
+		s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
+			Event: constants.StreamEventReportSection,
+			Data: dtos.ReportSectionEvent{
+				ReportID: reportID,
+				Index:    i,
+				Total:    len(rpt.Sections),
+				Section:  *section,
+			},
+		})
+	}
+
+	if ctx.Err() != nil {
+		errorMsg := "the report took too long to generate"
+		rpt.Status = constants.ReportStatusFailed
+		rpt.Error = &errorMsg
+		s.finishReport(userID, chatID, streamID, rpt, constants.StreamEventReportFailed)
+		return
+	}
+
+	markdown, err := s.writeReport(ctx, chat, rpt.Title, llmSections)
+	if err != nil {
+		log.Printf("ChatService -> runReport -> Error writing report %s, using the sections as is: %v", reportID, err)
+		parts := []string{"# " + rpt.Title}
+		for _, section := range rpt.Sections {
+			parts = append(parts, section.Markdown)
+		}
+		markdown = strings.Join(parts, "\n\n")
+	}
+	rpt.Markdown = markdown
+	rpt.Status = constants.ReportStatusCompleted
+	s.finishReport(userID, chatID, streamID, rpt, constants.StreamEventReportCompleted)
+	log.Printf("ChatService -> runReport -> Completed report %s with %d sections", reportID, len(rpt.Sections))
+}
+
+func (s *chatService) finishReport(userID, chatID, streamID string, rpt *models.Report, event string) {
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.reportRepo.Update(saveCtx, rpt); err != nil {
+		log.Printf("ChatService -> finishReport -> Error saving report %s: %v", rpt.ID.Hex(), err)
+	}
+	s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
+		Event: event,
+		Data: dtos.ReportCompletedEvent{
+			ReportID: rpt.ID.Hex(),
+			Report:   rpt.Markdown,
+			Error:    rpt.Error,
+		},
+	})
+}
+
+// runReportSection generates queries for the section's question and runs the first read-only one, the others
+// are left out. The section is filled in with the query, its outcome and Markdown, and the result rows returned.
+func (s *chatService) runReportSection(ctx context.Context, chatID, streamID, dbType, schema, modelID string, section *models.ReportSection) []map[string]interface{} {
+	fail := func(errorMsg string) []map[string]interface{} {
+		section.Error = &errorMsg
+		section.Markdown = fmt.Sprintf("## %s\n\n*%s*\n\nThe data for this section is not available: %s", section.Heading, section.Question, errorMsg)
+		return nil
+	}
+
+	llmResponse, err := s.generateMagicQuery(ctx, dbType, schema, section.Question, modelID)
+	if err != nil {
+		log.Printf("ChatService -> runReportSection -> Error generating query: %v", err)
+		return fail("no query could be generated for this question")
+	}
+
+	for _, query := range llmResponse.Queries {
+		queryText := strings.TrimSpace(query.Query)
+		if queryText == "" || query.IsCritical || !constants.IsReadOnlyQuery(queryText, dbType) {
+			continue
+		}
+		section.Query = queryText
+		section.QueryType = query.QueryType
+		break
+	}
+	if section.Query == "" {
+		return fail("no read-only query answers this question")
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, constants.ReportQueryTimeoutSeconds*time.Second)
+	defer cancel()
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, chatID, "", "", streamID, section.Query, section.QueryType, false, false)
+	if queryErr != nil {
+		errorMsg := queryErr.Message
+		if queryErr.Details != "" {
+			errorMsg = fmt.Sprintf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return fail(errorMsg)
+	}
+
+	var rows []map[string]interface{}
+	if result != nil {
+		rows = extractResultRows(result.Result)
+	}
+	section.RowCount = len(rows)
+	section.Markdown = buildReportSectionMarkdown(section, rows)
+	return rows
+}
+
+// writeReport asks the LLM for the report's Markdown, with the chat's preferred model
+func (s *chatService) writeReport(ctx context.Context, chat *models.Chat, title string, sections []string) (string, error) {
+	llmClient := s.llmClient
+	modelID := ""
+	if chat.PreferredLLMModel != nil && *chat.PreferredLLMModel != "" {
+		modelID = *chat.PreferredLLMModel
+		if s.llmManager != nil {
+			if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+				if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+					llmClient = providerClient
+				}
+			}
+		}
+	}
+
+	if llmClient == nil {
+		return "", fmt.Errorf("no LLM client available")
+	}
+
+	response, err := llmClient.GenerateRawJSON(ctx, constants.ReportWriterPrompt, constants.GetReportWriterUserMessage(title, sections), modelID)
+	if err != nil {
+		return "", fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	var parsed struct {
+		Report string `json:"report"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse report JSON: %v", err)
+	}
+	if strings.TrimSpace(parsed.Report) == "" {
+		return "", fmt.Errorf("the LLM returned an empty report")
+	}
+	return parsed.Report, nil
+}
+
+// buildReportSectionMarkdown shows the section's result as a table of its first ReportSectionRowLimit rows
+func buildReportSectionMarkdown(section *models.ReportSection, rows []map[string]interface{}) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n*%s*\n\n", section.Heading, section.Question)
+	if len(rows) == 0 {
+		sb.WriteString("The query returned no rows.")
+		return sb.String()
+	}
+
+	columns := reportColumns(rows)
+	sb.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for i, row := range rows {
+		if i == constants.ReportSectionRowLimit {
+			break
+		}
+		cells := make([]string, len(columns))
+		for j, column := range columns {
+			cells[j] = reportCell(row[column])
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	if len(rows) > constants.ReportSectionRowLimit {
+		fmt.Fprintf(&sb, "\n*Showing %d of %d rows.*", constants.ReportSectionRowLimit, len(rows))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// formatReportSectionForLLM describes a section and its results to the LLM writing the report
+func formatReportSectionForLLM(index int, section *models.ReportSection, rows []map[string]interface{}) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Section %d\nHeading: %s\nQuestion: %s\n", index+1, section.Heading, section.Question)
+	if section.Query != "" {
+		fmt.Fprintf(&sb, "Query: %s\n", section.Query)
+	}
+	if section.Error != nil {
+		fmt.Fprintf(&sb, "Error: %s", *section.Error)
+		return sb.String()
+	}
+
+	shown := rows
+	if len(shown) > constants.ReportLLMRowLimit {
+		shown = shown[:constants.ReportLLMRowLimit]
+	}
+	data, err := json.Marshal(shown)
+	if err != nil {
+		data = []byte("[]")
+	}
+	fmt.Fprintf(&sb, "Results (%d rows, %d shown): %s", len(rows), len(shown), data)
+	return sb.String()
+}
+
+// reportColumns returns the columns of the rows, sorted since rows carry no column order
+func reportColumns(rows []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func reportCell(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		text = v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		text = string(data)
+	default:
+		text = fmt.Sprintf("%v", v)
+	}
+	text = strings.NewReplacer("|", "\\|", "\r", " ", "\n", " ").Replace(text)
+	if runes := []rune(text); len(runes) > 80 {
+		text = string(runes[:77]) + "..."
+	}
+	return text
+}
+
+func buildReportResponse(rpt *models.Report) dtos.ReportResponse {
+	// A copy, the sections of a generating report are filled in by runReport
+	sections := append([]models.ReportSection{}, rpt.Sections...)
+	return dtos.ReportResponse{
+		ID:        rpt.ID.Hex(),
+		ChatID:    rpt.ChatID.Hex(),
+		Title:     rpt.Title,
+		Status:    rpt.Status,
+		Sections:  sections,
+		Report:    rpt.Markdown,
+		Error:     rpt.Error,
+		Model:     rpt.Model,
+		CreatedAt: rpt.CreatedAt,
+		UpdatedAt: rpt.UpdatedAt,
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetRollbackHistory returns a page of the chat's rollback attempts, most recent first
+func (s *chatService) GetRollbackHistory(ctx context.Context, userID, chatID string, req *dtos.RollbackHistoryRequest) (*dtos.RollbackHistoryResponse, uint32, error) {
+	log.Printf("ChatService -> GetRollbackHistory -> userID: %s, chatID: %s, page: %d, pageSize: %d", userID, chatID, req.Page, req.PageSize)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = constants.RollbackHistoryDefaultPageSize
+	}
+	if pageSize > constants.RollbackHistoryMaxPageSize {
+		pageSize = constants.RollbackHistoryMaxPageSize
+	}
+
+	records, total, err := s.rollbackRepo.FindByChatID(ctx, chat.ID, page, pageSize)
+	if err != nil {
+		log.Printf("ChatService -> GetRollbackHistory -> Error finding rollback records: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch rollback history: %v", err)
+	}
+
+	response := &dtos.RollbackHistoryResponse{
+		Records:  make([]dtos.RollbackRecordResponse, 0, len(records)),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for _, record := range records {
+		response.Records = append(response.Records, dtos.RollbackRecordResponse{
+			ID:               record.ID.Hex(),
+			MessageID:        record.MessageID.Hex(),
+			QueryID:          record.QueryID.Hex(),
+			Step:             record.Step,
+			RollbackQuery:    record.RollbackQuery,
+			Success:          record.Success,
+			Error:            record.Error,
+			DependentQueryID: objectIDHexPtr(record.DependentQueryID),
+			ExecutedAt:       record.ExecutedAt.Format(time.RFC3339),
+		})
+	}
+	return response, http.StatusOK, nil
+}
+
+// recordRollbackStep stores the audit record of a rollback step and returns its ID, nil when it couldn't be stored.
+// A failure to record is logged and doesn't affect the rollback itself.
+func (s *chatService) recordRollbackStep(chat *models.Chat, msg *models.Message, query *models.Query, step, stepQuery string, errorMsg *string, dependentQueryID *primitive.ObjectID) *primitive.ObjectID {
+	if s.rollbackRepo == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RollbackRecordTimeoutSeconds*time.Second)
+	defer cancel()
+
+	record := models.NewRollbackRecord(chat.ID, chat.UserID, msg.ID, query.ID, step, stepQuery, errorMsg, dependentQueryID)
+	if err := s.rollbackRepo.Create(ctx, record); err != nil {
+		log.Printf("ChatService -> RollbackQuery -> Error recording %s step of query %s: %v", step, query.ID.Hex(), err)
+		return nil
+	}
+
+	if step == constants.RollbackStepRollback {
+		count, err := s.rollbackRepo.CountRollbacksByMessageID(ctx, msg.ID)
+		if err != nil {
+			log.Printf("ChatService -> RollbackQuery -> Error counting rollbacks of message %s: %v", msg.ID.Hex(), err)
+		} else {
+			msg.RollbackCount = int(count)
+			if err := s.chatRepo.UpdateMessageRollbackCount(msg); err != nil {
+				log.Printf("ChatService -> RollbackQuery -> Error updating rollback count of message %s: %v", msg.ID.Hex(), err)
+			}
+		}
+	}
+	return &record.ID
+}
+
+// recordRollbackOutcome records the rollback step of a RollbackQuery call from what it returned. The rollback
+// succeeded when the query was rolled back without error, an execution error is returned in the response.
+func (s *chatService) recordRollbackOutcome(chat *models.Chat, msg *models.Message, query *models.Query, dependentQueryID *primitive.ObjectID, response *dtos.QueryExecutionResponse, err error) {
+	var errorMsg *string
+	switch {
+	case err != nil:
+		errorMsg = utils.StringPtr(err.Error())
+	case response == nil:
+		errorMsg = utils.StringPtr("rollback returned no result")
+	case response.Error != nil:
+		errorMsg = queryErrorMessage(response.Error)
+	case !response.IsRolledBack:
+		errorMsg = utils.StringPtr("query was not rolled back")
+	}
+
+	rollbackQuery := ""
+	if query.RollbackQuery != nil {
+		rollbackQuery = *query.RollbackQuery
+	}
+	s.recordRollbackStep(chat, msg, query, constants.RollbackStepRollback, rollbackQuery, errorMsg, dependentQueryID)
+}
+
+// queryErrorMessage formats a query error for a rollback record, nil when there is none
+func queryErrorMessage(queryErr *dtos.QueryError) *string {
+	if queryErr == nil {
+		return nil
+	}
+	if queryErr.Details != "" {
+		return utils.StringPtr(fmt.Sprintf("%s: %s", queryErr.Message, queryErr.Details))
+	}
+	return utils.StringPtr(queryErr.Message)
+}
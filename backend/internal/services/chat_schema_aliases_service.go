@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/models"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// UpdateSchemaAliases replaces the business names the LLM sees instead of a chat's table and column names.
+// The aliases are checked against the stored schema, the formatted schema is rebuilt with them in the background
+// and queries written with them are mapped back to the real names before they run.
+func (s *chatService) UpdateSchemaAliases(ctx context.Context, userID, chatID string, req *dtos.SchemaAliases) (*dtos.ChatResponse, uint32, error) {
+	log.Printf("ChatService -> UpdateSchemaAliases -> userID: %s, chatID: %s, tables: %d, columns: %d", userID, chatID, len(req.TableAliases), len(req.ColumnAliases))
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	aliases := &models.SchemaAliases{
+		TableAliases:  trimSchemaAliases(req.TableAliases),
+		ColumnAliases: trimSchemaAliases(req.ColumnAliases),
+	}
+	if len(aliases.TableAliases) == 0 && len(aliases.ColumnAliases) == 0 {
+		aliases = nil
+	}
+
+	if aliases != nil {
+		if !dbmanager.SupportsSchemaAliases(chat.Connection.Type) {
+			return nil, http.StatusBadRequest, fmt.Errorf("schema aliases are not supported for %s", chat.Connection.Type)
+		}
+		schema, err := s.dbManager.GetSchemaManager().GetStoredSchemaInfo(ctx, chatID)
+		if err != nil {
+			log.Printf("ChatService -> UpdateSchemaAliases -> Error getting stored schema: %v", err)
+			return nil, http.StatusBadRequest, fmt.Errorf("the database schema is not available yet, connect to the database first")
+		}
+		if err := dbmanager.ValidateSchemaAliases(schema, dbSchemaAliases(aliases)); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+
+	chat.Settings.SchemaAliases = aliases
+	chat.UpdatedAt = time.Now()
+	if err := s.chatRepo.Update(chat.ID, chat); err != nil {
+		log.Printf("ChatService -> UpdateSchemaAliases -> Error updating chat: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update schema aliases: %v", err)
+	}
+
+	s.dbManager.SetSchemaAliases(chatID, dbSchemaAliases(aliases))
+	if _, connected := s.dbManager.GetConnectionInfo(chatID); connected {
+		// The cached schema still shows the previous names
+		go s.refreshFormattedSchema(chat)
+	}
+
+	return s.buildChatResponse(chat), http.StatusOK, nil
+}
+
+// trimSchemaAliases drops the entries of an alias map without a name or an alias
+func trimSchemaAliases(aliases map[string]string) map[string]string {
+	trimmed := make(map[string]string, len(aliases))
+	for name, alias := range aliases {
+		name, alias = strings.TrimSpace(name), strings.TrimSpace(alias)
+		if name != "" && alias != "" {
+			trimmed[name] = alias
+		}
+	}
+	if len(trimmed) == 0 {
+		return nil
+	}
+	return trimmed
+}
+
+// dbSchemaAliases converts a chat's schema aliases for the connection config
+func dbSchemaAliases(aliases *models.SchemaAliases) *dbmanager.SchemaAliases {
+	if aliases == nil {
+		return nil
+	}
+	return &dbmanager.SchemaAliases{
+		Tables:  aliases.TableAliases,
+		Columns: aliases.ColumnAliases,
+	}
+}
+
+// schemaAliasesResponse returns a chat's schema aliases for the API, nil when it has none
+func schemaAliasesResponse(aliases *models.SchemaAliases) *dtos.SchemaAliases {
+	if aliases == nil {
+		return nil
+	}
+	return &dtos.SchemaAliases{
+		TableAliases:  aliases.TableAliases,
+		ColumnAliases: aliases.ColumnAliases,
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/dbmanager"
+	"strings"
+)
+
+// notifySchemaBreakingChanges streams the breaking changes of a schema refresh and keeps a warning
+// for the chat's next AI response, so queries written against the old schema don't fail silently
+func (s *chatService) notifySchemaBreakingChanges(ctx context.Context, userID, chatID, streamID string, changes []dbmanager.BreakingChange) {
+	warning := describeSchemaBreakingChanges(changes)
+	log.Printf("ChatService -> notifySchemaBreakingChanges -> chatID: %s, %d breaking changes", chatID, len(changes))
+
+	s.sendStreamEvent(userID, chatID, streamID, dtos.StreamResponse{
+		Event: constants.StreamEventSchemaBreakingChange,
+		Data: map[string]interface{}{
+			"chat_id":          chatID,
+			"breaking_changes": changes,
+			"message":          warning,
+		},
+	})
+
+	if s.redisRepo == nil {
+		return
+	}
+	if err := s.redisRepo.Set(constants.GetSchemaBreakingChangeWarningKey(chatID), []byte(warning), constants.SchemaBreakingChangeWarningTTL, ctx); err != nil {
+		log.Printf("ChatService -> notifySchemaBreakingChanges -> Error storing warning: %v", err)
+	}
+}
+
+// takeSchemaBreakingChangeWarning returns the pending breaking change warning of a chat and clears it,
+// so it is shown on one AI response only
+func (s *chatService) takeSchemaBreakingChangeWarning(ctx context.Context, chatID string) string {
+	if s.redisRepo == nil {
+		return ""
+	}
+	key := constants.GetSchemaBreakingChangeWarningKey(chatID)
+	warning, err := s.redisRepo.Get(key, ctx)
+	if err != nil || warning == "" {
+		return ""
+	}
+	if err := s.redisRepo.Del(key, ctx); err != nil {
+		log.Printf("ChatService -> takeSchemaBreakingChangeWarning -> Error clearing warning: %v", err)
+	}
+	return warning
+}
+
+// describeSchemaBreakingChanges writes one warning line per breaking change
+func describeSchemaBreakingChanges(changes []dbmanager.BreakingChange) string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		switch {
+		case change.Column == "":
+			lines = append(lines, fmt.Sprintf("⚠️ WARNING: Table `%s` was dropped from the schema. Previous queries referencing this table will fail.", change.Table))
+		case change.ChangeType == dbmanager.BreakingChangeRenamed:
+			lines = append(lines, fmt.Sprintf("⚠️ WARNING: Column `%s.%s` appears to have been renamed to `%s.%s`. Previous queries referencing the old name will fail.",
+				change.Table, change.OldValue, change.Table, change.NewValue))
+		case change.ChangeType == dbmanager.BreakingChangeTypeChanged:
+			lines = append(lines, fmt.Sprintf("⚠️ WARNING: Column `%s.%s` changed type from `%s` to `%s`. Previous queries comparing or casting this column may fail or behave differently.",
+				change.Table, change.Column, change.OldValue, change.NewValue))
+		default:
+			lines = append(lines, fmt.Sprintf("⚠️ WARNING: Column `%s.%s` was dropped from the schema. Previous queries referencing this column will fail.", change.Table, change.Column))
+		}
+	}
+	return strings.Join(lines, "\n\n")
+}
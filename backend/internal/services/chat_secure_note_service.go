@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateSecureNote stores an encrypted note about the chat's connection
+func (s *chatService) CreateSecureNote(ctx context.Context, userID, chatID string, req *dtos.CreateSecureNoteRequest) (*dtos.SecureNoteResponse, uint32, error) {
+	log.Printf("ChatService -> CreateSecureNote -> userID: %s, chatID: %s", userID, chatID)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.secureNoteRepo == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("secure notes are not available")
+	}
+	// Notes must never be stored in plain text
+	if s.crypto == nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("encryption is not configured, secure notes cannot be stored")
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("content is required")
+	}
+
+	existing, err := s.secureNoteRepo.FindByChatID(ctx, chat.ID, false)
+	if err != nil {
+		log.Printf("ChatService -> CreateSecureNote -> Error counting notes: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create secure note")
+	}
+	if len(existing) >= constants.SecureNotesMaxPerChat {
+		return nil, http.StatusBadRequest, fmt.Errorf("a chat can have at most %d secure notes", constants.SecureNotesMaxPerChat)
+	}
+
+	encrypted, err := s.crypto.EncryptField(content)
+	if err != nil {
+		log.Printf("ChatService -> CreateSecureNote -> Error encrypting note: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to encrypt secure note")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	note := models.NewSecureNote(chat.ID, chat.UserID, encrypted, isActive)
+	if err := s.secureNoteRepo.Create(ctx, note); err != nil {
+		log.Printf("ChatService -> CreateSecureNote -> Error creating note: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create secure note")
+	}
+
+	return buildSecureNoteResponse(note, content), http.StatusCreated, nil
+}
+
+// ListSecureNotes returns the decrypted notes of a chat to its owner
+func (s *chatService) ListSecureNotes(ctx context.Context, userID, chatID string) ([]dtos.SecureNoteResponse, uint32, error) {
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if s.secureNoteRepo == nil {
+		return []dtos.SecureNoteResponse{}, http.StatusOK, nil
+	}
+
+	notes, err := s.secureNoteRepo.FindByChatID(ctx, chat.ID, false)
+	if err != nil {
+		log.Printf("ChatService -> ListSecureNotes -> Error fetching notes: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch secure notes")
+	}
+
+	response := make([]dtos.SecureNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		content, err := s.decryptSecureNote(note)
+		if err != nil {
+			log.Printf("ChatService -> ListSecureNotes -> Error decrypting note %s: %v", note.ID.Hex(), err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decrypt secure notes")
+		}
+		response = append(response, *buildSecureNoteResponse(note, content))
+	}
+	return response, http.StatusOK, nil
+}
+
+// buildSecureNotesContext lists the chat's active notes for the system LLM message, or returns "" when there are none
+func (s *chatService) buildSecureNotesContext(ctx context.Context, chat *models.Chat) string {
+	if s.secureNoteRepo == nil {
+		return ""
+	}
+
+	notes, err := s.secureNoteRepo.FindByChatID(ctx, chat.ID, true)
+	if err != nil {
+		log.Printf("ChatService -> buildSecureNotesContext -> Error fetching notes for chat %s: %v", chat.ID.Hex(), err)
+		return ""
+	}
+
+	var builder strings.Builder
+	for _, note := range notes {
+		content, err := s.decryptSecureNote(note)
+		if err != nil {
+			log.Printf("ChatService -> buildSecureNotesContext -> Skipping note %s: %v", note.ID.Hex(), err)
+			continue
+		}
+		builder.WriteString("- ")
+		builder.WriteString(content)
+		builder.WriteString("\n")
+	}
+	if builder.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\n%s\n%s", constants.SecureNotesContextHeader, builder.String())
+}
+
+func (s *chatService) decryptSecureNote(note *models.SecureNote) (string, error) {
+	if s.crypto == nil {
+		return "", fmt.Errorf("encryption is not configured")
+	}
+	return s.crypto.DecryptField(note.Content)
+}
+
+// findOwnedChat loads a chat and checks that it belongs to the user
+func (s *chatService) findOwnedChat(userID, chatID string) (*models.Chat, uint32, error) {
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+	return chat, http.StatusOK, nil
+}
+
+func buildSecureNoteResponse(note *models.SecureNote, content string) *dtos.SecureNoteResponse {
+	return &dtos.SecureNoteResponse{
+		ID:        note.ID.Hex(),
+		ChatID:    note.ChatID.Hex(),
+		Content:   content,
+		IsActive:  note.IsActive,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// similarQuestion is a past user message matched against a new question
+type similarQuestion struct {
+	messageID  primitive.ObjectID
+	question   string
+	similarity float64
+}
+
+// FindSimilarQueries returns the queries generated for past questions of the chat that resemble the given one.
+// Questions are compared by the cosine similarity of their embeddings when the RAG pipeline is available,
+// otherwise (or when no message of the chat is vectorized yet) with a MongoDB text search.
+func (s *chatService) FindSimilarQueries(ctx context.Context, userID, chatID string, req *dtos.SimilarQueryRequest) (*dtos.SimilarQueryResponse, uint32, error) {
+	log.Printf("ChatService -> FindSimilarQueries -> userID: %s, chatID: %s, topK: %d", userID, chatID, req.TopK)
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("question is required")
+	}
+	topK := req.TopK
+	if topK <= 0 {
+		topK = constants.SimilarQueryDefaultTopK
+	} else if topK > constants.SimilarQueryMaxTopK {
+		topK = constants.SimilarQueryMaxTopK
+	}
+
+	searchMethod := constants.SimilarQuerySearchSemantic
+	matches, err := s.findSimilarQuestionsSemantic(ctx, chatID, question, topK*constants.SimilarQueryOverfetch)
+	if err != nil {
+		log.Printf("ChatService -> FindSimilarQueries -> Semantic search failed, falling back to full-text search: %v", err)
+	}
+	if len(matches) == 0 {
+		searchMethod = constants.SimilarQuerySearchFulltext
+		matches, err = s.findSimilarQuestionsFulltext(chat.ID, question, topK*constants.SimilarQueryOverfetch)
+		if err != nil {
+			log.Printf("ChatService -> FindSimilarQueries -> Full-text search failed: %v", err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to search past queries: %v", err)
+		}
+	}
+
+	results, err := s.similarQueriesForQuestions(chat.ID, matches, topK)
+	if err != nil {
+		log.Printf("ChatService -> FindSimilarQueries -> Error loading replies: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to load past queries: %v", err)
+	}
+
+	log.Printf("ChatService -> FindSimilarQueries -> Found %d similar queries with %s search", len(results), searchMethod)
+	return &dtos.SimilarQueryResponse{
+		Results:      results,
+		SearchMethod: searchMethod,
+	}, http.StatusOK, nil
+}
+
+// findSimilarQuestionsSemantic searches the vectorized user messages of the chat, nil when embeddings are not configured
+func (s *chatService) findSimilarQuestionsSemantic(ctx context.Context, chatID, question string, limit int) ([]similarQuestion, error) {
+	if s.vectorizationSvc == nil || !s.vectorizationSvc.IsAvailable(ctx) {
+		return nil, nil
+	}
+
+	hits, err := s.vectorizationSvc.SearchMessages(ctx, chatID, question, limit, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]similarQuestion, 0, len(hits))
+	for _, hit := range hits {
+		if role, _ := hit.Payload["role"].(string); role != "user" {
+			continue
+		}
+		messageID, _ := hit.Payload["message_id"].(string)
+		messageObjID, err := primitive.ObjectIDFromHex(messageID)
+		if err != nil {
+			continue
+		}
+		content, _ := hit.Payload["content"].(string)
+		matches = append(matches, similarQuestion{
+			messageID:  messageObjID,
+			question:   content,
+			similarity: float64(hit.Score), // The message collection uses cosine distance
+		})
+	}
+	return matches, nil
+}
+
+// findSimilarQuestionsFulltext searches the user messages of the chat with a MongoDB text search.
+// Text scores are unbounded, so they are scaled relative to the best match.
+func (s *chatService) findSimilarQuestionsFulltext(chatID primitive.ObjectID, question string, limit int) ([]similarQuestion, error) {
+	found, err := s.chatRepo.SearchMessagesByText(chatID, question, string(constants.MessageTypeUser), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]similarQuestion, 0, len(found))
+	for _, match := range found {
+		similarity := 0.0
+		if found[0].Score > 0 {
+			similarity = match.Score / found[0].Score
+		}
+		matches = append(matches, similarQuestion{
+			messageID:  match.ID,
+			question:   match.Content,
+			similarity: similarity,
+		})
+	}
+	return matches, nil
+}
+
+// similarQueriesForQuestions lists the queries of the assistant replies to the matched questions, best match first
+func (s *chatService) similarQueriesForQuestions(chatID primitive.ObjectID, matches []similarQuestion, topK int) ([]dtos.SimilarQuery, error) {
+	results := []dtos.SimilarQuery{}
+	if len(matches) == 0 {
+		return results, nil
+	}
+
+	questionIDs := make([]primitive.ObjectID, len(matches))
+	for i, match := range matches {
+		questionIDs[i] = match.messageID
+	}
+	replies, err := s.chatRepo.FindAssistantRepliesByUserMessageIDs(chatID, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	repliesByQuestion := make(map[primitive.ObjectID][]dtos.SimilarQuery, len(replies))
+	for _, reply := range replies {
+		if reply.UserMessageId == nil || reply.Queries == nil {
+			continue
+		}
+		for _, query := range *reply.Queries {
+			var executedAt *string
+			if query.IsExecuted {
+				executedAt = query.ActionAt
+			}
+			repliesByQuestion[*reply.UserMessageId] = append(repliesByQuestion[*reply.UserMessageId], dtos.SimilarQuery{
+				MessageID:  reply.ID.Hex(),
+				QueryID:    query.ID.Hex(),
+				Query:      query.Query,
+				ExecutedAt: executedAt,
+			})
+		}
+	}
+
+	for _, match := range matches {
+		for _, result := range repliesByQuestion[match.messageID] {
+			result.Question = match.question
+			result.Similarity = match.similarity
+			results = append(results, result)
+			if len(results) >= topK {
+				return results, nil
+			}
+		}
+	}
+	return results, nil
+}
@@ -5,16 +5,22 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"neobase-ai/internal/apis/dtos"
 	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/dbmanager"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // StoreSpreadsheetData stores CSV/Excel data in the spreadsheet database
-func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, columns []string, data [][]string, mergeStrategy string, mergeOptions MergeOptions) (*dtos.SpreadsheetUploadResponse, uint32, error) {
+// columnTypes optionally maps a column name to one of the supported override types (see normalizeSpreadsheetColumnType);
+// columns without an entry are stored as TEXT.
+// Rows are committed in batches and progress is streamed as import_progress events when streamID is set.
+func (s *chatService) StoreSpreadsheetData(userID, chatID, streamID, tableName string, columns []string, data [][]string, mergeStrategy string, mergeOptions MergeOptions, columnTypes map[string]string) (*dtos.SpreadsheetUploadResponse, uint32, error) {
 	log.Printf("ChatService -> StoreSpreadsheetData -> Starting for chatID: %s, table: %s, strategy: %s", chatID, tableName, mergeStrategy)
 
 	// Validate inputs
@@ -28,6 +34,12 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 		return nil, http.StatusBadRequest, fmt.Errorf("no data provided")
 	}
 
+	// Validate column type overrides up front so we fail before touching the table
+	resolvedTypes, err := resolveSpreadsheetColumnTypes(columnTypes)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
 	// Default merge strategy
 	if mergeStrategy == "" {
 		mergeStrategy = "replace"
@@ -39,8 +51,8 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 		return nil, http.StatusNotFound, fmt.Errorf("connection not found")
 	}
 
-	// Verify it's a spreadsheet connection
-	if connInfo.Config.Type != constants.DatabaseTypeSpreadsheet {
+	// Verify it's a spreadsheet connection, Google Sheets data is stored the same way when synced
+	if connInfo.Config.Type != constants.DatabaseTypeSpreadsheet && connInfo.Config.Type != constants.DatabaseTypeGoogleSheets {
 		return nil, http.StatusBadRequest, fmt.Errorf("connection is not a spreadsheet type")
 	}
 
@@ -56,6 +68,9 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 		schemaName = fmt.Sprintf("conn_%s", chatID)
 	}
 
+	tracker := s.newSpreadsheetImportTracker(userID, chatID, streamID, "", tableName)
+	tracker.setTotal(len(data))
+
 	// Check if table exists
 	tableExists := false
 	var existingRowCount int64
@@ -97,8 +112,11 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 			
 			// Execute merge
 			if err := mergeHandler.ExecuteMerge(columns, data, mergeOptions); err != nil {
-				return nil, http.StatusInternalServerError, fmt.Errorf("merge operation failed: %v", err)
+				err = fmt.Errorf("merge operation failed: %v", err)
+				tracker.fail(err)
+				return nil, http.StatusInternalServerError, err
 			}
+			tracker.addBatch(len(data))
 			
 			// Get final row count
 			finalCount := existingRowCount + int64(len(data))
@@ -141,20 +159,25 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 			}
 			log.Printf("ChatService -> StoreSpreadsheetData (merge) -> Completed schema refresh and database name update for chatID: %s", chatID)
 			
-			return &dtos.SpreadsheetUploadResponse{
+			response := &dtos.SpreadsheetUploadResponse{
 				TableName:   tableName,
 				RowCount:    int(finalCount),
 				ColumnCount: len(columns),
 				SizeBytes:   sizeBytes,
 				UploadedAt:  time.Now(),
-			}, http.StatusOK, nil
+			}
+			s.deleteImportMetadata(chatID)
+			tracker.complete(response)
+			return response, http.StatusOK, nil
 		}
 		
 		// Replace strategy - drop existing table
 		if mergeStrategy == "replace" {
 			dropQuery := fmt.Sprintf("DROP TABLE IF EXISTS %s.%s CASCADE", schemaName, tableName)
 			if err := conn.Exec(dropQuery); err != nil {
-				return nil, http.StatusInternalServerError, fmt.Errorf("failed to drop existing table: %v", err)
+				err = fmt.Errorf("failed to drop existing table: %v", err)
+				tracker.fail(err)
+				return nil, http.StatusInternalServerError, err
 			}
 			tableExists = false
 		}
@@ -170,7 +193,11 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 		
 		for _, col := range columns {
 			sanitizedCol := sanitizeColumnName(col)
-			columnDefs = append(columnDefs, fmt.Sprintf("%s TEXT", sanitizedCol))
+			colType := "TEXT"
+			if overrideType, ok := resolvedTypes[sanitizedCol]; ok {
+				colType = overrideType
+			}
+			columnDefs = append(columnDefs, fmt.Sprintf("%s %s", sanitizedCol, colType))
 		}
 
 		createTableQuery := fmt.Sprintf(
@@ -181,13 +208,20 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 		)
 
 		if err := conn.Exec(createTableQuery); err != nil {
-			return nil, http.StatusInternalServerError, fmt.Errorf("failed to create table: %v", err)
+			err = fmt.Errorf("failed to create table: %v", err)
+			tracker.fail(err)
+			return nil, http.StatusInternalServerError, err
 		}
 	}
 
-	// Insert data in batches
-	batchSize := 1000
+	// Insert data in batches, committing each batch on its own
+	batchSize := constants.SpreadsheetImportBatchSize
 	totalRows := len(data)
+
+	sanitizedColumns := make([]string, len(columns))
+	for i, col := range columns {
+		sanitizedColumns[i] = sanitizeColumnName(col)
+	}
 	
 	for i := 0; i < totalRows; i += batchSize {
 		end := i + batchSize
@@ -196,55 +230,37 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 		}
 
 		batch := data[i:end]
-		
-		// Build insert query
-		valueStrings := make([]string, 0, len(batch))
+
+		// Empty cells are stored as NULL; rows without any value are skipped
+		batchRows := make([][]interface{}, 0, len(batch))
 		for _, row := range batch {
-			values := make([]string, len(columns))
+			values := make([]interface{}, len(columns))
+			hasData := false
 			for j := range columns {
 				if j < len(row) && row[j] != "" {
-					// Escape single quotes
-					escapedVal := strings.ReplaceAll(row[j], "'", "''")
-					values[j] = fmt.Sprintf("'%s'", escapedVal)
-				} else {
-					// Use NULL for missing or empty values
-					values[j] = "NULL"
-				}
-			}
-			// Only add the row if it has at least one non-NULL value
-			hasData := false
-			for _, v := range values {
-				if v != "NULL" {
+					values[j] = row[j]
 					hasData = true
-					break
 				}
 			}
 			if hasData {
-				valueStrings = append(valueStrings, fmt.Sprintf("(%s)", strings.Join(values, ", ")))
+				batchRows = append(batchRows, values)
 			}
 		}
-		
+
 		// Skip this batch if no valid rows
-		if len(valueStrings) == 0 {
+		if len(batchRows) == 0 {
+			tracker.addBatch(len(batch))
 			continue
 		}
 
-		sanitizedColumns := make([]string, len(columns))
-		for i, col := range columns {
-			sanitizedColumns[i] = sanitizeColumnName(col)
-		}
-
-		insertQuery := fmt.Sprintf(
-			"INSERT INTO %s.%s (%s) VALUES %s",
-			schemaName,
-			tableName,
-			strings.Join(sanitizedColumns, ", "),
-			strings.Join(valueStrings, ", "),
-		)
-
-		if err := conn.Exec(insertQuery); err != nil {
-			return nil, http.StatusInternalServerError, fmt.Errorf("failed to insert data: %v", err)
+		// Large batches go through COPY, smaller ones through a batched INSERT
+		if _, err := s.dbManager.BulkInsertSpreadsheetRows(context.Background(), chatID, schemaName, tableName, sanitizedColumns, batchRows); err != nil {
+			err = fmt.Errorf("failed to insert data: %v", err)
+			tracker.addBatch(0, fmt.Sprintf("rows %d-%d: %v", i+1, end, err))
+			tracker.fail(err)
+			return nil, http.StatusInternalServerError, err
 		}
+		tracker.addBatch(len(batch))
 	}
 
 	// Get table size
@@ -274,13 +290,16 @@ func (s *chatService) StoreSpreadsheetData(userID, chatID, tableName string, col
 	}
 	log.Printf("ChatService -> StoreSpreadsheetData -> Completed schema refresh and database name update for chatID: %s", chatID)
 
-	return &dtos.SpreadsheetUploadResponse{
+	response := &dtos.SpreadsheetUploadResponse{
 		TableName:   tableName,
 		RowCount:    totalRows,
 		ColumnCount: len(columns),
 		SizeBytes:   sizeBytes,
 		UploadedAt:  time.Now(),
-	}, http.StatusOK, nil
+	}
+	s.deleteImportMetadata(chatID)
+	tracker.complete(response)
+	return response, http.StatusOK, nil
 }
 
 // GetSpreadsheetTableData retrieves paginated data from a spreadsheet table
@@ -813,6 +832,200 @@ func (s *chatService) DeleteSpreadsheetRow(userID, chatID, tableName, rowID stri
 	return http.StatusOK, nil
 }
 
+// UpdateSpreadsheetColumnType changes the type of a spreadsheet column, re-casting existing data in place.
+// If any value cannot be cast, the column is left untouched and the offending rows are returned with the error.
+func (s *chatService) UpdateSpreadsheetColumnType(ctx context.Context, userID, chatID, tableName, columnName, newType string) (*dtos.ColumnTypeUpdateResponse, uint32, error) {
+	log.Printf("ChatService -> UpdateSpreadsheetColumnType -> chatID: %s, table: %s, column: %s, newType: %s", chatID, tableName, columnName, newType)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	// Verify user owns the chat
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	normalizedType, err := normalizeSpreadsheetColumnType(newType)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	// Get connection info
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		return nil, http.StatusNotFound, fmt.Errorf("connection not found")
+	}
+
+	if connInfo.Config.Type != constants.DatabaseTypeSpreadsheet {
+		return nil, http.StatusBadRequest, fmt.Errorf("connection is not a spreadsheet type")
+	}
+
+	conn, err := s.dbManager.GetConnection(chatID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get database connection: %v", err)
+	}
+
+	schemaName := connInfo.Config.SchemaName
+	if schemaName == "" {
+		schemaName = fmt.Sprintf("conn_%s", chatID)
+	}
+
+	tableName = sanitizeColumnName(tableName)
+	columnName = sanitizeColumnName(columnName)
+	if tableName == "" || columnName == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("table name and column name are required")
+	}
+
+	// Internal bookkeeping columns must keep their types
+	if strings.HasPrefix(columnName, "_") {
+		return nil, http.StatusBadRequest, fmt.Errorf("column %s is managed internally and cannot be changed", columnName)
+	}
+
+	// Look up the current column type, which also confirms the column exists
+	typeQuery := fmt.Sprintf(`
+		SELECT data_type
+		FROM information_schema.columns
+		WHERE table_schema = '%s'
+		AND table_name = '%s'
+		AND column_name = '%s'
+	`, schemaName, tableName, columnName)
+
+	var typeRows []map[string]interface{}
+	if err := conn.QueryRows(typeQuery, &typeRows); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch column metadata: %v", err)
+	}
+	if len(typeRows) == 0 {
+		return nil, http.StatusNotFound, fmt.Errorf("column %s not found in table %s", columnName, tableName)
+	}
+	previousType, _ := typeRows[0]["data_type"].(string)
+
+	alterQuery := fmt.Sprintf(
+		"ALTER TABLE %s.%s ALTER COLUMN %s TYPE %s USING %s",
+		schemaName,
+		tableName,
+		columnName,
+		normalizedType,
+		spreadsheetCastExpression(columnName, normalizedType),
+	)
+
+	response := &dtos.ColumnTypeUpdateResponse{
+		TableName:    tableName,
+		ColumnName:   columnName,
+		PreviousType: previousType,
+		NewType:      normalizedType,
+	}
+
+	if err := conn.Exec(alterQuery); err != nil {
+		log.Printf("ChatService -> UpdateSpreadsheetColumnType -> Cast failed: %v", err)
+		// The ALTER runs as a single statement, so a failed cast leaves the column untouched.
+		// Collect the rows that block the conversion so the user can fix them.
+		response.FailedRows = s.findUncastableSpreadsheetRows(conn, schemaName, tableName, columnName, normalizedType)
+		return response, http.StatusUnprocessableEntity, fmt.Errorf("failed to convert column %s to %s: %v", columnName, normalizedType, err)
+	}
+
+	// Refresh the schema cache so the LLM sees the new column type
+	if _, err := s.RefreshSchema(ctx, userID, chatID, false); err != nil {
+		log.Printf("ChatService -> UpdateSpreadsheetColumnType -> Failed to refresh schema: %v", err)
+	}
+
+	return response, http.StatusOK, nil
+}
+
+// findUncastableSpreadsheetRows returns up to maxUncastableRows rows whose column value cannot be cast to targetType
+func (s *chatService) findUncastableSpreadsheetRows(conn dbmanager.DBExecutor, schemaName, tableName, columnName, targetType string) []map[string]interface{} {
+	const maxUncastableRows = 50
+
+	condition := spreadsheetUncastableCondition(columnName, targetType)
+	if condition == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf(
+		"SELECT * FROM %s.%s WHERE %s IS NOT NULL AND (%s) LIMIT %d",
+		schemaName,
+		tableName,
+		columnName,
+		condition,
+		maxUncastableRows,
+	)
+
+	var rows []map[string]interface{}
+	if err := conn.QueryRows(query, &rows); err != nil {
+		log.Printf("ChatService -> findUncastableSpreadsheetRows -> Failed to collect offending rows: %v", err)
+		return nil
+	}
+	return rows
+}
+
+var decimalTypePattern = regexp.MustCompile(`^DECIMAL\s*\(\s*(\d+)\s*,\s*(\d+)\s*\)$`)
+
+// normalizeSpreadsheetColumnType validates a user supplied column type and returns its canonical form.
+// Supported types: INTEGER, DECIMAL(p,s), TIMESTAMP, DATE, BOOLEAN.
+func normalizeSpreadsheetColumnType(colType string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(colType))
+	switch normalized {
+	case "INTEGER", "TIMESTAMP", "DATE", "BOOLEAN":
+		return normalized, nil
+	}
+
+	if matches := decimalTypePattern.FindStringSubmatch(normalized); matches != nil {
+		precision, _ := strconv.Atoi(matches[1])
+		scale, _ := strconv.Atoi(matches[2])
+		if precision < 1 || precision > 1000 {
+			return "", fmt.Errorf("decimal precision must be between 1 and 1000")
+		}
+		if scale > precision {
+			return "", fmt.Errorf("decimal scale cannot exceed precision")
+		}
+		return fmt.Sprintf("DECIMAL(%d,%d)", precision, scale), nil
+	}
+
+	return "", fmt.Errorf("unsupported column type %q, must be one of INTEGER, DECIMAL(p,s), TIMESTAMP, DATE, BOOLEAN", colType)
+}
+
+// resolveSpreadsheetColumnTypes validates column type overrides, keyed by the sanitized column name
+func resolveSpreadsheetColumnTypes(columnTypes map[string]string) (map[string]string, error) {
+	resolvedTypes := make(map[string]string, len(columnTypes))
+	for col, colType := range columnTypes {
+		normalizedType, err := normalizeSpreadsheetColumnType(colType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type for column %s: %v", col, err)
+		}
+		resolvedTypes[sanitizeColumnName(col)] = normalizedType
+	}
+	return resolvedTypes, nil
+}
+
+// spreadsheetCastExpression builds the USING expression for converting a column to targetType.
+// Values are trimmed and empty strings become NULL, since imported cells are often blank.
+func spreadsheetCastExpression(columnName, targetType string) string {
+	return fmt.Sprintf("NULLIF(TRIM(%s::text), '')::%s", columnName, targetType)
+}
+
+// spreadsheetUncastableCondition returns a SQL predicate matching values that cannot be cast to targetType
+func spreadsheetUncastableCondition(columnName, targetType string) string {
+	value := fmt.Sprintf("TRIM(%s::text)", columnName)
+	switch {
+	case targetType == "INTEGER":
+		return fmt.Sprintf("%s <> '' AND %s !~ '^[-+]?[0-9]+$'", value, value)
+	case strings.HasPrefix(targetType, "DECIMAL"):
+		return fmt.Sprintf("%s <> '' AND %s !~ '^[-+]?([0-9]+\\.?[0-9]*|\\.[0-9]+)([eE][-+]?[0-9]+)?$'", value, value)
+	case targetType == "BOOLEAN":
+		return fmt.Sprintf("%s <> '' AND LOWER(%s) NOT IN ('true', 'false', 't', 'f', 'yes', 'no', 'y', 'n', 'on', 'off', '1', '0')", value, value)
+	case targetType == "DATE" || targetType == "TIMESTAMP":
+		return fmt.Sprintf("%s <> '' AND %s !~ '^[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}([ T][0-9]{1,2}:[0-9]{2}(:[0-9]{2}(\\.[0-9]+)?)?)?([zZ]|[-+][0-9]{2}(:?[0-9]{2})?)?$'", value, value)
+	}
+	return ""
+}
+
 // sanitizeColumnName removes special characters from column names
 func sanitizeColumnName(name string) string {
 	// Replace spaces and special characters with underscores
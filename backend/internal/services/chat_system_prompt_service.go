@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/logger"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// UpdateSystemPrompt replaces the rules a chat appends to its system prompt, e.g. "amounts are stored in cents".
+// Rules containing a known injection phrase are rejected, the others are stored encrypted.
+func (s *chatService) UpdateSystemPrompt(ctx context.Context, userID, chatID string, req *dtos.UpdateSystemPromptRequest) (*dtos.ChatResponse, uint32, error) {
+	log.Printf("ChatService -> UpdateSystemPrompt -> userID: %s, chatID: %s", userID, chatID)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	rules := strings.TrimSpace(req.SystemPromptAppend)
+	if detected, pattern := promptInjectionDetector.Detect(rules); detected {
+		log.Printf("ChatService -> UpdateSystemPrompt -> Rejected the rules of chat %s, matched pattern: %s", chatID, pattern)
+		return nil, http.StatusBadRequest, fmt.Errorf("the system prompt rules contain disallowed instructions")
+	}
+
+	if rules == "" {
+		chat.Settings.SystemPromptAppend = nil
+	} else {
+		if s.crypto == nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("encryption is not configured, system prompt rules cannot be stored")
+		}
+		encrypted, err := s.crypto.EncryptField(rules)
+		if err != nil {
+			log.Printf("ChatService -> UpdateSystemPrompt -> Error encrypting rules: %v", err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to encrypt system prompt rules: %v", err)
+		}
+		chat.Settings.SystemPromptAppend = &encrypted
+	}
+
+	chat.UpdatedAt = time.Now()
+	if err := s.chatRepo.Update(chat.ID, chat); err != nil {
+		log.Printf("ChatService -> UpdateSystemPrompt -> Error updating chat: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update system prompt rules: %v", err)
+	}
+	logger.Debugf("ChatService -> UpdateSystemPrompt -> Chat %s now appends %d characters to its system prompt", chatID, len(rules))
+
+	return s.buildChatResponse(chat), http.StatusOK, nil
+}
+
+// systemPromptRulesForLLM returns the chat's rules encoded like user messages, or "" when it has none.
+// Rules stored before they were screened on update are dropped when they contain an injection phrase.
+func (s *chatService) systemPromptRulesForLLM(chat *models.Chat) string {
+	rules := s.decryptSystemPromptAppend(chat)
+	if rules == "" {
+		return ""
+	}
+	if detected, pattern := promptInjectionDetector.Detect(rules); detected {
+		log.Printf("ChatService -> systemPromptRulesForLLM -> Ignoring the rules of chat %s, matched pattern: %s", chat.ID.Hex(), pattern)
+		return ""
+	}
+	return utils.SanitizeLLMMessage(rules)
+}
+
+// decryptSystemPromptAppend returns the chat's system prompt rules in plain text, or "" when it has none
+func (s *chatService) decryptSystemPromptAppend(chat *models.Chat) string {
+	if chat.Settings.SystemPromptAppend == nil || *chat.Settings.SystemPromptAppend == "" {
+		return ""
+	}
+	if s.crypto == nil {
+		return ""
+	}
+	rules, err := s.crypto.DecryptField(*chat.Settings.SystemPromptAppend)
+	if err != nil {
+		log.Printf("ChatService -> decryptSystemPromptAppend -> Error decrypting rules of chat %s: %v", chat.ID.Hex(), err)
+		return ""
+	}
+	return rules
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetTablePreview returns sample rows of a table straight from the database, without an LLM round-trip.
+// The table must exist in the chat's schema and the query is built from its known columns, so no
+// user input reaches the query unquoted.
+func (s *chatService) GetTablePreview(ctx context.Context, userID, chatID, tableName string, limit int) (*dtos.TablePreviewResponse, uint32, error) {
+	log.Printf("ChatService -> GetTablePreview -> userID: %s, chatID: %s, table: %s, limit: %d", userID, chatID, tableName, limit)
+
+	chatObjID, err := primitive.ObjectIDFromHex(chatID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid chat ID")
+	}
+
+	chat, err := s.chatRepo.FindByID(chatObjID)
+	if err != nil || chat == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || chat.UserID != userObjID {
+		return nil, http.StatusForbidden, fmt.Errorf("unauthorized")
+	}
+
+	tableName = strings.TrimSpace(tableName)
+	if tableName == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("table name is required")
+	}
+	if limit <= 0 {
+		limit = constants.TablePreviewDefaultLimit
+	} else if limit > constants.TablePreviewMaxLimit {
+		limit = constants.TablePreviewMaxLimit
+	}
+
+	// Make sure we have a live connection
+	connInfo, exists := s.dbManager.GetConnectionInfo(chatID)
+	if !exists {
+		log.Printf("ChatService -> GetTablePreview -> Connection not found, creating new connection for chatID: %s", chatID)
+		if _, err := s.ConnectDB(ctx, userID, chatID, fmt.Sprintf("table-preview-%s", chatID)); err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("failed to connect to database: %v", err)
+		}
+		connInfo, exists = s.dbManager.GetConnectionInfo(chatID)
+		if !exists {
+			return nil, http.StatusInternalServerError, fmt.Errorf("connection created but not found in manager")
+		}
+	}
+	dbType := connInfo.Config.Type
+
+	dbConn, err := s.dbManager.GetConnection(chatID)
+	if err != nil {
+		log.Printf("ChatService -> GetTablePreview -> Error getting connection: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get database connection: %v", err)
+	}
+
+	schema, err := s.dbManager.GetSchemaManager().GetSchema(ctx, chatID, dbConn, dbType, []string{})
+	if err != nil {
+		log.Printf("ChatService -> GetTablePreview -> Error getting schema: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get schema: %v", err)
+	}
+
+	table, ok := schema.Tables[tableName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("table %s not found in the database schema", tableName)
+	}
+
+	columns := tablePreviewColumns(dbType, table)
+	query, err := buildTablePreviewQuery(dbType, tableName, table.Comment, columns, limit)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	log.Printf("ChatService -> GetTablePreview -> Preview query: %s", query)
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Duration(constants.TablePreviewQueryTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+	streamID := fmt.Sprintf("table-preview-%s-%d", chatID, startTime.UnixNano())
+	result, queryErr := s.dbManager.ExecuteQuery(queryCtx, chatID, "", "", streamID, query, "SELECT", false, false)
+	if queryErr != nil {
+		log.Printf("ChatService -> GetTablePreview -> Error executing preview query: %+v", queryErr)
+		errorMsg := queryErr.Message
+		if queryErr.Details != "" {
+			errorMsg = fmt.Sprintf("%s: %s", queryErr.Message, queryErr.Details)
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to preview table: %s", errorMsg)
+	}
+
+	rows := []map[string]interface{}{}
+	if result != nil {
+		if resultRows := extractResultRows(result.Result); resultRows != nil {
+			rows = resultRows
+		}
+	}
+
+	// Document databases have no fixed column list, report the fields the sampled rows actually have
+	if len(columns) == 0 {
+		columns = tablePreviewRowColumns(rows)
+	}
+
+	return &dtos.TablePreviewResponse{
+		TableName:       tableName,
+		Columns:         columns,
+		Rows:            rows,
+		Limit:           limit,
+		ExecutionTimeMs: time.Since(startTime).Milliseconds(),
+	}, http.StatusOK, nil
+}
+
+// tablePreviewColumns returns the sorted column names of a table as known from its schema.
+// MongoDB and Firestore documents, Temporal executions and NATS messages are previewed whole, and Airtable's record id is returned with every record.
+func tablePreviewColumns(dbType string, table dbmanager.TableSchema) []string {
+	if dbType == constants.DatabaseTypeMongoDB || dbType == constants.DatabaseTypeFerretDB || dbType == constants.DatabaseTypeTemporal ||
+		dbType == constants.DatabaseTypeNATS || dbType == constants.DatabaseTypeFirestore {
+		return nil
+	}
+	columns := make([]string, 0, len(table.Columns))
+	for name := range table.Columns {
+		if dbType == constants.DatabaseTypeAirtable && name == "id" {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// tablePreviewRowColumns collects the sorted field names of the returned rows
+func tablePreviewRowColumns(rows []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	columns := []string{}
+	for _, row := range rows {
+		for name := range row {
+			if !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// buildTablePreviewQuery builds a read-only query listing the given columns of a table, in the database's dialect.
+// The table comment tells Temporal workflow type tables from namespace tables.
+func buildTablePreviewQuery(dbType, tableName, tableComment string, columns []string, limit int) (string, error) {
+	switch dbType {
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		// The MongoDB driver splits the query on dots, so such collection names cannot be addressed this way
+		if strings.Contains(tableName, ".") {
+			return "", fmt.Errorf("collection %s cannot be previewed", tableName)
+		}
+		return fmt.Sprintf("db.%s.find({}).limit(%d)", tableName, limit), nil
+	case constants.DatabaseTypeAirtable:
+		options := map[string]interface{}{"maxRecords": limit}
+		if len(columns) > 0 {
+			options["fields"] = columns
+		}
+		tableJSON, _ := json.Marshal(tableName)
+		optionsJSON, _ := json.Marshal(options)
+		return fmt.Sprintf("base(%s).select(%s)", tableJSON, optionsJSON), nil
+	case constants.DatabaseTypeTemporal:
+		// Workflow type tables list executions of that type, namespace tables every execution of the namespace
+		return dbmanager.TemporalPreviewCommand(tableName, tableComment, limit), nil
+	case constants.DatabaseTypePocketBase:
+		options := map[string]interface{}{"perPage": limit}
+		if len(columns) > 0 {
+			options["fields"] = strings.Join(columns, ",")
+		}
+		optionsJSON, _ := json.Marshal(options)
+		return fmt.Sprintf("GET /api/collections/%s/records %s", url.PathEscape(tableName), optionsJSON), nil
+	case constants.DatabaseTypeNATS:
+		// Streams are previewed with their latest messages
+		return dbmanager.NATSPreviewQuery(tableName, limit), nil
+	case constants.DatabaseTypeFirestore:
+		// Collections are previewed with their first documents, the fields of documents differ
+		return dbmanager.FirestorePreviewQuery(tableName, limit), nil
+	}
+
+	if len(columns) == 0 {
+		return "", fmt.Errorf("table %s has no known columns", tableName)
+	}
+
+	quote := quotePreviewIdentifier
+	if dbType == constants.DatabaseTypeMySQL || dbType == constants.DatabaseTypeStarRocks ||
+		dbType == constants.DatabaseTypePlanetscale || dbType == constants.DatabaseTypeClickhouse {
+		quote = quotePreviewBacktickIdentifier
+	}
+
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = quote(column)
+	}
+	selectList := strings.Join(quotedColumns, ", ")
+
+	switch dbType {
+	case constants.DatabaseTypeOracle:
+		return fmt.Sprintf("SELECT %s FROM %s FETCH FIRST %d ROWS ONLY", selectList, quote(tableName), limit), nil
+	case constants.DatabaseTypeInfluxDB:
+		// Bound the time range so the preview does not read every file of the measurement
+		return fmt.Sprintf("SELECT %s FROM %s WHERE time > now() - INTERVAL '%s' ORDER BY time DESC LIMIT %d",
+			selectList, quote(tableName), constants.InfluxDBExampleRecordsWindow, limit), nil
+	default:
+		return fmt.Sprintf("SELECT %s FROM %s LIMIT %d", selectList, quote(tableName), limit), nil
+	}
+}
+
+// quotePreviewIdentifier double-quotes an identifier (PostgreSQL, Oracle, Trino, InfluxDB)
+func quotePreviewIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quotePreviewBacktickIdentifier backtick-quotes an identifier (MySQL, StarRocks, ClickHouse)
+func quotePreviewBacktickIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"neobase-ai/config"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/utils"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// chatTemplates are the seeded chat templates, in the order they are listed
+var chatTemplates = []models.ChatTemplate{
+	{
+		ID:           "ecommerce-analytics-postgresql",
+		Name:         "E-commerce Analytics (PostgreSQL)",
+		Description:  "Revenue, orders and customer insights for an online store.",
+		DatabaseType: constants.DatabaseTypePostgreSQL,
+		ExampleMessages: []string{
+			"What was the total revenue per month over the last 12 months?",
+			"Show the top 10 customers by lifetime order value",
+			"Which products are most often bought together?",
+			"What is the average order value by country?",
+		},
+		SchemaExample: `customers(id SERIAL PRIMARY KEY, name TEXT, email TEXT, country TEXT, created_at TIMESTAMPTZ)
+products(id SERIAL PRIMARY KEY, name TEXT, category TEXT, price NUMERIC(10,2))
+orders(id SERIAL PRIMARY KEY, customer_id INT REFERENCES customers(id), status TEXT, total NUMERIC(10,2), created_at TIMESTAMPTZ)
+order_items(id SERIAL PRIMARY KEY, order_id INT REFERENCES orders(id), product_id INT REFERENCES products(id), quantity INT, unit_price NUMERIC(10,2))`,
+	},
+	{
+		ID:           "user-behavior-mongodb",
+		Name:         "User Behavior (MongoDB)",
+		Description:  "Sessions, events and engagement of the users of an app.",
+		DatabaseType: constants.DatabaseTypeMongoDB,
+		ExampleMessages: []string{
+			"How many daily active users did we have in the last 30 days?",
+			"What are the 5 most common events per session?",
+			"Show the average session duration by device type",
+			"Which users signed up last week but never came back?",
+		},
+		SchemaExample: `users { _id: ObjectId, name: String, email: String, plan: String, signed_up_at: Date }
+sessions { _id: ObjectId, user_id: ObjectId, device: String, started_at: Date, ended_at: Date }
+events { _id: ObjectId, session_id: ObjectId, user_id: ObjectId, name: String, properties: Object, timestamp: Date }`,
+	},
+	{
+		ID:           "time-series-events-clickhouse",
+		Name:         "Time-series Events (ClickHouse)",
+		Description:  "High-volume page view and API request events over time.",
+		DatabaseType: constants.DatabaseTypeClickhouse,
+		ExampleMessages: []string{
+			"Show page views per hour for the last 24 hours",
+			"What is the p95 API latency per endpoint today?",
+			"Which countries had the biggest traffic growth this week compared to last week?",
+			"Show the error rate per minute over the last hour",
+		},
+		SchemaExample: `page_views(timestamp DateTime, user_id UInt64, url String, referrer String, country LowCardinality(String)) ENGINE = MergeTree ORDER BY timestamp
+api_requests(timestamp DateTime, endpoint LowCardinality(String), status_code UInt16, latency_ms UInt32) ENGINE = MergeTree ORDER BY (endpoint, timestamp)`,
+	},
+	{
+		ID:           "hr-reports-mysql",
+		Name:         "HR Reports (MySQL)",
+		Description:  "Headcount, salaries and attrition across departments.",
+		DatabaseType: constants.DatabaseTypeMySQL,
+		ExampleMessages: []string{
+			"What is the headcount per department?",
+			"Show the average salary by job title and department",
+			"Which employees joined in the last 90 days?",
+			"What was the attrition rate per quarter this year?",
+		},
+		SchemaExample: `departments(id INT PRIMARY KEY AUTO_INCREMENT, name VARCHAR(100), manager_id INT)
+employees(id INT PRIMARY KEY AUTO_INCREMENT, name VARCHAR(100), job_title VARCHAR(100), department_id INT, hired_at DATE, left_at DATE NULL)
+salaries(id INT PRIMARY KEY AUTO_INCREMENT, employee_id INT, amount DECIMAL(12,2), effective_from DATE)`,
+	},
+	{
+		ID:           "product-catalog-yugabytedb",
+		Name:         "Product Catalog (YugabyteDB)",
+		Description:  "Products, categories, inventory and prices across warehouses.",
+		DatabaseType: constants.DatabaseTypeYugabyteDB,
+		ExampleMessages: []string{
+			"How many products are in each category?",
+			"Which products are low on stock across all warehouses?",
+			"Show the price history of the 10 most expensive products",
+			"List the categories with no products in stock",
+		},
+		SchemaExample: `categories(id SERIAL PRIMARY KEY, name TEXT, parent_id INT REFERENCES categories(id))
+products(id SERIAL PRIMARY KEY, sku TEXT UNIQUE, name TEXT, category_id INT REFERENCES categories(id), price NUMERIC(10,2))
+inventory(product_id INT REFERENCES products(id), warehouse TEXT, quantity INT, PRIMARY KEY (product_id, warehouse))
+price_history(id SERIAL PRIMARY KEY, product_id INT REFERENCES products(id), price NUMERIC(10,2), changed_at TIMESTAMPTZ)`,
+	},
+}
+
+// exampleDBType returns the database type of the demo database configured from environment variables
+func exampleDBType() string {
+	if config.Env.ExampleDatabaseHost == "" {
+		return ""
+	}
+	if config.Env.ExampleDatabaseType == "postgres" {
+		return constants.DatabaseTypePostgreSQL
+	}
+	return config.Env.ExampleDatabaseType
+}
+
+// ListChatTemplates returns the seeded chat templates, flagging those the demo database can back
+func (s *chatService) ListChatTemplates() ([]models.ChatTemplate, uint32, error) {
+	demoType := exampleDBType()
+	templates := make([]models.ChatTemplate, len(chatTemplates))
+	for i, template := range chatTemplates {
+		template.IsExampleDB = template.DatabaseType == demoType
+		templates[i] = template
+	}
+	return templates, http.StatusOK, nil
+}
+
+// CreateChatFromTemplate creates a chat for a template and populates it with the template's example messages.
+// Templates backed by the demo database use it, other templates need a connection of the template's type.
+func (s *chatService) CreateChatFromTemplate(userID, templateID string, req *dtos.CreateChatFromTemplateRequest) (*dtos.ChatFromTemplateResponse, uint32, error) {
+	log.Printf("ChatService -> CreateChatFromTemplate -> userID: %s, templateID: %s", userID, templateID)
+
+	var template *models.ChatTemplate
+	for i := range chatTemplates {
+		if chatTemplates[i].ID == templateID {
+			template = &chatTemplates[i]
+			break
+		}
+	}
+	if template == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("chat template not found")
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	settings := req.Settings
+	if settings.AutoExecuteQuery == nil {
+		settings.AutoExecuteQuery = utils.TruePtr()
+	}
+
+	var chat *dtos.ChatResponse
+	var statusCode uint32
+	if req.Connection != nil {
+		if req.Connection.Type != template.DatabaseType {
+			return nil, http.StatusBadRequest, fmt.Errorf("the %s template needs a %s connection", template.Name, template.DatabaseType)
+		}
+		chat, statusCode, err = s.Create(userID, &dtos.CreateChatRequest{Connection: *req.Connection, Settings: settings})
+	} else {
+		if template.DatabaseType != exampleDBType() {
+			return nil, http.StatusBadRequest, fmt.Errorf("no demo database is configured for the %s template, please provide a %s connection", template.Name, template.DatabaseType)
+		}
+		chat, statusCode, err = s.CreateWithoutConnectionPing(userID, &dtos.CreateChatRequest{
+			Connection: dtos.CreateConnectionRequest{
+				Type:     template.DatabaseType,
+				Host:     config.Env.ExampleDatabaseHost,
+				Port:     utils.StringPtr(config.Env.ExampleDatabasePort),
+				Database: config.Env.ExampleDatabaseName,
+				Username: config.Env.ExampleDatabaseUsername,
+				Password: utils.StringPtr(config.Env.ExampleDatabasePassword),
+			},
+			Settings: settings,
+		})
+	}
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	chatObjID, err := primitive.ObjectIDFromHex(chat.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("invalid chat ID format")
+	}
+
+	// Save the example messages sequentially with increasing timestamps to keep their order, as Duplicate does
+	messages := make([]*dtos.MessageResponse, 0, len(template.ExampleMessages))
+	baseTime := time.Now()
+	for i, content := range template.ExampleMessages {
+		msg := models.NewMessage(userObjID, chatObjID, string(constants.MessageTypeUser), content, nil, nil)
+		msg.CreatedAt = baseTime.Add(time.Duration(i) * time.Second)
+		msg.UpdatedAt = msg.CreatedAt
+		if err := s.chatRepo.CreateMessage(msg); err != nil {
+			log.Printf("ChatService -> CreateChatFromTemplate -> Warning: failed to save example message: %v", err)
+			continue
+		}
+		messages = append(messages, s.buildMessageResponse(msg))
+	}
+
+	log.Printf("ChatService -> CreateChatFromTemplate -> Created chat %s from template %s with %d example messages", chat.ID, templateID, len(messages))
+	return &dtos.ChatFromTemplateResponse{
+		Chat:     chat,
+		Messages: messages,
+	}, http.StatusCreated, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GenerateTitle asks the LLM for a new chat title from the chat's first exchange, replacing the current title
+func (s *chatService) GenerateTitle(ctx context.Context, userID, chatID string) (*dtos.ChatResponse, uint32, error) {
+	log.Printf("ChatService -> GenerateTitle -> userID: %s, chatID: %s", userID, chatID)
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	// The first user message and its reply are among the oldest few, after any system messages
+	messages, err := s.chatRepo.FindOldestMessagesByChat(chat.ID, 10)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to fetch messages: %v", err)
+	}
+
+	var userMessage, assistantMessage string
+	for _, message := range messages {
+		if userMessage == "" && message.Type == string(constants.MessageTypeUser) {
+			userMessage = message.Content
+		} else if userMessage != "" && message.Type == string(constants.MessageTypeAssistant) {
+			assistantMessage = message.Content
+			break
+		}
+	}
+	if userMessage == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("the chat has no messages to generate a title from")
+	}
+
+	title, err := s.generateChatTitle(ctx, chat, userMessage, assistantMessage)
+	if err != nil {
+		log.Printf("ChatService -> GenerateTitle -> Error generating title: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to generate title: %v", err)
+	}
+
+	if _, err := s.chatRepo.UpdateTitle(ctx, chat.ID, title, false); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	chat.Title = &title
+
+	log.Printf("ChatService -> GenerateTitle -> Generated title: %s", title)
+	return s.buildChatResponse(chat), http.StatusOK, nil
+}
+
+// generateChatTitleInBackground names a chat after its first AI response. It runs on its own context
+// because the request that produced the response may already be done, and it never replaces a title.
+func (s *chatService) generateChatTitleInBackground(chat *models.Chat, userMessage, assistantMessage string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	title, err := s.generateChatTitle(ctx, chat, userMessage, assistantMessage)
+	if err != nil {
+		log.Printf("ChatService -> generateChatTitleInBackground -> chatID: %s, error: %v", chat.ID.Hex(), err)
+		return
+	}
+
+	saved, err := s.chatRepo.UpdateTitle(ctx, chat.ID, title, true)
+	if err != nil {
+		log.Printf("ChatService -> generateChatTitleInBackground -> Error saving title: %v", err)
+		return
+	}
+	if saved {
+		log.Printf("ChatService -> generateChatTitleInBackground -> chatID: %s, title: %s", chat.ID.Hex(), title)
+	}
+}
+
+// generateChatTitle asks the cheapest available model for a short title describing the exchange
+func (s *chatService) generateChatTitle(ctx context.Context, chat *models.Chat, userMessage, assistantMessage string) (string, error) {
+	llmClient := s.llmClient
+	modelID := ""
+	if chat.PreferredLLMModel != nil {
+		modelID = *chat.PreferredLLMModel
+	}
+	if titleModel := constants.GetChatTitleModel(); titleModel != nil && s.llmManager != nil {
+		if providerClient, err := s.llmManager.GetClient(titleModel.Provider); err == nil {
+			llmClient = providerClient
+			modelID = titleModel.ID
+		}
+	} else if modelID != "" && s.llmManager != nil {
+		if selectedModel := constants.GetLLMModel(modelID); selectedModel != nil {
+			if providerClient, err := s.llmManager.GetClient(selectedModel.Provider); err == nil {
+				llmClient = providerClient
+			}
+		}
+	}
+	if llmClient == nil {
+		return "", fmt.Errorf("no LLM client available")
+	}
+
+	userMessageContent := constants.GetChatTitleUserMessage(
+		chat.Connection.Type,
+		truncateForTitle(userMessage),
+		truncateForTitle(assistantMessage),
+	)
+	response, err := llmClient.GenerateRawJSON(ctx, constants.GeminiChatTitlePrompt, userMessageContent, modelID)
+	if err != nil {
+		return "", fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	var result struct {
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal([]byte(extractJSONFromText(response)), &result); err != nil {
+		return "", fmt.Errorf("failed to parse title: %v", err)
+	}
+
+	title := strings.Trim(strings.TrimSpace(result.Title), "\"'`.!")
+	if title == "" {
+		return "", fmt.Errorf("the LLM returned an empty title")
+	}
+	if runes := []rune(title); len(runes) > constants.ChatTitleMaxLength {
+		title = strings.TrimSpace(string(runes[:constants.ChatTitleMaxLength]))
+	}
+	return title, nil
+}
+
+// truncateForTitle keeps the start of a message, which is enough to tell what the chat is about
+func truncateForTitle(message string) string {
+	if runes := []rune(message); len(runes) > constants.ChatTitleMessageMaxLength {
+		return string(runes[:constants.ChatTitleMessageMaxLength]) + "..."
+	}
+	return message
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"neobase-ai/pkg/dbmanager"
+	"net/http"
+	"strings"
+)
+
+// readVaultCredentials reads the username and password stored in the Vault secret at path.
+// Paths outside VAULT_ALLOWED_PATH_PREFIX are rejected before anything is read from Vault.
+func (s *chatService) readVaultCredentials(userID, path string) (string, string, uint32, error) {
+	if s.vaultResolver == nil {
+		return "", "", http.StatusServiceUnavailable, fmt.Errorf("vault is not configured, set VAULT_ADDR to use a vault secret path")
+	}
+	if !s.vaultResolver.IsPathAllowed(userID, path) {
+		log.Printf("ChatService -> readVaultCredentials -> User %s requested vault secret %s outside the allowed prefix", userID, path)
+		return "", "", http.StatusForbidden, fmt.Errorf("vault secret path %s is not allowed", path)
+	}
+
+	secret, err := s.vaultResolver.ReadSecret(path)
+	if err != nil {
+		log.Printf("ChatService -> readVaultCredentials -> Error reading secret %s: %v", path, err)
+		return "", "", http.StatusBadRequest, fmt.Errorf("failed to read credentials from vault: %v", err)
+	}
+
+	username, ok := secret[constants.VaultUsernameKey]
+	if !ok {
+		return "", "", http.StatusBadRequest, fmt.Errorf("vault secret %s has no %s key", path, constants.VaultUsernameKey)
+	}
+	password, ok := secret[constants.VaultPasswordKey]
+	if !ok {
+		return "", "", http.StatusBadRequest, fmt.Errorf("vault secret %s has no %s key", path, constants.VaultPasswordKey)
+	}
+	return username, password, http.StatusOK, nil
+}
+
+// resolveVaultCredentials replaces the username and password of a connection request with the ones in its Vault secret,
+// so they are tested and encrypted like credentials entered in the connection form
+func (s *chatService) resolveVaultCredentials(userID string, req *dtos.CreateConnectionRequest) (uint32, error) {
+	if req == nil || req.VaultSecretPath == nil {
+		return http.StatusOK, nil
+	}
+
+	path := strings.TrimSpace(*req.VaultSecretPath)
+	if path == "" {
+		req.VaultSecretPath = nil
+		return http.StatusOK, nil
+	}
+	req.VaultSecretPath = &path
+
+	username, password, status, err := s.readVaultCredentials(userID, path)
+	if err != nil {
+		return status, err
+	}
+	req.Username = username
+	req.Password = &password
+
+	log.Printf("ChatService -> resolveVaultCredentials -> Resolved credentials from vault secret %s", path)
+	return http.StatusOK, nil
+}
+
+// ReResolveVaultCredentials reads the credentials of a chat's connection from its Vault secret again,
+// e.g. after they were rotated in Vault, and reconnects with them on the next query
+func (s *chatService) ReResolveVaultCredentials(ctx context.Context, userID, chatID string) (*dtos.ChatResponse, uint32, error) {
+	log.Printf("ChatService -> ReResolveVaultCredentials -> userID: %s, chatID: %s", userID, chatID)
+
+	chat, status, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, status, err
+	}
+	if chat.Connection.VaultSecretPath == nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("the connection of this chat does not use a vault secret path")
+	}
+
+	username, password, status, err := s.readVaultCredentials(userID, *chat.Connection.VaultSecretPath)
+	if err != nil {
+		return nil, status, err
+	}
+
+	connection := chat.Connection
+	utils.DecryptConnection(&connection)
+	connection.Username = &username
+	connection.Password = &password
+
+	if err := s.dbManager.TestConnection(&dbmanager.ConnectionConfig{
+		Type:                     connection.Type,
+		Host:                     connection.Host,
+		Port:                     connection.Port,
+		Username:                 connection.Username,
+		Password:                 connection.Password,
+		Database:                 connection.Database,
+		AuthDatabase:             connection.AuthDatabase,
+		UseSSL:                   connection.UseSSL,
+		SSLMode:                  connection.SSLMode,
+		SSLCertURL:               connection.SSLCertURL,
+		SSLKeyURL:                connection.SSLKeyURL,
+		SSLRootCertURL:           connection.SSLRootCertURL,
+		Catalog:                  connection.Catalog,
+		Schema:                   connection.Schema,
+		ServiceName:              connection.ServiceName,
+		AirtableAPIKey:           connection.AirtableAPIKey,
+		AirtableBaseID:           connection.AirtableBaseID,
+		PlanetscaleBranch:        connection.PlanetscaleBranch,
+		InfluxOrg:                connection.InfluxOrg,
+		InfluxToken:              connection.InfluxToken,
+		ReadPreference:           connection.ReadPreference,
+		TemporalNamespace:        connection.TemporalNamespace,
+		TemporalAddress:          connection.TemporalAddress,
+		NATSCredentialsFile:      connection.NATSCredentialsFile,
+		GoogleProjectID:          connection.GoogleProjectID,
+		FirestoreCredentialsJSON: connection.FirestoreCredentialsJSON,
+		CloudflareAccountID:      connection.CloudflareAccountID,
+		CloudflareD1DatabaseID:   connection.CloudflareD1DatabaseID,
+		CloudflareAPIToken:       connection.CloudflareAPIToken,
+	}); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%v", err)
+	}
+
+	if err := utils.EncryptConnection(&connection); err != nil {
+		log.Printf("ChatService -> ReResolveVaultCredentials -> Failed to encrypt connection details: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to secure connection details: %v", err)
+	}
+	chat.Connection = connection
+
+	if err := s.chatRepo.Update(chat.ID, chat); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to update chat: %v", err)
+	}
+
+	// Drop the connection opened with the old credentials, the next query reconnects with the new ones
+	if err := s.dbManager.Disconnect(chatID, userID, true); err != nil {
+		log.Printf("ChatService -> ReResolveVaultCredentials -> Warning: Failed to disconnect existing connection: %v", err)
+	}
+
+	return s.buildChatResponse(chat), http.StatusOK, nil
+}
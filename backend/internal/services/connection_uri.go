@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+)
+
+// applyConnectionURI parses req.ConnectionURI into the individual connection fields.
+// The raw URI is cleared afterwards so only the parsed fields are tested and stored.
+func applyConnectionURI(req *dtos.CreateConnectionRequest) error {
+	if req == nil || req.ConnectionURI == nil || strings.TrimSpace(*req.ConnectionURI) == "" {
+		return nil
+	}
+	rawURI := strings.TrimSpace(*req.ConnectionURI)
+	req.ConnectionURI = nil
+
+	schemes, ok := constants.ConnectionURISchemes[req.Type]
+	if !ok {
+		return fmt.Errorf("connection URIs are not supported for %s", req.Type)
+	}
+
+	parsed, err := url.Parse(rawURI)
+	if err != nil {
+		// url.Parse errors echo the input, which contains the password
+		return fmt.Errorf("invalid connection URI")
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if !containsString(schemes, scheme) {
+		return fmt.Errorf("connection URI scheme %q does not match data source type %s, expected one of: %s",
+			scheme, req.Type, strings.Join(schemes, ", "))
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("connection URI is missing a host")
+	}
+	if strings.Contains(parsed.Host, ",") {
+		return fmt.Errorf("connection URIs with multiple hosts are not supported, use the primary host")
+	}
+
+	host := parsed.Hostname()
+	if req.Type == constants.DatabaseTypeMongoDB && scheme == "mongodb+srv" && !strings.Contains(host, ".mongodb.net") {
+		return fmt.Errorf("mongodb+srv URIs are only supported for MongoDB Atlas hosts, use a mongodb:// URI instead")
+	}
+
+	req.Host = host
+	if port := parsed.Port(); port != "" {
+		req.Port = &port
+	} else {
+		req.Port = nil
+	}
+
+	if parsed.User != nil {
+		req.Username = parsed.User.Username()
+		if password, hasPassword := parsed.User.Password(); hasPassword {
+			req.Password = &password
+		}
+	}
+
+	if database := strings.Trim(parsed.Path, "/"); database != "" {
+		if req.Type == constants.DatabaseTypeTrino {
+			// Trino URIs carry the namespace as /catalog/schema
+			parts := strings.SplitN(database, "/", 2)
+			req.Catalog = &parts[0]
+			if len(parts) == 2 && parts[1] != "" {
+				req.Schema = &parts[1]
+			}
+		} else if req.Type == constants.DatabaseTypeOracle {
+			// Oracle URIs carry the service name as the path (host:port/service_name)
+			req.ServiceName = &database
+		} else {
+			req.Database = database
+		}
+	}
+
+	params := parsed.Query()
+	applyConnectionURIParams(req, scheme, params)
+
+	log.Printf("ChatService -> applyConnectionURI -> Parsed %s connection URI for host %s", req.Type, req.Host)
+	return nil
+}
+
+// applyConnectionURIParams maps the database-specific SSL and auth query parameters onto the request
+func applyConnectionURIParams(req *dtos.CreateConnectionRequest, scheme string, params url.Values) {
+	// Schemes that imply an encrypted connection
+	switch scheme {
+	case "rediss", "neo4j+s", "neo4j+ssc", "bolt+s", "bolt+ssc", "mongodb+srv", "https":
+		req.UseSSL = true
+	}
+
+	// libpq style sslmode (PostgreSQL family)
+	if sslMode := params.Get("sslmode"); sslMode != "" {
+		sslMode = strings.ToLower(sslMode)
+		req.SSLMode = &sslMode
+		req.UseSSL = sslMode != "disable"
+	}
+
+	switch req.Type {
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale:
+		if sslMode := strings.ToLower(params.Get("ssl-mode")); sslMode != "" {
+			req.UseSSL = sslMode != "disabled"
+		}
+		if tls := strings.ToLower(params.Get("tls")); tls != "" {
+			req.UseSSL = tls != "false"
+		}
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		if authSource := params.Get("authSource"); authSource != "" {
+			req.AuthDatabase = &authSource
+		}
+		if isTrueParam(params.Get("tls")) || isTrueParam(params.Get("ssl")) {
+			req.UseSSL = true
+		}
+	case constants.DatabaseTypeClickhouse:
+		if isTrueParam(params.Get("secure")) {
+			req.UseSSL = true
+		}
+	case constants.DatabaseTypeTrino:
+		if catalog := params.Get("catalog"); catalog != "" {
+			req.Catalog = &catalog
+		}
+		if schema := params.Get("schema"); schema != "" {
+			req.Schema = &schema
+		}
+		if isTrueParam(params.Get("SSL")) || isTrueParam(params.Get("ssl")) {
+			req.UseSSL = true
+		}
+	}
+
+	if req.UseSSL && req.SSLMode == nil {
+		sslMode := "require"
+		req.SSLMode = &sslMode
+	}
+}
+
+func isTrueParam(value string) bool {
+	return strings.EqualFold(value, "true") || value == "1"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1359,7 +1359,7 @@ func (s *dashboardService) getLLMClient(chat *models.Chat) (llm.Client, string,
 
 	// Fallback to searching enabled providers
 	if selectedModel == "" {
-		providers := []string{constants.OpenAI, constants.Gemini, constants.Claude, constants.Ollama}
+		providers := []string{constants.OpenAI, constants.Gemini, constants.Claude, constants.Ollama, constants.Cohere}
 		for _, provider := range providers {
 			if defaultModel := constants.GetDefaultModelForProvider(provider); defaultModel != nil && defaultModel.IsEnabled {
 				selectedModel = defaultModel.ID
@@ -231,7 +231,7 @@ func getDashboardTableInfo(
 		}, nil
 	}
 
-	formatted := dbMgr.GetSchemaManager().FormatSchemaForLLM(schema)
+	formatted := dbMgr.GetSchemaManager().FormatSchemaForLLM(dbmanager.WithoutHiddenTables(schema, dbMgr.HiddenTables(chatID)))
 	return &llm.ToolResult{
 		CallID:  call.ID,
 		Name:    call.Name,
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/repositories"
+	"neobase-ai/internal/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GDPRService carries out personal data requests: erasure of a user's data by an admin and export of
+// a user's own data. Both are recorded in the append-only GDPR log.
+type GDPRService interface {
+	DeleteUserData(ctx context.Context, adminUserID, userID string) (*dtos.UserDataDeletionReport, uint32, error)
+	ExportUserData(ctx context.Context, userID string) (*dtos.UserDataExport, uint32, error)
+}
+
+type gdprService struct {
+	userRepo    repositories.UserRepository
+	gdprRepo    repositories.GDPRRepository
+	chatService ChatService
+}
+
+// NewGDPRService creates a new GDPR service instance
+func NewGDPRService(userRepo repositories.UserRepository, gdprRepo repositories.GDPRRepository, chatService ChatService) GDPRService {
+	return &gdprService{
+		userRepo:    userRepo,
+		gdprRepo:    gdprRepo,
+		chatService: chatService,
+	}
+}
+
+// DeleteUserData erases everything NeoBase holds about a user. The chats' database connections and vectors are
+// released first, then every user data collection is cleared concurrently. The account itself is deleted last,
+// only when all collections were cleared, so a partly failed erasure can be run again.
+func (s *gdprService) DeleteUserData(ctx context.Context, adminUserID, userID string) (*dtos.UserDataDeletionReport, uint32, error) {
+	if status, err := requireAdminUser(s.userRepo, adminUserID); err != nil {
+		return nil, status, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("user not found")
+	}
+	if user.ID.Hex() == adminUserID {
+		return nil, http.StatusBadRequest, fmt.Errorf("the admin account can't erase itself")
+	}
+	adminObjID, _ := primitive.ObjectIDFromHex(adminUserID)
+
+	log.Printf("GDPRService -> DeleteUserData -> Erasing data of user %s", userID)
+
+	ctx, cancel := context.WithTimeout(ctx, constants.GDPROperationTimeout)
+	defer cancel()
+
+	var chats []models.Chat
+	if err := s.gdprRepo.FindUserDocuments(ctx, "chats", user.ID, &chats); err != nil {
+		log.Printf("GDPRService -> DeleteUserData -> Error listing chats: %v", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to list the user's chats")
+	}
+	for i := range chats {
+		s.chatService.ReleaseChatResources(&chats[i])
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		counts = make(map[string]int64, len(repositories.UserDataCollections))
+		errs   []string
+	)
+	for _, collection := range repositories.UserDataCollections {
+		wg.Add(1)
+		go func(collection string) {
+			defer wg.Done()
+			deleted, err := s.gdprRepo.DeleteUserDocuments(ctx, collection, user.ID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			counts[collection] = deleted
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+		}(collection)
+	}
+	wg.Wait()
+
+	report := &dtos.UserDataDeletionReport{
+		UserID:                userID,
+		DeletedChats:          counts["chats"],
+		DeletedMessages:       counts["messages"],
+		DeletedFeedback:       counts["message_feedback"],
+		DeletedReactions:      counts["message_reactions"],
+		DeletedVisualizations: counts["message_visualizations"],
+		DeletedDashboards:     counts["dashboards"],
+		DeletedWidgets:        counts["widgets"],
+		DeletedKnowledgeBases: counts["knowledge_bases"],
+		DeletedQueryTemplates: counts["query_templates"],
+		DeletedSecureNotes:    counts["secure_notes"],
+		DeletedSchemaFiles:    counts["schema_sources"],
+		DeletedReports:        counts["reports"],
+		DeletedRollbacks:      counts["rollback_records"],
+		DeletedAPIKeys:        counts["api_keys"],
+		Errors:                errs,
+		DeletedAt:             time.Now(),
+	}
+
+	if len(errs) == 0 {
+		if err := s.userRepo.Delete(user); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete account: %v", err))
+		} else {
+			report.DeletedAccount = true
+			counts["users"] = 1
+		}
+	}
+
+	// The log is written even when the erasure failed part way, with what was deleted and the errors
+	entry := models.NewGDPRLog(constants.GDPRActionDeletion, user.ID, adminObjID, counts, report.Errors)
+	if err := s.gdprRepo.CreateLog(context.Background(), entry); err != nil {
+		log.Printf("GDPRService -> DeleteUserData -> Error writing GDPR log for user %s: %v", userID, err)
+	}
+
+	if len(report.Errors) > 0 {
+		log.Printf("GDPRService -> DeleteUserData -> Erasure of user %s incomplete: %v", userID, report.Errors)
+		return report, http.StatusInternalServerError, fmt.Errorf("some of the user's data could not be deleted, run the erasure again")
+	}
+
+	log.Printf("GDPRService -> DeleteUserData -> Erased data of user %s: %v", userID, counts)
+	return report, http.StatusOK, nil
+}
+
+// ExportUserData returns everything NeoBase holds about the user, read concurrently from each collection
+func (s *gdprService) ExportUserData(ctx context.Context, userID string) (*dtos.UserDataExport, uint32, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return nil, http.StatusUnauthorized, fmt.Errorf("user not found")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.GDPROperationTimeout)
+	defer cancel()
+
+	export := &dtos.UserDataExport{
+		ExportVersion: constants.UserDataExportVersion,
+		ExportedAt:    time.Now(),
+		User:          user,
+	}
+	targets := map[string]interface{}{
+		"chats":                  &export.Chats,
+		"messages":               &export.Messages,
+		"message_feedback":       &export.Feedback,
+		"message_reactions":      &export.Reactions,
+		"message_visualizations": &export.Visualizations,
+		"dashboards":             &export.Dashboards,
+		"widgets":                &export.Widgets,
+		"knowledge_bases":        &export.KnowledgeBases,
+		"query_templates":        &export.QueryTemplates,
+		"secure_notes":           &export.SecureNotes,
+		"schema_sources":         &export.SchemaFiles,
+		"reports":                &export.Reports,
+		"rollback_records":       &export.Rollbacks,
+		"api_keys":               &export.APIKeys,
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []string
+	)
+	for collection, target := range targets {
+		wg.Add(1)
+		go func(collection string, target interface{}) {
+			defer wg.Done()
+			if err := s.gdprRepo.FindUserDocuments(ctx, collection, user.ID, target); err != nil {
+				mu.Lock()
+				errs = append(errs, err.Error())
+				mu.Unlock()
+			}
+		}(collection, target)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		log.Printf("GDPRService -> ExportUserData -> Export of user %s failed: %v", userID, errs)
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to export your data")
+	}
+
+	// Connections are stored encrypted, secrets are hidden from JSON by the model
+	for i := range export.Chats {
+		utils.DecryptConnection(&export.Chats[i].Connection)
+		for j := range export.Chats[i].SecondaryConnections {
+			utils.DecryptConnection(&export.Chats[i].SecondaryConnections[j])
+		}
+	}
+
+	counts := map[string]int64{
+		"chats":                  int64(len(export.Chats)),
+		"messages":               int64(len(export.Messages)),
+		"message_feedback":       int64(len(export.Feedback)),
+		"message_reactions":      int64(len(export.Reactions)),
+		"message_visualizations": int64(len(export.Visualizations)),
+		"dashboards":             int64(len(export.Dashboards)),
+		"widgets":                int64(len(export.Widgets)),
+		"knowledge_bases":        int64(len(export.KnowledgeBases)),
+		"query_templates":        int64(len(export.QueryTemplates)),
+		"secure_notes":           int64(len(export.SecureNotes)),
+		"schema_sources":         int64(len(export.SchemaFiles)),
+		"reports":                int64(len(export.Reports)),
+		"rollback_records":       int64(len(export.Rollbacks)),
+		"api_keys":               int64(len(export.APIKeys)),
+	}
+	entry := models.NewGDPRLog(constants.GDPRActionExport, user.ID, user.ID, counts, nil)
+	if err := s.gdprRepo.CreateLog(context.Background(), entry); err != nil {
+		log.Printf("GDPRService -> ExportUserData -> Error writing GDPR log for user %s: %v", userID, err)
+	}
+
+	log.Printf("GDPRService -> ExportUserData -> Exported data of user %s: %v", userID, counts)
+	return export, http.StatusOK, nil
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"neobase-ai/internal/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GlobalSearchService searches the messages and queries of every chat of a user
+type GlobalSearchService interface {
+	Search(ctx context.Context, userID, query, searchType string, page, pageSize int) (*dtos.GlobalSearchResponse, uint32, error)
+}
+
+type globalSearchService struct {
+	chatRepo repositories.ChatRepository
+}
+
+// NewGlobalSearchService creates a new global search service instance
+func NewGlobalSearchService(chatRepo repositories.ChatRepository) GlobalSearchService {
+	return &globalSearchService{
+		chatRepo: chatRepo,
+	}
+}
+
+// Search finds the user's messages whose content matches the keyword, or with type query the messages with a
+// query containing it. Only the requesting user's messages are searched.
+func (s *globalSearchService) Search(ctx context.Context, userID, query, searchType string, page, pageSize int) (*dtos.GlobalSearchResponse, uint32, error) {
+	log.Printf("GlobalSearchService -> Search -> userID: %s, type: %s, query: %s, page: %d, pageSize: %d", userID, searchType, query, page, pageSize)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid user ID format")
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("search keyword is required")
+	}
+	if len([]rune(query)) > constants.GlobalSearchMaxKeywordLen {
+		return nil, http.StatusBadRequest, fmt.Errorf("search keyword can't be longer than %d characters", constants.GlobalSearchMaxKeywordLen)
+	}
+	if searchType == "" {
+		searchType = constants.GlobalSearchTypeMessage
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = constants.GlobalSearchDefaultPageSize
+	}
+	if pageSize > constants.GlobalSearchMaxPageSize {
+		pageSize = constants.GlobalSearchMaxPageSize
+	}
+
+	response := &dtos.GlobalSearchResponse{
+		Results:  []dtos.GlobalSearchResult{},
+		Page:     page,
+		PageSize: pageSize,
+	}
+	chatTitles := make(map[primitive.ObjectID]string)
+
+	switch searchType {
+	case constants.GlobalSearchTypeMessage:
+		matches, total, err := s.chatRepo.SearchUserMessagesByText(userObjID, query, page, pageSize)
+		if err != nil {
+			log.Printf("GlobalSearchService -> Search -> Error searching messages: %v", err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to search messages: %v", err)
+		}
+		response.Total = total
+		for _, match := range matches {
+			response.Results = append(response.Results, dtos.GlobalSearchResult{
+				ChatID:    match.ChatID.Hex(),
+				ChatTitle: s.chatTitle(chatTitles, match.ChatID),
+				MessageID: match.ID.Hex(),
+				Snippet:   buildSearchSnippet(match.Content, strings.Fields(query)),
+				CreatedAt: match.CreatedAt.Format(time.RFC3339),
+			})
+		}
+
+	case constants.GlobalSearchTypeQuery:
+		messages, total, err := s.chatRepo.SearchUserQueriesByKeyword(userObjID, query, page, pageSize)
+		if err != nil {
+			log.Printf("GlobalSearchService -> Search -> Error searching queries: %v", err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to search queries: %v", err)
+		}
+		response.Total = total
+		for _, message := range messages {
+			result := dtos.GlobalSearchResult{
+				ChatID:    message.ChatID.Hex(),
+				ChatTitle: s.chatTitle(chatTitles, message.ChatID),
+				MessageID: message.ID.Hex(),
+				CreatedAt: message.CreatedAt.Format(time.RFC3339),
+			}
+			if match := firstQueryContaining(message, query); match != nil {
+				result.QueryID = match.ID.Hex()
+				result.Snippet = buildSearchSnippet(match.Query, []string{query})
+			}
+			response.Results = append(response.Results, result)
+		}
+
+	default:
+		return nil, http.StatusBadRequest, fmt.Errorf("search type must be %q or %q", constants.GlobalSearchTypeMessage, constants.GlobalSearchTypeQuery)
+	}
+
+	return response, http.StatusOK, nil
+}
+
+// chatTitle returns the title of a chat, falling back to its database name. Titles are looked up once per search.
+func (s *globalSearchService) chatTitle(titles map[primitive.ObjectID]string, chatID primitive.ObjectID) string {
+	if title, ok := titles[chatID]; ok {
+		return title
+	}
+
+	title := ""
+	chat, err := s.chatRepo.FindByID(chatID)
+	if err != nil || chat == nil {
+		log.Printf("GlobalSearchService -> chatTitle -> Chat %s not found: %v", chatID.Hex(), err)
+	} else if chat.Title != nil && *chat.Title != "" {
+		title = *chat.Title
+	} else {
+		title = chat.Connection.Database
+	}
+	titles[chatID] = title
+	return title
+}
+
+// firstQueryContaining returns the first query of the message containing the keyword, ignoring case
+func firstQueryContaining(message *models.Message, keyword string) *models.Query {
+	if message.Queries == nil {
+		return nil
+	}
+	keyword = strings.ToLower(keyword)
+	for i := range *message.Queries {
+		if strings.Contains(strings.ToLower((*message.Queries)[i].Query), keyword) {
+			return &(*message.Queries)[i]
+		}
+	}
+	return nil
+}
+
+// buildSearchSnippet cuts the text around the first of the keywords it contains, ignoring case, and collapses
+// whitespace. Text without any of the keywords is cut from the start.
+func buildSearchSnippet(text string, keywords []string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	// unicode.ToLower keeps one rune per rune, so indexes in lower are indexes in runes
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	start, end := 0, 0
+	for _, keyword := range keywords {
+		keywordRunes := []rune(strings.ToLower(keyword))
+		if index := indexRunes(lower, keywordRunes); index >= 0 {
+			start, end = index, index+len(keywordRunes)
+			break
+		}
+	}
+
+	from := start - constants.GlobalSearchSnippetRadius
+	if from < 0 {
+		from = 0
+	}
+	to := end + constants.GlobalSearchSnippetRadius
+	if to > len(runes) {
+		to = len(runes)
+	}
+
+	snippet := string(runes[from:to])
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// indexRunes returns the index of the first occurrence of needle in haystack, or -1
+func indexRunes(haystack, needle []rune) int {
+	if len(needle) == 0 {
+		return -1
+	}
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -34,6 +34,7 @@ type MergeOptions struct {
 	UpdateExisting  bool     // update existing rows (for merge)
 	InsertNew       bool     // insert new rows (for merge)
 	DeleteMissing   bool     // delete rows not in new data
+	FixedHeaders    bool     // the first row holds the column names, e.g. keys of JSON records, no header detection
 }
 
 // SpreadsheetMergeHandler handles complex merge operations
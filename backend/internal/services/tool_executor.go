@@ -178,7 +178,8 @@ func getTableInfo(
 		}, nil
 	}
 
-	// Format the schema for LLM readability
+	// Format the schema for LLM readability, without the tables hidden from the LLM
+	schema = dbmanager.WithoutHiddenTables(schema, dbMgr.HiddenTables(chatID))
 	content := dbMgr.GetSchemaManager().FormatSchemaForLLM(schema)
 
 	// Detect table name mismatches (e.g. spreadsheet 'sheet_data' → actual 'ventures').
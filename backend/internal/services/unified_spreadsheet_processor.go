@@ -17,13 +17,36 @@ import (
 
 // ProcessAndStoreSpreadsheetUnified processes CSV/Excel data exactly like Google Sheets
 // This ensures identical handling between all spreadsheet sources
+// columnTypes optionally overrides the inferred type of a column in the tables created (see normalizeSpreadsheetColumnType)
+// Progress is streamed as import_progress events when streamID is set
 func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 	userID string,
 	chatID string,
+	streamID string,
 	baseTableName string,
 	data [][]interface{},
 	mergeStrategy string,
 	mergeOptions MergeOptions,
+	columnTypes map[string]string,
+) (*dtos.SpreadsheetUploadResponse, uint32, error) {
+	resolvedTypes, err := resolveSpreadsheetColumnTypes(columnTypes)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	tracker := s.newSpreadsheetImportTracker(userID, chatID, streamID, "", baseTableName)
+	return s.runSpreadsheetImport(tracker, userID, chatID, baseTableName, data, mergeStrategy, mergeOptions, resolvedTypes)
+}
+
+// processAndStoreSpreadsheetUnified does the import work, reporting each committed batch to tracker
+func (s *chatService) processAndStoreSpreadsheetUnified(
+	userID string,
+	chatID string,
+	baseTableName string,
+	data [][]interface{},
+	mergeStrategy string,
+	mergeOptions MergeOptions,
+	columnTypes map[string]string,
+	tracker *spreadsheetImportTracker,
 ) (*dtos.SpreadsheetUploadResponse, uint32, error) {
 	
 	log.Printf("ProcessAndStoreSpreadsheetUnified -> Starting for chat %s, base table %s", 
@@ -63,6 +86,9 @@ func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 	
 	// Use robust analyzer to process the data (exactly like Google Sheets)
 	robustAnalyzer := dbmanager.NewRobustSheetAnalyzer(data)
+	if mergeOptions.FixedHeaders {
+		robustAnalyzer.WithFixedHeaders()
+	}
 	regions, err := robustAnalyzer.AnalyzeRobust()
 	if err != nil {
 		log.Printf("Warning: Failed to analyze data: %v, falling back to unstructured", err)
@@ -98,6 +124,13 @@ func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 		regions = []*dbmanager.DataRegion{region}
 	}
 	
+	// Report the total up front so progress can be shown as a percentage
+	totalDataRows := 0
+	for _, region := range regions {
+		totalDataRows += len(region.DataRows)
+	}
+	tracker.setTotal(totalDataRows)
+
 	// Process all detected regions (same as Google Sheets)
 	allTables := make([]string, 0)
 	totalRows := 0
@@ -174,8 +207,10 @@ func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 					
 					if err := mergeHandler.ExecuteMerge(region.Headers, stringData, mergeOptions); err != nil {
 						log.Printf("Warning: Merge failed for table %s: %v", currentTableName, err)
+						tracker.addBatch(len(region.DataRows), fmt.Sprintf("merge failed for table %s: %v", currentTableName, err))
 						continue
 					}
+					tracker.addBatch(len(region.DataRows))
 					
 					allTables = append(allTables, currentTableName)
 					totalRows += len(region.DataRows)
@@ -188,7 +223,7 @@ func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 		}
 		
 		// Store the region data (exactly like Google Sheets)
-		insertResult, err := s.storeSheetDataUnified(sqlDB, schemaName, currentTableName, region.Headers, region.DataRows)
+		insertResult, err := s.storeSheetDataUnified(sqlDB, chatID, schemaName, currentTableName, region.Headers, region.DataRows, columnTypes, tracker)
 		if err != nil {
 			log.Printf("Warning: Failed to store region %d: %v", regionIdx+1, err)
 			if insertResult != nil {
@@ -197,6 +232,9 @@ func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 				totalSuccessful += insertResult.SuccessfulRows
 				totalFailed += insertResult.FailedRows
 				allErrors = append(allErrors, insertResult.Errors...)
+			} else {
+				// The table could not be created, so none of the region's rows were attempted
+				tracker.addBatch(len(region.DataRows), fmt.Sprintf("table %s: %v", currentTableName, err))
 			}
 			continue
 		}
@@ -244,7 +282,9 @@ func (s *chatService) ProcessAndStoreSpreadsheetUnified(
 			// Add column metadata with inferred types
 			for _, header := range region.Headers {
 				dataType := "text" // default fallback
-				if inferredTypes, err := utils.NewDataTypeInferrer().InferColumnTypes(region.Headers, region.DataRows); err == nil {
+				if overrideType, ok := columnTypes[sanitizeColumnName(header)]; ok {
+					dataType = strings.ToLower(overrideType)
+				} else if inferredTypes, err := utils.NewDataTypeInferrer().InferColumnTypes(region.Headers, region.DataRows); err == nil {
 					if colType, exists := inferredTypes[header]; exists {
 						dataType = strings.ToLower(colType.PostgreSQLType)
 					}
@@ -318,7 +358,10 @@ func (r *DataInsertionResult) HasErrors() bool {
 }
 
 // storeSheetDataUnified stores sheet data exactly like Google Sheets driver
-func (s *chatService) storeSheetDataUnified(db *sql.DB, schemaName, tableName string, headers []string, data [][]interface{}) (*DataInsertionResult, error) {
+// Each batch is committed in its own transaction and reported to tracker
+// storeSheetDataUnified recreates the table with the inferred column types, columnTypes overriding them by sanitized
+// column name, and loads the data into it
+func (s *chatService) storeSheetDataUnified(db *sql.DB, chatID, schemaName, tableName string, headers []string, data [][]interface{}, columnTypes map[string]string, tracker *spreadsheetImportTracker) (*DataInsertionResult, error) {
 	// Drop existing table if it exists
 	dropQuery := fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", schemaName, tableName)
 	if _, err := db.Exec(dropQuery); err != nil {
@@ -345,6 +388,13 @@ func (s *chatService) storeSheetDataUnified(db *sql.DB, schemaName, tableName st
 			}
 		}
 	}
+	for _, header := range headers {
+		if overrideType, ok := columnTypes[sanitizeColumnName(header)]; ok {
+			dataType := inferredTypes[header]
+			dataType.PostgreSQLType = overrideType
+			inferredTypes[header] = dataType
+		}
+	}
 
 	// Create table with columns based on inferred types
 	columns := make([]string, 0)
@@ -374,8 +424,8 @@ func (s *chatService) storeSheetDataUnified(db *sql.DB, schemaName, tableName st
 			colNames = append(colNames, sanitizeColumnName(header))
 		}
 		
-		// Build insert query with type-aware conversion (batch insert for performance)
-		batchSize := 100
+		// Insert in batches with type-aware conversion, each batch is committed on its own
+		batchSize := constants.SpreadsheetImportBatchSize
 		totalRows := 0
 		successfulRows := 0
 		failedRows := 0
@@ -387,52 +437,49 @@ func (s *chatService) storeSheetDataUnified(db *sql.DB, schemaName, tableName st
 			}
 			
 			batch := data[i:end]
-			validRows := make([]string, 0, len(batch)) // All rows for insertion
-			
+			batchRows := make([][]interface{}, 0, len(batch))
+
 			for rowIdx, row := range batch {
-				values := make([]string, 0)
-				
+				// Values stay strings, the database casts them to the column types. NULL is stored as nil.
+				values := make([]interface{}, len(headers))
+
 				for j, header := range headers {
-					var value string
-					if j < len(row) && row[j] != nil {
-						rawValue := fmt.Sprintf("%v", row[j])
-						dataType := inferredTypes[header]
-						
-						// Convert value according to inferred type
-						convertedValue, conversionErr := s.convertValueToType(rawValue, dataType.PostgreSQLType)
-						if conversionErr != nil {
-							// Instead of skipping the row, store NULL for invalid values
-							log.Printf("CONVERSION_WARNING: Table '%s', Column '%s', Row %d: Cannot convert '%s' to %s, storing as NULL", 
-								tableName, header, i+rowIdx+1, rawValue, dataType.PostgreSQLType)
-							value = "" // Will be formatted as NULL by formatSQLValue
-						} else {
-							value = convertedValue
-						}
+					if j >= len(row) || row[j] == nil {
+						continue
+					}
+					rawValue := fmt.Sprintf("%v", row[j])
+					dataType := inferredTypes[header]
+
+					// Convert value according to inferred type
+					convertedValue, conversionErr := s.convertValueToType(rawValue, dataType.PostgreSQLType)
+					if conversionErr != nil {
+						// Instead of skipping the row, store NULL for invalid values
+						log.Printf("CONVERSION_WARNING: Table '%s', Column '%s', Row %d: Cannot convert '%s' to %s, storing as NULL", 
+							tableName, header, i+rowIdx+1, rawValue, dataType.PostgreSQLType)
+						continue
+					}
+					if convertedValue != "" {
+						values[j] = convertedValue
 					}
-					// Use appropriate SQL value formatting
-					values = append(values, s.formatSQLValue(value, inferredTypes[header].PostgreSQLType))
 				}
-				
+
 				// Add all rows to the batch (no longer skipping rows with conversion errors)
-				validRows = append(validRows, fmt.Sprintf("(%s)", strings.Join(values, ", ")))
+				batchRows = append(batchRows, values)
 				successfulRows++
 				totalRows++
 			}
-			
-			// Insert all rows (we no longer skip rows with conversion errors)
-			if len(validRows) > 0 {
-				insertQuery := fmt.Sprintf("INSERT INTO %s.%s (%s) VALUES %s",
-					schemaName, tableName,
-					strings.Join(colNames, ", "),
-					strings.Join(validRows, ", "))
-				
-				if _, err := db.Exec(insertQuery); err != nil {
+
+			// Large batches go through COPY, smaller ones through a batched INSERT
+			if len(batchRows) > 0 {
+				if _, err := s.dbManager.BulkInsertSpreadsheetRows(context.Background(), chatID, schemaName, tableName, colNames, batchRows); err != nil {
 					// Log the batch failure but continue processing
-					log.Printf("Error: Failed to insert batch %d-%d with %d rows: %v", i, end, len(validRows), err)
-					log.Printf("Failed query: %s", insertQuery)
+					log.Printf("Error: Failed to insert batch %d-%d with %d rows: %v", i, end, len(batchRows), err)
 					// Mark these rows as failed
-					failedRows += len(validRows)
-					successfulRows -= len(validRows)
+					failedRows += len(batchRows)
+					successfulRows -= len(batchRows)
+					tracker.addBatch(len(batch), fmt.Sprintf("table %s rows %d-%d: %v", tableName, i+1, end, err))
+				} else {
+					tracker.addBatch(len(batch))
 				}
 			}
 		}
@@ -474,7 +521,12 @@ func (s *chatService) convertValueToType(value string, postgresType string) (str
 		return "", nil // NULL value
 	}
 
-	switch strings.ToUpper(postgresType) {
+	normalizedType := strings.ToUpper(postgresType)
+	if strings.HasPrefix(normalizedType, "DECIMAL(") {
+		normalizedType = "DECIMAL"
+	}
+
+	switch normalizedType {
 	case "INTEGER":
 		// Remove common number formatting (commas, spaces) before parsing
 		cleanValue := strings.ReplaceAll(value, ",", "")
@@ -548,21 +600,3 @@ func (s *chatService) convertValueToType(value string, postgresType string) (str
 		return value, nil
 	}
 }
-
-// formatSQLValue formats a value for SQL insertion based on the column type
-func (s *chatService) formatSQLValue(value string, postgresType string) string {
-	if value == "" {
-		return "NULL"
-	}
-
-	switch strings.ToUpper(postgresType) {
-	case "INTEGER", "NUMERIC", "DECIMAL", "BOOLEAN":
-		// Numeric and boolean values don't need quotes
-		return value
-	default:
-		// String types need quotes and escaping
-		escaped := strings.ReplaceAll(value, "'", "''")
-		return fmt.Sprintf("'%s'", escaped)
-	}
-}
-
@@ -928,6 +928,13 @@ func getDBTerminology(dbType string) dbTerminology {
 			FieldLabel:  "Document Fields (inferred)",
 			EngineNote:  "NoSQL document store — schema is flexible and inferred from sampled documents",
 		}
+	case constants.DatabaseTypeFerretDB:
+		return dbTerminology{
+			EntityLabel: "Collection",
+			CountLabel:  "documents",
+			FieldLabel:  "Document Fields (inferred)",
+			EngineNote:  "FerretDB — MongoDB-compatible document store on PostgreSQL; no $where or mapReduce, limited $text and $lookup",
+		}
 	case constants.DatabaseTypeSpreadsheet:
 		return dbTerminology{
 			EntityLabel: "Sheet",
@@ -963,6 +970,20 @@ func getDBTerminology(dbType string) dbTerminology {
 			FieldLabel:  "Columns",
 			EngineNote:  "TimescaleDB — PostgreSQL extension optimised for time-series data; use time_bucket() for time aggregations",
 		}
+	case constants.DatabaseTypeSupabase:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "Supabase — hosted PostgreSQL with Row Level Security; storage buckets are virtual tables over storage.objects",
+		}
+	case constants.DatabaseTypeNeon:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "Neon — serverless PostgreSQL; each branch has its own endpoint and idle computes are suspended",
+		}
 	case constants.DatabaseTypeStarRocks:
 		return dbTerminology{
 			EntityLabel: "Table",
@@ -970,6 +991,83 @@ func getDBTerminology(dbType string) dbTerminology {
 			FieldLabel:  "Columns",
 			EngineNote:  "StarRocks — MySQL-compatible MPP analytical database; use APPROX_COUNT_DISTINCT() for large cardinality estimates",
 		}
+	case constants.DatabaseTypePlanetscale:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "PlanetScale — Vitess-based MySQL with branches; foreign keys are usually not enforced, filter on the sharding column to avoid scatter queries",
+		}
+	case constants.DatabaseTypeTrino:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "Trino — distributed SQL engine over data lake connectors; filter on partition columns and use CROSS JOIN UNNEST for ARRAY/MAP columns",
+		}
+	case constants.DatabaseTypeOracle:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "Oracle — enterprise RDBMS with PL/SQL; limit rows with FETCH FIRST n ROWS ONLY, select expressions FROM DUAL, and note '' is treated as NULL",
+		}
+	case constants.DatabaseTypeAirtable:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "records",
+			FieldLabel:  "Fields",
+			EngineNote:  "Airtable — REST API over a spreadsheet-database base; filter with filterByFormula formulas, no SQL, joins or aggregation",
+		}
+	case constants.DatabaseTypeInfluxDB:
+		return dbTerminology{
+			EntityLabel: "Measurement",
+			CountLabel:  "points",
+			FieldLabel:  "Tags and fields",
+			EngineNote:  "InfluxDB 3 — time-series database queried with SQL or InfluxQL; always filter on the time column, group by tags and aggregate fields",
+		}
+	case constants.DatabaseTypeTemporal:
+		return dbTerminology{
+			EntityLabel: "Workflow type",
+			CountLabel:  "executions",
+			FieldLabel:  "Search attributes",
+			EngineNote:  "Temporal — workflow engine queried through its visibility store with temporal workflow list --query filters over search attributes; no SQL, joins or aggregation beyond counts",
+		}
+	case constants.DatabaseTypePocketBase:
+		return dbTerminology{
+			EntityLabel: "Collection",
+			CountLabel:  "records",
+			FieldLabel:  "Fields",
+			EngineNote:  "PocketBase — backend with an embedded SQLite database queried through its REST API with filter expressions; no SQL, joins or aggregation beyond counts, relations are loaded with expand",
+		}
+	case constants.DatabaseTypeNATS:
+		return dbTerminology{
+			EntityLabel: "Stream",
+			CountLabel:  "messages",
+			FieldLabel:  "Message fields",
+			EngineNote:  "NATS JetStream — message streams read by sequence with js.FetchMsg and filtered by subject, start sequence or start time; no SQL, payloads cannot be filtered and nothing aggregates beyond counts",
+		}
+	case constants.DatabaseTypeFirestore:
+		return dbTerminology{
+			EntityLabel: "Collection",
+			CountLabel:  "documents",
+			FieldLabel:  "Fields",
+			EngineNote:  "Firestore — Google Cloud document database queried with chained Firestore SDK calls; no SQL or JOINs (related data lives in subcollections), inequality filters on several fields need a composite index, fields are inferred from sampled documents",
+		}
+	case constants.DatabaseTypeCloudflareD1:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "Cloudflare D1 — serverless SQLite queried over the Cloudflare API; use SQLite date functions such as date('now', '-7 days'), no ATTACH or transactions",
+		}
+	case constants.DatabaseTypeYugabyteDBCQL:
+		return dbTerminology{
+			EntityLabel: "Table",
+			CountLabel:  "rows",
+			FieldLabel:  "Columns",
+			EngineNote:  "YugabyteDB YCQL — Cassandra-compatible API of a distributed database; no JOINs, every query must restrict the partition key or use ALLOW FILTERING, with USING TTL, USING TIMESTAMP and IF NOT EXISTS writes",
+		}
 	case constants.DatabaseTypeCassandra:
 		return dbTerminology{
 			EntityLabel: "Table",
@@ -1033,16 +1131,16 @@ func buildSchemaChunkText(tableName string, table SchemaTable, dbType string, en
 		sb.WriteString(fmt.Sprintf("  - %s (%s", col.Name, col.Type))
 
 		// MongoDB: nullable means "field not always present", so skip NOT NULL noise
-		if dbType != constants.DatabaseTypeMongoDB {
+		if dbType != constants.DatabaseTypeMongoDB && dbType != constants.DatabaseTypeFerretDB {
 			if !col.IsNullable {
 				sb.WriteString(", NOT NULL")
 			}
 		}
 		if col.IsPrimaryKey {
 			switch dbType {
-			case constants.DatabaseTypeMongoDB:
+			case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB, constants.DatabaseTypeFirestore:
 				sb.WriteString(", _id")
-			case constants.DatabaseTypeCassandra:
+			case constants.DatabaseTypeCassandra, constants.DatabaseTypeYugabyteDBCQL:
 				sb.WriteString(", PARTITION KEY")
 			default:
 				sb.WriteString(", PK")
@@ -1080,7 +1178,7 @@ func buildSchemaChunkText(tableName string, table SchemaTable, dbType string, en
 	// Foreign keys — only relevant for relational / SQL-backed engines
 	if len(table.ForeignKeys) > 0 {
 		switch dbType {
-		case constants.DatabaseTypeMongoDB, constants.DatabaseTypeRedis, constants.DatabaseTypeNeo4j:
+		case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB, constants.DatabaseTypeRedis, constants.DatabaseTypeNeo4j:
 			// These engines don't have FK constraints — skip section
 		default:
 			sb.WriteString("Foreign Keys:\n")
@@ -43,7 +43,7 @@ func (s *chatService) GenerateVisualizationForMessage(
 	log.Printf("GenerateVisualizationForMessage -> Connection type: %s", connectionType)
 
 	// Fetch messages for this chat
-	msgResp, _, err := s.ListMessages(userID, chatID, 1, 100)
+	msgResp, _, err := s.ListMessages(userID, chatID, 1, 100, "")
 	if err != nil || msgResp == nil {
 		return nil, fmt.Errorf("failed to fetch messages: %v", err)
 	}
@@ -104,38 +104,7 @@ func (s *chatService) GenerateVisualizationForMessage(
 		return nil, fmt.Errorf("no query results found for query: %s", queryID)
 	}
 
-	// Convert result data to []map[string]interface{}
-	switch v := resultData.(type) {
-	case map[string]interface{}:
-		// Check if it's wrapped in a 'results' key (common format from DB queries)
-		if resultsArray, ok := v["results"].([]interface{}); ok {
-			// Extract the nested results array
-			for _, item := range resultsArray {
-				if m, ok := item.(map[string]interface{}); ok {
-					queryResults = append(queryResults, m)
-				}
-			}
-			log.Printf("GenerateVisualizationForMessage -> Extracted from 'results' key, length: %d", len(queryResults))
-		} else {
-			// Single result object (e.g., DML result with rowsAffected, or aggregation)
-			queryResults = []map[string]interface{}{v}
-			log.Printf("GenerateVisualizationForMessage -> Single result object, length: %d", len(queryResults))
-		}
-	case []interface{}:
-		// Array of results (most common for SELECT queries)
-		for _, item := range v {
-			if m, ok := item.(map[string]interface{}); ok {
-				queryResults = append(queryResults, m)
-			}
-		}
-		log.Printf("GenerateVisualizationForMessage -> Array of results, length: %d", len(queryResults))
-	default:
-		// Primitive value or other format - wrap it
-		queryResults = []map[string]interface{}{{
-			"value": resultData,
-		}}
-		log.Printf("GenerateVisualizationForMessage -> Wrapped primitive/unknown format, type: %T", resultData)
-	}
+	queryResults = visualizationRows(resultData)
 
 	log.Printf("GenerateVisualizationForMessage -> Final queryResults length: %d", len(queryResults))
 	if len(queryResults) > 0 {
@@ -322,6 +291,43 @@ func (s *chatService) GenerateVisualizationForMessage(
 	return visualization, nil
 }
 
+// visualizationRows converts a query's execution or example result into the rows charts are built from
+func visualizationRows(resultData interface{}) []map[string]interface{} {
+	var rows []map[string]interface{}
+	switch v := resultData.(type) {
+	case map[string]interface{}:
+		// Check if it's wrapped in a 'results' key (common format from DB queries)
+		if resultsArray, ok := v["results"].([]interface{}); ok {
+			// Extract the nested results array
+			for _, item := range resultsArray {
+				if m, ok := item.(map[string]interface{}); ok {
+					rows = append(rows, m)
+				}
+			}
+			log.Printf("visualizationRows -> Extracted from 'results' key, length: %d", len(rows))
+		} else {
+			// Single result object (e.g., DML result with rowsAffected, or aggregation)
+			rows = []map[string]interface{}{v}
+			log.Printf("visualizationRows -> Single result object, length: %d", len(rows))
+		}
+	case []interface{}:
+		// Array of results (most common for SELECT queries)
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				rows = append(rows, m)
+			}
+		}
+		log.Printf("visualizationRows -> Array of results, length: %d", len(rows))
+	default:
+		// Primitive value or other format - wrap it
+		rows = []map[string]interface{}{{
+			"value": resultData,
+		}}
+		log.Printf("visualizationRows -> Wrapped primitive/unknown format, type: %T", resultData)
+	}
+	return rows
+}
+
 // analyzeDataQuality analyzes the structure and content of query results
 // to help AI decide if visualization is suitable and what type to use
 func analyzeDataQuality(results []map[string]interface{}) map[string]interface{} {
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/models"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// visualizationTemplates are the seeded chart templates, in the order they are listed
+var visualizationTemplates = []models.VisualizationTemplate{
+	{
+		ID:              "monthly-trend",
+		Name:            "Monthly Trend",
+		Description:     "A line chart of one metric per month, such as monthly revenue or orders.",
+		RequiredColumns: []string{"date", "number"},
+		ChartConfigJSON: `{"chart_type":"line","title":"{{number:label}} by Month","description":"Monthly {{number:label}}",
+"chart_render":{"type":"line","x_axis":{"data_key":"{{date}}","label":"{{date:label}}","type":"date","format":"MMM YYYY"},
+"y_axis":{"data_key":"{{number}}","label":"{{number:label}}","type":"number"},
+"series":[{"data_key":"{{number}}","name":"{{number:label}}","type":"monotone","stroke":"#8884d8"}],
+"colors":["#8884d8"],"features":{"tooltip":true,"legend":false,"grid":true,"responsive":true,"zoom_enabled":true}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":1000}}`,
+	},
+	{
+		ID:              "growth-over-time",
+		Name:            "Growth Over Time",
+		Description:     "An area chart of a count over time, such as user signups or active accounts.",
+		RequiredColumns: []string{"date", "number"},
+		ChartConfigJSON: `{"chart_type":"area","title":"{{number:label}} Over Time","description":"{{number:label}} by {{date:label}}",
+"chart_render":{"type":"area","x_axis":{"data_key":"{{date}}","label":"{{date:label}}","type":"date"},
+"y_axis":{"data_key":"{{number}}","label":"{{number:label}}","type":"number"},
+"series":[{"data_key":"{{number}}","name":"{{number:label}}","type":"monotone","stroke":"#82ca9d","fill":"#82ca9d","area":true}],
+"colors":["#82ca9d"],"features":{"tooltip":true,"legend":false,"grid":true,"responsive":true,"zoom_enabled":true}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":1000}}`,
+	},
+	{
+		ID:              "two-metric-trend",
+		Name:            "Two Metric Trend",
+		Description:     "A line chart comparing two metrics over time, such as revenue and cost.",
+		RequiredColumns: []string{"date", "number", "number2"},
+		ChartConfigJSON: `{"chart_type":"line","title":"{{number:label}} and {{number2:label}} Over Time","description":"{{number:label}} compared with {{number2:label}}",
+"chart_render":{"type":"line","x_axis":{"data_key":"{{date}}","label":"{{date:label}}","type":"date"},
+"y_axis":{"data_key":"{{number}}","label":"Value","type":"number"},
+"series":[{"data_key":"{{number}}","name":"{{number:label}}","type":"monotone","stroke":"#8884d8"},{"data_key":"{{number2}}","name":"{{number2:label}}","type":"monotone","stroke":"#ffc658"}],
+"colors":["#8884d8","#ffc658"],"features":{"tooltip":true,"legend":true,"grid":true,"responsive":true,"zoom_enabled":true}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":1000}}`,
+	},
+	{
+		ID:              "category-breakdown",
+		Name:            "Category Breakdown",
+		Description:     "A donut chart of each category's share of a total, such as sales by product category.",
+		RequiredColumns: []string{"category", "number"},
+		ChartConfigJSON: `{"chart_type":"pie","title":"{{number:label}} by {{category:label}}","description":"Share of {{number:label}} per {{category:label}}",
+"chart_render":{"type":"pie","x_axis":{"data_key":"{{category}}","label":"{{category:label}}","type":"category"},
+"pie":{"data_key":"{{number}}","name_key":"{{category}}","inner_radius":60},
+"colors":["#8884d8","#82ca9d","#ffc658","#ff7c7c","#8dd1e1","#a4de6c","#d0ed57","#ffa07a"],"features":{"tooltip":true,"legend":true,"grid":false,"responsive":true,"zoom_enabled":false}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_rainbow","should_aggregate_beyond":20}}`,
+	},
+	{
+		ID:              "top-n-ranking",
+		Name:            "Top N Ranking",
+		Description:     "A bar chart ranking categories by a metric, such as the top 10 customers by spend.",
+		RequiredColumns: []string{"category", "number"},
+		ChartConfigJSON: `{"chart_type":"bar","title":"Top {{category:label}} by {{number:label}}","description":"{{category:label}} ranked by {{number:label}}",
+"chart_render":{"type":"bar","x_axis":{"data_key":"{{category}}","label":"{{category:label}}","type":"category"},
+"y_axis":{"data_key":"{{number}}","label":"{{number:label}}","type":"number"},
+"series":[{"data_key":"{{number}}","name":"{{number:label}}","fill":"#8884d8"}],
+"colors":["#8884d8"],"features":{"tooltip":true,"legend":false,"grid":true,"responsive":true,"zoom_enabled":false}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":50}}`,
+	},
+	{
+		ID:              "grouped-comparison",
+		Name:            "Grouped Comparison",
+		Description:     "A grouped bar chart of two metrics per category, such as orders and returns per region.",
+		RequiredColumns: []string{"category", "number", "number2"},
+		ChartConfigJSON: `{"chart_type":"bar","title":"{{number:label}} and {{number2:label}} by {{category:label}}","description":"{{number:label}} compared with {{number2:label}} per {{category:label}}",
+"chart_render":{"type":"bar","x_axis":{"data_key":"{{category}}","label":"{{category:label}}","type":"category"},
+"y_axis":{"data_key":"{{number}}","label":"Value","type":"number"},
+"series":[{"data_key":"{{number}}","name":"{{number:label}}","fill":"#8884d8"},{"data_key":"{{number2}}","name":"{{number2:label}}","fill":"#82ca9d"}],
+"colors":["#8884d8","#82ca9d"],"features":{"tooltip":true,"legend":true,"grid":true,"responsive":true,"zoom_enabled":false}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":50}}`,
+	},
+	{
+		ID:              "conversion-funnel",
+		Name:            "Conversion Funnel",
+		Description:     "A funnel of counts per stage, in the order of the result rows, such as visits to signups to purchases.",
+		RequiredColumns: []string{"category", "number"},
+		ChartConfigJSON: `{"chart_type":"funnel","title":"{{number:label}} by {{category:label}}","description":"Drop-off between {{category:label}} stages",
+"chart_render":{"type":"funnel","x_axis":{"data_key":"{{category}}","label":"{{category:label}}","type":"category"},
+"funnel":{"stage_key":"{{category}}","value_key":"{{number}}","colors":["#8884d8","#82ca9d","#ffc658","#ff7c7c","#8dd1e1"]},
+"colors":["#8884d8","#82ca9d","#ffc658","#ff7c7c","#8dd1e1"],"features":{"tooltip":true,"legend":false,"grid":false,"responsive":true,"zoom_enabled":false}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_rainbow","should_aggregate_beyond":20}}`,
+	},
+	{
+		ID:              "country-distribution",
+		Name:            "Geographic Distribution",
+		Description:     "A bar chart of a metric per country code, such as customers or revenue by country.",
+		RequiredColumns: []string{"country", "number"},
+		ChartConfigJSON: `{"chart_type":"bar","title":"{{number:label}} by Country","description":"{{number:label}} per {{country:label}}",
+"chart_render":{"type":"bar","x_axis":{"data_key":"{{country}}","label":"{{country:label}}","type":"category"},
+"y_axis":{"data_key":"{{number}}","label":"{{number:label}}","type":"number"},
+"series":[{"data_key":"{{number}}","name":"{{number:label}}","fill":"#8dd1e1"}],
+"colors":["#8dd1e1"],"features":{"tooltip":true,"legend":false,"grid":true,"responsive":true,"zoom_enabled":false}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":250}}`,
+	},
+	{
+		ID:              "correlation-scatter",
+		Name:            "Correlation Scatter",
+		Description:     "A scatter plot of two metrics per row, such as price against units sold.",
+		RequiredColumns: []string{"number", "number2"},
+		ChartConfigJSON: `{"chart_type":"scatter","title":"{{number:label}} vs {{number2:label}}","description":"How {{number2:label}} changes with {{number:label}}",
+"chart_render":{"type":"scatter","x_axis":{"data_key":"{{number}}","label":"{{number:label}}","type":"number"},
+"y_axis":{"data_key":"{{number2}}","label":"{{number2:label}}","type":"number"},
+"series":[{"data_key":"{{number2}}","name":"{{number2:label}}","fill":"#ff7c7c"}],
+"colors":["#ff7c7c"],"features":{"tooltip":true,"legend":false,"grid":true,"responsive":true,"zoom_enabled":true}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":1000}}`,
+	},
+	{
+		ID:              "activity-heatmap",
+		Name:            "Activity Heatmap",
+		Description:     "A heatmap of a metric across two dimensions, such as orders by weekday and hour.",
+		RequiredColumns: []string{"category", "category2", "number"},
+		ChartConfigJSON: `{"chart_type":"heatmap","title":"{{number:label}} by {{category:label}} and {{category2:label}}","description":"{{number:label}} for each {{category:label}} and {{category2:label}}",
+"chart_render":{"type":"heatmap","x_axis":{"data_key":"{{category}}","label":"{{category:label}}","type":"category"},
+"y_axis":{"data_key":"{{category2}}","label":"{{category2:label}}","type":"category"},
+"heatmap":{"x_key":"{{category}}","y_key":"{{category2}}","value_key":"{{number}}"},
+"colors":["#e0f3f8","#abd9e9","#74add1","#4575b4","#313695"],"features":{"tooltip":true,"legend":false,"grid":false,"responsive":true,"zoom_enabled":false}},
+"rendering_hints":{"chart_height":400,"chart_width":"100%","color_scheme":"neobase_primary","should_aggregate_beyond":500}}`,
+	},
+}
+
+// visualizationColumnSampleRows is how many result rows are looked at to tell a column's kind
+const visualizationColumnSampleRows = 50
+
+var (
+	visualizationPlaceholderPattern = regexp.MustCompile(`\{\{([a-z]+[0-9]*)(:label)?\}\}`)
+	yearMonthPattern                = regexp.MustCompile(`^\d{4}-\d{2}$`)
+	countryCodePattern              = regexp.MustCompile(`^[A-Z]{2,3}$`)
+)
+
+// ListVisualizationTemplates returns the templates usable with the results of a database type, or all of them
+func (s *chatService) ListVisualizationTemplates(dbType string) ([]models.VisualizationTemplate, uint32, error) {
+	templates := make([]models.VisualizationTemplate, 0, len(visualizationTemplates))
+	for _, template := range visualizationTemplates {
+		if dbType == "" || template.DBType == "" || template.DBType == dbType {
+			templates = append(templates, template)
+		}
+	}
+	return templates, http.StatusOK, nil
+}
+
+// ApplyVisualizationTemplate builds a chart for a query's result from a template instead of the LLM.
+// The template's placeholders are matched to result columns by kind, and the chart is saved as the query's visualization.
+func (s *chatService) ApplyVisualizationTemplate(ctx context.Context, userID, chatID, messageID, queryID, templateID string) (*dtos.VisualizationResponse, uint32, error) {
+	log.Printf("ChatService -> ApplyVisualizationTemplate -> chatID: %s, messageID: %s, queryID: %s, templateID: %s", chatID, messageID, queryID, templateID)
+
+	var template *models.VisualizationTemplate
+	for i := range visualizationTemplates {
+		if visualizationTemplates[i].ID == templateID {
+			template = &visualizationTemplates[i]
+			break
+		}
+	}
+	if template == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("visualization template not found")
+	}
+
+	chat, statusCode, err := s.findOwnedChat(userID, chatID)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	if template.DBType != "" && template.DBType != chat.Connection.Type {
+		return nil, http.StatusBadRequest, fmt.Errorf("the %s template only applies to %s results", template.Name, template.DBType)
+	}
+
+	messageObjID, err := primitive.ObjectIDFromHex(messageID)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid message ID")
+	}
+	message, err := s.chatRepo.FindMessageByID(messageObjID)
+	if err != nil || message == nil || message.ChatID != chat.ID {
+		return nil, http.StatusNotFound, fmt.Errorf("message not found")
+	}
+
+	var queryData *dtos.Query
+	if queries := s.buildMessageResponse(message).Queries; queries != nil {
+		for i := range *queries {
+			if (*queries)[i].ID == queryID {
+				queryData = &(*queries)[i]
+				break
+			}
+		}
+	}
+	if queryData == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("query not found in message")
+	}
+
+	var rows []map[string]interface{}
+	if len(queryData.ExecutionResult) > 0 {
+		rows = visualizationRows(queryData.ExecutionResult)
+	} else if len(queryData.ExampleResult) > 0 {
+		rows = visualizationRows(queryData.ExampleResult)
+	}
+	if len(rows) == 0 {
+		return &dtos.VisualizationResponse{
+			CanVisualize: false,
+			Reason:       "No data rows returned by the query. Visualization requires at least one data row.",
+		}, http.StatusOK, nil
+	}
+
+	columns, missing := matchTemplateColumns(template.RequiredColumns, rows)
+	if missing != "" {
+		return &dtos.VisualizationResponse{
+			CanVisualize: false,
+			Reason:       fmt.Sprintf("The %s template needs a %s column that the query result doesn't have.", template.Name, strings.TrimRight(missing, "0123456789")),
+		}, http.StatusOK, nil
+	}
+
+	chartConfigJSON := visualizationPlaceholderPattern.ReplaceAllStringFunc(template.ChartConfigJSON, func(token string) string {
+		match := visualizationPlaceholderPattern.FindStringSubmatch(token)
+		value := columns[match[1]]
+		if match[2] != "" {
+			value = humanizeColumnName(value)
+		}
+		// Column names are written inside JSON strings, so they are escaped like one
+		escaped, _ := json.Marshal(value)
+		return string(escaped[1 : len(escaped)-1])
+	})
+
+	var chartConfig dtos.ChartConfiguration
+	if err := json.Unmarshal([]byte(chartConfigJSON), &chartConfig); err != nil {
+		log.Printf("ChatService -> ApplyVisualizationTemplate -> Invalid chart config of template %s: %v", template.ID, err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("the %s template is invalid", template.Name)
+	}
+	chartConfig.DataFetch = dtos.ChartDataFetch{
+		QueryStrategy:  "original_query",
+		OptimizedQuery: queryData.Query,
+		ProjectedRows:  len(rows),
+		Transformation: "none",
+	}
+	chartConfig.RenderingHints.ProjectedRowCount = len(rows)
+
+	visualization := &dtos.VisualizationResponse{
+		CanVisualize:       true,
+		Reason:             fmt.Sprintf("Built from the %s template", template.Name),
+		ChartConfiguration: &chartConfig,
+	}
+
+	vizID, err := s.SaveVisualizationToMessage(ctx, messageID, chatID, userID, visualization, queryID)
+	if err != nil {
+		log.Printf("ChatService -> ApplyVisualizationTemplate -> Warning: Failed to save visualization: %v", err)
+	} else if vizID != "" {
+		visualization.VisualizationID = vizID
+		visualization.UpdatedAt = time.Now().Format(time.RFC3339)
+
+		queryObjID, _ := primitive.ObjectIDFromHex(queryID)
+		vizObjID, _ := primitive.ObjectIDFromHex(vizID)
+		if err := s.chatRepo.UpdateQueryVisualizationID(messageObjID, queryObjID, vizObjID); err != nil {
+			log.Printf("ChatService -> ApplyVisualizationTemplate -> Warning: Failed to link visualization to query: %v", err)
+		}
+	}
+
+	visualization.ChartData = rows
+	visualization.ReturnedCount = len(rows)
+	if queryData.Pagination != nil && queryData.Pagination.TotalRecordsCount > 0 {
+		visualization.TotalRecords = queryData.Pagination.TotalRecordsCount
+		visualization.HasMore = queryData.Pagination.TotalRecordsCount > len(rows)
+	}
+
+	log.Printf("ChatService -> ApplyVisualizationTemplate -> Applied template %s with columns %v", template.ID, columns)
+	return visualization, http.StatusOK, nil
+}
+
+// matchTemplateColumns assigns a distinct result column to each required placeholder, by kind.
+// A country column also serves as a category. It returns the first placeholder left without a column.
+func matchTemplateColumns(required []string, rows []map[string]interface{}) (map[string]string, string) {
+	kinds := classifyVisualizationColumns(rows)
+
+	columnNames := make([]string, 0, len(kinds))
+	for name := range kinds {
+		columnNames = append(columnNames, name)
+	}
+	sort.Strings(columnNames)
+
+	columns := make(map[string]string, len(required))
+	used := make(map[string]bool)
+	for _, placeholder := range required {
+		kind := strings.TrimRight(placeholder, "0123456789")
+		match := ""
+		for _, name := range columnNames {
+			if !used[name] && kinds[name] == kind {
+				match = name
+				break
+			}
+		}
+		if match == "" && kind == "category" {
+			for _, name := range columnNames {
+				if !used[name] && kinds[name] == "country" {
+					match = name
+					break
+				}
+			}
+		}
+		if match == "" {
+			return nil, placeholder
+		}
+		used[match] = true
+		columns[placeholder] = match
+	}
+	return columns, ""
+}
+
+// classifyVisualizationColumns tells whether each result column holds dates, numbers, country codes or categories
+func classifyVisualizationColumns(rows []map[string]interface{}) map[string]string {
+	if len(rows) > visualizationColumnSampleRows {
+		rows = rows[:visualizationColumnSampleRows]
+	}
+
+	kinds := make(map[string]string)
+	for name := range rows[0] {
+		isNumber, isDate, isCountryCode, seen := true, true, true, false
+		for _, row := range rows {
+			value, ok := row[name]
+			if !ok || value == nil {
+				continue
+			}
+			seen = true
+			switch v := value.(type) {
+			case float64, float32, int, int32, int64:
+				isDate, isCountryCode = false, false
+			case string:
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					isNumber = false
+				}
+				if !isDateLike(v) && !yearMonthPattern.MatchString(v) {
+					isDate = false
+				}
+				if !countryCodePattern.MatchString(v) {
+					isCountryCode = false
+				}
+			default:
+				isNumber, isDate, isCountryCode = false, false, false
+			}
+		}
+
+		lowerName := strings.ToLower(name)
+		switch {
+		case !seen:
+			continue
+		case isDate:
+			kinds[name] = "date"
+		case isNumber:
+			kinds[name] = "number"
+		case isCountryCode || strings.Contains(lowerName, "country"):
+			kinds[name] = "country"
+		default:
+			kinds[name] = "category"
+		}
+	}
+	return kinds
+}
+
+// humanizeColumnName turns a column name like total_revenue into the label Total Revenue
+func humanizeColumnName(name string) string {
+	words := strings.Fields(strings.NewReplacer("_", " ", "-", " ", ".", " ").Replace(name))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
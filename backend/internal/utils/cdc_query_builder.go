@@ -0,0 +1,13 @@
+package utils
+
+import "fmt"
+
+// BuildCDCCheckQuery builds the query reading the maximum of a timestamp column and the row count of a table,
+// which CDC watchers compare between checks. Table and column come from the schema and are quoted.
+func BuildCDCCheckQuery(dbType, table, column string) (string, error) {
+	quote, ok := liveCountQuote(dbType)
+	if !ok {
+		return "", fmt.Errorf("CDC watchers are not supported for %s", dbType)
+	}
+	return fmt.Sprintf("SELECT MAX(%s) AS current_max, COUNT(*) AS row_count FROM %s", quote(column), quote(table)), nil
+}
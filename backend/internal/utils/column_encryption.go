@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// EncryptedColumnPrefix marks a column value that was encrypted on its own before the result was stored
+const EncryptedColumnPrefix = "ENCRYPTED:"
+
+// NormalizeColumnNames trims column names and drops empty and duplicate (case-insensitive) entries
+func NormalizeColumnNames(columns []string) []string {
+	normalized := make([]string, 0, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		column = strings.TrimSpace(column)
+		key := strings.ToLower(column)
+		if column == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, column)
+	}
+	return normalized
+}
+
+// EncryptColumns encrypts the values of the listed columns in a JSON query result.
+// Keys are matched case-insensitively at any depth, so both {"results": [...]} and plain
+// arrays of rows are covered. Each value is JSON-encoded, encrypted and stored as
+// "ENCRYPTED:{base64}" so its original type is restored on decryption.
+func (c *AESGCMCrypto) EncryptColumns(result string, columns []string) (string, error) {
+	if result == "" || len(columns) == 0 {
+		return result, nil
+	}
+
+	targets := make(map[string]bool, len(columns))
+	for _, column := range NormalizeColumnNames(columns) {
+		targets[strings.ToLower(column)] = true
+	}
+
+	var data interface{}
+	if err := decodeJSONResult(result, &data); err != nil {
+		return "", err
+	}
+
+	encrypted, err := c.encryptColumnValues(data, targets)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJSONResult(encrypted)
+}
+
+// DecryptColumns restores every "ENCRYPTED:" value in a JSON query result.
+// Values are recognised by their prefix, so the column list is not needed.
+func (c *AESGCMCrypto) DecryptColumns(result string) (string, error) {
+	if !strings.Contains(result, EncryptedColumnPrefix) {
+		return result, nil
+	}
+
+	var data interface{}
+	if err := decodeJSONResult(result, &data); err != nil {
+		return "", err
+	}
+
+	decrypted, err := c.decryptColumnValues(data)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJSONResult(decrypted)
+}
+
+func (c *AESGCMCrypto) encryptColumnValues(value interface{}, targets map[string]bool) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if targets[strings.ToLower(key)] {
+				if child == nil {
+					continue
+				}
+				raw, err := json.Marshal(child)
+				if err != nil {
+					return nil, err
+				}
+				encrypted, err := c.Encrypt(string(raw))
+				if err != nil {
+					return nil, err
+				}
+				v[key] = EncryptedColumnPrefix + encrypted
+				continue
+			}
+			encrypted, err := c.encryptColumnValues(child, targets)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = encrypted
+		}
+		return v, nil
+	case []interface{}:
+		for i, child := range v {
+			encrypted, err := c.encryptColumnValues(child, targets)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = encrypted
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+func (c *AESGCMCrypto) decryptColumnValues(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.HasPrefix(v, EncryptedColumnPrefix) {
+			return v, nil
+		}
+		raw, err := c.Decrypt(strings.TrimPrefix(v, EncryptedColumnPrefix))
+		if err != nil {
+			return nil, err
+		}
+		var original interface{}
+		if err := decodeJSONResult(raw, &original); err != nil {
+			return nil, err
+		}
+		return original, nil
+	case map[string]interface{}:
+		for key, child := range v {
+			decrypted, err := c.decryptColumnValues(child)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = decrypted
+		}
+		return v, nil
+	case []interface{}:
+		for i, child := range v {
+			decrypted, err := c.decryptColumnValues(child)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = decrypted
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+// decodeJSONResult keeps numbers as json.Number so large integers survive the round trip
+func decodeJSONResult(data string, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func encodeJSONResult(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -109,6 +109,68 @@ func EncryptConnection(conn *models.Connection) error {
 		}
 	}
 
+	// Encrypt Supabase API keys if present
+	if conn.SupabaseAnonKey != nil {
+		if encryptedKey, err := encrypt(*conn.SupabaseAnonKey, key); err == nil {
+			*conn.SupabaseAnonKey = encryptedKey
+		} else {
+			return fmt.Errorf("failed to encrypt Supabase anon key: %v", err)
+		}
+	}
+
+	if conn.SupabaseServiceRoleKey != nil {
+		if encryptedKey, err := encrypt(*conn.SupabaseServiceRoleKey, key); err == nil {
+			*conn.SupabaseServiceRoleKey = encryptedKey
+		} else {
+			return fmt.Errorf("failed to encrypt Supabase service role key: %v", err)
+		}
+	}
+
+	// Encrypt Airtable personal access token if present
+	if conn.AirtableAPIKey != nil {
+		if encryptedKey, err := encrypt(*conn.AirtableAPIKey, key); err == nil {
+			*conn.AirtableAPIKey = encryptedKey
+		} else {
+			return fmt.Errorf("failed to encrypt Airtable API key: %v", err)
+		}
+	}
+
+	// Encrypt PlanetScale service token if present
+	if conn.PlanetscaleServiceToken != nil {
+		if encryptedToken, err := encrypt(*conn.PlanetscaleServiceToken, key); err == nil {
+			*conn.PlanetscaleServiceToken = encryptedToken
+		} else {
+			return fmt.Errorf("failed to encrypt PlanetScale service token: %v", err)
+		}
+	}
+
+	// Encrypt InfluxDB API token if present
+	if conn.InfluxToken != nil {
+		if encryptedToken, err := encrypt(*conn.InfluxToken, key); err == nil {
+			*conn.InfluxToken = encryptedToken
+		} else {
+			return fmt.Errorf("failed to encrypt InfluxDB token: %v", err)
+		}
+	}
+
+	// Encrypt Firestore service account JSON if present
+	if conn.FirestoreCredentialsJSON != nil {
+		if encryptedCredentials, err := encrypt(*conn.FirestoreCredentialsJSON, key); err == nil {
+			*conn.FirestoreCredentialsJSON = encryptedCredentials
+		} else {
+			return fmt.Errorf("failed to encrypt Firestore credentials: %v", err)
+		}
+	}
+
+	// Encrypt Cloudflare API token if present
+	if conn.CloudflareAPIToken != nil {
+		if encryptedToken, err := encrypt(*conn.CloudflareAPIToken, key); err == nil {
+			*conn.CloudflareAPIToken = encryptedToken
+		} else {
+			return fmt.Errorf("failed to encrypt Cloudflare API token: %v", err)
+		}
+	}
+
 	// Encrypt SSH authentication fields if present
 	if conn.SSHPrivateKey != nil {
 		if encryptedKey, err := encrypt(*conn.SSHPrivateKey, key); err == nil {
@@ -242,6 +304,68 @@ func DecryptConnection(conn *models.Connection) {
 		}
 	}
 
+	// Decrypt Supabase API keys if present
+	if conn.SupabaseAnonKey != nil {
+		if decryptedKey, err := decrypt(*conn.SupabaseAnonKey, key); err == nil {
+			*conn.SupabaseAnonKey = decryptedKey
+		} else {
+			log.Printf("Warning: Failed to decrypt Supabase anon key, using as-is: %v", err)
+		}
+	}
+
+	if conn.SupabaseServiceRoleKey != nil {
+		if decryptedKey, err := decrypt(*conn.SupabaseServiceRoleKey, key); err == nil {
+			*conn.SupabaseServiceRoleKey = decryptedKey
+		} else {
+			log.Printf("Warning: Failed to decrypt Supabase service role key, using as-is: %v", err)
+		}
+	}
+
+	// Decrypt Airtable personal access token if present
+	if conn.AirtableAPIKey != nil {
+		if decryptedKey, err := decrypt(*conn.AirtableAPIKey, key); err == nil {
+			*conn.AirtableAPIKey = decryptedKey
+		} else {
+			log.Printf("Warning: Failed to decrypt Airtable API key, using as-is: %v", err)
+		}
+	}
+
+	// Decrypt PlanetScale service token if present
+	if conn.PlanetscaleServiceToken != nil {
+		if decryptedToken, err := decrypt(*conn.PlanetscaleServiceToken, key); err == nil {
+			*conn.PlanetscaleServiceToken = decryptedToken
+		} else {
+			log.Printf("Warning: Failed to decrypt PlanetScale service token, using as-is: %v", err)
+		}
+	}
+
+	// Decrypt InfluxDB API token if present
+	if conn.InfluxToken != nil {
+		if decryptedToken, err := decrypt(*conn.InfluxToken, key); err == nil {
+			*conn.InfluxToken = decryptedToken
+		} else {
+			log.Printf("Warning: Failed to decrypt InfluxDB token, using as-is: %v", err)
+		}
+	}
+
+	// Decrypt Firestore service account JSON if present
+	if conn.FirestoreCredentialsJSON != nil {
+		if decryptedCredentials, err := decrypt(*conn.FirestoreCredentialsJSON, key); err == nil {
+			*conn.FirestoreCredentialsJSON = decryptedCredentials
+		} else {
+			log.Printf("Warning: Failed to decrypt Firestore credentials, using as-is: %v", err)
+		}
+	}
+
+	// Decrypt Cloudflare API token if present
+	if conn.CloudflareAPIToken != nil {
+		if decryptedToken, err := decrypt(*conn.CloudflareAPIToken, key); err == nil {
+			*conn.CloudflareAPIToken = decryptedToken
+		} else {
+			log.Printf("Warning: Failed to decrypt Cloudflare API token, using as-is: %v", err)
+		}
+	}
+
 	// Decrypt SSH authentication fields if present
 	if conn.SSHPrivateKey != nil {
 		if decryptedKey, err := decrypt(*conn.SSHPrivateKey, key); err == nil {
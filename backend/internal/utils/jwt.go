@@ -11,6 +11,16 @@ type JWTService interface {
 	GenerateToken(userID string) (*string, error)
 	GenerateRefreshToken(userID string) (*string, error)
 	ValidateToken(token string) (*string, error)
+	GenerateAPIKey(userID, keyID string, duration time.Duration) (*string, error)
+	ParseToken(token string) (*TokenClaims, error)
+}
+
+// TokenClaims are the claims of a validated token. Type is "api_key" for API keys and empty for session tokens.
+type TokenClaims struct {
+	UserID    string
+	Type      string
+	ID        string // jti, only set for API keys
+	ExpiresAt time.Time
 }
 
 type jwtService struct {
@@ -79,3 +89,60 @@ func (s *jwtService) ValidateToken(tokenString string) (*string, error) {
 
 	return nil, err
 }
+
+// GenerateAPIKey generates a long-lived token for programmatic access, identified by keyID so it can be revoked
+func (s *jwtService) GenerateAPIKey(userID, keyID string, duration time.Duration) (*string, error) {
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"type":    "api_key",
+		"jti":     keyID,
+		"iat":     time.Now().Unix(),
+		"iss":     "neobase-ai",
+		"exp":     time.Now().Add(duration).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(s.secretKey))
+	if err != nil {
+		return nil, err
+	}
+	return &tokenString, nil
+}
+
+// ParseToken validates a token and returns its claims, including the token type and ID of API keys
+func (s *jwtService) ParseToken(tokenString string) (*TokenClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.secretKey), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, errors.New("token has no user ID")
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("token has no expiry")
+	}
+	expiresAt := time.Unix(int64(exp), 0)
+	if expiresAt.Before(time.Now()) {
+		return nil, errors.New("token has expired")
+	}
+
+	tokenType, _ := claims["type"].(string)
+	tokenID, _ := claims["jti"].(string)
+	return &TokenClaims{
+		UserID:    userID,
+		Type:      tokenType,
+		ID:        tokenID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
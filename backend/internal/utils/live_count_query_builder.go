@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"neobase-ai/internal/constants"
+)
+
+// liveCountKeywords are the only keywords a live count WHERE clause may contain
+var liveCountKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "IN": true, "BETWEEN": true, "LIKE": true,
+	"IS": true, "NULL": true, "TRUE": true, "FALSE": true,
+}
+
+// liveCountOperators are the comparison operators, longest first so <= is not read as <
+var liveCountOperators = []string{"<=", ">=", "<>", "!=", "=", "<", ">"}
+
+// BuildLiveCountQuery builds the query counting the rows of a table that match a WHERE clause typed by the user.
+// The clause may only hold the table's columns, literals, comparison operators, AND, OR, NOT, IN, BETWEEN, LIKE
+// and IS NULL, so it cannot carry a statement, subquery, function call or comment of its own.
+// Table and columns come from the schema, the count is always the whole query.
+func BuildLiveCountQuery(dbType, table string, columns []string, whereClause string) (string, error) {
+	quote, ok := liveCountQuote(dbType)
+	if !ok {
+		return "", fmt.Errorf("live counts are not supported for %s", dbType)
+	}
+
+	whereClause = strings.TrimSpace(whereClause)
+	if whereClause == "" {
+		return "", fmt.Errorf("the WHERE clause is empty")
+	}
+	if err := validateLiveCountWhereClause(whereClause, columns); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("SELECT COUNT(*) AS match_count FROM %s WHERE %s", quote(table), whereClause), nil
+}
+
+// liveCountQuote returns how identifiers are quoted for the SQL databases live counts run on
+func liveCountQuote(dbType string) (func(string) string, bool) {
+	switch dbType {
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale, constants.DatabaseTypeClickhouse:
+		return func(name string) string { return "`" + strings.ReplaceAll(name, "`", "``") + "`" }, true
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeTrino, constants.DatabaseTypeOracle,
+		constants.DatabaseTypeInfluxDB, constants.DatabaseTypeCloudflareD1, constants.DatabaseTypeSpreadsheet,
+		constants.DatabaseTypeGoogleSheets:
+		return func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` }, true
+	default:
+		return nil, false
+	}
+}
+
+// validateLiveCountWhereClause reads the clause token by token and rejects anything outside the whitelist.
+// Unquoted column names match case-insensitively, quoted ones exactly.
+func validateLiveCountWhereClause(whereClause string, columns []string) error {
+	exact := make(map[string]bool, len(columns))
+	folded := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		exact[column] = true
+		folded[strings.ToLower(column)] = true
+	}
+
+	runes := []rune(whereClause)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '\'':
+			// String literal, a doubled quote escapes a quote. MySQL also escapes with backslashes,
+			// which would end the literal somewhere else than here, so they are refused.
+			end := i + 1
+			for ; end < len(runes); end++ {
+				if runes[end] == '\\' {
+					return fmt.Errorf("backslashes are not allowed in string literals")
+				}
+				if runes[end] == '\'' {
+					if end+1 < len(runes) && runes[end+1] == '\'' {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			if end >= len(runes) {
+				return fmt.Errorf("unterminated string literal")
+			}
+			i = end + 1
+
+		case r == '"' || r == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return fmt.Errorf("unterminated quoted identifier")
+			}
+			name := string(runes[i+1 : end])
+			if !exact[name] {
+				return fmt.Errorf("unknown column %s", name)
+			}
+			i = end + 1
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			if end < len(runes) && (unicode.IsLetter(runes[end]) || runes[end] == '_') {
+				return fmt.Errorf("invalid number %s", string(runes[i:end+1]))
+			}
+			i = end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			word := string(runes[i:end])
+			if !liveCountKeywords[strings.ToUpper(word)] && !folded[strings.ToLower(word)] {
+				if end < len(runes) && runes[end] == '(' {
+					return fmt.Errorf("function calls such as %s() are not allowed", word)
+				}
+				return fmt.Errorf("%s is neither a column of the table nor one of AND, OR, NOT, IN, BETWEEN, LIKE, IS and NULL", word)
+			}
+			i = end
+
+		case r == '(' || r == ')' || r == ',':
+			i++
+
+		default:
+			operator := ""
+			for _, candidate := range liveCountOperators {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if operator == "" {
+				return fmt.Errorf("%q is not allowed in the WHERE clause", r)
+			}
+			i += len([]rune(operator))
+		}
+	}
+	return nil
+}
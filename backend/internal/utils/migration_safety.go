@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"neobase-ai/internal/constants"
+)
+
+// MigrationRisk is the risk assessment of a DDL query, made before it is executed
+type MigrationRisk struct {
+	RiskLevel             string `json:"riskLevel"` // low, medium or high
+	Reason                string `json:"reason"`
+	EstimatedLockDuration string `json:"estimatedLockDuration"`
+}
+
+var (
+	sqlLineCommentPattern  = regexp.MustCompile(`--[^\n]*`)
+	sqlBlockCommentPattern = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+	ddlStatementPattern       = regexp.MustCompile(`(?i)^\s*(CREATE|ALTER|DROP|TRUNCATE|RENAME)\b`)
+	ddlCreateTablePattern     = regexp.MustCompile(`(?i)^\s*CREATE\s+(TEMP(ORARY)?\s+|UNLOGGED\s+)?TABLE\b`)
+	ddlCreateIndexPattern     = regexp.MustCompile(`(?i)^\s*CREATE\s+(UNIQUE\s+)?INDEX\b`)
+	ddlConcurrentlyPattern    = regexp.MustCompile(`(?i)\bCONCURRENTLY\b`)
+	ddlDropTablePattern       = regexp.MustCompile(`(?i)^\s*(DROP\s+(TABLE|DATABASE|SCHEMA)|TRUNCATE)\b`)
+	ddlDropIndexPattern       = regexp.MustCompile(`(?i)^\s*DROP\s+INDEX\b`)
+	ddlAlterTablePattern      = regexp.MustCompile(`(?i)^\s*ALTER\s+TABLE\b`)
+	ddlAddColumnPattern       = regexp.MustCompile(`(?i)\bADD\s+(COLUMN\s+)?(IF\s+NOT\s+EXISTS\s+)?["` + "`" + `]?\w+["` + "`" + `]?\s+\w+`)
+	ddlAddConstraintPattern   = regexp.MustCompile(`(?i)\bADD\s+(CONSTRAINT\b|PRIMARY\s+KEY\b|UNIQUE\b|FOREIGN\s+KEY\b|CHECK\b)`)
+	ddlNotNullPattern         = regexp.MustCompile(`(?i)\bNOT\s+NULL\b`)
+	ddlDefaultPattern         = regexp.MustCompile(`(?i)\bDEFAULT\b`)
+	ddlVolatileDefaultPattern = regexp.MustCompile(`(?i)\bDEFAULT\s+(gen_random_uuid|uuid_generate_v\d|random|clock_timestamp|nextval)\s*\(`)
+	ddlNotValidPattern        = regexp.MustCompile(`(?i)\bNOT\s+VALID\b`)
+	ddlAlterTypePattern       = regexp.MustCompile(`(?i)\bALTER\s+(COLUMN\s+)?["` + "`" + `]?\w+["` + "`" + `]?\s+(SET\s+DATA\s+)?TYPE\b`)
+	ddlSetNotNullPattern      = regexp.MustCompile(`(?i)\bSET\s+NOT\s+NULL\b`)
+	ddlDropColumnAttrPattern  = regexp.MustCompile(`(?i)\bDROP\s+(DEFAULT|NOT\s+NULL|IDENTITY|EXPRESSION)\b`)
+	ddlDropColumnPattern      = regexp.MustCompile(`(?i)\bDROP\s+(COLUMN\b|["` + "`" + `]?\w+["` + "`" + `]?\s*(,|$|CASCADE|RESTRICT))`)
+	ddlRenamePattern          = regexp.MustCompile(`(?i)\bRENAME\b`)
+	ddlInstantPattern         = regexp.MustCompile(`(?i)\bALGORITHM\s*=\s*INSTANT\b`)
+	ddlModifyColumnPattern    = regexp.MustCompile(`(?i)\b(MODIFY|CHANGE)\s+(COLUMN\s+)?`)
+)
+
+// MigrationSafetyChecker scores DDL queries for the locking and data loss risk of running them
+type MigrationSafetyChecker struct{}
+
+// NewMigrationSafetyChecker creates a migration safety checker
+func NewMigrationSafetyChecker() *MigrationSafetyChecker {
+	return &MigrationSafetyChecker{}
+}
+
+// Check returns the risk of the riskiest DDL statement in the query, or nil if the query has no DDL
+// or the database type is not checked
+func (c *MigrationSafetyChecker) Check(query, dbType string) *MigrationRisk {
+	var checkStatement func(string) *MigrationRisk
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
+		checkStatement = checkPostgreSQLMigration
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypePlanetscale:
+		checkStatement = checkMySQLMigration
+	case constants.DatabaseTypeStarRocks, constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypeOracle:
+		checkStatement = checkGenericMigration
+	default:
+		return nil
+	}
+
+	var riskiest *MigrationRisk
+	for _, statement := range splitDDLStatements(query) {
+		if !ddlStatementPattern.MatchString(statement) {
+			continue
+		}
+		risk := checkStatement(statement)
+		if risk == nil {
+			continue
+		}
+		if riskiest == nil || constants.MigrationRiskRank[risk.RiskLevel] > constants.MigrationRiskRank[riskiest.RiskLevel] {
+			riskiest = risk
+		}
+	}
+	return riskiest
+}
+
+// checkPostgreSQLMigration scores a statement by the lock PostgreSQL takes and whether it rewrites the table
+func checkPostgreSQLMigration(statement string) *MigrationRisk {
+	if risk := checkDestructiveMigration(statement); risk != nil {
+		return risk
+	}
+
+	switch {
+	case ddlCreateTablePattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskLow, "Creating a new table does not lock existing tables.", constants.MigrationLockNone}
+	case ddlCreateIndexPattern.MatchString(statement):
+		if ddlConcurrentlyPattern.MatchString(statement) {
+			return &MigrationRisk{constants.MigrationRiskLow, "CREATE INDEX CONCURRENTLY builds the index without blocking writes, but takes longer and cannot run inside a transaction.", constants.MigrationLockNone}
+		}
+		return &MigrationRisk{constants.MigrationRiskMedium, "CREATE INDEX blocks writes to the table until the index is built. Use CREATE INDEX CONCURRENTLY on tables in use.", constants.MigrationLockTableSize}
+	case ddlDropIndexPattern.MatchString(statement):
+		if ddlConcurrentlyPattern.MatchString(statement) {
+			return &MigrationRisk{constants.MigrationRiskLow, "DROP INDEX CONCURRENTLY removes the index without blocking queries.", constants.MigrationLockNone}
+		}
+		return &MigrationRisk{constants.MigrationRiskMedium, "DROP INDEX takes an exclusive lock on the table and queries relying on the index may slow down. Use DROP INDEX CONCURRENTLY on tables in use.", constants.MigrationLockMetadataOnly}
+	case !ddlAlterTablePattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskMedium, "Schema changes take an exclusive lock on the objects they change.", constants.MigrationLockMetadataOnly}
+	}
+
+	switch {
+	case ddlAlterTypePattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskHigh, "Changing a column type rewrites the whole table under an ACCESS EXCLUSIVE lock, blocking all reads and writes.", constants.MigrationLockTableSize}
+	case ddlAddColumnPattern.MatchString(statement) && !ddlAddConstraintPattern.MatchString(statement):
+		notNull := ddlNotNullPattern.MatchString(statement)
+		hasDefault := ddlDefaultPattern.MatchString(statement)
+		switch {
+		case notNull && !hasDefault:
+			return &MigrationRisk{constants.MigrationRiskHigh, "Adding a NOT NULL column without a DEFAULT fails on tables with rows, and backfilling it requires a table rewrite. Add it as nullable, backfill, then set NOT NULL.", constants.MigrationLockTableSize}
+		case ddlVolatileDefaultPattern.MatchString(statement):
+			return &MigrationRisk{constants.MigrationRiskHigh, "Adding a column with a volatile DEFAULT rewrites the whole table under an ACCESS EXCLUSIVE lock.", constants.MigrationLockTableSize}
+		case hasDefault:
+			return &MigrationRisk{constants.MigrationRiskLow, "Adding a column with a constant DEFAULT is a metadata-only change on PostgreSQL 11 and later.", constants.MigrationLockMetadataOnly}
+		default:
+			return &MigrationRisk{constants.MigrationRiskLow, "Adding a nullable column is a metadata-only change.", constants.MigrationLockMetadataOnly}
+		}
+	case ddlSetNotNullPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskMedium, "SET NOT NULL scans the whole table under an ACCESS EXCLUSIVE lock to check existing rows.", constants.MigrationLockTableSize}
+	case ddlAddConstraintPattern.MatchString(statement):
+		if ddlNotValidPattern.MatchString(statement) {
+			return &MigrationRisk{constants.MigrationRiskLow, "Constraints added NOT VALID only apply to new rows; validate them later with VALIDATE CONSTRAINT.", constants.MigrationLockMetadataOnly}
+		}
+		return &MigrationRisk{constants.MigrationRiskMedium, "Adding a constraint scans the whole table while holding a lock. Add it NOT VALID and validate it separately on large tables.", constants.MigrationLockTableSize}
+	case ddlDropColumnAttrPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskLow, "Dropping a column default or NOT NULL constraint is a metadata-only change.", constants.MigrationLockMetadataOnly}
+	case ddlDropColumnPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskHigh, "Dropping a column permanently deletes its data and breaks queries that still use it.", constants.MigrationLockMetadataOnly}
+	case ddlRenamePattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskMedium, "Renaming breaks application queries that use the old name.", constants.MigrationLockMetadataOnly}
+	default:
+		return &MigrationRisk{constants.MigrationRiskMedium, "ALTER TABLE takes an ACCESS EXCLUSIVE lock on the table, blocking reads and writes while it runs.", constants.MigrationLockMetadataOnly}
+	}
+}
+
+// checkMySQLMigration scores a statement by InnoDB's online DDL behaviour
+func checkMySQLMigration(statement string) *MigrationRisk {
+	if risk := checkDestructiveMigration(statement); risk != nil {
+		return risk
+	}
+
+	switch {
+	case ddlCreateTablePattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskLow, "Creating a new table does not lock existing tables.", constants.MigrationLockNone}
+	case ddlCreateIndexPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskMedium, "InnoDB builds indexes online, but the build competes with traffic and needs a metadata lock at the start and end.", constants.MigrationLockTableSize}
+	case !ddlAlterTablePattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskMedium, "Schema changes take a metadata lock on the objects they change.", constants.MigrationLockMetadataOnly}
+	case ddlInstantPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskLow, "ALGORITHM=INSTANT only changes metadata, the statement fails instead of copying the table if it cannot run instantly.", constants.MigrationLockMetadataOnly}
+	case ddlModifyColumnPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskHigh, "Changing a column definition copies the whole table and blocks writes while InnoDB rebuilds it.", constants.MigrationLockTableSize}
+	case ddlDropColumnAttrPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskLow, "Dropping a column default only changes table metadata.", constants.MigrationLockMetadataOnly}
+	case ddlDropColumnPattern.MatchString(statement):
+		return &MigrationRisk{constants.MigrationRiskHigh, "Dropping a column permanently deletes its data and rebuilds the table.", constants.MigrationLockTableSize}
+	default:
+		return &MigrationRisk{constants.MigrationRiskMedium, "ALTER TABLE on InnoDB may rebuild the table online and takes a metadata lock that queues behind long-running transactions.", constants.MigrationLockTableSize}
+	}
+}
+
+// checkGenericMigration scores a statement for databases without a dialect-specific checker
+func checkGenericMigration(statement string) *MigrationRisk {
+	if risk := checkDestructiveMigration(statement); risk != nil {
+		return risk
+	}
+	if ddlCreateTablePattern.MatchString(statement) {
+		return &MigrationRisk{constants.MigrationRiskLow, "Creating a new table does not affect existing tables.", constants.MigrationLockNone}
+	}
+	return &MigrationRisk{constants.MigrationRiskMedium, "Schema changes may lock or rewrite the objects they change.", constants.MigrationLockUntilDone}
+}
+
+// checkDestructiveMigration flags statements that delete tables or all of their rows
+func checkDestructiveMigration(statement string) *MigrationRisk {
+	if ddlDropTablePattern.MatchString(statement) {
+		return &MigrationRisk{constants.MigrationRiskHigh, "This permanently deletes the data it drops or truncates and cannot be undone without a backup.", constants.MigrationLockUntilDone}
+	}
+	return nil
+}
+
+// splitDDLStatements splits a query into statements, ignoring comments and semicolons inside string literals
+func splitDDLStatements(query string) []string {
+	unquoted := sqlStringLiteralPattern.ReplaceAllString(query, "''")
+	unquoted = sqlBlockCommentPattern.ReplaceAllString(unquoted, " ")
+	unquoted = sqlLineCommentPattern.ReplaceAllString(unquoted, " ")
+
+	var statements []string
+	for _, statement := range strings.Split(unquoted, ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
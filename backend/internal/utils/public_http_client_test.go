@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePublicHost(t *testing.T) {
+	for _, host := range []string{"127.0.0.1", "169.254.169.254", "localhost"} {
+		if err := ValidatePublicHost(context.Background(), host); err == nil {
+			t.Errorf("ValidatePublicHost(%s) = nil, want an error", host)
+		}
+	}
+}
+
+func TestNewPublicHTTPClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	resp, err := NewPublicHTTPClient(5 * time.Second).Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("request to %s succeeded, want it refused", server.URL)
+	}
+}
+
+func TestNewPublicHTTPClientDoesNotFollowRedirects(t *testing.T) {
+	client := NewPublicHTTPClient(5 * time.Second)
+	req := httptest.NewRequest(http.MethodPost, "https://hooks.example.com/", nil)
+	if err := client.CheckRedirect(req, []*http.Request{req}); err != http.ErrUseLastResponse {
+		t.Errorf("CheckRedirect() = %v, want http.ErrUseLastResponse", err)
+	}
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"neobase-ai/internal/constants"
+)
+
+// QueryComplexity is the result of scoring a query before it is executed
+type QueryComplexity struct {
+	Score   string   // low, medium, high or extreme
+	Points  int      // Sum of the points of every finding
+	Reasons []string // Human readable findings behind the score
+}
+
+var (
+	sqlStringLiteralPattern = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'`)
+	sqlJoinPattern          = regexp.MustCompile(`(?i)\bJOIN\b`)
+	sqlCrossJoinPattern     = regexp.MustCompile(`(?i)\bCROSS\s+JOIN\b`)
+	sqlSubqueryPattern      = regexp.MustCompile(`(?i)\(\s*SELECT\b`)
+	sqlWherePattern         = regexp.MustCompile(`(?i)\bWHERE\b`)
+	sqlLimitPattern         = regexp.MustCompile(`(?i)\b(LIMIT|TOP|FETCH\s+FIRST)\b`)
+	sqlWritePattern         = regexp.MustCompile(`(?i)^\s*(UPDATE|DELETE)\b`)
+	sqlTablePattern         = regexp.MustCompile("(?i)\\b(?:FROM|JOIN|UPDATE|INTO)\\s+([A-Za-z0-9_.\"`\\[\\]]+)")
+
+	mongoLookupPattern      = regexp.MustCompile(`\$lookup\b`)
+	mongoGraphLookupPattern = regexp.MustCompile(`\$graphLookup\b`)
+	mongoUnwindPattern      = regexp.MustCompile(`\$unwind\b`)
+	mongoMatchPattern       = regexp.MustCompile(`\$match\b`)
+	mongoCollectionPattern  = regexp.MustCompile(`db\.(?:getCollection\(\s*["']([^"']+)["']\s*\)|([A-Za-z0-9_]+))\.`)
+	mongoEmptyFindPattern   = regexp.MustCompile(`\.find\(\s*(\{\s*\})?\s*[,)]`)
+	mongoEmptyWritePattern  = regexp.MustCompile(`\.(updateMany|deleteMany)\(\s*\{\s*\}`)
+)
+
+// QueryComplexityScorer assigns a complexity score to a query from text heuristics and the schema's row counts
+type QueryComplexityScorer struct {
+	tableRowCounts map[string]int64
+}
+
+// NewQueryComplexityScorer creates a scorer, tableRowCounts maps table or collection names to their estimated row counts
+func NewQueryComplexityScorer(tableRowCounts map[string]int64) *QueryComplexityScorer {
+	counts := make(map[string]int64, len(tableRowCounts))
+	for name, rows := range tableRowCounts {
+		counts[normalizeComplexityTableName(name)] = rows
+	}
+	return &QueryComplexityScorer{tableRowCounts: counts}
+}
+
+// Score returns the complexity of the query, or nil for database types that are not scored
+func (s *QueryComplexityScorer) Score(query, dbType string) *QueryComplexity {
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks,
+		constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeOracle, constants.DatabaseTypeInfluxDB, constants.DatabaseTypeCloudflareD1, constants.DatabaseTypeSpreadsheet,
+		constants.DatabaseTypeGoogleSheets:
+		return s.scoreSQL(query)
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		return s.scoreMongoDB(query)
+	default:
+		return nil
+	}
+}
+
+func (s *QueryComplexityScorer) scoreSQL(query string) *QueryComplexity {
+	result := &QueryComplexity{}
+	// Keywords inside string literals must not count
+	unquoted := sqlStringLiteralPattern.ReplaceAllString(query, "''")
+
+	crossJoins := len(sqlCrossJoinPattern.FindAllString(unquoted, -1))
+	joins := len(sqlJoinPattern.FindAllString(unquoted, -1)) - crossJoins
+	if joins > 0 {
+		result.add(joins, fmt.Sprintf("%d JOIN(s)", joins))
+	}
+	if crossJoins > 0 {
+		result.add(crossJoins*4, fmt.Sprintf("%d CROSS JOIN(s)", crossJoins))
+	}
+	if subqueries := len(sqlSubqueryPattern.FindAllString(unquoted, -1)); subqueries > 0 {
+		result.add(subqueries*2, fmt.Sprintf("%d subquery(ies)", subqueries))
+	}
+
+	if !sqlWherePattern.MatchString(unquoted) {
+		isWrite := sqlWritePattern.MatchString(unquoted)
+		hasLimit := sqlLimitPattern.MatchString(unquoted)
+		for _, match := range sqlTablePattern.FindAllStringSubmatch(unquoted, -1) {
+			table, rows, large := s.largeTable(match[1])
+			if !large {
+				continue
+			}
+			if isWrite {
+				result.add(constants.QueryComplexityExtremePoints, fmt.Sprintf("writes every row of %s (~%d rows) without a WHERE clause", table, rows))
+			} else if !hasLimit {
+				result.add(5, fmt.Sprintf("scans all of %s (~%d rows) without a WHERE clause or LIMIT", table, rows))
+			}
+			break
+		}
+	}
+
+	result.Score = complexityLevel(result.Points)
+	return result
+}
+
+func (s *QueryComplexityScorer) scoreMongoDB(query string) *QueryComplexity {
+	result := &QueryComplexity{}
+
+	if lookups := len(mongoLookupPattern.FindAllString(query, -1)); lookups > 0 {
+		result.add(lookups*3, fmt.Sprintf("%d $lookup stage(s)", lookups))
+	}
+	if graphLookups := len(mongoGraphLookupPattern.FindAllString(query, -1)); graphLookups > 0 {
+		result.add(graphLookups*4, fmt.Sprintf("%d $graphLookup stage(s)", graphLookups))
+	}
+	if unwinds := len(mongoUnwindPattern.FindAllString(query, -1)); unwinds > 0 {
+		result.add(unwinds, fmt.Sprintf("%d $unwind stage(s)", unwinds))
+	}
+
+	if match := mongoCollectionPattern.FindStringSubmatch(query); match != nil {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		if collection, rows, large := s.largeTable(name); large {
+			isAggregate := strings.Contains(query, ".aggregate(")
+			switch {
+			case mongoEmptyWritePattern.MatchString(query):
+				result.add(constants.QueryComplexityExtremePoints, fmt.Sprintf("writes every document of %s (~%d documents) with an empty filter", collection, rows))
+			case isAggregate && !mongoMatchPattern.MatchString(query),
+				!isAggregate && mongoEmptyFindPattern.MatchString(query) && !strings.Contains(query, ".limit("):
+				result.add(5, fmt.Sprintf("scans the whole %s collection (~%d documents)", collection, rows))
+			}
+		}
+	}
+
+	result.Score = complexityLevel(result.Points)
+	return result
+}
+
+// largeTable looks up a referenced table in the schema row counts
+func (s *QueryComplexityScorer) largeTable(ref string) (string, int64, bool) {
+	name := normalizeComplexityTableName(ref)
+	rows, ok := s.tableRowCounts[name]
+	return name, rows, ok && rows >= constants.QueryComplexityLargeTableRows
+}
+
+func (c *QueryComplexity) add(points int, reason string) {
+	c.Points += points
+	c.Reasons = append(c.Reasons, reason)
+}
+
+// normalizeComplexityTableName strips quoting and the schema or database qualifier from a table reference
+func normalizeComplexityTableName(ref string) string {
+	name := strings.Trim(ref, "\"`[];")
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.ToLower(strings.Trim(name, "\"`[]"))
+}
+
+func complexityLevel(points int) string {
+	switch {
+	case points >= constants.QueryComplexityExtremePoints:
+		return constants.QueryComplexityExtreme
+	case points >= constants.QueryComplexityHighPoints:
+		return constants.QueryComplexityHigh
+	case points >= constants.QueryComplexityMediumPoints:
+		return constants.QueryComplexityMedium
+	default:
+		return constants.QueryComplexityLow
+	}
+}
@@ -0,0 +1,427 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+)
+
+// Query styles a chat can ask for
+const (
+	QueryStyleJoinInner    = "INNER JOIN"
+	QueryStyleJoinPlain    = "JOIN"
+	QueryStyleKeywordUpper = "upper"
+	QueryStyleKeywordLower = "lower"
+)
+
+// queryStyleAliasSuffixPattern keeps alias suffixes to characters that never need quoting
+var queryStyleAliasSuffixPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,16}$`)
+
+// ValidateQueryStyle checks the values of a chat's query style, empty values leave that part of queries as it is
+func ValidateQueryStyle(style *models.QueryStyleConfig) error {
+	if style == nil {
+		return nil
+	}
+	if style.JoinStyle != "" && style.JoinStyle != QueryStyleJoinInner && style.JoinStyle != QueryStyleJoinPlain {
+		return fmt.Errorf("joinStyle must be %q or %q", QueryStyleJoinInner, QueryStyleJoinPlain)
+	}
+	if style.Indentation != 0 && style.Indentation != 2 && style.Indentation != 4 {
+		return fmt.Errorf("indentation must be 2 or 4")
+	}
+	if style.KeywordCase != "" && style.KeywordCase != QueryStyleKeywordUpper && style.KeywordCase != QueryStyleKeywordLower {
+		return fmt.Errorf("keywordCase must be %q or %q", QueryStyleKeywordUpper, QueryStyleKeywordLower)
+	}
+	if style.NotEqualsOperator != "" && style.NotEqualsOperator != "!=" && style.NotEqualsOperator != "<>" {
+		return fmt.Errorf("notEqualsOperator must be \"!=\" or \"<>\"")
+	}
+	if style.TableAliasSuffix != "" && !queryStyleAliasSuffixPattern.MatchString(style.TableAliasSuffix) {
+		return fmt.Errorf("tableAliasSuffix may only contain up to 16 letters, digits and underscores")
+	}
+	return nil
+}
+
+// queryStyleKeywords are the SQL keywords whose case follows the style. Words that are common column names
+// (date, key, name, type, value...) are left out, changing their case could change what they refer to.
+var queryStyleKeywords = map[string]bool{
+	"ALL": true, "ALTER": true, "AND": true, "ANY": true, "AS": true, "ASC": true, "BEGIN": true, "BETWEEN": true,
+	"BY": true, "CASCADE": true, "CASE": true, "CAST": true, "CHECK": true, "COLUMN": true, "COMMIT": true,
+	"CONSTRAINT": true, "CREATE": true, "CROSS": true, "DEFAULT": true, "DELETE": true, "DESC": true,
+	"DISTINCT": true, "DROP": true, "ELSE": true, "END": true, "EXCEPT": true, "EXISTS": true, "FALSE": true,
+	"FETCH": true, "FILTER": true, "FIRST": true, "FOREIGN": true, "FROM": true, "FULL": true, "GROUP": true,
+	"HAVING": true, "ILIKE": true, "IN": true, "INDEX": true, "INNER": true, "INSERT": true, "INTERSECT": true,
+	"INTO": true, "IS": true, "JOIN": true, "LAST": true, "LATERAL": true, "LEFT": true, "LIKE": true,
+	"LIMIT": true, "NATURAL": true, "NEXT": true, "NOT": true, "NULL": true, "NULLS": true, "OFFSET": true,
+	"ON": true, "ONLY": true, "OR": true, "ORDER": true, "OUTER": true, "OVER": true, "PARTITION": true,
+	"PRIMARY": true, "RECURSIVE": true, "REFERENCES": true, "RETURNING": true, "RIGHT": true, "ROLLBACK": true,
+	"ROWS": true, "SELECT": true, "SET": true, "SOME": true, "TABLE": true, "THEN": true, "TRUE": true,
+	"TRUNCATE": true, "UNION": true, "UNIQUE": true, "UPDATE": true, "USING": true, "VALUES": true, "VIEW": true,
+	"WHEN": true, "WHERE": true, "WINDOW": true, "WITH": true,
+}
+
+// queryStyleFunctions are standard functions whose case follows the style when they are called
+var queryStyleFunctions = map[string]bool{
+	"AVG": true, "COALESCE": true, "COUNT": true, "MAX": true, "MIN": true, "NULLIF": true, "SUM": true,
+}
+
+// queryStyleJoinModifiers are the words that may come before JOIN, a JOIN after any of them is not a plain join
+var queryStyleJoinModifiers = map[string]bool{
+	"INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "OUTER": true, "CROSS": true, "NATURAL": true,
+	"SEMI": true, "ANTI": true, "ANY": true, "ALL": true, "ASOF": true, "ARRAY": true, "GLOBAL": true, "PASTE": true,
+}
+
+// queryStyleTableKeywords are the words a table name follows, its alias comes after it
+var queryStyleTableKeywords = map[string]bool{"FROM": true, "JOIN": true, "UPDATE": true, "INTO": true}
+
+// queryTokenKind is the kind of a token of the query naturalizer's tokenizer
+type queryTokenKind int
+
+const (
+	queryTokenWord queryTokenKind = iota
+	queryTokenSpace
+	queryTokenString
+	queryTokenQuoted
+	queryTokenComment
+	queryTokenOperator
+	queryTokenOther
+)
+
+// queryToken is a piece of a query, the tokens of a query joined together are the query
+type queryToken struct {
+	kind queryTokenKind
+	text string
+}
+
+// NaturalizeQuery rewrites a SQL query in a chat's style: keyword case, INNER JOIN or JOIN, != or <>,
+// indentation and table aliases named after their table. It works on tokens, string literals, quoted identifiers
+// and comments are never changed. Queries of other databases and chats without a style are returned as they are.
+func NaturalizeQuery(query, dbType string, style *models.QueryStyleConfig) string {
+	if style == nil || strings.TrimSpace(query) == "" {
+		return query
+	}
+	if _, ok := liveCountQuote(dbType); !ok {
+		return query
+	}
+
+	tokens := tokenizeQuery(query, queryStyleBackslashEscapes(dbType))
+	if style.TableAliasSuffix != "" {
+		tokens = applyQueryAliasSuffix(tokens, style.TableAliasSuffix)
+	}
+	if style.NotEqualsOperator != "" {
+		for i := range tokens {
+			if tokens[i].kind == queryTokenOperator && (tokens[i].text == "<>" || tokens[i].text == "!=") {
+				tokens[i].text = style.NotEqualsOperator
+			}
+		}
+	}
+	if style.JoinStyle != "" {
+		tokens = applyQueryJoinStyle(tokens, style.JoinStyle)
+	}
+	if style.KeywordCase != "" {
+		applyQueryKeywordCase(tokens, style.KeywordCase)
+	}
+	if style.Indentation != 0 {
+		applyQueryIndentation(tokens, style.Indentation)
+	}
+
+	var result strings.Builder
+	result.Grow(len(query))
+	for _, token := range tokens {
+		result.WriteString(token.text)
+	}
+	return result.String()
+}
+
+// queryStyleBackslashEscapes reports whether string literals of the database escape quotes with backslashes
+func queryStyleBackslashEscapes(dbType string) bool {
+	switch dbType {
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale, constants.DatabaseTypeClickhouse:
+		return true
+	default:
+		return false
+	}
+}
+
+// tokenizeQuery splits a query into words, whitespace, string literals, quoted identifiers, comments and operators
+func tokenizeQuery(query string, backslashEscapes bool) []queryToken {
+	runes := []rune(query)
+	tokens := make([]queryToken, 0, len(runes)/3)
+	isWordRune := func(r rune) bool {
+		return r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r > 127
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		kind := queryTokenOther
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			kind = queryTokenSpace
+			for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n' || runes[i] == '\r') {
+				i++
+			}
+
+		case r == '\'':
+			kind = queryTokenString
+			for i++; i < len(runes); i++ {
+				if backslashEscapes && runes[i] == '\\' {
+					i++
+					continue
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					i++
+					break
+				}
+			}
+
+		case r == '"' || r == '`':
+			kind = queryTokenQuoted
+			for i++; i < len(runes) && runes[i] != r; i++ {
+			}
+			if i < len(runes) {
+				i++
+			}
+
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			kind = queryTokenComment
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			kind = queryTokenComment
+			for i += 2; i < len(runes) && !(runes[i-1] == '*' && runes[i] == '/'); i++ {
+			}
+			if i < len(runes) {
+				i++
+			}
+
+		case isWordRune(r):
+			kind = queryTokenWord
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+
+		case strings.ContainsRune("<>!=|:", r):
+			kind = queryTokenOperator
+			i++
+			if i < len(runes) {
+				switch string(runes[start : i+1]) {
+				case "<>", "!=", "<=", ">=", "||", "::":
+					i++
+				}
+			}
+
+		default:
+			i++
+		}
+		if i > len(runes) {
+			i = len(runes)
+		}
+		tokens = append(tokens, queryToken{kind: kind, text: string(runes[start:i])})
+	}
+	return tokens
+}
+
+// nextQueryToken returns the index of the next token that is not whitespace or a comment, or -1
+func nextQueryToken(tokens []queryToken, i int) int {
+	for i++; i < len(tokens); i++ {
+		if tokens[i].kind != queryTokenSpace && tokens[i].kind != queryTokenComment {
+			return i
+		}
+	}
+	return -1
+}
+
+// previousQueryToken returns the index of the previous token that is not whitespace or a comment, or -1
+func previousQueryToken(tokens []queryToken, i int) int {
+	for i--; i >= 0; i-- {
+		if tokens[i].kind != queryTokenSpace && tokens[i].kind != queryTokenComment {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyQueryAliasSuffix renames the alias of every table to the table's name with the suffix, e.g. orders o
+// becomes orders orders_t, and renames the columns qualified with the alias. A table joined twice gets a number.
+func applyQueryAliasSuffix(tokens []queryToken, suffix string) []queryToken {
+	renames := make(map[string]string)
+	taken := make(map[string]bool)
+	var definitions []int
+
+	for i, token := range tokens {
+		if token.kind != queryTokenWord || !queryStyleTableKeywords[strings.ToUpper(token.text)] {
+			continue
+		}
+		// The table name, possibly schema-qualified
+		tableIndex := nextQueryToken(tokens, i)
+		if tableIndex < 0 || (tokens[tableIndex].kind != queryTokenWord && tokens[tableIndex].kind != queryTokenQuoted) {
+			continue
+		}
+		for {
+			dot := nextQueryToken(tokens, tableIndex)
+			if dot < 0 || tokens[dot].text != "." {
+				break
+			}
+			part := nextQueryToken(tokens, dot)
+			if part < 0 || (tokens[part].kind != queryTokenWord && tokens[part].kind != queryTokenQuoted) {
+				break
+			}
+			tableIndex = part
+		}
+		table := strings.Trim(tokens[tableIndex].text, "\"`")
+		if queryStyleKeywords[strings.ToUpper(table)] {
+			continue
+		}
+
+		aliasIndex := nextQueryToken(tokens, tableIndex)
+		if aliasIndex >= 0 && tokens[aliasIndex].kind == queryTokenWord && strings.EqualFold(tokens[aliasIndex].text, "AS") {
+			aliasIndex = nextQueryToken(tokens, aliasIndex)
+		}
+		if aliasIndex < 0 || tokens[aliasIndex].kind != queryTokenWord || queryStyleKeywords[strings.ToUpper(tokens[aliasIndex].text)] ||
+			queryStyleJoinModifiers[strings.ToUpper(tokens[aliasIndex].text)] {
+			continue
+		}
+		alias := tokens[aliasIndex].text
+		if _, renamed := renames[strings.ToLower(alias)]; renamed {
+			continue
+		}
+
+		name := table + suffix
+		for n := 2; taken[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s%s%d", table, suffix, n)
+		}
+		taken[strings.ToLower(name)] = true
+		renames[strings.ToLower(alias)] = name
+		definitions = append(definitions, aliasIndex)
+	}
+	if len(renames) == 0 {
+		return tokens
+	}
+
+	for _, i := range definitions {
+		tokens[i].text = renames[strings.ToLower(tokens[i].text)]
+	}
+	// Only qualifiers are renamed, a column may have the same name as an alias
+	for i, token := range tokens {
+		if token.kind != queryTokenWord {
+			continue
+		}
+		name, ok := renames[strings.ToLower(token.text)]
+		if !ok || i+1 >= len(tokens) || tokens[i+1].text != "." {
+			continue
+		}
+		if previous := previousQueryToken(tokens, i); previous >= 0 && tokens[previous].text == "." {
+			continue
+		}
+		tokens[i].text = name
+	}
+	return tokens
+}
+
+// applyQueryJoinStyle writes plain joins as INNER JOIN or JOIN
+func applyQueryJoinStyle(tokens []queryToken, joinStyle string) []queryToken {
+	result := make([]queryToken, 0, len(tokens)+4)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.kind != queryTokenWord {
+			result = append(result, token)
+			continue
+		}
+		upper := strings.ToUpper(token.text)
+
+		if joinStyle == QueryStyleJoinInner && upper == "JOIN" {
+			previous := previousQueryToken(tokens, i)
+			if previous < 0 || tokens[previous].kind != queryTokenWord || !queryStyleJoinModifiers[strings.ToUpper(tokens[previous].text)] {
+				inner := "INNER"
+				if token.text == strings.ToLower(token.text) {
+					inner = "inner"
+				}
+				result = append(result, queryToken{kind: queryTokenWord, text: inner}, queryToken{kind: queryTokenSpace, text: " "})
+			}
+		}
+
+		if joinStyle == QueryStyleJoinPlain && upper == "INNER" {
+			next := nextQueryToken(tokens, i)
+			if next >= 0 && strings.EqualFold(tokens[next].text, "JOIN") {
+				// Drop INNER and the whitespace up to JOIN
+				i = next - 1
+				continue
+			}
+		}
+
+		result = append(result, token)
+	}
+	return result
+}
+
+// applyQueryKeywordCase writes keywords, and calls of standard functions, in upper or lower case
+func applyQueryKeywordCase(tokens []queryToken, keywordCase string) {
+	for i, token := range tokens {
+		if token.kind != queryTokenWord {
+			continue
+		}
+		upper := strings.ToUpper(token.text)
+		isKeyword := queryStyleKeywords[upper]
+		if !isKeyword && queryStyleFunctions[upper] {
+			next := nextQueryToken(tokens, i)
+			isKeyword = next >= 0 && tokens[next].text == "("
+		}
+		if !isKeyword {
+			continue
+		}
+		// Qualified names (t.end) are identifiers, whatever they are called
+		if previous := previousQueryToken(tokens, i); previous >= 0 && tokens[previous].text == "." {
+			continue
+		}
+		if next := nextQueryToken(tokens, i); next >= 0 && tokens[next].text == "." {
+			continue
+		}
+		if keywordCase == QueryStyleKeywordUpper {
+			tokens[i].text = upper
+		} else {
+			tokens[i].text = strings.ToLower(token.text)
+		}
+	}
+}
+
+// applyQueryIndentation re-indents every line with the given number of spaces per level. The level of a line is
+// its indentation divided by the smallest indentation of the query, a tab counting as one level.
+func applyQueryIndentation(tokens []queryToken, spaces int) {
+	unit := 0
+	for _, token := range tokens {
+		if token.kind != queryTokenSpace || !strings.Contains(token.text, "\n") {
+			continue
+		}
+		indent := token.text[strings.LastIndex(token.text, "\n")+1:]
+		if width := len(strings.ReplaceAll(indent, "\t", "")); width > 0 && !strings.Contains(indent, "\t") && (unit == 0 || width < unit) {
+			unit = width
+		}
+	}
+
+	for i, token := range tokens {
+		if token.kind != queryTokenSpace || !strings.Contains(token.text, "\n") {
+			continue
+		}
+		newline := strings.LastIndex(token.text, "\n")
+		indent := token.text[newline+1:]
+		if indent == "" || strings.Trim(indent, "\r") == "" {
+			continue
+		}
+		levels := strings.Count(indent, "\t")
+		if width := len(strings.ReplaceAll(indent, "\t", "")); width > 0 {
+			if unit == 0 {
+				unit = width
+			}
+			levels += (width + unit/2) / unit
+		}
+		tokens[i].text = token.text[:newline+1] + strings.Repeat(" ", levels*spaces)
+	}
+}
@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"testing"
+
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+)
+
+type queryStyleTest struct {
+	name  string
+	input string
+	want  string
+}
+
+func runQueryStyleTests(t *testing.T, dbType string, style *models.QueryStyleConfig, tests []queryStyleTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NaturalizeQuery(tt.input, dbType, style); got != tt.want {
+				t.Errorf("NaturalizeQuery(%q)\n got: %q\nwant: %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNaturalizeQueryKeywordCaseUpper(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{KeywordCase: QueryStyleKeywordUpper}, []queryStyleTest{
+		{"keywords", "select id from users where active = true", "SELECT id FROM users WHERE active = TRUE"},
+		{"mixed case", "Select id From users Order By id Desc", "SELECT id FROM users ORDER BY id DESC"},
+		{"string literal", "select 'select from where' from t", "SELECT 'select from where' FROM t"},
+		{"escaped quote in literal", "select 'it''s from' as note from t", "SELECT 'it''s from' AS note FROM t"},
+		{"quoted identifier", `select "from", "order" from "select"`, `SELECT "from", "order" FROM "select"`},
+		{"comments", "select id -- from here\nfrom t /* where not */", "SELECT id -- from here\nFROM t /* where not */"},
+		{"function call", "select count(*), max(total) from orders", "SELECT COUNT(*), MAX(total) FROM orders"},
+		{"function name as column", "select count, sum from stats", "SELECT count, sum FROM stats"},
+		{"qualified keyword column", "select t.end, t.order from t", "SELECT t.end, t.order FROM t"},
+		{"common column names", "select date, name, type, value from events", "SELECT date, name, type, value FROM events"},
+	})
+}
+
+func TestNaturalizeQueryKeywordCaseLower(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{KeywordCase: QueryStyleKeywordLower}, []queryStyleTest{
+		{"keywords", "SELECT id FROM users WHERE active IS NOT NULL", "select id from users where active is not null"},
+		{"identifiers keep their case", "SELECT UserId FROM Users", "select UserId from Users"},
+		{"string literal", "SELECT 'SELECT FROM' FROM t", "select 'SELECT FROM' from t"},
+		{"quoted identifier", `SELECT "ORDER" FROM "GROUP"`, `select "ORDER" from "GROUP"`},
+		{"backtick identifier", "SELECT `FROM` FROM `SELECT`", "select `FROM` from `SELECT`"},
+		{"block comment", "SELECT /* KEEP THIS */ id FROM t", "select /* KEEP THIS */ id from t"},
+		{"function call", "SELECT COALESCE(a, 0), AVG(b) FROM t", "select coalesce(a, 0), avg(b) from t"},
+		{"join", "SELECT * FROM a LEFT OUTER JOIN b ON a.id = b.a_id", "select * from a left outer join b on a.id = b.a_id"},
+		{"cte", "WITH RECURSIVE r AS (SELECT 1) SELECT * FROM r", "with recursive r as (select 1) select * from r"},
+		{"group having", "SELECT c, COUNT(*) FROM t GROUP BY c HAVING COUNT(*) > 1", "select c, count(*) from t group by c having count(*) > 1"},
+	})
+}
+
+func TestNaturalizeQueryKeywordCaseMySQLBackslashEscapes(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypeMySQL, &models.QueryStyleConfig{KeywordCase: QueryStyleKeywordUpper}, []queryStyleTest{
+		{"backslash escaped quote", `select 'it\'s from' from t`, `SELECT 'it\'s from' FROM t`},
+		{"escaped backslash", `select 'a\\' from t where b = 'c'`, `SELECT 'a\\' FROM t WHERE b = 'c'`},
+	})
+}
+
+func TestNaturalizeQueryJoinStyleInner(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{JoinStyle: QueryStyleJoinInner}, []queryStyleTest{
+		{"plain join", "SELECT * FROM a JOIN b ON a.id = b.id", "SELECT * FROM a INNER JOIN b ON a.id = b.id"},
+		{"lower case join", "select * from a join b on a.id = b.id", "select * from a inner join b on a.id = b.id"},
+		{"already inner", "SELECT * FROM a INNER JOIN b ON a.id = b.id", "SELECT * FROM a INNER JOIN b ON a.id = b.id"},
+		{"left join", "SELECT * FROM a LEFT JOIN b ON a.id = b.id", "SELECT * FROM a LEFT JOIN b ON a.id = b.id"},
+		{"left outer join", "SELECT * FROM a LEFT OUTER JOIN b ON a.id = b.id", "SELECT * FROM a LEFT OUTER JOIN b ON a.id = b.id"},
+		{"cross join", "SELECT * FROM a CROSS JOIN b", "SELECT * FROM a CROSS JOIN b"},
+		{"several joins", "SELECT * FROM a JOIN b ON a.id = b.id JOIN c ON b.id = c.id", "SELECT * FROM a INNER JOIN b ON a.id = b.id INNER JOIN c ON b.id = c.id"},
+		{"join on a new line", "SELECT *\nFROM a\nJOIN b ON a.id = b.id", "SELECT *\nFROM a\nINNER JOIN b ON a.id = b.id"},
+		{"string literal", "SELECT 'a JOIN b' FROM a JOIN b ON true", "SELECT 'a JOIN b' FROM a INNER JOIN b ON true"},
+		{"quoted identifier", `SELECT "join" FROM a JOIN "join" ON true`, `SELECT "join" FROM a INNER JOIN "join" ON true`},
+	})
+}
+
+func TestNaturalizeQueryJoinStylePlain(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{JoinStyle: QueryStyleJoinPlain}, []queryStyleTest{
+		{"inner join", "SELECT * FROM a INNER JOIN b ON a.id = b.id", "SELECT * FROM a JOIN b ON a.id = b.id"},
+		{"lower case inner join", "select * from a inner join b on a.id = b.id", "select * from a join b on a.id = b.id"},
+		{"already plain", "SELECT * FROM a JOIN b ON a.id = b.id", "SELECT * FROM a JOIN b ON a.id = b.id"},
+		{"left join", "SELECT * FROM a LEFT JOIN b ON a.id = b.id", "SELECT * FROM a LEFT JOIN b ON a.id = b.id"},
+		{"full outer join", "SELECT * FROM a FULL OUTER JOIN b ON a.id = b.id", "SELECT * FROM a FULL OUTER JOIN b ON a.id = b.id"},
+		{"inner join across lines", "SELECT * FROM a INNER\n  JOIN b ON a.id = b.id", "SELECT * FROM a JOIN b ON a.id = b.id"},
+		{"several joins", "SELECT * FROM a INNER JOIN b ON true INNER JOIN c ON true", "SELECT * FROM a JOIN b ON true JOIN c ON true"},
+		{"inner as a column", "SELECT inner FROM t", "SELECT inner FROM t"},
+		{"string literal", "SELECT 'INNER JOIN' FROM a INNER JOIN b ON true", "SELECT 'INNER JOIN' FROM a JOIN b ON true"},
+		{"quoted identifier", `SELECT "inner" FROM a INNER JOIN b ON true`, `SELECT "inner" FROM a JOIN b ON true`},
+	})
+}
+
+func TestNaturalizeQueryNotEqualsOperator(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{NotEqualsOperator: "<>"}, []queryStyleTest{
+		{"bang equals", "SELECT * FROM t WHERE a != 1", "SELECT * FROM t WHERE a <> 1"},
+		{"already angle brackets", "SELECT * FROM t WHERE a <> 1", "SELECT * FROM t WHERE a <> 1"},
+		{"without spaces", "SELECT * FROM t WHERE a!=1", "SELECT * FROM t WHERE a<>1"},
+		{"several", "SELECT * FROM t WHERE a != 1 AND b != 2", "SELECT * FROM t WHERE a <> 1 AND b <> 2"},
+		{"other comparisons", "SELECT * FROM t WHERE a <= 1 AND b >= 2 AND c = 3", "SELECT * FROM t WHERE a <= 1 AND b >= 2 AND c = 3"},
+		{"string literal", "SELECT * FROM t WHERE a != 'x != y'", "SELECT * FROM t WHERE a <> 'x != y'"},
+		{"quoted identifier", `SELECT "a!=b" FROM t WHERE "a!=b" != 1`, `SELECT "a!=b" FROM t WHERE "a!=b" <> 1`},
+		{"comment", "SELECT * FROM t -- a != b\nWHERE a != b", "SELECT * FROM t -- a != b\nWHERE a <> b"},
+		{"cast", "SELECT a::text FROM t WHERE a != 1", "SELECT a::text FROM t WHERE a <> 1"},
+		{"concatenation", "SELECT a || b FROM t WHERE a != b", "SELECT a || b FROM t WHERE a <> b"},
+	})
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{NotEqualsOperator: "!="}, []queryStyleTest{
+		{"angle brackets", "SELECT * FROM t WHERE a <> 1", "SELECT * FROM t WHERE a != 1"},
+		{"angle brackets in a literal", "SELECT '<>' FROM t WHERE a <> 1", "SELECT '<>' FROM t WHERE a != 1"},
+	})
+}
+
+func TestNaturalizeQueryIndentation(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{Indentation: 4}, []queryStyleTest{
+		{"two to four spaces", "SELECT\n  id\nFROM t", "SELECT\n    id\nFROM t"},
+		{"nested levels", "SELECT\n  id,\n  (SELECT\n    1)\nFROM t", "SELECT\n    id,\n    (SELECT\n        1)\nFROM t"},
+		{"tabs", "SELECT\n\tid\nFROM t", "SELECT\n    id\nFROM t"},
+		{"already four spaces", "SELECT\n    id\nFROM t", "SELECT\n    id\nFROM t"},
+		{"single line", "SELECT id FROM t", "SELECT id FROM t"},
+		{"unindented lines", "SELECT id\nFROM t\nWHERE a = 1", "SELECT id\nFROM t\nWHERE a = 1"},
+		{"blank line", "SELECT id\n\n  FROM t", "SELECT id\n\n    FROM t"},
+		{"string literal", "SELECT 'a\n  b'\n  FROM t", "SELECT 'a\n  b'\n    FROM t"},
+		{"block comment", "SELECT 1 /* a\n  b */\n  FROM t", "SELECT 1 /* a\n  b */\n    FROM t"},
+		{"crlf line endings", "SELECT\r\n  id\r\nFROM t", "SELECT\r\n    id\r\nFROM t"},
+	})
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{Indentation: 2}, []queryStyleTest{
+		{"four to two spaces", "SELECT\n    id,\n        name\nFROM t", "SELECT\n  id,\n    name\nFROM t"},
+	})
+}
+
+func TestNaturalizeQueryTableAliasSuffix(t *testing.T) {
+	runQueryStyleTests(t, constants.DatabaseTypePostgreSQL, &models.QueryStyleConfig{TableAliasSuffix: "_t"}, []queryStyleTest{
+		{"alias", "SELECT o.id FROM orders o", "SELECT orders_t.id FROM orders orders_t"},
+		{"alias with as", "SELECT o.id FROM orders AS o", "SELECT orders_t.id FROM orders AS orders_t"},
+		{"joined tables", "SELECT o.id, c.name FROM orders o JOIN customers c ON o.customer_id = c.id",
+			"SELECT orders_t.id, customers_t.name FROM orders orders_t JOIN customers customers_t ON orders_t.customer_id = customers_t.id"},
+		{"self join", "SELECT a.id FROM users a JOIN users b ON a.manager_id = b.id",
+			"SELECT users_t.id FROM users users_t JOIN users users_t2 ON users_t.manager_id = users_t2.id"},
+		{"schema qualified table", "SELECT o.id FROM public.orders o", "SELECT orders_t.id FROM public.orders orders_t"},
+		{"no alias", "SELECT id FROM orders WHERE id = 1", "SELECT id FROM orders WHERE id = 1"},
+		{"column named like the alias", "SELECT o.o FROM orders o", "SELECT orders_t.o FROM orders orders_t"},
+		{"string literal", "SELECT 'o.id' FROM orders o WHERE o.id = 1", "SELECT 'o.id' FROM orders orders_t WHERE orders_t.id = 1"},
+		{"quoted identifier", `SELECT o."o.id" FROM "orders" o`, `SELECT orders_t."o.id" FROM "orders" orders_t`},
+		{"join keyword after the table", "SELECT * FROM orders LEFT JOIN customers c ON true", "SELECT * FROM orders LEFT JOIN customers customers_t ON true"},
+	})
+}
+
+func TestNaturalizeQueryLeavesQueriesAlone(t *testing.T) {
+	style := &models.QueryStyleConfig{KeywordCase: QueryStyleKeywordUpper, JoinStyle: QueryStyleJoinInner}
+
+	if got := NaturalizeQuery("select * from a join b", constants.DatabaseTypePostgreSQL, nil); got != "select * from a join b" {
+		t.Errorf("NaturalizeQuery() without a style = %q", got)
+	}
+	if got := NaturalizeQuery("db.users.find({select: 1})", constants.DatabaseTypeMongoDB, style); got != "db.users.find({select: 1})" {
+		t.Errorf("NaturalizeQuery() of a MongoDB query = %q", got)
+	}
+	if got := NaturalizeQuery("  ", constants.DatabaseTypePostgreSQL, style); got != "  " {
+		t.Errorf("NaturalizeQuery() of a blank query = %q", got)
+	}
+}
+
+func TestValidateQueryStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		style   *models.QueryStyleConfig
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"empty", &models.QueryStyleConfig{}, false},
+		{"all set", &models.QueryStyleConfig{JoinStyle: QueryStyleJoinPlain, Indentation: 2, KeywordCase: QueryStyleKeywordLower, NotEqualsOperator: "!=", TableAliasSuffix: "_t"}, false},
+		{"join style", &models.QueryStyleConfig{JoinStyle: "LEFT JOIN"}, true},
+		{"indentation", &models.QueryStyleConfig{Indentation: 3}, true},
+		{"keyword case", &models.QueryStyleConfig{KeywordCase: "title"}, true},
+		{"not equals operator", &models.QueryStyleConfig{NotEqualsOperator: "=!"}, true},
+		{"alias suffix", &models.QueryStyleConfig{TableAliasSuffix: "_t; DROP"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateQueryStyle(tt.style); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateQueryStyle(%s) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
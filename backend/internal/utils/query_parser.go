@@ -0,0 +1,508 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"neobase-ai/internal/constants"
+)
+
+// QueryTableJoin is a join between two tables read from a query's text
+type QueryTableJoin struct {
+	From      string // Table on the left of the join, or the collection running the $lookup
+	To        string // Joined table or looked up collection
+	Condition string // ON condition, USING column list or $lookup fields, empty for cross joins
+	Type      string // INNER, LEFT, RIGHT, FULL or CROSS, prefixed with NATURAL for natural joins
+}
+
+// QueryTables is what ParseQueryTables reads from a query
+type QueryTables struct {
+	Tables []string // In order of first appearance, without schema qualifier or quotes
+	Joins  []QueryTableJoin
+}
+
+const (
+	sqlTableRefPattern = "((?:[A-Za-z0-9_$]+|\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\])(?:\\.(?:[A-Za-z0-9_$]+|\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\]))*)"
+	sqlAliasPattern    = "(?:\\s+(?:AS\\s+)?([A-Za-z_][A-Za-z0-9_]*|\"[^\"]+\"|`[^`]+`))?"
+)
+
+var (
+	sqlFromRefPattern       = regexp.MustCompile(`(?i)\b(?:FROM|UPDATE|INTO)\s+` + sqlTableRefPattern + sqlAliasPattern)
+	sqlJoinRefPattern       = regexp.MustCompile(`(?i)\b(?:(NATURAL)\s+)?(?:(LEFT|RIGHT|FULL|INNER|CROSS)\s+)?(?:OUTER\s+)?JOIN\s+` + sqlTableRefPattern + sqlAliasPattern)
+	sqlNextRefPattern       = regexp.MustCompile(`(?i)^\s*,\s*` + sqlTableRefPattern + sqlAliasPattern)
+	sqlCTEPattern           = regexp.MustCompile(`(?i)(?:\bWITH(?:\s+RECURSIVE)?|,)\s+([A-Za-z_][A-Za-z0-9_]*)\s+AS\s*\(`)
+	sqlClauseEndPattern     = regexp.MustCompile(`(?i)^(?:(?:NATURAL|LEFT|RIGHT|FULL|INNER|CROSS|OUTER|ANY|ALL|GLOBAL|ASOF|SEMI|ANTI)\s+)*JOIN\b|^(?:WHERE|GROUP\s+BY|ORDER\s+BY|HAVING|LIMIT|UNION|INTERSECT|EXCEPT|WINDOW|RETURNING|OFFSET|FETCH|QUALIFY|SETTINGS|FORMAT)\b`)
+	sqlQualifierPattern     = regexp.MustCompile("([A-Za-z_][A-Za-z0-9_]*|\"[^\"]+\"|`[^`]+`)\\.[A-Za-z_\"`]")
+	mongoShellKeyPattern    = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*`)
+	mongoLookupFieldPattern = regexp.MustCompile(`["']?\b(from|localField|foreignField)\b["']?\s*:\s*["']([^"']*)["']`)
+)
+
+// sqlAliasKeywords can follow a table name without being its alias
+var sqlAliasKeywords = map[string]bool{
+	"ON": true, "USING": true, "WHERE": true, "JOIN": true, "LEFT": true, "RIGHT": true, "FULL": true, "INNER": true,
+	"CROSS": true, "NATURAL": true, "OUTER": true, "GROUP": true, "ORDER": true, "LIMIT": true, "HAVING": true,
+	"SET": true, "VALUES": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "WINDOW": true, "RETURNING": true,
+	"SELECT": true, "FINAL": true, "SAMPLE": true, "PREWHERE": true, "FORMAT": true, "OFFSET": true, "FETCH": true,
+	"FOR": true, "WITH": true, "LATERAL": true, "DEFAULT": true, "ANY": true, "ALL": true, "GLOBAL": true,
+	"ASOF": true, "SEMI": true, "ANTI": true, "SETTINGS": true, "QUALIFY": true,
+}
+
+// sqlTableRef is a table named in a FROM, JOIN, UPDATE or INTO clause
+type sqlTableRef struct {
+	table    string
+	alias    string
+	position int
+	join     *QueryTableJoin // Set for JOIN clauses and the extra tables of a comma separated FROM list
+}
+
+// ParseQueryTables reads the tables and joins of a SQL query, or the collections and $lookup stages of a MongoDB
+// aggregation, with regular expressions rather than a full parser. Subqueries are read like the outer query,
+// function arguments such as EXTRACT(YEAR FROM col) and CTE names are not taken for tables.
+func ParseQueryTables(query, dbType string) (*QueryTables, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("the query is empty")
+	}
+
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks,
+		constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeOracle, constants.DatabaseTypeInfluxDB, constants.DatabaseTypeCloudflareD1, constants.DatabaseTypeSpreadsheet,
+		constants.DatabaseTypeGoogleSheets:
+		return parseSQLQueryTables(query), nil
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		return parseMongoDBQueryTables(query), nil
+	default:
+		return nil, fmt.Errorf("query parsing is not supported for %s", dbType)
+	}
+}
+
+func parseSQLQueryTables(query string) *QueryTables {
+	// Literals and comments are blanked out with their length kept, so offsets in masked match the query
+	masked := sqlStringLiteralPattern.ReplaceAllStringFunc(query, func(literal string) string {
+		return "'" + strings.Repeat(" ", len(literal)-2) + "'"
+	})
+	blank := func(comment string) string { return strings.Repeat(" ", len(comment)) }
+	masked = sqlLineCommentPattern.ReplaceAllStringFunc(masked, blank)
+	masked = sqlBlockCommentPattern.ReplaceAllStringFunc(masked, blank)
+
+	ctes := make(map[string]bool)
+	for _, match := range sqlCTEPattern.FindAllStringSubmatch(masked, -1) {
+		ctes[strings.ToLower(match[1])] = true
+	}
+
+	var refs []sqlTableRef
+	for _, match := range sqlFromRefPattern.FindAllStringSubmatchIndex(masked, -1) {
+		if isSQLFunctionArgument(masked, match[0]) {
+			continue
+		}
+		ref := newSQLTableRef(masked, match[2:6], match[0])
+		isInto := strings.EqualFold(masked[match[0]:match[0]+4], "INTO")
+		if !isSQLTableName(ref.table) || (!isInto && isSQLTableFunction(masked, match[3])) {
+			continue
+		}
+		refs = append(refs, ref)
+
+		// Extra tables of a comma separated FROM list are implicit cross joins
+		rest := match[1]
+		if ref.alias == "" {
+			rest = match[3]
+		}
+		for {
+			next := sqlNextRefPattern.FindStringSubmatchIndex(masked[rest:])
+			if next == nil {
+				break
+			}
+			for i := 2; i < len(next); i++ {
+				if next[i] >= 0 {
+					next[i] += rest
+				}
+			}
+			listed := newSQLTableRef(masked, next[2:6], next[2])
+			if listed.alias == "" {
+				rest = next[3]
+			} else {
+				rest = next[5]
+			}
+			if !isSQLTableName(listed.table) || isSQLTableFunction(masked, next[3]) {
+				continue
+			}
+			listed.join = &QueryTableJoin{Type: "CROSS"}
+			refs = append(refs, listed)
+		}
+	}
+	for _, match := range sqlJoinRefPattern.FindAllStringSubmatchIndex(masked, -1) {
+		ref := newSQLTableRef(masked, match[6:10], match[0])
+		if !isSQLTableName(ref.table) || isSQLTableFunction(masked, match[7]) {
+			continue
+		}
+		joinType := "INNER"
+		if match[4] >= 0 {
+			joinType = strings.ToUpper(masked[match[4]:match[5]])
+		}
+		if match[2] >= 0 {
+			joinType = strings.TrimSpace("NATURAL " + strings.TrimSuffix(joinType, "INNER"))
+		}
+		end := match[1]
+		if ref.alias == "" {
+			end = match[7]
+		}
+		ref.join = &QueryTableJoin{Type: joinType, Condition: sqlJoinCondition(query, masked, end)}
+		refs = append(refs, ref)
+	}
+
+	// The clauses were collected by kind, the join resolution needs them in query order
+	sort.SliceStable(refs, func(i, j int) bool { return refs[i].position < refs[j].position })
+
+	aliases := make(map[string]string)
+	for _, ref := range refs {
+		aliases[strings.ToLower(ref.table)] = ref.table
+		if ref.alias != "" {
+			aliases[strings.ToLower(ref.alias)] = ref.table
+		}
+	}
+
+	result := &QueryTables{}
+	seen := make(map[string]bool)
+	for i, ref := range refs {
+		if ctes[strings.ToLower(ref.table)] {
+			continue
+		}
+		if !seen[strings.ToLower(ref.table)] {
+			seen[strings.ToLower(ref.table)] = true
+			result.Tables = append(result.Tables, ref.table)
+		}
+		if ref.join == nil {
+			continue
+		}
+
+		join := *ref.join
+		join.To = ref.table
+		join.From = sqlJoinSource(join.Condition, ref, aliases)
+		if join.From == "" {
+			// Without a qualified column to go by, the table is joined to the one listed before it
+			for j := i - 1; j >= 0; j-- {
+				if !ctes[strings.ToLower(refs[j].table)] {
+					join.From = refs[j].table
+					break
+				}
+			}
+		}
+		if join.From == "" || ctes[strings.ToLower(join.From)] {
+			continue
+		}
+		result.Joins = append(result.Joins, join)
+	}
+	return result
+}
+
+// newSQLTableRef reads a table and its optional alias from the submatch indexes of a table reference pattern
+func newSQLTableRef(masked string, groups []int, position int) sqlTableRef {
+	ref := sqlTableRef{table: unquoteSQLTableName(masked[groups[0]:groups[1]]), position: position}
+	if groups[2] >= 0 {
+		alias := strings.Trim(masked[groups[2]:groups[3]], "\"`")
+		if !sqlAliasKeywords[strings.ToUpper(alias)] {
+			ref.alias = alias
+		}
+	}
+	return ref
+}
+
+// unquoteSQLTableName strips quoting and the schema or database qualifier from a table reference
+func unquoteSQLTableName(ref string) string {
+	parts := strings.Split(ref, ".")
+	return strings.Trim(parts[len(parts)-1], "\"`[]")
+}
+
+// isSQLTableName rejects keywords read as a table name, such as LATERAL in JOIN LATERAL (SELECT ...)
+func isSQLTableName(name string) bool {
+	return name != "" && !sqlAliasKeywords[strings.ToUpper(name)]
+}
+
+// isSQLTableFunction tells whether the name ending at end is called, like generate_series(1, 10) or UNNEST(col)
+func isSQLTableFunction(masked string, end int) bool {
+	return end < len(masked) && masked[end] == '('
+}
+
+// isSQLFunctionArgument tells whether the clause at position names no table: a FROM inside a function call such as
+// EXTRACT(YEAR FROM col) rather than a subquery, IS DISTINCT FROM, ON CONFLICT DO UPDATE, ON DUPLICATE KEY UPDATE
+// and FOR UPDATE
+func isSQLFunctionArgument(masked string, position int) bool {
+	if before := strings.Fields(strings.ToUpper(masked[:position])); len(before) > 0 {
+		switch before[len(before)-1] {
+		case "DISTINCT", "DO", "KEY", "FOR":
+			return true
+		}
+	}
+	depth := 0
+	for i := position - 1; i >= 0; i-- {
+		switch masked[i] {
+		case ')':
+			depth++
+		case '(':
+			if depth == 0 {
+				return !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(masked[i+1:position])), "SELECT")
+			}
+			depth--
+		}
+	}
+	return false
+}
+
+// sqlJoinCondition returns the ON condition or USING column list following a JOIN clause, read from the original
+// query so literals are kept. It ends at the next clause, JOIN, comma or closing parenthesis of its own level.
+func sqlJoinCondition(query, masked string, start int) string {
+	rest := strings.TrimLeft(masked[start:], " \t\r\n")
+	start = len(masked) - len(rest)
+	startsWith := func(keyword string) bool {
+		return len(rest) >= len(keyword) && strings.EqualFold(rest[:len(keyword)], keyword) &&
+			(len(rest) == len(keyword) || !isSQLWordChar(rest[len(keyword)]))
+	}
+	switch {
+	case startsWith("ON"):
+		start += len("ON")
+	case startsWith("USING"):
+		// USING keeps its keyword, the column list alone would read as a tuple
+	default:
+		return ""
+	}
+
+	depth := 0
+	end := len(masked)
+scan:
+	for i := start; i < len(masked); i++ {
+		switch masked[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				end = i
+				break scan
+			}
+			depth--
+		case ';', ',':
+			if depth == 0 {
+				end = i
+				break scan
+			}
+		default:
+			if depth == 0 && (i == 0 || !isSQLWordChar(masked[i-1])) && sqlClauseEndPattern.MatchString(masked[i:]) {
+				end = i
+				break scan
+			}
+		}
+	}
+	return strings.Join(strings.Fields(query[start:end]), " ")
+}
+
+func isSQLWordChar(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// sqlJoinSource finds the table a join is made to from the first qualified column of its condition that belongs
+// to another table reference, which also works for self joins where both sides are the same table
+func sqlJoinSource(condition string, ref sqlTableRef, aliases map[string]string) string {
+	own := strings.ToLower(ref.alias)
+	if own == "" {
+		own = strings.ToLower(ref.table)
+	}
+	for _, match := range sqlQualifierPattern.FindAllStringSubmatch(condition, -1) {
+		qualifier := strings.ToLower(strings.Trim(match[1], "\"`"))
+		if qualifier == own {
+			continue
+		}
+		if table, ok := aliases[qualifier]; ok {
+			return table
+		}
+	}
+	return ""
+}
+
+// mongoLookupStage holds the fields of a $lookup stage that describe the join
+type mongoLookupStage struct {
+	From         string          `json:"from"`
+	LocalField   string          `json:"localField"`
+	ForeignField string          `json:"foreignField"`
+	Let          json.RawMessage `json:"let"`
+}
+
+// parseMongoDBQueryTables reads the collection of a query and the collections its $lookup stages join.
+// A $lookup nested in another's pipeline joins the outer stage's collection.
+func parseMongoDBQueryTables(query string) *QueryTables {
+	result := &QueryTables{}
+	seen := make(map[string]bool)
+	addTable := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			result.Tables = append(result.Tables, name)
+		}
+	}
+
+	root := ""
+	if match := mongoCollectionPattern.FindStringSubmatch(query); match != nil {
+		root = match[1]
+		if root == "" {
+			root = match[2]
+		}
+	}
+	addTable(root)
+
+	type lookupSpan struct {
+		end  int
+		from string
+	}
+	var enclosing []lookupSpan
+	for _, loc := range mongoLookupPattern.FindAllStringIndex(query, -1) {
+		stageText, start, end := mongoStageObject(query, loc[1])
+		if stageText == "" {
+			continue
+		}
+		for len(enclosing) > 0 && enclosing[len(enclosing)-1].end < start {
+			enclosing = enclosing[:len(enclosing)-1]
+		}
+		source := root
+		if len(enclosing) > 0 {
+			source = enclosing[len(enclosing)-1].from
+		}
+
+		stage := parseMongoLookupStage(stageText)
+		if stage.From == "" {
+			continue
+		}
+		addTable(stage.From)
+		enclosing = append(enclosing, lookupSpan{end: end, from: stage.From})
+		if source == "" {
+			continue
+		}
+
+		condition := "uncorrelated pipeline"
+		switch {
+		case stage.LocalField != "" || stage.ForeignField != "":
+			condition = fmt.Sprintf("%s.%s = %s.%s", source, stage.LocalField, stage.From, stage.ForeignField)
+		case len(stage.Let) > 0 && string(stage.Let) != "null":
+			var let bytes.Buffer
+			if err := json.Compact(&let, stage.Let); err == nil {
+				condition = "pipeline with let " + let.String()
+			}
+		}
+		// $lookup keeps the documents without a match, like a left outer join
+		result.Joins = append(result.Joins, QueryTableJoin{From: source, To: stage.From, Condition: condition, Type: "LEFT"})
+	}
+	return result
+}
+
+// mongoStageObject returns the object following a $lookup key that ends at start, and where it starts and ends
+func mongoStageObject(query string, start int) (string, int, int) {
+	i := start
+	for i < len(query) && (query[i] == '"' || query[i] == '\'' || query[i] == ':' || unicode.IsSpace(rune(query[i]))) {
+		i++
+	}
+	if i >= len(query) || query[i] != '{' {
+		return "", 0, 0
+	}
+
+	depth := 0
+	var quote byte
+	for j := i; j < len(query); j++ {
+		c := query[j]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				j++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return query[i : j+1], i, j
+			}
+		}
+	}
+	return "", 0, 0
+}
+
+// parseMongoLookupStage decodes a $lookup stage written in shell syntax. Stages JSON cannot hold,
+// such as pipelines with ObjectId() or ISODate() calls, fall back to reading the fields one by one.
+func parseMongoLookupStage(stageText string) mongoLookupStage {
+	var stage mongoLookupStage
+	if err := json.Unmarshal([]byte(mongoShellToJSON(stageText)), &stage); err == nil {
+		return stage
+	}
+
+	// The first occurrence of a field is the stage's own, nested stages of a pipeline come after from
+	for _, match := range mongoLookupFieldPattern.FindAllStringSubmatch(stageText, -1) {
+		var field *string
+		switch match[1] {
+		case "from":
+			field = &stage.From
+		case "localField":
+			field = &stage.LocalField
+		case "foreignField":
+			field = &stage.ForeignField
+		}
+		if *field == "" {
+			*field = match[2]
+		}
+	}
+	return stage
+}
+
+// mongoShellToJSON quotes the bare keys and turns the single quoted strings of shell syntax into JSON strings
+func mongoShellToJSON(text string) string {
+	var out strings.Builder
+	lastSignificant := byte(0)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(text) {
+				end = len(text) - 1
+			}
+			out.WriteString(text[i : end+1])
+			i = end
+			lastSignificant = '"'
+		case c == '\'':
+			end := i + 1
+			var value strings.Builder
+			for end < len(text) && text[end] != '\'' {
+				if text[end] == '\\' && end+1 < len(text) {
+					end++
+				}
+				value.WriteByte(text[end])
+				end++
+			}
+			quoted, _ := json.Marshal(value.String())
+			out.Write(quoted)
+			i = end
+			lastSignificant = '"'
+		case (lastSignificant == '{' || lastSignificant == ',') && mongoShellKeyPattern.MatchString(text[i:]):
+			key := mongoShellKeyPattern.FindString(text[i:])
+			out.WriteString(`"` + key + `"`)
+			i += len(key) - 1
+			lastSignificant = '"'
+		default:
+			out.WriteByte(c)
+			if !unicode.IsSpace(rune(c)) {
+				lastSignificant = c
+			}
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"neobase-ai/internal/constants"
+)
+
+// rollbackPlaceholderPatterns match placeholder text the LLM leaves in rollback queries it could not fully write
+var rollbackPlaceholderPatterns = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`(?i)\byour_[a-z0-9_]+`), "contains a 'your_...' placeholder"},
+	{regexp.MustCompile(`\b(TABLE_NAME|COLUMN_NAME|COLLECTION_NAME|DATABASE_NAME)\b`), "contains an uppercase name placeholder"},
+	{regexp.MustCompile(`(^|[^A-Za-z0-9_])<[a-z_][a-z0-9_\-]*>`), "contains an angle bracket placeholder"},
+	{regexp.MustCompile(`\{\{[^}]*\}\}`), "contains a template placeholder"},
+	{regexp.MustCompile(`\.\.\.`), "contains an ellipsis"},
+	{regexp.MustCompile(`\b(TODO|FIXME|PLACEHOLDER)\b`), "contains placeholder wording"},
+}
+
+// quotedLiteralPattern matches single, double and backtick quoted literals
+var quotedLiteralPattern = regexp.MustCompile("'(?:[^'\\\\]|\\\\.|'')*'|\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`")
+
+// ValidateRollbackQuery checks an AI generated rollback query for comments and placeholder text that
+// show the query is not runnable as-is. It returns false and the reason when the rollback should be discarded.
+func ValidateRollbackQuery(query, dbType string) (bool, string) {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return false, "rollback query is empty"
+	}
+
+	// Comment markers are only red flags outside of string literals
+	unquoted := quotedLiteralPattern.ReplaceAllString(trimmed, "''")
+	if dbType != constants.DatabaseTypeMongoDB && dbType != constants.DatabaseTypeFerretDB && strings.Contains(unquoted, "--") {
+		return false, "contains an SQL comment"
+	}
+	if strings.Contains(unquoted, "/*") {
+		return false, "contains a block comment"
+	}
+	if strings.Contains(unquoted, "//") {
+		return false, "contains a JavaScript comment"
+	}
+	if strings.HasPrefix(unquoted, "#") || strings.Contains(unquoted, "\n#") {
+		return false, "contains a comment"
+	}
+
+	for _, p := range rollbackPlaceholderPatterns {
+		if p.pattern.MatchString(trimmed) {
+			return false, p.reason
+		}
+	}
+
+	return true, ""
+}
@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"neobase-ai/internal/constants"
+)
+
+// ColumnStatsQueries are the read-only queries computing a column's statistics. A query is empty when it doesn't
+// apply to the column's kind. The summary returns one row with total_count, null_count, distinct_count and, by
+// kind, min_value, max_value, avg_value, stddev_value and the p25 to p95 percentiles. Top values return rows of
+// stat_value and stat_count, the monthly distribution rows of stat_month and stat_count, most recent first.
+type ColumnStatsQueries struct {
+	Summary string
+	// DistinctCount is only set for MongoDB, where the distinct values are counted in their own pipeline
+	DistinctCount string
+	TopValues     string
+	Distribution  string
+}
+
+var (
+	statsWrapperPattern   = regexp.MustCompile(`^(?:nullable|lowcardinality)\((.*)\)$`)
+	statsTimestampPattern = regexp.MustCompile(`^(timestamp|timestamptz|datetime|datetime64|date|date32|smalldatetime)\b`)
+	statsNumericPattern   = regexp.MustCompile(`^((tiny|small|medium|big)?int(eger)?\d*|u?int\d+|long|numeric|decimal\d*|dec|float\d*|double|real|number|money|(small|big)?serial)\b`)
+)
+
+// statsPercentiles are the percentiles computed for numeric columns, by result column
+var statsPercentiles = []struct {
+	Name     string
+	Fraction float64
+}{
+	{"p25", 0.25},
+	{"p50", 0.5},
+	{"p75", 0.75},
+	{"p95", 0.95},
+}
+
+// ColumnStatsKind classifies a column by its type as the schema reports it: numeric, timestamp or text.
+// Anything neither numeric nor a date, booleans and enums included, is described like text, by its values.
+func ColumnStatsKind(columnType string) string {
+	normalized := strings.ToLower(strings.TrimSpace(columnType))
+	for {
+		match := statsWrapperPattern.FindStringSubmatch(normalized)
+		if match == nil {
+			break
+		}
+		normalized = strings.TrimSpace(match[1])
+	}
+
+	switch {
+	case statsTimestampPattern.MatchString(normalized):
+		return constants.ColumnStatsKindTimestamp
+	case statsNumericPattern.MatchString(normalized):
+		return constants.ColumnStatsKindNumeric
+	default:
+		return constants.ColumnStatsKindText
+	}
+}
+
+// BuildColumnStatsQueries builds the statistics queries of a column in the database's dialect, from templates
+// rather than the LLM. Table and column come from the schema and are quoted, kind is a ColumnStatsKind.
+func BuildColumnStatsQueries(dbType, table, column, kind string) (*ColumnStatsQueries, error) {
+	switch dbType {
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		return buildMongoColumnStatsQueries(table, column, kind)
+	}
+
+	dialect, ok := statsDialects(dbType)
+	if !ok {
+		return nil, fmt.Errorf("column statistics are not supported for %s", dbType)
+	}
+
+	quotedTable := dialect.quote(table)
+	quotedColumn := dialect.quote(column)
+
+	selectList := []string{
+		"COUNT(*) AS total_count",
+		fmt.Sprintf("COUNT(*) - COUNT(%s) AS null_count", quotedColumn),
+		fmt.Sprintf("COUNT(DISTINCT %s) AS distinct_count", quotedColumn),
+	}
+	if kind == constants.ColumnStatsKindNumeric || kind == constants.ColumnStatsKindTimestamp {
+		selectList = append(selectList,
+			fmt.Sprintf("MIN(%s) AS min_value", quotedColumn),
+			fmt.Sprintf("MAX(%s) AS max_value", quotedColumn))
+	}
+	if kind == constants.ColumnStatsKindNumeric {
+		selectList = append(selectList,
+			fmt.Sprintf("AVG(%s) AS avg_value", quotedColumn),
+			fmt.Sprintf("%s(%s) AS stddev_value", dialect.stddev, quotedColumn))
+		if dialect.percentile != nil {
+			for _, percentile := range statsPercentiles {
+				selectList = append(selectList, fmt.Sprintf("%s AS %s", dialect.percentile(quotedColumn, percentile.Fraction), percentile.Name))
+			}
+		}
+	}
+
+	queries := &ColumnStatsQueries{
+		Summary: fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectList, ", "), quotedTable),
+	}
+
+	switch kind {
+	case constants.ColumnStatsKindText:
+		queries.TopValues = fmt.Sprintf("SELECT %s AS stat_value, COUNT(*) AS stat_count FROM %s WHERE %s IS NOT NULL GROUP BY %s ORDER BY stat_count DESC %s",
+			quotedColumn, quotedTable, quotedColumn, quotedColumn, dialect.limit(constants.ColumnStatsTopValuesLimit))
+	case constants.ColumnStatsKindTimestamp:
+		month := dialect.month(quotedColumn)
+		queries.Distribution = fmt.Sprintf("SELECT %s AS stat_month, COUNT(*) AS stat_count FROM %s WHERE %s IS NOT NULL GROUP BY %s ORDER BY stat_month DESC %s",
+			month, quotedTable, quotedColumn, month, dialect.limit(constants.ColumnStatsMaxMonths))
+	}
+	return queries, nil
+}
+
+// statsDialect holds what the statistics templates need to know about a SQL dialect
+type statsDialect struct {
+	quote      func(string) string
+	stddev     string
+	percentile func(column string, fraction float64) string // nil when the database has no percentile function
+	month      func(column string) string                   // The first day of the value's month
+	limit      func(rows int) string
+}
+
+func statsDialects(dbType string) (statsDialect, bool) {
+	quoted := func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` }
+	backticked := func(name string) string { return "`" + strings.ReplaceAll(name, "`", "``") + "`" }
+	limit := func(rows int) string { return fmt.Sprintf("LIMIT %d", rows) }
+	dateTrunc := func(column string) string { return fmt.Sprintf("date_trunc('month', %s)", column) }
+	percentileDisc := func(column string, fraction float64) string {
+		return fmt.Sprintf("percentile_disc(%g) WITHIN GROUP (ORDER BY %s)", fraction, column)
+	}
+
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeSpreadsheet, constants.DatabaseTypeGoogleSheets:
+		return statsDialect{quote: quoted, stddev: "STDDEV", percentile: percentileDisc, month: dateTrunc, limit: limit}, true
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypePlanetscale:
+		return statsDialect{quote: backticked, stddev: "STDDEV", month: statsMySQLMonth, limit: limit}, true
+	case constants.DatabaseTypeStarRocks:
+		return statsDialect{
+			quote:  backticked,
+			stddev: "STDDEV",
+			percentile: func(column string, fraction float64) string {
+				return fmt.Sprintf("percentile_approx(%s, %g)", column, fraction)
+			},
+			month: statsMySQLMonth,
+			limit: limit,
+		}, true
+	case constants.DatabaseTypeClickhouse:
+		return statsDialect{
+			quote:  backticked,
+			stddev: "stddevSamp",
+			percentile: func(column string, fraction float64) string {
+				return fmt.Sprintf("quantileExact(%g)(%s)", fraction, column)
+			},
+			month: func(column string) string { return fmt.Sprintf("toStartOfMonth(%s)", column) },
+			limit: limit,
+		}, true
+	case constants.DatabaseTypeTrino:
+		return statsDialect{
+			quote:  quoted,
+			stddev: "stddev",
+			percentile: func(column string, fraction float64) string {
+				return fmt.Sprintf("approx_percentile(%s, %g)", column, fraction)
+			},
+			month: dateTrunc,
+			limit: limit,
+		}, true
+	case constants.DatabaseTypeInfluxDB:
+		return statsDialect{
+			quote:  quoted,
+			stddev: "stddev",
+			percentile: func(column string, fraction float64) string {
+				return fmt.Sprintf("approx_percentile_cont(%s, %g)", column, fraction)
+			},
+			month: dateTrunc,
+			limit: limit,
+		}, true
+	case constants.DatabaseTypeOracle:
+		return statsDialect{
+			quote:      quoted,
+			stddev:     "STDDEV",
+			percentile: percentileDisc,
+			month:      func(column string) string { return fmt.Sprintf("TRUNC(%s, 'MM')", column) },
+			limit:      func(rows int) string { return fmt.Sprintf("FETCH FIRST %d ROWS ONLY", rows) },
+		}, true
+	}
+	return statsDialect{}, false
+}
+
+func statsMySQLMonth(column string) string {
+	return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-01')", column)
+}
+
+// buildMongoColumnStatsQueries builds aggregation pipelines, a column being a field path such as address.city
+func buildMongoColumnStatsQueries(collection, field, kind string) (*ColumnStatsQueries, error) {
+	// The MongoDB driver splits the query on dots, so such collection names cannot be addressed this way
+	if strings.Contains(collection, ".") {
+		return nil, fmt.Errorf("collection %s cannot be analyzed", collection)
+	}
+	path := "$" + field
+
+	group := map[string]interface{}{
+		"_id":         nil,
+		"total_count": map[string]interface{}{"$sum": 1},
+		"null_count": map[string]interface{}{"$sum": map[string]interface{}{
+			"$cond": []interface{}{map[string]interface{}{"$eq": []interface{}{map[string]interface{}{"$ifNull": []interface{}{path, nil}}, nil}}, 1, 0},
+		}},
+	}
+	if kind == constants.ColumnStatsKindNumeric || kind == constants.ColumnStatsKindTimestamp {
+		group["min_value"] = map[string]interface{}{"$min": path}
+		group["max_value"] = map[string]interface{}{"$max": path}
+	}
+	if kind == constants.ColumnStatsKindNumeric {
+		group["avg_value"] = map[string]interface{}{"$avg": path}
+		group["stddev_value"] = map[string]interface{}{"$stdDevSamp": path}
+	}
+
+	aggregate := func(stages ...map[string]interface{}) (string, error) {
+		pipeline, err := json.Marshal(stages)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("db.%s.aggregate(%s)", collection, pipeline), nil
+	}
+
+	queries := &ColumnStatsQueries{}
+	var err error
+	if queries.Summary, err = aggregate(
+		map[string]interface{}{"$group": group},
+		map[string]interface{}{"$project": map[string]interface{}{"_id": 0}},
+	); err != nil {
+		return nil, err
+	}
+	if queries.DistinctCount, err = aggregate(
+		map[string]interface{}{"$group": map[string]interface{}{"_id": path}},
+		map[string]interface{}{"$match": map[string]interface{}{"_id": map[string]interface{}{"$ne": nil}}},
+		map[string]interface{}{"$count": "distinct_count"},
+	); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case constants.ColumnStatsKindText:
+		queries.TopValues, err = aggregate(
+			map[string]interface{}{"$match": map[string]interface{}{field: map[string]interface{}{"$ne": nil}}},
+			map[string]interface{}{"$group": map[string]interface{}{"_id": path, "stat_count": map[string]interface{}{"$sum": 1}}},
+			map[string]interface{}{"$sort": map[string]interface{}{"stat_count": -1}},
+			map[string]interface{}{"$limit": constants.ColumnStatsTopValuesLimit},
+			map[string]interface{}{"$project": map[string]interface{}{"_id": 0, "stat_value": "$_id", "stat_count": 1}},
+		)
+	case constants.ColumnStatsKindTimestamp:
+		queries.Distribution, err = aggregate(
+			map[string]interface{}{"$match": map[string]interface{}{field: map[string]interface{}{"$type": "date"}}},
+			map[string]interface{}{"$group": map[string]interface{}{
+				"_id":        map[string]interface{}{"$dateToString": map[string]interface{}{"format": "%Y-%m", "date": path}},
+				"stat_count": map[string]interface{}{"$sum": 1},
+			}},
+			map[string]interface{}{"$sort": map[string]interface{}{"_id": -1}},
+			map[string]interface{}{"$limit": constants.ColumnStatsMaxMonths},
+			map[string]interface{}{"$project": map[string]interface{}{"_id": 0, "stat_month": "$_id", "stat_count": 1}},
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
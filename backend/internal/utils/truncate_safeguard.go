@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	truncateStatementPattern = regexp.MustCompile(`(?i)^\s*TRUNCATE\s+(TABLE\s+)?(ONLY\s+)?(IF\s+EXISTS\s+)?(.+)$`)
+	truncateIdentifierQuotes = strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "")
+)
+
+// TruncateTableSafeguard finds the tables a TRUNCATE empties, so the user has to type their names before it runs.
+// TRUNCATE cannot be undone in most databases, the same way GitHub asks for a repository's name before deleting it.
+type TruncateTableSafeguard struct{}
+
+// NewTruncateTableSafeguard creates a TRUNCATE safeguard
+func NewTruncateTableSafeguard() *TruncateTableSafeguard {
+	return &TruncateTableSafeguard{}
+}
+
+// ConfirmationText returns the text the user must type to run the query: the names of the truncated tables,
+// separated by ", " when there are several. It returns "" when the query truncates no table.
+func (g *TruncateTableSafeguard) ConfirmationText(query string) string {
+	var tables []string
+	seen := make(map[string]bool)
+	for _, statement := range splitDDLStatements(query) {
+		match := truncateStatementPattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+		// TRUNCATE a, b CASCADE: the table name is the first word of each comma separated item
+		for _, item := range strings.Split(match[4], ",") {
+			fields := strings.Fields(item)
+			if len(fields) == 0 {
+				continue
+			}
+			table := truncateIdentifierQuotes.Replace(strings.TrimSuffix(fields[0], "*"))
+			if table == "" || seen[table] {
+				continue
+			}
+			seen[table] = true
+			tables = append(tables, table)
+		}
+	}
+	return strings.Join(tables, ", ")
+}
@@ -0,0 +1,798 @@
+package dbmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AirtableClient is a minimal client for the Airtable REST API, scoped to a single base
+type AirtableClient struct {
+	apiKey     string
+	baseID     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// airtableRecord is a record as returned by the Airtable API
+type airtableRecord struct {
+	ID          string                 `json:"id"`
+	CreatedTime string                 `json:"createdTime"`
+	Fields      map[string]interface{} `json:"fields"`
+}
+
+// airtableListResponse is a page of the list records endpoint
+type airtableListResponse struct {
+	Records []airtableRecord `json:"records"`
+	Offset  string           `json:"offset"`
+}
+
+// airtableDeleteResponse is the response of the delete records endpoint
+type airtableDeleteResponse struct {
+	Records []struct {
+		ID      string `json:"id"`
+		Deleted bool   `json:"deleted"`
+	} `json:"records"`
+}
+
+// airtableSelectOptions mirrors the options of airtable.js select()
+type airtableSelectOptions struct {
+	FilterByFormula string               `json:"filterByFormula,omitempty"`
+	Fields          []string             `json:"fields,omitempty"`
+	Sort            []airtableSortOption `json:"sort,omitempty"`
+	MaxRecords      int                  `json:"maxRecords,omitempty"`
+	PageSize        int                  `json:"pageSize,omitempty"`
+	View            string               `json:"view,omitempty"`
+	Offset          string               `json:"offset,omitempty"`
+}
+
+type airtableSortOption struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// airtableWriteRecord is a record in a create or update request
+type airtableWriteRecord struct {
+	ID     string                 `json:"id,omitempty"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// airtableOperation is a parsed Airtable query, e.g. base("Tasks").select({...})
+type airtableOperation struct {
+	Table  string
+	Method string
+	Args   string
+}
+
+var (
+	// airtableOperationPattern matches base("Table").method(args)
+	airtableOperationPattern = regexp.MustCompile(`(?s)^base\(\s*("(?:[^"\\]|\\.)*"|'[^']*')\s*\)\s*\.\s*(select|find|create|update|destroy)\s*\((.*)\)$`)
+	// airtableListTablesPattern matches base.tables()
+	airtableListTablesPattern = regexp.MustCompile(`^base\s*\.\s*tables\s*\(\s*\)$`)
+)
+
+// newAirtableClient creates a client for the base in the connection config
+func newAirtableClient(config ConnectionConfig) (*AirtableClient, error) {
+	if config.AirtableAPIKey == nil || *config.AirtableAPIKey == "" {
+		return nil, fmt.Errorf("an Airtable personal access token is required")
+	}
+	if config.AirtableBaseID == nil || *config.AirtableBaseID == "" {
+		return nil, fmt.Errorf("an Airtable base ID is required")
+	}
+
+	return &AirtableClient{
+		apiKey:  *config.AirtableAPIKey,
+		baseID:  *config.AirtableBaseID,
+		baseURL: constants.AirtableAPIBaseURL,
+		httpClient: &http.Client{
+			Timeout: constants.AirtableRequestTimeout,
+		},
+	}, nil
+}
+
+// do sends a request to the Airtable API and decodes the JSON response into out
+func (c *AirtableClient) do(ctx context.Context, method, path string, params url.Values, body interface{}, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode Airtable request: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create Airtable request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Airtable request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Airtable response: %v", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return parseAirtableError(resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode Airtable response: %v", err)
+	}
+	return nil
+}
+
+// parseAirtableError turns an Airtable error body into an error.
+// Airtable returns either {"error": {"type": "...", "message": "..."}} or {"error": "NOT_FOUND"}.
+func parseAirtableError(status int, body []byte) error {
+	if status == http.StatusTooManyRequests {
+		return fmt.Errorf("Airtable rate limit exceeded (5 requests per second per base), wait 30 seconds and try again")
+	}
+
+	var payload struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && len(payload.Error) > 0 {
+		var detailed struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(payload.Error, &detailed); err == nil && detailed.Type != "" {
+			if detailed.Message != "" {
+				return fmt.Errorf("Airtable error %s: %s", detailed.Type, detailed.Message)
+			}
+			return fmt.Errorf("Airtable error %s", detailed.Type)
+		}
+		var code string
+		if err := json.Unmarshal(payload.Error, &code); err == nil && code != "" {
+			return fmt.Errorf("Airtable error %s (HTTP %d)", code, status)
+		}
+	}
+	return fmt.Errorf("Airtable request failed with HTTP %d", status)
+}
+
+// tablePath returns the records path of a table, Airtable accepts either the table name or ID
+func (c *AirtableClient) tablePath(table string) string {
+	return "/" + url.PathEscape(c.baseID) + "/" + url.PathEscape(table)
+}
+
+// listTables fetches the tables of the base from the Meta API
+func (c *AirtableClient) listTables(ctx context.Context) ([]airtableTable, error) {
+	var resp struct {
+		Tables []airtableTable `json:"tables"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/meta/bases/"+url.PathEscape(c.baseID)+"/tables", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tables, nil
+}
+
+// listRecords fetches a single page of records
+func (c *AirtableClient) listRecords(ctx context.Context, table string, opts airtableSelectOptions) (*airtableListResponse, error) {
+	params := url.Values{}
+	if opts.FilterByFormula != "" {
+		params.Set("filterByFormula", opts.FilterByFormula)
+	}
+	for _, field := range opts.Fields {
+		params.Add("fields[]", field)
+	}
+	for i, sort := range opts.Sort {
+		params.Set(fmt.Sprintf("sort[%d][field]", i), sort.Field)
+		if sort.Direction != "" {
+			params.Set(fmt.Sprintf("sort[%d][direction]", i), strings.ToLower(sort.Direction))
+		}
+	}
+	if opts.MaxRecords > 0 {
+		params.Set("maxRecords", strconv.Itoa(opts.MaxRecords))
+	}
+	if opts.PageSize > 0 {
+		params.Set("pageSize", strconv.Itoa(opts.PageSize))
+	}
+	if opts.View != "" {
+		params.Set("view", opts.View)
+	}
+	if opts.Offset != "" {
+		params.Set("offset", opts.Offset)
+	}
+
+	var resp airtableListResponse
+	if err := c.do(ctx, http.MethodGet, c.tablePath(table), params, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ping checks the token and base ID with a Meta API call
+func (c *AirtableClient) ping(ctx context.Context) error {
+	_, err := c.listTables(ctx)
+	return err
+}
+
+// AirtableDriver implements the DatabaseDriver interface for Airtable bases
+type AirtableDriver struct{}
+
+// NewAirtableDriver creates a new Airtable driver
+func NewAirtableDriver() DatabaseDriver {
+	return &AirtableDriver{}
+}
+
+// Connect validates the API key and base ID and returns a connection holding the REST client
+func (d *AirtableDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newAirtableClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.AirtableRequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to Airtable: %v", err)
+	}
+
+	log.Printf("AirtableDriver -> Connect -> Connected to Airtable base %s", client.baseID)
+
+	conn := &Connection{
+		DB:          nil, // Airtable is accessed over REST, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect releases the idle HTTP connections of the client
+func (d *AirtableDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*AirtableClient)
+	if !ok {
+		return fmt.Errorf("invalid Airtable connection")
+	}
+	client.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Ping checks if the Airtable base is still reachable with the stored token
+func (d *AirtableDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*AirtableClient)
+	if !ok {
+		return fmt.Errorf("invalid Airtable connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("AirtableDriver -> Ping -> Airtable check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the Airtable connection is still valid
+func (d *AirtableDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("AirtableDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes an Airtable operation
+func (d *AirtableDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*AirtableClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeAirtableOperation(ctx, client, query)
+}
+
+// parseAirtableOperation parses base("Table").method(args)
+func parseAirtableOperation(query string) (*airtableOperation, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	query = strings.TrimSpace(query)
+
+	if airtableListTablesPattern.MatchString(query) {
+		return &airtableOperation{Method: "tables"}, nil
+	}
+
+	matches := airtableOperationPattern.FindStringSubmatch(query)
+	if matches == nil {
+		return nil, fmt.Errorf(`invalid Airtable query, expected base("Table").select({...}), find, create, update, destroy or base.tables()`)
+	}
+
+	table := matches[1]
+	if strings.HasPrefix(table, `"`) {
+		if err := json.Unmarshal([]byte(table), &table); err != nil {
+			return nil, fmt.Errorf("invalid table name %s: %v", matches[1], err)
+		}
+	} else {
+		table = strings.Trim(table, "'")
+	}
+	if table == "" {
+		return nil, fmt.Errorf("table name is required")
+	}
+
+	return &airtableOperation{
+		Table:  table,
+		Method: matches[2],
+		Args:   strings.TrimSpace(matches[3]),
+	}, nil
+}
+
+// executeAirtableOperation runs a single Airtable operation. Writes are applied immediately:
+// the Airtable API has no transactions, so there is nothing to roll back.
+func executeAirtableOperation(ctx context.Context, client *AirtableClient, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	op, err := parseAirtableOperation(query)
+	if err != nil {
+		result.Error = &dtos.QueryError{
+			Message: err.Error(),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("AirtableDriver -> executeAirtableOperation -> %s on table %q", op.Method, op.Table)
+
+	switch op.Method {
+	case "tables":
+		tables, err := client.listTables(ctx)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		rows := make([]map[string]interface{}, 0, len(tables))
+		for _, table := range tables {
+			rows = append(rows, map[string]interface{}{
+				"id":          table.ID,
+				"name":        table.Name,
+				"description": table.Description,
+				"fields":      len(table.Fields),
+			})
+		}
+		result.Result = rows
+
+	case "select":
+		rows, err := selectAirtableRecords(ctx, client, op)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = rows
+
+	case "find":
+		var recordID string
+		if err := json.Unmarshal([]byte(op.Args), &recordID); err != nil {
+			recordID = strings.Trim(op.Args, `'"`)
+		}
+		if recordID == "" {
+			result.Error = &dtos.QueryError{Message: "find() requires a record ID", Code: "INVALID_QUERY"}
+			return result
+		}
+		var record airtableRecord
+		if err := client.do(ctx, http.MethodGet, client.tablePath(op.Table)+"/"+url.PathEscape(recordID), nil, nil, &record); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = []map[string]interface{}{flattenAirtableRecord(record)}
+
+	case "create", "update":
+		records, err := parseAirtableWriteRecords(op)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "INVALID_QUERY"}
+			return result
+		}
+		method := http.MethodPost
+		if op.Method == "update" {
+			method = http.MethodPatch
+		}
+
+		written := make([]map[string]interface{}, 0, len(records))
+		for start := 0; start < len(records); start += constants.AirtableMaxBatchSize {
+			end := start + constants.AirtableMaxBatchSize
+			if end > len(records) {
+				end = len(records)
+			}
+			var resp airtableListResponse
+			body := map[string]interface{}{
+				"records":  records[start:end],
+				"typecast": true,
+			}
+			if err := client.do(ctx, method, client.tablePath(op.Table), nil, body, &resp); err != nil {
+				result.Error = airtablePartialWriteError(err, op.Method, len(written))
+				return result
+			}
+			for _, record := range resp.Records {
+				written = append(written, flattenAirtableRecord(record))
+			}
+		}
+
+		verb := "created"
+		if op.Method == "update" {
+			verb = "updated"
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected": len(written),
+			"message":      fmt.Sprintf("%d record(s) %s", len(written), verb),
+			"records":      written,
+		}
+
+	case "destroy":
+		var recordIDs []string
+		if err := json.Unmarshal([]byte(op.Args), &recordIDs); err != nil {
+			var recordID string
+			if err := json.Unmarshal([]byte(op.Args), &recordID); err != nil {
+				result.Error = &dtos.QueryError{
+					Message: fmt.Sprintf("destroy() expects a JSON array of record IDs: %v", err),
+					Code:    "INVALID_QUERY",
+				}
+				return result
+			}
+			recordIDs = []string{recordID}
+		}
+		if len(recordIDs) == 0 {
+			result.Error = &dtos.QueryError{Message: "destroy() requires at least one record ID", Code: "INVALID_QUERY"}
+			return result
+		}
+
+		deleted := 0
+		for start := 0; start < len(recordIDs); start += constants.AirtableMaxBatchSize {
+			end := start + constants.AirtableMaxBatchSize
+			if end > len(recordIDs) {
+				end = len(recordIDs)
+			}
+			params := url.Values{}
+			for _, id := range recordIDs[start:end] {
+				params.Add("records[]", id)
+			}
+			var resp airtableDeleteResponse
+			if err := client.do(ctx, http.MethodDelete, client.tablePath(op.Table), params, nil, &resp); err != nil {
+				result.Error = airtablePartialWriteError(err, op.Method, deleted)
+				return result
+			}
+			for _, record := range resp.Records {
+				if record.Deleted {
+					deleted++
+				}
+			}
+		}
+
+		result.Result = map[string]interface{}{
+			"rowsAffected": deleted,
+			"message":      fmt.Sprintf("%d record(s) deleted", deleted),
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// selectAirtableRecords follows Airtable's offset cursor until maxRecords is reached.
+// A select with pageSize or offset fetches one page only and returns the next page's
+// offset on the last record, so the cursor pagination of the chat can request it.
+func selectAirtableRecords(ctx context.Context, client *AirtableClient, op *airtableOperation) ([]map[string]interface{}, error) {
+	var opts airtableSelectOptions
+	if op.Args != "" {
+		if err := json.Unmarshal([]byte(op.Args), &opts); err != nil {
+			return nil, fmt.Errorf("select() options must be a JSON object: %v", err)
+		}
+	}
+	if opts.PageSize > constants.AirtableMaxPageSize {
+		opts.PageSize = constants.AirtableMaxPageSize
+	}
+
+	singlePage := opts.PageSize > 0 || opts.Offset != ""
+	limit := opts.MaxRecords
+	if limit <= 0 && !singlePage {
+		limit = constants.AirtableDefaultMaxRecords
+	}
+
+	rows := make([]map[string]interface{}, 0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("query execution cancelled: %v", err)
+		}
+
+		page, err := client.listRecords(ctx, op.Table, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range page.Records {
+			rows = append(rows, flattenAirtableRecord(record))
+		}
+
+		if singlePage {
+			if page.Offset != "" && len(rows) > 0 {
+				rows[len(rows)-1][constants.AirtableOffsetField] = page.Offset
+			}
+			break
+		}
+		if page.Offset == "" || len(rows) >= limit {
+			break
+		}
+		opts.Offset = page.Offset
+	}
+
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+// parseAirtableWriteRecords parses the records of create() and update(), a single record object is also accepted
+func parseAirtableWriteRecords(op *airtableOperation) ([]airtableWriteRecord, error) {
+	var records []airtableWriteRecord
+	if err := json.Unmarshal([]byte(op.Args), &records); err != nil {
+		var record airtableWriteRecord
+		if err := json.Unmarshal([]byte(op.Args), &record); err != nil {
+			return nil, fmt.Errorf("%s() expects a JSON array of records: %v", op.Method, err)
+		}
+		records = []airtableWriteRecord{record}
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s() requires at least one record", op.Method)
+	}
+
+	for i, record := range records {
+		if len(record.Fields) == 0 {
+			return nil, fmt.Errorf("record %d has no fields", i+1)
+		}
+		if op.Method == "update" && record.ID == "" {
+			return nil, fmt.Errorf("record %d has no id, update() needs the record IDs", i+1)
+		}
+		if op.Method == "create" {
+			records[i].ID = ""
+		}
+	}
+	return records, nil
+}
+
+// airtablePartialWriteError reports a failed write. Earlier batches were already applied and cannot be rolled back.
+func airtablePartialWriteError(err error, method string, applied int) *dtos.QueryError {
+	queryErr := &dtos.QueryError{
+		Message: err.Error(),
+		Code:    "EXECUTION_ERROR",
+	}
+	if applied > 0 {
+		queryErr.Details = fmt.Sprintf("%d record(s) were already written by %s() before the error, Airtable cannot roll them back", applied, method)
+	}
+	return queryErr
+}
+
+// airtableInjectOffset substitutes the offset cursor into a paginated select.
+// The LLM may or may not quote the placeholder, so quoted forms are replaced first.
+func airtableInjectOffset(query, offset string) string {
+	const placeholder = "{{cursor_value}}"
+
+	encoded, _ := json.Marshal(offset)
+	query = strings.ReplaceAll(query, `"`+placeholder+`"`, string(encoded))
+	query = strings.ReplaceAll(query, "'"+placeholder+"'", string(encoded))
+	return strings.ReplaceAll(query, placeholder, string(encoded))
+}
+
+// flattenAirtableRecord returns the record's fields with its id and createdTime as a single row
+func flattenAirtableRecord(record airtableRecord) map[string]interface{} {
+	row := make(map[string]interface{}, len(record.Fields)+2)
+	for key, value := range record.Fields {
+		row[key] = value
+	}
+	row["id"] = record.ID
+	if record.CreatedTime != "" {
+		row["createdTime"] = record.CreatedTime
+	}
+	return row
+}
+
+// BeginTx returns a transaction that executes operations immediately.
+// Airtable has no transactions, every write is committed by the API as soon as it succeeds.
+func (d *AirtableDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*AirtableClient)
+	if !ok {
+		log.Printf("AirtableDriver.BeginTx: Invalid Airtable connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &AirtableTransaction{
+		client: client,
+	}
+}
+
+// AirtableTransaction implements the Transaction interface for Airtable in autocommit mode
+type AirtableTransaction struct {
+	client *AirtableClient
+}
+
+// ExecuteQuery executes an operation. Writes are applied as soon as the API accepts them.
+func (t *AirtableTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeAirtableOperation(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as operations are already applied
+func (t *AirtableTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo operations that already ran on Airtable
+func (t *AirtableTransaction) Rollback() error {
+	log.Printf("AirtableTransaction -> Rollback -> Airtable has no transactions, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the tables and fields of the base
+func (d *AirtableDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("AirtableDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewAirtableSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a table
+func (d *AirtableDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("AirtableDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewAirtableSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches example records from a table
+func (d *AirtableDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("AirtableDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewAirtableSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// AirtableExecutor implements the DBExecutor interface for Airtable
+type AirtableExecutor struct {
+	client *AirtableClient
+	conn   *Connection
+}
+
+// NewAirtableExecutor creates a new Airtable executor
+func NewAirtableExecutor(conn *Connection) (*AirtableExecutor, error) {
+	client, ok := conn.APIClient.(*AirtableClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid Airtable connection")
+	}
+
+	return &AirtableExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for Airtable as it doesn't use GORM
+func (e *AirtableExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *AirtableExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes an operation and returns its error, if any
+func (e *AirtableExecutor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.AirtableRequestTimeout)
+	defer cancel()
+	return executeAirtableOperation(ctx, e.client, query)
+}
+
+// Raw executes an Airtable operation, *Not Used By DBManager*
+func (e *AirtableExecutor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute Airtable operation: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes an Airtable operation, *Not Used By DBManager*
+func (e *AirtableExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes an Airtable read and stores the records in dest
+func (e *AirtableExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes an Airtable read and stores the records in dest
+func (e *AirtableExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	result := e.run(query)
+	if result.Error != nil {
+		return fmt.Errorf("failed to execute Airtable operation: %v", result.Error.Message)
+	}
+	rows, ok := result.Result.([]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Airtable operation did not return records")
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the HTTP client is released by the driver on disconnect
+func (e *AirtableExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the Airtable schema
+func (e *AirtableExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &AirtableDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for an Airtable table
+func (e *AirtableExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &AirtableDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
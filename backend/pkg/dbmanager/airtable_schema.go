@@ -0,0 +1,332 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// airtableTable is a table returned by the Meta API (GET /meta/bases/{baseId}/tables)
+type airtableTable struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	PrimaryFieldID string          `json:"primaryFieldId"`
+	Fields         []airtableField `json:"fields"`
+	Views          []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"views"`
+}
+
+// airtableField is a field of an Airtable table
+type airtableField struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Description string                 `json:"description"`
+	Options     map[string]interface{} `json:"options,omitempty"`
+}
+
+// airtableReadOnlyFieldTypes are computed by Airtable and cannot be written
+var airtableReadOnlyFieldTypes = map[string]bool{
+	"formula":              true,
+	"rollup":               true,
+	"multipleLookupValues": true,
+	"count":                true,
+	"autoNumber":           true,
+	"createdTime":          true,
+	"lastModifiedTime":     true,
+	"createdBy":            true,
+	"lastModifiedBy":       true,
+	"button":               true,
+}
+
+// AirtableSchemaFetcher implements schema fetching for Airtable bases using the Meta API
+type AirtableSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewAirtableSchemaFetcher creates a new Airtable schema fetcher
+func NewAirtableSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &AirtableSchemaFetcher{db: db}
+}
+
+// client returns the REST client of the executor
+func (f *AirtableSchemaFetcher) client(db DBExecutor) (*AirtableClient, error) {
+	executor, ok := db.(*AirtableExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid Airtable connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema retrieves the tables and fields of the base
+func (f *AirtableSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("AirtableSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("AirtableSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := client.listTables(ctx)
+	if err != nil {
+		log.Printf("AirtableSchemaFetcher -> GetSchema -> Error fetching tables: %v", err)
+		return nil, fmt.Errorf("failed to fetch tables: %v", err)
+	}
+
+	// Linked record fields reference tables by ID
+	tableNames := make(map[string]string, len(tables))
+	for _, table := range tables {
+		tableNames[table.ID] = table.Name
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, table := range tables {
+		if filterTables && !selected[table.Name] {
+			continue
+		}
+
+		tableSchema := buildAirtableTableSchema(table, tableNames)
+		tableData, _ := json.Marshal(tableSchema)
+		tableSchema.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[table.Name] = tableSchema
+
+		// Views are per table and can be passed as the "view" option of select()
+		for _, view := range table.Views {
+			schema.Views[table.Name+"."+view.Name] = ViewSchema{
+				Name:       view.Name,
+				Definition: fmt.Sprintf("%s view of table %s", view.Type, table.Name),
+			}
+		}
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("AirtableSchemaFetcher -> GetSchema -> Fetched %d tables and %d views", len(schema.Tables), len(schema.Views))
+	return schema, nil
+}
+
+// buildAirtableTableSchema converts a Meta API table into a TableSchema.
+// Airtable has no row count endpoint, so RowCount is left at 0.
+func buildAirtableTableSchema(table airtableTable, tableNames map[string]string) TableSchema {
+	tableSchema := TableSchema{
+		Name:        table.Name,
+		Comment:     table.Description,
+		Columns:     make(map[string]ColumnInfo),
+		Indexes:     make(map[string]IndexInfo),
+		ForeignKeys: make(map[string]ForeignKey),
+		Constraints: make(map[string]ConstraintInfo),
+	}
+
+	// Every record has a system id, which is the key for find, update and destroy
+	tableSchema.Columns["id"] = ColumnInfo{
+		Name:       "id",
+		Type:       "recordId",
+		IsNullable: false,
+		Comment:    "Airtable record ID (rec...)",
+	}
+	tableSchema.Indexes["record_id"] = IndexInfo{
+		Name:     "record_id",
+		Columns:  []string{"id"},
+		IsUnique: true,
+	}
+
+	for _, field := range table.Fields {
+		var notes []string
+		if field.Description != "" {
+			notes = append(notes, field.Description)
+		}
+		if field.ID == table.PrimaryFieldID {
+			notes = append(notes, "primary field")
+		}
+		if airtableReadOnlyFieldTypes[field.Type] {
+			notes = append(notes, "read-only")
+		}
+		if choices := airtableFieldChoices(field); len(choices) > 0 {
+			notes = append(notes, "options: "+strings.Join(choices, ", "))
+		}
+
+		tableSchema.Columns[field.Name] = ColumnInfo{
+			Name:       field.Name,
+			Type:       field.Type,
+			IsNullable: true, // Airtable fields are never required
+			Comment:    strings.Join(notes, "; "),
+		}
+
+		if field.Type == "multipleRecordLinks" {
+			if linkedTableID, ok := field.Options["linkedTableId"].(string); ok {
+				refTable := tableNames[linkedTableID]
+				if refTable == "" {
+					refTable = linkedTableID
+				}
+				tableSchema.ForeignKeys[field.Name] = ForeignKey{
+					Name:       field.Name,
+					ColumnName: field.Name,
+					RefTable:   refTable,
+					RefColumn:  "id",
+				}
+			}
+		}
+	}
+
+	return tableSchema
+}
+
+// airtableFieldChoices returns the choice names of single and multiple select fields
+func airtableFieldChoices(field airtableField) []string {
+	if field.Type != "singleSelect" && field.Type != "multipleSelects" {
+		return nil
+	}
+	rawChoices, ok := field.Options["choices"].([]interface{})
+	if !ok {
+		return nil
+	}
+	choices := make([]string, 0, len(rawChoices))
+	for _, raw := range rawChoices {
+		if choice, ok := raw.(map[string]interface{}); ok {
+			if name, ok := choice["name"].(string); ok {
+				choices = append(choices, name)
+			}
+		}
+	}
+	return choices
+}
+
+// GetTableChecksum calculates a checksum for a table's field definitions
+func (f *AirtableSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("AirtableSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	tables, err := client.listTables(ctx)
+	if err != nil {
+		log.Printf("AirtableSchemaFetcher -> GetTableChecksum -> Error fetching tables: %v", err)
+		return "", fmt.Errorf("failed to get table definition: %v", err)
+	}
+
+	for _, t := range tables {
+		if t.Name != table && t.ID != table {
+			continue
+		}
+		definitions := make([]string, 0, len(t.Fields))
+		for _, field := range t.Fields {
+			definitions = append(definitions, fmt.Sprintf("%s:%s:%s;", field.ID, field.Name, field.Type))
+		}
+		sort.Strings(definitions)
+		return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(definitions, "")))), nil
+	}
+
+	return "", fmt.Errorf("no table definition found for table: %s", table)
+}
+
+// FetchExampleRecords retrieves sample records from a table
+func (f *AirtableSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("AirtableSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := client.listRecords(ctx, table, airtableSelectOptions{MaxRecords: limit})
+	if err != nil {
+		log.Printf("AirtableSchemaFetcher -> FetchExampleRecords -> Error fetching records from table %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for table %s: %v", table, err)
+	}
+
+	records := make([]map[string]interface{}, 0, len(page.Records))
+	for _, record := range page.Records {
+		records = append(records, flattenAirtableRecord(record))
+	}
+	return records, nil
+}
+
+// AirtableSimplifier implements SchemaSimplifier for Airtable field types
+type AirtableSimplifier struct{}
+
+// SimplifyDataType maps Airtable field types to readable type names
+func (s *AirtableSimplifier) SimplifyDataType(dbType string) string {
+	switch dbType {
+	case "singleLineText", "multilineText", "richText", "email", "url", "phoneNumber":
+		return "text"
+	case "number", "currency", "percent", "duration", "rating", "autoNumber", "count":
+		return "number"
+	case "checkbox":
+		return "boolean"
+	case "date":
+		return "date"
+	case "dateTime", "createdTime", "lastModifiedTime":
+		return "datetime"
+	case "singleSelect":
+		return "select"
+	case "multipleSelects":
+		return "multi-select"
+	case "multipleRecordLinks":
+		return "linked records"
+	case "multipleAttachments":
+		return "attachments"
+	case "singleCollaborator", "multipleCollaborators", "createdBy", "lastModifiedBy":
+		return "user"
+	case "formula", "rollup", "multipleLookupValues":
+		return "computed"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints returns constraints for an Airtable field
+func (s *AirtableSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	if col.Name == "id" && col.Type == "recordId" {
+		constraints = append(constraints, "PRIMARY KEY")
+	}
+	if airtableReadOnlyFieldTypes[col.Type] {
+		constraints = append(constraints, "READ ONLY")
+	}
+	if fk, ok := table.ForeignKeys[col.Name]; ok {
+		constraints = append(constraints, fmt.Sprintf("LINKS TO %s", fk.RefTable))
+	}
+
+	return constraints
+}
@@ -9,7 +9,6 @@ import (
 	"io/ioutil"
 	"log"
 	"neobase-ai/internal/apis/dtos"
-	"neobase-ai/internal/utils"
 	"os"
 	"strings"
 	"sync"
@@ -101,7 +100,7 @@ func (d *ClickHouseDriver) Connect(config ConnectionConfig) (*Connection, error)
 			tlsConfig = nil
 		} else {
 			// Fetch certificates from URLs
-			certPath, keyPath, rootCertPath, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				if sshTunnel != nil {
 					sshTunnel.Close()
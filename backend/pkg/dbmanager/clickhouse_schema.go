@@ -83,6 +83,9 @@ func (f *ClickHouseSchemaFetcher) FetchSchema(ctx context.Context) (*SchemaInfo,
 
 	log.Printf("ClickHouseSchemaFetcher -> FetchSchema -> Processing %d tables", len(tables))
 
+	// Engine, replication and storage details are best effort, system tables may be restricted for the user
+	tableMetadata := f.fetchTableMetadata(ctx)
+
 	for _, table := range tables {
 		log.Printf("ClickHouseSchemaFetcher -> FetchSchema -> Processing table: %s", table)
 
@@ -110,6 +113,10 @@ func (f *ClickHouseSchemaFetcher) FetchSchema(ctx context.Context) (*SchemaInfo,
 		tableSchema.RowCount = rowCount
 		log.Printf("ClickHouseSchemaFetcher -> FetchSchema -> Table %s has %d rows", table, rowCount)
 
+		if metadata, ok := tableMetadata[table]; ok {
+			tableSchema.Metadata = metadata
+		}
+
 		// Calculate table schema checksum
 		tableData, _ := json.Marshal(tableSchema)
 		tableSchema.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
@@ -135,6 +142,142 @@ type TableInfo struct {
 	PrimaryKey   []string
 }
 
+// ClickHouse keys of TableSchema.Metadata
+const (
+	ClickHouseMetadataEngine         = "engine"
+	ClickHouseMetadataStoragePolicy  = "storage_policy"
+	ClickHouseMetadataDisks          = "disks"
+	ClickHouseMetadataZookeeperPath  = "zookeeper_path"
+	ClickHouseMetadataReplicaName    = "replica_name"
+	ClickHouseMetadataTotalReplicas  = "total_replicas"
+	ClickHouseMetadataActiveReplicas = "active_replicas"
+	ClickHouseMetadataReadOnly       = "is_readonly"
+)
+
+// fetchTableMetadata collects the engine of every table from system.tables, the replication state of
+// Replicated* tables from system.replicas and the disks available to MergeTree tables from system.disks.
+// Failures are logged and leave the affected details out, so schema discovery still works without them.
+func (f *ClickHouseSchemaFetcher) fetchTableMetadata(ctx context.Context) map[string]map[string]string {
+	metadata := make(map[string]map[string]string)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("ClickHouseSchemaFetcher -> fetchTableMetadata -> Context cancelled: %v", err)
+		return metadata
+	}
+
+	var engines []struct {
+		Name          string `db:"name"`
+		Engine        string `db:"engine"`
+		StoragePolicy string `db:"storage_policy"`
+	}
+	enginesQuery := `
+        SELECT name, engine, storage_policy
+        FROM system.tables
+        WHERE database = currentDatabase()
+        AND engine NOT LIKE 'View%';
+    `
+	if err := f.db.Query(enginesQuery, &engines); err != nil {
+		log.Printf("ClickHouseSchemaFetcher -> fetchTableMetadata -> Error fetching table engines: %v", err)
+		return metadata
+	}
+
+	// Disk names and paths only, free space changes constantly and would change the schema checksum
+	var disks []struct {
+		Name string `db:"name"`
+		Path string `db:"path"`
+	}
+	disksQuery := `
+        SELECT name, path
+        FROM system.disks
+        ORDER BY name;
+    `
+	diskList := ""
+	if err := f.db.Query(disksQuery, &disks); err != nil {
+		log.Printf("ClickHouseSchemaFetcher -> fetchTableMetadata -> Error fetching disks: %v", err)
+	} else {
+		diskNames := make([]string, 0, len(disks))
+		for _, disk := range disks {
+			diskNames = append(diskNames, fmt.Sprintf("%s (%s)", disk.Name, disk.Path))
+		}
+		diskList = strings.Join(diskNames, ", ")
+	}
+
+	for _, table := range engines {
+		tableMetadata := map[string]string{
+			ClickHouseMetadataEngine: table.Engine,
+		}
+		if strings.Contains(table.Engine, "MergeTree") {
+			if table.StoragePolicy != "" {
+				tableMetadata[ClickHouseMetadataStoragePolicy] = table.StoragePolicy
+			}
+			if diskList != "" {
+				tableMetadata[ClickHouseMetadataDisks] = diskList
+			}
+		}
+		metadata[table.Name] = tableMetadata
+	}
+
+	var replicas []struct {
+		Table          string `db:"table"`
+		ZookeeperPath  string `db:"zookeeper_path"`
+		ReplicaName    string `db:"replica_name"`
+		IsReadonly     uint8  `db:"is_readonly"`
+		TotalReplicas  uint8  `db:"total_replicas"`
+		ActiveReplicas uint8  `db:"active_replicas"`
+	}
+	replicasQuery := `
+        SELECT table, zookeeper_path, replica_name, is_readonly, total_replicas, active_replicas
+        FROM system.replicas
+        WHERE database = currentDatabase();
+    `
+	if err := f.db.Query(replicasQuery, &replicas); err != nil {
+		log.Printf("ClickHouseSchemaFetcher -> fetchTableMetadata -> Error fetching replicas: %v", err)
+		return metadata
+	}
+
+	for _, replica := range replicas {
+		tableMetadata, ok := metadata[replica.Table]
+		if !ok {
+			continue
+		}
+		tableMetadata[ClickHouseMetadataZookeeperPath] = replica.ZookeeperPath
+		tableMetadata[ClickHouseMetadataReplicaName] = replica.ReplicaName
+		tableMetadata[ClickHouseMetadataTotalReplicas] = fmt.Sprintf("%d", replica.TotalReplicas)
+		tableMetadata[ClickHouseMetadataActiveReplicas] = fmt.Sprintf("%d", replica.ActiveReplicas)
+		tableMetadata[ClickHouseMetadataReadOnly] = fmt.Sprintf("%t", replica.IsReadonly != 0)
+	}
+
+	log.Printf("ClickHouseSchemaFetcher -> fetchTableMetadata -> Fetched metadata for %d tables, %d replicated",
+		len(metadata), len(replicas))
+	return metadata
+}
+
+// describeClickHouseTableMetadata renders the engine, replication and storage details of a table
+// for the LLM schema description
+func describeClickHouseTableMetadata(table TableSchema) string {
+	if len(table.Metadata) == 0 {
+		return ""
+	}
+
+	var description strings.Builder
+	if engine := table.Metadata[ClickHouseMetadataEngine]; engine != "" && !strings.Contains(strings.ToLower(table.Comment), "engine=") {
+		description.WriteString(fmt.Sprintf(" [Engine: %s]", engine))
+	}
+	if total := table.Metadata[ClickHouseMetadataTotalReplicas]; total != "" {
+		description.WriteString(fmt.Sprintf(" [Replicas: %s/%s active]", table.Metadata[ClickHouseMetadataActiveReplicas], total))
+		if table.Metadata[ClickHouseMetadataReadOnly] == "true" {
+			description.WriteString(" [Replica is read-only]")
+		}
+	}
+	if policy := table.Metadata[ClickHouseMetadataStoragePolicy]; policy != "" {
+		description.WriteString(fmt.Sprintf(" [Storage Policy: %s]", policy))
+	}
+	if disks := table.Metadata[ClickHouseMetadataDisks]; disks != "" {
+		description.WriteString(fmt.Sprintf(" [Disks: %s]", disks))
+	}
+	return description.String()
+}
+
 // fetchTables retrieves all tables in the database
 func (f *ClickHouseSchemaFetcher) fetchTables(_ context.Context) ([]string, error) {
 	var tables []string
@@ -55,7 +55,7 @@ func (t *ClickHouseTransaction) ExecuteQuery(ctx context.Context, query string)
 			strings.HasPrefix(strings.ToUpper(strings.TrimSpace(stmt)), "DESCRIBE") {
 			// For SELECT, SHOW, DESCRIBE queries, return the results
 			var rows []map[string]interface{}
-			if err := t.tx.WithContext(ctx).Raw(stmt).Scan(&rows).Error; err != nil {
+			if err := t.tx.WithContext(ctx).Raw(withClickHouseMaxExecutionTime(ctx, stmt)).Scan(&rows).Error; err != nil {
 				result.Error = &dtos.QueryError{
 					Message: err.Error(),
 					Code:    "EXECUTION_ERROR",
@@ -161,3 +161,19 @@ func (t *ClickHouseTransaction) Rollback() error {
 	}
 	return t.tx.Rollback().Error
 }
+
+// withClickHouseMaxExecutionTime appends a max_execution_time setting derived from the context deadline,
+// so ClickHouse stops the query server-side when the per-query timeout expires
+func withClickHouseMaxExecutionTime(ctx context.Context, stmt string) string {
+	deadline, ok := ctx.Deadline()
+	upper := strings.ToUpper(strings.TrimSpace(stmt))
+	if !ok || !strings.HasPrefix(upper, "SELECT") || strings.Contains(upper, "SETTINGS") {
+		return stmt
+	}
+	seconds := int(time.Until(deadline).Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	trimmed := strings.TrimRight(strings.TrimSpace(stmt), ";")
+	return fmt.Sprintf("%s SETTINGS max_execution_time = %d", trimmed, seconds)
+}
@@ -0,0 +1,484 @@
+package dbmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CloudflareD1Client is a minimal client for the D1 query endpoint of the Cloudflare API, scoped to a single database
+type CloudflareD1Client struct {
+	accountID  string
+	databaseID string
+	token      string
+	httpClient *http.Client
+}
+
+// cloudflareD1Response is the envelope of Cloudflare API responses
+type cloudflareD1Response struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result []cloudflareD1StatementResult `json:"result"`
+}
+
+// cloudflareD1StatementResult is the result of one statement of a query
+type cloudflareD1StatementResult struct {
+	Results []map[string]interface{} `json:"results"`
+	Success bool                     `json:"success"`
+	Meta    struct {
+		Changes     int64   `json:"changes"`
+		Duration    float64 `json:"duration"`
+		RowsRead    int64   `json:"rows_read"`
+		RowsWritten int64   `json:"rows_written"`
+		LastRowID   int64   `json:"last_row_id"`
+	} `json:"meta"`
+}
+
+// cloudflareD1UnsupportedPattern matches statements D1 rejects: it has no ATTACH and runs every query in its own transaction
+var cloudflareD1UnsupportedPattern = regexp.MustCompile(`(?i)^(attach|detach|begin|commit|end|rollback|savepoint|release|vacuum)\b`)
+
+// newCloudflareD1Client creates a client for the D1 database in the connection config
+func newCloudflareD1Client(config ConnectionConfig) (*CloudflareD1Client, error) {
+	if config.CloudflareAccountID == nil || strings.TrimSpace(*config.CloudflareAccountID) == "" {
+		return nil, fmt.Errorf("a Cloudflare account ID is required")
+	}
+	databaseID := config.Database
+	if config.CloudflareD1DatabaseID != nil && strings.TrimSpace(*config.CloudflareD1DatabaseID) != "" {
+		databaseID = *config.CloudflareD1DatabaseID
+	}
+	if strings.TrimSpace(databaseID) == "" {
+		return nil, fmt.Errorf("a D1 database ID is required")
+	}
+	if config.CloudflareAPIToken == nil || strings.TrimSpace(*config.CloudflareAPIToken) == "" {
+		return nil, fmt.Errorf("a Cloudflare API token with D1 access is required")
+	}
+
+	return &CloudflareD1Client{
+		accountID:  strings.TrimSpace(*config.CloudflareAccountID),
+		databaseID: strings.TrimSpace(databaseID),
+		token:      strings.TrimSpace(*config.CloudflareAPIToken),
+		httpClient: &http.Client{
+			Timeout: constants.CloudflareD1RequestTimeout,
+		},
+	}, nil
+}
+
+// do sends a query to the D1 API and returns the decoded response
+func (c *CloudflareD1Client) do(ctx context.Context, payload []byte) (*cloudflareD1Response, error) {
+	endpoint := fmt.Sprintf("%s/accounts/%s/d1/database/%s/query",
+		constants.CloudflareAPIBaseURL, url.PathEscape(c.accountID), url.PathEscape(c.databaseID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create D1 request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("D1 request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read D1 response: %v", err)
+	}
+
+	var response cloudflareD1Response
+	// Keep integers exact, float64 loses precision above 2^53
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	decodeErr := decoder.Decode(&response)
+
+	if resp.StatusCode >= http.StatusBadRequest || (decodeErr == nil && !response.Success) {
+		return nil, parseCloudflareD1Error(resp.StatusCode, &response, decodeErr, body)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode D1 response: %v", decodeErr)
+	}
+	return &response, nil
+}
+
+// parseCloudflareD1Error turns a failed Cloudflare API response into an error.
+// Cloudflare returns {"success": false, "errors": [{"code": 7500, "message": "..."}]}.
+func parseCloudflareD1Error(status int, response *cloudflareD1Response, decodeErr error, body []byte) error {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("Cloudflare rejected the API token (HTTP %d), check that it has D1 read and edit permissions for the account", status)
+	case http.StatusNotFound:
+		return fmt.Errorf("D1 database not found, check the account ID and database ID")
+	}
+
+	if decodeErr == nil && len(response.Errors) > 0 {
+		messages := make([]string, 0, len(response.Errors))
+		for _, apiErr := range response.Errors {
+			messages = append(messages, apiErr.Message)
+		}
+		return fmt.Errorf("D1 error: %s", strings.Join(messages, "; "))
+	}
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return fmt.Errorf("D1 error (HTTP %d): %s", status, text)
+	}
+	return fmt.Errorf("D1 request failed with HTTP %d", status)
+}
+
+// query runs a SQL query and returns the result of each of its statements
+func (c *CloudflareD1Client) query(ctx context.Context, query string) ([]cloudflareD1StatementResult, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"sql":    query,
+		"params": []interface{}{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode D1 query: %v", err)
+	}
+
+	response, err := c.do(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	return response.Result, nil
+}
+
+// queryRows runs a read and returns the rows of its last statement
+func (c *CloudflareD1Client) queryRows(ctx context.Context, query string) ([]map[string]interface{}, error) {
+	results, err := c.query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 || results[len(results)-1].Results == nil {
+		return []map[string]interface{}{}, nil
+	}
+	return results[len(results)-1].Results, nil
+}
+
+// ping checks the token and database with a trivial query
+func (c *CloudflareD1Client) ping(ctx context.Context) error {
+	_, err := c.query(ctx, "SELECT 1")
+	return err
+}
+
+// CloudflareD1Driver implements the DatabaseDriver interface for Cloudflare D1
+type CloudflareD1Driver struct{}
+
+// NewCloudflareD1Driver creates a new Cloudflare D1 driver
+func NewCloudflareD1Driver() DatabaseDriver {
+	return &CloudflareD1Driver{}
+}
+
+// Connect validates the account, database and token and returns a connection holding the HTTP client
+func (d *CloudflareD1Driver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newCloudflareD1Client(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.CloudflareD1RequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to Cloudflare D1: %v", err)
+	}
+
+	log.Printf("CloudflareD1Driver -> Connect -> Connected to D1 database %s", client.databaseID)
+
+	conn := &Connection{
+		DB:          nil, // D1 is queried over the Cloudflare API, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect releases the idle HTTP connections of the client
+func (d *CloudflareD1Driver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*CloudflareD1Client)
+	if !ok {
+		return fmt.Errorf("invalid Cloudflare D1 connection")
+	}
+	client.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Ping checks if the D1 database is still reachable with the stored token
+func (d *CloudflareD1Driver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*CloudflareD1Client)
+	if !ok {
+		return fmt.Errorf("invalid Cloudflare D1 connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("CloudflareD1Driver -> Ping -> D1 check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the D1 connection is still valid
+func (d *CloudflareD1Driver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("CloudflareD1Driver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a SQLite query on the D1 database
+func (d *CloudflareD1Driver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*CloudflareD1Client)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeCloudflareD1Statement(ctx, client, query)
+}
+
+// executeCloudflareD1Statement runs a query. D1 commits every query on its own, so writes are applied immediately.
+func executeCloudflareD1Statement(ctx context.Context, client *CloudflareD1Client, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		result.Error = &dtos.QueryError{Message: "query is empty", Code: "INVALID_QUERY"}
+		return result
+	}
+	if matches := cloudflareD1UnsupportedPattern.FindStringSubmatch(query); matches != nil {
+		result.Error = &dtos.QueryError{
+			Message: fmt.Sprintf("Cloudflare D1 does not support %s, every query runs in its own transaction and only the connected database is available", strings.ToUpper(matches[1])),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("CloudflareD1Driver -> executeCloudflareD1Statement -> Running query on D1 database %s", client.databaseID)
+
+	statements, err := client.query(ctx, query)
+	if err != nil {
+		result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+		return result
+	}
+
+	rows := []map[string]interface{}{}
+	var changes int64
+	for _, statement := range statements {
+		changes += statement.Meta.Changes
+	}
+	if len(statements) > 0 && statements[len(statements)-1].Results != nil {
+		rows = statements[len(statements)-1].Results
+	}
+
+	if len(rows) == 0 && changes > 0 {
+		result.RowsAffected = changes
+		result.Result = map[string]interface{}{
+			"rowsAffected": changes,
+			"message":      fmt.Sprintf("%d row(s) affected", changes),
+		}
+	} else {
+		result.Result = map[string]interface{}{
+			"results": rows,
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// BeginTx returns a transaction that executes statements immediately.
+// D1 rejects BEGIN and COMMIT, every query is committed as soon as the API accepts it.
+func (d *CloudflareD1Driver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*CloudflareD1Client)
+	if !ok {
+		log.Printf("CloudflareD1Driver.BeginTx: Invalid Cloudflare D1 connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &CloudflareD1Transaction{
+		client: client,
+	}
+}
+
+// CloudflareD1Transaction implements the Transaction interface for D1 in autocommit mode
+type CloudflareD1Transaction struct {
+	client *CloudflareD1Client
+}
+
+// ExecuteQuery executes a query. Writes are committed as soon as the API accepts them.
+func (t *CloudflareD1Transaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeCloudflareD1Statement(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as queries are already committed
+func (t *CloudflareD1Transaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo queries D1 already committed
+func (t *CloudflareD1Transaction) Rollback() error {
+	log.Printf("CloudflareD1Transaction -> Rollback -> D1 commits every query on its own, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the tables of the D1 database
+func (d *CloudflareD1Driver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("CloudflareD1Driver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewCloudflareD1SchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a table
+func (d *CloudflareD1Driver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("CloudflareD1Driver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewCloudflareD1SchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches example rows from a table
+func (d *CloudflareD1Driver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("CloudflareD1Driver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewCloudflareD1SchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// CloudflareD1Executor implements the DBExecutor interface for Cloudflare D1
+type CloudflareD1Executor struct {
+	client *CloudflareD1Client
+	conn   *Connection
+}
+
+// NewCloudflareD1Executor creates a new Cloudflare D1 executor
+func NewCloudflareD1Executor(conn *Connection) (*CloudflareD1Executor, error) {
+	client, ok := conn.APIClient.(*CloudflareD1Client)
+	if !ok {
+		return nil, fmt.Errorf("invalid Cloudflare D1 connection")
+	}
+
+	return &CloudflareD1Executor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for D1 as it doesn't use GORM
+func (e *CloudflareD1Executor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *CloudflareD1Executor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes a query with the request timeout
+func (e *CloudflareD1Executor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.CloudflareD1RequestTimeout)
+	defer cancel()
+	return executeCloudflareD1Statement(ctx, e.client, query)
+}
+
+// Raw executes a D1 query, *Not Used By DBManager*
+func (e *CloudflareD1Executor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute D1 query: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes a D1 query, *Not Used By DBManager*
+func (e *CloudflareD1Executor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes a D1 read and stores the rows in dest
+func (e *CloudflareD1Executor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes a D1 read and stores the rows in dest
+func (e *CloudflareD1Executor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.CloudflareD1RequestTimeout)
+	defer cancel()
+	rows, err := e.client.queryRows(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to execute D1 query: %v", err)
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the HTTP client is released by the driver on disconnect
+func (e *CloudflareD1Executor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the D1 schema
+func (e *CloudflareD1Executor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &CloudflareD1Driver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for a D1 table
+func (e *CloudflareD1Executor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &CloudflareD1Driver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
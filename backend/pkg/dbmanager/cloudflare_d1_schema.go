@@ -0,0 +1,299 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CloudflareD1SchemaFetcher implements schema fetching for Cloudflare D1 with sqlite_master and the table_info pragmas
+type CloudflareD1SchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewCloudflareD1SchemaFetcher creates a new Cloudflare D1 schema fetcher
+func NewCloudflareD1SchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &CloudflareD1SchemaFetcher{db: db}
+}
+
+// client returns the HTTP client of the executor
+func (f *CloudflareD1SchemaFetcher) client(db DBExecutor) (*CloudflareD1Client, error) {
+	executor, ok := db.(*CloudflareD1Executor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid Cloudflare D1 connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema lists the tables and views of sqlite_master, the columns of every table with pragma_table_info
+// and its foreign keys with pragma_foreign_key_list. Each is a single request, whatever the number of tables.
+func (f *CloudflareD1SchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("CloudflareD1SchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// sqlite_ tables belong to SQLite and _cf_ tables to Cloudflare, neither holds user data
+	objects, err := client.queryRows(ctx, "SELECT type, name, sql FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT GLOB 'sqlite_*' AND name NOT GLOB '_cf_*' ORDER BY name")
+	if err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> GetSchema -> Error fetching tables: %v", err)
+		return nil, fmt.Errorf("failed to fetch tables: %v", err)
+	}
+
+	columnRows, err := client.queryRows(ctx, `SELECT m.name AS table_name, p.cid, p.name, p.type, p."notnull", p.dflt_value, p.pk
+FROM sqlite_master m JOIN pragma_table_info(m.name) p
+WHERE m.type = 'table' AND m.name NOT GLOB 'sqlite_*' AND m.name NOT GLOB '_cf_*'
+ORDER BY m.name, p.cid`)
+	if err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> GetSchema -> Error fetching columns: %v", err)
+		return nil, fmt.Errorf("failed to fetch columns: %v", err)
+	}
+
+	foreignKeyRows, err := client.queryRows(ctx, `SELECT m.name AS table_name, p.id, p."from", p."table", p."to", p.on_update, p.on_delete
+FROM sqlite_master m JOIN pragma_foreign_key_list(m.name) p
+WHERE m.type = 'table' AND m.name NOT GLOB 'sqlite_*' AND m.name NOT GLOB '_cf_*'`)
+	if err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> GetSchema -> Error fetching foreign keys: %v", err)
+		return nil, fmt.Errorf("failed to fetch foreign keys: %v", err)
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, row := range objects {
+		name := cloudflareD1RowString(row, "name")
+		if name == "" || (filterTables && !selected[name]) {
+			continue
+		}
+		if cloudflareD1RowString(row, "type") == "view" {
+			schema.Views[name] = ViewSchema{
+				Name:       name,
+				Definition: cloudflareD1RowString(row, "sql"),
+			}
+			continue
+		}
+		schema.Tables[name] = TableSchema{
+			Name:        name,
+			Columns:     make(map[string]ColumnInfo),
+			Indexes:     make(map[string]IndexInfo),
+			ForeignKeys: make(map[string]ForeignKey),
+			Constraints: make(map[string]ConstraintInfo),
+		}
+	}
+
+	primaryKeys := make(map[string][]string)
+	for _, row := range columnRows {
+		tableName := cloudflareD1RowString(row, "table_name")
+		table, ok := schema.Tables[tableName]
+		if !ok {
+			continue
+		}
+		column := cloudflareD1Column(row)
+		table.Columns[column.Name] = column
+		if pk := cloudflareD1RowString(row, "pk"); pk != "" && pk != "0" {
+			primaryKeys[tableName] = append(primaryKeys[tableName], column.Name)
+		}
+	}
+
+	for tableName, columns := range primaryKeys {
+		schema.Tables[tableName].Constraints["PRIMARY"] = ConstraintInfo{
+			Name:    "PRIMARY",
+			Type:    "PRIMARY KEY",
+			Columns: columns,
+		}
+	}
+
+	for _, row := range foreignKeyRows {
+		table, ok := schema.Tables[cloudflareD1RowString(row, "table_name")]
+		if !ok {
+			continue
+		}
+		column := cloudflareD1RowString(row, "from")
+		name := fmt.Sprintf("fk_%s_%s_%s", table.Name, cloudflareD1RowString(row, "id"), column)
+		table.ForeignKeys[name] = ForeignKey{
+			Name:       name,
+			ColumnName: column,
+			RefTable:   cloudflareD1RowString(row, "table"),
+			RefColumn:  cloudflareD1RowString(row, "to"),
+			OnDelete:   cloudflareD1RowString(row, "on_delete"),
+			OnUpdate:   cloudflareD1RowString(row, "on_update"),
+		}
+	}
+
+	for name, table := range schema.Tables {
+		tableData, _ := json.Marshal(table)
+		table.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[name] = table
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("CloudflareD1SchemaFetcher -> GetSchema -> Fetched %d tables and %d views", len(schema.Tables), len(schema.Views))
+	return schema, nil
+}
+
+// cloudflareD1Column builds a column from a pragma_table_info row.
+// SQLite allows columns without a declared type, they take any value.
+func cloudflareD1Column(row map[string]interface{}) ColumnInfo {
+	columnType := cloudflareD1RowString(row, "type")
+	if columnType == "" {
+		columnType = "ANY"
+	}
+	notNull := cloudflareD1RowString(row, "notnull")
+	return ColumnInfo{
+		Name:         cloudflareD1RowString(row, "name"),
+		Type:         columnType,
+		IsNullable:   notNull == "" || notNull == "0",
+		DefaultValue: cloudflareD1RowString(row, "dflt_value"),
+	}
+}
+
+// cloudflareD1RowString returns a column of a D1 result row as a string
+func cloudflareD1RowString(row map[string]interface{}, key string) string {
+	value, ok := row[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// GetTableChecksum calculates a checksum for a table's column definitions
+func (f *CloudflareD1SchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := client.queryRows(ctx, fmt.Sprintf("PRAGMA table_info(%s)", quoteCloudflareD1Identifier(table)))
+	if err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> GetTableChecksum -> Error fetching columns: %v", err)
+		return "", fmt.Errorf("failed to get table definition: %v", err)
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no table definition found for table: %s", table)
+	}
+
+	definitions := make([]string, 0, len(rows))
+	for _, row := range rows {
+		definitions = append(definitions, fmt.Sprintf("%s:%s:%s:%s:%s;",
+			cloudflareD1RowString(row, "name"), cloudflareD1RowString(row, "type"), cloudflareD1RowString(row, "notnull"),
+			cloudflareD1RowString(row, "dflt_value"), cloudflareD1RowString(row, "pk")))
+	}
+	sort.Strings(definitions)
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(definitions, "")))), nil
+}
+
+// FetchExampleRecords retrieves the first rows of a table
+func (f *CloudflareD1SchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := client.queryRows(ctx, fmt.Sprintf("SELECT * FROM %s LIMIT %d", quoteCloudflareD1Identifier(table), limit))
+	if err != nil {
+		log.Printf("CloudflareD1SchemaFetcher -> FetchExampleRecords -> Error fetching rows from table %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for table %s: %v", table, err)
+	}
+	return records, nil
+}
+
+// quoteCloudflareD1Identifier double-quotes a SQLite identifier
+func quoteCloudflareD1Identifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// CloudflareD1Simplifier implements SchemaSimplifier for SQLite column types
+type CloudflareD1Simplifier struct{}
+
+// SimplifyDataType maps declared SQLite types to readable type names with SQLite's type affinity rules
+func (s *CloudflareD1Simplifier) SimplifyDataType(dbType string) string {
+	upper := strings.ToUpper(dbType)
+	switch {
+	case strings.Contains(upper, "BOOL"):
+		return "boolean"
+	case strings.Contains(upper, "DATE"), strings.Contains(upper, "TIME"):
+		return "timestamp"
+	case strings.Contains(upper, "JSON"):
+		return "json"
+	case strings.Contains(upper, "INT"):
+		return "integer"
+	case strings.Contains(upper, "CHAR"), strings.Contains(upper, "CLOB"), strings.Contains(upper, "TEXT"):
+		return "text"
+	case strings.Contains(upper, "BLOB"):
+		return "binary"
+	case strings.Contains(upper, "REAL"), strings.Contains(upper, "FLOA"), strings.Contains(upper, "DOUB"),
+		strings.Contains(upper, "NUMERIC"), strings.Contains(upper, "DECIMAL"):
+		return "decimal"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints returns the key, nullability and default of a column
+func (s *CloudflareD1Simplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	if primary, ok := table.Constraints["PRIMARY"]; ok {
+		for _, column := range primary.Columns {
+			if column == col.Name {
+				constraints = append(constraints, "PRIMARY KEY")
+				break
+			}
+		}
+	}
+	if !col.IsNullable {
+		constraints = append(constraints, "NOT NULL")
+	}
+	if col.DefaultValue != "" {
+		constraints = append(constraints, fmt.Sprintf("DEFAULT %s", col.DefaultValue))
+	}
+	for _, fk := range table.ForeignKeys {
+		if fk.ColumnName == col.Name {
+			constraints = append(constraints, fmt.Sprintf("REFERENCES %s(%s)", fk.RefTable, fk.RefColumn))
+		}
+	}
+
+	return constraints
+}
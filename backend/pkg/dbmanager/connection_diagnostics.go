@@ -0,0 +1,435 @@
+package dbmanager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// postgresSSLRequestCode asks a PostgreSQL server to switch the connection to TLS before the startup message
+const postgresSSLRequestCode = 80877103
+
+// errServerRejectsSSL is returned when a PostgreSQL server answers an SSL request with "N"
+var errServerRejectsSSL = errors.New("the server does not support SSL connections")
+
+// DiagnoseConnection tests a connection like TestConnection, but one step at a time: the TCP connection to the host,
+// the SSL certificates and TLS handshake, then authentication, the database and the user's permission to use it,
+// told apart by the error the database returns. The first step that fails is explained with a suggestion to fix it.
+func (m *Manager) DiagnoseConnection(config *ConnectionConfig) *dtos.ConnectionDiagnostic {
+	diagnostic := &dtos.ConnectionDiagnostic{}
+
+	host, port, direct := diagnosticAddress(config)
+	if config.SSHEnabled {
+		// The database host is only reachable through the tunnel, so the tunnel stands in for the TCP check
+		if config.SSHHost != nil && config.SSHPort != nil && config.SSHUsername != nil && config.SSHPrivateKey != nil {
+			sshPassphrase := ""
+			if config.SSHPassphrase != nil {
+				sshPassphrase = *config.SSHPassphrase
+			}
+			if err := TestSSHTunnel(*config.SSHHost, *config.SSHPort, *config.SSHUsername, *config.SSHPrivateKey, sshPassphrase); err != nil {
+				return failDiagnostic(diagnostic, constants.ConnectionCheckTCP, err,
+					"The SSH tunnel could not be opened. Check the SSH host, port, username and private key.")
+			}
+		}
+		direct = false
+	}
+
+	if direct {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), constants.ConnectionDiagnosticDialTimeout)
+		if err != nil {
+			return failDiagnostic(diagnostic, constants.ConnectionCheckTCP, err, tcpSuggestion(host, port, err))
+		}
+		conn.Close()
+		diagnostic.TCPReachable = true
+	}
+
+	if config.UseSSL {
+		rootCAs, err := checkDiagnosticCertificates(config)
+		if err != nil {
+			return failDiagnostic(diagnostic, constants.ConnectionCheckTLS, err, tlsSuggestion(host, err))
+		}
+		if direct {
+			if err := handshakeDiagnosticTLS(config, host, port, rootCAs); err != nil {
+				return failDiagnostic(diagnostic, constants.ConnectionCheckTLS, err, tlsSuggestion(host, err))
+			}
+			diagnostic.TLSValid = true
+		}
+	} else {
+		diagnostic.TLSValid = diagnostic.TCPReachable
+	}
+
+	err := m.TestConnection(config)
+	if err == nil {
+		passDiagnosticChecks(diagnostic, "")
+		diagnostic.Suggestion = "The connection works, nothing needs to be fixed."
+		return diagnostic
+	}
+
+	check, suggestion := classifyConnectionError(config, host, port, err)
+	if check == constants.ConnectionCheckUnknown {
+		// The steps checked above still hold, the ones after them can't be told apart
+		message := err.Error()
+		diagnostic.FailedCheck = check
+		diagnostic.Error = &message
+		diagnostic.Suggestion = suggestion
+		return diagnostic
+	}
+	return failDiagnostic(diagnostic, check, err, suggestion)
+}
+
+// failDiagnostic marks the steps before check as passed, check and the ones after it as failed
+func failDiagnostic(diagnostic *dtos.ConnectionDiagnostic, check string, err error, suggestion string) *dtos.ConnectionDiagnostic {
+	log.Printf("DBManager -> DiagnoseConnection -> %s check failed: %v", check, err)
+	message := err.Error()
+	passDiagnosticChecks(diagnostic, check)
+	diagnostic.FailedCheck = check
+	diagnostic.Error = &message
+	diagnostic.Suggestion = suggestion
+	return diagnostic
+}
+
+// passDiagnosticChecks sets every check before failedCheck, or every check when it is empty
+func passDiagnosticChecks(diagnostic *dtos.ConnectionDiagnostic, failedCheck string) {
+	checks := []struct {
+		name   string
+		passed *bool
+	}{
+		{constants.ConnectionCheckTCP, &diagnostic.TCPReachable},
+		{constants.ConnectionCheckTLS, &diagnostic.TLSValid},
+		{constants.ConnectionCheckAuth, &diagnostic.AuthSucceeded},
+		{constants.ConnectionCheckDatabase, &diagnostic.DatabaseExists},
+		{constants.ConnectionCheckPermission, &diagnostic.UserHasPermission},
+	}
+	passed := true
+	for _, check := range checks {
+		if check.name == failedCheck {
+			passed = false
+		}
+		*check.passed = passed
+	}
+}
+
+// diagnosticAddress returns the host and port the database listens on, or false when they can't be dialled
+// directly, e.g. an Airtable base or a MongoDB SRV record that resolves to several hosts
+func diagnosticAddress(config *ConnectionConfig) (string, string, bool) {
+	host := strings.TrimSpace(config.Host)
+	port := ""
+	if config.Port != nil {
+		port = strings.TrimSpace(*config.Port)
+	}
+
+	if strings.Contains(host, "://") {
+		parsed, err := url.Parse(host)
+		if err != nil {
+			return "", "", false
+		}
+		host = parsed.Hostname()
+		if parsed.Port() != "" {
+			port = parsed.Port()
+		} else if port == "" && parsed.Scheme == "https" {
+			port = "443"
+		} else if port == "" && parsed.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	if host == "" || port == "" || strings.Contains(host, ",") {
+		return host, port, false
+	}
+	if (config.Type == constants.DatabaseTypeMongoDB || config.Type == constants.DatabaseTypeFerretDB) && strings.Contains(host, ".mongodb.net") {
+		return host, port, false
+	}
+	return host, port, true
+}
+
+// checkDiagnosticCertificates downloads and checks the connection's certificate files and returns its CA
+// certificates, nil when there are none and the system's are used
+func checkDiagnosticCertificates(config *ConnectionConfig) (*x509.CertPool, error) {
+	files := []struct {
+		url  *string
+		kind sslCertKind
+	}{
+		{config.SSLCertURL, sslCertKindClientCert},
+		{config.SSLKeyURL, sslCertKindClientKey},
+		{config.SSLRootCertURL, sslCertKindRootCert},
+	}
+
+	var rootCAs *x509.CertPool
+	for _, file := range files {
+		if file.url == nil || *file.url == "" {
+			continue
+		}
+		data, err := fetchSSLCertificate(*file.url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download the %s: %v", file.kind, err)
+		}
+		if err := validateSSLCertificate(file.kind, data); err != nil {
+			if cert := findInvalidCertificate(data); cert != nil {
+				return nil, x509.CertificateInvalidError{Cert: cert, Reason: x509.Expired, Detail: string(file.kind)}
+			}
+			return nil, fmt.Errorf("the %s is not valid: %v", file.kind, err)
+		}
+		if file.kind == sslCertKindRootCert {
+			rootCAs = x509.NewCertPool()
+			rootCAs.AppendCertsFromPEM(data)
+		}
+	}
+	return rootCAs, nil
+}
+
+// findInvalidCertificate returns the first certificate of a PEM file that is expired or not valid yet
+func findInvalidCertificate(data []byte) *x509.Certificate {
+	now := time.Now()
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err == nil && (now.After(cert.NotAfter) || now.Before(cert.NotBefore)) {
+			return cert
+		}
+	}
+	return nil
+}
+
+// handshakeDiagnosticTLS opens a TLS connection to the server and checks its certificate the way the connection's
+// SSL mode does. MySQL switches to TLS within its own handshake, its TLS errors are found in the connection test.
+func handshakeDiagnosticTLS(config *ConnectionConfig, host, port string, rootCAs *x509.CertPool) error {
+	address := net.JoinHostPort(host, port)
+	dialer := &net.Dialer{Timeout: constants.ConnectionDiagnosticDialTimeout}
+	// The certificate is verified below, so an expired one is told apart from a hostname mismatch
+	tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: true}
+
+	var certs []*x509.Certificate
+	switch config.Type {
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale:
+		return nil
+
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
+		conn, err := dialer.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(constants.ConnectionDiagnosticTLSTimeout))
+
+		if err := requestPostgresSSL(conn); err != nil {
+			return err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return err
+		}
+		certs = tlsConn.ConnectionState().PeerCertificates
+
+	default:
+		dialer.Timeout = constants.ConnectionDiagnosticTLSTimeout
+		tlsConn, err := tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+		if err != nil {
+			return err
+		}
+		defer tlsConn.Close()
+		certs = tlsConn.ConnectionState().PeerCertificates
+	}
+
+	return verifyDiagnosticCertificate(config, host, certs, rootCAs)
+}
+
+// requestPostgresSSL sends the SSLRequest message and reads whether the server accepts it
+func requestPostgresSSL(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	answer := make([]byte, 1)
+	if _, err := conn.Read(answer); err != nil {
+		return err
+	}
+	if answer[0] != 'S' {
+		return errServerRejectsSSL
+	}
+	return nil
+}
+
+// verifyDiagnosticCertificate fails on an expired server certificate whatever the SSL mode. The chain is verified
+// for verify-ca and verify-full, the hostname only for verify-full, and require trusts any certificate.
+func verifyDiagnosticCertificate(config *ConnectionConfig, host string, certs []*x509.Certificate, rootCAs *x509.CertPool) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("the server sent no certificate")
+	}
+	leaf := certs[0]
+	now := time.Now()
+	if now.After(leaf.NotAfter) || now.Before(leaf.NotBefore) {
+		return x509.CertificateInvalidError{Cert: leaf, Reason: x509.Expired}
+	}
+
+	sslMode := "verify-full"
+	if config.SSLMode != nil && *config.SSLMode != "" {
+		sslMode = *config.SSLMode
+	} else if isPostgresFamily(config.Type) {
+		// TestConnection connects with sslmode=require when no mode is set
+		sslMode = "require"
+	}
+	if sslMode != "verify-ca" && sslMode != "verify-full" {
+		return nil
+	}
+
+	options := x509.VerifyOptions{Roots: rootCAs, Intermediates: x509.NewCertPool()}
+	for _, cert := range certs[1:] {
+		options.Intermediates.AddCert(cert)
+	}
+	if sslMode == "verify-full" {
+		options.DNSName = host
+	}
+	_, err := leaf.Verify(options)
+	return err
+}
+
+func isPostgresFamily(dbType string) bool {
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
+		return true
+	}
+	return false
+}
+
+// classifyConnectionError tells which step a failed connection test failed at from the error the driver returned,
+// using the error codes of PostgreSQL (SQLSTATE), MySQL and Oracle where there are any
+func classifyConnectionError(config *ConnectionConfig, host, port string, err error) (string, string) {
+	message := strings.ToLower(err.Error())
+	username := ""
+	if config.Username != nil {
+		username = *config.Username
+	}
+
+	switch {
+	case containsAny(message, "x509:", "tls:", "certificate", "ssl is not enabled on the server", "server does not support ssl"):
+		return constants.ConnectionCheckTLS, tlsSuggestion(host, err)
+
+	case containsAny(message, "pg_hba.conf"):
+		return constants.ConnectionCheckAuth, fmt.Sprintf("The server does not accept connections from NeoBase's address for the user %q. "+
+			"Add a host entry for it in pg_hba.conf, or allow NeoBase's IP address in your provider's network settings.", username)
+
+	// MySQL 1044 is an access denied to a database, unlike 1045 which is a wrong password
+	case containsAny(message, "error 1044", "error 1142", "42501", "permission denied", "not authorized on", "insufficient privilege",
+		"ora-01045", "access_denied", "forbidden"),
+		strings.Contains(message, "access denied for user") && strings.Contains(message, "to database"):
+		return constants.ConnectionCheckPermission, permissionSuggestion(config, username)
+
+	case containsAny(message, "28p01", "28000", "password authentication failed", "access denied for user", "authentication failed",
+		"auth error", "ora-01017", "invalid username or password", "authorization violation", "unauthenticated", "unauthorized"):
+		if username == "" {
+			return constants.ConnectionCheckAuth, "The database requires a login. Enter a username and password."
+		}
+		return constants.ConnectionCheckAuth, fmt.Sprintf("The database rejected the user %q or its password. "+
+			"Check both for typos and that the password hasn't been changed or expired.", username)
+
+	case containsAny(message, "3d000", "unknown database", "error 1049", "ora-12514", "unknown_database", "database not found", "bucket not found"),
+		strings.Contains(message, "database") && strings.Contains(message, "does not exist"),
+		strings.Contains(message, "catalog") && containsAny(message, "does not exist", "not found"),
+		strings.Contains(message, "namespace") && strings.Contains(message, "not found"):
+		if config.Type == constants.DatabaseTypeOracle {
+			return constants.ConnectionCheckDatabase, "The server does not know the service name. Check it in the listener's services (lsnrctl services)."
+		}
+		return constants.ConnectionCheckDatabase, fmt.Sprintf("The database %q does not exist on the server. "+
+			"Check its name, names are case sensitive on most databases.", config.Database)
+
+	case containsAny(message, "connection refused", "no such host", "i/o timeout", "network is unreachable", "no route to host",
+		"context deadline exceeded", "connection reset", "server selection timeout"):
+		return constants.ConnectionCheckTCP, tcpSuggestion(host, port, err)
+	}
+
+	return constants.ConnectionCheckUnknown, fmt.Sprintf("The database returned an error that couldn't be traced to one step: %v. "+
+		"Check the connection details and the database server's logs.", err)
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func tcpSuggestion(host, port string, err error) string {
+	address := net.JoinHostPort(host, port)
+	message := strings.ToLower(err.Error())
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) || strings.Contains(message, "no such host"):
+		return fmt.Sprintf("The host name %q could not be found. Check it for typos and that it is a public address, not one of your local network.", host)
+	case strings.Contains(message, "connection refused"):
+		return fmt.Sprintf("Nothing is listening on %s. Check the port and that the database server is running.", address)
+	case strings.Contains(message, "timeout") || strings.Contains(message, "deadline exceeded"):
+		return fmt.Sprintf("%s did not answer. A firewall or security group is probably blocking it: allow inbound connections on port %s from NeoBase's IP address.", address, port)
+	}
+	return fmt.Sprintf("Could not connect to %s. Check the host and port, and that the server accepts connections from outside its network.", address)
+}
+
+func tlsSuggestion(host string, err error) string {
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	var authorityErr x509.UnknownAuthorityError
+	message := strings.ToLower(err.Error())
+
+	switch {
+	case errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired:
+		owner := "The server's"
+		if invalidErr.Detail != "" {
+			owner = "Your"
+		}
+		if time.Now().Before(invalidErr.Cert.NotBefore) {
+			return fmt.Sprintf("%s SSL certificate is not valid until %s. Check the clock of the machine that issued it.",
+				owner, invalidErr.Cert.NotBefore.Format("2006-01-02"))
+		}
+		return fmt.Sprintf("%s SSL certificate expired on %s. Renew it or disable SSL.", owner, invalidErr.Cert.NotAfter.Format("2006-01-02"))
+	case strings.Contains(message, "certificate has expired"):
+		return "The server's SSL certificate has expired. Renew it or disable SSL."
+
+	case errors.As(err, &hostnameErr) || strings.Contains(message, "certificate is valid for"):
+		return fmt.Sprintf("The server's SSL certificate was not issued for %q. Connect with the host name on the certificate, "+
+			"or use the SSL mode verify-ca, which doesn't check the host name.", host)
+
+	case errors.As(err, &authorityErr) || strings.Contains(message, "unknown authority"):
+		return "The server's SSL certificate is not signed by a trusted authority. Add the URL of its CA certificate, " +
+			"or use the SSL mode require, which doesn't verify the certificate."
+
+	case errors.Is(err, errServerRejectsSSL) || containsAny(message, "ssl is not enabled on the server", "server does not support ssl"):
+		return "The server does not accept SSL connections. Enable SSL on the server or disable SSL for this connection."
+
+	case strings.Contains(message, "failed to download"):
+		return "A certificate file could not be downloaded. Check its URL and that NeoBase can read it."
+	}
+	return "The SSL handshake failed. Check the SSL mode and the certificate files, or disable SSL."
+}
+
+func permissionSuggestion(config *ConnectionConfig, username string) string {
+	switch {
+	case isPostgresFamily(config.Type):
+		return fmt.Sprintf("The user %q signed in but can't use the database %q. Grant it access, e.g. "+
+			"GRANT CONNECT ON DATABASE %s TO %s; and GRANT USAGE ON SCHEMA public TO %s;",
+			username, config.Database, config.Database, username, username)
+	case config.Type == constants.DatabaseTypeMySQL || config.Type == constants.DatabaseTypeStarRocks || config.Type == constants.DatabaseTypePlanetscale:
+		return fmt.Sprintf("The user %q signed in but can't use the database %q. Grant it access, e.g. GRANT SELECT ON %s.* TO '%s'@'%%';",
+			username, config.Database, config.Database, username)
+	case config.Type == constants.DatabaseTypeMongoDB || config.Type == constants.DatabaseTypeFerretDB:
+		return fmt.Sprintf("The user %q signed in but can't read the database %q. Give it the read role on that database.", username, config.Database)
+	}
+	return fmt.Sprintf("The user %q signed in but isn't allowed to use %q. Ask your database administrator to grant it read access.", username, config.Database)
+}
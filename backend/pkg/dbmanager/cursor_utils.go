@@ -1,6 +1,8 @@
 package dbmanager
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
 	"neobase-ai/internal/constants"
 	"strconv"
@@ -31,8 +33,26 @@ func BuildCursorQuery(dbType, baseQuery, paginatedQuery, cursorField, cursorDire
 		switch dbType {
 		case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeMySQL,
 			constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
-			constants.DatabaseTypeStarRocks, constants.DatabaseTypeClickhouse:
+			constants.DatabaseTypeStarRocks, constants.DatabaseTypeClickhouse,
+			constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeTrino,
+			constants.DatabaseTypePlanetscale, constants.DatabaseTypeOracle,
+			constants.DatabaseTypeInfluxDB, constants.DatabaseTypeYugabyteDBCQL, constants.DatabaseTypeCloudflareD1:
 			return strings.ReplaceAll(paginatedQuery, placeholder, sqlFormatCursorValue(cursorValue))
+		case constants.DatabaseTypeAirtable:
+			// The cursor is Airtable's opaque offset token, always a JSON string
+			return airtableInjectOffset(paginatedQuery, cursorValue)
+		case constants.DatabaseTypeTemporal:
+			// The cursor is Temporal's next page token, base64 encoded
+			return temporalInjectPageToken(paginatedQuery, cursorValue)
+		case constants.DatabaseTypePocketBase:
+			// The cursor is the next page number, always a JSON number
+			return pocketBaseInjectPage(paginatedQuery, cursorValue)
+		case constants.DatabaseTypeNATS:
+			// The cursor is the next stream sequence, always a JSON number
+			return natsInjectSequence(paginatedQuery, cursorValue)
+		case constants.DatabaseTypeFirestore:
+			// The cursor is the ID (or path) of the last document, always a JSON string
+			return firestoreInjectCursor(paginatedQuery, cursorValue)
 		default:
 			return mongoInjectTemplatedCursor(paginatedQuery, cursorValue)
 		}
@@ -40,7 +60,7 @@ func BuildCursorQuery(dbType, baseQuery, paginatedQuery, cursorField, cursorDire
 
 	// Fallback: no {{cursor_value}} in paginatedQuery.
 	// For MongoDB, dynamically inject a cursor condition into the base query.
-	if (dbType == constants.DatabaseTypeMongoDB) && cursorField != "" {
+	if (dbType == constants.DatabaseTypeMongoDB || dbType == constants.DatabaseTypeFerretDB) && cursorField != "" {
 		log.Printf("[CURSOR] No {{cursor_value}} in template — attempting dynamic cursor injection (field=%s, dir=%s)", cursorField, cursorDirection)
 		if injected := mongoDynamicCursorInject(baseQuery, cursorField, cursorDirection, cursorValue); injected != baseQuery {
 			return injected
@@ -63,3 +83,39 @@ func sqlFormatCursorValue(value string) string {
 	}
 	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
 }
+
+// BuildKeysetQuery substitutes the previous page's last key into a keyset pagination query.
+// lastKey comes from JSON, so numbers stay unquoted and strings are quoted for the target database.
+func BuildKeysetQuery(dbType, keysetQuery string, lastKey interface{}) string {
+	var formatted string
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeMySQL,
+		constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeStarRocks, constants.DatabaseTypeClickhouse,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeTrino,
+		constants.DatabaseTypePlanetscale, constants.DatabaseTypeOracle,
+		constants.DatabaseTypeInfluxDB, constants.DatabaseTypeYugabyteDBCQL, constants.DatabaseTypeCloudflareD1:
+		switch v := lastKey.(type) {
+		case float64:
+			formatted = strconv.FormatFloat(v, 'f', -1, 64)
+		case json.Number:
+			formatted = v.String()
+		case bool:
+			formatted = strconv.FormatBool(v)
+		default:
+			formatted = "'" + strings.ReplaceAll(fmt.Sprintf("%v", v), "'", "''") + "'"
+		}
+	default:
+		encoded, err := json.Marshal(lastKey)
+		if err != nil {
+			log.Printf("[KEYSET] Failed to encode last key %v: %v", lastKey, err)
+			encoded = []byte("null")
+		}
+		formatted = string(encoded)
+	}
+
+	// The LLM may wrap the placeholder in quotes itself; drop them so values are not double-quoted
+	query := strings.ReplaceAll(keysetQuery, "'"+constants.KeysetLastKeyPlaceholder+"'", constants.KeysetLastKeyPlaceholder)
+	query = strings.ReplaceAll(query, "\""+constants.KeysetLastKeyPlaceholder+"\"", constants.KeysetLastKeyPlaceholder)
+	return strings.ReplaceAll(query, constants.KeysetLastKeyPlaceholder, formatted)
+}
@@ -31,6 +31,10 @@ type BaseWrapper struct {
 }
 
 func (w *BaseWrapper) updateUsage() error {
+	// Transient connections are not registered with the manager, there is nothing to refresh
+	if w.chatID == "" {
+		return nil
+	}
 	if err := w.manager.UpdateLastUsed(w.chatID); err != nil {
 		log.Printf("Failed to update last used time: %v", err)
 		return err
@@ -63,6 +67,18 @@ func (w *PostgresWrapper) GetDB() *sql.DB {
 	return sqlDB
 }
 
+// connectionDriver returns the driver registered for this chat's connection type,
+// falling back to the PostgreSQL driver for the PostgreSQL-compatible databases that share it
+func (w *PostgresWrapper) connectionDriver() (DatabaseDriver, bool) {
+	if connInfo, ok := w.manager.GetConnectionInfo(w.chatID); ok {
+		if driver, exists := w.manager.drivers[connInfo.Config.Type]; exists {
+			return driver, true
+		}
+	}
+	driver, exists := w.manager.drivers["postgresql"]
+	return driver, exists
+}
+
 // GetSchema fetches the current database schema
 func (w *PostgresWrapper) GetSchema(ctx context.Context) (*SchemaInfo, error) {
 	// Check for context cancellation
@@ -71,7 +87,7 @@ func (w *PostgresWrapper) GetSchema(ctx context.Context) (*SchemaInfo, error) {
 		return nil, err
 	}
 
-	driver, exists := w.manager.drivers["postgresql"]
+	driver, exists := w.connectionDriver()
 	if !exists {
 		// Check if yugabytedb driver exists
 		driver, exists = w.manager.drivers["yugabytedb"]
@@ -129,7 +145,7 @@ func (w *PostgresWrapper) GetTableChecksum(ctx context.Context, table string) (s
 		return "", fmt.Errorf("failed to update usage: %v", err)
 	}
 
-	driver, exists := w.manager.drivers["postgresql"]
+	driver, exists := w.connectionDriver()
 	if !exists {
 		// Check if yugabytedb driver exists
 		driver, exists = w.manager.drivers["yugabytedb"]
@@ -481,3 +497,289 @@ func (w *ClickHouseWrapper) Close() error {
 	}
 	return sqlDB.Close()
 }
+
+// TrinoWrapper implements DBExecutor for Trino
+type TrinoWrapper struct {
+	BaseWrapper
+}
+
+func NewTrinoWrapper(db *gorm.DB, manager *Manager, chatID string) *TrinoWrapper {
+	return &TrinoWrapper{
+		BaseWrapper: BaseWrapper{
+			db:      db,
+			manager: manager,
+			chatID:  chatID,
+		},
+	}
+}
+
+// GetDB returns the underlying *sql.DB
+func (w *TrinoWrapper) GetDB() *sql.DB {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		log.Printf("Failed to get SQL DB: %v", err)
+		return nil
+	}
+	return sqlDB
+}
+
+// GetSchema fetches the current database schema
+func (w *TrinoWrapper) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	// Check for context cancellation
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoWrapper -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	// Check if Trino driver exists
+	_, exists := w.manager.drivers["trino"]
+	if !exists {
+		return nil, fmt.Errorf("Trino driver not found")
+	}
+
+	// Get the schema fetcher factory for Trino
+	fetcherFactory, exists := w.manager.fetchers["trino"]
+	if !exists {
+		return nil, fmt.Errorf("Trino schema fetcher not found")
+	}
+
+	// Create a schema fetcher for this connection
+	fetcher := fetcherFactory(w)
+
+	// Get selected collections from the chat service if available
+	var selectedTables []string
+	if w.manager.streamHandler != nil {
+		// Try to get selected collections from the chat service
+		selectedCollections, err := w.manager.streamHandler.GetSelectedCollections(w.chatID)
+		if err == nil && selectedCollections != "ALL" && selectedCollections != "" {
+			selectedTables = strings.Split(selectedCollections, ",")
+			log.Printf("TrinoWrapper -> GetSchema -> Using selected collections for chat %s: %v", w.chatID, selectedTables)
+		} else {
+			// Default to ALL if there's an error or no specific collections
+			selectedTables = []string{"ALL"}
+			log.Printf("TrinoWrapper -> GetSchema -> Using ALL tables for chat %s", w.chatID)
+		}
+	} else {
+		// Default to ALL if stream handler is not available
+		selectedTables = []string{"ALL"}
+	}
+
+	// Pass the selected tables to get the schema
+	schema, err := fetcher.GetSchema(ctx, w, selectedTables)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			log.Printf("Schema fetch cancelled by context")
+			return nil, err
+		}
+		return nil, err
+	}
+	return schema, nil
+}
+
+// GetTableChecksum calculates checksum for a single table
+func (w *TrinoWrapper) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	// Check for context cancellation
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoWrapper -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	if err := w.updateUsage(); err != nil {
+		return "", fmt.Errorf("failed to update usage: %v", err)
+	}
+
+	// Get the schema fetcher factory for Trino
+	fetcherFactory, exists := w.manager.fetchers["trino"]
+	if !exists {
+		return "", fmt.Errorf("Trino schema fetcher not found")
+	}
+
+	// Create a schema fetcher for this connection
+	fetcher := fetcherFactory(w)
+
+	return fetcher.GetTableChecksum(ctx, w, table)
+}
+
+// Raw executes a raw SQL query
+func (w *TrinoWrapper) Raw(sql string, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Raw(sql, values...).Error
+}
+
+// Exec executes a SQL statement
+func (w *TrinoWrapper) Exec(sql string, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Exec(sql, values...).Error
+}
+
+// Query executes a SQL query and scans the result into dest
+func (w *TrinoWrapper) Query(sql string, dest interface{}, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Raw(sql, values...).Scan(dest).Error
+}
+
+// QueryRows executes a SQL query and scans the result into dest
+func (w *TrinoWrapper) QueryRows(sql string, dest *[]map[string]interface{}, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Raw(sql, values...).Scan(dest).Error
+}
+
+// Close closes the database connection
+func (w *TrinoWrapper) Close() error {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// OracleWrapper implements DBExecutor for Oracle
+type OracleWrapper struct {
+	BaseWrapper
+}
+
+func NewOracleWrapper(db *gorm.DB, manager *Manager, chatID string) *OracleWrapper {
+	return &OracleWrapper{
+		BaseWrapper: BaseWrapper{
+			db:      db,
+			manager: manager,
+			chatID:  chatID,
+		},
+	}
+}
+
+// GetDB returns the underlying *sql.DB
+func (w *OracleWrapper) GetDB() *sql.DB {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		log.Printf("Failed to get SQL DB: %v", err)
+		return nil
+	}
+	return sqlDB
+}
+
+// GetSchema fetches the current database schema
+func (w *OracleWrapper) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	// Check for context cancellation
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleWrapper -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	// Check if Oracle driver exists
+	_, exists := w.manager.drivers["oracle"]
+	if !exists {
+		return nil, fmt.Errorf("Oracle driver not found")
+	}
+
+	// Get the schema fetcher factory for Oracle
+	fetcherFactory, exists := w.manager.fetchers["oracle"]
+	if !exists {
+		return nil, fmt.Errorf("Oracle schema fetcher not found")
+	}
+
+	// Create a schema fetcher for this connection
+	fetcher := fetcherFactory(w)
+
+	// Get selected collections from the chat service if available
+	var selectedTables []string
+	if w.manager.streamHandler != nil {
+		// Try to get selected collections from the chat service
+		selectedCollections, err := w.manager.streamHandler.GetSelectedCollections(w.chatID)
+		if err == nil && selectedCollections != "ALL" && selectedCollections != "" {
+			selectedTables = strings.Split(selectedCollections, ",")
+			log.Printf("OracleWrapper -> GetSchema -> Using selected collections for chat %s: %v", w.chatID, selectedTables)
+		} else {
+			// Default to ALL if there's an error or no specific collections
+			selectedTables = []string{"ALL"}
+			log.Printf("OracleWrapper -> GetSchema -> Using ALL tables for chat %s", w.chatID)
+		}
+	} else {
+		// Default to ALL if stream handler is not available
+		selectedTables = []string{"ALL"}
+	}
+
+	// Pass the selected tables to get the schema
+	schema, err := fetcher.GetSchema(ctx, w, selectedTables)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			log.Printf("Schema fetch cancelled by context")
+			return nil, err
+		}
+		return nil, err
+	}
+	return schema, nil
+}
+
+// GetTableChecksum calculates checksum for a single table
+func (w *OracleWrapper) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	// Check for context cancellation
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleWrapper -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	if err := w.updateUsage(); err != nil {
+		return "", fmt.Errorf("failed to update usage: %v", err)
+	}
+
+	// Get the schema fetcher factory for Oracle
+	fetcherFactory, exists := w.manager.fetchers["oracle"]
+	if !exists {
+		return "", fmt.Errorf("Oracle schema fetcher not found")
+	}
+
+	// Create a schema fetcher for this connection
+	fetcher := fetcherFactory(w)
+
+	return fetcher.GetTableChecksum(ctx, w, table)
+}
+
+// Raw executes a raw SQL query
+func (w *OracleWrapper) Raw(sql string, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Raw(sql, values...).Error
+}
+
+// Exec executes a SQL statement
+func (w *OracleWrapper) Exec(sql string, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Exec(sql, values...).Error
+}
+
+// Query executes a SQL query and scans the result into dest
+func (w *OracleWrapper) Query(sql string, dest interface{}, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Raw(sql, values...).Scan(dest).Error
+}
+
+// QueryRows executes a SQL query and scans the result into dest
+func (w *OracleWrapper) QueryRows(sql string, dest *[]map[string]interface{}, values ...interface{}) error {
+	if err := w.updateUsage(); err != nil {
+		return fmt.Errorf("failed to update usage: %v", err)
+	}
+	return w.db.Raw(sql, values...).Scan(dest).Error
+}
+
+// Close closes the database connection
+func (w *OracleWrapper) Close() error {
+	sqlDB, err := w.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,378 @@
+package dbmanager
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"neobase-ai/internal/constants"
+)
+
+var (
+	sqlDryRunUpdatePattern      = regexp.MustCompile(`(?is)^UPDATE\s+(.+)$`)
+	sqlDryRunDeletePattern      = regexp.MustCompile(`(?is)^DELETE\s+(.+)$`)
+	sqlDryRunInsertPattern      = regexp.MustCompile(`(?is)^(INSERT|REPLACE)\s+(?:IGNORE\s+)?INTO\s+(.+)$`)
+	sqlDryRunAlterMutation      = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(\S+)\s+(?:ON\s+CLUSTER\s+\S+\s+)?(UPDATE|DELETE)\s+(.+)$`)
+	sqlDryRunOnlyPattern        = regexp.MustCompile(`(?i)^ONLY\s+`)
+	mongoDryRunOperationPattern = regexp.MustCompile(`(?s)^db\.([^.]+)\.(\w+)\((.*)\)\s*;?$`)
+)
+
+// DryRunPlan describes how to find the number of rows a write query would change without running it
+type DryRunPlan struct {
+	CountQuery  string // read-only query returning the number of affected rows, empty when StaticCount is set
+	StaticCount *int64 // affected rows known from the query itself, e.g. the rows of INSERT ... VALUES
+	MaxCount    int64  // cap on the counted rows (e.g. 1 for updateOne), 0 when there is none
+}
+
+// BuildDryRunPlan turns an UPDATE, DELETE or INSERT into a plan that counts the rows it would change.
+// SQL writes become SELECT COUNT(*) with the same WHERE clause, MongoDB writes become countDocuments with the same filter.
+func BuildDryRunPlan(dbType, query, queryType string) (*DryRunPlan, error) {
+	statement := strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	if statement == "" {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks,
+		constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeSpreadsheet, constants.DatabaseTypeGoogleSheets:
+		if strings.Contains(statement, ";") {
+			return nil, fmt.Errorf("dry run supports a single statement only")
+		}
+		return buildSQLDryRunPlan(statement, strings.ToUpper(queryType))
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		return buildMongoDryRunPlan(statement)
+	default:
+		return nil, fmt.Errorf("dry run is not supported for %s", dbType)
+	}
+}
+
+func buildSQLDryRunPlan(statement, queryType string) (*DryRunPlan, error) {
+	// ClickHouse mutations: ALTER TABLE t UPDATE ... WHERE / ALTER TABLE t DELETE WHERE
+	if match := sqlDryRunAlterMutation.FindStringSubmatch(statement); match != nil {
+		whereIdx := topLevelKeywordIndex(match[3], "WHERE")
+		if whereIdx == -1 {
+			return nil, fmt.Errorf("could not find the WHERE clause of the mutation")
+		}
+		return &DryRunPlan{CountQuery: buildCountQuery(match[1], match[3][whereIdx+len("WHERE"):], "")}, nil
+	}
+
+	if match := sqlDryRunUpdatePattern.FindStringSubmatch(statement); match != nil {
+		return buildUpdateDryRunPlan(match[1])
+	}
+	if match := sqlDryRunDeletePattern.FindStringSubmatch(statement); match != nil {
+		return buildDeleteDryRunPlan(match[1])
+	}
+	if match := sqlDryRunInsertPattern.FindStringSubmatch(statement); match != nil {
+		return buildInsertDryRunPlan(match[2])
+	}
+
+	return nil, fmt.Errorf("dry run is only supported for UPDATE, DELETE and INSERT queries, got %s", queryType)
+}
+
+// buildUpdateDryRunPlan handles UPDATE t [alias] SET ... [FROM ...] [WHERE ...] [ORDER BY/LIMIT] [RETURNING ...]
+func buildUpdateDryRunPlan(rest string) (*DryRunPlan, error) {
+	setIdx := topLevelKeywordIndex(rest, "SET")
+	if setIdx == -1 {
+		return nil, fmt.Errorf("could not find the SET clause of the UPDATE")
+	}
+	tables := sqlDryRunOnlyPattern.ReplaceAllString(strings.TrimSpace(rest[:setIdx]), "")
+	afterSet := rest[setIdx+len("SET"):]
+
+	// PostgreSQL UPDATE ... FROM joins the extra tables into the count
+	whereIdx := topLevelKeywordIndex(afterSet, "WHERE")
+	setClause := afterSet
+	if whereIdx != -1 {
+		setClause = afterSet[:whereIdx]
+	}
+	if fromIdx := topLevelKeywordIndex(setClause, "FROM"); fromIdx != -1 {
+		tables += ", " + strings.TrimSpace(setClause[fromIdx+len("FROM"):])
+	}
+
+	where := ""
+	if whereIdx != -1 {
+		where = afterSet[whereIdx+len("WHERE"):]
+	}
+	where, tail := splitDryRunTail(where)
+	return &DryRunPlan{CountQuery: buildCountQuery(tables, where, tail)}, nil
+}
+
+// buildDeleteDryRunPlan handles DELETE FROM t [USING ...] [WHERE ...] and MySQL's DELETE t1 FROM t1 JOIN t2 ...
+func buildDeleteDryRunPlan(rest string) (*DryRunPlan, error) {
+	fromIdx := topLevelKeywordIndex(rest, "FROM")
+	if fromIdx == -1 {
+		return nil, fmt.Errorf("could not find the FROM clause of the DELETE")
+	}
+	afterFrom := rest[fromIdx+len("FROM"):]
+
+	var tables, where, tail string
+	if whereIdx := topLevelKeywordIndex(afterFrom, "WHERE"); whereIdx != -1 {
+		tables = afterFrom[:whereIdx]
+		where, tail = splitDryRunTail(afterFrom[whereIdx+len("WHERE"):])
+	} else {
+		tables, tail = splitDryRunTail(afterFrom)
+	}
+	tables = sqlDryRunOnlyPattern.ReplaceAllString(strings.TrimSpace(tables), "")
+	if usingIdx := topLevelKeywordIndex(tables, "USING"); usingIdx != -1 {
+		tables = strings.TrimSpace(tables[:usingIdx]) + ", " + strings.TrimSpace(tables[usingIdx+len("USING"):])
+	}
+
+	return &DryRunPlan{CountQuery: buildCountQuery(strings.TrimSpace(tables), where, tail)}, nil
+}
+
+// buildInsertDryRunPlan counts the tuples of INSERT ... VALUES, or the rows returned by INSERT ... SELECT
+func buildInsertDryRunPlan(rest string) (*DryRunPlan, error) {
+	if valuesIdx := topLevelKeywordIndex(rest, "VALUES"); valuesIdx != -1 {
+		values := rest[valuesIdx+len("VALUES"):]
+		for _, keyword := range []string{"ON", "RETURNING", "AS"} {
+			if idx := topLevelKeywordIndex(values, keyword); idx != -1 {
+				values = values[:idx]
+			}
+		}
+		count := int64(0)
+		for _, tuple := range splitTopLevel(values, ',') {
+			if strings.HasPrefix(strings.TrimSpace(tuple), "(") {
+				count++
+			}
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("could not find the rows of the INSERT")
+		}
+		return &DryRunPlan{StaticCount: &count}, nil
+	}
+
+	selectIdx := topLevelKeywordIndex(rest, "SELECT")
+	if withIdx := topLevelKeywordIndex(rest, "WITH"); withIdx != -1 && (selectIdx == -1 || withIdx < selectIdx) {
+		selectIdx = withIdx
+	}
+	if selectIdx == -1 {
+		return nil, fmt.Errorf("dry run supports INSERT ... VALUES and INSERT ... SELECT only")
+	}
+	source := rest[selectIdx:]
+	for _, keyword := range []string{"ON CONFLICT", "ON DUPLICATE", "RETURNING"} {
+		if idx := topLevelKeywordIndex(source, keyword); idx != -1 {
+			source = source[:idx]
+		}
+	}
+	return &DryRunPlan{CountQuery: fmt.Sprintf("SELECT COUNT(*) AS count FROM (%s) AS dry_run", strings.TrimSpace(source))}, nil
+}
+
+// buildCountQuery builds the COUNT(*) query of a write; ORDER BY/LIMIT tails are kept in a subquery so LIMIT still caps the count
+func buildCountQuery(tables, where, tail string) string {
+	query := "FROM " + strings.TrimSpace(tables)
+	if strings.TrimSpace(where) != "" {
+		query += " WHERE " + strings.TrimSpace(where)
+	}
+	if tail != "" {
+		return fmt.Sprintf("SELECT COUNT(*) AS count FROM (SELECT 1 %s %s) AS dry_run", query, tail)
+	}
+	return "SELECT COUNT(*) AS count " + query
+}
+
+// splitDryRunTail drops RETURNING and splits ORDER BY/LIMIT off the end of a WHERE clause
+func splitDryRunTail(where string) (string, string) {
+	if idx := topLevelKeywordIndex(where, "RETURNING"); idx != -1 {
+		where = where[:idx]
+	}
+	tailIdx := -1
+	for _, keyword := range []string{"ORDER BY", "LIMIT"} {
+		if idx := topLevelKeywordIndex(where, keyword); idx != -1 && (tailIdx == -1 || idx < tailIdx) {
+			tailIdx = idx
+		}
+	}
+	if tailIdx == -1 {
+		return strings.TrimSpace(where), ""
+	}
+	return strings.TrimSpace(where[:tailIdx]), strings.TrimSpace(where[tailIdx:])
+}
+
+// buildMongoDryRunPlan turns db.collection.updateMany({filter}, {update}) into db.collection.countDocuments({filter})
+func buildMongoDryRunPlan(statement string) (*DryRunPlan, error) {
+	match := mongoDryRunOperationPattern.FindStringSubmatch(statement)
+	if match == nil {
+		return nil, fmt.Errorf("invalid MongoDB query format. Expected: db.collection.operation({...})")
+	}
+	collection, operation := match[1], match[2]
+	args := splitTopLevel(match[3], ',')
+
+	filter := "{}"
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		filter = strings.TrimSpace(args[0])
+	}
+	countQuery := fmt.Sprintf("db.%s.countDocuments(%s)", collection, filter)
+
+	switch operation {
+	case "updateMany", "deleteMany":
+		return &DryRunPlan{CountQuery: countQuery}, nil
+	case "updateOne", "deleteOne", "replaceOne", "findOneAndUpdate", "findOneAndDelete", "findOneAndReplace":
+		return &DryRunPlan{CountQuery: countQuery, MaxCount: 1}, nil
+	case "insertOne":
+		count := int64(1)
+		return &DryRunPlan{StaticCount: &count}, nil
+	case "insertMany":
+		documents := ""
+		if len(args) > 0 {
+			documents = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(args[0]), "["), "]")
+		}
+		count := int64(0)
+		for _, document := range splitTopLevel(documents, ',') {
+			if strings.TrimSpace(document) != "" {
+				count++
+			}
+		}
+		return &DryRunPlan{StaticCount: &count}, nil
+	default:
+		return nil, fmt.Errorf("dry run is not supported for the MongoDB operation %s", operation)
+	}
+}
+
+// topLevelKeywordIndex returns the index of the first occurrence of keyword (one or more words) outside
+// quotes and brackets, or -1. Keywords are matched case-insensitively on word boundaries.
+func topLevelKeywordIndex(s, keyword string) int {
+	words := strings.Fields(strings.ToUpper(keyword))
+	depth := 0
+	var quote rune
+	runes := []rune(s)
+	upper := []rune(strings.ToUpper(s))
+	byteIdx := 0
+	for i := 0; i < len(runes); byteIdx, i = byteIdx+len(string(runes[i])), i+1 {
+		r := runes[i]
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch r {
+		case '\'', '"', '`':
+			quote = r
+			continue
+		case '(', '{', '[':
+			depth++
+			continue
+		case ')', '}', ']':
+			depth--
+			continue
+		}
+		if depth != 0 || (i > 0 && isDryRunWordRune(runes[i-1])) {
+			continue
+		}
+		if end := matchKeywordWords(upper, i, words); end != -1 {
+			return byteIdx
+		}
+	}
+	return -1
+}
+
+// matchKeywordWords matches words separated by whitespace at position i, returning the end position or -1
+func matchKeywordWords(upper []rune, i int, words []string) int {
+	pos := i
+	for w, word := range words {
+		if w > 0 {
+			start := pos
+			for pos < len(upper) && unicode.IsSpace(upper[pos]) {
+				pos++
+			}
+			if pos == start {
+				return -1
+			}
+		}
+		wordRunes := []rune(word)
+		if pos+len(wordRunes) > len(upper) || string(upper[pos:pos+len(wordRunes)]) != word {
+			return -1
+		}
+		pos += len(wordRunes)
+	}
+	if pos < len(upper) && isDryRunWordRune(upper[pos]) {
+		return -1
+	}
+	return pos
+}
+
+func isDryRunWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '$'
+}
+
+// splitTopLevel splits s on sep outside quotes and brackets
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range s {
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch r {
+		case '\'', '"', '`':
+			quote = r
+		case '(', '{', '[':
+			depth++
+		case ')', '}', ']':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + len(string(r))
+			}
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
+
+// ExtractDryRunCount reads the count returned by a dry run count query, either {"count": n} or {"results": [{"count": n}]}
+func ExtractDryRunCount(result interface{}) (int64, error) {
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("unexpected dry run result %T", result)
+	}
+	if count, ok := dryRunCountValue(resultMap["count"]); ok {
+		return count, nil
+	}
+
+	rows := reflect.ValueOf(resultMap["results"])
+	if rows.Kind() == reflect.Slice && rows.Len() > 0 {
+		if row, ok := rows.Index(0).Interface().(map[string]interface{}); ok {
+			for key, value := range row {
+				if strings.EqualFold(key, "count") || strings.EqualFold(key, "count()") || strings.EqualFold(key, "_col0") {
+					if count, ok := dryRunCountValue(value); ok {
+						return count, nil
+					}
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("dry run result does not contain a count")
+}
+
+func dryRunCountValue(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	case string:
+		count, err := strconv.ParseInt(v, 10, 64)
+		return count, err == nil
+	case []byte:
+		count, err := strconv.ParseInt(string(v), 10, 64)
+		return count, err == nil
+	}
+	return 0, false
+}
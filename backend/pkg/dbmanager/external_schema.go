@@ -0,0 +1,151 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ExternalSchema is what a schema file uploaded for a chat, such as a schema.prisma, knows about the database
+type ExternalSchema struct {
+	Source string                   // Shown to the LLM, e.g. "Prisma schema"
+	Tables map[string]ExternalTable // Keyed by table name
+}
+
+type ExternalTable struct {
+	Description        string
+	ColumnDescriptions map[string]string
+	Relations          []ExternalRelation
+}
+
+// ExternalRelation is a named relation, Columns of its table reference RefColumns of RefTable
+type ExternalRelation struct {
+	Name        string
+	Description string
+	Columns     []string
+	RefTable    string
+	RefColumns  []string
+	OnDelete    string
+	OnUpdate    string
+}
+
+// ExternalSchemaProvider returns the external schema of a chat, or nil when none was uploaded
+type ExternalSchemaProvider interface {
+	GetExternalSchema(ctx context.Context, chatID string) (*ExternalSchema, error)
+}
+
+// SetExternalSchemaProvider sets where uploaded schema files are read from when the schema is formatted for the LLM
+func (m *Manager) SetExternalSchemaProvider(provider ExternalSchemaProvider) {
+	m.externalSchemaProvider = provider
+}
+
+// mergeExternalSchema returns a copy of storage completed with the chat's external schema. Empty table and
+// column descriptions are filled from it, and its relations are kept aside to be shown next to the foreign
+// keys, where their names and descriptions are preferred. The stored schema is left as it is.
+func (sm *SchemaManager) mergeExternalSchema(ctx context.Context, chatID string, storage *SchemaStorage) *SchemaStorage {
+	if sm.dbManager == nil || sm.dbManager.externalSchemaProvider == nil || storage == nil || storage.LLMSchema == nil {
+		return storage
+	}
+
+	external, err := sm.dbManager.externalSchemaProvider.GetExternalSchema(ctx, chatID)
+	if err != nil {
+		log.Printf("SchemaManager -> mergeExternalSchema -> Error getting external schema for chat %s: %v", chatID, err)
+		return storage
+	}
+	if external == nil || len(external.Tables) == 0 {
+		return storage
+	}
+
+	merged := *storage
+	merged.LLMSchema = &LLMSchemaInfo{
+		Tables:        make(map[string]LLMTableInfo, len(storage.LLMSchema.Tables)),
+		Relationships: storage.LLMSchema.Relationships,
+	}
+	merged.ExternalSource = external.Source
+	merged.ExternalRelations = make(map[string][]ExternalRelation)
+
+	matched := 0
+	for tableName, table := range storage.LLMSchema.Tables {
+		externalTable, ok := findExternalTable(external.Tables, tableName)
+		if !ok {
+			merged.LLMSchema.Tables[tableName] = table
+			continue
+		}
+		matched++
+
+		if table.Description == "" {
+			table.Description = externalTable.Description
+		}
+		columns := make([]LLMColumnInfo, len(table.Columns))
+		copy(columns, table.Columns)
+		for i := range columns {
+			if columns[i].Description == "" {
+				columns[i].Description = externalTable.ColumnDescriptions[columns[i].Name]
+			}
+		}
+		table.Columns = columns
+
+		merged.LLMSchema.Tables[tableName] = table
+		if len(externalTable.Relations) > 0 {
+			merged.ExternalRelations[tableName] = externalTable.Relations
+		}
+	}
+
+	log.Printf("SchemaManager -> mergeExternalSchema -> Merged %s into %d of %d tables for chat %s",
+		external.Source, matched, len(storage.LLMSchema.Tables), chatID)
+	return &merged
+}
+
+// findExternalTable looks a table up by name, ignoring case and the schema prefix of either name
+func findExternalTable(tables map[string]ExternalTable, tableName string) (ExternalTable, bool) {
+	if table, ok := tables[tableName]; ok {
+		return table, true
+	}
+	for name, table := range tables {
+		if sameTableName(name, tableName) {
+			return table, true
+		}
+	}
+	return ExternalTable{}, false
+}
+
+// sameTableName compares two table names, ignoring case and a schema prefix such as "public."
+func sameTableName(a, b string) bool {
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	return strings.EqualFold(a[strings.LastIndex(a, ".")+1:], b[strings.LastIndex(b, ".")+1:])
+}
+
+// findExternalRelation returns the relation backing a foreign key, matched on its column and referenced table
+func findExternalRelation(relations []ExternalRelation, fk ForeignKey) (ExternalRelation, bool) {
+	for _, relation := range relations {
+		if !sameTableName(relation.RefTable, fk.RefTable) {
+			continue
+		}
+		for _, column := range relation.Columns {
+			if strings.EqualFold(column, fk.ColumnName) {
+				return relation, true
+			}
+		}
+	}
+	return ExternalRelation{}, false
+}
+
+// formatExternalRelationNote is appended to a relation line, e.g. ` -- relation "UserPosts": who wrote the post`
+func formatExternalRelationNote(relation ExternalRelation) string {
+	var note strings.Builder
+	if relation.Name != "" {
+		note.WriteString(fmt.Sprintf(" -- relation %q", relation.Name))
+	} else if relation.Description != "" {
+		note.WriteString(" --")
+	}
+	if relation.Description != "" {
+		if relation.Name != "" {
+			note.WriteString(":")
+		}
+		note.WriteString(" " + relation.Description)
+	}
+	return note.String()
+}
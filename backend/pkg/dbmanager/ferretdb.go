@@ -0,0 +1,45 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"neobase-ai/internal/constants"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// isMongoDBCompatible reports whether a data source type is served by the MongoDB driver
+func isMongoDBCompatible(dbType string) bool {
+	return dbType == constants.DatabaseTypeMongoDB || dbType == constants.DatabaseTypeFerretDB
+}
+
+// isFerretDBInternalCollection reports whether a collection holds FerretDB's own metadata
+func isFerretDBInternalCollection(name string) bool {
+	return strings.HasPrefix(name, "_ferretdb")
+}
+
+// detectFerretDB reports whether the server behind a MongoDB client is FerretDB.
+// FerretDB adds its own version to the buildInfo reply; older releases are recognised by their settings collection.
+func detectFerretDB(ctx context.Context, client *mongo.Client, database string) (bool, error) {
+	var buildInfo bson.M
+	if err := client.Database("admin").RunCommand(ctx, bson.M{"buildInfo": 1}).Decode(&buildInfo); err != nil {
+		return false, fmt.Errorf("failed to run buildInfo: %v", err)
+	}
+	if version, ok := buildInfo[constants.FerretDBBuildInfoVersion]; ok {
+		log.Printf("detectFerretDB -> FerretDB version %v", version)
+		return true, nil
+	}
+
+	if database == "" {
+		return false, nil
+	}
+	names, err := client.Database(database).ListCollectionNames(ctx, bson.M{"name": constants.FerretDBSettingsCollection})
+	if err != nil {
+		return false, fmt.Errorf("failed to list collections: %v", err)
+	}
+	return len(names) > 0, nil
+}
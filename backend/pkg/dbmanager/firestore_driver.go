@@ -0,0 +1,1143 @@
+package dbmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FirestoreClient wraps a Firestore client with the project and database it is bound to
+type FirestoreClient struct {
+	client    *firestore.Client
+	projectID string
+	database  string
+}
+
+// firestoreCall is one call of a Firestore query chain, e.g. Where("status", "==", "active")
+type firestoreCall struct {
+	Method string
+	Args   []json.RawMessage
+}
+
+// firestoreQuery is a parsed Firestore query chain, e.g. db.Collection("users").Where("status", "==", "active").Limit(50)
+type firestoreQuery struct {
+	Calls []firestoreCall
+	// Operation is what the chain does: collections, read, count, get, add, set, update or delete
+	Operation string
+}
+
+// firestoreCallArgs lists the minimum and maximum number of arguments of each call, -1 is unlimited
+var firestoreCallArgs = map[string][2]int{
+	"Collections":     {0, 0},
+	"Collection":      {1, 1},
+	"CollectionGroup": {1, 1},
+	"Doc":             {1, 1},
+	"Where":           {3, 3},
+	"OrderBy":         {1, 2},
+	"Limit":           {1, 1},
+	"Offset":          {1, 1},
+	"Select":          {1, -1},
+	"StartAt":         {1, -1},
+	"StartAfter":      {1, -1},
+	"EndAt":           {1, -1},
+	"EndBefore":       {1, -1},
+	"Get":             {0, 0},
+	"Count":           {0, 0},
+	"Add":             {1, 1},
+	"Set":             {1, 2},
+	"Update":          {1, 1},
+	"Delete":          {0, 0},
+}
+
+// firestoreQueryMethods narrow a collection or collection group read
+var firestoreQueryMethods = map[string]bool{
+	"Where": true, "OrderBy": true, "Limit": true, "Offset": true, "Select": true,
+	"StartAt": true, "StartAfter": true, "EndAt": true, "EndBefore": true,
+}
+
+// firestoreWriteOperations change documents
+var firestoreWriteOperations = map[string]bool{
+	"add": true, "set": true, "update": true, "delete": true,
+}
+
+// firestoreWhereOperators are the filter operators of Where
+var firestoreWhereOperators = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"array-contains": true, "array-contains-any": true, "in": true, "not-in": true,
+}
+
+// newFirestoreClient creates a Firestore client with the service account JSON of the connection config.
+// The project falls back to the project_id of the service account, the database to the project's default database.
+func newFirestoreClient(ctx context.Context, config ConnectionConfig) (*FirestoreClient, error) {
+	credentials := getValue(config.FirestoreCredentialsJSON)
+	if strings.TrimSpace(credentials) == "" {
+		return nil, fmt.Errorf("a Firestore service account JSON is required")
+	}
+
+	projectID := getValue(config.GoogleProjectID)
+	if projectID == "" {
+		var serviceAccount struct {
+			ProjectID string `json:"project_id"`
+		}
+		if err := json.Unmarshal([]byte(credentials), &serviceAccount); err != nil {
+			return nil, fmt.Errorf("invalid Firestore service account JSON: %v", err)
+		}
+		projectID = serviceAccount.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("a Google Cloud project ID is required")
+	}
+
+	database := config.Database
+	if database == "" {
+		database = constants.FirestoreDefaultDatabase
+	}
+
+	client, err := firestore.NewClientWithDatabase(ctx, projectID, database, option.WithCredentialsJSON([]byte(credentials)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Firestore client for project %s: %v", projectID, err)
+	}
+
+	return &FirestoreClient{
+		client:    client,
+		projectID: projectID,
+		database:  database,
+	}, nil
+}
+
+// close closes the Firestore client
+func (c *FirestoreClient) close() {
+	if err := c.client.Close(); err != nil {
+		log.Printf("FirestoreClient -> close -> Error closing client: %v", err)
+	}
+}
+
+// ping checks that the credentials can list the collections of the database
+func (c *FirestoreClient) ping(ctx context.Context) error {
+	iter := c.client.Collections(ctx)
+	if _, err := iter.Next(); err != nil && err != iterator.Done {
+		return err
+	}
+	return nil
+}
+
+// listCollections returns the IDs of the top-level collections, sorted
+func (c *FirestoreClient) listCollections(ctx context.Context) ([]string, error) {
+	collections := []string{}
+	iter := c.client.Collections(ctx)
+	for {
+		collection, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collections: %v", err)
+		}
+		collections = append(collections, collection.ID)
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// parseFirestoreQuery parses a chain of calls starting with db, the arguments are JSON values.
+// The chain is checked for its shape: a collection, document or collection group first, query calls on
+// collections only, and Get, Count, Add, Set, Update or Delete last.
+func parseFirestoreQuery(query string) (*firestoreQuery, error) {
+	query = strings.TrimSpace(query)
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	if !strings.HasPrefix(query, "db.") {
+		return nil, fmt.Errorf(`invalid Firestore query, expected a chain starting with db e.g. db.Collection("users").Where("status", "==", "active")`)
+	}
+
+	parsed := &firestoreQuery{}
+	rest := query[len("db"):]
+	for rest != "" {
+		if rest[0] != '.' {
+			return nil, fmt.Errorf("invalid Firestore query near %q, calls must be chained with a dot", truncateFirestoreQuery(rest))
+		}
+		rest = rest[1:]
+
+		nameEnd := strings.IndexByte(rest, '(')
+		if nameEnd <= 0 {
+			return nil, fmt.Errorf("invalid Firestore query near %q, expected Method(arguments)", truncateFirestoreQuery(rest))
+		}
+		call := firestoreCall{Method: strings.TrimSpace(rest[:nameEnd])}
+		argCount, ok := firestoreCallArgs[call.Method]
+		if !ok {
+			methods := make([]string, 0, len(firestoreCallArgs))
+			for method := range firestoreCallArgs {
+				methods = append(methods, method)
+			}
+			sort.Strings(methods)
+			return nil, fmt.Errorf("unsupported Firestore call %s, supported calls are %s", call.Method, strings.Join(methods, ", "))
+		}
+
+		argsEnd, err := firestoreClosingParen(rest, nameEnd)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", call.Method, err)
+		}
+		if args := strings.TrimSpace(rest[nameEnd+1 : argsEnd]); args != "" {
+			decoder := json.NewDecoder(strings.NewReader("[" + args + "]"))
+			decoder.UseNumber()
+			if err := decoder.Decode(&call.Args); err != nil {
+				return nil, fmt.Errorf("%s arguments must be JSON values (double-quoted strings, numbers, arrays or objects): %v", call.Method, err)
+			}
+		}
+		if len(call.Args) < argCount[0] || (argCount[1] >= 0 && len(call.Args) > argCount[1]) {
+			return nil, fmt.Errorf("%s takes %s arguments, got %d", call.Method, firestoreArgCountText(argCount), len(call.Args))
+		}
+
+		parsed.Calls = append(parsed.Calls, call)
+		rest = strings.TrimSpace(rest[argsEnd+1:])
+	}
+
+	if err := parsed.validateChain(); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// firestoreClosingParen returns the index of the parenthesis closing the one at open, skipping strings and nested brackets
+func firestoreClosingParen(s string, open int) (int, error) {
+	depth := 0
+	inString := false
+	for i := open; i < len(s); i++ {
+		ch := s[i]
+		if inString {
+			switch ch {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch ch {
+		case '"':
+			inString = true
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				if ch != ')' {
+					return 0, fmt.Errorf("unbalanced brackets in arguments")
+				}
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("missing closing parenthesis")
+}
+
+// firestoreArgCountText describes the number of arguments a call takes
+func firestoreArgCountText(argCount [2]int) string {
+	switch {
+	case argCount[1] < 0:
+		return fmt.Sprintf("at least %d", argCount[0])
+	case argCount[0] == argCount[1]:
+		return fmt.Sprintf("%d", argCount[0])
+	default:
+		return fmt.Sprintf("%d to %d", argCount[0], argCount[1])
+	}
+}
+
+// truncateFirestoreQuery shortens a query fragment for error messages
+func truncateFirestoreQuery(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
+
+// validateChain checks the order of the calls and sets the operation of the chain
+func (q *firestoreQuery) validateChain() error {
+	if len(q.Calls) == 0 {
+		return fmt.Errorf("empty Firestore query")
+	}
+
+	first := q.Calls[0].Method
+	switch first {
+	case "Collections":
+		if len(q.Calls) > 1 {
+			return fmt.Errorf("db.Collections() lists the top-level collections and takes no further calls")
+		}
+		q.Operation = "collections"
+		return nil
+	case "Collection", "CollectionGroup":
+	default:
+		return fmt.Errorf("a Firestore query must start with db.Collection, db.CollectionGroup or db.Collections, not db.%s", first)
+	}
+
+	// target is what the chain points at so far: a collection, a collection group, a narrowed query or a document
+	target := "collection"
+	if first == "CollectionGroup" {
+		target = "group"
+	}
+	for i, call := range q.Calls[1:] {
+		last := i == len(q.Calls)-2
+		switch {
+		case call.Method == "Collections" || call.Method == "CollectionGroup":
+			return fmt.Errorf("%s can only start a query", call.Method)
+		case call.Method == "Collection":
+			if target != "document" {
+				return fmt.Errorf("Collection can only follow db or Doc, use Doc(id).Collection(name) for subcollections")
+			}
+			target = "collection"
+		case call.Method == "Doc":
+			if target != "collection" {
+				return fmt.Errorf("Doc can only follow Collection, not a collection group or query calls")
+			}
+			target = "document"
+		case firestoreQueryMethods[call.Method]:
+			if target == "document" {
+				return fmt.Errorf("%s narrows a collection, it cannot follow Doc", call.Method)
+			}
+			target = "query"
+		default:
+			// Get, Count, Add, Set, Update and Delete end the chain
+			if !last {
+				return fmt.Errorf("%s must be the last call of the query", call.Method)
+			}
+			switch call.Method {
+			case "Get":
+				if target != "document" {
+					return fmt.Errorf("Get reads one document and must follow Doc, leave it out to read a collection")
+				}
+			case "Count":
+				if target == "document" {
+					return fmt.Errorf("Count counts the documents of a collection, it cannot follow Doc")
+				}
+			case "Add":
+				if target != "collection" {
+					return fmt.Errorf("Add must follow Collection")
+				}
+			default:
+				if target != "document" {
+					return fmt.Errorf("%s changes one document and must follow Doc", call.Method)
+				}
+			}
+			q.Operation = strings.ToLower(call.Method)
+			return nil
+		}
+	}
+
+	if target == "document" {
+		return fmt.Errorf("a query ending with Doc must call Get, Set, Update or Delete")
+	}
+	q.Operation = "read"
+	return nil
+}
+
+// IsWrite reports whether the query changes a document
+func (q *firestoreQuery) IsWrite() bool {
+	return firestoreWriteOperations[q.Operation]
+}
+
+// collectionNames returns the collections and collection groups the query reads or writes
+func (q *firestoreQuery) collectionNames() []string {
+	names := []string{}
+	for _, call := range q.Calls {
+		if call.Method != "Collection" && call.Method != "CollectionGroup" {
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(call.Args[0], &name); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// stringArg decodes the i-th argument of a call as a non-empty string
+func (c firestoreCall) stringArg(i int, name string) (string, error) {
+	var value string
+	if err := json.Unmarshal(c.Args[i], &value); err != nil || strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("the %s of %s must be a non-empty string", name, c.Method)
+	}
+	return value, nil
+}
+
+// intArg decodes the i-th argument of a call as a non-negative integer
+func (c firestoreCall) intArg(i int, name string) (int, error) {
+	var value int
+	if err := json.Unmarshal(c.Args[i], &value); err != nil || value < 0 {
+		return 0, fmt.Errorf("the %s of %s must be a non-negative integer", name, c.Method)
+	}
+	return value, nil
+}
+
+// firestoreValueConverter turns decoded JSON arguments into Firestore values
+type firestoreValueConverter struct {
+	client *firestore.Client
+}
+
+// convert decodes a JSON argument into a Firestore value.
+// Integers become int64, {"$date": "..."} a timestamp and {"$ref": "collection/id"} a document reference.
+func (v firestoreValueConverter) convert(raw json.RawMessage) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return v.convertValue(value)
+}
+
+func (v firestoreValueConverter) convertValue(value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			return i, nil
+		}
+		return typed.Float64()
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, item := range typed {
+			value, err := v.convertValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = value
+		}
+		return converted, nil
+	case map[string]interface{}:
+		if len(typed) == 1 {
+			if date, ok := typed["$date"].(string); ok {
+				parsed, err := time.Parse(time.RFC3339Nano, date)
+				if err != nil {
+					return nil, fmt.Errorf("invalid $date %q, expected an RFC 3339 time: %v", date, err)
+				}
+				return parsed, nil
+			}
+			if ref, ok := typed["$ref"].(string); ok {
+				doc := v.client.Doc(ref)
+				if doc == nil {
+					return nil, fmt.Errorf("invalid $ref %q, expected a document path like users/user_123", ref)
+				}
+				return doc, nil
+			}
+		}
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			value, err := v.convertValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[key] = value
+		}
+		return converted, nil
+	default:
+		return value, nil
+	}
+}
+
+// convertObject decodes a JSON object argument into document data
+func (v firestoreValueConverter) convertObject(raw json.RawMessage, method string) (map[string]interface{}, error) {
+	value, err := v.convert(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s data: %v", method, err)
+	}
+	data, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the data of %s must be a JSON object", method)
+	}
+	return data, nil
+}
+
+// firestoreTarget is what a query chain resolves to before its last call
+type firestoreTarget struct {
+	collection *firestore.CollectionRef
+	doc        *firestore.DocumentRef
+	query      firestore.Query
+	isGroup    bool
+	hasLimit   bool
+}
+
+// resolve builds the collection, document or SDK query of the chain, without its last call when that is a
+// Get, Count or write
+func (q *firestoreQuery) resolve(ctx context.Context, client *firestore.Client) (*firestoreTarget, error) {
+	converter := firestoreValueConverter{client: client}
+	target := &firestoreTarget{}
+
+	calls := q.Calls
+	if q.Operation != "read" {
+		calls = calls[:len(calls)-1]
+	}
+	for _, call := range calls {
+		switch call.Method {
+		case "Collection":
+			name, err := call.stringArg(0, "collection name")
+			if err != nil {
+				return nil, err
+			}
+			if target.doc != nil {
+				target.collection = target.doc.Collection(name)
+			} else {
+				target.collection = client.Collection(name)
+			}
+			if target.collection == nil {
+				return nil, fmt.Errorf("invalid collection name %q", name)
+			}
+			target.doc = nil
+			target.query = target.collection.Query
+		case "CollectionGroup":
+			name, err := call.stringArg(0, "collection group name")
+			if err != nil {
+				return nil, err
+			}
+			if strings.Contains(name, "/") {
+				return nil, fmt.Errorf("a collection group is a collection ID without slashes, got %q", name)
+			}
+			target.query = client.CollectionGroup(name).Query
+			target.isGroup = true
+		case "Doc":
+			id, err := call.stringArg(0, "document ID")
+			if err != nil {
+				return nil, err
+			}
+			target.doc = target.collection.Doc(id)
+			if target.doc == nil {
+				return nil, fmt.Errorf("invalid document ID %q", id)
+			}
+			target.collection = nil
+		case "Where":
+			path, err := call.stringArg(0, "field")
+			if err != nil {
+				return nil, err
+			}
+			op, err := call.stringArg(1, "operator")
+			if err != nil {
+				return nil, err
+			}
+			if !firestoreWhereOperators[op] {
+				return nil, fmt.Errorf("unsupported Where operator %q, use ==, !=, <, <=, >, >=, array-contains, array-contains-any, in or not-in", op)
+			}
+			value, err := converter.convert(call.Args[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid Where value: %v", err)
+			}
+			target.query = target.query.Where(path, op, value)
+		case "OrderBy":
+			path, err := call.stringArg(0, "field")
+			if err != nil {
+				return nil, err
+			}
+			direction := firestore.Asc
+			if len(call.Args) > 1 {
+				dir, err := call.stringArg(1, "direction")
+				if err != nil {
+					return nil, err
+				}
+				switch strings.ToLower(dir) {
+				case "asc":
+				case "desc":
+					direction = firestore.Desc
+				default:
+					return nil, fmt.Errorf(`the direction of OrderBy must be "asc" or "desc", got %q`, dir)
+				}
+			}
+			target.query = target.query.OrderBy(path, direction)
+		case "Limit":
+			limit, err := call.intArg(0, "limit")
+			if err != nil {
+				return nil, err
+			}
+			if limit == 0 || limit > constants.FirestoreMaxLimit {
+				limit = constants.FirestoreMaxLimit
+			}
+			target.query = target.query.Limit(limit)
+			target.hasLimit = true
+		case "Offset":
+			offset, err := call.intArg(0, "offset")
+			if err != nil {
+				return nil, err
+			}
+			target.query = target.query.Offset(offset)
+		case "Select":
+			fields := make([]string, len(call.Args))
+			for i := range call.Args {
+				field, err := call.stringArg(i, "field")
+				if err != nil {
+					return nil, err
+				}
+				fields[i] = field
+			}
+			target.query = target.query.Select(fields...)
+		case "StartAt", "StartAfter", "EndAt", "EndBefore":
+			cursor, err := firestoreCursor(ctx, client, target, converter, call)
+			if err != nil {
+				return nil, err
+			}
+			switch call.Method {
+			case "StartAt":
+				target.query = target.query.StartAt(cursor...)
+			case "StartAfter":
+				target.query = target.query.StartAfter(cursor...)
+			case "EndAt":
+				target.query = target.query.EndAt(cursor...)
+			case "EndBefore":
+				target.query = target.query.EndBefore(cursor...)
+			}
+		}
+	}
+	return target, nil
+}
+
+// firestoreCursor returns the cursor values of StartAt, StartAfter, EndAt or EndBefore. A single {"$doc": id}
+// argument is the snapshot of that document, the path of the document for collection groups, so the cursor
+// takes the values of every OrderBy field from it.
+func firestoreCursor(ctx context.Context, client *firestore.Client, target *firestoreTarget, converter firestoreValueConverter, call firestoreCall) ([]interface{}, error) {
+	if len(call.Args) == 1 {
+		var docCursor struct {
+			Doc *string `json:"$doc"`
+		}
+		if err := json.Unmarshal(call.Args[0], &docCursor); err == nil && docCursor.Doc != nil {
+			var doc *firestore.DocumentRef
+			if target.isGroup {
+				doc = client.Doc(*docCursor.Doc)
+			} else if target.collection != nil {
+				doc = target.collection.Doc(*docCursor.Doc)
+			}
+			if doc == nil {
+				return nil, fmt.Errorf("invalid %s document %q", call.Method, *docCursor.Doc)
+			}
+			snapshot, err := doc.Get(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the %s document %s: %v", call.Method, *docCursor.Doc, err)
+			}
+			return []interface{}{snapshot}, nil
+		}
+	}
+
+	cursor := make([]interface{}, len(call.Args))
+	for i, arg := range call.Args {
+		value, err := converter.convert(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %v", call.Method, err)
+		}
+		cursor[i] = value
+	}
+	return cursor, nil
+}
+
+// firestoreDocumentRow returns a document's fields with its ID, and its path for collection group reads
+func firestoreDocumentRow(snapshot *firestore.DocumentSnapshot, withPath bool) map[string]interface{} {
+	row := map[string]interface{}{}
+	for key, value := range snapshot.Data() {
+		row[key] = firestoreRowValue(value)
+	}
+	row[constants.FirestoreIDField] = snapshot.Ref.ID
+	if withPath {
+		row[constants.FirestorePathField] = firestoreRelativePath(snapshot.Ref)
+	}
+	return row
+}
+
+// firestoreRowValue converts a Firestore value for JSON results: references become their document path and
+// timestamps RFC 3339 strings
+func firestoreRowValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case *firestore.DocumentRef:
+		return firestoreRelativePath(typed)
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano)
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[key] = firestoreRowValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, item := range typed {
+			converted[i] = firestoreRowValue(item)
+		}
+		return converted
+	default:
+		return value
+	}
+}
+
+// firestoreRelativePath returns the path of a document within its database, e.g. users/user_123
+func firestoreRelativePath(doc *firestore.DocumentRef) string {
+	if doc == nil {
+		return ""
+	}
+	if i := strings.Index(doc.Path, "/documents/"); i >= 0 {
+		return doc.Path[i+len("/documents/"):]
+	}
+	return doc.Path
+}
+
+// FirestoreDriver implements the DatabaseDriver interface for Firestore
+type FirestoreDriver struct{}
+
+// NewFirestoreDriver creates a new Firestore driver
+func NewFirestoreDriver() DatabaseDriver {
+	return &FirestoreDriver{}
+}
+
+// Connect creates a Firestore client and checks that the service account can read the database
+func (d *FirestoreDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.FirestoreRequestTimeout)
+	defer cancel()
+
+	// The client outlives this call, its own context must not be cancelled when Connect returns
+	client, err := newFirestoreClient(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ping(ctx); err != nil {
+		client.close()
+		return nil, fmt.Errorf("failed to connect to Firestore: %v", err)
+	}
+
+	log.Printf("FirestoreDriver -> Connect -> Connected to Firestore database %s of project %s", client.database, client.projectID)
+
+	conn := &Connection{
+		DB:          nil, // Firestore is queried with the Firestore SDK, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect closes the Firestore client
+func (d *FirestoreDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*FirestoreClient)
+	if !ok {
+		return fmt.Errorf("invalid Firestore connection")
+	}
+	client.close()
+	return nil
+}
+
+// Ping checks if the Firestore database is still readable
+func (d *FirestoreDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*FirestoreClient)
+	if !ok {
+		return fmt.Errorf("invalid Firestore connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("FirestoreDriver -> Ping -> Collections check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the Firestore connection is still valid
+func (d *FirestoreDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("FirestoreDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a Firestore query chain
+func (d *FirestoreDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*FirestoreClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeFirestoreQuery(ctx, client, query)
+}
+
+// executeFirestoreQuery translates a query chain into Firestore SDK calls and runs it.
+// Writes are applied immediately, there is nothing to roll back.
+func executeFirestoreQuery(ctx context.Context, client *FirestoreClient, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	parsed, err := parseFirestoreQuery(query)
+	if err != nil {
+		result.Error = &dtos.QueryError{
+			Message: err.Error(),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("FirestoreDriver -> executeFirestoreQuery -> Running %s on %v", parsed.Operation, parsed.collectionNames())
+
+	invalid := func(err error) *QueryExecutionResult {
+		result.Error = &dtos.QueryError{Message: err.Error(), Code: "INVALID_QUERY"}
+		return result
+	}
+	failed := func(err error) *QueryExecutionResult {
+		result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+		return result
+	}
+
+	if parsed.Operation == "collections" {
+		collections, err := client.listCollections(ctx)
+		if err != nil {
+			return failed(err)
+		}
+		rows := make([]map[string]interface{}, 0, len(collections))
+		for _, collection := range collections {
+			rows = append(rows, map[string]interface{}{"collection": collection})
+		}
+		result.Result = rows
+		return firestoreResult(result, startTime)
+	}
+
+	target, err := parsed.resolve(ctx, client.client)
+	if err != nil {
+		return invalid(err)
+	}
+	converter := firestoreValueConverter{client: client.client}
+	last := parsed.Calls[len(parsed.Calls)-1]
+
+	switch parsed.Operation {
+	case "read":
+		q := target.query
+		if !target.hasLimit {
+			q = q.Limit(constants.FirestoreMaxLimit)
+		}
+		iter := q.Documents(ctx)
+		defer iter.Stop()
+		rows := make([]map[string]interface{}, 0)
+		for {
+			snapshot, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return failed(err)
+			}
+			rows = append(rows, firestoreDocumentRow(snapshot, target.isGroup))
+		}
+		result.Result = rows
+
+	case "count":
+		aggregation, err := target.query.NewAggregationQuery().WithCount("count").Get(ctx)
+		if err != nil {
+			return failed(err)
+		}
+		var count int64
+		switch value := aggregation["count"].(type) {
+		case *firestorepb.Value:
+			count = value.GetIntegerValue()
+		case int64:
+			count = value
+		}
+		result.Result = map[string]interface{}{"count": count}
+
+	case "get":
+		snapshot, err := target.doc.Get(ctx)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				result.Result = []map[string]interface{}{}
+				break
+			}
+			return failed(err)
+		}
+		result.Result = []map[string]interface{}{firestoreDocumentRow(snapshot, false)}
+
+	case "add":
+		data, err := converter.convertObject(last.Args[0], last.Method)
+		if err != nil {
+			return invalid(err)
+		}
+		doc, _, err := target.collection.Add(ctx, data)
+		if err != nil {
+			return failed(err)
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected":             1,
+			"message":                  fmt.Sprintf("1 document(s) added to %s with ID %s", target.collection.ID, doc.ID),
+			constants.FirestoreIDField: doc.ID,
+		}
+
+	case "set":
+		data, err := converter.convertObject(last.Args[0], last.Method)
+		if err != nil {
+			return invalid(err)
+		}
+		var options struct {
+			Merge bool `json:"merge"`
+		}
+		if len(last.Args) > 1 {
+			decoder := json.NewDecoder(bytes.NewReader(last.Args[1]))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&options); err != nil {
+				return invalid(fmt.Errorf("invalid Set options: %v", err))
+			}
+		}
+		var setOptions []firestore.SetOption
+		if options.Merge {
+			setOptions = append(setOptions, firestore.MergeAll)
+		}
+		if _, err := target.doc.Set(ctx, data, setOptions...); err != nil {
+			return failed(err)
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected":             1,
+			"message":                  fmt.Sprintf("1 document(s) written to %s", firestoreRelativePath(target.doc)),
+			constants.FirestoreIDField: target.doc.ID,
+		}
+
+	case "update":
+		data, err := converter.convertObject(last.Args[0], last.Method)
+		if err != nil {
+			return invalid(err)
+		}
+		if len(data) == 0 {
+			return invalid(fmt.Errorf("Update needs at least one field"))
+		}
+		paths := make([]string, 0, len(data))
+		for path := range data {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		updates := make([]firestore.Update, 0, len(paths))
+		for _, path := range paths {
+			updates = append(updates, firestore.Update{Path: path, Value: data[path]})
+		}
+		if _, err := target.doc.Update(ctx, updates); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return failed(fmt.Errorf("document %s does not exist", firestoreRelativePath(target.doc)))
+			}
+			return failed(err)
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected":             1,
+			"message":                  fmt.Sprintf("1 document(s) updated in %s", firestoreRelativePath(target.doc)),
+			constants.FirestoreIDField: target.doc.ID,
+		}
+
+	case "delete":
+		if _, err := target.doc.Delete(ctx); err != nil {
+			return failed(err)
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected":             1,
+			"message":                  fmt.Sprintf("1 document(s) deleted from %s", firestoreRelativePath(target.doc)),
+			constants.FirestoreIDField: target.doc.ID,
+		}
+	}
+
+	return firestoreResult(result, startTime)
+}
+
+// firestoreResult sets the execution time and the streamed JSON of a result
+func firestoreResult(result *QueryExecutionResult, startTime time.Time) *QueryExecutionResult {
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// firestoreInjectCursor substitutes the last document ID, or path for collection groups, into a paginated query.
+// The cursor is always a JSON string.
+func firestoreInjectCursor(query, cursor string) string {
+	const placeholder = "{{cursor_value}}"
+
+	encoded, _ := json.Marshal(cursor)
+	query = strings.ReplaceAll(query, `"`+placeholder+`"`, string(encoded))
+	return strings.ReplaceAll(query, placeholder, string(encoded))
+}
+
+// BeginTx returns a transaction that executes queries immediately.
+// Firestore transactions need their reads and writes in one callback, so writes are applied one by one.
+func (d *FirestoreDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*FirestoreClient)
+	if !ok {
+		log.Printf("FirestoreDriver.BeginTx: Invalid Firestore connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &FirestoreTransaction{
+		client: client,
+	}
+}
+
+// FirestoreTransaction implements the Transaction interface for Firestore in autocommit mode
+type FirestoreTransaction struct {
+	client *FirestoreClient
+}
+
+// ExecuteQuery executes a query. Writes are applied immediately.
+func (t *FirestoreTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeFirestoreQuery(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as writes are already applied
+func (t *FirestoreTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot take back applied writes
+func (t *FirestoreTransaction) Rollback() error {
+	log.Printf("FirestoreTransaction -> Rollback -> Writes are applied one by one, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the top-level collections of the database
+func (d *FirestoreDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("FirestoreDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewFirestoreSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a collection
+func (d *FirestoreDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("FirestoreDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewFirestoreSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches the first documents of a collection
+func (d *FirestoreDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("FirestoreDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewFirestoreSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// FirestoreExecutor implements the DBExecutor interface for Firestore
+type FirestoreExecutor struct {
+	client *FirestoreClient
+	conn   *Connection
+}
+
+// NewFirestoreExecutor creates a new Firestore executor
+func NewFirestoreExecutor(conn *Connection) (*FirestoreExecutor, error) {
+	client, ok := conn.APIClient.(*FirestoreClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid Firestore connection")
+	}
+
+	return &FirestoreExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for Firestore as it doesn't use GORM
+func (e *FirestoreExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *FirestoreExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes a query and returns its result
+func (e *FirestoreExecutor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.FirestoreRequestTimeout)
+	defer cancel()
+	return executeFirestoreQuery(ctx, e.client, query)
+}
+
+// Raw executes a Firestore query, *Not Used By DBManager*
+func (e *FirestoreExecutor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute Firestore query: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes a Firestore query, *Not Used By DBManager*
+func (e *FirestoreExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes a Firestore read and stores the documents in dest
+func (e *FirestoreExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes a Firestore read and stores the documents in dest
+func (e *FirestoreExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	result := e.run(query)
+	if result.Error != nil {
+		return fmt.Errorf("failed to execute Firestore query: %v", result.Error.Message)
+	}
+	rows, ok := result.Result.([]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Firestore query did not return documents")
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the Firestore client is closed by the driver on disconnect
+func (e *FirestoreExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the Firestore schema
+func (e *FirestoreExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &FirestoreDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for a collection
+func (e *FirestoreExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &FirestoreDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
@@ -0,0 +1,294 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+// FirestoreSchemaFetcher implements schema fetching for Firestore
+type FirestoreSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewFirestoreSchemaFetcher creates a new Firestore schema fetcher
+func NewFirestoreSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &FirestoreSchemaFetcher{db: db}
+}
+
+// client returns the Firestore client of the executor
+func (f *FirestoreSchemaFetcher) client(db DBExecutor) (*FirestoreClient, error) {
+	executor, ok := db.(*FirestoreExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid Firestore connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema lists the top-level collections as tables. Firestore has no schema, so the fields of a collection
+// and their types are inferred from its first documents.
+func (f *FirestoreSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("FirestoreSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("FirestoreSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := client.listCollections(ctx)
+	if err != nil {
+		log.Printf("FirestoreSchemaFetcher -> GetSchema -> Error listing collections: %v", err)
+		return nil, err
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, name := range collections {
+		if filterTables && !selected[name] {
+			continue
+		}
+
+		snapshots, err := client.sampleDocuments(ctx, name, constants.FirestoreSchemaSampleSize)
+		if err != nil {
+			log.Printf("FirestoreSchemaFetcher -> GetSchema -> Error sampling collection %s: %v", name, err)
+			return nil, err
+		}
+
+		columns := firestoreInferColumns(snapshots)
+		tableData, _ := json.Marshal(columns)
+
+		schema.Tables[name] = TableSchema{
+			Name:        name,
+			Columns:     columns,
+			Indexes:     make(map[string]IndexInfo),
+			ForeignKeys: make(map[string]ForeignKey),
+			Constraints: make(map[string]ConstraintInfo),
+			Comment:     fmt.Sprintf("collection, fields inferred from %d sampled document(s)", len(snapshots)),
+			Checksum:    fmt.Sprintf("%x", md5.Sum(tableData)),
+		}
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("FirestoreSchemaFetcher -> GetSchema -> Fetched %d collections", len(schema.Tables))
+	return schema, nil
+}
+
+// sampleDocuments returns the first documents of a collection
+func (c *FirestoreClient) sampleDocuments(ctx context.Context, collection string, limit int) ([]*firestore.DocumentSnapshot, error) {
+	ref := c.client.Collection(collection)
+	if ref == nil {
+		return nil, fmt.Errorf("invalid collection name %q", collection)
+	}
+
+	snapshots := make([]*firestore.DocumentSnapshot, 0, limit)
+	iter := ref.Limit(limit).Documents(ctx)
+	defer iter.Stop()
+	for {
+		snapshot, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read documents of %s: %v", collection, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// firestoreInferColumns returns the fields of the sampled documents with their types, plus the document ID.
+// A field with different types across documents gets every type joined with |, and a field missing from some
+// documents or null in one of them is nullable.
+func firestoreInferColumns(snapshots []*firestore.DocumentSnapshot) map[string]ColumnInfo {
+	types := make(map[string]map[string]bool)
+	seen := make(map[string]int)
+	for _, snapshot := range snapshots {
+		for field, value := range snapshot.Data() {
+			if types[field] == nil {
+				types[field] = make(map[string]bool)
+			}
+			types[field][firestoreFieldType(value)] = true
+			seen[field]++
+		}
+	}
+
+	columns := map[string]ColumnInfo{
+		constants.FirestoreIDField: {
+			Name:       constants.FirestoreIDField,
+			Type:       "string",
+			IsNullable: false,
+			Comment:    "document ID",
+		},
+	}
+	for field, fieldTypes := range types {
+		nullable := seen[field] < len(snapshots) || fieldTypes["null"]
+		delete(fieldTypes, "null")
+
+		names := make([]string, 0, len(fieldTypes))
+		for name := range fieldTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fieldType := strings.Join(names, "|")
+		if fieldType == "" {
+			fieldType = "null"
+		}
+
+		columns[field] = ColumnInfo{
+			Name:       field,
+			Type:       fieldType,
+			IsNullable: nullable,
+		}
+	}
+	return columns
+}
+
+// firestoreFieldType returns the Firestore type of a document value
+func firestoreFieldType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case int64:
+		return "integer"
+	case float64:
+		return "double"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "timestamp"
+	case []byte:
+		return "bytes"
+	case *firestore.DocumentRef:
+		return "reference"
+	case *latlng.LatLng:
+		return "geopoint"
+	case map[string]interface{}:
+		return "map"
+	case []interface{}:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// FirestorePreviewQuery returns the query reading the first documents of a collection
+func FirestorePreviewQuery(collection string, limit int) string {
+	collectionJSON, _ := json.Marshal(collection)
+	return fmt.Sprintf("db.Collection(%s).Limit(%d)", collectionJSON, limit)
+}
+
+// GetTableChecksum calculates a checksum for the inferred fields of a collection, documents are data and are left out
+func (f *FirestoreSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("FirestoreSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	snapshots, err := client.sampleDocuments(ctx, table, constants.FirestoreSchemaSampleSize)
+	if err != nil {
+		return "", err
+	}
+
+	columns := firestoreInferColumns(snapshots)
+	names := make([]string, 0, len(columns))
+	for name, column := range columns {
+		names = append(names, name+":"+column.Type)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("%x", md5.Sum([]byte(table+";"+strings.Join(names, ";")))), nil
+}
+
+// FetchExampleRecords retrieves the first documents of a collection
+func (f *FirestoreSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("FirestoreSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := client.sampleDocuments(ctx, table, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch example records for %s: %v", table, err)
+	}
+
+	records := make([]map[string]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		records = append(records, firestoreDocumentRow(snapshot, false))
+	}
+	return records, nil
+}
+
+// FirestoreSimplifier implements SchemaSimplifier for the inferred Firestore field types
+type FirestoreSimplifier struct{}
+
+// SimplifyDataType maps Firestore types to readable type names
+func (s *FirestoreSimplifier) SimplifyDataType(dbType string) string {
+	switch dbType {
+	case "integer", "double":
+		return "number"
+	case "string":
+		return "text"
+	case "map":
+		return "object"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints marks the document ID as the key of a collection
+func (s *FirestoreSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	if col.Name == constants.FirestoreIDField {
+		constraints = append(constraints, "PRIMARY KEY")
+	}
+	if !col.IsNullable {
+		constraints = append(constraints, "NOT NULL")
+	}
+
+	return constraints
+}
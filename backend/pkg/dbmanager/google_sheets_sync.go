@@ -0,0 +1,123 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"neobase-ai/config"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// GoogleSheetTable is one table read from a Google Sheet, named the same way as on import
+type GoogleSheetTable struct {
+	SheetName string
+	TableName string
+	Headers   []string
+	Rows      [][]string
+}
+
+// FetchGoogleSheetTables reads every sheet of a spreadsheet and splits it into tables with the same
+// region detection used when the sheet was imported. The access token is always obtained from the
+// refresh token, so expired tokens are refreshed automatically.
+func FetchGoogleSheetTables(ctx context.Context, sheetID, refreshToken string) ([]GoogleSheetTable, error) {
+	if sheetID == "" {
+		return nil, fmt.Errorf("google sheet ID not found")
+	}
+	if refreshToken == "" {
+		return nil, fmt.Errorf("google refresh token not found, please re-authorize Google Sheets access")
+	}
+
+	oauthConfig := &oauth2.Config{
+		ClientID:     config.Env.GoogleClientID,
+		ClientSecret: config.Env.GoogleClientSecret,
+		Endpoint:     google.Endpoint,
+		RedirectURL:  config.Env.GoogleRedirectURL,
+		Scopes: []string{
+			"https://www.googleapis.com/auth/spreadsheets.readonly",
+		},
+	}
+	tokenSource := oauthConfig.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
+	if _, err := tokenSource.Token(); err != nil {
+		return nil, fmt.Errorf("failed to refresh google access token: %w", err)
+	}
+
+	service, err := sheets.NewService(ctx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sheets service: %w", err)
+	}
+
+	spreadsheet, err := service.Spreadsheets.Get(sheetID).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spreadsheet: %w", err)
+	}
+
+	tables := make([]GoogleSheetTable, 0, len(spreadsheet.Sheets))
+	for _, sheet := range spreadsheet.Sheets {
+		sheetName := sheet.Properties.Title
+		tableName := sanitizeTableName(sheetName)
+
+		resp, err := service.Spreadsheets.Values.Get(sheetID, fmt.Sprintf("%s!A:ZZ", sheetName)).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %s: %w", sheetName, err)
+		}
+		if len(resp.Values) == 0 {
+			log.Printf("FetchGoogleSheetTables -> Sheet %s is empty, skipping", sheetName)
+			continue
+		}
+
+		regions, err := NewRobustSheetAnalyzer(resp.Values).AnalyzeRobust()
+		if err != nil {
+			// Same unstructured fallback as the import
+			log.Printf("FetchGoogleSheetTables -> Failed to analyze sheet %s: %v", sheetName, err)
+			table := GoogleSheetTable{
+				SheetName: sheetName,
+				TableName: tableName,
+				Headers:   []string{"row_num", "col_num", "value"},
+			}
+			for rowIdx, row := range resp.Values {
+				for colIdx, cell := range row {
+					if cell != nil && fmt.Sprintf("%v", cell) != "" {
+						table.Rows = append(table.Rows, []string{fmt.Sprintf("%d", rowIdx+1), columnIndexToName(colIdx), fmt.Sprintf("%v", cell)})
+					}
+				}
+			}
+			tables = append(tables, table)
+			continue
+		}
+
+		for regionIdx, region := range regions {
+			currentTableName := tableName
+			if len(regions) > 1 {
+				currentTableName = fmt.Sprintf("%s_%d", tableName, regionIdx+1)
+			}
+			tables = append(tables, GoogleSheetTable{
+				SheetName: sheetName,
+				TableName: currentTableName,
+				Headers:   region.Headers,
+				Rows:      stringifySheetRows(region.DataRows),
+			})
+		}
+	}
+
+	return tables, nil
+}
+
+// stringifySheetRows converts sheet cells to strings, nil cells become empty strings
+func stringifySheetRows(rows [][]interface{}) [][]string {
+	result := make([][]string, len(rows))
+	for i, row := range rows {
+		values := make([]string, len(row))
+		for j, cell := range row {
+			if cell != nil {
+				values[j] = fmt.Sprintf("%v", cell)
+			}
+		}
+		result[i] = values
+	}
+	return result
+}
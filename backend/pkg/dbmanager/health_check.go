@@ -0,0 +1,161 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"sort"
+	"time"
+)
+
+// HealthCheckResult tells whether a connection can still run queries, beyond the connection being open
+type HealthCheckResult struct {
+	IsHealthy           bool      `json:"isHealthy"`
+	LatencyMs           int       `json:"latencyMs"`           // Round trip of the test query
+	HasSelectPermission bool      `json:"hasSelectPermission"` // At least one known table can be read
+	ErrorMessage        *string   `json:"errorMessage,omitempty"`
+	CheckedAt           time.Time `json:"checkedAt"`
+}
+
+// HealthCheck runs a test query on the chat's connection (SELECT 1, or a ping where there is no SQL), measures its
+// round trip and reads one row of a known table to check the user has SELECT permission. A database without known
+// tables, such as one whose schema hasn't been fetched yet, is healthy when the test query succeeds.
+// The result is stored on the connection and returned by GetConnectionInfo.
+func (m *Manager) HealthCheck(chatID string) *HealthCheckResult {
+	result := &HealthCheckResult{CheckedAt: time.Now()}
+
+	m.mu.RLock()
+	conn, exists := m.connections[chatID]
+	var driver DatabaseDriver
+	driverExists := false
+	if exists {
+		driver, driverExists = m.drivers[conn.Config.Type]
+	}
+	m.mu.RUnlock()
+	if !exists {
+		message := "no connection found"
+		result.ErrorMessage = &message
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.HealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := runHealthCheckQuery(ctx, conn)
+	result.LatencyMs = int(time.Since(start).Milliseconds())
+	if err != nil {
+		message := fmt.Sprintf("test query failed: %v", err)
+		result.ErrorMessage = &message
+		m.storeHealthCheck(chatID, conn, result)
+		return result
+	}
+
+	tables := m.healthCheckTables(ctx, chatID)
+	if driverExists {
+		if executor, err := m.newExecutor(conn, chatID); err == nil {
+			for _, table := range tables {
+				if _, err := driver.FetchExampleRecords(ctx, executor, table, 1); err != nil {
+					log.Printf("DBManager -> HealthCheck -> Can't read %s for chat %s: %v", table, chatID, err)
+					continue
+				}
+				result.HasSelectPermission = true
+				break
+			}
+		}
+	}
+
+	result.IsHealthy = result.HasSelectPermission || len(tables) == 0
+	if !result.IsHealthy {
+		message := "connected, but none of the database's tables can be read, check the user's SELECT permissions"
+		result.ErrorMessage = &message
+	}
+
+	m.storeHealthCheck(chatID, conn, result)
+	return result
+}
+
+// runHealthCheckQuery runs the cheapest query of the connection's type. SQL databases run SELECT 1 so a
+// connection that is open but can't execute, e.g. a read-only replica in recovery, is caught.
+func runHealthCheckQuery(ctx context.Context, conn *Connection) error {
+	if conn.DB == nil || isMongoDBCompatible(conn.Config.Type) {
+		return pingConnection(ctx, conn)
+	}
+
+	query := "SELECT 1"
+	if conn.Config.Type == constants.DatabaseTypeOracle {
+		query = "SELECT 1 FROM DUAL"
+	}
+	var result int
+	return conn.DB.WithContext(ctx).Raw(query).Scan(&result).Error
+}
+
+// healthCheckTables returns the first few tables of the stored schema, in name order so checks are repeatable
+func (m *Manager) healthCheckTables(ctx context.Context, chatID string) []string {
+	schema, err := m.schemaManager.GetStoredSchemaInfo(ctx, chatID)
+	if err != nil || schema == nil {
+		return nil
+	}
+
+	tables := make([]string, 0, len(schema.Tables))
+	for name := range schema.Tables {
+		tables = append(tables, name)
+	}
+	sort.Strings(tables)
+	if len(tables) > constants.HealthCheckPermissionTables {
+		tables = tables[:constants.HealthCheckPermissionTables]
+	}
+	return tables
+}
+
+// storeHealthCheck keeps the result on the connection, unless the chat has reconnected since the check started
+func (m *Manager) storeHealthCheck(chatID string, conn *Connection, result *HealthCheckResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, exists := m.connections[chatID]; exists && current == conn {
+		conn.LastHealthCheck = result
+	}
+}
+
+// startHealthMonitor health checks every connected chat every HealthCheckInterval, until the manager stops
+func (m *Manager) startHealthMonitor() {
+	ticker := time.NewTicker(constants.HealthCheckInterval)
+	defer ticker.Stop()
+
+	log.Printf("DBManager -> startHealthMonitor -> Checking connection health every %v", constants.HealthCheckInterval)
+
+	for {
+		select {
+		case <-m.stopCleanup:
+			log.Printf("DBManager -> startHealthMonitor -> Health monitor stopped")
+			return
+		case <-ticker.C:
+			m.checkConnectionsHealth()
+		}
+	}
+}
+
+// checkConnectionsHealth checks the connected chats one at a time, like the schema poller, to keep the load low
+func (m *Manager) checkConnectionsHealth() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("DBManager -> checkConnectionsHealth -> Panic recovered: %v", r)
+		}
+	}()
+
+	m.mu.RLock()
+	chatIDs := make([]string, 0, len(m.connections))
+	for chatID, conn := range m.connections {
+		if conn.Status == StatusConnected {
+			chatIDs = append(chatIDs, chatID)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, chatID := range chatIDs {
+		if result := m.HealthCheck(chatID); !result.IsHealthy && result.ErrorMessage != nil {
+			log.Printf("DBManager -> checkConnectionsHealth -> Chat %s is unhealthy: %s", chatID, *result.ErrorMessage)
+		}
+	}
+}
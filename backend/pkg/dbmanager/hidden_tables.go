@@ -0,0 +1,114 @@
+package dbmanager
+
+import (
+	"strings"
+)
+
+// SetHiddenTables changes the hidden tables of a chat's open connection, so the next schema formatted for the LLM
+// leaves them out without reconnecting
+func (m *Manager) SetHiddenTables(chatID string, tables []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if conn, exists := m.connections[chatID]; exists {
+		conn.Config.HiddenTables = tables
+	}
+}
+
+// HiddenTables returns the tables of a chat's open connection that are kept out of the LLM context
+func (m *Manager) HiddenTables(chatID string) []string {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if conn, exists := m.connections[chatID]; exists {
+		return conn.Config.HiddenTables
+	}
+	return nil
+}
+
+// IsHiddenTable reports whether a table is in the hidden list. Names are compared case-insensitively, and a
+// schema-qualified table (public.users) matches a hidden name without its schema (users).
+func IsHiddenTable(hidden []string, table string) bool {
+	if len(hidden) == 0 || table == "" {
+		return false
+	}
+	unqualified := table[strings.LastIndex(table, ".")+1:]
+	for _, name := range hidden {
+		if strings.EqualFold(name, table) || strings.EqualFold(name, unqualified) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutHiddenTables returns a copy of schema without the hidden tables, and without the foreign keys of the
+// remaining tables that reference them, so the LLM is not told they exist. The schema itself is left as it is.
+func WithoutHiddenTables(schema *SchemaInfo, hidden []string) *SchemaInfo {
+	if schema == nil || len(hidden) == 0 {
+		return schema
+	}
+
+	filtered := *schema
+	filtered.Tables = make(map[string]TableSchema, len(schema.Tables))
+	for name, table := range schema.Tables {
+		if IsHiddenTable(hidden, name) {
+			continue
+		}
+		hasHiddenRef := false
+		for _, fk := range table.ForeignKeys {
+			if IsHiddenTable(hidden, fk.RefTable) {
+				hasHiddenRef = true
+				break
+			}
+		}
+		if hasHiddenRef {
+			foreignKeys := make(map[string]ForeignKey, len(table.ForeignKeys))
+			for fkName, fk := range table.ForeignKeys {
+				if !IsHiddenTable(hidden, fk.RefTable) {
+					foreignKeys[fkName] = fk
+				}
+			}
+			table.ForeignKeys = foreignKeys
+		}
+		filtered.Tables[name] = table
+	}
+	return &filtered
+}
+
+// withoutHiddenStorageTables returns a copy of storage without the hidden tables, their example records and
+// the relationships that involve them
+func withoutHiddenStorageTables(storage *SchemaStorage, hidden []string) *SchemaStorage {
+	if storage == nil || len(hidden) == 0 {
+		return storage
+	}
+
+	filtered := *storage
+	filtered.FullSchema = WithoutHiddenTables(storage.FullSchema, hidden)
+	if storage.LLMSchema != nil {
+		filtered.LLMSchema = &LLMSchemaInfo{
+			Tables: make(map[string]LLMTableInfo, len(storage.LLMSchema.Tables)),
+		}
+		for name, table := range storage.LLMSchema.Tables {
+			if !IsHiddenTable(hidden, name) {
+				filtered.LLMSchema.Tables[name] = table
+			}
+		}
+		for _, rel := range storage.LLMSchema.Relationships {
+			if !IsHiddenTable(hidden, rel.FromTable) && !IsHiddenTable(hidden, rel.ToTable) && !IsHiddenTable(hidden, rel.Through) {
+				filtered.LLMSchema.Relationships = append(filtered.LLMSchema.Relationships, rel)
+			}
+		}
+	}
+	if storage.ExternalRelations != nil {
+		filtered.ExternalRelations = make(map[string][]ExternalRelation, len(storage.ExternalRelations))
+		for name, relations := range storage.ExternalRelations {
+			for _, relation := range relations {
+				if !IsHiddenTable(hidden, relation.RefTable) {
+					filtered.ExternalRelations[name] = append(filtered.ExternalRelations[name], relation)
+				}
+			}
+		}
+	}
+	return &filtered
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"neobase-ai/config"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
 	"neobase-ai/pkg/redis"
 	"time"
 	
@@ -26,16 +28,17 @@ func NewImportMetadataStore(redisRepo redis.IRedisRepositories) *ImportMetadataS
 
 // StoreMetadata stores import metadata for a connection
 func (s *ImportMetadataStore) StoreMetadata(chatID string, metadata *dtos.ImportMetadata) error {
-	key := fmt.Sprintf("import_metadata:%s", chatID)
+	key := constants.GetImportMetadataKey(chatID)
 	
+	metadata.StoredAt = time.Now()
 	data, err := json.Marshal(metadata)
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 	
-	// Store with 7 day expiration
+	// Expire with the configured TTL, CleanupExpiredMetadata removes anything that outlived it
 	ctx := context.Background()
-	if err := s.redisRepo.Set(key, data, 7*24*time.Hour, ctx); err != nil {
+	if err := s.redisRepo.Set(key, data, importMetadataTTL(), ctx); err != nil {
 		return fmt.Errorf("failed to store metadata: %w", err)
 	}
 	
@@ -45,7 +48,7 @@ func (s *ImportMetadataStore) StoreMetadata(chatID string, metadata *dtos.Import
 
 // GetMetadata retrieves import metadata for a connection
 func (s *ImportMetadataStore) GetMetadata(chatID string) (*dtos.ImportMetadata, error) {
-	key := fmt.Sprintf("import_metadata:%s", chatID)
+	key := constants.GetImportMetadataKey(chatID)
 	
 	ctx := context.Background()
 	data, err := s.redisRepo.Get(key, ctx)
@@ -70,7 +73,7 @@ func (s *ImportMetadataStore) GetMetadata(chatID string) (*dtos.ImportMetadata,
 
 // DeleteMetadata removes import metadata for a connection
 func (s *ImportMetadataStore) DeleteMetadata(chatID string) error {
-	key := fmt.Sprintf("import_metadata:%s", chatID)
+	key := constants.GetImportMetadataKey(chatID)
 	
 	ctx := context.Background()
 	if err := s.redisRepo.Del(key, ctx); err != nil {
@@ -79,4 +82,111 @@ func (s *ImportMetadataStore) DeleteMetadata(chatID string) error {
 	
 	log.Printf("ImportMetadataStore -> Deleted metadata for chat %s", chatID)
 	return nil
-}
\ No newline at end of file
+}
+
+// StoreImportStatus persists the latest import status for a chat so it can be polled while the import runs
+func (s *ImportMetadataStore) StoreImportStatus(chatID string, status *dtos.ImportStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal import status: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := s.redisRepo.Set(constants.GetImportStatusKey(chatID), data, constants.SpreadsheetImportStatusTTL, ctx); err != nil {
+		return fmt.Errorf("failed to store import status: %w", err)
+	}
+	return nil
+}
+
+// GetImportStatus retrieves the latest import status for a chat, or nil if no import has run recently
+func (s *ImportMetadataStore) GetImportStatus(chatID string) (*dtos.ImportStatus, error) {
+	ctx := context.Background()
+	data, err := s.redisRepo.Get(constants.GetImportStatusKey(chatID), ctx)
+	if err != nil {
+		if err == goredis.Nil {
+			return nil, nil // No import status found
+		}
+		return nil, fmt.Errorf("failed to get import status: %w", err)
+	}
+
+	if data == "" {
+		return nil, nil
+	}
+
+	var status dtos.ImportStatus
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal import status: %w", err)
+	}
+
+	return &status, nil
+}
+
+// CleanupExpiredMetadata deletes import metadata stored more than ttl ago and returns how many entries were deleted.
+// Entries written before StoredAt was recorded have their Redis expiry shortened to ttl instead.
+func (s *ImportMetadataStore) CleanupExpiredMetadata(ttl time.Duration) (int, error) {
+	ctx := context.Background()
+	keys, err := s.redisRepo.ScanKeys(constants.ImportMetadataKeyPrefix+"*", ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list import metadata: %w", err)
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		data, err := s.redisRepo.Get(key, ctx)
+		if err != nil || data == "" {
+			continue // Expired since it was listed
+		}
+
+		var metadata dtos.ImportMetadata
+		if err := json.Unmarshal([]byte(data), &metadata); err != nil || metadata.StoredAt.IsZero() {
+			if remaining, err := s.redisRepo.TTL(key, ctx); err == nil && (remaining < 0 || remaining > ttl) {
+				if err := s.redisRepo.Expire(key, ttl, ctx); err != nil {
+					log.Printf("ImportMetadataStore -> CleanupExpiredMetadata -> Failed to set expiry of %s: %v", key, err)
+				}
+			}
+			continue
+		}
+
+		if time.Since(metadata.StoredAt) < ttl {
+			continue
+		}
+		if err := s.redisRepo.Del(key, ctx); err != nil {
+			log.Printf("ImportMetadataStore -> CleanupExpiredMetadata -> Failed to delete %s: %v", key, err)
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// StartCleanup runs CleanupExpiredMetadata with the configured TTL every interval until ctx is done
+func (s *ImportMetadataStore) StartCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.CleanupExpiredMetadata(importMetadataTTL())
+			if err != nil {
+				log.Printf("ImportMetadataStore -> StartCleanup -> %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("ImportMetadataStore -> StartCleanup -> Deleted %d expired import metadata entries", deleted)
+			}
+		}
+	}
+}
+
+// importMetadataTTL returns how long import metadata is kept, from IMPORT_METADATA_TTL_HOURS
+func importMetadataTTL() time.Duration {
+	hours := config.Env.ImportMetadataTTLHours
+	if hours <= 0 {
+		hours = constants.DefaultImportMetadataTTLHours
+	}
+	return time.Duration(hours) * time.Hour
+}
@@ -0,0 +1,590 @@
+package dbmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InfluxDBClient is a minimal client for the InfluxDB 3 HTTP API, scoped to a single database
+type InfluxDBClient struct {
+	baseURL    string
+	database   string
+	token      string
+	org        string
+	httpClient *http.Client
+}
+
+// influxDBStatementKind is how a statement is sent to InfluxDB
+type influxDBStatementKind string
+
+const (
+	influxDBStatementSQL      influxDBStatementKind = "sql"
+	influxDBStatementInfluxQL influxDBStatementKind = "influxql"
+	influxDBStatementWrite    influxDBStatementKind = "write"
+	influxDBStatementDrop     influxDBStatementKind = "drop"
+)
+
+// influxDBStatement is a parsed InfluxDB query
+type influxDBStatement struct {
+	Kind influxDBStatementKind
+	// Query is the SQL or InfluxQL text, the line protocol of a write, or the measurement of a drop
+	Query string
+}
+
+var (
+	// influxQLPattern matches InfluxQL-only syntax: SHOW statements, GROUP BY time(...) and FILL(...)
+	influxQLPattern = regexp.MustCompile(`(?is)^show\s|\bgroup\s+by\s+(.*,\s*)?time\s*\(|\bfill\s*\(`)
+	// influxDBInsertPattern matches INSERT followed by line protocol
+	influxDBInsertPattern = regexp.MustCompile(`(?is)^insert\s+(.+)$`)
+	// influxDBDropPattern matches DROP MEASUREMENT or DROP TABLE with an optionally quoted name
+	influxDBDropPattern = regexp.MustCompile(`(?i)^drop\s+(?:measurement|table)\s+(?:if\s+exists\s+)?("(?:[^"\\]|\\.)*"|[A-Za-z0-9_.\-]+)$`)
+	// influxDBUnsupportedPattern matches SQL writes InfluxDB 3 does not support
+	influxDBUnsupportedPattern = regexp.MustCompile(`(?i)^(update|delete|alter|create|truncate|merge|upsert|replace)\b`)
+)
+
+// newInfluxDBClient creates a client for the database in the connection config.
+// The token is optional, InfluxDB 3 Core can run without authentication.
+func newInfluxDBClient(config ConnectionConfig) (*InfluxDBClient, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("an InfluxDB host is required")
+	}
+	if config.Database == "" {
+		return nil, fmt.Errorf("an InfluxDB database is required")
+	}
+
+	baseURL := strings.TrimRight(config.Host, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		scheme := "http"
+		if config.UseSSL {
+			scheme = "https"
+		}
+		port := constants.InfluxDBDefaultPort
+		if config.Port != nil && *config.Port != "" {
+			port = *config.Port
+		}
+		baseURL = fmt.Sprintf("%s://%s:%s", scheme, baseURL, port)
+	}
+
+	client := &InfluxDBClient{
+		baseURL:  baseURL,
+		database: config.Database,
+		httpClient: &http.Client{
+			Timeout: constants.InfluxDBRequestTimeout,
+		},
+	}
+	if config.InfluxToken != nil {
+		client.token = *config.InfluxToken
+	}
+	if config.InfluxOrg != nil {
+		client.org = *config.InfluxOrg
+	}
+	return client, nil
+}
+
+// do sends a request to the InfluxDB API and returns the response body
+func (c *InfluxDBClient) do(ctx context.Context, method, path string, params url.Values, contentType string, body []byte) ([]byte, error) {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create InfluxDB request: %v", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("InfluxDB request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read InfluxDB response: %v", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, parseInfluxDBError(resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
+
+// parseInfluxDBError turns an InfluxDB error body into an error.
+// InfluxDB returns either {"error": "..."}, {"message": "..."} or plain text.
+func parseInfluxDBError(status int, body []byte) error {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("InfluxDB rejected the token (HTTP %d), check that it has access to the database", status)
+	}
+
+	var payload struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if payload.Error != "" {
+			return fmt.Errorf("InfluxDB error: %s", payload.Error)
+		}
+		if payload.Message != "" {
+			return fmt.Errorf("InfluxDB error: %s", payload.Message)
+		}
+	}
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return fmt.Errorf("InfluxDB error (HTTP %d): %s", status, text)
+	}
+	return fmt.Errorf("InfluxDB request failed with HTTP %d", status)
+}
+
+// query runs a SQL or InfluxQL read and returns its rows
+func (c *InfluxDBClient) query(ctx context.Context, kind influxDBStatementKind, query string) ([]map[string]interface{}, error) {
+	path := "/api/v3/query_sql"
+	if kind == influxDBStatementInfluxQL {
+		path = "/api/v3/query_influxql"
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"db":     c.database,
+		"q":      query,
+		"format": "json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode InfluxDB query: %v", err)
+	}
+
+	body, err := c.do(ctx, http.MethodPost, path, nil, "application/json", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return rows, nil
+	}
+	// Keep integer fields exact, float64 loses precision above 2^53
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to decode InfluxDB response: %v", err)
+	}
+	return rows, nil
+}
+
+// writeLineProtocol writes points in line protocol. InfluxDB Cloud connections with an organization
+// use the v2 compatible endpoint, which addresses the database as a bucket.
+func (c *InfluxDBClient) writeLineProtocol(ctx context.Context, lines string) error {
+	path := "/api/v3/write_lp"
+	params := url.Values{"db": {c.database}}
+	if c.org != "" {
+		path = "/api/v2/write"
+		params = url.Values{"org": {c.org}, "bucket": {c.database}, "precision": {"ns"}}
+	}
+
+	_, err := c.do(ctx, http.MethodPost, path, params, "text/plain; charset=utf-8", []byte(lines))
+	return err
+}
+
+// dropMeasurement deletes a measurement and all its data
+func (c *InfluxDBClient) dropMeasurement(ctx context.Context, measurement string) error {
+	params := url.Values{"db": {c.database}, "table": {measurement}}
+	_, err := c.do(ctx, http.MethodDelete, "/api/v3/configure/table", params, "", nil)
+	return err
+}
+
+// ping checks the token and database with a trivial query
+func (c *InfluxDBClient) ping(ctx context.Context) error {
+	_, err := c.query(ctx, influxDBStatementSQL, "SELECT 1")
+	return err
+}
+
+// parseInfluxDBStatement decides how a query is sent to InfluxDB: SQL, InfluxQL, a line protocol write or a measurement drop
+func parseInfluxDBStatement(query string) (*influxDBStatement, error) {
+	query = strings.TrimSpace(query)
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	if query == "" {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	if matches := influxDBInsertPattern.FindStringSubmatch(query); matches != nil {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(matches[1])), "into ") {
+			return nil, fmt.Errorf("InfluxDB 3 does not support SQL INSERT, write points in line protocol: INSERT measurement,tag=value field=1")
+		}
+		// Each line may repeat the INSERT keyword
+		lines := strings.Split(matches[1], "\n")
+		points := make([]string, 0, len(lines))
+		for _, line := range lines {
+			line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), ";"))
+			if prefix := influxDBInsertPattern.FindStringSubmatch(line); prefix != nil {
+				line = strings.TrimSpace(prefix[1])
+			}
+			if line != "" {
+				points = append(points, line)
+			}
+		}
+		if len(points) == 0 {
+			return nil, fmt.Errorf("INSERT requires at least one point in line protocol")
+		}
+		return &influxDBStatement{Kind: influxDBStatementWrite, Query: strings.Join(points, "\n")}, nil
+	}
+
+	if matches := influxDBDropPattern.FindStringSubmatch(query); matches != nil {
+		measurement := matches[1]
+		if strings.HasPrefix(measurement, `"`) {
+			if err := json.Unmarshal([]byte(measurement), &measurement); err != nil {
+				return nil, fmt.Errorf("invalid measurement name %s: %v", matches[1], err)
+			}
+		}
+		return &influxDBStatement{Kind: influxDBStatementDrop, Query: measurement}, nil
+	}
+
+	if matches := influxDBUnsupportedPattern.FindStringSubmatch(query); matches != nil {
+		return nil, fmt.Errorf("InfluxDB 3 does not support %s, write points with INSERT <line protocol> or remove a measurement with DROP MEASUREMENT", strings.ToUpper(matches[1]))
+	}
+
+	if influxQLPattern.MatchString(query) {
+		return &influxDBStatement{Kind: influxDBStatementInfluxQL, Query: query}, nil
+	}
+	return &influxDBStatement{Kind: influxDBStatementSQL, Query: query}, nil
+}
+
+// InfluxDBDriver implements the DatabaseDriver interface for InfluxDB 3
+type InfluxDBDriver struct{}
+
+// NewInfluxDBDriver creates a new InfluxDB driver
+func NewInfluxDBDriver() DatabaseDriver {
+	return &InfluxDBDriver{}
+}
+
+// Connect validates the host, token and database and returns a connection holding the HTTP client
+func (d *InfluxDBDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newInfluxDBClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.InfluxDBRequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to InfluxDB: %v", err)
+	}
+
+	log.Printf("InfluxDBDriver -> Connect -> Connected to InfluxDB database %s at %s", client.database, client.baseURL)
+
+	conn := &Connection{
+		DB:          nil, // InfluxDB 3 is queried over HTTP, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect releases the idle HTTP connections of the client
+func (d *InfluxDBDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*InfluxDBClient)
+	if !ok {
+		return fmt.Errorf("invalid InfluxDB connection")
+	}
+	client.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Ping checks if the InfluxDB database is still reachable with the stored token
+func (d *InfluxDBDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*InfluxDBClient)
+	if !ok {
+		return fmt.Errorf("invalid InfluxDB connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("InfluxDBDriver -> Ping -> InfluxDB check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the InfluxDB connection is still valid
+func (d *InfluxDBDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("InfluxDBDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a SQL or InfluxQL read, a line protocol write or a measurement drop
+func (d *InfluxDBDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*InfluxDBClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeInfluxDBStatement(ctx, client, query)
+}
+
+// executeInfluxDBStatement runs a single statement. Writes are applied immediately:
+// InfluxDB 3 has no transactions, so there is nothing to roll back.
+func executeInfluxDBStatement(ctx context.Context, client *InfluxDBClient, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	statement, err := parseInfluxDBStatement(query)
+	if err != nil {
+		result.Error = &dtos.QueryError{
+			Message: err.Error(),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("InfluxDBDriver -> executeInfluxDBStatement -> Running %s statement on database %s", statement.Kind, client.database)
+
+	switch statement.Kind {
+	case influxDBStatementSQL, influxDBStatementInfluxQL:
+		rows, err := client.query(ctx, statement.Kind, statement.Query)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = map[string]interface{}{
+			"results": rows,
+		}
+
+	case influxDBStatementWrite:
+		if err := client.writeLineProtocol(ctx, statement.Query); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		points := strings.Count(statement.Query, "\n") + 1
+		result.RowsAffected = int64(points)
+		result.Result = map[string]interface{}{
+			"rowsAffected": points,
+			"message":      fmt.Sprintf("%d point(s) written", points),
+		}
+
+	case influxDBStatementDrop:
+		if err := client.dropMeasurement(ctx, statement.Query); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = map[string]interface{}{
+			"message": fmt.Sprintf("Measurement %s deleted", statement.Query),
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// BeginTx returns a transaction that executes statements immediately.
+// InfluxDB 3 has no transactions, every write is persisted as soon as the API accepts it.
+func (d *InfluxDBDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*InfluxDBClient)
+	if !ok {
+		log.Printf("InfluxDBDriver.BeginTx: Invalid InfluxDB connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &InfluxDBTransaction{
+		client: client,
+	}
+}
+
+// InfluxDBTransaction implements the Transaction interface for InfluxDB in autocommit mode
+type InfluxDBTransaction struct {
+	client *InfluxDBClient
+}
+
+// ExecuteQuery executes a statement. Writes are applied as soon as the API accepts them.
+func (t *InfluxDBTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeInfluxDBStatement(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as statements are already applied
+func (t *InfluxDBTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo writes that already reached InfluxDB
+func (t *InfluxDBTransaction) Rollback() error {
+	log.Printf("InfluxDBTransaction -> Rollback -> InfluxDB has no transactions, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the measurements of the database with their tags and fields
+func (d *InfluxDBDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("InfluxDBDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewInfluxDBSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a measurement
+func (d *InfluxDBDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("InfluxDBDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewInfluxDBSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches recent example points from a measurement
+func (d *InfluxDBDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("InfluxDBDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewInfluxDBSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// InfluxDBExecutor implements the DBExecutor interface for InfluxDB
+type InfluxDBExecutor struct {
+	client *InfluxDBClient
+	conn   *Connection
+}
+
+// NewInfluxDBExecutor creates a new InfluxDB executor
+func NewInfluxDBExecutor(conn *Connection) (*InfluxDBExecutor, error) {
+	client, ok := conn.APIClient.(*InfluxDBClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid InfluxDB connection")
+	}
+
+	return &InfluxDBExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for InfluxDB as it doesn't use GORM
+func (e *InfluxDBExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *InfluxDBExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes a statement with the request timeout
+func (e *InfluxDBExecutor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.InfluxDBRequestTimeout)
+	defer cancel()
+	return executeInfluxDBStatement(ctx, e.client, query)
+}
+
+// Raw executes an InfluxDB statement, *Not Used By DBManager*
+func (e *InfluxDBExecutor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute InfluxDB statement: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes an InfluxDB statement, *Not Used By DBManager*
+func (e *InfluxDBExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes an InfluxDB read and stores the rows in dest
+func (e *InfluxDBExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes an InfluxDB read and stores the rows in dest
+func (e *InfluxDBExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	result := e.run(query)
+	if result.Error != nil {
+		return fmt.Errorf("failed to execute InfluxDB statement: %v", result.Error.Message)
+	}
+	resultMap, ok := result.Result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("InfluxDB statement did not return rows")
+	}
+	rows, ok := resultMap["results"].([]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("InfluxDB statement did not return rows")
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the HTTP client is released by the driver on disconnect
+func (e *InfluxDBExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the InfluxDB schema
+func (e *InfluxDBExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &InfluxDBDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for an InfluxDB measurement
+func (e *InfluxDBExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &InfluxDBDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
@@ -0,0 +1,273 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// influxDBTimeColumn is present in every measurement
+	influxDBTimeColumn = "time"
+	// influxDBMeasurementKey is the column InfluxQL results name their measurement in
+	influxDBMeasurementKey = "iox::measurement"
+	// Column kinds, stored in ColumnInfo.Comment so the simplifier and the LLM can tell them apart
+	influxDBTagComment   = "tag"
+	influxDBFieldComment = "field"
+)
+
+// InfluxDBSchemaFetcher implements schema fetching for InfluxDB 3 with InfluxQL SHOW statements
+type InfluxDBSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewInfluxDBSchemaFetcher creates a new InfluxDB schema fetcher
+func NewInfluxDBSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &InfluxDBSchemaFetcher{db: db}
+}
+
+// client returns the HTTP client of the executor
+func (f *InfluxDBSchemaFetcher) client(db DBExecutor) (*InfluxDBClient, error) {
+	executor, ok := db.(*InfluxDBExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid InfluxDB connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema enumerates the measurements with SHOW MEASUREMENTS, and their tags and field types
+// with SHOW TAG KEYS and SHOW FIELD KEYS. Every measurement also gets the time column.
+func (f *InfluxDBSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("InfluxDBSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements, err := client.query(ctx, influxDBStatementInfluxQL, "SHOW MEASUREMENTS")
+	if err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetSchema -> Error fetching measurements: %v", err)
+		return nil, fmt.Errorf("failed to fetch measurements: %v", err)
+	}
+
+	// Without FROM, SHOW FIELD KEYS and SHOW TAG KEYS cover every measurement in one request each
+	fieldRows, err := client.query(ctx, influxDBStatementInfluxQL, "SHOW FIELD KEYS")
+	if err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetSchema -> Error fetching field keys: %v", err)
+		return nil, fmt.Errorf("failed to fetch field keys: %v", err)
+	}
+	tagRows, err := client.query(ctx, influxDBStatementInfluxQL, "SHOW TAG KEYS")
+	if err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetSchema -> Error fetching tag keys: %v", err)
+		return nil, fmt.Errorf("failed to fetch tag keys: %v", err)
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, row := range measurements {
+		name := influxDBRowString(row, "name")
+		if name == "" || (filterTables && !selected[name]) {
+			continue
+		}
+		schema.Tables[name] = newInfluxDBTableSchema(name)
+	}
+
+	for _, row := range tagRows {
+		table, ok := schema.Tables[influxDBRowString(row, influxDBMeasurementKey)]
+		tagKey := influxDBRowString(row, "tagKey")
+		if !ok || tagKey == "" {
+			continue
+		}
+		table.Columns[tagKey] = ColumnInfo{
+			Name:       tagKey,
+			Type:       "tag",
+			IsNullable: true,
+			Comment:    influxDBTagComment,
+		}
+	}
+
+	for _, row := range fieldRows {
+		table, ok := schema.Tables[influxDBRowString(row, influxDBMeasurementKey)]
+		fieldKey := influxDBRowString(row, "fieldKey")
+		if !ok || fieldKey == "" {
+			continue
+		}
+		table.Columns[fieldKey] = ColumnInfo{
+			Name:       fieldKey,
+			Type:       influxDBRowString(row, "fieldType"),
+			IsNullable: true,
+			Comment:    influxDBFieldComment,
+		}
+	}
+
+	for name, table := range schema.Tables {
+		tableData, _ := json.Marshal(table)
+		table.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[name] = table
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("InfluxDBSchemaFetcher -> GetSchema -> Fetched %d measurements", len(schema.Tables))
+	return schema, nil
+}
+
+// newInfluxDBTableSchema creates a measurement with its time column.
+// InfluxDB has no keys or constraints, and counting rows would scan every file, so RowCount is left at 0.
+func newInfluxDBTableSchema(name string) TableSchema {
+	return TableSchema{
+		Name: name,
+		Columns: map[string]ColumnInfo{
+			influxDBTimeColumn: {
+				Name:    influxDBTimeColumn,
+				Type:    "timestamp",
+				Comment: "timestamp of the point",
+			},
+		},
+		Indexes:     make(map[string]IndexInfo),
+		ForeignKeys: make(map[string]ForeignKey),
+		Constraints: make(map[string]ConstraintInfo),
+		Comment:     "measurement",
+	}
+}
+
+// influxDBRowString returns a string column of an InfluxQL result row
+func influxDBRowString(row map[string]interface{}, key string) string {
+	value, ok := row[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// GetTableChecksum calculates a checksum for a measurement's tag and field definitions
+func (f *InfluxDBSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	from := fmt.Sprintf(" FROM %s", quoteInfluxDBIdentifier(table))
+	fieldRows, err := client.query(ctx, influxDBStatementInfluxQL, "SHOW FIELD KEYS"+from)
+	if err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetTableChecksum -> Error fetching field keys: %v", err)
+		return "", fmt.Errorf("failed to get measurement definition: %v", err)
+	}
+	tagRows, err := client.query(ctx, influxDBStatementInfluxQL, "SHOW TAG KEYS"+from)
+	if err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> GetTableChecksum -> Error fetching tag keys: %v", err)
+		return "", fmt.Errorf("failed to get measurement definition: %v", err)
+	}
+	if len(fieldRows) == 0 && len(tagRows) == 0 {
+		return "", fmt.Errorf("no measurement definition found for measurement: %s", table)
+	}
+
+	definitions := make([]string, 0, len(fieldRows)+len(tagRows))
+	for _, row := range fieldRows {
+		definitions = append(definitions, fmt.Sprintf("field:%s:%s;", influxDBRowString(row, "fieldKey"), influxDBRowString(row, "fieldType")))
+	}
+	for _, row := range tagRows {
+		definitions = append(definitions, fmt.Sprintf("tag:%s;", influxDBRowString(row, "tagKey")))
+	}
+	sort.Strings(definitions)
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(definitions, "")))), nil
+}
+
+// FetchExampleRecords retrieves the latest points of a measurement, limited to recent data
+func (f *InfluxDBSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE time > now() - INTERVAL '%s' ORDER BY time DESC LIMIT %d",
+		quoteInfluxDBIdentifier(table), constants.InfluxDBExampleRecordsWindow, limit)
+	records, err := client.query(ctx, influxDBStatementSQL, query)
+	if err != nil {
+		log.Printf("InfluxDBSchemaFetcher -> FetchExampleRecords -> Error fetching points from measurement %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for measurement %s: %v", table, err)
+	}
+	return records, nil
+}
+
+// quoteInfluxDBIdentifier double-quotes a measurement name for SQL and InfluxQL
+func quoteInfluxDBIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+}
+
+// InfluxDBSimplifier implements SchemaSimplifier for InfluxDB column types
+type InfluxDBSimplifier struct{}
+
+// SimplifyDataType maps InfluxDB field types to readable type names
+func (s *InfluxDBSimplifier) SimplifyDataType(dbType string) string {
+	switch strings.ToLower(dbType) {
+	case "float", "integer", "unsigned":
+		return "number"
+	case "string", "tag":
+		return "text"
+	case "boolean":
+		return "boolean"
+	case "timestamp":
+		return "timestamp"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints marks the time column and tells tags from fields
+func (s *InfluxDBSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	switch {
+	case col.Name == influxDBTimeColumn && col.Type == "timestamp":
+		constraints = append(constraints, "TIME")
+	case col.Comment == influxDBTagComment:
+		constraints = append(constraints, "TAG")
+	case col.Comment == influxDBFieldComment:
+		constraints = append(constraints, "FIELD")
+	}
+
+	return constraints
+}
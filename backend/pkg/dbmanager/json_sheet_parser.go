@@ -0,0 +1,177 @@
+package dbmanager
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"neobase-ai/internal/constants"
+	"strings"
+)
+
+// ParseJSONRecords reads a JSON array of objects, or JSON Lines with one object per line, into rows like a
+// CSV file's: the first row holds the column names, every key seen in any record in the order first seen.
+// Nested objects are flattened into dot-notation columns (address.city), arrays are kept as JSON strings and
+// missing keys and nulls become empty cells.
+func ParseJSONRecords(reader io.Reader, format string) ([][]interface{}, error) {
+	buffered := bufio.NewReader(reader)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		buffered.Discard(3)
+	}
+	decoder := json.NewDecoder(buffered)
+	decoder.UseNumber()
+
+	var records []map[string]string
+	var columns []string
+	seen := make(map[string]bool)
+	addRecord := func(raw json.RawMessage, index int) error {
+		record := make(map[string]string)
+		if err := flattenJSONRecord("", raw, record, &columns, seen); err != nil {
+			return fmt.Errorf("record %d: %v", index+1, err)
+		}
+		records = append(records, record)
+		return nil
+	}
+
+	switch format {
+	case constants.SpreadsheetFormatJSON:
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSON: %w", err)
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("a JSON file must hold an array of objects")
+		}
+		for decoder.More() {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("failed to read record %d: %w", len(records)+1, err)
+			}
+			if err := addRecord(raw, len(records)); err != nil {
+				return nil, err
+			}
+		}
+
+	case constants.SpreadsheetFormatJSONLines:
+		// The decoder reads one value after the other, so objects spread over several lines are read too
+		for {
+			var raw json.RawMessage
+			err := decoder.Decode(&raw)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read line %d: %w", len(records)+1, err)
+			}
+			if err := addRecord(raw, len(records)); err != nil {
+				return nil, err
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported JSON format: %s", format)
+	}
+
+	if len(records) == 0 || len(columns) == 0 {
+		return nil, fmt.Errorf("JSON file has no records")
+	}
+
+	rows := make([][]interface{}, 0, len(records)+1)
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	rows = append(rows, header)
+	for _, record := range records {
+		row := make([]interface{}, len(columns))
+		for i, column := range columns {
+			row[i] = record[column]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// flattenJSONRecord adds the fields of a JSON object to record, reading its keys in order so the columns keep
+// the order of the file
+func flattenJSONRecord(prefix string, raw json.RawMessage, record map[string]string, columns *[]string, seen map[string]bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object, got %s", jsonValueKind(raw))
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		if jsonValueKind(value) == "object" {
+			if err := flattenJSONRecord(key, value, record, columns, seen); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !seen[key] {
+			seen[key] = true
+			*columns = append(*columns, key)
+		}
+		record[key] = jsonCellValue(value)
+	}
+	return nil
+}
+
+// jsonCellValue returns a JSON value as a cell: strings unquoted, numbers as written, arrays as compact JSON
+func jsonCellValue(raw json.RawMessage) string {
+	switch jsonValueKind(raw) {
+	case "null":
+		return ""
+	case "string":
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			return value
+		}
+	case "array":
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, raw); err == nil {
+			return compacted.String()
+		}
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+func jsonValueKind(raw json.RawMessage) string {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return "empty"
+	}
+	switch trimmed[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 'n':
+		return "null"
+	case 't', 'f':
+		return "boolean"
+	}
+	return "number"
+}
@@ -42,6 +42,7 @@ type DatabasePool struct {
 	LastUsed   time.Time
 	Mutex      sync.Mutex // For thread-safe reference counting
 	MongoDBObj interface{}
+	APIClient  interface{}
 }
 
 // Manager handles database connections
@@ -66,8 +67,12 @@ type Manager struct {
 		totalConnections int
 		reuseCount       int
 	}
-	spreadsheetInternalConn *Connection // Shared PostgreSQL connection for spreadsheet operations
-	spreadsheetConnMu       sync.Mutex  // Mutex for spreadsheet connection
+	spreadsheetInternalConn *Connection                   // Shared PostgreSQL connection for spreadsheet operations
+	spreadsheetConnMu       sync.Mutex                    // Mutex for spreadsheet connection
+	poolManager             *ConnectionPoolManager        // Sizes the database/sql pools in dbPools
+	mongoWatchers           map[string]context.CancelFunc // chatID -> stops the chat's MongoDB change stream
+	mongoWatchersMu         sync.Mutex
+	externalSchemaProvider  ExternalSchemaProvider // Uploaded schema files merged into the LLM schema, may be nil
 }
 
 // NewManager creates a new connection manager
@@ -88,6 +93,8 @@ func NewManager(redisRepo redis.IRedisRepositories, encryptionKey string) (*Mana
 		executionMu:      sync.RWMutex{},
 		fetchers:         make(map[string]FetcherFactory),
 		dbPools:          make(map[string]*DatabasePool),
+		poolManager:      NewConnectionPoolManager(config.Env.DBPoolMin, config.Env.DBPoolMax, time.Duration(config.Env.DBPoolIdleTimeout)*time.Second),
+		mongoWatchers:    make(map[string]context.CancelFunc),
 	}
 
 	// Set the DBManager in the SchemaManager
@@ -105,6 +112,14 @@ func NewManager(redisRepo redis.IRedisRepositories, encryptionKey string) (*Mana
 		m.startCleanupRoutine()
 	}()
 
+	// Check connected chats can still run queries, the results are kept on each connection
+	go m.startHealthMonitor()
+
+	// Poll connected chats for schema changes made outside of NeoBase
+	if config.Env.SchemaAutoRefreshEnabled && config.Env.SchemaPollInterval > 0 {
+		go m.startSchemaPoller()
+	}
+
 	// Register default fetchers
 	m.RegisterFetcher("postgresql", func(db DBExecutor) SchemaFetcher {
 		return &PostgresDriver{}
@@ -119,6 +134,16 @@ func NewManager(redisRepo redis.IRedisRepositories, encryptionKey string) (*Mana
 		return &PostgresDriver{}
 	})
 
+	// Supabase is hosted PostgreSQL — its fetcher also lists storage buckets
+	m.RegisterFetcher("supabase", func(db DBExecutor) SchemaFetcher {
+		return &SupabaseDriver{}
+	})
+
+	// Neon is serverless PostgreSQL — reuse PostgreSQL schema fetcher
+	m.RegisterFetcher("neon", func(db DBExecutor) SchemaFetcher {
+		return &PostgresDriver{}
+	})
+
 	// Add MySQL schema fetcher registration
 	m.RegisterFetcher("mysql", func(db DBExecutor) SchemaFetcher {
 		return NewMySQLSchemaFetcher(db)
@@ -129,15 +154,75 @@ func NewManager(redisRepo redis.IRedisRepositories, encryptionKey string) (*Mana
 		return NewMySQLSchemaFetcher(db)
 	})
 
+	// PlanetScale is MySQL-compatible (Vitess) — reuse MySQL schema fetcher
+	m.RegisterFetcher("planetscale", func(db DBExecutor) SchemaFetcher {
+		return NewMySQLSchemaFetcher(db)
+	})
+
 	// Add ClickHouse schema fetcher registration
 	m.RegisterFetcher("clickhouse", func(db DBExecutor) SchemaFetcher {
 		return NewClickHouseSchemaFetcher(db)
 	})
 
+	// Trino schema fetcher (reads information_schema of the session catalog and schema)
+	m.RegisterFetcher("trino", func(db DBExecutor) SchemaFetcher {
+		return NewTrinoSchemaFetcher(db)
+	})
+
+	// Oracle schema fetcher (reads the ALL_* data dictionary views of the connecting user's schema)
+	m.RegisterFetcher("oracle", func(db DBExecutor) SchemaFetcher {
+		return NewOracleSchemaFetcher(db)
+	})
+
 	m.RegisterFetcher("mongodb", func(db DBExecutor) SchemaFetcher {
 		return NewMongoDBSchemaFetcher(db)
 	})
 
+	// FerretDB schema fetcher (MongoDB-compatible)
+	m.RegisterFetcher("ferretdb", func(db DBExecutor) SchemaFetcher {
+		return NewMongoDBSchemaFetcher(db)
+	})
+
+	// Airtable schema fetcher (reads tables and fields from the Meta API)
+	m.RegisterFetcher("airtable", func(db DBExecutor) SchemaFetcher {
+		return NewAirtableSchemaFetcher(db)
+	})
+
+	// InfluxDB schema fetcher (reads measurements, tags and fields with InfluxQL SHOW statements)
+	m.RegisterFetcher("influxdb", func(db DBExecutor) SchemaFetcher {
+		return NewInfluxDBSchemaFetcher(db)
+	})
+
+	// Temporal schema fetcher (lists workflow types and namespaces from the visibility store)
+	m.RegisterFetcher("temporal", func(db DBExecutor) SchemaFetcher {
+		return NewTemporalSchemaFetcher(db)
+	})
+
+	// PocketBase schema fetcher (reads collections and their fields from the collections API)
+	m.RegisterFetcher("pocketbase", func(db DBExecutor) SchemaFetcher {
+		return NewPocketBaseSchemaFetcher(db)
+	})
+
+	// NATS schema fetcher (lists JetStream streams, every stream has the same message columns)
+	m.RegisterFetcher("nats", func(db DBExecutor) SchemaFetcher {
+		return NewNATSSchemaFetcher(db)
+	})
+
+	// YugabyteDB YCQL schema fetcher (reads tables and columns of the keyspace from system_schema)
+	m.RegisterFetcher(constants.DatabaseTypeYugabyteDBCQL, func(db DBExecutor) SchemaFetcher {
+		return NewYugabyteDBCQLSchemaFetcher(db)
+	})
+
+	// Firestore schema fetcher (lists top-level collections and infers their fields from sampled documents)
+	m.RegisterFetcher(constants.DatabaseTypeFirestore, func(db DBExecutor) SchemaFetcher {
+		return NewFirestoreSchemaFetcher(db)
+	})
+
+	// Cloudflare D1 schema fetcher (reads tables from sqlite_master and columns with pragma_table_info)
+	m.RegisterFetcher(constants.DatabaseTypeCloudflareD1, func(db DBExecutor) SchemaFetcher {
+		return NewCloudflareD1SchemaFetcher(db)
+	})
+
 	// Add Google Sheets schema fetcher registration
 	m.RegisterFetcher("google_sheets", func(db DBExecutor) SchemaFetcher {
 		return &SpreadsheetDriver{
@@ -226,18 +311,60 @@ func (m *Manager) registerDefaultDrivers() {
 	// Register TimescaleDB driver (PostgreSQL extension — uses PostgreSQL driver)
 	m.RegisterDriver("timescaledb", NewPostgresDriver())
 
+	// Register Supabase driver (PostgreSQL driver with storage bucket discovery)
+	m.RegisterDriver("supabase", NewSupabaseDriver())
+
+	// Register Neon driver (PostgreSQL driver with a pgxpool and connect retries)
+	m.RegisterDriver("neon", NewNeonDriver())
+
 	// Register MySQL driver
 	m.RegisterDriver("mysql", NewMySQLDriver())
 
 	// Register StarRocks driver (MySQL-wire-compatible — uses MySQL driver)
 	m.RegisterDriver("starrocks", NewMySQLDriver())
 
+	// Register PlanetScale driver (MySQL-compatible — uses MySQL driver with the branch in the DSN)
+	m.RegisterDriver("planetscale", NewMySQLDriver())
+
 	// Register ClickHouse driver
 	m.RegisterDriver("clickhouse", NewClickHouseDriver())
 
+	// Register Trino driver
+	m.RegisterDriver("trino", NewTrinoDriver())
+
+	// Register Oracle driver
+	m.RegisterDriver("oracle", NewOracleDriver())
+
+	// Register Airtable driver
+	m.RegisterDriver("airtable", NewAirtableDriver())
+
+	// Register InfluxDB driver
+	m.RegisterDriver("influxdb", NewInfluxDBDriver())
+
+	// Register Temporal driver
+	m.RegisterDriver("temporal", NewTemporalDriver())
+
+	// Register PocketBase driver
+	m.RegisterDriver("pocketbase", NewPocketBaseDriver())
+
+	// Register NATS JetStream driver
+	m.RegisterDriver("nats", NewNATSDriver())
+
+	// Register YugabyteDB YCQL driver (Cassandra-compatible API, queried with gocql)
+	m.RegisterDriver(constants.DatabaseTypeYugabyteDBCQL, NewYugabyteDBCQLDriver())
+
+	// Register Firestore driver (queried with the Firestore SDK)
+	m.RegisterDriver(constants.DatabaseTypeFirestore, NewFirestoreDriver())
+
+	// Register Cloudflare D1 driver (SQLite queried over the Cloudflare API)
+	m.RegisterDriver(constants.DatabaseTypeCloudflareD1, NewCloudflareD1Driver())
+
 	// Register MongoDB driver
 	m.RegisterDriver("mongodb", NewMongoDBDriver())
 
+	// Register FerretDB driver (MongoDB wire protocol)
+	m.RegisterDriver("ferretdb", NewMongoDBDriver())
+
 	// Register MongoDB schema fetcher
 	m.RegisterFetcher("mongodb", func(db DBExecutor) SchemaFetcher {
 		return NewMongoDBSchemaFetcher(db)
@@ -269,6 +396,11 @@ func (m *Manager) GetPoolMetrics() map[string]interface{} {
 	}
 }
 
+// GetPoolStats returns the size and usage of the database/sql connection pools
+func (m *Manager) GetPoolStats() *PoolStatsResponse {
+	return m.poolManager.Stats()
+}
+
 // GetRedisRepo returns the Redis repository
 func (m *Manager) GetRedisRepo() redis.IRedisRepositories {
 	return m.redisRepo
@@ -375,14 +507,22 @@ func (m *Manager) Connect(chatID, userID, streamID string, config ConnectionConf
 		}
 
 		// Set MongoDBObj for MongoDB connections when reusing from pool
-		if config.Type == "mongodb" && pool.MongoDBObj != nil {
+		if isMongoDBCompatible(config.Type) && pool.MongoDBObj != nil {
 			conn.MongoDBObj = pool.MongoDBObj
 			log.Printf("DBManager -> Connect -> Set MongoDBObj from pool for MongoDB connection")
 		}
 
+		// REST API backed connections share the API client of the pool
+		if pool.APIClient != nil {
+			conn.APIClient = pool.APIClient
+		}
+
 		// Update metrics
 		m.poolMetrics.reuseCount++
 
+		// Another chat shares the pool, give it room for the extra load
+		m.poolManager.Adjust(configKey, pool.RefCount)
+
 		// For spreadsheet connections from pool, ensure schema exists
 		if config.Type == "spreadsheet" && chatID != "" {
 			schemaName := fmt.Sprintf("conn_%s", chatID)
@@ -405,7 +545,7 @@ func (m *Manager) Connect(chatID, userID, streamID string, config ConnectionConf
 		log.Printf("DBManager -> Connect -> Driver connection successful, creating new pool")
 		// Create and store the new pool
 		newPool := &DatabasePool{
-			DB:       nil, // The driver doesn't expose sql.DB directly
+			DB:       nil, // Set below for database/sql based drivers
 			GORMDB:   conn.DB,
 			RefCount: 1,
 			Config:   config,
@@ -413,9 +553,19 @@ func (m *Manager) Connect(chatID, userID, streamID string, config ConnectionConf
 		}
 
 		// For MongoDB, store the MongoDB client in the pool
-		if config.Type == "mongodb" {
+		if isMongoDBCompatible(config.Type) {
 			newPool.MongoDBObj = conn.MongoDBObj
 		}
+		newPool.APIClient = conn.APIClient
+
+		// database/sql pools are sized by the pool manager. Spreadsheet connections share
+		// the internal PostgreSQL pool, so they are left as the driver configured them.
+		if conn.DB != nil && config.Type != "spreadsheet" && config.Type != "google_sheets" {
+			if sqlDB, err := conn.DB.DB(); err == nil {
+				newPool.DB = sqlDB
+				m.poolManager.Register(configKey, config.Type, sqlDB)
+			}
+		}
 
 		m.dbPoolsMu.Lock()
 		m.dbPools[configKey] = newPool
@@ -445,6 +595,7 @@ func (m *Manager) Connect(chatID, userID, streamID string, config ConnectionConf
 				m.dbPoolsMu.Lock()
 				delete(m.dbPools, configKey)
 				m.dbPoolsMu.Unlock()
+				m.poolManager.Unregister(configKey)
 			}
 			return fmt.Errorf("failed to create schema: %w", err)
 		}
@@ -497,6 +648,9 @@ func (m *Manager) Connect(chatID, userID, streamID string, config ConnectionConf
 		m.StartSchemaTracking(chatID)
 	}()
 
+	// Watch MongoDB inserts for new fields, when change streams are enabled
+	m.startMongoWatcher(chatID, conn)
+
 	conn.OnSchemaChange = func(chatID string) {
 		m.doSchemaCheck(chatID)
 	}
@@ -688,7 +842,11 @@ func (m *Manager) disconnectInternal(chatID, userID string, deleteSchema bool, c
 
 			// Remove from pool
 			delete(m.dbPools, configKey)
+			m.poolManager.Unregister(configKey)
 			log.Printf("DBManager -> disconnectInternal -> Removed pool from dbPools map")
+		} else {
+			// Fewer chats share the pool, let it shrink back towards their share
+			m.poolManager.Adjust(configKey, refCount)
 		}
 	}
 	m.dbPoolsMu.Unlock()
@@ -698,6 +856,8 @@ func (m *Manager) disconnectInternal(chatID, userID string, deleteSchema bool, c
 	delete(m.connections, chatID)
 	m.mu.Unlock()
 
+	m.stopMongoWatcher(chatID)
+
 	log.Printf("DBManager -> disconnectInternal -> Removed connection from connections map")
 
 	// Delete schema if requested
@@ -751,10 +911,12 @@ func (m *Manager) GetConnection(chatID string) (DBExecutor, error) {
 
 	// Also update the pool's LastUsed
 	if conn.ConfigKey != "" {
+		activeChats := 0
 		m.dbPoolsMu.RLock()
 		if pool, exists := m.dbPools[conn.ConfigKey]; exists {
 			pool.Mutex.Lock()
 			pool.LastUsed = time.Now()
+			activeChats = pool.RefCount
 
 			// Verify database consistency
 			if pool.Config.Database != conn.Config.Database {
@@ -765,21 +927,36 @@ func (m *Manager) GetConnection(chatID string) (DBExecutor, error) {
 			pool.Mutex.Unlock()
 		}
 		m.dbPoolsMu.RUnlock()
+
+		// Grow the pool before it runs out, and report an exhausted pool instead of letting the query hang
+		m.poolManager.Adjust(conn.ConfigKey, activeChats)
+		if err := m.poolManager.CheckCapacity(conn.ConfigKey); err != nil {
+			log.Printf("DBManager -> GetConnection -> %v", err)
+			return nil, err
+		}
 	}
 
 	// Log connection details for debugging
 	log.Printf("DBManager -> GetConnection -> Returning connection for chatID: %s, database: %s",
 		chatID, conn.Config.Database)
 
-	// Create appropriate wrapper based on database type
+	return m.newExecutor(conn, chatID)
+}
+
+// newExecutor creates the DBExecutor wrapper of a connection based on its database type
+func (m *Manager) newExecutor(conn *Connection, chatID string) (DBExecutor, error) {
 	switch conn.Config.Type {
-	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB:
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB, constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
 		return NewPostgresWrapper(conn.DB, m, chatID), nil
-	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks:
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale:
 		return NewMySQLWrapper(conn.DB, m, chatID), nil
 	case constants.DatabaseTypeClickhouse:
 		return NewClickHouseWrapper(conn.DB, m, chatID), nil
-	case constants.DatabaseTypeMongoDB:
+	case constants.DatabaseTypeTrino:
+		return NewTrinoWrapper(conn.DB, m, chatID), nil
+	case constants.DatabaseTypeOracle:
+		return NewOracleWrapper(conn.DB, m, chatID), nil
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
 		// For MongoDB, we use the MongoDBObj field instead of DB
 		_, ok := conn.MongoDBObj.(*MongoDBWrapper)
 		if !ok {
@@ -790,6 +967,62 @@ func (m *Manager) GetConnection(chatID string) (DBExecutor, error) {
 			return nil, fmt.Errorf("failed to create MongoDB executor: %v", err)
 		}
 		return executor, nil
+	case constants.DatabaseTypeAirtable:
+		// Airtable is a REST API, the client is stored in the APIClient field
+		executor, err := NewAirtableExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Airtable executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypeInfluxDB:
+		// InfluxDB 3 is queried over HTTP, the client is stored in the APIClient field
+		executor, err := NewInfluxDBExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create InfluxDB executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypeTemporal:
+		// Temporal is queried over gRPC, the SDK client is stored in the APIClient field
+		executor, err := NewTemporalExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Temporal executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypePocketBase:
+		// PocketBase is a REST API, the client is stored in the APIClient field
+		executor, err := NewPocketBaseExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create PocketBase executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypeNATS:
+		// NATS is queried with the JetStream API, the client is stored in the APIClient field
+		executor, err := NewNATSExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create NATS executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypeYugabyteDBCQL:
+		// YCQL is queried with gocql, the client holding the session is stored in the APIClient field
+		executor, err := NewYugabyteDBCQLExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create YugabyteDB YCQL executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypeFirestore:
+		// Firestore is queried with the Firestore SDK, the client is stored in the APIClient field
+		executor, err := NewFirestoreExecutor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Firestore executor: %v", err)
+		}
+		return executor, nil
+	case constants.DatabaseTypeCloudflareD1:
+		// D1 is queried over the Cloudflare API, the client is stored in the APIClient field
+		executor, err := NewCloudflareD1Executor(conn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloudflare D1 executor: %v", err)
+		}
+		return executor, nil
 	case "spreadsheet", constants.DatabaseTypeGoogleSheets:
 		// For Spreadsheet and Google Sheets, we need to create a wrapper that includes the schema name
 		wrapper := &spreadsheetSchemaWrapper{
@@ -879,6 +1112,14 @@ func (m *Manager) Stop() error {
 	close(m.stopCleanup)
 	log.Println("DBManager -> Stop -> Signaled cleanup routine to stop")
 
+	// Stop MongoDB change streams before their clients are disconnected
+	m.mongoWatchersMu.Lock()
+	for chatID, cancel := range m.mongoWatchers {
+		cancel()
+		delete(m.mongoWatchers, chatID)
+	}
+	m.mongoWatchersMu.Unlock()
+
 	// Close all connections
 	m.mu.Lock()
 	for chatID, conn := range m.connections {
@@ -1105,22 +1346,7 @@ func (m *Manager) doSchemaCheck(chatID string) error {
 	}
 
 	// Get selected collections from the chat service if available
-	var selectedTables []string
-	if m.streamHandler != nil {
-		// Try to get selected collections from the chat service
-		selectedCollections, err := m.streamHandler.GetSelectedCollections(chatID)
-		if err == nil && selectedCollections != "ALL" && selectedCollections != "" {
-			selectedTables = strings.Split(selectedCollections, ",")
-			log.Printf("DBManager -> doSchemaCheck -> Using selected collections for chat %s: %v", chatID, selectedTables)
-		} else {
-			// Default to ALL if there's an error or no specific collections
-			selectedTables = []string{"ALL"}
-			log.Printf("DBManager -> doSchemaCheck -> Using ALL tables for chat %s", chatID)
-		}
-	} else {
-		// Default to ALL if stream handler is not available
-		selectedTables = []string{"ALL"}
-	}
+	selectedTables := m.getSelectedTables(chatID)
 
 	// Force clear any cached schema to ensure we get fresh data
 	m.schemaManager.ClearSchemaCache(chatID)
@@ -1161,6 +1387,26 @@ func (m *Manager) doSchemaCheck(chatID string) error {
 	return nil
 }
 
+// getSelectedTables returns the tables selected for a chat, defaulting to ALL
+func (m *Manager) getSelectedTables(chatID string) []string {
+	if m.streamHandler == nil {
+		// Default to ALL if stream handler is not available
+		return []string{"ALL"}
+	}
+
+	// Try to get selected collections from the chat service
+	selectedCollections, err := m.streamHandler.GetSelectedCollections(chatID)
+	if err == nil && selectedCollections != "ALL" && selectedCollections != "" {
+		selectedTables := strings.Split(selectedCollections, ",")
+		log.Printf("DBManager -> getSelectedTables -> Using selected collections for chat %s: %v", chatID, selectedTables)
+		return selectedTables
+	}
+
+	// Default to ALL if there's an error or no specific collections
+	log.Printf("DBManager -> getSelectedTables -> Using ALL tables for chat %s", chatID)
+	return []string{"ALL"}
+}
+
 // Add exported methods to access internal fields
 func (m *Manager) GetConnections() map[string]*Connection {
 	m.mu.RLock()
@@ -1172,6 +1418,28 @@ func (m *Manager) GetSchemaManager() *SchemaManager {
 	return m.schemaManager
 }
 
+// BulkInsertSpreadsheetRows loads rows into a table of a spreadsheet chat through the spreadsheet driver
+func (m *Manager) BulkInsertSpreadsheetRows(ctx context.Context, chatID, schemaName, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	m.mu.RLock()
+	conn, exists := m.connections[chatID]
+	driver, driverExists := m.drivers[constants.DatabaseTypeSpreadsheet]
+	m.mu.RUnlock()
+
+	if !exists || conn.DB == nil {
+		return 0, fmt.Errorf("connection not found for chat %s", chatID)
+	}
+	spreadsheetDriver, ok := driver.(*SpreadsheetDriver)
+	if !driverExists || !ok {
+		return 0, fmt.Errorf("spreadsheet driver not registered")
+	}
+
+	sqlDB, err := conn.DB.DB()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get SQL DB: %v", err)
+	}
+	return spreadsheetDriver.BulkInsert(ctx, sqlDB, schemaName, tableName, columns, rows)
+}
+
 func (m *Manager) GetConnectionInfo(chatID string) (*ConnectionInfo, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -1183,7 +1451,8 @@ func (m *Manager) GetConnectionInfo(chatID string) (*ConnectionInfo, bool) {
 
 	// Convert Connection to ConnectionInfo
 	connInfo := &ConnectionInfo{
-		Config: conn.Config,
+		Config:          conn.Config,
+		LastHealthCheck: conn.LastHealthCheck,
 	}
 
 	// Get the underlying *sql.DB from gorm.DB
@@ -1197,6 +1466,16 @@ func (m *Manager) GetConnectionInfo(chatID string) (*ConnectionInfo, bool) {
 	return connInfo, true
 }
 
+// SetParallelWorkersDisabled changes the parallel workers setting of a chat's open connection, so a settings
+// change applies without reconnecting
+func (m *Manager) SetParallelWorkersDisabled(chatID string, disabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if conn, exists := m.connections[chatID]; exists {
+		conn.Config.DisableParallelWorkers = disabled
+	}
+}
+
 // IsConnected checks if there is an active connection for the given chat
 func (m *Manager) IsConnected(chatID string) bool {
 	m.mu.RLock()
@@ -1207,37 +1486,102 @@ func (m *Manager) IsConnected(chatID string) bool {
 		return false
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return pingConnection(ctx, conn) == nil
+}
+
+// pingConnection checks that a connection still reaches its database, with the cheapest call of its type
+func pingConnection(ctx context.Context, conn *Connection) error {
 	// For MongoDB connections
-	if conn.Config.Type == "mongodb" {
+	if isMongoDBCompatible(conn.Config.Type) {
 		if wrapper, ok := conn.MongoDBObj.(*MongoDBWrapper); ok && wrapper != nil {
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-			defer cancel()
-			err := wrapper.Client.Ping(ctx, nil)
-			return err == nil
+			return wrapper.Client.Ping(ctx, nil)
 		}
-		return false
+		return fmt.Errorf("no MongoDB client")
+	}
+
+	// For Airtable connections, check the base with a Meta API call
+	if conn.Config.Type == constants.DatabaseTypeAirtable {
+		if client, ok := conn.APIClient.(*AirtableClient); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no Airtable client")
+	}
+
+	// For InfluxDB connections, run a trivial query on the database
+	if conn.Config.Type == constants.DatabaseTypeInfluxDB {
+		if client, ok := conn.APIClient.(*InfluxDBClient); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no InfluxDB client")
+	}
+
+	// For Temporal connections, describe the connection's namespace
+	if conn.Config.Type == constants.DatabaseTypeTemporal {
+		if client, ok := conn.APIClient.(*TemporalClient); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no Temporal client")
+	}
+
+	// For PocketBase connections, list the collections with the stored token
+	if conn.Config.Type == constants.DatabaseTypePocketBase {
+		if client, ok := conn.APIClient.(*PocketBaseClient); ok && client != nil {
+			_, err := client.listCollections(ctx)
+			return err
+		}
+		return fmt.Errorf("no PocketBase client")
+	}
+
+	// For NATS connections, check JetStream on the open connection
+	if conn.Config.Type == constants.DatabaseTypeNATS {
+		if client, ok := conn.APIClient.(*NATSClient); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no NATS client")
+	}
+
+	// For YugabyteDB YCQL connections, query the cluster on the open session
+	if conn.Config.Type == constants.DatabaseTypeYugabyteDBCQL {
+		if client, ok := conn.APIClient.(*YugabyteDBCQLClient); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no YugabyteDB YCQL client")
+	}
+
+	// For Firestore connections, list collections with the open client
+	if conn.Config.Type == constants.DatabaseTypeFirestore {
+		if client, ok := conn.APIClient.(*FirestoreClient); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no Firestore client")
+	}
+
+	// For Cloudflare D1 connections, run a trivial query on the database
+	if conn.Config.Type == constants.DatabaseTypeCloudflareD1 {
+		if client, ok := conn.APIClient.(*CloudflareD1Client); ok && client != nil {
+			return client.ping(ctx)
+		}
+		return fmt.Errorf("no Cloudflare D1 client")
 	}
 
 	// For SQL connections
 	if conn.DB != nil {
 		sqlDB, err := conn.DB.DB()
 		if err != nil {
-			return false
+			return err
 		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
-
-		err = sqlDB.PingContext(ctx)
-		return err == nil
+		return sqlDB.PingContext(ctx)
 	}
 
-	return false
+	return fmt.Errorf("no database client")
 }
 
 type ConnectionInfo struct {
-	DB     *sql.DB
-	Config ConnectionConfig
+	DB              *sql.DB
+	Config          ConnectionConfig
+	LastHealthCheck *HealthCheckResult // Latest result of the health monitor, nil until the first check
 }
 
 // SetStreamHandler sets the stream handler for database events
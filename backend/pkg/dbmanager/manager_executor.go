@@ -20,7 +20,6 @@ import (
 	"crypto/x509"
 	"neobase-ai/internal/apis/dtos"
 	"neobase-ai/internal/constants"
-	"neobase-ai/internal/utils"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -63,8 +62,14 @@ func (m *Manager) CancelQueryExecution(streamID string) {
 func (m *Manager) ExecuteQuery(ctx context.Context, chatID, messageID, queryID, streamID string, query string, queryType string, isRollback bool, findCount bool) (*QueryExecutionResult, *dtos.QueryError) {
 	m.executionMu.Lock()
 
-	// Create cancellable context with timeout
-	execCtx, cancel := context.WithTimeout(ctx, 1*time.Minute) // 1 minute timeout
+	// Create cancellable context with timeout, callers that set their own deadline (per-chat query timeout) keep it
+	var execCtx context.Context
+	var cancel context.CancelFunc
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		execCtx, cancel = context.WithCancel(ctx)
+	} else {
+		execCtx, cancel = context.WithTimeout(ctx, 1*time.Minute) // 1 minute timeout
+	}
 
 	// Track execution
 	execution := &QueryExecution{
@@ -107,6 +112,9 @@ func (m *Manager) ExecuteQuery(ctx context.Context, chatID, messageID, queryID,
 
 	log.Printf("Manager -> ExecuteQuery -> Driver: %v", driver)
 
+	// The LLM writes queries with the chat's schema aliases, map them back to the real names before validating
+	query = ResolveSchemaAliases(query, conn.Config.Type, conn.Config.SchemaAliases)
+
 	// Validate query safety before executing
 	if !isRollback { // Skip validation for rollback queries
 		validator := GetValidatorForDatabase(conn.Config.Type)
@@ -140,122 +148,194 @@ func (m *Manager) ExecuteQuery(ctx context.Context, chatID, messageID, queryID,
 		}
 	}
 
-	// Begin transaction
-	tx := driver.BeginTx(execCtx, conn)
-	if tx == nil {
-		return nil, &dtos.QueryError{
-			Code:    "FAILED_TO_START_TRANSACTION",
-			Message: "failed to start transaction",
-			Details: "Failed to start transaction",
+	// Cap read results so a single query cannot load millions of rows into memory.
+	// Only the executed query is capped, the stored query is left as the user wrote it.
+	truncateAt := 0
+	if !isRollback && !findCount {
+		maxResultRows := resolveMaxResultRows(conn)
+		if limitedQuery, capped := applyResultRowLimit(query, conn.Config.Type, maxResultRows); capped {
+			log.Printf("Manager -> ExecuteQuery -> Capping query results at %d rows", maxResultRows)
+			query = limitedQuery
+			truncateAt = maxResultRows
 		}
 	}
 
-	// Check if transaction has an error (MongoDB transaction might return a non-nil transaction with an error)
-	if mongoTx, ok := tx.(*MongoDBTransaction); ok && mongoTx.Error != nil {
-		log.Printf("Manager -> ExecuteQuery -> MongoDB transaction error: %v", mongoTx.Error)
-		return nil, &dtos.QueryError{
-			Code:    "FAILED_TO_START_TRANSACTION",
-			Message: "failed to start transaction",
-			Details: mongoTx.Error.Error(),
+	// SET LOCAL only lasts for the transaction the query runs in, pooled connections keep their defaults
+	if !isRollback && conn.Config.DisableParallelWorkers {
+		switch conn.Config.Type {
+		case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB, constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
+			query = "SET LOCAL max_parallel_workers_per_gather = 0;\n" + query
 		}
 	}
 
-	execution.Tx = tx
-
-	// Execute query with proper cancellation handling
+	// Transient connection errors (reset, timeout, EOF) are retried with exponential backoff,
+	// every attempt runs in a fresh transaction
+	retryPolicy := resolveRetryPolicy(conn)
+	var tx Transaction
 	var result *QueryExecutionResult
-	done := make(chan struct{})
 	var queryErr *dtos.QueryError
-
-	go func() {
-		defer close(done)
-		log.Printf("Manager -> ExecuteQuery -> Executing query: %v", query)
-		var err error
-		result, err = tx.ExecuteQuery(execCtx, query)
-		if err != nil {
-			log.Printf("Manager -> ExecuteQuery -> Error executing query: %v", err)
-			result = &QueryExecutionResult{
-				Error: &dtos.QueryError{
-					Message: err.Error(),
-					Code:    "EXECUTION_ERROR",
-				},
+	for attempt := 0; ; attempt++ {
+		// Begin transaction
+		tx = driver.BeginTx(execCtx, conn)
+		if tx == nil {
+			return nil, &dtos.QueryError{
+				Code:    "FAILED_TO_START_TRANSACTION",
+				Message: "failed to start transaction",
+				Details: "Failed to start transaction",
 			}
 		}
-		if result.Error != nil {
-			queryErr = result.Error
-		}
-	}()
 
-	select {
-	case <-execCtx.Done():
-		if err := tx.Rollback(); err != nil {
-			log.Printf("Error rolling back transaction: %v", err)
-		}
-		if execCtx.Err() == context.DeadlineExceeded {
+		// Check if transaction has an error (MongoDB transaction might return a non-nil transaction with an error)
+		if mongoTx, ok := tx.(*MongoDBTransaction); ok && mongoTx.Error != nil {
+			log.Printf("Manager -> ExecuteQuery -> MongoDB transaction error: %v", mongoTx.Error)
 			return nil, &dtos.QueryError{
-				Code:    "QUERY_EXECUTION_TIMED_OUT",
-				Message: "query execution timed out",
-				Details: "Query execution timed out",
+				Code:    "FAILED_TO_START_TRANSACTION",
+				Message: "failed to start transaction",
+				Details: mongoTx.Error.Error(),
 			}
 		}
-		return nil, &dtos.QueryError{
-			Code:    "QUERY_EXECUTION_CANCELLED",
-			Message: "query execution cancelled",
-			Details: "Query execution cancelled",
-		}
 
-	case <-done:
-		if queryErr != nil {
+		execution.Tx = tx
+
+		// Execute query with proper cancellation handling
+		result, queryErr = nil, nil
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			log.Printf("Manager -> ExecuteQuery -> Executing query: %v", query)
+			var err error
+			result, err = tx.ExecuteQuery(execCtx, query)
+			if err != nil {
+				log.Printf("Manager -> ExecuteQuery -> Error executing query: %v", err)
+				result = &QueryExecutionResult{
+					Error: &dtos.QueryError{
+						Message: err.Error(),
+						Code:    "EXECUTION_ERROR",
+					},
+				}
+			}
+			if result.Error != nil {
+				queryErr = result.Error
+			}
+		}()
+
+		select {
+		case <-execCtx.Done():
 			if err := tx.Rollback(); err != nil {
 				log.Printf("Error rolling back transaction: %v", err)
 			}
-			return result, queryErr
+			return nil, queryContextError(execCtx)
+		case <-done:
 		}
-		if err := tx.Commit(); err != nil {
-			return nil, &dtos.QueryError{
-				Code:    "QUERY_EXECUTION_FAILED",
-				Message: "query execution failed",
-				Details: err.Error(),
+
+		if queryErr == nil {
+			break
+		}
+		if err := tx.Rollback(); err != nil {
+			log.Printf("Error rolling back transaction: %v", err)
+		}
+		if attempt >= retryPolicy.MaxRetries || !isRetryableQueryError(queryErr) || !canRetryQuery(conn, query) {
+			if attempt > 0 {
+				queryErr.Details = strings.TrimSpace(fmt.Sprintf("%s (failed after %d attempts)", queryErr.Details, attempt+1))
 			}
+			return result, queryErr
 		}
-		log.Println("Manager -> ExecuteQuery -> Commit completed:")
-		log.Printf("Manager -> ExecuteQuery -> Query type: %v", queryType)
 
-		go func() {
-			log.Println("Manager -> ExecuteQuery -> Checking if schema trigger is needed")
-			time.Sleep(2 * time.Second)
-			switch conn.Config.Type {
-			case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB:
-				if queryType == "DDL" || queryType == "ALTER" || queryType == "DROP" {
-					if conn.OnSchemaChange != nil {
-						conn.OnSchemaChange(conn.ChatID)
-					}
+		delay := retryDelay(retryPolicy, attempt)
+		log.Printf("Manager -> ExecuteQuery -> WARNING: attempt %d/%d failed with a transient error, retrying in %v: %s",
+			attempt+1, retryPolicy.MaxRetries+1, delay, queryErr.Message)
+		select {
+		case <-execCtx.Done():
+			return nil, queryContextError(execCtx)
+		case <-time.After(delay):
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, &dtos.QueryError{
+			Code:    "QUERY_EXECUTION_FAILED",
+			Message: "query execution failed",
+			Details: err.Error(),
+		}
+	}
+	log.Println("Manager -> ExecuteQuery -> Commit completed:")
+	log.Printf("Manager -> ExecuteQuery -> Query type: %v", queryType)
+
+	// The capped query fetches one row past the cap, getting it back means rows were cut off
+	if truncateAt > 0 && result != nil && trimResultRows(result.Result, truncateAt) {
+		result.Truncated = true
+		result.TruncatedAt = truncateAt
+		m.notifyResultTruncated(conn.UserID, chatID, streamID, messageID, queryID, truncateAt)
+	}
+
+	go func() {
+		log.Println("Manager -> ExecuteQuery -> Checking if schema trigger is needed")
+		time.Sleep(2 * time.Second)
+		switch conn.Config.Type {
+		case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB, constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
+			if queryType == "DDL" || queryType == "ALTER" || queryType == "DROP" || queryType == constants.QueryTypeDDLMigration {
+				if conn.OnSchemaChange != nil {
+					conn.OnSchemaChange(conn.ChatID)
 				}
-			case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks:
-				if queryType == "DDL" || queryType == "ALTER" || queryType == "DROP" {
-					if conn.OnSchemaChange != nil {
-						conn.OnSchemaChange(conn.ChatID)
-					}
+			}
+		case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale:
+			if queryType == "DDL" || queryType == "ALTER" || queryType == "DROP" || queryType == constants.QueryTypeDDLMigration {
+				if conn.OnSchemaChange != nil {
+					conn.OnSchemaChange(conn.ChatID)
 				}
-			case constants.DatabaseTypeClickhouse:
-				if queryType == "DDL" || queryType == "ALTER" || queryType == "DROP" {
-					if conn.OnSchemaChange != nil {
-						conn.OnSchemaChange(conn.ChatID)
-					}
+			}
+		case constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypeOracle, constants.DatabaseTypeInfluxDB,
+			constants.DatabaseTypeCloudflareD1:
+			if queryType == "DDL" || queryType == "ALTER" || queryType == "DROP" || queryType == constants.QueryTypeDDLMigration {
+				if conn.OnSchemaChange != nil {
+					conn.OnSchemaChange(conn.ChatID)
 				}
-			case constants.DatabaseTypeMongoDB:
-				if queryType == "CREATE_COLLECTION" || queryType == "DROP_COLLECTION" {
-					if conn.OnSchemaChange != nil {
-						conn.OnSchemaChange(conn.ChatID)
-					}
+			}
+		case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+			if queryType == "CREATE_COLLECTION" || queryType == "DROP_COLLECTION" {
+				if conn.OnSchemaChange != nil {
+					conn.OnSchemaChange(conn.ChatID)
 				}
 			}
-		}()
+		}
+	}()
 
-		return result, nil
+	return result, nil
+}
+
+// queryContextError returns the error of a query stopped by its context, either timed out or cancelled
+func queryContextError(ctx context.Context) *dtos.QueryError {
+	if ctx.Err() == context.DeadlineExceeded {
+		return &dtos.QueryError{
+			Code:    "QUERY_EXECUTION_TIMED_OUT",
+			Message: "query execution timed out",
+			Details: "Query execution timed out",
+		}
+	}
+	return &dtos.QueryError{
+		Code:    "QUERY_EXECUTION_CANCELLED",
+		Message: "query execution cancelled",
+		Details: "Query execution cancelled",
 	}
 }
 
+// notifyResultTruncated tells the client that a query result was cut off at the row cap
+func (m *Manager) notifyResultTruncated(userID, chatID, streamID, messageID, queryID string, truncatedAt int) {
+	log.Printf("Manager -> notifyResultTruncated -> Query %s result truncated at %d rows", queryID, truncatedAt)
+	if m.streamHandler == nil || streamID == "" {
+		return
+	}
+	m.streamHandler.HandleDBEvent(userID, chatID, streamID, dtos.StreamResponse{
+		Event: constants.StreamEventResultTruncated,
+		Data: map[string]interface{}{
+			"message_id":   messageID,
+			"query_id":     queryID,
+			"truncated_at": truncatedAt,
+		},
+	})
+}
+
 // TestConnection tests if the provided credentials are valid without creating a persistent connection
 func (m *Manager) TestConnection(config *ConnectionConfig) error {
 	var tempFiles []string
@@ -274,7 +354,11 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 	}
 
 	switch config.Type {
-	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB:
+	case constants.DatabaseTypeNeon:
+		// Neon computes may be suspended, so the test retries while they wake up
+		return testNeonConnection(config)
+
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB, constants.DatabaseTypeSupabase:
 		var dsn string
 		port := "5432" // Default port
 		if config.Type == constants.DatabaseTypeYugabyteDB {
@@ -306,7 +390,7 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 			}
 
 			// Fetch certificates from URLs
-			certPath, keyPath, rootCertPath, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				return err
 			}
@@ -359,7 +443,7 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 
 		return nil
 
-	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks:
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale:
 		var dsn string
 		port := "3306" // Default port for MySQL / StarRocks (MySQL FE query port)
 		if config.Type == constants.DatabaseTypeStarRocks {
@@ -392,7 +476,7 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 			tlsConfigName := fmt.Sprintf("custom-test-%d", time.Now().UnixNano())
 
 			// Fetch certificates from URLs
-			certPath, keyPath, rootCertPath, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				return err
 			}
@@ -447,6 +531,9 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 			dsn += "&tls=" + tlsConfigName
 		}
 
+		// PlanetScale requires TLS and targets the selected branch
+		dsn += planetscaleDSNParams(*config)
+
 		// Open connection
 		db, err := sql.Open("mysql", dsn)
 		if err != nil {
@@ -488,7 +575,7 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 		// Configure SSL/TLS
 		if config.UseSSL {
 			// Fetch certificates from URLs
-			_, _, _, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			_, _, _, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				return err
 			}
@@ -544,7 +631,166 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 
 		return nil
 
-	case constants.DatabaseTypeMongoDB:
+	case constants.DatabaseTypeAirtable:
+		client, err := newAirtableClient(*config)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.AirtableRequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to Airtable: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeInfluxDB:
+		client, err := newInfluxDBClient(*config)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.InfluxDBRequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to InfluxDB: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeTemporal:
+		client, err := newTemporalClient(*config)
+		if err != nil {
+			return err
+		}
+		defer client.close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.TemporalRequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to Temporal: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypePocketBase:
+		client, err := newPocketBaseClient(*config)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.PocketBaseRequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to PocketBase: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeNATS:
+		client, err := newNATSClient(*config)
+		if err != nil {
+			return err
+		}
+		defer client.close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.NATSRequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to NATS JetStream: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeYugabyteDBCQL:
+		client, err := newYugabyteDBCQLClient(*config)
+		if err != nil {
+			return err
+		}
+		defer client.close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.YugabyteDBCQLRequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to YugabyteDB YCQL: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeFirestore:
+		ctx, cancel := context.WithTimeout(context.Background(), constants.FirestoreRequestTimeout)
+		defer cancel()
+
+		client, err := newFirestoreClient(ctx, *config)
+		if err != nil {
+			return err
+		}
+		defer client.close()
+
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to Firestore: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeCloudflareD1:
+		client, err := newCloudflareD1Client(*config)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), constants.CloudflareD1RequestTimeout)
+		defer cancel()
+		if err := client.ping(ctx); err != nil {
+			return fmt.Errorf("failed to connect to Cloudflare D1: %v", err)
+		}
+		return nil
+
+	case constants.DatabaseTypeTrino:
+		dsn, certTempFiles, err := buildTrinoDSN(*config)
+		if err != nil {
+			return err
+		}
+		tempFiles = certTempFiles
+
+		db, err := sql.Open("trino", dsn)
+		if err != nil {
+			for _, file := range tempFiles {
+				os.Remove(file)
+			}
+			return fmt.Errorf("failed to create connection: %v", err)
+		}
+
+		// Ping only opens the client, run a query so the coordinator and catalog are checked too
+		var result int
+		err = db.QueryRow("SELECT 1").Scan(&result)
+		db.Close()
+
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %v", err)
+		}
+
+		return nil
+
+	case constants.DatabaseTypeOracle:
+		if config.SSHEnabled {
+			return fmt.Errorf("SSH tunnels are not supported for Oracle connections")
+		}
+
+		db, err := openOracleDB(*config)
+		if err != nil {
+			return err
+		}
+
+		var result int
+		err = db.QueryRow("SELECT 1 FROM DUAL").Scan(&result)
+		db.Close()
+
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %v", err)
+		}
+
+		return nil
+
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
 		var port string
 		if config.Port != nil && *config.Port != "" {
 			port = *config.Port
@@ -638,7 +884,7 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 		// Configure SSL/TLS
 		if config.UseSSL {
 			// Fetch certificates from URLs
-			certPath, keyPath, rootCertPath, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				return err
 			}
@@ -731,6 +977,13 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 		// Ping the database to verify connection
 		err = client.Ping(ctx, readpref.Primary())
 
+		// FerretDB identifies itself in buildInfo, which tells it apart from a native MongoDB server
+		var isFerretDB bool
+		var detectErr error
+		if err == nil && config.Type == constants.DatabaseTypeFerretDB {
+			isFerretDB, detectErr = detectFerretDB(ctx, client, config.Database)
+		}
+
 		// Disconnect regardless of ping result
 		client.Disconnect(ctx)
 
@@ -744,6 +997,18 @@ func (m *Manager) TestConnection(config *ConnectionConfig) error {
 			return fmt.Errorf("failed to ping MongoDB: %v", err)
 		}
 
+		if config.Type == constants.DatabaseTypeFerretDB {
+			if detectErr != nil {
+				log.Printf("DBManager -> TestConnection -> Error detecting FerretDB: %v", detectErr)
+				return fmt.Errorf("failed to verify FerretDB server: %v", detectErr)
+			}
+			if !isFerretDB {
+				return fmt.Errorf("the server is a native MongoDB server, not FerretDB, please use the MongoDB data source type")
+			}
+			log.Printf("DBManager -> TestConnection -> Successfully connected to FerretDB")
+			return nil
+		}
+
 		log.Printf("DBManager -> TestConnection -> Successfully connected to MongoDB")
 		return nil
 
@@ -0,0 +1,188 @@
+package dbmanager
+
+import (
+	"context"
+	"errors"
+	"log"
+	"neobase-ai/config"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// mongoWatchRetryDelay is the wait before a dropped change stream is reopened from its resume token
+	mongoWatchRetryDelay = 5 * time.Second
+	// mongoWatchRefreshCooldown is the least time between two schema checks triggered by inserts, so a burst
+	// of documents with a new field refreshes the schema once
+	mongoWatchRefreshCooldown = time.Minute
+)
+
+// MongoDB error codes that mean a change stream can't be opened or resumed
+const (
+	mongoErrChangeStreamNotReplicaSet = 40573 // $changeStream needs a replica set or sharded cluster
+	mongoErrChangeStreamHistoryLost   = 286   // The resume token fell off the oplog
+)
+
+// startMongoWatcher opens a change stream on the database of a MongoDB chat, when MONGO_CHANGE_STREAM_ENABLED is set,
+// so documents inserted with new top-level fields refresh the schema without waiting for a poll
+func (m *Manager) startMongoWatcher(chatID string, conn *Connection) {
+	if !config.Env.MongoChangeStreamEnabled || conn.Config.Type != "mongodb" {
+		return
+	}
+
+	wrapper, ok := conn.MongoDBObj.(*MongoDBWrapper)
+	if !ok || wrapper == nil || wrapper.Client == nil {
+		log.Printf("DBManager -> startMongoWatcher -> No MongoDB client for chat %s", chatID)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.mongoWatchersMu.Lock()
+	if existingCancel, exists := m.mongoWatchers[chatID]; exists {
+		existingCancel()
+	}
+	m.mongoWatchers[chatID] = cancel
+	m.mongoWatchersMu.Unlock()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("DBManager -> startMongoWatcher -> Watcher panic recovered for chat %s: %v", chatID, r)
+			}
+		}()
+		m.watchMongoInserts(ctx, chatID, wrapper)
+	}()
+}
+
+// stopMongoWatcher closes the change stream of a chat, if it has one
+func (m *Manager) stopMongoWatcher(chatID string) {
+	m.mongoWatchersMu.Lock()
+	defer m.mongoWatchersMu.Unlock()
+
+	if cancel, exists := m.mongoWatchers[chatID]; exists {
+		cancel()
+		delete(m.mongoWatchers, chatID)
+		log.Printf("DBManager -> stopMongoWatcher -> Stopped change stream for chat %s", chatID)
+	}
+}
+
+// watchMongoInserts reads insert events until ctx is cancelled. A dropped stream is reopened after the last
+// event it delivered, using the resume token, so inserts made while it was down are still seen.
+func (m *Manager) watchMongoInserts(ctx context.Context, chatID string, wrapper *MongoDBWrapper) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "operationType", Value: "insert"}}}},
+	}
+
+	var resumeToken bson.Raw
+	var lastRefresh time.Time
+
+	log.Printf("DBManager -> watchMongoInserts -> Watching inserts on database %s for chat %s", wrapper.Database, chatID)
+
+	for ctx.Err() == nil {
+		opts := options.ChangeStream()
+		if resumeToken != nil {
+			opts.SetResumeAfter(resumeToken)
+		}
+
+		stream, err := wrapper.Client.Database(wrapper.Database).Watch(ctx, pipeline, opts)
+		if err != nil {
+			var cmdErr mongo.CommandError
+			if errors.As(err, &cmdErr) && cmdErr.Code == mongoErrChangeStreamNotReplicaSet {
+				log.Printf("DBManager -> watchMongoInserts -> Change streams need a replica set, not watching chat %s", chatID)
+				return
+			}
+			if errors.As(err, &cmdErr) && cmdErr.Code == mongoErrChangeStreamHistoryLost {
+				log.Printf("DBManager -> watchMongoInserts -> Resume token expired for chat %s, watching from now", chatID)
+				resumeToken = nil
+				continue
+			}
+			log.Printf("DBManager -> watchMongoInserts -> Failed to open change stream for chat %s: %v", chatID, err)
+		} else {
+			for stream.Next(ctx) {
+				resumeToken = stream.ResumeToken()
+
+				var event struct {
+					Ns struct {
+						Coll string `bson:"coll"`
+					} `bson:"ns"`
+					FullDocument bson.M `bson:"fullDocument"`
+				}
+				if err := stream.Decode(&event); err != nil {
+					log.Printf("DBManager -> watchMongoInserts -> Failed to decode change event for chat %s: %v", chatID, err)
+					continue
+				}
+
+				if time.Since(lastRefresh) < mongoWatchRefreshCooldown {
+					continue
+				}
+				if m.hasNewMongoFields(ctx, chatID, event.Ns.Coll, event.FullDocument) {
+					lastRefresh = time.Now()
+					log.Printf("DBManager -> watchMongoInserts -> New fields inserted into %s for chat %s, running schema check", event.Ns.Coll, chatID)
+					if err := m.doSchemaCheck(chatID); err != nil {
+						log.Printf("DBManager -> watchMongoInserts -> Schema check failed for chat %s: %v", chatID, err)
+					}
+				}
+			}
+			if err := stream.Err(); err != nil && ctx.Err() == nil {
+				log.Printf("DBManager -> watchMongoInserts -> Change stream for chat %s dropped: %v", chatID, err)
+			}
+			stream.Close(context.Background())
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(mongoWatchRetryDelay):
+		}
+	}
+
+	log.Printf("DBManager -> watchMongoInserts -> Stopped watching chat %s", chatID)
+}
+
+// hasNewMongoFields tells whether an inserted document has top-level fields missing from the stored schema
+// of its collection. Collections the chat hasn't selected are ignored.
+func (m *Manager) hasNewMongoFields(ctx context.Context, chatID, collection string, document bson.M) bool {
+	if collection == "" || len(document) == 0 {
+		return false
+	}
+
+	selectedTables := m.getSelectedTables(chatID)
+	if !(len(selectedTables) == 1 && selectedTables[0] == "ALL") {
+		selected := false
+		for _, table := range selectedTables {
+			if table == collection {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			return false
+		}
+	}
+
+	schema, err := m.schemaManager.GetStoredSchemaInfo(ctx, chatID)
+	if err != nil {
+		// Nothing stored yet, the first schema fetch will include the collection as it is
+		return false
+	}
+
+	table, exists := schema.Tables[collection]
+	if !exists {
+		return true
+	}
+
+	knownFields := make(map[string]bool, len(table.Columns))
+	for name := range table.Columns {
+		knownFields[strings.SplitN(name, ".", 2)[0]] = true
+	}
+	for field := range document {
+		if !knownFields[field] {
+			return true
+		}
+	}
+	return false
+}
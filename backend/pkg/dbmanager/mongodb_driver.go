@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
 	"neobase-ai/internal/utils"
 	"net/url"
 	"os"
@@ -89,6 +90,14 @@ func (d *MongoDBDriver) GetSchema(ctx context.Context, db DBExecutor, selectedCo
 			continue
 		}
 
+		// FerretDB keeps its metadata in _ferretdb* collections, they are not part of the user's schema
+		if isFerretDBInternalCollection(collName) {
+			if collName == constants.FerretDBSettingsCollection {
+				log.Printf("MongoDBDriver -> GetSchema -> Detected FerretDB backend")
+			}
+			continue
+		}
+
 		log.Printf("MongoDBDriver -> GetSchema -> Processing collection: %s", collName)
 
 		// Get collection details
@@ -673,6 +682,20 @@ func (d *MongoDBDriver) Connect(config ConnectionConfig) (*Connection, error) {
 	// Configure client options
 	clientOptions := options.Client().ApplyURI(uri)
 
+	// Route reads to the configured replica set members, writes always go to the primary
+	if config.ReadPreference != nil && *config.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(*config.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference %q: %v", *config.ReadPreference, err)
+		}
+		readPref, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference %q: %v", *config.ReadPreference, err)
+		}
+		clientOptions.SetReadPreference(readPref)
+		log.Printf("MongoDBDriver -> Connect -> Using read preference: %s", *config.ReadPreference)
+	}
+
 	// Set a shorter connection timeout for encrypted connections
 	if strings.Contains(config.Host, "+") || strings.Contains(config.Host, "/") || strings.Contains(config.Host, "=") {
 		clientOptions.SetConnectTimeout(5 * time.Second)
@@ -694,7 +717,7 @@ func (d *MongoDBDriver) Connect(config ConnectionConfig) (*Connection, error) {
 			// Do nothing
 		} else {
 			// Fetch certificates from URLs
-			certPath, keyPath, rootCertPath, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				if sshTunnel != nil {
 					sshTunnel.Close()
@@ -872,6 +895,19 @@ func (d *MongoDBDriver) IsAlive(conn *Connection) bool {
 	return err == nil
 }
 
+// isMongoDBReadOperation reports whether an operation only reads, so it may follow a secondary read preference.
+// Aggregations writing with $out or $merge are treated as writes.
+func isMongoDBReadOperation(operation, params string) bool {
+	switch operation {
+	case "find", "findOne", "countDocuments":
+		return true
+	case "aggregate":
+		return !strings.Contains(params, "$out") && !strings.Contains(params, "$merge")
+	default:
+		return false
+	}
+}
+
 // ExecuteQuery executes a MongoDB query
 func (d *MongoDBDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
 	// Sanitize MongoDB operator spacing.
@@ -1077,8 +1113,12 @@ func (d *MongoDBDriver) ExecuteQuery(ctx context.Context, conn *Connection, quer
 		}
 	}
 
-	// Get the MongoDB collection
-	collection := wrapper.Client.Database(wrapper.Database).Collection(collectionName)
+	// Get the MongoDB collection, pinned to the primary for anything that is not a plain read
+	collectionOpts := options.Collection()
+	if !isMongoDBReadOperation(operation, paramsStr) {
+		collectionOpts.SetReadPreference(readpref.Primary())
+	}
+	collection := wrapper.Client.Database(wrapper.Database).Collection(collectionName, collectionOpts)
 
 	// Check if the collection exists (except for dropCollection operation)
 	if operation != "dropCollection" {
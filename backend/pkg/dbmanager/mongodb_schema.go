@@ -6,6 +6,8 @@ import (
 	"log"
 	"time"
 
+	"neobase-ai/internal/constants"
+
 	"go.mongodb.org/mongo-driver/bson"
 )
 
@@ -43,6 +45,19 @@ func (f *MongoDBSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, sel
 		return nil, fmt.Errorf("failed to list collections: %v", err)
 	}
 	log.Printf("MongoDBSchemaFetcher -> GetSchema -> Found %d collections: %v", len(collections), collections)
+
+	// FerretDB keeps its metadata in _ferretdb* collections, they are not part of the user's schema
+	userCollections := collections[:0]
+	for _, coll := range collections {
+		if coll == constants.FerretDBSettingsCollection {
+			log.Printf("MongoDBSchemaFetcher -> GetSchema -> Detected FerretDB backend")
+		}
+		if !isFerretDBInternalCollection(coll) {
+			userCollections = append(userCollections, coll)
+		}
+	}
+	collections = userCollections
+
 	// Filter collections if specific ones are selected
 	var targetCollections []string
 	if len(selectedCollections) == 0 || (len(selectedCollections) == 1 && selectedCollections[0] == "ALL") {
@@ -657,7 +657,11 @@ func (tx *MongoDBTransaction) ExecuteQuery(ctx context.Context, query string) (*
 		// If count() modifier is present, perform a count operation instead of find
 		if modifiers.Count {
 			// Execute the countDocuments operation
-			count, err := collection.CountDocuments(ctx, filter)
+			countOptions := options.Count()
+			if maxTime, ok := maxTimeFromContext(ctx); ok {
+				countOptions.SetMaxTime(maxTime)
+			}
+			count, err := collection.CountDocuments(ctx, filter, countOptions)
 			if err != nil {
 				return &QueryExecutionResult{
 					Error: &dtos.QueryError{
@@ -675,6 +679,9 @@ func (tx *MongoDBTransaction) ExecuteQuery(ctx context.Context, query string) (*
 
 		// Create find options
 		findOptions := options.Find()
+		if maxTime, ok := maxTimeFromContext(ctx); ok {
+			findOptions.SetMaxTime(maxTime)
+		}
 
 		// Apply limit if specified
 		if modifiers.Limit > 0 {
@@ -843,7 +850,11 @@ func (tx *MongoDBTransaction) ExecuteQuery(ctx context.Context, query string) (*
 
 		// Execute the findOne operation
 		var doc bson.M
-		err = collection.FindOne(ctx, filter).Decode(&doc)
+		findOneOptions := options.FindOne()
+		if maxTime, ok := maxTimeFromContext(ctx); ok {
+			findOneOptions.SetMaxTime(maxTime)
+		}
+		err = collection.FindOne(ctx, filter, findOneOptions).Decode(&doc)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
 				// No documents found, return empty result
@@ -1532,7 +1543,11 @@ func (tx *MongoDBTransaction) ExecuteQuery(ctx context.Context, query string) (*
 		}
 
 		// Execute the aggregation
-		cursor, err := collection.Aggregate(ctx, pipeline)
+		aggregateOptions := options.Aggregate()
+		if maxTime, ok := maxTimeFromContext(ctx); ok {
+			aggregateOptions.SetMaxTime(maxTime)
+		}
+		cursor, err := collection.Aggregate(ctx, pipeline, aggregateOptions)
 		if err != nil {
 			log.Printf("MongoDBTransaction -> ExecuteQuery -> Error executing aggregation: %v", err)
 
@@ -1617,7 +1632,11 @@ func (tx *MongoDBTransaction) ExecuteQuery(ctx context.Context, query string) (*
 		}
 
 		// Execute the countDocuments operation
-		count, err := collection.CountDocuments(ctx, filter)
+		countOptions := options.Count()
+		if maxTime, ok := maxTimeFromContext(ctx); ok {
+			countOptions.SetMaxTime(maxTime)
+		}
+		count, err := collection.CountDocuments(ctx, filter, countOptions)
 		if err != nil {
 			return &QueryExecutionResult{
 				Error: &dtos.QueryError{
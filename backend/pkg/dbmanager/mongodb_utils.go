@@ -1621,3 +1621,17 @@ func mongoInjectTemplatedCursor(query, cursorValue string) string {
 	query = strings.Replace(query, placeholder, replacement, -1)
 	return query
 }
+
+// maxTimeFromContext returns the time left before the context deadline, used as the server-side maxTimeMS
+// so MongoDB aborts the operation itself instead of leaving it running after the client gives up
+func maxTimeFromContext(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, false
+	}
+	return remaining, true
+}
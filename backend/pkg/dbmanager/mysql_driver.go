@@ -10,7 +10,6 @@ import (
 	"io/ioutil"
 	"log"
 	"neobase-ai/internal/apis/dtos"
-	"neobase-ai/internal/utils"
 	"os"
 	"strings"
 	"sync"
@@ -118,7 +117,7 @@ func (d *MySQLDriver) Connect(config ConnectionConfig) (*Connection, error) {
 			tlsConfigName := fmt.Sprintf("custom-%d", time.Now().UnixNano())
 
 			// Fetch certificates from URLs
-			certPath, keyPath, rootCertPath, certTempFiles, err := utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				return nil, err
 			}
@@ -198,6 +197,9 @@ func (d *MySQLDriver) Connect(config ConnectionConfig) (*Connection, error) {
 		}
 	}
 
+	// PlanetScale requires TLS and targets the selected branch
+	dsn += planetscaleDSNParams(config)
+
 	// Open connection
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
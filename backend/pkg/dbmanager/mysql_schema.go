@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"neobase-ai/internal/constants"
+	"sort"
 	"strings"
 	"time"
 )
@@ -137,6 +139,15 @@ func (f *MySQLSchemaFetcher) FetchSchema(ctx context.Context) (*SchemaInfo, erro
 		tableData, _ := json.Marshal(tableSchema)
 		tableSchema.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
 
+		// Sample JSON columns after the checksum, their keys come from the data and must not mark the table as changed
+		for columnName, column := range tableSchema.Columns {
+			if !strings.EqualFold(column.Type, "json") {
+				continue
+			}
+			column.JSONKeys = f.fetchJSONKeys(table, columnName)
+			tableSchema.Columns[columnName] = column
+		}
+
 		schema.Tables[table] = tableSchema
 	}
 
@@ -369,6 +380,45 @@ func (f *MySQLSchemaFetcher) fetchColumns(_ context.Context, table string) (map[
 	return columns, nil
 }
 
+// fetchJSONKeys infers the structure of a JSON column from the top-level keys of its first object values.
+// Sampling is best effort, a failure only leaves the keys out of the schema.
+func (f *MySQLSchemaFetcher) fetchJSONKeys(table, column string) []string {
+	var rows []map[string]interface{}
+	query := fmt.Sprintf("SELECT JSON_KEYS(`%s`) AS json_keys FROM `%s` WHERE JSON_TYPE(`%s`) = 'OBJECT' LIMIT %d",
+		column, table, column, constants.MySQLJSONKeySampleRows)
+	if err := f.db.QueryRows(query, &rows); err != nil {
+		log.Printf("MySQLSchemaFetcher -> fetchJSONKeys -> Error sampling %s.%s: %v", table, column, err)
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+	for _, row := range rows {
+		var raw []byte
+		switch value := row["json_keys"].(type) {
+		case []byte:
+			raw = value
+		case string:
+			raw = []byte(value)
+		default:
+			continue
+		}
+
+		var rowKeys []string
+		if err := json.Unmarshal(raw, &rowKeys); err != nil {
+			continue
+		}
+		for _, key := range rowKeys {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // fetchIndexes retrieves all indexes for a specific table
 func (f *MySQLSchemaFetcher) fetchIndexes(_ context.Context, table string) (map[string]IndexInfo, error) {
 	indexes := make(map[string]IndexInfo)
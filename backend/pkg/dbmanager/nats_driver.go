@@ -0,0 +1,954 @@
+package dbmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSClient wraps a NATS connection and its JetStream context
+type NATSClient struct {
+	conn      *nats.Conn
+	js        nats.JetStreamContext
+	url       string
+	tempFiles []string
+}
+
+// natsCall is a parsed JetStream call, e.g. js.FetchMsg("ORDERS", 50, {"subject": "orders.>"})
+type natsCall struct {
+	Method string
+	Args   []json.RawMessage
+}
+
+// natsFetchOptions are the options of js.FetchMsg and js.CountMsg
+type natsFetchOptions struct {
+	Subject   string `json:"subject,omitempty"`
+	StartSeq  uint64 `json:"startSeq,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
+	Last      bool   `json:"last,omitempty"`
+	Consumer  string `json:"consumer,omitempty"`
+}
+
+// natsPublishOptions are the options of js.Publish
+type natsPublishOptions struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	MsgID   string            `json:"msgId,omitempty"`
+}
+
+// natsCallArgs lists the minimum and maximum number of arguments of each call
+var natsCallArgs = map[string][2]int{
+	"FetchMsg":   {2, 3},
+	"GetMsg":     {2, 2},
+	"GetLastMsg": {2, 2},
+	"CountMsg":   {1, 2},
+	"StreamInfo": {1, 1},
+	"Streams":    {0, 0},
+	"Consumers":  {1, 1},
+	"Publish":    {2, 3},
+}
+
+// natsCallPattern matches js.Method(arguments)
+var natsCallPattern = regexp.MustCompile(`(?s)^js\.(\w+)\s*\((.*)\)$`)
+
+// newNATSClient connects to the NATS server of the connection config and opens its JetStream context.
+// Credentials are a .creds file (NKey/JWT), a username and password, or a token in the password alone.
+func newNATSClient(config ConnectionConfig) (*NATSClient, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("a NATS server host is required")
+	}
+	port := constants.NATSDefaultPort
+	if config.Port != nil && *config.Port != "" {
+		port = *config.Port
+	}
+	scheme := "nats"
+	if config.UseSSL {
+		scheme = "tls"
+	}
+	url := scheme + "://" + net.JoinHostPort(config.Host, port)
+
+	options := []nats.Option{
+		nats.Name("NeoBase"),
+		nats.Timeout(constants.NATSRequestTimeout),
+	}
+
+	credentialsFile := getValue(config.NATSCredentialsFile)
+	username := getValue(config.Username)
+	password := getValue(config.Password)
+	switch {
+	case credentialsFile != "":
+		if _, err := os.Stat(credentialsFile); err != nil {
+			return nil, fmt.Errorf("NATS credentials file %s is not readable: %v", credentialsFile, err)
+		}
+		options = append(options, nats.UserCredentials(credentialsFile))
+	case username != "":
+		options = append(options, nats.UserInfo(username, password))
+	case password != "":
+		options = append(options, nats.Token(password))
+	}
+
+	var tempFiles []string
+	if config.UseSSL {
+		tlsConfig, certTempFiles, err := buildNATSTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		tempFiles = certTempFiles
+		options = append(options, nats.Secure(tlsConfig))
+	}
+
+	conn, err := nats.Connect(url, options...)
+	if err != nil {
+		removeTempFiles(tempFiles)
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %v", url, err)
+	}
+
+	js, err := conn.JetStream(nats.MaxWait(constants.NATSRequestTimeout))
+	if err != nil {
+		conn.Close()
+		removeTempFiles(tempFiles)
+		return nil, fmt.Errorf("failed to open JetStream context: %v", err)
+	}
+
+	return &NATSClient{
+		conn:      conn,
+		js:        js,
+		url:       url,
+		tempFiles: tempFiles,
+	}, nil
+}
+
+// buildNATSTLSConfig builds the TLS config of the connection, with a client certificate for mTLS when one is set
+func buildNATSTLSConfig(config ConnectionConfig) (*tls.Config, []string, error) {
+	tlsConfig := &tls.Config{
+		ServerName: config.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	certPath, keyPath, rootCertPath, tempFiles, err := prepareSSLCertificates(
+		getValue(config.SSLCertURL), getValue(config.SSLKeyURL), getValue(config.SSLRootCertURL))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if certPath != "" && keyPath != "" {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			removeTempFiles(tempFiles)
+			return nil, nil, fmt.Errorf("failed to load client certificates: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if rootCertPath != "" {
+		pem, err := os.ReadFile(rootCertPath)
+		if err != nil {
+			removeTempFiles(tempFiles)
+			return nil, nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		rootCertPool := x509.NewCertPool()
+		if !rootCertPool.AppendCertsFromPEM(pem) {
+			removeTempFiles(tempFiles)
+			return nil, nil, fmt.Errorf("failed to parse CA certificate")
+		}
+		tlsConfig.RootCAs = rootCertPool
+	}
+
+	return tlsConfig, tempFiles, nil
+}
+
+// close drains the connection and removes its certificate files
+func (c *NATSClient) close() {
+	if err := c.conn.Drain(); err != nil {
+		c.conn.Close()
+	}
+	removeTempFiles(c.tempFiles)
+}
+
+// ping checks that the server is reachable and JetStream is enabled for the account
+func (c *NATSClient) ping(ctx context.Context) error {
+	if !c.conn.IsConnected() {
+		return fmt.Errorf("NATS connection is %s", c.conn.Status())
+	}
+	_, err := c.js.AccountInfo(nats.Context(ctx))
+	return err
+}
+
+// listStreams returns the streams of the account, sorted by name
+func (c *NATSClient) listStreams(ctx context.Context) ([]*nats.StreamInfo, error) {
+	streams := []*nats.StreamInfo{}
+	for info := range c.js.Streams(nats.Context(ctx)) {
+		streams = append(streams, info)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list streams: %v", err)
+	}
+	sort.Slice(streams, func(i, j int) bool { return streams[i].Config.Name < streams[j].Config.Name })
+	return streams, nil
+}
+
+// countMessages counts the messages of a stream, only those on subject when it is set
+func (c *NATSClient) countMessages(ctx context.Context, stream, subject string) (uint64, error) {
+	if subject == "" {
+		info, err := c.js.StreamInfo(stream, nats.Context(ctx))
+		if err != nil {
+			return 0, err
+		}
+		return info.State.Msgs, nil
+	}
+
+	info, err := c.js.StreamInfo(stream, nats.Context(ctx), &nats.StreamInfoRequest{SubjectsFilter: subject})
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for _, n := range info.State.Subjects {
+		count += n
+	}
+	return count, nil
+}
+
+// fetchMessages reads up to limit messages of a stream with an ephemeral ordered consumer.
+// Ordered consumers need no acknowledgements, so durable consumers and the stream are left untouched.
+// It returns the sequence after the last message when more messages match, 0 otherwise.
+func (c *NATSClient) fetchMessages(ctx context.Context, stream string, limit int, opts natsFetchOptions) ([]map[string]interface{}, uint64, error) {
+	info, err := c.js.StreamInfo(stream, nats.Context(ctx))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if opts.Consumer != "" {
+		// Peek at the messages the durable consumer would receive next, without changing its position
+		consumer, err := c.js.ConsumerInfo(stream, opts.Consumer, nats.Context(ctx))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read consumer %s: %v", opts.Consumer, err)
+		}
+		if opts.Subject == "" {
+			opts.Subject = consumer.Config.FilterSubject
+		}
+		if opts.StartSeq == 0 && consumer.AckFloor.Stream > 0 {
+			opts.StartSeq = consumer.AckFloor.Stream + 1
+		}
+	}
+
+	if info.State.Msgs == 0 || (opts.StartSeq > 0 && opts.StartSeq > info.State.LastSeq) {
+		return []map[string]interface{}{}, 0, nil
+	}
+
+	if opts.Last {
+		rows, err := c.fetchLastMessages(ctx, stream, info, limit, opts.Subject)
+		return rows, 0, err
+	}
+
+	var startOpt nats.SubOpt = nats.DeliverAll()
+	switch {
+	case opts.StartSeq > 0:
+		startOpt = nats.StartSequence(opts.StartSeq)
+	case opts.StartTime != "":
+		startTime, err := time.Parse(time.RFC3339, opts.StartTime)
+		if err != nil {
+			return nil, 0, fmt.Errorf("startTime must be an RFC 3339 time: %v", err)
+		}
+		startOpt = nats.StartTime(startTime)
+	}
+
+	rows, more, err := c.readOrdered(ctx, stream, opts.Subject, startOpt, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	var nextSeq uint64
+	if more && len(rows) > 0 {
+		nextSeq = rows[len(rows)-1]["sequence"].(uint64) + 1
+	}
+	return rows, nextSeq, nil
+}
+
+// fetchLastMessages reads the last limit messages of a stream. Without a subject the start sequence
+// is known, with one a growing window at the end of the stream is read until it holds enough matches.
+func (c *NATSClient) fetchLastMessages(ctx context.Context, stream string, info *nats.StreamInfo, limit int, subject string) ([]map[string]interface{}, error) {
+	first, last := info.State.FirstSeq, info.State.LastSeq
+	window := uint64(limit)
+	if subject != "" {
+		window = uint64(limit) * 4
+	}
+
+	for {
+		start := first
+		if last >= window && last-window+1 > first {
+			start = last - window + 1
+		}
+
+		rows, _, err := c.readOrdered(ctx, stream, subject, nats.StartSequence(start), constants.NATSMaxFetchLimit*10)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) >= limit || start == first {
+			if len(rows) > limit {
+				rows = rows[len(rows)-limit:]
+			}
+			return rows, nil
+		}
+		window *= 2
+	}
+}
+
+// readOrdered reads up to limit messages with an ordered consumer, stopping early when no message is pending.
+// It reports whether more matching messages follow the last one read.
+func (c *NATSClient) readOrdered(ctx context.Context, stream, subject string, startOpt nats.SubOpt, limit int) ([]map[string]interface{}, bool, error) {
+	sub, err := c.js.SubscribeSync(subject, nats.BindStream(stream), nats.OrderedConsumer(), startOpt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read stream %s: %v", stream, err)
+	}
+	defer func() {
+		if err := sub.Unsubscribe(); err != nil {
+			log.Printf("NATSDriver -> readOrdered -> Failed to remove ordered consumer: %v", err)
+		}
+	}()
+
+	rows := make([]map[string]interface{}, 0)
+	for len(rows) < limit {
+		if err := ctx.Err(); err != nil {
+			return nil, false, fmt.Errorf("query execution cancelled: %v", err)
+		}
+		msg, err := sub.NextMsg(constants.NATSFetchWait)
+		if errors.Is(err, nats.ErrTimeout) {
+			return rows, false, nil
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read stream %s: %v", stream, err)
+		}
+		meta, err := msg.Metadata()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read message metadata: %v", err)
+		}
+
+		rows = append(rows, natsMessageRow(meta.Sequence.Stream, msg.Subject, msg.Data, meta.Timestamp, msg.Header))
+		if meta.NumPending == 0 {
+			return rows, false, nil
+		}
+	}
+	return rows, true, nil
+}
+
+// natsMessageRow flattens a message into a row with the columns of a stream table.
+// Payloads that are not UTF-8 are base64 encoded and large payloads are truncated.
+func natsMessageRow(sequence uint64, subject string, data []byte, timestamp time.Time, header nats.Header) map[string]interface{} {
+	row := map[string]interface{}{
+		"sequence":  sequence,
+		"subject":   subject,
+		"timestamp": timestamp.UTC().Format(time.RFC3339Nano),
+	}
+
+	if len(data) > constants.NATSMaxMessageDataBytes {
+		data = data[:constants.NATSMaxMessageDataBytes]
+		row["data_truncated"] = true
+	}
+	if utf8.Valid(data) {
+		row["data"] = string(data)
+	} else {
+		row["data"] = base64.StdEncoding.EncodeToString(data)
+		row["data_encoding"] = "base64"
+	}
+
+	if len(header) > 0 {
+		headers := make(map[string]string, len(header))
+		for key, values := range header {
+			headers[key] = strings.Join(values, ", ")
+		}
+		row["headers"] = headers
+	}
+	return row
+}
+
+// natsStreamRow summarizes a stream
+func natsStreamRow(info *nats.StreamInfo) map[string]interface{} {
+	row := map[string]interface{}{
+		"name":        info.Config.Name,
+		"subjects":    info.Config.Subjects,
+		"messages":    info.State.Msgs,
+		"bytes":       info.State.Bytes,
+		"first_seq":   info.State.FirstSeq,
+		"last_seq":    info.State.LastSeq,
+		"consumers":   info.State.Consumers,
+		"created":     info.Created.UTC().Format(time.RFC3339Nano),
+		"max_age":     info.Config.MaxAge.String(),
+		"max_msgs":    info.Config.MaxMsgs,
+		"max_bytes":   info.Config.MaxBytes,
+		"description": info.Config.Description,
+	}
+	if !info.State.FirstTime.IsZero() {
+		row["first_time"] = info.State.FirstTime.UTC().Format(time.RFC3339Nano)
+	}
+	if !info.State.LastTime.IsZero() {
+		row["last_time"] = info.State.LastTime.UTC().Format(time.RFC3339Nano)
+	}
+	return row
+}
+
+// natsConsumerRow summarizes a consumer with its backlog
+func natsConsumerRow(info *nats.ConsumerInfo) map[string]interface{} {
+	filterSubject := info.Config.FilterSubject
+	if filterSubject == "" && len(info.Config.FilterSubjects) > 0 {
+		filterSubject = strings.Join(info.Config.FilterSubjects, ", ")
+	}
+	return map[string]interface{}{
+		"name":            info.Name,
+		"stream":          info.Stream,
+		"durable":         info.Config.Durable != "",
+		"filter_subject":  filterSubject,
+		"num_pending":     info.NumPending,
+		"num_ack_pending": info.NumAckPending,
+		"num_redelivered": info.NumRedelivered,
+		"num_waiting":     info.NumWaiting,
+		"delivered_seq":   info.Delivered.Stream,
+		"ack_floor_seq":   info.AckFloor.Stream,
+		"created":         info.Created.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// parseNATSCall parses js.Method(arguments), the arguments are JSON values
+func parseNATSCall(query string) (*natsCall, error) {
+	query = strings.TrimSpace(query)
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+
+	matches := natsCallPattern.FindStringSubmatch(query)
+	if matches == nil {
+		return nil, fmt.Errorf(`invalid JetStream query, expected js.Method(arguments) e.g. js.FetchMsg("ORDERS", 50)`)
+	}
+
+	call := &natsCall{Method: matches[1]}
+	argCount, ok := natsCallArgs[call.Method]
+	if !ok {
+		methods := make([]string, 0, len(natsCallArgs))
+		for method := range natsCallArgs {
+			methods = append(methods, "js."+method)
+		}
+		sort.Strings(methods)
+		return nil, fmt.Errorf("unsupported JetStream call js.%s, supported calls are %s", call.Method, strings.Join(methods, ", "))
+	}
+
+	if args := strings.TrimSpace(matches[2]); args != "" {
+		decoder := json.NewDecoder(strings.NewReader("[" + args + "]"))
+		decoder.UseNumber()
+		if err := decoder.Decode(&call.Args); err != nil {
+			return nil, fmt.Errorf("js.%s arguments must be JSON values (double-quoted strings, numbers or objects): %v", call.Method, err)
+		}
+	}
+	if len(call.Args) < argCount[0] || len(call.Args) > argCount[1] {
+		return nil, fmt.Errorf("js.%s takes %d to %d arguments, got %d", call.Method, argCount[0], argCount[1], len(call.Args))
+	}
+
+	return call, nil
+}
+
+// stringArg decodes the i-th argument as a non-empty string
+func (c *natsCall) stringArg(i int, name string) (string, error) {
+	var value string
+	if err := json.Unmarshal(c.Args[i], &value); err != nil || strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("the %s of js.%s must be a non-empty string", name, c.Method)
+	}
+	return value, nil
+}
+
+// uintArg decodes the i-th argument as a positive integer
+func (c *natsCall) uintArg(i int, name string) (uint64, error) {
+	value, err := strconv.ParseUint(strings.Trim(string(c.Args[i]), `"`), 10, 64)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("the %s of js.%s must be a positive integer", name, c.Method)
+	}
+	return value, nil
+}
+
+// optionsArg decodes the i-th argument, when given, into options. Unknown option names are rejected.
+func (c *natsCall) optionsArg(i int, options interface{}) error {
+	if i >= len(c.Args) {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(c.Args[i]))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(options); err != nil {
+		return fmt.Errorf("invalid js.%s options: %v", c.Method, err)
+	}
+	return nil
+}
+
+// publishData returns the payload of js.Publish: strings are sent as they are, other values as JSON
+func (c *natsCall) publishData() []byte {
+	var text string
+	if err := json.Unmarshal(c.Args[1], &text); err == nil {
+		return []byte(text)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, c.Args[1]); err == nil {
+		return compact.Bytes()
+	}
+	return c.Args[1]
+}
+
+// NATSDriver implements the DatabaseDriver interface for NATS JetStream
+type NATSDriver struct{}
+
+// NewNATSDriver creates a new NATS JetStream driver
+func NewNATSDriver() DatabaseDriver {
+	return &NATSDriver{}
+}
+
+// Connect connects to the NATS server and checks that JetStream is enabled
+func (d *NATSDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newNATSClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.NATSRequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		client.close()
+		return nil, fmt.Errorf("failed to connect to NATS JetStream: %v", err)
+	}
+
+	log.Printf("NATSDriver -> Connect -> Connected to NATS JetStream at %s", client.url)
+
+	conn := &Connection{
+		DB:          nil, // NATS is queried with the JetStream API, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect drains and closes the NATS connection
+func (d *NATSDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*NATSClient)
+	if !ok {
+		return fmt.Errorf("invalid NATS connection")
+	}
+	client.close()
+	return nil
+}
+
+// Ping checks if the NATS server is still reachable with JetStream enabled
+func (d *NATSDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*NATSClient)
+	if !ok {
+		return fmt.Errorf("invalid NATS connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("NATSDriver -> Ping -> JetStream check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the NATS connection is still valid
+func (d *NATSDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("NATSDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a JetStream call
+func (d *NATSDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*NATSClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeNATSCall(ctx, client, query)
+}
+
+// executeNATSCall runs a single JetStream call. Published messages are delivered immediately,
+// there is nothing to roll back.
+func executeNATSCall(ctx context.Context, client *NATSClient, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	call, err := parseNATSCall(query)
+	if err != nil {
+		result.Error = &dtos.QueryError{
+			Message: err.Error(),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("NATSDriver -> executeNATSCall -> Running js.%s", call.Method)
+
+	invalid := func(err error) *QueryExecutionResult {
+		result.Error = &dtos.QueryError{Message: err.Error(), Code: "INVALID_QUERY"}
+		return result
+	}
+	failed := func(err error) *QueryExecutionResult {
+		result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+		return result
+	}
+
+	switch call.Method {
+	case "Streams":
+		streams, err := client.listStreams(ctx)
+		if err != nil {
+			return failed(err)
+		}
+		rows := make([]map[string]interface{}, 0, len(streams))
+		for _, info := range streams {
+			rows = append(rows, natsStreamRow(info))
+		}
+		result.Result = rows
+
+	case "StreamInfo":
+		stream, err := call.stringArg(0, "stream name")
+		if err != nil {
+			return invalid(err)
+		}
+		info, err := client.js.StreamInfo(stream, nats.Context(ctx))
+		if err != nil {
+			return failed(err)
+		}
+		result.Result = []map[string]interface{}{natsStreamRow(info)}
+
+	case "Consumers":
+		stream, err := call.stringArg(0, "stream name")
+		if err != nil {
+			return invalid(err)
+		}
+		rows := make([]map[string]interface{}, 0)
+		for info := range client.js.Consumers(stream, nats.Context(ctx)) {
+			rows = append(rows, natsConsumerRow(info))
+		}
+		if err := ctx.Err(); err != nil {
+			return failed(fmt.Errorf("failed to list consumers: %v", err))
+		}
+		result.Result = rows
+
+	case "GetMsg":
+		stream, err := call.stringArg(0, "stream name")
+		if err != nil {
+			return invalid(err)
+		}
+		seq, err := call.uintArg(1, "sequence")
+		if err != nil {
+			return invalid(err)
+		}
+		msg, err := client.js.GetMsg(stream, seq, nats.Context(ctx))
+		if err != nil {
+			return failed(err)
+		}
+		result.Result = []map[string]interface{}{natsMessageRow(msg.Sequence, msg.Subject, msg.Data, msg.Time, msg.Header)}
+
+	case "GetLastMsg":
+		stream, err := call.stringArg(0, "stream name")
+		if err != nil {
+			return invalid(err)
+		}
+		subject, err := call.stringArg(1, "subject")
+		if err != nil {
+			return invalid(err)
+		}
+		msg, err := client.js.GetLastMsg(stream, subject, nats.Context(ctx))
+		if err != nil {
+			return failed(err)
+		}
+		result.Result = []map[string]interface{}{natsMessageRow(msg.Sequence, msg.Subject, msg.Data, msg.Time, msg.Header)}
+
+	case "CountMsg":
+		stream, err := call.stringArg(0, "stream name")
+		if err != nil {
+			return invalid(err)
+		}
+		var opts natsFetchOptions
+		if err := call.optionsArg(1, &opts); err != nil {
+			return invalid(err)
+		}
+		count, err := client.countMessages(ctx, stream, opts.Subject)
+		if err != nil {
+			return failed(err)
+		}
+		result.Result = map[string]interface{}{"count": count}
+
+	case "FetchMsg":
+		stream, err := call.stringArg(0, "stream name")
+		if err != nil {
+			return invalid(err)
+		}
+		limit, err := call.uintArg(1, "batch size")
+		if err != nil {
+			return invalid(err)
+		}
+		if limit > constants.NATSMaxFetchLimit {
+			limit = constants.NATSMaxFetchLimit
+		}
+		var opts natsFetchOptions
+		if err := call.optionsArg(2, &opts); err != nil {
+			return invalid(err)
+		}
+		rows, nextSeq, err := client.fetchMessages(ctx, stream, int(limit), opts)
+		if err != nil {
+			return failed(err)
+		}
+		if nextSeq > 0 {
+			rows[len(rows)-1][constants.NATSNextSequenceField] = nextSeq
+		}
+		result.Result = rows
+
+	case "Publish":
+		subject, err := call.stringArg(0, "subject")
+		if err != nil {
+			return invalid(err)
+		}
+		var opts natsPublishOptions
+		if err := call.optionsArg(2, &opts); err != nil {
+			return invalid(err)
+		}
+
+		msg := nats.NewMsg(subject)
+		msg.Data = call.publishData()
+		for key, value := range opts.Headers {
+			msg.Header.Set(key, value)
+		}
+		pubOpts := []nats.PubOpt{nats.Context(ctx)}
+		if opts.MsgID != "" {
+			pubOpts = append(pubOpts, nats.MsgId(opts.MsgID))
+		}
+
+		ack, err := client.js.PublishMsg(msg, pubOpts...)
+		if err != nil {
+			return failed(err)
+		}
+		message := fmt.Sprintf("1 message(s) published to stream %s at sequence %d", ack.Stream, ack.Sequence)
+		rowsAffected := 1
+		if ack.Duplicate {
+			message = fmt.Sprintf("Message %s was already published to stream %s, the duplicate was ignored", opts.MsgID, ack.Stream)
+			rowsAffected = 0
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected": rowsAffected,
+			"message":      message,
+			"stream":       ack.Stream,
+			"sequence":     ack.Sequence,
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// natsInjectSequence substitutes the next stream sequence into a paginated fetch.
+// Sequences are numbers, so a quoted placeholder is replaced with the bare number.
+func natsInjectSequence(query, sequence string) string {
+	const placeholder = "{{cursor_value}}"
+
+	if _, err := strconv.ParseUint(sequence, 10, 64); err != nil {
+		encoded, _ := json.Marshal(sequence)
+		sequence = string(encoded)
+	}
+	query = strings.ReplaceAll(query, `"`+placeholder+`"`, sequence)
+	return strings.ReplaceAll(query, placeholder, sequence)
+}
+
+// BeginTx returns a transaction that executes calls immediately.
+// JetStream has no transactions, every message is delivered as soon as it is published.
+func (d *NATSDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*NATSClient)
+	if !ok {
+		log.Printf("NATSDriver.BeginTx: Invalid NATS connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &NATSTransaction{
+		client: client,
+	}
+}
+
+// NATSTransaction implements the Transaction interface for NATS JetStream in autocommit mode
+type NATSTransaction struct {
+	client *NATSClient
+}
+
+// ExecuteQuery executes a call. Published messages are delivered immediately.
+func (t *NATSTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeNATSCall(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as calls are already applied
+func (t *NATSTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot take back published messages
+func (t *NATSTransaction) Rollback() error {
+	log.Printf("NATSTransaction -> Rollback -> JetStream has no transactions, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the streams of the account
+func (d *NATSDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("NATSDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewNATSSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a stream
+func (d *NATSDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("NATSDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewNATSSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches the latest messages of a stream
+func (d *NATSDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("NATSDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewNATSSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// NATSExecutor implements the DBExecutor interface for NATS JetStream
+type NATSExecutor struct {
+	client *NATSClient
+	conn   *Connection
+}
+
+// NewNATSExecutor creates a new NATS JetStream executor
+func NewNATSExecutor(conn *Connection) (*NATSExecutor, error) {
+	client, ok := conn.APIClient.(*NATSClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid NATS connection")
+	}
+
+	return &NATSExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for NATS as it doesn't use GORM
+func (e *NATSExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *NATSExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes a call and returns its result
+func (e *NATSExecutor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.NATSRequestTimeout)
+	defer cancel()
+	return executeNATSCall(ctx, e.client, query)
+}
+
+// Raw executes a JetStream call, *Not Used By DBManager*
+func (e *NATSExecutor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute JetStream call: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes a JetStream call, *Not Used By DBManager*
+func (e *NATSExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes a JetStream read and stores the rows in dest
+func (e *NATSExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes a JetStream read and stores the rows in dest
+func (e *NATSExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	result := e.run(query)
+	if result.Error != nil {
+		return fmt.Errorf("failed to execute JetStream call: %v", result.Error.Message)
+	}
+	rows, ok := result.Result.([]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("JetStream call did not return rows")
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the NATS connection is closed by the driver on disconnect
+func (e *NATSExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the NATS JetStream schema
+func (e *NATSExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &NATSDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for a stream
+func (e *NATSExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &NATSDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
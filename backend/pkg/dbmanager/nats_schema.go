@@ -0,0 +1,215 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsMessageColumns are the columns every message of a stream has
+var natsMessageColumns = map[string]ColumnInfo{
+	"sequence":  {Name: "sequence", Type: "int64", IsNullable: false, Comment: "position of the message in the stream"},
+	"subject":   {Name: "subject", Type: "string", IsNullable: false, Comment: "subject the message was published on"},
+	"data":      {Name: "data", Type: "string", IsNullable: true, Comment: "message payload"},
+	"timestamp": {Name: "timestamp", Type: "time.Time", IsNullable: false, Comment: "time the stream stored the message"},
+}
+
+// NATSSchemaFetcher implements schema fetching for NATS JetStream
+type NATSSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewNATSSchemaFetcher creates a new NATS JetStream schema fetcher
+func NewNATSSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &NATSSchemaFetcher{db: db}
+}
+
+// client returns the NATS client of the executor
+func (f *NATSSchemaFetcher) client(db DBExecutor) (*NATSClient, error) {
+	executor, ok := db.(*NATSExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid NATS connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema lists the streams of the account as tables with the message columns.
+// The table comment carries the stream's subjects and sequence range, which is what queries filter on.
+func (f *NATSSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("NATSSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("NATSSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, err := client.listStreams(ctx)
+	if err != nil {
+		log.Printf("NATSSchemaFetcher -> GetSchema -> Error listing streams: %v", err)
+		return nil, err
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, info := range streams {
+		name := info.Config.Name
+		if filterTables && !selected[name] {
+			continue
+		}
+
+		columns := make(map[string]ColumnInfo, len(natsMessageColumns))
+		for columnName, column := range natsMessageColumns {
+			columns[columnName] = column
+		}
+		tableData, _ := json.Marshal(columns)
+
+		schema.Tables[name] = TableSchema{
+			Name:        name,
+			Columns:     columns,
+			Indexes:     make(map[string]IndexInfo),
+			ForeignKeys: make(map[string]ForeignKey),
+			Constraints: make(map[string]ConstraintInfo),
+			Comment:     natsStreamComment(info),
+			RowCount:    int64(info.State.Msgs),
+			Checksum:    fmt.Sprintf("%x", md5.Sum(append(tableData, []byte(strings.Join(info.Config.Subjects, ","))...))),
+		}
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("NATSSchemaFetcher -> GetSchema -> Fetched %d streams", len(schema.Tables))
+	return schema, nil
+}
+
+// natsStreamComment describes a stream's subjects and contents for the table comment
+func natsStreamComment(info *nats.StreamInfo) string {
+	comment := fmt.Sprintf("stream with subjects %s, %d messages", strings.Join(info.Config.Subjects, ", "), info.State.Msgs)
+	if info.State.Msgs > 0 {
+		comment += fmt.Sprintf(", sequences %d to %d", info.State.FirstSeq, info.State.LastSeq)
+	}
+	if info.Config.Description != "" {
+		comment += ": " + info.Config.Description
+	}
+	return comment
+}
+
+// NATSPreviewQuery returns the call reading the latest messages of a stream
+func NATSPreviewQuery(stream string, limit int) string {
+	streamJSON, _ := json.Marshal(stream)
+	return fmt.Sprintf(`js.FetchMsg(%s, %d, {"last": true})`, streamJSON, limit)
+}
+
+// GetTableChecksum calculates a checksum for a stream's subjects, messages are data and are left out
+func (f *NATSSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("NATSSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := client.js.StreamInfo(table, nats.Context(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to get stream %s: %v", table, err)
+	}
+
+	subjects := append([]string{}, info.Config.Subjects...)
+	sort.Strings(subjects)
+	return fmt.Sprintf("%x", md5.Sum([]byte(table+";"+strings.Join(subjects, ";")))), nil
+}
+
+// FetchExampleRecords retrieves the latest messages of a stream
+func (f *NATSSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("NATSSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.js.StreamInfo(table, nats.Context(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch example records for %s: %v", table, err)
+	}
+
+	// Messages can be deleted from the middle of a stream, so sequences are read back from the last one
+	records := make([]map[string]interface{}, 0, limit)
+	for seq, attempts := info.State.LastSeq, 0; seq >= info.State.FirstSeq && seq > 0 && len(records) < limit && attempts < limit*10; seq, attempts = seq-1, attempts+1 {
+		msg, err := client.js.GetMsg(table, seq, nats.Context(ctx))
+		if err != nil {
+			if err == nats.ErrMsgNotFound {
+				continue
+			}
+			log.Printf("NATSSchemaFetcher -> FetchExampleRecords -> Error reading message %d of stream %s: %v", seq, table, err)
+			return nil, fmt.Errorf("failed to fetch example records for %s: %v", table, err)
+		}
+		records = append(records, natsMessageRow(msg.Sequence, msg.Subject, msg.Data, msg.Time, msg.Header))
+	}
+	return records, nil
+}
+
+// NATSSimplifier implements SchemaSimplifier for NATS JetStream message columns
+type NATSSimplifier struct{}
+
+// SimplifyDataType maps the message column types to readable type names
+func (s *NATSSimplifier) SimplifyDataType(dbType string) string {
+	switch dbType {
+	case "int64":
+		return "number"
+	case "string":
+		return "text"
+	case "time.Time":
+		return "timestamp"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints marks the sequence as the key of a stream
+func (s *NATSSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	if col.Name == "sequence" {
+		constraints = append(constraints, "PRIMARY KEY")
+	}
+	if !col.IsNullable {
+		constraints = append(constraints, "NOT NULL")
+	}
+
+	return constraints
+}
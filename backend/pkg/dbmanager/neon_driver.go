@@ -0,0 +1,208 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"neobase-ai/internal/constants"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// NeonDriver is the PostgreSQL driver for Neon serverless PostgreSQL.
+// Connections go through a small pgxpool, and connecting is retried because suspended computes take a moment to wake up.
+type NeonDriver struct {
+	PostgresDriver
+}
+
+func NewNeonDriver() DatabaseDriver {
+	return &NeonDriver{}
+}
+
+// buildNeonDSN builds a postgresql:// URL for a Neon endpoint. Neon only accepts TLS connections, and the
+// endpoint ID is passed in the options parameter so clients without SNI support reach the right compute.
+func buildNeonDSN(config ConnectionConfig) (string, []string, error) {
+	port := "5432"
+	if config.Port != nil && *config.Port != "" {
+		port = *config.Port
+	}
+
+	dsn := url.URL{
+		Scheme: "postgresql",
+		Host:   net.JoinHostPort(config.Host, port),
+		Path:   "/" + config.Database,
+	}
+	if config.Username != nil {
+		if config.Password != nil {
+			dsn.User = url.UserPassword(*config.Username, *config.Password)
+		} else {
+			dsn.User = url.User(*config.Username)
+		}
+	}
+
+	params := url.Values{}
+	sslMode := "require"
+	if config.UseSSL && config.SSLMode != nil && *config.SSLMode != "" && *config.SSLMode != "disable" {
+		sslMode = *config.SSLMode
+	}
+	params.Set("sslmode", sslMode)
+
+	var tempFiles []string
+	if config.UseSSL && config.SSLCertURL != nil && config.SSLKeyURL != nil && config.SSLRootCertURL != nil {
+		certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+		if err != nil {
+			return "", nil, err
+		}
+		tempFiles = certTempFiles
+		if certPath != "" {
+			params.Set("sslcert", certPath)
+		}
+		if keyPath != "" {
+			params.Set("sslkey", keyPath)
+		}
+		if rootCertPath != "" {
+			params.Set("sslrootcert", rootCertPath)
+		}
+	}
+
+	if endpointID := constants.GetNeonEndpointID(config.Host); endpointID != "" {
+		params.Set("options", fmt.Sprintf(constants.NeonEndpointOptionTemplate, endpointID))
+	}
+
+	dsn.RawQuery = params.Encode()
+	return dsn.String(), tempFiles, nil
+}
+
+// retryNeonConnect runs connect up to NeonConnectMaxAttempts times with exponential backoff
+func retryNeonConnect(operation string, connect func(ctx context.Context) error) error {
+	delay := constants.NeonConnectRetryBaseDelay
+	var err error
+	for attempt := 1; attempt <= constants.NeonConnectMaxAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.NeonConnectAttemptTimeout)
+		err = connect(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt < constants.NeonConnectMaxAttempts {
+			log.Printf("NeonDriver -> %s -> Attempt %d/%d failed, the compute may be waking up, retrying in %v: %v",
+				operation, attempt, constants.NeonConnectMaxAttempts, delay, err)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("failed to connect to Neon after %d attempts: %v", constants.NeonConnectMaxAttempts, err)
+}
+
+func (d *NeonDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	if config.SSHEnabled {
+		return nil, fmt.Errorf("SSH tunnels are not supported for Neon, connect to the Neon endpoint directly")
+	}
+
+	dsn, tempFiles, err := buildNeonDSN(config)
+	if err != nil {
+		return nil, err
+	}
+	cleanupTempFiles := func() {
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		cleanupTempFiles()
+		// Parse errors echo the DSN, which contains the password
+		return nil, fmt.Errorf("invalid Neon connection settings")
+	}
+	poolConfig.MaxConns = constants.NeonMaxPoolConnections
+	poolConfig.MaxConnIdleTime = constants.NeonPoolMaxConnIdleTime
+	poolConfig.HealthCheckPeriod = constants.NeonPoolHealthCheckPeriod
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		cleanupTempFiles()
+		return nil, fmt.Errorf("failed to create connection pool: %v", err)
+	}
+
+	if err := retryNeonConnect("Connect", pool.Ping); err != nil {
+		pool.Close()
+		cleanupTempFiles()
+		return nil, err
+	}
+
+	// database/sql on top of the pool, so the PostgreSQL query and schema code can be reused
+	sqlDB := stdlib.OpenDBFromPool(pool)
+	sqlDB.SetMaxOpenConns(constants.NeonMaxPoolConnections)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		sqlDB.Close()
+		pool.Close()
+		cleanupTempFiles()
+		return nil, fmt.Errorf("failed to create GORM connection: %v", err)
+	}
+
+	log.Printf("NeonDriver -> Connect -> Connected to Neon endpoint %s (pool size %d)", constants.GetNeonEndpointID(config.Host), constants.NeonMaxPoolConnections)
+
+	return &Connection{
+		DB:          gormDB,
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		TempFiles:   tempFiles,
+		PgxPool:     pool,
+	}, nil
+}
+
+func (d *NeonDriver) Disconnect(conn *Connection) error {
+	// Closing the *sql.DB does not close the pool it was opened from
+	if err := d.PostgresDriver.Disconnect(conn); err != nil {
+		return err
+	}
+	if pool, ok := conn.PgxPool.(*pgxpool.Pool); ok {
+		pool.Close()
+	}
+	return nil
+}
+
+// testNeonConnection checks Neon credentials, retrying while a suspended compute wakes up
+func testNeonConnection(config *ConnectionConfig) error {
+	dsn, tempFiles, err := buildNeonDSN(*config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+	}()
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return fmt.Errorf("invalid Neon connection settings")
+	}
+
+	return retryNeonConnect("TestConnection", func(ctx context.Context) error {
+		conn, err := pgx.ConnectConfig(ctx, connConfig)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(context.Background())
+		return conn.Ping(ctx)
+	})
+}
@@ -0,0 +1,388 @@
+package dbmanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godror/godror"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// oraclePLSQLBlockPattern matches statements that must be sent to Oracle as one block, semicolons included
+var oraclePLSQLBlockPattern = regexp.MustCompile(`(?is)^\s*(BEGIN|DECLARE|CREATE\s+(OR\s+REPLACE\s+)?(EDITIONABLE\s+|NONEDITIONABLE\s+)?(PROCEDURE|FUNCTION|TRIGGER|PACKAGE|TYPE)\b)`)
+
+// OracleDriver implements the DatabaseDriver interface for Oracle Database 19c+
+type OracleDriver struct{}
+
+// NewOracleDriver creates a new Oracle driver
+func NewOracleDriver() DatabaseDriver {
+	return &OracleDriver{}
+}
+
+// Connect establishes a connection to an Oracle database
+func (d *OracleDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	if config.SSHEnabled {
+		return nil, fmt.Errorf("SSH tunnels are not supported for Oracle connections")
+	}
+
+	sqlDB, err := openOracleDB(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to connect to database: %v", err)
+	}
+
+	// godror has no GORM dialect. Queries are raw SQL without bind variables,
+	// so the MySQL dialector is only used as a thin wrapper around *sql.DB.
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to initialise Oracle connection: %v", err)
+	}
+
+	sqlDB.SetMaxOpenConns(10)
+	sqlDB.SetMaxIdleConns(5)
+	sqlDB.SetConnMaxLifetime(time.Hour)
+
+	log.Printf("OracleDriver -> Connect -> Connected to Oracle at %s (service: %s)", config.Host, oracleServiceName(config))
+
+	conn := &Connection{
+		DB:          gormDB,
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+	}
+
+	return conn, nil
+}
+
+// openOracleDB opens a *sql.DB for the connection using an Easy Connect string (host:port/service_name)
+func openOracleDB(config ConnectionConfig) (*sql.DB, error) {
+	serviceName := oracleServiceName(config)
+	if serviceName == "" {
+		return nil, fmt.Errorf("a service name is required for Oracle connections")
+	}
+	if config.Username == nil || *config.Username == "" {
+		return nil, fmt.Errorf("a username is required for Oracle connections")
+	}
+
+	port := constants.OracleDefaultPort
+	if config.Port != nil && *config.Port != "" {
+		port = *config.Port
+	}
+
+	// Easy Connect Plus uses the tcps:// protocol prefix for TLS
+	connectString := fmt.Sprintf("%s:%s/%s", config.Host, port, serviceName)
+	if config.UseSSL && (config.SSLMode == nil || *config.SSLMode != "disable") {
+		connectString = "tcps://" + connectString
+	}
+
+	var params godror.ConnectionParams
+	params.Username = *config.Username
+	params.Password = godror.NewPassword(getValue(config.Password))
+	params.ConnectString = connectString
+	params.Timezone = time.UTC
+
+	return sql.OpenDB(godror.NewConnector(params)), nil
+}
+
+// oracleServiceName returns the Oracle service name, falling back to the database field
+func oracleServiceName(config ConnectionConfig) string {
+	if config.ServiceName != nil && *config.ServiceName != "" {
+		return *config.ServiceName
+	}
+	return config.Database
+}
+
+// Disconnect closes an Oracle connection
+func (d *OracleDriver) Disconnect(conn *Connection) error {
+	sqlDB, err := conn.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get SQL DB: %v", err)
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("failed to close connection: %v", err)
+	}
+
+	return nil
+}
+
+// Ping checks if the Oracle connection is alive
+func (d *OracleDriver) Ping(conn *Connection) error {
+	if conn == nil || conn.DB == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+
+	var result int
+	if err := conn.DB.Raw("SELECT 1 FROM DUAL").Scan(&result).Error; err != nil {
+		log.Printf("OracleDriver -> Ping -> Query test failed: %v", err)
+		return fmt.Errorf("connection test query failed: %v", err)
+	}
+
+	return nil
+}
+
+// IsAlive checks if the Oracle connection is still valid
+func (d *OracleDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("OracleDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a SQL or PL/SQL query on the Oracle database
+func (d *OracleDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	if conn == nil || conn.DB == nil {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeOracleStatements(ctx, conn.DB, query)
+}
+
+// executeOracleStatements runs each statement of the query and returns the result of the last one
+func executeOracleStatements(ctx context.Context, db *gorm.DB, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	for _, stmt := range splitOracleStatements(query) {
+		if ctx.Err() != nil {
+			result.Error = &dtos.QueryError{
+				Message: "Query execution cancelled",
+				Code:    "EXECUTION_CANCELLED",
+			}
+			return result
+		}
+
+		if isOracleReadStatement(stmt) {
+			var rows []map[string]interface{}
+			if err := db.WithContext(ctx).Raw(stmt).Scan(&rows).Error; err != nil {
+				result.Error = &dtos.QueryError{
+					Message: err.Error(),
+					Code:    "EXECUTION_ERROR",
+				}
+				return result
+			}
+
+			processedRows, _ := processOracleRecords(rows)
+			result.Result = map[string]interface{}{
+				"results": processedRows,
+			}
+		} else {
+			execResult := db.WithContext(ctx).Exec(stmt)
+			if execResult.Error != nil {
+				result.Error = &dtos.QueryError{
+					Message: execResult.Error.Error(),
+					Code:    "EXECUTION_ERROR",
+				}
+				return result
+			}
+
+			rowsAffected := execResult.RowsAffected
+			if rowsAffected > 0 {
+				result.Result = map[string]interface{}{
+					"rowsAffected": rowsAffected,
+					"message":      fmt.Sprintf("%d row(s) affected", rowsAffected),
+				}
+			} else {
+				result.Result = map[string]interface{}{
+					"message": "Query performed successfully",
+				}
+			}
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// splitOracleStatements splits a query into statements. Oracle rejects a trailing semicolon on SQL
+// statements but requires it after END of a PL/SQL block, so PL/SQL blocks are kept whole.
+func splitOracleStatements(query string) []string {
+	query = strings.TrimSpace(query)
+	// SQL*Plus terminates PL/SQL blocks with a "/" line, which is not part of the statement
+	query = strings.TrimSpace(strings.TrimSuffix(query, "/"))
+
+	if oraclePLSQLBlockPattern.MatchString(query) {
+		return []string{query}
+	}
+
+	var statements []string
+	for _, stmt := range splitClickHouseStatements(query) {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+// isOracleReadStatement reports whether a statement returns rows
+func isOracleReadStatement(stmt string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(stmt))
+	return strings.HasPrefix(upper, "SELECT") || strings.HasPrefix(upper, "WITH")
+}
+
+// processOracleRecords converts driver values into JSON friendly values
+func processOracleRecords(records []map[string]interface{}) ([]map[string]interface{}, error) {
+	if len(records) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	for i, record := range records {
+		for key, value := range record {
+			switch v := value.(type) {
+			case godror.Number:
+				// NUMBER values arrive as decimal strings
+				if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+					records[i][key] = n
+				} else if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+					records[i][key] = f
+				} else {
+					records[i][key] = string(v)
+				}
+			case []byte:
+				records[i][key] = string(v)
+			case time.Time:
+				records[i][key] = v.Format(time.RFC3339Nano)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// BeginTx starts a new transaction and sets a savepoint, so the query's changes can be undone with ROLLBACK TO
+func (d *OracleDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	if conn == nil || conn.DB == nil {
+		log.Printf("OracleDriver.BeginTx: Connection or DB is nil")
+		return nil
+	}
+
+	tx := conn.DB.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		log.Printf("Failed to begin transaction: %v", tx.Error)
+		return nil
+	}
+
+	if err := tx.Exec("SAVEPOINT " + constants.OracleSavepointName).Error; err != nil {
+		log.Printf("OracleDriver.BeginTx: Failed to set savepoint: %v", err)
+		tx.Rollback()
+		return nil
+	}
+
+	return &OracleTransaction{
+		tx:   tx,
+		conn: conn,
+	}
+}
+
+// OracleTransaction implements the Transaction interface for Oracle
+type OracleTransaction struct {
+	tx   *gorm.DB
+	conn *Connection
+}
+
+// ExecuteQuery executes a query within the transaction
+func (t *OracleTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	if t.tx == nil {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active transaction",
+				Code:    "TRANSACTION_ERROR",
+			},
+		}, nil
+	}
+	return executeOracleStatements(ctx, t.tx, query), nil
+}
+
+// Commit commits the transaction
+func (t *OracleTransaction) Commit() error {
+	if t.tx == nil {
+		return fmt.Errorf("no active transaction to commit")
+	}
+	return t.tx.Commit().Error
+}
+
+// Rollback undoes the query with ROLLBACK TO SAVEPOINT, then ends the transaction.
+// DDL commits implicitly in Oracle and removes the savepoint, so it cannot be rolled back.
+func (t *OracleTransaction) Rollback() error {
+	if t.tx == nil {
+		return fmt.Errorf("no active transaction to rollback")
+	}
+	if err := t.tx.Exec("ROLLBACK TO SAVEPOINT " + constants.OracleSavepointName).Error; err != nil {
+		log.Printf("OracleTransaction -> Rollback -> Failed to roll back to savepoint: %v", err)
+	}
+	return t.tx.Rollback().Error
+}
+
+// GetSchema retrieves the schema of the connecting user
+func (d *OracleDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewOracleSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a table
+func (d *OracleDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewOracleSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches example records from a table
+func (d *OracleDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewOracleSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
@@ -0,0 +1,356 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// oracleCurrentSchema is the schema of the connecting user. Oracle's data dictionary views
+// (ALL_*) list every object the user can see, so all queries are filtered by owner.
+const oracleCurrentSchema = "SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')"
+
+// OracleSchemaFetcher implements schema fetching for Oracle using the ALL_* data dictionary views.
+// The godror connection is wrapped in GORM without Oracle bind variable support, so values are
+// inlined with quoteOracleLiteral instead of passed as ? arguments. Columns are aliased in quoted
+// lowercase because Oracle returns unquoted aliases in uppercase.
+type OracleSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewOracleSchemaFetcher creates a new Oracle schema fetcher
+func NewOracleSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &OracleSchemaFetcher{db: db}
+}
+
+// oracleColumnRow is a row of ALL_TAB_COLUMNS
+type oracleColumnRow struct {
+	TableName     string `gorm:"column:table_name"`
+	ColumnName    string `gorm:"column:column_name"`
+	DataType      string `gorm:"column:data_type"`
+	CharLength    *int64 `gorm:"column:char_length"`
+	DataPrecision *int64 `gorm:"column:data_precision"`
+	DataScale     *int64 `gorm:"column:data_scale"`
+	Nullable      string `gorm:"column:nullable"`
+}
+
+// oracleConstraintRow is a column of a constraint from ALL_CONSTRAINTS joined with ALL_CONS_COLUMNS
+type oracleConstraintRow struct {
+	TableName      string  `gorm:"column:table_name"`
+	ConstraintName string  `gorm:"column:constraint_name"`
+	ConstraintType string  `gorm:"column:constraint_type"`
+	ColumnName     string  `gorm:"column:column_name"`
+	RefTable       *string `gorm:"column:ref_table"`
+	RefColumn      *string `gorm:"column:ref_column"`
+	DeleteRule     *string `gorm:"column:delete_rule"`
+}
+
+// oracleIndexRow is a column of an index from ALL_INDEXES joined with ALL_IND_COLUMNS
+type oracleIndexRow struct {
+	TableName  string `gorm:"column:table_name"`
+	IndexName  string `gorm:"column:index_name"`
+	Uniqueness string `gorm:"column:uniqueness"`
+	ColumnName string `gorm:"column:column_name"`
+}
+
+const oracleColumnsQuery = `
+        SELECT table_name AS "table_name", column_name AS "column_name", data_type AS "data_type",
+               char_length AS "char_length", data_precision AS "data_precision", data_scale AS "data_scale",
+               nullable AS "nullable"
+        FROM all_tab_columns
+        WHERE owner = ` + oracleCurrentSchema
+
+// GetSchema retrieves the schema for the selected tables
+func (f *OracleSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("OracleSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	// Tables of the connecting user's schema. NUM_ROWS is the optimizer statistic, counting rows would scan every table.
+	var tableList []struct {
+		TableName string `gorm:"column:table_name"`
+		NumRows   *int64 `gorm:"column:num_rows"`
+	}
+	tablesQuery := `
+        SELECT table_name AS "table_name", num_rows AS "num_rows"
+        FROM all_tables
+        WHERE owner = ` + oracleCurrentSchema + `
+        AND dropped = 'NO'
+        AND nested = 'NO'
+        AND secondary = 'N'
+        ORDER BY table_name`
+	if err := db.Query(tablesQuery, &tableList); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetSchema -> Error fetching tables: %v", err)
+		return nil, fmt.Errorf("failed to fetch tables: %v", err)
+	}
+
+	for _, table := range tableList {
+		if filterTables && !selected[table.TableName] {
+			continue
+		}
+		var rowCount int64
+		if table.NumRows != nil {
+			rowCount = *table.NumRows
+		}
+		schema.Tables[table.TableName] = TableSchema{
+			Name:        table.TableName,
+			Columns:     make(map[string]ColumnInfo),
+			Indexes:     make(map[string]IndexInfo),
+			ForeignKeys: make(map[string]ForeignKey),
+			Constraints: make(map[string]ConstraintInfo),
+			RowCount:    rowCount,
+		}
+	}
+
+	var viewList []struct {
+		ViewName string `gorm:"column:view_name"`
+	}
+	viewsQuery := `
+        SELECT view_name AS "view_name"
+        FROM all_views
+        WHERE owner = ` + oracleCurrentSchema + `
+        ORDER BY view_name`
+	if err := db.Query(viewsQuery, &viewList); err != nil {
+		// Views are optional context for the LLM, continue without them
+		log.Printf("OracleSchemaFetcher -> GetSchema -> Error fetching views: %v", err)
+	}
+	for _, view := range viewList {
+		if filterTables && !selected[view.ViewName] {
+			continue
+		}
+		schema.Views[view.ViewName] = ViewSchema{Name: view.ViewName}
+	}
+
+	// Columns, constraints and indexes are fetched for the whole schema at once, a round trip per table is slow
+	var columns []oracleColumnRow
+	if err := db.Query(oracleColumnsQuery+" ORDER BY table_name, column_id", &columns); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetSchema -> Error fetching columns: %v", err)
+		return nil, fmt.Errorf("failed to fetch columns: %v", err)
+	}
+
+	for _, col := range columns {
+		table, ok := schema.Tables[col.TableName]
+		if !ok {
+			continue
+		}
+		table.Columns[col.ColumnName] = ColumnInfo{
+			Name:       col.ColumnName,
+			Type:       formatOracleDataType(col),
+			IsNullable: col.Nullable == "Y",
+		}
+	}
+
+	if err := f.fetchConstraints(schema); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetSchema -> Error fetching constraints: %v", err)
+	}
+	if err := f.fetchIndexes(schema); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetSchema -> Error fetching indexes: %v", err)
+	}
+
+	for tableName, table := range schema.Tables {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("context cancelled: %v", err)
+		}
+
+		tableData, _ := json.Marshal(table)
+		table.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[tableName] = table
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("OracleSchemaFetcher -> GetSchema -> Fetched %d tables and %d views", len(schema.Tables), len(schema.Views))
+	return schema, nil
+}
+
+// fetchConstraints adds primary key, unique and foreign key constraints from ALL_CONSTRAINTS
+func (f *OracleSchemaFetcher) fetchConstraints(schema *SchemaInfo) error {
+	var rows []oracleConstraintRow
+	query := `
+        SELECT c.table_name AS "table_name", c.constraint_name AS "constraint_name",
+               c.constraint_type AS "constraint_type", cc.column_name AS "column_name",
+               rc.table_name AS "ref_table", rcc.column_name AS "ref_column", c.delete_rule AS "delete_rule"
+        FROM all_constraints c
+        JOIN all_cons_columns cc
+            ON cc.owner = c.owner AND cc.constraint_name = c.constraint_name
+        LEFT JOIN all_constraints rc
+            ON rc.owner = c.r_owner AND rc.constraint_name = c.r_constraint_name
+        LEFT JOIN all_cons_columns rcc
+            ON rcc.owner = rc.owner AND rcc.constraint_name = rc.constraint_name AND rcc.position = cc.position
+        WHERE c.owner = ` + oracleCurrentSchema + `
+        AND c.constraint_type IN ('P', 'U', 'R')
+        ORDER BY c.table_name, c.constraint_name, cc.position`
+	if err := f.db.Query(query, &rows); err != nil {
+		return fmt.Errorf("failed to fetch constraints: %v", err)
+	}
+
+	for _, row := range rows {
+		table, ok := schema.Tables[row.TableName]
+		if !ok {
+			continue
+		}
+
+		if row.ConstraintType == "R" {
+			fk := ForeignKey{
+				Name:       row.ConstraintName,
+				ColumnName: row.ColumnName,
+				RefTable:   getValue(row.RefTable),
+				RefColumn:  getValue(row.RefColumn),
+				OnDelete:   getValue(row.DeleteRule),
+				// Oracle has no ON UPDATE actions
+				OnUpdate: "NO ACTION",
+			}
+			// Composite keys are keyed per column so every column pair is kept
+			key := row.ConstraintName
+			if _, exists := table.ForeignKeys[key]; exists {
+				key = row.ConstraintName + "_" + row.ColumnName
+			}
+			table.ForeignKeys[key] = fk
+			continue
+		}
+
+		constraintType := "UNIQUE"
+		if row.ConstraintType == "P" {
+			constraintType = "PRIMARY KEY"
+		}
+		constraint := table.Constraints[row.ConstraintName]
+		constraint.Name = row.ConstraintName
+		constraint.Type = constraintType
+		constraint.Columns = append(constraint.Columns, row.ColumnName)
+		table.Constraints[row.ConstraintName] = constraint
+	}
+
+	return nil
+}
+
+// fetchIndexes adds indexes from ALL_INDEXES
+func (f *OracleSchemaFetcher) fetchIndexes(schema *SchemaInfo) error {
+	var rows []oracleIndexRow
+	query := `
+        SELECT i.table_name AS "table_name", i.index_name AS "index_name",
+               i.uniqueness AS "uniqueness", ic.column_name AS "column_name"
+        FROM all_indexes i
+        JOIN all_ind_columns ic
+            ON ic.index_owner = i.owner AND ic.index_name = i.index_name
+        WHERE i.table_owner = ` + oracleCurrentSchema + `
+        AND i.index_type NOT IN ('LOB', 'IOT - TOP')
+        ORDER BY i.table_name, i.index_name, ic.column_position`
+	if err := f.db.Query(query, &rows); err != nil {
+		return fmt.Errorf("failed to fetch indexes: %v", err)
+	}
+
+	for _, row := range rows {
+		table, ok := schema.Tables[row.TableName]
+		if !ok {
+			continue
+		}
+		index := table.Indexes[row.IndexName]
+		index.Name = row.IndexName
+		index.IsUnique = row.Uniqueness == "UNIQUE"
+		index.Columns = append(index.Columns, row.ColumnName)
+		table.Indexes[row.IndexName] = index
+	}
+
+	return nil
+}
+
+// formatOracleDataType adds length, precision and scale to an Oracle type, e.g. VARCHAR2(100) or NUMBER(12,2)
+func formatOracleDataType(col oracleColumnRow) string {
+	switch col.DataType {
+	case "VARCHAR2", "NVARCHAR2", "CHAR", "NCHAR", "RAW":
+		if col.CharLength != nil && *col.CharLength > 0 {
+			return fmt.Sprintf("%s(%d)", col.DataType, *col.CharLength)
+		}
+	case "NUMBER":
+		if col.DataPrecision == nil {
+			if col.DataScale != nil && *col.DataScale == 0 {
+				return "INTEGER"
+			}
+			return col.DataType
+		}
+		if col.DataScale != nil && *col.DataScale != 0 {
+			return fmt.Sprintf("NUMBER(%d,%d)", *col.DataPrecision, *col.DataScale)
+		}
+		return fmt.Sprintf("NUMBER(%d)", *col.DataPrecision)
+	}
+	return col.DataType
+}
+
+// GetTableChecksum calculates a checksum for a table's column definitions
+func (f *OracleSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	var columns []oracleColumnRow
+	query := oracleColumnsQuery + " AND table_name = " + quoteOracleLiteral(table) + " ORDER BY column_id"
+	if err := db.Query(query, &columns); err != nil {
+		log.Printf("OracleSchemaFetcher -> GetTableChecksum -> Error getting columns for %s: %v", table, err)
+		return "", fmt.Errorf("failed to get table definition: %v", err)
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("no table definition found for table: %s", table)
+	}
+
+	var definition strings.Builder
+	for _, col := range columns {
+		definition.WriteString(fmt.Sprintf("%s:%s:%s;", col.ColumnName, formatOracleDataType(col), col.Nullable))
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(definition.String()))), nil
+}
+
+// FetchExampleRecords retrieves sample records from a table
+func (f *OracleSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("OracleSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s FETCH FIRST %d ROWS ONLY", quoteOracleIdentifier(table), limit)
+
+	var records []map[string]interface{}
+	if err := db.QueryRows(query, &records); err != nil {
+		log.Printf("OracleSchemaFetcher -> FetchExampleRecords -> Error fetching records from table %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for table %s: %v", table, err)
+	}
+
+	return processOracleRecords(records)
+}
+
+// quoteOracleIdentifier double-quotes a table name, escaping embedded quotes.
+// Names come from the data dictionary, so their case is already what Oracle stores.
+func quoteOracleIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteOracleLiteral single-quotes a string value, escaping embedded quotes
+func quoteOracleLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
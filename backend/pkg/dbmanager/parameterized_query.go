@@ -0,0 +1,276 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"strings"
+	"time"
+)
+
+// namedParameterSegment is a piece of a query split on its :name placeholders.
+// Text segments have an empty Name, placeholder segments have no Text.
+type namedParameterSegment struct {
+	Text string
+	Name string
+}
+
+// splitNamedParameters splits a query on its :name placeholders. Quoted strings, quoted identifiers,
+// comments and PostgreSQL :: casts are kept as text, so a ':' inside them is never read as a placeholder.
+func splitNamedParameters(query string) []namedParameterSegment {
+	segments := []namedParameterSegment{}
+	var text strings.Builder
+
+	flushText := func() {
+		if text.Len() > 0 {
+			segments = append(segments, namedParameterSegment{Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// Copy the quoted string up to its closing quote, a doubled quote is an escaped one
+			end := i + 1
+			for end < len(query) {
+				if query[end] == c {
+					if end+1 < len(query) && query[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(query) {
+				end = len(query) - 1
+			}
+			text.WriteString(query[i : end+1])
+			i = end
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i - 1
+			}
+			text.WriteString(query[i : i+end+1])
+			i += end
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i - 3
+			}
+			text.WriteString(query[i : i+end+4])
+			i += end + 3
+		case c == ':' && i+1 < len(query) && query[i+1] == ':':
+			text.WriteString("::")
+			i++
+		case c == ':' && i+1 < len(query) && isParameterNameStart(query[i+1]):
+			end := i + 1
+			for end < len(query) && isParameterNameChar(query[end]) {
+				end++
+			}
+			flushText()
+			segments = append(segments, namedParameterSegment{Name: query[i+1 : end]})
+			i = end - 1
+		default:
+			text.WriteByte(c)
+		}
+	}
+	flushText()
+
+	return segments
+}
+
+func isParameterNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isParameterNameChar(c byte) bool {
+	return isParameterNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// NamedParameters returns the distinct :name placeholders of a query, in the order they first appear
+func NamedParameters(query string) []string {
+	names := []string{}
+	seen := make(map[string]bool)
+	for _, segment := range splitNamedParameters(query) {
+		if segment.Name != "" && !seen[segment.Name] {
+			seen[segment.Name] = true
+			names = append(names, segment.Name)
+		}
+	}
+	return names
+}
+
+// SupportsParameterBinding reports whether queries of a database type can be run with bound parameters
+func SupportsParameterBinding(dbType string) bool {
+	return parameterPlaceholder(dbType, 1) != ""
+}
+
+// parameterPlaceholder returns the driver's placeholder for the n-th bound value (1-based),
+// or "" when the database type is not queried through database/sql
+func parameterPlaceholder(dbType string, n int) string {
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeSpreadsheet, constants.DatabaseTypeGoogleSheets:
+		return fmt.Sprintf("$%d", n)
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino:
+		return "?"
+	case constants.DatabaseTypeOracle:
+		return fmt.Sprintf(":%d", n)
+	default:
+		return ""
+	}
+}
+
+// bindNamedParameters replaces each :name placeholder of a query with the driver's positional placeholder
+// and returns the values to bind, in order. Values are never written into the query text.
+func bindNamedParameters(query, dbType string, params map[string]interface{}) (string, []interface{}, error) {
+	if !SupportsParameterBinding(dbType) {
+		return "", nil, fmt.Errorf("parameterized queries are not supported for %s", dbType)
+	}
+
+	var bound strings.Builder
+	args := []interface{}{}
+	for _, segment := range splitNamedParameters(query) {
+		if segment.Name == "" {
+			bound.WriteString(segment.Text)
+			continue
+		}
+		value, ok := params[segment.Name]
+		if !ok {
+			return "", nil, fmt.Errorf("missing value for parameter :%s", segment.Name)
+		}
+		args = append(args, value)
+		bound.WriteString(parameterPlaceholder(dbType, len(args)))
+	}
+	return bound.String(), args, nil
+}
+
+// ExecuteParameterizedQuery runs a read-only query with :name placeholders, binding params through the driver.
+// The query goes through the same safety validation and result row cap as ExecuteQuery.
+func (m *Manager) ExecuteParameterizedQuery(ctx context.Context, chatID, query string, params map[string]interface{}) (*QueryExecutionResult, *dtos.QueryError) {
+	m.mu.RLock()
+	conn, exists := m.connections[chatID]
+	m.mu.RUnlock()
+	if !exists {
+		return nil, &dtos.QueryError{
+			Code:    "NO_CONNECTION_FOUND",
+			Message: "no connection found",
+			Details: "No connection found for chat ID: " + chatID,
+		}
+	}
+	if conn.DB == nil {
+		return nil, &dtos.QueryError{
+			Code:    "NOT_SUPPORTED",
+			Message: "parameterized queries are not supported",
+			Details: fmt.Sprintf("Parameterized queries are not supported for %s", conn.Config.Type),
+		}
+	}
+
+	if !constants.IsReadOnlyQuery(query, conn.Config.Type) {
+		return nil, &dtos.QueryError{
+			Code:    "SAFETY_VIOLATION",
+			Message: "Query blocked by safety validation",
+			Details: "Only read-only queries can be run with bound parameters",
+		}
+	}
+
+	// Saved queries may use the chat's schema aliases, parameter names are left as they are
+	query = ResolveSchemaAliases(query, conn.Config.Type, conn.Config.SchemaAliases)
+	boundQuery, args, err := bindNamedParameters(query, conn.Config.Type, params)
+	if err != nil {
+		return nil, &dtos.QueryError{
+			Code:    "INVALID_PARAMETERS",
+			Message: "invalid query parameters",
+			Details: err.Error(),
+		}
+	}
+
+	if validator := GetValidatorForDatabase(conn.Config.Type); validator != nil {
+		tableMetadata := make(map[string]TableSchema)
+		if m.schemaManager != nil {
+			if storage, err := m.schemaManager.storageService.Retrieve(ctx, chatID); err == nil && storage != nil && storage.FullSchema != nil {
+				tableMetadata = storage.FullSchema.Tables
+			}
+		}
+		if err := validator.ValidateSafety(boundQuery, "SELECT", tableMetadata); err != nil {
+			log.Printf("Manager -> ExecuteParameterizedQuery -> Query safety validation failed: %v", err)
+			return nil, &dtos.QueryError{
+				Code:    "SAFETY_VIOLATION",
+				Message: "Query blocked by safety validation",
+				Details: err.Error(),
+			}
+		}
+	}
+
+	truncateAt := 0
+	maxResultRows := resolveMaxResultRows(conn)
+	if limitedQuery, capped := applyResultRowLimit(boundQuery, conn.Config.Type, maxResultRows); capped {
+		boundQuery = limitedQuery
+		truncateAt = maxResultRows
+	}
+
+	sqlDB, err := conn.DB.DB()
+	if err != nil {
+		return nil, &dtos.QueryError{
+			Code:    "NO_CONNECTION_FOUND",
+			Message: "no connection found",
+			Details: err.Error(),
+		}
+	}
+
+	execCtx := ctx
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		execCtx, cancel = context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+	}
+
+	startTime := time.Now()
+	log.Printf("Manager -> ExecuteParameterizedQuery -> Executing query with %d bound values: %s", len(args), boundQuery)
+	rows, err := sqlDB.QueryContext(execCtx, boundQuery, args...)
+	if err != nil {
+		if execCtx.Err() == context.DeadlineExceeded {
+			return nil, &dtos.QueryError{
+				Code:    "QUERY_EXECUTION_TIMED_OUT",
+				Message: "query execution timed out",
+				Details: "Query execution timed out",
+			}
+		}
+		return nil, &dtos.QueryError{
+			Code:    "QUERY_EXECUTION_FAILED",
+			Message: err.Error(),
+			Details: "Failed to execute parameterized query",
+		}
+	}
+	defer rows.Close()
+
+	results, err := processRows(rows, startTime)
+	if err != nil {
+		return nil, &dtos.QueryError{
+			Code:    "RESULT_PROCESSING_FAILED",
+			Message: err.Error(),
+			Details: "Failed to process query results",
+		}
+	}
+
+	if err := m.UpdateLastUsed(chatID); err != nil {
+		log.Printf("Manager -> ExecuteParameterizedQuery -> Failed to update last used time: %v", err)
+	}
+
+	result := &QueryExecutionResult{
+		Result:        map[string]interface{}{"results": results},
+		ExecutionTime: int(time.Since(startTime).Milliseconds()),
+	}
+	if truncateAt > 0 && trimResultRows(result.Result, truncateAt) {
+		result.Truncated = true
+		result.TruncatedAt = truncateAt
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package dbmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"neobase-ai/internal/constants"
+)
+
+// PlanetscaleBranch is a branch of a PlanetScale database as returned by the PlanetScale API
+type PlanetscaleBranch struct {
+	Name         string    `json:"name"`
+	ParentBranch string    `json:"parent_branch"`
+	Production   bool      `json:"production"`
+	Ready        bool      `json:"ready"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// planetscaleDSNParams returns the extra MySQL DSN parameters of a PlanetScale connection.
+// PlanetScale only accepts TLS connections, and passwords are scoped to a single branch, so the
+// selected branch is sent as a connection attribute to tag the session with the branch it targets.
+func planetscaleDSNParams(config ConnectionConfig) string {
+	if config.Type != constants.DatabaseTypePlanetscale {
+		return ""
+	}
+
+	params := ""
+	if !config.UseSSL {
+		params += "&tls=true"
+	}
+
+	branch := constants.PlanetscaleDefaultBranch
+	if config.PlanetscaleBranch != nil && *config.PlanetscaleBranch != "" {
+		branch = *config.PlanetscaleBranch
+	}
+	params += "&connectionAttributes=" + url.QueryEscape(constants.PlanetscaleBranchAttrName+":"+branch)
+	return params
+}
+
+// ListPlanetscaleBranches lists the branches of a PlanetScale database using a service token
+func ListPlanetscaleBranches(ctx context.Context, organization, database, serviceTokenID, serviceToken string) ([]PlanetscaleBranch, error) {
+	if organization == "" || database == "" {
+		return nil, fmt.Errorf("planetscale organization and database are required")
+	}
+	if serviceTokenID == "" || serviceToken == "" {
+		return nil, fmt.Errorf("planetscale service token is required to list branches")
+	}
+
+	endpoint := fmt.Sprintf("%s/organizations/%s/databases/%s/branches",
+		constants.PlanetscaleAPIBaseURL, url.PathEscape(organization), url.PathEscape(database))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create planetscale request: %v", err)
+	}
+	req.Header.Set("Authorization", serviceTokenID+":"+serviceToken)
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call planetscale API: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read planetscale response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("planetscale API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data []PlanetscaleBranch `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse planetscale branches: %v", err)
+	}
+	return result.Data, nil
+}
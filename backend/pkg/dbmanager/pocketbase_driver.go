@@ -0,0 +1,781 @@
+package dbmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PocketBaseClient is a minimal client for the PocketBase REST API, authenticated as a superuser
+type PocketBaseClient struct {
+	baseURL    string
+	identity   string
+	password   string
+	httpClient *http.Client
+
+	tokenMu sync.Mutex
+	token   string
+}
+
+// pocketBaseListResponse is a page of the list records endpoint
+type pocketBaseListResponse struct {
+	Page       int                      `json:"page"`
+	PerPage    int                      `json:"perPage"`
+	TotalItems int                      `json:"totalItems"`
+	TotalPages int                      `json:"totalPages"`
+	Items      []map[string]interface{} `json:"items"`
+}
+
+// pocketBaseListOptions are the options of a records list, sent as query parameters.
+// Count is not a PocketBase option: it turns the list into a count of the matching records.
+type pocketBaseListOptions struct {
+	Filter  string `json:"filter,omitempty"`
+	Sort    string `json:"sort,omitempty"`
+	Expand  string `json:"expand,omitempty"`
+	Fields  string `json:"fields,omitempty"`
+	Page    int    `json:"page,omitempty"`
+	PerPage int    `json:"perPage,omitempty"`
+	Count   bool   `json:"count,omitempty"`
+}
+
+// pocketBaseRequest is a parsed PocketBase query, e.g. GET /api/collections/orders/records {"filter": "..."}
+type pocketBaseRequest struct {
+	Method     string
+	Collection string
+	RecordID   string
+	Body       string
+}
+
+// pocketBaseRequestPattern matches METHOD /api/collections[/name/records[/id]] followed by optional JSON
+var pocketBaseRequestPattern = regexp.MustCompile(`(?is)^(GET|POST|PATCH|DELETE)\s+/api/collections(?:/([^\s/?]+)/records(?:/([^\s/?]+))?)?/?\s*(.*)$`)
+
+// newPocketBaseClient creates a client for the PocketBase instance in the connection config.
+// The host may be a full URL, otherwise the URL is built from the host, port and SSL setting.
+func newPocketBaseClient(config ConnectionConfig) (*PocketBaseClient, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("a PocketBase URL is required")
+	}
+	if config.Username == nil || *config.Username == "" || config.Password == nil || *config.Password == "" {
+		return nil, fmt.Errorf("PocketBase superuser email and password are required")
+	}
+
+	baseURL := strings.TrimRight(config.Host, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		scheme := "http"
+		if config.UseSSL {
+			scheme = "https"
+		}
+		port := constants.PocketBaseDefaultPort
+		if config.Port != nil && *config.Port != "" {
+			port = *config.Port
+		}
+		baseURL = scheme + "://" + net.JoinHostPort(baseURL, port)
+	}
+
+	return &PocketBaseClient{
+		baseURL:  baseURL,
+		identity: *config.Username,
+		password: *config.Password,
+		httpClient: &http.Client{
+			Timeout: constants.PocketBaseRequestTimeout,
+		},
+	}, nil
+}
+
+// authenticate signs in as a superuser. PocketBase 0.23+ keeps superusers in the _superusers collection,
+// older versions have a separate admins API, which is tried when the collection does not exist.
+func (c *PocketBaseClient) authenticate(ctx context.Context) error {
+	body := map[string]string{"identity": c.identity, "password": c.password}
+	var resp struct {
+		Token string `json:"token"`
+	}
+
+	status, err := c.send(ctx, http.MethodPost, "/api/collections/_superusers/auth-with-password", nil, body, "", &resp)
+	if status == http.StatusNotFound {
+		status, err = c.send(ctx, http.MethodPost, "/api/admins/auth-with-password", nil, body, "", &resp)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to authenticate as PocketBase superuser: %v", err)
+	}
+	if resp.Token == "" {
+		return fmt.Errorf("PocketBase did not return an auth token")
+	}
+
+	c.tokenMu.Lock()
+	c.token = resp.Token
+	c.tokenMu.Unlock()
+	return nil
+}
+
+// do sends an authenticated request and decodes the JSON response into out.
+// An expired token is renewed once with the stored credentials.
+func (c *PocketBaseClient) do(ctx context.Context, method, path string, params url.Values, body interface{}, out interface{}) error {
+	c.tokenMu.Lock()
+	token := c.token
+	c.tokenMu.Unlock()
+
+	if token == "" {
+		if err := c.authenticate(ctx); err != nil {
+			return err
+		}
+		return c.do(ctx, method, path, params, body, out)
+	}
+
+	status, err := c.send(ctx, method, path, params, body, token, out)
+	if status == http.StatusUnauthorized {
+		if err := c.authenticate(ctx); err != nil {
+			return err
+		}
+		c.tokenMu.Lock()
+		token = c.token
+		c.tokenMu.Unlock()
+		_, err = c.send(ctx, method, path, params, body, token, out)
+	}
+	return err
+}
+
+// send sends a single request and returns its HTTP status
+func (c *PocketBaseClient) send(ctx context.Context, method, path string, params url.Values, body interface{}, token string, out interface{}) (int, error) {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode PocketBase request: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create PocketBase request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("PocketBase request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read PocketBase response: %v", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.StatusCode, parsePocketBaseError(resp.StatusCode, respBody)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return resp.StatusCode, nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode PocketBase response: %v", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// parsePocketBaseError turns a PocketBase error body into an error.
+// PocketBase returns {"status": 400, "message": "...", "data": {"field": {"code": "...", "message": "..."}}}.
+func parsePocketBaseError(status int, body []byte) error {
+	var payload struct {
+		Message string `json:"message"`
+		Data    map[string]struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Message == "" {
+		return fmt.Errorf("PocketBase request failed with HTTP %d", status)
+	}
+
+	details := make([]string, 0, len(payload.Data))
+	for field, fieldErr := range payload.Data {
+		details = append(details, fmt.Sprintf("%s: %s", field, fieldErr.Message))
+	}
+	if len(details) > 0 {
+		return fmt.Errorf("PocketBase error (HTTP %d): %s %s", status, payload.Message, strings.Join(details, "; "))
+	}
+	return fmt.Errorf("PocketBase error (HTTP %d): %s", status, payload.Message)
+}
+
+// recordsPath returns the records path of a collection, or of one of its records
+func (c *PocketBaseClient) recordsPath(collection, recordID string) string {
+	path := "/api/collections/" + url.PathEscape(collection) + "/records"
+	if recordID != "" {
+		path += "/" + url.PathEscape(recordID)
+	}
+	return path
+}
+
+// listCollections fetches the collections with their field definitions
+func (c *PocketBaseClient) listCollections(ctx context.Context) ([]pocketBaseCollection, error) {
+	params := url.Values{}
+	params.Set("perPage", strconv.Itoa(constants.PocketBaseMaxPerPage))
+
+	var resp struct {
+		Items []pocketBaseCollection `json:"items"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/collections", params, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// listRecords fetches a single page of records. The total is only counted when withTotal is set.
+func (c *PocketBaseClient) listRecords(ctx context.Context, collection string, opts pocketBaseListOptions, withTotal bool) (*pocketBaseListResponse, error) {
+	params := url.Values{}
+	if opts.Filter != "" {
+		params.Set("filter", opts.Filter)
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if opts.Expand != "" {
+		params.Set("expand", opts.Expand)
+	}
+	if opts.Fields != "" {
+		params.Set("fields", opts.Fields)
+	}
+	if opts.Page > 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		params.Set("perPage", strconv.Itoa(opts.PerPage))
+	}
+	if !withTotal {
+		params.Set("skipTotal", "1")
+	}
+
+	var resp pocketBaseListResponse
+	if err := c.do(ctx, http.MethodGet, c.recordsPath(collection, ""), params, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// countRecords returns the number of records matching a filter
+func (c *PocketBaseClient) countRecords(ctx context.Context, collection, filter string) (int, error) {
+	page, err := c.listRecords(ctx, collection, pocketBaseListOptions{Filter: filter, Fields: "id", PerPage: 1}, true)
+	if err != nil {
+		return 0, err
+	}
+	return page.TotalItems, nil
+}
+
+// ping checks the credentials by listing the collections
+func (c *PocketBaseClient) ping(ctx context.Context) error {
+	if err := c.authenticate(ctx); err != nil {
+		return err
+	}
+	_, err := c.listCollections(ctx)
+	return err
+}
+
+// PocketBaseDriver implements the DatabaseDriver interface for PocketBase instances
+type PocketBaseDriver struct{}
+
+// NewPocketBaseDriver creates a new PocketBase driver
+func NewPocketBaseDriver() DatabaseDriver {
+	return &PocketBaseDriver{}
+}
+
+// Connect signs in as a superuser and returns a connection holding the REST client
+func (d *PocketBaseDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newPocketBaseClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.PocketBaseRequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to PocketBase: %v", err)
+	}
+
+	log.Printf("PocketBaseDriver -> Connect -> Connected to PocketBase at %s", client.baseURL)
+
+	conn := &Connection{
+		DB:          nil, // PocketBase is accessed over REST, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect releases the idle HTTP connections of the client
+func (d *PocketBaseDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*PocketBaseClient)
+	if !ok {
+		return fmt.Errorf("invalid PocketBase connection")
+	}
+	client.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Ping checks if the PocketBase instance is still reachable with the stored credentials
+func (d *PocketBaseDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*PocketBaseClient)
+	if !ok {
+		return fmt.Errorf("invalid PocketBase connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.listCollections(ctx); err != nil {
+		log.Printf("PocketBaseDriver -> Ping -> PocketBase check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the PocketBase connection is still valid
+func (d *PocketBaseDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("PocketBaseDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a PocketBase request
+func (d *PocketBaseDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*PocketBaseClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executePocketBaseRequest(ctx, client, query)
+}
+
+// parsePocketBaseRequest parses METHOD /api/collections/name/records[/id] {json}
+func parsePocketBaseRequest(query string) (*pocketBaseRequest, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	query = strings.TrimSpace(query)
+
+	matches := pocketBaseRequestPattern.FindStringSubmatch(query)
+	if matches == nil {
+		return nil, fmt.Errorf(`invalid PocketBase query, expected GET, POST, PATCH or DELETE /api/collections/{collection}/records[/{id}] followed by JSON options or body`)
+	}
+
+	req := &pocketBaseRequest{
+		Method:     strings.ToUpper(matches[1]),
+		Collection: matches[2],
+		RecordID:   matches[3],
+		Body:       strings.TrimSpace(matches[4]),
+	}
+	for _, part := range []*string{&req.Collection, &req.RecordID} {
+		if unescaped, err := url.PathUnescape(*part); err == nil {
+			*part = unescaped
+		}
+	}
+
+	switch {
+	case req.Collection == "":
+		if req.Method != http.MethodGet {
+			return nil, fmt.Errorf("collections cannot be changed through queries, use the PocketBase dashboard")
+		}
+	case req.Method == http.MethodPost && req.RecordID != "":
+		return nil, fmt.Errorf("POST creates a record, send it to /api/collections/%s/records without a record ID", req.Collection)
+	case (req.Method == http.MethodPatch || req.Method == http.MethodDelete) && req.RecordID == "":
+		return nil, fmt.Errorf("%s needs the ID of the record to change: /api/collections/%s/records/{id}", req.Method, req.Collection)
+	case (req.Method == http.MethodPost || req.Method == http.MethodPatch) && req.Body == "":
+		return nil, fmt.Errorf("%s needs a JSON body with the record's field values", req.Method)
+	}
+
+	return req, nil
+}
+
+// executePocketBaseRequest runs a single PocketBase request. Writes are applied immediately:
+// PocketBase's REST API has no transactions, so there is nothing to roll back.
+func executePocketBaseRequest(ctx context.Context, client *PocketBaseClient, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	req, err := parsePocketBaseRequest(query)
+	if err != nil {
+		result.Error = &dtos.QueryError{
+			Message: err.Error(),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("PocketBaseDriver -> executePocketBaseRequest -> %s on collection %q", req.Method, req.Collection)
+
+	switch {
+	case req.Collection == "":
+		collections, err := client.listCollections(ctx)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		rows := make([]map[string]interface{}, 0, len(collections))
+		for _, collection := range collections {
+			rows = append(rows, map[string]interface{}{
+				"id":     collection.ID,
+				"name":   collection.Name,
+				"type":   collection.Type,
+				"fields": len(collection.fieldList()),
+			})
+		}
+		result.Result = rows
+
+	case req.Method == http.MethodGet && req.RecordID == "":
+		var opts pocketBaseListOptions
+		if req.Body != "" {
+			if err := json.Unmarshal([]byte(req.Body), &opts); err != nil {
+				result.Error = &dtos.QueryError{Message: fmt.Sprintf("list options must be a JSON object: %v", err), Code: "INVALID_QUERY"}
+				return result
+			}
+		}
+		if opts.Count {
+			count, err := client.countRecords(ctx, req.Collection, opts.Filter)
+			if err != nil {
+				result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+				return result
+			}
+			result.Result = map[string]interface{}{"count": count}
+			break
+		}
+		rows, err := listPocketBaseRecords(ctx, client, req.Collection, opts)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = rows
+
+	case req.Method == http.MethodGet:
+		var opts pocketBaseListOptions
+		if req.Body != "" {
+			if err := json.Unmarshal([]byte(req.Body), &opts); err != nil {
+				result.Error = &dtos.QueryError{Message: fmt.Sprintf("options must be a JSON object: %v", err), Code: "INVALID_QUERY"}
+				return result
+			}
+		}
+		params := url.Values{}
+		if opts.Expand != "" {
+			params.Set("expand", opts.Expand)
+		}
+		if opts.Fields != "" {
+			params.Set("fields", opts.Fields)
+		}
+		var record map[string]interface{}
+		if err := client.do(ctx, http.MethodGet, client.recordsPath(req.Collection, req.RecordID), params, nil, &record); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = []map[string]interface{}{record}
+
+	case req.Method == http.MethodPost || req.Method == http.MethodPatch:
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(req.Body), &fields); err != nil {
+			result.Error = &dtos.QueryError{Message: fmt.Sprintf("the body must be a JSON object of field values: %v", err), Code: "INVALID_QUERY"}
+			return result
+		}
+		if len(fields) == 0 {
+			result.Error = &dtos.QueryError{Message: "the body has no field values", Code: "INVALID_QUERY"}
+			return result
+		}
+
+		var record map[string]interface{}
+		if err := client.do(ctx, req.Method, client.recordsPath(req.Collection, req.RecordID), nil, fields, &record); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+
+		verb := "created"
+		if req.Method == http.MethodPatch {
+			verb = "updated"
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected": 1,
+			"message":      fmt.Sprintf("1 record(s) %s", verb),
+			"records":      []map[string]interface{}{record},
+		}
+
+	case req.Method == http.MethodDelete:
+		if err := client.do(ctx, http.MethodDelete, client.recordsPath(req.Collection, req.RecordID), nil, nil, nil); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = map[string]interface{}{
+			"rowsAffected": 1,
+			"message":      "1 record(s) deleted",
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// listPocketBaseRecords follows the pages of a list until PocketBaseDefaultMaxRecords is reached.
+// A list with page or perPage fetches one page only and returns the next page number on the last record,
+// so the cursor pagination of the chat can request it.
+func listPocketBaseRecords(ctx context.Context, client *PocketBaseClient, collection string, opts pocketBaseListOptions) ([]map[string]interface{}, error) {
+	if opts.PerPage > constants.PocketBaseMaxPerPage {
+		opts.PerPage = constants.PocketBaseMaxPerPage
+	}
+
+	singlePage := opts.Page > 0 || opts.PerPage > 0
+	if !singlePage {
+		opts.PerPage = constants.PocketBaseMaxPerPage
+	}
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+
+	rows := make([]map[string]interface{}, 0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("query execution cancelled: %v", err)
+		}
+
+		page, err := client.listRecords(ctx, collection, opts, false)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, page.Items...)
+
+		// Totals are skipped for speed, a full page means there may be more
+		hasMore := opts.PerPage > 0 && len(page.Items) >= opts.PerPage
+		if singlePage {
+			if hasMore && len(rows) > 0 {
+				rows[len(rows)-1][constants.PocketBaseNextPageField] = opts.Page + 1
+			}
+			break
+		}
+		if !hasMore || len(rows) >= constants.PocketBaseDefaultMaxRecords {
+			break
+		}
+		opts.Page++
+	}
+
+	if !singlePage && len(rows) > constants.PocketBaseDefaultMaxRecords {
+		rows = rows[:constants.PocketBaseDefaultMaxRecords]
+	}
+	return rows, nil
+}
+
+// pocketBaseInjectPage substitutes the next page number into a paginated list.
+// The LLM may or may not quote the placeholder, page numbers are always sent as JSON numbers.
+func pocketBaseInjectPage(query, page string) string {
+	const placeholder = "{{cursor_value}}"
+
+	value := page
+	if _, err := strconv.Atoi(page); err != nil {
+		encoded, _ := json.Marshal(page)
+		value = string(encoded)
+	}
+	query = strings.ReplaceAll(query, `"`+placeholder+`"`, value)
+	query = strings.ReplaceAll(query, "'"+placeholder+"'", value)
+	return strings.ReplaceAll(query, placeholder, value)
+}
+
+// BeginTx returns a transaction that executes requests immediately.
+// PocketBase has no transactions over its REST API, every write is committed as soon as it succeeds.
+func (d *PocketBaseDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*PocketBaseClient)
+	if !ok {
+		log.Printf("PocketBaseDriver.BeginTx: Invalid PocketBase connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &PocketBaseTransaction{
+		client: client,
+	}
+}
+
+// PocketBaseTransaction implements the Transaction interface for PocketBase in autocommit mode
+type PocketBaseTransaction struct {
+	client *PocketBaseClient
+}
+
+// ExecuteQuery executes a request. Writes are applied as soon as the API accepts them.
+func (t *PocketBaseTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executePocketBaseRequest(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as requests are already applied
+func (t *PocketBaseTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo requests that already ran on PocketBase
+func (t *PocketBaseTransaction) Rollback() error {
+	log.Printf("PocketBaseTransaction -> Rollback -> PocketBase has no transactions, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the collections and fields of the instance
+func (d *PocketBaseDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("PocketBaseDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewPocketBaseSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a collection
+func (d *PocketBaseDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("PocketBaseDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewPocketBaseSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches example records from a collection
+func (d *PocketBaseDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("PocketBaseDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewPocketBaseSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// PocketBaseExecutor implements the DBExecutor interface for PocketBase
+type PocketBaseExecutor struct {
+	client *PocketBaseClient
+	conn   *Connection
+}
+
+// NewPocketBaseExecutor creates a new PocketBase executor
+func NewPocketBaseExecutor(conn *Connection) (*PocketBaseExecutor, error) {
+	client, ok := conn.APIClient.(*PocketBaseClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid PocketBase connection")
+	}
+
+	return &PocketBaseExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for PocketBase as it doesn't use GORM
+func (e *PocketBaseExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *PocketBaseExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes a request and returns its result
+func (e *PocketBaseExecutor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.PocketBaseRequestTimeout)
+	defer cancel()
+	return executePocketBaseRequest(ctx, e.client, query)
+}
+
+// Raw executes a PocketBase request, *Not Used By DBManager*
+func (e *PocketBaseExecutor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute PocketBase request: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes a PocketBase request, *Not Used By DBManager*
+func (e *PocketBaseExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes a PocketBase read and stores the records in dest
+func (e *PocketBaseExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes a PocketBase read and stores the records in dest
+func (e *PocketBaseExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	result := e.run(query)
+	if result.Error != nil {
+		return fmt.Errorf("failed to execute PocketBase request: %v", result.Error.Message)
+	}
+	rows, ok := result.Result.([]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("PocketBase request did not return records")
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the HTTP client is released by the driver on disconnect
+func (e *PocketBaseExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the PocketBase schema
+func (e *PocketBaseExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &PocketBaseDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for a PocketBase collection
+func (e *PocketBaseExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &PocketBaseDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
@@ -0,0 +1,363 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pocketBaseCollection is a collection returned by GET /api/collections.
+// PocketBase 0.23+ lists every field in "fields", older versions use "schema" and leave out the system fields.
+type pocketBaseCollection struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	System  bool              `json:"system"`
+	Fields  []pocketBaseField `json:"fields"`
+	Schema  []pocketBaseField `json:"schema"`
+	Indexes []string          `json:"indexes"`
+}
+
+// pocketBaseField is a field of a PocketBase collection. Older versions nest the type settings in "options".
+type pocketBaseField struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Type         string                 `json:"type"`
+	System       bool                   `json:"system"`
+	Hidden       bool                   `json:"hidden"`
+	Required     bool                   `json:"required"`
+	Values       []string               `json:"values"`
+	CollectionID string                 `json:"collectionId"`
+	MaxSelect    int                    `json:"maxSelect"`
+	Options      map[string]interface{} `json:"options,omitempty"`
+}
+
+// fieldList returns the fields of a collection for every PocketBase version,
+// adding the system fields that versions before 0.23 do not list.
+func (c pocketBaseCollection) fieldList() []pocketBaseField {
+	if len(c.Fields) > 0 {
+		return c.Fields
+	}
+
+	fields := []pocketBaseField{{Name: "id", Type: "text", System: true, Required: true}}
+	for _, field := range c.Schema {
+		if values, ok := field.Options["values"].([]interface{}); ok && len(field.Values) == 0 {
+			for _, value := range values {
+				if s, ok := value.(string); ok {
+					field.Values = append(field.Values, s)
+				}
+			}
+		}
+		if collectionID, ok := field.Options["collectionId"].(string); ok && field.CollectionID == "" {
+			field.CollectionID = collectionID
+		}
+		if maxSelect, ok := field.Options["maxSelect"].(float64); ok && field.MaxSelect == 0 {
+			field.MaxSelect = int(maxSelect)
+		}
+		fields = append(fields, field)
+	}
+	if c.Type != "view" {
+		fields = append(fields,
+			pocketBaseField{Name: "created", Type: "autodate", System: true},
+			pocketBaseField{Name: "updated", Type: "autodate", System: true},
+		)
+	}
+	return fields
+}
+
+// PocketBaseSchemaFetcher implements schema fetching for PocketBase instances using the collections API
+type PocketBaseSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewPocketBaseSchemaFetcher creates a new PocketBase schema fetcher
+func NewPocketBaseSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &PocketBaseSchemaFetcher{db: db}
+}
+
+// client returns the REST client of the executor
+func (f *PocketBaseSchemaFetcher) client(db DBExecutor) (*PocketBaseClient, error) {
+	executor, ok := db.(*PocketBaseExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid PocketBase connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema retrieves the collections and fields of the instance.
+// System collections (_superusers, _mfas, ...) are left out.
+func (f *PocketBaseSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("PocketBaseSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("PocketBaseSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := client.listCollections(ctx)
+	if err != nil {
+		log.Printf("PocketBaseSchemaFetcher -> GetSchema -> Error fetching collections: %v", err)
+		return nil, fmt.Errorf("failed to fetch collections: %v", err)
+	}
+
+	// Relation fields reference collections by ID
+	collectionNames := make(map[string]string, len(collections))
+	for _, collection := range collections {
+		collectionNames[collection.ID] = collection.Name
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, collection := range collections {
+		if strings.HasPrefix(collection.Name, "_") {
+			continue
+		}
+		if filterTables && !selected[collection.Name] {
+			continue
+		}
+
+		tableSchema := buildPocketBaseTableSchema(collection, collectionNames)
+		if count, err := client.countRecords(ctx, collection.Name, ""); err == nil {
+			tableSchema.RowCount = int64(count)
+		} else {
+			log.Printf("PocketBaseSchemaFetcher -> GetSchema -> Error counting records of %s: %v", collection.Name, err)
+		}
+
+		tableData, _ := json.Marshal(tableSchema)
+		tableSchema.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[collection.Name] = tableSchema
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("PocketBaseSchemaFetcher -> GetSchema -> Fetched %d collections", len(schema.Tables))
+	return schema, nil
+}
+
+// buildPocketBaseTableSchema converts a collection into a TableSchema.
+// Hidden and password fields are never exposed to the LLM.
+func buildPocketBaseTableSchema(collection pocketBaseCollection, collectionNames map[string]string) TableSchema {
+	comment := fmt.Sprintf("%s collection", collection.Type)
+	if collection.Type == "view" {
+		comment += " (read-only)"
+	}
+
+	tableSchema := TableSchema{
+		Name:        collection.Name,
+		Comment:     comment,
+		Columns:     make(map[string]ColumnInfo),
+		Indexes:     make(map[string]IndexInfo),
+		ForeignKeys: make(map[string]ForeignKey),
+		Constraints: make(map[string]ConstraintInfo),
+	}
+
+	for _, field := range collection.fieldList() {
+		if field.Hidden || field.Type == "password" {
+			continue
+		}
+
+		var notes []string
+		if field.System {
+			notes = append(notes, "system field")
+		}
+		if len(field.Values) > 0 {
+			notes = append(notes, "values: "+strings.Join(field.Values, ", "))
+		}
+		if (field.Type == "select" || field.Type == "relation" || field.Type == "file") && field.MaxSelect > 1 {
+			notes = append(notes, "multiple values")
+		}
+
+		tableSchema.Columns[field.Name] = ColumnInfo{
+			Name:       field.Name,
+			Type:       field.Type,
+			IsNullable: !field.Required,
+			Comment:    strings.Join(notes, "; "),
+		}
+
+		if field.Type == "relation" && field.CollectionID != "" {
+			refTable := collectionNames[field.CollectionID]
+			if refTable == "" {
+				refTable = field.CollectionID
+			}
+			tableSchema.ForeignKeys[field.Name] = ForeignKey{
+				Name:       field.Name,
+				ColumnName: field.Name,
+				RefTable:   refTable,
+				RefColumn:  "id",
+			}
+		}
+	}
+
+	tableSchema.Indexes["record_id"] = IndexInfo{
+		Name:     "record_id",
+		Columns:  []string{"id"},
+		IsUnique: true,
+	}
+	for i, definition := range collection.Indexes {
+		name, columns, unique := parsePocketBaseIndex(definition)
+		if name == "" {
+			name = fmt.Sprintf("idx_%d", i)
+		}
+		tableSchema.Indexes[name] = IndexInfo{
+			Name:     name,
+			Columns:  columns,
+			IsUnique: unique,
+		}
+	}
+
+	return tableSchema
+}
+
+// parsePocketBaseIndex reads the name and columns of a CREATE [UNIQUE] INDEX statement of a collection
+func parsePocketBaseIndex(definition string) (string, []string, bool) {
+	upper := strings.ToUpper(definition)
+	unique := strings.Contains(upper, "UNIQUE INDEX")
+
+	name := ""
+	if idx := strings.Index(upper, "INDEX"); idx >= 0 {
+		rest := strings.Fields(definition[idx+len("INDEX"):])
+		if len(rest) > 0 {
+			name = strings.Trim(rest[0], "`\"[]")
+		}
+	}
+
+	columns := []string{}
+	start, end := strings.Index(definition, "("), strings.LastIndex(definition, ")")
+	if start >= 0 && end > start {
+		for _, column := range strings.Split(definition[start+1:end], ",") {
+			column = strings.Fields(strings.TrimSpace(column) + " ")[0]
+			columns = append(columns, strings.Trim(column, "`\"[]"))
+		}
+	}
+
+	return name, columns, unique
+}
+
+// GetTableChecksum calculates a checksum for a collection's field definitions
+func (f *PocketBaseSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("PocketBaseSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	collections, err := client.listCollections(ctx)
+	if err != nil {
+		log.Printf("PocketBaseSchemaFetcher -> GetTableChecksum -> Error fetching collections: %v", err)
+		return "", fmt.Errorf("failed to get collection definition: %v", err)
+	}
+
+	for _, collection := range collections {
+		if collection.Name != table && collection.ID != table {
+			continue
+		}
+		definitions := make([]string, 0, len(collection.fieldList()))
+		for _, field := range collection.fieldList() {
+			definitions = append(definitions, fmt.Sprintf("%s:%s:%s:%t;", field.ID, field.Name, field.Type, field.Required))
+		}
+		sort.Strings(definitions)
+		return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(definitions, "")))), nil
+	}
+
+	return "", fmt.Errorf("no collection definition found for collection: %s", table)
+}
+
+// FetchExampleRecords retrieves sample records from a collection
+func (f *PocketBaseSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("PocketBaseSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := client.listRecords(ctx, table, pocketBaseListOptions{PerPage: limit}, false)
+	if err != nil {
+		log.Printf("PocketBaseSchemaFetcher -> FetchExampleRecords -> Error fetching records from collection %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for collection %s: %v", table, err)
+	}
+	return page.Items, nil
+}
+
+// PocketBaseSimplifier implements SchemaSimplifier for PocketBase field types
+type PocketBaseSimplifier struct{}
+
+// SimplifyDataType maps PocketBase field types to readable type names
+func (s *PocketBaseSimplifier) SimplifyDataType(dbType string) string {
+	switch dbType {
+	case "text", "editor", "email", "url":
+		return "text"
+	case "number":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "date", "autodate":
+		return "datetime"
+	case "select":
+		return "select"
+	case "relation":
+		return "relation"
+	case "file":
+		return "file"
+	case "json":
+		return "json"
+	case "geoPoint":
+		return "location"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints returns constraints for a PocketBase field
+func (s *PocketBaseSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	if col.Name == "id" {
+		constraints = append(constraints, "PRIMARY KEY")
+	}
+	if !col.IsNullable && col.Name != "id" {
+		constraints = append(constraints, "REQUIRED")
+	}
+	if col.Type == "autodate" {
+		constraints = append(constraints, "READ ONLY")
+	}
+	if fk, ok := table.ForeignKeys[col.Name]; ok {
+		constraints = append(constraints, fmt.Sprintf("RELATION TO %s", fk.RefTable))
+	}
+
+	return constraints
+}
@@ -0,0 +1,219 @@
+package dbmanager
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"neobase-ai/internal/constants"
+)
+
+// ConnectionPoolManager sizes the database/sql pools shared by chats. A pool starts at the minimum size,
+// grows with the number of chats using it and when most of its connections are busy, and never exceeds the maximum.
+type ConnectionPoolManager struct {
+	minConns    int
+	maxConns    int
+	idleTimeout time.Duration
+	pools       map[string]*managedPool // key: config key of the DatabasePool
+	mu          sync.Mutex
+}
+
+// managedPool is a database/sql pool tracked by the ConnectionPoolManager
+type managedPool struct {
+	dbType      string
+	db          *sql.DB
+	maxOpen     int
+	activeChats int
+}
+
+// PoolStats summarises the pools of one database type
+type PoolStats struct {
+	DBType             string  `json:"db_type"`
+	Pools              int     `json:"pools"`
+	ActiveChats        int     `json:"active_chats"`
+	MaxOpenConnections int     `json:"max_open_connections"`
+	OpenConnections    int     `json:"open_connections"`
+	InUse              int     `json:"in_use"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"wait_count"`
+	WaitDurationMs     int64   `json:"wait_duration_ms"`
+	UtilizationPercent float64 `json:"utilization_percent"`
+}
+
+// PoolStatsResponse is the pool configuration and the stats of every database type with an open pool
+type PoolStatsResponse struct {
+	MinConnections     int         `json:"min_connections"`
+	MaxConnections     int         `json:"max_connections"`
+	IdleTimeoutSeconds int         `json:"idle_timeout_seconds"`
+	Pools              []PoolStats `json:"pools"`
+}
+
+// PoolExhaustedError is returned when every connection of a pool at its maximum size is in use
+type PoolExhaustedError struct {
+	DBType    string
+	InUse     int
+	MaxOpen   int
+	WaitCount int64
+}
+
+func (e *PoolExhaustedError) Error() string {
+	return fmt.Sprintf("connection pool exhausted for %s: %d of %d connections in use and %d requests have waited for a connection, try again shortly or raise DB_POOL_MAX",
+		e.DBType, e.InUse, e.MaxOpen, e.WaitCount)
+}
+
+// NewConnectionPoolManager creates a pool manager. Sizes below 1 fall back to the defaults.
+func NewConnectionPoolManager(minConns, maxConns int, idleTimeout time.Duration) *ConnectionPoolManager {
+	if minConns <= 0 {
+		minConns = constants.DefaultDBPoolMin
+	}
+	if maxConns < minConns {
+		maxConns = minConns
+	}
+	return &ConnectionPoolManager{
+		minConns:    minConns,
+		maxConns:    maxConns,
+		idleTimeout: idleTimeout,
+		pools:       make(map[string]*managedPool),
+	}
+}
+
+// Register starts tracking a pool, replacing the static sizes set by the driver
+func (p *ConnectionPoolManager) Register(configKey, dbType string, db *sql.DB) {
+	if db == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	db.SetMaxOpenConns(p.minConns)
+	db.SetMaxIdleConns(p.minConns)
+	if p.idleTimeout > 0 {
+		db.SetConnMaxIdleTime(p.idleTimeout)
+	}
+
+	p.pools[configKey] = &managedPool{
+		dbType:  dbType,
+		db:      db,
+		maxOpen: p.minConns,
+	}
+	log.Printf("ConnectionPoolManager -> Register -> Tracking %s pool with %d max open connections", dbType, p.minConns)
+}
+
+// Unregister stops tracking a pool that has been closed
+func (p *ConnectionPoolManager) Unregister(configKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pools, configKey)
+}
+
+// Adjust resizes a pool for the number of chats using it and its current load.
+// A pool that is more than DBPoolHighUtilizationPercent busy grows by half its size, up to the maximum.
+func (p *ConnectionPoolManager) Adjust(configKey string, activeChats int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pool, exists := p.pools[configKey]
+	if !exists {
+		return
+	}
+	pool.activeChats = activeChats
+
+	target := activeChats * constants.DBPoolConnectionsPerChat
+	if target < p.minConns {
+		target = p.minConns
+	}
+
+	stats := pool.db.Stats()
+	if stats.InUse*100 >= pool.maxOpen*constants.DBPoolHighUtilizationPercent {
+		log.Printf("ConnectionPoolManager -> Adjust -> WARNING: %s pool is at %d/%d connections in use (%d active chats)",
+			pool.dbType, stats.InUse, pool.maxOpen, activeChats)
+		if grown := pool.maxOpen + (pool.maxOpen+1)/2; grown > target {
+			target = grown
+		}
+	}
+
+	// Pools only shrink back towards the per-chat share when they are not busy
+	if target < pool.maxOpen && stats.InUse >= target {
+		return
+	}
+	if target > p.maxConns {
+		target = p.maxConns
+	}
+	if target == pool.maxOpen {
+		return
+	}
+
+	log.Printf("ConnectionPoolManager -> Adjust -> Resizing %s pool from %d to %d max open connections (%d active chats)",
+		pool.dbType, pool.maxOpen, target, activeChats)
+	pool.db.SetMaxOpenConns(target)
+	pool.db.SetMaxIdleConns(target)
+	pool.maxOpen = target
+}
+
+// CheckCapacity returns a PoolExhaustedError when the pool is at its maximum size with every connection in use
+func (p *ConnectionPoolManager) CheckCapacity(configKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pool, exists := p.pools[configKey]
+	if !exists {
+		return nil
+	}
+
+	stats := pool.db.Stats()
+	if pool.maxOpen >= p.maxConns && stats.InUse >= pool.maxOpen && stats.WaitCount > 0 {
+		return &PoolExhaustedError{
+			DBType:    pool.dbType,
+			InUse:     stats.InUse,
+			MaxOpen:   pool.maxOpen,
+			WaitCount: stats.WaitCount,
+		}
+	}
+	return nil
+}
+
+// Stats returns the pool configuration and the pool stats grouped by database type
+func (p *ConnectionPoolManager) Stats() *PoolStatsResponse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	byType := make(map[string]*PoolStats)
+	for _, pool := range p.pools {
+		stats := pool.db.Stats()
+		entry, exists := byType[pool.dbType]
+		if !exists {
+			entry = &PoolStats{DBType: pool.dbType}
+			byType[pool.dbType] = entry
+		}
+		entry.Pools++
+		entry.ActiveChats += pool.activeChats
+		entry.MaxOpenConnections += pool.maxOpen
+		entry.OpenConnections += stats.OpenConnections
+		entry.InUse += stats.InUse
+		entry.Idle += stats.Idle
+		entry.WaitCount += stats.WaitCount
+		entry.WaitDurationMs += stats.WaitDuration.Milliseconds()
+	}
+
+	response := &PoolStatsResponse{
+		MinConnections:     p.minConns,
+		MaxConnections:     p.maxConns,
+		IdleTimeoutSeconds: int(p.idleTimeout.Seconds()),
+		Pools:              make([]PoolStats, 0, len(byType)),
+	}
+	for _, entry := range byType {
+		if entry.MaxOpenConnections > 0 {
+			entry.UtilizationPercent = float64(entry.InUse) * 100 / float64(entry.MaxOpenConnections)
+		}
+		response.Pools = append(response.Pools, *entry)
+	}
+	sort.Slice(response.Pools, func(i, j int) bool {
+		return response.Pools[i].DBType < response.Pools[j].DBType
+	})
+
+	return response
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -116,7 +117,7 @@ func (d *PostgresDriver) Connect(config ConnectionConfig) (*Connection, error) {
 		var err error
 
 		if config.SSLCertURL != nil && config.SSLKeyURL != nil && config.SSLRootCertURL != nil {
-			certPath, keyPath, rootCertPath, certTempFiles, err = utils.PrepareCertificatesFromURLs(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
+			certPath, keyPath, rootCertPath, certTempFiles, err = prepareSSLCertificates(*config.SSLCertURL, *config.SSLKeyURL, *config.SSLRootCertURL)
 			if err != nil {
 				return nil, err
 			}
@@ -579,16 +580,22 @@ func (d *PostgresDriver) getTables(ctx context.Context, db *sql.DB, tables []str
 
 		log.Printf("PostgresDriver -> getTables -> Fetching columns for table: %s", tableName)
 
-		// Get columns
+		// Get columns, information_schema reports extension types such as pgvector's as USER-DEFINED,
+		// so their declared type with its dimensions comes from pg_attribute
 		columnQuery := `
 			SELECT 
 				column_name, 
-				data_type, 
+				CASE WHEN c.udt_name IN ('vector', 'halfvec', 'sparsevec') THEN (
+					SELECT format_type(a.atttypid, a.atttypmod)
+					FROM pg_attribute a
+					WHERE a.attrelid = (c.table_schema || '.' || c.table_name)::regclass
+					AND a.attname = c.column_name
+				) ELSE c.data_type END AS data_type, 
 				is_nullable,
 				column_default,
 				col_description((table_schema || '.' || table_name)::regclass::oid, ordinal_position) as column_comment
 			FROM 
-				information_schema.columns
+				information_schema.columns c
 			WHERE 
 				table_schema = 'public' AND 
 				table_name = $1
@@ -1204,6 +1211,63 @@ func (d *PostgresDriver) FetchExampleRecords(ctx context.Context, db DBExecutor,
 	return records, nil
 }
 
+// CopyFromRows bulk loads rows into schemaName.tableName using the PostgreSQL COPY protocol, in one transaction.
+// Values are sent in text format so the server casts each one to its column type, the same as a quoted INSERT literal.
+// Nil values are loaded as NULL. The connection must come from lib/pq, which PostgresDriver.Connect opens.
+func CopyFromRows(ctx context.Context, db *sql.DB, schemaName, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if db == nil {
+		return 0, fmt.Errorf("failed to get SQL DB connection")
+	}
+
+	copyQuery := pq.CopyIn(tableName, columns...)
+	target := tableName
+	if schemaName != "" {
+		copyQuery = pq.CopyInSchema(schemaName, tableName, columns...)
+		target = fmt.Sprintf("%s.%s", schemaName, tableName)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, copyQuery)
+	if err != nil {
+		return 0, fmt.Errorf("COPY into %s failed: %v", target, err)
+	}
+
+	values := make([]interface{}, len(columns))
+	for _, row := range rows {
+		for i := range values {
+			values[i] = nil
+			if i < len(row) {
+				values[i] = row[i]
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			stmt.Close()
+			return 0, fmt.Errorf("COPY into %s failed: %v", target, err)
+		}
+	}
+
+	// An Exec without values ends the COPY and returns the number of rows loaded
+	result, err := stmt.ExecContext(ctx)
+	if err != nil {
+		stmt.Close()
+		return 0, fmt.Errorf("COPY into %s failed: %v", target, err)
+	}
+	if err := stmt.Close(); err != nil {
+		return 0, fmt.Errorf("COPY into %s failed: %v", target, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit COPY into %s: %w", target, err)
+	}
+
+	copied, _ := result.RowsAffected()
+	return copied, nil
+}
+
 // Helper function to get value from pointer or empty string
 func getValue(s *string) string {
 	if s == nil {
@@ -4,10 +4,43 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"time"
 )
 
+// pgVectorTypePattern matches the column types of the pgvector extension, with their dimensions when declared
+var pgVectorTypePattern = regexp.MustCompile(`(?i)^(vector|halfvec|sparsevec)(?:\((\d+)\))?$`)
+
+// describePgVectorColumn annotates a pgvector column for the LLM, so it compares embeddings with the distance
+// operators instead of reading them and knows whether a similarity search can use an index.
+// Other columns get an empty string.
+func describePgVectorColumn(col ColumnInfo, table TableSchema) string {
+	match := pgVectorTypePattern.FindStringSubmatch(strings.TrimSpace(col.Type))
+	if match == nil {
+		return ""
+	}
+
+	note := "pgvector embedding"
+	if match[2] != "" {
+		note += fmt.Sprintf(", %s dimensions", match[2])
+	}
+	note += ", compare with <-> (L2), <#> (negative inner product) or <=> (cosine distance)"
+
+	indexed := false
+	for _, idx := range table.Indexes {
+		for _, column := range idx.Columns {
+			if column == col.Name {
+				indexed = true
+			}
+		}
+	}
+	if !indexed {
+		note += ", no index: similarity searches scan every row"
+	}
+	return note
+}
+
 // Helper function to split SQL statements
 func splitStatements(query string) []string {
 	// Basic statement splitting - can be enhanced for more complex cases
@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"log"
 	"neobase-ai/internal/apis/dtos"
+	"regexp"
 	"strings"
 	"time"
 )
 
+// postgresConcurrentIndexPattern matches index statements using CONCURRENTLY, which PostgreSQL rejects inside a transaction block
+var postgresConcurrentIndexPattern = regexp.MustCompile(`(?is)^\s*(CREATE\s+(UNIQUE\s+)?INDEX|DROP\s+INDEX|REINDEX\s+\w+)\s+CONCURRENTLY\b`)
+
 type PostgresTransaction struct {
 	tx   *sql.Tx
 	conn *Connection // Add connection reference
@@ -47,7 +51,7 @@ func (tx *PostgresTransaction) ExecuteQuery(ctx context.Context, query string) (
 			}
 		} else {
 			// For non-SELECT queries
-			lastResult, err = tx.tx.ExecContext(ctx, stmt)
+			lastResult, err = tx.exec(ctx, stmt)
 			if err != nil {
 				return &QueryExecutionResult{
 					Error: &dtos.QueryError{
@@ -136,6 +140,20 @@ func (tx *PostgresTransaction) ExecuteQuery(ctx context.Context, query string) (
 	return result, nil
 }
 
+// exec runs a statement in the transaction. CONCURRENTLY index statements cannot run in a transaction
+// block, so they run on the connection pool in autocommit mode and are not undone by a rollback.
+func (tx *PostgresTransaction) exec(ctx context.Context, stmt string) (sql.Result, error) {
+	if postgresConcurrentIndexPattern.MatchString(stmt) && tx.conn != nil && tx.conn.DB != nil {
+		sqlDB, err := tx.conn.DB.DB()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("PostgreSQL Transaction -> exec -> Running CONCURRENTLY statement outside the transaction")
+		return sqlDB.ExecContext(ctx, stmt)
+	}
+	return tx.tx.ExecContext(ctx, stmt)
+}
+
 func (t *PostgresTransaction) Commit() error {
 	log.Printf("PostgreSQL Transaction -> Commit -> Committing transaction")
 	return t.tx.Commit()
@@ -0,0 +1,100 @@
+package dbmanager
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"neobase-ai/config"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+)
+
+// retryableQueryErrorPatterns are driver errors of a dropped or unreachable connection, the query itself may be fine.
+// A bare EOF only counts as the whole error or its last wrapped cause, other timeouts are left to the query's own limits.
+var retryableQueryErrorPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`connection reset`),
+	regexp.MustCompile(`broken pipe`),
+	regexp.MustCompile(`connection refused`),
+	regexp.MustCompile(`bad connection`),
+	regexp.MustCompile(`invalid connection`),
+	regexp.MustCompile(`i/o timeout`),
+	regexp.MustCompile(`connection timed out`),
+	regexp.MustCompile(`unexpected eof`),
+	regexp.MustCompile(`(^|: )eof(\s|$)`),
+	regexp.MustCompile(`server closed the connection`),
+	regexp.MustCompile(`connection closed`),
+	regexp.MustCompile(`no connection to the server`),
+	regexp.MustCompile(`server selection error`),
+}
+
+// nonRetryableQueryErrorPatterns are errors that fail the same way on every attempt, even when they mention a timeout
+var nonRetryableQueryErrorPatterns = []string{
+	"syntax error",
+	"permission denied",
+	"access denied",
+	"not authorized",
+	"unauthorized",
+	"authentication failed",
+	"statement timeout",
+	"lock timeout",
+	"lock wait timeout",
+	"does not exist",
+	"safety violation",
+}
+
+// resolveRetryPolicy returns the retry policy of a connection, falling back to the server defaults
+func resolveRetryPolicy(conn *Connection) RetryPolicy {
+	policy := RetryPolicy{
+		MaxRetries: config.Env.QueryMaxRetries,
+		BaseDelay:  time.Duration(config.Env.QueryBaseRetryDelayMs) * time.Millisecond,
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = constants.DefaultQueryBaseRetryDelayMs * time.Millisecond
+	}
+	if conn != nil && conn.Config.RetryPolicy != nil {
+		if conn.Config.RetryPolicy.MaxRetries >= 0 {
+			policy.MaxRetries = conn.Config.RetryPolicy.MaxRetries
+		}
+		if conn.Config.RetryPolicy.BaseDelay > 0 {
+			policy.BaseDelay = conn.Config.RetryPolicy.BaseDelay
+		}
+	}
+	return policy
+}
+
+// isRetryableQueryError reports whether a query failed because of the connection rather than the query,
+// e.g. a connection reset, a network timeout or an unexpected EOF
+func isRetryableQueryError(queryErr *dtos.QueryError) bool {
+	if queryErr == nil {
+		return false
+	}
+	message := strings.ToLower(queryErr.Message + " " + queryErr.Details)
+	for _, pattern := range nonRetryableQueryErrorPatterns {
+		if strings.Contains(message, pattern) {
+			return false
+		}
+	}
+	for _, pattern := range retryableQueryErrorPatterns {
+		if pattern.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// canRetryQuery reports whether a failed query can safely run again. Only read-only queries are retried,
+// a write may have been applied before the connection dropped, even inside a transaction whose COMMIT was sent.
+func canRetryQuery(conn *Connection, query string) bool {
+	return constants.IsReadOnlyQuery(query, conn.Config.Type)
+}
+
+// retryDelay returns the backoff before retry attempt (0-based): BaseDelay * 2^attempt plus up to BaseDelay of jitter
+func retryDelay(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << uint(attempt)
+	if policy.BaseDelay > 0 {
+		delay += time.Duration(rand.Int63n(int64(policy.BaseDelay)))
+	}
+	return delay
+}
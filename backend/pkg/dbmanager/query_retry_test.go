@@ -0,0 +1,59 @@
+package dbmanager
+
+import (
+	"testing"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+)
+
+func TestIsRetryableQueryError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"read tcp 10.0.0.2:51234->10.0.0.9:5432: read: connection reset by peer", true},
+		{"write tcp 10.0.0.2:51234->10.0.0.9:3306: write: broken pipe", true},
+		{"driver: bad connection", true},
+		{"invalid connection", true},
+		{"dial tcp 10.0.0.9:5432: i/o timeout", true},
+		{"EOF", true},
+		{"failed to execute query: EOF", true},
+		{"unexpected EOF", true},
+		{"server selection error: context deadline exceeded", true},
+		{`syntax error at or near "SELEC"`, false},
+		{"canceling statement due to statement timeout", false},
+		{"Lock wait timeout exceeded; try restarting transaction", false},
+		{"query timeout exceeded", false},
+		{`column "timeout_seconds" does not exist`, false},
+		{"relation thereof_items is missing", false},
+		{`invalid input syntax for type integer: "geoffrey"`, false},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryableQueryError(&dtos.QueryError{Message: tt.message}); got != tt.want {
+			t.Errorf("isRetryableQueryError(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestCanRetryQuery(t *testing.T) {
+	tests := []struct {
+		dbType string
+		query  string
+		want   bool
+	}{
+		{constants.DatabaseTypePostgreSQL, "SELECT * FROM orders", true},
+		{constants.DatabaseTypePostgreSQL, "UPDATE orders SET paid = true", false},
+		{constants.DatabaseTypeClickhouse, "SELECT count() FROM events", true},
+		{constants.DatabaseTypeClickhouse, "INSERT INTO events VALUES (1)", false},
+		{constants.DatabaseTypeTrino, "INSERT INTO hive.sales.orders VALUES (1)", false},
+	}
+
+	for _, tt := range tests {
+		conn := &Connection{Config: ConnectionConfig{Type: tt.dbType}}
+		if got := canRetryQuery(conn, tt.query); got != tt.want {
+			t.Errorf("canRetryQuery(%s, %q) = %v, want %v", tt.dbType, tt.query, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package dbmanager
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -73,8 +75,11 @@ func (v *BaseQueryValidator) ValidateTableScanRisk(query string, tableMetadata m
 		return nil
 	}
 
-	// Skip if query has LIMIT, COUNT, or is an aggregate
+	// Skip if query has LIMIT (FETCH FIRST/NEXT or ROWNUM in Oracle), COUNT, or is an aggregate
 	if strings.Contains(queryUpper, "LIMIT") ||
+		strings.Contains(queryUpper, "FETCH FIRST") ||
+		strings.Contains(queryUpper, "FETCH NEXT") ||
+		strings.Contains(queryUpper, "ROWNUM") ||
 		strings.Contains(queryUpper, "COUNT(") ||
 		strings.Contains(queryUpper, "SUM(") ||
 		strings.Contains(queryUpper, "AVG(") ||
@@ -288,6 +293,214 @@ func (v *MongoDBQueryValidator) extractMongoCollectionName(query string) string
 	return ""
 }
 
+// ============================================================================
+// Airtable Validator
+// ============================================================================
+
+// AirtableQueryValidator implements validation for Airtable operations
+type AirtableQueryValidator struct {
+	*BaseQueryValidator
+}
+
+// NewAirtableQueryValidator creates a validator for Airtable
+func NewAirtableQueryValidator() *AirtableQueryValidator {
+	return &AirtableQueryValidator{
+		BaseQueryValidator: NewBaseQueryValidator("airtable"),
+	}
+}
+
+// ValidateSafety performs safety validation for Airtable operations.
+// Airtable has no "delete where" operation, so writes must name the records they change.
+func (v *AirtableQueryValidator) ValidateSafety(query string, queryType string, tableMetadata map[string]TableSchema) error {
+	op, err := parseAirtableOperation(query)
+	if err != nil {
+		return err
+	}
+
+	switch op.Method {
+	case "update", "destroy":
+		if !strings.Contains(op.Args, "rec") {
+			return fmt.Errorf("SAFETY VIOLATION: %s() must list the IDs (rec...) of the records to change. "+
+				"Select the records first to get their IDs", op.Method)
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Temporal Validator
+// ============================================================================
+
+// TemporalQueryValidator implements validation for Temporal CLI commands
+type TemporalQueryValidator struct {
+	*BaseQueryValidator
+}
+
+// NewTemporalQueryValidator creates a validator for Temporal
+func NewTemporalQueryValidator() *TemporalQueryValidator {
+	return &TemporalQueryValidator{
+		BaseQueryValidator: NewBaseQueryValidator("temporal"),
+	}
+}
+
+// ValidateSafety performs safety validation for Temporal commands.
+// Temporal has no batch writes here, so signal, cancel and terminate must name the workflow they change.
+func (v *TemporalQueryValidator) ValidateSafety(query string, queryType string, tableMetadata map[string]TableSchema) error {
+	command, err := parseTemporalCommand(query)
+	if err != nil {
+		return err
+	}
+
+	switch command.Action {
+	case "signal", "cancel", "terminate":
+		if strings.ContainsAny(command.Flags["workflow-id"], "*%") {
+			return fmt.Errorf("SAFETY VIOLATION: temporal workflow %s must name a single workflow ID, wildcards are not supported. "+
+				"List the executions first to get their IDs", command.Action)
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// PocketBase Validator
+// ============================================================================
+
+// PocketBaseQueryValidator implements validation for PocketBase API requests
+type PocketBaseQueryValidator struct {
+	*BaseQueryValidator
+}
+
+// NewPocketBaseQueryValidator creates a validator for PocketBase
+func NewPocketBaseQueryValidator() *PocketBaseQueryValidator {
+	return &PocketBaseQueryValidator{
+		BaseQueryValidator: NewBaseQueryValidator("pocketbase"),
+	}
+}
+
+// ValidateSafety performs safety validation for PocketBase requests.
+// The records API has no batch writes, so PATCH and DELETE must name the record they change.
+func (v *PocketBaseQueryValidator) ValidateSafety(query string, queryType string, tableMetadata map[string]TableSchema) error {
+	req, err := parsePocketBaseRequest(query)
+	if err != nil {
+		return err
+	}
+
+	if (req.Method == "PATCH" || req.Method == "DELETE") && strings.ContainsAny(req.RecordID, "*%") {
+		return fmt.Errorf("SAFETY VIOLATION: %s must name a single record ID, wildcards are not supported. "+
+			"List the records first to get their IDs", req.Method)
+	}
+
+	return nil
+}
+
+// ============================================================================
+// NATS Validator
+// ============================================================================
+
+// NATSQueryValidator implements validation for NATS JetStream calls
+type NATSQueryValidator struct {
+	*BaseQueryValidator
+}
+
+// NewNATSQueryValidator creates a validator for NATS JetStream
+func NewNATSQueryValidator() *NATSQueryValidator {
+	return &NATSQueryValidator{
+		BaseQueryValidator: NewBaseQueryValidator("nats"),
+	}
+}
+
+// ValidateSafety performs safety validation for JetStream calls.
+// A message is published on one subject, wildcards would only reach no stream or the wrong one.
+func (v *NATSQueryValidator) ValidateSafety(query string, queryType string, tableMetadata map[string]TableSchema) error {
+	call, err := parseNATSCall(query)
+	if err != nil {
+		return err
+	}
+
+	if call.Method == "Publish" {
+		subject, err := call.stringArg(0, "subject")
+		if err != nil {
+			return err
+		}
+		if strings.ContainsAny(subject, "*> ") {
+			return fmt.Errorf("SAFETY VIOLATION: js.Publish must name a single subject, wildcards are not supported. " +
+				"Publish on a concrete subject such as orders.eu.created")
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Firestore Validator
+// ============================================================================
+
+// FirestoreQueryValidator implements validation for Firestore query chains
+type FirestoreQueryValidator struct {
+	*BaseQueryValidator
+}
+
+// NewFirestoreQueryValidator creates a validator for Firestore
+func NewFirestoreQueryValidator() *FirestoreQueryValidator {
+	return &FirestoreQueryValidator{
+		BaseQueryValidator: NewBaseQueryValidator("firestore"),
+	}
+}
+
+// ValidateSafety performs safety validation for Firestore query chains.
+// Set without merge replaces the whole document, so an empty Set would wipe every field of it.
+func (v *FirestoreQueryValidator) ValidateSafety(query string, queryType string, tableMetadata map[string]TableSchema) error {
+	parsed, err := parseFirestoreQuery(query)
+	if err != nil {
+		return err
+	}
+
+	if parsed.Operation == "set" {
+		call := parsed.Calls[len(parsed.Calls)-1]
+		var data map[string]interface{}
+		if err := json.Unmarshal(call.Args[0], &data); err == nil && len(data) == 0 && len(call.Args) == 1 {
+			return fmt.Errorf("SAFETY VIOLATION: Set with an empty document removes every field of the document. " +
+				"Use Delete to remove the document, or Update to change some of its fields")
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+// YugabyteDB YCQL Validator
+// ============================================================================
+
+var (
+	ycqlUpdateDeletePattern = regexp.MustCompile(`(?i)^\s*(update|delete)\b`)
+	ycqlWherePattern        = regexp.MustCompile(`(?i)\bwhere\b`)
+)
+
+// YugabyteDBCQLQueryValidator implements validation for YCQL statements
+type YugabyteDBCQLQueryValidator struct {
+	*BaseQueryValidator
+}
+
+// NewYugabyteDBCQLQueryValidator creates a validator for YugabyteDB YCQL
+func NewYugabyteDBCQLQueryValidator() *YugabyteDBCQLQueryValidator {
+	return &YugabyteDBCQLQueryValidator{
+		BaseQueryValidator: NewBaseQueryValidator("yugabytedb_ycql"),
+	}
+}
+
+// ValidateSafety performs safety validation for YCQL statements.
+// UPDATE and DELETE must name the rows they change by primary key, a whole table is emptied with TRUNCATE.
+func (v *YugabyteDBCQLQueryValidator) ValidateSafety(query string, queryType string, tableMetadata map[string]TableSchema) error {
+	if ycqlUpdateDeletePattern.MatchString(query) && !ycqlWherePattern.MatchString(query) {
+		return fmt.Errorf("SAFETY VIOLATION: YCQL UPDATE and DELETE statements must have a WHERE clause on the primary key. " +
+			"Use TRUNCATE to empty a whole table")
+	}
+
+	return nil
+}
+
 // ============================================================================
 // Validator Factory
 // ============================================================================
@@ -303,12 +516,26 @@ func GetValidatorForDatabase(dbType string) QueryValidator {
 		return NewSQLQueryValidator("clickhouse")
 	case "yugabyte", "yugabytedb":
 		return NewSQLQueryValidator("yugabyte")
-	case "timescaledb":
+	case "timescaledb", "supabase", "neon":
 		return NewSQLQueryValidator("postgresql")
-	case "starrocks":
+	case "starrocks", "planetscale":
 		return NewSQLQueryValidator("mysql")
-	case "mongodb", "mongo":
+	case "oracle":
+		return NewSQLQueryValidator("oracle")
+	case "mongodb", "mongo", "ferretdb":
 		return NewMongoDBQueryValidator()
+	case "airtable":
+		return NewAirtableQueryValidator()
+	case "temporal":
+		return NewTemporalQueryValidator()
+	case "pocketbase":
+		return NewPocketBaseQueryValidator()
+	case "nats":
+		return NewNATSQueryValidator()
+	case "yugabytedb_ycql":
+		return NewYugabyteDBCQLQueryValidator()
+	case "firestore":
+		return NewFirestoreQueryValidator()
 	case "spreadsheet", "google_sheets":
 		// Spreadsheet connections use PostgreSQL internally, so use SQL validator
 		return NewSQLQueryValidator("spreadsheet")
@@ -0,0 +1,147 @@
+package dbmanager
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"neobase-ai/config"
+	"neobase-ai/internal/constants"
+)
+
+var (
+	sqlSelectStatementPattern = regexp.MustCompile(`(?is)^\s*(SELECT|WITH)\b`)
+	sqlOtherRowCapPattern     = regexp.MustCompile(`(?i)\b(FETCH\s+(FIRST|NEXT)|TOP\s*\(?\s*\d+)`)
+	sqlLockingClausePattern   = regexp.MustCompile(`(?i)\bFOR\s+(UPDATE|SHARE|NO\s+KEY\s+UPDATE|KEY\s+SHARE)\b`)
+	sqlClickHouseTailPattern  = regexp.MustCompile(`(?i)\b(SETTINGS|FORMAT)\s+\w+[^)]*$`)
+	sqlOracleRownumPattern    = regexp.MustCompile(`(?i)\bROWNUM\b`)
+	// LIMIT n, LIMIT offset, n (MySQL) and LIMIT n OFFSET m at the end of the statement
+	sqlTrailingLimitPattern  = regexp.MustCompile(`(?i)\bLIMIT\s+(\d+)(?:\s*,\s*(\d+))?(?:\s+OFFSET\s+\d+)?\s*$`)
+	sqlTrailingLimitAll      = regexp.MustCompile(`(?i)\bLIMIT\s+ALL\s*$`)
+	sqlTrailingOffsetPattern = regexp.MustCompile(`(?i)\bOFFSET\s+\d+(?:\s+ROWS?)?\s*$`)
+
+	mongoFindPattern       = regexp.MustCompile(`\.find\(`)
+	mongoNonListingPattern = regexp.MustCompile(`\.(count|countDocuments|explain|findOne)\(`)
+	mongoLimitPattern      = regexp.MustCompile(`\.limit\(\s*(\d+)\s*\)`)
+)
+
+// resolveMaxResultRows returns the row cap of a connection
+func resolveMaxResultRows(conn *Connection) int {
+	if conn != nil && conn.Config.MaxResultRows > 0 {
+		return conn.Config.MaxResultRows
+	}
+	if config.Env.MaxQueryResultRows > 0 {
+		return config.Env.MaxQueryResultRows
+	}
+	return constants.DefaultMaxQueryResultRows
+}
+
+// applyResultRowLimit caps a read query at maxRows rows. It returns the query to execute and
+// whether the cap was injected; queries that already return at most maxRows rows are left as they are.
+// A capped query fetches maxRows+1 rows so trimResultRows can tell a cut off result from one of exactly maxRows rows.
+func applyResultRowLimit(query, dbType string, maxRows int) (string, bool) {
+	if maxRows <= 0 || !constants.IsReadOnlyQuery(query, dbType) {
+		return query, false
+	}
+
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks,
+		constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeOracle, constants.DatabaseTypeInfluxDB, constants.DatabaseTypeCloudflareD1, constants.DatabaseTypeSpreadsheet,
+		constants.DatabaseTypeGoogleSheets:
+		return applySQLResultRowLimit(query, dbType, maxRows)
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
+		return applyMongoResultRowLimit(query, maxRows)
+	default:
+		return query, false
+	}
+}
+
+func applySQLResultRowLimit(query, dbType string, maxRows int) (string, bool) {
+	statement := strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	if !sqlSelectStatementPattern.MatchString(statement) || strings.Contains(statement, ";") {
+		return query, false
+	}
+	// Queries capped with other syntax or ending in clauses that must stay last are run as written
+	if sqlOtherRowCapPattern.MatchString(statement) || sqlLockingClausePattern.MatchString(statement) {
+		return query, false
+	}
+	if dbType == constants.DatabaseTypeClickhouse && sqlClickHouseTailPattern.MatchString(statement) {
+		return query, false
+	}
+	if dbType == constants.DatabaseTypeOracle {
+		// Oracle has no LIMIT, rows are capped with FETCH FIRST, or FETCH NEXT after OFFSET n ROWS
+		if sqlOracleRownumPattern.MatchString(statement) {
+			return query, false
+		}
+		if sqlTrailingOffsetPattern.MatchString(statement) {
+			return fmt.Sprintf("%s FETCH NEXT %d ROWS ONLY", statement, maxRows+1), true
+		}
+		return fmt.Sprintf("%s FETCH FIRST %d ROWS ONLY", statement, maxRows+1), true
+	}
+
+	limitClause := fmt.Sprintf("LIMIT %d", maxRows+1)
+
+	if loc := sqlTrailingLimitPattern.FindStringSubmatchIndex(statement); loc != nil {
+		// The row count is the second number in MySQL's LIMIT offset, count
+		countStart, countEnd := loc[2], loc[3]
+		if loc[4] >= 0 {
+			countStart, countEnd = loc[4], loc[5]
+		}
+		count, err := strconv.Atoi(statement[countStart:countEnd])
+		if err == nil && count <= maxRows {
+			return query, false
+		}
+		return statement[:countStart] + strconv.Itoa(maxRows+1) + statement[countEnd:], true
+	}
+
+	if loc := sqlTrailingLimitAll.FindStringIndex(statement); loc != nil {
+		return statement[:loc[0]] + limitClause, true
+	}
+
+	// Trino takes OFFSET before LIMIT, the other engines take LIMIT before OFFSET
+	if loc := sqlTrailingOffsetPattern.FindStringIndex(statement); loc != nil && dbType != constants.DatabaseTypeTrino {
+		return statement[:loc[0]] + limitClause + " " + statement[loc[0]:], true
+	}
+
+	return statement + " " + limitClause, true
+}
+
+func applyMongoResultRowLimit(query string, maxRows int) (string, bool) {
+	statement := strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	if !mongoFindPattern.MatchString(statement) || mongoNonListingPattern.MatchString(statement) {
+		return query, false
+	}
+
+	if match := mongoLimitPattern.FindStringSubmatchIndex(statement); match != nil {
+		count, err := strconv.Atoi(statement[match[2]:match[3]])
+		if err == nil && count > 0 && count <= maxRows {
+			return query, false
+		}
+		return statement[:match[2]] + strconv.Itoa(maxRows+1) + statement[match[3]:], true
+	}
+
+	limitCall := fmt.Sprintf(".limit(%d)", maxRows+1)
+	if strings.HasSuffix(statement, ".toArray()") {
+		return strings.TrimSuffix(statement, ".toArray()") + limitCall + ".toArray()", true
+	}
+	return statement + limitCall, true
+}
+
+// trimResultRows cuts the row list of a query result down to maxRows rows and reports whether
+// any row was dropped. Results that are not a row list are left as they are.
+func trimResultRows(result interface{}, maxRows int) bool {
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	rows := reflect.ValueOf(resultMap["results"])
+	if rows.Kind() != reflect.Slice || rows.Len() <= maxRows {
+		return false
+	}
+	resultMap["results"] = rows.Slice(0, maxRows).Interface()
+	return true
+}
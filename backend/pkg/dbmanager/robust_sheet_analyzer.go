@@ -26,6 +26,7 @@ type AnalyzerConfig struct {
 	AutoDetectHeaders   bool    // Automatically detect headers
 	HandleMergedCells   bool    // Handle merged cells
 	DetectMultipleTables bool   // Detect multiple tables in one sheet
+	FixedHeaders         bool   // The first row holds the headers, e.g. the keys of JSON records
 }
 
 // DataArea represents a detected data area in the sheet
@@ -56,12 +57,23 @@ func NewRobustSheetAnalyzer(data [][]interface{}) *RobustSheetAnalyzer {
 	}
 }
 
+// WithFixedHeaders skips area and header detection: the data is one table whose first row holds the headers.
+// Records such as JSON objects have known column names, and their missing keys leave gaps that would split areas.
+func (rsa *RobustSheetAnalyzer) WithFixedHeaders() *RobustSheetAnalyzer {
+	rsa.config.FixedHeaders = true
+	return rsa
+}
+
 // AnalyzeRobust performs comprehensive analysis handling any sheet format
 func (rsa *RobustSheetAnalyzer) AnalyzeRobust() ([]*DataRegion, error) {
 	if len(rsa.data) == 0 {
 		return nil, fmt.Errorf("no data to analyze")
 	}
 
+	if rsa.config.FixedHeaders {
+		return []*DataRegion{rsa.handleFixedHeaders()}, nil
+	}
+
 	log.Printf("RobustSheetAnalyzer -> Starting analysis of %d rows", len(rsa.data))
 
 	// Step 1: Detect all data areas (could be multiple tables)
@@ -286,6 +298,27 @@ func (rsa *RobustSheetAnalyzer) handleStructuredData(data [][]interface{}, area
 	return region
 }
 
+// handleFixedHeaders turns the data into one region with the first row as headers
+func (rsa *RobustSheetAnalyzer) handleFixedHeaders() *DataRegion {
+	headers := rsa.rowToHeaders(rsa.data[0])
+	normalizedRows := rsa.normalizeRows(rsa.data[1:], len(headers))
+
+	region := &DataRegion{
+		StartRow: 0,
+		EndRow:   len(rsa.data) - 1,
+		StartCol: 0,
+		EndCol:   len(headers) - 1,
+		Headers:  headers,
+		DataRows: normalizedRows,
+		Quality:  rsa.calculateDataQuality(normalizedRows, headers),
+	}
+	region.Issues = rsa.detectDataIssues(region)
+	region.Suggestions = rsa.generateSuggestions(region)
+
+	log.Printf("RobustSheetAnalyzer -> Fixed headers: %d columns, %d rows", len(headers), len(normalizedRows))
+	return region
+}
+
 // handlePivotTable handles pivot table format
 func (rsa *RobustSheetAnalyzer) handlePivotTable(data [][]interface{}, area DataArea) *DataRegion {
 	// Pivot tables have row headers and column headers
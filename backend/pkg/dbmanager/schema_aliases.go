@@ -0,0 +1,487 @@
+package dbmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"neobase-ai/internal/constants"
+)
+
+// SchemaAliases are business names the LLM sees instead of a chat's table and column names,
+// e.g. user_accounts for usr_acct. Queries written with them are mapped back before they run.
+type SchemaAliases struct {
+	// Tables maps a real table name to its alias
+	Tables map[string]string `json:"tables,omitempty"`
+	// Columns maps a real "table.column" to the alias of the column
+	Columns map[string]string `json:"columns,omitempty"`
+}
+
+// schemaAliasPattern is what an alias may look like, a plain identifier that never needs quoting
+var schemaAliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// schemaAliasReservedWords are SQL words an alias may not take, they would be read as the alias in every query
+var schemaAliasReservedWords = map[string]bool{
+	"ALL": true, "AND": true, "AS": true, "ASC": true, "BETWEEN": true, "BY": true, "CASE": true, "CROSS": true,
+	"DELETE": true, "DESC": true, "DISTINCT": true, "ELSE": true, "END": true, "EXISTS": true, "FALSE": true,
+	"FROM": true, "FULL": true, "GROUP": true, "HAVING": true, "IN": true, "INNER": true, "INSERT": true,
+	"INTO": true, "IS": true, "JOIN": true, "LEFT": true, "LIKE": true, "LIMIT": true, "NOT": true, "NULL": true,
+	"OFFSET": true, "ON": true, "OR": true, "ORDER": true, "OUTER": true, "RIGHT": true, "SELECT": true,
+	"SET": true, "TABLE": true, "THEN": true, "TRUE": true, "UNION": true, "UPDATE": true, "USING": true,
+	"VALUES": true, "WHEN": true, "WHERE": true, "WITH": true,
+}
+
+// SupportsSchemaAliases reports whether queries of a database type can be mapped back from aliases.
+// Only SQL databases are supported, their identifiers can be told apart from values.
+func SupportsSchemaAliases(dbType string) bool {
+	switch dbType {
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB,
+		constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon, constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks,
+		constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypePlanetscale,
+		constants.DatabaseTypeOracle, constants.DatabaseTypeCloudflareD1, constants.DatabaseTypeSpreadsheet,
+		constants.DatabaseTypeGoogleSheets:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetSchemaAliases changes the schema aliases of a chat's open connection, so the next schema formatted for the LLM
+// and the next query use them without reconnecting
+func (m *Manager) SetSchemaAliases(chatID string, aliases *SchemaAliases) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if conn, exists := m.connections[chatID]; exists {
+		conn.Config.SchemaAliases = aliases
+	}
+}
+
+// SchemaAliases returns the schema aliases of a chat's open connection
+func (m *Manager) SchemaAliases(chatID string) *SchemaAliases {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if conn, exists := m.connections[chatID]; exists {
+		return conn.Config.SchemaAliases
+	}
+	return nil
+}
+
+// IsEmpty reports whether no table or column has an alias
+func (a *SchemaAliases) IsEmpty() bool {
+	return a == nil || (len(a.Tables) == 0 && len(a.Columns) == 0)
+}
+
+// tableName returns the alias of a table, or the table itself
+func (a *SchemaAliases) tableName(table string) string {
+	if alias, ok := a.Tables[table]; ok {
+		return alias
+	}
+	return table
+}
+
+// columnName returns the alias of a table's column, or the column itself
+func (a *SchemaAliases) columnName(table, column string) string {
+	if alias, ok := a.Columns[table+"."+column]; ok {
+		return alias
+	}
+	return column
+}
+
+// splitColumnAliasKey splits "table.column" at its last dot, tables may be schema-qualified
+func splitColumnAliasKey(key string) (string, string, bool) {
+	dot := strings.LastIndex(key, ".")
+	if dot <= 0 || dot == len(key)-1 {
+		return "", "", false
+	}
+	return key[:dot], key[dot+1:], true
+}
+
+// ValidateSchemaAliases checks the aliases against the schema. Every aliased table and column must exist, and an
+// alias may not be a SQL keyword nor a real table or column name, otherwise mapping queries back would change them.
+func ValidateSchemaAliases(schema *SchemaInfo, aliases *SchemaAliases) error {
+	if aliases.IsEmpty() {
+		return nil
+	}
+	if schema == nil {
+		return fmt.Errorf("the database schema is not available yet, connect to the database first")
+	}
+
+	realTables := make(map[string]bool, len(schema.Tables))
+	realColumns := make(map[string]bool)
+	for name, table := range schema.Tables {
+		realTables[strings.ToLower(name)] = true
+		for column := range table.Columns {
+			realColumns[strings.ToLower(column)] = true
+		}
+	}
+
+	checkAlias := func(alias string) error {
+		if !schemaAliasPattern.MatchString(alias) {
+			return fmt.Errorf("alias %q may only contain letters, digits and underscores, and may not start with a digit", alias)
+		}
+		if schemaAliasReservedWords[strings.ToUpper(alias)] {
+			return fmt.Errorf("alias %q is a SQL keyword", alias)
+		}
+		if realTables[strings.ToLower(alias)] {
+			return fmt.Errorf("alias %q is already the name of a table", alias)
+		}
+		if realColumns[strings.ToLower(alias)] {
+			return fmt.Errorf("alias %q is already the name of a column", alias)
+		}
+		return nil
+	}
+
+	tableAliases := make(map[string]string, len(aliases.Tables))
+	for table, alias := range aliases.Tables {
+		if _, ok := schema.Tables[table]; !ok {
+			return fmt.Errorf("table %s not found in the database schema", table)
+		}
+		if err := checkAlias(alias); err != nil {
+			return err
+		}
+		if other, taken := tableAliases[strings.ToLower(alias)]; taken {
+			return fmt.Errorf("alias %q is used for both %s and %s", alias, other, table)
+		}
+		tableAliases[strings.ToLower(alias)] = table
+	}
+
+	columnAliases := make(map[string]string)
+	for key, alias := range aliases.Columns {
+		tableName, column, ok := splitColumnAliasKey(key)
+		if !ok {
+			return fmt.Errorf("column %q must be written as table.column", key)
+		}
+		table, ok := schema.Tables[tableName]
+		if !ok {
+			return fmt.Errorf("table %s not found in the database schema", tableName)
+		}
+		if _, ok := table.Columns[column]; !ok {
+			return fmt.Errorf("column %s not found in table %s", column, tableName)
+		}
+		if err := checkAlias(alias); err != nil {
+			return err
+		}
+		if _, taken := tableAliases[strings.ToLower(alias)]; taken {
+			return fmt.Errorf("alias %q is already the alias of a table", alias)
+		}
+		tableKey := tableName + "." + strings.ToLower(alias)
+		if other, taken := columnAliases[tableKey]; taken {
+			return fmt.Errorf("alias %q is used for both %s and %s of table %s", alias, other, column, tableName)
+		}
+		columnAliases[tableKey] = column
+	}
+	return nil
+}
+
+// withSchemaAliases returns a copy of storage with the aliases in place of the real table and column names,
+// so the schema formatted for the LLM only shows the aliases. The storage itself is left as it is.
+func withSchemaAliases(storage *SchemaStorage, aliases *SchemaAliases) *SchemaStorage {
+	if storage == nil || aliases.IsEmpty() {
+		return storage
+	}
+
+	renamed := *storage
+	if storage.FullSchema != nil {
+		fullSchema := *storage.FullSchema
+		fullSchema.Tables = make(map[string]TableSchema, len(storage.FullSchema.Tables))
+		for name, table := range storage.FullSchema.Tables {
+			fullSchema.Tables[aliases.tableName(name)] = aliasTableSchema(table, name, aliases)
+		}
+		renamed.FullSchema = &fullSchema
+	}
+
+	if storage.LLMSchema != nil {
+		renamed.LLMSchema = &LLMSchemaInfo{
+			Tables:        make(map[string]LLMTableInfo, len(storage.LLMSchema.Tables)),
+			Relationships: make([]SchemaRelationship, 0, len(storage.LLMSchema.Relationships)),
+		}
+		for name, table := range storage.LLMSchema.Tables {
+			renamed.LLMSchema.Tables[aliases.tableName(name)] = aliasLLMTable(table, name, aliases)
+		}
+		for _, rel := range storage.LLMSchema.Relationships {
+			rel.FromTable = aliases.tableName(rel.FromTable)
+			rel.ToTable = aliases.tableName(rel.ToTable)
+			if rel.Through != "" {
+				rel.Through = aliases.tableName(rel.Through)
+			}
+			renamed.LLMSchema.Relationships = append(renamed.LLMSchema.Relationships, rel)
+		}
+	}
+
+	if storage.ExternalRelations != nil {
+		renamed.ExternalRelations = make(map[string][]ExternalRelation, len(storage.ExternalRelations))
+		for name, relations := range storage.ExternalRelations {
+			aliased := make([]ExternalRelation, 0, len(relations))
+			for _, relation := range relations {
+				relation.Columns = aliasColumnList(name, relation.Columns, aliases)
+				relation.RefColumns = aliasColumnList(relation.RefTable, relation.RefColumns, aliases)
+				relation.RefTable = aliases.tableName(relation.RefTable)
+				aliased = append(aliased, relation)
+			}
+			renamed.ExternalRelations[aliases.tableName(name)] = aliased
+		}
+	}
+	return &renamed
+}
+
+// aliasTableSchema renames a table of the full schema, its columns and the columns its indexes,
+// constraints and foreign keys refer to
+func aliasTableSchema(table TableSchema, name string, aliases *SchemaAliases) TableSchema {
+	table.Name = aliases.tableName(table.Name)
+
+	columns := make(map[string]ColumnInfo, len(table.Columns))
+	for columnName, column := range table.Columns {
+		column.Name = aliases.columnName(name, column.Name)
+		columns[aliases.columnName(name, columnName)] = column
+	}
+	table.Columns = columns
+
+	indexes := make(map[string]IndexInfo, len(table.Indexes))
+	for indexName, index := range table.Indexes {
+		index.Columns = aliasColumnList(name, index.Columns, aliases)
+		indexes[indexName] = index
+	}
+	table.Indexes = indexes
+
+	constraints := make(map[string]ConstraintInfo, len(table.Constraints))
+	for constraintName, constraint := range table.Constraints {
+		constraint.Columns = aliasColumnList(name, constraint.Columns, aliases)
+		constraints[constraintName] = constraint
+	}
+	table.Constraints = constraints
+
+	foreignKeys := make(map[string]ForeignKey, len(table.ForeignKeys))
+	for fkName, fk := range table.ForeignKeys {
+		fk.ColumnName = aliases.columnName(name, fk.ColumnName)
+		fk.RefColumn = aliases.columnName(fk.RefTable, fk.RefColumn)
+		fk.RefTable = aliases.tableName(fk.RefTable)
+		foreignKeys[fkName] = fk
+	}
+	table.ForeignKeys = foreignKeys
+	return table
+}
+
+// aliasLLMTable renames a table of the LLM schema, its columns, primary key and the keys of its example records
+func aliasLLMTable(table LLMTableInfo, name string, aliases *SchemaAliases) LLMTableInfo {
+	table.Name = aliases.tableName(table.Name)
+	if table.PrimaryKey != "" {
+		table.PrimaryKey = aliases.columnName(name, table.PrimaryKey)
+	}
+
+	columns := make([]LLMColumnInfo, len(table.Columns))
+	for i, column := range table.Columns {
+		column.Name = aliases.columnName(name, column.Name)
+		columns[i] = column
+	}
+	table.Columns = columns
+
+	if len(table.ExampleRecords) > 0 {
+		records := make([]map[string]interface{}, len(table.ExampleRecords))
+		for i, record := range table.ExampleRecords {
+			aliased := make(map[string]interface{}, len(record))
+			for key, value := range record {
+				aliased[aliases.columnName(name, key)] = value
+			}
+			records[i] = aliased
+		}
+		table.ExampleRecords = records
+	}
+	return table
+}
+
+// aliasColumnList returns the aliases of a table's columns, in the same order
+func aliasColumnList(table string, columns []string, aliases *SchemaAliases) []string {
+	if len(columns) == 0 {
+		return columns
+	}
+	aliased := make([]string, len(columns))
+	for i, column := range columns {
+		aliased[i] = aliases.columnName(table, column)
+	}
+	return aliased
+}
+
+// schemaAliasCorrelationPattern reads the name a query gives a table, e.g. o in FROM order_details o
+var schemaAliasCorrelationPattern = regexp.MustCompile("(?i)\\b(?:FROM|JOIN|UPDATE|INTO)\\s+[`\"]?([A-Za-z_][A-Za-z0-9_.$]*)[`\"]?(?:\\s+AS)?\\s+[`\"]?([A-Za-z_][A-Za-z0-9_]*)")
+
+// schemaAliasTarget is a real column an alias stands for
+type schemaAliasTarget struct {
+	table  string
+	column string
+}
+
+// ResolveSchemaAliases maps the table and column aliases of a query written by the LLM back to the real names.
+// Identifiers are replaced outside string literals and comments. A column alias used by several tables is only
+// replaced when it is qualified with the (aliased) table name, since the query alone doesn't tell which is meant.
+func ResolveSchemaAliases(query, dbType string, aliases *SchemaAliases) string {
+	if aliases.IsEmpty() || !SupportsSchemaAliases(dbType) {
+		return query
+	}
+
+	tables := make(map[string]string, len(aliases.Tables))
+	for table, alias := range aliases.Tables {
+		tables[strings.ToLower(alias)] = table
+	}
+	columns := make(map[string][]schemaAliasTarget, len(aliases.Columns))
+	for key, alias := range aliases.Columns {
+		if table, column, ok := splitColumnAliasKey(key); ok {
+			columns[strings.ToLower(alias)] = append(columns[strings.ToLower(alias)], schemaAliasTarget{table: table, column: column})
+		}
+	}
+
+	// Names the query gives its tables qualify columns like the tables themselves
+	correlations := make(map[string]string)
+	for _, match := range schemaAliasCorrelationPattern.FindAllStringSubmatch(query, -1) {
+		if schemaAliasReservedWords[strings.ToUpper(match[2])] {
+			continue
+		}
+		table := match[1]
+		if realTable, ok := tables[strings.ToLower(table)]; ok {
+			table = realTable
+		}
+		correlations[strings.ToLower(match[2])] = table
+	}
+
+	backtickQuotes := dbType == constants.DatabaseTypeMySQL || dbType == constants.DatabaseTypeStarRocks ||
+		dbType == constants.DatabaseTypePlanetscale || dbType == constants.DatabaseTypeClickhouse
+
+	// resolve returns the real name of an identifier, qualifier is the real table before a dot, if any
+	resolve := func(name, qualifier string) (string, bool) {
+		lower := strings.ToLower(name)
+		if table, ok := correlations[strings.ToLower(qualifier)]; ok {
+			qualifier = table
+		}
+		if targets, ok := columns[lower]; ok {
+			// Aliases are never real column names, so an alias of a single column can only mean that column
+			if len(targets) == 1 {
+				return targets[0].column, true
+			}
+			for _, target := range targets {
+				if strings.EqualFold(target.table, qualifier) {
+					return target.column, true
+				}
+			}
+		}
+		if table, ok := tables[lower]; ok {
+			return table, true
+		}
+		return "", false
+	}
+
+	var result strings.Builder
+	result.Grow(len(query))
+	runes := []rune(query)
+	qualifier := ""
+	afterAs := false
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\'':
+			end := i + 1
+			for ; end < len(runes); end++ {
+				if backtickQuotes && runes[end] == '\\' {
+					end++
+					continue
+				}
+				if runes[end] == '\'' {
+					if end+1 < len(runes) && runes[end+1] == '\'' {
+						end++
+						continue
+					}
+					break
+				}
+			}
+			end = min(end+1, len(runes))
+			result.WriteString(string(runes[i:end]))
+			i = end
+			qualifier, afterAs = "", false
+
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			end := i
+			for end < len(runes) && runes[end] != '\n' {
+				end++
+			}
+			result.WriteString(string(runes[i:end]))
+			i = end
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			for end+1 < len(runes) && !(runes[end] == '*' && runes[end+1] == '/') {
+				end++
+			}
+			end = min(end+2, len(runes))
+			result.WriteString(string(runes[i:end]))
+			i = end
+
+		case r == '"' || (backtickQuotes && r == '`'):
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			name := string(runes[i+1 : min(end, len(runes))])
+			end = min(end+1, len(runes))
+			realName, ok := "", false
+			if !afterAs {
+				realName, ok = resolve(name, qualifier)
+			}
+			if ok {
+				result.WriteRune(r)
+				result.WriteString(realName)
+				result.WriteRune(r)
+			} else {
+				result.WriteString(string(runes[i:end]))
+			}
+			i = end
+			qualifier, afterAs = nextAliasQualifier(runes, end, realName, name, ok), false
+
+		case r == '_' || (r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')):
+			end := i + 1
+			for end < len(runes) && (runes[end] == '_' || runes[end] == '$' || runes[end] < 128 &&
+				(runes[end] >= 'a' && runes[end] <= 'z' || runes[end] >= 'A' && runes[end] <= 'Z' || runes[end] >= '0' && runes[end] <= '9')) {
+				end++
+			}
+			word := string(runes[i:end])
+			realName, ok := "", false
+			// Function calls, the names given with AS and bound parameters (:name, @name) are the query's own
+			isParameter := i > 0 && (runes[i-1] == '@' || runes[i-1] == '$' || (runes[i-1] == ':' && (i < 2 || runes[i-2] != ':')))
+			if !afterAs && !isParameter && (end >= len(runes) || runes[end] != '(') {
+				realName, ok = resolve(word, qualifier)
+			}
+			if ok {
+				result.WriteString(realName)
+			} else {
+				result.WriteString(word)
+			}
+			i = end
+			qualifier = nextAliasQualifier(runes, end, realName, word, ok)
+			afterAs = strings.EqualFold(word, "AS")
+
+		default:
+			result.WriteRune(r)
+			i++
+			if !isSpaceRune(r) && r != '.' {
+				qualifier, afterAs = "", false
+			}
+		}
+	}
+	return result.String()
+}
+
+// nextAliasQualifier returns the table name qualifying the next identifier when a dot follows the current one
+func nextAliasQualifier(runes []rune, end int, realName, name string, resolved bool) string {
+	if end < len(runes) && runes[end] == '.' {
+		if resolved {
+			return realName
+		}
+		return name
+	}
+	return ""
+}
+
+// isSpaceRune reports whether r is whitespace
+func isSpaceRune(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
@@ -0,0 +1,126 @@
+package dbmanager
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kinds of breaking schema changes
+const (
+	BreakingChangeDropped     = "dropped"
+	BreakingChangeTypeChanged = "type_changed"
+	BreakingChangeRenamed     = "renamed"
+)
+
+// BreakingChange is a schema change that can make previously working queries fail.
+// Column is empty when the whole table was dropped.
+type BreakingChange struct {
+	Table      string `json:"table"`
+	Column     string `json:"column,omitempty"`
+	ChangeType string `json:"changeType"`
+	OldValue   string `json:"oldValue,omitempty"`
+	NewValue   string `json:"newValue,omitempty"`
+}
+
+// findBreakingChanges compares the stored schema with the current one and returns the dropped tables,
+// dropped columns, renamed columns and column type changes, sorted by table and column.
+func findBreakingChanges(oldSchema, newSchema *SchemaInfo) []BreakingChange {
+	if oldSchema == nil || newSchema == nil {
+		return nil
+	}
+
+	var changes []BreakingChange
+	for tableName, oldTable := range oldSchema.Tables {
+		newTable, exists := newSchema.Tables[tableName]
+		if !exists {
+			changes = append(changes, BreakingChange{
+				Table:      tableName,
+				ChangeType: BreakingChangeDropped,
+				OldValue:   tableName,
+			})
+			continue
+		}
+		changes = append(changes, findColumnBreakingChanges(tableName, oldTable, newTable)...)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Table != changes[j].Table {
+			return changes[i].Table < changes[j].Table
+		}
+		return changes[i].Column < changes[j].Column
+	})
+	return changes
+}
+
+// findColumnBreakingChanges finds the breaking column changes of a table present in both schemas.
+// A removed column is reported as renamed when exactly one added column has the same type and nullability,
+// since the schema alone can't tell a rename from a drop and an unrelated add otherwise.
+func findColumnBreakingChanges(tableName string, oldTable, newTable TableSchema) []BreakingChange {
+	var changes []BreakingChange
+
+	var addedColumns []ColumnInfo
+	for colName, newCol := range newTable.Columns {
+		if _, exists := oldTable.Columns[colName]; !exists {
+			newCol.Name = colName
+			addedColumns = append(addedColumns, newCol)
+		}
+	}
+
+	var removedColumns []ColumnInfo
+	for colName, oldCol := range oldTable.Columns {
+		newCol, exists := newTable.Columns[colName]
+		if !exists {
+			oldCol.Name = colName
+			removedColumns = append(removedColumns, oldCol)
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(oldCol.Type), strings.TrimSpace(newCol.Type)) {
+			changes = append(changes, BreakingChange{
+				Table:      tableName,
+				Column:     colName,
+				ChangeType: BreakingChangeTypeChanged,
+				OldValue:   oldCol.Type,
+				NewValue:   newCol.Type,
+			})
+		}
+	}
+
+	for _, oldCol := range removedColumns {
+		var candidates []ColumnInfo
+		for _, newCol := range addedColumns {
+			if strings.EqualFold(oldCol.Type, newCol.Type) && oldCol.IsNullable == newCol.IsNullable {
+				candidates = append(candidates, newCol)
+			}
+		}
+
+		if len(candidates) == 1 && countColumnsLike(removedColumns, candidates[0]) == 1 {
+			changes = append(changes, BreakingChange{
+				Table:      tableName,
+				Column:     oldCol.Name,
+				ChangeType: BreakingChangeRenamed,
+				OldValue:   oldCol.Name,
+				NewValue:   candidates[0].Name,
+			})
+			continue
+		}
+
+		changes = append(changes, BreakingChange{
+			Table:      tableName,
+			Column:     oldCol.Name,
+			ChangeType: BreakingChangeDropped,
+			OldValue:   oldCol.Type,
+		})
+	}
+	return changes
+}
+
+// countColumnsLike counts the columns with the same type and nullability as column
+func countColumnsLike(columns []ColumnInfo, column ColumnInfo) int {
+	count := 0
+	for _, col := range columns {
+		if strings.EqualFold(col.Type, column.Type) && col.IsNullable == column.IsNullable {
+			count++
+		}
+	}
+	return count
+}
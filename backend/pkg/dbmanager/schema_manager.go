@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"neobase-ai/internal/constants"
+	"neobase-ai/pkg/embedding"
 	"neobase-ai/pkg/redis"
 	"reflect"
 	"sort"
@@ -42,6 +43,8 @@ type TableSchema struct {
 	Checksum    string                    `json:"checksum"`
 	RowCount    int64                     `json:"row_count"`
 	SizeBytes   int64                     `json:"size_bytes"`
+	// Metadata holds database specific table details, such as the ClickHouse engine and replication state
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 type ColumnInfo struct {
@@ -50,6 +53,9 @@ type ColumnInfo struct {
 	IsNullable   bool   `json:"is_nullable"`
 	DefaultValue string `json:"default_value,omitempty"`
 	Comment      string `json:"comment,omitempty"`
+	// JSONKeys are the top-level keys sampled from a JSON column's values. They describe the data rather than
+	// the schema, so they are left out of checksums and column comparisons.
+	JSONKeys []string `json:"json_keys,omitempty"`
 }
 
 type IndexInfo struct {
@@ -75,6 +81,8 @@ type SchemaDiff struct {
 	UpdatedAt      time.Time            `json:"updated_at"`
 	IsFirstTime    bool                 `json:"is_first_time,omitempty"`
 	FullSchema     *SchemaInfo          `json:"full_schema,omitempty"`
+	// BreakingChanges lists the dropped tables and the dropped, renamed or retyped columns
+	BreakingChanges []BreakingChange `json:"breaking_changes,omitempty"`
 }
 
 type TableDiff struct {
@@ -99,6 +107,10 @@ type SchemaStorage struct {
 	TableChecksums map[string]string `json:"table_checksums"`
 
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations from the chat's external schema by table, set only on merged copies (see external_schema.go)
+	ExternalSource    string                        `json:"-"`
+	ExternalRelations map[string][]ExternalRelation `json:"-"`
 }
 
 // LLMSchemaInfo is a simplified schema representation for the LLM
@@ -146,6 +158,11 @@ type SchemaManager struct {
 	dbManager      *Manager
 	fetcherMap     map[string]func(DBExecutor) SchemaFetcher
 	simplifiers    map[string]SchemaSimplifier
+
+	// Embedding-based table selection (optional, see schema_relevance.go)
+	tableEmbedder       embedding.Provider
+	tableReranker       embedding.Reranker
+	tableEmbeddingStore *embedding.TableEmbeddingStore
 }
 
 func NewSchemaManager(redisRepo redis.IRedisRepositories, encryptionKey string, dbManager *Manager) (*SchemaManager, error) {
@@ -517,7 +534,7 @@ func (sm *SchemaManager) getTableChecksums(ctx context.Context, db DBExecutor, d
 	}
 
 	switch dbType {
-	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB:
+	case constants.DatabaseTypePostgreSQL, constants.DatabaseTypeYugabyteDB, constants.DatabaseTypeTimescaleDB, constants.DatabaseTypeSupabase, constants.DatabaseTypeNeon:
 		// Check for context cancellation
 		if err := ctx.Err(); err != nil {
 			log.Printf("getTableChecksums -> context cancelled: %v", err)
@@ -556,8 +573,8 @@ func (sm *SchemaManager) getTableChecksums(ctx context.Context, db DBExecutor, d
 			checksums[tableName] = checksum
 		}
 		return checksums, nil
-	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks:
-		// Implement MySQL / StarRocks checksum calculation
+	case constants.DatabaseTypeMySQL, constants.DatabaseTypeStarRocks, constants.DatabaseTypePlanetscale:
+		// Implement MySQL / StarRocks / PlanetScale checksum calculation
 		checksums := make(map[string]string)
 
 		// Get schema directly from the database
@@ -590,8 +607,10 @@ func (sm *SchemaManager) getTableChecksums(ctx context.Context, db DBExecutor, d
 			checksums[tableName] = checksum
 		}
 		return checksums, nil
-	case constants.DatabaseTypeClickhouse:
-		// Implement ClickHouse checksum calculation
+	case constants.DatabaseTypeClickhouse, constants.DatabaseTypeTrino, constants.DatabaseTypeOracle, constants.DatabaseTypeAirtable, constants.DatabaseTypeInfluxDB,
+		constants.DatabaseTypeTemporal, constants.DatabaseTypePocketBase, constants.DatabaseTypeNATS, constants.DatabaseTypeYugabyteDBCQL,
+		constants.DatabaseTypeFirestore, constants.DatabaseTypeCloudflareD1:
+		// Implement ClickHouse, Trino, Oracle, Airtable, InfluxDB, Temporal, PocketBase, NATS, YCQL, Firestore and D1 checksum calculation
 		checksums := make(map[string]string)
 
 		// Get schema directly from the database
@@ -624,7 +643,7 @@ func (sm *SchemaManager) getTableChecksums(ctx context.Context, db DBExecutor, d
 			checksums[tableName] = checksum
 		}
 		return checksums, nil
-	case constants.DatabaseTypeMongoDB:
+	case constants.DatabaseTypeMongoDB, constants.DatabaseTypeFerretDB:
 		// Implement MongoDB checksum calculation
 		checksums := make(map[string]string)
 
@@ -887,6 +906,9 @@ func (m *SchemaManager) FormatSchemaForLLM(schema *SchemaInfo) string {
 		if table.Comment != "" {
 			result.WriteString(fmt.Sprintf("Description: %s\n", table.Comment))
 		}
+		if engine := table.Metadata[ClickHouseMetadataEngine]; engine != "" {
+			result.WriteString(fmt.Sprintf("Engine: %s\n", engine))
+		}
 
 		// Sort columns for consistent output
 		columnNames := make([]string, 0, len(table.Columns))
@@ -935,6 +957,14 @@ func (m *SchemaManager) FormatSchemaForLLM(schema *SchemaInfo) string {
 				result.WriteString(fmt.Sprintf(" -- %s", column.Comment))
 			}
 
+			if len(column.JSONKeys) > 0 {
+				result.WriteString(fmt.Sprintf(" [JSON keys: %s]", strings.Join(column.JSONKeys, ", ")))
+			}
+
+			if vectorNote := describePgVectorColumn(column, table); vectorNote != "" {
+				result.WriteString(fmt.Sprintf(" [%s]", vectorNote))
+			}
+
 			result.WriteString("\n")
 		}
 
@@ -1082,10 +1112,55 @@ func (m *SchemaManager) FormatSchemaForLLMWithExamples(storage *SchemaStorage) s
 					result.WriteString(fmt.Sprintf(" ON UPDATE %s", fk.OnUpdate))
 				}
 
+				// The external schema names the relation and may say what it means
+				if relation, ok := findExternalRelation(storage.ExternalRelations[tableName], fk); ok {
+					result.WriteString(formatExternalRelationNote(relation))
+				}
+
 				result.WriteString("\n")
 			}
 		}
 
+		// Relations the database has no foreign key for, e.g. with Prisma's relationMode = "prisma"
+		if relations := storage.ExternalRelations[tableName]; len(relations) > 0 {
+			var fullTable TableSchema
+			if storage.FullSchema != nil {
+				fullTable = storage.FullSchema.Tables[tableName]
+			}
+
+			var missing []ExternalRelation
+			for _, relation := range relations {
+				hasForeignKey := false
+				for _, fk := range fullTable.ForeignKeys {
+					if _, ok := findExternalRelation([]ExternalRelation{relation}, fk); ok {
+						hasForeignKey = true
+						break
+					}
+				}
+				if !hasForeignKey {
+					missing = append(missing, relation)
+				}
+			}
+
+			if len(missing) > 0 {
+				result.WriteString(fmt.Sprintf("\nRelations (from %s):\n", storage.ExternalSource))
+				for _, relation := range missing {
+					result.WriteString(fmt.Sprintf("  - (%s) references %s(%s)",
+						strings.Join(relation.Columns, ", "),
+						relation.RefTable,
+						strings.Join(relation.RefColumns, ", ")))
+					if relation.OnDelete != "" {
+						result.WriteString(fmt.Sprintf(" ON DELETE %s", relation.OnDelete))
+					}
+					if relation.OnUpdate != "" {
+						result.WriteString(fmt.Sprintf(" ON UPDATE %s", relation.OnUpdate))
+					}
+					result.WriteString(formatExternalRelationNote(relation))
+					result.WriteString("\n")
+				}
+			}
+		}
+
 		// Add row count information
 		result.WriteString(fmt.Sprintf("\nRow Count: %d\n", table.RowCount))
 
@@ -1432,6 +1507,9 @@ func (sm *SchemaManager) createLLMSchema(schema *SchemaInfo, dbType string) *LLM
 				IsNullable:  col.IsNullable,
 				IsIndexed:   sm.isColumnIndexed(col.Name, table.Indexes),
 			}
+			if vectorNote := describePgVectorColumn(col, table); vectorNote != "" {
+				llmCol.Description = strings.TrimSpace(fmt.Sprintf("%s [%s]", llmCol.Description, vectorNote))
+			}
 			llmTable.Columns = append(llmTable.Columns, llmCol)
 		}
 
@@ -1495,6 +1573,9 @@ func (sm *SchemaManager) createLLMSchema(schema *SchemaInfo, dbType string) *LLM
 				}
 			}
 		}
+		if dbType == constants.DatabaseTypeClickhouse {
+			llmTable.Description += describeClickHouseTableMetadata(table)
+		}
 
 		llmSchema.Tables[tableName] = llmTable
 	}
@@ -1570,10 +1651,17 @@ func (sm *SchemaManager) createLLMSchemaWithExamples(ctx context.Context, schema
 				IsNullable:  col.IsNullable,
 				IsIndexed:   sm.isColumnIndexed(col.Name, table.Indexes),
 			}
+			if vectorNote := describePgVectorColumn(col, table); vectorNote != "" {
+				llmCol.Description = strings.TrimSpace(fmt.Sprintf("%s [%s]", llmCol.Description, vectorNote))
+			}
 			llmTable.Columns = append(llmTable.Columns, llmCol)
 			log.Printf("createLLMSchemaWithExamples -> Added column: %s of simplified type %s", col.Name, simplifiedType)
 		}
 
+		if dbType == constants.DatabaseTypeClickhouse {
+			llmTable.Description += describeClickHouseTableMetadata(table)
+		}
+
 		// Find primary key
 		for constraintName, constraint := range table.Constraints {
 			if constraint.Type == "PRIMARY KEY" && len(constraint.Columns) > 0 {
@@ -1667,6 +1755,16 @@ func (sm *SchemaManager) registerDefaultFetchers() {
 		return &PostgresDriver{}
 	})
 
+	// Register Supabase schema fetcher (adds storage buckets as virtual tables)
+	sm.RegisterFetcher("supabase", func(db DBExecutor) SchemaFetcher {
+		return &SupabaseDriver{}
+	})
+
+	// Register Neon schema fetcher (Neon is serverless PostgreSQL)
+	sm.RegisterFetcher("neon", func(db DBExecutor) SchemaFetcher {
+		return &PostgresDriver{}
+	})
+
 	// Register MySQL schema fetcher
 	sm.RegisterFetcher("mysql", func(db DBExecutor) SchemaFetcher {
 		return NewMySQLSchemaFetcher(db)
@@ -1677,16 +1775,76 @@ func (sm *SchemaManager) registerDefaultFetchers() {
 		return NewMySQLSchemaFetcher(db)
 	})
 
+	// Register PlanetScale schema fetcher (PlanetScale is MySQL-compatible)
+	sm.RegisterFetcher("planetscale", func(db DBExecutor) SchemaFetcher {
+		return NewMySQLSchemaFetcher(db)
+	})
+
 	// Register ClickHouse schema fetcher
 	sm.RegisterFetcher("clickhouse", func(db DBExecutor) SchemaFetcher {
 		return NewClickHouseSchemaFetcher(db)
 	})
 
+	// Register Trino schema fetcher
+	sm.RegisterFetcher("trino", func(db DBExecutor) SchemaFetcher {
+		return NewTrinoSchemaFetcher(db)
+	})
+
+	// Register Oracle schema fetcher
+	sm.RegisterFetcher("oracle", func(db DBExecutor) SchemaFetcher {
+		return NewOracleSchemaFetcher(db)
+	})
+
 	// Register MongoDB schema fetcher
 	sm.RegisterFetcher("mongodb", func(db DBExecutor) SchemaFetcher {
 		return NewMongoDBSchemaFetcher(db)
 	})
 
+	// Register FerretDB schema fetcher (MongoDB-compatible)
+	sm.RegisterFetcher("ferretdb", func(db DBExecutor) SchemaFetcher {
+		return NewMongoDBSchemaFetcher(db)
+	})
+
+	// Register Airtable schema fetcher
+	sm.RegisterFetcher("airtable", func(db DBExecutor) SchemaFetcher {
+		return NewAirtableSchemaFetcher(db)
+	})
+
+	// Register InfluxDB schema fetcher
+	sm.RegisterFetcher("influxdb", func(db DBExecutor) SchemaFetcher {
+		return NewInfluxDBSchemaFetcher(db)
+	})
+
+	// Register Temporal schema fetcher
+	sm.RegisterFetcher("temporal", func(db DBExecutor) SchemaFetcher {
+		return NewTemporalSchemaFetcher(db)
+	})
+
+	// Register PocketBase schema fetcher
+	sm.RegisterFetcher("pocketbase", func(db DBExecutor) SchemaFetcher {
+		return NewPocketBaseSchemaFetcher(db)
+	})
+
+	// Register NATS JetStream schema fetcher
+	sm.RegisterFetcher("nats", func(db DBExecutor) SchemaFetcher {
+		return NewNATSSchemaFetcher(db)
+	})
+
+	// Register YugabyteDB YCQL schema fetcher
+	sm.RegisterFetcher(constants.DatabaseTypeYugabyteDBCQL, func(db DBExecutor) SchemaFetcher {
+		return NewYugabyteDBCQLSchemaFetcher(db)
+	})
+
+	// Register Firestore schema fetcher
+	sm.RegisterFetcher(constants.DatabaseTypeFirestore, func(db DBExecutor) SchemaFetcher {
+		return NewFirestoreSchemaFetcher(db)
+	})
+
+	// Register Cloudflare D1 schema fetcher
+	sm.RegisterFetcher(constants.DatabaseTypeCloudflareD1, func(db DBExecutor) SchemaFetcher {
+		return NewCloudflareD1SchemaFetcher(db)
+	})
+
 	// Register Spreadsheet schema fetcher (uses custom SpreadsheetDriver fetcher)
 	sm.RegisterFetcher("spreadsheet", func(db DBExecutor) SchemaFetcher {
 		return &SpreadsheetDriver{
@@ -1927,8 +2085,10 @@ func (sm *SchemaManager) CompareSchemas(oldSchema, newSchema *SchemaInfo) (*Sche
 		return nil, false
 	}
 
-	log.Printf("SchemaManager -> CompareSchemas -> Changes detected: added tables=%d, removed tables=%d, modified tables=%d",
-		len(diff.AddedTables), len(diff.RemovedTables), len(diff.ModifiedTables))
+	diff.BreakingChanges = findBreakingChanges(oldSchema, newSchema)
+
+	log.Printf("SchemaManager -> CompareSchemas -> Changes detected: added tables=%d, removed tables=%d, modified tables=%d, breaking changes=%d",
+		len(diff.AddedTables), len(diff.RemovedTables), len(diff.ModifiedTables), len(diff.BreakingChanges))
 	return diff, true
 }
 
@@ -2158,8 +2318,11 @@ func (sm *SchemaManager) FormatSchemaWithExamplesAndCollections(ctx context.Cont
 		return "", fmt.Errorf("failed to get schema with examples: %v", err)
 	}
 
-	// Format the schema for LLM
-	return sm.FormatSchemaForLLMWithExamples(storage), nil
+	// Format the schema for LLM, with what the chat's uploaded schema files add to it, without its hidden tables
+	// and with its schema aliases in place of the real names
+	merged := sm.mergeExternalSchema(ctx, chatID, storage)
+	visible := withoutHiddenStorageTables(merged, sm.dbManager.HiddenTables(chatID))
+	return sm.FormatSchemaForLLMWithExamples(withSchemaAliases(visible, sm.dbManager.SchemaAliases(chatID))), nil
 }
 
 // Add a method to register simplifiers
@@ -2196,6 +2359,37 @@ func (sm *SchemaManager) registerDefaultSimplifiers() {
 	// Register ClickHouse simplifier
 	sm.RegisterSimplifier("clickhouse", &ClickHouseSimplifier{})
 
+	// Register Trino simplifier (Trino type names follow ANSI SQL, like PostgreSQL)
+	sm.RegisterSimplifier("trino", &PostgresSimplifier{})
+
+	// Register Oracle simplifier (unknown types pass through, so VARCHAR2(n) and NUMBER(p,s) reach the LLM unchanged)
+	sm.RegisterSimplifier("oracle", &PostgresSimplifier{})
+
 	// Register MongoDB simplifier
 	sm.RegisterSimplifier("mongodb", &MongoDBSimplifier{})
+	sm.RegisterSimplifier("ferretdb", &MongoDBSimplifier{})
+
+	// Register Airtable simplifier
+	sm.RegisterSimplifier("airtable", &AirtableSimplifier{})
+
+	// Register InfluxDB simplifier
+	sm.RegisterSimplifier("influxdb", &InfluxDBSimplifier{})
+
+	// Register Temporal simplifier
+	sm.RegisterSimplifier("temporal", &TemporalSimplifier{})
+
+	// Register PocketBase simplifier
+	sm.RegisterSimplifier("pocketbase", &PocketBaseSimplifier{})
+
+	// Register NATS JetStream simplifier
+	sm.RegisterSimplifier("nats", &NATSSimplifier{})
+
+	// Register YugabyteDB YCQL simplifier
+	sm.RegisterSimplifier(constants.DatabaseTypeYugabyteDBCQL, &YugabyteDBCQLSimplifier{})
+
+	// Register Firestore simplifier
+	sm.RegisterSimplifier(constants.DatabaseTypeFirestore, &FirestoreSimplifier{})
+
+	// Register Cloudflare D1 simplifier
+	sm.RegisterSimplifier(constants.DatabaseTypeCloudflareD1, &CloudflareD1Simplifier{})
 }
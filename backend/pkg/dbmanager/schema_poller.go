@@ -0,0 +1,129 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/config"
+	"time"
+)
+
+// schemaPollHashTTL keeps the last polled schema hash around for a few missed polls
+const schemaPollHashTTL = 24 * time.Hour
+
+// schemaPollHashKey is the Redis key holding the structural schema hash of a chat
+func schemaPollHashKey(chatID string) string {
+	return fmt.Sprintf("schema_poll_hash:%s", chatID)
+}
+
+// startSchemaPoller periodically checks every connected chat for schema changes so that
+// tables added during a session show up without a manual refresh
+func (m *Manager) startSchemaPoller() {
+	interval := time.Duration(config.Env.SchemaPollInterval) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("DBManager -> startSchemaPoller -> Polling schemas every %v", interval)
+
+	for {
+		select {
+		case <-m.stopCleanup:
+			log.Printf("DBManager -> startSchemaPoller -> Schema poller stopped")
+			return
+		case <-ticker.C:
+			m.pollSchemas()
+		}
+	}
+}
+
+// pollSchemas checks the schema of each connected chat, one chat at a time to keep the load on user databases low
+func (m *Manager) pollSchemas() {
+	m.mu.RLock()
+	chatIDs := make([]string, 0, len(m.connections))
+	for chatID, conn := range m.connections {
+		if conn.Status == StatusConnected {
+			chatIDs = append(chatIDs, chatID)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, chatID := range chatIDs {
+		if err := m.pollSchema(chatID); err != nil {
+			log.Printf("DBManager -> pollSchemas -> Schema poll failed for chat %s: %v", chatID, err)
+		}
+	}
+}
+
+// pollSchema fetches the current schema, compares its hash with the one stored in Redis on the
+// previous poll, and runs a full schema check, which notifies the chat service, when it differs
+func (m *Manager) pollSchema(chatID string) error {
+	m.mu.RLock()
+	dbConn, exists := m.connections[chatID]
+	m.mu.RUnlock()
+	if !exists || dbConn.Status != StatusConnected {
+		return nil
+	}
+
+	conn, err := m.GetConnection(chatID)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	schema, err := m.schemaManager.fetchSchema(ctx, conn, dbConn.Config.Type, m.getSelectedTables(chatID))
+	if err != nil {
+		return fmt.Errorf("failed to fetch schema: %v", err)
+	}
+
+	hash, err := structuralSchemaHash(schema)
+	if err != nil {
+		return err
+	}
+
+	key := schemaPollHashKey(chatID)
+	previousHash, _ := m.redisRepo.Get(key, ctx)
+	if err := m.redisRepo.Set(key, []byte(hash), schemaPollHashTTL, ctx); err != nil {
+		log.Printf("DBManager -> pollSchema -> Failed to store schema hash for chat %s: %v", chatID, err)
+	}
+
+	// The first poll only records the baseline
+	if previousHash == "" || previousHash == hash {
+		return nil
+	}
+
+	log.Printf("DBManager -> pollSchema -> Schema hash changed for chat %s, running schema check", chatID)
+	return m.doSchemaCheck(chatID)
+}
+
+// structuralSchemaHash hashes the table and view definitions of a schema, ignoring row counts,
+// sizes and checksums so that data changes alone do not count as a schema change
+func structuralSchemaHash(schema *SchemaInfo) (string, error) {
+	tables := make(map[string]TableSchema, len(schema.Tables))
+	for name, table := range schema.Tables {
+		table.Checksum = ""
+		table.RowCount = 0
+		table.SizeBytes = 0
+		tables[name] = table
+	}
+
+	views := make(map[string]string, len(schema.Views))
+	for name, view := range schema.Views {
+		views[name] = view.Definition
+	}
+
+	data, err := json.Marshal(struct {
+		Tables map[string]TableSchema `json:"tables"`
+		Views  map[string]string      `json:"views"`
+	}{tables, views})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schema: %v", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
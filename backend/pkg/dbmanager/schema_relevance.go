@@ -0,0 +1,245 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"neobase-ai/pkg/embedding"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rerankCandidateMultiplier controls how many cosine-similarity candidates are sent to the
+// reranker: k * multiplier. Reranking a wider pool recovers tables whose names embed poorly.
+const rerankCandidateMultiplier = 3
+
+// SetTableEmbeddings enables embedding-based table selection for GetTopKRelevantTables.
+// reranker may be nil, in which case tables are ranked by cosine similarity alone.
+func (sm *SchemaManager) SetTableEmbeddings(provider embedding.Provider, reranker embedding.Reranker, store *embedding.TableEmbeddingStore) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.tableEmbedder = provider
+	sm.tableReranker = reranker
+	sm.tableEmbeddingStore = store
+}
+
+// IsTableEmbeddingEnabled reports whether embedding-based table selection is configured.
+func (sm *SchemaManager) IsTableEmbeddingEnabled() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.tableEmbedder != nil && sm.tableEmbeddingStore != nil
+}
+
+// GetTopKRelevantTables returns the names of the k tables most relevant to query, most relevant first.
+// Table embeddings are built lazily from the stored LLM schema, without the chat's hidden tables, and rebuilt
+// whenever the schema or the hidden tables change. If the schema has k tables or fewer, all of them are returned without calling the embedding API.
+func (sm *SchemaManager) GetTopKRelevantTables(ctx context.Context, chatID, query string, k int) ([]string, error) {
+	sm.mu.RLock()
+	embedder, reranker, store := sm.tableEmbedder, sm.tableReranker, sm.tableEmbeddingStore
+	sm.mu.RUnlock()
+
+	if embedder == nil || store == nil {
+		return nil, fmt.Errorf("table embeddings are not configured")
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("k must be positive, got: %d", k)
+	}
+
+	storage, err := sm.getStoredSchema(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored schema: %v", err)
+	}
+	// Hidden tables are neither embedded nor sent to the reranker
+	storage = withoutHiddenStorageTables(storage, sm.dbManager.HiddenTables(chatID))
+	if storage.LLMSchema == nil || len(storage.LLMSchema.Tables) == 0 {
+		return nil, fmt.Errorf("no LLM schema found for chat %s", chatID)
+	}
+
+	documents := buildTableDocuments(storage.LLMSchema)
+	if len(documents) <= k || strings.TrimSpace(query) == "" {
+		return sortedTableNames(documents), nil
+	}
+
+	embeddings, err := sm.ensureTableEmbeddings(ctx, chatID, embedder, store, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	queryVector, err := embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %v", err)
+	}
+
+	ranked := embedding.RankTables(embeddings, queryVector)
+
+	// Rerank a wider candidate pool when a reranker is available; fall back to cosine order on failure
+	if reranker != nil {
+		candidateCount := k * rerankCandidateMultiplier
+		if candidateCount > len(ranked) {
+			candidateCount = len(ranked)
+		}
+		candidates := ranked[:candidateCount]
+
+		candidateDocs := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			candidateDocs[i] = documents[candidate.Name]
+		}
+
+		results, rerankErr := reranker.Rerank(ctx, query, candidateDocs, k)
+		if rerankErr == nil && len(results) > 0 {
+			tables := make([]string, 0, len(results))
+			for _, result := range results {
+				if result.Index >= 0 && result.Index < len(candidates) {
+					tables = append(tables, candidates[result.Index].Name)
+				}
+			}
+			log.Printf("SchemaManager -> GetTopKRelevantTables -> chatID: %s, reranked %d candidates to %d tables: %v", chatID, len(candidates), len(tables), tables)
+			return tables, nil
+		}
+		log.Printf("SchemaManager -> GetTopKRelevantTables -> Rerank failed, using cosine similarity order: %v", rerankErr)
+	}
+
+	if k > len(ranked) {
+		k = len(ranked)
+	}
+	tables := make([]string, k)
+	for i := 0; i < k; i++ {
+		tables[i] = ranked[i].Name
+	}
+	log.Printf("SchemaManager -> GetTopKRelevantTables -> chatID: %s, selected %d tables: %v", chatID, len(tables), tables)
+	return tables, nil
+}
+
+// FormatSchemaForTables formats the stored schema for the LLM, limited to the given tables.
+func (sm *SchemaManager) FormatSchemaForTables(ctx context.Context, chatID string, tables []string) (string, error) {
+	storage, err := sm.getStoredSchema(ctx, chatID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get stored schema: %v", err)
+	}
+	if storage.LLMSchema == nil {
+		return "", fmt.Errorf("no LLM schema found for chat %s", chatID)
+	}
+
+	hidden := sm.dbManager.HiddenTables(chatID)
+	wanted := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		if !IsHiddenTable(hidden, table) {
+			wanted[table] = true
+		}
+	}
+
+	filtered := &LLMSchemaInfo{Tables: make(map[string]LLMTableInfo, len(tables))}
+	for name, table := range storage.LLMSchema.Tables {
+		if wanted[name] {
+			filtered.Tables[name] = table
+		}
+	}
+	// Keep only relationships between selected tables so the LLM is not pointed at hidden ones
+	for _, rel := range storage.LLMSchema.Relationships {
+		if wanted[rel.FromTable] && wanted[rel.ToTable] {
+			filtered.Relationships = append(filtered.Relationships, rel)
+		}
+	}
+
+	return sm.FormatSchemaForLLMWithExamples(withSchemaAliases(&SchemaStorage{
+		FullSchema: WithoutHiddenTables(storage.FullSchema, hidden),
+		LLMSchema:  filtered,
+		UpdatedAt:  storage.UpdatedAt,
+	}, sm.dbManager.SchemaAliases(chatID))), nil
+}
+
+// ensureTableEmbeddings returns stored table embeddings, rebuilding them when the schema fingerprint changed.
+func (sm *SchemaManager) ensureTableEmbeddings(ctx context.Context, chatID string, embedder embedding.Provider, store *embedding.TableEmbeddingStore, documents map[string]string) (*embedding.TableEmbeddings, error) {
+	names := sortedTableNames(documents)
+	fingerprint := tableDocumentsFingerprint(names, documents)
+
+	existing, err := store.Get(ctx, chatID)
+	if err != nil {
+		log.Printf("SchemaManager -> ensureTableEmbeddings -> Failed to read stored embeddings, rebuilding: %v", err)
+	} else if existing != nil && existing.Fingerprint == fingerprint && existing.Model == embedder.GetModelName() {
+		return existing, nil
+	}
+
+	log.Printf("SchemaManager -> ensureTableEmbeddings -> Embedding %d tables for chatID: %s", len(names), chatID)
+
+	texts := make([]string, len(names))
+	for i, name := range names {
+		texts[i] = documents[name]
+	}
+
+	vectors, err := embedder.EmbedBatch(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed tables: %v", err)
+	}
+	if len(vectors) != len(names) {
+		return nil, fmt.Errorf("expected %d table embeddings, got %d", len(names), len(vectors))
+	}
+
+	embeddings := &embedding.TableEmbeddings{
+		Fingerprint: fingerprint,
+		Model:       embedder.GetModelName(),
+		Vectors:     make(map[string][]float32, len(names)),
+		UpdatedAt:   time.Now(),
+	}
+	for i, name := range names {
+		embeddings.Vectors[name] = vectors[i]
+	}
+
+	if err := store.Store(ctx, chatID, embeddings); err != nil {
+		// Non-fatal: the embeddings are still usable for this request
+		log.Printf("SchemaManager -> ensureTableEmbeddings -> Failed to store embeddings: %v", err)
+	}
+
+	return embeddings, nil
+}
+
+// buildTableDocuments builds a short text description per table used for embedding and reranking.
+func buildTableDocuments(schema *LLMSchemaInfo) map[string]string {
+	documents := make(map[string]string, len(schema.Tables))
+	for name, table := range schema.Tables {
+		var doc strings.Builder
+		doc.WriteString(fmt.Sprintf("Table: %s", name))
+		if table.Description != "" {
+			doc.WriteString(fmt.Sprintf("\nDescription: %s", table.Description))
+		}
+
+		columns := make([]string, 0, len(table.Columns))
+		for _, col := range table.Columns {
+			if col.Description != "" {
+				columns = append(columns, fmt.Sprintf("%s (%s): %s", col.Name, col.Type, col.Description))
+			} else {
+				columns = append(columns, fmt.Sprintf("%s (%s)", col.Name, col.Type))
+			}
+		}
+		sort.Strings(columns)
+		if len(columns) > 0 {
+			doc.WriteString("\nColumns: ")
+			doc.WriteString(strings.Join(columns, ", "))
+		}
+
+		documents[name] = doc.String()
+	}
+	return documents
+}
+
+// tableDocumentsFingerprint hashes the table documents so stale embeddings can be detected.
+func tableDocumentsFingerprint(names []string, documents map[string]string) string {
+	hash := md5.New()
+	for _, name := range names {
+		hash.Write([]byte(documents[name]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func sortedTableNames(documents map[string]string) []string {
+	names := make([]string, 0, len(documents))
+	for name := range documents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
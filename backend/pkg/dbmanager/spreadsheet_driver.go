@@ -1,13 +1,17 @@
 package dbmanager
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"neobase-ai/config"
 	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 // SpreadsheetDriver implements DatabaseDriver for CSV/Excel storage using PostgreSQL
@@ -24,6 +28,26 @@ type SpreadsheetTransaction struct {
 	driver     *SpreadsheetDriver
 }
 
+// DetectSpreadsheetFileFormat tells which parser reads an uploaded file, from its extension. A .json file
+// holding objects rather than an array, as head (its first bytes) shows, is read as JSON Lines.
+func DetectSpreadsheetFileFormat(filename string, head []byte) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return constants.SpreadsheetFormatCSV, nil
+	case ".xlsx", ".xls":
+		return constants.SpreadsheetFormatExcel, nil
+	case ".jsonl", ".ndjson":
+		return constants.SpreadsheetFormatJSONLines, nil
+	case ".json":
+		trimmed := bytes.TrimSpace(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")))
+		if len(trimmed) > 0 && trimmed[0] == '{' {
+			return constants.SpreadsheetFormatJSONLines, nil
+		}
+		return constants.SpreadsheetFormatJSON, nil
+	}
+	return "", fmt.Errorf("Invalid file type. Only CSV, Excel, JSON and JSON Lines files are allowed")
+}
+
 // NewSpreadsheetDriver creates a new Spreadsheet driver
 func NewSpreadsheetDriver() DatabaseDriver {
 	return &SpreadsheetDriver{
@@ -492,6 +516,82 @@ func (d *SpreadsheetDriver) FetchExampleRecords(ctx context.Context, db DBExecut
 	return records, nil
 }
 
+// BulkInsert loads rows into schemaName.tableName, using the COPY protocol for more than
+// constants.SpreadsheetCopyThreshold rows and a batched INSERT otherwise. Nil values are stored as NULL.
+// A failed COPY is rolled back as a whole, so its rows are retried with the batched INSERT.
+func (d *SpreadsheetDriver) BulkInsert(ctx context.Context, db *sql.DB, schemaName, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if len(rows) > constants.SpreadsheetCopyThreshold {
+		start := time.Now()
+		copied, err := CopyFromRows(ctx, db, schemaName, tableName, columns, rows)
+		if err == nil {
+			log.Printf("SpreadsheetDriver -> BulkInsert -> Copied %d rows into %s.%s in %v", copied, schemaName, tableName, time.Since(start))
+			return copied, nil
+		}
+		log.Printf("SpreadsheetDriver -> BulkInsert -> COPY failed, falling back to a batched INSERT: %v", err)
+	}
+	return d.batchInsert(ctx, db, schemaName, tableName, columns, rows)
+}
+
+// batchInsert inserts rows with parameterized multi-row INSERT statements in a single transaction
+func (d *SpreadsheetDriver) batchInsert(ctx context.Context, db *sql.DB, schemaName, tableName string, columns []string, rows [][]interface{}) (int64, error) {
+	if db == nil {
+		return 0, fmt.Errorf("failed to get SQL DB connection")
+	}
+
+	// PostgreSQL allows at most 65535 bind parameters per statement
+	rowsPerStatement := 65535 / len(columns)
+	if rowsPerStatement == 0 {
+		return 0, fmt.Errorf("table %s has too many columns for a batched insert", tableName)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	var inserted int64
+	for i := 0; i < len(rows); i += rowsPerStatement {
+		end := i + rowsPerStatement
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		valueStrings := make([]string, 0, end-i)
+		args := make([]interface{}, 0, (end-i)*len(columns))
+		for _, row := range rows[i:end] {
+			placeholders := make([]string, len(columns))
+			for j := range columns {
+				var value interface{}
+				if j < len(row) {
+					value = row[j]
+				}
+				args = append(args, value)
+				placeholders[j] = fmt.Sprintf("$%d", len(args))
+			}
+			valueStrings = append(valueStrings, fmt.Sprintf("(%s)", strings.Join(placeholders, ", ")))
+		}
+
+		insertQuery := fmt.Sprintf("INSERT INTO %s.%s (%s) VALUES %s",
+			schemaName, tableName, strings.Join(columns, ", "), strings.Join(valueStrings, ", "))
+		result, err := tx.ExecContext(ctx, insertQuery, args...)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			inserted += n
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit insert: %w", err)
+	}
+	return inserted, nil
+}
+
 // setSearchPath sets the search path for this transaction if not already set
 func (t *SpreadsheetTransaction) setSearchPath(ctx context.Context) error {
 	if t.searchPathSet {
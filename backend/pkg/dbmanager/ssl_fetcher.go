@@ -0,0 +1,272 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"neobase-ai/config"
+	"neobase-ai/internal/constants"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// sslCertKind tells what a downloaded SSL file should hold, it is checked before the file reaches a driver
+type sslCertKind string
+
+const (
+	sslCertKindClientCert sslCertKind = "client certificate"
+	sslCertKindClientKey  sslCertKind = "client key"
+	sslCertKindRootCert   sslCertKind = "CA certificate"
+)
+
+type sslCertCacheEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// sslCertCache keeps downloaded certificates by URL, so reconnects and pooled connections don't download them again
+var sslCertCache = struct {
+	sync.Mutex
+	entries map[string]sslCertCacheEntry
+}{entries: make(map[string]sslCertCacheEntry)}
+
+// prepareSSLCertificates downloads the certificates of a connection, checks them and writes them to temporary files
+// for the driver. The URLs may be https://, s3://bucket/key or gs://bucket/object, empty ones are skipped.
+// The caller removes the returned temporary files once the connection is closed.
+func prepareSSLCertificates(sslCertURL, sslKeyURL, sslRootCertURL string) (certPath, keyPath, rootCertPath string, tempFiles []string, err error) {
+	tempFiles = []string{}
+
+	files := []struct {
+		url  string
+		kind sslCertKind
+		path *string
+	}{
+		{sslCertURL, sslCertKindClientCert, &certPath},
+		{sslKeyURL, sslCertKindClientKey, &keyPath},
+		{sslRootCertURL, sslCertKindRootCert, &rootCertPath},
+	}
+
+	for _, file := range files {
+		if file.url == "" {
+			continue
+		}
+
+		data, err := fetchSSLCertificate(file.url)
+		if err == nil {
+			err = validateSSLCertificate(file.kind, data)
+		}
+		if err == nil {
+			*file.path, err = writeSSLCertificateFile(data)
+		}
+		if err != nil {
+			removeTempFiles(tempFiles)
+			return "", "", "", nil, fmt.Errorf("failed to prepare %s: %v", file.kind, err)
+		}
+		tempFiles = append(tempFiles, *file.path)
+	}
+
+	return certPath, keyPath, rootCertPath, tempFiles, nil
+}
+
+// fetchSSLCertificate returns the contents at a certificate URL, from the cache while they are fresh
+func fetchSSLCertificate(certURL string) ([]byte, error) {
+	sslCertCache.Lock()
+	entry, ok := sslCertCache.entries[certURL]
+	sslCertCache.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.data, nil
+	}
+
+	parsed, err := url.Parse(certURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate URL: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.SSLCertFetchTimeoutSeconds*time.Second)
+	defer cancel()
+
+	var data []byte
+	switch strings.ToLower(parsed.Scheme) {
+	case "https", "http":
+		data, err = fetchSSLCertificateHTTP(ctx, certURL)
+	case "s3":
+		data, err = fetchSSLCertificateS3(ctx, parsed.Host, strings.TrimPrefix(parsed.Path, "/"))
+	case "gs":
+		data, err = fetchSSLCertificateGCS(ctx, parsed.Host, strings.TrimPrefix(parsed.Path, "/"))
+	default:
+		return nil, fmt.Errorf("unsupported certificate URL scheme %q, use https://, s3:// or gs://", parsed.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > constants.SSLCertMaxBytes {
+		return nil, fmt.Errorf("certificate at %s is larger than %d bytes", redactSSLCertURL(parsed), constants.SSLCertMaxBytes)
+	}
+
+	if ttl := time.Duration(config.Env.SSLCertCacheTTLSeconds) * time.Second; ttl > 0 {
+		sslCertCache.Lock()
+		sslCertCache.entries[certURL] = sslCertCacheEntry{data: data, expiresAt: time.Now().Add(ttl)}
+		sslCertCache.Unlock()
+	}
+
+	log.Printf("DBManager -> fetchSSLCertificate -> Downloaded %d bytes from %s", len(data), redactSSLCertURL(parsed))
+	return data, nil
+}
+
+func fetchSSLCertificateHTTP(ctx context.Context, certURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate URL: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch certificate from URL: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch certificate, status: %s", resp.Status)
+	}
+
+	// One byte past the limit is read so an oversized file is reported instead of truncated
+	return io.ReadAll(io.LimitReader(resp.Body, constants.SSLCertMaxBytes+1))
+}
+
+// fetchSSLCertificateS3 reads an S3 object with the default AWS credential chain (env, shared config, instance role)
+func fetchSSLCertificateS3(ctx context.Context, bucket, key string) ([]byte, error) {
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("S3 certificate URLs must look like s3://bucket/key")
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS credentials: %v", err)
+	}
+
+	output, err := s3.NewFromConfig(awsCfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch certificate from s3://%s/%s: %v", bucket, key, err)
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(io.LimitReader(output.Body, constants.SSLCertMaxBytes+1))
+}
+
+// fetchSSLCertificateGCS reads a GCS object with the Application Default Credentials
+func fetchSSLCertificateGCS(ctx context.Context, bucket, object string) ([]byte, error) {
+	if bucket == "" || object == "" {
+		return nil, fmt.Errorf("GCS certificate URLs must look like gs://bucket/object")
+	}
+
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %v", err)
+	}
+	defer client.Close()
+
+	reader, err := client.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch certificate from gs://%s/%s: %v", bucket, object, err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(io.LimitReader(reader, constants.SSLCertMaxBytes+1))
+}
+
+// validateSSLCertificate checks that downloaded data is a PEM file of the expected kind.
+// Certificates must parse and be within their validity period, so an expired CA fails here with its dates
+// instead of as a handshake error from the driver.
+func validateSSLCertificate(kind sslCertKind, data []byte) error {
+	rest := data
+	blocks, certs := 0, 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		blocks++
+
+		if kind == sslCertKindClientKey {
+			// EC keys may come after an EC PARAMETERS block
+			if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+				continue
+			}
+			if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+				return nil
+			}
+			if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+				return nil
+			}
+			if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				return nil
+			}
+			return fmt.Errorf("the private key is malformed or encrypted")
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("malformed certificate: %v", err)
+		}
+		certs++
+		now := time.Now()
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %q expired on %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+		}
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %q is not valid until %s", cert.Subject.CommonName, cert.NotBefore.Format(time.RFC3339))
+		}
+	}
+
+	if blocks == 0 {
+		return fmt.Errorf("the file is not PEM encoded")
+	}
+	if kind == sslCertKindClientKey {
+		return fmt.Errorf("no private key found")
+	}
+	if certs == 0 {
+		return fmt.Errorf("no certificate found")
+	}
+	return nil
+}
+
+func writeSSLCertificateFile(data []byte) (string, error) {
+	tmpFile, err := os.CreateTemp("", "cert-*.pem")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", fmt.Errorf("failed to save certificate: %v", err)
+	}
+	return tmpFile.Name(), nil
+}
+
+// redactSSLCertURL drops the query of a URL for logs and errors, presigned URLs carry their signature there
+func redactSSLCertURL(parsed *url.URL) string {
+	redacted := *parsed
+	redacted.RawQuery = ""
+	redacted.User = nil
+	return redacted.String()
+}
@@ -0,0 +1,206 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+	"strings"
+)
+
+// SupabaseDriver is the PostgreSQL driver with Supabase-aware schema discovery.
+// Storage buckets are added to the schema as virtual tables backed by storage.objects.
+type SupabaseDriver struct {
+	PostgresDriver
+}
+
+func NewSupabaseDriver() DatabaseDriver {
+	return &SupabaseDriver{}
+}
+
+// supabaseStorageObjectColumns mirrors the columns of storage.objects that every virtual bucket table exposes
+var supabaseStorageObjectColumns = map[string]ColumnInfo{
+	"id":               {Name: "id", Type: "uuid", IsNullable: false, DefaultValue: "gen_random_uuid()"},
+	"bucket_id":        {Name: "bucket_id", Type: "text", IsNullable: true, Comment: "References storage.buckets.id"},
+	"name":             {Name: "name", Type: "text", IsNullable: true, Comment: "Object path inside the bucket, e.g. folder/file.png"},
+	"owner":            {Name: "owner", Type: "uuid", IsNullable: true, Comment: "References auth.users.id"},
+	"created_at":       {Name: "created_at", Type: "timestamp with time zone", IsNullable: true, DefaultValue: "now()"},
+	"updated_at":       {Name: "updated_at", Type: "timestamp with time zone", IsNullable: true, DefaultValue: "now()"},
+	"last_accessed_at": {Name: "last_accessed_at", Type: "timestamp with time zone", IsNullable: true, DefaultValue: "now()"},
+	"metadata":         {Name: "metadata", Type: "jsonb", IsNullable: true, Comment: "Contains size, mimetype, eTag and cacheControl"},
+}
+
+// GetSchema returns the public schema plus one virtual table per storage bucket
+func (d *SupabaseDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	allSelected := len(selectedTables) == 0 || selectedTables[0] == "ALL"
+
+	// Split the selection into real tables and storage bucket virtual tables
+	realTables := make([]string, 0, len(selectedTables))
+	selectedBuckets := make(map[string]bool)
+	for _, table := range selectedTables {
+		if bucketID, ok := supabaseBucketIDFromTable(table); ok {
+			selectedBuckets[bucketID] = true
+		} else {
+			realTables = append(realTables, table)
+		}
+	}
+
+	var schema *SchemaInfo
+	if allSelected || len(realTables) > 0 {
+		var err error
+		schema, err = d.PostgresDriver.GetSchema(ctx, db, realTables)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Only storage buckets were selected
+		sqlDB := db.GetDB()
+		if sqlDB == nil {
+			return nil, fmt.Errorf("failed to get SQL DB connection")
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return nil, fmt.Errorf("database connection is not valid: %v", err)
+		}
+		schema = d.PostgresDriver.convertToSchemaInfo(map[string]PostgresTable{}, map[string][]PostgresIndex{}, map[string]PostgresView{})
+	}
+
+	bucketTables, err := d.getStorageBucketTables(ctx, db)
+	if err != nil {
+		// The connecting role may not have access to the storage schema, the public schema is still usable
+		log.Printf("SupabaseDriver -> GetSchema -> Skipping storage buckets: %v", err)
+		return schema, nil
+	}
+
+	for bucketID, table := range bucketTables {
+		if !allSelected && !selectedBuckets[bucketID] {
+			continue
+		}
+		schema.Tables[table.Name] = table
+	}
+
+	log.Printf("SupabaseDriver -> GetSchema -> Added %d storage bucket virtual tables", len(bucketTables))
+	return schema, nil
+}
+
+// getStorageBucketTables lists storage.buckets and builds a virtual table for each, keyed by bucket ID
+func (d *SupabaseDriver) getStorageBucketTables(ctx context.Context, db DBExecutor) (map[string]TableSchema, error) {
+	sqlDB := db.GetDB()
+	if sqlDB == nil {
+		return nil, fmt.Errorf("failed to get SQL DB connection")
+	}
+
+	query := `
+		SELECT b.id, b.name, b.public, COUNT(o.id)
+		FROM storage.buckets b
+		LEFT JOIN storage.objects o ON o.bucket_id = b.id
+		GROUP BY b.id, b.name, b.public
+		ORDER BY b.id;
+	`
+
+	rows, err := sqlDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query storage buckets: %v", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]TableSchema)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var bucketID, bucketName string
+		var public bool
+		var objectCount int64
+		if err := rows.Scan(&bucketID, &bucketName, &public, &objectCount); err != nil {
+			return nil, fmt.Errorf("failed to scan storage bucket: %v", err)
+		}
+
+		visibility := "private"
+		if public {
+			visibility = "public"
+		}
+
+		columns := make(map[string]ColumnInfo, len(supabaseStorageObjectColumns))
+		for name, column := range supabaseStorageObjectColumns {
+			columns[name] = column
+		}
+
+		tableName := constants.SupabaseStorageBucketTablePrefix + bucketID
+		tables[bucketID] = TableSchema{
+			Name:    tableName,
+			Columns: columns,
+			Indexes: map[string]IndexInfo{},
+			ForeignKeys: map[string]ForeignKey{
+				"owner": {Name: "owner", ColumnName: "owner", RefTable: "auth.users", RefColumn: "id"},
+			},
+			Constraints: map[string]ConstraintInfo{},
+			Comment: fmt.Sprintf("Virtual table for the %s Supabase storage bucket %q. Query it as SELECT ... FROM storage.objects WHERE bucket_id = '%s'",
+				visibility, bucketName, strings.ReplaceAll(bucketID, "'", "''")),
+			Checksum: utils.MD5Hash(fmt.Sprintf("%s:%s:%t", bucketID, bucketName, public)),
+			RowCount: objectCount,
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read storage buckets: %v", err)
+	}
+
+	return tables, nil
+}
+
+// GetTableChecksum calculates the checksum for a table, using the bucket definition for storage bucket virtual tables
+func (d *SupabaseDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	bucketID, ok := supabaseBucketIDFromTable(table)
+	if !ok {
+		return d.PostgresDriver.GetTableChecksum(ctx, db, table)
+	}
+
+	sqlDB := db.GetDB()
+	if sqlDB == nil {
+		return "", fmt.Errorf("failed to get SQL DB connection")
+	}
+
+	var bucketName string
+	var public bool
+	if err := sqlDB.QueryRowContext(ctx, "SELECT name, public FROM storage.buckets WHERE id = $1", bucketID).Scan(&bucketName, &public); err != nil {
+		return "", fmt.Errorf("failed to get storage bucket checksum: %v", err)
+	}
+
+	return utils.MD5Hash(fmt.Sprintf("%s:%s:%t", bucketID, bucketName, public)), nil
+}
+
+// FetchExampleRecords fetches sample rows, reading storage.objects for storage bucket virtual tables
+func (d *SupabaseDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	bucketID, ok := supabaseBucketIDFromTable(table)
+	if !ok {
+		return d.PostgresDriver.FetchExampleRecords(ctx, db, table, limit)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	query := fmt.Sprintf("SELECT id, bucket_id, name, owner, created_at, updated_at, metadata FROM storage.objects WHERE bucket_id = $1 ORDER BY created_at DESC LIMIT %d", limit)
+
+	var records []map[string]interface{}
+	if err := db.QueryRows(query, &records, bucketID); err != nil {
+		return nil, fmt.Errorf("failed to fetch example records for storage bucket %s: %v", bucketID, err)
+	}
+	if len(records) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	return records, nil
+}
+
+// supabaseBucketIDFromTable returns the bucket ID if the table name refers to a storage bucket virtual table
+func supabaseBucketIDFromTable(table string) (string, bool) {
+	if !strings.HasPrefix(table, constants.SupabaseStorageBucketTablePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(table, constants.SupabaseStorageBucketTablePrefix), true
+}
@@ -0,0 +1,866 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	temporalclient "go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TemporalClient wraps a Temporal SDK client bound to the connection's namespace
+type TemporalClient struct {
+	client    temporalclient.Client
+	address   string
+	namespace string
+	tempFiles []string
+}
+
+// temporalCommand is a parsed Temporal CLI command
+type temporalCommand struct {
+	// Action is list, count, describe, signal, cancel, terminate or namespaces
+	Action string
+	Flags  map[string]string
+}
+
+// temporalCommandFlags lists the flags each command accepts
+var temporalCommandFlags = map[string][]string{
+	"list":       {"query", "limit", "namespace", "next-page-token"},
+	"count":      {"query", "namespace"},
+	"describe":   {"workflow-id", "run-id"},
+	"signal":     {"workflow-id", "run-id", "name", "input"},
+	"cancel":     {"workflow-id", "run-id"},
+	"terminate":  {"workflow-id", "run-id", "reason"},
+	"namespaces": {},
+}
+
+// temporalFlagAliases maps the short flags of the Temporal CLI to their long names
+var temporalFlagAliases = map[string]string{
+	"q": "query",
+	"n": "namespace",
+	"w": "workflow-id",
+	"r": "run-id",
+	"i": "input",
+}
+
+// newTemporalClient dials the Temporal frontend service of the connection config.
+// The password is used as a Temporal Cloud API key, SSL certificates enable mTLS.
+func newTemporalClient(config ConnectionConfig) (*TemporalClient, error) {
+	address := getValue(config.TemporalAddress)
+	if address == "" {
+		if config.Host == "" {
+			return nil, fmt.Errorf("a Temporal address is required")
+		}
+		port := constants.TemporalDefaultPort
+		if config.Port != nil && *config.Port != "" {
+			port = *config.Port
+		}
+		address = net.JoinHostPort(config.Host, port)
+	}
+
+	namespace := getValue(config.TemporalNamespace)
+	if namespace == "" {
+		namespace = constants.TemporalDefaultNamespace
+	}
+
+	options := temporalclient.Options{
+		HostPort:  address,
+		Namespace: namespace,
+	}
+
+	apiKey := getValue(config.Password)
+	var tempFiles []string
+	if config.UseSSL || apiKey != "" {
+		tlsConfig, certTempFiles, err := buildTemporalTLSConfig(config, address)
+		if err != nil {
+			return nil, err
+		}
+		tempFiles = certTempFiles
+		options.ConnectionOptions.TLS = tlsConfig
+	}
+	if apiKey != "" {
+		options.Credentials = temporalclient.NewAPIKeyStaticCredentials(apiKey)
+	}
+
+	c, err := temporalclient.Dial(options)
+	if err != nil {
+		removeTempFiles(tempFiles)
+		return nil, fmt.Errorf("failed to connect to Temporal at %s: %v", address, err)
+	}
+
+	return &TemporalClient{
+		client:    c,
+		address:   address,
+		namespace: namespace,
+		tempFiles: tempFiles,
+	}, nil
+}
+
+// buildTemporalTLSConfig builds the TLS config for Temporal Cloud or a self-hosted cluster with mTLS
+func buildTemporalTLSConfig(config ConnectionConfig, address string) (*tls.Config, []string, error) {
+	serverName, _, err := net.SplitHostPort(address)
+	if err != nil {
+		serverName = address
+	}
+	tlsConfig := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	certPath, keyPath, rootCertPath, tempFiles, err := prepareSSLCertificates(
+		getValue(config.SSLCertURL), getValue(config.SSLKeyURL), getValue(config.SSLRootCertURL))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if certPath != "" && keyPath != "" {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			removeTempFiles(tempFiles)
+			return nil, nil, fmt.Errorf("failed to load client certificates: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if rootCertPath != "" {
+		pem, err := os.ReadFile(rootCertPath)
+		if err != nil {
+			removeTempFiles(tempFiles)
+			return nil, nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		rootCertPool := x509.NewCertPool()
+		if !rootCertPool.AppendCertsFromPEM(pem) {
+			removeTempFiles(tempFiles)
+			return nil, nil, fmt.Errorf("failed to parse CA certificate")
+		}
+		tlsConfig.RootCAs = rootCertPool
+	}
+
+	return tlsConfig, tempFiles, nil
+}
+
+// removeTempFiles deletes downloaded certificate files
+func removeTempFiles(files []string) {
+	for _, file := range files {
+		os.Remove(file)
+	}
+}
+
+// close closes the SDK client and removes its certificate files
+func (c *TemporalClient) close() {
+	c.client.Close()
+	removeTempFiles(c.tempFiles)
+}
+
+// ping checks the frontend service and that the connection's namespace exists
+func (c *TemporalClient) ping(ctx context.Context) error {
+	_, err := c.client.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: c.namespace,
+	})
+	return err
+}
+
+// listWorkflows reads executions from the visibility store, following next page tokens until limit is reached.
+// It returns the token of the page after the last execution, empty when there are no more executions.
+func (c *TemporalClient) listWorkflows(ctx context.Context, namespace, query string, limit int, pageToken []byte) ([]map[string]interface{}, []byte, error) {
+	rows := make([]map[string]interface{}, 0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, fmt.Errorf("query execution cancelled: %v", err)
+		}
+
+		// Never request more than the remaining limit, so the next page token starts right after the last row
+		pageSize := limit - len(rows)
+		if pageSize > constants.TemporalPageSize {
+			pageSize = constants.TemporalPageSize
+		}
+
+		resp, err := c.client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     namespace,
+			PageSize:      int32(pageSize),
+			NextPageToken: pageToken,
+			Query:         query,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, info := range resp.GetExecutions() {
+			rows = append(rows, temporalExecutionRow(info))
+		}
+
+		pageToken = resp.GetNextPageToken()
+		if len(pageToken) == 0 || len(rows) >= limit {
+			return rows, pageToken, nil
+		}
+	}
+}
+
+// countWorkflows counts the executions matching a visibility query
+func (c *TemporalClient) countWorkflows(ctx context.Context, namespace, query string) (int64, error) {
+	resp, err := c.client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: namespace,
+		Query:     query,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetCount(), nil
+}
+
+// listNamespaces returns the names of the registered namespaces of the cluster
+func (c *TemporalClient) listNamespaces(ctx context.Context) ([]string, error) {
+	names := []string{}
+	var pageToken []byte
+	for {
+		resp, err := c.client.WorkflowService().ListNamespaces(ctx, &workflowservice.ListNamespacesRequest{
+			PageSize:      int32(constants.TemporalPageSize),
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range resp.GetNamespaces() {
+			if name := namespace.GetNamespaceInfo().GetName(); name != "" {
+				names = append(names, name)
+			}
+		}
+		pageToken = resp.GetNextPageToken()
+		if len(pageToken) == 0 {
+			return names, nil
+		}
+	}
+}
+
+// temporalExecutionRow flattens a workflow execution into a row keyed by search attribute names
+func temporalExecutionRow(info *workflowpb.WorkflowExecutionInfo) map[string]interface{} {
+	row := map[string]interface{}{
+		"WorkflowId":      info.GetExecution().GetWorkflowId(),
+		"RunId":           info.GetExecution().GetRunId(),
+		"WorkflowType":    info.GetType().GetName(),
+		"ExecutionStatus": temporalEnumName(info.GetStatus().String(), "WORKFLOW_EXECUTION_STATUS_"),
+		"HistoryLength":   info.GetHistoryLength(),
+		"TaskQueue":       info.GetTaskQueue(),
+	}
+	if startTime := temporalTimestamp(info.GetStartTime()); startTime != nil {
+		row["StartTime"] = startTime
+	}
+	if executionTime := temporalTimestamp(info.GetExecutionTime()); executionTime != nil {
+		row["ExecutionTime"] = executionTime
+	}
+	if closeTime := temporalTimestamp(info.GetCloseTime()); closeTime != nil {
+		row["CloseTime"] = closeTime
+	}
+
+	dataConverter := converter.GetDefaultDataConverter()
+	for name, payload := range info.GetSearchAttributes().GetIndexedFields() {
+		if _, exists := row[name]; exists {
+			continue
+		}
+		var value interface{}
+		if err := dataConverter.FromPayload(payload, &value); err != nil {
+			log.Printf("TemporalDriver -> temporalExecutionRow -> Failed to decode search attribute %s: %v", name, err)
+			continue
+		}
+		row[name] = value
+	}
+	return row
+}
+
+// temporalTimestamp formats a protobuf timestamp as RFC 3339, nil when it is not set
+func temporalTimestamp(ts *timestamppb.Timestamp) interface{} {
+	if ts == nil || (ts.GetSeconds() == 0 && ts.GetNanos() == 0) {
+		return nil
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+// temporalEnumName turns a Temporal enum name such as WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW
+// into the value visibility queries use, ContinuedAsNew
+func temporalEnumName(value, prefix string) string {
+	value = strings.TrimPrefix(value, prefix)
+	if strings.ToUpper(value) != value {
+		return value
+	}
+	var name strings.Builder
+	for _, part := range strings.Split(strings.ToLower(value), "_") {
+		if part == "" {
+			continue
+		}
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return name.String()
+}
+
+// splitTemporalCommand splits a command into arguments like a POSIX shell: single quotes are literal,
+// double quotes allow \" and \\ escapes, and a backslash before a newline continues the line
+func splitTemporalCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			escaped = false
+			if quote == '"' && r != '"' && r != '\\' {
+				current.WriteRune('\\')
+			}
+			if r != '\n' {
+				current.WriteRune(r)
+			}
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' {
+				escaped = true
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in command", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// parseTemporalCommand parses a temporal workflow or temporal operator namespace list command
+func parseTemporalCommand(query string) (*temporalCommand, error) {
+	query = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	args, err := splitTemporalCommand(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && args[0] == "temporal" {
+		args = args[1:]
+	}
+
+	command := &temporalCommand{Flags: make(map[string]string)}
+	switch {
+	case len(args) >= 2 && args[0] == "workflow":
+		command.Action = args[1]
+		args = args[2:]
+	case len(args) >= 3 && args[0] == "operator" && args[1] == "namespace" && args[2] == "list":
+		command.Action = "namespaces"
+		args = args[3:]
+	default:
+		return nil, fmt.Errorf("unsupported Temporal command, use temporal workflow list|count|describe|signal|cancel|terminate or temporal operator namespace list")
+	}
+
+	allowed, ok := temporalCommandFlags[command.Action]
+	if !ok {
+		return nil, fmt.Errorf("unsupported command temporal workflow %s, use list, count, describe, signal, cancel or terminate", command.Action)
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return nil, fmt.Errorf("unexpected argument %q, values must follow a flag such as --query", arg)
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if index := strings.Index(name, "="); index >= 0 {
+			name, value, hasValue = name[:index], name[index+1:], true
+		}
+		if alias, ok := temporalFlagAliases[name]; ok {
+			name = alias
+		}
+
+		if !containsString(allowed, name) {
+			return nil, fmt.Errorf("unknown flag --%s for this Temporal command", name)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("flag --%s needs a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		command.Flags[name] = value
+	}
+
+	switch command.Action {
+	case "describe", "signal", "cancel", "terminate":
+		if command.Flags["workflow-id"] == "" {
+			return nil, fmt.Errorf("temporal workflow %s requires --workflow-id", command.Action)
+		}
+	}
+	if command.Action == "signal" && command.Flags["name"] == "" {
+		return nil, fmt.Errorf("temporal workflow signal requires --name")
+	}
+	return command, nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// TemporalDriver implements the DatabaseDriver interface for Temporal's visibility store
+type TemporalDriver struct{}
+
+// NewTemporalDriver creates a new Temporal driver
+func NewTemporalDriver() DatabaseDriver {
+	return &TemporalDriver{}
+}
+
+// Connect dials the Temporal frontend service and checks the namespace
+func (d *TemporalDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newTemporalClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.TemporalRequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		client.close()
+		return nil, fmt.Errorf("failed to connect to Temporal namespace %s: %v", client.namespace, err)
+	}
+
+	log.Printf("TemporalDriver -> Connect -> Connected to Temporal namespace %s at %s", client.namespace, client.address)
+
+	conn := &Connection{
+		DB:          nil, // Temporal is queried over gRPC, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect closes the SDK client
+func (d *TemporalDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*TemporalClient)
+	if !ok {
+		return fmt.Errorf("invalid Temporal connection")
+	}
+	client.close()
+	return nil
+}
+
+// Ping checks if the Temporal namespace is still reachable
+func (d *TemporalDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*TemporalClient)
+	if !ok {
+		return fmt.Errorf("invalid Temporal connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("TemporalDriver -> Ping -> Temporal check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the Temporal connection is still valid
+func (d *TemporalDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("TemporalDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a Temporal CLI command
+func (d *TemporalDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*TemporalClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeTemporalCommand(ctx, client, query)
+}
+
+// executeTemporalCommand runs a single command. Signals, cancellations and terminations
+// reach the workflow immediately, there is nothing to roll back.
+func executeTemporalCommand(ctx context.Context, client *TemporalClient, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	command, err := parseTemporalCommand(query)
+	if err != nil {
+		result.Error = &dtos.QueryError{
+			Message: err.Error(),
+			Code:    "INVALID_QUERY",
+		}
+		return result
+	}
+
+	log.Printf("TemporalDriver -> executeTemporalCommand -> Running %s on namespace %s", command.Action, client.namespace)
+
+	namespace := command.Flags["namespace"]
+	if namespace == "" {
+		namespace = client.namespace
+	}
+	workflowID, runID := command.Flags["workflow-id"], command.Flags["run-id"]
+
+	switch command.Action {
+	case "list":
+		limit := constants.TemporalDefaultListLimit
+		if value, ok := command.Flags["limit"]; ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 {
+				result.Error = &dtos.QueryError{Message: fmt.Sprintf("--limit must be a positive number, got %q", value), Code: "INVALID_QUERY"}
+				return result
+			}
+			limit = parsed
+		}
+		var pageToken []byte
+		if value := command.Flags["next-page-token"]; value != "" {
+			pageToken, err = base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				result.Error = &dtos.QueryError{Message: fmt.Sprintf("invalid --next-page-token: %v", err), Code: "INVALID_QUERY"}
+				return result
+			}
+		}
+
+		rows, nextPageToken, err := client.listWorkflows(ctx, namespace, command.Flags["query"], limit, pageToken)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		// The cursor pagination of the chat reads the next page's token from the last row
+		if len(nextPageToken) > 0 && len(rows) > 0 {
+			rows[len(rows)-1][constants.TemporalNextPageTokenField] = base64.StdEncoding.EncodeToString(nextPageToken)
+		}
+		result.Result = map[string]interface{}{
+			"results": rows,
+		}
+
+	case "count":
+		count, err := client.countWorkflows(ctx, namespace, command.Flags["query"])
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.Result = map[string]interface{}{
+			"count": count,
+		}
+
+	case "describe":
+		resp, err := client.client.DescribeWorkflowExecution(ctx, workflowID, runID)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		row := temporalExecutionRow(resp.GetWorkflowExecutionInfo())
+		pendingActivities := make([]map[string]interface{}, 0, len(resp.GetPendingActivities()))
+		for _, activity := range resp.GetPendingActivities() {
+			pendingActivities = append(pendingActivities, map[string]interface{}{
+				"ActivityId":   activity.GetActivityId(),
+				"ActivityType": activity.GetActivityType().GetName(),
+				"State":        temporalEnumName(activity.GetState().String(), "PENDING_ACTIVITY_STATE_"),
+				"Attempt":      activity.GetAttempt(),
+			})
+		}
+		row["PendingActivities"] = pendingActivities
+		row["PendingChildren"] = len(resp.GetPendingChildren())
+		result.Result = map[string]interface{}{
+			"results": []map[string]interface{}{row},
+		}
+
+	case "namespaces":
+		names, err := client.listNamespaces(ctx)
+		if err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		rows := make([]map[string]interface{}, 0, len(names))
+		for _, name := range names {
+			rows = append(rows, map[string]interface{}{"Namespace": name})
+		}
+		result.Result = map[string]interface{}{
+			"results": rows,
+		}
+
+	case "signal":
+		var input interface{}
+		if value := command.Flags["input"]; value != "" {
+			if err := json.Unmarshal([]byte(value), &input); err != nil {
+				result.Error = &dtos.QueryError{Message: fmt.Sprintf("--input must be JSON: %v", err), Code: "INVALID_QUERY"}
+				return result
+			}
+		}
+		if err := client.client.SignalWorkflow(ctx, workflowID, runID, command.Flags["name"], input); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.RowsAffected = 1
+		result.Result = map[string]interface{}{
+			"rowsAffected": 1,
+			"message":      fmt.Sprintf("Signal %s sent to workflow %s", command.Flags["name"], workflowID),
+		}
+
+	case "cancel":
+		if err := client.client.CancelWorkflow(ctx, workflowID, runID); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.RowsAffected = 1
+		result.Result = map[string]interface{}{
+			"rowsAffected": 1,
+			"message":      fmt.Sprintf("Cancellation requested for workflow %s", workflowID),
+		}
+
+	case "terminate":
+		if err := client.client.TerminateWorkflow(ctx, workflowID, runID, command.Flags["reason"]); err != nil {
+			result.Error = &dtos.QueryError{Message: err.Error(), Code: "EXECUTION_ERROR"}
+			return result
+		}
+		result.RowsAffected = 1
+		result.Result = map[string]interface{}{
+			"rowsAffected": 1,
+			"message":      fmt.Sprintf("Workflow %s terminated", workflowID),
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// temporalInjectPageToken substitutes the next page token into a paginated list.
+// Tokens are base64, so they need no escaping inside the quoted flag value.
+func temporalInjectPageToken(query, token string) string {
+	return strings.ReplaceAll(query, "{{cursor_value}}", token)
+}
+
+// BeginTx returns a transaction that executes commands immediately.
+// Temporal has no transactions, every signal, cancellation and termination is applied as soon as it is sent.
+func (d *TemporalDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*TemporalClient)
+	if !ok {
+		log.Printf("TemporalDriver.BeginTx: Invalid Temporal connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &TemporalTransaction{
+		client: client,
+	}
+}
+
+// TemporalTransaction implements the Transaction interface for Temporal in autocommit mode
+type TemporalTransaction struct {
+	client *TemporalClient
+}
+
+// ExecuteQuery executes a command. Writes reach the workflow as soon as they are sent.
+func (t *TemporalTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeTemporalCommand(ctx, t.client, query), nil
+}
+
+// Commit is a no-op as commands are already applied
+func (t *TemporalTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo commands that already reached the workflow
+func (t *TemporalTransaction) Rollback() error {
+	log.Printf("TemporalTransaction -> Rollback -> Temporal has no transactions, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the workflow types and namespaces with their search attributes
+func (d *TemporalDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TemporalDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewTemporalSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a workflow type or namespace
+func (d *TemporalDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TemporalDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewTemporalSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches recent executions of a workflow type or namespace
+func (d *TemporalDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TemporalDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewTemporalSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// TemporalExecutor implements the DBExecutor interface for Temporal
+type TemporalExecutor struct {
+	client *TemporalClient
+	conn   *Connection
+}
+
+// NewTemporalExecutor creates a new Temporal executor
+func NewTemporalExecutor(conn *Connection) (*TemporalExecutor, error) {
+	client, ok := conn.APIClient.(*TemporalClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid Temporal connection")
+	}
+
+	return &TemporalExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for Temporal as it doesn't use GORM
+func (e *TemporalExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *TemporalExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// run executes a command with the request timeout
+func (e *TemporalExecutor) run(query string) *QueryExecutionResult {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.TemporalRequestTimeout)
+	defer cancel()
+	return executeTemporalCommand(ctx, e.client, query)
+}
+
+// Raw executes a Temporal command, *Not Used By DBManager*
+func (e *TemporalExecutor) Raw(query string, values ...interface{}) error {
+	if result := e.run(query); result.Error != nil {
+		return fmt.Errorf("failed to execute Temporal command: %v", result.Error.Message)
+	}
+	return nil
+}
+
+// Exec executes a Temporal command, *Not Used By DBManager*
+func (e *TemporalExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes a Temporal read and stores the executions in dest
+func (e *TemporalExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes a Temporal read and stores the executions in dest
+func (e *TemporalExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	result := e.run(query)
+	if result.Error != nil {
+		return fmt.Errorf("failed to execute Temporal command: %v", result.Error.Message)
+	}
+	resultMap, ok := result.Result.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Temporal command did not return executions")
+	}
+	rows, ok := resultMap["results"].([]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Temporal command did not return executions")
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the SDK client is closed by the driver on disconnect
+func (e *TemporalExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the Temporal schema
+func (e *TemporalExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &TemporalDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for a Temporal workflow type or namespace
+func (e *TemporalExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &TemporalDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
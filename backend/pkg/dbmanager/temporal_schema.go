@@ -0,0 +1,340 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"sort"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+const (
+	// Table kinds, stored in TableSchema.Comment so the preview and the LLM can tell them apart
+	temporalWorkflowTypeComment = "workflow type"
+	// TemporalNamespaceComment marks tables that stand for a whole namespace
+	TemporalNamespaceComment = "namespace"
+	// Column kinds, stored in ColumnInfo.Comment
+	temporalSystemAttributeComment = "system search attribute"
+	temporalCustomAttributeComment = "custom search attribute"
+)
+
+// temporalSystemSearchAttributes are the system search attributes every execution has. They are used
+// when the operator API is not available, e.g. for Temporal Cloud API keys without admin access.
+var temporalSystemSearchAttributes = map[string]string{
+	"WorkflowId":        "keyword",
+	"RunId":             "keyword",
+	"WorkflowType":      "keyword",
+	"ExecutionStatus":   "keyword",
+	"StartTime":         "datetime",
+	"CloseTime":         "datetime",
+	"ExecutionTime":     "datetime",
+	"ExecutionDuration": "int",
+	"HistoryLength":     "int",
+	"TaskQueue":         "keyword",
+}
+
+// TemporalSchemaFetcher implements schema fetching for Temporal's visibility store
+type TemporalSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewTemporalSchemaFetcher creates a new Temporal schema fetcher
+func NewTemporalSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &TemporalSchemaFetcher{db: db}
+}
+
+// client returns the Temporal client of the executor
+func (f *TemporalSchemaFetcher) client(db DBExecutor) (*TemporalClient, error) {
+	executor, ok := db.(*TemporalExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid Temporal connection")
+	}
+	return executor.client, nil
+}
+
+// searchAttributes returns the search attributes of the connection's namespace by name, with their column comment
+func (f *TemporalSchemaFetcher) searchAttributes(ctx context.Context, client *TemporalClient) (map[string]ColumnInfo, error) {
+	columns := make(map[string]ColumnInfo)
+
+	resp, err := client.client.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: client.namespace,
+	})
+	if err != nil {
+		log.Printf("TemporalSchemaFetcher -> searchAttributes -> Operator API unavailable, using system search attributes: %v", err)
+		for name, valueType := range temporalSystemSearchAttributes {
+			columns[name] = ColumnInfo{Name: name, Type: valueType, IsNullable: true, Comment: temporalSystemAttributeComment}
+		}
+		return columns, nil
+	}
+
+	for name, valueType := range resp.GetSystemAttributes() {
+		columns[name] = ColumnInfo{Name: name, Type: temporalIndexedValueTypeName(valueType), IsNullable: true, Comment: temporalSystemAttributeComment}
+	}
+	for name, valueType := range resp.GetCustomAttributes() {
+		columns[name] = ColumnInfo{Name: name, Type: temporalIndexedValueTypeName(valueType), IsNullable: true, Comment: temporalCustomAttributeComment}
+	}
+	return columns, nil
+}
+
+// temporalIndexedValueTypeName returns the lowercase search attribute type, e.g. keyword, datetime or keywordlist
+func temporalIndexedValueTypeName(valueType enumspb.IndexedValueType) string {
+	name := strings.TrimPrefix(valueType.String(), "INDEXED_VALUE_TYPE_")
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// workflowTypes returns the workflow types of the most recent executions of the connection's namespace.
+// The visibility store has no API that lists workflow types, so they are collected from executions.
+func (f *TemporalSchemaFetcher) workflowTypes(ctx context.Context, client *TemporalClient) ([]string, error) {
+	rows, _, err := client.listWorkflows(ctx, client.namespace, "", constants.TemporalSchemaScanLimit, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	types := []string{}
+	for _, row := range rows {
+		name, _ := row["WorkflowType"].(string)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			types = append(types, name)
+		}
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// GetSchema lists the workflow types of the connection's namespace and the namespaces of the cluster as tables.
+// Their columns are the search attributes that visibility queries can filter on.
+func (f *TemporalSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("TemporalSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("TemporalSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := f.searchAttributes(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowTypes, err := f.workflowTypes(ctx, client)
+	if err != nil {
+		log.Printf("TemporalSchemaFetcher -> GetSchema -> Error listing workflow executions: %v", err)
+		return nil, fmt.Errorf("failed to list workflow executions: %v", err)
+	}
+
+	// Listing namespaces needs cluster-wide access, fall back to the connection's namespace without it
+	namespaces, err := client.listNamespaces(ctx)
+	if err != nil {
+		log.Printf("TemporalSchemaFetcher -> GetSchema -> Could not list namespaces, using %s only: %v", client.namespace, err)
+		namespaces = []string{client.namespace}
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, workflowType := range workflowTypes {
+		if filterTables && !selected[workflowType] {
+			continue
+		}
+		table := newTemporalTableSchema(workflowType, temporalWorkflowTypeComment, columns)
+		if count, err := client.countWorkflows(ctx, client.namespace, temporalWorkflowTypeQuery(workflowType)); err == nil {
+			table.RowCount = count
+		}
+		schema.Tables[workflowType] = table
+	}
+
+	for _, namespace := range namespaces {
+		if filterTables && !selected[namespace] {
+			continue
+		}
+		// Workflow types are what users ask about most, they keep the name when a namespace has the same one
+		if _, exists := schema.Tables[namespace]; exists {
+			log.Printf("TemporalSchemaFetcher -> GetSchema -> Namespace %s has the name of a workflow type, skipping it", namespace)
+			continue
+		}
+		// Custom search attributes are registered per namespace, other namespaces only get the system ones
+		namespaceColumns := columns
+		if namespace != client.namespace {
+			namespaceColumns = make(map[string]ColumnInfo)
+			for name, column := range columns {
+				if column.Comment == temporalSystemAttributeComment {
+					namespaceColumns[name] = column
+				}
+			}
+		}
+		table := newTemporalTableSchema(namespace, TemporalNamespaceComment, namespaceColumns)
+		if count, err := client.countWorkflows(ctx, namespace, ""); err == nil {
+			table.RowCount = count
+		}
+		schema.Tables[namespace] = table
+	}
+
+	for name, table := range schema.Tables {
+		tableData, _ := json.Marshal(table.Columns)
+		table.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[name] = table
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("TemporalSchemaFetcher -> GetSchema -> Fetched %d workflow types and namespaces", len(schema.Tables))
+	return schema, nil
+}
+
+// newTemporalTableSchema creates a workflow type or namespace table. Temporal has no keys or constraints.
+func newTemporalTableSchema(name, comment string, columns map[string]ColumnInfo) TableSchema {
+	tableColumns := make(map[string]ColumnInfo, len(columns))
+	for columnName, column := range columns {
+		tableColumns[columnName] = column
+	}
+	return TableSchema{
+		Name:        name,
+		Columns:     tableColumns,
+		Indexes:     make(map[string]IndexInfo),
+		ForeignKeys: make(map[string]ForeignKey),
+		Constraints: make(map[string]ConstraintInfo),
+		Comment:     comment,
+	}
+}
+
+// temporalWorkflowTypeQuery returns the visibility query matching the executions of a workflow type
+func temporalWorkflowTypeQuery(workflowType string) string {
+	return fmt.Sprintf("WorkflowType = '%s'", strings.ReplaceAll(workflowType, "'", "''"))
+}
+
+// TemporalPreviewCommand returns the command listing the latest executions of a workflow type or namespace table
+func TemporalPreviewCommand(tableName, tableComment string, limit int) string {
+	if tableComment == TemporalNamespaceComment {
+		return fmt.Sprintf("temporal workflow list --namespace %s --limit %d", quoteTemporalArg(tableName), limit)
+	}
+	return fmt.Sprintf("temporal workflow list --query %s --limit %d", quoteTemporalArg(temporalWorkflowTypeQuery(tableName)), limit)
+}
+
+// quoteTemporalArg double-quotes a command argument for splitTemporalCommand
+func quoteTemporalArg(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// GetTableChecksum calculates a checksum for the search attribute definitions of a workflow type or namespace
+func (f *TemporalSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TemporalSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	columns, err := f.searchAttributes(ctx, client)
+	if err != nil {
+		return "", fmt.Errorf("failed to get search attributes: %v", err)
+	}
+
+	definitions := make([]string, 0, len(columns))
+	for name, column := range columns {
+		definitions = append(definitions, fmt.Sprintf("%s:%s:%s;", name, column.Type, column.Comment))
+	}
+	sort.Strings(definitions)
+	return fmt.Sprintf("%x", md5.Sum([]byte(table+";"+strings.Join(definitions, "")))), nil
+}
+
+// FetchExampleRecords retrieves the latest executions of a workflow type, or of a namespace
+func (f *TemporalSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TemporalSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	records, _, err := client.listWorkflows(ctx, client.namespace, temporalWorkflowTypeQuery(table), limit, nil)
+	if err != nil {
+		log.Printf("TemporalSchemaFetcher -> FetchExampleRecords -> Error listing executions of workflow type %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for %s: %v", table, err)
+	}
+	if len(records) > 0 {
+		return records, nil
+	}
+
+	// No executions of such a workflow type, the table may be a namespace
+	if _, err := client.client.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Namespace: table}); err != nil {
+		return records, nil
+	}
+	records, _, err = client.listWorkflows(ctx, table, "", limit, nil)
+	if err != nil {
+		log.Printf("TemporalSchemaFetcher -> FetchExampleRecords -> Error listing executions of namespace %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for %s: %v", table, err)
+	}
+	return records, nil
+}
+
+// TemporalSimplifier implements SchemaSimplifier for Temporal search attribute types
+type TemporalSimplifier struct{}
+
+// SimplifyDataType maps search attribute types to readable type names
+func (s *TemporalSimplifier) SimplifyDataType(dbType string) string {
+	switch strings.ToLower(dbType) {
+	case "keyword", "text":
+		return "text"
+	case "int", "double":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "datetime":
+		return "timestamp"
+	case "keywordlist":
+		return "array"
+	default:
+		return dbType
+	}
+}
+
+// GetColumnConstraints tells system search attributes from custom ones
+func (s *TemporalSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	switch col.Comment {
+	case temporalSystemAttributeComment:
+		constraints = append(constraints, "SYSTEM")
+	case temporalCustomAttributeComment:
+		constraints = append(constraints, "CUSTOM")
+	}
+
+	return constraints
+}
@@ -0,0 +1,103 @@
+package dbmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"time"
+)
+
+// TransientConnection is a connection opened for a single request. It is not pooled, cached in Redis
+// or tracked for schema changes, so nothing about it outlives Close.
+type TransientConnection struct {
+	manager  *Manager
+	driver   DatabaseDriver
+	conn     *Connection
+	executor DBExecutor
+}
+
+// OpenTransientConnection connects to a database without registering the connection with a chat.
+// Connecting pings the database, so an unreachable database or bad credentials fail here.
+func (m *Manager) OpenTransientConnection(config ConnectionConfig) (*TransientConnection, error) {
+	driver, exists := m.drivers[config.Type]
+	if !exists {
+		return nil, fmt.Errorf("unsupported data source type: %s", config.Type)
+	}
+
+	conn, err := driver.Connect(config)
+	if err != nil {
+		return nil, err
+	}
+
+	executor, err := m.newExecutor(conn, "")
+	if err != nil {
+		if disconnectErr := driver.Disconnect(conn); disconnectErr != nil {
+			log.Printf("DBManager -> OpenTransientConnection -> Error closing connection: %v", disconnectErr)
+		}
+		return nil, err
+	}
+
+	log.Printf("DBManager -> OpenTransientConnection -> Opened transient %s connection", config.Type)
+	return &TransientConnection{
+		manager:  m,
+		driver:   driver,
+		conn:     conn,
+		executor: executor,
+	}, nil
+}
+
+// FormatSchema fetches the schema of every table and formats it for the LLM. The schema is not stored,
+// and example records are left out so no table data is sent to the LLM.
+func (t *TransientConnection) FormatSchema(ctx context.Context) (string, error) {
+	if t.manager.schemaManager == nil {
+		return "", fmt.Errorf("schema manager is not available")
+	}
+
+	schema, err := t.manager.schemaManager.fetchSchema(ctx, t.executor, t.conn.Config.Type, []string{"ALL"})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch schema: %v", err)
+	}
+	return t.manager.schemaManager.FormatSchemaForLLM(schema), nil
+}
+
+// ExecuteQuery validates a query and runs it on the connection, capping read results at the connection's row cap
+func (t *TransientConnection) ExecuteQuery(ctx context.Context, query, queryType string) *QueryExecutionResult {
+	if validator := GetValidatorForDatabase(t.conn.Config.Type); validator != nil {
+		if err := validator.ValidateSafety(query, queryType, make(map[string]TableSchema)); err != nil {
+			log.Printf("DBManager -> TransientConnection -> ExecuteQuery -> Query safety validation failed: %v", err)
+			return &QueryExecutionResult{
+				Error: &dtos.QueryError{
+					Code:    "SAFETY_VIOLATION",
+					Message: err.Error(),
+					Details: "Query blocked by safety validation",
+				},
+			}
+		}
+	}
+
+	truncateAt := 0
+	maxResultRows := resolveMaxResultRows(t.conn)
+	if limitedQuery, capped := applyResultRowLimit(query, t.conn.Config.Type, maxResultRows); capped {
+		query = limitedQuery
+		truncateAt = maxResultRows
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+	result := t.driver.ExecuteQuery(execCtx, t.conn, query, queryType, false)
+
+	// The capped query fetches one row past the cap, getting it back means rows were cut off
+	if truncateAt > 0 && result != nil && trimResultRows(result.Result, truncateAt) {
+		result.Truncated = true
+		result.TruncatedAt = truncateAt
+	}
+	return result
+}
+
+// Close disconnects from the database, the driver removes the connection's certificate files
+func (t *TransientConnection) Close() {
+	if err := t.driver.Disconnect(t.conn); err != nil {
+		log.Printf("DBManager -> TransientConnection -> Close -> Error disconnecting: %v", err)
+	}
+}
@@ -0,0 +1,381 @@
+package dbmanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trinodb/trino-go-client/trino"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// TrinoDriver implements the DatabaseDriver interface for Trino (and Presto-compatible) clusters
+type TrinoDriver struct{}
+
+// NewTrinoDriver creates a new Trino driver
+func NewTrinoDriver() DatabaseDriver {
+	return &TrinoDriver{}
+}
+
+// Connect establishes a connection to a Trino coordinator
+func (d *TrinoDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	if config.SSHEnabled {
+		return nil, fmt.Errorf("SSH tunnels are not supported for Trino connections")
+	}
+
+	dsn, tempFiles, err := buildTrinoDSN(config)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := sql.Open("trino", dsn)
+	if err != nil {
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+		return nil, fmt.Errorf("failed to connect to Trino: %v", err)
+	}
+
+	if err := sqlDB.Ping(); err != nil {
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to connect to database: %v", err)
+	}
+
+	// Trino has no GORM dialect. Raw SQL is all we need, and the MySQL dialector
+	// binds arguments with '?' placeholders, which is what the Trino client expects.
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to initialise Trino connection: %v", err)
+	}
+
+	// Every Trino query is an HTTP request to the coordinator, so keep the pool small
+	sqlDB.SetMaxOpenConns(10)
+	sqlDB.SetMaxIdleConns(2)
+	sqlDB.SetConnMaxLifetime(time.Hour)
+
+	log.Printf("TrinoDriver -> Connect -> Connected to Trino at %s (catalog: %s, schema: %s)",
+		config.Host, getValue(config.Catalog), getValue(config.Schema))
+
+	conn := &Connection{
+		DB:          gormDB,
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		TempFiles:   tempFiles,
+	}
+
+	return conn, nil
+}
+
+// buildTrinoDSN builds the Trino client DSN. The catalog and schema set the session defaults
+// so unqualified table names resolve to catalog.schema.table.
+func buildTrinoDSN(config ConnectionConfig) (string, []string, error) {
+	if config.Catalog == nil || *config.Catalog == "" {
+		return "", nil, fmt.Errorf("a catalog is required for Trino connections")
+	}
+
+	scheme := "http"
+	port := constants.TrinoDefaultPort
+	if config.UseSSL && (config.SSLMode == nil || *config.SSLMode != "disable") {
+		scheme = "https"
+		port = constants.TrinoDefaultSSLPort
+	}
+	if config.Port != nil && *config.Port != "" {
+		port = *config.Port
+	}
+
+	username := getValue(config.Username)
+	if username == "" {
+		username = "neobase"
+	}
+
+	serverURI := &url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(config.Host, port),
+		User:   url.User(username),
+	}
+	if config.Password != nil && *config.Password != "" {
+		// The Trino server only accepts passwords over HTTPS
+		if scheme != "https" {
+			return "", nil, fmt.Errorf("Trino password authentication requires SSL")
+		}
+		serverURI.User = url.UserPassword(username, *config.Password)
+	}
+
+	trinoConfig := &trino.Config{
+		ServerURI: serverURI.String(),
+		Source:    "neobase",
+		Catalog:   *config.Catalog,
+	}
+	if config.Schema != nil && *config.Schema != "" {
+		trinoConfig.Schema = *config.Schema
+	}
+
+	// A custom CA certificate is needed for clusters behind a private certificate authority
+	var tempFiles []string
+	if scheme == "https" && config.SSLRootCertURL != nil && *config.SSLRootCertURL != "" {
+		_, _, rootCertPath, certTempFiles, err := prepareSSLCertificates("", "", *config.SSLRootCertURL)
+		if err != nil {
+			return "", nil, err
+		}
+		tempFiles = certTempFiles
+		trinoConfig.SSLCertPath = rootCertPath
+	}
+
+	dsn, err := trinoConfig.FormatDSN()
+	if err != nil {
+		for _, file := range tempFiles {
+			os.Remove(file)
+		}
+		return "", nil, fmt.Errorf("invalid Trino connection settings: %v", err)
+	}
+	return dsn, tempFiles, nil
+}
+
+// Disconnect closes a Trino connection
+func (d *TrinoDriver) Disconnect(conn *Connection) error {
+	sqlDB, err := conn.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get SQL DB: %v", err)
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("failed to close connection: %v", err)
+	}
+
+	// Clean up temporary certificate files
+	for _, file := range conn.TempFiles {
+		os.Remove(file)
+	}
+
+	return nil
+}
+
+// Ping checks if the Trino connection is alive
+func (d *TrinoDriver) Ping(conn *Connection) error {
+	if conn == nil || conn.DB == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+
+	var result int
+	if err := conn.DB.Raw("SELECT 1").Scan(&result).Error; err != nil {
+		log.Printf("TrinoDriver -> Ping -> Query test failed: %v", err)
+		return fmt.Errorf("connection test query failed: %v", err)
+	}
+
+	return nil
+}
+
+// IsAlive checks if the Trino connection is still valid
+func (d *TrinoDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("TrinoDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a SQL query on the Trino cluster
+func (d *TrinoDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	if conn == nil || conn.DB == nil {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeTrinoStatements(ctx, conn.DB, query)
+}
+
+// executeTrinoStatements runs each statement of the query and returns the result of the last one
+func executeTrinoStatements(ctx context.Context, db *gorm.DB, query string) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	// Trino uses the same quoting rules as ClickHouse for splitting statements
+	statements := splitClickHouseStatements(query)
+
+	for _, stmt := range statements {
+		// The Trino server rejects a trailing semicolon, which the splitter already removes
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			result.Error = &dtos.QueryError{
+				Message: "Query execution cancelled",
+				Code:    "EXECUTION_CANCELLED",
+			}
+			return result
+		}
+
+		if isTrinoReadStatement(stmt) {
+			var rows []map[string]interface{}
+			if err := db.WithContext(ctx).Raw(stmt).Scan(&rows).Error; err != nil {
+				result.Error = &dtos.QueryError{
+					Message: err.Error(),
+					Code:    "EXECUTION_ERROR",
+				}
+				return result
+			}
+
+			processedRows, _ := processTrinoRecords(rows)
+			result.Result = map[string]interface{}{
+				"results": processedRows,
+			}
+		} else {
+			// INSERT, CREATE, DELETE etc. Support for writes depends on the connector.
+			execResult := db.WithContext(ctx).Exec(stmt)
+			if execResult.Error != nil {
+				result.Error = &dtos.QueryError{
+					Message: execResult.Error.Error(),
+					Code:    "EXECUTION_ERROR",
+				}
+				return result
+			}
+
+			rowsAffected := execResult.RowsAffected
+			if rowsAffected > 0 {
+				result.Result = map[string]interface{}{
+					"rowsAffected": rowsAffected,
+					"message":      fmt.Sprintf("%d row(s) affected", rowsAffected),
+				}
+			} else {
+				result.Result = map[string]interface{}{
+					"message": "Query performed successfully",
+				}
+			}
+		}
+	}
+
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+
+	resultJSON, err := json.Marshal(result.Result)
+	if err != nil {
+		return &QueryExecutionResult{
+			ExecutionTime: int(time.Since(startTime).Milliseconds()),
+			Error: &dtos.QueryError{
+				Code:    "JSON_MARSHAL_FAILED",
+				Message: err.Error(),
+				Details: "Failed to marshal query results",
+			},
+		}
+	}
+	// Store the result JSON in StreamData for streaming purposes
+	result.StreamData = resultJSON
+
+	return result
+}
+
+// isTrinoReadStatement reports whether a statement returns rows
+func isTrinoReadStatement(stmt string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(stmt))
+	for _, prefix := range []string{"SELECT", "WITH", "SHOW", "DESCRIBE", "EXPLAIN", "VALUES", "TABLE "} {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BeginTx starts a new transaction. The Trino client does not support database/sql transactions
+// and most lake connectors cannot roll back, so statements are executed in autocommit mode.
+func (d *TrinoDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	if conn == nil || conn.DB == nil {
+		log.Printf("TrinoDriver.BeginTx: Connection or DB is nil")
+		return nil
+	}
+
+	return &TrinoTransaction{
+		db:   conn.DB,
+		conn: conn,
+	}
+}
+
+// TrinoTransaction implements the Transaction interface for Trino in autocommit mode
+type TrinoTransaction struct {
+	db   *gorm.DB
+	conn *Connection
+}
+
+// ExecuteQuery executes a query. Each statement is committed as soon as it completes.
+func (t *TrinoTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	if t.db == nil {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active transaction",
+				Code:    "TRANSACTION_ERROR",
+			},
+		}, nil
+	}
+	return executeTrinoStatements(ctx, t.db, query), nil
+}
+
+// Commit is a no-op as statements are already committed
+func (t *TrinoTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo statements that already ran on Trino
+func (t *TrinoTransaction) Rollback() error {
+	log.Printf("TrinoTransaction -> Rollback -> Trino statements run in autocommit mode, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the schema of the connection's catalog and schema
+func (d *TrinoDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewTrinoSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a table
+func (d *TrinoDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewTrinoSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches example records from a table
+func (d *TrinoDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewTrinoSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
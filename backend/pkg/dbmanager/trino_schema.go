@@ -0,0 +1,238 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// TrinoSchemaFetcher implements schema fetching for Trino. Discovery is limited to the
+// catalog and schema the connection was opened with (current_catalog / current_schema).
+type TrinoSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewTrinoSchemaFetcher creates a new Trino schema fetcher
+func NewTrinoSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &TrinoSchemaFetcher{db: db}
+}
+
+// trinoColumnRow is a row of information_schema.columns
+type trinoColumnRow struct {
+	TableName     string  `gorm:"column:table_name"`
+	ColumnName    string  `gorm:"column:column_name"`
+	DataType      string  `gorm:"column:data_type"`
+	IsNullable    string  `gorm:"column:is_nullable"`
+	ColumnDefault *string `gorm:"column:column_default"`
+}
+
+// GetSchema retrieves the schema for the selected tables
+func (f *TrinoSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("TrinoSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	// Tables and views of the session catalog and schema
+	var tableList []struct {
+		TableName string `gorm:"column:table_name"`
+		TableType string `gorm:"column:table_type"`
+	}
+	tablesQuery := `
+        SELECT table_name, table_type
+        FROM information_schema.tables
+        WHERE table_catalog = current_catalog
+        AND table_schema = current_schema
+        ORDER BY table_name`
+	if err := db.Query(tablesQuery, &tableList); err != nil {
+		log.Printf("TrinoSchemaFetcher -> GetSchema -> Error fetching tables: %v", err)
+		return nil, fmt.Errorf("failed to fetch tables: %v", err)
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	for _, table := range tableList {
+		if filterTables && !selected[table.TableName] {
+			continue
+		}
+		if table.TableType == "VIEW" {
+			schema.Views[table.TableName] = ViewSchema{Name: table.TableName}
+			continue
+		}
+		schema.Tables[table.TableName] = TableSchema{
+			Name:        table.TableName,
+			Columns:     make(map[string]ColumnInfo),
+			Indexes:     make(map[string]IndexInfo),
+			ForeignKeys: make(map[string]ForeignKey),
+			Constraints: make(map[string]ConstraintInfo),
+		}
+	}
+
+	// Fetch all columns in one query, a round trip per table is slow on large lakes
+	var columns []trinoColumnRow
+	columnsQuery := `
+        SELECT table_name, column_name, data_type, is_nullable, column_default
+        FROM information_schema.columns
+        WHERE table_catalog = current_catalog
+        AND table_schema = current_schema
+        ORDER BY table_name, ordinal_position`
+	if err := db.Query(columnsQuery, &columns); err != nil {
+		log.Printf("TrinoSchemaFetcher -> GetSchema -> Error fetching columns: %v", err)
+		return nil, fmt.Errorf("failed to fetch columns: %v", err)
+	}
+
+	for _, col := range columns {
+		table, ok := schema.Tables[col.TableName]
+		if !ok {
+			continue
+		}
+		defaultValue := ""
+		if col.ColumnDefault != nil {
+			defaultValue = *col.ColumnDefault
+		}
+		table.Columns[col.ColumnName] = ColumnInfo{
+			Name:         col.ColumnName,
+			Type:         col.DataType,
+			IsNullable:   strings.EqualFold(col.IsNullable, "YES"),
+			DefaultValue: defaultValue,
+		}
+	}
+
+	for tableName, table := range schema.Tables {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("context cancelled: %v", err)
+		}
+
+		// Row counts come from connector statistics; counting rows would scan the whole table
+		table.RowCount = f.getTableRowCount(tableName)
+
+		tableData, _ := json.Marshal(table)
+		table.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[tableName] = table
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("TrinoSchemaFetcher -> GetSchema -> Fetched %d tables and %d views", len(schema.Tables), len(schema.Views))
+	return schema, nil
+}
+
+// getTableRowCount reads the row count estimate from SHOW STATS, returning 0 when the connector has none
+func (f *TrinoSchemaFetcher) getTableRowCount(table string) int64 {
+	var stats []map[string]interface{}
+	if err := f.db.QueryRows(fmt.Sprintf("SHOW STATS FOR %s", quoteTrinoIdentifier(table)), &stats); err != nil {
+		log.Printf("TrinoSchemaFetcher -> getTableRowCount -> No statistics for table %s: %v", table, err)
+		return 0
+	}
+
+	// The summary row has a NULL column_name and holds the table row count
+	for _, row := range stats {
+		if row["column_name"] != nil {
+			continue
+		}
+		switch v := row["row_count"].(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		}
+	}
+	return 0
+}
+
+// GetTableChecksum calculates a checksum for a table's column definitions
+func (f *TrinoSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	var columns []trinoColumnRow
+	query := `
+        SELECT table_name, column_name, data_type, is_nullable, column_default
+        FROM information_schema.columns
+        WHERE table_catalog = current_catalog
+        AND table_schema = current_schema
+        AND table_name = ?
+        ORDER BY ordinal_position`
+	if err := db.Query(query, &columns, table); err != nil {
+		log.Printf("TrinoSchemaFetcher -> GetTableChecksum -> Error getting columns for %s: %v", table, err)
+		return "", fmt.Errorf("failed to get table definition: %v", err)
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("no table definition found for table: %s", table)
+	}
+
+	var definition strings.Builder
+	for _, col := range columns {
+		definition.WriteString(fmt.Sprintf("%s:%s:%s;", col.ColumnName, col.DataType, col.IsNullable))
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(definition.String()))), nil
+}
+
+// FetchExampleRecords retrieves sample records from a table
+func (f *TrinoSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("TrinoSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	// No ORDER BY: sorting a lake table forces a full scan, while a bare LIMIT stops after the first split
+	query := fmt.Sprintf("SELECT * FROM %s LIMIT %d", quoteTrinoIdentifier(table), limit)
+
+	var records []map[string]interface{}
+	if err := db.QueryRows(query, &records); err != nil {
+		log.Printf("TrinoSchemaFetcher -> FetchExampleRecords -> Error fetching records from table %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for table %s: %v", table, err)
+	}
+
+	return processTrinoRecords(records)
+}
+
+// processTrinoRecords converts driver values into JSON friendly values
+func processTrinoRecords(records []map[string]interface{}) ([]map[string]interface{}, error) {
+	if len(records) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	for i, record := range records {
+		for key, value := range record {
+			switch v := value.(type) {
+			case []byte:
+				records[i][key] = string(v)
+			case time.Time:
+				records[i][key] = v.Format(time.RFC3339Nano)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// quoteTrinoIdentifier double-quotes a table name, escaping embedded quotes
+func quoteTrinoIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
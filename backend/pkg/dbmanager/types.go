@@ -49,8 +49,57 @@ type ConnectionConfig struct {
 	GoogleSheetID      *string `json:"google_sheet_id,omitempty"`
 	GoogleAuthToken    *string `json:"google_auth_token,omitempty"`
 	GoogleRefreshToken *string `json:"google_refresh_token,omitempty"`
+	// Supabase specific fields
+	SupabaseAnonKey        *string `json:"supabase_anon_key,omitempty"`
+	SupabaseServiceRoleKey *string `json:"supabase_service_role_key,omitempty"`
+	// Trino specific fields (catalog.schema.table namespace)
+	Catalog *string `json:"catalog,omitempty"`
+	Schema  *string `json:"schema,omitempty"`
+	// Oracle specific fields (service name of the Easy Connect string, falls back to Database)
+	ServiceName *string `json:"service_name,omitempty"`
+	// Airtable specific fields (REST API, no host or credentials)
+	AirtableAPIKey *string `json:"airtable_api_key,omitempty"`
+	AirtableBaseID *string `json:"airtable_base_id,omitempty"`
+	// PlanetScale specific fields (the service token is only used to list branches)
+	PlanetscaleBranch         *string `json:"planetscale_branch,omitempty"`
+	PlanetscaleOrganization   *string `json:"planetscale_organization,omitempty"`
+	PlanetscaleServiceTokenID *string `json:"planetscale_service_token_id,omitempty"`
+	PlanetscaleServiceToken   *string `json:"planetscale_service_token,omitempty"`
+	// InfluxDB specific fields (the org is only needed for InfluxDB Cloud writes)
+	InfluxOrg   *string `json:"influx_org,omitempty"`
+	InfluxToken *string `json:"influx_token,omitempty"`
+	// MongoDB read preference (primary, primaryPreferred, secondary, secondaryPreferred, nearest), writes always go to the primary
+	ReadPreference *string `json:"read_preference,omitempty"`
+	// Temporal specific fields (the address falls back to Host:Port, the namespace to "default")
+	TemporalNamespace *string `json:"temporal_namespace,omitempty"`
+	TemporalAddress   *string `json:"temporal_address,omitempty"`
+	// NATS .creds file (user JWT and NKey seed), takes precedence over Username/Password and a token in Password
+	NATSCredentialsFile *string `json:"nats_credentials_file,omitempty"`
+	// Firestore project and service account JSON, the project falls back to the project_id of the service account
+	GoogleProjectID          *string `json:"google_project_id,omitempty"`
+	FirestoreCredentialsJSON *string `json:"firestore_credentials_json,omitempty"`
+	// Cloudflare D1 account, database and API token, D1 is queried over the Cloudflare REST API
+	CloudflareAccountID    *string `json:"cloudflare_account_id,omitempty"`
+	CloudflareD1DatabaseID *string `json:"cloudflare_d1_database_id,omitempty"`
+	CloudflareAPIToken     *string `json:"cloudflare_api_token,omitempty"`
 	// ChatID for schema naming
 	ChatID string `json:"chat_id,omitempty"`
+	// MaxResultRows is the user's row cap for query results, 0 uses config.Env.MaxQueryResultRows
+	MaxResultRows int `json:"max_result_rows,omitempty"`
+	// DisableParallelWorkers runs PostgreSQL queries without parallel workers, so their cost and timing are predictable
+	DisableParallelWorkers bool `json:"disable_parallel_workers,omitempty"`
+	// HiddenTables are left out of the schema sent to the LLM, they can still be queried directly
+	HiddenTables []string `json:"hidden_tables,omitempty"`
+	// SchemaAliases are business names the LLM sees instead of the real table and column names
+	SchemaAliases *SchemaAliases `json:"schema_aliases,omitempty"`
+	// RetryPolicy retries read-only queries failing with transient connection errors, nil uses QUERY_MAX_RETRIES and QUERY_BASE_RETRY_DELAY_MS
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+}
+
+// RetryPolicy is the exponential backoff of a query failing with a transient connection error
+type RetryPolicy struct {
+	MaxRetries int           `json:"max_retries"`
+	BaseDelay  time.Duration `json:"base_delay"`
 }
 
 // Connection represents an active database connection
@@ -67,8 +116,12 @@ type Connection struct {
 	TempFiles      []string
 	OnSchemaChange func(chatID string)
 	MongoDBObj     interface{} // For MongoDB connections
+	APIClient      interface{} // For API backed connections (*AirtableClient, *InfluxDBClient, *TemporalClient, *PocketBaseClient, *NATSClient, *YugabyteDBCQLClient, *FirestoreClient, *CloudflareD1Client)
 	SSHTunnel      interface{} // For SSH tunnel connections (*SSHTunnel type)
+	PgxPool        interface{} // For pgxpool backed connections (*pgxpool.Pool), e.g. Neon
 	ConfigKey      string      // Key for connection pooling
+	// LastHealthCheck is the latest HealthCheck result, set under the manager's lock
+	LastHealthCheck *HealthCheckResult
 }
 
 // DatabaseDriver interface defines methods that all database drivers must implement
@@ -98,6 +151,8 @@ type QueryExecutionResult struct {
 	ExecutionTime int              `json:"execution_time"`
 	RowsAffected  int64            `json:"rows_affected,omitempty"`
 	StreamData    []byte           `json:"stream_data,omitempty"`
+	Truncated     bool             `json:"truncated,omitempty"`    // The result was cut off at the row cap
+	TruncatedAt   int              `json:"truncated_at,omitempty"` // The row cap the result was cut off at
 }
 
 // SSEEvent represents a Server-Sent Event
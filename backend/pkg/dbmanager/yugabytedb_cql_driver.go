@@ -0,0 +1,467 @@
+package dbmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math/big"
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// ycqlTransactionPattern matches a BEGIN TRANSACTION ... END TRANSACTION block, sent to the server as one statement
+var ycqlTransactionPattern = regexp.MustCompile(`(?is)^begin\s+transaction\b.*\bend\s+transaction\s*;?$`)
+
+// YugabyteDBCQLClient holds the gocql session of a YugabyteDB YCQL connection
+type YugabyteDBCQLClient struct {
+	session   *gocql.Session
+	keyspace  string
+	host      string
+	tempFiles []string
+}
+
+// newYugabyteDBCQLClient opens a session on the YCQL port of the cluster, in the keyspace of the connection config
+func newYugabyteDBCQLClient(config ConnectionConfig) (*YugabyteDBCQLClient, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("a YugabyteDB host is required")
+	}
+	if config.Database == "" {
+		return nil, fmt.Errorf("a YCQL keyspace is required")
+	}
+
+	port := constants.YugabyteDBCQLDefaultPort
+	if config.Port != nil && *config.Port != "" {
+		port = *config.Port
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YCQL port %q", port)
+	}
+
+	cluster := gocql.NewCluster(config.Host)
+	cluster.Port = portNumber
+	cluster.Keyspace = config.Database
+	cluster.Consistency = gocql.Quorum
+	cluster.Timeout = constants.YugabyteDBCQLRequestTimeout
+	cluster.ConnectTimeout = constants.YugabyteDBCQLConnectTimeout
+	if username := getValue(config.Username); username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{
+			Username: username,
+			Password: getValue(config.Password),
+		}
+	}
+
+	var tempFiles []string
+	if config.UseSSL {
+		certPath, keyPath, rootCertPath, certTempFiles, err := prepareSSLCertificates(
+			getValue(config.SSLCertURL), getValue(config.SSLKeyURL), getValue(config.SSLRootCertURL))
+		if err != nil {
+			return nil, err
+		}
+		tempFiles = certTempFiles
+		cluster.SslOpts = &gocql.SslOptions{
+			CertPath: certPath,
+			KeyPath:  keyPath,
+			CaPath:   rootCertPath,
+			// "require" encrypts without checking the server certificate, like it does for YSQL
+			EnableHostVerification: getValue(config.SSLMode) != "require",
+		}
+	}
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		removeTempFiles(tempFiles)
+		return nil, fmt.Errorf("failed to connect to YugabyteDB YCQL at %s:%s: %v", config.Host, port, err)
+	}
+
+	return &YugabyteDBCQLClient{
+		session:   session,
+		keyspace:  config.Database,
+		host:      config.Host,
+		tempFiles: tempFiles,
+	}, nil
+}
+
+// close closes the session and removes its certificate files
+func (c *YugabyteDBCQLClient) close() {
+	c.session.Close()
+	removeTempFiles(c.tempFiles)
+}
+
+// ping runs a lightweight query on the cluster
+func (c *YugabyteDBCQLClient) ping(ctx context.Context) error {
+	if c.session.Closed() {
+		return fmt.Errorf("YCQL session is closed")
+	}
+	var version string
+	return c.session.Query("SELECT release_version FROM system.local").WithContext(ctx).Scan(&version)
+}
+
+// query runs a statement and returns its rows, at most maxRows when maxRows is positive.
+// The second result tells whether rows were left out.
+func (c *YugabyteDBCQLClient) query(ctx context.Context, statement string, maxRows int, values ...interface{}) ([]map[string]interface{}, bool, error) {
+	iter := c.session.Query(statement, values...).WithContext(ctx).Iter()
+
+	rows := []map[string]interface{}{}
+	truncated := false
+	for {
+		row := make(map[string]interface{})
+		if !iter.MapScan(row) {
+			break
+		}
+		if maxRows > 0 && len(rows) >= maxRows {
+			truncated = true
+			break
+		}
+		for column, value := range row {
+			row[column] = normalizeYCQLValue(value)
+		}
+		rows = append(rows, row)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, false, err
+	}
+	return rows, truncated, nil
+}
+
+// normalizeYCQLValue converts values gocql returns into JSON friendly ones: uuids become strings and
+// decimals and varints their text form, collections are converted element by element
+func normalizeYCQLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case gocql.UUID:
+		return v.String()
+	case time.Time:
+		return v
+	case []byte:
+		return v
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	case fmt.Stringer:
+		// Decimals (*inf.Dec), durations and inet addresses
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = normalizeYCQLValue(rv.Index(i).Interface())
+		}
+		return items
+	case reflect.Map:
+		entries := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			entries[fmt.Sprintf("%v", normalizeYCQLValue(iter.Key().Interface()))] = normalizeYCQLValue(iter.Value().Interface())
+		}
+		return entries
+	}
+	return value
+}
+
+// executeYCQLStatement runs a CQL statement. Reads are capped at maxRows rows, writes return a message
+// unless they are conditional (IF NOT EXISTS, IF ...), which return the [applied] row.
+func executeYCQLStatement(ctx context.Context, client *YugabyteDBCQLClient, query string, maxRows int) *QueryExecutionResult {
+	startTime := time.Now()
+	result := &QueryExecutionResult{}
+
+	statement := strings.TrimSpace(query)
+	if !ycqlTransactionPattern.MatchString(statement) {
+		statement = strings.TrimSpace(strings.TrimSuffix(statement, ";"))
+	}
+	if statement == "" {
+		result.Error = &dtos.QueryError{Message: "empty CQL statement", Code: "INVALID_QUERY"}
+		return result
+	}
+
+	isRead := constants.IsReadOnlyQuery(statement, constants.DatabaseTypeYugabyteDBCQL)
+	if !isRead {
+		maxRows = 0
+	}
+
+	rows, truncated, err := client.query(ctx, statement, maxRows)
+	if err != nil {
+		log.Printf("YugabyteDBCQLDriver -> executeYCQLStatement -> Statement failed: %v", err)
+		result.Error = &dtos.QueryError{
+			Message: "Failed to execute CQL statement",
+			Code:    "EXECUTION_ERROR",
+			Details: err.Error(),
+		}
+		result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+		return result
+	}
+
+	switch {
+	case isRead:
+		result.Result = rows
+		if truncated {
+			result.Truncated = true
+			result.TruncatedAt = maxRows
+		}
+	case len(rows) > 0:
+		result.Result = rows
+	default:
+		result.Result = map[string]interface{}{"message": "Statement executed successfully"}
+	}
+	result.ExecutionTime = int(time.Since(startTime).Milliseconds())
+	return result
+}
+
+// YugabyteDBCQLDriver implements the DatabaseDriver interface for YugabyteDB's Cassandra-compatible YCQL API
+type YugabyteDBCQLDriver struct{}
+
+// NewYugabyteDBCQLDriver creates a new YugabyteDB YCQL driver
+func NewYugabyteDBCQLDriver() DatabaseDriver {
+	return &YugabyteDBCQLDriver{}
+}
+
+// Connect opens a YCQL session and returns a connection holding the client
+func (d *YugabyteDBCQLDriver) Connect(config ConnectionConfig) (*Connection, error) {
+	client, err := newYugabyteDBCQLClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.YugabyteDBCQLRequestTimeout)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		client.close()
+		return nil, fmt.Errorf("failed to connect to YugabyteDB YCQL: %v", err)
+	}
+
+	log.Printf("YugabyteDBCQLDriver -> Connect -> Connected to YugabyteDB YCQL at %s, keyspace %s", client.host, client.keyspace)
+
+	conn := &Connection{
+		DB:          nil, // YCQL is queried with gocql, not GORM
+		LastUsed:    time.Now(),
+		Status:      StatusConnected,
+		Config:      config,
+		Subscribers: make(map[string]bool),
+		SubLock:     sync.RWMutex{},
+		APIClient:   client,
+	}
+
+	return conn, nil
+}
+
+// Disconnect closes the YCQL session
+func (d *YugabyteDBCQLDriver) Disconnect(conn *Connection) error {
+	client, ok := conn.APIClient.(*YugabyteDBCQLClient)
+	if !ok {
+		return fmt.Errorf("invalid YugabyteDB YCQL connection")
+	}
+	client.close()
+	return nil
+}
+
+// Ping checks if the cluster is still reachable
+func (d *YugabyteDBCQLDriver) Ping(conn *Connection) error {
+	if conn == nil {
+		return fmt.Errorf("no active connection to ping")
+	}
+	client, ok := conn.APIClient.(*YugabyteDBCQLClient)
+	if !ok {
+		return fmt.Errorf("invalid YugabyteDB YCQL connection")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.ping(ctx); err != nil {
+		log.Printf("YugabyteDBCQLDriver -> Ping -> YCQL check failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IsAlive checks if the YCQL connection is still valid
+func (d *YugabyteDBCQLDriver) IsAlive(conn *Connection) bool {
+	if err := d.Ping(conn); err != nil {
+		log.Printf("YugabyteDBCQLDriver -> IsAlive -> Connection is not healthy: %v", err)
+		return false
+	}
+	return true
+}
+
+// ExecuteQuery executes a CQL statement
+func (d *YugabyteDBCQLDriver) ExecuteQuery(ctx context.Context, conn *Connection, query string, queryType string, findCount bool) *QueryExecutionResult {
+	client, ok := conn.APIClient.(*YugabyteDBCQLClient)
+	if !ok {
+		return &QueryExecutionResult{
+			Error: &dtos.QueryError{
+				Message: "No active connection",
+				Code:    "CONNECTION_ERROR",
+			},
+		}
+	}
+	return executeYCQLStatement(ctx, client, query, resolveMaxResultRows(conn))
+}
+
+// BeginTx returns a transaction that executes statements immediately.
+// YCQL only groups writes in BEGIN TRANSACTION blocks sent as one statement, there is no session transaction to hold open.
+func (d *YugabyteDBCQLDriver) BeginTx(ctx context.Context, conn *Connection) Transaction {
+	client, ok := conn.APIClient.(*YugabyteDBCQLClient)
+	if !ok {
+		log.Printf("YugabyteDBCQLDriver.BeginTx: Invalid YugabyteDB YCQL connection, type: %T", conn.APIClient)
+		return nil
+	}
+
+	return &YugabyteDBCQLTransaction{
+		client:  client,
+		maxRows: resolveMaxResultRows(conn),
+	}
+}
+
+// YugabyteDBCQLTransaction implements the Transaction interface for YCQL in autocommit mode
+type YugabyteDBCQLTransaction struct {
+	client  *YugabyteDBCQLClient
+	maxRows int
+}
+
+// ExecuteQuery executes a statement. Writes are applied as soon as the cluster accepts them.
+func (t *YugabyteDBCQLTransaction) ExecuteQuery(ctx context.Context, query string) (*QueryExecutionResult, error) {
+	return executeYCQLStatement(ctx, t.client, query, t.maxRows), nil
+}
+
+// Commit is a no-op as statements are already applied
+func (t *YugabyteDBCQLTransaction) Commit() error {
+	return nil
+}
+
+// Rollback cannot undo statements that already ran, rollbacks are done with the rollback query
+func (t *YugabyteDBCQLTransaction) Rollback() error {
+	log.Printf("YugabyteDBCQLTransaction -> Rollback -> YCQL statements are applied immediately, nothing to roll back")
+	return nil
+}
+
+// GetSchema retrieves the tables and columns of the keyspace
+func (d *YugabyteDBCQLDriver) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("YugabyteDBCQLDriver -> GetSchema -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewYugabyteDBCQLSchemaFetcher(db)
+	return fetcher.GetSchema(ctx, db, selectedTables)
+}
+
+// GetTableChecksum calculates a checksum for a table
+func (d *YugabyteDBCQLDriver) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("YugabyteDBCQLDriver -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", err
+	}
+
+	fetcher := NewYugabyteDBCQLSchemaFetcher(db)
+	return fetcher.GetTableChecksum(ctx, db, table)
+}
+
+// FetchExampleRecords fetches example rows from a table
+func (d *YugabyteDBCQLDriver) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("YugabyteDBCQLDriver -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, err
+	}
+
+	fetcher := NewYugabyteDBCQLSchemaFetcher(db)
+	return fetcher.FetchExampleRecords(ctx, db, table, limit)
+}
+
+// YugabyteDBCQLExecutor implements the DBExecutor interface for YugabyteDB YCQL
+type YugabyteDBCQLExecutor struct {
+	client *YugabyteDBCQLClient
+	conn   *Connection
+}
+
+// NewYugabyteDBCQLExecutor creates a new YugabyteDB YCQL executor
+func NewYugabyteDBCQLExecutor(conn *Connection) (*YugabyteDBCQLExecutor, error) {
+	client, ok := conn.APIClient.(*YugabyteDBCQLClient)
+	if !ok {
+		return nil, fmt.Errorf("invalid YugabyteDB YCQL connection")
+	}
+
+	return &YugabyteDBCQLExecutor{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// GetDB returns nil for YCQL as it doesn't use GORM
+func (e *YugabyteDBCQLExecutor) GetDB() *sql.DB {
+	return nil
+}
+
+// GetConnection returns the underlying connection
+func (e *YugabyteDBCQLExecutor) GetConnection() *Connection {
+	return e.conn
+}
+
+// Raw executes a CQL statement, *Not Used By DBManager*
+func (e *YugabyteDBCQLExecutor) Raw(query string, values ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.YugabyteDBCQLRequestTimeout)
+	defer cancel()
+	if _, _, err := e.client.query(ctx, query, 0, values...); err != nil {
+		return fmt.Errorf("failed to execute CQL statement: %v", err)
+	}
+	return nil
+}
+
+// Exec executes a CQL statement, *Not Used By DBManager*
+func (e *YugabyteDBCQLExecutor) Exec(query string, values ...interface{}) error {
+	return e.Raw(query, values...)
+}
+
+// Query executes a CQL read and stores the rows in dest
+func (e *YugabyteDBCQLExecutor) Query(query string, dest interface{}, values ...interface{}) error {
+	destMap, ok := dest.(*[]map[string]interface{})
+	if !ok {
+		return fmt.Errorf("destination must be *[]map[string]interface{}")
+	}
+	return e.QueryRows(query, destMap, values...)
+}
+
+// QueryRows executes a CQL read and stores the rows in dest
+func (e *YugabyteDBCQLExecutor) QueryRows(query string, dest *[]map[string]interface{}, values ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.YugabyteDBCQLRequestTimeout)
+	defer cancel()
+	rows, _, err := e.client.query(ctx, query, 0, values...)
+	if err != nil {
+		return fmt.Errorf("failed to execute CQL statement: %v", err)
+	}
+	*dest = rows
+	return nil
+}
+
+// Close is a no-op, the session is closed by the driver on disconnect
+func (e *YugabyteDBCQLExecutor) Close() error {
+	return nil
+}
+
+// GetSchema fetches the YCQL schema
+func (e *YugabyteDBCQLExecutor) GetSchema(ctx context.Context) (*SchemaInfo, error) {
+	driver := &YugabyteDBCQLDriver{}
+	return driver.GetSchema(ctx, e, []string{"ALL"})
+}
+
+// GetTableChecksum calculates a checksum for a YCQL table
+func (e *YugabyteDBCQLExecutor) GetTableChecksum(ctx context.Context, table string) (string, error) {
+	driver := &YugabyteDBCQLDriver{}
+	return driver.GetTableChecksum(ctx, e, table)
+}
@@ -0,0 +1,350 @@
+package dbmanager
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// YCQL primary key parts, stored as table constraints
+const (
+	ycqlPartitionKeyConstraint  = "PARTITION KEY"
+	ycqlClusteringKeyConstraint = "CLUSTERING KEY"
+)
+
+// ycqlColumn is a row of system_schema.columns
+type ycqlColumn struct {
+	Name            string
+	Kind            string // partition_key, clustering, regular or static
+	Position        int
+	Type            string
+	ClusteringOrder string
+}
+
+// YugabyteDBCQLSchemaFetcher implements schema fetching for YugabyteDB YCQL using the system_schema keyspace
+type YugabyteDBCQLSchemaFetcher struct {
+	db DBExecutor
+}
+
+// NewYugabyteDBCQLSchemaFetcher creates a new YugabyteDB YCQL schema fetcher
+func NewYugabyteDBCQLSchemaFetcher(db DBExecutor) SchemaFetcher {
+	return &YugabyteDBCQLSchemaFetcher{db: db}
+}
+
+// client returns the YCQL client of the executor
+func (f *YugabyteDBCQLSchemaFetcher) client(db DBExecutor) (*YugabyteDBCQLClient, error) {
+	executor, ok := db.(*YugabyteDBCQLExecutor)
+	if !ok || executor.client == nil {
+		return nil, fmt.Errorf("invalid YugabyteDB YCQL connection")
+	}
+	return executor.client, nil
+}
+
+// GetSchema retrieves the tables of the connection's keyspace with their columns, primary key and secondary indexes
+func (f *YugabyteDBCQLSchemaFetcher) GetSchema(ctx context.Context, db DBExecutor, selectedTables []string) (*SchemaInfo, error) {
+	log.Printf("YugabyteDBCQLSchemaFetcher -> GetSchema -> Starting schema fetch with selected tables: %v", selectedTables)
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> GetSchema -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	tableRows, _, err := client.query(ctx, "SELECT table_name, default_time_to_live FROM system_schema.tables WHERE keyspace_name = ?", 0, client.keyspace)
+	if err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> GetSchema -> Error fetching tables: %v", err)
+		return nil, fmt.Errorf("failed to fetch tables: %v", err)
+	}
+
+	columnsByTable, err := f.fetchColumns(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	indexesByTable, err := f.fetchIndexes(ctx, client)
+	if err != nil {
+		// Indexes only help the LLM choose filters, the schema is usable without them
+		log.Printf("YugabyteDBCQLSchemaFetcher -> GetSchema -> Error fetching indexes: %v", err)
+		indexesByTable = make(map[string]map[string]IndexInfo)
+	}
+
+	selected := make(map[string]bool)
+	filterTables := len(selectedTables) > 0 && !(len(selectedTables) == 1 && selectedTables[0] == "ALL")
+	for _, table := range selectedTables {
+		selected[table] = true
+	}
+
+	schema := &SchemaInfo{
+		Tables:    make(map[string]TableSchema),
+		Views:     make(map[string]ViewSchema),
+		UpdatedAt: time.Now(),
+	}
+
+	for _, row := range tableRows {
+		name := fmt.Sprintf("%v", row["table_name"])
+		if filterTables && !selected[name] {
+			continue
+		}
+
+		tableSchema := buildYCQLTableSchema(name, columnsByTable[name], indexesByTable[name])
+		if ttl := fmt.Sprintf("%v", row["default_time_to_live"]); ttl != "" && ttl != "0" && ttl != "<nil>" {
+			tableSchema.Comment = fmt.Sprintf("rows expire after %s seconds by default (default_time_to_live)", ttl)
+		}
+
+		tableData, _ := json.Marshal(tableSchema)
+		tableSchema.Checksum = fmt.Sprintf("%x", md5.Sum(tableData))
+		schema.Tables[name] = tableSchema
+	}
+
+	schemaData, _ := json.Marshal(schema.Tables)
+	schema.Checksum = fmt.Sprintf("%x", md5.Sum(schemaData))
+
+	log.Printf("YugabyteDBCQLSchemaFetcher -> GetSchema -> Fetched %d tables", len(schema.Tables))
+	return schema, nil
+}
+
+// fetchColumns reads the columns of every table of the keyspace from system_schema.columns
+func (f *YugabyteDBCQLSchemaFetcher) fetchColumns(ctx context.Context, client *YugabyteDBCQLClient) (map[string][]ycqlColumn, error) {
+	rows, _, err := client.query(ctx,
+		"SELECT table_name, column_name, kind, position, type, clustering_order FROM system_schema.columns WHERE keyspace_name = ?", 0, client.keyspace)
+	if err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> fetchColumns -> Error fetching columns: %v", err)
+		return nil, fmt.Errorf("failed to fetch columns: %v", err)
+	}
+
+	columnsByTable := make(map[string][]ycqlColumn)
+	for _, row := range rows {
+		table := fmt.Sprintf("%v", row["table_name"])
+		position := 0
+		if value, ok := row["position"].(int); ok {
+			position = value
+		}
+		columnsByTable[table] = append(columnsByTable[table], ycqlColumn{
+			Name:            fmt.Sprintf("%v", row["column_name"]),
+			Kind:            fmt.Sprintf("%v", row["kind"]),
+			Position:        position,
+			Type:            fmt.Sprintf("%v", row["type"]),
+			ClusteringOrder: fmt.Sprintf("%v", row["clustering_order"]),
+		})
+	}
+	return columnsByTable, nil
+}
+
+// fetchIndexes reads the secondary indexes of the keyspace, the indexed columns are in the "target" option
+func (f *YugabyteDBCQLSchemaFetcher) fetchIndexes(ctx context.Context, client *YugabyteDBCQLClient) (map[string]map[string]IndexInfo, error) {
+	rows, _, err := client.query(ctx, "SELECT table_name, index_name, options FROM system_schema.indexes WHERE keyspace_name = ?", 0, client.keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	indexesByTable := make(map[string]map[string]IndexInfo)
+	for _, row := range rows {
+		table := fmt.Sprintf("%v", row["table_name"])
+		name := fmt.Sprintf("%v", row["index_name"])
+		var columns []string
+		if options, ok := row["options"].(map[string]interface{}); ok {
+			if target, ok := options["target"].(string); ok {
+				for _, column := range strings.Split(target, ",") {
+					column = strings.Trim(strings.TrimSpace(column), `()"`)
+					if column != "" {
+						columns = append(columns, column)
+					}
+				}
+			}
+		}
+		if indexesByTable[table] == nil {
+			indexesByTable[table] = make(map[string]IndexInfo)
+		}
+		indexesByTable[table][name] = IndexInfo{Name: name, Columns: columns}
+	}
+	return indexesByTable, nil
+}
+
+// buildYCQLTableSchema converts the columns of a table into a TableSchema.
+// The partition key and clustering columns are kept as constraints in key order, they decide which queries are allowed.
+func buildYCQLTableSchema(name string, columns []ycqlColumn, indexes map[string]IndexInfo) TableSchema {
+	tableSchema := TableSchema{
+		Name:        name,
+		Columns:     make(map[string]ColumnInfo),
+		Indexes:     make(map[string]IndexInfo),
+		ForeignKeys: make(map[string]ForeignKey),
+		Constraints: make(map[string]ConstraintInfo),
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Position < columns[j].Position
+	})
+
+	var partitionKey, clusteringKey []string
+	for _, column := range columns {
+		comment := ""
+		switch column.Kind {
+		case "partition_key":
+			partitionKey = append(partitionKey, column.Name)
+			comment = "partition key, required in WHERE"
+		case "clustering":
+			clusteringKey = append(clusteringKey, column.Name)
+			comment = fmt.Sprintf("clustering column, %s order", strings.ToUpper(column.ClusteringOrder))
+		case "static":
+			comment = "static column, shared by every row of a partition"
+		}
+
+		tableSchema.Columns[column.Name] = ColumnInfo{
+			Name:       column.Name,
+			Type:       column.Type,
+			IsNullable: column.Kind != "partition_key" && column.Kind != "clustering",
+			Comment:    comment,
+		}
+	}
+
+	if len(partitionKey) > 0 {
+		tableSchema.Constraints["partition_key"] = ConstraintInfo{
+			Name:    "partition_key",
+			Type:    ycqlPartitionKeyConstraint,
+			Columns: partitionKey,
+		}
+		tableSchema.Indexes["primary_key"] = IndexInfo{
+			Name:     "primary_key",
+			Columns:  append(append([]string{}, partitionKey...), clusteringKey...),
+			IsUnique: true,
+		}
+	}
+	if len(clusteringKey) > 0 {
+		tableSchema.Constraints["clustering_key"] = ConstraintInfo{
+			Name:    "clustering_key",
+			Type:    ycqlClusteringKeyConstraint,
+			Columns: clusteringKey,
+		}
+	}
+	for indexName, index := range indexes {
+		tableSchema.Indexes[indexName] = index
+	}
+
+	return tableSchema
+}
+
+// GetTableChecksum calculates a checksum for a table's column definitions
+func (f *YugabyteDBCQLSchemaFetcher) GetTableChecksum(ctx context.Context, db DBExecutor, table string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> GetTableChecksum -> Context cancelled: %v", err)
+		return "", fmt.Errorf("context cancelled: %v", err)
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return "", err
+	}
+
+	rows, _, err := client.query(ctx,
+		"SELECT column_name, kind, position, type FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ?", 0, client.keyspace, table)
+	if err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> GetTableChecksum -> Error fetching columns: %v", err)
+		return "", fmt.Errorf("failed to get table definition: %v", err)
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no table definition found for table: %s", table)
+	}
+
+	definitions := make([]string, 0, len(rows))
+	for _, row := range rows {
+		definitions = append(definitions, fmt.Sprintf("%v:%v:%v:%v;", row["column_name"], row["kind"], row["position"], row["type"]))
+	}
+	sort.Strings(definitions)
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(definitions, "")))), nil
+}
+
+// FetchExampleRecords retrieves sample rows from a table
+func (f *YugabyteDBCQLSchemaFetcher) FetchExampleRecords(ctx context.Context, db DBExecutor, table string, limit int) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> FetchExampleRecords -> Context cancelled: %v", err)
+		return nil, fmt.Errorf("context cancelled: %v", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	} else if limit > 10 {
+		limit = 10
+	}
+
+	client, err := f.client(db)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM "%s" LIMIT %d`, strings.ReplaceAll(table, `"`, `""`), limit)
+	rows, _, err := client.query(ctx, query, limit)
+	if err != nil {
+		log.Printf("YugabyteDBCQLSchemaFetcher -> FetchExampleRecords -> Error fetching rows from table %s: %v", table, err)
+		return nil, fmt.Errorf("failed to fetch example records for table %s: %v", table, err)
+	}
+	return rows, nil
+}
+
+// YugabyteDBCQLSimplifier implements SchemaSimplifier for CQL types
+type YugabyteDBCQLSimplifier struct{}
+
+// SimplifyDataType maps CQL types to readable type names, collections keep their element types
+func (s *YugabyteDBCQLSimplifier) SimplifyDataType(dbType string) string {
+	normalized := strings.ToLower(strings.TrimSpace(dbType))
+	if strings.HasPrefix(normalized, "frozen<") {
+		normalized = strings.TrimSuffix(strings.TrimPrefix(normalized, "frozen<"), ">")
+	}
+
+	switch normalized {
+	case "text", "varchar", "ascii":
+		return "text"
+	case "int", "bigint", "smallint", "tinyint", "varint", "counter":
+		return "integer"
+	case "float", "double", "decimal":
+		return "decimal"
+	case "boolean":
+		return "boolean"
+	case "timestamp":
+		return "timestamp"
+	case "date":
+		return "date"
+	case "time":
+		return "time"
+	case "uuid", "timeuuid":
+		return "uuid"
+	case "blob":
+		return "binary"
+	case "jsonb":
+		return "json"
+	case "inet":
+		return "ip address"
+	default:
+		return normalized
+	}
+}
+
+// GetColumnConstraints returns the primary key part of a YCQL column
+func (s *YugabyteDBCQLSimplifier) GetColumnConstraints(col ColumnInfo, table TableSchema) []string {
+	constraints := []string{}
+
+	for _, constraint := range []string{"partition_key", "clustering_key"} {
+		info, ok := table.Constraints[constraint]
+		if !ok {
+			continue
+		}
+		for _, column := range info.Columns {
+			if column == col.Name {
+				constraints = append(constraints, info.Type)
+			}
+		}
+	}
+	if strings.HasPrefix(col.Comment, "static") {
+		constraints = append(constraints, "STATIC")
+	}
+
+	return constraints
+}
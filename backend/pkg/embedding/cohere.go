@@ -0,0 +1,235 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"neobase-ai/internal/constants"
+	"net/http"
+)
+
+const cohereBaseURL = "https://api.cohere.com/v2"
+
+// CohereProvider implements the Provider and Reranker interfaces using Cohere's REST API.
+// We call the REST API directly to avoid pulling in the Cohere SDK for two endpoints.
+type CohereProvider struct {
+	apiKey      string
+	model       string
+	rerankModel string
+	baseURL     string
+}
+
+// --- REST API request/response types ---
+
+type cohereEmbedRequest struct {
+	Model          string   `json:"model"`
+	Texts          []string `json:"texts"`
+	InputType      string   `json:"input_type"`
+	EmbeddingTypes []string `json:"embedding_types"`
+}
+
+type cohereEmbedResponse struct {
+	Embeddings struct {
+		Float [][]float32 `json:"float"`
+	} `json:"embeddings"`
+}
+
+type cohereRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+type cohereRerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+type cohereAPIError struct {
+	Message string `json:"message"`
+}
+
+// NewCohereProvider creates a new Cohere embedding provider using the REST API.
+func NewCohereProvider(apiKey string, model string) (*CohereProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("Cohere API key is required for embedding provider")
+	}
+
+	if model == "" {
+		model = DefaultCohereModel
+	}
+
+	log.Printf("Embedding -> Cohere provider initialized with model: %s (rerank model: %s)", model, DefaultCohereRerankModel)
+
+	return &CohereProvider{
+		apiKey:      apiKey,
+		model:       model,
+		rerankModel: DefaultCohereRerankModel,
+		baseURL:     cohereBaseURL,
+	}, nil
+}
+
+// Embed generates a vector embedding for a single text input (document/storage context).
+func (p *CohereProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	embeddings, err := p.embed(ctx, []string{text}, "search_document")
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// EmbedQuery generates a vector embedding optimized for search queries.
+// Uses the search_query input type so the query lands in the same space as search_document vectors.
+func (p *CohereProvider) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	embeddings, err := p.embed(ctx, []string{text}, "search_query")
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// EmbedBatch generates vector embeddings for multiple texts, batching requests to Cohere's limit.
+func (p *CohereProvider) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("texts cannot be empty")
+	}
+
+	// Cohere embed supports up to 96 texts per call
+	const maxBatchSize = 96
+	var allEmbeddings [][]float32
+
+	for i := 0; i < len(texts); i += maxBatchSize {
+		end := i + maxBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		embeddings, err := p.embed(ctx, texts[i:end], "search_document")
+		if err != nil {
+			return nil, err
+		}
+		allEmbeddings = append(allEmbeddings, embeddings...)
+	}
+
+	return allEmbeddings, nil
+}
+
+// embed calls the Cohere embed endpoint with the given input type.
+func (p *CohereProvider) embed(ctx context.Context, texts []string, inputType string) ([][]float32, error) {
+	var result cohereEmbedResponse
+	err := p.post(ctx, "/embed", cohereEmbedRequest{
+		Model:          p.model,
+		Texts:          texts,
+		InputType:      inputType,
+		EmbeddingTypes: []string{"float"},
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Cohere embedding error: %w", err)
+	}
+
+	if len(result.Embeddings.Float) != len(texts) {
+		return nil, fmt.Errorf("Cohere returned %d embeddings for %d texts", len(result.Embeddings.Float), len(texts))
+	}
+
+	return result.Embeddings.Float, nil
+}
+
+// Rerank scores documents against a query with Cohere's rerank model.
+// Results are ordered by descending relevance and limited to topN (all documents if topN <= 0).
+func (p *CohereProvider) Rerank(ctx context.Context, query string, documents []string, topN int) ([]RerankResult, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	var result cohereRerankResponse
+	err := p.post(ctx, "/rerank", cohereRerankRequest{
+		Model:     p.rerankModel,
+		Query:     query,
+		Documents: documents,
+		TopN:      topN,
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Cohere rerank error: %w", err)
+	}
+
+	results := make([]RerankResult, 0, len(result.Results))
+	for _, r := range result.Results {
+		results = append(results, RerankResult{Index: r.Index, Score: r.RelevanceScore})
+	}
+	return results, nil
+}
+
+// post sends an authenticated JSON request to the Cohere API and decodes the response into out.
+func (p *CohereProvider) post(ctx context.Context, path string, payload interface{}, out interface{}) error {
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+path, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr cohereAPIError
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// GetDimension returns the dimensionality of embeddings produced by this provider.
+// Looks up the dimension from the centralized embedding model definitions in constants.
+func (p *CohereProvider) GetDimension() int {
+	if model := constants.GetEmbeddingModel(p.model); model != nil {
+		return model.Dimension
+	}
+	return CohereDimension // fallback
+}
+
+// GetProviderName returns "cohere".
+func (p *CohereProvider) GetProviderName() string {
+	return "cohere"
+}
+
+// GetModelName returns the embedding model name.
+func (p *CohereProvider) GetModelName() string {
+	return p.model
+}
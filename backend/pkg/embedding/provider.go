@@ -34,6 +34,13 @@ func NewProvider(cfg Config) (Provider, error) {
 		}
 		return NewGeminiProvider(cfg.APIKey, model)
 
+	case "cohere":
+		model := cfg.Model
+		if model == "" {
+			model = DefaultCohereModel
+		}
+		return NewCohereProvider(cfg.APIKey, model)
+
 	default:
 		return nil, fmt.Errorf("unsupported embedding provider: %s", provider)
 	}
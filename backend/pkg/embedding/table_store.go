@@ -0,0 +1,117 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"neobase-ai/pkg/redis"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	tableEmbeddingKeyPrefix = "table_embeddings:"
+	tableEmbeddingTTL       = 30 * 24 * time.Hour // Matches the schema storage TTL
+)
+
+// TableEmbeddings holds one embedding per table for a chat.
+// Fingerprint identifies the schema descriptions the vectors were built from,
+// so callers can detect when the schema changed and the vectors need rebuilding.
+type TableEmbeddings struct {
+	Fingerprint string               `json:"fingerprint"`
+	Model       string               `json:"model"`
+	Vectors     map[string][]float32 `json:"vectors"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// ScoredTable is a table name with its similarity score against a query.
+type ScoredTable struct {
+	Name  string
+	Score float64
+}
+
+// TableEmbeddingStore persists per-chat table embeddings in Redis.
+// Unlike the Qdrant pipeline, it holds a single vector per table and searches in memory,
+// which is cheap enough for schemas with a few thousand tables.
+type TableEmbeddingStore struct {
+	redisRepo redis.IRedisRepositories
+}
+
+// NewTableEmbeddingStore creates a new Redis-backed table embedding store.
+func NewTableEmbeddingStore(redisRepo redis.IRedisRepositories) *TableEmbeddingStore {
+	return &TableEmbeddingStore{redisRepo: redisRepo}
+}
+
+// Get returns the stored table embeddings for a chat, or nil if none exist.
+func (s *TableEmbeddingStore) Get(ctx context.Context, chatID string) (*TableEmbeddings, error) {
+	data, err := s.redisRepo.GetCompressed(tableEmbeddingKeyPrefix+chatID, ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "key does not exist") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read table embeddings: %w", err)
+	}
+
+	var embeddings TableEmbeddings
+	if err := json.Unmarshal(data, &embeddings); err != nil {
+		return nil, fmt.Errorf("failed to parse table embeddings: %w", err)
+	}
+	return &embeddings, nil
+}
+
+// Store saves the table embeddings for a chat.
+func (s *TableEmbeddingStore) Store(ctx context.Context, chatID string, embeddings *TableEmbeddings) error {
+	data, err := json.Marshal(embeddings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal table embeddings: %w", err)
+	}
+
+	if err := s.redisRepo.SetCompressed(tableEmbeddingKeyPrefix+chatID, data, tableEmbeddingTTL, ctx); err != nil {
+		return fmt.Errorf("failed to store table embeddings: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the stored table embeddings for a chat.
+func (s *TableEmbeddingStore) Delete(ctx context.Context, chatID string) error {
+	return s.redisRepo.Del(tableEmbeddingKeyPrefix+chatID, ctx)
+}
+
+// RankTables scores every table against the query vector by cosine similarity,
+// returning them ordered from most to least similar.
+func RankTables(embeddings *TableEmbeddings, queryVector []float32) []ScoredTable {
+	ranked := make([]ScoredTable, 0, len(embeddings.Vectors))
+	for name, vector := range embeddings.Vectors {
+		ranked = append(ranked, ScoredTable{Name: name, Score: CosineSimilarity(queryVector, vector)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score == ranked[j].Score {
+			return ranked[i].Name < ranked[j].Name
+		}
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors, or 0 if they are
+// empty, of different lengths, or either has zero magnitude.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
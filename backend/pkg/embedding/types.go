@@ -3,7 +3,7 @@ package embedding
 import "context"
 
 // Provider defines the interface for text embedding services.
-// Implementations exist for OpenAI and Gemini.
+// Implementations exist for OpenAI, Gemini and Cohere.
 type Provider interface {
 	// Embed generates a vector embedding for a single text input (document/storage context).
 	// For indexing documents — uses RETRIEVAL_DOCUMENT task type where supported.
@@ -21,16 +21,30 @@ type Provider interface {
 	// GetDimension returns the dimensionality of embedding vectors produced by this provider.
 	GetDimension() int
 
-	// GetProviderName returns the provider identifier (e.g., "openai", "gemini").
+	// GetProviderName returns the provider identifier (e.g., "openai", "gemini", "cohere").
 	GetProviderName() string
 
 	// GetModelName returns the embedding model being used.
 	GetModelName() string
 }
 
+// Reranker defines the interface for services that re-score documents against a query.
+// Currently implemented by the Cohere provider.
+type Reranker interface {
+	// Rerank returns the documents most relevant to query, ordered by descending score.
+	// topN limits the number of results; topN <= 0 returns all documents.
+	Rerank(ctx context.Context, query string, documents []string, topN int) ([]RerankResult, error)
+}
+
+// RerankResult is a single reranked document, referenced by its index in the input slice.
+type RerankResult struct {
+	Index int
+	Score float64
+}
+
 // Config holds configuration for initializing an embedding provider.
 type Config struct {
-	Provider string // "openai" or "gemini"
+	Provider string // "openai", "gemini" or "cohere"
 	Model    string // e.g., "text-embedding-3-small" or "text-embedding-004"
 	APIKey   string
 }
@@ -41,8 +55,17 @@ const DefaultOpenAIModel = "text-embedding-3-small"
 // DefaultGeminiModel is the default embedding model for Gemini.
 const DefaultGeminiModel = "gemini-embedding-001"
 
+// DefaultCohereModel is the default embedding model for Cohere.
+const DefaultCohereModel = "embed-english-v3.0"
+
+// DefaultCohereRerankModel is the rerank model used alongside Cohere embeddings.
+const DefaultCohereRerankModel = "rerank-v3.5"
+
 // OpenAIDimension is the embedding dimension for text-embedding-3-small.
 const OpenAIDimension = 1536
 
 // GeminiDimension is the default embedding dimension for gemini-embedding-001 (supports 128-3072 via MRL, we use 768).
 const GeminiDimension = 768
+
+// CohereDimension is the embedding dimension for embed-english-v3.0.
+const CohereDimension = 1024
@@ -0,0 +1,243 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+)
+
+// CircuitState is the state of a provider's circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed lets every call through
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every call until the cooldown has passed
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets one probe call through, which closes the circuit again or reopens it
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// ErrCircuitOpen is returned without calling the provider while its circuit is open. It is a fallback error,
+// so the fallback chain moves on to the next model.
+var ErrCircuitOpen = errors.New("LLM circuit breaker is open")
+
+// CircuitBreaker stops calling a provider that keeps failing with rate limit, unavailable or network errors,
+// since retrying it right away only adds to its load. Other errors, such as an invalid request, mean the
+// provider answered and don't count as failures.
+type CircuitBreaker struct {
+	provider      string
+	state         CircuitState
+	failures      []time.Time // failures within the window, while closed
+	openedAt      time.Time
+	probing       bool // a half-open probe call is in flight
+	lastError     string
+	lastChangedAt time.Time
+	mu            sync.Mutex
+}
+
+// CircuitBreakerStats is the state of a provider's circuit breaker
+type CircuitBreakerStats struct {
+	Provider       string       `json:"provider"`
+	State          CircuitState `json:"state"`
+	RecentFailures int          `json:"recent_failures"`
+	LastError      string       `json:"last_error,omitempty"`
+	LastChangedAt  time.Time    `json:"last_changed_at"`
+	RetryAt        *time.Time   `json:"retry_at,omitempty"` // When an open circuit lets the next probe through
+}
+
+// NewCircuitBreaker creates a closed circuit breaker for a provider
+func NewCircuitBreaker(provider string) *CircuitBreaker {
+	return &CircuitBreaker{
+		provider:      provider,
+		state:         CircuitClosed,
+		lastChangedAt: time.Now(),
+	}
+}
+
+// Execute runs fn if the circuit lets the call through and records its outcome
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	probe, err := cb.allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	cb.record(probe, err)
+	return err
+}
+
+// allow checks whether a call may go to the provider, moving an open circuit to half-open once the cooldown has
+// passed. It reports whether the call is the half-open probe.
+func (cb *CircuitBreaker) allow() (bool, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		retryAt := cb.openedAt.Add(constants.LLMCircuitBreakerCooldown)
+		if time.Now().Before(retryAt) {
+			return false, fmt.Errorf("%w for %s, retrying after %s", ErrCircuitOpen, cb.provider, retryAt.Format(time.RFC3339))
+		}
+		cb.transition(CircuitHalfOpen)
+		cb.probing = true
+		return true, nil
+	case CircuitHalfOpen:
+		if cb.probing {
+			return false, fmt.Errorf("%w for %s, a probe call is in flight", ErrCircuitOpen, cb.provider)
+		}
+		cb.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record counts a failed call and opens the circuit at the threshold. A successful probe closes it, a failed one reopens it.
+func (cb *CircuitBreaker) record(probe bool, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if probe {
+		cb.probing = false
+	}
+	if errors.Is(err, context.Canceled) {
+		// The caller gave up, that says nothing about the provider. An unfinished probe is let through again.
+		return
+	}
+	failed := err != nil && IsFallbackError(err)
+	if failed {
+		cb.lastError = err.Error()
+	}
+
+	switch {
+	case probe && failed:
+		cb.open()
+	case probe:
+		cb.failures = nil
+		cb.transition(CircuitClosed)
+	case failed && cb.state == CircuitClosed:
+		now := time.Now()
+		cb.failures = append(cb.recentFailures(now), now)
+		if len(cb.failures) >= constants.LLMCircuitBreakerFailureThreshold {
+			cb.open()
+		}
+	}
+}
+
+func (cb *CircuitBreaker) open() {
+	cb.openedAt = time.Now()
+	cb.failures = nil
+	cb.transition(CircuitOpen)
+}
+
+func (cb *CircuitBreaker) transition(state CircuitState) {
+	if cb.state == state {
+		return
+	}
+	log.Printf("WARN: LLM circuit breaker -> %s: %s -> %s (last error: %s)", cb.provider, cb.state, state, cb.lastError)
+	cb.state = state
+	cb.lastChangedAt = time.Now()
+}
+
+// recentFailures drops the failures older than the window
+func (cb *CircuitBreaker) recentFailures(now time.Time) []time.Time {
+	cutoff := now.Add(-constants.LLMCircuitBreakerFailureWindow)
+	recent := cb.failures[:0]
+	for _, failedAt := range cb.failures {
+		if failedAt.After(cutoff) {
+			recent = append(recent, failedAt)
+		}
+	}
+	return recent
+}
+
+// Reset closes the circuit and forgets its failures
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = nil
+	cb.probing = false
+	cb.transition(CircuitClosed)
+}
+
+// Stats returns the breaker's state
+func (cb *CircuitBreaker) Stats() CircuitBreakerStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = cb.recentFailures(time.Now())
+	stats := CircuitBreakerStats{
+		Provider:       cb.provider,
+		State:          cb.state,
+		RecentFailures: len(cb.failures),
+		LastError:      cb.lastError,
+		LastChangedAt:  cb.lastChangedAt,
+	}
+	if cb.state == CircuitOpen {
+		retryAt := cb.openedAt.Add(constants.LLMCircuitBreakerCooldown)
+		stats.RetryAt = &retryAt
+	}
+	return stats
+}
+
+// circuitBreakerClient sends every generate call of a client through its provider's circuit breaker
+type circuitBreakerClient struct {
+	Client
+	breaker *CircuitBreaker
+}
+
+func (c *circuitBreakerClient) GenerateResponse(ctx context.Context, messages []*models.LLMMessage, dbType string, nonTechMode bool, modelID ...string) (string, error) {
+	var response string
+	err := c.breaker.Execute(func() error {
+		var err error
+		response, err = c.Client.GenerateResponse(ctx, messages, dbType, nonTechMode, modelID...)
+		return err
+	})
+	return response, err
+}
+
+func (c *circuitBreakerClient) GenerateRecommendations(ctx context.Context, messages []*models.LLMMessage, dbType string) (string, error) {
+	var response string
+	err := c.breaker.Execute(func() error {
+		var err error
+		response, err = c.Client.GenerateRecommendations(ctx, messages, dbType)
+		return err
+	})
+	return response, err
+}
+
+func (c *circuitBreakerClient) GenerateVisualization(ctx context.Context, systemPrompt string, visualizationPrompt string, dataRequest string, modelID ...string) (string, error) {
+	var response string
+	err := c.breaker.Execute(func() error {
+		var err error
+		response, err = c.Client.GenerateVisualization(ctx, systemPrompt, visualizationPrompt, dataRequest, modelID...)
+		return err
+	})
+	return response, err
+}
+
+func (c *circuitBreakerClient) GenerateRawJSON(ctx context.Context, systemPrompt string, userMessage string, modelID ...string) (string, error) {
+	var response string
+	err := c.breaker.Execute(func() error {
+		var err error
+		response, err = c.Client.GenerateRawJSON(ctx, systemPrompt, userMessage, modelID...)
+		return err
+	})
+	return response, err
+}
+
+func (c *circuitBreakerClient) GenerateWithTools(ctx context.Context, messages []*models.LLMMessage, tools []ToolDefinition, executor ToolExecutorFunc, config ToolCallConfig) (*ToolCallResult, error) {
+	var result *ToolCallResult
+	err := c.breaker.Execute(func() error {
+		var err error
+		result, err = c.Client.GenerateWithTools(ctx, messages, tools, executor, config)
+		return err
+	})
+	return result, err
+}
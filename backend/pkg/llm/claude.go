@@ -10,6 +10,7 @@ import (
 	"neobase-ai/internal/constants"
 	"neobase-ai/internal/models"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,7 @@ type ClaudeClient struct {
 	temperature         float64
 	DBConfigs           []LLMDBConfig
 	httpClient          *http.Client
+	baseURL             string // constants.ClaudeAPIBaseURL, or LLM_PROXY_URL
 }
 
 // Claude API request/response structures
@@ -87,15 +89,26 @@ func NewClaudeClient(config Config) (*ClaudeClient, error) {
 		model = "claude-3-5-sonnet-20241022" // Default to latest Sonnet
 	}
 
+	baseURL := constants.ClaudeAPIBaseURL
+	if config.ProxyURL != "" {
+		baseURL = strings.TrimSuffix(config.ProxyURL, "/")
+	}
+
+	httpClient := &http.Client{
+		Timeout: 120 * time.Second,
+	}
+	if config.Signer != nil {
+		httpClient = newSigningHTTPClient(config.Signer, 120*time.Second, nil)
+	}
+
 	return &ClaudeClient{
 		apiKey:              config.APIKey,
 		model:               model,
 		maxCompletionTokens: config.MaxCompletionTokens,
 		temperature:         config.Temperature,
 		DBConfigs:           config.DBConfigs,
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second,
-		},
+		httpClient:          httpClient,
+		baseURL:             baseURL,
 	}, nil
 }
 
@@ -113,7 +126,7 @@ func (c *ClaudeClient) GenerateResponse(ctx context.Context, messages []*models.
 	}
 
 	// Get the system prompt with non-tech mode if enabled
-	systemPrompt := constants.GetSystemPrompt(constants.Claude, dbType, nonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Claude, dbType, nonTechMode), messages)
 	responseSchemaJSON := ""
 
 	for _, dbConfig := range c.DBConfigs {
@@ -183,6 +196,14 @@ func (c *ClaudeClient) GenerateResponse(ctx context.Context, messages []*models.
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -216,7 +237,7 @@ func (c *ClaudeClient) GenerateResponse(ctx context.Context, messages []*models.
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/messages", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %v", err)
 	}
@@ -315,7 +336,7 @@ func (c *ClaudeClient) GenerateRawJSON(ctx context.Context, systemPrompt string,
 		return "", fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/messages", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %v", err)
 	}
@@ -411,6 +432,14 @@ func (c *ClaudeClient) GenerateRecommendations(ctx context.Context, messages []*
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -444,7 +473,7 @@ func (c *ClaudeClient) GenerateRecommendations(ctx context.Context, messages []*
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/messages", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %v", err)
 	}
@@ -567,7 +596,7 @@ func (c *ClaudeClient) GenerateVisualization(ctx context.Context, systemPrompt s
 	}
 
 	// Make API request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/messages", bytes.NewBuffer(reqBody))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %v", err)
 	}
@@ -686,7 +715,7 @@ func (c *ClaudeClient) GenerateWithTools(ctx context.Context, messages []*models
 	}
 
 	// Build system prompt: always include DB-specific prompt, then append tool-calling addendum
-	systemPrompt := constants.GetSystemPrompt(constants.Claude, config.DBType, config.NonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Claude, config.DBType, config.NonTechMode), messages)
 	if config.SystemPrompt != "" {
 		systemPrompt = systemPrompt + "\n\n" + config.SystemPrompt
 	}
@@ -723,6 +752,14 @@ func (c *ClaudeClient) GenerateWithTools(ctx context.Context, messages []*models
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 		if content != "" {
 			rawMessages = append(rawMessages, claudeRawMessage{
@@ -764,7 +801,7 @@ func (c *ClaudeClient) GenerateWithTools(ctx context.Context, messages []*models
 			return nil, fmt.Errorf("failed to marshal Claude request: %v", err)
 		}
 
-		req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+		req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v1/messages", bytes.NewBuffer(jsonData))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Claude request: %v", err)
 		}
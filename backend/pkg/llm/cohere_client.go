@@ -0,0 +1,582 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"strings"
+
+	cohere "github.com/cohere-ai/cohere-go/v2"
+	cohereclient "github.com/cohere-ai/cohere-go/v2/client"
+	cohereoption "github.com/cohere-ai/cohere-go/v2/option"
+)
+
+type CohereClient struct {
+	client              *cohereclient.Client
+	model               string
+	maxCompletionTokens int
+	temperature         float64
+	DBConfigs           []LLMDBConfig
+}
+
+func NewCohereClient(config Config) (*CohereClient, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("Cohere API key is required")
+	}
+
+	model := config.Model
+	if model == "" {
+		model = "command-a-03-2025" // Default to latest Command A
+	}
+
+	return &CohereClient{
+		client:              cohereclient.NewClient(cohereoption.WithToken(config.APIKey)),
+		model:               model,
+		maxCompletionTokens: config.MaxCompletionTokens,
+		temperature:         config.Temperature,
+		DBConfigs:           config.DBConfigs,
+	}, nil
+}
+
+func (c *CohereClient) GenerateResponse(ctx context.Context, messages []*models.LLMMessage, dbType string, nonTechMode bool, modelID ...string) (string, error) {
+	// Check if the context is cancelled
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	// Use provided model if specified, otherwise use the client's default model
+	model := c.model
+	if len(modelID) > 0 && modelID[0] != "" {
+		model = modelID[0]
+		log.Printf("Cohere GenerateResponse -> Using selected model: %s", model)
+	}
+
+	// Get the system prompt with non-tech mode if enabled
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Cohere, dbType, nonTechMode), messages)
+	responseSchemaJSON := ""
+
+	for _, dbConfig := range c.DBConfigs {
+		if dbConfig.DBType == dbType {
+			responseSchemaJSON = dbConfig.Schema.(string)
+			break
+		}
+	}
+
+	// Cohere has no schema-typed structured output, so the schema goes into the system prompt
+	if responseSchemaJSON == "" {
+		responseSchemaJSON = constants.CohereLLMResponseSchemaJSON
+	}
+	systemPrompt = withCohereResponseSchema(systemPrompt, responseSchemaJSON)
+
+	cohereMessages := toCohereMessages(systemPrompt, messages, nonTechMode, true)
+
+	resp, err := c.chat(ctx, model, cohereMessages, nil, true)
+	if err != nil {
+		return "", err
+	}
+
+	responseText := cohereResponseText(resp)
+	if responseText == "" {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	return responseText, nil
+}
+
+// GenerateRawJSON generates a response with a custom system prompt and no response schema.
+// Used for tasks like KB generation that need raw JSON output.
+func (c *CohereClient) GenerateRawJSON(ctx context.Context, systemPrompt string, userMessage string, modelID ...string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	model := c.model
+	if len(modelID) > 0 && modelID[0] != "" {
+		model = modelID[0]
+		log.Printf("Cohere GenerateRawJSON -> Using selected model: %s", model)
+	}
+
+	cohereMessages := cohere.ChatMessages{
+		newCohereSystemMessage(systemPrompt),
+		newCohereUserMessage(userMessage),
+	}
+
+	// JSON output without enforcing a specific schema
+	resp, err := c.chat(ctx, model, cohereMessages, nil, true)
+	if err != nil {
+		return "", err
+	}
+
+	responseText := cohereResponseText(resp)
+	log.Printf("Cohere GenerateRawJSON -> response length: %d", len(responseText))
+	if responseText == "" {
+		return "", fmt.Errorf("no text content in Cohere response")
+	}
+	return responseText, nil
+}
+
+func (c *CohereClient) GenerateRecommendations(ctx context.Context, messages []*models.LLMMessage, dbType string) (string, error) {
+	// Check if the context is cancelled
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	// Get recommendations-specific prompt and schema
+	systemPrompt := constants.GetRecommendationsPrompt(constants.Cohere)
+	responseSchemaJSON := constants.GetRecommendationsSchema(constants.Cohere).(string)
+	systemPrompt = withCohereResponseSchema(systemPrompt, responseSchemaJSON)
+
+	cohereMessages := toCohereMessages(systemPrompt, messages, false, false)
+
+	resp, err := c.chat(ctx, c.model, cohereMessages, nil, true)
+	if err != nil {
+		return "", err
+	}
+
+	responseText := cohereResponseText(resp)
+	if responseText == "" {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	return responseText, nil
+}
+
+// GenerateVisualization generates a visualization configuration for query results
+// This method uses a dedicated visualization system prompt and enforces JSON response format
+func (c *CohereClient) GenerateVisualization(ctx context.Context, systemPrompt string, visualizationPrompt string, dataRequest string, modelID ...string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	model := c.model
+	if len(modelID) > 0 && modelID[0] != "" {
+		model = modelID[0]
+		log.Printf("Cohere GenerateVisualization -> Using selected model: %s", model)
+	}
+
+	cohereMessages := cohere.ChatMessages{
+		newCohereSystemMessage(systemPrompt),
+		newCohereUserMessage(visualizationPrompt),
+		newCohereUserMessage(dataRequest),
+	}
+
+	resp, err := c.chat(ctx, model, cohereMessages, nil, true)
+	if err != nil {
+		return "", err
+	}
+
+	responseText := cohereResponseText(resp)
+	if responseText == "" {
+		return "", fmt.Errorf("no valid text response found")
+	}
+
+	log.Printf("COHERE -> GenerateVisualization -> responseText: %s", responseText)
+
+	// Validate JSON response
+	var visualizationResponse map[string]interface{}
+	if err := json.Unmarshal([]byte(responseText), &visualizationResponse); err != nil {
+		log.Printf("Error: Cohere visualization response is not valid JSON: %v", err)
+		return "", fmt.Errorf("invalid JSON response from Cohere: %v", err)
+	}
+
+	return responseText, nil
+}
+
+func (c *CohereClient) GetModelInfo() ModelInfo {
+	contextLimit := 128000 // Command R series
+	if model := constants.GetLLMModel(c.model); model != nil && model.InputTokenLimit > 0 {
+		contextLimit = model.InputTokenLimit
+	}
+
+	return ModelInfo{
+		Name:                c.model,
+		Provider:            constants.Cohere,
+		MaxCompletionTokens: c.maxCompletionTokens,
+		ContextLimit:        contextLimit,
+	}
+}
+
+func (c *CohereClient) SetModel(modelID string) error {
+	c.model = modelID
+	return nil
+}
+
+// GenerateWithTools implements iterative tool-calling using Cohere's native tool use.
+func (c *CohereClient) GenerateWithTools(ctx context.Context, messages []*models.LLMMessage, tools []ToolDefinition, executor ToolExecutorFunc, config ToolCallConfig) (*ToolCallResult, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	model := c.model
+	if config.ModelID != "" {
+		model = config.ModelID
+		log.Printf("Cohere GenerateWithTools -> Using selected model: %s", model)
+	}
+
+	maxIterations := config.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxIterations
+	}
+
+	// Build system prompt: always include DB-specific prompt, then append tool-calling addendum
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Cohere, config.DBType, config.NonTechMode), messages)
+	if config.SystemPrompt != "" {
+		systemPrompt = systemPrompt + "\n\n" + config.SystemPrompt
+	}
+
+	// Convert tool definitions to Cohere tools
+	cohereTools := make([]*cohere.ToolV2, 0, len(tools))
+	for _, tool := range tools {
+		name, description := tool.Name, tool.Description
+		cohereTools = append(cohereTools, &cohere.ToolV2{
+			Function: &cohere.ToolV2Function{
+				Name:        &name,
+				Description: &description,
+				Parameters:  tool.Parameters,
+			},
+		})
+	}
+
+	cohereMessages := toCohereMessages(systemPrompt, messages, config.NonTechMode, false)
+
+	totalCalls := 0
+	var toolHistory []ToolCall
+	emptyRetries := 0
+	const maxEmptyRetries = 2
+
+	// Iterative tool-calling loop
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if config.OnIteration != nil {
+			config.OnIteration(iteration, totalCalls)
+		}
+
+		// Tools and a JSON response format can't be combined, the final answer comes through generate_final_response
+		resp, err := c.chat(ctx, model, cohereMessages, cohereTools, false)
+		if err != nil {
+			return nil, fmt.Errorf("Cohere tool-calling API error at iteration %d: %v", iteration, err)
+		}
+
+		var toolCalls []*cohere.ToolCallV2
+		var toolPlan *string
+		if resp.Message != nil {
+			toolCalls = resp.Message.ToolCalls
+			toolPlan = resp.Message.ToolPlan
+		}
+		textContent := cohereResponseText(resp)
+
+		// No tool calls — LLM returned text
+		if len(toolCalls) == 0 {
+			log.Printf("Cohere GenerateWithTools -> Iteration %d: No tool calls (finish_reason=%s)", iteration, resp.FinishReason)
+			if textContent != "" {
+				// Try to detect and parse text that looks like a tool call attempt
+				if parsed, ok := TryParseTextToolCall(textContent); ok {
+					log.Printf("Cohere GenerateWithTools -> Extracted structured response from text tool-call")
+					return &ToolCallResult{
+						Response:    parsed,
+						Iterations:  iteration + 1,
+						TotalCalls:  totalCalls,
+						ToolHistory: toolHistory,
+					}, nil
+				}
+				// Try raw JSON
+				var testJSON map[string]interface{}
+				if json.Unmarshal([]byte(textContent), &testJSON) == nil {
+					return &ToolCallResult{
+						Response:    textContent,
+						Iterations:  iteration + 1,
+						TotalCalls:  totalCalls,
+						ToolHistory: toolHistory,
+					}, nil
+				}
+				// Plain text instead of tool call — nudge LLM to use generate_final_response
+				emptyRetries++
+				if emptyRetries > maxEmptyRetries {
+					log.Printf("Cohere GenerateWithTools -> Plain text after %d retries, wrapping as assistantMessage", maxEmptyRetries)
+					wrappedResponse, _ := json.Marshal(map[string]interface{}{
+						"assistantMessage": textContent,
+						"queries":          []interface{}{},
+						"actionButtons":    []interface{}{},
+					})
+					return &ToolCallResult{
+						Response:    string(wrappedResponse),
+						Iterations:  iteration + 1,
+						TotalCalls:  totalCalls,
+						ToolHistory: toolHistory,
+					}, nil
+				}
+				log.Printf("Cohere GenerateWithTools -> Plain text at iteration %d, nudging to use generate_final_response (%d/%d)", iteration, emptyRetries, maxEmptyRetries)
+				cohereMessages = append(cohereMessages,
+					newCohereAssistantMessage(textContent),
+					newCohereUserMessage("You returned a plain text response instead of calling the generate_final_response tool. You MUST call generate_final_response with your complete answer including any queries in the 'queries' array. Do not respond with plain text."),
+				)
+				continue
+			}
+			// Empty text + no tool calls — nudge the LLM to retry
+			emptyRetries++
+			if emptyRetries > maxEmptyRetries {
+				return nil, fmt.Errorf("empty response from Cohere after %d retries at iteration %d", maxEmptyRetries, iteration)
+			}
+			log.Printf("Cohere GenerateWithTools -> Empty text at iteration %d, retrying (%d/%d)", iteration, emptyRetries, maxEmptyRetries)
+			cohereMessages = append(cohereMessages,
+				newCohereAssistantMessage("I need to continue."),
+				newCohereUserMessage("Your previous response was empty. Please call generate_final_response with your complete answer, or call an appropriate tool if you need more information."),
+			)
+			continue
+		}
+
+		// Add assistant message with the tool calls (and the tool plan Cohere expects back) to conversation
+		cohereMessages = append(cohereMessages, &cohere.ChatMessageV2{
+			Role: "assistant",
+			Assistant: &cohere.AssistantMessage{
+				ToolCalls: toolCalls,
+				ToolPlan:  toolPlan,
+			},
+		})
+
+		// Process tool calls and add a tool message per result
+		for _, tc := range toolCalls {
+			if tc == nil || tc.Function == nil {
+				continue
+			}
+			totalCalls++
+
+			callID := cohereString(tc.Id)
+			name := cohereString(tc.Function.Name)
+			args := make(map[string]interface{})
+			if rawArgs := cohereString(tc.Function.Arguments); rawArgs != "" {
+				if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
+					log.Printf("Cohere GenerateWithTools -> Failed to parse arguments of tool %s: %v", name, err)
+				}
+			}
+
+			call := ToolCall{
+				ID:        callID,
+				Name:      name,
+				Arguments: args,
+			}
+			toolHistory = append(toolHistory, call)
+
+			if config.OnToolCall != nil {
+				config.OnToolCall(call)
+			}
+
+			// Check if this is the final response tool
+			if name == FinalResponseToolName {
+				log.Printf("Cohere GenerateWithTools -> Final response tool called at iteration %d", iteration)
+				responseJSON, err := json.Marshal(args)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal final response arguments: %v", err)
+				}
+				return &ToolCallResult{
+					Response:    string(responseJSON),
+					Iterations:  iteration + 1,
+					TotalCalls:  totalCalls,
+					ToolHistory: toolHistory,
+				}, nil
+			}
+
+			// Execute the tool
+			toolResult, err := executor(ctx, call)
+			if err != nil {
+				log.Printf("Cohere GenerateWithTools -> Tool %s execution error: %v", name, err)
+				toolResult = &ToolResult{
+					CallID:  callID,
+					Name:    name,
+					Content: fmt.Sprintf("Error executing tool: %v", err),
+					IsError: true,
+				}
+			}
+
+			if config.OnToolResult != nil {
+				config.OnToolResult(call, *toolResult)
+			}
+
+			cohereMessages = append(cohereMessages, &cohere.ChatMessageV2{
+				Role: "tool",
+				Tool: &cohere.ToolMessageV2{
+					ToolCallId: callID,
+					Content:    &cohere.ToolMessageV2Content{String: toolResult.Content},
+				},
+			})
+		}
+
+		// Reset empty-retry budget after successful tool execution so that
+		// each phase (exploration vs. final-response) gets its own retries.
+		emptyRetries = 0
+	}
+
+	// Max iterations reached
+	log.Printf("Cohere GenerateWithTools -> Max iterations (%d) reached", maxIterations)
+	wrappedResponse, _ := json.Marshal(map[string]interface{}{
+		"assistantMessage": "I explored the database but reached the maximum number of steps. Please try a more specific question.",
+		"queries":          []interface{}{},
+		"actionButtons":    []interface{}{},
+	})
+	return &ToolCallResult{
+		Response:    string(wrappedResponse),
+		Iterations:  maxIterations,
+		TotalCalls:  totalCalls,
+		ToolHistory: toolHistory,
+	}, nil
+}
+
+// chat sends a single request to Cohere's v2/chat API
+func (c *CohereClient) chat(ctx context.Context, model string, messages cohere.ChatMessages, tools []*cohere.ToolV2, jsonResponse bool) (*cohere.ChatResponse, error) {
+	// Check if the context is cancelled
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	temperature := c.temperature
+	req := &cohere.V2ChatRequest{
+		Model:       model,
+		Messages:    messages,
+		Temperature: &temperature,
+	}
+	if c.maxCompletionTokens > 0 {
+		maxTokens := c.maxCompletionTokens
+		req.MaxTokens = &maxTokens
+	}
+	if len(tools) > 0 {
+		req.Tools = tools
+	}
+	if jsonResponse {
+		req.ResponseFormat = &cohere.ResponseFormatV2{
+			Type:       "json_object",
+			JsonObject: &cohere.JsonResponseFormatV2{},
+		}
+	}
+
+	resp, err := c.client.V2.Chat(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Cohere API error: %v", err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("no response from Cohere")
+	}
+	return resp, nil
+}
+
+// withCohereResponseSchema appends the JSON schema the response must follow to a system prompt
+func withCohereResponseSchema(systemPrompt, responseSchemaJSON string) string {
+	return systemPrompt + "\n\nRESPONSE FORMAT:\nRespond ONLY with a single JSON object, without markdown code fences or any text around it. " +
+		"The JSON object MUST conform to this JSON schema:\n" + responseSchemaJSON
+}
+
+// toCohereMessages converts the chat history into Cohere messages, led by the system prompt.
+// tagModes marks messages that were sent in a different technical mode than the current request.
+func toCohereMessages(systemPrompt string, messages []*models.LLMMessage, nonTechMode bool, tagModes bool) cohere.ChatMessages {
+	cohereMessages := make(cohere.ChatMessages, 0, len(messages)+1)
+	cohereMessages = append(cohereMessages, newCohereSystemMessage(systemPrompt))
+
+	for _, msg := range messages {
+		content := ""
+
+		switch msg.Role {
+		case "user":
+			if userMsg, ok := msg.Content["user_message"].(string); ok {
+				content = userMsg
+				// Add non-tech mode context if the mode differs from current request
+				if tagModes && msg.NonTechMode != nonTechMode {
+					if msg.NonTechMode {
+						content = "[This message was sent in NON-TECHNICAL MODE] " + content
+					} else {
+						content = "[This message was sent in TECHNICAL MODE] " + content
+					}
+				}
+			}
+		case "assistant":
+			content = getAssistantContent(msg.Content)
+			// Add non-tech mode context if the mode differs from current request
+			if tagModes && content != "" && msg.NonTechMode != nonTechMode {
+				if msg.NonTechMode {
+					content = "[This response was generated in NON-TECHNICAL MODE]\n" + content
+				} else {
+					content = "[This response was generated in TECHNICAL MODE]\n" + content
+				}
+			}
+		case "system":
+			if schemaUpdate, ok := msg.Content["schema_update"].(string); ok {
+				content = fmt.Sprintf("Database schema update:\n%s", schemaUpdate)
+			}
+			// Append RAG context (relevant schema context or no-match signal) if present
+			if ragCtx, ok := msg.Content["rag_context"].(string); ok && ragCtx != "" {
+				if content != "" {
+					content += "\n\n" + ragCtx
+				} else {
+					content = ragCtx
+				}
+			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
+		}
+
+		if content == "" {
+			continue
+		}
+		if msg.Role == "assistant" {
+			cohereMessages = append(cohereMessages, newCohereAssistantMessage(content))
+		} else {
+			// Schema updates are sent as user turns, same as Claude
+			cohereMessages = append(cohereMessages, newCohereUserMessage(content))
+		}
+	}
+
+	return cohereMessages
+}
+
+func newCohereSystemMessage(content string) *cohere.ChatMessageV2 {
+	return &cohere.ChatMessageV2{
+		Role:   "system",
+		System: &cohere.SystemMessage{Content: &cohere.SystemMessageContent{String: content}},
+	}
+}
+
+func newCohereUserMessage(content string) *cohere.ChatMessageV2 {
+	return &cohere.ChatMessageV2{
+		Role: "user",
+		User: &cohere.UserMessage{Content: &cohere.UserMessageContent{String: content}},
+	}
+}
+
+func newCohereAssistantMessage(content string) *cohere.ChatMessageV2 {
+	return &cohere.ChatMessageV2{
+		Role:      "assistant",
+		Assistant: &cohere.AssistantMessage{Content: &cohere.AssistantMessageContent{String: content}},
+	}
+}
+
+// cohereResponseText joins the text content items of a chat response
+func cohereResponseText(resp *cohere.ChatResponse) string {
+	if resp == nil || resp.Message == nil {
+		return ""
+	}
+
+	var text strings.Builder
+	for _, item := range resp.Message.Content {
+		if item != nil && item.Text != nil {
+			text.WriteString(item.Text.Text)
+		}
+	}
+	return strings.TrimSpace(text.String())
+}
+
+func cohereString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
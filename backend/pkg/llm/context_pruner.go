@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"encoding/json"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+)
+
+const (
+	// ContextPruneThreshold is the share of the model's input token limit the context may use before pruning
+	ContextPruneThreshold = 0.8
+	// ContextPruneKeepPairs is the number of most recent user/assistant pairs that are never pruned
+	ContextPruneKeepPairs = 5
+	// charsPerToken is the rule-of-thumb used to estimate tokens from text length
+	charsPerToken = 4
+)
+
+// ContextPruner drops the oldest conversation turns from an LLM context so it fits in the model's input window.
+// Pruning only affects the messages sent to the LLM, stored messages are left untouched.
+type ContextPruner struct {
+	tokenLimit int
+	keepPairs  int
+}
+
+// NewContextPruner creates a pruner for the given model, returns nil when the model has no known input token limit
+func NewContextPruner(modelID string) *ContextPruner {
+	model := constants.GetLLMModel(modelID)
+	if model == nil || model.InputTokenLimit <= 0 {
+		return nil
+	}
+	return &ContextPruner{
+		tokenLimit: int(float64(model.InputTokenLimit) * ContextPruneThreshold),
+		keepPairs:  ContextPruneKeepPairs,
+	}
+}
+
+// EstimateTokens estimates the token count of the messages, assuming ~4 characters per token
+func EstimateTokens(messages []*models.LLMMessage) int {
+	total := 0
+	for _, msg := range messages {
+		total += estimateMessageTokens(msg)
+	}
+	return total
+}
+
+// EstimateTextTokens estimates the token count of a text, assuming ~4 characters per token
+func EstimateTextTokens(text string) int {
+	return len(text) / charsPerToken
+}
+
+func estimateMessageTokens(msg *models.LLMMessage) int {
+	if msg == nil {
+		return 0
+	}
+	contentBytes, err := json.Marshal(msg.Content)
+	if err != nil {
+		return 0
+	}
+	return len(contentBytes) / charsPerToken
+}
+
+// Prune removes the oldest user/assistant pairs while the context is over the token limit.
+// System messages (schema and context notes) and the last keepPairs pairs are always kept.
+func (p *ContextPruner) Prune(messages []*models.LLMMessage) []*models.LLMMessage {
+	if p == nil || len(messages) == 0 {
+		return messages
+	}
+
+	totalTokens := EstimateTokens(messages)
+	if totalTokens <= p.tokenLimit {
+		return messages
+	}
+
+	// Indexes of conversation messages, oldest first
+	conversation := make([]int, 0, len(messages))
+	for i, msg := range messages {
+		if msg != nil && msg.Role != string(constants.MessageTypeSystem) {
+			conversation = append(conversation, i)
+		}
+	}
+
+	prunable := len(conversation) - p.keepPairs*2
+	if prunable <= 0 {
+		log.Printf("ContextPruner -> Prune -> Context is ~%d tokens (limit %d) but only the last %d pairs remain, nothing to prune", totalTokens, p.tokenLimit, p.keepPairs)
+		return messages
+	}
+
+	removed := make(map[int]bool)
+	for i := 0; i < prunable && totalTokens > p.tokenLimit; {
+		// Drop a user message together with the assistant reply that follows it
+		step := 1
+		if i+1 < prunable &&
+			messages[conversation[i]].Role == string(constants.MessageTypeUser) &&
+			messages[conversation[i+1]].Role == string(constants.MessageTypeAssistant) {
+			step = 2
+		}
+		for j := i; j < i+step; j++ {
+			removed[conversation[j]] = true
+			totalTokens -= estimateMessageTokens(messages[conversation[j]])
+		}
+		i += step
+	}
+
+	pruned := make([]*models.LLMMessage, 0, len(messages)-len(removed))
+	for i, msg := range messages {
+		if !removed[i] {
+			pruned = append(pruned, msg)
+		}
+	}
+
+	log.Printf("ContextPruner -> Prune -> Removed %d oldest messages from the LLM context, ~%d tokens remaining (limit %d)", len(removed), totalTokens, p.tokenLimit)
+	return pruned
+}
@@ -0,0 +1,165 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+	"strings"
+	"time"
+)
+
+// ConversationSummarizer condenses the oldest half of an LLM context into one system message, as an alternative
+// to pruning that keeps what the old turns were about. Summarizing only affects the messages sent to the LLM,
+// stored messages are left untouched.
+type ConversationSummarizer struct {
+	client     Client
+	modelID    string // Model writing the summary
+	tokenLimit int
+}
+
+// NewConversationSummarizer creates a summarizer for contexts sent to contextModelID, with the summary written by
+// summaryModelID through client. Returns nil when the context model has no known input token limit.
+func NewConversationSummarizer(contextModelID string, client Client, summaryModelID string) *ConversationSummarizer {
+	model := constants.GetLLMModel(contextModelID)
+	if model == nil || model.InputTokenLimit <= 0 || client == nil {
+		return nil
+	}
+	return &ConversationSummarizer{
+		client:     client,
+		modelID:    summaryModelID,
+		tokenLimit: int(float64(model.InputTokenLimit) * constants.ContextSummarizeThreshold),
+	}
+}
+
+// SelectMessages returns the oldest half of the conversation messages when the context is over the threshold,
+// or nil when it fits. System messages and the last ContextPruneKeepPairs pairs are never selected.
+func (s *ConversationSummarizer) SelectMessages(messages []*models.LLMMessage) []*models.LLMMessage {
+	if s == nil || len(messages) == 0 {
+		return nil
+	}
+
+	totalTokens := EstimateTokens(messages)
+	if totalTokens <= s.tokenLimit {
+		return nil
+	}
+
+	conversation := make([]*models.LLMMessage, 0, len(messages))
+	for _, msg := range messages {
+		if msg != nil && msg.Role != string(constants.MessageTypeSystem) {
+			conversation = append(conversation, msg)
+		}
+	}
+
+	count := len(conversation) / 2
+	if keep := len(conversation) - ContextPruneKeepPairs*2; count > keep {
+		count = keep
+	}
+	// Don't split a user message from the assistant reply that follows it
+	if count > 0 && count < len(conversation) &&
+		conversation[count-1].Role == string(constants.MessageTypeUser) &&
+		conversation[count].Role == string(constants.MessageTypeAssistant) {
+		count--
+	}
+	if count < 2 {
+		log.Printf("ConversationSummarizer -> SelectMessages -> Context is ~%d tokens (limit %d) but there is too little conversation to summarize", totalTokens, s.tokenLimit)
+		return nil
+	}
+
+	log.Printf("ConversationSummarizer -> SelectMessages -> Context is ~%d tokens (limit %d), summarizing the oldest %d messages", totalTokens, s.tokenLimit, count)
+	return conversation[:count]
+}
+
+// Summarize asks the LLM for a summary of the messages and returns it as a system message with content.summary
+func (s *ConversationSummarizer) Summarize(ctx context.Context, messages []*models.LLMMessage) (*models.LLMMessage, error) {
+	var transcript strings.Builder
+	for _, msg := range messages {
+		var speaker, text string
+		switch msg.Role {
+		case string(constants.MessageTypeUser):
+			speaker = "User"
+			text, _ = msg.Content["user_message"].(string)
+		case string(constants.MessageTypeAssistant):
+			speaker = "Assistant"
+			text = getAssistantContent(msg.Content)
+		}
+		if text == "" {
+			continue
+		}
+		if runes := []rune(text); len(runes) > constants.ContextSummaryMessageMaxLength {
+			text = string(runes[:constants.ContextSummaryMessageMaxLength]) + "...(truncated)"
+		}
+		transcript.WriteString(fmt.Sprintf("%s: %s\n\n", speaker, text))
+	}
+	if transcript.Len() == 0 {
+		return nil, fmt.Errorf("nothing to summarize")
+	}
+
+	response, err := s.client.GenerateRawJSON(ctx, constants.GeminiSummarizationPrompt, constants.GetSummarizationUserMessage(transcript.String()), s.modelID)
+	if err != nil {
+		return nil, fmt.Errorf("LLM call failed: %v", err)
+	}
+
+	response = strings.TrimSpace(response)
+	if start := strings.Index(response, "{"); start >= 0 {
+		if object := extractJSONObject(response[start:]); object != "" {
+			response = object
+		}
+	}
+	var result struct {
+		Summary string `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse summary: %v", err)
+	}
+	if strings.TrimSpace(result.Summary) == "" {
+		return nil, fmt.Errorf("the LLM returned an empty summary")
+	}
+
+	now := time.Now()
+	return &models.LLMMessage{
+		Role: string(constants.MessageTypeSystem),
+		Content: map[string]interface{}{
+			"summary": strings.TrimSpace(result.Summary),
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ReplaceWithSummary returns the messages with the summarized ones left out and the summary in place of the first
+func ReplaceWithSummary(messages, summarized []*models.LLMMessage, summary *models.LLMMessage) []*models.LLMMessage {
+	if summary == nil || len(summarized) == 0 {
+		return messages
+	}
+
+	removed := make(map[*models.LLMMessage]bool, len(summarized))
+	for _, msg := range summarized {
+		removed[msg] = true
+	}
+
+	result := make([]*models.LLMMessage, 0, len(messages)-len(summarized)+1)
+	inserted := false
+	for _, msg := range messages {
+		if !removed[msg] {
+			result = append(result, msg)
+			continue
+		}
+		if !inserted {
+			result = append(result, summary)
+			inserted = true
+		}
+	}
+	return result
+}
+
+// conversationSummaryText formats the summary held by a system message for the LLM, empty when it holds none
+func conversationSummaryText(content map[string]interface{}) string {
+	summary, ok := content["summary"].(string)
+	if !ok || summary == "" {
+		return ""
+	}
+	return fmt.Sprintf("Summary of the earlier conversation (the original messages are left out):\n%s", summary)
+}
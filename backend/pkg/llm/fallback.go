@@ -0,0 +1,36 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"neobase-ai/internal/constants"
+)
+
+// IsFallbackError reports whether an LLM call failed in a way the next model of a fallback chain may not:
+// a rate limit (429), an unavailable or overloaded service (503), a network error or an open circuit breaker.
+// Provider SDK errors are wrapped with %v, so their status codes are matched in the message.
+func IsFallbackError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, marker := range constants.LLMFallbackErrorMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
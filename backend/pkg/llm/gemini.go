@@ -41,7 +41,17 @@ func NewGeminiClient(config Config) (*GeminiClient, error) {
 		return nil, fmt.Errorf("gemini API key is required")
 	}
 	// Create the Gemini SDK client using the provided API key.
-	client, err := genai.NewClient(context.Background(), option.WithAPIKey(config.APIKey))
+	clientOptions := []option.ClientOption{option.WithAPIKey(config.APIKey)}
+	if config.ProxyURL != "" {
+		clientOptions = append(clientOptions, option.WithEndpoint(config.ProxyURL))
+	}
+	if config.Signer != nil {
+		// A custom HTTP client replaces the SDK's authentication, so the API key is sent as a header
+		clientOptions = append(clientOptions, option.WithHTTPClient(newSigningHTTPClient(config.Signer, 0, map[string]string{
+			"x-goog-api-key": config.APIKey,
+		})))
+	}
+	client, err := genai.NewClient(context.Background(), clientOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %v", err)
 	}
@@ -75,7 +85,7 @@ func (c *GeminiClient) GenerateResponse(ctx context.Context, messages []*models.
 	geminiMessages := make([]*genai.Content, 0)
 
 	// Get the system prompt with non-tech mode if enabled
-	systemPrompt := constants.GetSystemPrompt(constants.Gemini, dbType, nonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Gemini, dbType, nonTechMode), messages)
 	var responseSchema *genai.Schema
 
 	for _, dbConfig := range c.DBConfigs {
@@ -136,6 +146,14 @@ func (c *GeminiClient) GenerateResponse(ctx context.Context, messages []*models.
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -348,6 +366,14 @@ func (c *GeminiClient) GenerateRecommendations(ctx context.Context, messages []*
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -553,7 +579,7 @@ func (c *GeminiClient) GenerateWithTools(ctx context.Context, messages []*models
 	}
 
 	// Build system prompt: always include DB-specific prompt, then append tool-calling addendum
-	systemPrompt := constants.GetSystemPrompt(constants.Gemini, config.DBType, config.NonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Gemini, config.DBType, config.NonTechMode), messages)
 	if config.SystemPrompt != "" {
 		systemPrompt = systemPrompt + "\n\n" + config.SystemPrompt
 	}
@@ -580,6 +606,14 @@ func (c *GeminiClient) GenerateWithTools(ctx context.Context, messages []*models
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 		if content != "" {
 			role := "user"
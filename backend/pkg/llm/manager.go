@@ -4,18 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 )
 
 type Manager struct {
-	clients map[string]Client
-	mu      sync.RWMutex
+	clients  map[string]Client
+	breakers map[string]*CircuitBreaker // key: client name, kept when a client is registered again
+	mu       sync.RWMutex
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		clients: make(map[string]Client),
+		clients:  make(map[string]Client),
+		breakers: make(map[string]*CircuitBreaker),
 	}
 }
 
@@ -35,6 +38,8 @@ func (m *Manager) RegisterClient(name string, config Config) error {
 		client, err = NewClaudeClient(config)
 	case "ollama":
 		client, err = NewOllamaClient(config)
+	case "cohere":
+		client, err = NewCohereClient(config)
 	// Add other providers here
 	default:
 		return fmt.Errorf("unsupported LLM provider: %s", config.Provider)
@@ -44,7 +49,12 @@ func (m *Manager) RegisterClient(name string, config Config) error {
 		return fmt.Errorf("failed to create LLM client: %v", err)
 	}
 
-	m.clients[name] = client
+	breaker, exists := m.breakers[name]
+	if !exists {
+		breaker = NewCircuitBreaker(name)
+		m.breakers[name] = breaker
+	}
+	m.clients[name] = &circuitBreakerClient{Client: client, breaker: breaker}
 	return nil
 }
 
@@ -66,6 +76,35 @@ func (m *Manager) RemoveClient(name string) {
 	delete(m.clients, name)
 }
 
+// GetCircuitBreakers returns the circuit breaker state of every registered provider, sorted by provider
+func (m *Manager) GetCircuitBreakers() []CircuitBreakerStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]CircuitBreakerStats, 0, len(m.breakers))
+	for _, breaker := range m.breakers {
+		stats = append(stats, breaker.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Provider < stats[j].Provider
+	})
+	return stats
+}
+
+// ResetCircuitBreaker closes the circuit of a provider, so its next call goes through
+func (m *Manager) ResetCircuitBreaker(provider string) (*CircuitBreakerStats, error) {
+	m.mu.RLock()
+	breaker, exists := m.breakers[provider]
+	m.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("LLM client not found: %s", provider)
+	}
+
+	breaker.Reset()
+	stats := breaker.Stats()
+	return &stats, nil
+}
+
 // Add helper function to properly format assistant response
 func formatAssistantResponse(response map[string]interface{}) string {
 	// Convert the response to JSON string
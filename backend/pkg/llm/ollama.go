@@ -95,7 +95,7 @@ func (c *OllamaClient) GenerateResponse(ctx context.Context, messages []*models.
 	}
 
 	// Get the system prompt with non-tech mode if enabled
-	systemPrompt := constants.GetSystemPrompt(constants.Ollama, dbType, nonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Ollama, dbType, nonTechMode), messages)
 	responseSchemaJSON := ""
 
 	for _, dbConfig := range c.DBConfigs {
@@ -158,6 +158,14 @@ func (c *OllamaClient) GenerateResponse(ctx context.Context, messages []*models.
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -352,6 +360,14 @@ func (c *OllamaClient) GenerateRecommendations(ctx context.Context, messages []*
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -620,7 +636,7 @@ func (c *OllamaClient) GenerateWithTools(ctx context.Context, messages []*models
 	}
 
 	// Build system prompt: always include DB-specific prompt, then append tool-calling addendum
-	systemPrompt := constants.GetSystemPrompt(constants.Ollama, config.DBType, config.NonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.Ollama, config.DBType, config.NonTechMode), messages)
 	if config.SystemPrompt != "" {
 		systemPrompt = systemPrompt + "\n\n" + config.SystemPrompt
 	}
@@ -665,6 +681,14 @@ func (c *OllamaClient) GenerateWithTools(ctx context.Context, messages []*models
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 		if content != "" {
 			ollamaMessages = append(ollamaMessages, ollamaToolMessage{
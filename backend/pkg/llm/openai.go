@@ -24,7 +24,14 @@ func NewOpenAIClient(config Config) (*OpenAIClient, error) {
 		return nil, fmt.Errorf("OpenAI API key is required")
 	}
 
-	client := openai.NewClient(config.APIKey)
+	clientConfig := openai.DefaultConfig(config.APIKey)
+	if config.ProxyURL != "" {
+		clientConfig.BaseURL = config.ProxyURL
+	}
+	if config.Signer != nil {
+		clientConfig.HTTPClient = newSigningHTTPClient(config.Signer, 0, nil)
+	}
+	client := openai.NewClientWithConfig(clientConfig)
 	model := config.Model
 	if model == "" {
 		model = openai.GPT4o
@@ -57,7 +64,7 @@ func (c *OpenAIClient) GenerateResponse(ctx context.Context, messages []*models.
 
 	// Get the system prompt with non-tech mode if enabled
 	log.Printf("OpenAI GenerateResponse -> nonTechMode parameter: %v", nonTechMode)
-	systemPrompt := constants.GetSystemPrompt(constants.OpenAI, dbType, nonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.OpenAI, dbType, nonTechMode), messages)
 	responseSchema := ""
 
 	for _, dbConfig := range c.DBConfigs {
@@ -116,6 +123,14 @@ func (c *OpenAIClient) GenerateResponse(ctx context.Context, messages []*models.
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -254,6 +269,14 @@ func (c *OpenAIClient) GenerateRecommendations(ctx context.Context, messages []*
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 
 		if content != "" {
@@ -407,7 +430,7 @@ func (c *OpenAIClient) GenerateWithTools(ctx context.Context, messages []*models
 	}
 
 	// Build system prompt: always include DB-specific prompt, then append tool-calling addendum
-	systemPrompt := constants.GetSystemPrompt(constants.OpenAI, config.DBType, config.NonTechMode)
+	systemPrompt := withSystemPromptAppend(constants.GetSystemPrompt(constants.OpenAI, config.DBType, config.NonTechMode), messages)
 	if config.SystemPrompt != "" {
 		systemPrompt = systemPrompt + "\n\n" + config.SystemPrompt
 	}
@@ -453,6 +476,14 @@ func (c *OpenAIClient) GenerateWithTools(ctx context.Context, messages []*models
 					content = ragCtx
 				}
 			}
+			// Append the summary of conversation turns condensed out of the context if present
+			if summary := conversationSummaryText(msg.Content); summary != "" {
+				if content != "" {
+					content += "\n\n" + summary
+				} else {
+					content = summary
+				}
+			}
 		}
 		if content != "" {
 			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
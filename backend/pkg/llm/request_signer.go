@@ -0,0 +1,110 @@
+package llm
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"neobase-ai/internal/constants"
+)
+
+// LLMRequestSigner signs outgoing LLM API requests, for enterprise proxies that authenticate and audit LLM traffic
+type LLMRequestSigner interface {
+	Sign(req *http.Request) error
+}
+
+// HMACRequestSigner signs requests with an HMAC of the timestamp followed by the request body,
+// sent in the X-NeoBase-Timestamp and X-NeoBase-Signature headers
+type HMACRequestSigner struct {
+	key     []byte
+	newHash func() hash.Hash
+}
+
+func NewHMACRequestSigner(key, algorithm string) (*HMACRequestSigner, error) {
+	if key == "" {
+		return nil, fmt.Errorf("signing key is required")
+	}
+
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "", constants.LLMProxySigningAlgorithmHMACSHA256:
+		newHash = sha256.New
+	case constants.LLMProxySigningAlgorithmHMACSHA512:
+		newHash = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+
+	return &HMACRequestSigner{
+		key:     []byte(key),
+		newHash: newHash,
+	}, nil
+}
+
+func (s *HMACRequestSigner) Sign(req *http.Request) error {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read request body for signing: %v", err)
+		}
+		// The body was consumed, hand the transport a fresh reader
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(s.newHash, s.key)
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+
+	req.Header.Set(constants.LLMProxyTimestampHeader, timestamp)
+	req.Header.Set(constants.LLMProxySignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+// signingTransport signs every request before handing it to the underlying transport.
+// Headers are set on every request too, e.g. the API key of SDKs that drop it with a custom HTTP client.
+type signingTransport struct {
+	base    http.RoundTripper
+	signer  LLMRequestSigner
+	headers map[string]string
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request
+	signedReq := req.Clone(req.Context())
+	for key, value := range t.headers {
+		signedReq.Header.Set(key, value)
+	}
+	if t.signer != nil {
+		if err := t.signer.Sign(signedReq); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(signedReq)
+}
+
+// newSigningHTTPClient returns an HTTP client that signs its requests with signer
+func newSigningHTTPClient(signer LLMRequestSigner, timeout time.Duration, headers map[string]string) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &signingTransport{
+			base:    http.DefaultTransport,
+			signer:  signer,
+			headers: headers,
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package llm
+
+import (
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/models"
+)
+
+// SystemPromptAppendKey is the key of a system message's content holding the chat's own prompt rules
+const SystemPromptAppendKey = "system_prompt_append"
+
+// withSystemPromptAppend appends the rules carried by the conversation's system message to the system prompt
+func withSystemPromptAppend(systemPrompt string, messages []*models.LLMMessage) string {
+	for _, msg := range messages {
+		if msg.Role != "system" {
+			continue
+		}
+		if rules, ok := msg.Content[SystemPromptAppendKey].(string); ok && rules != "" {
+			return constants.AppendSystemPromptRules(systemPrompt, rules)
+		}
+	}
+	return systemPrompt
+}
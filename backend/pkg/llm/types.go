@@ -46,6 +46,8 @@ type Config struct {
 	MaxCompletionTokens int
 	Temperature         float64
 	DBConfigs           []LLMDBConfig
+	ProxyURL            string           // Replaces the provider's base URL, e.g. an enterprise proxy (OpenAI, Gemini and Claude)
+	Signer              LLMRequestSigner // Signs every request for the proxy, nil sends requests unsigned
 }
 
 type LLMDBConfig struct {
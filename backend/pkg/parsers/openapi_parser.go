@@ -0,0 +1,238 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPISpec is what an OpenAPI document (or Swagger 2.0, converted to OpenAPI 3) tells about the data model:
+// the schema definitions of its components
+type OpenAPISpec struct {
+	Title   string
+	Version string // The OpenAPI or Swagger version of the document, e.g. "3.0.3"
+	Schemas []OpenAPISchema
+}
+
+// OpenAPISchema is an object schema of components.schemas (definitions in Swagger 2.0)
+type OpenAPISchema struct {
+	Name        string
+	Description string
+	Properties  []OpenAPIProperty
+}
+
+// OpenAPIProperty is a property of an object schema, with the allOf parts of the schema merged in
+type OpenAPIProperty struct {
+	Name        string
+	Type        string // e.g. "string" or "array", types of OpenAPI 3.1 unions are joined with |
+	Format      string // e.g. "date-time"
+	Description string
+	Example     string   // The example value, strings as they are and other values as JSON
+	Enum        []string // The allowed values, in the order of the document
+	// Ref is the name of the component schema the property, or the items of an array property, refers to
+	Ref      string
+	Required bool
+}
+
+// ParseOpenAPISpec parses an OpenAPI 3 or Swagger 2.0 document in JSON or YAML. References to other files
+// are not followed, a spec split across files should be bundled first.
+func ParseOpenAPISpec(content []byte) (*OpenAPISpec, error) {
+	var header struct {
+		Swagger string `yaml:"swagger"`
+		OpenAPI string `yaml:"openapi"`
+	}
+	// YAML is a superset of JSON, so both formats are read the same way
+	if err := yaml.Unmarshal(content, &header); err != nil {
+		return nil, fmt.Errorf("the file is neither JSON nor YAML: %v", err)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = false
+
+	var doc *openapi3.T
+	version := header.OpenAPI
+	switch {
+	case strings.HasPrefix(header.OpenAPI, "3."):
+		var err error
+		doc, err = loader.LoadFromData(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI document: %v", err)
+		}
+	case strings.HasPrefix(header.Swagger, "2."):
+		version = header.Swagger
+		v2, err := parseSwagger2(content)
+		if err != nil {
+			return nil, err
+		}
+		doc, err = openapi2conv.ToV3(v2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the Swagger 2.0 document: %v", err)
+		}
+		if err := loader.ResolveRefsIn(doc, nil); err != nil {
+			return nil, fmt.Errorf("invalid Swagger 2.0 document: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("the file is not an OpenAPI 3 or Swagger 2.0 document, it has no openapi or swagger version")
+	}
+
+	spec := &OpenAPISpec{Version: version}
+	if doc.Info != nil {
+		spec.Title = doc.Info.Title
+	}
+	if doc.Components == nil {
+		return spec, nil
+	}
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ref := doc.Components.Schemas[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		schema := OpenAPISchema{
+			Name:        name,
+			Description: strings.TrimSpace(ref.Value.Description),
+			Properties:  openAPIProperties(ref.Value),
+		}
+		// Enums and other scalar schemas describe values, not tables
+		if len(schema.Properties) == 0 {
+			continue
+		}
+		spec.Schemas = append(spec.Schemas, schema)
+	}
+	return spec, nil
+}
+
+// parseSwagger2 reads a Swagger 2.0 document, YAML is converted to JSON first as openapi2 only reads JSON
+func parseSwagger2(content []byte) (*openapi2.T, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid Swagger 2.0 document: %v", err)
+	}
+	data, err := json.Marshal(stringKeys(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Swagger 2.0 document: %v", err)
+	}
+
+	var doc openapi2.T
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid Swagger 2.0 document: %v", err)
+	}
+	return &doc, nil
+}
+
+// stringKeys converts the maps YAML decodes with non-string keys, such as unquoted response codes, for JSON
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = stringKeys(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = stringKeys(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stringKeys(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// openAPIProperties returns the properties of an object schema and of its allOf parts, sorted by name
+func openAPIProperties(schema *openapi3.Schema) []OpenAPIProperty {
+	required := make(map[string]bool)
+	properties := make(map[string]OpenAPIProperty)
+	collectOpenAPIProperties(schema, required, properties, 0)
+
+	result := make([]OpenAPIProperty, 0, len(properties))
+	for name, property := range properties {
+		property.Required = required[name]
+		result = append(result, property)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// collectOpenAPIProperties adds the properties of schema, the first definition of a property wins.
+// depth stops allOf chains that refer back to themselves.
+func collectOpenAPIProperties(schema *openapi3.Schema, required map[string]bool, properties map[string]OpenAPIProperty, depth int) {
+	if schema == nil || depth > 5 {
+		return
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for name, ref := range schema.Properties {
+		if _, ok := properties[name]; ok || ref == nil || ref.Value == nil {
+			continue
+		}
+		properties[name] = openAPIProperty(name, ref)
+	}
+	for _, part := range schema.AllOf {
+		if part != nil {
+			collectOpenAPIProperties(part.Value, required, properties, depth+1)
+		}
+	}
+}
+
+func openAPIProperty(name string, ref *openapi3.SchemaRef) OpenAPIProperty {
+	value := ref.Value
+	property := OpenAPIProperty{
+		Name:        name,
+		Type:        strings.Join(value.Type.Slice(), "|"),
+		Format:      value.Format,
+		Description: strings.TrimSpace(value.Description),
+		Example:     openAPIValueString(value.Example),
+		Ref:         componentSchemaName(ref.Ref),
+	}
+	if property.Ref == "" && value.Items != nil {
+		property.Ref = componentSchemaName(value.Items.Ref)
+	}
+	for _, item := range value.Enum {
+		property.Enum = append(property.Enum, openAPIValueString(item))
+	}
+	return property
+}
+
+// componentSchemaName returns the schema name of a local reference such as #/components/schemas/Order
+func componentSchemaName(ref string) string {
+	for _, prefix := range []string{"#/components/schemas/", "#/definitions/"} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+	return ""
+}
+
+func openAPIValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
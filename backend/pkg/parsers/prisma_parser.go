@@ -0,0 +1,417 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PrismaSchema is what a schema.prisma file tells about the database: its models, enums and how relations are kept
+type PrismaSchema struct {
+	Provider string // The datasource provider, e.g. "postgresql"
+	// RelationMode is "prisma" when relations are emulated by the client and have no foreign keys in the database
+	RelationMode string
+	Models       []PrismaModel
+	Enums        []PrismaEnum
+}
+
+// PrismaModel is a model or view block
+type PrismaModel struct {
+	Name          string
+	DBName        string // From @@map, empty when the table is named after the model
+	Documentation string // From the /// comments above the block
+	IsView        bool
+	Fields        []PrismaField
+}
+
+// PrismaField is a field of a model. Relation fields have a model as their type and no column of their own.
+type PrismaField struct {
+	Name          string
+	DBName        string // From @map, empty when the column is named after the field
+	Type          string
+	IsList        bool
+	IsOptional    bool
+	NativeType    string // From @db.*, e.g. "VarChar(255)"
+	IsID          bool
+	IsUnique      bool
+	Default       string
+	Documentation string
+	Relation      *PrismaRelation // Set when the field has an @relation attribute
+}
+
+// PrismaRelation is an @relation attribute. Only the side holding the foreign key lists fields and references.
+type PrismaRelation struct {
+	Name       string
+	Fields     []string
+	References []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+// PrismaEnum is an enum block
+type PrismaEnum struct {
+	Name          string
+	DBName        string
+	Documentation string
+	Values        []string
+}
+
+var (
+	prismaBlockStartRegex = regexp.MustCompile(`^(model|view|enum|type|datasource|generator)\s+(\w+)\s*\{$`)
+	prismaFieldTypeRegex  = regexp.MustCompile(`^(\w+(?:\([^)]*\))?)(\[\])?(\?)?$`)
+	prismaIdentifierRegex = regexp.MustCompile(`^\w+$`)
+)
+
+// ParsePrismaSchema parses the models, views and enums of a Prisma schema, with their documentation,
+// @map/@@map names, @id, @unique, @default, @db.* and @relation attributes. Composite types and
+// generators are skipped, the datasource is only read for its provider and relation mode.
+func ParsePrismaSchema(content string) (*PrismaSchema, error) {
+	schema := &PrismaSchema{}
+
+	var (
+		blockKind string
+		model     *PrismaModel
+		enum      *PrismaEnum
+		docLines  []string
+		blockLine int
+	)
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, rawLine := range lines {
+		lineNumber := i + 1
+		line := strings.TrimSpace(rawLine)
+
+		if strings.HasPrefix(line, "///") {
+			docLines = append(docLines, strings.TrimSpace(strings.TrimPrefix(line, "///")))
+			continue
+		}
+		line = strings.TrimSpace(stripPrismaComment(line))
+		if line == "" {
+			continue
+		}
+		documentation := strings.Join(docLines, " ")
+		docLines = nil
+
+		if blockKind == "" {
+			match := prismaBlockStartRegex.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: expected a model, view, enum, type, datasource or generator block, got %q", lineNumber, line)
+			}
+			blockKind, blockLine = match[1], lineNumber
+			switch blockKind {
+			case "model", "view":
+				model = &PrismaModel{Name: match[2], Documentation: documentation, IsView: blockKind == "view"}
+			case "enum":
+				enum = &PrismaEnum{Name: match[2], Documentation: documentation}
+			}
+			continue
+		}
+
+		if line == "}" {
+			switch blockKind {
+			case "model", "view":
+				schema.Models = append(schema.Models, *model)
+				model = nil
+			case "enum":
+				schema.Enums = append(schema.Enums, *enum)
+				enum = nil
+			}
+			blockKind = ""
+			continue
+		}
+
+		var err error
+		switch blockKind {
+		case "model", "view":
+			err = parsePrismaModelLine(model, line, documentation)
+		case "enum":
+			parsePrismaEnumLine(enum, line)
+		case "datasource":
+			parsePrismaDatasourceLine(schema, line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+	}
+
+	if blockKind != "" {
+		return nil, fmt.Errorf("line %d: %s block is not closed", blockLine, blockKind)
+	}
+	return schema, nil
+}
+
+// Model returns the model with the given name, or nil
+func (s *PrismaSchema) Model(name string) *PrismaModel {
+	for i := range s.Models {
+		if s.Models[i].Name == name {
+			return &s.Models[i]
+		}
+	}
+	return nil
+}
+
+// IsRelationField tells whether a field points to another model rather than holding a column
+func (s *PrismaSchema) IsRelationField(field *PrismaField) bool {
+	return field.Relation != nil || s.Model(field.Type) != nil
+}
+
+// TableName is the name of the model's table in the database
+func (m *PrismaModel) TableName() string {
+	if m.DBName != "" {
+		return m.DBName
+	}
+	return m.Name
+}
+
+// Field returns the field with the given name, or nil
+func (m *PrismaModel) Field(name string) *PrismaField {
+	for i := range m.Fields {
+		if m.Fields[i].Name == name {
+			return &m.Fields[i]
+		}
+	}
+	return nil
+}
+
+// ColumnName is the name of the field's column in the database
+func (f *PrismaField) ColumnName() string {
+	if f.DBName != "" {
+		return f.DBName
+	}
+	return f.Name
+}
+
+func parsePrismaModelLine(model *PrismaModel, line, documentation string) error {
+	if strings.HasPrefix(line, "@@") {
+		name, args, _ := splitPrismaAttribute(strings.TrimPrefix(line, "@@"))
+		if name == "map" {
+			if value := firstPrismaArgument(args); value != "" {
+				model.DBName = value
+			}
+		}
+		// @@id, @@unique and @@index don't change what the LLM is told about relations
+		return nil
+	}
+
+	parts := strings.Fields(line)
+	if len(parts) < 2 || !prismaIdentifierRegex.MatchString(parts[0]) {
+		return fmt.Errorf("invalid field %q", line)
+	}
+
+	// The type ends at the first attribute, attributes start with @
+	rest := strings.TrimSpace(strings.TrimPrefix(line, parts[0]))
+	typeEnd := len(rest)
+	if at := strings.Index(rest, "@"); at >= 0 {
+		typeEnd = at
+	}
+	fieldType := strings.Join(strings.Fields(rest[:typeEnd]), "")
+	match := prismaFieldTypeRegex.FindStringSubmatch(fieldType)
+	if match == nil {
+		return fmt.Errorf("invalid type %q of field %s", fieldType, parts[0])
+	}
+
+	field := PrismaField{
+		Name:          parts[0],
+		Type:          match[1],
+		IsList:        match[2] != "",
+		IsOptional:    match[3] != "",
+		Documentation: documentation,
+	}
+
+	for _, attribute := range splitPrismaAttributes(rest[typeEnd:]) {
+		name, args, _ := splitPrismaAttribute(attribute)
+		switch {
+		case name == "id":
+			field.IsID = true
+		case name == "unique":
+			field.IsUnique = true
+		case name == "map":
+			field.DBName = firstPrismaArgument(args)
+		case name == "default":
+			field.Default = strings.TrimSpace(args)
+		case strings.HasPrefix(name, "db."):
+			field.NativeType = strings.TrimPrefix(attribute, "db.")
+		case name == "relation":
+			field.Relation = parsePrismaRelation(args)
+		}
+	}
+
+	model.Fields = append(model.Fields, field)
+	return nil
+}
+
+func parsePrismaEnumLine(enum *PrismaEnum, line string) {
+	if strings.HasPrefix(line, "@@") {
+		if name, args, _ := splitPrismaAttribute(strings.TrimPrefix(line, "@@")); name == "map" {
+			enum.DBName = firstPrismaArgument(args)
+		}
+		return
+	}
+	if value := strings.Fields(line)[0]; prismaIdentifierRegex.MatchString(value) {
+		enum.Values = append(enum.Values, value)
+	}
+}
+
+func parsePrismaDatasourceLine(schema *PrismaSchema, line string) {
+	key, value, found := strings.Cut(line, "=")
+	if !found {
+		return
+	}
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	switch strings.TrimSpace(key) {
+	case "provider":
+		schema.Provider = value
+	case "relationMode", "referentialIntegrity":
+		schema.RelationMode = value
+	}
+}
+
+// parsePrismaRelation reads the arguments of @relation, where the name may be given by position or as name:
+func parsePrismaRelation(args string) *PrismaRelation {
+	relation := &PrismaRelation{}
+	for i, arg := range splitPrismaArguments(args) {
+		key, value, named := cutPrismaNamedArgument(arg)
+		if !named {
+			if i == 0 {
+				relation.Name = unquotePrisma(arg)
+			}
+			continue
+		}
+		switch key {
+		case "name":
+			relation.Name = unquotePrisma(value)
+		case "fields":
+			relation.Fields = parsePrismaList(value)
+		case "references":
+			relation.References = parsePrismaList(value)
+		case "onDelete":
+			relation.OnDelete = value
+		case "onUpdate":
+			relation.OnUpdate = value
+		}
+	}
+	return relation
+}
+
+// splitPrismaAttributes splits the attributes after a field type, e.g. `@id @default(uuid()) @db.Uuid`,
+// keeping parentheses and strings together
+func splitPrismaAttributes(text string) []string {
+	var attributes []string
+	var current strings.Builder
+	depth, inString := 0, false
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inString = !inString
+		case inString:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == '@' && depth == 0:
+			if attribute := strings.TrimSpace(current.String()); attribute != "" {
+				attributes = append(attributes, attribute)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if attribute := strings.TrimSpace(current.String()); attribute != "" {
+		attributes = append(attributes, attribute)
+	}
+	return attributes
+}
+
+// splitPrismaAttribute splits `relation(fields: [a])` into its name and the text between the parentheses
+func splitPrismaAttribute(attribute string) (name, args string, hasArgs bool) {
+	open := strings.Index(attribute, "(")
+	if open < 0 {
+		return strings.TrimSpace(attribute), "", false
+	}
+	closeIndex := strings.LastIndex(attribute, ")")
+	if closeIndex < open {
+		closeIndex = len(attribute)
+	}
+	return strings.TrimSpace(attribute[:open]), attribute[open+1 : closeIndex], true
+}
+
+// splitPrismaArguments splits on the commas that are not inside brackets, parentheses or strings
+func splitPrismaArguments(args string) []string {
+	var parts []string
+	var current strings.Builder
+	depth, inString := 0, false
+
+	for _, r := range args {
+		switch {
+		case r == '"':
+			inString = !inString
+		case inString:
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if part := strings.TrimSpace(current.String()); part != "" {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// cutPrismaNamedArgument splits `fields: [a]` into its key and value, positional arguments are not named
+func cutPrismaNamedArgument(arg string) (key, value string, named bool) {
+	key, value, found := strings.Cut(arg, ":")
+	if !found || !prismaIdentifierRegex.MatchString(strings.TrimSpace(key)) {
+		return "", arg, false
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), true
+}
+
+// firstPrismaArgument returns the unquoted value of the first argument, accepting both "x" and name: "x"
+func firstPrismaArgument(args string) string {
+	parts := splitPrismaArguments(args)
+	if len(parts) == 0 {
+		return ""
+	}
+	if _, value, named := cutPrismaNamedArgument(parts[0]); named {
+		return unquotePrisma(value)
+	}
+	return unquotePrisma(parts[0])
+}
+
+// parsePrismaList reads a field list such as [authorId, tenantId], dropping sort and length arguments
+func parsePrismaList(value string) []string {
+	value = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "["), "]")
+	var items []string
+	for _, item := range splitPrismaArguments(value) {
+		if name, _, _ := splitPrismaAttribute(item); name != "" {
+			items = append(items, name)
+		}
+	}
+	return items
+}
+
+func unquotePrisma(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `"`)
+}
+
+// stripPrismaComment drops a // comment from a line, unless the slashes are inside a string such as a URL
+func stripPrismaComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '"':
+			inString = !inString
+		case !inString && line[i] == '/' && i+1 < len(line) && line[i+1] == '/':
+			return line[:i]
+		}
+	}
+	return line
+}
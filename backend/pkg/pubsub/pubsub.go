@@ -0,0 +1,19 @@
+package pubsub
+
+import "context"
+
+// PubSub broadcasts messages to every subscriber of a channel, across all backend instances.
+type PubSub interface {
+	// Publish sends a payload to all current subscribers of the channel.
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe starts listening on a channel. The subscription ends when ctx is cancelled or Close is called.
+	Subscribe(ctx context.Context, channel string) (Subscription, error)
+}
+
+// Subscription is an active subscription to a single channel.
+type Subscription interface {
+	// Messages returns the channel on which published payloads are delivered. It is closed when the subscription ends.
+	Messages() <-chan []byte
+	// Close ends the subscription.
+	Close() error
+}
@@ -0,0 +1,83 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// RedisPubSub implements PubSub on top of Redis PUBLISH/SUBSCRIBE.
+// Messages are fire-and-forget: subscribers that are not connected when a message is published miss it.
+type RedisPubSub struct {
+	client *redis.Client
+}
+
+// NewRedisPubSub creates a new Redis-backed PubSub using an existing client.
+func NewRedisPubSub(client *redis.Client) *RedisPubSub {
+	return &RedisPubSub{client: client}
+}
+
+// Publish sends a payload to all subscribers of the channel.
+func (p *RedisPubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	if err := p.client.Publish(ctx, channel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Subscribe subscribes to a channel and forwards its payloads until ctx is cancelled or Close is called.
+func (p *RedisPubSub) Subscribe(ctx context.Context, channel string) (Subscription, error) {
+	redisSub := p.client.Subscribe(ctx, channel)
+
+	// Wait for the subscription confirmation so publishes right after Subscribe returns are not missed
+	if _, err := redisSub.Receive(ctx); err != nil {
+		redisSub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	sub := &redisSubscription{
+		sub:      redisSub,
+		messages: make(chan []byte, 100),
+	}
+	go sub.forward(ctx)
+	return sub, nil
+}
+
+type redisSubscription struct {
+	sub      *redis.PubSub
+	messages chan []byte
+}
+
+func (s *redisSubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *redisSubscription) Close() error {
+	return s.sub.Close()
+}
+
+// forward copies payloads from the Redis subscription until it is closed or ctx is done.
+func (s *redisSubscription) forward(ctx context.Context) {
+	defer close(s.messages)
+	defer s.sub.Close()
+
+	redisMessages := s.sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-redisMessages:
+			if !ok {
+				return
+			}
+			select {
+			case s.messages <- []byte(msg.Payload):
+			default:
+				// Slow consumer: drop the message rather than blocking the Redis connection
+				log.Printf("PubSub -> Dropping message on channel %s: subscriber buffer full", msg.Channel)
+			}
+		}
+	}
+}
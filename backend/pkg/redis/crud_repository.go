@@ -22,6 +22,7 @@ type IRedisRepositories interface {
 	Get(key string, ctx context.Context) (string, error)
 	Del(key string, ctx context.Context) error
 	GetAllByField(ctx context.Context, modelType interface{}, filterFunc func(interface{}) bool) ([]interface{}, error)
+	ScanKeys(pattern string, ctx context.Context) ([]string, error)
 	TTL(key string, ctx context.Context) (time.Duration, error)
 	Expire(key string, expiredTime time.Duration, ctx context.Context) error
 	StartPipeline(ctx context.Context) *Pipeline
@@ -135,6 +136,27 @@ func (r *RedisRepositories) GetAllByField(ctx context.Context, modelType interfa
 	return results, nil
 }
 
+// ScanKeys returns all keys matching the pattern, using SCAN so Redis is not blocked
+func (r *RedisRepositories) ScanKeys(pattern string, ctx context.Context) ([]string, error) {
+	var keys []string
+	var cursor uint64
+
+	for {
+		batch, nextCursor, err := r.Client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+
+		if nextCursor == 0 {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	return keys, nil
+}
+
 func (r *RedisRepositories) TTL(key string, ctx context.Context) (time.Duration, error) {
 	duration, err := r.Client.TTL(ctx, key).Result()
 	if err != nil {
@@ -0,0 +1,293 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfFont         = "Helvetica"
+	pdfCodeFont     = "Courier"
+	pdfBodySize     = 10.5
+	pdfLineHeight   = 5.5
+	pdfMargin       = 18.0
+	pdfListIndent   = 6.0
+	pdfTableRowSize = 6.5
+)
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletPattern     = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	numberedPattern   = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	tableSepPattern   = regexp.MustCompile(`^\|?\s*:?-{2,}:?\s*(\|\s*:?-{2,}:?\s*)*\|?$`)
+	rulePattern       = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	inlineCodePattern = regexp.MustCompile("`([^`]*)`")
+	linkPattern       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	emphasisPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__|\*([^*\s][^*]*)\*`)
+)
+
+// RenderMarkdownPDF renders a Markdown report as an A4 PDF. It handles what the report writer produces:
+// headings, paragraphs, bullet and numbered lists, bold text, tables, code blocks and horizontal rules.
+// Text is set in the standard PDF fonts, so characters outside Windows-1252 can't be shown.
+func RenderMarkdownPDF(title, markdown string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("NeoBase", true)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(pdfFont, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	r := &markdownRenderer{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	r.render(strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n"))
+
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type markdownRenderer struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+func (r *markdownRenderer) render(lines []string) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			r.paragraph(strings.Join(paragraph, " "))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, strings.TrimRight(lines[i], " \t"))
+			}
+			r.codeBlock(code)
+
+		case headingPattern.MatchString(trimmed):
+			flush()
+			match := headingPattern.FindStringSubmatch(trimmed)
+			r.heading(len(match[1]), match[2])
+
+		case rulePattern.MatchString(trimmed):
+			flush()
+			r.rule()
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && tableSepPattern.MatchString(strings.TrimSpace(lines[i+1])):
+			flush()
+			rows := [][]string{splitTableRow(trimmed)}
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, splitTableRow(strings.TrimSpace(lines[i])))
+			}
+			i--
+			r.table(rows)
+
+		case bulletPattern.MatchString(line):
+			flush()
+			match := bulletPattern.FindStringSubmatch(line)
+			r.listItem(len(match[1])/2, "•", match[2])
+
+		case numberedPattern.MatchString(line):
+			flush()
+			match := numberedPattern.FindStringSubmatch(line)
+			r.listItem(len(match[1])/2, match[2]+".", match[3])
+
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+}
+
+func (r *markdownRenderer) heading(level int, text string) {
+	sizes := map[int]float64{1: 20, 2: 15, 3: 12.5}
+	size, ok := sizes[level]
+	if !ok {
+		size = 11
+	}
+
+	if r.pdf.GetY() > pdfMargin+1 {
+		r.pdf.Ln(size * 0.35)
+	}
+	r.pdf.SetFont(pdfFont, "B", size)
+	r.pdf.SetTextColor(33, 33, 33)
+	r.pdf.MultiCell(0, size*0.5, r.tr(plainText(text)), "", "L", false)
+	if level <= 2 {
+		left, _, right, _ := r.pdf.GetMargins()
+		width, _ := r.pdf.GetPageSize()
+		y := r.pdf.GetY() + 1
+		r.pdf.SetDrawColor(210, 210, 210)
+		r.pdf.Line(left, y, width-right, y)
+		r.pdf.Ln(3)
+	} else {
+		r.pdf.Ln(1.5)
+	}
+}
+
+func (r *markdownRenderer) paragraph(text string) {
+	r.inline(text)
+	r.pdf.Ln(pdfLineHeight + 2)
+}
+
+func (r *markdownRenderer) listItem(depth int, marker, text string) {
+	left, top, right, _ := r.pdf.GetMargins()
+	indent := left + float64(depth+1)*pdfListIndent
+
+	r.pdf.SetFont(pdfFont, "", pdfBodySize)
+	r.pdf.SetTextColor(33, 33, 33)
+	r.pdf.SetX(indent - pdfListIndent + 1)
+	r.pdf.Write(pdfLineHeight, r.tr(marker))
+
+	// Wrapped lines start at the item's indent
+	r.pdf.SetMargins(indent, top, right)
+	r.pdf.SetX(indent)
+	r.inline(text)
+	r.pdf.SetMargins(left, top, right)
+	r.pdf.Ln(pdfLineHeight + 1)
+}
+
+// inline writes text with its **bold** and *italic* spans, links and inline code are shown as plain text
+func (r *markdownRenderer) inline(text string) {
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = inlineCodePattern.ReplaceAllString(text, "$1")
+
+	r.pdf.SetTextColor(33, 33, 33)
+	write := func(style, part string) {
+		if part != "" {
+			r.pdf.SetFont(pdfFont, style, pdfBodySize)
+			r.pdf.Write(pdfLineHeight, r.tr(part))
+		}
+	}
+	last := 0
+	for _, match := range emphasisPattern.FindAllStringSubmatchIndex(text, -1) {
+		write("", text[last:match[0]])
+		switch {
+		case match[2] >= 0:
+			write("B", text[match[2]:match[3]])
+		case match[4] >= 0:
+			write("B", text[match[4]:match[5]])
+		default:
+			write("I", text[match[6]:match[7]])
+		}
+		last = match[1]
+	}
+	write("", text[last:])
+}
+
+func (r *markdownRenderer) codeBlock(lines []string) {
+	r.pdf.SetFont(pdfCodeFont, "", 8.5)
+	r.pdf.SetTextColor(50, 50, 50)
+	r.pdf.SetFillColor(245, 245, 245)
+	r.pdf.MultiCell(0, 4.5, r.tr(strings.Join(lines, "\n")), "", "L", true)
+	r.pdf.Ln(3)
+}
+
+func (r *markdownRenderer) rule() {
+	left, _, right, _ := r.pdf.GetMargins()
+	width, _ := r.pdf.GetPageSize()
+	y := r.pdf.GetY() + 2
+	r.pdf.SetDrawColor(200, 200, 200)
+	r.pdf.Line(left, y, width-right, y)
+	r.pdf.Ln(5)
+}
+
+// table draws the rows with equal column widths, the first row as the header. Cells too wide for their
+// column are cut off with an ellipsis.
+func (r *markdownRenderer) table(rows [][]string) {
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return
+	}
+
+	left, _, right, _ := r.pdf.GetMargins()
+	pageWidth, _ := r.pdf.GetPageSize()
+	columnWidth := (pageWidth - left - right) / float64(columns)
+	fontSize := pdfBodySize - 1.5
+	if columns > 6 {
+		fontSize = 7
+	}
+
+	r.pdf.SetDrawColor(200, 200, 200)
+	r.pdf.SetTextColor(33, 33, 33)
+	for rowIndex, row := range rows {
+		header := rowIndex == 0
+		style := ""
+		if header {
+			style = "B"
+			r.pdf.SetFillColor(235, 238, 242)
+		} else {
+			r.pdf.SetFillColor(250, 250, 250)
+		}
+		r.pdf.SetFont(pdfFont, style, fontSize)
+
+		for column := 0; column < columns; column++ {
+			cell := ""
+			if column < len(row) {
+				cell = r.fitText(r.tr(plainText(row[column])), columnWidth-2)
+			}
+			r.pdf.CellFormat(columnWidth, pdfTableRowSize, cell, "1", 0, "L", header || rowIndex%2 == 0, 0, "")
+		}
+		r.pdf.Ln(-1)
+	}
+	r.pdf.Ln(4)
+}
+
+// fitText shortens text with an ellipsis until it fits width. The text is already translated to the font's
+// single byte encoding, so it is cut by bytes.
+func (r *markdownRenderer) fitText(text string, width float64) string {
+	if r.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	ellipsis := "..."
+	for len(text) > 0 && r.pdf.GetStringWidth(text+ellipsis) > width {
+		text = text[:len(text)-1]
+	}
+	return text + ellipsis
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// plainText drops the inline Markdown of headings and table cells
+func plainText(text string) string {
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = inlineCodePattern.ReplaceAllString(text, "$1")
+	return emphasisPattern.ReplaceAllString(text, "$1$2$3")
+}
@@ -0,0 +1,164 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"neobase-ai/internal/constants"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// Config holds the address of the Vault server and the credentials NeoBase authenticates with.
+// Token auth is used when Token is set, otherwise AppRole auth with RoleID and SecretID.
+// AllowedPathPrefix limits the secrets users may reference, {user_id} in it is replaced with the requesting user.
+type Config struct {
+	Address           string
+	Token             string
+	RoleID            string
+	SecretID          string
+	AllowedPathPrefix string
+}
+
+// VaultSecretResolver reads database credentials from HashiCorp Vault.
+// Secret leases are not tracked, callers read a secret whenever they need fresh credentials.
+type VaultSecretResolver struct {
+	client            *vaultapi.Client
+	allowedPathPrefix string
+	useAppRole        bool
+	roleID            string
+	secretID          string
+	loginMu           sync.Mutex
+	tokenExpiresAt    time.Time // Zero until the first AppRole login, and after a read fails
+}
+
+func NewVaultSecretResolver(config Config) (*VaultSecretResolver, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("vault address is required")
+	}
+	if config.Token == "" && (config.RoleID == "" || config.SecretID == "") {
+		return nil, fmt.Errorf("either a vault token or an AppRole role ID and secret ID are required")
+	}
+	allowedPathPrefix := strings.Trim(config.AllowedPathPrefix, "/")
+	if allowedPathPrefix == "" {
+		return nil, fmt.Errorf("vault allowed path prefix is required")
+	}
+
+	vaultConfig := vaultapi.DefaultConfig()
+	vaultConfig.Address = config.Address
+	vaultConfig.Timeout = constants.VaultRequestTimeout
+
+	client, err := vaultapi.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault client: %v", err)
+	}
+
+	resolver := &VaultSecretResolver{
+		client:            client,
+		allowedPathPrefix: allowedPathPrefix,
+		useAppRole:        config.Token == "",
+		roleID:            config.RoleID,
+		secretID:          config.SecretID,
+	}
+	if !resolver.useAppRole {
+		client.SetToken(config.Token)
+	}
+	return resolver, nil
+}
+
+// IsPathAllowed reports whether userID may read the secret at path, i.e. whether path lies under the allowed prefix.
+// Without this check any user could point a connection at another secret NeoBase's token can read.
+func (r *VaultSecretResolver) IsPathAllowed(userID, path string) bool {
+	prefix := strings.ReplaceAll(r.allowedPathPrefix, constants.VaultUserIDPlaceholder, userID)
+	path = strings.Trim(path, "/")
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// ensureToken logs in with AppRole when there is no token yet or the current one is about to expire.
+// The token is reused for every read until then. Callers hold loginMu.
+func (r *VaultSecretResolver) ensureToken(ctx context.Context) error {
+	if !r.tokenExpiresAt.IsZero() && time.Now().Before(r.tokenExpiresAt) {
+		return nil
+	}
+	return r.login(ctx)
+}
+
+// login exchanges the AppRole role ID and secret ID for a client token and records when it expires
+func (r *VaultSecretResolver) login(ctx context.Context) error {
+	secret, err := r.client.Logical().WriteWithContext(ctx, constants.VaultAppRoleLoginPath, map[string]interface{}{
+		"role_id":   r.roleID,
+		"secret_id": r.secretID,
+	})
+	if err != nil {
+		return fmt.Errorf("vault AppRole login failed: %v", err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return fmt.Errorf("vault AppRole login returned no token")
+	}
+	r.client.SetToken(secret.Auth.ClientToken)
+
+	ttl := time.Duration(secret.Auth.LeaseDuration) * time.Second
+	if ttl <= constants.VaultTokenRenewMargin {
+		// Tokens too short lived to be worth caching are used for a single read
+		r.tokenExpiresAt = time.Time{}
+	} else {
+		r.tokenExpiresAt = time.Now().Add(ttl - constants.VaultTokenRenewMargin)
+	}
+	return nil
+}
+
+// ReadSecret reads the secret at path and returns its string values.
+// KV version 2 paths (e.g. secret/data/neobase/orders-db) nest the values under "data", which is unwrapped.
+// Callers check the path with IsPathAllowed first.
+func (r *VaultSecretResolver) ReadSecret(path string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.VaultRequestTimeout)
+	defer cancel()
+
+	if r.useAppRole {
+		r.loginMu.Lock()
+		err := r.ensureToken(ctx)
+		r.loginMu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := r.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		if r.useAppRole {
+			// The token may have been revoked early, log in again on the next read
+			r.loginMu.Lock()
+			r.tokenExpiresAt = time.Time{}
+			r.loginMu.Unlock()
+		}
+		return nil, fmt.Errorf("failed to read vault secret %s: %v", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault secret %s not found", path)
+	}
+
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		if value == nil {
+			continue
+		}
+		values[key] = fmt.Sprintf("%v", value)
+	}
+
+	log.Printf("VaultSecretResolver -> ReadSecret -> Read %d values from %s", len(values), path)
+	return values, nil
+}
@@ -0,0 +1,46 @@
+package vault
+
+import "testing"
+
+func TestIsPathAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		userID string
+		path   string
+		want   bool
+	}{
+		{"secret under prefix", "secret/data/neobase", "u1", "secret/data/neobase/orders-db", true},
+		{"nested secret under prefix", "secret/data/neobase/", "u1", "/secret/data/neobase/team/orders-db", true},
+		{"prefix itself", "secret/data/neobase", "u1", "secret/data/neobase", true},
+		{"sibling sharing the prefix string", "secret/data/neobase", "u1", "secret/data/neobase-admin/root", false},
+		{"other mount", "secret/data/neobase", "u1", "secret/data/payments/db", false},
+		{"parent traversal", "secret/data/neobase", "u1", "secret/data/neobase/../payments/db", false},
+		{"empty segment", "secret/data/neobase", "u1", "secret/data/neobase//db", false},
+		{"own user subtree", "secret/data/neobase/{user_id}", "u1", "secret/data/neobase/u1/orders-db", true},
+		{"other user subtree", "secret/data/neobase/{user_id}", "u1", "secret/data/neobase/u2/orders-db", false},
+		{"empty path", "secret/data/neobase", "u1", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, err := NewVaultSecretResolver(Config{
+				Address:           "http://127.0.0.1:8200",
+				Token:             "test-token",
+				AllowedPathPrefix: tt.prefix,
+			})
+			if err != nil {
+				t.Fatalf("NewVaultSecretResolver() error = %v", err)
+			}
+			if got := resolver.IsPathAllowed(tt.userID, tt.path); got != tt.want {
+				t.Errorf("IsPathAllowed(%q, %q) = %v, want %v", tt.userID, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVaultSecretResolverRequiresAllowedPathPrefix(t *testing.T) {
+	if _, err := NewVaultSecretResolver(Config{Address: "http://127.0.0.1:8200", Token: "test-token", AllowedPathPrefix: "/"}); err == nil {
+		t.Fatal("NewVaultSecretResolver() error = nil, want an error for an empty allowed path prefix")
+	}
+}
@@ -0,0 +1,215 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"neobase-ai/internal/apis/dtos"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/utils"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/ory/dockertest/v3"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// question is asked of every database, each seeded with the same customers
+const question = "Which customers live in London?"
+
+var mongoFindPattern = regexp.MustCompile(`(?s)^db\.(\w+)\.find\((.*)\)$`)
+
+func TestE2EPostgreSQL(t *testing.T) {
+	requireSuite(t)
+	resource := startContainer(t, &dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "16-alpine",
+		Env:        []string{"POSTGRES_USER=neobase", "POSTGRES_PASSWORD=neobase", "POSTGRES_DB=shop"},
+	})
+	db := openSQL(t, "postgres", fmt.Sprintf("postgres://neobase:neobase@%s/shop?sslmode=disable", resource.GetHostPort("5432/tcp")))
+	seedSQL(t, db, "CREATE TABLE customers (id SERIAL PRIMARY KEY, name TEXT NOT NULL, city TEXT NOT NULL)")
+
+	query := askQuestion(t, "postgresql.json", dtos.CreateConnectionRequest{
+		Type:     constants.DatabaseTypePostgreSQL,
+		Host:     resource.GetBoundIP("5432/tcp"),
+		Port:     utils.ToStringPtr(resource.GetPort("5432/tcp")),
+		Username: "neobase",
+		Password: utils.ToStringPtr("neobase"),
+		Database: "shop",
+	})
+
+	if _, err := db.Exec("EXPLAIN " + strings.TrimSuffix(query, ";")); err != nil {
+		t.Errorf("EXPLAIN of the generated query %q failed: %v", query, err)
+	}
+}
+
+func TestE2EMySQL(t *testing.T) {
+	requireSuite(t)
+	resource := startContainer(t, &dockertest.RunOptions{
+		Repository: "mysql",
+		Tag:        "8.0",
+		Env:        []string{"MYSQL_ROOT_PASSWORD=neobase", "MYSQL_DATABASE=shop"},
+	})
+	db := openSQL(t, "mysql", fmt.Sprintf("root:neobase@tcp(%s)/shop", resource.GetHostPort("3306/tcp")))
+	seedSQL(t, db, "CREATE TABLE customers (id INT AUTO_INCREMENT PRIMARY KEY, name VARCHAR(100) NOT NULL, city VARCHAR(100) NOT NULL)")
+
+	query := askQuestion(t, "mysql.json", dtos.CreateConnectionRequest{
+		Type:     constants.DatabaseTypeMySQL,
+		Host:     resource.GetBoundIP("3306/tcp"),
+		Port:     utils.ToStringPtr(resource.GetPort("3306/tcp")),
+		Username: "root",
+		Password: utils.ToStringPtr("neobase"),
+		Database: "shop",
+	})
+
+	if _, err := db.Exec("EXPLAIN " + strings.TrimSuffix(query, ";")); err != nil {
+		t.Errorf("EXPLAIN of the generated query %q failed: %v", query, err)
+	}
+}
+
+func TestE2EMongoDB(t *testing.T) {
+	requireSuite(t)
+	resource := startContainer(t, &dockertest.RunOptions{
+		Repository: "mongo",
+		Tag:        "7",
+		Env:        []string{"MONGO_INITDB_ROOT_USERNAME=neobase", "MONGO_INITDB_ROOT_PASSWORD=neobase"},
+	})
+	uri := fmt.Sprintf("mongodb://neobase:neobase@%s/?authSource=admin", resource.GetHostPort("27017/tcp"))
+	if err := pool.Retry(func() error { return pingMongo(uri) }); err != nil {
+		t.Fatalf("mongodb did not start: %v", err)
+	}
+
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to mongodb: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	db := client.Database("shop")
+	_, err = db.Collection("customers").InsertMany(ctx, []interface{}{
+		bson.M{"name": "Ada", "city": "London"},
+		bson.M{"name": "Grace", "city": "New York"},
+		bson.M{"name": "Linus", "city": "Helsinki"},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed customers: %v", err)
+	}
+
+	query := askQuestion(t, "mongodb.json", dtos.CreateConnectionRequest{
+		Type:         constants.DatabaseTypeMongoDB,
+		Host:         resource.GetBoundIP("27017/tcp"),
+		Port:         utils.ToStringPtr(resource.GetPort("27017/tcp")),
+		Username:     "neobase",
+		Password:     utils.ToStringPtr("neobase"),
+		Database:     "shop",
+		AuthDatabase: utils.ToStringPtr("admin"),
+	})
+
+	// MongoDB has no EXPLAIN statement, the find is explained through the explain command instead
+	match := mongoFindPattern.FindStringSubmatch(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	if match == nil {
+		t.Fatalf("generated query %q is not a db.<collection>.find(<filter>) call", query)
+	}
+	filter := bson.D{}
+	if strings.TrimSpace(match[2]) != "" {
+		if err := bson.UnmarshalExtJSON([]byte(match[2]), false, &filter); err != nil {
+			t.Fatalf("filter of the generated query %q is not valid extended JSON: %v", query, err)
+		}
+	}
+	explain := bson.D{{Key: "explain", Value: bson.D{{Key: "find", Value: match[1]}, {Key: "filter", Value: filter}}}}
+	if err := db.RunCommand(ctx, explain).Err(); err != nil {
+		t.Errorf("explain of the generated query %q failed: %v", query, err)
+	}
+}
+
+func openSQL(t *testing.T, driverName, dsn string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", driverName, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := pool.Retry(db.Ping); err != nil {
+		t.Fatalf("%s did not start: %v", driverName, err)
+	}
+	return db
+}
+
+// seedSQL creates the customers table with createTable and fills it
+func seedSQL(t *testing.T, db *sql.DB, createTable string) {
+	t.Helper()
+	for _, statement := range []string{
+		createTable,
+		"INSERT INTO customers (name, city) VALUES ('Ada', 'London'), ('Grace', 'New York'), ('Linus', 'Helsinki')",
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to seed customers: %v", err)
+		}
+	}
+}
+
+// askQuestion signs up a new user, connects a chat to the database, asks the question with the LLM
+// replaying fixture and returns the first query of the AI response
+func askQuestion(t *testing.T, fixture string, connection dtos.CreateConnectionRequest) string {
+	t.Helper()
+	llm.useFixture(t, fixture)
+
+	username := fmt.Sprintf("e2e%d", time.Now().UnixNano())
+	var auth dtos.AuthResponse
+	call(t, "POST", "/api/auth/signup", "", dtos.SignupRequest{
+		Username: username,
+		Email:    username + "@neobase.test",
+		Password: "neobase-e2e",
+	}, &auth)
+
+	var chat dtos.ChatResponse
+	call(t, "POST", "/api/chats", auth.AccessToken, dtos.CreateChatRequest{Connection: connection}, &chat)
+
+	streamID := "e2e-" + username
+	call(t, "POST", "/api/chats/"+chat.ID+"/connect", auth.AccessToken, dtos.ConnectDBRequest{StreamID: streamID}, nil)
+	waitFor(t, "the database connection", func() bool {
+		var status dtos.ConnectionStatusResponse
+		call(t, "GET", "/api/chats/"+chat.ID+"/connection-status", auth.AccessToken, nil, &status)
+		return status.IsConnected
+	})
+
+	call(t, "POST", "/api/chats/"+chat.ID+"/messages", auth.AccessToken, dtos.CreateMessageRequest{
+		StreamID: streamID,
+		Content:  question,
+	}, nil)
+
+	var query string
+	waitFor(t, "the AI response", func() bool {
+		var messages dtos.MessageListResponse
+		call(t, "GET", "/api/chats/"+chat.ID+"/messages", auth.AccessToken, nil, &messages)
+		for _, message := range messages.Messages {
+			if message.Type == string(constants.MessageTypeAssistant) && message.Queries != nil && len(*message.Queries) > 0 {
+				query = (*message.Queries)[0].Query
+				return true
+			}
+		}
+		return false
+	})
+	return query
+}
+
+// waitFor polls done until it reports true, failing the test after answerTimeout
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(answerTimeout)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
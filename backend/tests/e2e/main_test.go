@@ -0,0 +1,316 @@
+//go:build e2e
+
+// Package e2e runs NeoBase end to end against real databases started in Docker:
+//
+//	go test -tags=e2e ./tests/e2e/...
+//
+// The app is booted in-process with its own MongoDB and Redis containers and is driven only through its HTTP API.
+// LLM calls go to a local server replaying the pre-recorded completions in testdata, so no API key is needed.
+// Without a reachable Docker daemon every test is skipped.
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"neobase-ai/config"
+	"neobase-ai/internal/apis/routes"
+	"neobase-ai/internal/constants"
+	"neobase-ai/internal/di"
+	"neobase-ai/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// containerExpirySeconds makes Docker remove a container left behind by a killed test run
+	containerExpirySeconds = 600
+	redisPassword          = "neobase_e2e"
+	// answerTimeout bounds the wait for the AI response to a message
+	answerTimeout = 2 * time.Minute
+)
+
+var (
+	pool *dockertest.Pool
+	// setupErr is why the suite could not start, every test is skipped with it
+	setupErr error
+	// apiURL is the base URL of the NeoBase instance under test
+	apiURL string
+	llm    = &fakeLLM{}
+)
+
+func TestMain(m *testing.M) {
+	var resources []*dockertest.Resource
+	var app *httptest.Server
+	setupErr = func() error {
+		var err error
+		if pool, err = dockertest.NewPool(""); err != nil {
+			return fmt.Errorf("failed to create docker pool: %v", err)
+		}
+		if err := pool.Client.Ping(); err != nil {
+			return fmt.Errorf("docker is not reachable: %v", err)
+		}
+		pool.MaxWait = 2 * time.Minute
+
+		mongoResource, err := runContainer(&dockertest.RunOptions{Repository: "mongo", Tag: "7"})
+		if err != nil {
+			return err
+		}
+		resources = append(resources, mongoResource)
+		mongoURI := "mongodb://" + mongoResource.GetHostPort("27017/tcp")
+		if err := pool.Retry(func() error { return pingMongo(mongoURI) }); err != nil {
+			return fmt.Errorf("mongodb did not start: %v", err)
+		}
+
+		redisResource, err := runContainer(&dockertest.RunOptions{
+			Repository: "redis",
+			Tag:        "7-alpine",
+			Cmd:        []string{"redis-server", "--requirepass", redisPassword},
+		})
+		if err != nil {
+			return err
+		}
+		resources = append(resources, redisResource)
+		redisAddr := redisResource.GetHostPort("6379/tcp")
+		if err := pool.Retry(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			client := redis.NewClient(&redis.Options{Addr: redisAddr, Password: redisPassword})
+			defer client.Close()
+			return client.Ping(ctx).Err()
+		}); err != nil {
+			return fmt.Errorf("redis did not start: %v", err)
+		}
+
+		llmServer := httptest.NewServer(llm)
+		defaultModel := constants.GetDefaultModelForProvider(constants.OpenAI)
+		if defaultModel == nil {
+			return fmt.Errorf("no enabled OpenAI model to replay the fixtures with")
+		}
+		env := map[string]string{
+			"IS_DOCKER":              "true",
+			"ENVIRONMENT":            "DEVELOPMENT",
+			"NEOBASE_MONGODB_URI":    mongoURI,
+			"NEOBASE_MONGODB_NAME":   "neobase_e2e",
+			"NEOBASE_REDIS_HOST":     redisResource.GetBoundIP("6379/tcp"),
+			"NEOBASE_REDIS_PORT":     redisResource.GetPort("6379/tcp"),
+			"NEOBASE_REDIS_PASSWORD": redisPassword,
+			"OPENAI_API_KEY":         "e2e-fixtures",
+			"DEFAULT_LLM_MODEL":      defaultModel.ID,
+			"LLM_PROXY_URL":          llmServer.URL,
+			"MAX_CHATS_PER_USER":     "10",
+		}
+		for key, value := range env {
+			os.Setenv(key, value)
+		}
+		if err := config.LoadEnv(); err != nil {
+			return fmt.Errorf("failed to load environment variables: %v", err)
+		}
+		di.Initialize()
+
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.Use(middleware.CustomRecoveryMiddleware())
+		engine.Use(middleware.CompressionMiddleware())
+		routes.SetupDefaultRoutes(engine)
+		app = httptest.NewServer(engine)
+		apiURL = app.URL
+		return nil
+	}()
+	if setupErr != nil {
+		log.Printf("e2e -> TestMain -> skipping the suite: %v", setupErr)
+	}
+
+	code := m.Run()
+
+	if app != nil {
+		app.Close()
+	}
+	for _, resource := range resources {
+		if err := pool.Purge(resource); err != nil {
+			log.Printf("e2e -> TestMain -> failed to remove container %s: %v", resource.Container.Name, err)
+		}
+	}
+	os.Exit(code)
+}
+
+// runContainer starts a container that Docker removes on its own once it stops or expires
+func runContainer(opts *dockertest.RunOptions) (*dockertest.Resource, error) {
+	resource, err := pool.RunWithOptions(opts, func(hostConfig *docker.HostConfig) {
+		hostConfig.AutoRemove = true
+		hostConfig.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s:%s: %v", opts.Repository, opts.Tag, err)
+	}
+	if err := resource.Expire(containerExpirySeconds); err != nil {
+		_ = pool.Purge(resource)
+		return nil, fmt.Errorf("failed to set the expiry of %s:%s: %v", opts.Repository, opts.Tag, err)
+	}
+	return resource, nil
+}
+
+// startContainer starts a database container for a single test and removes it when the test ends
+func startContainer(t *testing.T, opts *dockertest.RunOptions) *dockertest.Resource {
+	t.Helper()
+	resource, err := runContainer(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Logf("failed to remove container %s: %v", resource.Container.Name, err)
+		}
+	})
+	return resource
+}
+
+func pingMongo(uri string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+	return client.Ping(ctx, nil)
+}
+
+// fakeLLM stands in for the OpenAI API, answering every chat completion with the fixture of the running test
+type fakeLLM struct {
+	mu      sync.Mutex
+	fixture []byte
+}
+
+// useFixture replays testdata/<name> for the LLM calls of the test
+func (f *fakeLLM) useFixture(t *testing.T, name string) {
+	t.Helper()
+	fixture, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	f.mu.Lock()
+	f.fixture = fixture
+	f.mu.Unlock()
+	t.Cleanup(func() {
+		f.mu.Lock()
+		f.fixture = nil
+		f.mu.Unlock()
+	})
+}
+
+// ServeHTTP answers tool-calling requests with the recorded generate_final_response call. Requests without
+// tools, such as the JSON schema fallback or title generation, get the same arguments as the message content.
+func (f *fakeLLM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	fixture := f.fixture
+	f.mu.Unlock()
+	if fixture == nil || filepath.Base(r.URL.Path) != "completions" {
+		http.Error(w, `{"error":{"message":"no fixture for this request"}}`, http.StatusNotFound)
+		return
+	}
+
+	var request struct {
+		Tools []json.RawMessage `json:"tools"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, `{"error":{"message":"invalid request body"}}`, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(request.Tools) > 0 {
+		w.Write(fixture)
+		return
+	}
+
+	var completion map[string]interface{}
+	if err := json.Unmarshal(fixture, &completion); err != nil {
+		http.Error(w, `{"error":{"message":"invalid fixture"}}`, http.StatusInternalServerError)
+		return
+	}
+	for _, choice := range completion["choices"].([]interface{}) {
+		message := choice.(map[string]interface{})["message"].(map[string]interface{})
+		toolCalls, _ := message["tool_calls"].([]interface{})
+		if len(toolCalls) > 0 {
+			message["content"] = toolCalls[0].(map[string]interface{})["function"].(map[string]interface{})["arguments"]
+		}
+		delete(message, "tool_calls")
+		choice.(map[string]interface{})["finish_reason"] = "stop"
+	}
+	json.NewEncoder(w).Encode(completion)
+}
+
+// apiResponse is the envelope every NeoBase endpoint answers with
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *string         `json:"error"`
+}
+
+// call sends body to the NeoBase API and decodes the response data into out, failing the test on any error
+func call(t *testing.T, method, path, token string, body, out interface{}) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to encode %s %s request: %v", method, path, err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, apiURL+path, reader)
+	if err != nil {
+		t.Fatalf("failed to build %s %s request: %v", method, path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var response apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("%s %s returned an undecodable body (status %d): %v", method, path, resp.StatusCode, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest || !response.Success {
+		message := ""
+		if response.Error != nil {
+			message = *response.Error
+		}
+		t.Fatalf("%s %s returned %d: %s", method, path, resp.StatusCode, message)
+	}
+	if out != nil {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			t.Fatalf("failed to decode %s %s response data: %v", method, path, err)
+		}
+	}
+}
+
+func requireSuite(t *testing.T) {
+	t.Helper()
+	if setupErr != nil {
+		t.Skipf("e2e suite is not running: %v", setupErr)
+	}
+}
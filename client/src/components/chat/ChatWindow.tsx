@@ -324,6 +324,7 @@ export default function ChatWindow({
             if (userMsg) handleSendMessage(userMsg.content);
             else toast.error('Could not find original message to retry');
         } else if (action === 'open_settings') { setOpenWithSettingsTab(true); setShowEditConnection(true); }
+        else if (action === 'use_secondary_reads') { setShowEditConnection(true); }
         else { handleSendMessage(`${label}`); }
     }, [messages, handleSendMessage, handleFixErrorAction, handleFixRollbackErrorAction]);
 
@@ -450,6 +451,7 @@ export default function ChatWindow({
                                             onEditQuery={handleEditQuery}
                                             userId={userId || ''}
                                             userName={userName || ''}
+                                            queryTimeoutSeconds={chat.settings?.query_timeout_seconds}
                                             searchQuery={search.showSearch ? search.searchQuery : ''}
                                             searchResultRefs={search.searchResultRefs}
                                             buttonCallback={(action, label) => handleButtonCallback(message, action, label)}
@@ -47,6 +47,7 @@ interface MessageTileProps {
     buttonCallback?: (action: string, label?: string) => void;
     userId?: string;
     userName?: string;
+    queryTimeoutSeconds?: number;
     onPinMessage?: (messageId: string, isPinned: boolean) => Promise<void>;
 }
 
@@ -59,7 +60,7 @@ export default function MessageTile({
     checkSSEConnection, isFirstMessage,
     onQueryUpdate, onEditQuery,
     searchQuery, searchResultRefs,
-    buttonCallback, userId, userName, onPinMessage,
+    buttonCallback, userId, userName, queryTimeoutSeconds, onPinMessage,
 }: MessageTileProps) {
     const { streamId } = useStream();
 
@@ -294,6 +295,7 @@ export default function MessageTile({
                                                             searchResultRefs={searchResultRefs}
                                                             userId={userId}
                                                             userName={userName}
+                                                            queryTimeoutSeconds={queryTimeoutSeconds}
                                                             onSetIsEditingQuery={(qid, val) => ops.setEditingQueries(prev => ({ ...prev, [qid]: val }))}
                                                             onSetEditedQueryText={(qid, val) => ops.setEditedQueryTexts(prev => ({ ...prev, [qid]: val }))}
                                                             onEditQuery={onEditQuery}
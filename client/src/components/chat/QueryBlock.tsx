@@ -55,6 +55,7 @@ export interface QueryBlockProps {
     searchResultRefs?: React.MutableRefObject<{ [key: string]: HTMLElement | null }>;
     userId?: string;
     userName?: string;
+    queryTimeoutSeconds?: number;
     // Handlers
     onSetIsEditingQuery: (queryId: string, value: boolean) => void;
     onSetEditedQueryText: (queryId: string, value: string) => void;
@@ -82,7 +83,7 @@ export default function QueryBlock({
     expandedCells, setExpandedCells, expandedNodesRef,
     pageDataCacheRef, openDownloadMenu, setOpenDownloadMenu,
     searchQuery, searchResultRefs,
-    userId, userName,
+    userId, userName, queryTimeoutSeconds,
     onSetIsEditingQuery, onSetEditedQueryText, onEditQuery,
     onExecuteQuery, onRollback, onPageChange,
     onExportData, onExportVisualization,
@@ -248,6 +249,9 @@ export default function QueryBlock({
                         ) : (
                             <span className="text-xs bg-blue-500/20 text-blue-300 px-2 py-0.5 rounded">Execute Manually</span>
                         )}
+                        <span className="text-xs bg-gray-800 text-gray-400 px-2 py-0.5 rounded flex items-center gap-1" title="Query is cancelled if it runs longer than this">
+                            <Clock className="w-3 h-3" />Timeout {queryTimeoutSeconds ?? 30}s
+                        </span>
                     </div>
 
                     <div className="flex items-center">
@@ -474,7 +474,7 @@ export default function Sidebar({
                               onClick={() => handleSelectConnection(connection.id)}
                               className={`w-full h-full cursor-pointer ${isExpanded ? 'p-4' : 'p-3'} rounded-lg transition-all ${selectedConnection?.id === connection.id ? 'bg-[#FFDB58]' : 'bg-white hover:bg-gray-100'
                                 }`}
-                              title={connection.connection.database}
+                              title={connection.title ? `${connection.title} (${connection.connection.database})` : connection.connection.database}
                             >
                               <div className={`flex items-center h-full ${isExpanded ? 'gap-3' : 'justify-center'}`}>
                                 <DatabaseLogo
@@ -486,7 +486,11 @@ export default function Sidebar({
                                   }`}>
                                   <div className="text-left">
                                     <h3 className="font-bold text-lg leading-tight">
-                                      {connection.connection.is_example_db 
+                                      {connection.title
+                                        ? connection.title.length > 20
+                                          ? connection.title.slice(0, 20) + '...'
+                                          : connection.title
+                                        : connection.connection.is_example_db 
                                         ? 'Sample Database' 
                                         : connection.connection.database.length > 20 
                                           ? connection.connection.database.slice(0, 20) + '...' 
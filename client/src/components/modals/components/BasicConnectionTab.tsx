@@ -572,6 +572,36 @@ const BasicConnectionTab: React.FC<BasicConnectionTabProps> = ({
         </div>
       )}
 
+      {/* MongoDB Read Preference - Only show when MongoDB is selected */}
+      {formData.type === 'mongodb' && (
+        <div className="mb-6">
+          <label className="block font-bold mb-2 text-lg">Read Preference</label>
+          <p className="text-gray-600 text-sm mb-2">Replica set members that serve reads, writes always go to the primary</p>
+          <div className="relative">
+            <select
+              name="read_preference"
+              value={formData.read_preference || 'primary'}
+              onChange={handleChange}
+              className="neo-input w-full appearance-none pr-12"
+            >
+              <option value="primary">Primary - Always read the latest writes</option>
+              <option value="primaryPreferred">Primary Preferred - Secondaries only when the primary is down</option>
+              <option value="secondary">Secondary - Only read from secondaries</option>
+              <option value="secondaryPreferred">Secondary Preferred - Secondaries, primary as fallback</option>
+              <option value="nearest">Nearest - Lowest network latency</option>
+            </select>
+            <div className="absolute inset-y-0 right-0 flex items-center pr-4 pointer-events-none">
+              <ChevronDown className="w-5 h-5 text-gray-400" />
+            </div>
+          </div>
+          {formData.read_preference && formData.read_preference !== 'primary' && (
+            <p className="text-gray-500 text-xs mt-2">
+              Reads may lag behind writes, a query right after an insert or update might not see the change yet.
+            </p>
+          )}
+        </div>
+      )}
+
       <div className="mb-6">
         <label className="block font-bold mb-2 text-lg">Username</label>
         <p className="text-gray-600 text-sm mb-2">Database user with appropriate permissions</p>
@@ -97,7 +97,7 @@ const FileUploadTab: React.FC<FileUploadTabProps> = ({
     setUploadError(null);
     setIsProcessing(true);
 
-    const validExtensions = ['.csv', '.xlsx', '.xls'];
+    const validExtensions = ['.csv', '.xlsx', '.xls', '.json', '.jsonl', '.ndjson'];
     const maxFileSize = 100 * 1024 * 1024; // 100MB
 
     const newFiles: FileUpload[] = [];
@@ -106,7 +106,7 @@ const FileUploadTab: React.FC<FileUploadTabProps> = ({
       const extension = file.name.substring(file.name.lastIndexOf('.')).toLowerCase();
       
       if (!validExtensions.includes(extension)) {
-        setUploadError(`Invalid file type: ${file.name}. Only CSV and Excel files are allowed.`);
+        setUploadError(`Invalid file type: ${file.name}. Only CSV, Excel, JSON and JSON Lines files are allowed.`);
         continue;
       }
 
@@ -316,12 +316,12 @@ const FileUploadTab: React.FC<FileUploadTabProps> = ({
             ref={fileInputRef}
             type="file"
             multiple
-            accept=".csv,.xlsx,.xls"
+            accept=".csv,.xlsx,.xls,.json,.jsonl,.ndjson"
             onChange={handleFileInput}
             className="hidden"
           />
           <p className="text-xs text-gray-500 mt-4">
-            Supported formats: CSV, XLSX, XLS (Max 100MB per file)
+            Supported formats: CSV, XLSX, XLS, JSON, JSONL/NDJSON (Max 100MB per file)
           </p>
         </div>
 
@@ -139,6 +139,7 @@ export function useConnectionModal({
     password: '',
     database: initialData?.connection.database || (initialData?.connection.type === 'spreadsheet' ? 'spreadsheet_db' : ''),
     auth_database: initialData?.connection.auth_database || 'admin',
+    read_preference: initialData?.connection.read_preference,
     use_ssl: initialData?.connection.use_ssl || false,
     ssl_mode: initialData?.connection.ssl_mode || 'disable',
     ssl_cert_url: initialData?.connection.ssl_cert_url || '',
@@ -473,15 +473,21 @@ export function useQueryOperations({
             }
 
             const apiPage = Math.ceil(page / 2);
+            const base = Math.floor((page - 1) / 2) * 2 + 1;
+            // Keyset pagination: when the previous batch is cached, continue from its last key instead of an OFFSET
+            const keysetField = query.pagination?.keyset_field;
+            const prevBatchLast = keysetField ? pageDataCacheRef.current[queryId][base - 1]?.data?.slice(-1)[0] : undefined;
+            const lastKey = keysetField && prevBatchLast ? prevBatchLast[keysetField] : undefined;
             const response = await axios.post(
                 `${import.meta.env.VITE_API_URL}/chats/${chatId}/queries/results`,
-                { message_id: message.id, query_id: queryId, stream_id: streamId, offset: (apiPage - 1) * 50 },
+                lastKey !== undefined && lastKey !== null
+                    ? { message_id: message.id, query_id: queryId, stream_id: streamId, last_key: lastKey }
+                    : { message_id: message.id, query_id: queryId, stream_id: streamId, offset: (apiPage - 1) * 50 },
             );
             const responseData = response.data.data;
             const fullData = parseResults(responseData.execution_result);
             const totalRecords = responseData.total_records_count;
             const pd = sliceIntoPages(fullData, state.pageSize, page % 2);
-            const base = Math.floor((page - 1) / 2) * 2 + 1;
             pageDataCacheRef.current[queryId][base] = { data: sliceIntoPages(fullData, state.pageSize, 1), totalRecords };
             pageDataCacheRef.current[queryId][base + 1] = { data: sliceIntoPages(fullData, state.pageSize, 2), totalRecords };
 
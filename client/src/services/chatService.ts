@@ -1,5 +1,5 @@
-import { Chat, Connection, TablesResponse, ChatSettings } from '../types/chat';
-import { ExecuteQueryResponse, MessagesResponse, SendMessageResponse } from '../types/messages';
+import { Chat, Connection, TablesResponse, TablePreviewResponse, LiveCountResponse, ColumnStatistics, ChatSettings, SchemaAliases, GoogleSheetsSyncResponse, PlanetscaleBranchesResponse, ChatExport, ChatImportResponse, ChatTemplate, ChatFromTemplateResponse, DatabaseHealth, ExternalSchemaSource, ConnectionDiagnostic, Report } from '../types/chat';
+import { DataMigrationResponse, DeleteMessagesFilter, DependencyGraph, DeleteMessagesResponse, ExecuteQueryResponse, QueryWatch, CDCWatch, MessageFeedbackIssue, MessageFeedbackResponse, MessageReactionEmoji, MessageReactionResponse, MessagesResponse, MockDataResponse, RollbackHistoryResponse, SendMessageResponse } from '../types/messages';
 import axios from './axiosConfig';
 
 const API_URL = import.meta.env.VITE_API_URL;
@@ -113,6 +113,26 @@ const chatService = {
         }
     },
 
+    async checkDatabaseHealth(chatId: string): Promise<DatabaseHealth> {
+        try {
+            const response = await axios.get(`${API_URL}/chats/${chatId}/health`);
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Check database health error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to check database health');
+        }
+    },
+
+    async diagnoseConnection(connection: Connection): Promise<ConnectionDiagnostic> {
+        try {
+            const response = await axios.post(`${API_URL}/connections/diagnose`, connection);
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Diagnose connection error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to diagnose connection');
+        }
+    },
+
     async connectToConnection(chatId: string, streamId: string): Promise<void> {
         try {
             const response = await axios.post(`${API_URL}/chats/${chatId}/connect`, { stream_id: streamId });
@@ -158,6 +178,65 @@ const chatService = {
             throw new Error(error.response?.data?.error || 'Failed to get messages');
         }
     },
+    async getThreadMessages(chatId: string, threadId: string, page: number, perPage: number): Promise<MessagesResponse> {
+        try {
+            const response = await axios.get<MessagesResponse>(
+                `${import.meta.env.VITE_API_URL}/chats/${chatId}/messages?page=${page}&page_size=${perPage}&threadId=${threadId}`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+            return response.data;
+        } catch (error: any) {
+            console.error('Get thread messages error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to get thread messages');
+        }
+    },
+    async sendThreadMessage(chatId: string, parentMessageId: string, streamId: string, content: string, llmModel?: string): Promise<SendMessageResponse> {
+        try {
+            const response = await axios.post<SendMessageResponse>(
+                `${API_URL}/chats/${chatId}/messages/${parentMessageId}/thread`,
+                {
+                    stream_id: streamId,
+                    content: content,
+                    llm_model: llmModel
+                },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+            return response.data
+        } catch (error: any) {
+            console.error('Send thread message error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to send thread message');
+        }
+    },
+    async regenerateMessage(chatId: string, messageId: string, streamId: string): Promise<SendMessageResponse> {
+        try {
+            const response = await axios.post<SendMessageResponse>(
+                `${API_URL}/chats/${chatId}/messages/${messageId}/regenerate`,
+                { stream_id: streamId },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+            return response.data
+        } catch (error: any) {
+            console.error('Regenerate message error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to regenerate message');
+        }
+    },
     async sendMessage(chatId: string, messageId: string, streamId: string, content: string, llmModel?: string): Promise<SendMessageResponse> {
         try {
             const response = await axios.post<SendMessageResponse>(
@@ -200,14 +279,16 @@ const chatService = {
         }
     },
 
-    async executeQuery(chatId: string, messageId: string, queryId: string, streamId: string, controller: AbortController): Promise<ExecuteQueryResponse | undefined> {
+    async executeQuery(chatId: string, messageId: string, queryId: string, streamId: string, controller: AbortController, dryRun = false, confirmationText?: string): Promise<ExecuteQueryResponse | undefined> {
         try {
             const response = await axios.post<ExecuteQueryResponse>(
                 `${API_URL}/chats/${chatId}/queries/execute`,
                 {
                     message_id: messageId,
                     query_id: queryId,
-                    stream_id: streamId
+                    stream_id: streamId,
+                    dry_run: dryRun,
+                    confirmationText
                 },
                 {
                     signal: controller.signal,
@@ -229,6 +310,128 @@ const chatService = {
         }
     },
 
+    async startQueryWatch(chatId: string, messageId: string, queryId: string, streamId: string, intervalSeconds: number, query?: string): Promise<QueryWatch> {
+        try {
+            const response = await axios.post<{success: boolean, data: QueryWatch}>(
+                `${API_URL}/chats/${chatId}/watch`,
+                {
+                    query,
+                    intervalSeconds,
+                    messageId,
+                    queryId,
+                    streamId
+                },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Start query watch error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to watch query');
+        }
+    },
+
+    async getQueryDependencyGraph(chatId: string, messageId: string, queryId: string): Promise<DependencyGraph> {
+        try {
+            const response = await axios.post<{success: boolean, data: DependencyGraph}>(
+                `${API_URL}/chats/${chatId}/messages/${messageId}/queries/${queryId}/dependency-graph`,
+                {},
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Query dependency graph error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to build the query dependency graph');
+        }
+    },
+
+    async stopQueryWatch(chatId: string, watchId: string): Promise<void> {
+        try {
+            await axios.delete(
+                `${API_URL}/chats/${chatId}/watch/${watchId}`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+        } catch (error: any) {
+            console.error('Stop query watch error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to stop watching query');
+        }
+    },
+
+    async startCDCWatch(chatId: string, tables: string[], checkIntervalSeconds: number, webhookUrl: string, timestampColumn?: string): Promise<CDCWatch> {
+        try {
+            const response = await axios.post<{success: boolean, data: CDCWatch}>(
+                `${API_URL}/chats/${chatId}/cdc/watch`,
+                { tables, checkIntervalSeconds, webhookUrl, timestampColumn },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to start CDC watcher');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Start CDC watch error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to start CDC watcher');
+        }
+    },
+
+    async stopCDCWatch(chatId: string, watchId: string): Promise<void> {
+        try {
+            await axios.delete(
+                `${API_URL}/chats/${chatId}/cdc/watch/${watchId}`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+        } catch (error: any) {
+            console.error('Stop CDC watch error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to stop CDC watcher');
+        }
+    },
+
+    async deleteMessagesByFilter(chatId: string, filter: DeleteMessagesFilter): Promise<DeleteMessagesResponse> {
+        try {
+            const response = await axios.delete<{success: boolean, data: DeleteMessagesResponse}>(
+                `${API_URL}/chats/${chatId}/messages`,
+                {
+                    params: filter,
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Delete messages error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to delete messages');
+        }
+    },
+
     async rollbackQuery(chatId: string, messageId: string, queryId: string, streamId: string, controller: AbortController): Promise<ExecuteQueryResponse | undefined> {
         try {
             const response = await axios.post<ExecuteQueryResponse>(`${API_URL}/chats/${chatId}/queries/rollback`, {
@@ -276,6 +479,88 @@ const chatService = {
         }
     },
 
+    async uploadPrismaSchema(chatId: string, file: File): Promise<ExternalSchemaSource> {
+        try {
+            const body = new FormData();
+            body.append('file', file);
+            const response = await axios.post(`${API_URL}/chats/${chatId}/schema/upload-prisma`, body);
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Upload Prisma schema error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to upload Prisma schema');
+        }
+    },
+
+    // Accepts OpenAPI 3 and Swagger 2.0 specs in JSON or YAML
+    async uploadOpenAPISpec(chatId: string, file: File): Promise<ExternalSchemaSource> {
+        try {
+            const body = new FormData();
+            body.append('file', file);
+            const response = await axios.post(`${API_URL}/chats/${chatId}/schema/enrich-from-openapi`, body);
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Upload OpenAPI spec error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to upload OpenAPI spec');
+        }
+    },
+
+    // The report is generated in the background, its sections arrive as report_section events on the stream
+    async generateReport(chatId: string, streamId: string, title: string, sections: { heading: string; question: string }[]): Promise<Report> {
+        try {
+            const response = await axios.post(`${API_URL}/chats/${chatId}/generate-report`, {
+                title,
+                sections,
+                stream_id: streamId
+            });
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Generate report error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to generate report');
+        }
+    },
+
+    async getRollbackHistory(chatId: string, page = 1, pageSize = 20): Promise<RollbackHistoryResponse> {
+        try {
+            const response = await axios.get(`${API_URL}/chats/${chatId}/rollback-history`, {
+                params: { page, pageSize }
+            });
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Get rollback history error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to get rollback history');
+        }
+    },
+
+    async listReports(chatId: string): Promise<Report[]> {
+        try {
+            const response = await axios.get(`${API_URL}/chats/${chatId}/reports`);
+            return response.data.data;
+        } catch (error: any) {
+            console.error('List reports error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to list reports');
+        }
+    },
+
+    async getReport(chatId: string, reportId: string): Promise<Report> {
+        try {
+            const response = await axios.get(`${API_URL}/chats/${chatId}/reports/${reportId}`);
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Get report error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to get report');
+        }
+    },
+
+    async downloadReportPdf(chatId: string, reportId: string): Promise<Blob> {
+        try {
+            const response = await axios.get(`${API_URL}/chats/${chatId}/reports/${reportId}/pdf`, { responseType: 'blob' });
+            return response.data;
+        } catch (error: any) {
+            console.error('Download report PDF error:', error);
+            throw new Error('Failed to download report PDF');
+        }
+    },
+
     async editQuery(
         chatId: string,
         messageId: string,
@@ -377,6 +662,250 @@ const chatService = {
         }
     },
 
+    async generateChatTitle(chatId: string): Promise<Chat> {
+        try {
+            const response = await axios.post<{success: boolean, data: Chat}>(
+                `${API_URL}/chats/${chatId}/generate-title`,
+                {},
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to generate chat title');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Generate chat title error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to generate chat title');
+        }
+    },
+
+    async exportChat(chatId: string, includeCredentials: boolean = false): Promise<ChatExport> {
+        try {
+            const response = await axios.get<{success: boolean, data: ChatExport}>(
+                `${API_URL}/chats/${chatId}/export?includeCredentials=${includeCredentials}`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to export chat');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Export chat error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to export chat');
+        }
+    },
+
+    async importChat(chatExport: ChatExport): Promise<ChatImportResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: ChatImportResponse}>(
+                `${API_URL}/chats/import`,
+                chatExport,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to import chat');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Import chat error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to import chat');
+        }
+    },
+
+    async getChatTemplates(): Promise<ChatTemplate[]> {
+        try {
+            const response = await axios.get<{success: boolean, data: ChatTemplate[]}>(
+                `${API_URL}/chat-templates`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to fetch chat templates');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Get chat templates error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to fetch chat templates');
+        }
+    },
+
+    async createChatFromTemplate(templateId: string, connection?: Connection): Promise<ChatFromTemplateResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: ChatFromTemplateResponse}>(
+                `${API_URL}/chats/from-template/${templateId}`,
+                connection ? { connection } : {},
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to create chat from template');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Create chat from template error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to create chat from template');
+        }
+    },
+
+    async submitMessageFeedback(chatId: string, messageId: string, rating: number, issue: MessageFeedbackIssue, freeText?: string): Promise<MessageFeedbackResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: MessageFeedbackResponse}>(
+                `${API_URL}/chats/${chatId}/messages/${messageId}/feedback`,
+                { rating, issue, freeText },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to submit feedback');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Submit message feedback error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to submit feedback');
+        }
+    },
+
+    async addMessageReaction(chatId: string, messageId: string, emoji: MessageReactionEmoji): Promise<MessageReactionResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: MessageReactionResponse}>(
+                `${API_URL}/chats/${chatId}/messages/${messageId}/reactions`,
+                { emoji },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to add reaction');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Add message reaction error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to add reaction');
+        }
+    },
+
+    async removeMessageReaction(chatId: string, messageId: string, emoji: MessageReactionEmoji): Promise<MessageReactionResponse> {
+        try {
+            const response = await axios.delete<{success: boolean, data: MessageReactionResponse}>(
+                `${API_URL}/chats/${chatId}/messages/${messageId}/reactions/${encodeURIComponent(emoji)}`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to remove reaction');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Remove message reaction error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to remove reaction');
+        }
+    },
+
+    async generateMigration(chatId: string, description: string): Promise<DataMigrationResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: DataMigrationResponse}>(
+                `${API_URL}/chats/${chatId}/migration`,
+                { description },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to generate migration');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Generate migration error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to generate migration');
+        }
+    },
+
+    async generateMockData(chatId: string, tables: string[], rowsPerTable: number, force = false): Promise<MockDataResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: MockDataResponse}>(
+                `${API_URL}/chats/${chatId}/mock-data${force ? '?force=true' : ''}`,
+                { tables, rowsPerTable },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`,
+                        'Content-Type': 'application/json'
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to generate mock data');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Generate mock data error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to generate mock data');
+        }
+    },
+
     async getTables(chatId: string): Promise<TablesResponse> {
         try {
             const response = await axios.get<{success: boolean, data: TablesResponse}>(
@@ -401,6 +930,186 @@ const chatService = {
         }
     },
 
+    async getTablePreview(chatId: string, tableName: string, limit = 20): Promise<TablePreviewResponse> {
+        try {
+            const response = await axios.get<{success: boolean, data: TablePreviewResponse}>(
+                `${API_URL}/chats/${chatId}/tables/${encodeURIComponent(tableName)}/preview`,
+                {
+                    params: { limit },
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to preview table');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Preview table error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to preview table');
+        }
+    },
+
+    async liveCount(chatId: string, table: string, whereClause: string): Promise<LiveCountResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: LiveCountResponse}>(
+                `${API_URL}/chats/${chatId}/live-count`,
+                { table, whereClause },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to count rows');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Live count error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to count rows');
+        }
+    },
+
+    async updateSystemPrompt(chatId: string, systemPromptAppend: string): Promise<Chat> {
+        try {
+            const response = await axios.put<{success: boolean, data: Chat}>(
+                `${API_URL}/chats/${chatId}/settings/system-prompt`,
+                { system_prompt_append: systemPromptAppend },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to update system prompt rules');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Update system prompt error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to update system prompt rules');
+        }
+    },
+
+    async updateSchemaAliases(chatId: string, aliases: SchemaAliases): Promise<Chat> {
+        try {
+            const response = await axios.post<{success: boolean, data: Chat}>(
+                `${API_URL}/chats/${chatId}/schema/aliases`,
+                aliases,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to update schema aliases');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Update schema aliases error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to update schema aliases');
+        }
+    },
+
+    async getColumnStatistics(chatId: string, tableName: string, columnName: string): Promise<ColumnStatistics> {
+        try {
+            const response = await axios.get(
+                `${API_URL}/chats/${chatId}/tables/${encodeURIComponent(tableName)}/columns/${encodeURIComponent(columnName)}/stats`
+            );
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Get column statistics error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to get column statistics');
+        }
+    },
+
+    async syncGoogleSheet(chatId: string, streamId?: string): Promise<GoogleSheetsSyncResponse> {
+        try {
+            const response = await axios.post<{success: boolean, data: GoogleSheetsSyncResponse}>(
+                `${API_URL}/chats/${chatId}/google-sheets/sync`,
+                { stream_id: streamId },
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    },
+                    timeout: 300000
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to sync Google Sheet');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Sync Google Sheet error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to sync Google Sheet');
+        }
+    },
+
+    async getPlanetscaleBranches(chatId: string): Promise<PlanetscaleBranchesResponse> {
+        try {
+            const response = await axios.get<{success: boolean, data: PlanetscaleBranchesResponse}>(
+                `${API_URL}/chats/${chatId}/planetscale/branches`,
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to fetch PlanetScale branches');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Get PlanetScale branches error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to fetch PlanetScale branches');
+        }
+    },
+
+    async reResolveVaultCredentials(chatId: string): Promise<Chat> {
+        try {
+            const response = await axios.put<{success: boolean, data: Chat}>(
+                `${API_URL}/chats/${chatId}/connection/re-resolve-vault`,
+                {},
+                {
+                    withCredentials: true,
+                    headers: {
+                        'Authorization': `Bearer ${localStorage.getItem('token')}`
+                    }
+                }
+            );
+
+            if (!response.data.success) {
+                throw new Error('Failed to refresh credentials from Vault');
+            }
+
+            return response.data.data;
+        } catch (error: any) {
+            console.error('Re-resolve Vault credentials error:', error);
+            throw new Error(error.response?.data?.error || 'Failed to refresh credentials from Vault');
+        }
+    },
+
     async updateAutoExecuteQuery(chatId: string, autoExecuteQuery: boolean): Promise<Chat> {
         try {
             const response = await axios.patch<CreateChatResponse>(
@@ -430,7 +1139,10 @@ const chatService = {
 
     async getQueryRecommendations(chatId: string, streamId?: string): Promise<QueryRecommendationsResponse> {
         try {
-            const params = streamId ? { stream_id: streamId } : {};
+            const params = {
+                ...(streamId ? { stream_id: streamId } : {}),
+                timezone: Intl.DateTimeFormat().resolvedOptions().timeZone,
+            };
             const response = await axios.get<QueryRecommendationsResponse>(
                 `${API_URL}/chats/${chatId}/recommendations`,
                 {
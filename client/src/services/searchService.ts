@@ -0,0 +1,27 @@
+import axios from './axiosConfig';
+import { GlobalSearchResponse, GlobalSearchType } from '../types/search';
+
+const API_URL = import.meta.env.VITE_API_URL;
+
+interface SearchApiResponse<T> {
+  success: boolean;
+  data: T;
+  error?: string;
+}
+
+const searchService = {
+  /**
+   * Search the messages, or the queries, of all the user's chats.
+   */
+  async search(q: string, type: GlobalSearchType = 'message', page = 1, pageSize = 20): Promise<GlobalSearchResponse> {
+    const response = await axios.get<SearchApiResponse<GlobalSearchResponse>>(`${API_URL}/search`, {
+      params: { q, type, page, pageSize },
+    });
+    if (!response.data.success) {
+      throw new Error(response.data.error || 'Failed to search chats');
+    }
+    return response.data.data;
+  },
+};
+
+export default searchService;
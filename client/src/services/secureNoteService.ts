@@ -0,0 +1,36 @@
+import axios from './axiosConfig';
+import { CreateSecureNoteRequest, SecureNote } from '../types/secureNote';
+
+const API_URL = import.meta.env.VITE_API_URL;
+
+interface SecureNoteApiResponse<T> {
+  success: boolean;
+  data: T;
+  error?: string;
+}
+
+const secureNoteService = {
+  /**
+   * List the decrypted secure notes of a chat.
+   */
+  async listSecureNotes(chatId: string): Promise<SecureNote[]> {
+    const response = await axios.get<SecureNoteApiResponse<SecureNote[]>>(`${API_URL}/chats/${chatId}/secure-notes`);
+    if (!response.data.success) {
+      throw new Error(response.data.error || 'Failed to fetch secure notes');
+    }
+    return response.data.data;
+  },
+
+  /**
+   * Create a secure note. Active notes are added to every LLM call of the chat.
+   */
+  async createSecureNote(chatId: string, request: CreateSecureNoteRequest): Promise<SecureNote> {
+    const response = await axios.post<SecureNoteApiResponse<SecureNote>>(`${API_URL}/chats/${chatId}/secure-notes`, request);
+    if (!response.data.success) {
+      throw new Error(response.data.error || 'Failed to create secure note');
+    }
+    return response.data.data;
+  },
+};
+
+export default secureNoteService;
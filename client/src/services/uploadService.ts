@@ -1,4 +1,4 @@
-import { FileUpload } from '../types/chat';
+import { FileUpload, ImportStatus } from '../types/chat';
 
 const API_URL = import.meta.env.VITE_API_URL;
 
@@ -101,6 +101,31 @@ const uploadService = {
           errorData.error || `Failed to upload file: ${response.status} ${response.statusText}`
         );
       }
+
+      // Large files are imported in the background; wait for the job before uploading the next file
+      if (response.status === 202) {
+        await this.waitForImport(chatId);
+      }
+    }
+  },
+
+  async getImportStatus(chatId: string): Promise<ImportStatus> {
+    const response = await fetch(`${API_URL}/chats/${chatId}/import/status`, {
+      headers: { Authorization: `Bearer ${localStorage.getItem('token')}` },
+    });
+    const data = await response.json();
+    if (!response.ok) throw new Error(data.error || 'Failed to load import status');
+    return data.data;
+  },
+
+  async waitForImport(chatId: string, intervalMs = 2000): Promise<ImportStatus> {
+    for (;;) {
+      const status = await this.getImportStatus(chatId);
+      if (status.status === 'completed') return status;
+      if (status.status === 'failed') {
+        throw new Error(status.error || 'Import failed');
+      }
+      await new Promise(resolve => setTimeout(resolve, intervalMs));
     }
   },
 };
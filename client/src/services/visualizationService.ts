@@ -3,7 +3,8 @@ import {
   VisualizationResponse, 
   GenerateVisualizationRequest,
   ChartConfiguration,
-  ChartDataResponse 
+  ChartDataResponse,
+  VisualizationTemplate
 } from '../types/visualization';
 
 const API_URL = import.meta.env.VITE_API_URL;
@@ -66,6 +67,32 @@ export const visualizationService = {
     return response.data;
   },
 
+  /**
+   * List the chart templates, optionally only those usable with a database type
+   */
+  listVisualizationTemplates: async (dbType?: string): Promise<VisualizationTemplate[]> => {
+    const response = await axios.get(`${API_URL}/visualization-templates`, {
+      params: dbType ? { dbType } : undefined
+    });
+    return response.data.data;
+  },
+
+  /**
+   * Build a query's chart from a template, without calling the AI
+   * Template columns are matched to the query result's columns by kind
+   */
+  applyVisualizationTemplate: async (
+    chatId: string,
+    messageId: string,
+    queryId: string,
+    templateId: string
+  ): Promise<VisualizationResponse> => {
+    const response = await axios.post(
+      `${API_URL}/chats/${chatId}/messages/${messageId}/queries/${queryId}/apply-template/${templateId}`
+    );
+    return response.data.data;
+  },
+
   /**
    * Transform query results to match expected column mapping for chart rendering
    * Handles nested objects and special cases
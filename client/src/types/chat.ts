@@ -1,3 +1,5 @@
+import { BackendMessage } from "./messages";
+
 // Create a new file for chat types
 export type SSLMode = 'disable' | 'require' | 'verify-ca' | 'verify-full';
 
@@ -30,14 +32,31 @@ export interface FileUpload {
     };
 }
 
+export interface ImportProgress {
+    job_id?: string;
+    table_name: string;
+    processed: number;
+    total: number;
+    errors: string[];
+}
+
+export interface ImportStatus extends ImportProgress {
+    status: 'running' | 'completed' | 'failed';
+    error?: string;
+    started_at: string;
+    updated_at: string;
+    completed_at?: string;
+}
+
 export interface Connection {
-    type: 'postgresql' | 'yugabytedb' | 'mysql' | 'clickhouse' | 'mongodb' | 'redis' | 'neo4j' | 'spreadsheet' | 'google_sheets' | 'timescaledb' | 'starrocks';
+    type: 'postgresql' | 'yugabytedb' | 'mysql' | 'clickhouse' | 'mongodb' | 'redis' | 'neo4j' | 'spreadsheet' | 'google_sheets' | 'timescaledb' | 'starrocks' | 'trino' | 'oracle' | 'airtable' | 'planetscale' | 'ferretdb' | 'influxdb' | 'neon' | 'temporal' | 'pocketbase' | 'nats' | 'yugabytedb_ycql' | 'firestore' | 'cloudflare_d1';
     host: string;
     port: string;
     username: string;
     password?: string;
     database: string;
     auth_database?: string; // Database to authenticate against (for MongoDB)
+    connection_uri?: string; // Alternative to host/port/credentials, parsed by the backend and never stored
     is_example_db: boolean;
     use_ssl?: boolean;
     ssl_mode?: SSLMode;
@@ -62,11 +81,46 @@ export interface Connection {
     google_sheet_url?: string;
     google_auth_token?: string;
     google_refresh_token?: string;
+    last_synced_at?: string; // When the sheet data was last synced without re-importing
+    // Trino specific fields
+    catalog?: string; // Default catalog, e.g. hive
+    schema?: string; // Default schema within the catalog
+    // Oracle specific fields
+    service_name?: string; // Service name of the Easy Connect string, e.g. ORCLPDB1
+    // Airtable specific fields
+    airtable_api_key?: string; // Personal access token, write-only
+    airtable_base_id?: string; // Base ID, e.g. appXXXXXXXXXXXXXX
+    // PlanetScale specific fields
+    planetscale_branch?: string; // Branch the credentials belong to, defaults to main
+    planetscale_organization?: string; // Organization, used to list branches
+    planetscale_service_token_id?: string; // Service token ID, used to list branches
+    planetscale_service_token?: string; // Service token, write-only
+    // InfluxDB specific fields
+    influx_org?: string; // Organization, only needed for InfluxDB Cloud writes
+    influx_token?: string; // API token, write-only
+    // MongoDB specific fields
+    read_preference?: 'primary' | 'primaryPreferred' | 'secondary' | 'secondaryPreferred' | 'nearest'; // Replica set members reads are served from, writes always use the primary
+    temporal_namespace?: string; // Namespace workflows are listed from, defaults to 'default'
+    temporal_address?: string; // Frontend service host:port, e.g. my-ns.a1b2c.tmprl.cloud:7233
+    // NATS specific fields
+    nats_credentials_file?: string; // Path of a .creds file on the server, used instead of username/password or a token
+    // YugabyteDB specific fields
+    api_type?: 'ysql' | 'ycql'; // 'ycql' connects to the Cassandra-compatible YCQL API (port 9042) instead of YSQL
+    // Firestore specific fields
+    google_project_id?: string; // Google Cloud project ID, defaults to the project_id of the service account
+    firestore_credentials_json?: string; // Service account key JSON, write-only
+    // Cloudflare D1 specific fields
+    cloudflare_account_id?: string;
+    cloudflare_d1_database_id?: string; // Database UUID, shown by wrangler d1 list
+    cloudflare_api_token?: string; // API token with D1 read and edit permissions, write-only
+    // HashiCorp Vault secret with username and password keys, resolved by the server instead of the username and password fields
+    vault_secret_path?: string;
 }
 
 export interface Chat {
     id: string;
     user_id: string;
+    title?: string; // Generated by the AI after the first response, or set by the user
     connection: Connection;
     selected_collections?: string; // "ALL" or comma-separated table names
     preferred_llm_model?: string; // User's preferred LLM model for this chat
@@ -99,20 +153,82 @@ export interface TableInfo {
     name: string;
     columns: ColumnInfo[];
     is_selected: boolean;
+    is_hidden?: boolean; // Hidden from the AI by the chat's hidden tables setting, shown with a lock
+    // Only set for databases that report them, such as ClickHouse
+    engine?: string;
+    metadata?: Record<string, string>;
 }
 
 export interface TablesResponse {
     tables: TableInfo[];
 } 
 
+export interface TablePreviewResponse {
+    table_name: string;
+    columns: string[];
+    rows: Record<string, any>[];
+    limit: number;
+    execution_time_ms: number;
+}
+
+// Rows of a table matching a WHERE clause, counted while the user types it
+export interface LiveCountResponse {
+    count: number;
+    estimatedMs: number;
+}
+
+// Distribution of a column's values: min/max for numeric and timestamp columns, avg/stddev/percentiles for
+// numeric ones, topValues for text and monthlyCounts for timestamps
+export interface ColumnStatistics {
+    tableName: string;
+    columnName: string;
+    type: 'numeric' | 'text' | 'timestamp';
+    dataType: string;
+    totalCount: number;
+    nullCount: number;
+    distinctCount: number;
+    min?: any;
+    max?: any;
+    avg?: number;
+    stddev?: number;
+    percentiles?: Record<'p25' | 'p50' | 'p75' | 'p95', any>;
+    topValues?: { value: any; count: number }[];
+    monthlyCounts?: { month: string; count: number }[];
+    executionTimeMs: number;
+}
+
 export interface ChatSettings {
     auto_execute_query: boolean;
     share_data_with_ai: boolean;
     non_tech_mode: boolean;
     auto_generate_visualization: boolean; // Auto-generate chart visualizations for compatible queries (default: false)
+    query_timeout_seconds?: number; // Per-query execution timeout in seconds (default: 30)
+    encrypted_columns?: string[]; // Columns whose values are encrypted individually in stored query results
+    fallback_chain?: string[]; // Model IDs tried in order when the selected model is rate limited or unavailable
+    max_query_cost_units?: number; // PostgreSQL queries whose EXPLAIN total cost is higher are blocked, 0 removes the budget
+    disable_parallel_workers?: boolean; // Run PostgreSQL queries without parallel workers for predictable costs
+    hidden_tables?: string[]; // Tables left out of the schema the AI sees, updated with PUT /chats/:id/settings/hidden-tables
+    system_prompt_append?: string; // Rules appended to the system prompt of this chat, updated with PUT /chats/:id/settings/system-prompt
+    schema_aliases?: SchemaAliases; // Business names the AI sees instead of the real table and column names, updated with POST /chats/:id/schema/aliases
+    query_style?: QueryStyle; // Team code style generated and edited SQL queries are rewritten in, an empty style turns it off
     selected_llm_model?: string; // LLM model selected for this chat (e.g., "gpt-4o", "gemini-2.0-flash")
 }
 
+// Schema aliases by real table name and by real "table.column"
+export interface SchemaAliases {
+    tableAliases?: Record<string, string>;
+    columnAliases?: Record<string, string>;
+}
+
+// Style generated SQL is rewritten in, fields left out keep the query as the AI wrote it
+export interface QueryStyle {
+    joinStyle?: 'INNER JOIN' | 'JOIN';
+    indentation?: 2 | 4;
+    keywordCase?: 'upper' | 'lower';
+    notEqualsOperator?: '!=' | '<>';
+    tableAliasSuffix?: string; // e.g. "_t" aliases orders as orders_t
+}
+
 // LLM Model Types
 export interface LLMModel {
     id: string;
@@ -146,4 +262,160 @@ export interface ModelsByProviderResponse {
     };
     error?: string;
     message?: string;
-}
\ No newline at end of file
+}
+
+export interface GoogleSheetsSyncTableResult {
+    table_name: string;
+    previous_rows: number;
+    row_count: number;
+    sheet_row_count: number;
+    error?: string;
+}
+
+export interface GoogleSheetsSyncResponse {
+    tables: GoogleSheetsSyncTableResult[];
+    last_synced_at: string;
+}
+
+export interface PlanetscaleBranch {
+    name: string;
+    parent_branch?: string;
+    production: boolean;
+    ready: boolean;
+    created_at: string;
+    updated_at: string;
+}
+
+export interface PlanetscaleBranchesResponse {
+    current_branch: string;
+    branches: PlanetscaleBranch[];
+}
+
+// Portable chat format used to move a chat between NeoBase instances
+export interface ChatExport {
+    exportVersion: string;
+    exportedAt: string;
+    includesCredentials: boolean;
+    chat: {
+        id: string;
+        selectedCollections: string;
+        settings: ChatSettings;
+        preferredLLMModel?: string;
+        createdAt: string;
+        updatedAt: string;
+    };
+    connection: Record<string, any>;
+    secondaryConnections?: Record<string, any>[];
+    messages: Record<string, any>[];
+    llmMessages?: Record<string, any>[];
+    schemaSnapshot?: {
+        schema: string;
+        updatedAt?: string;
+    };
+}
+
+export interface ChatImportResponse {
+    chat: Chat;
+    messagesImported: number;
+    messagesSkipped: number;
+    warnings?: string[];
+}
+
+// Pre-configured chat with example messages that a new chat can be created from
+export interface ChatTemplate {
+    id: string;
+    name: string;
+    description: string;
+    database_type: string;
+    example_messages: string[];
+    schema_example: string;
+    is_example_db: boolean;
+}
+
+export interface ChatFromTemplateResponse {
+    chat: Chat;
+    messages: BackendMessage[];
+}
+
+// Result of running a test query on a chat's database and reading one of its tables
+export interface DatabaseHealth {
+    isHealthy: boolean;
+    latencyMs: number;
+    hasSelectPermission: boolean;
+    errorMessage?: string;
+    checkedAt: string;
+}
+
+// Why a connection test fails, checked in order: every check after failedCheck is false too
+export interface ConnectionDiagnostic {
+    tcpReachable: boolean;
+    tlsValid: boolean;
+    authSucceeded: boolean;
+    databaseExists: boolean;
+    userHasPermission: boolean;
+    failedCheck?: 'tcp' | 'tls' | 'auth' | 'database' | 'permission' | 'unknown';
+    error?: string;
+    suggestion: string;
+}
+
+// A table of an uploaded schema file, named as in the database
+export interface ExternalSchemaTable {
+    name: string;
+    model?: string;
+    description?: string;
+    columns: {
+        name: string;
+        type: string;
+        native_type?: string;
+        description?: string;
+        example?: string; // From OpenAPI specs
+        enum?: string[]; // From OpenAPI specs
+    }[];
+    relations?: {
+        name?: string;
+        field: string;
+        columns: string[];
+        ref_table: string;
+        ref_columns: string[];
+        on_delete?: string;
+        on_update?: string;
+        description?: string;
+    }[];
+}
+
+// An uploaded schema.prisma or OpenAPI spec whose relations and documentation are merged into the LLM schema
+export interface ExternalSchemaSource {
+    id: string;
+    chat_id: string;
+    source_type: 'prisma' | 'openapi';
+    file_name: string;
+    tables: ExternalSchemaTable[];
+    relation_count: number;
+    unmatched_tables?: string[];
+    updated_at: string;
+}
+
+// A section of a generated report, the question with the query that answered it
+export interface ReportSection {
+    heading: string;
+    question: string;
+    query?: string;
+    query_type?: string;
+    row_count: number;
+    error?: string;
+    markdown: string;
+}
+
+// A Markdown report generated from a list of questions, report is empty while generating and in lists
+export interface Report {
+    id: string;
+    chat_id: string;
+    title: string;
+    status: 'generating' | 'completed' | 'failed';
+    sections: ReportSection[];
+    report?: string;
+    error?: string;
+    model?: string;
+    created_at: string;
+    updated_at: string;
+}
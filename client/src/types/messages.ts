@@ -23,6 +23,14 @@ export interface BackendMessage {
     pinned_at?: string;
     llm_model?: string;
     llm_model_name?: string; // Human-readable display name for the LLM model
+    llm_model_used?: string; // Model that actually answered, differs from llm_model when a fallback model was used
+    parent_message_id?: string;
+    thread_id?: string;
+    feedback_count?: number;
+    avg_rating?: number;
+    reactions?: Record<string, number>; // Number of reactions per emoji
+    regeneration_count?: number; // Times the AI response to this user message was regenerated
+    rollback_count?: number; // Rollback attempts of this message's queries
     action_buttons?: ActionButton[];
     queries?: {
         id: string;
@@ -51,6 +59,8 @@ export interface BackendMessage {
         tables: string;
         rollback_query?: string;
         rollback_dependent_query?: string;
+        migrationRisk?: MigrationRisk; // Only set for DDL queries
+        requiresConfirmationText?: string; // Truncated table names, to be typed before a TRUNCATE runs
     }[];
     created_at: string;
 }
@@ -74,7 +84,10 @@ export const transformBackendMessage = (msg: BackendMessage): Message => {
         is_pinned: msg.is_pinned,
         pinned_at: msg.pinned_at,
         llm_model: msg.llm_model,
-        llm_model_name: msg.llm_model_name
+        llm_model_name: msg.llm_model_name,
+        llm_model_used: msg.llm_model_used,
+        parent_message_id: msg.parent_message_id,
+        thread_id: msg.thread_id
     };
 };
 
@@ -108,5 +121,123 @@ export interface ExecuteQueryResponse {
         };
         action_buttons?: ActionButton[];
         action_at?: string;
+        dry_run_result?: DryRunResult;
+        estimated_cost?: number; // PostgreSQL EXPLAIN total cost, set when the chat has a query cost budget
+        cost_budget_exceeded?: boolean;
     };
-}
\ No newline at end of file
+}
+
+// Number of rows a write query would change, returned instead of executing it when dry_run is set
+export interface DryRunResult {
+    affected_rows: number;
+    query_type: string;
+    count_query?: string;
+}
+
+// Tables a query touches and the joins between them, joinType is FOREIGN_KEY for a relationship
+// between two of its tables that the query does not join on
+export interface DependencyGraph {
+    nodes: { table: string; rowCount: number }[];
+    edges: { from: string; to: string; joinCondition: string; joinType: string }[];
+    adjacency: Record<string, string[]>;
+}
+
+// A query re-run on an interval, its results arrive as watch_result stream events
+export interface QueryWatch {
+    watch_id: string;
+    chat_id: string;
+    message_id: string;
+    query_id: string;
+    query: string;
+    interval_seconds: number;
+    started_at: string;
+    expires_at: string;
+}
+
+export interface QueryWatchDiff {
+    added: Record<string, any>[];
+    removed: Record<string, any>[];
+    row_count_delta: number;
+    changed: boolean;
+}
+
+export interface CDCWatch {
+    watch_id: string;
+    chat_id: string;
+    tables: string[];
+    timestamp_column: string;
+    check_interval_seconds: number;
+    webhook_url: string;
+    started_at: string;
+}
+
+export interface DeleteMessagesFilter {
+    before?: string; // RFC3339 or YYYY-MM-DD
+    type?: 'user' | 'assistant';
+    hasErrors?: boolean;
+}
+
+export interface DeleteMessagesResponse {
+    deletedCount: number;
+    matchedCount: number;
+    jobId?: string; // Set when more than 100 messages matched and the deletion runs in the background
+    status: 'completed' | 'running';
+}
+
+export interface DataMigrationResponse {
+    user_message: BackendMessage;
+    message: BackendMessage; // Holds the forward, rollback and verification scripts as DDL_MIGRATION queries
+    warnings?: string[];
+}
+
+export interface RollbackRecord {
+    id: string;
+    message_id: string;
+    query_id: string;
+    step: 'dependent_query' | 'rollback';
+    rollback_query: string;
+    success: boolean;
+    error?: string;
+    dependent_query_id?: string; // Record of the dependent query run before this rollback
+    executed_at: string;
+}
+
+export interface RollbackHistoryResponse {
+    records: RollbackRecord[];
+    total: number;
+    page: number;
+    page_size: number;
+}
+
+export interface MockDataResponse {
+    insertedRows: Record<string, number>;
+    userMessage: BackendMessage;
+    message: BackendMessage; // Holds one executed INSERT query per table, each rolling back its rows
+    warnings?: string[];
+}
+
+export type MessageFeedbackIssue = 'wrong_table' | 'wrong_query' | 'wrong_explanation' | 'too_slow' | 'correct';
+
+export interface MessageFeedbackResponse {
+    message_id: string;
+    rating: number;
+    issue: MessageFeedbackIssue;
+    freeText?: string;
+    feedback_count: number;
+    avg_rating: number;
+    is_low_rated: boolean;
+}
+
+export type MessageReactionEmoji = '👍' | '👎' | '🔥';
+
+export interface MessageReactionResponse {
+    message_id: string;
+    emoji: MessageReactionEmoji;
+    reactions: Record<string, number>;
+}
+
+export interface MigrationRisk {
+    riskLevel: 'low' | 'medium' | 'high';
+    reason: string;
+    estimatedLockDuration: string;
+}
@@ -7,6 +7,8 @@ export interface QueryResult {
         cursor_field?: string;        // Field used for cursor pagination (e.g., 'id', 'created_at')
         cursor_direction?: string;     // 'ASC' or 'DESC'
         page_size?: number;            // Records per page
+        keyset_query?: string;         // Keyset page query, used instead of OFFSET when last_key is sent
+        keyset_field?: string;         // Field whose last value is sent as last_key
     };
     description: string;
     execution_time?: number | null;
@@ -27,6 +29,7 @@ export interface QueryResult {
     is_streaming?: boolean;
     is_edited?: boolean;
     action_at?: string;
+    complexity_score?: 'low' | 'medium' | 'high' | 'extreme'; // Extreme queries always need manual confirmation
     visualization?: {
         id?: string;
         can_visualize: boolean;
@@ -66,6 +69,9 @@ export interface Message {
     pinned_at?: string;
     llm_model?: string; // LLM model ID used to generate this message (e.g., "gpt-4o", "gemini-2.0-flash")
     llm_model_name?: string; // Human-readable display name for the LLM model (e.g., "GPT-4 Omni", "Gemini 2.0 Flash")
+    llm_model_used?: string; // Model that actually answered, differs from llm_model when a fallback model was used
+    parent_message_id?: string; // AI message a threaded follow-up was asked about
+    thread_id?: string; // Root AI message of the thread, unset for main thread messages
 }
 
 export interface LoadingStep {
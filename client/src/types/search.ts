@@ -0,0 +1,18 @@
+export type GlobalSearchType = 'message' | 'query';
+
+// A message, or a query of a message, matching the search keyword
+export interface GlobalSearchResult {
+  chatID: string;
+  chatTitle: string;
+  messageID: string;
+  queryID?: string; // Only set for query searches
+  snippet: string;
+  createdAt: string;
+}
+
+export interface GlobalSearchResponse {
+  results: GlobalSearchResult[];
+  total: number;
+  page: number;
+  pageSize: number;
+}
@@ -0,0 +1,14 @@
+// Encrypted context about a connection, active notes are sent with every LLM call of the chat
+export interface SecureNote {
+  id: string;
+  chat_id: string;
+  content: string;
+  is_active: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateSecureNoteRequest {
+  content: string;
+  is_active?: boolean;
+}
@@ -3,6 +3,6 @@ export interface StreamResponse {
     'db-disconnected' | 'sse-connected' | 'response-cancelled' | 'query-results' |
     'rollback-executed' | 'query-execution-failed' | 'rollback-query-failed' | 'system-message' |
     'dashboard-blueprints' | 'dashboard-generation-progress' | 'dashboard-generation-complete' |
-    'dashboard-widget-data' | 'dashboard-widget-error';
+    'dashboard-widget-data' | 'dashboard-widget-error' | 'query_timeout' | 'import_progress';
     data?: any;
 } 
\ No newline at end of file
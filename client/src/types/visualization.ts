@@ -111,6 +111,15 @@ export interface VisualizationResponse {
   error?: string;
 }
 
+export interface VisualizationTemplate {
+  id: string;
+  name: string;
+  description: string;
+  db_type?: string;
+  required_columns: string[];
+  chart_config_json: string;
+}
+
 export interface GenerateVisualizationRequest {
   user_query: string;
   executed_query: string;